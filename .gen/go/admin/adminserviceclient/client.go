@@ -36,17 +36,101 @@ import (
 
 // Interface is a client for the AdminService service.
 type Interface interface {
+	DeleteWorkflowExecution(
+		ctx context.Context,
+		Request *admin.DeleteWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	DescribeFailoverDrill(
+		ctx context.Context,
+		Request *admin.DescribeFailoverDrillRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.DescribeFailoverDrillResponse, error)
+
 	DescribeHistoryHost(
 		ctx context.Context,
 		Request *shared.DescribeHistoryHostRequest,
 		opts ...yarpc.CallOption,
 	) (*shared.DescribeHistoryHostResponse, error)
 
+	DescribeReplicationDLQ(
+		ctx context.Context,
+		Request *admin.DescribeReplicationDLQRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.DescribeReplicationDLQResponse, error)
+
+	DescribeShardBacklogs(
+		ctx context.Context,
+		Request *shared.DescribeShardBacklogsRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeShardBacklogsResponse, error)
+
+	DescribeShardOperations(
+		ctx context.Context,
+		Request *shared.DescribeShardOperationsRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeShardOperationsResponse, error)
+
+	DescribeTaskListLatency(
+		ctx context.Context,
+		Request *shared.DescribeTaskListLatencyRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeTaskListLatencyResponse, error)
+
 	DescribeWorkflowExecution(
 		ctx context.Context,
 		Request *admin.DescribeWorkflowExecutionRequest,
 		opts ...yarpc.CallOption,
 	) (*admin.DescribeWorkflowExecutionResponse, error)
+
+	DiffWorkflowExecutionHistory(
+		ctx context.Context,
+		Request *admin.DiffWorkflowExecutionHistoryRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.DiffWorkflowExecutionHistoryResponse, error)
+
+	FixWorkflowExecutions(
+		ctx context.Context,
+		Request *admin.FixWorkflowExecutionsRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.FixWorkflowExecutionsResponse, error)
+
+	ForceUnloadTaskList(
+		ctx context.Context,
+		Request *shared.ForceUnloadTaskListRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.ForceUnloadTaskListResponse, error)
+
+	GetWorkflowExecutionHistoryBatches(
+		ctx context.Context,
+		Request *admin.GetWorkflowExecutionHistoryBatchesRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.GetWorkflowExecutionHistoryBatchesResponse, error)
+
+	ListPostCloseEvents(
+		ctx context.Context,
+		Request *admin.ListPostCloseEventsRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.ListPostCloseEventsResponse, error)
+
+	ListTaskListDLQTasks(
+		ctx context.Context,
+		Request *shared.ListTaskListDLQTasksRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.ListTaskListDLQTasksResponse, error)
+
+	RefreshDomainCache(
+		ctx context.Context,
+		Request *shared.RefreshDomainCacheRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	RequeueTaskListDLQTasks(
+		ctx context.Context,
+		Request *shared.RequeueTaskListDLQTasksRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.RequeueTaskListDLQTasksResponse, error)
 }
 
 // New builds a new client for the AdminService service.
@@ -73,6 +157,52 @@ type client struct {
 	c thrift.Client
 }
 
+func (c client) DeleteWorkflowExecution(
+	ctx context.Context,
+	_Request *admin.DeleteWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_DeleteWorkflowExecution_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DeleteWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_DeleteWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) DescribeFailoverDrill(
+	ctx context.Context,
+	_Request *admin.DescribeFailoverDrillRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.DescribeFailoverDrillResponse, err error) {
+
+	args := admin.AdminService_DescribeFailoverDrill_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DescribeFailoverDrill_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_DescribeFailoverDrill_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeHistoryHost(
 	ctx context.Context,
 	_Request *shared.DescribeHistoryHostRequest,
@@ -96,6 +226,98 @@ func (c client) DescribeHistoryHost(
 	return
 }
 
+func (c client) DescribeReplicationDLQ(
+	ctx context.Context,
+	_Request *admin.DescribeReplicationDLQRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.DescribeReplicationDLQResponse, err error) {
+
+	args := admin.AdminService_DescribeReplicationDLQ_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DescribeReplicationDLQ_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_DescribeReplicationDLQ_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) DescribeShardBacklogs(
+	ctx context.Context,
+	_Request *shared.DescribeShardBacklogsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeShardBacklogsResponse, err error) {
+
+	args := admin.AdminService_DescribeShardBacklogs_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DescribeShardBacklogs_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_DescribeShardBacklogs_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) DescribeShardOperations(
+	ctx context.Context,
+	_Request *shared.DescribeShardOperationsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeShardOperationsResponse, err error) {
+
+	args := admin.AdminService_DescribeShardOperations_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DescribeShardOperations_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_DescribeShardOperations_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) DescribeTaskListLatency(
+	ctx context.Context,
+	_Request *shared.DescribeTaskListLatencyRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeTaskListLatencyResponse, err error) {
+
+	args := admin.AdminService_DescribeTaskListLatency_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DescribeTaskListLatency_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_DescribeTaskListLatency_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeWorkflowExecution(
 	ctx context.Context,
 	_Request *admin.DescribeWorkflowExecutionRequest,
@@ -118,3 +340,187 @@ func (c client) DescribeWorkflowExecution(
 	success, err = admin.AdminService_DescribeWorkflowExecution_Helper.UnwrapResponse(&result)
 	return
 }
+
+func (c client) DiffWorkflowExecutionHistory(
+	ctx context.Context,
+	_Request *admin.DiffWorkflowExecutionHistoryRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.DiffWorkflowExecutionHistoryResponse, err error) {
+
+	args := admin.AdminService_DiffWorkflowExecutionHistory_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DiffWorkflowExecutionHistory_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_DiffWorkflowExecutionHistory_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) FixWorkflowExecutions(
+	ctx context.Context,
+	_Request *admin.FixWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.FixWorkflowExecutionsResponse, err error) {
+
+	args := admin.AdminService_FixWorkflowExecutions_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_FixWorkflowExecutions_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_FixWorkflowExecutions_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) ForceUnloadTaskList(
+	ctx context.Context,
+	_Request *shared.ForceUnloadTaskListRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.ForceUnloadTaskListResponse, err error) {
+
+	args := admin.AdminService_ForceUnloadTaskList_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_ForceUnloadTaskList_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_ForceUnloadTaskList_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) GetWorkflowExecutionHistoryBatches(
+	ctx context.Context,
+	_Request *admin.GetWorkflowExecutionHistoryBatchesRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.GetWorkflowExecutionHistoryBatchesResponse, err error) {
+
+	args := admin.AdminService_GetWorkflowExecutionHistoryBatches_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_GetWorkflowExecutionHistoryBatches_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_GetWorkflowExecutionHistoryBatches_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) ListPostCloseEvents(
+	ctx context.Context,
+	_Request *admin.ListPostCloseEventsRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ListPostCloseEventsResponse, err error) {
+
+	args := admin.AdminService_ListPostCloseEvents_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_ListPostCloseEvents_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_ListPostCloseEvents_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) ListTaskListDLQTasks(
+	ctx context.Context,
+	_Request *shared.ListTaskListDLQTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.ListTaskListDLQTasksResponse, err error) {
+
+	args := admin.AdminService_ListTaskListDLQTasks_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_ListTaskListDLQTasks_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_ListTaskListDLQTasks_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) RefreshDomainCache(
+	ctx context.Context,
+	_Request *shared.RefreshDomainCacheRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_RefreshDomainCache_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_RefreshDomainCache_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_RefreshDomainCache_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) RequeueTaskListDLQTasks(
+	ctx context.Context,
+	_Request *shared.RequeueTaskListDLQTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.RequeueTaskListDLQTasksResponse, err error) {
+
+	args := admin.AdminService_RequeueTaskListDLQTasks_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_RequeueTaskListDLQTasks_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_RequeueTaskListDLQTasks_Helper.UnwrapResponse(&result)
+	return
+}
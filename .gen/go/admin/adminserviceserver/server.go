@@ -34,15 +34,85 @@ import (
 
 // Interface is the server-side interface for the AdminService service.
 type Interface interface {
+	DeleteWorkflowExecution(
+		ctx context.Context,
+		Request *admin.DeleteWorkflowExecutionRequest,
+	) error
+
+	DescribeFailoverDrill(
+		ctx context.Context,
+		Request *admin.DescribeFailoverDrillRequest,
+	) (*admin.DescribeFailoverDrillResponse, error)
+
 	DescribeHistoryHost(
 		ctx context.Context,
 		Request *shared.DescribeHistoryHostRequest,
 	) (*shared.DescribeHistoryHostResponse, error)
 
+	DescribeReplicationDLQ(
+		ctx context.Context,
+		Request *admin.DescribeReplicationDLQRequest,
+	) (*admin.DescribeReplicationDLQResponse, error)
+
+	DescribeShardBacklogs(
+		ctx context.Context,
+		Request *shared.DescribeShardBacklogsRequest,
+	) (*shared.DescribeShardBacklogsResponse, error)
+
+	DescribeShardOperations(
+		ctx context.Context,
+		Request *shared.DescribeShardOperationsRequest,
+	) (*shared.DescribeShardOperationsResponse, error)
+
+	DescribeTaskListLatency(
+		ctx context.Context,
+		Request *shared.DescribeTaskListLatencyRequest,
+	) (*shared.DescribeTaskListLatencyResponse, error)
+
 	DescribeWorkflowExecution(
 		ctx context.Context,
 		Request *admin.DescribeWorkflowExecutionRequest,
 	) (*admin.DescribeWorkflowExecutionResponse, error)
+
+	DiffWorkflowExecutionHistory(
+		ctx context.Context,
+		Request *admin.DiffWorkflowExecutionHistoryRequest,
+	) (*admin.DiffWorkflowExecutionHistoryResponse, error)
+
+	FixWorkflowExecutions(
+		ctx context.Context,
+		Request *admin.FixWorkflowExecutionsRequest,
+	) (*admin.FixWorkflowExecutionsResponse, error)
+
+	ForceUnloadTaskList(
+		ctx context.Context,
+		Request *shared.ForceUnloadTaskListRequest,
+	) (*shared.ForceUnloadTaskListResponse, error)
+
+	GetWorkflowExecutionHistoryBatches(
+		ctx context.Context,
+		Request *admin.GetWorkflowExecutionHistoryBatchesRequest,
+	) (*admin.GetWorkflowExecutionHistoryBatchesResponse, error)
+
+	ListPostCloseEvents(
+		ctx context.Context,
+		Request *admin.ListPostCloseEventsRequest,
+	) (*admin.ListPostCloseEventsResponse, error)
+
+	ListTaskListDLQTasks(
+		ctx context.Context,
+		Request *shared.ListTaskListDLQTasksRequest,
+	) (*shared.ListTaskListDLQTasksResponse, error)
+
+	RefreshDomainCache(
+		ctx context.Context,
+		Request *shared.RefreshDomainCacheRequest,
+	) error
+
+	RequeueTaskListDLQTasks(
+		ctx context.Context,
+		Request *shared.RequeueTaskListDLQTasksRequest,
+	) (*shared.RequeueTaskListDLQTasksResponse, error)
 }
 
 // New prepares an implementation of the AdminService service for
@@ -56,6 +126,28 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 		Name: "AdminService",
 		Methods: []thrift.Method{
 
+			thrift.Method{
+				Name: "DeleteWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DeleteWorkflowExecution),
+				},
+				Signature:    "DeleteWorkflowExecution(Request *admin.DeleteWorkflowExecutionRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "DescribeFailoverDrill",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DescribeFailoverDrill),
+				},
+				Signature:    "DescribeFailoverDrill(Request *admin.DescribeFailoverDrillRequest) (*admin.DescribeFailoverDrillResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "DescribeHistoryHost",
 				HandlerSpec: thrift.HandlerSpec{
@@ -67,6 +159,50 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: admin.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "DescribeReplicationDLQ",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DescribeReplicationDLQ),
+				},
+				Signature:    "DescribeReplicationDLQ(Request *admin.DescribeReplicationDLQRequest) (*admin.DescribeReplicationDLQResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "DescribeShardBacklogs",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DescribeShardBacklogs),
+				},
+				Signature:    "DescribeShardBacklogs(Request *shared.DescribeShardBacklogsRequest) (*shared.DescribeShardBacklogsResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "DescribeShardOperations",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DescribeShardOperations),
+				},
+				Signature:    "DescribeShardOperations(Request *shared.DescribeShardOperationsRequest) (*shared.DescribeShardOperationsResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "DescribeTaskListLatency",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DescribeTaskListLatency),
+				},
+				Signature:    "DescribeTaskListLatency(Request *shared.DescribeTaskListLatencyRequest) (*shared.DescribeTaskListLatencyResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "DescribeWorkflowExecution",
 				HandlerSpec: thrift.HandlerSpec{
@@ -77,16 +213,142 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				Signature:    "DescribeWorkflowExecution(Request *admin.DescribeWorkflowExecutionRequest) (*admin.DescribeWorkflowExecutionResponse)",
 				ThriftModule: admin.ThriftModule,
 			},
+
+			thrift.Method{
+				Name: "DiffWorkflowExecutionHistory",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DiffWorkflowExecutionHistory),
+				},
+				Signature:    "DiffWorkflowExecutionHistory(Request *admin.DiffWorkflowExecutionHistoryRequest) (*admin.DiffWorkflowExecutionHistoryResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "FixWorkflowExecutions",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.FixWorkflowExecutions),
+				},
+				Signature:    "FixWorkflowExecutions(Request *admin.FixWorkflowExecutionsRequest) (*admin.FixWorkflowExecutionsResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "ForceUnloadTaskList",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ForceUnloadTaskList),
+				},
+				Signature:    "ForceUnloadTaskList(Request *shared.ForceUnloadTaskListRequest) (*shared.ForceUnloadTaskListResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "GetWorkflowExecutionHistoryBatches",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.GetWorkflowExecutionHistoryBatches),
+				},
+				Signature:    "GetWorkflowExecutionHistoryBatches(Request *admin.GetWorkflowExecutionHistoryBatchesRequest) (*admin.GetWorkflowExecutionHistoryBatchesResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "ListPostCloseEvents",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ListPostCloseEvents),
+				},
+				Signature:    "ListPostCloseEvents(Request *admin.ListPostCloseEventsRequest) (*admin.ListPostCloseEventsResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "ListTaskListDLQTasks",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ListTaskListDLQTasks),
+				},
+				Signature:    "ListTaskListDLQTasks(Request *shared.ListTaskListDLQTasksRequest) (*shared.ListTaskListDLQTasksResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "RefreshDomainCache",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.RefreshDomainCache),
+				},
+				Signature:    "RefreshDomainCache(Request *shared.RefreshDomainCacheRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "RequeueTaskListDLQTasks",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.RequeueTaskListDLQTasks),
+				},
+				Signature:    "RequeueTaskListDLQTasks(Request *shared.RequeueTaskListDLQTasksRequest) (*shared.RequeueTaskListDLQTasksResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
 		},
 	}
 
-	procedures := make([]transport.Procedure, 0, 2)
+	procedures := make([]transport.Procedure, 0, 16)
 	procedures = append(procedures, thrift.BuildProcedures(service, opts...)...)
 	return procedures
 }
 
 type handler struct{ impl Interface }
 
+func (h handler) DeleteWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DeleteWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.DeleteWorkflowExecution(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DeleteWorkflowExecution_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) DescribeFailoverDrill(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DescribeFailoverDrill_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeFailoverDrill(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DescribeFailoverDrill_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) DescribeHistoryHost(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args admin.AdminService_DescribeHistoryHost_Args
 	if err := args.FromWire(body); err != nil {
@@ -106,6 +368,82 @@ func (h handler) DescribeHistoryHost(ctx context.Context, body wire.Value) (thri
 	return response, err
 }
 
+func (h handler) DescribeReplicationDLQ(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DescribeReplicationDLQ_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeReplicationDLQ(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DescribeReplicationDLQ_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) DescribeShardBacklogs(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DescribeShardBacklogs_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeShardBacklogs(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DescribeShardBacklogs_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) DescribeShardOperations(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DescribeShardOperations_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeShardOperations(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DescribeShardOperations_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) DescribeTaskListLatency(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DescribeTaskListLatency_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeTaskListLatency(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DescribeTaskListLatency_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) DescribeWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args admin.AdminService_DescribeWorkflowExecution_Args
 	if err := args.FromWire(body); err != nil {
@@ -124,3 +462,155 @@ func (h handler) DescribeWorkflowExecution(ctx context.Context, body wire.Value)
 	}
 	return response, err
 }
+
+func (h handler) DiffWorkflowExecutionHistory(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DiffWorkflowExecutionHistory_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DiffWorkflowExecutionHistory(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DiffWorkflowExecutionHistory_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) FixWorkflowExecutions(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_FixWorkflowExecutions_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.FixWorkflowExecutions(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_FixWorkflowExecutions_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) ForceUnloadTaskList(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_ForceUnloadTaskList_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.ForceUnloadTaskList(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_ForceUnloadTaskList_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) GetWorkflowExecutionHistoryBatches(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_GetWorkflowExecutionHistoryBatches_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.GetWorkflowExecutionHistoryBatches(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_GetWorkflowExecutionHistoryBatches_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) ListPostCloseEvents(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_ListPostCloseEvents_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.ListPostCloseEvents(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_ListPostCloseEvents_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) ListTaskListDLQTasks(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_ListTaskListDLQTasks_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.ListTaskListDLQTasks(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_ListTaskListDLQTasks_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) RefreshDomainCache(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_RefreshDomainCache_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.RefreshDomainCache(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_RefreshDomainCache_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) RequeueTaskListDLQTasks(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_RequeueTaskListDLQTasks_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.RequeueTaskListDLQTasks(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_RequeueTaskListDLQTasks_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
@@ -63,6 +63,70 @@ func (m *MockClient) EXPECT() *_MockClientRecorder {
 	return m.recorder
 }
 
+// DeleteWorkflowExecution responds to a DeleteWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DeleteWorkflowExecution(gomock.Any(), ...).Return(...)
+// 	... := client.DeleteWorkflowExecution(...)
+func (m *MockClient) DeleteWorkflowExecution(
+	ctx context.Context,
+	_Request *admin.DeleteWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DeleteWorkflowExecution", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DeleteWorkflowExecution(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DeleteWorkflowExecution", args...)
+}
+
+// DescribeFailoverDrill responds to a DescribeFailoverDrill call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DescribeFailoverDrill(gomock.Any(), ...).Return(...)
+// 	... := client.DescribeFailoverDrill(...)
+func (m *MockClient) DescribeFailoverDrill(
+	ctx context.Context,
+	_Request *admin.DescribeFailoverDrillRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.DescribeFailoverDrillResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeFailoverDrill", args...)
+	success, _ = ret[i].(*admin.DescribeFailoverDrillResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeFailoverDrill(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeFailoverDrill", args...)
+}
+
 // DescribeHistoryHost responds to a DescribeHistoryHost call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -96,6 +160,138 @@ func (mr *_MockClientRecorder) DescribeHistoryHost(
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeHistoryHost", args...)
 }
 
+// DescribeReplicationDLQ responds to a DescribeReplicationDLQ call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DescribeReplicationDLQ(gomock.Any(), ...).Return(...)
+// 	... := client.DescribeReplicationDLQ(...)
+func (m *MockClient) DescribeReplicationDLQ(
+	ctx context.Context,
+	_Request *admin.DescribeReplicationDLQRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.DescribeReplicationDLQResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeReplicationDLQ", args...)
+	success, _ = ret[i].(*admin.DescribeReplicationDLQResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeReplicationDLQ(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeReplicationDLQ", args...)
+}
+
+// DescribeShardBacklogs responds to a DescribeShardBacklogs call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DescribeShardBacklogs(gomock.Any(), ...).Return(...)
+// 	... := client.DescribeShardBacklogs(...)
+func (m *MockClient) DescribeShardBacklogs(
+	ctx context.Context,
+	_Request *shared.DescribeShardBacklogsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeShardBacklogsResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeShardBacklogs", args...)
+	success, _ = ret[i].(*shared.DescribeShardBacklogsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeShardBacklogs(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeShardBacklogs", args...)
+}
+
+// DescribeShardOperations responds to a DescribeShardOperations call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DescribeShardOperations(gomock.Any(), ...).Return(...)
+// 	... := client.DescribeShardOperations(...)
+func (m *MockClient) DescribeShardOperations(
+	ctx context.Context,
+	_Request *shared.DescribeShardOperationsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeShardOperationsResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeShardOperations", args...)
+	success, _ = ret[i].(*shared.DescribeShardOperationsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeShardOperations(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeShardOperations", args...)
+}
+
+// DescribeTaskListLatency responds to a DescribeTaskListLatency call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DescribeTaskListLatency(gomock.Any(), ...).Return(...)
+// 	... := client.DescribeTaskListLatency(...)
+func (m *MockClient) DescribeTaskListLatency(
+	ctx context.Context,
+	_Request *shared.DescribeTaskListLatencyRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeTaskListLatencyResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeTaskListLatency", args...)
+	success, _ = ret[i].(*shared.DescribeTaskListLatencyResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeTaskListLatency(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeTaskListLatency", args...)
+}
+
 // DescribeWorkflowExecution responds to a DescribeWorkflowExecution call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -128,3 +324,265 @@ func (mr *_MockClientRecorder) DescribeWorkflowExecution(
 	args := append([]interface{}{ctx, _Request}, opts...)
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeWorkflowExecution", args...)
 }
+
+// DiffWorkflowExecutionHistory responds to a DiffWorkflowExecutionHistory call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DiffWorkflowExecutionHistory(gomock.Any(), ...).Return(...)
+// 	... := client.DiffWorkflowExecutionHistory(...)
+func (m *MockClient) DiffWorkflowExecutionHistory(
+	ctx context.Context,
+	_Request *admin.DiffWorkflowExecutionHistoryRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.DiffWorkflowExecutionHistoryResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DiffWorkflowExecutionHistory", args...)
+	success, _ = ret[i].(*admin.DiffWorkflowExecutionHistoryResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DiffWorkflowExecutionHistory(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DiffWorkflowExecutionHistory", args...)
+}
+
+// FixWorkflowExecutions responds to a FixWorkflowExecutions call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().FixWorkflowExecutions(gomock.Any(), ...).Return(...)
+// 	... := client.FixWorkflowExecutions(...)
+func (m *MockClient) FixWorkflowExecutions(
+	ctx context.Context,
+	_Request *admin.FixWorkflowExecutionsRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.FixWorkflowExecutionsResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "FixWorkflowExecutions", args...)
+	success, _ = ret[i].(*admin.FixWorkflowExecutionsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) FixWorkflowExecutions(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "FixWorkflowExecutions", args...)
+}
+
+// ForceUnloadTaskList responds to a ForceUnloadTaskList call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().ForceUnloadTaskList(gomock.Any(), ...).Return(...)
+// 	... := client.ForceUnloadTaskList(...)
+func (m *MockClient) ForceUnloadTaskList(
+	ctx context.Context,
+	_Request *shared.ForceUnloadTaskListRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.ForceUnloadTaskListResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ForceUnloadTaskList", args...)
+	success, _ = ret[i].(*shared.ForceUnloadTaskListResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ForceUnloadTaskList(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ForceUnloadTaskList", args...)
+}
+
+// GetWorkflowExecutionHistoryBatches responds to a GetWorkflowExecutionHistoryBatches call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().GetWorkflowExecutionHistoryBatches(gomock.Any(), ...).Return(...)
+// 	... := client.GetWorkflowExecutionHistoryBatches(...)
+func (m *MockClient) GetWorkflowExecutionHistoryBatches(
+	ctx context.Context,
+	_Request *admin.GetWorkflowExecutionHistoryBatchesRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.GetWorkflowExecutionHistoryBatchesResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "GetWorkflowExecutionHistoryBatches", args...)
+	success, _ = ret[i].(*admin.GetWorkflowExecutionHistoryBatchesResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) GetWorkflowExecutionHistoryBatches(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "GetWorkflowExecutionHistoryBatches", args...)
+}
+
+// ListPostCloseEvents responds to a ListPostCloseEvents call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().ListPostCloseEvents(gomock.Any(), ...).Return(...)
+// 	... := client.ListPostCloseEvents(...)
+func (m *MockClient) ListPostCloseEvents(
+	ctx context.Context,
+	_Request *admin.ListPostCloseEventsRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ListPostCloseEventsResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ListPostCloseEvents", args...)
+	success, _ = ret[i].(*admin.ListPostCloseEventsResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ListPostCloseEvents(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ListPostCloseEvents", args...)
+}
+
+// ListTaskListDLQTasks responds to a ListTaskListDLQTasks call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().ListTaskListDLQTasks(gomock.Any(), ...).Return(...)
+// 	... := client.ListTaskListDLQTasks(...)
+func (m *MockClient) ListTaskListDLQTasks(
+	ctx context.Context,
+	_Request *shared.ListTaskListDLQTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.ListTaskListDLQTasksResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ListTaskListDLQTasks", args...)
+	success, _ = ret[i].(*shared.ListTaskListDLQTasksResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ListTaskListDLQTasks(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ListTaskListDLQTasks", args...)
+}
+
+// RefreshDomainCache responds to a RefreshDomainCache call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().RefreshDomainCache(gomock.Any(), ...).Return(...)
+// 	... := client.RefreshDomainCache(...)
+func (m *MockClient) RefreshDomainCache(
+	ctx context.Context,
+	_Request *shared.RefreshDomainCacheRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "RefreshDomainCache", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) RefreshDomainCache(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "RefreshDomainCache", args...)
+}
+
+// RequeueTaskListDLQTasks responds to a RequeueTaskListDLQTasks call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().RequeueTaskListDLQTasks(gomock.Any(), ...).Return(...)
+// 	... := client.RequeueTaskListDLQTasks(...)
+func (m *MockClient) RequeueTaskListDLQTasks(
+	ctx context.Context,
+	_Request *shared.RequeueTaskListDLQTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.RequeueTaskListDLQTasksResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "RequeueTaskListDLQTasks", args...)
+	success, _ = ret[i].(*shared.RequeueTaskListDLQTasksResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) RequeueTaskListDLQTasks(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "RequeueTaskListDLQTasks", args...)
+}
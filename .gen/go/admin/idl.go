@@ -33,11 +33,11 @@ var ThriftModule = &thriftreflect.ThriftModule{
 	Name:     "admin",
 	Package:  "github.com/uber/cadence/.gen/go/admin",
 	FilePath: "admin.thrift",
-	SHA1:     "bb61fbdf93f8f77bd9957ecfcacf5508cedfe81d",
+	SHA1:     "ede703c70279d2d23e6a8cba78bf8b837edb2101",
 	Includes: []*thriftreflect.ThriftModule{
 		shared.ThriftModule,
 	},
 	Raw: rawIDL,
 }
 
-const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\nnamespace java com.uber.cadence.admin\n\ninclude \"shared.thrift\"\n\n/**\n* AdminService provides advanced APIs for debugging and analysis with admin privillege\n**/\nservice AdminService {\n  /**\n  * DescribeWorkflowExecution returns information about the internal states of workflow execution.\n  **/\n  DescribeWorkflowExecutionResponse DescribeWorkflowExecution(1: DescribeWorkflowExecutionRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n    * DescribeHistoryHost returns information about the internal states of a history host\n    **/\n    shared.DescribeHistoryHostResponse DescribeHistoryHost(1: shared.DescribeHistoryHostRequest request)\n      throws (\n        1: shared.BadRequestError       badRequestError,\n        2: shared.InternalServiceError  internalServiceError,\n        3: shared.AccessDeniedError     accessDeniedError,\n      )\n}\n\nstruct DescribeWorkflowExecutionRequest {\n  10: optional string                       domain\n  20: optional shared.WorkflowExecution     execution\n}\n\nstruct DescribeWorkflowExecutionResponse{\n  10: optional string shardId\n  20: optional string historyAddr\n  40: optional string mutableStateInCache\n  50: optional string mutableStateInDatabase\n}"
+const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\nnamespace java com.uber.cadence.admin\n\ninclude \"shared.thrift\"\n\n/**\n* AdminService provides advanced APIs for debugging and analysis with admin privillege\n**/\nservice AdminService {\n  /**\n  * DescribeWorkflowExecution returns information about the internal states of workflow execution.\n  **/\n  DescribeWorkflowExecutionResponse DescribeWorkflowExecution(1: DescribeWorkflowExecutionRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n    * DescribeHistoryHost returns information about the internal states of a history host\n    **/\n    shared.DescribeHistoryHostResponse DescribeHistoryHost(1: shared.DescribeHistoryHostRequest request)\n      throws (\n        1: shared.BadRequestError       badRequestError,\n        2: shared.InternalServiceError  internalServiceError,\n        3: shared.AccessDeniedError     accessDeniedError,\n      )\n\n  /**\n  * DescribeShardBacklogs returns the shards with the oldest unacked timer, transfer and replication tasks\n  * across the history hosts, so stuck shards can be spotted.\n  **/\n  shared.DescribeShardBacklogsResponse DescribeShardBacklogs(1: shared.DescribeShardBacklogsRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * DescribeShardOperations returns the recent significant operations of a history shard, such as processed tasks,\n  * ack level moves, resolved conflicts and range renewals, most recent first.\n  **/\n  shared.DescribeShardOperationsResponse DescribeShardOperations(1: shared.DescribeShardOperationsRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * DeleteWorkflowExecution force deletes a workflow execution run: its visibility records, the current\n  * execution pointer when it points to the run, its history and finally its mutable state. A running\n  * execution is only deleted when force is set.\n  **/\n  void DeleteWorkflowExecution(1: DeleteWorkflowExecutionRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.ServiceBusyError        serviceBusyError,\n      5: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * GetWorkflowExecutionHistoryBatches returns the history of a workflow execution run page by page,\n  * keeping the events grouped in the batches they were persisted with.\n  **/\n  GetWorkflowExecutionHistoryBatchesResponse GetWorkflowExecutionHistoryBatches(1: GetWorkflowExecutionHistoryBatchesRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.ServiceBusyError        serviceBusyError,\n      5: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * ListPostCloseEvents returns the externally generated events, such as signals, which were replicated from a remote\n  * cluster for a workflow execution run after it was closed in the current cluster. They could not be applied to the\n  * run and were recorded instead of being dropped.\n  **/\n  ListPostCloseEventsResponse ListPostCloseEvents(1: ListPostCloseEventsRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.ServiceBusyError        serviceBusyError,\n      5: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * DiffWorkflowExecutionHistory compares the history of a workflow execution run in the current cluster\n  * with the one in a remote cluster, and returns the first event where the two diverge.\n  **/\n  DiffWorkflowExecutionHistoryResponse DiffWorkflowExecutionHistory(1: DiffWorkflowExecutionHistoryRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.ServiceBusyError        serviceBusyError,\n      5: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * ListTaskListDLQTasks returns the tasks of a tasklist which were moved to its DLQ after repeatedly failing\n  * to be dispatched.\n  **/\n  shared.ListTaskListDLQTasksResponse ListTaskListDLQTasks(1: shared.ListTaskListDLQTasksRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.ServiceBusyError        serviceBusyError,\n      5: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * RefreshDomainCache asks a frontend host to refresh its domain cache right away, it is called after a domain\n  * got updated so that the change is not picked up only by the periodic refresh\n  **/\n  void RefreshDomainCache(1: shared.RefreshDomainCacheRequest request)\n    throws (\n      1: shared.BadRequestError badRequestError,\n      2: shared.InternalServiceError internalServiceError,\n    )\n\n  /**\n  * RequeueTaskListDLQTasks writes the given tasks of a tasklist DLQ, or all of them when none are given, back to\n  * the tasklist so they are dispatched again.\n  **/\n  shared.RequeueTaskListDLQTasksResponse RequeueTaskListDLQTasks(1: shared.RequeueTaskListDLQTasksRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.ServiceBusyError        serviceBusyError,\n      5: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * DescribeFailoverDrill reports whether the current cluster, as the target of a failover drill of the domain,\n  * could take it over: how far behind the active cluster its standby task processing is, and whether workers\n  * poll the task lists of the domain in this cluster.\n  **/\n  DescribeFailoverDrillResponse DescribeFailoverDrill(1: DescribeFailoverDrillRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.ServiceBusyError        serviceBusyError,\n      5: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * DescribeTaskListLatency returns the percentiles of the schedule-to-start latency of the tasks recently\n  * dispatched from a tasklist, the time they waited for a worker to pick them up.\n  **/\n  shared.DescribeTaskListLatencyResponse DescribeTaskListLatency(1: shared.DescribeTaskListLatencyRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.ServiceBusyError        serviceBusyError,\n      5: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * ForceUnloadTaskList drops the manager of a tasklist from the matching host it is loaded on, so that it is\n  * placed again by membership on the next request. It is used to move hot tasklists off a degraded matching host.\n  **/\n  shared.ForceUnloadTaskListResponse ForceUnloadTaskList(1: shared.ForceUnloadTaskListRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.ServiceBusyError        serviceBusyError,\n      5: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * FixWorkflowExecutions applies targeted fixes to the workflow execution runs reported inconsistent by a scanner:\n  * missing tasks are regenerated, corrupted mutable states are rebuilt from history and orphan runs are deleted.\n  * Each run is fixed independently and reported in the results, nothing is changed on a dry run.\n  **/\n  FixWorkflowExecutionsResponse FixWorkflowExecutions(1: FixWorkflowExecutionsRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.ServiceBusyError        serviceBusyError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n  * DescribeReplicationDLQ summarizes the replication tasks from a remote cluster which the current cluster failed\n  * to apply and moved to the DLQ: how many there are and how old the oldest one is, for each history shard of a page\n  * of shards which has any.\n  **/\n  DescribeReplicationDLQResponse DescribeReplicationDLQ(1: DescribeReplicationDLQRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.ServiceBusyError        serviceBusyError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n}\n\nstruct DescribeWorkflowExecutionRequest {\n  10: optional string                       domain\n  20: optional shared.WorkflowExecution     execution\n}\n\nstruct DescribeWorkflowExecutionResponse{\n  10: optional string shardId\n  20: optional string historyAddr\n  40: optional string mutableStateInCache\n  50: optional string mutableStateInDatabase\n}\n\nstruct DeleteWorkflowExecutionRequest {\n  10: optional string                       domain\n  20: optional shared.WorkflowExecution     execution\n  30: optional bool                         force\n}\n\nstruct GetWorkflowExecutionHistoryBatchesRequest {\n  10: optional string                       domain\n  20: optional shared.WorkflowExecution     execution\n  30: optional i32                          maximumPageSize\n  40: optional binary                       nextPageToken\n}\n\nstruct GetWorkflowExecutionHistoryBatchesResponse {\n  10: optional list<shared.History>         historyBatches\n  20: optional binary                       nextPageToken\n}\n\nstruct ListPostCloseEventsRequest {\n  10: optional string                       domain\n  20: optional shared.WorkflowExecution     execution\n  30: optional i32                          maximumPageSize\n  40: optional binary                       nextPageToken\n}\n\nstruct PostCloseEventBatch {\n  10: optional string                       sourceCluster\n  // time the events were recorded in the current cluster, in unix nanoseconds\n  20: optional i64                          recordedTimestamp\n  30: optional shared.History               history\n}\n\nstruct ListPostCloseEventsResponse {\n  10: optional list<PostCloseEventBatch>    batches\n  20: optional binary                       nextPageToken\n}\n\nstruct DiffWorkflowExecutionHistoryRequest {\n  10: optional string                       domain\n  20: optional shared.WorkflowExecution     execution\n  30: optional string                       remoteCluster\n  40: optional i32                          maximumPageSize\n}\n\nstruct HistoryDivergence {\n  10: optional i32                          batchIndex\n  20: optional i64                          eventId\n  30: optional i64                          localEventId\n  40: optional i64                          remoteEventId\n  50: optional i64                          localVersion\n  60: optional i64                          remoteVersion\n  70: optional shared.EventType             localEventType\n  80: optional shared.EventType             remoteEventType\n  90: optional string                       reason\n}\n\nstruct DiffWorkflowExecutionHistoryResponse {\n  10: optional bool                         identical\n  20: optional i64                          comparedEventCount\n  30: optional HistoryDivergence            divergence\n}\n\nstruct DescribeFailoverDrillRequest {\n  10: optional string                       domain\n  // task lists which must have pollers in this cluster, the task lists of the domain known to this cluster\n  // are checked when not set\n  20: optional list<shared.TaskList>        taskLists\n}\n\nstruct FailoverDrillTaskListStatus {\n  10: optional shared.TaskList              taskList\n  20: optional shared.TaskListType          taskListType\n  30: optional i32                          pollerCount\n}\n\nstruct DescribeFailoverDrillResponse {\n  10: optional string                       domain\n  20: optional string                       activeClusterName\n  30: optional string                       drillClusterName\n  // age of the oldest unprocessed standby task replicated from the active cluster, across all shards\n  40: optional i64                          replicationLagInSeconds\n  50: optional list<FailoverDrillTaskListStatus> taskLists\n  // whether the drill found nothing preventing a failover to this cluster\n  60: optional bool                         ready\n  // what prevents a failover to this cluster, empty when ready\n  70: optional list<string>                 issues\n}\n\nstruct WorkflowExecutionIssue {\n  10: optional string                       domain\n  20: optional shared.WorkflowExecution     execution\n  30: optional shared.WorkflowExecutionIssueType issueType\n}\n\nstruct FixWorkflowExecutionsRequest {\n  10: optional list<WorkflowExecutionIssue> issues\n  20: optional bool                         dryRun\n}\n\nstruct WorkflowExecutionFixResult {\n  10: optional WorkflowExecutionIssue       issue\n  // whether the fix was applied, never set on a dry run\n  20: optional bool                         fixed\n  // what was done to fix the run, or what would be done on a dry run\n  30: optional string                       action\n  // why the run could not be fixed\n  40: optional string                       error\n}\n\nstruct FixWorkflowExecutionsResponse {\n  10: optional list<WorkflowExecutionFixResult> results\n}\n\nstruct DescribeReplicationDLQRequest {\n  10: optional string                       sourceCluster\n  // first shard of the page of shards to describe\n  20: optional i32                          startShardId\n  30: optional i32                          maximumShardCount\n}\n\nstruct ReplicationDLQSummary {\n  10: optional i32                          shardId\n  20: optional string                       sourceCluster\n  30: optional i64                          messageCount\n  // time the oldest message was published by the source cluster, in unix nanoseconds\n  40: optional i64                          oldestMessageTimestamp\n}\n\nstruct DescribeReplicationDLQResponse {\n  // summaries of the shards of the page with messages in the DLQ\n  10: optional list<ReplicationDLQSummary>  summaries\n  // first shard of the next page, not set after the last shard\n  20: optional i32                          nextShardId\n}\n"
@@ -24,18 +24,20 @@
 package admin
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/uber/cadence/.gen/go/shared"
 	"go.uber.org/thriftrw/wire"
 	"strings"
 )
 
-type DescribeWorkflowExecutionRequest struct {
+type DeleteWorkflowExecutionRequest struct {
 	Domain    *string                   `json:"domain,omitempty"`
 	Execution *shared.WorkflowExecution `json:"execution,omitempty"`
+	Force     *bool                     `json:"force,omitempty"`
 }
 
-// ToWire translates a DescribeWorkflowExecutionRequest struct into a Thrift-level intermediate
+// ToWire translates a DeleteWorkflowExecutionRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -50,9 +52,9 @@ type DescribeWorkflowExecutionRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *DescribeWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+func (v *DeleteWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -74,6 +76,14 @@ func (v *DescribeWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
+	if v.Force != nil {
+		w, err = wire.NewValueBool(*(v.Force)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -84,11 +94,11 @@ func _WorkflowExecution_Read(w wire.Value) (*shared.WorkflowExecution, error) {
 	return &v, err
 }
 
-// FromWire deserializes a DescribeWorkflowExecutionRequest struct from its Thrift-level
+// FromWire deserializes a DeleteWorkflowExecutionRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a DescribeWorkflowExecutionRequest struct
+// An error is returned if we were unable to build a DeleteWorkflowExecutionRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -96,12 +106,12 @@ func _WorkflowExecution_Read(w wire.Value) (*shared.WorkflowExecution, error) {
 //     return nil, err
 //   }
 //
-//   var v DescribeWorkflowExecutionRequest
+//   var v DeleteWorkflowExecutionRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *DescribeWorkflowExecutionRequest) FromWire(w wire.Value) error {
+func (v *DeleteWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -123,6 +133,16 @@ func (v *DescribeWorkflowExecutionRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 30:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Force = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -130,14 +150,14 @@ func (v *DescribeWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a DescribeWorkflowExecutionRequest
+// String returns a readable string representation of a DeleteWorkflowExecutionRequest
 // struct.
-func (v *DescribeWorkflowExecutionRequest) String() string {
+func (v *DeleteWorkflowExecutionRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [2]string
+	var fields [3]string
 	i := 0
 	if v.Domain != nil {
 		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
@@ -147,8 +167,12 @@ func (v *DescribeWorkflowExecutionRequest) String() string {
 		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
 		i++
 	}
+	if v.Force != nil {
+		fields[i] = fmt.Sprintf("Force: %v", *(v.Force))
+		i++
+	}
 
-	return fmt.Sprintf("DescribeWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("DeleteWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
 func _String_EqualsPtr(lhs, rhs *string) bool {
@@ -161,24 +185,37 @@ func _String_EqualsPtr(lhs, rhs *string) bool {
 	return lhs == nil && rhs == nil
 }
 
-// Equals returns true if all the fields of this DescribeWorkflowExecutionRequest match the
-// provided DescribeWorkflowExecutionRequest.
+func _Bool_EqualsPtr(lhs, rhs *bool) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this DeleteWorkflowExecutionRequest match the
+// provided DeleteWorkflowExecutionRequest.
 //
 // This function performs a deep comparison.
-func (v *DescribeWorkflowExecutionRequest) Equals(rhs *DescribeWorkflowExecutionRequest) bool {
+func (v *DeleteWorkflowExecutionRequest) Equals(rhs *DeleteWorkflowExecutionRequest) bool {
 	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
 		return false
 	}
 	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
 		return false
 	}
+	if !_Bool_EqualsPtr(v.Force, rhs.Force) {
+		return false
+	}
 
 	return true
 }
 
 // GetDomain returns the value of Domain if it is set or its
 // zero value if it is unset.
-func (v *DescribeWorkflowExecutionRequest) GetDomain() (o string) {
+func (v *DeleteWorkflowExecutionRequest) GetDomain() (o string) {
 	if v.Domain != nil {
 		return *v.Domain
 	}
@@ -188,7 +225,7 @@ func (v *DescribeWorkflowExecutionRequest) GetDomain() (o string) {
 
 // GetExecution returns the value of Execution if it is set or its
 // zero value if it is unset.
-func (v *DescribeWorkflowExecutionRequest) GetExecution() (o *shared.WorkflowExecution) {
+func (v *DeleteWorkflowExecutionRequest) GetExecution() (o *shared.WorkflowExecution) {
 	if v.Execution != nil {
 		return v.Execution
 	}
@@ -196,14 +233,51 @@ func (v *DescribeWorkflowExecutionRequest) GetExecution() (o *shared.WorkflowExe
 	return
 }
 
-type DescribeWorkflowExecutionResponse struct {
-	ShardId                *string `json:"shardId,omitempty"`
-	HistoryAddr            *string `json:"historyAddr,omitempty"`
-	MutableStateInCache    *string `json:"mutableStateInCache,omitempty"`
-	MutableStateInDatabase *string `json:"mutableStateInDatabase,omitempty"`
+// GetForce returns the value of Force if it is set or its
+// zero value if it is unset.
+func (v *DeleteWorkflowExecutionRequest) GetForce() (o bool) {
+	if v.Force != nil {
+		return *v.Force
+	}
+
+	return
 }
 
-// ToWire translates a DescribeWorkflowExecutionResponse struct into a Thrift-level intermediate
+type DescribeFailoverDrillRequest struct {
+	Domain    *string            `json:"domain,omitempty"`
+	TaskLists []*shared.TaskList `json:"taskLists,omitempty"`
+}
+
+type _List_TaskList_ValueList []*shared.TaskList
+
+func (v _List_TaskList_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_TaskList_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_TaskList_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_TaskList_ValueList) Close() {}
+
+// ToWire translates a DescribeFailoverDrillRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -218,55 +292,63 @@ type DescribeWorkflowExecutionResponse struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *DescribeWorkflowExecutionResponse) ToWire() (wire.Value, error) {
+func (v *DescribeFailoverDrillRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.ShardId != nil {
-		w, err = wire.NewValueString(*(v.ShardId)), error(nil)
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.HistoryAddr != nil {
-		w, err = wire.NewValueString(*(v.HistoryAddr)), error(nil)
+	if v.TaskLists != nil {
+		w, err = wire.NewValueList(_List_TaskList_ValueList(v.TaskLists)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.MutableStateInCache != nil {
-		w, err = wire.NewValueString(*(v.MutableStateInCache)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _TaskList_Read(w wire.Value) (*shared.TaskList, error) {
+	var v shared.TaskList
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_TaskList_Read(l wire.ValueList) ([]*shared.TaskList, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
 	}
-	if v.MutableStateInDatabase != nil {
-		w, err = wire.NewValueString(*(v.MutableStateInDatabase)), error(nil)
+
+	o := make([]*shared.TaskList, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _TaskList_Read(x)
 		if err != nil {
-			return w, err
+			return err
 		}
-		fields[i] = wire.Field{ID: 50, Value: w}
-		i++
-	}
-
-	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
 }
 
-// FromWire deserializes a DescribeWorkflowExecutionResponse struct from its Thrift-level
+// FromWire deserializes a DescribeFailoverDrillRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a DescribeWorkflowExecutionResponse struct
+// An error is returned if we were unable to build a DescribeFailoverDrillRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -274,12 +356,12 @@ func (v *DescribeWorkflowExecutionResponse) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v DescribeWorkflowExecutionResponse
+//   var v DescribeFailoverDrillRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *DescribeWorkflowExecutionResponse) FromWire(w wire.Value) error {
+func (v *DescribeFailoverDrillRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -288,37 +370,15 @@ func (v *DescribeWorkflowExecutionResponse) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
-				v.ShardId = &x
+				v.Domain = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.HistoryAddr = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.MutableStateInCache = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 50:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.MutableStateInDatabase = &x
+			if field.Value.Type() == wire.TList {
+				v.TaskLists, err = _List_TaskList_Read(field.Value.GetList())
 				if err != nil {
 					return err
 				}
@@ -330,91 +390,4469 @@ func (v *DescribeWorkflowExecutionResponse) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a DescribeWorkflowExecutionResponse
+// String returns a readable string representation of a DescribeFailoverDrillRequest
 // struct.
-func (v *DescribeWorkflowExecutionResponse) String() string {
+func (v *DescribeFailoverDrillRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [2]string
 	i := 0
-	if v.ShardId != nil {
-		fields[i] = fmt.Sprintf("ShardId: %v", *(v.ShardId))
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
 		i++
 	}
-	if v.HistoryAddr != nil {
-		fields[i] = fmt.Sprintf("HistoryAddr: %v", *(v.HistoryAddr))
+	if v.TaskLists != nil {
+		fields[i] = fmt.Sprintf("TaskLists: %v", v.TaskLists)
 		i++
 	}
-	if v.MutableStateInCache != nil {
-		fields[i] = fmt.Sprintf("MutableStateInCache: %v", *(v.MutableStateInCache))
-		i++
+
+	return fmt.Sprintf("DescribeFailoverDrillRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_TaskList_Equals(lhs, rhs []*shared.TaskList) bool {
+	if len(lhs) != len(rhs) {
+		return false
 	}
-	if v.MutableStateInDatabase != nil {
-		fields[i] = fmt.Sprintf("MutableStateInDatabase: %v", *(v.MutableStateInDatabase))
-		i++
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
 	}
 
-	return fmt.Sprintf("DescribeWorkflowExecutionResponse{%v}", strings.Join(fields[:i], ", "))
+	return true
 }
 
-// Equals returns true if all the fields of this DescribeWorkflowExecutionResponse match the
-// provided DescribeWorkflowExecutionResponse.
+// Equals returns true if all the fields of this DescribeFailoverDrillRequest match the
+// provided DescribeFailoverDrillRequest.
 //
 // This function performs a deep comparison.
-func (v *DescribeWorkflowExecutionResponse) Equals(rhs *DescribeWorkflowExecutionResponse) bool {
-	if !_String_EqualsPtr(v.ShardId, rhs.ShardId) {
-		return false
-	}
-	if !_String_EqualsPtr(v.HistoryAddr, rhs.HistoryAddr) {
-		return false
-	}
-	if !_String_EqualsPtr(v.MutableStateInCache, rhs.MutableStateInCache) {
+func (v *DescribeFailoverDrillRequest) Equals(rhs *DescribeFailoverDrillRequest) bool {
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
 		return false
 	}
-	if !_String_EqualsPtr(v.MutableStateInDatabase, rhs.MutableStateInDatabase) {
+	if !((v.TaskLists == nil && rhs.TaskLists == nil) || (v.TaskLists != nil && rhs.TaskLists != nil && _List_TaskList_Equals(v.TaskLists, rhs.TaskLists))) {
 		return false
 	}
 
 	return true
 }
 
-// GetShardId returns the value of ShardId if it is set or its
+// GetDomain returns the value of Domain if it is set or its
 // zero value if it is unset.
-func (v *DescribeWorkflowExecutionResponse) GetShardId() (o string) {
-	if v.ShardId != nil {
-		return *v.ShardId
+func (v *DescribeFailoverDrillRequest) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
 	}
 
 	return
 }
 
-// GetHistoryAddr returns the value of HistoryAddr if it is set or its
+// GetTaskLists returns the value of TaskLists if it is set or its
 // zero value if it is unset.
-func (v *DescribeWorkflowExecutionResponse) GetHistoryAddr() (o string) {
-	if v.HistoryAddr != nil {
-		return *v.HistoryAddr
+func (v *DescribeFailoverDrillRequest) GetTaskLists() (o []*shared.TaskList) {
+	if v.TaskLists != nil {
+		return v.TaskLists
 	}
 
 	return
 }
 
-// GetMutableStateInCache returns the value of MutableStateInCache if it is set or its
-// zero value if it is unset.
-func (v *DescribeWorkflowExecutionResponse) GetMutableStateInCache() (o string) {
-	if v.MutableStateInCache != nil {
-		return *v.MutableStateInCache
+type DescribeFailoverDrillResponse struct {
+	Domain                  *string                        `json:"domain,omitempty"`
+	ActiveClusterName       *string                        `json:"activeClusterName,omitempty"`
+	DrillClusterName        *string                        `json:"drillClusterName,omitempty"`
+	ReplicationLagInSeconds *int64                         `json:"replicationLagInSeconds,omitempty"`
+	TaskLists               []*FailoverDrillTaskListStatus `json:"taskLists,omitempty"`
+	Ready                   *bool                          `json:"ready,omitempty"`
+	Issues                  []string                       `json:"issues,omitempty"`
+}
+
+type _List_FailoverDrillTaskListStatus_ValueList []*FailoverDrillTaskListStatus
+
+func (v _List_FailoverDrillTaskListStatus_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_FailoverDrillTaskListStatus_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_FailoverDrillTaskListStatus_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_FailoverDrillTaskListStatus_ValueList) Close() {}
+
+type _List_String_ValueList []string
+
+func (v _List_String_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := wire.NewValueString(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	return
+func (v _List_String_ValueList) Size() int {
+	return len(v)
 }
 
-// GetMutableStateInDatabase returns the value of MutableStateInDatabase if it is set or its
-// zero value if it is unset.
-func (v *DescribeWorkflowExecutionResponse) GetMutableStateInDatabase() (o string) {
-	if v.MutableStateInDatabase != nil {
-		return *v.MutableStateInDatabase
+func (_List_String_ValueList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_List_String_ValueList) Close() {}
+
+// ToWire translates a DescribeFailoverDrillResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DescribeFailoverDrillResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [7]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.ActiveClusterName != nil {
+		w, err = wire.NewValueString(*(v.ActiveClusterName)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.DrillClusterName != nil {
+		w, err = wire.NewValueString(*(v.DrillClusterName)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.ReplicationLagInSeconds != nil {
+		w, err = wire.NewValueI64(*(v.ReplicationLagInSeconds)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.TaskLists != nil {
+		w, err = wire.NewValueList(_List_FailoverDrillTaskListStatus_ValueList(v.TaskLists)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.Ready != nil {
+		w, err = wire.NewValueBool(*(v.Ready)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.Issues != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.Issues)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _FailoverDrillTaskListStatus_Read(w wire.Value) (*FailoverDrillTaskListStatus, error) {
+	var v FailoverDrillTaskListStatus
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_FailoverDrillTaskListStatus_Read(l wire.ValueList) ([]*FailoverDrillTaskListStatus, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*FailoverDrillTaskListStatus, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _FailoverDrillTaskListStatus_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _List_String_Read(l wire.ValueList) ([]string, error) {
+	if l.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+
+	o := make([]string, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := x.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a DescribeFailoverDrillResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DescribeFailoverDrillResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DescribeFailoverDrillResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DescribeFailoverDrillResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ActiveClusterName = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DrillClusterName = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ReplicationLagInSeconds = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TList {
+				v.TaskLists, err = _List_FailoverDrillTaskListStatus_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Ready = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TList {
+				v.Issues, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DescribeFailoverDrillResponse
+// struct.
+func (v *DescribeFailoverDrillResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [7]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.ActiveClusterName != nil {
+		fields[i] = fmt.Sprintf("ActiveClusterName: %v", *(v.ActiveClusterName))
+		i++
+	}
+	if v.DrillClusterName != nil {
+		fields[i] = fmt.Sprintf("DrillClusterName: %v", *(v.DrillClusterName))
+		i++
+	}
+	if v.ReplicationLagInSeconds != nil {
+		fields[i] = fmt.Sprintf("ReplicationLagInSeconds: %v", *(v.ReplicationLagInSeconds))
+		i++
+	}
+	if v.TaskLists != nil {
+		fields[i] = fmt.Sprintf("TaskLists: %v", v.TaskLists)
+		i++
+	}
+	if v.Ready != nil {
+		fields[i] = fmt.Sprintf("Ready: %v", *(v.Ready))
+		i++
+	}
+	if v.Issues != nil {
+		fields[i] = fmt.Sprintf("Issues: %v", v.Issues)
+		i++
+	}
+
+	return fmt.Sprintf("DescribeFailoverDrillResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _I64_EqualsPtr(lhs, rhs *int64) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _List_FailoverDrillTaskListStatus_Equals(lhs, rhs []*FailoverDrillTaskListStatus) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func _List_String_Equals(lhs, rhs []string) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this DescribeFailoverDrillResponse match the
+// provided DescribeFailoverDrillResponse.
+//
+// This function performs a deep comparison.
+func (v *DescribeFailoverDrillResponse) Equals(rhs *DescribeFailoverDrillResponse) bool {
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ActiveClusterName, rhs.ActiveClusterName) {
+		return false
+	}
+	if !_String_EqualsPtr(v.DrillClusterName, rhs.DrillClusterName) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ReplicationLagInSeconds, rhs.ReplicationLagInSeconds) {
+		return false
+	}
+	if !((v.TaskLists == nil && rhs.TaskLists == nil) || (v.TaskLists != nil && rhs.TaskLists != nil && _List_FailoverDrillTaskListStatus_Equals(v.TaskLists, rhs.TaskLists))) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.Ready, rhs.Ready) {
+		return false
+	}
+	if !((v.Issues == nil && rhs.Issues == nil) || (v.Issues != nil && rhs.Issues != nil && _List_String_Equals(v.Issues, rhs.Issues))) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *DescribeFailoverDrillResponse) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// GetActiveClusterName returns the value of ActiveClusterName if it is set or its
+// zero value if it is unset.
+func (v *DescribeFailoverDrillResponse) GetActiveClusterName() (o string) {
+	if v.ActiveClusterName != nil {
+		return *v.ActiveClusterName
+	}
+
+	return
+}
+
+// GetDrillClusterName returns the value of DrillClusterName if it is set or its
+// zero value if it is unset.
+func (v *DescribeFailoverDrillResponse) GetDrillClusterName() (o string) {
+	if v.DrillClusterName != nil {
+		return *v.DrillClusterName
+	}
+
+	return
+}
+
+// GetReplicationLagInSeconds returns the value of ReplicationLagInSeconds if it is set or its
+// zero value if it is unset.
+func (v *DescribeFailoverDrillResponse) GetReplicationLagInSeconds() (o int64) {
+	if v.ReplicationLagInSeconds != nil {
+		return *v.ReplicationLagInSeconds
+	}
+
+	return
+}
+
+// GetTaskLists returns the value of TaskLists if it is set or its
+// zero value if it is unset.
+func (v *DescribeFailoverDrillResponse) GetTaskLists() (o []*FailoverDrillTaskListStatus) {
+	if v.TaskLists != nil {
+		return v.TaskLists
+	}
+
+	return
+}
+
+// GetReady returns the value of Ready if it is set or its
+// zero value if it is unset.
+func (v *DescribeFailoverDrillResponse) GetReady() (o bool) {
+	if v.Ready != nil {
+		return *v.Ready
+	}
+
+	return
+}
+
+// GetIssues returns the value of Issues if it is set or its
+// zero value if it is unset.
+func (v *DescribeFailoverDrillResponse) GetIssues() (o []string) {
+	if v.Issues != nil {
+		return v.Issues
+	}
+
+	return
+}
+
+type DescribeReplicationDLQRequest struct {
+	SourceCluster     *string `json:"sourceCluster,omitempty"`
+	StartShardId      *int32  `json:"startShardId,omitempty"`
+	MaximumShardCount *int32  `json:"maximumShardCount,omitempty"`
+}
+
+// ToWire translates a DescribeReplicationDLQRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DescribeReplicationDLQRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.SourceCluster != nil {
+		w, err = wire.NewValueString(*(v.SourceCluster)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.StartShardId != nil {
+		w, err = wire.NewValueI32(*(v.StartShardId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.MaximumShardCount != nil {
+		w, err = wire.NewValueI32(*(v.MaximumShardCount)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a DescribeReplicationDLQRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DescribeReplicationDLQRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DescribeReplicationDLQRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DescribeReplicationDLQRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.SourceCluster = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.StartShardId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.MaximumShardCount = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DescribeReplicationDLQRequest
+// struct.
+func (v *DescribeReplicationDLQRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.SourceCluster != nil {
+		fields[i] = fmt.Sprintf("SourceCluster: %v", *(v.SourceCluster))
+		i++
+	}
+	if v.StartShardId != nil {
+		fields[i] = fmt.Sprintf("StartShardId: %v", *(v.StartShardId))
+		i++
+	}
+	if v.MaximumShardCount != nil {
+		fields[i] = fmt.Sprintf("MaximumShardCount: %v", *(v.MaximumShardCount))
+		i++
+	}
+
+	return fmt.Sprintf("DescribeReplicationDLQRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _I32_EqualsPtr(lhs, rhs *int32) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this DescribeReplicationDLQRequest match the
+// provided DescribeReplicationDLQRequest.
+//
+// This function performs a deep comparison.
+func (v *DescribeReplicationDLQRequest) Equals(rhs *DescribeReplicationDLQRequest) bool {
+	if !_String_EqualsPtr(v.SourceCluster, rhs.SourceCluster) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.StartShardId, rhs.StartShardId) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.MaximumShardCount, rhs.MaximumShardCount) {
+		return false
+	}
+
+	return true
+}
+
+// GetSourceCluster returns the value of SourceCluster if it is set or its
+// zero value if it is unset.
+func (v *DescribeReplicationDLQRequest) GetSourceCluster() (o string) {
+	if v.SourceCluster != nil {
+		return *v.SourceCluster
+	}
+
+	return
+}
+
+// GetStartShardId returns the value of StartShardId if it is set or its
+// zero value if it is unset.
+func (v *DescribeReplicationDLQRequest) GetStartShardId() (o int32) {
+	if v.StartShardId != nil {
+		return *v.StartShardId
+	}
+
+	return
+}
+
+// GetMaximumShardCount returns the value of MaximumShardCount if it is set or its
+// zero value if it is unset.
+func (v *DescribeReplicationDLQRequest) GetMaximumShardCount() (o int32) {
+	if v.MaximumShardCount != nil {
+		return *v.MaximumShardCount
+	}
+
+	return
+}
+
+type DescribeReplicationDLQResponse struct {
+	Summaries   []*ReplicationDLQSummary `json:"summaries,omitempty"`
+	NextShardId *int32                   `json:"nextShardId,omitempty"`
+}
+
+type _List_ReplicationDLQSummary_ValueList []*ReplicationDLQSummary
+
+func (v _List_ReplicationDLQSummary_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_ReplicationDLQSummary_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_ReplicationDLQSummary_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_ReplicationDLQSummary_ValueList) Close() {}
+
+// ToWire translates a DescribeReplicationDLQResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DescribeReplicationDLQResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Summaries != nil {
+		w, err = wire.NewValueList(_List_ReplicationDLQSummary_ValueList(v.Summaries)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.NextShardId != nil {
+		w, err = wire.NewValueI32(*(v.NextShardId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ReplicationDLQSummary_Read(w wire.Value) (*ReplicationDLQSummary, error) {
+	var v ReplicationDLQSummary
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_ReplicationDLQSummary_Read(l wire.ValueList) ([]*ReplicationDLQSummary, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*ReplicationDLQSummary, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _ReplicationDLQSummary_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a DescribeReplicationDLQResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DescribeReplicationDLQResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DescribeReplicationDLQResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DescribeReplicationDLQResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.Summaries, err = _List_ReplicationDLQSummary_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.NextShardId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DescribeReplicationDLQResponse
+// struct.
+func (v *DescribeReplicationDLQResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Summaries != nil {
+		fields[i] = fmt.Sprintf("Summaries: %v", v.Summaries)
+		i++
+	}
+	if v.NextShardId != nil {
+		fields[i] = fmt.Sprintf("NextShardId: %v", *(v.NextShardId))
+		i++
+	}
+
+	return fmt.Sprintf("DescribeReplicationDLQResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_ReplicationDLQSummary_Equals(lhs, rhs []*ReplicationDLQSummary) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this DescribeReplicationDLQResponse match the
+// provided DescribeReplicationDLQResponse.
+//
+// This function performs a deep comparison.
+func (v *DescribeReplicationDLQResponse) Equals(rhs *DescribeReplicationDLQResponse) bool {
+	if !((v.Summaries == nil && rhs.Summaries == nil) || (v.Summaries != nil && rhs.Summaries != nil && _List_ReplicationDLQSummary_Equals(v.Summaries, rhs.Summaries))) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.NextShardId, rhs.NextShardId) {
+		return false
+	}
+
+	return true
+}
+
+// GetSummaries returns the value of Summaries if it is set or its
+// zero value if it is unset.
+func (v *DescribeReplicationDLQResponse) GetSummaries() (o []*ReplicationDLQSummary) {
+	if v.Summaries != nil {
+		return v.Summaries
+	}
+
+	return
+}
+
+// GetNextShardId returns the value of NextShardId if it is set or its
+// zero value if it is unset.
+func (v *DescribeReplicationDLQResponse) GetNextShardId() (o int32) {
+	if v.NextShardId != nil {
+		return *v.NextShardId
+	}
+
+	return
+}
+
+type DescribeWorkflowExecutionRequest struct {
+	Domain    *string                   `json:"domain,omitempty"`
+	Execution *shared.WorkflowExecution `json:"execution,omitempty"`
+}
+
+// ToWire translates a DescribeWorkflowExecutionRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DescribeWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a DescribeWorkflowExecutionRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DescribeWorkflowExecutionRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DescribeWorkflowExecutionRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DescribeWorkflowExecutionRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DescribeWorkflowExecutionRequest
+// struct.
+func (v *DescribeWorkflowExecutionRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+
+	return fmt.Sprintf("DescribeWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this DescribeWorkflowExecutionRequest match the
+// provided DescribeWorkflowExecutionRequest.
+//
+// This function performs a deep comparison.
+func (v *DescribeWorkflowExecutionRequest) Equals(rhs *DescribeWorkflowExecutionRequest) bool {
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionRequest) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// GetExecution returns the value of Execution if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionRequest) GetExecution() (o *shared.WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
+	}
+
+	return
+}
+
+type DescribeWorkflowExecutionResponse struct {
+	ShardId                *string `json:"shardId,omitempty"`
+	HistoryAddr            *string `json:"historyAddr,omitempty"`
+	MutableStateInCache    *string `json:"mutableStateInCache,omitempty"`
+	MutableStateInDatabase *string `json:"mutableStateInDatabase,omitempty"`
+}
+
+// ToWire translates a DescribeWorkflowExecutionResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DescribeWorkflowExecutionResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ShardId != nil {
+		w, err = wire.NewValueString(*(v.ShardId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.HistoryAddr != nil {
+		w, err = wire.NewValueString(*(v.HistoryAddr)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.MutableStateInCache != nil {
+		w, err = wire.NewValueString(*(v.MutableStateInCache)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.MutableStateInDatabase != nil {
+		w, err = wire.NewValueString(*(v.MutableStateInDatabase)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a DescribeWorkflowExecutionResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DescribeWorkflowExecutionResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DescribeWorkflowExecutionResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DescribeWorkflowExecutionResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ShardId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.HistoryAddr = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.MutableStateInCache = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.MutableStateInDatabase = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DescribeWorkflowExecutionResponse
+// struct.
+func (v *DescribeWorkflowExecutionResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.ShardId != nil {
+		fields[i] = fmt.Sprintf("ShardId: %v", *(v.ShardId))
+		i++
+	}
+	if v.HistoryAddr != nil {
+		fields[i] = fmt.Sprintf("HistoryAddr: %v", *(v.HistoryAddr))
+		i++
+	}
+	if v.MutableStateInCache != nil {
+		fields[i] = fmt.Sprintf("MutableStateInCache: %v", *(v.MutableStateInCache))
+		i++
+	}
+	if v.MutableStateInDatabase != nil {
+		fields[i] = fmt.Sprintf("MutableStateInDatabase: %v", *(v.MutableStateInDatabase))
+		i++
+	}
+
+	return fmt.Sprintf("DescribeWorkflowExecutionResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this DescribeWorkflowExecutionResponse match the
+// provided DescribeWorkflowExecutionResponse.
+//
+// This function performs a deep comparison.
+func (v *DescribeWorkflowExecutionResponse) Equals(rhs *DescribeWorkflowExecutionResponse) bool {
+	if !_String_EqualsPtr(v.ShardId, rhs.ShardId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.HistoryAddr, rhs.HistoryAddr) {
+		return false
+	}
+	if !_String_EqualsPtr(v.MutableStateInCache, rhs.MutableStateInCache) {
+		return false
+	}
+	if !_String_EqualsPtr(v.MutableStateInDatabase, rhs.MutableStateInDatabase) {
+		return false
+	}
+
+	return true
+}
+
+// GetShardId returns the value of ShardId if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionResponse) GetShardId() (o string) {
+	if v.ShardId != nil {
+		return *v.ShardId
+	}
+
+	return
+}
+
+// GetHistoryAddr returns the value of HistoryAddr if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionResponse) GetHistoryAddr() (o string) {
+	if v.HistoryAddr != nil {
+		return *v.HistoryAddr
+	}
+
+	return
+}
+
+// GetMutableStateInCache returns the value of MutableStateInCache if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionResponse) GetMutableStateInCache() (o string) {
+	if v.MutableStateInCache != nil {
+		return *v.MutableStateInCache
+	}
+
+	return
+}
+
+// GetMutableStateInDatabase returns the value of MutableStateInDatabase if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionResponse) GetMutableStateInDatabase() (o string) {
+	if v.MutableStateInDatabase != nil {
+		return *v.MutableStateInDatabase
+	}
+
+	return
+}
+
+type DiffWorkflowExecutionHistoryRequest struct {
+	Domain          *string                   `json:"domain,omitempty"`
+	Execution       *shared.WorkflowExecution `json:"execution,omitempty"`
+	RemoteCluster   *string                   `json:"remoteCluster,omitempty"`
+	MaximumPageSize *int32                    `json:"maximumPageSize,omitempty"`
+}
+
+// ToWire translates a DiffWorkflowExecutionHistoryRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DiffWorkflowExecutionHistoryRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.RemoteCluster != nil {
+		w, err = wire.NewValueString(*(v.RemoteCluster)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.MaximumPageSize != nil {
+		w, err = wire.NewValueI32(*(v.MaximumPageSize)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a DiffWorkflowExecutionHistoryRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DiffWorkflowExecutionHistoryRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DiffWorkflowExecutionHistoryRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DiffWorkflowExecutionHistoryRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RemoteCluster = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.MaximumPageSize = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DiffWorkflowExecutionHistoryRequest
+// struct.
+func (v *DiffWorkflowExecutionHistoryRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+	if v.RemoteCluster != nil {
+		fields[i] = fmt.Sprintf("RemoteCluster: %v", *(v.RemoteCluster))
+		i++
+	}
+	if v.MaximumPageSize != nil {
+		fields[i] = fmt.Sprintf("MaximumPageSize: %v", *(v.MaximumPageSize))
+		i++
+	}
+
+	return fmt.Sprintf("DiffWorkflowExecutionHistoryRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this DiffWorkflowExecutionHistoryRequest match the
+// provided DiffWorkflowExecutionHistoryRequest.
+//
+// This function performs a deep comparison.
+func (v *DiffWorkflowExecutionHistoryRequest) Equals(rhs *DiffWorkflowExecutionHistoryRequest) bool {
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.RemoteCluster, rhs.RemoteCluster) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.MaximumPageSize, rhs.MaximumPageSize) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *DiffWorkflowExecutionHistoryRequest) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// GetExecution returns the value of Execution if it is set or its
+// zero value if it is unset.
+func (v *DiffWorkflowExecutionHistoryRequest) GetExecution() (o *shared.WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
+	}
+
+	return
+}
+
+// GetRemoteCluster returns the value of RemoteCluster if it is set or its
+// zero value if it is unset.
+func (v *DiffWorkflowExecutionHistoryRequest) GetRemoteCluster() (o string) {
+	if v.RemoteCluster != nil {
+		return *v.RemoteCluster
+	}
+
+	return
+}
+
+// GetMaximumPageSize returns the value of MaximumPageSize if it is set or its
+// zero value if it is unset.
+func (v *DiffWorkflowExecutionHistoryRequest) GetMaximumPageSize() (o int32) {
+	if v.MaximumPageSize != nil {
+		return *v.MaximumPageSize
+	}
+
+	return
+}
+
+type DiffWorkflowExecutionHistoryResponse struct {
+	Identical          *bool              `json:"identical,omitempty"`
+	ComparedEventCount *int64             `json:"comparedEventCount,omitempty"`
+	Divergence         *HistoryDivergence `json:"divergence,omitempty"`
+}
+
+// ToWire translates a DiffWorkflowExecutionHistoryResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DiffWorkflowExecutionHistoryResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Identical != nil {
+		w, err = wire.NewValueBool(*(v.Identical)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.ComparedEventCount != nil {
+		w, err = wire.NewValueI64(*(v.ComparedEventCount)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Divergence != nil {
+		w, err = v.Divergence.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _HistoryDivergence_Read(w wire.Value) (*HistoryDivergence, error) {
+	var v HistoryDivergence
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a DiffWorkflowExecutionHistoryResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DiffWorkflowExecutionHistoryResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DiffWorkflowExecutionHistoryResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DiffWorkflowExecutionHistoryResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Identical = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ComparedEventCount = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.Divergence, err = _HistoryDivergence_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DiffWorkflowExecutionHistoryResponse
+// struct.
+func (v *DiffWorkflowExecutionHistoryResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.Identical != nil {
+		fields[i] = fmt.Sprintf("Identical: %v", *(v.Identical))
+		i++
+	}
+	if v.ComparedEventCount != nil {
+		fields[i] = fmt.Sprintf("ComparedEventCount: %v", *(v.ComparedEventCount))
+		i++
+	}
+	if v.Divergence != nil {
+		fields[i] = fmt.Sprintf("Divergence: %v", v.Divergence)
+		i++
+	}
+
+	return fmt.Sprintf("DiffWorkflowExecutionHistoryResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this DiffWorkflowExecutionHistoryResponse match the
+// provided DiffWorkflowExecutionHistoryResponse.
+//
+// This function performs a deep comparison.
+func (v *DiffWorkflowExecutionHistoryResponse) Equals(rhs *DiffWorkflowExecutionHistoryResponse) bool {
+	if !_Bool_EqualsPtr(v.Identical, rhs.Identical) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ComparedEventCount, rhs.ComparedEventCount) {
+		return false
+	}
+	if !((v.Divergence == nil && rhs.Divergence == nil) || (v.Divergence != nil && rhs.Divergence != nil && v.Divergence.Equals(rhs.Divergence))) {
+		return false
+	}
+
+	return true
+}
+
+// GetIdentical returns the value of Identical if it is set or its
+// zero value if it is unset.
+func (v *DiffWorkflowExecutionHistoryResponse) GetIdentical() (o bool) {
+	if v.Identical != nil {
+		return *v.Identical
+	}
+
+	return
+}
+
+// GetComparedEventCount returns the value of ComparedEventCount if it is set or its
+// zero value if it is unset.
+func (v *DiffWorkflowExecutionHistoryResponse) GetComparedEventCount() (o int64) {
+	if v.ComparedEventCount != nil {
+		return *v.ComparedEventCount
+	}
+
+	return
+}
+
+// GetDivergence returns the value of Divergence if it is set or its
+// zero value if it is unset.
+func (v *DiffWorkflowExecutionHistoryResponse) GetDivergence() (o *HistoryDivergence) {
+	if v.Divergence != nil {
+		return v.Divergence
+	}
+
+	return
+}
+
+type FailoverDrillTaskListStatus struct {
+	TaskList     *shared.TaskList     `json:"taskList,omitempty"`
+	TaskListType *shared.TaskListType `json:"taskListType,omitempty"`
+	PollerCount  *int32               `json:"pollerCount,omitempty"`
+}
+
+// ToWire translates a FailoverDrillTaskListStatus struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *FailoverDrillTaskListStatus) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.TaskList != nil {
+		w, err = v.TaskList.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.TaskListType != nil {
+		w, err = v.TaskListType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.PollerCount != nil {
+		w, err = wire.NewValueI32(*(v.PollerCount)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _TaskListType_Read(w wire.Value) (shared.TaskListType, error) {
+	var v shared.TaskListType
+	err := v.FromWire(w)
+	return v, err
+}
+
+// FromWire deserializes a FailoverDrillTaskListStatus struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a FailoverDrillTaskListStatus struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v FailoverDrillTaskListStatus
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *FailoverDrillTaskListStatus) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TStruct {
+				v.TaskList, err = _TaskList_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI32 {
+				var x shared.TaskListType
+				x, err = _TaskListType_Read(field.Value)
+				v.TaskListType = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.PollerCount = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a FailoverDrillTaskListStatus
+// struct.
+func (v *FailoverDrillTaskListStatus) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.TaskList != nil {
+		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
+		i++
+	}
+	if v.TaskListType != nil {
+		fields[i] = fmt.Sprintf("TaskListType: %v", *(v.TaskListType))
+		i++
+	}
+	if v.PollerCount != nil {
+		fields[i] = fmt.Sprintf("PollerCount: %v", *(v.PollerCount))
+		i++
+	}
+
+	return fmt.Sprintf("FailoverDrillTaskListStatus{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _TaskListType_EqualsPtr(lhs, rhs *shared.TaskListType) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this FailoverDrillTaskListStatus match the
+// provided FailoverDrillTaskListStatus.
+//
+// This function performs a deep comparison.
+func (v *FailoverDrillTaskListStatus) Equals(rhs *FailoverDrillTaskListStatus) bool {
+	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
+		return false
+	}
+	if !_TaskListType_EqualsPtr(v.TaskListType, rhs.TaskListType) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.PollerCount, rhs.PollerCount) {
+		return false
+	}
+
+	return true
+}
+
+// GetTaskList returns the value of TaskList if it is set or its
+// zero value if it is unset.
+func (v *FailoverDrillTaskListStatus) GetTaskList() (o *shared.TaskList) {
+	if v.TaskList != nil {
+		return v.TaskList
+	}
+
+	return
+}
+
+// GetTaskListType returns the value of TaskListType if it is set or its
+// zero value if it is unset.
+func (v *FailoverDrillTaskListStatus) GetTaskListType() (o shared.TaskListType) {
+	if v.TaskListType != nil {
+		return *v.TaskListType
+	}
+
+	return
+}
+
+// GetPollerCount returns the value of PollerCount if it is set or its
+// zero value if it is unset.
+func (v *FailoverDrillTaskListStatus) GetPollerCount() (o int32) {
+	if v.PollerCount != nil {
+		return *v.PollerCount
+	}
+
+	return
+}
+
+type FixWorkflowExecutionsRequest struct {
+	Issues []*WorkflowExecutionIssue `json:"issues,omitempty"`
+	DryRun *bool                     `json:"dryRun,omitempty"`
+}
+
+type _List_WorkflowExecutionIssue_ValueList []*WorkflowExecutionIssue
+
+func (v _List_WorkflowExecutionIssue_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_WorkflowExecutionIssue_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_WorkflowExecutionIssue_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_WorkflowExecutionIssue_ValueList) Close() {}
+
+// ToWire translates a FixWorkflowExecutionsRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *FixWorkflowExecutionsRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Issues != nil {
+		w, err = wire.NewValueList(_List_WorkflowExecutionIssue_ValueList(v.Issues)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.DryRun != nil {
+		w, err = wire.NewValueBool(*(v.DryRun)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _WorkflowExecutionIssue_Read(w wire.Value) (*WorkflowExecutionIssue, error) {
+	var v WorkflowExecutionIssue
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_WorkflowExecutionIssue_Read(l wire.ValueList) ([]*WorkflowExecutionIssue, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*WorkflowExecutionIssue, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _WorkflowExecutionIssue_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a FixWorkflowExecutionsRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a FixWorkflowExecutionsRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v FixWorkflowExecutionsRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *FixWorkflowExecutionsRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.Issues, err = _List_WorkflowExecutionIssue_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.DryRun = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a FixWorkflowExecutionsRequest
+// struct.
+func (v *FixWorkflowExecutionsRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Issues != nil {
+		fields[i] = fmt.Sprintf("Issues: %v", v.Issues)
+		i++
+	}
+	if v.DryRun != nil {
+		fields[i] = fmt.Sprintf("DryRun: %v", *(v.DryRun))
+		i++
+	}
+
+	return fmt.Sprintf("FixWorkflowExecutionsRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_WorkflowExecutionIssue_Equals(lhs, rhs []*WorkflowExecutionIssue) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this FixWorkflowExecutionsRequest match the
+// provided FixWorkflowExecutionsRequest.
+//
+// This function performs a deep comparison.
+func (v *FixWorkflowExecutionsRequest) Equals(rhs *FixWorkflowExecutionsRequest) bool {
+	if !((v.Issues == nil && rhs.Issues == nil) || (v.Issues != nil && rhs.Issues != nil && _List_WorkflowExecutionIssue_Equals(v.Issues, rhs.Issues))) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.DryRun, rhs.DryRun) {
+		return false
+	}
+
+	return true
+}
+
+// GetIssues returns the value of Issues if it is set or its
+// zero value if it is unset.
+func (v *FixWorkflowExecutionsRequest) GetIssues() (o []*WorkflowExecutionIssue) {
+	if v.Issues != nil {
+		return v.Issues
+	}
+
+	return
+}
+
+// GetDryRun returns the value of DryRun if it is set or its
+// zero value if it is unset.
+func (v *FixWorkflowExecutionsRequest) GetDryRun() (o bool) {
+	if v.DryRun != nil {
+		return *v.DryRun
+	}
+
+	return
+}
+
+type FixWorkflowExecutionsResponse struct {
+	Results []*WorkflowExecutionFixResult `json:"results,omitempty"`
+}
+
+type _List_WorkflowExecutionFixResult_ValueList []*WorkflowExecutionFixResult
+
+func (v _List_WorkflowExecutionFixResult_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_WorkflowExecutionFixResult_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_WorkflowExecutionFixResult_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_WorkflowExecutionFixResult_ValueList) Close() {}
+
+// ToWire translates a FixWorkflowExecutionsResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *FixWorkflowExecutionsResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Results != nil {
+		w, err = wire.NewValueList(_List_WorkflowExecutionFixResult_ValueList(v.Results)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _WorkflowExecutionFixResult_Read(w wire.Value) (*WorkflowExecutionFixResult, error) {
+	var v WorkflowExecutionFixResult
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_WorkflowExecutionFixResult_Read(l wire.ValueList) ([]*WorkflowExecutionFixResult, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*WorkflowExecutionFixResult, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _WorkflowExecutionFixResult_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a FixWorkflowExecutionsResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a FixWorkflowExecutionsResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v FixWorkflowExecutionsResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *FixWorkflowExecutionsResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.Results, err = _List_WorkflowExecutionFixResult_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a FixWorkflowExecutionsResponse
+// struct.
+func (v *FixWorkflowExecutionsResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Results != nil {
+		fields[i] = fmt.Sprintf("Results: %v", v.Results)
+		i++
+	}
+
+	return fmt.Sprintf("FixWorkflowExecutionsResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_WorkflowExecutionFixResult_Equals(lhs, rhs []*WorkflowExecutionFixResult) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this FixWorkflowExecutionsResponse match the
+// provided FixWorkflowExecutionsResponse.
+//
+// This function performs a deep comparison.
+func (v *FixWorkflowExecutionsResponse) Equals(rhs *FixWorkflowExecutionsResponse) bool {
+	if !((v.Results == nil && rhs.Results == nil) || (v.Results != nil && rhs.Results != nil && _List_WorkflowExecutionFixResult_Equals(v.Results, rhs.Results))) {
+		return false
+	}
+
+	return true
+}
+
+// GetResults returns the value of Results if it is set or its
+// zero value if it is unset.
+func (v *FixWorkflowExecutionsResponse) GetResults() (o []*WorkflowExecutionFixResult) {
+	if v.Results != nil {
+		return v.Results
+	}
+
+	return
+}
+
+type GetWorkflowExecutionHistoryBatchesRequest struct {
+	Domain          *string                   `json:"domain,omitempty"`
+	Execution       *shared.WorkflowExecution `json:"execution,omitempty"`
+	MaximumPageSize *int32                    `json:"maximumPageSize,omitempty"`
+	NextPageToken   []byte                    `json:"nextPageToken,omitempty"`
+}
+
+// ToWire translates a GetWorkflowExecutionHistoryBatchesRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *GetWorkflowExecutionHistoryBatchesRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.MaximumPageSize != nil {
+		w, err = wire.NewValueI32(*(v.MaximumPageSize)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a GetWorkflowExecutionHistoryBatchesRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a GetWorkflowExecutionHistoryBatchesRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v GetWorkflowExecutionHistoryBatchesRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *GetWorkflowExecutionHistoryBatchesRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.MaximumPageSize = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a GetWorkflowExecutionHistoryBatchesRequest
+// struct.
+func (v *GetWorkflowExecutionHistoryBatchesRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+	if v.MaximumPageSize != nil {
+		fields[i] = fmt.Sprintf("MaximumPageSize: %v", *(v.MaximumPageSize))
+		i++
+	}
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+		i++
+	}
+
+	return fmt.Sprintf("GetWorkflowExecutionHistoryBatchesRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this GetWorkflowExecutionHistoryBatchesRequest match the
+// provided GetWorkflowExecutionHistoryBatchesRequest.
+//
+// This function performs a deep comparison.
+func (v *GetWorkflowExecutionHistoryBatchesRequest) Equals(rhs *GetWorkflowExecutionHistoryBatchesRequest) bool {
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.MaximumPageSize, rhs.MaximumPageSize) {
+		return false
+	}
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionHistoryBatchesRequest) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// GetExecution returns the value of Execution if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionHistoryBatchesRequest) GetExecution() (o *shared.WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
+	}
+
+	return
+}
+
+// GetMaximumPageSize returns the value of MaximumPageSize if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionHistoryBatchesRequest) GetMaximumPageSize() (o int32) {
+	if v.MaximumPageSize != nil {
+		return *v.MaximumPageSize
+	}
+
+	return
+}
+
+// GetNextPageToken returns the value of NextPageToken if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionHistoryBatchesRequest) GetNextPageToken() (o []byte) {
+	if v.NextPageToken != nil {
+		return v.NextPageToken
+	}
+
+	return
+}
+
+type GetWorkflowExecutionHistoryBatchesResponse struct {
+	HistoryBatches []*shared.History `json:"historyBatches,omitempty"`
+	NextPageToken  []byte            `json:"nextPageToken,omitempty"`
+}
+
+type _List_History_ValueList []*shared.History
+
+func (v _List_History_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_History_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_History_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_History_ValueList) Close() {}
+
+// ToWire translates a GetWorkflowExecutionHistoryBatchesResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *GetWorkflowExecutionHistoryBatchesResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.HistoryBatches != nil {
+		w, err = wire.NewValueList(_List_History_ValueList(v.HistoryBatches)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _History_Read(w wire.Value) (*shared.History, error) {
+	var v shared.History
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_History_Read(l wire.ValueList) ([]*shared.History, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*shared.History, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _History_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a GetWorkflowExecutionHistoryBatchesResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a GetWorkflowExecutionHistoryBatchesResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v GetWorkflowExecutionHistoryBatchesResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *GetWorkflowExecutionHistoryBatchesResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.HistoryBatches, err = _List_History_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a GetWorkflowExecutionHistoryBatchesResponse
+// struct.
+func (v *GetWorkflowExecutionHistoryBatchesResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.HistoryBatches != nil {
+		fields[i] = fmt.Sprintf("HistoryBatches: %v", v.HistoryBatches)
+		i++
+	}
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+		i++
+	}
+
+	return fmt.Sprintf("GetWorkflowExecutionHistoryBatchesResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_History_Equals(lhs, rhs []*shared.History) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this GetWorkflowExecutionHistoryBatchesResponse match the
+// provided GetWorkflowExecutionHistoryBatchesResponse.
+//
+// This function performs a deep comparison.
+func (v *GetWorkflowExecutionHistoryBatchesResponse) Equals(rhs *GetWorkflowExecutionHistoryBatchesResponse) bool {
+	if !((v.HistoryBatches == nil && rhs.HistoryBatches == nil) || (v.HistoryBatches != nil && rhs.HistoryBatches != nil && _List_History_Equals(v.HistoryBatches, rhs.HistoryBatches))) {
+		return false
+	}
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+		return false
+	}
+
+	return true
+}
+
+// GetHistoryBatches returns the value of HistoryBatches if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionHistoryBatchesResponse) GetHistoryBatches() (o []*shared.History) {
+	if v.HistoryBatches != nil {
+		return v.HistoryBatches
+	}
+
+	return
+}
+
+// GetNextPageToken returns the value of NextPageToken if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionHistoryBatchesResponse) GetNextPageToken() (o []byte) {
+	if v.NextPageToken != nil {
+		return v.NextPageToken
+	}
+
+	return
+}
+
+type HistoryDivergence struct {
+	BatchIndex      *int32            `json:"batchIndex,omitempty"`
+	EventId         *int64            `json:"eventId,omitempty"`
+	LocalEventId    *int64            `json:"localEventId,omitempty"`
+	RemoteEventId   *int64            `json:"remoteEventId,omitempty"`
+	LocalVersion    *int64            `json:"localVersion,omitempty"`
+	RemoteVersion   *int64            `json:"remoteVersion,omitempty"`
+	LocalEventType  *shared.EventType `json:"localEventType,omitempty"`
+	RemoteEventType *shared.EventType `json:"remoteEventType,omitempty"`
+	Reason          *string           `json:"reason,omitempty"`
+}
+
+// ToWire translates a HistoryDivergence struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *HistoryDivergence) ToWire() (wire.Value, error) {
+	var (
+		fields [9]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.BatchIndex != nil {
+		w, err = wire.NewValueI32(*(v.BatchIndex)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.EventId != nil {
+		w, err = wire.NewValueI64(*(v.EventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.LocalEventId != nil {
+		w, err = wire.NewValueI64(*(v.LocalEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.RemoteEventId != nil {
+		w, err = wire.NewValueI64(*(v.RemoteEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.LocalVersion != nil {
+		w, err = wire.NewValueI64(*(v.LocalVersion)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.RemoteVersion != nil {
+		w, err = wire.NewValueI64(*(v.RemoteVersion)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.LocalEventType != nil {
+		w, err = v.LocalEventType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+	if v.RemoteEventType != nil {
+		w, err = v.RemoteEventType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
+	if v.Reason != nil {
+		w, err = wire.NewValueString(*(v.Reason)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 90, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _EventType_Read(w wire.Value) (shared.EventType, error) {
+	var v shared.EventType
+	err := v.FromWire(w)
+	return v, err
+}
+
+// FromWire deserializes a HistoryDivergence struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a HistoryDivergence struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v HistoryDivergence
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *HistoryDivergence) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.BatchIndex = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.EventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LocalEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.RemoteEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LocalVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.RemoteVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TI32 {
+				var x shared.EventType
+				x, err = _EventType_Read(field.Value)
+				v.LocalEventType = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 80:
+			if field.Value.Type() == wire.TI32 {
+				var x shared.EventType
+				x, err = _EventType_Read(field.Value)
+				v.RemoteEventType = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 90:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Reason = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a HistoryDivergence
+// struct.
+func (v *HistoryDivergence) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [9]string
+	i := 0
+	if v.BatchIndex != nil {
+		fields[i] = fmt.Sprintf("BatchIndex: %v", *(v.BatchIndex))
+		i++
+	}
+	if v.EventId != nil {
+		fields[i] = fmt.Sprintf("EventId: %v", *(v.EventId))
+		i++
+	}
+	if v.LocalEventId != nil {
+		fields[i] = fmt.Sprintf("LocalEventId: %v", *(v.LocalEventId))
+		i++
+	}
+	if v.RemoteEventId != nil {
+		fields[i] = fmt.Sprintf("RemoteEventId: %v", *(v.RemoteEventId))
+		i++
+	}
+	if v.LocalVersion != nil {
+		fields[i] = fmt.Sprintf("LocalVersion: %v", *(v.LocalVersion))
+		i++
+	}
+	if v.RemoteVersion != nil {
+		fields[i] = fmt.Sprintf("RemoteVersion: %v", *(v.RemoteVersion))
+		i++
+	}
+	if v.LocalEventType != nil {
+		fields[i] = fmt.Sprintf("LocalEventType: %v", *(v.LocalEventType))
+		i++
+	}
+	if v.RemoteEventType != nil {
+		fields[i] = fmt.Sprintf("RemoteEventType: %v", *(v.RemoteEventType))
+		i++
+	}
+	if v.Reason != nil {
+		fields[i] = fmt.Sprintf("Reason: %v", *(v.Reason))
+		i++
+	}
+
+	return fmt.Sprintf("HistoryDivergence{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _EventType_EqualsPtr(lhs, rhs *shared.EventType) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this HistoryDivergence match the
+// provided HistoryDivergence.
+//
+// This function performs a deep comparison.
+func (v *HistoryDivergence) Equals(rhs *HistoryDivergence) bool {
+	if !_I32_EqualsPtr(v.BatchIndex, rhs.BatchIndex) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.EventId, rhs.EventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.LocalEventId, rhs.LocalEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.RemoteEventId, rhs.RemoteEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.LocalVersion, rhs.LocalVersion) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.RemoteVersion, rhs.RemoteVersion) {
+		return false
+	}
+	if !_EventType_EqualsPtr(v.LocalEventType, rhs.LocalEventType) {
+		return false
+	}
+	if !_EventType_EqualsPtr(v.RemoteEventType, rhs.RemoteEventType) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Reason, rhs.Reason) {
+		return false
+	}
+
+	return true
+}
+
+// GetBatchIndex returns the value of BatchIndex if it is set or its
+// zero value if it is unset.
+func (v *HistoryDivergence) GetBatchIndex() (o int32) {
+	if v.BatchIndex != nil {
+		return *v.BatchIndex
+	}
+
+	return
+}
+
+// GetEventId returns the value of EventId if it is set or its
+// zero value if it is unset.
+func (v *HistoryDivergence) GetEventId() (o int64) {
+	if v.EventId != nil {
+		return *v.EventId
+	}
+
+	return
+}
+
+// GetLocalEventId returns the value of LocalEventId if it is set or its
+// zero value if it is unset.
+func (v *HistoryDivergence) GetLocalEventId() (o int64) {
+	if v.LocalEventId != nil {
+		return *v.LocalEventId
+	}
+
+	return
+}
+
+// GetRemoteEventId returns the value of RemoteEventId if it is set or its
+// zero value if it is unset.
+func (v *HistoryDivergence) GetRemoteEventId() (o int64) {
+	if v.RemoteEventId != nil {
+		return *v.RemoteEventId
+	}
+
+	return
+}
+
+// GetLocalVersion returns the value of LocalVersion if it is set or its
+// zero value if it is unset.
+func (v *HistoryDivergence) GetLocalVersion() (o int64) {
+	if v.LocalVersion != nil {
+		return *v.LocalVersion
+	}
+
+	return
+}
+
+// GetRemoteVersion returns the value of RemoteVersion if it is set or its
+// zero value if it is unset.
+func (v *HistoryDivergence) GetRemoteVersion() (o int64) {
+	if v.RemoteVersion != nil {
+		return *v.RemoteVersion
+	}
+
+	return
+}
+
+// GetLocalEventType returns the value of LocalEventType if it is set or its
+// zero value if it is unset.
+func (v *HistoryDivergence) GetLocalEventType() (o shared.EventType) {
+	if v.LocalEventType != nil {
+		return *v.LocalEventType
+	}
+
+	return
+}
+
+// GetRemoteEventType returns the value of RemoteEventType if it is set or its
+// zero value if it is unset.
+func (v *HistoryDivergence) GetRemoteEventType() (o shared.EventType) {
+	if v.RemoteEventType != nil {
+		return *v.RemoteEventType
+	}
+
+	return
+}
+
+// GetReason returns the value of Reason if it is set or its
+// zero value if it is unset.
+func (v *HistoryDivergence) GetReason() (o string) {
+	if v.Reason != nil {
+		return *v.Reason
+	}
+
+	return
+}
+
+type ListPostCloseEventsRequest struct {
+	Domain          *string                   `json:"domain,omitempty"`
+	Execution       *shared.WorkflowExecution `json:"execution,omitempty"`
+	MaximumPageSize *int32                    `json:"maximumPageSize,omitempty"`
+	NextPageToken   []byte                    `json:"nextPageToken,omitempty"`
+}
+
+// ToWire translates a ListPostCloseEventsRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ListPostCloseEventsRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.MaximumPageSize != nil {
+		w, err = wire.NewValueI32(*(v.MaximumPageSize)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a ListPostCloseEventsRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ListPostCloseEventsRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ListPostCloseEventsRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ListPostCloseEventsRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.MaximumPageSize = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ListPostCloseEventsRequest
+// struct.
+func (v *ListPostCloseEventsRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+	if v.MaximumPageSize != nil {
+		fields[i] = fmt.Sprintf("MaximumPageSize: %v", *(v.MaximumPageSize))
+		i++
+	}
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+		i++
+	}
+
+	return fmt.Sprintf("ListPostCloseEventsRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this ListPostCloseEventsRequest match the
+// provided ListPostCloseEventsRequest.
+//
+// This function performs a deep comparison.
+func (v *ListPostCloseEventsRequest) Equals(rhs *ListPostCloseEventsRequest) bool {
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.MaximumPageSize, rhs.MaximumPageSize) {
+		return false
+	}
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *ListPostCloseEventsRequest) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// GetExecution returns the value of Execution if it is set or its
+// zero value if it is unset.
+func (v *ListPostCloseEventsRequest) GetExecution() (o *shared.WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
+	}
+
+	return
+}
+
+// GetMaximumPageSize returns the value of MaximumPageSize if it is set or its
+// zero value if it is unset.
+func (v *ListPostCloseEventsRequest) GetMaximumPageSize() (o int32) {
+	if v.MaximumPageSize != nil {
+		return *v.MaximumPageSize
+	}
+
+	return
+}
+
+// GetNextPageToken returns the value of NextPageToken if it is set or its
+// zero value if it is unset.
+func (v *ListPostCloseEventsRequest) GetNextPageToken() (o []byte) {
+	if v.NextPageToken != nil {
+		return v.NextPageToken
+	}
+
+	return
+}
+
+type ListPostCloseEventsResponse struct {
+	Batches       []*PostCloseEventBatch `json:"batches,omitempty"`
+	NextPageToken []byte                 `json:"nextPageToken,omitempty"`
+}
+
+type _List_PostCloseEventBatch_ValueList []*PostCloseEventBatch
+
+func (v _List_PostCloseEventBatch_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_PostCloseEventBatch_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_PostCloseEventBatch_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_PostCloseEventBatch_ValueList) Close() {}
+
+// ToWire translates a ListPostCloseEventsResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ListPostCloseEventsResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Batches != nil {
+		w, err = wire.NewValueList(_List_PostCloseEventBatch_ValueList(v.Batches)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _PostCloseEventBatch_Read(w wire.Value) (*PostCloseEventBatch, error) {
+	var v PostCloseEventBatch
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_PostCloseEventBatch_Read(l wire.ValueList) ([]*PostCloseEventBatch, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*PostCloseEventBatch, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _PostCloseEventBatch_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a ListPostCloseEventsResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ListPostCloseEventsResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ListPostCloseEventsResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ListPostCloseEventsResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.Batches, err = _List_PostCloseEventBatch_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ListPostCloseEventsResponse
+// struct.
+func (v *ListPostCloseEventsResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Batches != nil {
+		fields[i] = fmt.Sprintf("Batches: %v", v.Batches)
+		i++
+	}
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+		i++
+	}
+
+	return fmt.Sprintf("ListPostCloseEventsResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_PostCloseEventBatch_Equals(lhs, rhs []*PostCloseEventBatch) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this ListPostCloseEventsResponse match the
+// provided ListPostCloseEventsResponse.
+//
+// This function performs a deep comparison.
+func (v *ListPostCloseEventsResponse) Equals(rhs *ListPostCloseEventsResponse) bool {
+	if !((v.Batches == nil && rhs.Batches == nil) || (v.Batches != nil && rhs.Batches != nil && _List_PostCloseEventBatch_Equals(v.Batches, rhs.Batches))) {
+		return false
+	}
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+		return false
+	}
+
+	return true
+}
+
+// GetBatches returns the value of Batches if it is set or its
+// zero value if it is unset.
+func (v *ListPostCloseEventsResponse) GetBatches() (o []*PostCloseEventBatch) {
+	if v.Batches != nil {
+		return v.Batches
+	}
+
+	return
+}
+
+// GetNextPageToken returns the value of NextPageToken if it is set or its
+// zero value if it is unset.
+func (v *ListPostCloseEventsResponse) GetNextPageToken() (o []byte) {
+	if v.NextPageToken != nil {
+		return v.NextPageToken
+	}
+
+	return
+}
+
+type PostCloseEventBatch struct {
+	SourceCluster     *string         `json:"sourceCluster,omitempty"`
+	RecordedTimestamp *int64          `json:"recordedTimestamp,omitempty"`
+	History           *shared.History `json:"history,omitempty"`
+}
+
+// ToWire translates a PostCloseEventBatch struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *PostCloseEventBatch) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.SourceCluster != nil {
+		w, err = wire.NewValueString(*(v.SourceCluster)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.RecordedTimestamp != nil {
+		w, err = wire.NewValueI64(*(v.RecordedTimestamp)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.History != nil {
+		w, err = v.History.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a PostCloseEventBatch struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a PostCloseEventBatch struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v PostCloseEventBatch
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *PostCloseEventBatch) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.SourceCluster = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.RecordedTimestamp = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.History, err = _History_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a PostCloseEventBatch
+// struct.
+func (v *PostCloseEventBatch) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.SourceCluster != nil {
+		fields[i] = fmt.Sprintf("SourceCluster: %v", *(v.SourceCluster))
+		i++
+	}
+	if v.RecordedTimestamp != nil {
+		fields[i] = fmt.Sprintf("RecordedTimestamp: %v", *(v.RecordedTimestamp))
+		i++
+	}
+	if v.History != nil {
+		fields[i] = fmt.Sprintf("History: %v", v.History)
+		i++
+	}
+
+	return fmt.Sprintf("PostCloseEventBatch{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this PostCloseEventBatch match the
+// provided PostCloseEventBatch.
+//
+// This function performs a deep comparison.
+func (v *PostCloseEventBatch) Equals(rhs *PostCloseEventBatch) bool {
+	if !_String_EqualsPtr(v.SourceCluster, rhs.SourceCluster) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.RecordedTimestamp, rhs.RecordedTimestamp) {
+		return false
+	}
+	if !((v.History == nil && rhs.History == nil) || (v.History != nil && rhs.History != nil && v.History.Equals(rhs.History))) {
+		return false
+	}
+
+	return true
+}
+
+// GetSourceCluster returns the value of SourceCluster if it is set or its
+// zero value if it is unset.
+func (v *PostCloseEventBatch) GetSourceCluster() (o string) {
+	if v.SourceCluster != nil {
+		return *v.SourceCluster
+	}
+
+	return
+}
+
+// GetRecordedTimestamp returns the value of RecordedTimestamp if it is set or its
+// zero value if it is unset.
+func (v *PostCloseEventBatch) GetRecordedTimestamp() (o int64) {
+	if v.RecordedTimestamp != nil {
+		return *v.RecordedTimestamp
+	}
+
+	return
+}
+
+// GetHistory returns the value of History if it is set or its
+// zero value if it is unset.
+func (v *PostCloseEventBatch) GetHistory() (o *shared.History) {
+	if v.History != nil {
+		return v.History
+	}
+
+	return
+}
+
+type ReplicationDLQSummary struct {
+	ShardId                *int32  `json:"shardId,omitempty"`
+	SourceCluster          *string `json:"sourceCluster,omitempty"`
+	MessageCount           *int64  `json:"messageCount,omitempty"`
+	OldestMessageTimestamp *int64  `json:"oldestMessageTimestamp,omitempty"`
+}
+
+// ToWire translates a ReplicationDLQSummary struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ReplicationDLQSummary) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ShardId != nil {
+		w, err = wire.NewValueI32(*(v.ShardId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.SourceCluster != nil {
+		w, err = wire.NewValueString(*(v.SourceCluster)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.MessageCount != nil {
+		w, err = wire.NewValueI64(*(v.MessageCount)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.OldestMessageTimestamp != nil {
+		w, err = wire.NewValueI64(*(v.OldestMessageTimestamp)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a ReplicationDLQSummary struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ReplicationDLQSummary struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ReplicationDLQSummary
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ReplicationDLQSummary) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ShardId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.SourceCluster = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.MessageCount = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.OldestMessageTimestamp = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ReplicationDLQSummary
+// struct.
+func (v *ReplicationDLQSummary) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.ShardId != nil {
+		fields[i] = fmt.Sprintf("ShardId: %v", *(v.ShardId))
+		i++
+	}
+	if v.SourceCluster != nil {
+		fields[i] = fmt.Sprintf("SourceCluster: %v", *(v.SourceCluster))
+		i++
+	}
+	if v.MessageCount != nil {
+		fields[i] = fmt.Sprintf("MessageCount: %v", *(v.MessageCount))
+		i++
+	}
+	if v.OldestMessageTimestamp != nil {
+		fields[i] = fmt.Sprintf("OldestMessageTimestamp: %v", *(v.OldestMessageTimestamp))
+		i++
+	}
+
+	return fmt.Sprintf("ReplicationDLQSummary{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this ReplicationDLQSummary match the
+// provided ReplicationDLQSummary.
+//
+// This function performs a deep comparison.
+func (v *ReplicationDLQSummary) Equals(rhs *ReplicationDLQSummary) bool {
+	if !_I32_EqualsPtr(v.ShardId, rhs.ShardId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.SourceCluster, rhs.SourceCluster) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.MessageCount, rhs.MessageCount) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.OldestMessageTimestamp, rhs.OldestMessageTimestamp) {
+		return false
+	}
+
+	return true
+}
+
+// GetShardId returns the value of ShardId if it is set or its
+// zero value if it is unset.
+func (v *ReplicationDLQSummary) GetShardId() (o int32) {
+	if v.ShardId != nil {
+		return *v.ShardId
+	}
+
+	return
+}
+
+// GetSourceCluster returns the value of SourceCluster if it is set or its
+// zero value if it is unset.
+func (v *ReplicationDLQSummary) GetSourceCluster() (o string) {
+	if v.SourceCluster != nil {
+		return *v.SourceCluster
+	}
+
+	return
+}
+
+// GetMessageCount returns the value of MessageCount if it is set or its
+// zero value if it is unset.
+func (v *ReplicationDLQSummary) GetMessageCount() (o int64) {
+	if v.MessageCount != nil {
+		return *v.MessageCount
+	}
+
+	return
+}
+
+// GetOldestMessageTimestamp returns the value of OldestMessageTimestamp if it is set or its
+// zero value if it is unset.
+func (v *ReplicationDLQSummary) GetOldestMessageTimestamp() (o int64) {
+	if v.OldestMessageTimestamp != nil {
+		return *v.OldestMessageTimestamp
+	}
+
+	return
+}
+
+type WorkflowExecutionFixResult struct {
+	Issue  *WorkflowExecutionIssue `json:"issue,omitempty"`
+	Fixed  *bool                   `json:"fixed,omitempty"`
+	Action *string                 `json:"action,omitempty"`
+	Error  *string                 `json:"error,omitempty"`
+}
+
+// ToWire translates a WorkflowExecutionFixResult struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *WorkflowExecutionFixResult) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Issue != nil {
+		w, err = v.Issue.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Fixed != nil {
+		w, err = wire.NewValueBool(*(v.Fixed)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Action != nil {
+		w, err = wire.NewValueString(*(v.Action)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Error != nil {
+		w, err = wire.NewValueString(*(v.Error)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a WorkflowExecutionFixResult struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a WorkflowExecutionFixResult struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v WorkflowExecutionFixResult
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *WorkflowExecutionFixResult) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TStruct {
+				v.Issue, err = _WorkflowExecutionIssue_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Fixed = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Action = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Error = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a WorkflowExecutionFixResult
+// struct.
+func (v *WorkflowExecutionFixResult) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.Issue != nil {
+		fields[i] = fmt.Sprintf("Issue: %v", v.Issue)
+		i++
+	}
+	if v.Fixed != nil {
+		fields[i] = fmt.Sprintf("Fixed: %v", *(v.Fixed))
+		i++
+	}
+	if v.Action != nil {
+		fields[i] = fmt.Sprintf("Action: %v", *(v.Action))
+		i++
+	}
+	if v.Error != nil {
+		fields[i] = fmt.Sprintf("Error: %v", *(v.Error))
+		i++
+	}
+
+	return fmt.Sprintf("WorkflowExecutionFixResult{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this WorkflowExecutionFixResult match the
+// provided WorkflowExecutionFixResult.
+//
+// This function performs a deep comparison.
+func (v *WorkflowExecutionFixResult) Equals(rhs *WorkflowExecutionFixResult) bool {
+	if !((v.Issue == nil && rhs.Issue == nil) || (v.Issue != nil && rhs.Issue != nil && v.Issue.Equals(rhs.Issue))) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.Fixed, rhs.Fixed) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Action, rhs.Action) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Error, rhs.Error) {
+		return false
+	}
+
+	return true
+}
+
+// GetIssue returns the value of Issue if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionFixResult) GetIssue() (o *WorkflowExecutionIssue) {
+	if v.Issue != nil {
+		return v.Issue
+	}
+
+	return
+}
+
+// GetFixed returns the value of Fixed if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionFixResult) GetFixed() (o bool) {
+	if v.Fixed != nil {
+		return *v.Fixed
+	}
+
+	return
+}
+
+// GetAction returns the value of Action if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionFixResult) GetAction() (o string) {
+	if v.Action != nil {
+		return *v.Action
+	}
+
+	return
+}
+
+// GetError returns the value of Error if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionFixResult) GetError() (o string) {
+	if v.Error != nil {
+		return *v.Error
+	}
+
+	return
+}
+
+type WorkflowExecutionIssue struct {
+	Domain    *string                            `json:"domain,omitempty"`
+	Execution *shared.WorkflowExecution          `json:"execution,omitempty"`
+	IssueType *shared.WorkflowExecutionIssueType `json:"issueType,omitempty"`
+}
+
+// ToWire translates a WorkflowExecutionIssue struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *WorkflowExecutionIssue) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.IssueType != nil {
+		w, err = v.IssueType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _WorkflowExecutionIssueType_Read(w wire.Value) (shared.WorkflowExecutionIssueType, error) {
+	var v shared.WorkflowExecutionIssueType
+	err := v.FromWire(w)
+	return v, err
+}
+
+// FromWire deserializes a WorkflowExecutionIssue struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a WorkflowExecutionIssue struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v WorkflowExecutionIssue
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *WorkflowExecutionIssue) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI32 {
+				var x shared.WorkflowExecutionIssueType
+				x, err = _WorkflowExecutionIssueType_Read(field.Value)
+				v.IssueType = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a WorkflowExecutionIssue
+// struct.
+func (v *WorkflowExecutionIssue) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+	if v.IssueType != nil {
+		fields[i] = fmt.Sprintf("IssueType: %v", *(v.IssueType))
+		i++
+	}
+
+	return fmt.Sprintf("WorkflowExecutionIssue{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _WorkflowExecutionIssueType_EqualsPtr(lhs, rhs *shared.WorkflowExecutionIssueType) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this WorkflowExecutionIssue match the
+// provided WorkflowExecutionIssue.
+//
+// This function performs a deep comparison.
+func (v *WorkflowExecutionIssue) Equals(rhs *WorkflowExecutionIssue) bool {
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+	if !_WorkflowExecutionIssueType_EqualsPtr(v.IssueType, rhs.IssueType) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionIssue) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// GetExecution returns the value of Execution if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionIssue) GetExecution() (o *shared.WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
+	}
+
+	return
+}
+
+// GetIssueType returns the value of IssueType if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionIssue) GetIssueType() (o shared.WorkflowExecutionIssueType) {
+	if v.IssueType != nil {
+		return *v.IssueType
 	}
 
 	return
@@ -388,12 +388,6 @@ func _GetWorkflowExecutionHistoryResponse_Read(w wire.Value) (*shared.GetWorkflo
 	return &v, err
 }
 
-func _ServiceBusyError_Read(w wire.Value) (*shared.ServiceBusyError, error) {
-	var v shared.ServiceBusyError
-	err := v.FromWire(w)
-	return &v, err
-}
-
 // FromWire deserializes a WorkflowService_GetWorkflowExecutionHistory_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -428,18 +428,6 @@ func _DescribeMutableStateResponse_Read(w wire.Value) (*DescribeMutableStateResp
 	return &v, err
 }
 
-func _EntityNotExistsError_Read(w wire.Value) (*shared.EntityNotExistsError, error) {
-	var v shared.EntityNotExistsError
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _ShardOwnershipLostError_Read(w wire.Value) (*ShardOwnershipLostError, error) {
-	var v ShardOwnershipLostError
-	err := v.FromWire(w)
-	return &v, err
-}
-
 func _LimitExceededError_Read(w wire.Value) (*shared.LimitExceededError, error) {
 	var v shared.LimitExceededError
 	err := v.FromWire(w)
@@ -235,6 +235,8 @@ func init() {
 			return true
 		case *shared.LimitExceededError:
 			return true
+		case *shared.ServiceBusyError:
+			return true
 		default:
 			return false
 		}
@@ -271,6 +273,11 @@ func init() {
 				return nil, errors.New("WrapResponse received non-nil error type with nil value for HistoryService_GetMutableState_Result.LimitExceededError")
 			}
 			return &HistoryService_GetMutableState_Result{LimitExceededError: e}, nil
+		case *shared.ServiceBusyError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for HistoryService_GetMutableState_Result.ServiceBusyError")
+			}
+			return &HistoryService_GetMutableState_Result{ServiceBusyError: e}, nil
 		}
 
 		return nil, err
@@ -296,6 +303,10 @@ func init() {
 			err = result.LimitExceededError
 			return
 		}
+		if result.ServiceBusyError != nil {
+			err = result.ServiceBusyError
+			return
+		}
 
 		if result.Success != nil {
 			success = result.Success
@@ -321,6 +332,7 @@ type HistoryService_GetMutableState_Result struct {
 	EntityNotExistError     *shared.EntityNotExistsError `json:"entityNotExistError,omitempty"`
 	ShardOwnershipLostError *ShardOwnershipLostError     `json:"shardOwnershipLostError,omitempty"`
 	LimitExceededError      *shared.LimitExceededError   `json:"limitExceededError,omitempty"`
+	ServiceBusyError        *shared.ServiceBusyError     `json:"serviceBusyError,omitempty"`
 }
 
 // ToWire translates a HistoryService_GetMutableState_Result struct into a Thrift-level intermediate
@@ -340,7 +352,7 @@ type HistoryService_GetMutableState_Result struct {
 //   }
 func (v *HistoryService_GetMutableState_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -394,6 +406,14 @@ func (v *HistoryService_GetMutableState_Result) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 5, Value: w}
 		i++
 	}
+	if v.ServiceBusyError != nil {
+		w, err = v.ServiceBusyError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
+	}
 
 	if i != 1 {
 		return wire.Value{}, fmt.Errorf("HistoryService_GetMutableState_Result should have exactly one field: got %v fields", i)
@@ -402,12 +422,6 @@ func (v *HistoryService_GetMutableState_Result) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _GetMutableStateResponse_Read(w wire.Value) (*GetMutableStateResponse, error) {
-	var v GetMutableStateResponse
-	err := v.FromWire(w)
-	return &v, err
-}
-
 // FromWire deserializes a HistoryService_GetMutableState_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -477,6 +491,14 @@ func (v *HistoryService_GetMutableState_Result) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 6:
+			if field.Value.Type() == wire.TStruct {
+				v.ServiceBusyError, err = _ServiceBusyError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -500,6 +522,9 @@ func (v *HistoryService_GetMutableState_Result) FromWire(w wire.Value) error {
 	if v.LimitExceededError != nil {
 		count++
 	}
+	if v.ServiceBusyError != nil {
+		count++
+	}
 	if count != 1 {
 		return fmt.Errorf("HistoryService_GetMutableState_Result should have exactly one field: got %v fields", count)
 	}
@@ -514,7 +539,7 @@ func (v *HistoryService_GetMutableState_Result) String() string {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [7]string
 	i := 0
 	if v.Success != nil {
 		fields[i] = fmt.Sprintf("Success: %v", v.Success)
@@ -540,6 +565,10 @@ func (v *HistoryService_GetMutableState_Result) String() string {
 		fields[i] = fmt.Sprintf("LimitExceededError: %v", v.LimitExceededError)
 		i++
 	}
+	if v.ServiceBusyError != nil {
+		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
+		i++
+	}
 
 	return fmt.Sprintf("HistoryService_GetMutableState_Result{%v}", strings.Join(fields[:i], ", "))
 }
@@ -567,6 +596,9 @@ func (v *HistoryService_GetMutableState_Result) Equals(rhs *HistoryService_GetMu
 	if !((v.LimitExceededError == nil && rhs.LimitExceededError == nil) || (v.LimitExceededError != nil && rhs.LimitExceededError != nil && v.LimitExceededError.Equals(rhs.LimitExceededError))) {
 		return false
 	}
+	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
+		return false
+	}
 
 	return true
 }
@@ -631,6 +663,16 @@ func (v *HistoryService_GetMutableState_Result) GetLimitExceededError() (o *shar
 	return
 }
 
+// GetServiceBusyError returns the value of ServiceBusyError if it is set or its
+// zero value if it is unset.
+func (v *HistoryService_GetMutableState_Result) GetServiceBusyError() (o *shared.ServiceBusyError) {
+	if v.ServiceBusyError != nil {
+		return v.ServiceBusyError
+	}
+
+	return
+}
+
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
@@ -442,12 +442,6 @@ func (v *HistoryService_RecordActivityTaskStarted_Result) ToWire() (wire.Value,
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _RecordActivityTaskStartedResponse_Read(w wire.Value) (*RecordActivityTaskStartedResponse, error) {
-	var v RecordActivityTaskStartedResponse
-	err := v.FromWire(w)
-	return &v, err
-}
-
 func _EventAlreadyStartedError_Read(w wire.Value) (*EventAlreadyStartedError, error) {
 	var v EventAlreadyStartedError
 	err := v.FromWire(w)
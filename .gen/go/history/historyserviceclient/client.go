@@ -36,6 +36,12 @@ import (
 
 // Interface is a client for the HistoryService service.
 type Interface interface {
+	DeleteWorkflowExecution(
+		ctx context.Context,
+		DeleteRequest *history.DeleteWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	DescribeHistoryHost(
 		ctx context.Context,
 		Request *shared.DescribeHistoryHostRequest,
@@ -48,18 +54,48 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*history.DescribeMutableStateResponse, error)
 
+	DescribeShardBacklogs(
+		ctx context.Context,
+		Request *shared.DescribeShardBacklogsRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeShardBacklogsResponse, error)
+
+	DescribeShardOperations(
+		ctx context.Context,
+		Request *shared.DescribeShardOperationsRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeShardOperationsResponse, error)
+
 	DescribeWorkflowExecution(
 		ctx context.Context,
 		DescribeRequest *history.DescribeWorkflowExecutionRequest,
 		opts ...yarpc.CallOption,
 	) (*shared.DescribeWorkflowExecutionResponse, error)
 
+	FixWorkflowExecution(
+		ctx context.Context,
+		Request *history.FixWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) (*history.FixWorkflowExecutionResponse, error)
+
 	GetMutableState(
 		ctx context.Context,
 		GetRequest *history.GetMutableStateRequest,
 		opts ...yarpc.CallOption,
 	) (*history.GetMutableStateResponse, error)
 
+	GetMutableStates(
+		ctx context.Context,
+		GetRequest *history.GetMutableStatesRequest,
+		opts ...yarpc.CallOption,
+	) (*history.GetMutableStatesResponse, error)
+
+	RatelimitUpdate(
+		ctx context.Context,
+		Request *history.RatelimitUpdateRequest,
+		opts ...yarpc.CallOption,
+	) (*history.RatelimitUpdateResponse, error)
+
 	RecordActivityTaskHeartbeat(
 		ctx context.Context,
 		HeartbeatRequest *history.RecordActivityTaskHeartbeatRequest,
@@ -84,6 +120,12 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*history.RecordDecisionTaskStartedResponse, error)
 
+	RefreshDomainCache(
+		ctx context.Context,
+		Request *shared.RefreshDomainCacheRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	RemoveSignalMutableState(
 		ctx context.Context,
 		RemoveRequest *history.RemoveSignalMutableStateRequest,
@@ -162,6 +204,12 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*shared.StartWorkflowExecutionResponse, error)
 
+	SyncActivity(
+		ctx context.Context,
+		SyncActivityRequest *history.SyncActivityRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	SyncShardStatus(
 		ctx context.Context,
 		SyncShardStatusRequest *history.SyncShardStatusRequest,
@@ -173,6 +221,18 @@ type Interface interface {
 		TerminateRequest *history.TerminateWorkflowExecutionRequest,
 		opts ...yarpc.CallOption,
 	) error
+
+	UpdateWorkflowExecution(
+		ctx context.Context,
+		UpdateRequest *history.UpdateWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.UpdateWorkflowExecutionResponse, error)
+
+	UpdateWorkflowExecutionOptions(
+		ctx context.Context,
+		UpdateRequest *history.UpdateWorkflowExecutionOptionsRequest,
+		opts ...yarpc.CallOption,
+	) error
 }
 
 // New builds a new client for the HistoryService service.
@@ -199,6 +259,29 @@ type client struct {
 	c thrift.Client
 }
 
+func (c client) DeleteWorkflowExecution(
+	ctx context.Context,
+	_DeleteRequest *history.DeleteWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_DeleteWorkflowExecution_Helper.Args(_DeleteRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_DeleteWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_DeleteWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeHistoryHost(
 	ctx context.Context,
 	_Request *shared.DescribeHistoryHostRequest,
@@ -245,6 +328,52 @@ func (c client) DescribeMutableState(
 	return
 }
 
+func (c client) DescribeShardBacklogs(
+	ctx context.Context,
+	_Request *shared.DescribeShardBacklogsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeShardBacklogsResponse, err error) {
+
+	args := history.HistoryService_DescribeShardBacklogs_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_DescribeShardBacklogs_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_DescribeShardBacklogs_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) DescribeShardOperations(
+	ctx context.Context,
+	_Request *shared.DescribeShardOperationsRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeShardOperationsResponse, err error) {
+
+	args := history.HistoryService_DescribeShardOperations_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_DescribeShardOperations_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_DescribeShardOperations_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeWorkflowExecution(
 	ctx context.Context,
 	_DescribeRequest *history.DescribeWorkflowExecutionRequest,
@@ -268,6 +397,29 @@ func (c client) DescribeWorkflowExecution(
 	return
 }
 
+func (c client) FixWorkflowExecution(
+	ctx context.Context,
+	_Request *history.FixWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *history.FixWorkflowExecutionResponse, err error) {
+
+	args := history.HistoryService_FixWorkflowExecution_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_FixWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_FixWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) GetMutableState(
 	ctx context.Context,
 	_GetRequest *history.GetMutableStateRequest,
@@ -291,6 +443,52 @@ func (c client) GetMutableState(
 	return
 }
 
+func (c client) GetMutableStates(
+	ctx context.Context,
+	_GetRequest *history.GetMutableStatesRequest,
+	opts ...yarpc.CallOption,
+) (success *history.GetMutableStatesResponse, err error) {
+
+	args := history.HistoryService_GetMutableStates_Helper.Args(_GetRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_GetMutableStates_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_GetMutableStates_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) RatelimitUpdate(
+	ctx context.Context,
+	_Request *history.RatelimitUpdateRequest,
+	opts ...yarpc.CallOption,
+) (success *history.RatelimitUpdateResponse, err error) {
+
+	args := history.HistoryService_RatelimitUpdate_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_RatelimitUpdate_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_RatelimitUpdate_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) RecordActivityTaskHeartbeat(
 	ctx context.Context,
 	_HeartbeatRequest *history.RecordActivityTaskHeartbeatRequest,
@@ -383,6 +581,29 @@ func (c client) RecordDecisionTaskStarted(
 	return
 }
 
+func (c client) RefreshDomainCache(
+	ctx context.Context,
+	_Request *shared.RefreshDomainCacheRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_RefreshDomainCache_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_RefreshDomainCache_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_RefreshDomainCache_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) RemoveSignalMutableState(
 	ctx context.Context,
 	_RemoveRequest *history.RemoveSignalMutableStateRequest,
@@ -682,6 +903,29 @@ func (c client) StartWorkflowExecution(
 	return
 }
 
+func (c client) SyncActivity(
+	ctx context.Context,
+	_SyncActivityRequest *history.SyncActivityRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_SyncActivity_Helper.Args(_SyncActivityRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_SyncActivity_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_SyncActivity_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) SyncShardStatus(
 	ctx context.Context,
 	_SyncShardStatusRequest *history.SyncShardStatusRequest,
@@ -727,3 +971,49 @@ func (c client) TerminateWorkflowExecution(
 	err = history.HistoryService_TerminateWorkflowExecution_Helper.UnwrapResponse(&result)
 	return
 }
+
+func (c client) UpdateWorkflowExecution(
+	ctx context.Context,
+	_UpdateRequest *history.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.UpdateWorkflowExecutionResponse, err error) {
+
+	args := history.HistoryService_UpdateWorkflowExecution_Helper.Args(_UpdateRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_UpdateWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_UpdateWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) UpdateWorkflowExecutionOptions(
+	ctx context.Context,
+	_UpdateRequest *history.UpdateWorkflowExecutionOptionsRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_UpdateWorkflowExecutionOptions_Helper.Args(_UpdateRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_UpdateWorkflowExecutionOptions_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_UpdateWorkflowExecutionOptions_Helper.UnwrapResponse(&result)
+	return
+}
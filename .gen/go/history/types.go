@@ -31,12 +31,13 @@ import (
 	"strings"
 )
 
-type DescribeMutableStateRequest struct {
+type DeleteWorkflowExecutionRequest struct {
 	DomainUUID *string                   `json:"domainUUID,omitempty"`
 	Execution  *shared.WorkflowExecution `json:"execution,omitempty"`
+	Force      *bool                     `json:"force,omitempty"`
 }
 
-// ToWire translates a DescribeMutableStateRequest struct into a Thrift-level intermediate
+// ToWire translates a DeleteWorkflowExecutionRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -51,9 +52,9 @@ type DescribeMutableStateRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *DescribeMutableStateRequest) ToWire() (wire.Value, error) {
+func (v *DeleteWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -75,6 +76,14 @@ func (v *DescribeMutableStateRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
+	if v.Force != nil {
+		w, err = wire.NewValueBool(*(v.Force)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -85,11 +94,11 @@ func _WorkflowExecution_Read(w wire.Value) (*shared.WorkflowExecution, error) {
 	return &v, err
 }
 
-// FromWire deserializes a DescribeMutableStateRequest struct from its Thrift-level
+// FromWire deserializes a DeleteWorkflowExecutionRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a DescribeMutableStateRequest struct
+// An error is returned if we were unable to build a DeleteWorkflowExecutionRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -97,12 +106,12 @@ func _WorkflowExecution_Read(w wire.Value) (*shared.WorkflowExecution, error) {
 //     return nil, err
 //   }
 //
-//   var v DescribeMutableStateRequest
+//   var v DeleteWorkflowExecutionRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *DescribeMutableStateRequest) FromWire(w wire.Value) error {
+func (v *DeleteWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -124,6 +133,16 @@ func (v *DescribeMutableStateRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 30:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Force = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -131,14 +150,14 @@ func (v *DescribeMutableStateRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a DescribeMutableStateRequest
+// String returns a readable string representation of a DeleteWorkflowExecutionRequest
 // struct.
-func (v *DescribeMutableStateRequest) String() string {
+func (v *DeleteWorkflowExecutionRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [2]string
+	var fields [3]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
@@ -148,8 +167,12 @@ func (v *DescribeMutableStateRequest) String() string {
 		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
 		i++
 	}
+	if v.Force != nil {
+		fields[i] = fmt.Sprintf("Force: %v", *(v.Force))
+		i++
+	}
 
-	return fmt.Sprintf("DescribeMutableStateRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("DeleteWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
 func _String_EqualsPtr(lhs, rhs *string) bool {
@@ -162,6 +185,179 @@ func _String_EqualsPtr(lhs, rhs *string) bool {
 	return lhs == nil && rhs == nil
 }
 
+func _Bool_EqualsPtr(lhs, rhs *bool) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this DeleteWorkflowExecutionRequest match the
+// provided DeleteWorkflowExecutionRequest.
+//
+// This function performs a deep comparison.
+func (v *DeleteWorkflowExecutionRequest) Equals(rhs *DeleteWorkflowExecutionRequest) bool {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.Force, rhs.Force) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *DeleteWorkflowExecutionRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// GetExecution returns the value of Execution if it is set or its
+// zero value if it is unset.
+func (v *DeleteWorkflowExecutionRequest) GetExecution() (o *shared.WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
+	}
+
+	return
+}
+
+// GetForce returns the value of Force if it is set or its
+// zero value if it is unset.
+func (v *DeleteWorkflowExecutionRequest) GetForce() (o bool) {
+	if v.Force != nil {
+		return *v.Force
+	}
+
+	return
+}
+
+type DescribeMutableStateRequest struct {
+	DomainUUID *string                   `json:"domainUUID,omitempty"`
+	Execution  *shared.WorkflowExecution `json:"execution,omitempty"`
+}
+
+// ToWire translates a DescribeMutableStateRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DescribeMutableStateRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a DescribeMutableStateRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DescribeMutableStateRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DescribeMutableStateRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DescribeMutableStateRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DescribeMutableStateRequest
+// struct.
+func (v *DescribeMutableStateRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+
+	return fmt.Sprintf("DescribeMutableStateRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
 // Equals returns true if all the fields of this DescribeMutableStateRequest match the
 // provided DescribeMutableStateRequest.
 //
@@ -619,13 +815,14 @@ func (v *EventAlreadyStartedError) Error() string {
 	return v.String()
 }
 
-type GetMutableStateRequest struct {
-	DomainUUID          *string                   `json:"domainUUID,omitempty"`
-	Execution           *shared.WorkflowExecution `json:"execution,omitempty"`
-	ExpectedNextEventId *int64                    `json:"expectedNextEventId,omitempty"`
+type FixWorkflowExecutionRequest struct {
+	DomainUUID *string                            `json:"domainUUID,omitempty"`
+	Execution  *shared.WorkflowExecution          `json:"execution,omitempty"`
+	IssueType  *shared.WorkflowExecutionIssueType `json:"issueType,omitempty"`
+	DryRun     *bool                              `json:"dryRun,omitempty"`
 }
 
-// ToWire translates a GetMutableStateRequest struct into a Thrift-level intermediate
+// ToWire translates a FixWorkflowExecutionRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -640,9 +837,9 @@ type GetMutableStateRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *GetMutableStateRequest) ToWire() (wire.Value, error) {
+func (v *FixWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -664,23 +861,37 @@ func (v *GetMutableStateRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.ExpectedNextEventId != nil {
-		w, err = wire.NewValueI64(*(v.ExpectedNextEventId)), error(nil)
+	if v.IssueType != nil {
+		w, err = v.IssueType.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
+	if v.DryRun != nil {
+		w, err = wire.NewValueBool(*(v.DryRun)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a GetMutableStateRequest struct from its Thrift-level
+func _WorkflowExecutionIssueType_Read(w wire.Value) (shared.WorkflowExecutionIssueType, error) {
+	var v shared.WorkflowExecutionIssueType
+	err := v.FromWire(w)
+	return v, err
+}
+
+// FromWire deserializes a FixWorkflowExecutionRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a GetMutableStateRequest struct
+// An error is returned if we were unable to build a FixWorkflowExecutionRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -688,12 +899,12 @@ func (v *GetMutableStateRequest) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v GetMutableStateRequest
+//   var v FixWorkflowExecutionRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *GetMutableStateRequest) FromWire(w wire.Value) error {
+func (v *FixWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -717,10 +928,20 @@ func (v *GetMutableStateRequest) FromWire(w wire.Value) error {
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.ExpectedNextEventId = &x
+			if field.Value.Type() == wire.TI32 {
+				var x shared.WorkflowExecutionIssueType
+				x, err = _WorkflowExecutionIssueType_Read(field.Value)
+				v.IssueType = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.DryRun = &x
 				if err != nil {
 					return err
 				}
@@ -732,14 +953,14 @@ func (v *GetMutableStateRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a GetMutableStateRequest
+// String returns a readable string representation of a FixWorkflowExecutionRequest
 // struct.
-func (v *GetMutableStateRequest) String() string {
+func (v *FixWorkflowExecutionRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [4]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
@@ -749,36 +970,43 @@ func (v *GetMutableStateRequest) String() string {
 		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
 		i++
 	}
-	if v.ExpectedNextEventId != nil {
-		fields[i] = fmt.Sprintf("ExpectedNextEventId: %v", *(v.ExpectedNextEventId))
+	if v.IssueType != nil {
+		fields[i] = fmt.Sprintf("IssueType: %v", *(v.IssueType))
+		i++
+	}
+	if v.DryRun != nil {
+		fields[i] = fmt.Sprintf("DryRun: %v", *(v.DryRun))
 		i++
 	}
 
-	return fmt.Sprintf("GetMutableStateRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("FixWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-func _I64_EqualsPtr(lhs, rhs *int64) bool {
+func _WorkflowExecutionIssueType_EqualsPtr(lhs, rhs *shared.WorkflowExecutionIssueType) bool {
 	if lhs != nil && rhs != nil {
 
 		x := *lhs
 		y := *rhs
-		return (x == y)
+		return x.Equals(y)
 	}
 	return lhs == nil && rhs == nil
 }
 
-// Equals returns true if all the fields of this GetMutableStateRequest match the
-// provided GetMutableStateRequest.
+// Equals returns true if all the fields of this FixWorkflowExecutionRequest match the
+// provided FixWorkflowExecutionRequest.
 //
 // This function performs a deep comparison.
-func (v *GetMutableStateRequest) Equals(rhs *GetMutableStateRequest) bool {
+func (v *FixWorkflowExecutionRequest) Equals(rhs *FixWorkflowExecutionRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
 	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.ExpectedNextEventId, rhs.ExpectedNextEventId) {
+	if !_WorkflowExecutionIssueType_EqualsPtr(v.IssueType, rhs.IssueType) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.DryRun, rhs.DryRun) {
 		return false
 	}
 
@@ -787,7 +1015,7 @@ func (v *GetMutableStateRequest) Equals(rhs *GetMutableStateRequest) bool {
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *GetMutableStateRequest) GetDomainUUID() (o string) {
+func (v *FixWorkflowExecutionRequest) GetDomainUUID() (o string) {
 	if v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -797,7 +1025,7 @@ func (v *GetMutableStateRequest) GetDomainUUID() (o string) {
 
 // GetExecution returns the value of Execution if it is set or its
 // zero value if it is unset.
-func (v *GetMutableStateRequest) GetExecution() (o *shared.WorkflowExecution) {
+func (v *FixWorkflowExecutionRequest) GetExecution() (o *shared.WorkflowExecution) {
 	if v.Execution != nil {
 		return v.Execution
 	}
@@ -805,31 +1033,31 @@ func (v *GetMutableStateRequest) GetExecution() (o *shared.WorkflowExecution) {
 	return
 }
 
-// GetExpectedNextEventId returns the value of ExpectedNextEventId if it is set or its
+// GetIssueType returns the value of IssueType if it is set or its
 // zero value if it is unset.
-func (v *GetMutableStateRequest) GetExpectedNextEventId() (o int64) {
-	if v.ExpectedNextEventId != nil {
-		return *v.ExpectedNextEventId
+func (v *FixWorkflowExecutionRequest) GetIssueType() (o shared.WorkflowExecutionIssueType) {
+	if v.IssueType != nil {
+		return *v.IssueType
 	}
 
 	return
 }
 
-type GetMutableStateResponse struct {
-	Execution                            *shared.WorkflowExecution `json:"execution,omitempty"`
-	WorkflowType                         *shared.WorkflowType      `json:"workflowType,omitempty"`
-	NextEventId                          *int64                    `json:"NextEventId,omitempty"`
-	LastFirstEventId                     *int64                    `json:"LastFirstEventId,omitempty"`
-	TaskList                             *shared.TaskList          `json:"taskList,omitempty"`
-	StickyTaskList                       *shared.TaskList          `json:"stickyTaskList,omitempty"`
-	ClientLibraryVersion                 *string                   `json:"clientLibraryVersion,omitempty"`
-	ClientFeatureVersion                 *string                   `json:"clientFeatureVersion,omitempty"`
-	ClientImpl                           *string                   `json:"clientImpl,omitempty"`
-	IsWorkflowRunning                    *bool                     `json:"isWorkflowRunning,omitempty"`
-	StickyTaskListScheduleToStartTimeout *int32                    `json:"stickyTaskListScheduleToStartTimeout,omitempty"`
+// GetDryRun returns the value of DryRun if it is set or its
+// zero value if it is unset.
+func (v *FixWorkflowExecutionRequest) GetDryRun() (o bool) {
+	if v.DryRun != nil {
+		return *v.DryRun
+	}
+
+	return
 }
 
-// ToWire translates a GetMutableStateResponse struct into a Thrift-level intermediate
+type FixWorkflowExecutionResponse struct {
+	Action *string `json:"action,omitempty"`
+}
+
+// ToWire translates a FixWorkflowExecutionResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -844,123 +1072,165 @@ type GetMutableStateResponse struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *GetMutableStateResponse) ToWire() (wire.Value, error) {
+func (v *FixWorkflowExecutionResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [11]wire.Field
+		fields [1]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.Execution != nil {
-		w, err = v.Execution.ToWire()
+	if v.Action != nil {
+		w, err = wire.NewValueString(*(v.Action)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.WorkflowType != nil {
-		w, err = v.WorkflowType.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 20, Value: w}
-		i++
-	}
-	if v.NextEventId != nil {
-		w, err = wire.NewValueI64(*(v.NextEventId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
-	if v.LastFirstEventId != nil {
-		w, err = wire.NewValueI64(*(v.LastFirstEventId)), error(nil)
-		if err != nil {
-			return w, err
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a FixWorkflowExecutionResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a FixWorkflowExecutionResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v FixWorkflowExecutionResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *FixWorkflowExecutionResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Action = &x
+				if err != nil {
+					return err
+				}
+
+			}
 		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
 	}
-	if v.TaskList != nil {
-		w, err = v.TaskList.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 50, Value: w}
-		i++
+
+	return nil
+}
+
+// String returns a readable string representation of a FixWorkflowExecutionResponse
+// struct.
+func (v *FixWorkflowExecutionResponse) String() string {
+	if v == nil {
+		return "<nil>"
 	}
-	if v.StickyTaskList != nil {
-		w, err = v.StickyTaskList.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 60, Value: w}
+
+	var fields [1]string
+	i := 0
+	if v.Action != nil {
+		fields[i] = fmt.Sprintf("Action: %v", *(v.Action))
 		i++
 	}
-	if v.ClientLibraryVersion != nil {
-		w, err = wire.NewValueString(*(v.ClientLibraryVersion)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 70, Value: w}
-		i++
+
+	return fmt.Sprintf("FixWorkflowExecutionResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this FixWorkflowExecutionResponse match the
+// provided FixWorkflowExecutionResponse.
+//
+// This function performs a deep comparison.
+func (v *FixWorkflowExecutionResponse) Equals(rhs *FixWorkflowExecutionResponse) bool {
+	if !_String_EqualsPtr(v.Action, rhs.Action) {
+		return false
 	}
-	if v.ClientFeatureVersion != nil {
-		w, err = wire.NewValueString(*(v.ClientFeatureVersion)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 80, Value: w}
-		i++
+
+	return true
+}
+
+// GetAction returns the value of Action if it is set or its
+// zero value if it is unset.
+func (v *FixWorkflowExecutionResponse) GetAction() (o string) {
+	if v.Action != nil {
+		return *v.Action
 	}
-	if v.ClientImpl != nil {
-		w, err = wire.NewValueString(*(v.ClientImpl)), error(nil)
+
+	return
+}
+
+type GetMutableStateRequest struct {
+	DomainUUID          *string                   `json:"domainUUID,omitempty"`
+	Execution           *shared.WorkflowExecution `json:"execution,omitempty"`
+	ExpectedNextEventId *int64                    `json:"expectedNextEventId,omitempty"`
+}
+
+// ToWire translates a GetMutableStateRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *GetMutableStateRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 90, Value: w}
+		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.IsWorkflowRunning != nil {
-		w, err = wire.NewValueBool(*(v.IsWorkflowRunning)), error(nil)
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 100, Value: w}
+		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.StickyTaskListScheduleToStartTimeout != nil {
-		w, err = wire.NewValueI32(*(v.StickyTaskListScheduleToStartTimeout)), error(nil)
+	if v.ExpectedNextEventId != nil {
+		w, err = wire.NewValueI64(*(v.ExpectedNextEventId)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 110, Value: w}
+		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _WorkflowType_Read(w wire.Value) (*shared.WorkflowType, error) {
-	var v shared.WorkflowType
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _TaskList_Read(w wire.Value) (*shared.TaskList, error) {
-	var v shared.TaskList
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a GetMutableStateResponse struct from its Thrift-level
+// FromWire deserializes a GetMutableStateRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a GetMutableStateResponse struct
+// An error is returned if we were unable to build a GetMutableStateRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -968,19 +1238,21 @@ func _TaskList_Read(w wire.Value) (*shared.TaskList, error) {
 //     return nil, err
 //   }
 //
-//   var v GetMutableStateResponse
+//   var v GetMutableStateRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *GetMutableStateResponse) FromWire(w wire.Value) error {
+func (v *GetMutableStateRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TStruct {
-				v.Execution, err = _WorkflowExecution_Read(field.Value)
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
 				if err != nil {
 					return err
 				}
@@ -988,7 +1260,7 @@ func (v *GetMutableStateResponse) FromWire(w wire.Value) error {
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.WorkflowType, err = _WorkflowType_Read(field.Value)
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -998,83 +1270,1774 @@ func (v *GetMutableStateResponse) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.NextEventId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.LastFirstEventId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 50:
-			if field.Value.Type() == wire.TStruct {
-				v.TaskList, err = _TaskList_Read(field.Value)
+				v.ExpectedNextEventId = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 60:
-			if field.Value.Type() == wire.TStruct {
-				v.StickyTaskList, err = _TaskList_Read(field.Value)
-				if err != nil {
-					return err
-				}
+		}
+	}
 
-			}
-		case 70:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.ClientLibraryVersion = &x
-				if err != nil {
-					return err
-				}
+	return nil
+}
 
-			}
-		case 80:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.ClientFeatureVersion = &x
-				if err != nil {
-					return err
-				}
+// String returns a readable string representation of a GetMutableStateRequest
+// struct.
+func (v *GetMutableStateRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+	if v.ExpectedNextEventId != nil {
+		fields[i] = fmt.Sprintf("ExpectedNextEventId: %v", *(v.ExpectedNextEventId))
+		i++
+	}
+
+	return fmt.Sprintf("GetMutableStateRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _I64_EqualsPtr(lhs, rhs *int64) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this GetMutableStateRequest match the
+// provided GetMutableStateRequest.
+//
+// This function performs a deep comparison.
+func (v *GetMutableStateRequest) Equals(rhs *GetMutableStateRequest) bool {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ExpectedNextEventId, rhs.ExpectedNextEventId) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// GetExecution returns the value of Execution if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateRequest) GetExecution() (o *shared.WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
+	}
+
+	return
+}
+
+// GetExpectedNextEventId returns the value of ExpectedNextEventId if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateRequest) GetExpectedNextEventId() (o int64) {
+	if v.ExpectedNextEventId != nil {
+		return *v.ExpectedNextEventId
+	}
+
+	return
+}
+
+type GetMutableStateResponse struct {
+	Execution                            *shared.WorkflowExecution `json:"execution,omitempty"`
+	WorkflowType                         *shared.WorkflowType      `json:"workflowType,omitempty"`
+	NextEventId                          *int64                    `json:"NextEventId,omitempty"`
+	LastFirstEventId                     *int64                    `json:"LastFirstEventId,omitempty"`
+	TaskList                             *shared.TaskList          `json:"taskList,omitempty"`
+	StickyTaskList                       *shared.TaskList          `json:"stickyTaskList,omitempty"`
+	ClientLibraryVersion                 *string                   `json:"clientLibraryVersion,omitempty"`
+	ClientFeatureVersion                 *string                   `json:"clientFeatureVersion,omitempty"`
+	ClientImpl                           *string                   `json:"clientImpl,omitempty"`
+	IsWorkflowRunning                    *bool                     `json:"isWorkflowRunning,omitempty"`
+	StickyTaskListScheduleToStartTimeout *int32                    `json:"stickyTaskListScheduleToStartTimeout,omitempty"`
+}
+
+// ToWire translates a GetMutableStateResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *GetMutableStateResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [11]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowType != nil {
+		w, err = v.WorkflowType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.NextEventId != nil {
+		w, err = wire.NewValueI64(*(v.NextEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.LastFirstEventId != nil {
+		w, err = wire.NewValueI64(*(v.LastFirstEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.TaskList != nil {
+		w, err = v.TaskList.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.StickyTaskList != nil {
+		w, err = v.StickyTaskList.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.ClientLibraryVersion != nil {
+		w, err = wire.NewValueString(*(v.ClientLibraryVersion)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+	if v.ClientFeatureVersion != nil {
+		w, err = wire.NewValueString(*(v.ClientFeatureVersion)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
+	if v.ClientImpl != nil {
+		w, err = wire.NewValueString(*(v.ClientImpl)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 90, Value: w}
+		i++
+	}
+	if v.IsWorkflowRunning != nil {
+		w, err = wire.NewValueBool(*(v.IsWorkflowRunning)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 100, Value: w}
+		i++
+	}
+	if v.StickyTaskListScheduleToStartTimeout != nil {
+		w, err = wire.NewValueI32(*(v.StickyTaskListScheduleToStartTimeout)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 110, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _WorkflowType_Read(w wire.Value) (*shared.WorkflowType, error) {
+	var v shared.WorkflowType
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _TaskList_Read(w wire.Value) (*shared.TaskList, error) {
+	var v shared.TaskList
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a GetMutableStateResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a GetMutableStateResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v GetMutableStateResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *GetMutableStateResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowType, err = _WorkflowType_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.NextEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LastFirstEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TStruct {
+				v.TaskList, err = _TaskList_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TStruct {
+				v.StickyTaskList, err = _TaskList_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ClientLibraryVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 80:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ClientFeatureVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 90:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ClientImpl = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 100:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.IsWorkflowRunning = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 110:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.StickyTaskListScheduleToStartTimeout = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a GetMutableStateResponse
+// struct.
+func (v *GetMutableStateResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [11]string
+	i := 0
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+	if v.WorkflowType != nil {
+		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
+		i++
+	}
+	if v.NextEventId != nil {
+		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
+		i++
+	}
+	if v.LastFirstEventId != nil {
+		fields[i] = fmt.Sprintf("LastFirstEventId: %v", *(v.LastFirstEventId))
+		i++
+	}
+	if v.TaskList != nil {
+		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
+		i++
+	}
+	if v.StickyTaskList != nil {
+		fields[i] = fmt.Sprintf("StickyTaskList: %v", v.StickyTaskList)
+		i++
+	}
+	if v.ClientLibraryVersion != nil {
+		fields[i] = fmt.Sprintf("ClientLibraryVersion: %v", *(v.ClientLibraryVersion))
+		i++
+	}
+	if v.ClientFeatureVersion != nil {
+		fields[i] = fmt.Sprintf("ClientFeatureVersion: %v", *(v.ClientFeatureVersion))
+		i++
+	}
+	if v.ClientImpl != nil {
+		fields[i] = fmt.Sprintf("ClientImpl: %v", *(v.ClientImpl))
+		i++
+	}
+	if v.IsWorkflowRunning != nil {
+		fields[i] = fmt.Sprintf("IsWorkflowRunning: %v", *(v.IsWorkflowRunning))
+		i++
+	}
+	if v.StickyTaskListScheduleToStartTimeout != nil {
+		fields[i] = fmt.Sprintf("StickyTaskListScheduleToStartTimeout: %v", *(v.StickyTaskListScheduleToStartTimeout))
+		i++
+	}
+
+	return fmt.Sprintf("GetMutableStateResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _I32_EqualsPtr(lhs, rhs *int32) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this GetMutableStateResponse match the
+// provided GetMutableStateResponse.
+//
+// This function performs a deep comparison.
+func (v *GetMutableStateResponse) Equals(rhs *GetMutableStateResponse) bool {
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+	if !((v.WorkflowType == nil && rhs.WorkflowType == nil) || (v.WorkflowType != nil && rhs.WorkflowType != nil && v.WorkflowType.Equals(rhs.WorkflowType))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.LastFirstEventId, rhs.LastFirstEventId) {
+		return false
+	}
+	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
+		return false
+	}
+	if !((v.StickyTaskList == nil && rhs.StickyTaskList == nil) || (v.StickyTaskList != nil && rhs.StickyTaskList != nil && v.StickyTaskList.Equals(rhs.StickyTaskList))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ClientLibraryVersion, rhs.ClientLibraryVersion) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ClientFeatureVersion, rhs.ClientFeatureVersion) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ClientImpl, rhs.ClientImpl) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.IsWorkflowRunning, rhs.IsWorkflowRunning) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.StickyTaskListScheduleToStartTimeout, rhs.StickyTaskListScheduleToStartTimeout) {
+		return false
+	}
+
+	return true
+}
+
+// GetExecution returns the value of Execution if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetExecution() (o *shared.WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
+	}
+
+	return
+}
+
+// GetWorkflowType returns the value of WorkflowType if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetWorkflowType() (o *shared.WorkflowType) {
+	if v.WorkflowType != nil {
+		return v.WorkflowType
+	}
+
+	return
+}
+
+// GetNextEventId returns the value of NextEventId if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetNextEventId() (o int64) {
+	if v.NextEventId != nil {
+		return *v.NextEventId
+	}
+
+	return
+}
+
+// GetLastFirstEventId returns the value of LastFirstEventId if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetLastFirstEventId() (o int64) {
+	if v.LastFirstEventId != nil {
+		return *v.LastFirstEventId
+	}
+
+	return
+}
+
+// GetTaskList returns the value of TaskList if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetTaskList() (o *shared.TaskList) {
+	if v.TaskList != nil {
+		return v.TaskList
+	}
+
+	return
+}
+
+// GetStickyTaskList returns the value of StickyTaskList if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetStickyTaskList() (o *shared.TaskList) {
+	if v.StickyTaskList != nil {
+		return v.StickyTaskList
+	}
+
+	return
+}
+
+// GetClientLibraryVersion returns the value of ClientLibraryVersion if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetClientLibraryVersion() (o string) {
+	if v.ClientLibraryVersion != nil {
+		return *v.ClientLibraryVersion
+	}
+
+	return
+}
+
+// GetClientFeatureVersion returns the value of ClientFeatureVersion if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetClientFeatureVersion() (o string) {
+	if v.ClientFeatureVersion != nil {
+		return *v.ClientFeatureVersion
+	}
+
+	return
+}
+
+// GetClientImpl returns the value of ClientImpl if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetClientImpl() (o string) {
+	if v.ClientImpl != nil {
+		return *v.ClientImpl
+	}
+
+	return
+}
+
+// GetIsWorkflowRunning returns the value of IsWorkflowRunning if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetIsWorkflowRunning() (o bool) {
+	if v.IsWorkflowRunning != nil {
+		return *v.IsWorkflowRunning
+	}
+
+	return
+}
+
+// GetStickyTaskListScheduleToStartTimeout returns the value of StickyTaskListScheduleToStartTimeout if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetStickyTaskListScheduleToStartTimeout() (o int32) {
+	if v.StickyTaskListScheduleToStartTimeout != nil {
+		return *v.StickyTaskListScheduleToStartTimeout
+	}
+
+	return
+}
+
+type GetMutableStatesRequest struct {
+	DomainUUID *string                     `json:"domainUUID,omitempty"`
+	Executions []*shared.WorkflowExecution `json:"executions,omitempty"`
+}
+
+type _List_WorkflowExecution_ValueList []*shared.WorkflowExecution
+
+func (v _List_WorkflowExecution_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_WorkflowExecution_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_WorkflowExecution_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_WorkflowExecution_ValueList) Close() {}
+
+// ToWire translates a GetMutableStatesRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *GetMutableStatesRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Executions != nil {
+		w, err = wire.NewValueList(_List_WorkflowExecution_ValueList(v.Executions)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_WorkflowExecution_Read(l wire.ValueList) ([]*shared.WorkflowExecution, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*shared.WorkflowExecution, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _WorkflowExecution_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a GetMutableStatesRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a GetMutableStatesRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v GetMutableStatesRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *GetMutableStatesRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TList {
+				v.Executions, err = _List_WorkflowExecution_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a GetMutableStatesRequest
+// struct.
+func (v *GetMutableStatesRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.Executions != nil {
+		fields[i] = fmt.Sprintf("Executions: %v", v.Executions)
+		i++
+	}
+
+	return fmt.Sprintf("GetMutableStatesRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_WorkflowExecution_Equals(lhs, rhs []*shared.WorkflowExecution) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this GetMutableStatesRequest match the
+// provided GetMutableStatesRequest.
+//
+// This function performs a deep comparison.
+func (v *GetMutableStatesRequest) Equals(rhs *GetMutableStatesRequest) bool {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.Executions == nil && rhs.Executions == nil) || (v.Executions != nil && rhs.Executions != nil && _List_WorkflowExecution_Equals(v.Executions, rhs.Executions))) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStatesRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// GetExecutions returns the value of Executions if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStatesRequest) GetExecutions() (o []*shared.WorkflowExecution) {
+	if v.Executions != nil {
+		return v.Executions
+	}
+
+	return
+}
+
+type GetMutableStatesResponse struct {
+	States   []*GetMutableStateResponse  `json:"states,omitempty"`
+	NotFound []*shared.WorkflowExecution `json:"notFound,omitempty"`
+}
+
+type _List_GetMutableStateResponse_ValueList []*GetMutableStateResponse
+
+func (v _List_GetMutableStateResponse_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_GetMutableStateResponse_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_GetMutableStateResponse_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_GetMutableStateResponse_ValueList) Close() {}
+
+// ToWire translates a GetMutableStatesResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *GetMutableStatesResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.States != nil {
+		w, err = wire.NewValueList(_List_GetMutableStateResponse_ValueList(v.States)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.NotFound != nil {
+		w, err = wire.NewValueList(_List_WorkflowExecution_ValueList(v.NotFound)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _GetMutableStateResponse_Read(w wire.Value) (*GetMutableStateResponse, error) {
+	var v GetMutableStateResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_GetMutableStateResponse_Read(l wire.ValueList) ([]*GetMutableStateResponse, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*GetMutableStateResponse, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _GetMutableStateResponse_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a GetMutableStatesResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a GetMutableStatesResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v GetMutableStatesResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *GetMutableStatesResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.States, err = _List_GetMutableStateResponse_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TList {
+				v.NotFound, err = _List_WorkflowExecution_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a GetMutableStatesResponse
+// struct.
+func (v *GetMutableStatesResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.States != nil {
+		fields[i] = fmt.Sprintf("States: %v", v.States)
+		i++
+	}
+	if v.NotFound != nil {
+		fields[i] = fmt.Sprintf("NotFound: %v", v.NotFound)
+		i++
+	}
+
+	return fmt.Sprintf("GetMutableStatesResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_GetMutableStateResponse_Equals(lhs, rhs []*GetMutableStateResponse) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this GetMutableStatesResponse match the
+// provided GetMutableStatesResponse.
+//
+// This function performs a deep comparison.
+func (v *GetMutableStatesResponse) Equals(rhs *GetMutableStatesResponse) bool {
+	if !((v.States == nil && rhs.States == nil) || (v.States != nil && rhs.States != nil && _List_GetMutableStateResponse_Equals(v.States, rhs.States))) {
+		return false
+	}
+	if !((v.NotFound == nil && rhs.NotFound == nil) || (v.NotFound != nil && rhs.NotFound != nil && _List_WorkflowExecution_Equals(v.NotFound, rhs.NotFound))) {
+		return false
+	}
+
+	return true
+}
+
+// GetStates returns the value of States if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStatesResponse) GetStates() (o []*GetMutableStateResponse) {
+	if v.States != nil {
+		return v.States
+	}
+
+	return
+}
+
+// GetNotFound returns the value of NotFound if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStatesResponse) GetNotFound() (o []*shared.WorkflowExecution) {
+	if v.NotFound != nil {
+		return v.NotFound
+	}
+
+	return
+}
+
+type ParentExecutionInfo struct {
+	DomainUUID  *string                   `json:"domainUUID,omitempty"`
+	Domain      *string                   `json:"domain,omitempty"`
+	Execution   *shared.WorkflowExecution `json:"execution,omitempty"`
+	InitiatedId *int64                    `json:"initiatedId,omitempty"`
+}
+
+// ToWire translates a ParentExecutionInfo struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ParentExecutionInfo) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 15, Value: w}
+		i++
+	}
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.InitiatedId != nil {
+		w, err = wire.NewValueI64(*(v.InitiatedId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a ParentExecutionInfo struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ParentExecutionInfo struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ParentExecutionInfo
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ParentExecutionInfo) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 15:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
 
 			}
-		case 90:
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.InitiatedId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ParentExecutionInfo
+// struct.
+func (v *ParentExecutionInfo) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+	if v.InitiatedId != nil {
+		fields[i] = fmt.Sprintf("InitiatedId: %v", *(v.InitiatedId))
+		i++
+	}
+
+	return fmt.Sprintf("ParentExecutionInfo{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this ParentExecutionInfo match the
+// provided ParentExecutionInfo.
+//
+// This function performs a deep comparison.
+func (v *ParentExecutionInfo) Equals(rhs *ParentExecutionInfo) bool {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.InitiatedId, rhs.InitiatedId) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *ParentExecutionInfo) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *ParentExecutionInfo) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// GetExecution returns the value of Execution if it is set or its
+// zero value if it is unset.
+func (v *ParentExecutionInfo) GetExecution() (o *shared.WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
+	}
+
+	return
+}
+
+// GetInitiatedId returns the value of InitiatedId if it is set or its
+// zero value if it is unset.
+func (v *ParentExecutionInfo) GetInitiatedId() (o int64) {
+	if v.InitiatedId != nil {
+		return *v.InitiatedId
+	}
+
+	return
+}
+
+type RatelimitQuota struct {
+	Key    *string  `json:"key,omitempty"`
+	Weight *float64 `json:"weight,omitempty"`
+}
+
+// ToWire translates a RatelimitQuota struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RatelimitQuota) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Key != nil {
+		w, err = wire.NewValueString(*(v.Key)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Weight != nil {
+		w, err = wire.NewValueDouble(*(v.Weight)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a RatelimitQuota struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RatelimitQuota struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RatelimitQuota
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *RatelimitQuota) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Key = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TDouble {
+				var x float64
+				x, err = field.Value.GetDouble(), error(nil)
+				v.Weight = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a RatelimitQuota
+// struct.
+func (v *RatelimitQuota) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Key != nil {
+		fields[i] = fmt.Sprintf("Key: %v", *(v.Key))
+		i++
+	}
+	if v.Weight != nil {
+		fields[i] = fmt.Sprintf("Weight: %v", *(v.Weight))
+		i++
+	}
+
+	return fmt.Sprintf("RatelimitQuota{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Double_EqualsPtr(lhs, rhs *float64) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this RatelimitQuota match the
+// provided RatelimitQuota.
+//
+// This function performs a deep comparison.
+func (v *RatelimitQuota) Equals(rhs *RatelimitQuota) bool {
+	if !_String_EqualsPtr(v.Key, rhs.Key) {
+		return false
+	}
+	if !_Double_EqualsPtr(v.Weight, rhs.Weight) {
+		return false
+	}
+
+	return true
+}
+
+// GetKey returns the value of Key if it is set or its
+// zero value if it is unset.
+func (v *RatelimitQuota) GetKey() (o string) {
+	if v.Key != nil {
+		return *v.Key
+	}
+
+	return
+}
+
+// GetWeight returns the value of Weight if it is set or its
+// zero value if it is unset.
+func (v *RatelimitQuota) GetWeight() (o float64) {
+	if v.Weight != nil {
+		return *v.Weight
+	}
+
+	return
+}
+
+type RatelimitUpdateRequest struct {
+	Host          *string           `json:"host,omitempty"`
+	ElapsedMillis *int64            `json:"elapsedMillis,omitempty"`
+	Usages        []*RatelimitUsage `json:"usages,omitempty"`
+}
+
+type _List_RatelimitUsage_ValueList []*RatelimitUsage
+
+func (v _List_RatelimitUsage_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_RatelimitUsage_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_RatelimitUsage_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_RatelimitUsage_ValueList) Close() {}
+
+// ToWire translates a RatelimitUpdateRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RatelimitUpdateRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Host != nil {
+		w, err = wire.NewValueString(*(v.Host)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.ElapsedMillis != nil {
+		w, err = wire.NewValueI64(*(v.ElapsedMillis)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Usages != nil {
+		w, err = wire.NewValueList(_List_RatelimitUsage_ValueList(v.Usages)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _RatelimitUsage_Read(w wire.Value) (*RatelimitUsage, error) {
+	var v RatelimitUsage
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_RatelimitUsage_Read(l wire.ValueList) ([]*RatelimitUsage, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*RatelimitUsage, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _RatelimitUsage_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a RatelimitUpdateRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RatelimitUpdateRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RatelimitUpdateRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *RatelimitUpdateRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
-				v.ClientImpl = &x
+				v.Host = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 100:
-			if field.Value.Type() == wire.TBool {
-				var x bool
-				x, err = field.Value.GetBool(), error(nil)
-				v.IsWorkflowRunning = &x
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ElapsedMillis = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 110:
-			if field.Value.Type() == wire.TI32 {
-				var x int32
-				x, err = field.Value.GetI32(), error(nil)
-				v.StickyTaskListScheduleToStartTimeout = &x
+		case 30:
+			if field.Value.Type() == wire.TList {
+				v.Usages, err = _List_RatelimitUsage_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a RatelimitUpdateRequest
+// struct.
+func (v *RatelimitUpdateRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.Host != nil {
+		fields[i] = fmt.Sprintf("Host: %v", *(v.Host))
+		i++
+	}
+	if v.ElapsedMillis != nil {
+		fields[i] = fmt.Sprintf("ElapsedMillis: %v", *(v.ElapsedMillis))
+		i++
+	}
+	if v.Usages != nil {
+		fields[i] = fmt.Sprintf("Usages: %v", v.Usages)
+		i++
+	}
+
+	return fmt.Sprintf("RatelimitUpdateRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_RatelimitUsage_Equals(lhs, rhs []*RatelimitUsage) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this RatelimitUpdateRequest match the
+// provided RatelimitUpdateRequest.
+//
+// This function performs a deep comparison.
+func (v *RatelimitUpdateRequest) Equals(rhs *RatelimitUpdateRequest) bool {
+	if !_String_EqualsPtr(v.Host, rhs.Host) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ElapsedMillis, rhs.ElapsedMillis) {
+		return false
+	}
+	if !((v.Usages == nil && rhs.Usages == nil) || (v.Usages != nil && rhs.Usages != nil && _List_RatelimitUsage_Equals(v.Usages, rhs.Usages))) {
+		return false
+	}
+
+	return true
+}
+
+// GetHost returns the value of Host if it is set or its
+// zero value if it is unset.
+func (v *RatelimitUpdateRequest) GetHost() (o string) {
+	if v.Host != nil {
+		return *v.Host
+	}
+
+	return
+}
+
+// GetElapsedMillis returns the value of ElapsedMillis if it is set or its
+// zero value if it is unset.
+func (v *RatelimitUpdateRequest) GetElapsedMillis() (o int64) {
+	if v.ElapsedMillis != nil {
+		return *v.ElapsedMillis
+	}
+
+	return
+}
+
+// GetUsages returns the value of Usages if it is set or its
+// zero value if it is unset.
+func (v *RatelimitUpdateRequest) GetUsages() (o []*RatelimitUsage) {
+	if v.Usages != nil {
+		return v.Usages
+	}
+
+	return
+}
+
+type RatelimitUpdateResponse struct {
+	Quotas []*RatelimitQuota `json:"quotas,omitempty"`
+}
+
+type _List_RatelimitQuota_ValueList []*RatelimitQuota
+
+func (v _List_RatelimitQuota_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_RatelimitQuota_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_RatelimitQuota_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_RatelimitQuota_ValueList) Close() {}
+
+// ToWire translates a RatelimitUpdateResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RatelimitUpdateResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Quotas != nil {
+		w, err = wire.NewValueList(_List_RatelimitQuota_ValueList(v.Quotas)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _RatelimitQuota_Read(w wire.Value) (*RatelimitQuota, error) {
+	var v RatelimitQuota
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_RatelimitQuota_Read(l wire.ValueList) ([]*RatelimitQuota, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*RatelimitQuota, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _RatelimitQuota_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a RatelimitUpdateResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RatelimitUpdateResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RatelimitUpdateResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *RatelimitUpdateResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.Quotas, err = _List_RatelimitQuota_Read(field.Value.GetList())
 				if err != nil {
 					return err
 				}
@@ -1086,243 +3049,414 @@ func (v *GetMutableStateResponse) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a GetMutableStateResponse
+// String returns a readable string representation of a RatelimitUpdateResponse
 // struct.
-func (v *GetMutableStateResponse) String() string {
+func (v *RatelimitUpdateResponse) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [11]string
+	var fields [1]string
 	i := 0
-	if v.Execution != nil {
-		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
-		i++
-	}
-	if v.WorkflowType != nil {
-		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
-		i++
-	}
-	if v.NextEventId != nil {
-		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
-		i++
-	}
-	if v.LastFirstEventId != nil {
-		fields[i] = fmt.Sprintf("LastFirstEventId: %v", *(v.LastFirstEventId))
+	if v.Quotas != nil {
+		fields[i] = fmt.Sprintf("Quotas: %v", v.Quotas)
 		i++
 	}
-	if v.TaskList != nil {
-		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
-		i++
+
+	return fmt.Sprintf("RatelimitUpdateResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_RatelimitQuota_Equals(lhs, rhs []*RatelimitQuota) bool {
+	if len(lhs) != len(rhs) {
+		return false
 	}
-	if v.StickyTaskList != nil {
-		fields[i] = fmt.Sprintf("StickyTaskList: %v", v.StickyTaskList)
-		i++
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
 	}
-	if v.ClientLibraryVersion != nil {
-		fields[i] = fmt.Sprintf("ClientLibraryVersion: %v", *(v.ClientLibraryVersion))
-		i++
+
+	return true
+}
+
+// Equals returns true if all the fields of this RatelimitUpdateResponse match the
+// provided RatelimitUpdateResponse.
+//
+// This function performs a deep comparison.
+func (v *RatelimitUpdateResponse) Equals(rhs *RatelimitUpdateResponse) bool {
+	if !((v.Quotas == nil && rhs.Quotas == nil) || (v.Quotas != nil && rhs.Quotas != nil && _List_RatelimitQuota_Equals(v.Quotas, rhs.Quotas))) {
+		return false
 	}
-	if v.ClientFeatureVersion != nil {
-		fields[i] = fmt.Sprintf("ClientFeatureVersion: %v", *(v.ClientFeatureVersion))
-		i++
+
+	return true
+}
+
+// GetQuotas returns the value of Quotas if it is set or its
+// zero value if it is unset.
+func (v *RatelimitUpdateResponse) GetQuotas() (o []*RatelimitQuota) {
+	if v.Quotas != nil {
+		return v.Quotas
 	}
-	if v.ClientImpl != nil {
-		fields[i] = fmt.Sprintf("ClientImpl: %v", *(v.ClientImpl))
+
+	return
+}
+
+type RatelimitUsage struct {
+	Key      *string `json:"key,omitempty"`
+	Allowed  *int64  `json:"allowed,omitempty"`
+	Rejected *int64  `json:"rejected,omitempty"`
+}
+
+// ToWire translates a RatelimitUsage struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RatelimitUsage) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Key != nil {
+		w, err = wire.NewValueString(*(v.Key)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.IsWorkflowRunning != nil {
-		fields[i] = fmt.Sprintf("IsWorkflowRunning: %v", *(v.IsWorkflowRunning))
+	if v.Allowed != nil {
+		w, err = wire.NewValueI64(*(v.Allowed)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.StickyTaskListScheduleToStartTimeout != nil {
-		fields[i] = fmt.Sprintf("StickyTaskListScheduleToStartTimeout: %v", *(v.StickyTaskListScheduleToStartTimeout))
+	if v.Rejected != nil {
+		w, err = wire.NewValueI64(*(v.Rejected)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
 
-	return fmt.Sprintf("GetMutableStateResponse{%v}", strings.Join(fields[:i], ", "))
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _Bool_EqualsPtr(lhs, rhs *bool) bool {
-	if lhs != nil && rhs != nil {
+// FromWire deserializes a RatelimitUsage struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RatelimitUsage struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RatelimitUsage
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *RatelimitUsage) FromWire(w wire.Value) error {
+	var err error
 
-		x := *lhs
-		y := *rhs
-		return (x == y)
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Key = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Allowed = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Rejected = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
 	}
-	return lhs == nil && rhs == nil
+
+	return nil
 }
 
-func _I32_EqualsPtr(lhs, rhs *int32) bool {
-	if lhs != nil && rhs != nil {
+// String returns a readable string representation of a RatelimitUsage
+// struct.
+func (v *RatelimitUsage) String() string {
+	if v == nil {
+		return "<nil>"
+	}
 
-		x := *lhs
-		y := *rhs
-		return (x == y)
+	var fields [3]string
+	i := 0
+	if v.Key != nil {
+		fields[i] = fmt.Sprintf("Key: %v", *(v.Key))
+		i++
 	}
-	return lhs == nil && rhs == nil
+	if v.Allowed != nil {
+		fields[i] = fmt.Sprintf("Allowed: %v", *(v.Allowed))
+		i++
+	}
+	if v.Rejected != nil {
+		fields[i] = fmt.Sprintf("Rejected: %v", *(v.Rejected))
+		i++
+	}
+
+	return fmt.Sprintf("RatelimitUsage{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this GetMutableStateResponse match the
-// provided GetMutableStateResponse.
+// Equals returns true if all the fields of this RatelimitUsage match the
+// provided RatelimitUsage.
 //
 // This function performs a deep comparison.
-func (v *GetMutableStateResponse) Equals(rhs *GetMutableStateResponse) bool {
-	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
-		return false
-	}
-	if !((v.WorkflowType == nil && rhs.WorkflowType == nil) || (v.WorkflowType != nil && rhs.WorkflowType != nil && v.WorkflowType.Equals(rhs.WorkflowType))) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
+func (v *RatelimitUsage) Equals(rhs *RatelimitUsage) bool {
+	if !_String_EqualsPtr(v.Key, rhs.Key) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.LastFirstEventId, rhs.LastFirstEventId) {
-		return false
-	}
-	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
+	if !_I64_EqualsPtr(v.Allowed, rhs.Allowed) {
 		return false
 	}
-	if !((v.StickyTaskList == nil && rhs.StickyTaskList == nil) || (v.StickyTaskList != nil && rhs.StickyTaskList != nil && v.StickyTaskList.Equals(rhs.StickyTaskList))) {
+	if !_I64_EqualsPtr(v.Rejected, rhs.Rejected) {
 		return false
 	}
-	if !_String_EqualsPtr(v.ClientLibraryVersion, rhs.ClientLibraryVersion) {
-		return false
+
+	return true
+}
+
+// GetKey returns the value of Key if it is set or its
+// zero value if it is unset.
+func (v *RatelimitUsage) GetKey() (o string) {
+	if v.Key != nil {
+		return *v.Key
 	}
-	if !_String_EqualsPtr(v.ClientFeatureVersion, rhs.ClientFeatureVersion) {
-		return false
+
+	return
+}
+
+// GetAllowed returns the value of Allowed if it is set or its
+// zero value if it is unset.
+func (v *RatelimitUsage) GetAllowed() (o int64) {
+	if v.Allowed != nil {
+		return *v.Allowed
 	}
-	if !_String_EqualsPtr(v.ClientImpl, rhs.ClientImpl) {
-		return false
+
+	return
+}
+
+// GetRejected returns the value of Rejected if it is set or its
+// zero value if it is unset.
+func (v *RatelimitUsage) GetRejected() (o int64) {
+	if v.Rejected != nil {
+		return *v.Rejected
 	}
-	if !_Bool_EqualsPtr(v.IsWorkflowRunning, rhs.IsWorkflowRunning) {
-		return false
+
+	return
+}
+
+type RecordActivityTaskHeartbeatRequest struct {
+	DomainUUID       *string                                    `json:"domainUUID,omitempty"`
+	HeartbeatRequest *shared.RecordActivityTaskHeartbeatRequest `json:"heartbeatRequest,omitempty"`
+}
+
+// ToWire translates a RecordActivityTaskHeartbeatRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RecordActivityTaskHeartbeatRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
 	}
-	if !_I32_EqualsPtr(v.StickyTaskListScheduleToStartTimeout, rhs.StickyTaskListScheduleToStartTimeout) {
-		return false
+	if v.HeartbeatRequest != nil {
+		w, err = v.HeartbeatRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
 	}
 
-	return true
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// GetExecution returns the value of Execution if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetExecution() (o *shared.WorkflowExecution) {
-	if v.Execution != nil {
-		return v.Execution
-	}
-
-	return
+func _RecordActivityTaskHeartbeatRequest_Read(w wire.Value) (*shared.RecordActivityTaskHeartbeatRequest, error) {
+	var v shared.RecordActivityTaskHeartbeatRequest
+	err := v.FromWire(w)
+	return &v, err
 }
 
-// GetWorkflowType returns the value of WorkflowType if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetWorkflowType() (o *shared.WorkflowType) {
-	if v.WorkflowType != nil {
-		return v.WorkflowType
-	}
-
-	return
-}
+// FromWire deserializes a RecordActivityTaskHeartbeatRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RecordActivityTaskHeartbeatRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RecordActivityTaskHeartbeatRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *RecordActivityTaskHeartbeatRequest) FromWire(w wire.Value) error {
+	var err error
 
-// GetNextEventId returns the value of NextEventId if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetNextEventId() (o int64) {
-	if v.NextEventId != nil {
-		return *v.NextEventId
-	}
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
 
-	return
-}
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.HeartbeatRequest, err = _RecordActivityTaskHeartbeatRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
 
-// GetLastFirstEventId returns the value of LastFirstEventId if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetLastFirstEventId() (o int64) {
-	if v.LastFirstEventId != nil {
-		return *v.LastFirstEventId
+			}
+		}
 	}
 
-	return
+	return nil
 }
 
-// GetTaskList returns the value of TaskList if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetTaskList() (o *shared.TaskList) {
-	if v.TaskList != nil {
-		return v.TaskList
+// String returns a readable string representation of a RecordActivityTaskHeartbeatRequest
+// struct.
+func (v *RecordActivityTaskHeartbeatRequest) String() string {
+	if v == nil {
+		return "<nil>"
 	}
 
-	return
-}
-
-// GetStickyTaskList returns the value of StickyTaskList if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetStickyTaskList() (o *shared.TaskList) {
-	if v.StickyTaskList != nil {
-		return v.StickyTaskList
+	var fields [2]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
 	}
-
-	return
-}
-
-// GetClientLibraryVersion returns the value of ClientLibraryVersion if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetClientLibraryVersion() (o string) {
-	if v.ClientLibraryVersion != nil {
-		return *v.ClientLibraryVersion
+	if v.HeartbeatRequest != nil {
+		fields[i] = fmt.Sprintf("HeartbeatRequest: %v", v.HeartbeatRequest)
+		i++
 	}
 
-	return
+	return fmt.Sprintf("RecordActivityTaskHeartbeatRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// GetClientFeatureVersion returns the value of ClientFeatureVersion if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetClientFeatureVersion() (o string) {
-	if v.ClientFeatureVersion != nil {
-		return *v.ClientFeatureVersion
+// Equals returns true if all the fields of this RecordActivityTaskHeartbeatRequest match the
+// provided RecordActivityTaskHeartbeatRequest.
+//
+// This function performs a deep comparison.
+func (v *RecordActivityTaskHeartbeatRequest) Equals(rhs *RecordActivityTaskHeartbeatRequest) bool {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
 	}
-
-	return
-}
-
-// GetClientImpl returns the value of ClientImpl if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetClientImpl() (o string) {
-	if v.ClientImpl != nil {
-		return *v.ClientImpl
+	if !((v.HeartbeatRequest == nil && rhs.HeartbeatRequest == nil) || (v.HeartbeatRequest != nil && rhs.HeartbeatRequest != nil && v.HeartbeatRequest.Equals(rhs.HeartbeatRequest))) {
+		return false
 	}
 
-	return
+	return true
 }
 
-// GetIsWorkflowRunning returns the value of IsWorkflowRunning if it is set or its
+// GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *GetMutableStateResponse) GetIsWorkflowRunning() (o bool) {
-	if v.IsWorkflowRunning != nil {
-		return *v.IsWorkflowRunning
+func (v *RecordActivityTaskHeartbeatRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
 	}
 
 	return
 }
 
-// GetStickyTaskListScheduleToStartTimeout returns the value of StickyTaskListScheduleToStartTimeout if it is set or its
+// GetHeartbeatRequest returns the value of HeartbeatRequest if it is set or its
 // zero value if it is unset.
-func (v *GetMutableStateResponse) GetStickyTaskListScheduleToStartTimeout() (o int32) {
-	if v.StickyTaskListScheduleToStartTimeout != nil {
-		return *v.StickyTaskListScheduleToStartTimeout
+func (v *RecordActivityTaskHeartbeatRequest) GetHeartbeatRequest() (o *shared.RecordActivityTaskHeartbeatRequest) {
+	if v.HeartbeatRequest != nil {
+		return v.HeartbeatRequest
 	}
 
 	return
 }
 
-type ParentExecutionInfo struct {
-	DomainUUID  *string                   `json:"domainUUID,omitempty"`
-	Domain      *string                   `json:"domain,omitempty"`
-	Execution   *shared.WorkflowExecution `json:"execution,omitempty"`
-	InitiatedId *int64                    `json:"initiatedId,omitempty"`
+type RecordActivityTaskStartedRequest struct {
+	DomainUUID        *string                            `json:"domainUUID,omitempty"`
+	WorkflowExecution *shared.WorkflowExecution          `json:"workflowExecution,omitempty"`
+	ScheduleId        *int64                             `json:"scheduleId,omitempty"`
+	TaskId            *int64                             `json:"taskId,omitempty"`
+	RequestId         *string                            `json:"requestId,omitempty"`
+	PollRequest       *shared.PollForActivityTaskRequest `json:"pollRequest,omitempty"`
 }
 
-// ToWire translates a ParentExecutionInfo struct into a Thrift-level intermediate
+// ToWire translates a RecordActivityTaskStartedRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -1337,9 +3471,9 @@ type ParentExecutionInfo struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *ParentExecutionInfo) ToWire() (wire.Value, error) {
+func (v *RecordActivityTaskStartedRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [6]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -1353,39 +3487,61 @@ func (v *ParentExecutionInfo) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.Domain != nil {
-		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 15, Value: w}
+		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.Execution != nil {
-		w, err = v.Execution.ToWire()
+	if v.ScheduleId != nil {
+		w, err = wire.NewValueI64(*(v.ScheduleId)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 20, Value: w}
+		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.InitiatedId != nil {
-		w, err = wire.NewValueI64(*(v.InitiatedId)), error(nil)
+	if v.TaskId != nil {
+		w, err = wire.NewValueI64(*(v.TaskId)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 30, Value: w}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.RequestId != nil {
+		w, err = wire.NewValueString(*(v.RequestId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 45, Value: w}
+		i++
+	}
+	if v.PollRequest != nil {
+		w, err = v.PollRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a ParentExecutionInfo struct from its Thrift-level
+func _PollForActivityTaskRequest_Read(w wire.Value) (*shared.PollForActivityTaskRequest, error) {
+	var v shared.PollForActivityTaskRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a RecordActivityTaskStartedRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a ParentExecutionInfo struct
+// An error is returned if we were unable to build a RecordActivityTaskStartedRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -1393,12 +3549,12 @@ func (v *ParentExecutionInfo) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v ParentExecutionInfo
+//   var v RecordActivityTaskStartedRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *ParentExecutionInfo) FromWire(w wire.Value) error {
+func (v *RecordActivityTaskStartedRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -1413,29 +3569,47 @@ func (v *ParentExecutionInfo) FromWire(w wire.Value) error {
 				}
 
 			}
-		case 15:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.Domain = &x
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.Execution, err = _WorkflowExecution_Read(field.Value)
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ScheduleId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.TaskId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 45:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RequestId = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 30:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.InitiatedId = &x
+		case 50:
+			if field.Value.Type() == wire.TStruct {
+				v.PollRequest, err = _PollForActivityTaskRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -1447,50 +3621,64 @@ func (v *ParentExecutionInfo) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a ParentExecutionInfo
+// String returns a readable string representation of a RecordActivityTaskStartedRequest
 // struct.
-func (v *ParentExecutionInfo) String() string {
+func (v *RecordActivityTaskStartedRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [6]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.Domain != nil {
-		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
 		i++
 	}
-	if v.Execution != nil {
-		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+	if v.ScheduleId != nil {
+		fields[i] = fmt.Sprintf("ScheduleId: %v", *(v.ScheduleId))
 		i++
 	}
-	if v.InitiatedId != nil {
-		fields[i] = fmt.Sprintf("InitiatedId: %v", *(v.InitiatedId))
+	if v.TaskId != nil {
+		fields[i] = fmt.Sprintf("TaskId: %v", *(v.TaskId))
+		i++
+	}
+	if v.RequestId != nil {
+		fields[i] = fmt.Sprintf("RequestId: %v", *(v.RequestId))
+		i++
+	}
+	if v.PollRequest != nil {
+		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
 		i++
 	}
 
-	return fmt.Sprintf("ParentExecutionInfo{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RecordActivityTaskStartedRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this ParentExecutionInfo match the
-// provided ParentExecutionInfo.
+// Equals returns true if all the fields of this RecordActivityTaskStartedRequest match the
+// provided RecordActivityTaskStartedRequest.
 //
 // This function performs a deep comparison.
-func (v *ParentExecutionInfo) Equals(rhs *ParentExecutionInfo) bool {
+func (v *RecordActivityTaskStartedRequest) Equals(rhs *RecordActivityTaskStartedRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
 		return false
 	}
-	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+	if !_I64_EqualsPtr(v.ScheduleId, rhs.ScheduleId) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.InitiatedId, rhs.InitiatedId) {
+	if !_I64_EqualsPtr(v.TaskId, rhs.TaskId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.RequestId, rhs.RequestId) {
+		return false
+	}
+	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
 		return false
 	}
 
@@ -1499,7 +3687,7 @@ func (v *ParentExecutionInfo) Equals(rhs *ParentExecutionInfo) bool {
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *ParentExecutionInfo) GetDomainUUID() (o string) {
+func (v *RecordActivityTaskStartedRequest) GetDomainUUID() (o string) {
 	if v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -1507,42 +3695,64 @@ func (v *ParentExecutionInfo) GetDomainUUID() (o string) {
 	return
 }
 
-// GetDomain returns the value of Domain if it is set or its
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
 // zero value if it is unset.
-func (v *ParentExecutionInfo) GetDomain() (o string) {
-	if v.Domain != nil {
-		return *v.Domain
+func (v *RecordActivityTaskStartedRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
+	if v.WorkflowExecution != nil {
+		return v.WorkflowExecution
 	}
 
 	return
 }
 
-// GetExecution returns the value of Execution if it is set or its
+// GetScheduleId returns the value of ScheduleId if it is set or its
 // zero value if it is unset.
-func (v *ParentExecutionInfo) GetExecution() (o *shared.WorkflowExecution) {
-	if v.Execution != nil {
-		return v.Execution
+func (v *RecordActivityTaskStartedRequest) GetScheduleId() (o int64) {
+	if v.ScheduleId != nil {
+		return *v.ScheduleId
 	}
 
 	return
 }
 
-// GetInitiatedId returns the value of InitiatedId if it is set or its
+// GetTaskId returns the value of TaskId if it is set or its
 // zero value if it is unset.
-func (v *ParentExecutionInfo) GetInitiatedId() (o int64) {
-	if v.InitiatedId != nil {
-		return *v.InitiatedId
+func (v *RecordActivityTaskStartedRequest) GetTaskId() (o int64) {
+	if v.TaskId != nil {
+		return *v.TaskId
 	}
 
 	return
 }
 
-type RecordActivityTaskHeartbeatRequest struct {
-	DomainUUID       *string                                    `json:"domainUUID,omitempty"`
-	HeartbeatRequest *shared.RecordActivityTaskHeartbeatRequest `json:"heartbeatRequest,omitempty"`
+// GetRequestId returns the value of RequestId if it is set or its
+// zero value if it is unset.
+func (v *RecordActivityTaskStartedRequest) GetRequestId() (o string) {
+	if v.RequestId != nil {
+		return *v.RequestId
+	}
+
+	return
 }
 
-// ToWire translates a RecordActivityTaskHeartbeatRequest struct into a Thrift-level intermediate
+// GetPollRequest returns the value of PollRequest if it is set or its
+// zero value if it is unset.
+func (v *RecordActivityTaskStartedRequest) GetPollRequest() (o *shared.PollForActivityTaskRequest) {
+	if v.PollRequest != nil {
+		return v.PollRequest
+	}
+
+	return
+}
+
+type RecordActivityTaskStartedResponse struct {
+	ScheduledEvent                  *shared.HistoryEvent `json:"scheduledEvent,omitempty"`
+	StartedTimestamp                *int64               `json:"startedTimestamp,omitempty"`
+	Attempt                         *int64               `json:"attempt,omitempty"`
+	ScheduledTimestampOfThisAttempt *int64               `json:"scheduledTimestampOfThisAttempt,omitempty"`
+}
+
+// ToWire translates a RecordActivityTaskStartedResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -1557,45 +3767,61 @@ type RecordActivityTaskHeartbeatRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordActivityTaskHeartbeatRequest) ToWire() (wire.Value, error) {
+func (v *RecordActivityTaskStartedResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.DomainUUID != nil {
-		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+	if v.ScheduledEvent != nil {
+		w, err = v.ScheduledEvent.ToWire()
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 10, Value: w}
+		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.HeartbeatRequest != nil {
-		w, err = v.HeartbeatRequest.ToWire()
+	if v.StartedTimestamp != nil {
+		w, err = wire.NewValueI64(*(v.StartedTimestamp)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 20, Value: w}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Attempt != nil {
+		w, err = wire.NewValueI64(*(v.Attempt)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.ScheduledTimestampOfThisAttempt != nil {
+		w, err = wire.NewValueI64(*(v.ScheduledTimestampOfThisAttempt)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _RecordActivityTaskHeartbeatRequest_Read(w wire.Value) (*shared.RecordActivityTaskHeartbeatRequest, error) {
-	var v shared.RecordActivityTaskHeartbeatRequest
+func _HistoryEvent_Read(w wire.Value) (*shared.HistoryEvent, error) {
+	var v shared.HistoryEvent
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a RecordActivityTaskHeartbeatRequest struct from its Thrift-level
+// FromWire deserializes a RecordActivityTaskStartedResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordActivityTaskHeartbeatRequest struct
+// An error is returned if we were unable to build a RecordActivityTaskStartedResponse struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -1603,29 +3829,49 @@ func _RecordActivityTaskHeartbeatRequest_Read(w wire.Value) (*shared.RecordActiv
 //     return nil, err
 //   }
 //
-//   var v RecordActivityTaskHeartbeatRequest
+//   var v RecordActivityTaskStartedResponse
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordActivityTaskHeartbeatRequest) FromWire(w wire.Value) error {
+func (v *RecordActivityTaskStartedResponse) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
-		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.DomainUUID = &x
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.ScheduledEvent, err = _HistoryEvent_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.HeartbeatRequest, err = _RecordActivityTaskHeartbeatRequest_Read(field.Value)
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartedTimestamp = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Attempt = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ScheduledTimestampOfThisAttempt = &x
 				if err != nil {
 					return err
 				}
@@ -1637,72 +3883,110 @@ func (v *RecordActivityTaskHeartbeatRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RecordActivityTaskHeartbeatRequest
+// String returns a readable string representation of a RecordActivityTaskStartedResponse
 // struct.
-func (v *RecordActivityTaskHeartbeatRequest) String() string {
+func (v *RecordActivityTaskStartedResponse) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [2]string
+	var fields [4]string
 	i := 0
-	if v.DomainUUID != nil {
-		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+	if v.ScheduledEvent != nil {
+		fields[i] = fmt.Sprintf("ScheduledEvent: %v", v.ScheduledEvent)
 		i++
 	}
-	if v.HeartbeatRequest != nil {
-		fields[i] = fmt.Sprintf("HeartbeatRequest: %v", v.HeartbeatRequest)
+	if v.StartedTimestamp != nil {
+		fields[i] = fmt.Sprintf("StartedTimestamp: %v", *(v.StartedTimestamp))
+		i++
+	}
+	if v.Attempt != nil {
+		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
+		i++
+	}
+	if v.ScheduledTimestampOfThisAttempt != nil {
+		fields[i] = fmt.Sprintf("ScheduledTimestampOfThisAttempt: %v", *(v.ScheduledTimestampOfThisAttempt))
 		i++
 	}
 
-	return fmt.Sprintf("RecordActivityTaskHeartbeatRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RecordActivityTaskStartedResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordActivityTaskHeartbeatRequest match the
-// provided RecordActivityTaskHeartbeatRequest.
+// Equals returns true if all the fields of this RecordActivityTaskStartedResponse match the
+// provided RecordActivityTaskStartedResponse.
 //
 // This function performs a deep comparison.
-func (v *RecordActivityTaskHeartbeatRequest) Equals(rhs *RecordActivityTaskHeartbeatRequest) bool {
-	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+func (v *RecordActivityTaskStartedResponse) Equals(rhs *RecordActivityTaskStartedResponse) bool {
+	if !((v.ScheduledEvent == nil && rhs.ScheduledEvent == nil) || (v.ScheduledEvent != nil && rhs.ScheduledEvent != nil && v.ScheduledEvent.Equals(rhs.ScheduledEvent))) {
 		return false
 	}
-	if !((v.HeartbeatRequest == nil && rhs.HeartbeatRequest == nil) || (v.HeartbeatRequest != nil && rhs.HeartbeatRequest != nil && v.HeartbeatRequest.Equals(rhs.HeartbeatRequest))) {
+	if !_I64_EqualsPtr(v.StartedTimestamp, rhs.StartedTimestamp) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Attempt, rhs.Attempt) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ScheduledTimestampOfThisAttempt, rhs.ScheduledTimestampOfThisAttempt) {
 		return false
 	}
 
 	return true
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
+// GetScheduledEvent returns the value of ScheduledEvent if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskHeartbeatRequest) GetDomainUUID() (o string) {
-	if v.DomainUUID != nil {
-		return *v.DomainUUID
+func (v *RecordActivityTaskStartedResponse) GetScheduledEvent() (o *shared.HistoryEvent) {
+	if v.ScheduledEvent != nil {
+		return v.ScheduledEvent
+	}
+
+	return
+}
+
+// GetStartedTimestamp returns the value of StartedTimestamp if it is set or its
+// zero value if it is unset.
+func (v *RecordActivityTaskStartedResponse) GetStartedTimestamp() (o int64) {
+	if v.StartedTimestamp != nil {
+		return *v.StartedTimestamp
+	}
+
+	return
+}
+
+// GetAttempt returns the value of Attempt if it is set or its
+// zero value if it is unset.
+func (v *RecordActivityTaskStartedResponse) GetAttempt() (o int64) {
+	if v.Attempt != nil {
+		return *v.Attempt
 	}
 
 	return
 }
 
-// GetHeartbeatRequest returns the value of HeartbeatRequest if it is set or its
+// GetScheduledTimestampOfThisAttempt returns the value of ScheduledTimestampOfThisAttempt if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskHeartbeatRequest) GetHeartbeatRequest() (o *shared.RecordActivityTaskHeartbeatRequest) {
-	if v.HeartbeatRequest != nil {
-		return v.HeartbeatRequest
+func (v *RecordActivityTaskStartedResponse) GetScheduledTimestampOfThisAttempt() (o int64) {
+	if v.ScheduledTimestampOfThisAttempt != nil {
+		return *v.ScheduledTimestampOfThisAttempt
 	}
 
 	return
 }
 
-type RecordActivityTaskStartedRequest struct {
-	DomainUUID        *string                            `json:"domainUUID,omitempty"`
-	WorkflowExecution *shared.WorkflowExecution          `json:"workflowExecution,omitempty"`
-	ScheduleId        *int64                             `json:"scheduleId,omitempty"`
-	TaskId            *int64                             `json:"taskId,omitempty"`
-	RequestId         *string                            `json:"requestId,omitempty"`
-	PollRequest       *shared.PollForActivityTaskRequest `json:"pollRequest,omitempty"`
+// RecordChildExecutionCompletedRequest is used for reporting the completion of child execution to parent workflow
+// execution which started it.  When a child execution is completed it creates this request and calls the
+// RecordChildExecutionCompleted API with the workflowExecution of parent.  It also sets the completedExecution of the
+// child as it could potentially be different than the ChildExecutionStartedEvent of parent in the situation when
+// child creates multiple runs through ContinueAsNew before finally completing.
+type RecordChildExecutionCompletedRequest struct {
+	DomainUUID         *string                   `json:"domainUUID,omitempty"`
+	WorkflowExecution  *shared.WorkflowExecution `json:"workflowExecution,omitempty"`
+	InitiatedId        *int64                    `json:"initiatedId,omitempty"`
+	CompletedExecution *shared.WorkflowExecution `json:"completedExecution,omitempty"`
+	CompletionEvent    *shared.HistoryEvent      `json:"completionEvent,omitempty"`
 }
 
-// ToWire translates a RecordActivityTaskStartedRequest struct into a Thrift-level intermediate
+// ToWire translates a RecordChildExecutionCompletedRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -1717,9 +4001,9 @@ type RecordActivityTaskStartedRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordActivityTaskStartedRequest) ToWire() (wire.Value, error) {
+func (v *RecordChildExecutionCompletedRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -1741,32 +4025,24 @@ func (v *RecordActivityTaskStartedRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.ScheduleId != nil {
-		w, err = wire.NewValueI64(*(v.ScheduleId)), error(nil)
+	if v.InitiatedId != nil {
+		w, err = wire.NewValueI64(*(v.InitiatedId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.TaskId != nil {
-		w, err = wire.NewValueI64(*(v.TaskId)), error(nil)
+	if v.CompletedExecution != nil {
+		w, err = v.CompletedExecution.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
-	if v.RequestId != nil {
-		w, err = wire.NewValueString(*(v.RequestId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 45, Value: w}
-		i++
-	}
-	if v.PollRequest != nil {
-		w, err = v.PollRequest.ToWire()
+	if v.CompletionEvent != nil {
+		w, err = v.CompletionEvent.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -1777,17 +4053,11 @@ func (v *RecordActivityTaskStartedRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _PollForActivityTaskRequest_Read(w wire.Value) (*shared.PollForActivityTaskRequest, error) {
-	var v shared.PollForActivityTaskRequest
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a RecordActivityTaskStartedRequest struct from its Thrift-level
+// FromWire deserializes a RecordChildExecutionCompletedRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordActivityTaskStartedRequest struct
+// An error is returned if we were unable to build a RecordChildExecutionCompletedRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -1795,12 +4065,12 @@ func _PollForActivityTaskRequest_Read(w wire.Value) (*shared.PollForActivityTask
 //     return nil, err
 //   }
 //
-//   var v RecordActivityTaskStartedRequest
+//   var v RecordChildExecutionCompletedRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordActivityTaskStartedRequest) FromWire(w wire.Value) error {
+func (v *RecordChildExecutionCompletedRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -1827,27 +4097,15 @@ func (v *RecordActivityTaskStartedRequest) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.ScheduleId = &x
+				v.InitiatedId = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 40:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.TaskId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 45:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.RequestId = &x
+			if field.Value.Type() == wire.TStruct {
+				v.CompletedExecution, err = _WorkflowExecution_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -1855,7 +4113,7 @@ func (v *RecordActivityTaskStartedRequest) FromWire(w wire.Value) error {
 			}
 		case 50:
 			if field.Value.Type() == wire.TStruct {
-				v.PollRequest, err = _PollForActivityTaskRequest_Read(field.Value)
+				v.CompletionEvent, err = _HistoryEvent_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -1867,14 +4125,14 @@ func (v *RecordActivityTaskStartedRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RecordActivityTaskStartedRequest
+// String returns a readable string representation of a RecordChildExecutionCompletedRequest
 // struct.
-func (v *RecordActivityTaskStartedRequest) String() string {
+func (v *RecordChildExecutionCompletedRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [5]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
@@ -1884,47 +4142,40 @@ func (v *RecordActivityTaskStartedRequest) String() string {
 		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
 		i++
 	}
-	if v.ScheduleId != nil {
-		fields[i] = fmt.Sprintf("ScheduleId: %v", *(v.ScheduleId))
-		i++
-	}
-	if v.TaskId != nil {
-		fields[i] = fmt.Sprintf("TaskId: %v", *(v.TaskId))
+	if v.InitiatedId != nil {
+		fields[i] = fmt.Sprintf("InitiatedId: %v", *(v.InitiatedId))
 		i++
 	}
-	if v.RequestId != nil {
-		fields[i] = fmt.Sprintf("RequestId: %v", *(v.RequestId))
+	if v.CompletedExecution != nil {
+		fields[i] = fmt.Sprintf("CompletedExecution: %v", v.CompletedExecution)
 		i++
 	}
-	if v.PollRequest != nil {
-		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
+	if v.CompletionEvent != nil {
+		fields[i] = fmt.Sprintf("CompletionEvent: %v", v.CompletionEvent)
 		i++
 	}
 
-	return fmt.Sprintf("RecordActivityTaskStartedRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RecordChildExecutionCompletedRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordActivityTaskStartedRequest match the
-// provided RecordActivityTaskStartedRequest.
+// Equals returns true if all the fields of this RecordChildExecutionCompletedRequest match the
+// provided RecordChildExecutionCompletedRequest.
 //
 // This function performs a deep comparison.
-func (v *RecordActivityTaskStartedRequest) Equals(rhs *RecordActivityTaskStartedRequest) bool {
+func (v *RecordChildExecutionCompletedRequest) Equals(rhs *RecordChildExecutionCompletedRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
 	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.ScheduleId, rhs.ScheduleId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.TaskId, rhs.TaskId) {
+	if !_I64_EqualsPtr(v.InitiatedId, rhs.InitiatedId) {
 		return false
 	}
-	if !_String_EqualsPtr(v.RequestId, rhs.RequestId) {
+	if !((v.CompletedExecution == nil && rhs.CompletedExecution == nil) || (v.CompletedExecution != nil && rhs.CompletedExecution != nil && v.CompletedExecution.Equals(rhs.CompletedExecution))) {
 		return false
 	}
-	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
+	if !((v.CompletionEvent == nil && rhs.CompletionEvent == nil) || (v.CompletionEvent != nil && rhs.CompletionEvent != nil && v.CompletionEvent.Equals(rhs.CompletionEvent))) {
 		return false
 	}
 
@@ -1933,7 +4184,7 @@ func (v *RecordActivityTaskStartedRequest) Equals(rhs *RecordActivityTaskStarted
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedRequest) GetDomainUUID() (o string) {
+func (v *RecordChildExecutionCompletedRequest) GetDomainUUID() (o string) {
 	if v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -1943,7 +4194,7 @@ func (v *RecordActivityTaskStartedRequest) GetDomainUUID() (o string) {
 
 // GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
+func (v *RecordChildExecutionCompletedRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
 	if v.WorkflowExecution != nil {
 		return v.WorkflowExecution
 	}
@@ -1951,54 +4202,46 @@ func (v *RecordActivityTaskStartedRequest) GetWorkflowExecution() (o *shared.Wor
 	return
 }
 
-// GetScheduleId returns the value of ScheduleId if it is set or its
-// zero value if it is unset.
-func (v *RecordActivityTaskStartedRequest) GetScheduleId() (o int64) {
-	if v.ScheduleId != nil {
-		return *v.ScheduleId
-	}
-
-	return
-}
-
-// GetTaskId returns the value of TaskId if it is set or its
+// GetInitiatedId returns the value of InitiatedId if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedRequest) GetTaskId() (o int64) {
-	if v.TaskId != nil {
-		return *v.TaskId
+func (v *RecordChildExecutionCompletedRequest) GetInitiatedId() (o int64) {
+	if v.InitiatedId != nil {
+		return *v.InitiatedId
 	}
 
 	return
 }
 
-// GetRequestId returns the value of RequestId if it is set or its
+// GetCompletedExecution returns the value of CompletedExecution if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedRequest) GetRequestId() (o string) {
-	if v.RequestId != nil {
-		return *v.RequestId
+func (v *RecordChildExecutionCompletedRequest) GetCompletedExecution() (o *shared.WorkflowExecution) {
+	if v.CompletedExecution != nil {
+		return v.CompletedExecution
 	}
 
 	return
 }
 
-// GetPollRequest returns the value of PollRequest if it is set or its
+// GetCompletionEvent returns the value of CompletionEvent if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedRequest) GetPollRequest() (o *shared.PollForActivityTaskRequest) {
-	if v.PollRequest != nil {
-		return v.PollRequest
+func (v *RecordChildExecutionCompletedRequest) GetCompletionEvent() (o *shared.HistoryEvent) {
+	if v.CompletionEvent != nil {
+		return v.CompletionEvent
 	}
 
 	return
 }
 
-type RecordActivityTaskStartedResponse struct {
-	ScheduledEvent                  *shared.HistoryEvent `json:"scheduledEvent,omitempty"`
-	StartedTimestamp                *int64               `json:"startedTimestamp,omitempty"`
-	Attempt                         *int64               `json:"attempt,omitempty"`
-	ScheduledTimestampOfThisAttempt *int64               `json:"scheduledTimestampOfThisAttempt,omitempty"`
+type RecordDecisionTaskStartedRequest struct {
+	DomainUUID        *string                            `json:"domainUUID,omitempty"`
+	WorkflowExecution *shared.WorkflowExecution          `json:"workflowExecution,omitempty"`
+	ScheduleId        *int64                             `json:"scheduleId,omitempty"`
+	TaskId            *int64                             `json:"taskId,omitempty"`
+	RequestId         *string                            `json:"requestId,omitempty"`
+	PollRequest       *shared.PollForDecisionTaskRequest `json:"pollRequest,omitempty"`
 }
 
-// ToWire translates a RecordActivityTaskStartedResponse struct into a Thrift-level intermediate
+// ToWire translates a RecordDecisionTaskStartedRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -2013,40 +4256,56 @@ type RecordActivityTaskStartedResponse struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordActivityTaskStartedResponse) ToWire() (wire.Value, error) {
+func (v *RecordDecisionTaskStartedRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [6]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.ScheduledEvent != nil {
-		w, err = v.ScheduledEvent.ToWire()
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.StartedTimestamp != nil {
-		w, err = wire.NewValueI64(*(v.StartedTimestamp)), error(nil)
+	if v.ScheduleId != nil {
+		w, err = wire.NewValueI64(*(v.ScheduleId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.Attempt != nil {
-		w, err = wire.NewValueI64(*(v.Attempt)), error(nil)
+	if v.TaskId != nil {
+		w, err = wire.NewValueI64(*(v.TaskId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
-	if v.ScheduledTimestampOfThisAttempt != nil {
-		w, err = wire.NewValueI64(*(v.ScheduledTimestampOfThisAttempt)), error(nil)
+	if v.RequestId != nil {
+		w, err = wire.NewValueString(*(v.RequestId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 45, Value: w}
+		i++
+	}
+	if v.PollRequest != nil {
+		w, err = v.PollRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -2057,17 +4316,17 @@ func (v *RecordActivityTaskStartedResponse) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _HistoryEvent_Read(w wire.Value) (*shared.HistoryEvent, error) {
-	var v shared.HistoryEvent
+func _PollForDecisionTaskRequest_Read(w wire.Value) (*shared.PollForDecisionTaskRequest, error) {
+	var v shared.PollForDecisionTaskRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a RecordActivityTaskStartedResponse struct from its Thrift-level
+// FromWire deserializes a RecordDecisionTaskStartedRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordActivityTaskStartedResponse struct
+// An error is returned if we were unable to build a RecordDecisionTaskStartedRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -2075,19 +4334,29 @@ func _HistoryEvent_Read(w wire.Value) (*shared.HistoryEvent, error) {
 //     return nil, err
 //   }
 //
-//   var v RecordActivityTaskStartedResponse
+//   var v RecordDecisionTaskStartedRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordActivityTaskStartedResponse) FromWire(w wire.Value) error {
+func (v *RecordDecisionTaskStartedRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.ScheduledEvent, err = _HistoryEvent_Read(field.Value)
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -2097,7 +4366,7 @@ func (v *RecordActivityTaskStartedResponse) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.StartedTimestamp = &x
+				v.ScheduleId = &x
 				if err != nil {
 					return err
 				}
@@ -2107,17 +4376,25 @@ func (v *RecordActivityTaskStartedResponse) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.Attempt = &x
+				v.TaskId = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 50:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.ScheduledTimestampOfThisAttempt = &x
+		case 45:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RequestId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TStruct {
+				v.PollRequest, err = _PollForDecisionTaskRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -2129,110 +4406,144 @@ func (v *RecordActivityTaskStartedResponse) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RecordActivityTaskStartedResponse
+// String returns a readable string representation of a RecordDecisionTaskStartedRequest
 // struct.
-func (v *RecordActivityTaskStartedResponse) String() string {
+func (v *RecordDecisionTaskStartedRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [6]string
 	i := 0
-	if v.ScheduledEvent != nil {
-		fields[i] = fmt.Sprintf("ScheduledEvent: %v", v.ScheduledEvent)
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.StartedTimestamp != nil {
-		fields[i] = fmt.Sprintf("StartedTimestamp: %v", *(v.StartedTimestamp))
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
 		i++
 	}
-	if v.Attempt != nil {
-		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
+	if v.ScheduleId != nil {
+		fields[i] = fmt.Sprintf("ScheduleId: %v", *(v.ScheduleId))
 		i++
 	}
-	if v.ScheduledTimestampOfThisAttempt != nil {
-		fields[i] = fmt.Sprintf("ScheduledTimestampOfThisAttempt: %v", *(v.ScheduledTimestampOfThisAttempt))
+	if v.TaskId != nil {
+		fields[i] = fmt.Sprintf("TaskId: %v", *(v.TaskId))
+		i++
+	}
+	if v.RequestId != nil {
+		fields[i] = fmt.Sprintf("RequestId: %v", *(v.RequestId))
+		i++
+	}
+	if v.PollRequest != nil {
+		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
 		i++
 	}
 
-	return fmt.Sprintf("RecordActivityTaskStartedResponse{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RecordDecisionTaskStartedRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordActivityTaskStartedResponse match the
-// provided RecordActivityTaskStartedResponse.
+// Equals returns true if all the fields of this RecordDecisionTaskStartedRequest match the
+// provided RecordDecisionTaskStartedRequest.
 //
 // This function performs a deep comparison.
-func (v *RecordActivityTaskStartedResponse) Equals(rhs *RecordActivityTaskStartedResponse) bool {
-	if !((v.ScheduledEvent == nil && rhs.ScheduledEvent == nil) || (v.ScheduledEvent != nil && rhs.ScheduledEvent != nil && v.ScheduledEvent.Equals(rhs.ScheduledEvent))) {
+func (v *RecordDecisionTaskStartedRequest) Equals(rhs *RecordDecisionTaskStartedRequest) bool {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.StartedTimestamp, rhs.StartedTimestamp) {
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.Attempt, rhs.Attempt) {
+	if !_I64_EqualsPtr(v.ScheduleId, rhs.ScheduleId) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.ScheduledTimestampOfThisAttempt, rhs.ScheduledTimestampOfThisAttempt) {
+	if !_I64_EqualsPtr(v.TaskId, rhs.TaskId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.RequestId, rhs.RequestId) {
+		return false
+	}
+	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
 		return false
 	}
 
 	return true
 }
 
-// GetScheduledEvent returns the value of ScheduledEvent if it is set or its
+// GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedResponse) GetScheduledEvent() (o *shared.HistoryEvent) {
-	if v.ScheduledEvent != nil {
-		return v.ScheduledEvent
+func (v *RecordDecisionTaskStartedRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
 	}
 
 	return
 }
 
-// GetStartedTimestamp returns the value of StartedTimestamp if it is set or its
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedResponse) GetStartedTimestamp() (o int64) {
-	if v.StartedTimestamp != nil {
-		return *v.StartedTimestamp
+func (v *RecordDecisionTaskStartedRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
+	if v.WorkflowExecution != nil {
+		return v.WorkflowExecution
 	}
 
 	return
 }
 
-// GetAttempt returns the value of Attempt if it is set or its
+// GetScheduleId returns the value of ScheduleId if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedResponse) GetAttempt() (o int64) {
-	if v.Attempt != nil {
-		return *v.Attempt
+func (v *RecordDecisionTaskStartedRequest) GetScheduleId() (o int64) {
+	if v.ScheduleId != nil {
+		return *v.ScheduleId
 	}
 
 	return
 }
 
-// GetScheduledTimestampOfThisAttempt returns the value of ScheduledTimestampOfThisAttempt if it is set or its
+// GetTaskId returns the value of TaskId if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskStartedResponse) GetScheduledTimestampOfThisAttempt() (o int64) {
-	if v.ScheduledTimestampOfThisAttempt != nil {
-		return *v.ScheduledTimestampOfThisAttempt
+func (v *RecordDecisionTaskStartedRequest) GetTaskId() (o int64) {
+	if v.TaskId != nil {
+		return *v.TaskId
 	}
 
 	return
 }
 
-// RecordChildExecutionCompletedRequest is used for reporting the completion of child execution to parent workflow
-// execution which started it.  When a child execution is completed it creates this request and calls the
-// RecordChildExecutionCompleted API with the workflowExecution of parent.  It also sets the completedExecution of the
-// child as it could potentially be different than the ChildExecutionStartedEvent of parent in the situation when
-// child creates multiple runs through ContinueAsNew before finally completing.
-type RecordChildExecutionCompletedRequest struct {
-	DomainUUID         *string                   `json:"domainUUID,omitempty"`
-	WorkflowExecution  *shared.WorkflowExecution `json:"workflowExecution,omitempty"`
-	InitiatedId        *int64                    `json:"initiatedId,omitempty"`
-	CompletedExecution *shared.WorkflowExecution `json:"completedExecution,omitempty"`
-	CompletionEvent    *shared.HistoryEvent      `json:"completionEvent,omitempty"`
+// GetRequestId returns the value of RequestId if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedRequest) GetRequestId() (o string) {
+	if v.RequestId != nil {
+		return *v.RequestId
+	}
+
+	return
 }
 
-// ToWire translates a RecordChildExecutionCompletedRequest struct into a Thrift-level intermediate
+// GetPollRequest returns the value of PollRequest if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedRequest) GetPollRequest() (o *shared.PollForDecisionTaskRequest) {
+	if v.PollRequest != nil {
+		return v.PollRequest
+	}
+
+	return
+}
+
+type RecordDecisionTaskStartedResponse struct {
+	WorkflowType            *shared.WorkflowType          `json:"workflowType,omitempty"`
+	PreviousStartedEventId  *int64                        `json:"previousStartedEventId,omitempty"`
+	ScheduledEventId        *int64                        `json:"scheduledEventId,omitempty"`
+	StartedEventId          *int64                        `json:"startedEventId,omitempty"`
+	NextEventId             *int64                        `json:"nextEventId,omitempty"`
+	Attempt                 *int64                        `json:"attempt,omitempty"`
+	StickyExecutionEnabled  *bool                         `json:"stickyExecutionEnabled,omitempty"`
+	DecisionInfo            *shared.TransientDecisionInfo `json:"decisionInfo,omitempty"`
+	StickyInvalidationCount *int64                        `json:"stickyInvalidationCount,omitempty"`
+	ContinueAsNewSuggested  *bool                         `json:"continueAsNewSuggested,omitempty"`
+}
+
+// ToWire translates a RecordDecisionTaskStartedResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -2247,63 +4558,109 @@ type RecordChildExecutionCompletedRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordChildExecutionCompletedRequest) ToWire() (wire.Value, error) {
+func (v *RecordDecisionTaskStartedResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [10]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.DomainUUID != nil {
-		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+	if v.WorkflowType != nil {
+		w, err = v.WorkflowType.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		w, err = v.WorkflowExecution.ToWire()
+	if v.PreviousStartedEventId != nil {
+		w, err = wire.NewValueI64(*(v.PreviousStartedEventId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.InitiatedId != nil {
-		w, err = wire.NewValueI64(*(v.InitiatedId)), error(nil)
+	if v.ScheduledEventId != nil {
+		w, err = wire.NewValueI64(*(v.ScheduledEventId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.CompletedExecution != nil {
-		w, err = v.CompletedExecution.ToWire()
+	if v.StartedEventId != nil {
+		w, err = wire.NewValueI64(*(v.StartedEventId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
-	if v.CompletionEvent != nil {
-		w, err = v.CompletionEvent.ToWire()
+	if v.NextEventId != nil {
+		w, err = wire.NewValueI64(*(v.NextEventId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
+	if v.Attempt != nil {
+		w, err = wire.NewValueI64(*(v.Attempt)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.StickyExecutionEnabled != nil {
+		w, err = wire.NewValueBool(*(v.StickyExecutionEnabled)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+	if v.DecisionInfo != nil {
+		w, err = v.DecisionInfo.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
+	if v.StickyInvalidationCount != nil {
+		w, err = wire.NewValueI64(*(v.StickyInvalidationCount)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 90, Value: w}
+		i++
+	}
+	if v.ContinueAsNewSuggested != nil {
+		w, err = wire.NewValueBool(*(v.ContinueAsNewSuggested)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 100, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a RecordChildExecutionCompletedRequest struct from its Thrift-level
+func _TransientDecisionInfo_Read(w wire.Value) (*shared.TransientDecisionInfo, error) {
+	var v shared.TransientDecisionInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a RecordDecisionTaskStartedResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordChildExecutionCompletedRequest struct
+// An error is returned if we were unable to build a RecordDecisionTaskStartedResponse struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -2311,29 +4668,29 @@ func (v *RecordChildExecutionCompletedRequest) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v RecordChildExecutionCompletedRequest
+//   var v RecordDecisionTaskStartedResponse
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordChildExecutionCompletedRequest) FromWire(w wire.Value) error {
+func (v *RecordDecisionTaskStartedResponse) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.DomainUUID = &x
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowType, err = _WorkflowType_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.PreviousStartedEventId = &x
 				if err != nil {
 					return err
 				}
@@ -2343,23 +4700,75 @@ func (v *RecordChildExecutionCompletedRequest) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.InitiatedId = &x
+				v.ScheduledEventId = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartedEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.NextEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Attempt = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.StickyExecutionEnabled = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 80:
 			if field.Value.Type() == wire.TStruct {
-				v.CompletedExecution, err = _WorkflowExecution_Read(field.Value)
+				v.DecisionInfo, err = _TransientDecisionInfo_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 90:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StickyInvalidationCount = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 50:
-			if field.Value.Type() == wire.TStruct {
-				v.CompletionEvent, err = _HistoryEvent_Read(field.Value)
+		case 100:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.ContinueAsNewSuggested = &x
 				if err != nil {
 					return err
 				}
@@ -2371,123 +4780,205 @@ func (v *RecordChildExecutionCompletedRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RecordChildExecutionCompletedRequest
+// String returns a readable string representation of a RecordDecisionTaskStartedResponse
 // struct.
-func (v *RecordChildExecutionCompletedRequest) String() string {
+func (v *RecordDecisionTaskStartedResponse) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [10]string
 	i := 0
-	if v.DomainUUID != nil {
-		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+	if v.WorkflowType != nil {
+		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+	if v.PreviousStartedEventId != nil {
+		fields[i] = fmt.Sprintf("PreviousStartedEventId: %v", *(v.PreviousStartedEventId))
 		i++
 	}
-	if v.InitiatedId != nil {
-		fields[i] = fmt.Sprintf("InitiatedId: %v", *(v.InitiatedId))
+	if v.ScheduledEventId != nil {
+		fields[i] = fmt.Sprintf("ScheduledEventId: %v", *(v.ScheduledEventId))
 		i++
 	}
-	if v.CompletedExecution != nil {
-		fields[i] = fmt.Sprintf("CompletedExecution: %v", v.CompletedExecution)
+	if v.StartedEventId != nil {
+		fields[i] = fmt.Sprintf("StartedEventId: %v", *(v.StartedEventId))
 		i++
 	}
-	if v.CompletionEvent != nil {
-		fields[i] = fmt.Sprintf("CompletionEvent: %v", v.CompletionEvent)
+	if v.NextEventId != nil {
+		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
+		i++
+	}
+	if v.Attempt != nil {
+		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
+		i++
+	}
+	if v.StickyExecutionEnabled != nil {
+		fields[i] = fmt.Sprintf("StickyExecutionEnabled: %v", *(v.StickyExecutionEnabled))
+		i++
+	}
+	if v.DecisionInfo != nil {
+		fields[i] = fmt.Sprintf("DecisionInfo: %v", v.DecisionInfo)
+		i++
+	}
+	if v.StickyInvalidationCount != nil {
+		fields[i] = fmt.Sprintf("StickyInvalidationCount: %v", *(v.StickyInvalidationCount))
+		i++
+	}
+	if v.ContinueAsNewSuggested != nil {
+		fields[i] = fmt.Sprintf("ContinueAsNewSuggested: %v", *(v.ContinueAsNewSuggested))
 		i++
 	}
 
-	return fmt.Sprintf("RecordChildExecutionCompletedRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RecordDecisionTaskStartedResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordChildExecutionCompletedRequest match the
-// provided RecordChildExecutionCompletedRequest.
+// Equals returns true if all the fields of this RecordDecisionTaskStartedResponse match the
+// provided RecordDecisionTaskStartedResponse.
 //
 // This function performs a deep comparison.
-func (v *RecordChildExecutionCompletedRequest) Equals(rhs *RecordChildExecutionCompletedRequest) bool {
-	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+func (v *RecordDecisionTaskStartedResponse) Equals(rhs *RecordDecisionTaskStartedResponse) bool {
+	if !((v.WorkflowType == nil && rhs.WorkflowType == nil) || (v.WorkflowType != nil && rhs.WorkflowType != nil && v.WorkflowType.Equals(rhs.WorkflowType))) {
 		return false
 	}
-	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+	if !_I64_EqualsPtr(v.PreviousStartedEventId, rhs.PreviousStartedEventId) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.InitiatedId, rhs.InitiatedId) {
+	if !_I64_EqualsPtr(v.ScheduledEventId, rhs.ScheduledEventId) {
 		return false
 	}
-	if !((v.CompletedExecution == nil && rhs.CompletedExecution == nil) || (v.CompletedExecution != nil && rhs.CompletedExecution != nil && v.CompletedExecution.Equals(rhs.CompletedExecution))) {
+	if !_I64_EqualsPtr(v.StartedEventId, rhs.StartedEventId) {
 		return false
 	}
-	if !((v.CompletionEvent == nil && rhs.CompletionEvent == nil) || (v.CompletionEvent != nil && rhs.CompletionEvent != nil && v.CompletionEvent.Equals(rhs.CompletionEvent))) {
+	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Attempt, rhs.Attempt) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.StickyExecutionEnabled, rhs.StickyExecutionEnabled) {
+		return false
+	}
+	if !((v.DecisionInfo == nil && rhs.DecisionInfo == nil) || (v.DecisionInfo != nil && rhs.DecisionInfo != nil && v.DecisionInfo.Equals(rhs.DecisionInfo))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.StickyInvalidationCount, rhs.StickyInvalidationCount) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.ContinueAsNewSuggested, rhs.ContinueAsNewSuggested) {
 		return false
 	}
 
 	return true
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
+// GetWorkflowType returns the value of WorkflowType if it is set or its
 // zero value if it is unset.
-func (v *RecordChildExecutionCompletedRequest) GetDomainUUID() (o string) {
-	if v.DomainUUID != nil {
-		return *v.DomainUUID
+func (v *RecordDecisionTaskStartedResponse) GetWorkflowType() (o *shared.WorkflowType) {
+	if v.WorkflowType != nil {
+		return v.WorkflowType
 	}
 
 	return
 }
 
-// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// GetPreviousStartedEventId returns the value of PreviousStartedEventId if it is set or its
 // zero value if it is unset.
-func (v *RecordChildExecutionCompletedRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
-	if v.WorkflowExecution != nil {
-		return v.WorkflowExecution
+func (v *RecordDecisionTaskStartedResponse) GetPreviousStartedEventId() (o int64) {
+	if v.PreviousStartedEventId != nil {
+		return *v.PreviousStartedEventId
 	}
 
 	return
 }
 
-// GetInitiatedId returns the value of InitiatedId if it is set or its
+// GetScheduledEventId returns the value of ScheduledEventId if it is set or its
 // zero value if it is unset.
-func (v *RecordChildExecutionCompletedRequest) GetInitiatedId() (o int64) {
-	if v.InitiatedId != nil {
-		return *v.InitiatedId
+func (v *RecordDecisionTaskStartedResponse) GetScheduledEventId() (o int64) {
+	if v.ScheduledEventId != nil {
+		return *v.ScheduledEventId
 	}
 
 	return
 }
 
-// GetCompletedExecution returns the value of CompletedExecution if it is set or its
+// GetStartedEventId returns the value of StartedEventId if it is set or its
 // zero value if it is unset.
-func (v *RecordChildExecutionCompletedRequest) GetCompletedExecution() (o *shared.WorkflowExecution) {
-	if v.CompletedExecution != nil {
-		return v.CompletedExecution
+func (v *RecordDecisionTaskStartedResponse) GetStartedEventId() (o int64) {
+	if v.StartedEventId != nil {
+		return *v.StartedEventId
 	}
 
 	return
 }
 
-// GetCompletionEvent returns the value of CompletionEvent if it is set or its
+// GetNextEventId returns the value of NextEventId if it is set or its
 // zero value if it is unset.
-func (v *RecordChildExecutionCompletedRequest) GetCompletionEvent() (o *shared.HistoryEvent) {
-	if v.CompletionEvent != nil {
-		return v.CompletionEvent
+func (v *RecordDecisionTaskStartedResponse) GetNextEventId() (o int64) {
+	if v.NextEventId != nil {
+		return *v.NextEventId
 	}
 
 	return
 }
 
-type RecordDecisionTaskStartedRequest struct {
-	DomainUUID        *string                            `json:"domainUUID,omitempty"`
-	WorkflowExecution *shared.WorkflowExecution          `json:"workflowExecution,omitempty"`
-	ScheduleId        *int64                             `json:"scheduleId,omitempty"`
-	TaskId            *int64                             `json:"taskId,omitempty"`
-	RequestId         *string                            `json:"requestId,omitempty"`
-	PollRequest       *shared.PollForDecisionTaskRequest `json:"pollRequest,omitempty"`
+// GetAttempt returns the value of Attempt if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetAttempt() (o int64) {
+	if v.Attempt != nil {
+		return *v.Attempt
+	}
+
+	return
 }
 
-// ToWire translates a RecordDecisionTaskStartedRequest struct into a Thrift-level intermediate
+// GetStickyExecutionEnabled returns the value of StickyExecutionEnabled if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetStickyExecutionEnabled() (o bool) {
+	if v.StickyExecutionEnabled != nil {
+		return *v.StickyExecutionEnabled
+	}
+
+	return
+}
+
+// GetDecisionInfo returns the value of DecisionInfo if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetDecisionInfo() (o *shared.TransientDecisionInfo) {
+	if v.DecisionInfo != nil {
+		return v.DecisionInfo
+	}
+
+	return
+}
+
+// GetStickyInvalidationCount returns the value of StickyInvalidationCount if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetStickyInvalidationCount() (o int64) {
+	if v.StickyInvalidationCount != nil {
+		return *v.StickyInvalidationCount
+	}
+
+	return
+}
+
+// GetContinueAsNewSuggested returns the value of ContinueAsNewSuggested if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetContinueAsNewSuggested() (o bool) {
+	if v.ContinueAsNewSuggested != nil {
+		return *v.ContinueAsNewSuggested
+	}
+
+	return
+}
+
+type RemoveSignalMutableStateRequest struct {
+	DomainUUID        *string                   `json:"domainUUID,omitempty"`
+	WorkflowExecution *shared.WorkflowExecution `json:"workflowExecution,omitempty"`
+	RequestId         *string                   `json:"requestId,omitempty"`
+}
+
+// ToWire translates a RemoveSignalMutableStateRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -2502,9 +4993,9 @@ type RecordDecisionTaskStartedRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordDecisionTaskStartedRequest) ToWire() (wire.Value, error) {
+func (v *RemoveSignalMutableStateRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -2526,53 +5017,23 @@ func (v *RecordDecisionTaskStartedRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.ScheduleId != nil {
-		w, err = wire.NewValueI64(*(v.ScheduleId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
-	if v.TaskId != nil {
-		w, err = wire.NewValueI64(*(v.TaskId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
-	}
 	if v.RequestId != nil {
 		w, err = wire.NewValueString(*(v.RequestId)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 45, Value: w}
-		i++
-	}
-	if v.PollRequest != nil {
-		w, err = v.PollRequest.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 50, Value: w}
+		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _PollForDecisionTaskRequest_Read(w wire.Value) (*shared.PollForDecisionTaskRequest, error) {
-	var v shared.PollForDecisionTaskRequest
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a RecordDecisionTaskStartedRequest struct from its Thrift-level
+// FromWire deserializes a RemoveSignalMutableStateRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordDecisionTaskStartedRequest struct
+// An error is returned if we were unable to build a RemoveSignalMutableStateRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -2580,12 +5041,12 @@ func _PollForDecisionTaskRequest_Read(w wire.Value) (*shared.PollForDecisionTask
 //     return nil, err
 //   }
 //
-//   var v RecordDecisionTaskStartedRequest
+//   var v RemoveSignalMutableStateRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordDecisionTaskStartedRequest) FromWire(w wire.Value) error {
+func (v *RemoveSignalMutableStateRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -2609,26 +5070,6 @@ func (v *RecordDecisionTaskStartedRequest) FromWire(w wire.Value) error {
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.ScheduleId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.TaskId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 45:
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
@@ -2637,14 +5078,6 @@ func (v *RecordDecisionTaskStartedRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
-			}
-		case 50:
-			if field.Value.Type() == wire.TStruct {
-				v.PollRequest, err = _PollForDecisionTaskRequest_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
 			}
 		}
 	}
@@ -2652,14 +5085,14 @@ func (v *RecordDecisionTaskStartedRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RecordDecisionTaskStartedRequest
+// String returns a readable string representation of a RemoveSignalMutableStateRequest
 // struct.
-func (v *RecordDecisionTaskStartedRequest) String() string {
+func (v *RemoveSignalMutableStateRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [3]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
@@ -2669,56 +5102,35 @@ func (v *RecordDecisionTaskStartedRequest) String() string {
 		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
 		i++
 	}
-	if v.ScheduleId != nil {
-		fields[i] = fmt.Sprintf("ScheduleId: %v", *(v.ScheduleId))
-		i++
-	}
-	if v.TaskId != nil {
-		fields[i] = fmt.Sprintf("TaskId: %v", *(v.TaskId))
-		i++
-	}
 	if v.RequestId != nil {
 		fields[i] = fmt.Sprintf("RequestId: %v", *(v.RequestId))
 		i++
 	}
-	if v.PollRequest != nil {
-		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
-		i++
-	}
 
-	return fmt.Sprintf("RecordDecisionTaskStartedRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RemoveSignalMutableStateRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordDecisionTaskStartedRequest match the
-// provided RecordDecisionTaskStartedRequest.
+// Equals returns true if all the fields of this RemoveSignalMutableStateRequest match the
+// provided RemoveSignalMutableStateRequest.
 //
 // This function performs a deep comparison.
-func (v *RecordDecisionTaskStartedRequest) Equals(rhs *RecordDecisionTaskStartedRequest) bool {
+func (v *RemoveSignalMutableStateRequest) Equals(rhs *RemoveSignalMutableStateRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
 	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.ScheduleId, rhs.ScheduleId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.TaskId, rhs.TaskId) {
-		return false
-	}
 	if !_String_EqualsPtr(v.RequestId, rhs.RequestId) {
 		return false
 	}
-	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
-		return false
-	}
 
 	return true
 }
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedRequest) GetDomainUUID() (o string) {
+func (v *RemoveSignalMutableStateRequest) GetDomainUUID() (o string) {
 	if v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -2728,7 +5140,7 @@ func (v *RecordDecisionTaskStartedRequest) GetDomainUUID() (o string) {
 
 // GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
+func (v *RemoveSignalMutableStateRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
 	if v.WorkflowExecution != nil {
 		return v.WorkflowExecution
 	}
@@ -2736,58 +5148,69 @@ func (v *RecordDecisionTaskStartedRequest) GetWorkflowExecution() (o *shared.Wor
 	return
 }
 
-// GetScheduleId returns the value of ScheduleId if it is set or its
+// GetRequestId returns the value of RequestId if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedRequest) GetScheduleId() (o int64) {
-	if v.ScheduleId != nil {
-		return *v.ScheduleId
+func (v *RemoveSignalMutableStateRequest) GetRequestId() (o string) {
+	if v.RequestId != nil {
+		return *v.RequestId
 	}
 
 	return
 }
 
-// GetTaskId returns the value of TaskId if it is set or its
-// zero value if it is unset.
-func (v *RecordDecisionTaskStartedRequest) GetTaskId() (o int64) {
-	if v.TaskId != nil {
-		return *v.TaskId
-	}
-
-	return
+type ReplicateEventsRequest struct {
+	SourceCluster     *string                     `json:"sourceCluster,omitempty"`
+	DomainUUID        *string                     `json:"domainUUID,omitempty"`
+	WorkflowExecution *shared.WorkflowExecution   `json:"workflowExecution,omitempty"`
+	FirstEventId      *int64                      `json:"firstEventId,omitempty"`
+	NextEventId       *int64                      `json:"nextEventId,omitempty"`
+	Version           *int64                      `json:"version,omitempty"`
+	ReplicationInfo   map[string]*ReplicationInfo `json:"replicationInfo,omitempty"`
+	History           *shared.History             `json:"history,omitempty"`
+	NewRunHistory     *shared.History             `json:"newRunHistory,omitempty"`
+	ForceBufferEvents *bool                       `json:"forceBufferEvents,omitempty"`
+	SourceTimestamp   *int64                      `json:"sourceTimestamp,omitempty"`
 }
 
-// GetRequestId returns the value of RequestId if it is set or its
-// zero value if it is unset.
-func (v *RecordDecisionTaskStartedRequest) GetRequestId() (o string) {
-	if v.RequestId != nil {
-		return *v.RequestId
-	}
+type _Map_String_ReplicationInfo_MapItemList map[string]*ReplicationInfo
 
-	return
-}
+func (m _Map_String_ReplicationInfo_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
 
-// GetPollRequest returns the value of PollRequest if it is set or its
-// zero value if it is unset.
-func (v *RecordDecisionTaskStartedRequest) GetPollRequest() (o *shared.PollForDecisionTaskRequest) {
-	if v.PollRequest != nil {
-		return v.PollRequest
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	return
+func (m _Map_String_ReplicationInfo_MapItemList) Size() int {
+	return len(m)
 }
 
-type RecordDecisionTaskStartedResponse struct {
-	WorkflowType           *shared.WorkflowType          `json:"workflowType,omitempty"`
-	PreviousStartedEventId *int64                        `json:"previousStartedEventId,omitempty"`
-	ScheduledEventId       *int64                        `json:"scheduledEventId,omitempty"`
-	StartedEventId         *int64                        `json:"startedEventId,omitempty"`
-	NextEventId            *int64                        `json:"nextEventId,omitempty"`
-	Attempt                *int64                        `json:"attempt,omitempty"`
-	StickyExecutionEnabled *bool                         `json:"stickyExecutionEnabled,omitempty"`
-	DecisionInfo           *shared.TransientDecisionInfo `json:"decisionInfo,omitempty"`
+func (_Map_String_ReplicationInfo_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
 }
 
-// ToWire translates a RecordDecisionTaskStartedResponse struct into a Thrift-level intermediate
+func (_Map_String_ReplicationInfo_MapItemList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_Map_String_ReplicationInfo_MapItemList) Close() {}
+
+// ToWire translates a ReplicateEventsRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -2802,40 +5225,40 @@ type RecordDecisionTaskStartedResponse struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordDecisionTaskStartedResponse) ToWire() (wire.Value, error) {
+func (v *ReplicateEventsRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [8]wire.Field
+		fields [11]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.WorkflowType != nil {
-		w, err = v.WorkflowType.ToWire()
+	if v.SourceCluster != nil {
+		w, err = wire.NewValueString(*(v.SourceCluster)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.PreviousStartedEventId != nil {
-		w, err = wire.NewValueI64(*(v.PreviousStartedEventId)), error(nil)
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.ScheduledEventId != nil {
-		w, err = wire.NewValueI64(*(v.ScheduledEventId)), error(nil)
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.StartedEventId != nil {
-		w, err = wire.NewValueI64(*(v.StartedEventId)), error(nil)
+	if v.FirstEventId != nil {
+		w, err = wire.NewValueI64(*(v.FirstEventId)), error(nil)
 		if err != nil {
 			return w, err
 		}
@@ -2850,45 +5273,103 @@ func (v *RecordDecisionTaskStartedResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
-	if v.Attempt != nil {
-		w, err = wire.NewValueI64(*(v.Attempt)), error(nil)
+	if v.Version != nil {
+		w, err = wire.NewValueI64(*(v.Version)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 60, Value: w}
 		i++
 	}
-	if v.StickyExecutionEnabled != nil {
-		w, err = wire.NewValueBool(*(v.StickyExecutionEnabled)), error(nil)
+	if v.ReplicationInfo != nil {
+		w, err = wire.NewValueMap(_Map_String_ReplicationInfo_MapItemList(v.ReplicationInfo)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 70, Value: w}
 		i++
 	}
-	if v.DecisionInfo != nil {
-		w, err = v.DecisionInfo.ToWire()
+	if v.History != nil {
+		w, err = v.History.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 80, Value: w}
 		i++
 	}
+	if v.NewRunHistory != nil {
+		w, err = v.NewRunHistory.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 90, Value: w}
+		i++
+	}
+	if v.ForceBufferEvents != nil {
+		w, err = wire.NewValueBool(*(v.ForceBufferEvents)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 100, Value: w}
+		i++
+	}
+	if v.SourceTimestamp != nil {
+		w, err = wire.NewValueI64(*(v.SourceTimestamp)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 110, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _TransientDecisionInfo_Read(w wire.Value) (*shared.TransientDecisionInfo, error) {
-	var v shared.TransientDecisionInfo
+func _ReplicationInfo_Read(w wire.Value) (*ReplicationInfo, error) {
+	var v ReplicationInfo
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a RecordDecisionTaskStartedResponse struct from its Thrift-level
+func _Map_String_ReplicationInfo_Read(m wire.MapItemList) (map[string]*ReplicationInfo, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make(map[string]*ReplicationInfo, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := _ReplicationInfo_Read(x.Value)
+		if err != nil {
+			return err
+		}
+
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func _History_Read(w wire.Value) (*shared.History, error) {
+	var v shared.History
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a ReplicateEventsRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordDecisionTaskStartedResponse struct
+// An error is returned if we were unable to build a ReplicateEventsRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -2896,39 +5377,39 @@ func _TransientDecisionInfo_Read(w wire.Value) (*shared.TransientDecisionInfo, e
 //     return nil, err
 //   }
 //
-//   var v RecordDecisionTaskStartedResponse
+//   var v ReplicateEventsRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordDecisionTaskStartedResponse) FromWire(w wire.Value) error {
+func (v *ReplicateEventsRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowType, err = _WorkflowType_Read(field.Value)
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.SourceCluster = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.PreviousStartedEventId = &x
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.ScheduledEventId = &x
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -2938,7 +5419,7 @@ func (v *RecordDecisionTaskStartedResponse) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.StartedEventId = &x
+				v.FirstEventId = &x
 				if err != nil {
 					return err
 				}
@@ -2958,25 +5439,51 @@ func (v *RecordDecisionTaskStartedResponse) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.Attempt = &x
+				v.Version = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 70:
+			if field.Value.Type() == wire.TMap {
+				v.ReplicationInfo, err = _Map_String_ReplicationInfo_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 80:
+			if field.Value.Type() == wire.TStruct {
+				v.History, err = _History_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 90:
+			if field.Value.Type() == wire.TStruct {
+				v.NewRunHistory, err = _History_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 100:
 			if field.Value.Type() == wire.TBool {
 				var x bool
 				x, err = field.Value.GetBool(), error(nil)
-				v.StickyExecutionEnabled = &x
+				v.ForceBufferEvents = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 80:
-			if field.Value.Type() == wire.TStruct {
-				v.DecisionInfo, err = _TransientDecisionInfo_Read(field.Value)
+		case 110:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.SourceTimestamp = &x
 				if err != nil {
 					return err
 				}
@@ -2988,119 +5495,157 @@ func (v *RecordDecisionTaskStartedResponse) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RecordDecisionTaskStartedResponse
+// String returns a readable string representation of a ReplicateEventsRequest
 // struct.
-func (v *RecordDecisionTaskStartedResponse) String() string {
+func (v *ReplicateEventsRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [8]string
+	var fields [11]string
 	i := 0
-	if v.WorkflowType != nil {
-		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
+	if v.SourceCluster != nil {
+		fields[i] = fmt.Sprintf("SourceCluster: %v", *(v.SourceCluster))
 		i++
 	}
-	if v.PreviousStartedEventId != nil {
-		fields[i] = fmt.Sprintf("PreviousStartedEventId: %v", *(v.PreviousStartedEventId))
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.ScheduledEventId != nil {
-		fields[i] = fmt.Sprintf("ScheduledEventId: %v", *(v.ScheduledEventId))
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
 		i++
 	}
-	if v.StartedEventId != nil {
-		fields[i] = fmt.Sprintf("StartedEventId: %v", *(v.StartedEventId))
+	if v.FirstEventId != nil {
+		fields[i] = fmt.Sprintf("FirstEventId: %v", *(v.FirstEventId))
 		i++
 	}
 	if v.NextEventId != nil {
 		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
 		i++
 	}
-	if v.Attempt != nil {
-		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
+	if v.Version != nil {
+		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
 		i++
 	}
-	if v.StickyExecutionEnabled != nil {
-		fields[i] = fmt.Sprintf("StickyExecutionEnabled: %v", *(v.StickyExecutionEnabled))
+	if v.ReplicationInfo != nil {
+		fields[i] = fmt.Sprintf("ReplicationInfo: %v", v.ReplicationInfo)
 		i++
 	}
-	if v.DecisionInfo != nil {
-		fields[i] = fmt.Sprintf("DecisionInfo: %v", v.DecisionInfo)
+	if v.History != nil {
+		fields[i] = fmt.Sprintf("History: %v", v.History)
+		i++
+	}
+	if v.NewRunHistory != nil {
+		fields[i] = fmt.Sprintf("NewRunHistory: %v", v.NewRunHistory)
+		i++
+	}
+	if v.ForceBufferEvents != nil {
+		fields[i] = fmt.Sprintf("ForceBufferEvents: %v", *(v.ForceBufferEvents))
+		i++
+	}
+	if v.SourceTimestamp != nil {
+		fields[i] = fmt.Sprintf("SourceTimestamp: %v", *(v.SourceTimestamp))
 		i++
 	}
 
-	return fmt.Sprintf("RecordDecisionTaskStartedResponse{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("ReplicateEventsRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordDecisionTaskStartedResponse match the
-// provided RecordDecisionTaskStartedResponse.
+func _Map_String_ReplicationInfo_Equals(lhs, rhs map[string]*ReplicationInfo) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if all the fields of this ReplicateEventsRequest match the
+// provided ReplicateEventsRequest.
 //
 // This function performs a deep comparison.
-func (v *RecordDecisionTaskStartedResponse) Equals(rhs *RecordDecisionTaskStartedResponse) bool {
-	if !((v.WorkflowType == nil && rhs.WorkflowType == nil) || (v.WorkflowType != nil && rhs.WorkflowType != nil && v.WorkflowType.Equals(rhs.WorkflowType))) {
+func (v *ReplicateEventsRequest) Equals(rhs *ReplicateEventsRequest) bool {
+	if !_String_EqualsPtr(v.SourceCluster, rhs.SourceCluster) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.PreviousStartedEventId, rhs.PreviousStartedEventId) {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.ScheduledEventId, rhs.ScheduledEventId) {
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.StartedEventId, rhs.StartedEventId) {
+	if !_I64_EqualsPtr(v.FirstEventId, rhs.FirstEventId) {
 		return false
 	}
 	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.Attempt, rhs.Attempt) {
+	if !_I64_EqualsPtr(v.Version, rhs.Version) {
 		return false
 	}
-	if !_Bool_EqualsPtr(v.StickyExecutionEnabled, rhs.StickyExecutionEnabled) {
+	if !((v.ReplicationInfo == nil && rhs.ReplicationInfo == nil) || (v.ReplicationInfo != nil && rhs.ReplicationInfo != nil && _Map_String_ReplicationInfo_Equals(v.ReplicationInfo, rhs.ReplicationInfo))) {
 		return false
 	}
-	if !((v.DecisionInfo == nil && rhs.DecisionInfo == nil) || (v.DecisionInfo != nil && rhs.DecisionInfo != nil && v.DecisionInfo.Equals(rhs.DecisionInfo))) {
+	if !((v.History == nil && rhs.History == nil) || (v.History != nil && rhs.History != nil && v.History.Equals(rhs.History))) {
+		return false
+	}
+	if !((v.NewRunHistory == nil && rhs.NewRunHistory == nil) || (v.NewRunHistory != nil && rhs.NewRunHistory != nil && v.NewRunHistory.Equals(rhs.NewRunHistory))) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.ForceBufferEvents, rhs.ForceBufferEvents) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.SourceTimestamp, rhs.SourceTimestamp) {
 		return false
 	}
 
 	return true
 }
 
-// GetWorkflowType returns the value of WorkflowType if it is set or its
+// GetSourceCluster returns the value of SourceCluster if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetWorkflowType() (o *shared.WorkflowType) {
-	if v.WorkflowType != nil {
-		return v.WorkflowType
+func (v *ReplicateEventsRequest) GetSourceCluster() (o string) {
+	if v.SourceCluster != nil {
+		return *v.SourceCluster
 	}
 
 	return
 }
 
-// GetPreviousStartedEventId returns the value of PreviousStartedEventId if it is set or its
+// GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetPreviousStartedEventId() (o int64) {
-	if v.PreviousStartedEventId != nil {
-		return *v.PreviousStartedEventId
+func (v *ReplicateEventsRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
 	}
 
 	return
 }
 
-// GetScheduledEventId returns the value of ScheduledEventId if it is set or its
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetScheduledEventId() (o int64) {
-	if v.ScheduledEventId != nil {
-		return *v.ScheduledEventId
+func (v *ReplicateEventsRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
+	if v.WorkflowExecution != nil {
+		return v.WorkflowExecution
 	}
 
 	return
 }
 
-// GetStartedEventId returns the value of StartedEventId if it is set or its
+// GetFirstEventId returns the value of FirstEventId if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetStartedEventId() (o int64) {
-	if v.StartedEventId != nil {
-		return *v.StartedEventId
+func (v *ReplicateEventsRequest) GetFirstEventId() (o int64) {
+	if v.FirstEventId != nil {
+		return *v.FirstEventId
 	}
 
 	return
@@ -3108,7 +5653,7 @@ func (v *RecordDecisionTaskStartedResponse) GetStartedEventId() (o int64) {
 
 // GetNextEventId returns the value of NextEventId if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetNextEventId() (o int64) {
+func (v *ReplicateEventsRequest) GetNextEventId() (o int64) {
 	if v.NextEventId != nil {
 		return *v.NextEventId
 	}
@@ -3116,43 +5661,72 @@ func (v *RecordDecisionTaskStartedResponse) GetNextEventId() (o int64) {
 	return
 }
 
-// GetAttempt returns the value of Attempt if it is set or its
+// GetVersion returns the value of Version if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetAttempt() (o int64) {
-	if v.Attempt != nil {
-		return *v.Attempt
+func (v *ReplicateEventsRequest) GetVersion() (o int64) {
+	if v.Version != nil {
+		return *v.Version
 	}
 
 	return
 }
 
-// GetStickyExecutionEnabled returns the value of StickyExecutionEnabled if it is set or its
+// GetReplicationInfo returns the value of ReplicationInfo if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetStickyExecutionEnabled() (o bool) {
-	if v.StickyExecutionEnabled != nil {
-		return *v.StickyExecutionEnabled
+func (v *ReplicateEventsRequest) GetReplicationInfo() (o map[string]*ReplicationInfo) {
+	if v.ReplicationInfo != nil {
+		return v.ReplicationInfo
 	}
 
 	return
 }
 
-// GetDecisionInfo returns the value of DecisionInfo if it is set or its
+// GetHistory returns the value of History if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetDecisionInfo() (o *shared.TransientDecisionInfo) {
-	if v.DecisionInfo != nil {
-		return v.DecisionInfo
+func (v *ReplicateEventsRequest) GetHistory() (o *shared.History) {
+	if v.History != nil {
+		return v.History
 	}
 
 	return
 }
 
-type RemoveSignalMutableStateRequest struct {
-	DomainUUID        *string                   `json:"domainUUID,omitempty"`
-	WorkflowExecution *shared.WorkflowExecution `json:"workflowExecution,omitempty"`
-	RequestId         *string                   `json:"requestId,omitempty"`
+// GetNewRunHistory returns the value of NewRunHistory if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetNewRunHistory() (o *shared.History) {
+	if v.NewRunHistory != nil {
+		return v.NewRunHistory
+	}
+
+	return
 }
 
-// ToWire translates a RemoveSignalMutableStateRequest struct into a Thrift-level intermediate
+// GetForceBufferEvents returns the value of ForceBufferEvents if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetForceBufferEvents() (o bool) {
+	if v.ForceBufferEvents != nil {
+		return *v.ForceBufferEvents
+	}
+
+	return
+}
+
+// GetSourceTimestamp returns the value of SourceTimestamp if it is set or its
+// zero value if it is unset.
+func (v *ReplicateEventsRequest) GetSourceTimestamp() (o int64) {
+	if v.SourceTimestamp != nil {
+		return *v.SourceTimestamp
+	}
+
+	return
+}
+
+type ReplicationInfo struct {
+	Version     *int64 `json:"version,omitempty"`
+	LastEventId *int64 `json:"lastEventId,omitempty"`
+}
+
+// ToWire translates a ReplicationInfo struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -3167,47 +5741,39 @@ type RemoveSignalMutableStateRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RemoveSignalMutableStateRequest) ToWire() (wire.Value, error) {
+func (v *ReplicationInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.DomainUUID != nil {
-		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+	if v.Version != nil {
+		w, err = wire.NewValueI64(*(v.Version)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		w, err = v.WorkflowExecution.ToWire()
+	if v.LastEventId != nil {
+		w, err = wire.NewValueI64(*(v.LastEventId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.RequestId != nil {
-		w, err = wire.NewValueString(*(v.RequestId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a RemoveSignalMutableStateRequest struct from its Thrift-level
+// FromWire deserializes a ReplicationInfo struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RemoveSignalMutableStateRequest struct
+// An error is returned if we were unable to build a ReplicationInfo struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -3215,39 +5781,31 @@ func (v *RemoveSignalMutableStateRequest) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v RemoveSignalMutableStateRequest
+//   var v ReplicationInfo
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RemoveSignalMutableStateRequest) FromWire(w wire.Value) error {
+func (v *ReplicationInfo) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.DomainUUID = &x
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Version = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 30:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.RequestId = &x
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LastEventId = &x
 				if err != nil {
 					return err
 				}
@@ -3259,131 +5817,71 @@ func (v *RemoveSignalMutableStateRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RemoveSignalMutableStateRequest
+// String returns a readable string representation of a ReplicationInfo
 // struct.
-func (v *RemoveSignalMutableStateRequest) String() string {
+func (v *ReplicationInfo) String() string {
 	if v == nil {
 		return "<nil>"
 	}
-
-	var fields [3]string
-	i := 0
-	if v.DomainUUID != nil {
-		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
-		i++
-	}
-	if v.WorkflowExecution != nil {
-		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+
+	var fields [2]string
+	i := 0
+	if v.Version != nil {
+		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
 		i++
 	}
-	if v.RequestId != nil {
-		fields[i] = fmt.Sprintf("RequestId: %v", *(v.RequestId))
+	if v.LastEventId != nil {
+		fields[i] = fmt.Sprintf("LastEventId: %v", *(v.LastEventId))
 		i++
 	}
 
-	return fmt.Sprintf("RemoveSignalMutableStateRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("ReplicationInfo{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RemoveSignalMutableStateRequest match the
-// provided RemoveSignalMutableStateRequest.
+// Equals returns true if all the fields of this ReplicationInfo match the
+// provided ReplicationInfo.
 //
 // This function performs a deep comparison.
-func (v *RemoveSignalMutableStateRequest) Equals(rhs *RemoveSignalMutableStateRequest) bool {
-	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
-		return false
-	}
-	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+func (v *ReplicationInfo) Equals(rhs *ReplicationInfo) bool {
+	if !_I64_EqualsPtr(v.Version, rhs.Version) {
 		return false
 	}
-	if !_String_EqualsPtr(v.RequestId, rhs.RequestId) {
+	if !_I64_EqualsPtr(v.LastEventId, rhs.LastEventId) {
 		return false
 	}
 
 	return true
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
-// zero value if it is unset.
-func (v *RemoveSignalMutableStateRequest) GetDomainUUID() (o string) {
-	if v.DomainUUID != nil {
-		return *v.DomainUUID
-	}
-
-	return
-}
-
-// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// GetVersion returns the value of Version if it is set or its
 // zero value if it is unset.
-func (v *RemoveSignalMutableStateRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
-	if v.WorkflowExecution != nil {
-		return v.WorkflowExecution
+func (v *ReplicationInfo) GetVersion() (o int64) {
+	if v.Version != nil {
+		return *v.Version
 	}
 
 	return
 }
 
-// GetRequestId returns the value of RequestId if it is set or its
+// GetLastEventId returns the value of LastEventId if it is set or its
 // zero value if it is unset.
-func (v *RemoveSignalMutableStateRequest) GetRequestId() (o string) {
-	if v.RequestId != nil {
-		return *v.RequestId
+func (v *ReplicationInfo) GetLastEventId() (o int64) {
+	if v.LastEventId != nil {
+		return *v.LastEventId
 	}
 
 	return
 }
 
-type ReplicateEventsRequest struct {
-	SourceCluster     *string                     `json:"sourceCluster,omitempty"`
-	DomainUUID        *string                     `json:"domainUUID,omitempty"`
-	WorkflowExecution *shared.WorkflowExecution   `json:"workflowExecution,omitempty"`
-	FirstEventId      *int64                      `json:"firstEventId,omitempty"`
-	NextEventId       *int64                      `json:"nextEventId,omitempty"`
-	Version           *int64                      `json:"version,omitempty"`
-	ReplicationInfo   map[string]*ReplicationInfo `json:"replicationInfo,omitempty"`
-	History           *shared.History             `json:"history,omitempty"`
-	NewRunHistory     *shared.History             `json:"newRunHistory,omitempty"`
-	ForceBufferEvents *bool                       `json:"forceBufferEvents,omitempty"`
-}
-
-type _Map_String_ReplicationInfo_MapItemList map[string]*ReplicationInfo
-
-func (m _Map_String_ReplicationInfo_MapItemList) ForEach(f func(wire.MapItem) error) error {
-	for k, v := range m {
-		if v == nil {
-			return fmt.Errorf("invalid [%v]: value is nil", k)
-		}
-		kw, err := wire.NewValueString(k), error(nil)
-		if err != nil {
-			return err
-		}
-
-		vw, err := v.ToWire()
-		if err != nil {
-			return err
-		}
-		err = f(wire.MapItem{Key: kw, Value: vw})
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (m _Map_String_ReplicationInfo_MapItemList) Size() int {
-	return len(m)
-}
-
-func (_Map_String_ReplicationInfo_MapItemList) KeyType() wire.Type {
-	return wire.TBinary
-}
-
-func (_Map_String_ReplicationInfo_MapItemList) ValueType() wire.Type {
-	return wire.TStruct
+type RequestCancelWorkflowExecutionRequest struct {
+	DomainUUID                *string                                       `json:"domainUUID,omitempty"`
+	CancelRequest             *shared.RequestCancelWorkflowExecutionRequest `json:"cancelRequest,omitempty"`
+	ExternalInitiatedEventId  *int64                                        `json:"externalInitiatedEventId,omitempty"`
+	ExternalWorkflowExecution *shared.WorkflowExecution                     `json:"externalWorkflowExecution,omitempty"`
+	ChildWorkflowOnly         *bool                                         `json:"childWorkflowOnly,omitempty"`
 }
 
-func (_Map_String_ReplicationInfo_MapItemList) Close() {}
-
-// ToWire translates a ReplicateEventsRequest struct into a Thrift-level intermediate
+// ToWire translates a RequestCancelWorkflowExecutionRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -3398,143 +5896,69 @@ func (_Map_String_ReplicationInfo_MapItemList) Close() {}
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *ReplicateEventsRequest) ToWire() (wire.Value, error) {
+func (v *RequestCancelWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [10]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.SourceCluster != nil {
-		w, err = wire.NewValueString(*(v.SourceCluster)), error(nil)
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.DomainUUID != nil {
-		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+	if v.CancelRequest != nil {
+		w, err = v.CancelRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		w, err = v.WorkflowExecution.ToWire()
+	if v.ExternalInitiatedEventId != nil {
+		w, err = wire.NewValueI64(*(v.ExternalInitiatedEventId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.FirstEventId != nil {
-		w, err = wire.NewValueI64(*(v.FirstEventId)), error(nil)
+	if v.ExternalWorkflowExecution != nil {
+		w, err = v.ExternalWorkflowExecution.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
-	if v.NextEventId != nil {
-		w, err = wire.NewValueI64(*(v.NextEventId)), error(nil)
+	if v.ChildWorkflowOnly != nil {
+		w, err = wire.NewValueBool(*(v.ChildWorkflowOnly)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
-	if v.Version != nil {
-		w, err = wire.NewValueI64(*(v.Version)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 60, Value: w}
-		i++
-	}
-	if v.ReplicationInfo != nil {
-		w, err = wire.NewValueMap(_Map_String_ReplicationInfo_MapItemList(v.ReplicationInfo)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 70, Value: w}
-		i++
-	}
-	if v.History != nil {
-		w, err = v.History.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 80, Value: w}
-		i++
-	}
-	if v.NewRunHistory != nil {
-		w, err = v.NewRunHistory.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 90, Value: w}
-		i++
-	}
-	if v.ForceBufferEvents != nil {
-		w, err = wire.NewValueBool(*(v.ForceBufferEvents)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 100, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _ReplicationInfo_Read(w wire.Value) (*ReplicationInfo, error) {
-	var v ReplicationInfo
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _Map_String_ReplicationInfo_Read(m wire.MapItemList) (map[string]*ReplicationInfo, error) {
-	if m.KeyType() != wire.TBinary {
-		return nil, nil
-	}
-
-	if m.ValueType() != wire.TStruct {
-		return nil, nil
-	}
-
-	o := make(map[string]*ReplicationInfo, m.Size())
-	err := m.ForEach(func(x wire.MapItem) error {
-		k, err := x.Key.GetString(), error(nil)
-		if err != nil {
-			return err
-		}
-
-		v, err := _ReplicationInfo_Read(x.Value)
-		if err != nil {
-			return err
-		}
-
-		o[k] = v
-		return nil
-	})
-	m.Close()
-	return o, err
-}
-
-func _History_Read(w wire.Value) (*shared.History, error) {
-	var v shared.History
+func _RequestCancelWorkflowExecutionRequest_Read(w wire.Value) (*shared.RequestCancelWorkflowExecutionRequest, error) {
+	var v shared.RequestCancelWorkflowExecutionRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a ReplicateEventsRequest struct from its Thrift-level
+// FromWire deserializes a RequestCancelWorkflowExecutionRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a ReplicateEventsRequest struct
+// An error is returned if we were unable to build a RequestCancelWorkflowExecutionRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -3542,103 +5966,57 @@ func _History_Read(w wire.Value) (*shared.History, error) {
 //     return nil, err
 //   }
 //
-//   var v ReplicateEventsRequest
+//   var v RequestCancelWorkflowExecutionRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
-//   return &v, nil
-func (v *ReplicateEventsRequest) FromWire(w wire.Value) error {
-	var err error
-
-	for _, field := range w.GetStruct().Fields {
-		switch field.ID {
-		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.SourceCluster = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 20:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.DomainUUID = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 30:
-			if field.Value.Type() == wire.TStruct {
-				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.FirstEventId = &x
+//   return &v, nil
+func (v *RequestCancelWorkflowExecutionRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 50:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.NextEventId = &x
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.CancelRequest, err = _RequestCancelWorkflowExecutionRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 60:
+		case 30:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.Version = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 70:
-			if field.Value.Type() == wire.TMap {
-				v.ReplicationInfo, err = _Map_String_ReplicationInfo_Read(field.Value.GetMap())
-				if err != nil {
-					return err
-				}
-
-			}
-		case 80:
-			if field.Value.Type() == wire.TStruct {
-				v.History, err = _History_Read(field.Value)
+				v.ExternalInitiatedEventId = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 90:
+		case 40:
 			if field.Value.Type() == wire.TStruct {
-				v.NewRunHistory, err = _History_Read(field.Value)
+				v.ExternalWorkflowExecution, err = _WorkflowExecution_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
-		case 100:
+		case 50:
 			if field.Value.Type() == wire.TBool {
 				var x bool
 				x, err = field.Value.GetBool(), error(nil)
-				v.ForceBufferEvents = &x
+				v.ChildWorkflowOnly = &x
 				if err != nil {
 					return err
 				}
@@ -3650,221 +6028,268 @@ func (v *ReplicateEventsRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a ReplicateEventsRequest
+// String returns a readable string representation of a RequestCancelWorkflowExecutionRequest
 // struct.
-func (v *ReplicateEventsRequest) String() string {
+func (v *RequestCancelWorkflowExecutionRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [10]string
+	var fields [5]string
 	i := 0
-	if v.SourceCluster != nil {
-		fields[i] = fmt.Sprintf("SourceCluster: %v", *(v.SourceCluster))
-		i++
-	}
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
-		i++
-	}
-	if v.FirstEventId != nil {
-		fields[i] = fmt.Sprintf("FirstEventId: %v", *(v.FirstEventId))
-		i++
-	}
-	if v.NextEventId != nil {
-		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
-		i++
-	}
-	if v.Version != nil {
-		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
-		i++
-	}
-	if v.ReplicationInfo != nil {
-		fields[i] = fmt.Sprintf("ReplicationInfo: %v", v.ReplicationInfo)
+	if v.CancelRequest != nil {
+		fields[i] = fmt.Sprintf("CancelRequest: %v", v.CancelRequest)
 		i++
 	}
-	if v.History != nil {
-		fields[i] = fmt.Sprintf("History: %v", v.History)
+	if v.ExternalInitiatedEventId != nil {
+		fields[i] = fmt.Sprintf("ExternalInitiatedEventId: %v", *(v.ExternalInitiatedEventId))
 		i++
 	}
-	if v.NewRunHistory != nil {
-		fields[i] = fmt.Sprintf("NewRunHistory: %v", v.NewRunHistory)
+	if v.ExternalWorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("ExternalWorkflowExecution: %v", v.ExternalWorkflowExecution)
 		i++
 	}
-	if v.ForceBufferEvents != nil {
-		fields[i] = fmt.Sprintf("ForceBufferEvents: %v", *(v.ForceBufferEvents))
+	if v.ChildWorkflowOnly != nil {
+		fields[i] = fmt.Sprintf("ChildWorkflowOnly: %v", *(v.ChildWorkflowOnly))
 		i++
 	}
 
-	return fmt.Sprintf("ReplicateEventsRequest{%v}", strings.Join(fields[:i], ", "))
-}
-
-func _Map_String_ReplicationInfo_Equals(lhs, rhs map[string]*ReplicationInfo) bool {
-	if len(lhs) != len(rhs) {
-		return false
-	}
-
-	for lk, lv := range lhs {
-		rv, ok := rhs[lk]
-		if !ok {
-			return false
-		}
-		if !lv.Equals(rv) {
-			return false
-		}
-	}
-	return true
+	return fmt.Sprintf("RequestCancelWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this ReplicateEventsRequest match the
-// provided ReplicateEventsRequest.
+// Equals returns true if all the fields of this RequestCancelWorkflowExecutionRequest match the
+// provided RequestCancelWorkflowExecutionRequest.
 //
 // This function performs a deep comparison.
-func (v *ReplicateEventsRequest) Equals(rhs *ReplicateEventsRequest) bool {
-	if !_String_EqualsPtr(v.SourceCluster, rhs.SourceCluster) {
-		return false
-	}
+func (v *RequestCancelWorkflowExecutionRequest) Equals(rhs *RequestCancelWorkflowExecutionRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.FirstEventId, rhs.FirstEventId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
+	if !((v.CancelRequest == nil && rhs.CancelRequest == nil) || (v.CancelRequest != nil && rhs.CancelRequest != nil && v.CancelRequest.Equals(rhs.CancelRequest))) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.Version, rhs.Version) {
+	if !_I64_EqualsPtr(v.ExternalInitiatedEventId, rhs.ExternalInitiatedEventId) {
 		return false
 	}
-	if !((v.ReplicationInfo == nil && rhs.ReplicationInfo == nil) || (v.ReplicationInfo != nil && rhs.ReplicationInfo != nil && _Map_String_ReplicationInfo_Equals(v.ReplicationInfo, rhs.ReplicationInfo))) {
+	if !((v.ExternalWorkflowExecution == nil && rhs.ExternalWorkflowExecution == nil) || (v.ExternalWorkflowExecution != nil && rhs.ExternalWorkflowExecution != nil && v.ExternalWorkflowExecution.Equals(rhs.ExternalWorkflowExecution))) {
 		return false
 	}
-	if !((v.History == nil && rhs.History == nil) || (v.History != nil && rhs.History != nil && v.History.Equals(rhs.History))) {
+	if !_Bool_EqualsPtr(v.ChildWorkflowOnly, rhs.ChildWorkflowOnly) {
 		return false
 	}
-	if !((v.NewRunHistory == nil && rhs.NewRunHistory == nil) || (v.NewRunHistory != nil && rhs.NewRunHistory != nil && v.NewRunHistory.Equals(rhs.NewRunHistory))) {
-		return false
+
+	return true
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *RequestCancelWorkflowExecutionRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
 	}
-	if !_Bool_EqualsPtr(v.ForceBufferEvents, rhs.ForceBufferEvents) {
-		return false
+
+	return
+}
+
+// GetCancelRequest returns the value of CancelRequest if it is set or its
+// zero value if it is unset.
+func (v *RequestCancelWorkflowExecutionRequest) GetCancelRequest() (o *shared.RequestCancelWorkflowExecutionRequest) {
+	if v.CancelRequest != nil {
+		return v.CancelRequest
 	}
 
-	return true
+	return
 }
 
-// GetSourceCluster returns the value of SourceCluster if it is set or its
+// GetExternalInitiatedEventId returns the value of ExternalInitiatedEventId if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetSourceCluster() (o string) {
-	if v.SourceCluster != nil {
-		return *v.SourceCluster
+func (v *RequestCancelWorkflowExecutionRequest) GetExternalInitiatedEventId() (o int64) {
+	if v.ExternalInitiatedEventId != nil {
+		return *v.ExternalInitiatedEventId
 	}
 
 	return
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
+// GetExternalWorkflowExecution returns the value of ExternalWorkflowExecution if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetDomainUUID() (o string) {
-	if v.DomainUUID != nil {
-		return *v.DomainUUID
+func (v *RequestCancelWorkflowExecutionRequest) GetExternalWorkflowExecution() (o *shared.WorkflowExecution) {
+	if v.ExternalWorkflowExecution != nil {
+		return v.ExternalWorkflowExecution
 	}
 
 	return
 }
 
-// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// GetChildWorkflowOnly returns the value of ChildWorkflowOnly if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
-	if v.WorkflowExecution != nil {
-		return v.WorkflowExecution
+func (v *RequestCancelWorkflowExecutionRequest) GetChildWorkflowOnly() (o bool) {
+	if v.ChildWorkflowOnly != nil {
+		return *v.ChildWorkflowOnly
+	}
+
+	return
+}
+
+type ResetStickyTaskListRequest struct {
+	DomainUUID *string                   `json:"domainUUID,omitempty"`
+	Execution  *shared.WorkflowExecution `json:"execution,omitempty"`
+}
+
+// ToWire translates a ResetStickyTaskListRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ResetStickyTaskListRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
 	}
 
-	return
-}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a ResetStickyTaskListRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ResetStickyTaskListRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ResetStickyTaskListRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ResetStickyTaskListRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
 
-// GetFirstEventId returns the value of FirstEventId if it is set or its
-// zero value if it is unset.
-func (v *ReplicateEventsRequest) GetFirstEventId() (o int64) {
-	if v.FirstEventId != nil {
-		return *v.FirstEventId
+			}
+		}
 	}
 
-	return
+	return nil
 }
 
-// GetNextEventId returns the value of NextEventId if it is set or its
-// zero value if it is unset.
-func (v *ReplicateEventsRequest) GetNextEventId() (o int64) {
-	if v.NextEventId != nil {
-		return *v.NextEventId
+// String returns a readable string representation of a ResetStickyTaskListRequest
+// struct.
+func (v *ResetStickyTaskListRequest) String() string {
+	if v == nil {
+		return "<nil>"
 	}
 
-	return
-}
-
-// GetVersion returns the value of Version if it is set or its
-// zero value if it is unset.
-func (v *ReplicateEventsRequest) GetVersion() (o int64) {
-	if v.Version != nil {
-		return *v.Version
+	var fields [2]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
 	}
-
-	return
-}
-
-// GetReplicationInfo returns the value of ReplicationInfo if it is set or its
-// zero value if it is unset.
-func (v *ReplicateEventsRequest) GetReplicationInfo() (o map[string]*ReplicationInfo) {
-	if v.ReplicationInfo != nil {
-		return v.ReplicationInfo
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
 	}
 
-	return
+	return fmt.Sprintf("ResetStickyTaskListRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// GetHistory returns the value of History if it is set or its
-// zero value if it is unset.
-func (v *ReplicateEventsRequest) GetHistory() (o *shared.History) {
-	if v.History != nil {
-		return v.History
+// Equals returns true if all the fields of this ResetStickyTaskListRequest match the
+// provided ResetStickyTaskListRequest.
+//
+// This function performs a deep comparison.
+func (v *ResetStickyTaskListRequest) Equals(rhs *ResetStickyTaskListRequest) bool {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
 	}
 
-	return
+	return true
 }
 
-// GetNewRunHistory returns the value of NewRunHistory if it is set or its
+// GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetNewRunHistory() (o *shared.History) {
-	if v.NewRunHistory != nil {
-		return v.NewRunHistory
+func (v *ResetStickyTaskListRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
 	}
 
 	return
 }
 
-// GetForceBufferEvents returns the value of ForceBufferEvents if it is set or its
+// GetExecution returns the value of Execution if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetForceBufferEvents() (o bool) {
-	if v.ForceBufferEvents != nil {
-		return *v.ForceBufferEvents
+func (v *ResetStickyTaskListRequest) GetExecution() (o *shared.WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
 	}
 
 	return
 }
 
-type ReplicationInfo struct {
-	Version     *int64 `json:"version,omitempty"`
-	LastEventId *int64 `json:"lastEventId,omitempty"`
+type ResetStickyTaskListResponse struct {
+	StickyInvalidationCount *int64 `json:"stickyInvalidationCount,omitempty"`
 }
 
-// ToWire translates a ReplicationInfo struct into a Thrift-level intermediate
+// ToWire translates a ResetStickyTaskListResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -3879,39 +6304,31 @@ type ReplicationInfo struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *ReplicationInfo) ToWire() (wire.Value, error) {
+func (v *ResetStickyTaskListResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [1]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.Version != nil {
-		w, err = wire.NewValueI64(*(v.Version)), error(nil)
+	if v.StickyInvalidationCount != nil {
+		w, err = wire.NewValueI64(*(v.StickyInvalidationCount)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.LastEventId != nil {
-		w, err = wire.NewValueI64(*(v.LastEventId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 20, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a ReplicationInfo struct from its Thrift-level
+// FromWire deserializes a ResetStickyTaskListResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a ReplicationInfo struct
+// An error is returned if we were unable to build a ResetStickyTaskListResponse struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -3919,12 +6336,12 @@ func (v *ReplicationInfo) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v ReplicationInfo
+//   var v ResetStickyTaskListResponse
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *ReplicationInfo) FromWire(w wire.Value) error {
+func (v *ResetStickyTaskListResponse) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -3933,17 +6350,7 @@ func (v *ReplicationInfo) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.Version = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 20:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.LastEventId = &x
+				v.StickyInvalidationCount = &x
 				if err != nil {
 					return err
 				}
@@ -3955,71 +6362,51 @@ func (v *ReplicationInfo) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a ReplicationInfo
+// String returns a readable string representation of a ResetStickyTaskListResponse
 // struct.
-func (v *ReplicationInfo) String() string {
+func (v *ResetStickyTaskListResponse) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [2]string
+	var fields [1]string
 	i := 0
-	if v.Version != nil {
-		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
-		i++
-	}
-	if v.LastEventId != nil {
-		fields[i] = fmt.Sprintf("LastEventId: %v", *(v.LastEventId))
+	if v.StickyInvalidationCount != nil {
+		fields[i] = fmt.Sprintf("StickyInvalidationCount: %v", *(v.StickyInvalidationCount))
 		i++
 	}
 
-	return fmt.Sprintf("ReplicationInfo{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("ResetStickyTaskListResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this ReplicationInfo match the
-// provided ReplicationInfo.
+// Equals returns true if all the fields of this ResetStickyTaskListResponse match the
+// provided ResetStickyTaskListResponse.
 //
 // This function performs a deep comparison.
-func (v *ReplicationInfo) Equals(rhs *ReplicationInfo) bool {
-	if !_I64_EqualsPtr(v.Version, rhs.Version) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.LastEventId, rhs.LastEventId) {
+func (v *ResetStickyTaskListResponse) Equals(rhs *ResetStickyTaskListResponse) bool {
+	if !_I64_EqualsPtr(v.StickyInvalidationCount, rhs.StickyInvalidationCount) {
 		return false
 	}
 
 	return true
 }
 
-// GetVersion returns the value of Version if it is set or its
-// zero value if it is unset.
-func (v *ReplicationInfo) GetVersion() (o int64) {
-	if v.Version != nil {
-		return *v.Version
-	}
-
-	return
-}
-
-// GetLastEventId returns the value of LastEventId if it is set or its
+// GetStickyInvalidationCount returns the value of StickyInvalidationCount if it is set or its
 // zero value if it is unset.
-func (v *ReplicationInfo) GetLastEventId() (o int64) {
-	if v.LastEventId != nil {
-		return *v.LastEventId
+func (v *ResetStickyTaskListResponse) GetStickyInvalidationCount() (o int64) {
+	if v.StickyInvalidationCount != nil {
+		return *v.StickyInvalidationCount
 	}
 
 	return
 }
 
-type RequestCancelWorkflowExecutionRequest struct {
-	DomainUUID                *string                                       `json:"domainUUID,omitempty"`
-	CancelRequest             *shared.RequestCancelWorkflowExecutionRequest `json:"cancelRequest,omitempty"`
-	ExternalInitiatedEventId  *int64                                        `json:"externalInitiatedEventId,omitempty"`
-	ExternalWorkflowExecution *shared.WorkflowExecution                     `json:"externalWorkflowExecution,omitempty"`
-	ChildWorkflowOnly         *bool                                         `json:"childWorkflowOnly,omitempty"`
+type RespondActivityTaskCanceledRequest struct {
+	DomainUUID    *string                                    `json:"domainUUID,omitempty"`
+	CancelRequest *shared.RespondActivityTaskCanceledRequest `json:"cancelRequest,omitempty"`
 }
 
-// ToWire translates a RequestCancelWorkflowExecutionRequest struct into a Thrift-level intermediate
+// ToWire translates a RespondActivityTaskCanceledRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -4034,9 +6421,9 @@ type RequestCancelWorkflowExecutionRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RequestCancelWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+func (v *RespondActivityTaskCanceledRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -4058,45 +6445,21 @@ func (v *RequestCancelWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.ExternalInitiatedEventId != nil {
-		w, err = wire.NewValueI64(*(v.ExternalInitiatedEventId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
-	if v.ExternalWorkflowExecution != nil {
-		w, err = v.ExternalWorkflowExecution.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
-	}
-	if v.ChildWorkflowOnly != nil {
-		w, err = wire.NewValueBool(*(v.ChildWorkflowOnly)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 50, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _RequestCancelWorkflowExecutionRequest_Read(w wire.Value) (*shared.RequestCancelWorkflowExecutionRequest, error) {
-	var v shared.RequestCancelWorkflowExecutionRequest
+func _RespondActivityTaskCanceledRequest_Read(w wire.Value) (*shared.RespondActivityTaskCanceledRequest, error) {
+	var v shared.RespondActivityTaskCanceledRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a RequestCancelWorkflowExecutionRequest struct from its Thrift-level
+// FromWire deserializes a RespondActivityTaskCanceledRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RequestCancelWorkflowExecutionRequest struct
+// An error is returned if we were unable to build a RespondActivityTaskCanceledRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -4104,12 +6467,12 @@ func _RequestCancelWorkflowExecutionRequest_Read(w wire.Value) (*shared.RequestC
 //     return nil, err
 //   }
 //
-//   var v RequestCancelWorkflowExecutionRequest
+//   var v RespondActivityTaskCanceledRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RequestCancelWorkflowExecutionRequest) FromWire(w wire.Value) error {
+func (v *RespondActivityTaskCanceledRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -4126,35 +6489,7 @@ func (v *RequestCancelWorkflowExecutionRequest) FromWire(w wire.Value) error {
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.CancelRequest, err = _RequestCancelWorkflowExecutionRequest_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 30:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.ExternalInitiatedEventId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TStruct {
-				v.ExternalWorkflowExecution, err = _WorkflowExecution_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 50:
-			if field.Value.Type() == wire.TBool {
-				var x bool
-				x, err = field.Value.GetBool(), error(nil)
-				v.ChildWorkflowOnly = &x
+				v.CancelRequest, err = _RespondActivityTaskCanceledRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -4166,14 +6501,14 @@ func (v *RequestCancelWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RequestCancelWorkflowExecutionRequest
+// String returns a readable string representation of a RespondActivityTaskCanceledRequest
 // struct.
-func (v *RequestCancelWorkflowExecutionRequest) String() string {
+func (v *RespondActivityTaskCanceledRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [2]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
@@ -4183,49 +6518,28 @@ func (v *RequestCancelWorkflowExecutionRequest) String() string {
 		fields[i] = fmt.Sprintf("CancelRequest: %v", v.CancelRequest)
 		i++
 	}
-	if v.ExternalInitiatedEventId != nil {
-		fields[i] = fmt.Sprintf("ExternalInitiatedEventId: %v", *(v.ExternalInitiatedEventId))
-		i++
-	}
-	if v.ExternalWorkflowExecution != nil {
-		fields[i] = fmt.Sprintf("ExternalWorkflowExecution: %v", v.ExternalWorkflowExecution)
-		i++
-	}
-	if v.ChildWorkflowOnly != nil {
-		fields[i] = fmt.Sprintf("ChildWorkflowOnly: %v", *(v.ChildWorkflowOnly))
-		i++
-	}
 
-	return fmt.Sprintf("RequestCancelWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RespondActivityTaskCanceledRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RequestCancelWorkflowExecutionRequest match the
-// provided RequestCancelWorkflowExecutionRequest.
+// Equals returns true if all the fields of this RespondActivityTaskCanceledRequest match the
+// provided RespondActivityTaskCanceledRequest.
 //
 // This function performs a deep comparison.
-func (v *RequestCancelWorkflowExecutionRequest) Equals(rhs *RequestCancelWorkflowExecutionRequest) bool {
+func (v *RespondActivityTaskCanceledRequest) Equals(rhs *RespondActivityTaskCanceledRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
 	if !((v.CancelRequest == nil && rhs.CancelRequest == nil) || (v.CancelRequest != nil && rhs.CancelRequest != nil && v.CancelRequest.Equals(rhs.CancelRequest))) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.ExternalInitiatedEventId, rhs.ExternalInitiatedEventId) {
-		return false
-	}
-	if !((v.ExternalWorkflowExecution == nil && rhs.ExternalWorkflowExecution == nil) || (v.ExternalWorkflowExecution != nil && rhs.ExternalWorkflowExecution != nil && v.ExternalWorkflowExecution.Equals(rhs.ExternalWorkflowExecution))) {
-		return false
-	}
-	if !_Bool_EqualsPtr(v.ChildWorkflowOnly, rhs.ChildWorkflowOnly) {
-		return false
-	}
 
 	return true
 }
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RequestCancelWorkflowExecutionRequest) GetDomainUUID() (o string) {
+func (v *RespondActivityTaskCanceledRequest) GetDomainUUID() (o string) {
 	if v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -4235,7 +6549,7 @@ func (v *RequestCancelWorkflowExecutionRequest) GetDomainUUID() (o string) {
 
 // GetCancelRequest returns the value of CancelRequest if it is set or its
 // zero value if it is unset.
-func (v *RequestCancelWorkflowExecutionRequest) GetCancelRequest() (o *shared.RequestCancelWorkflowExecutionRequest) {
+func (v *RespondActivityTaskCanceledRequest) GetCancelRequest() (o *shared.RespondActivityTaskCanceledRequest) {
 	if v.CancelRequest != nil {
 		return v.CancelRequest
 	}
@@ -4243,42 +6557,12 @@ func (v *RequestCancelWorkflowExecutionRequest) GetCancelRequest() (o *shared.Re
 	return
 }
 
-// GetExternalInitiatedEventId returns the value of ExternalInitiatedEventId if it is set or its
-// zero value if it is unset.
-func (v *RequestCancelWorkflowExecutionRequest) GetExternalInitiatedEventId() (o int64) {
-	if v.ExternalInitiatedEventId != nil {
-		return *v.ExternalInitiatedEventId
-	}
-
-	return
-}
-
-// GetExternalWorkflowExecution returns the value of ExternalWorkflowExecution if it is set or its
-// zero value if it is unset.
-func (v *RequestCancelWorkflowExecutionRequest) GetExternalWorkflowExecution() (o *shared.WorkflowExecution) {
-	if v.ExternalWorkflowExecution != nil {
-		return v.ExternalWorkflowExecution
-	}
-
-	return
-}
-
-// GetChildWorkflowOnly returns the value of ChildWorkflowOnly if it is set or its
-// zero value if it is unset.
-func (v *RequestCancelWorkflowExecutionRequest) GetChildWorkflowOnly() (o bool) {
-	if v.ChildWorkflowOnly != nil {
-		return *v.ChildWorkflowOnly
-	}
-
-	return
-}
-
-type ResetStickyTaskListRequest struct {
-	DomainUUID *string                   `json:"domainUUID,omitempty"`
-	Execution  *shared.WorkflowExecution `json:"execution,omitempty"`
+type RespondActivityTaskCompletedRequest struct {
+	DomainUUID      *string                                     `json:"domainUUID,omitempty"`
+	CompleteRequest *shared.RespondActivityTaskCompletedRequest `json:"completeRequest,omitempty"`
 }
 
-// ToWire translates a ResetStickyTaskListRequest struct into a Thrift-level intermediate
+// ToWire translates a RespondActivityTaskCompletedRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -4293,7 +6577,7 @@ type ResetStickyTaskListRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *ResetStickyTaskListRequest) ToWire() (wire.Value, error) {
+func (v *RespondActivityTaskCompletedRequest) ToWire() (wire.Value, error) {
 	var (
 		fields [2]wire.Field
 		i      int = 0
@@ -4309,8 +6593,8 @@ func (v *ResetStickyTaskListRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.Execution != nil {
-		w, err = v.Execution.ToWire()
+	if v.CompleteRequest != nil {
+		w, err = v.CompleteRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -4321,11 +6605,17 @@ func (v *ResetStickyTaskListRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a ResetStickyTaskListRequest struct from its Thrift-level
+func _RespondActivityTaskCompletedRequest_Read(w wire.Value) (*shared.RespondActivityTaskCompletedRequest, error) {
+	var v shared.RespondActivityTaskCompletedRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a RespondActivityTaskCompletedRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a ResetStickyTaskListRequest struct
+// An error is returned if we were unable to build a RespondActivityTaskCompletedRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -4333,12 +6623,12 @@ func (v *ResetStickyTaskListRequest) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v ResetStickyTaskListRequest
+//   var v RespondActivityTaskCompletedRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *ResetStickyTaskListRequest) FromWire(w wire.Value) error {
+func (v *RespondActivityTaskCompletedRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -4355,7 +6645,7 @@ func (v *ResetStickyTaskListRequest) FromWire(w wire.Value) error {
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				v.CompleteRequest, err = _RespondActivityTaskCompletedRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -4367,9 +6657,9 @@ func (v *ResetStickyTaskListRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a ResetStickyTaskListRequest
+// String returns a readable string representation of a RespondActivityTaskCompletedRequest
 // struct.
-func (v *ResetStickyTaskListRequest) String() string {
+func (v *RespondActivityTaskCompletedRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -4380,23 +6670,23 @@ func (v *ResetStickyTaskListRequest) String() string {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.Execution != nil {
-		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+	if v.CompleteRequest != nil {
+		fields[i] = fmt.Sprintf("CompleteRequest: %v", v.CompleteRequest)
 		i++
 	}
 
-	return fmt.Sprintf("ResetStickyTaskListRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RespondActivityTaskCompletedRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this ResetStickyTaskListRequest match the
-// provided ResetStickyTaskListRequest.
+// Equals returns true if all the fields of this RespondActivityTaskCompletedRequest match the
+// provided RespondActivityTaskCompletedRequest.
 //
 // This function performs a deep comparison.
-func (v *ResetStickyTaskListRequest) Equals(rhs *ResetStickyTaskListRequest) bool {
+func (v *RespondActivityTaskCompletedRequest) Equals(rhs *RespondActivityTaskCompletedRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+	if !((v.CompleteRequest == nil && rhs.CompleteRequest == nil) || (v.CompleteRequest != nil && rhs.CompleteRequest != nil && v.CompleteRequest.Equals(rhs.CompleteRequest))) {
 		return false
 	}
 
@@ -4405,7 +6695,7 @@ func (v *ResetStickyTaskListRequest) Equals(rhs *ResetStickyTaskListRequest) boo
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *ResetStickyTaskListRequest) GetDomainUUID() (o string) {
+func (v *RespondActivityTaskCompletedRequest) GetDomainUUID() (o string) {
 	if v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -4413,20 +6703,22 @@ func (v *ResetStickyTaskListRequest) GetDomainUUID() (o string) {
 	return
 }
 
-// GetExecution returns the value of Execution if it is set or its
+// GetCompleteRequest returns the value of CompleteRequest if it is set or its
 // zero value if it is unset.
-func (v *ResetStickyTaskListRequest) GetExecution() (o *shared.WorkflowExecution) {
-	if v.Execution != nil {
-		return v.Execution
+func (v *RespondActivityTaskCompletedRequest) GetCompleteRequest() (o *shared.RespondActivityTaskCompletedRequest) {
+	if v.CompleteRequest != nil {
+		return v.CompleteRequest
 	}
 
 	return
 }
 
-type ResetStickyTaskListResponse struct {
+type RespondActivityTaskFailedRequest struct {
+	DomainUUID    *string                                  `json:"domainUUID,omitempty"`
+	FailedRequest *shared.RespondActivityTaskFailedRequest `json:"failedRequest,omitempty"`
 }
 
-// ToWire translates a ResetStickyTaskListResponse struct into a Thrift-level intermediate
+// ToWire translates a RespondActivityTaskFailedRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -4441,20 +6733,45 @@ type ResetStickyTaskListResponse struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *ResetStickyTaskListResponse) ToWire() (wire.Value, error) {
+func (v *RespondActivityTaskFailedRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [0]wire.Field
+		fields [2]wire.Field
 		i      int = 0
+		w      wire.Value
+		err    error
 	)
 
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.FailedRequest != nil {
+		w, err = v.FailedRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a ResetStickyTaskListResponse struct from its Thrift-level
+func _RespondActivityTaskFailedRequest_Read(w wire.Value) (*shared.RespondActivityTaskFailedRequest, error) {
+	var v shared.RespondActivityTaskFailedRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a RespondActivityTaskFailedRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a ResetStickyTaskListResponse struct
+// An error is returned if we were unable to build a RespondActivityTaskFailedRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -4462,49 +6779,102 @@ func (v *ResetStickyTaskListResponse) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v ResetStickyTaskListResponse
+//   var v RespondActivityTaskFailedRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *ResetStickyTaskListResponse) FromWire(w wire.Value) error {
+func (v *RespondActivityTaskFailedRequest) FromWire(w wire.Value) error {
+	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.FailedRequest, err = _RespondActivityTaskFailedRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
 		}
 	}
 
 	return nil
 }
 
-// String returns a readable string representation of a ResetStickyTaskListResponse
+// String returns a readable string representation of a RespondActivityTaskFailedRequest
 // struct.
-func (v *ResetStickyTaskListResponse) String() string {
+func (v *RespondActivityTaskFailedRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [0]string
+	var fields [2]string
 	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.FailedRequest != nil {
+		fields[i] = fmt.Sprintf("FailedRequest: %v", v.FailedRequest)
+		i++
+	}
 
-	return fmt.Sprintf("ResetStickyTaskListResponse{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RespondActivityTaskFailedRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this ResetStickyTaskListResponse match the
-// provided ResetStickyTaskListResponse.
+// Equals returns true if all the fields of this RespondActivityTaskFailedRequest match the
+// provided RespondActivityTaskFailedRequest.
 //
 // This function performs a deep comparison.
-func (v *ResetStickyTaskListResponse) Equals(rhs *ResetStickyTaskListResponse) bool {
+func (v *RespondActivityTaskFailedRequest) Equals(rhs *RespondActivityTaskFailedRequest) bool {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.FailedRequest == nil && rhs.FailedRequest == nil) || (v.FailedRequest != nil && rhs.FailedRequest != nil && v.FailedRequest.Equals(rhs.FailedRequest))) {
+		return false
+	}
 
 	return true
 }
 
-type RespondActivityTaskCanceledRequest struct {
-	DomainUUID    *string                                    `json:"domainUUID,omitempty"`
-	CancelRequest *shared.RespondActivityTaskCanceledRequest `json:"cancelRequest,omitempty"`
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *RespondActivityTaskFailedRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
 }
 
-// ToWire translates a RespondActivityTaskCanceledRequest struct into a Thrift-level intermediate
+// GetFailedRequest returns the value of FailedRequest if it is set or its
+// zero value if it is unset.
+func (v *RespondActivityTaskFailedRequest) GetFailedRequest() (o *shared.RespondActivityTaskFailedRequest) {
+	if v.FailedRequest != nil {
+		return v.FailedRequest
+	}
+
+	return
+}
+
+type RespondDecisionTaskCompletedRequest struct {
+	DomainUUID      *string                                     `json:"domainUUID,omitempty"`
+	CompleteRequest *shared.RespondDecisionTaskCompletedRequest `json:"completeRequest,omitempty"`
+}
+
+// ToWire translates a RespondDecisionTaskCompletedRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -4519,7 +6889,7 @@ type RespondActivityTaskCanceledRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RespondActivityTaskCanceledRequest) ToWire() (wire.Value, error) {
+func (v *RespondDecisionTaskCompletedRequest) ToWire() (wire.Value, error) {
 	var (
 		fields [2]wire.Field
 		i      int = 0
@@ -4535,8 +6905,8 @@ func (v *RespondActivityTaskCanceledRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.CancelRequest != nil {
-		w, err = v.CancelRequest.ToWire()
+	if v.CompleteRequest != nil {
+		w, err = v.CompleteRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -4547,17 +6917,17 @@ func (v *RespondActivityTaskCanceledRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _RespondActivityTaskCanceledRequest_Read(w wire.Value) (*shared.RespondActivityTaskCanceledRequest, error) {
-	var v shared.RespondActivityTaskCanceledRequest
+func _RespondDecisionTaskCompletedRequest_Read(w wire.Value) (*shared.RespondDecisionTaskCompletedRequest, error) {
+	var v shared.RespondDecisionTaskCompletedRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a RespondActivityTaskCanceledRequest struct from its Thrift-level
+// FromWire deserializes a RespondDecisionTaskCompletedRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RespondActivityTaskCanceledRequest struct
+// An error is returned if we were unable to build a RespondDecisionTaskCompletedRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -4565,12 +6935,12 @@ func _RespondActivityTaskCanceledRequest_Read(w wire.Value) (*shared.RespondActi
 //     return nil, err
 //   }
 //
-//   var v RespondActivityTaskCanceledRequest
+//   var v RespondDecisionTaskCompletedRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RespondActivityTaskCanceledRequest) FromWire(w wire.Value) error {
+func (v *RespondDecisionTaskCompletedRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -4587,7 +6957,7 @@ func (v *RespondActivityTaskCanceledRequest) FromWire(w wire.Value) error {
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.CancelRequest, err = _RespondActivityTaskCanceledRequest_Read(field.Value)
+				v.CompleteRequest, err = _RespondDecisionTaskCompletedRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -4599,9 +6969,9 @@ func (v *RespondActivityTaskCanceledRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RespondActivityTaskCanceledRequest
+// String returns a readable string representation of a RespondDecisionTaskCompletedRequest
 // struct.
-func (v *RespondActivityTaskCanceledRequest) String() string {
+func (v *RespondDecisionTaskCompletedRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -4612,23 +6982,23 @@ func (v *RespondActivityTaskCanceledRequest) String() string {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.CancelRequest != nil {
-		fields[i] = fmt.Sprintf("CancelRequest: %v", v.CancelRequest)
+	if v.CompleteRequest != nil {
+		fields[i] = fmt.Sprintf("CompleteRequest: %v", v.CompleteRequest)
 		i++
 	}
 
-	return fmt.Sprintf("RespondActivityTaskCanceledRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RespondDecisionTaskCompletedRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RespondActivityTaskCanceledRequest match the
-// provided RespondActivityTaskCanceledRequest.
+// Equals returns true if all the fields of this RespondDecisionTaskCompletedRequest match the
+// provided RespondDecisionTaskCompletedRequest.
 //
 // This function performs a deep comparison.
-func (v *RespondActivityTaskCanceledRequest) Equals(rhs *RespondActivityTaskCanceledRequest) bool {
+func (v *RespondDecisionTaskCompletedRequest) Equals(rhs *RespondDecisionTaskCompletedRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !((v.CancelRequest == nil && rhs.CancelRequest == nil) || (v.CancelRequest != nil && rhs.CancelRequest != nil && v.CancelRequest.Equals(rhs.CancelRequest))) {
+	if !((v.CompleteRequest == nil && rhs.CompleteRequest == nil) || (v.CompleteRequest != nil && rhs.CompleteRequest != nil && v.CompleteRequest.Equals(rhs.CompleteRequest))) {
 		return false
 	}
 
@@ -4637,7 +7007,7 @@ func (v *RespondActivityTaskCanceledRequest) Equals(rhs *RespondActivityTaskCanc
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RespondActivityTaskCanceledRequest) GetDomainUUID() (o string) {
+func (v *RespondDecisionTaskCompletedRequest) GetDomainUUID() (o string) {
 	if v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -4645,22 +7015,51 @@ func (v *RespondActivityTaskCanceledRequest) GetDomainUUID() (o string) {
 	return
 }
 
-// GetCancelRequest returns the value of CancelRequest if it is set or its
+// GetCompleteRequest returns the value of CompleteRequest if it is set or its
 // zero value if it is unset.
-func (v *RespondActivityTaskCanceledRequest) GetCancelRequest() (o *shared.RespondActivityTaskCanceledRequest) {
-	if v.CancelRequest != nil {
-		return v.CancelRequest
+func (v *RespondDecisionTaskCompletedRequest) GetCompleteRequest() (o *shared.RespondDecisionTaskCompletedRequest) {
+	if v.CompleteRequest != nil {
+		return v.CompleteRequest
 	}
 
 	return
 }
 
-type RespondActivityTaskCompletedRequest struct {
-	DomainUUID      *string                                     `json:"domainUUID,omitempty"`
-	CompleteRequest *shared.RespondActivityTaskCompletedRequest `json:"completeRequest,omitempty"`
+type RespondDecisionTaskCompletedResponse struct {
+	StartedResponse             *RecordDecisionTaskStartedResponse   `json:"startedResponse,omitempty"`
+	ActivitiesToDispatchLocally []*RecordActivityTaskStartedResponse `json:"activitiesToDispatchLocally,omitempty"`
 }
 
-// ToWire translates a RespondActivityTaskCompletedRequest struct into a Thrift-level intermediate
+type _List_RecordActivityTaskStartedResponse_ValueList []*RecordActivityTaskStartedResponse
+
+func (v _List_RecordActivityTaskStartedResponse_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_RecordActivityTaskStartedResponse_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_RecordActivityTaskStartedResponse_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_RecordActivityTaskStartedResponse_ValueList) Close() {}
+
+// ToWire translates a RespondDecisionTaskCompletedResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -4675,7 +7074,7 @@ type RespondActivityTaskCompletedRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RespondActivityTaskCompletedRequest) ToWire() (wire.Value, error) {
+func (v *RespondDecisionTaskCompletedResponse) ToWire() (wire.Value, error) {
 	var (
 		fields [2]wire.Field
 		i      int = 0
@@ -4683,16 +7082,16 @@ func (v *RespondActivityTaskCompletedRequest) ToWire() (wire.Value, error) {
 		err    error
 	)
 
-	if v.DomainUUID != nil {
-		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+	if v.StartedResponse != nil {
+		w, err = v.StartedResponse.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.CompleteRequest != nil {
-		w, err = v.CompleteRequest.ToWire()
+	if v.ActivitiesToDispatchLocally != nil {
+		w, err = wire.NewValueList(_List_RecordActivityTaskStartedResponse_ValueList(v.ActivitiesToDispatchLocally)), error(nil)
 		if err != nil {
 			return w, err
 		}
@@ -4703,17 +7102,41 @@ func (v *RespondActivityTaskCompletedRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _RespondActivityTaskCompletedRequest_Read(w wire.Value) (*shared.RespondActivityTaskCompletedRequest, error) {
-	var v shared.RespondActivityTaskCompletedRequest
+func _RecordDecisionTaskStartedResponse_Read(w wire.Value) (*RecordDecisionTaskStartedResponse, error) {
+	var v RecordDecisionTaskStartedResponse
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a RespondActivityTaskCompletedRequest struct from its Thrift-level
+func _RecordActivityTaskStartedResponse_Read(w wire.Value) (*RecordActivityTaskStartedResponse, error) {
+	var v RecordActivityTaskStartedResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_RecordActivityTaskStartedResponse_Read(l wire.ValueList) ([]*RecordActivityTaskStartedResponse, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*RecordActivityTaskStartedResponse, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _RecordActivityTaskStartedResponse_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a RespondDecisionTaskCompletedResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RespondActivityTaskCompletedRequest struct
+// An error is returned if we were unable to build a RespondDecisionTaskCompletedResponse struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -4721,29 +7144,27 @@ func _RespondActivityTaskCompletedRequest_Read(w wire.Value) (*shared.RespondAct
 //     return nil, err
 //   }
 //
-//   var v RespondActivityTaskCompletedRequest
+//   var v RespondDecisionTaskCompletedResponse
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RespondActivityTaskCompletedRequest) FromWire(w wire.Value) error {
+func (v *RespondDecisionTaskCompletedResponse) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.DomainUUID = &x
+			if field.Value.Type() == wire.TStruct {
+				v.StartedResponse, err = _RecordDecisionTaskStartedResponse_Read(field.Value)
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.CompleteRequest, err = _RespondActivityTaskCompletedRequest_Read(field.Value)
+			if field.Value.Type() == wire.TList {
+				v.ActivitiesToDispatchLocally, err = _List_RecordActivityTaskStartedResponse_Read(field.Value.GetList())
 				if err != nil {
 					return err
 				}
@@ -4755,68 +7176,83 @@ func (v *RespondActivityTaskCompletedRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RespondActivityTaskCompletedRequest
+// String returns a readable string representation of a RespondDecisionTaskCompletedResponse
 // struct.
-func (v *RespondActivityTaskCompletedRequest) String() string {
+func (v *RespondDecisionTaskCompletedResponse) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
 	var fields [2]string
 	i := 0
-	if v.DomainUUID != nil {
-		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+	if v.StartedResponse != nil {
+		fields[i] = fmt.Sprintf("StartedResponse: %v", v.StartedResponse)
 		i++
 	}
-	if v.CompleteRequest != nil {
-		fields[i] = fmt.Sprintf("CompleteRequest: %v", v.CompleteRequest)
+	if v.ActivitiesToDispatchLocally != nil {
+		fields[i] = fmt.Sprintf("ActivitiesToDispatchLocally: %v", v.ActivitiesToDispatchLocally)
 		i++
 	}
 
-	return fmt.Sprintf("RespondActivityTaskCompletedRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RespondDecisionTaskCompletedResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RespondActivityTaskCompletedRequest match the
-// provided RespondActivityTaskCompletedRequest.
+func _List_RecordActivityTaskStartedResponse_Equals(lhs, rhs []*RecordActivityTaskStartedResponse) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this RespondDecisionTaskCompletedResponse match the
+// provided RespondDecisionTaskCompletedResponse.
 //
 // This function performs a deep comparison.
-func (v *RespondActivityTaskCompletedRequest) Equals(rhs *RespondActivityTaskCompletedRequest) bool {
-	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+func (v *RespondDecisionTaskCompletedResponse) Equals(rhs *RespondDecisionTaskCompletedResponse) bool {
+	if !((v.StartedResponse == nil && rhs.StartedResponse == nil) || (v.StartedResponse != nil && rhs.StartedResponse != nil && v.StartedResponse.Equals(rhs.StartedResponse))) {
 		return false
 	}
-	if !((v.CompleteRequest == nil && rhs.CompleteRequest == nil) || (v.CompleteRequest != nil && rhs.CompleteRequest != nil && v.CompleteRequest.Equals(rhs.CompleteRequest))) {
+	if !((v.ActivitiesToDispatchLocally == nil && rhs.ActivitiesToDispatchLocally == nil) || (v.ActivitiesToDispatchLocally != nil && rhs.ActivitiesToDispatchLocally != nil && _List_RecordActivityTaskStartedResponse_Equals(v.ActivitiesToDispatchLocally, rhs.ActivitiesToDispatchLocally))) {
 		return false
 	}
 
 	return true
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
+// GetStartedResponse returns the value of StartedResponse if it is set or its
 // zero value if it is unset.
-func (v *RespondActivityTaskCompletedRequest) GetDomainUUID() (o string) {
-	if v.DomainUUID != nil {
-		return *v.DomainUUID
+func (v *RespondDecisionTaskCompletedResponse) GetStartedResponse() (o *RecordDecisionTaskStartedResponse) {
+	if v.StartedResponse != nil {
+		return v.StartedResponse
 	}
 
 	return
 }
 
-// GetCompleteRequest returns the value of CompleteRequest if it is set or its
+// GetActivitiesToDispatchLocally returns the value of ActivitiesToDispatchLocally if it is set or its
 // zero value if it is unset.
-func (v *RespondActivityTaskCompletedRequest) GetCompleteRequest() (o *shared.RespondActivityTaskCompletedRequest) {
-	if v.CompleteRequest != nil {
-		return v.CompleteRequest
+func (v *RespondDecisionTaskCompletedResponse) GetActivitiesToDispatchLocally() (o []*RecordActivityTaskStartedResponse) {
+	if v.ActivitiesToDispatchLocally != nil {
+		return v.ActivitiesToDispatchLocally
 	}
 
 	return
 }
 
-type RespondActivityTaskFailedRequest struct {
+type RespondDecisionTaskFailedRequest struct {
 	DomainUUID    *string                                  `json:"domainUUID,omitempty"`
-	FailedRequest *shared.RespondActivityTaskFailedRequest `json:"failedRequest,omitempty"`
+	FailedRequest *shared.RespondDecisionTaskFailedRequest `json:"failedRequest,omitempty"`
 }
 
-// ToWire translates a RespondActivityTaskFailedRequest struct into a Thrift-level intermediate
+// ToWire translates a RespondDecisionTaskFailedRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -4831,7 +7267,7 @@ type RespondActivityTaskFailedRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RespondActivityTaskFailedRequest) ToWire() (wire.Value, error) {
+func (v *RespondDecisionTaskFailedRequest) ToWire() (wire.Value, error) {
 	var (
 		fields [2]wire.Field
 		i      int = 0
@@ -4859,17 +7295,17 @@ func (v *RespondActivityTaskFailedRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _RespondActivityTaskFailedRequest_Read(w wire.Value) (*shared.RespondActivityTaskFailedRequest, error) {
-	var v shared.RespondActivityTaskFailedRequest
+func _RespondDecisionTaskFailedRequest_Read(w wire.Value) (*shared.RespondDecisionTaskFailedRequest, error) {
+	var v shared.RespondDecisionTaskFailedRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a RespondActivityTaskFailedRequest struct from its Thrift-level
+// FromWire deserializes a RespondDecisionTaskFailedRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RespondActivityTaskFailedRequest struct
+// An error is returned if we were unable to build a RespondDecisionTaskFailedRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -4877,12 +7313,12 @@ func _RespondActivityTaskFailedRequest_Read(w wire.Value) (*shared.RespondActivi
 //     return nil, err
 //   }
 //
-//   var v RespondActivityTaskFailedRequest
+//   var v RespondDecisionTaskFailedRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RespondActivityTaskFailedRequest) FromWire(w wire.Value) error {
+func (v *RespondDecisionTaskFailedRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -4899,7 +7335,7 @@ func (v *RespondActivityTaskFailedRequest) FromWire(w wire.Value) error {
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.FailedRequest, err = _RespondActivityTaskFailedRequest_Read(field.Value)
+				v.FailedRequest, err = _RespondDecisionTaskFailedRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -4911,9 +7347,9 @@ func (v *RespondActivityTaskFailedRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RespondActivityTaskFailedRequest
+// String returns a readable string representation of a RespondDecisionTaskFailedRequest
 // struct.
-func (v *RespondActivityTaskFailedRequest) String() string {
+func (v *RespondDecisionTaskFailedRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -4929,14 +7365,14 @@ func (v *RespondActivityTaskFailedRequest) String() string {
 		i++
 	}
 
-	return fmt.Sprintf("RespondActivityTaskFailedRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("RespondDecisionTaskFailedRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RespondActivityTaskFailedRequest match the
-// provided RespondActivityTaskFailedRequest.
+// Equals returns true if all the fields of this RespondDecisionTaskFailedRequest match the
+// provided RespondDecisionTaskFailedRequest.
 //
 // This function performs a deep comparison.
-func (v *RespondActivityTaskFailedRequest) Equals(rhs *RespondActivityTaskFailedRequest) bool {
+func (v *RespondDecisionTaskFailedRequest) Equals(rhs *RespondDecisionTaskFailedRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
@@ -4949,7 +7385,7 @@ func (v *RespondActivityTaskFailedRequest) Equals(rhs *RespondActivityTaskFailed
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RespondActivityTaskFailedRequest) GetDomainUUID() (o string) {
+func (v *RespondDecisionTaskFailedRequest) GetDomainUUID() (o string) {
 	if v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -4959,7 +7395,7 @@ func (v *RespondActivityTaskFailedRequest) GetDomainUUID() (o string) {
 
 // GetFailedRequest returns the value of FailedRequest if it is set or its
 // zero value if it is unset.
-func (v *RespondActivityTaskFailedRequest) GetFailedRequest() (o *shared.RespondActivityTaskFailedRequest) {
+func (v *RespondDecisionTaskFailedRequest) GetFailedRequest() (o *shared.RespondDecisionTaskFailedRequest) {
 	if v.FailedRequest != nil {
 		return v.FailedRequest
 	}
@@ -4967,12 +7403,12 @@ func (v *RespondActivityTaskFailedRequest) GetFailedRequest() (o *shared.Respond
 	return
 }
 
-type RespondDecisionTaskCompletedRequest struct {
-	DomainUUID      *string                                     `json:"domainUUID,omitempty"`
-	CompleteRequest *shared.RespondDecisionTaskCompletedRequest `json:"completeRequest,omitempty"`
+type ScheduleDecisionTaskRequest struct {
+	DomainUUID        *string                   `json:"domainUUID,omitempty"`
+	WorkflowExecution *shared.WorkflowExecution `json:"workflowExecution,omitempty"`
 }
 
-// ToWire translates a RespondDecisionTaskCompletedRequest struct into a Thrift-level intermediate
+// ToWire translates a ScheduleDecisionTaskRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -4987,7 +7423,7 @@ type RespondDecisionTaskCompletedRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RespondDecisionTaskCompletedRequest) ToWire() (wire.Value, error) {
+func (v *ScheduleDecisionTaskRequest) ToWire() (wire.Value, error) {
 	var (
 		fields [2]wire.Field
 		i      int = 0
@@ -5003,8 +7439,8 @@ func (v *RespondDecisionTaskCompletedRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.CompleteRequest != nil {
-		w, err = v.CompleteRequest.ToWire()
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -5015,17 +7451,11 @@ func (v *RespondDecisionTaskCompletedRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _RespondDecisionTaskCompletedRequest_Read(w wire.Value) (*shared.RespondDecisionTaskCompletedRequest, error) {
-	var v shared.RespondDecisionTaskCompletedRequest
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a RespondDecisionTaskCompletedRequest struct from its Thrift-level
+// FromWire deserializes a ScheduleDecisionTaskRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RespondDecisionTaskCompletedRequest struct
+// An error is returned if we were unable to build a ScheduleDecisionTaskRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -5033,12 +7463,12 @@ func _RespondDecisionTaskCompletedRequest_Read(w wire.Value) (*shared.RespondDec
 //     return nil, err
 //   }
 //
-//   var v RespondDecisionTaskCompletedRequest
+//   var v ScheduleDecisionTaskRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RespondDecisionTaskCompletedRequest) FromWire(w wire.Value) error {
+func (v *ScheduleDecisionTaskRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -5055,7 +7485,7 @@ func (v *RespondDecisionTaskCompletedRequest) FromWire(w wire.Value) error {
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.CompleteRequest, err = _RespondDecisionTaskCompletedRequest_Read(field.Value)
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -5067,9 +7497,9 @@ func (v *RespondDecisionTaskCompletedRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RespondDecisionTaskCompletedRequest
+// String returns a readable string representation of a ScheduleDecisionTaskRequest
 // struct.
-func (v *RespondDecisionTaskCompletedRequest) String() string {
+func (v *ScheduleDecisionTaskRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -5080,23 +7510,23 @@ func (v *RespondDecisionTaskCompletedRequest) String() string {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.CompleteRequest != nil {
-		fields[i] = fmt.Sprintf("CompleteRequest: %v", v.CompleteRequest)
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
 		i++
 	}
 
-	return fmt.Sprintf("RespondDecisionTaskCompletedRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("ScheduleDecisionTaskRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RespondDecisionTaskCompletedRequest match the
-// provided RespondDecisionTaskCompletedRequest.
+// Equals returns true if all the fields of this ScheduleDecisionTaskRequest match the
+// provided ScheduleDecisionTaskRequest.
 //
 // This function performs a deep comparison.
-func (v *RespondDecisionTaskCompletedRequest) Equals(rhs *RespondDecisionTaskCompletedRequest) bool {
+func (v *ScheduleDecisionTaskRequest) Equals(rhs *ScheduleDecisionTaskRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !((v.CompleteRequest == nil && rhs.CompleteRequest == nil) || (v.CompleteRequest != nil && rhs.CompleteRequest != nil && v.CompleteRequest.Equals(rhs.CompleteRequest))) {
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
 		return false
 	}
 
@@ -5105,7 +7535,7 @@ func (v *RespondDecisionTaskCompletedRequest) Equals(rhs *RespondDecisionTaskCom
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RespondDecisionTaskCompletedRequest) GetDomainUUID() (o string) {
+func (v *ScheduleDecisionTaskRequest) GetDomainUUID() (o string) {
 	if v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -5113,21 +7543,22 @@ func (v *RespondDecisionTaskCompletedRequest) GetDomainUUID() (o string) {
 	return
 }
 
-// GetCompleteRequest returns the value of CompleteRequest if it is set or its
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
 // zero value if it is unset.
-func (v *RespondDecisionTaskCompletedRequest) GetCompleteRequest() (o *shared.RespondDecisionTaskCompletedRequest) {
-	if v.CompleteRequest != nil {
-		return v.CompleteRequest
+func (v *ScheduleDecisionTaskRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
+	if v.WorkflowExecution != nil {
+		return v.WorkflowExecution
 	}
 
 	return
 }
 
-type RespondDecisionTaskCompletedResponse struct {
-	StartedResponse *RecordDecisionTaskStartedResponse `json:"startedResponse,omitempty"`
+type ShardOwnershipLostError struct {
+	Message *string `json:"message,omitempty"`
+	Owner   *string `json:"owner,omitempty"`
 }
 
-// ToWire translates a RespondDecisionTaskCompletedResponse struct into a Thrift-level intermediate
+// ToWire translates a ShardOwnershipLostError struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -5142,37 +7573,39 @@ type RespondDecisionTaskCompletedResponse struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RespondDecisionTaskCompletedResponse) ToWire() (wire.Value, error) {
+func (v *ShardOwnershipLostError) ToWire() (wire.Value, error) {
 	var (
-		fields [1]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.StartedResponse != nil {
-		w, err = v.StartedResponse.ToWire()
+	if v.Message != nil {
+		w, err = wire.NewValueString(*(v.Message)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Owner != nil {
+		w, err = wire.NewValueString(*(v.Owner)), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 10, Value: w}
+		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _RecordDecisionTaskStartedResponse_Read(w wire.Value) (*RecordDecisionTaskStartedResponse, error) {
-	var v RecordDecisionTaskStartedResponse
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a RespondDecisionTaskCompletedResponse struct from its Thrift-level
+// FromWire deserializes a ShardOwnershipLostError struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RespondDecisionTaskCompletedResponse struct
+// An error is returned if we were unable to build a ShardOwnershipLostError struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -5180,19 +7613,31 @@ func _RecordDecisionTaskStartedResponse_Read(w wire.Value) (*RecordDecisionTaskS
 //     return nil, err
 //   }
 //
-//   var v RespondDecisionTaskCompletedResponse
+//   var v ShardOwnershipLostError
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RespondDecisionTaskCompletedResponse) FromWire(w wire.Value) error {
+func (v *ShardOwnershipLostError) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
-			if field.Value.Type() == wire.TStruct {
-				v.StartedResponse, err = _RecordDecisionTaskStartedResponse_Read(field.Value)
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Message = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Owner = &x
 				if err != nil {
 					return err
 				}
@@ -5204,51 +7649,72 @@ func (v *RespondDecisionTaskCompletedResponse) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RespondDecisionTaskCompletedResponse
+// String returns a readable string representation of a ShardOwnershipLostError
 // struct.
-func (v *RespondDecisionTaskCompletedResponse) String() string {
+func (v *ShardOwnershipLostError) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [1]string
+	var fields [2]string
 	i := 0
-	if v.StartedResponse != nil {
-		fields[i] = fmt.Sprintf("StartedResponse: %v", v.StartedResponse)
+	if v.Message != nil {
+		fields[i] = fmt.Sprintf("Message: %v", *(v.Message))
+		i++
+	}
+	if v.Owner != nil {
+		fields[i] = fmt.Sprintf("Owner: %v", *(v.Owner))
 		i++
 	}
 
-	return fmt.Sprintf("RespondDecisionTaskCompletedResponse{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("ShardOwnershipLostError{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RespondDecisionTaskCompletedResponse match the
-// provided RespondDecisionTaskCompletedResponse.
+// Equals returns true if all the fields of this ShardOwnershipLostError match the
+// provided ShardOwnershipLostError.
 //
 // This function performs a deep comparison.
-func (v *RespondDecisionTaskCompletedResponse) Equals(rhs *RespondDecisionTaskCompletedResponse) bool {
-	if !((v.StartedResponse == nil && rhs.StartedResponse == nil) || (v.StartedResponse != nil && rhs.StartedResponse != nil && v.StartedResponse.Equals(rhs.StartedResponse))) {
+func (v *ShardOwnershipLostError) Equals(rhs *ShardOwnershipLostError) bool {
+	if !_String_EqualsPtr(v.Message, rhs.Message) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Owner, rhs.Owner) {
 		return false
 	}
 
 	return true
 }
 
-// GetStartedResponse returns the value of StartedResponse if it is set or its
+// GetMessage returns the value of Message if it is set or its
 // zero value if it is unset.
-func (v *RespondDecisionTaskCompletedResponse) GetStartedResponse() (o *RecordDecisionTaskStartedResponse) {
-	if v.StartedResponse != nil {
-		return v.StartedResponse
+func (v *ShardOwnershipLostError) GetMessage() (o string) {
+	if v.Message != nil {
+		return *v.Message
 	}
 
 	return
 }
 
-type RespondDecisionTaskFailedRequest struct {
-	DomainUUID    *string                                  `json:"domainUUID,omitempty"`
-	FailedRequest *shared.RespondDecisionTaskFailedRequest `json:"failedRequest,omitempty"`
+// GetOwner returns the value of Owner if it is set or its
+// zero value if it is unset.
+func (v *ShardOwnershipLostError) GetOwner() (o string) {
+	if v.Owner != nil {
+		return *v.Owner
+	}
+
+	return
 }
 
-// ToWire translates a RespondDecisionTaskFailedRequest struct into a Thrift-level intermediate
+func (v *ShardOwnershipLostError) Error() string {
+	return v.String()
+}
+
+type SignalWithStartWorkflowExecutionRequest struct {
+	DomainUUID             *string                                         `json:"domainUUID,omitempty"`
+	SignalWithStartRequest *shared.SignalWithStartWorkflowExecutionRequest `json:"signalWithStartRequest,omitempty"`
+}
+
+// ToWire translates a SignalWithStartWorkflowExecutionRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -5263,7 +7729,7 @@ type RespondDecisionTaskFailedRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RespondDecisionTaskFailedRequest) ToWire() (wire.Value, error) {
+func (v *SignalWithStartWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	var (
 		fields [2]wire.Field
 		i      int = 0
@@ -5279,8 +7745,8 @@ func (v *RespondDecisionTaskFailedRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.FailedRequest != nil {
-		w, err = v.FailedRequest.ToWire()
+	if v.SignalWithStartRequest != nil {
+		w, err = v.SignalWithStartRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -5291,17 +7757,17 @@ func (v *RespondDecisionTaskFailedRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _RespondDecisionTaskFailedRequest_Read(w wire.Value) (*shared.RespondDecisionTaskFailedRequest, error) {
-	var v shared.RespondDecisionTaskFailedRequest
+func _SignalWithStartWorkflowExecutionRequest_Read(w wire.Value) (*shared.SignalWithStartWorkflowExecutionRequest, error) {
+	var v shared.SignalWithStartWorkflowExecutionRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a RespondDecisionTaskFailedRequest struct from its Thrift-level
+// FromWire deserializes a SignalWithStartWorkflowExecutionRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RespondDecisionTaskFailedRequest struct
+// An error is returned if we were unable to build a SignalWithStartWorkflowExecutionRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -5309,12 +7775,12 @@ func _RespondDecisionTaskFailedRequest_Read(w wire.Value) (*shared.RespondDecisi
 //     return nil, err
 //   }
 //
-//   var v RespondDecisionTaskFailedRequest
+//   var v SignalWithStartWorkflowExecutionRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RespondDecisionTaskFailedRequest) FromWire(w wire.Value) error {
+func (v *SignalWithStartWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -5331,7 +7797,7 @@ func (v *RespondDecisionTaskFailedRequest) FromWire(w wire.Value) error {
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.FailedRequest, err = _RespondDecisionTaskFailedRequest_Read(field.Value)
+				v.SignalWithStartRequest, err = _SignalWithStartWorkflowExecutionRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -5343,9 +7809,9 @@ func (v *RespondDecisionTaskFailedRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RespondDecisionTaskFailedRequest
+// String returns a readable string representation of a SignalWithStartWorkflowExecutionRequest
 // struct.
-func (v *RespondDecisionTaskFailedRequest) String() string {
+func (v *SignalWithStartWorkflowExecutionRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -5356,23 +7822,23 @@ func (v *RespondDecisionTaskFailedRequest) String() string {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.FailedRequest != nil {
-		fields[i] = fmt.Sprintf("FailedRequest: %v", v.FailedRequest)
+	if v.SignalWithStartRequest != nil {
+		fields[i] = fmt.Sprintf("SignalWithStartRequest: %v", v.SignalWithStartRequest)
 		i++
 	}
 
-	return fmt.Sprintf("RespondDecisionTaskFailedRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("SignalWithStartWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RespondDecisionTaskFailedRequest match the
-// provided RespondDecisionTaskFailedRequest.
+// Equals returns true if all the fields of this SignalWithStartWorkflowExecutionRequest match the
+// provided SignalWithStartWorkflowExecutionRequest.
 //
 // This function performs a deep comparison.
-func (v *RespondDecisionTaskFailedRequest) Equals(rhs *RespondDecisionTaskFailedRequest) bool {
+func (v *SignalWithStartWorkflowExecutionRequest) Equals(rhs *SignalWithStartWorkflowExecutionRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !((v.FailedRequest == nil && rhs.FailedRequest == nil) || (v.FailedRequest != nil && rhs.FailedRequest != nil && v.FailedRequest.Equals(rhs.FailedRequest))) {
+	if !((v.SignalWithStartRequest == nil && rhs.SignalWithStartRequest == nil) || (v.SignalWithStartRequest != nil && rhs.SignalWithStartRequest != nil && v.SignalWithStartRequest.Equals(rhs.SignalWithStartRequest))) {
 		return false
 	}
 
@@ -5381,7 +7847,7 @@ func (v *RespondDecisionTaskFailedRequest) Equals(rhs *RespondDecisionTaskFailed
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RespondDecisionTaskFailedRequest) GetDomainUUID() (o string) {
+func (v *SignalWithStartWorkflowExecutionRequest) GetDomainUUID() (o string) {
 	if v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -5389,22 +7855,24 @@ func (v *RespondDecisionTaskFailedRequest) GetDomainUUID() (o string) {
 	return
 }
 
-// GetFailedRequest returns the value of FailedRequest if it is set or its
+// GetSignalWithStartRequest returns the value of SignalWithStartRequest if it is set or its
 // zero value if it is unset.
-func (v *RespondDecisionTaskFailedRequest) GetFailedRequest() (o *shared.RespondDecisionTaskFailedRequest) {
-	if v.FailedRequest != nil {
-		return v.FailedRequest
+func (v *SignalWithStartWorkflowExecutionRequest) GetSignalWithStartRequest() (o *shared.SignalWithStartWorkflowExecutionRequest) {
+	if v.SignalWithStartRequest != nil {
+		return v.SignalWithStartRequest
 	}
 
 	return
 }
 
-type ScheduleDecisionTaskRequest struct {
-	DomainUUID        *string                   `json:"domainUUID,omitempty"`
-	WorkflowExecution *shared.WorkflowExecution `json:"workflowExecution,omitempty"`
+type SignalWorkflowExecutionRequest struct {
+	DomainUUID                *string                                `json:"domainUUID,omitempty"`
+	SignalRequest             *shared.SignalWorkflowExecutionRequest `json:"signalRequest,omitempty"`
+	ExternalWorkflowExecution *shared.WorkflowExecution              `json:"externalWorkflowExecution,omitempty"`
+	ChildWorkflowOnly         *bool                                  `json:"childWorkflowOnly,omitempty"`
 }
 
-// ToWire translates a ScheduleDecisionTaskRequest struct into a Thrift-level intermediate
+// ToWire translates a SignalWorkflowExecutionRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -5419,9 +7887,9 @@ type ScheduleDecisionTaskRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *ScheduleDecisionTaskRequest) ToWire() (wire.Value, error) {
+func (v *SignalWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -5435,23 +7903,45 @@ func (v *ScheduleDecisionTaskRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		w, err = v.WorkflowExecution.ToWire()
+	if v.SignalRequest != nil {
+		w, err = v.SignalRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
+	if v.ExternalWorkflowExecution != nil {
+		w, err = v.ExternalWorkflowExecution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.ChildWorkflowOnly != nil {
+		w, err = wire.NewValueBool(*(v.ChildWorkflowOnly)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a ScheduleDecisionTaskRequest struct from its Thrift-level
+func _SignalWorkflowExecutionRequest_Read(w wire.Value) (*shared.SignalWorkflowExecutionRequest, error) {
+	var v shared.SignalWorkflowExecutionRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a SignalWorkflowExecutionRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a ScheduleDecisionTaskRequest struct
+// An error is returned if we were unable to build a SignalWorkflowExecutionRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -5459,12 +7949,12 @@ func (v *ScheduleDecisionTaskRequest) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v ScheduleDecisionTaskRequest
+//   var v SignalWorkflowExecutionRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *ScheduleDecisionTaskRequest) FromWire(w wire.Value) error {
+func (v *SignalWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -5481,7 +7971,25 @@ func (v *ScheduleDecisionTaskRequest) FromWire(w wire.Value) error {
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+				v.SignalRequest, err = _SignalWorkflowExecutionRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.ExternalWorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.ChildWorkflowOnly = &x
 				if err != nil {
 					return err
 				}
@@ -5493,68 +8001,103 @@ func (v *ScheduleDecisionTaskRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a ScheduleDecisionTaskRequest
+// String returns a readable string representation of a SignalWorkflowExecutionRequest
 // struct.
-func (v *ScheduleDecisionTaskRequest) String() string {
+func (v *SignalWorkflowExecutionRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [2]string
+	var fields [4]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+	if v.SignalRequest != nil {
+		fields[i] = fmt.Sprintf("SignalRequest: %v", v.SignalRequest)
+		i++
+	}
+	if v.ExternalWorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("ExternalWorkflowExecution: %v", v.ExternalWorkflowExecution)
+		i++
+	}
+	if v.ChildWorkflowOnly != nil {
+		fields[i] = fmt.Sprintf("ChildWorkflowOnly: %v", *(v.ChildWorkflowOnly))
 		i++
 	}
 
-	return fmt.Sprintf("ScheduleDecisionTaskRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("SignalWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this ScheduleDecisionTaskRequest match the
-// provided ScheduleDecisionTaskRequest.
+// Equals returns true if all the fields of this SignalWorkflowExecutionRequest match the
+// provided SignalWorkflowExecutionRequest.
 //
 // This function performs a deep comparison.
-func (v *ScheduleDecisionTaskRequest) Equals(rhs *ScheduleDecisionTaskRequest) bool {
+func (v *SignalWorkflowExecutionRequest) Equals(rhs *SignalWorkflowExecutionRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+	if !((v.SignalRequest == nil && rhs.SignalRequest == nil) || (v.SignalRequest != nil && rhs.SignalRequest != nil && v.SignalRequest.Equals(rhs.SignalRequest))) {
+		return false
+	}
+	if !((v.ExternalWorkflowExecution == nil && rhs.ExternalWorkflowExecution == nil) || (v.ExternalWorkflowExecution != nil && rhs.ExternalWorkflowExecution != nil && v.ExternalWorkflowExecution.Equals(rhs.ExternalWorkflowExecution))) {
 		return false
 	}
+	if !_Bool_EqualsPtr(v.ChildWorkflowOnly, rhs.ChildWorkflowOnly) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *SignalWorkflowExecutionRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// GetSignalRequest returns the value of SignalRequest if it is set or its
+// zero value if it is unset.
+func (v *SignalWorkflowExecutionRequest) GetSignalRequest() (o *shared.SignalWorkflowExecutionRequest) {
+	if v.SignalRequest != nil {
+		return v.SignalRequest
+	}
 
-	return true
+	return
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
+// GetExternalWorkflowExecution returns the value of ExternalWorkflowExecution if it is set or its
 // zero value if it is unset.
-func (v *ScheduleDecisionTaskRequest) GetDomainUUID() (o string) {
-	if v.DomainUUID != nil {
-		return *v.DomainUUID
+func (v *SignalWorkflowExecutionRequest) GetExternalWorkflowExecution() (o *shared.WorkflowExecution) {
+	if v.ExternalWorkflowExecution != nil {
+		return v.ExternalWorkflowExecution
 	}
 
 	return
 }
 
-// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// GetChildWorkflowOnly returns the value of ChildWorkflowOnly if it is set or its
 // zero value if it is unset.
-func (v *ScheduleDecisionTaskRequest) GetWorkflowExecution() (o *shared.WorkflowExecution) {
-	if v.WorkflowExecution != nil {
-		return v.WorkflowExecution
+func (v *SignalWorkflowExecutionRequest) GetChildWorkflowOnly() (o bool) {
+	if v.ChildWorkflowOnly != nil {
+		return *v.ChildWorkflowOnly
 	}
 
 	return
 }
 
-type ShardOwnershipLostError struct {
-	Message *string `json:"message,omitempty"`
-	Owner   *string `json:"owner,omitempty"`
+type StartWorkflowExecutionRequest struct {
+	DomainUUID          *string                               `json:"domainUUID,omitempty"`
+	StartRequest        *shared.StartWorkflowExecutionRequest `json:"startRequest,omitempty"`
+	ParentExecutionInfo *ParentExecutionInfo                  `json:"parentExecutionInfo,omitempty"`
 }
 
-// ToWire translates a ShardOwnershipLostError struct into a Thrift-level intermediate
+// ToWire translates a StartWorkflowExecutionRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -5569,39 +8112,59 @@ type ShardOwnershipLostError struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *ShardOwnershipLostError) ToWire() (wire.Value, error) {
+func (v *StartWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.Message != nil {
-		w, err = wire.NewValueString(*(v.Message)), error(nil)
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.Owner != nil {
-		w, err = wire.NewValueString(*(v.Owner)), error(nil)
+	if v.StartRequest != nil {
+		w, err = v.StartRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
+	if v.ParentExecutionInfo != nil {
+		w, err = v.ParentExecutionInfo.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a ShardOwnershipLostError struct from its Thrift-level
+func _StartWorkflowExecutionRequest_Read(w wire.Value) (*shared.StartWorkflowExecutionRequest, error) {
+	var v shared.StartWorkflowExecutionRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _ParentExecutionInfo_Read(w wire.Value) (*ParentExecutionInfo, error) {
+	var v ParentExecutionInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a StartWorkflowExecutionRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a ShardOwnershipLostError struct
+// An error is returned if we were unable to build a StartWorkflowExecutionRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -5609,12 +8172,12 @@ func (v *ShardOwnershipLostError) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v ShardOwnershipLostError
+//   var v StartWorkflowExecutionRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *ShardOwnershipLostError) FromWire(w wire.Value) error {
+func (v *StartWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -5623,17 +8186,23 @@ func (v *ShardOwnershipLostError) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
-				v.Message = &x
+				v.DomainUUID = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.Owner = &x
+			if field.Value.Type() == wire.TStruct {
+				v.StartRequest, err = _StartWorkflowExecutionRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.ParentExecutionInfo, err = _ParentExecutionInfo_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -5645,72 +8214,92 @@ func (v *ShardOwnershipLostError) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a ShardOwnershipLostError
+// String returns a readable string representation of a StartWorkflowExecutionRequest
 // struct.
-func (v *ShardOwnershipLostError) String() string {
+func (v *StartWorkflowExecutionRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [2]string
+	var fields [3]string
 	i := 0
-	if v.Message != nil {
-		fields[i] = fmt.Sprintf("Message: %v", *(v.Message))
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.Owner != nil {
-		fields[i] = fmt.Sprintf("Owner: %v", *(v.Owner))
+	if v.StartRequest != nil {
+		fields[i] = fmt.Sprintf("StartRequest: %v", v.StartRequest)
+		i++
+	}
+	if v.ParentExecutionInfo != nil {
+		fields[i] = fmt.Sprintf("ParentExecutionInfo: %v", v.ParentExecutionInfo)
 		i++
 	}
 
-	return fmt.Sprintf("ShardOwnershipLostError{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("StartWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this ShardOwnershipLostError match the
-// provided ShardOwnershipLostError.
+// Equals returns true if all the fields of this StartWorkflowExecutionRequest match the
+// provided StartWorkflowExecutionRequest.
 //
 // This function performs a deep comparison.
-func (v *ShardOwnershipLostError) Equals(rhs *ShardOwnershipLostError) bool {
-	if !_String_EqualsPtr(v.Message, rhs.Message) {
+func (v *StartWorkflowExecutionRequest) Equals(rhs *StartWorkflowExecutionRequest) bool {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !_String_EqualsPtr(v.Owner, rhs.Owner) {
+	if !((v.StartRequest == nil && rhs.StartRequest == nil) || (v.StartRequest != nil && rhs.StartRequest != nil && v.StartRequest.Equals(rhs.StartRequest))) {
+		return false
+	}
+	if !((v.ParentExecutionInfo == nil && rhs.ParentExecutionInfo == nil) || (v.ParentExecutionInfo != nil && rhs.ParentExecutionInfo != nil && v.ParentExecutionInfo.Equals(rhs.ParentExecutionInfo))) {
 		return false
 	}
 
 	return true
 }
 
-// GetMessage returns the value of Message if it is set or its
+// GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *ShardOwnershipLostError) GetMessage() (o string) {
-	if v.Message != nil {
-		return *v.Message
+func (v *StartWorkflowExecutionRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
 	}
 
 	return
 }
 
-// GetOwner returns the value of Owner if it is set or its
+// GetStartRequest returns the value of StartRequest if it is set or its
 // zero value if it is unset.
-func (v *ShardOwnershipLostError) GetOwner() (o string) {
-	if v.Owner != nil {
-		return *v.Owner
+func (v *StartWorkflowExecutionRequest) GetStartRequest() (o *shared.StartWorkflowExecutionRequest) {
+	if v.StartRequest != nil {
+		return v.StartRequest
 	}
 
 	return
 }
 
-func (v *ShardOwnershipLostError) Error() string {
-	return v.String()
+// GetParentExecutionInfo returns the value of ParentExecutionInfo if it is set or its
+// zero value if it is unset.
+func (v *StartWorkflowExecutionRequest) GetParentExecutionInfo() (o *ParentExecutionInfo) {
+	if v.ParentExecutionInfo != nil {
+		return v.ParentExecutionInfo
+	}
+
+	return
 }
 
-type SignalWithStartWorkflowExecutionRequest struct {
-	DomainUUID             *string                                         `json:"domainUUID,omitempty"`
-	SignalWithStartRequest *shared.SignalWithStartWorkflowExecutionRequest `json:"signalWithStartRequest,omitempty"`
+type SyncActivityRequest struct {
+	DomainId      *string `json:"domainId,omitempty"`
+	WorkflowId    *string `json:"workflowId,omitempty"`
+	RunId         *string `json:"runId,omitempty"`
+	Version       *int64  `json:"version,omitempty"`
+	ScheduledId   *int64  `json:"scheduledId,omitempty"`
+	ScheduledTime *int64  `json:"scheduledTime,omitempty"`
+	StartedId     *int64  `json:"startedId,omitempty"`
+	StartedTime   *int64  `json:"startedTime,omitempty"`
+	Attempt       *int32  `json:"attempt,omitempty"`
 }
 
-// ToWire translates a SignalWithStartWorkflowExecutionRequest struct into a Thrift-level intermediate
+// ToWire translates a SyncActivityRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -5725,45 +8314,95 @@ type SignalWithStartWorkflowExecutionRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *SignalWithStartWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+func (v *SyncActivityRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [9]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.DomainUUID != nil {
-		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+	if v.DomainId != nil {
+		w, err = wire.NewValueString(*(v.DomainId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.SignalWithStartRequest != nil {
-		w, err = v.SignalWithStartRequest.ToWire()
+	if v.WorkflowId != nil {
+		w, err = wire.NewValueString(*(v.WorkflowId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
+	if v.RunId != nil {
+		w, err = wire.NewValueString(*(v.RunId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Version != nil {
+		w, err = wire.NewValueI64(*(v.Version)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.ScheduledId != nil {
+		w, err = wire.NewValueI64(*(v.ScheduledId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.ScheduledTime != nil {
+		w, err = wire.NewValueI64(*(v.ScheduledTime)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.StartedId != nil {
+		w, err = wire.NewValueI64(*(v.StartedId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+	if v.StartedTime != nil {
+		w, err = wire.NewValueI64(*(v.StartedTime)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
+	if v.Attempt != nil {
+		w, err = wire.NewValueI32(*(v.Attempt)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 90, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _SignalWithStartWorkflowExecutionRequest_Read(w wire.Value) (*shared.SignalWithStartWorkflowExecutionRequest, error) {
-	var v shared.SignalWithStartWorkflowExecutionRequest
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a SignalWithStartWorkflowExecutionRequest struct from its Thrift-level
+// FromWire deserializes a SyncActivityRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a SignalWithStartWorkflowExecutionRequest struct
+// An error is returned if we were unable to build a SyncActivityRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -5771,29 +8410,101 @@ func _SignalWithStartWorkflowExecutionRequest_Read(w wire.Value) (*shared.Signal
 //     return nil, err
 //   }
 //
-//   var v SignalWithStartWorkflowExecutionRequest
+//   var v SyncActivityRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *SignalWithStartWorkflowExecutionRequest) FromWire(w wire.Value) error {
+func (v *SyncActivityRequest) FromWire(w wire.Value) error {
 	var err error
 
-	for _, field := range w.GetStruct().Fields {
-		switch field.ID {
-		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.DomainUUID = &x
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkflowId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RunId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Version = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ScheduledId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ScheduledTime = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.SignalWithStartRequest, err = _SignalWithStartWorkflowExecutionRequest_Read(field.Value)
+		case 70:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartedId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 80:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartedTime = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 90:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Attempt = &x
 				if err != nil {
 					return err
 				}
@@ -5805,70 +8516,189 @@ func (v *SignalWithStartWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a SignalWithStartWorkflowExecutionRequest
+// String returns a readable string representation of a SyncActivityRequest
 // struct.
-func (v *SignalWithStartWorkflowExecutionRequest) String() string {
+func (v *SyncActivityRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [2]string
+	var fields [9]string
 	i := 0
-	if v.DomainUUID != nil {
-		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+	if v.DomainId != nil {
+		fields[i] = fmt.Sprintf("DomainId: %v", *(v.DomainId))
 		i++
 	}
-	if v.SignalWithStartRequest != nil {
-		fields[i] = fmt.Sprintf("SignalWithStartRequest: %v", v.SignalWithStartRequest)
+	if v.WorkflowId != nil {
+		fields[i] = fmt.Sprintf("WorkflowId: %v", *(v.WorkflowId))
+		i++
+	}
+	if v.RunId != nil {
+		fields[i] = fmt.Sprintf("RunId: %v", *(v.RunId))
+		i++
+	}
+	if v.Version != nil {
+		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
+		i++
+	}
+	if v.ScheduledId != nil {
+		fields[i] = fmt.Sprintf("ScheduledId: %v", *(v.ScheduledId))
+		i++
+	}
+	if v.ScheduledTime != nil {
+		fields[i] = fmt.Sprintf("ScheduledTime: %v", *(v.ScheduledTime))
+		i++
+	}
+	if v.StartedId != nil {
+		fields[i] = fmt.Sprintf("StartedId: %v", *(v.StartedId))
+		i++
+	}
+	if v.StartedTime != nil {
+		fields[i] = fmt.Sprintf("StartedTime: %v", *(v.StartedTime))
+		i++
+	}
+	if v.Attempt != nil {
+		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
 		i++
 	}
 
-	return fmt.Sprintf("SignalWithStartWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("SyncActivityRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this SignalWithStartWorkflowExecutionRequest match the
-// provided SignalWithStartWorkflowExecutionRequest.
+// Equals returns true if all the fields of this SyncActivityRequest match the
+// provided SyncActivityRequest.
 //
 // This function performs a deep comparison.
-func (v *SignalWithStartWorkflowExecutionRequest) Equals(rhs *SignalWithStartWorkflowExecutionRequest) bool {
-	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+func (v *SyncActivityRequest) Equals(rhs *SyncActivityRequest) bool {
+	if !_String_EqualsPtr(v.DomainId, rhs.DomainId) {
 		return false
 	}
-	if !((v.SignalWithStartRequest == nil && rhs.SignalWithStartRequest == nil) || (v.SignalWithStartRequest != nil && rhs.SignalWithStartRequest != nil && v.SignalWithStartRequest.Equals(rhs.SignalWithStartRequest))) {
+	if !_String_EqualsPtr(v.WorkflowId, rhs.WorkflowId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.RunId, rhs.RunId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Version, rhs.Version) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ScheduledId, rhs.ScheduledId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ScheduledTime, rhs.ScheduledTime) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.StartedId, rhs.StartedId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.StartedTime, rhs.StartedTime) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.Attempt, rhs.Attempt) {
 		return false
 	}
 
 	return true
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
+// GetDomainId returns the value of DomainId if it is set or its
 // zero value if it is unset.
-func (v *SignalWithStartWorkflowExecutionRequest) GetDomainUUID() (o string) {
-	if v.DomainUUID != nil {
-		return *v.DomainUUID
+func (v *SyncActivityRequest) GetDomainId() (o string) {
+	if v.DomainId != nil {
+		return *v.DomainId
 	}
 
 	return
 }
 
-// GetSignalWithStartRequest returns the value of SignalWithStartRequest if it is set or its
+// GetWorkflowId returns the value of WorkflowId if it is set or its
 // zero value if it is unset.
-func (v *SignalWithStartWorkflowExecutionRequest) GetSignalWithStartRequest() (o *shared.SignalWithStartWorkflowExecutionRequest) {
-	if v.SignalWithStartRequest != nil {
-		return v.SignalWithStartRequest
+func (v *SyncActivityRequest) GetWorkflowId() (o string) {
+	if v.WorkflowId != nil {
+		return *v.WorkflowId
 	}
 
 	return
 }
 
-type SignalWorkflowExecutionRequest struct {
-	DomainUUID                *string                                `json:"domainUUID,omitempty"`
-	SignalRequest             *shared.SignalWorkflowExecutionRequest `json:"signalRequest,omitempty"`
-	ExternalWorkflowExecution *shared.WorkflowExecution              `json:"externalWorkflowExecution,omitempty"`
-	ChildWorkflowOnly         *bool                                  `json:"childWorkflowOnly,omitempty"`
+// GetRunId returns the value of RunId if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityRequest) GetRunId() (o string) {
+	if v.RunId != nil {
+		return *v.RunId
+	}
+
+	return
 }
 
-// ToWire translates a SignalWorkflowExecutionRequest struct into a Thrift-level intermediate
+// GetVersion returns the value of Version if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityRequest) GetVersion() (o int64) {
+	if v.Version != nil {
+		return *v.Version
+	}
+
+	return
+}
+
+// GetScheduledId returns the value of ScheduledId if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityRequest) GetScheduledId() (o int64) {
+	if v.ScheduledId != nil {
+		return *v.ScheduledId
+	}
+
+	return
+}
+
+// GetScheduledTime returns the value of ScheduledTime if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityRequest) GetScheduledTime() (o int64) {
+	if v.ScheduledTime != nil {
+		return *v.ScheduledTime
+	}
+
+	return
+}
+
+// GetStartedId returns the value of StartedId if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityRequest) GetStartedId() (o int64) {
+	if v.StartedId != nil {
+		return *v.StartedId
+	}
+
+	return
+}
+
+// GetStartedTime returns the value of StartedTime if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityRequest) GetStartedTime() (o int64) {
+	if v.StartedTime != nil {
+		return *v.StartedTime
+	}
+
+	return
+}
+
+// GetAttempt returns the value of Attempt if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityRequest) GetAttempt() (o int32) {
+	if v.Attempt != nil {
+		return *v.Attempt
+	}
+
+	return
+}
+
+
+type SyncShardStatusRequest struct {
+	SourceCluster *string `json:"sourceCluster,omitempty"`
+	ShardId       *int64  `json:"shardId,omitempty"`
+	Timestamp     *int64  `json:"timestamp,omitempty"`
+}
+
+// ToWire translates a SyncShardStatusRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -5883,61 +8713,47 @@ type SignalWorkflowExecutionRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *SignalWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+func (v *SyncShardStatusRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.DomainUUID != nil {
-		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+	if v.SourceCluster != nil {
+		w, err = wire.NewValueString(*(v.SourceCluster)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.SignalRequest != nil {
-		w, err = v.SignalRequest.ToWire()
+	if v.ShardId != nil {
+		w, err = wire.NewValueI64(*(v.ShardId)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.ExternalWorkflowExecution != nil {
-		w, err = v.ExternalWorkflowExecution.ToWire()
+	if v.Timestamp != nil {
+		w, err = wire.NewValueI64(*(v.Timestamp)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.ChildWorkflowOnly != nil {
-		w, err = wire.NewValueBool(*(v.ChildWorkflowOnly)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _SignalWorkflowExecutionRequest_Read(w wire.Value) (*shared.SignalWorkflowExecutionRequest, error) {
-	var v shared.SignalWorkflowExecutionRequest
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a SignalWorkflowExecutionRequest struct from its Thrift-level
+// FromWire deserializes a SyncShardStatusRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a SignalWorkflowExecutionRequest struct
+// An error is returned if we were unable to build a SyncShardStatusRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -5945,12 +8761,12 @@ func _SignalWorkflowExecutionRequest_Read(w wire.Value) (*shared.SignalWorkflowE
 //     return nil, err
 //   }
 //
-//   var v SignalWorkflowExecutionRequest
+//   var v SyncShardStatusRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *SignalWorkflowExecutionRequest) FromWire(w wire.Value) error {
+func (v *SyncShardStatusRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -5959,33 +8775,27 @@ func (v *SignalWorkflowExecutionRequest) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
-				v.DomainUUID = &x
+				v.SourceCluster = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.SignalRequest, err = _SignalWorkflowExecutionRequest_Read(field.Value)
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ShardId = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TStruct {
-				v.ExternalWorkflowExecution, err = _WorkflowExecution_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TBool {
-				var x bool
-				x, err = field.Value.GetBool(), error(nil)
-				v.ChildWorkflowOnly = &x
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Timestamp = &x
 				if err != nil {
 					return err
 				}
@@ -5997,103 +8807,85 @@ func (v *SignalWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a SignalWorkflowExecutionRequest
+// String returns a readable string representation of a SyncShardStatusRequest
 // struct.
-func (v *SignalWorkflowExecutionRequest) String() string {
+func (v *SyncShardStatusRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [3]string
 	i := 0
-	if v.DomainUUID != nil {
-		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
-		i++
-	}
-	if v.SignalRequest != nil {
-		fields[i] = fmt.Sprintf("SignalRequest: %v", v.SignalRequest)
+	if v.SourceCluster != nil {
+		fields[i] = fmt.Sprintf("SourceCluster: %v", *(v.SourceCluster))
 		i++
 	}
-	if v.ExternalWorkflowExecution != nil {
-		fields[i] = fmt.Sprintf("ExternalWorkflowExecution: %v", v.ExternalWorkflowExecution)
+	if v.ShardId != nil {
+		fields[i] = fmt.Sprintf("ShardId: %v", *(v.ShardId))
 		i++
 	}
-	if v.ChildWorkflowOnly != nil {
-		fields[i] = fmt.Sprintf("ChildWorkflowOnly: %v", *(v.ChildWorkflowOnly))
+	if v.Timestamp != nil {
+		fields[i] = fmt.Sprintf("Timestamp: %v", *(v.Timestamp))
 		i++
 	}
 
-	return fmt.Sprintf("SignalWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("SyncShardStatusRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this SignalWorkflowExecutionRequest match the
-// provided SignalWorkflowExecutionRequest.
+// Equals returns true if all the fields of this SyncShardStatusRequest match the
+// provided SyncShardStatusRequest.
 //
 // This function performs a deep comparison.
-func (v *SignalWorkflowExecutionRequest) Equals(rhs *SignalWorkflowExecutionRequest) bool {
-	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
-		return false
-	}
-	if !((v.SignalRequest == nil && rhs.SignalRequest == nil) || (v.SignalRequest != nil && rhs.SignalRequest != nil && v.SignalRequest.Equals(rhs.SignalRequest))) {
+func (v *SyncShardStatusRequest) Equals(rhs *SyncShardStatusRequest) bool {
+	if !_String_EqualsPtr(v.SourceCluster, rhs.SourceCluster) {
 		return false
 	}
-	if !((v.ExternalWorkflowExecution == nil && rhs.ExternalWorkflowExecution == nil) || (v.ExternalWorkflowExecution != nil && rhs.ExternalWorkflowExecution != nil && v.ExternalWorkflowExecution.Equals(rhs.ExternalWorkflowExecution))) {
+	if !_I64_EqualsPtr(v.ShardId, rhs.ShardId) {
 		return false
 	}
-	if !_Bool_EqualsPtr(v.ChildWorkflowOnly, rhs.ChildWorkflowOnly) {
+	if !_I64_EqualsPtr(v.Timestamp, rhs.Timestamp) {
 		return false
 	}
 
 	return true
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
-// zero value if it is unset.
-func (v *SignalWorkflowExecutionRequest) GetDomainUUID() (o string) {
-	if v.DomainUUID != nil {
-		return *v.DomainUUID
-	}
-
-	return
-}
-
-// GetSignalRequest returns the value of SignalRequest if it is set or its
+// GetSourceCluster returns the value of SourceCluster if it is set or its
 // zero value if it is unset.
-func (v *SignalWorkflowExecutionRequest) GetSignalRequest() (o *shared.SignalWorkflowExecutionRequest) {
-	if v.SignalRequest != nil {
-		return v.SignalRequest
+func (v *SyncShardStatusRequest) GetSourceCluster() (o string) {
+	if v.SourceCluster != nil {
+		return *v.SourceCluster
 	}
 
 	return
 }
 
-// GetExternalWorkflowExecution returns the value of ExternalWorkflowExecution if it is set or its
+// GetShardId returns the value of ShardId if it is set or its
 // zero value if it is unset.
-func (v *SignalWorkflowExecutionRequest) GetExternalWorkflowExecution() (o *shared.WorkflowExecution) {
-	if v.ExternalWorkflowExecution != nil {
-		return v.ExternalWorkflowExecution
+func (v *SyncShardStatusRequest) GetShardId() (o int64) {
+	if v.ShardId != nil {
+		return *v.ShardId
 	}
 
 	return
 }
 
-// GetChildWorkflowOnly returns the value of ChildWorkflowOnly if it is set or its
+// GetTimestamp returns the value of Timestamp if it is set or its
 // zero value if it is unset.
-func (v *SignalWorkflowExecutionRequest) GetChildWorkflowOnly() (o bool) {
-	if v.ChildWorkflowOnly != nil {
-		return *v.ChildWorkflowOnly
+func (v *SyncShardStatusRequest) GetTimestamp() (o int64) {
+	if v.Timestamp != nil {
+		return *v.Timestamp
 	}
 
 	return
 }
 
-type StartWorkflowExecutionRequest struct {
-	DomainUUID          *string                               `json:"domainUUID,omitempty"`
-	StartRequest        *shared.StartWorkflowExecutionRequest `json:"startRequest,omitempty"`
-	ParentExecutionInfo *ParentExecutionInfo                  `json:"parentExecutionInfo,omitempty"`
+type TerminateWorkflowExecutionRequest struct {
+	DomainUUID       *string                                   `json:"domainUUID,omitempty"`
+	TerminateRequest *shared.TerminateWorkflowExecutionRequest `json:"terminateRequest,omitempty"`
 }
 
-// ToWire translates a StartWorkflowExecutionRequest struct into a Thrift-level intermediate
+// ToWire translates a TerminateWorkflowExecutionRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -6108,9 +8900,9 @@ type StartWorkflowExecutionRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *StartWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+func (v *TerminateWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -6124,43 +8916,29 @@ func (v *StartWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.StartRequest != nil {
-		w, err = v.StartRequest.ToWire()
+	if v.TerminateRequest != nil {
+		w, err = v.TerminateRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.ParentExecutionInfo != nil {
-		w, err = v.ParentExecutionInfo.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _StartWorkflowExecutionRequest_Read(w wire.Value) (*shared.StartWorkflowExecutionRequest, error) {
-	var v shared.StartWorkflowExecutionRequest
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _ParentExecutionInfo_Read(w wire.Value) (*ParentExecutionInfo, error) {
-	var v ParentExecutionInfo
+func _TerminateWorkflowExecutionRequest_Read(w wire.Value) (*shared.TerminateWorkflowExecutionRequest, error) {
+	var v shared.TerminateWorkflowExecutionRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a StartWorkflowExecutionRequest struct from its Thrift-level
+// FromWire deserializes a TerminateWorkflowExecutionRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a StartWorkflowExecutionRequest struct
+// An error is returned if we were unable to build a TerminateWorkflowExecutionRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -6168,12 +8946,12 @@ func _ParentExecutionInfo_Read(w wire.Value) (*ParentExecutionInfo, error) {
 //     return nil, err
 //   }
 //
-//   var v StartWorkflowExecutionRequest
+//   var v TerminateWorkflowExecutionRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *StartWorkflowExecutionRequest) FromWire(w wire.Value) error {
+func (v *TerminateWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -6190,15 +8968,7 @@ func (v *StartWorkflowExecutionRequest) FromWire(w wire.Value) error {
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.StartRequest, err = _StartWorkflowExecutionRequest_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 30:
-			if field.Value.Type() == wire.TStruct {
-				v.ParentExecutionInfo, err = _ParentExecutionInfo_Read(field.Value)
+				v.TerminateRequest, err = _TerminateWorkflowExecutionRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -6210,43 +8980,36 @@ func (v *StartWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a StartWorkflowExecutionRequest
+// String returns a readable string representation of a TerminateWorkflowExecutionRequest
 // struct.
-func (v *StartWorkflowExecutionRequest) String() string {
+func (v *TerminateWorkflowExecutionRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [2]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.StartRequest != nil {
-		fields[i] = fmt.Sprintf("StartRequest: %v", v.StartRequest)
-		i++
-	}
-	if v.ParentExecutionInfo != nil {
-		fields[i] = fmt.Sprintf("ParentExecutionInfo: %v", v.ParentExecutionInfo)
+	if v.TerminateRequest != nil {
+		fields[i] = fmt.Sprintf("TerminateRequest: %v", v.TerminateRequest)
 		i++
 	}
 
-	return fmt.Sprintf("StartWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("TerminateWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this StartWorkflowExecutionRequest match the
-// provided StartWorkflowExecutionRequest.
+// Equals returns true if all the fields of this TerminateWorkflowExecutionRequest match the
+// provided TerminateWorkflowExecutionRequest.
 //
 // This function performs a deep comparison.
-func (v *StartWorkflowExecutionRequest) Equals(rhs *StartWorkflowExecutionRequest) bool {
+func (v *TerminateWorkflowExecutionRequest) Equals(rhs *TerminateWorkflowExecutionRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !((v.StartRequest == nil && rhs.StartRequest == nil) || (v.StartRequest != nil && rhs.StartRequest != nil && v.StartRequest.Equals(rhs.StartRequest))) {
-		return false
-	}
-	if !((v.ParentExecutionInfo == nil && rhs.ParentExecutionInfo == nil) || (v.ParentExecutionInfo != nil && rhs.ParentExecutionInfo != nil && v.ParentExecutionInfo.Equals(rhs.ParentExecutionInfo))) {
+	if !((v.TerminateRequest == nil && rhs.TerminateRequest == nil) || (v.TerminateRequest != nil && rhs.TerminateRequest != nil && v.TerminateRequest.Equals(rhs.TerminateRequest))) {
 		return false
 	}
 
@@ -6255,7 +9018,7 @@ func (v *StartWorkflowExecutionRequest) Equals(rhs *StartWorkflowExecutionReques
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *StartWorkflowExecutionRequest) GetDomainUUID() (o string) {
+func (v *TerminateWorkflowExecutionRequest) GetDomainUUID() (o string) {
 	if v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -6263,33 +9026,22 @@ func (v *StartWorkflowExecutionRequest) GetDomainUUID() (o string) {
 	return
 }
 
-// GetStartRequest returns the value of StartRequest if it is set or its
-// zero value if it is unset.
-func (v *StartWorkflowExecutionRequest) GetStartRequest() (o *shared.StartWorkflowExecutionRequest) {
-	if v.StartRequest != nil {
-		return v.StartRequest
-	}
-
-	return
-}
-
-// GetParentExecutionInfo returns the value of ParentExecutionInfo if it is set or its
+// GetTerminateRequest returns the value of TerminateRequest if it is set or its
 // zero value if it is unset.
-func (v *StartWorkflowExecutionRequest) GetParentExecutionInfo() (o *ParentExecutionInfo) {
-	if v.ParentExecutionInfo != nil {
-		return v.ParentExecutionInfo
+func (v *TerminateWorkflowExecutionRequest) GetTerminateRequest() (o *shared.TerminateWorkflowExecutionRequest) {
+	if v.TerminateRequest != nil {
+		return v.TerminateRequest
 	}
 
 	return
 }
 
-type SyncShardStatusRequest struct {
-	SourceCluster *string `json:"sourceCluster,omitempty"`
-	ShardId       *int64  `json:"shardId,omitempty"`
-	Timestamp     *int64  `json:"timestamp,omitempty"`
+type UpdateWorkflowExecutionOptionsRequest struct {
+	DomainUUID    *string                                       `json:"domainUUID,omitempty"`
+	UpdateRequest *shared.UpdateWorkflowExecutionOptionsRequest `json:"updateRequest,omitempty"`
 }
 
-// ToWire translates a SyncShardStatusRequest struct into a Thrift-level intermediate
+// ToWire translates a UpdateWorkflowExecutionOptionsRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -6304,47 +9056,45 @@ type SyncShardStatusRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *SyncShardStatusRequest) ToWire() (wire.Value, error) {
+func (v *UpdateWorkflowExecutionOptionsRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.SourceCluster != nil {
-		w, err = wire.NewValueString(*(v.SourceCluster)), error(nil)
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.ShardId != nil {
-		w, err = wire.NewValueI64(*(v.ShardId)), error(nil)
+	if v.UpdateRequest != nil {
+		w, err = v.UpdateRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.Timestamp != nil {
-		w, err = wire.NewValueI64(*(v.Timestamp)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a SyncShardStatusRequest struct from its Thrift-level
+func _UpdateWorkflowExecutionOptionsRequest_Read(w wire.Value) (*shared.UpdateWorkflowExecutionOptionsRequest, error) {
+	var v shared.UpdateWorkflowExecutionOptionsRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a UpdateWorkflowExecutionOptionsRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a SyncShardStatusRequest struct
+// An error is returned if we were unable to build a UpdateWorkflowExecutionOptionsRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -6352,12 +9102,12 @@ func (v *SyncShardStatusRequest) ToWire() (wire.Value, error) {
 //     return nil, err
 //   }
 //
-//   var v SyncShardStatusRequest
+//   var v UpdateWorkflowExecutionOptionsRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *SyncShardStatusRequest) FromWire(w wire.Value) error {
+func (v *UpdateWorkflowExecutionOptionsRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -6366,27 +9116,15 @@ func (v *SyncShardStatusRequest) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				var x string
 				x, err = field.Value.GetString(), error(nil)
-				v.SourceCluster = &x
+				v.DomainUUID = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.ShardId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 30:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.Timestamp = &x
+			if field.Value.Type() == wire.TStruct {
+				v.UpdateRequest, err = _UpdateWorkflowExecutionOptionsRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -6398,85 +9136,68 @@ func (v *SyncShardStatusRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a SyncShardStatusRequest
+// String returns a readable string representation of a UpdateWorkflowExecutionOptionsRequest
 // struct.
-func (v *SyncShardStatusRequest) String() string {
+func (v *UpdateWorkflowExecutionOptionsRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [2]string
 	i := 0
-	if v.SourceCluster != nil {
-		fields[i] = fmt.Sprintf("SourceCluster: %v", *(v.SourceCluster))
-		i++
-	}
-	if v.ShardId != nil {
-		fields[i] = fmt.Sprintf("ShardId: %v", *(v.ShardId))
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.Timestamp != nil {
-		fields[i] = fmt.Sprintf("Timestamp: %v", *(v.Timestamp))
+	if v.UpdateRequest != nil {
+		fields[i] = fmt.Sprintf("UpdateRequest: %v", v.UpdateRequest)
 		i++
 	}
 
-	return fmt.Sprintf("SyncShardStatusRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("UpdateWorkflowExecutionOptionsRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this SyncShardStatusRequest match the
-// provided SyncShardStatusRequest.
+// Equals returns true if all the fields of this UpdateWorkflowExecutionOptionsRequest match the
+// provided UpdateWorkflowExecutionOptionsRequest.
 //
 // This function performs a deep comparison.
-func (v *SyncShardStatusRequest) Equals(rhs *SyncShardStatusRequest) bool {
-	if !_String_EqualsPtr(v.SourceCluster, rhs.SourceCluster) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.ShardId, rhs.ShardId) {
+func (v *UpdateWorkflowExecutionOptionsRequest) Equals(rhs *UpdateWorkflowExecutionOptionsRequest) bool {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.Timestamp, rhs.Timestamp) {
+	if !((v.UpdateRequest == nil && rhs.UpdateRequest == nil) || (v.UpdateRequest != nil && rhs.UpdateRequest != nil && v.UpdateRequest.Equals(rhs.UpdateRequest))) {
 		return false
 	}
 
 	return true
 }
 
-// GetSourceCluster returns the value of SourceCluster if it is set or its
-// zero value if it is unset.
-func (v *SyncShardStatusRequest) GetSourceCluster() (o string) {
-	if v.SourceCluster != nil {
-		return *v.SourceCluster
-	}
-
-	return
-}
-
-// GetShardId returns the value of ShardId if it is set or its
+// GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *SyncShardStatusRequest) GetShardId() (o int64) {
-	if v.ShardId != nil {
-		return *v.ShardId
+func (v *UpdateWorkflowExecutionOptionsRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
 	}
 
 	return
 }
 
-// GetTimestamp returns the value of Timestamp if it is set or its
+// GetUpdateRequest returns the value of UpdateRequest if it is set or its
 // zero value if it is unset.
-func (v *SyncShardStatusRequest) GetTimestamp() (o int64) {
-	if v.Timestamp != nil {
-		return *v.Timestamp
+func (v *UpdateWorkflowExecutionOptionsRequest) GetUpdateRequest() (o *shared.UpdateWorkflowExecutionOptionsRequest) {
+	if v.UpdateRequest != nil {
+		return v.UpdateRequest
 	}
 
 	return
 }
 
-type TerminateWorkflowExecutionRequest struct {
-	DomainUUID       *string                                   `json:"domainUUID,omitempty"`
-	TerminateRequest *shared.TerminateWorkflowExecutionRequest `json:"terminateRequest,omitempty"`
+type UpdateWorkflowExecutionRequest struct {
+	DomainUUID    *string                                `json:"domainUUID,omitempty"`
+	UpdateRequest *shared.UpdateWorkflowExecutionRequest `json:"updateRequest,omitempty"`
 }
 
-// ToWire translates a TerminateWorkflowExecutionRequest struct into a Thrift-level intermediate
+// ToWire translates a UpdateWorkflowExecutionRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -6491,7 +9212,7 @@ type TerminateWorkflowExecutionRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *TerminateWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+func (v *UpdateWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	var (
 		fields [2]wire.Field
 		i      int = 0
@@ -6507,8 +9228,8 @@ func (v *TerminateWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.TerminateRequest != nil {
-		w, err = v.TerminateRequest.ToWire()
+	if v.UpdateRequest != nil {
+		w, err = v.UpdateRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -6519,17 +9240,17 @@ func (v *TerminateWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _TerminateWorkflowExecutionRequest_Read(w wire.Value) (*shared.TerminateWorkflowExecutionRequest, error) {
-	var v shared.TerminateWorkflowExecutionRequest
+func _UpdateWorkflowExecutionRequest_Read(w wire.Value) (*shared.UpdateWorkflowExecutionRequest, error) {
+	var v shared.UpdateWorkflowExecutionRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a TerminateWorkflowExecutionRequest struct from its Thrift-level
+// FromWire deserializes a UpdateWorkflowExecutionRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a TerminateWorkflowExecutionRequest struct
+// An error is returned if we were unable to build a UpdateWorkflowExecutionRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -6537,12 +9258,12 @@ func _TerminateWorkflowExecutionRequest_Read(w wire.Value) (*shared.TerminateWor
 //     return nil, err
 //   }
 //
-//   var v TerminateWorkflowExecutionRequest
+//   var v UpdateWorkflowExecutionRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *TerminateWorkflowExecutionRequest) FromWire(w wire.Value) error {
+func (v *UpdateWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -6559,7 +9280,7 @@ func (v *TerminateWorkflowExecutionRequest) FromWire(w wire.Value) error {
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.TerminateRequest, err = _TerminateWorkflowExecutionRequest_Read(field.Value)
+				v.UpdateRequest, err = _UpdateWorkflowExecutionRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -6571,9 +9292,9 @@ func (v *TerminateWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a TerminateWorkflowExecutionRequest
+// String returns a readable string representation of a UpdateWorkflowExecutionRequest
 // struct.
-func (v *TerminateWorkflowExecutionRequest) String() string {
+func (v *UpdateWorkflowExecutionRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -6584,23 +9305,23 @@ func (v *TerminateWorkflowExecutionRequest) String() string {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.TerminateRequest != nil {
-		fields[i] = fmt.Sprintf("TerminateRequest: %v", v.TerminateRequest)
+	if v.UpdateRequest != nil {
+		fields[i] = fmt.Sprintf("UpdateRequest: %v", v.UpdateRequest)
 		i++
 	}
 
-	return fmt.Sprintf("TerminateWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("UpdateWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this TerminateWorkflowExecutionRequest match the
-// provided TerminateWorkflowExecutionRequest.
+// Equals returns true if all the fields of this UpdateWorkflowExecutionRequest match the
+// provided UpdateWorkflowExecutionRequest.
 //
 // This function performs a deep comparison.
-func (v *TerminateWorkflowExecutionRequest) Equals(rhs *TerminateWorkflowExecutionRequest) bool {
+func (v *UpdateWorkflowExecutionRequest) Equals(rhs *UpdateWorkflowExecutionRequest) bool {
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !((v.TerminateRequest == nil && rhs.TerminateRequest == nil) || (v.TerminateRequest != nil && rhs.TerminateRequest != nil && v.TerminateRequest.Equals(rhs.TerminateRequest))) {
+	if !((v.UpdateRequest == nil && rhs.UpdateRequest == nil) || (v.UpdateRequest != nil && rhs.UpdateRequest != nil && v.UpdateRequest.Equals(rhs.UpdateRequest))) {
 		return false
 	}
 
@@ -6609,7 +9330,7 @@ func (v *TerminateWorkflowExecutionRequest) Equals(rhs *TerminateWorkflowExecuti
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *TerminateWorkflowExecutionRequest) GetDomainUUID() (o string) {
+func (v *UpdateWorkflowExecutionRequest) GetDomainUUID() (o string) {
 	if v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -6617,11 +9338,11 @@ func (v *TerminateWorkflowExecutionRequest) GetDomainUUID() (o string) {
 	return
 }
 
-// GetTerminateRequest returns the value of TerminateRequest if it is set or its
+// GetUpdateRequest returns the value of UpdateRequest if it is set or its
 // zero value if it is unset.
-func (v *TerminateWorkflowExecutionRequest) GetTerminateRequest() (o *shared.TerminateWorkflowExecutionRequest) {
-	if v.TerminateRequest != nil {
-		return v.TerminateRequest
+func (v *UpdateWorkflowExecutionRequest) GetUpdateRequest() (o *shared.UpdateWorkflowExecutionRequest) {
+	if v.UpdateRequest != nil {
+		return v.UpdateRequest
 	}
 
 	return
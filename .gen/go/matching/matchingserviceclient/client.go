@@ -60,6 +60,30 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*shared.DescribeTaskListResponse, error)
 
+	DescribeTaskListLatency(
+		ctx context.Context,
+		Request *matching.DescribeTaskListLatencyRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeTaskListLatencyResponse, error)
+
+	ForceUnloadTaskList(
+		ctx context.Context,
+		Request *matching.ForceUnloadTaskListRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.ForceUnloadTaskListResponse, error)
+
+	GetTaskListsByDomain(
+		ctx context.Context,
+		Request *matching.GetTaskListsByDomainRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.GetTaskListsByDomainResponse, error)
+
+	ListTaskListDLQTasks(
+		ctx context.Context,
+		Request *matching.ListTaskListDLQTasksRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.ListTaskListDLQTasksResponse, error)
+
 	PollForActivityTask(
 		ctx context.Context,
 		PollRequest *matching.PollForActivityTaskRequest,
@@ -78,6 +102,24 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*shared.QueryWorkflowResponse, error)
 
+	RecordWorkerHeartbeat(
+		ctx context.Context,
+		Request *matching.RecordWorkerHeartbeatRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	RefreshDomainCache(
+		ctx context.Context,
+		Request *shared.RefreshDomainCacheRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	RequeueTaskListDLQTasks(
+		ctx context.Context,
+		Request *matching.RequeueTaskListDLQTasksRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.RequeueTaskListDLQTasksResponse, error)
+
 	RespondQueryTaskCompleted(
 		ctx context.Context,
 		Request *matching.RespondQueryTaskCompletedRequest,
@@ -201,6 +243,98 @@ func (c client) DescribeTaskList(
 	return
 }
 
+func (c client) DescribeTaskListLatency(
+	ctx context.Context,
+	_Request *matching.DescribeTaskListLatencyRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeTaskListLatencyResponse, err error) {
+
+	args := matching.MatchingService_DescribeTaskListLatency_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result matching.MatchingService_DescribeTaskListLatency_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = matching.MatchingService_DescribeTaskListLatency_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) ForceUnloadTaskList(
+	ctx context.Context,
+	_Request *matching.ForceUnloadTaskListRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.ForceUnloadTaskListResponse, err error) {
+
+	args := matching.MatchingService_ForceUnloadTaskList_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result matching.MatchingService_ForceUnloadTaskList_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = matching.MatchingService_ForceUnloadTaskList_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) GetTaskListsByDomain(
+	ctx context.Context,
+	_Request *matching.GetTaskListsByDomainRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.GetTaskListsByDomainResponse, err error) {
+
+	args := matching.MatchingService_GetTaskListsByDomain_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result matching.MatchingService_GetTaskListsByDomain_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = matching.MatchingService_GetTaskListsByDomain_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) ListTaskListDLQTasks(
+	ctx context.Context,
+	_Request *matching.ListTaskListDLQTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.ListTaskListDLQTasksResponse, err error) {
+
+	args := matching.MatchingService_ListTaskListDLQTasks_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result matching.MatchingService_ListTaskListDLQTasks_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = matching.MatchingService_ListTaskListDLQTasks_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) PollForActivityTask(
 	ctx context.Context,
 	_PollRequest *matching.PollForActivityTaskRequest,
@@ -270,6 +404,75 @@ func (c client) QueryWorkflow(
 	return
 }
 
+func (c client) RecordWorkerHeartbeat(
+	ctx context.Context,
+	_Request *matching.RecordWorkerHeartbeatRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := matching.MatchingService_RecordWorkerHeartbeat_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result matching.MatchingService_RecordWorkerHeartbeat_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = matching.MatchingService_RecordWorkerHeartbeat_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) RefreshDomainCache(
+	ctx context.Context,
+	_Request *shared.RefreshDomainCacheRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := matching.MatchingService_RefreshDomainCache_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result matching.MatchingService_RefreshDomainCache_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = matching.MatchingService_RefreshDomainCache_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) RequeueTaskListDLQTasks(
+	ctx context.Context,
+	_Request *matching.RequeueTaskListDLQTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.RequeueTaskListDLQTasksResponse, err error) {
+
+	args := matching.MatchingService_RequeueTaskListDLQTasks_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result matching.MatchingService_RequeueTaskListDLQTasks_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = matching.MatchingService_RequeueTaskListDLQTasks_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) RespondQueryTaskCompleted(
 	ctx context.Context,
 	_Request *matching.RespondQueryTaskCompletedRequest,
@@ -54,6 +54,26 @@ type Interface interface {
 		Request *matching.DescribeTaskListRequest,
 	) (*shared.DescribeTaskListResponse, error)
 
+	DescribeTaskListLatency(
+		ctx context.Context,
+		Request *matching.DescribeTaskListLatencyRequest,
+	) (*shared.DescribeTaskListLatencyResponse, error)
+
+	ForceUnloadTaskList(
+		ctx context.Context,
+		Request *matching.ForceUnloadTaskListRequest,
+	) (*shared.ForceUnloadTaskListResponse, error)
+
+	GetTaskListsByDomain(
+		ctx context.Context,
+		Request *matching.GetTaskListsByDomainRequest,
+	) (*shared.GetTaskListsByDomainResponse, error)
+
+	ListTaskListDLQTasks(
+		ctx context.Context,
+		Request *matching.ListTaskListDLQTasksRequest,
+	) (*shared.ListTaskListDLQTasksResponse, error)
+
 	PollForActivityTask(
 		ctx context.Context,
 		PollRequest *matching.PollForActivityTaskRequest,
@@ -69,6 +89,21 @@ type Interface interface {
 		QueryRequest *matching.QueryWorkflowRequest,
 	) (*shared.QueryWorkflowResponse, error)
 
+	RecordWorkerHeartbeat(
+		ctx context.Context,
+		Request *matching.RecordWorkerHeartbeatRequest,
+	) error
+
+	RefreshDomainCache(
+		ctx context.Context,
+		Request *shared.RefreshDomainCacheRequest,
+	) error
+
+	RequeueTaskListDLQTasks(
+		ctx context.Context,
+		Request *matching.RequeueTaskListDLQTasksRequest,
+	) (*shared.RequeueTaskListDLQTasksResponse, error)
+
 	RespondQueryTaskCompleted(
 		ctx context.Context,
 		Request *matching.RespondQueryTaskCompletedRequest,
@@ -130,6 +165,50 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: matching.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "DescribeTaskListLatency",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DescribeTaskListLatency),
+				},
+				Signature:    "DescribeTaskListLatency(Request *matching.DescribeTaskListLatencyRequest) (*shared.DescribeTaskListLatencyResponse)",
+				ThriftModule: matching.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "ForceUnloadTaskList",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ForceUnloadTaskList),
+				},
+				Signature:    "ForceUnloadTaskList(Request *matching.ForceUnloadTaskListRequest) (*shared.ForceUnloadTaskListResponse)",
+				ThriftModule: matching.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "GetTaskListsByDomain",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.GetTaskListsByDomain),
+				},
+				Signature:    "GetTaskListsByDomain(Request *matching.GetTaskListsByDomainRequest) (*shared.GetTaskListsByDomainResponse)",
+				ThriftModule: matching.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "ListTaskListDLQTasks",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ListTaskListDLQTasks),
+				},
+				Signature:    "ListTaskListDLQTasks(Request *matching.ListTaskListDLQTasksRequest) (*shared.ListTaskListDLQTasksResponse)",
+				ThriftModule: matching.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "PollForActivityTask",
 				HandlerSpec: thrift.HandlerSpec{
@@ -163,6 +242,39 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: matching.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "RecordWorkerHeartbeat",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.RecordWorkerHeartbeat),
+				},
+				Signature:    "RecordWorkerHeartbeat(Request *matching.RecordWorkerHeartbeatRequest)",
+				ThriftModule: matching.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "RefreshDomainCache",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.RefreshDomainCache),
+				},
+				Signature:    "RefreshDomainCache(Request *shared.RefreshDomainCacheRequest)",
+				ThriftModule: matching.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "RequeueTaskListDLQTasks",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.RequeueTaskListDLQTasks),
+				},
+				Signature:    "RequeueTaskListDLQTasks(Request *matching.RequeueTaskListDLQTasksRequest) (*shared.RequeueTaskListDLQTasksResponse)",
+				ThriftModule: matching.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "RespondQueryTaskCompleted",
 				HandlerSpec: thrift.HandlerSpec{
@@ -176,7 +288,7 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 		},
 	}
 
-	procedures := make([]transport.Procedure, 0, 8)
+	procedures := make([]transport.Procedure, 0, 15)
 	procedures = append(procedures, thrift.BuildProcedures(service, opts...)...)
 	return procedures
 }
@@ -259,6 +371,82 @@ func (h handler) DescribeTaskList(ctx context.Context, body wire.Value) (thrift.
 	return response, err
 }
 
+func (h handler) DescribeTaskListLatency(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args matching.MatchingService_DescribeTaskListLatency_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeTaskListLatency(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := matching.MatchingService_DescribeTaskListLatency_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) ForceUnloadTaskList(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args matching.MatchingService_ForceUnloadTaskList_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.ForceUnloadTaskList(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := matching.MatchingService_ForceUnloadTaskList_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) GetTaskListsByDomain(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args matching.MatchingService_GetTaskListsByDomain_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.GetTaskListsByDomain(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := matching.MatchingService_GetTaskListsByDomain_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) ListTaskListDLQTasks(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args matching.MatchingService_ListTaskListDLQTasks_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.ListTaskListDLQTasks(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := matching.MatchingService_ListTaskListDLQTasks_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) PollForActivityTask(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args matching.MatchingService_PollForActivityTask_Args
 	if err := args.FromWire(body); err != nil {
@@ -316,6 +504,63 @@ func (h handler) QueryWorkflow(ctx context.Context, body wire.Value) (thrift.Res
 	return response, err
 }
 
+func (h handler) RecordWorkerHeartbeat(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args matching.MatchingService_RecordWorkerHeartbeat_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.RecordWorkerHeartbeat(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := matching.MatchingService_RecordWorkerHeartbeat_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) RefreshDomainCache(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args matching.MatchingService_RefreshDomainCache_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.RefreshDomainCache(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := matching.MatchingService_RefreshDomainCache_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) RequeueTaskListDLQTasks(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args matching.MatchingService_RequeueTaskListDLQTasks_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.RequeueTaskListDLQTasks(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := matching.MatchingService_RequeueTaskListDLQTasks_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) RespondQueryTaskCompleted(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args matching.MatchingService_RespondQueryTaskCompleted_Args
 	if err := args.FromWire(body); err != nil {
@@ -577,6 +577,7 @@ type HistoryTaskAttributes struct {
 	ReplicationInfo map[string]*history.ReplicationInfo `json:"replicationInfo,omitempty"`
 	History         *shared.History                     `json:"history,omitempty"`
 	NewRunHistory   *shared.History                     `json:"newRunHistory,omitempty"`
+	Timestamp       *int64                              `json:"timestamp,omitempty"`
 }
 
 type _List_String_ValueList []string
@@ -660,7 +661,7 @@ func (_Map_String_ReplicationInfo_MapItemList) Close() {}
 //   }
 func (v *HistoryTaskAttributes) ToWire() (wire.Value, error) {
 	var (
-		fields [10]wire.Field
+		fields [11]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -746,6 +747,14 @@ func (v *HistoryTaskAttributes) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 90, Value: w}
 		i++
 	}
+	if v.Timestamp != nil {
+		w, err = wire.NewValueI64(*(v.Timestamp)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 100, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -921,6 +930,16 @@ func (v *HistoryTaskAttributes) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 100:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Timestamp = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -935,7 +954,7 @@ func (v *HistoryTaskAttributes) String() string {
 		return "<nil>"
 	}
 
-	var fields [10]string
+	var fields [11]string
 	i := 0
 	if v.TargetClusters != nil {
 		fields[i] = fmt.Sprintf("TargetClusters: %v", v.TargetClusters)
@@ -977,6 +996,10 @@ func (v *HistoryTaskAttributes) String() string {
 		fields[i] = fmt.Sprintf("NewRunHistory: %v", v.NewRunHistory)
 		i++
 	}
+	if v.Timestamp != nil {
+		fields[i] = fmt.Sprintf("Timestamp: %v", *(v.Timestamp))
+		i++
+	}
 
 	return fmt.Sprintf("HistoryTaskAttributes{%v}", strings.Join(fields[:i], ", "))
 }
@@ -1048,6 +1071,9 @@ func (v *HistoryTaskAttributes) Equals(rhs *HistoryTaskAttributes) bool {
 	if !((v.NewRunHistory == nil && rhs.NewRunHistory == nil) || (v.NewRunHistory != nil && rhs.NewRunHistory != nil && v.NewRunHistory.Equals(rhs.NewRunHistory))) {
 		return false
 	}
+	if !_I64_EqualsPtr(v.Timestamp, rhs.Timestamp) {
+		return false
+	}
 
 	return true
 }
@@ -1152,11 +1178,22 @@ func (v *HistoryTaskAttributes) GetNewRunHistory() (o *shared.History) {
 	return
 }
 
+// GetTimestamp returns the value of Timestamp if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskAttributes) GetTimestamp() (o int64) {
+	if v.Timestamp != nil {
+		return *v.Timestamp
+	}
+
+	return
+}
+
 type ReplicationTask struct {
 	TaskType                      *ReplicationTaskType           `json:"taskType,omitempty"`
 	DomainTaskAttributes          *DomainTaskAttributes          `json:"domainTaskAttributes,omitempty"`
 	HistoryTaskAttributes         *HistoryTaskAttributes         `json:"historyTaskAttributes,omitempty"`
 	SyncShardStatusTaskAttributes *SyncShardStatusTaskAttributes `json:"syncShardStatusTaskAttributes,omitempty"`
+	SyncActivityTaskAttributes    *SyncActivityTaskAttributes    `json:"syncActivityTaskAttributes,omitempty"`
 }
 
 // ToWire translates a ReplicationTask struct into a Thrift-level intermediate
@@ -1176,7 +1213,7 @@ type ReplicationTask struct {
 //   }
 func (v *ReplicationTask) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -1214,6 +1251,14 @@ func (v *ReplicationTask) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
+	if v.SyncActivityTaskAttributes != nil {
+		w, err = v.SyncActivityTaskAttributes.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -1242,6 +1287,12 @@ func _SyncShardStatusTaskAttributes_Read(w wire.Value) (*SyncShardStatusTaskAttr
 	return &v, err
 }
 
+func _SyncActivityTaskAttributes_Read(w wire.Value) (*SyncActivityTaskAttributes, error) {
+	var v SyncActivityTaskAttributes
+	err := v.FromWire(w)
+	return &v, err
+}
+
 // FromWire deserializes a ReplicationTask struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -1297,6 +1348,14 @@ func (v *ReplicationTask) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 50:
+			if field.Value.Type() == wire.TStruct {
+				v.SyncActivityTaskAttributes, err = _SyncActivityTaskAttributes_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -1311,7 +1370,7 @@ func (v *ReplicationTask) String() string {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [5]string
 	i := 0
 	if v.TaskType != nil {
 		fields[i] = fmt.Sprintf("TaskType: %v", *(v.TaskType))
@@ -1329,6 +1388,10 @@ func (v *ReplicationTask) String() string {
 		fields[i] = fmt.Sprintf("SyncShardStatusTaskAttributes: %v", v.SyncShardStatusTaskAttributes)
 		i++
 	}
+	if v.SyncActivityTaskAttributes != nil {
+		fields[i] = fmt.Sprintf("SyncActivityTaskAttributes: %v", v.SyncActivityTaskAttributes)
+		i++
+	}
 
 	return fmt.Sprintf("ReplicationTask{%v}", strings.Join(fields[:i], ", "))
 }
@@ -1360,6 +1423,9 @@ func (v *ReplicationTask) Equals(rhs *ReplicationTask) bool {
 	if !((v.SyncShardStatusTaskAttributes == nil && rhs.SyncShardStatusTaskAttributes == nil) || (v.SyncShardStatusTaskAttributes != nil && rhs.SyncShardStatusTaskAttributes != nil && v.SyncShardStatusTaskAttributes.Equals(rhs.SyncShardStatusTaskAttributes))) {
 		return false
 	}
+	if !((v.SyncActivityTaskAttributes == nil && rhs.SyncActivityTaskAttributes == nil) || (v.SyncActivityTaskAttributes != nil && rhs.SyncActivityTaskAttributes != nil && v.SyncActivityTaskAttributes.Equals(rhs.SyncActivityTaskAttributes))) {
+		return false
+	}
 
 	return true
 }
@@ -1404,12 +1470,23 @@ func (v *ReplicationTask) GetSyncShardStatusTaskAttributes() (o *SyncShardStatus
 	return
 }
 
+// GetSyncActivityTaskAttributes returns the value of SyncActivityTaskAttributes if it is set or its
+// zero value if it is unset.
+func (v *ReplicationTask) GetSyncActivityTaskAttributes() (o *SyncActivityTaskAttributes) {
+	if v.SyncActivityTaskAttributes != nil {
+		return v.SyncActivityTaskAttributes
+	}
+
+	return
+}
+
 type ReplicationTaskType int32
 
 const (
 	ReplicationTaskTypeDomain          ReplicationTaskType = 0
 	ReplicationTaskTypeHistory         ReplicationTaskType = 1
 	ReplicationTaskTypeSyncShardStatus ReplicationTaskType = 2
+	ReplicationTaskTypeSyncActivity    ReplicationTaskType = 3
 )
 
 // ReplicationTaskType_Values returns all recognized values of ReplicationTaskType.
@@ -1418,6 +1495,7 @@ func ReplicationTaskType_Values() []ReplicationTaskType {
 		ReplicationTaskTypeDomain,
 		ReplicationTaskTypeHistory,
 		ReplicationTaskTypeSyncShardStatus,
+		ReplicationTaskTypeSyncActivity,
 	}
 }
 
@@ -1437,6 +1515,9 @@ func (v *ReplicationTaskType) UnmarshalText(value []byte) error {
 	case "SyncShardStatus":
 		*v = ReplicationTaskTypeSyncShardStatus
 		return nil
+	case "SyncActivity":
+		*v = ReplicationTaskTypeSyncActivity
+		return nil
 	default:
 		return fmt.Errorf("unknown enum value %q for %q", value, "ReplicationTaskType")
 	}
@@ -1456,6 +1537,8 @@ func (v ReplicationTaskType) MarshalText() ([]byte, error) {
 		return []byte("History"), nil
 	case 2:
 		return []byte("SyncShardStatus"), nil
+	case 3:
+		return []byte("SyncActivity"), nil
 	}
 	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -1502,6 +1585,8 @@ func (v ReplicationTaskType) String() string {
 		return "History"
 	case 2:
 		return "SyncShardStatus"
+	case 3:
+		return "SyncActivity"
 	}
 	return fmt.Sprintf("ReplicationTaskType(%d)", w)
 }
@@ -1526,6 +1611,8 @@ func (v ReplicationTaskType) MarshalJSON() ([]byte, error) {
 		return ([]byte)("\"History\""), nil
 	case 2:
 		return ([]byte)("\"SyncShardStatus\""), nil
+	case 3:
+		return ([]byte)("\"SyncActivity\""), nil
 	}
 	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -1566,6 +1653,421 @@ func (v *ReplicationTaskType) UnmarshalJSON(text []byte) error {
 	}
 }
 
+type SyncActivityTaskAttributes struct {
+	DomainId      *string `json:"domainId,omitempty"`
+	WorkflowId    *string `json:"workflowId,omitempty"`
+	RunId         *string `json:"runId,omitempty"`
+	Version       *int64  `json:"version,omitempty"`
+	ScheduledId   *int64  `json:"scheduledId,omitempty"`
+	ScheduledTime *int64  `json:"scheduledTime,omitempty"`
+	StartedId     *int64  `json:"startedId,omitempty"`
+	StartedTime   *int64  `json:"startedTime,omitempty"`
+	Attempt       *int32  `json:"attempt,omitempty"`
+}
+
+// ToWire translates a SyncActivityTaskAttributes struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *SyncActivityTaskAttributes) ToWire() (wire.Value, error) {
+	var (
+		fields [9]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainId != nil {
+		w, err = wire.NewValueString(*(v.DomainId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowId != nil {
+		w, err = wire.NewValueString(*(v.WorkflowId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.RunId != nil {
+		w, err = wire.NewValueString(*(v.RunId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Version != nil {
+		w, err = wire.NewValueI64(*(v.Version)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.ScheduledId != nil {
+		w, err = wire.NewValueI64(*(v.ScheduledId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.ScheduledTime != nil {
+		w, err = wire.NewValueI64(*(v.ScheduledTime)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.StartedId != nil {
+		w, err = wire.NewValueI64(*(v.StartedId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+	if v.StartedTime != nil {
+		w, err = wire.NewValueI64(*(v.StartedTime)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
+	if v.Attempt != nil {
+		w, err = wire.NewValueI32(*(v.Attempt)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 90, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a SyncActivityTaskAttributes struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a SyncActivityTaskAttributes struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v SyncActivityTaskAttributes
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *SyncActivityTaskAttributes) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkflowId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RunId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Version = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ScheduledId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ScheduledTime = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartedId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 80:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.StartedTime = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 90:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Attempt = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a SyncActivityTaskAttributes
+// struct.
+func (v *SyncActivityTaskAttributes) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [9]string
+	i := 0
+	if v.DomainId != nil {
+		fields[i] = fmt.Sprintf("DomainId: %v", *(v.DomainId))
+		i++
+	}
+	if v.WorkflowId != nil {
+		fields[i] = fmt.Sprintf("WorkflowId: %v", *(v.WorkflowId))
+		i++
+	}
+	if v.RunId != nil {
+		fields[i] = fmt.Sprintf("RunId: %v", *(v.RunId))
+		i++
+	}
+	if v.Version != nil {
+		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
+		i++
+	}
+	if v.ScheduledId != nil {
+		fields[i] = fmt.Sprintf("ScheduledId: %v", *(v.ScheduledId))
+		i++
+	}
+	if v.ScheduledTime != nil {
+		fields[i] = fmt.Sprintf("ScheduledTime: %v", *(v.ScheduledTime))
+		i++
+	}
+	if v.StartedId != nil {
+		fields[i] = fmt.Sprintf("StartedId: %v", *(v.StartedId))
+		i++
+	}
+	if v.StartedTime != nil {
+		fields[i] = fmt.Sprintf("StartedTime: %v", *(v.StartedTime))
+		i++
+	}
+	if v.Attempt != nil {
+		fields[i] = fmt.Sprintf("Attempt: %v", *(v.Attempt))
+		i++
+	}
+
+	return fmt.Sprintf("SyncActivityTaskAttributes{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _I32_EqualsPtr(lhs, rhs *int32) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this SyncActivityTaskAttributes match the
+// provided SyncActivityTaskAttributes.
+//
+// This function performs a deep comparison.
+func (v *SyncActivityTaskAttributes) Equals(rhs *SyncActivityTaskAttributes) bool {
+	if !_String_EqualsPtr(v.DomainId, rhs.DomainId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.WorkflowId, rhs.WorkflowId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.RunId, rhs.RunId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Version, rhs.Version) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ScheduledId, rhs.ScheduledId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ScheduledTime, rhs.ScheduledTime) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.StartedId, rhs.StartedId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.StartedTime, rhs.StartedTime) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.Attempt, rhs.Attempt) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomainId returns the value of DomainId if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityTaskAttributes) GetDomainId() (o string) {
+	if v.DomainId != nil {
+		return *v.DomainId
+	}
+
+	return
+}
+
+// GetWorkflowId returns the value of WorkflowId if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityTaskAttributes) GetWorkflowId() (o string) {
+	if v.WorkflowId != nil {
+		return *v.WorkflowId
+	}
+
+	return
+}
+
+// GetRunId returns the value of RunId if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityTaskAttributes) GetRunId() (o string) {
+	if v.RunId != nil {
+		return *v.RunId
+	}
+
+	return
+}
+
+// GetVersion returns the value of Version if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityTaskAttributes) GetVersion() (o int64) {
+	if v.Version != nil {
+		return *v.Version
+	}
+
+	return
+}
+
+// GetScheduledId returns the value of ScheduledId if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityTaskAttributes) GetScheduledId() (o int64) {
+	if v.ScheduledId != nil {
+		return *v.ScheduledId
+	}
+
+	return
+}
+
+// GetScheduledTime returns the value of ScheduledTime if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityTaskAttributes) GetScheduledTime() (o int64) {
+	if v.ScheduledTime != nil {
+		return *v.ScheduledTime
+	}
+
+	return
+}
+
+// GetStartedId returns the value of StartedId if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityTaskAttributes) GetStartedId() (o int64) {
+	if v.StartedId != nil {
+		return *v.StartedId
+	}
+
+	return
+}
+
+// GetStartedTime returns the value of StartedTime if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityTaskAttributes) GetStartedTime() (o int64) {
+	if v.StartedTime != nil {
+		return *v.StartedTime
+	}
+
+	return
+}
+
+// GetAttempt returns the value of Attempt if it is set or its
+// zero value if it is unset.
+func (v *SyncActivityTaskAttributes) GetAttempt() (o int32) {
+	if v.Attempt != nil {
+		return *v.Attempt
+	}
+
+	return
+}
+
+
 type SyncShardStatusTaskAttributes struct {
 	SourceCluster *string `json:"sourceCluster,omitempty"`
 	ShardId       *int64  `json:"shardId,omitempty"`
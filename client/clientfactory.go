@@ -21,6 +21,8 @@
 package client
 
 import (
+	"github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
@@ -32,6 +34,8 @@ import (
 type Factory interface {
 	NewHistoryClient() (history.Client, error)
 	NewMatchingClient() (matching.Client, error)
+	NewRemoteAdminClient(rpcName, rpcAddress string) (admin.Client, error)
+	NewFrontendClientForHost(rpcAddress string) (frontend.Client, error)
 }
 
 type rpcClientFactory struct {
@@ -73,3 +77,13 @@ func (cf *rpcClientFactory) NewMatchingClient() (matching.Client, error) {
 	}
 	return client, nil
 }
+
+func (cf *rpcClientFactory) NewRemoteAdminClient(rpcName, rpcAddress string) (admin.Client, error) {
+	d := cf.df.CreateDispatcherForOutbound("admin-service-client", rpcName, rpcAddress)
+	return admin.New(d, rpcName), nil
+}
+
+func (cf *rpcClientFactory) NewFrontendClientForHost(rpcAddress string) (frontend.Client, error) {
+	d := cf.df.CreateDispatcherForOutbound("frontend-service-client", common.FrontendServiceName, rpcAddress)
+	return frontend.New(d), nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/client/matching"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/membership"
+)
+
+type (
+	// DomainCacheRefresher asks the frontend, history and matching hosts to refresh their domain cache right away,
+	// it is used after a domain got updated so that the change is not picked up only by the periodic refresh
+	DomainCacheRefresher interface {
+		RefreshDomainCache(ctx context.Context, domainID string) error
+	}
+
+	domainCacheRefresherImpl struct {
+		clientFactory    Factory
+		frontendResolver membership.ServiceResolver
+		historyClient    history.Client
+		matchingClient   matching.Client
+
+		sync.Mutex
+		adminClients map[string]admin.Client
+	}
+)
+
+var _ DomainCacheRefresher = (*domainCacheRefresherImpl)(nil)
+
+// NewDomainCacheRefresher creates a DomainCacheRefresher, the frontend hosts are reached through their admin service
+func NewDomainCacheRefresher(clientFactory Factory, frontendResolver membership.ServiceResolver,
+	historyClient history.Client, matchingClient matching.Client) DomainCacheRefresher {
+	return &domainCacheRefresherImpl{
+		clientFactory:    clientFactory,
+		frontendResolver: frontendResolver,
+		historyClient:    historyClient,
+		matchingClient:   matchingClient,
+		adminClients:     make(map[string]admin.Client),
+	}
+}
+
+// RefreshDomainCache asks the hosts of every service concurrently, it returns the first error once all of them
+// answered
+func (r *domainCacheRefresherImpl) RefreshDomainCache(ctx context.Context, domainID string) error {
+	request := &shared.RefreshDomainCacheRequest{DomainId: common.StringPtr(domainID)}
+	refreshes := []func() error{
+		func() error { return r.refreshFrontendHosts(ctx, request) },
+		func() error { return r.historyClient.RefreshDomainCache(ctx, request) },
+		func() error { return r.matchingClient.RefreshDomainCache(ctx, request) },
+	}
+	return runConcurrently(refreshes)
+}
+
+func (r *domainCacheRefresherImpl) refreshFrontendHosts(ctx context.Context,
+	request *shared.RefreshDomainCacheRequest) error {
+	hosts, err := r.frontendResolver.Members()
+	if err != nil {
+		return err
+	}
+
+	var refreshes []func() error
+	for _, host := range hosts {
+		address := host.GetAddress()
+		client, err := r.getAdminClient(address)
+		if err != nil {
+			return err
+		}
+		hostRequest := *request
+		hostRequest.HostAddress = common.StringPtr(address)
+		refreshes = append(refreshes, func() error {
+			return client.RefreshDomainCache(ctx, &hostRequest)
+		})
+	}
+	return runConcurrently(refreshes)
+}
+
+func (r *domainCacheRefresherImpl) getAdminClient(address string) (admin.Client, error) {
+	r.Lock()
+	defer r.Unlock()
+	if client, ok := r.adminClients[address]; ok {
+		return client, nil
+	}
+	client, err := r.clientFactory.NewRemoteAdminClient(common.FrontendServiceName, address)
+	if err != nil {
+		return nil, err
+	}
+	r.adminClients[address] = client
+	return client, nil
+}
+
+func runConcurrently(ops []func() error) error {
+	errs := make([]error, len(ops))
+	var wg sync.WaitGroup
+	for i, op := range ops {
+		wg.Add(1)
+		go func(i int, op func() error) {
+			defer wg.Done()
+			errs[i] = op()
+		}(i, op)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
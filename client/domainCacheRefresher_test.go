@@ -0,0 +1,129 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/.gen/go/admin/adminservicetest"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/mocks"
+)
+
+type (
+	domainCacheRefresherSuite struct {
+		suite.Suite
+		mockCtrl       *gomock.Controller
+		mockResolver   *mocks.ServiceResolver
+		mockHistory    *mocks.HistoryClient
+		mockMatching   *mocks.MatchingClient
+		mockFrontends  []*adminservicetest.MockClient
+		refresher      *domainCacheRefresherImpl
+		frontendHosts  []*membership.HostInfo
+		refreshRequest *shared.RefreshDomainCacheRequest
+	}
+)
+
+const testRefreshDomainID = "deadbeef-0123-4567-890a-bcdef0123456"
+
+func TestDomainCacheRefresherSuite(t *testing.T) {
+	s := new(domainCacheRefresherSuite)
+	suite.Run(t, s)
+}
+
+func (s *domainCacheRefresherSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockResolver = &mocks.ServiceResolver{}
+	s.mockHistory = &mocks.HistoryClient{}
+	s.mockMatching = &mocks.MatchingClient{}
+	s.refresher = NewDomainCacheRefresher(nil, s.mockResolver, s.mockHistory,
+		s.mockMatching).(*domainCacheRefresherImpl)
+
+	// the admin clients of the frontend hosts are cached up front, the factory is not used
+	s.mockFrontends = nil
+	s.frontendHosts = nil
+	for _, address := range []string{"frontend-host-0", "frontend-host-1"} {
+		client := adminservicetest.NewMockClient(s.mockCtrl)
+		s.mockFrontends = append(s.mockFrontends, client)
+		s.frontendHosts = append(s.frontendHosts, membership.NewHostInfo(address, nil))
+		s.refresher.adminClients[address] = admin.Client(client)
+	}
+	s.mockResolver.On("Members").Return(s.frontendHosts, nil)
+	s.refreshRequest = &shared.RefreshDomainCacheRequest{DomainId: common.StringPtr(testRefreshDomainID)}
+}
+
+func (s *domainCacheRefresherSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+	s.mockResolver.AssertExpectations(s.T())
+	s.mockHistory.AssertExpectations(s.T())
+	s.mockMatching.AssertExpectations(s.T())
+}
+
+func (s *domainCacheRefresherSuite) hostRequest(host *membership.HostInfo) *shared.RefreshDomainCacheRequest {
+	return &shared.RefreshDomainCacheRequest{
+		DomainId:    common.StringPtr(testRefreshDomainID),
+		HostAddress: common.StringPtr(host.GetAddress()),
+	}
+}
+
+func (s *domainCacheRefresherSuite) TestRefreshDomainCache_AllServices() {
+	for i, frontend := range s.mockFrontends {
+		frontend.EXPECT().RefreshDomainCache(gomock.Any(), s.hostRequest(s.frontendHosts[i])).Return(nil)
+	}
+	s.mockHistory.On("RefreshDomainCache", mock.Anything, s.refreshRequest).Return(nil).Once()
+	s.mockMatching.On("RefreshDomainCache", mock.Anything, s.refreshRequest).Return(nil).Once()
+
+	err := s.refresher.RefreshDomainCache(context.Background(), testRefreshDomainID)
+	s.NoError(err)
+}
+
+func (s *domainCacheRefresherSuite) TestRefreshDomainCache_FrontendHostFailure() {
+	refreshErr := errors.New("frontend host unavailable")
+	s.mockFrontends[0].EXPECT().RefreshDomainCache(gomock.Any(), s.hostRequest(s.frontendHosts[0])).
+		Return(refreshErr)
+	s.mockFrontends[1].EXPECT().RefreshDomainCache(gomock.Any(), s.hostRequest(s.frontendHosts[1])).Return(nil)
+	// a failing frontend host does not keep the other services from being refreshed
+	s.mockHistory.On("RefreshDomainCache", mock.Anything, s.refreshRequest).Return(nil).Once()
+	s.mockMatching.On("RefreshDomainCache", mock.Anything, s.refreshRequest).Return(nil).Once()
+
+	err := s.refresher.RefreshDomainCache(context.Background(), testRefreshDomainID)
+	s.Equal(refreshErr, err)
+}
+
+func (s *domainCacheRefresherSuite) TestRefreshDomainCache_MatchingFailure() {
+	refreshErr := &shared.InternalServiceError{Message: "matching host unavailable"}
+	for i, frontend := range s.mockFrontends {
+		frontend.EXPECT().RefreshDomainCache(gomock.Any(), s.hostRequest(s.frontendHosts[i])).Return(nil)
+	}
+	s.mockHistory.On("RefreshDomainCache", mock.Anything, s.refreshRequest).Return(nil).Once()
+	s.mockMatching.On("RefreshDomainCache", mock.Anything, s.refreshRequest).Return(refreshErr).Once()
+
+	err := s.refresher.RefreshDomainCache(context.Background(), testRefreshDomainID)
+	s.Equal(refreshErr, err)
+}
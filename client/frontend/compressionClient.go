@@ -0,0 +1,106 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/compression"
+	"go.uber.org/yarpc"
+)
+
+var _ Client = (*compressionClient)(nil)
+
+// compressionClient accepts gzip compressed payloads from the frontend and decompresses them, the calls not
+// returning history pages or activity inputs are passed through to the wrapped client
+type compressionClient struct {
+	Client
+}
+
+// NewCompressionClient creates a new instance of Client accepting compressed payloads
+func NewCompressionClient(client Client) Client {
+	return &compressionClient{
+		Client: client,
+	}
+}
+
+func (c *compressionClient) GetWorkflowExecutionHistory(
+	context context.Context,
+	request *shared.GetWorkflowExecutionHistoryRequest,
+	opts ...yarpc.CallOption) (*shared.GetWorkflowExecutionHistoryResponse, error) {
+	var headers map[string]string
+	resp, err := c.Client.GetWorkflowExecutionHistory(context, request, acceptCompression(&headers, opts)...)
+	if err != nil || !isCompressed(headers) {
+		return resp, err
+	}
+	resp.History, err = compression.DecompressHistory(resp.History)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *compressionClient) PollForDecisionTask(
+	context context.Context,
+	request *shared.PollForDecisionTaskRequest,
+	opts ...yarpc.CallOption) (*shared.PollForDecisionTaskResponse, error) {
+	var headers map[string]string
+	resp, err := c.Client.PollForDecisionTask(context, request, acceptCompression(&headers, opts)...)
+	if err != nil || !isCompressed(headers) {
+		return resp, err
+	}
+	resp.History, err = compression.DecompressHistory(resp.History)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *compressionClient) PollForActivityTask(
+	context context.Context,
+	request *shared.PollForActivityTaskRequest,
+	opts ...yarpc.CallOption) (*shared.PollForActivityTaskResponse, error) {
+	var headers map[string]string
+	resp, err := c.Client.PollForActivityTask(context, request, acceptCompression(&headers, opts)...)
+	if err != nil || !isCompressed(headers) {
+		return resp, err
+	}
+	resp.Input, err = compression.Decompress(resp.Input)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// acceptCompression adds the accepted payload encoding header to the call options, and has the response headers
+// stored in the map headers points to, which must be the map of the caller
+func acceptCompression(headers *map[string]string, opts []yarpc.CallOption) []yarpc.CallOption {
+	return append(opts,
+		yarpc.WithHeader(common.AcceptPayloadEncodingHeaderName, compression.EncodingGzip),
+		yarpc.ResponseHeaders(headers),
+	)
+}
+
+func isCompressed(headers map[string]string) bool {
+	return headers[common.PayloadEncodingHeaderName] == compression.EncodingGzip
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package frontend
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/compression"
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/encoding"
+	"go.uber.org/yarpc/api/transport"
+)
+
+type (
+	compressionClientSuite struct {
+		suite.Suite
+		*require.Assertions
+		frontend *fakeCompressingFrontend
+		client   Client
+	}
+
+	// fakeCompressingFrontend compresses the payloads of its responses when the call accepts gzip, the way the
+	// payload compression interceptor of the frontend does
+	fakeCompressingFrontend struct {
+		Client
+		compress bool
+		history  *shared.History
+		input    []byte
+	}
+)
+
+func TestCompressionClientSuite(t *testing.T) {
+	s := new(compressionClientSuite)
+	suite.Run(t, s)
+}
+
+func (s *compressionClientSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.frontend = &fakeCompressingFrontend{
+		compress: true,
+		history: &shared.History{Events: []*shared.HistoryEvent{
+			{
+				EventId:   common.Int64Ptr(1),
+				EventType: shared.EventTypeWorkflowExecutionStarted.Ptr(),
+				WorkflowExecutionStartedEventAttributes: &shared.WorkflowExecutionStartedEventAttributes{
+					Input: bytes.Repeat([]byte("input"), 100),
+				},
+			},
+		}},
+		input: bytes.Repeat([]byte("input"), 100),
+	}
+	s.client = NewCompressionClient(s.frontend)
+}
+
+func (f *fakeCompressingFrontend) respond(ctx context.Context, opts []yarpc.CallOption) (bool, error) {
+	encodingOpts := make([]encoding.CallOption, 0, len(opts))
+	for _, opt := range opts {
+		encodingOpts = append(encodingOpts, encoding.CallOption(opt))
+	}
+	call := encoding.NewOutboundCall(encodingOpts...)
+	request := &transport.Request{}
+	if _, err := call.WriteToRequest(ctx, request); err != nil {
+		return false, err
+	}
+	accepted, _ := request.Headers.Get(common.AcceptPayloadEncodingHeaderName)
+	compressed := f.compress && accepted == compression.EncodingGzip
+
+	headers := transport.NewHeaders()
+	if compressed {
+		headers = headers.With(common.PayloadEncodingHeaderName, compression.EncodingGzip)
+	}
+	_, err := call.ReadFromResponse(ctx, &transport.Response{Headers: headers})
+	return compressed, err
+}
+
+func (f *fakeCompressingFrontend) GetWorkflowExecutionHistory(
+	ctx context.Context,
+	request *shared.GetWorkflowExecutionHistoryRequest,
+	opts ...yarpc.CallOption) (*shared.GetWorkflowExecutionHistoryResponse, error) {
+	compressed, err := f.respond(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	history := f.history
+	if compressed {
+		if history, err = compression.CompressHistory(history); err != nil {
+			return nil, err
+		}
+	}
+	return &shared.GetWorkflowExecutionHistoryResponse{History: history}, nil
+}
+
+func (f *fakeCompressingFrontend) PollForActivityTask(
+	ctx context.Context,
+	request *shared.PollForActivityTaskRequest,
+	opts ...yarpc.CallOption) (*shared.PollForActivityTaskResponse, error) {
+	compressed, err := f.respond(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	input := f.input
+	if compressed {
+		if input, err = compression.Compress(input); err != nil {
+			return nil, err
+		}
+	}
+	return &shared.PollForActivityTaskResponse{Input: input}, nil
+}
+
+func (s *compressionClientSuite) TestGetWorkflowExecutionHistoryRoundTrip() {
+	resp, err := s.client.GetWorkflowExecutionHistory(context.Background(), &shared.GetWorkflowExecutionHistoryRequest{})
+	s.NoError(err)
+	s.Equal(s.frontend.history, resp.History)
+}
+
+func (s *compressionClientSuite) TestPollForActivityTaskRoundTrip() {
+	resp, err := s.client.PollForActivityTask(context.Background(), &shared.PollForActivityTaskRequest{})
+	s.NoError(err)
+	s.Equal(s.frontend.input, resp.Input)
+}
+
+func (s *compressionClientSuite) TestUncompressedResponse() {
+	s.frontend.compress = false
+
+	resp, err := s.client.PollForActivityTask(context.Background(), &shared.PollForActivityTaskRequest{})
+	s.NoError(err)
+	s.Equal(s.frontend.input, resp.Input)
+}
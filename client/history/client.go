@@ -35,6 +35,14 @@ import (
 
 var _ Client = (*clientImpl)(nil)
 
+type (
+	// ratelimitUpdateError is returned by RatelimitUpdate for the usages of the shards which failed to update
+	ratelimitUpdateError struct {
+		usages []*h.RatelimitUsage
+		cause  error
+	}
+)
+
 type clientImpl struct {
 	resolver        membership.ServiceResolver
 	tokenSerializer common.TaskTokenSerializer
@@ -110,6 +118,65 @@ func (c *clientImpl) GetMutableState(
 	return response, nil
 }
 
+func (c *clientImpl) GetMutableStates(
+	ctx context.Context,
+	request *h.GetMutableStatesRequest,
+	opts ...yarpc.CallOption) (*h.GetMutableStatesResponse, error) {
+	// split the executions per shard so each call can be redirected independently on shard movement
+	executionsByShard := make(map[int][]*workflow.WorkflowExecution)
+	for _, execution := range request.Executions {
+		shardID := common.WorkflowIDToHistoryShard(execution.GetWorkflowId(), c.numberOfShards)
+		executionsByShard[shardID] = append(executionsByShard[shardID], execution)
+	}
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	response := &h.GetMutableStatesResponse{}
+	var responseLock sync.Mutex
+	var responseErr error
+	var wg sync.WaitGroup
+	for _, executions := range executionsByShard {
+		client, err := c.getHostForRequest(executions[0].GetWorkflowId())
+		if err != nil {
+			return nil, err
+		}
+		shardRequest := &h.GetMutableStatesRequest{
+			DomainUUID: request.DomainUUID,
+			Executions: executions,
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var shardResponse *h.GetMutableStatesResponse
+			op := func(ctx context.Context, client historyserviceclient.Interface) error {
+				var err error
+				ctx, cancel := c.createContext(ctx)
+				defer cancel()
+				shardResponse, err = client.GetMutableStates(ctx, shardRequest, opts...)
+				return err
+			}
+			err := c.executeWithRedirect(ctx, client, op)
+
+			responseLock.Lock()
+			defer responseLock.Unlock()
+			if err != nil {
+				if responseErr == nil {
+					responseErr = err
+				}
+				return
+			}
+			response.States = append(response.States, shardResponse.States...)
+			response.NotFound = append(response.NotFound, shardResponse.NotFound...)
+		}()
+	}
+	wg.Wait()
+
+	if responseErr != nil {
+		return nil, responseErr
+	}
+	return response, nil
+}
+
 func (c *clientImpl) DescribeHistoryHost(
 	ctx context.Context,
 	request *workflow.DescribeHistoryHostRequest,
@@ -151,6 +218,59 @@ func (c *clientImpl) DescribeHistoryHost(
 	return response, nil
 }
 
+func (c *clientImpl) DescribeShardBacklogs(
+	ctx context.Context,
+	request *workflow.DescribeShardBacklogsRequest,
+	opts ...yarpc.CallOption) (*workflow.DescribeShardBacklogsResponse, error) {
+
+	if request.HostAddress == nil {
+		return nil, &workflow.BadRequestError{Message: "HostAddress is not set on request."}
+	}
+	client := c.getThriftClient(*request.HostAddress)
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	var response *workflow.DescribeShardBacklogsResponse
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.DescribeShardBacklogs(ctx, request, opts...)
+		return err
+	}
+	err := c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) DescribeShardOperations(
+	ctx context.Context,
+	request *workflow.DescribeShardOperationsRequest,
+	opts ...yarpc.CallOption) (*workflow.DescribeShardOperationsResponse, error) {
+
+	host, err := c.resolver.Lookup(string(request.GetShardId()))
+	if err != nil {
+		return nil, err
+	}
+	client := c.getThriftClient(host.GetAddress())
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	var response *workflow.DescribeShardOperationsResponse
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.DescribeShardOperations(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) DescribeMutableState(
 	ctx context.Context,
 	request *h.DescribeMutableStateRequest,
@@ -447,6 +567,93 @@ func (c *clientImpl) SignalWorkflowExecution(
 	return err
 }
 
+func (c *clientImpl) UpdateWorkflowExecutionOptions(
+	ctx context.Context,
+	request *h.UpdateWorkflowExecutionOptionsRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getHostForRequest(*request.UpdateRequest.WorkflowExecution.WorkflowId)
+	if err != nil {
+		return err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.UpdateWorkflowExecutionOptions(ctx, request, opts...)
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+
+	return err
+}
+
+func (c *clientImpl) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *h.DeleteWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getHostForRequest(*request.Execution.WorkflowId)
+	if err != nil {
+		return err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.DeleteWorkflowExecution(ctx, request, opts...)
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+
+	return err
+}
+
+func (c *clientImpl) UpdateWorkflowExecution(
+	ctx context.Context,
+	request *h.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*workflow.UpdateWorkflowExecutionResponse, error) {
+	client, err := c.getHostForRequest(*request.UpdateRequest.WorkflowExecution.WorkflowId)
+	if err != nil {
+		return nil, err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	var response *workflow.UpdateWorkflowExecutionResponse
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.UpdateWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *clientImpl) FixWorkflowExecution(
+	ctx context.Context,
+	request *h.FixWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*h.FixWorkflowExecutionResponse, error) {
+	client, err := c.getHostForRequest(*request.Execution.WorkflowId)
+	if err != nil {
+		return nil, err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	var response *h.FixWorkflowExecutionResponse
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.FixWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) SignalWithStartWorkflowExecution(
 	ctx context.Context,
 	request *h.SignalWithStartWorkflowExecutionRequest,
@@ -584,6 +791,137 @@ func (c *clientImpl) SyncShardStatus(
 	return err
 }
 
+func (c *clientImpl) SyncActivity(
+	ctx context.Context,
+	request *h.SyncActivityRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getHostForRequest(request.GetWorkflowId())
+	if err != nil {
+		return err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.SyncActivity(ctx, request, opts...)
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	return err
+}
+
+func (c *clientImpl) RefreshDomainCache(
+	ctx context.Context,
+	request *workflow.RefreshDomainCacheRequest,
+	opts ...yarpc.CallOption) error {
+
+	var addresses []string
+	if request.HostAddress != nil {
+		addresses = append(addresses, request.GetHostAddress())
+	} else {
+		// the domain cache of every history host has to be refreshed
+		hosts, err := c.resolver.Members()
+		if err != nil {
+			return err
+		}
+		for _, host := range hosts {
+			addresses = append(addresses, host.GetAddress())
+		}
+	}
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	errs := make([]error, len(addresses))
+	var wg sync.WaitGroup
+	for i, address := range addresses {
+		wg.Add(1)
+		go func(i int, address string) {
+			defer wg.Done()
+			hostRequest := *request
+			hostRequest.HostAddress = common.StringPtr(address)
+			ctx, cancel := c.createContext(ctx)
+			defer cancel()
+			errs[i] = c.getThriftClient(address).RefreshDomainCache(ctx, &hostRequest, opts...)
+		}(i, address)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RatelimitUpdate sends the usages to the owners of their shards. The shards are updated independently, when some
+// of them fail the response holds the quotas of the others, together with an error carrying the usages which failed.
+func (c *clientImpl) RatelimitUpdate(
+	ctx context.Context,
+	request *h.RatelimitUpdateRequest,
+	opts ...yarpc.CallOption) (*h.RatelimitUpdateResponse, error) {
+	// the usage of a key is aggregated by the owner of the shard the key maps to
+	usagesByShard := make(map[int][]*h.RatelimitUsage)
+	for _, usage := range request.Usages {
+		shardID := common.WorkflowIDToHistoryShard(usage.GetKey(), c.numberOfShards)
+		usagesByShard[shardID] = append(usagesByShard[shardID], usage)
+	}
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	response := &h.RatelimitUpdateResponse{}
+	var updateErr *ratelimitUpdateError
+	var responseLock sync.Mutex
+	onShardResult := func(usages []*h.RatelimitUsage, shardResponse *h.RatelimitUpdateResponse, err error) {
+		responseLock.Lock()
+		defer responseLock.Unlock()
+		if err != nil {
+			if updateErr == nil {
+				updateErr = &ratelimitUpdateError{cause: err}
+			}
+			updateErr.usages = append(updateErr.usages, usages...)
+			return
+		}
+		response.Quotas = append(response.Quotas, shardResponse.Quotas...)
+	}
+
+	var wg sync.WaitGroup
+	for _, usages := range usagesByShard {
+		client, err := c.getHostForRequest(usages[0].GetKey())
+		if err != nil {
+			onShardResult(usages, nil, err)
+			continue
+		}
+		shardRequest := &h.RatelimitUpdateRequest{
+			Host:          request.Host,
+			ElapsedMillis: request.ElapsedMillis,
+			Usages:        usages,
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var shardResponse *h.RatelimitUpdateResponse
+			op := func(ctx context.Context, client historyserviceclient.Interface) error {
+				var err error
+				ctx, cancel := c.createContext(ctx)
+				defer cancel()
+				shardResponse, err = client.RatelimitUpdate(ctx, shardRequest, opts...)
+				return err
+			}
+			err := c.executeWithRedirect(ctx, client, op)
+			onShardResult(shardRequest.Usages, shardResponse, err)
+		}()
+	}
+	wg.Wait()
+
+	if updateErr != nil {
+		return response, updateErr
+	}
+	return response, nil
+}
+
+func (e *ratelimitUpdateError) Error() string {
+	return e.cause.Error()
+}
+
 func (c *clientImpl) getHostForRequest(workflowID string) (historyserviceclient.Interface, error) {
 	key := common.WorkflowIDToHistoryShard(workflowID, c.numberOfShards)
 	host, err := c.resolver.Lookup(string(key))
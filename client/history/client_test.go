@@ -0,0 +1,271 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/history/historyserviceclient"
+	"github.com/uber/cadence/.gen/go/history/historyservicetest"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/mocks"
+)
+
+type (
+	clientSuite struct {
+		suite.Suite
+		mockCtrl     *gomock.Controller
+		mockResolver *mocks.ServiceResolver
+		mockHosts    []*historyservicetest.MockClient
+		client       *clientImpl
+	}
+)
+
+const (
+	testNumberOfShards = 2
+	testDomainID       = "deadbeef-0123-4567-890a-bcdef0123456"
+)
+
+func TestClientSuite(t *testing.T) {
+	s := new(clientSuite)
+	suite.Run(t, s)
+}
+
+func (s *clientSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockResolver = &mocks.ServiceResolver{}
+	s.client = &clientImpl{
+		resolver:        s.mockResolver,
+		tokenSerializer: common.NewJSONTaskTokenSerializer(),
+		numberOfShards:  testNumberOfShards,
+		thriftCache:     make(map[string]historyserviceclient.Interface),
+	}
+
+	// one history host per shard
+	s.mockHosts = nil
+	for shardID := 0; shardID < testNumberOfShards; shardID++ {
+		host := historyservicetest.NewMockClient(s.mockCtrl)
+		address := fmt.Sprintf("history-host-%v", shardID)
+		s.mockHosts = append(s.mockHosts, host)
+		s.client.thriftCache[address] = host
+		s.mockResolver.On("Lookup", string(rune(shardID))).Return(membership.NewHostInfo(address, nil), nil)
+	}
+}
+
+func (s *clientSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *clientSuite) TestGetMutableStates_FanOut() {
+	executions, executionsByShard := s.newExecutions(10)
+	s.Equal(testNumberOfShards, len(executionsByShard), "the executions are expected to cover every shard")
+
+	var states []*h.GetMutableStateResponse
+	var notFound []*workflow.WorkflowExecution
+	for shardID, shardExecutions := range executionsByShard {
+		// the first execution of each shard does not exist
+		shardResponse := &h.GetMutableStatesResponse{NotFound: shardExecutions[:1]}
+		for _, execution := range shardExecutions[1:] {
+			shardResponse.States = append(shardResponse.States, &h.GetMutableStateResponse{Execution: execution})
+		}
+		states = append(states, shardResponse.States...)
+		notFound = append(notFound, shardResponse.NotFound...)
+
+		s.mockHosts[shardID].EXPECT().GetMutableStates(gomock.Any(), &h.GetMutableStatesRequest{
+			DomainUUID: common.StringPtr(testDomainID),
+			Executions: shardExecutions,
+		}).Return(shardResponse, nil)
+	}
+
+	resp, err := s.client.GetMutableStates(context.Background(), &h.GetMutableStatesRequest{
+		DomainUUID: common.StringPtr(testDomainID),
+		Executions: executions,
+	})
+	s.NoError(err)
+	s.Equal(len(states), len(resp.States))
+	for _, state := range states {
+		s.Contains(resp.States, state)
+	}
+	s.Equal(len(notFound), len(resp.NotFound))
+	for _, execution := range notFound {
+		s.Contains(resp.NotFound, execution)
+	}
+}
+
+func (s *clientSuite) TestGetMutableStates_ShardFailure() {
+	executions, executionsByShard := s.newExecutions(10)
+	s.Equal(testNumberOfShards, len(executionsByShard), "the executions are expected to cover every shard")
+
+	shardErr := errors.New("some random error")
+	for shardID, shardExecutions := range executionsByShard {
+		var shardResponse *h.GetMutableStatesResponse
+		var err error
+		if shardID == 0 {
+			err = shardErr
+		} else {
+			shardResponse = &h.GetMutableStatesResponse{NotFound: shardExecutions}
+		}
+		s.mockHosts[shardID].EXPECT().GetMutableStates(gomock.Any(), gomock.Any()).Return(shardResponse, err)
+	}
+
+	resp, err := s.client.GetMutableStates(context.Background(), &h.GetMutableStatesRequest{
+		DomainUUID: common.StringPtr(testDomainID),
+		Executions: executions,
+	})
+	s.Equal(shardErr, err)
+	s.Nil(resp)
+}
+
+func (s *clientSuite) TestGetMutableStates_ShardMoved() {
+	executions, executionsByShard := s.newExecutions(10)
+	shardExecutions := executionsByShard[0]
+	movedShardOwner := historyservicetest.NewMockClient(s.mockCtrl)
+	s.client.thriftCache["history-host-moved"] = movedShardOwner
+
+	// only the calls of the moved shard are redirected
+	for shardID, shardExecutions := range executionsByShard {
+		if shardID == 0 {
+			s.mockHosts[shardID].EXPECT().GetMutableStates(gomock.Any(), gomock.Any()).Return(nil,
+				&h.ShardOwnershipLostError{Owner: common.StringPtr("history-host-moved")})
+			continue
+		}
+		s.mockHosts[shardID].EXPECT().GetMutableStates(gomock.Any(), gomock.Any()).Return(
+			&h.GetMutableStatesResponse{NotFound: shardExecutions}, nil)
+	}
+	movedShardOwner.EXPECT().GetMutableStates(gomock.Any(), &h.GetMutableStatesRequest{
+		DomainUUID: common.StringPtr(testDomainID),
+		Executions: shardExecutions,
+	}).Return(&h.GetMutableStatesResponse{NotFound: shardExecutions}, nil)
+
+	resp, err := s.client.GetMutableStates(context.Background(), &h.GetMutableStatesRequest{
+		DomainUUID: common.StringPtr(testDomainID),
+		Executions: executions,
+	})
+	s.NoError(err)
+	s.Equal(len(executions), len(resp.NotFound))
+}
+
+func (s *clientSuite) TestRatelimitUpdate_ShardFailure() {
+	usages, usagesByShard := s.newUsages(10)
+	s.Equal(testNumberOfShards, len(usagesByShard), "the usages are expected to cover every shard")
+
+	shardErr := errors.New("some random error")
+	var quotas []*h.RatelimitQuota
+	for shardID, shardUsages := range usagesByShard {
+		if shardID == 0 {
+			s.mockHosts[shardID].EXPECT().RatelimitUpdate(gomock.Any(), gomock.Any()).Return(nil, shardErr)
+			continue
+		}
+		shardResponse := s.newQuotas(shardUsages)
+		quotas = append(quotas, shardResponse.Quotas...)
+		s.mockHosts[shardID].EXPECT().RatelimitUpdate(gomock.Any(), gomock.Any()).Return(shardResponse, nil)
+	}
+
+	// the quotas of the shards which were updated are returned with the error of the others
+	resp, err := s.client.RatelimitUpdate(context.Background(), &h.RatelimitUpdateRequest{Usages: usages})
+	s.Equal(quotas, resp.Quotas)
+	s.IsType(&ratelimitUpdateError{}, err)
+	s.Equal(shardErr.Error(), err.Error())
+	s.Equal(usagesByShard[0], err.(*ratelimitUpdateError).usages)
+}
+
+func (s *clientSuite) TestRatelimitUpdate_RetriesFailedShards() {
+	usages, usagesByShard := s.newUsages(10)
+	s.Equal(testNumberOfShards, len(usagesByShard), "the usages are expected to cover every shard")
+
+	var quotas []*h.RatelimitQuota
+	for shardID, shardUsages := range usagesByShard {
+		shardResponse := s.newQuotas(shardUsages)
+		quotas = append(quotas, shardResponse.Quotas...)
+		if shardID == 0 {
+			// the usages of the shard which failed are sent again alone
+			gomock.InOrder(
+				s.mockHosts[shardID].EXPECT().RatelimitUpdate(gomock.Any(), gomock.Any()).Return(nil,
+					&workflow.ServiceBusyError{}),
+				s.mockHosts[shardID].EXPECT().RatelimitUpdate(gomock.Any(), &h.RatelimitUpdateRequest{
+					Usages: shardUsages,
+				}).Return(shardResponse, nil),
+			)
+			continue
+		}
+		s.mockHosts[shardID].EXPECT().RatelimitUpdate(gomock.Any(), gomock.Any()).Return(shardResponse, nil)
+	}
+
+	policy := backoff.NewExponentialRetryPolicy(time.Millisecond)
+	policy.SetMaximumAttempts(2)
+	client := NewRetryableClient(s.client, policy, common.IsWhitelistServiceTransientError)
+	resp, err := client.RatelimitUpdate(context.Background(), &h.RatelimitUpdateRequest{Usages: usages})
+	s.NoError(err)
+	s.Equal(len(quotas), len(resp.Quotas))
+	for _, quota := range quotas {
+		s.Contains(resp.Quotas, quota)
+	}
+}
+
+// newUsages returns the given number of rate limit usages, and the same usages grouped by history shard
+func (s *clientSuite) newUsages(count int) ([]*h.RatelimitUsage, map[int][]*h.RatelimitUsage) {
+	var usages []*h.RatelimitUsage
+	usagesByShard := make(map[int][]*h.RatelimitUsage)
+	for i := 0; i < count; i++ {
+		usage := &h.RatelimitUsage{
+			Key:     common.StringPtr(fmt.Sprintf("ratelimit-update-test-%v", i)),
+			Allowed: common.Int64Ptr(int64(i)),
+		}
+		shardID := common.WorkflowIDToHistoryShard(usage.GetKey(), testNumberOfShards)
+		usages = append(usages, usage)
+		usagesByShard[shardID] = append(usagesByShard[shardID], usage)
+	}
+	return usages, usagesByShard
+}
+
+func (s *clientSuite) newQuotas(usages []*h.RatelimitUsage) *h.RatelimitUpdateResponse {
+	response := &h.RatelimitUpdateResponse{}
+	for _, usage := range usages {
+		response.Quotas = append(response.Quotas, &h.RatelimitQuota{Key: usage.Key, Weight: common.Float64Ptr(0.5)})
+	}
+	return response
+}
+
+// newExecutions returns the given number of executions, and the same executions grouped by history shard
+func (s *clientSuite) newExecutions(count int) ([]*workflow.WorkflowExecution, map[int][]*workflow.WorkflowExecution) {
+	var executions []*workflow.WorkflowExecution
+	executionsByShard := make(map[int][]*workflow.WorkflowExecution)
+	for i := 0; i < count; i++ {
+		execution := &workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(fmt.Sprintf("get-mutable-states-test-%v", i)),
+			RunId:      common.StringPtr("0d00698f-08e1-4d36-a3e2-3bf109f5d2d6"),
+		}
+		shardID := common.WorkflowIDToHistoryShard(execution.GetWorkflowId(), testNumberOfShards)
+		executions = append(executions, execution)
+		executionsByShard[shardID] = append(executionsByShard[shardID], execution)
+	}
+	return executions, executionsByShard
+}
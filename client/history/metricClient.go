@@ -70,6 +70,24 @@ func (c *metricClient) DescribeHistoryHost(
 	return resp, err
 }
 
+func (c *metricClient) DescribeShardBacklogs(
+	context context.Context,
+	request *shared.DescribeShardBacklogsRequest,
+	opts ...yarpc.CallOption) (*shared.DescribeShardBacklogsResponse, error) {
+	resp, err := c.client.DescribeShardBacklogs(context, request, opts...)
+
+	return resp, err
+}
+
+func (c *metricClient) DescribeShardOperations(
+	context context.Context,
+	request *shared.DescribeShardOperationsRequest,
+	opts ...yarpc.CallOption) (*shared.DescribeShardOperationsResponse, error) {
+	resp, err := c.client.DescribeShardOperations(context, request, opts...)
+
+	return resp, err
+}
+
 func (c *metricClient) DescribeMutableState(
 	context context.Context,
 	request *h.DescribeMutableStateRequest,
@@ -96,6 +114,23 @@ func (c *metricClient) GetMutableState(
 	return resp, err
 }
 
+func (c *metricClient) GetMutableStates(
+	context context.Context,
+	request *h.GetMutableStatesRequest,
+	opts ...yarpc.CallOption) (*h.GetMutableStatesResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientGetMutableStatesScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientGetMutableStatesScope, metrics.CadenceLatency)
+	resp, err := c.client.GetMutableStates(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientGetMutableStatesScope, metrics.HistoryClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) ResetStickyTaskList(
 	context context.Context,
 	request *h.ResetStickyTaskListRequest,
@@ -300,6 +335,74 @@ func (c *metricClient) SignalWorkflowExecution(
 	return err
 }
 
+func (c *metricClient) UpdateWorkflowExecutionOptions(
+	context context.Context,
+	request *h.UpdateWorkflowExecutionOptionsRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientUpdateWorkflowExecutionOptionsScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientUpdateWorkflowExecutionOptionsScope, metrics.CadenceLatency)
+	err := c.client.UpdateWorkflowExecutionOptions(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientUpdateWorkflowExecutionOptionsScope, metrics.HistoryClientFailures)
+	}
+
+	return err
+}
+
+func (c *metricClient) DeleteWorkflowExecution(
+	context context.Context,
+	request *h.DeleteWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.CadenceLatency)
+	err := c.client.DeleteWorkflowExecution(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.HistoryClientFailures)
+	}
+
+	return err
+}
+
+func (c *metricClient) UpdateWorkflowExecution(
+	context context.Context,
+	request *h.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*shared.UpdateWorkflowExecutionResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientUpdateWorkflowExecutionScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientUpdateWorkflowExecutionScope, metrics.CadenceLatency)
+	resp, err := c.client.UpdateWorkflowExecution(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientUpdateWorkflowExecutionScope, metrics.HistoryClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) FixWorkflowExecution(
+	context context.Context,
+	request *h.FixWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*h.FixWorkflowExecutionResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientFixWorkflowExecutionScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientFixWorkflowExecutionScope, metrics.CadenceLatency)
+	resp, err := c.client.FixWorkflowExecution(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientFixWorkflowExecutionScope, metrics.HistoryClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) SignalWithStartWorkflowExecution(
 	context context.Context,
 	request *h.SignalWithStartWorkflowExecutionRequest,
@@ -402,6 +505,40 @@ func (c *metricClient) ReplicateEvents(
 	return err
 }
 
+func (c *metricClient) RefreshDomainCache(
+	context context.Context,
+	request *shared.RefreshDomainCacheRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientRefreshDomainCacheScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientRefreshDomainCacheScope, metrics.CadenceLatency)
+	err := c.client.RefreshDomainCache(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientRefreshDomainCacheScope, metrics.HistoryClientFailures)
+	}
+
+	return err
+}
+
+func (c *metricClient) RatelimitUpdate(
+	context context.Context,
+	request *h.RatelimitUpdateRequest,
+	opts ...yarpc.CallOption) (*h.RatelimitUpdateResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientRatelimitUpdateScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientRatelimitUpdateScope, metrics.CadenceLatency)
+	resp, err := c.client.RatelimitUpdate(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientRatelimitUpdateScope, metrics.HistoryClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) SyncShardStatus(
 	context context.Context,
 	request *h.SyncShardStatusRequest,
@@ -418,3 +555,20 @@ func (c *metricClient) SyncShardStatus(
 
 	return err
 }
+
+func (c *metricClient) SyncActivity(
+	context context.Context,
+	request *h.SyncActivityRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientSyncActivityScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientSyncActivityScope, metrics.CadenceLatency)
+	err := c.client.SyncActivity(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientSyncActivityScope, metrics.HistoryClientFailures)
+	}
+
+	return err
+}
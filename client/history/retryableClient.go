@@ -78,6 +78,38 @@ func (c *retryableClient) DescribeHistoryHost(
 	return resp, err
 }
 
+func (c *retryableClient) DescribeShardBacklogs(
+	ctx context.Context,
+	request *shared.DescribeShardBacklogsRequest,
+	opts ...yarpc.CallOption) (*shared.DescribeShardBacklogsResponse, error) {
+
+	var resp *shared.DescribeShardBacklogsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeShardBacklogs(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeShardOperations(
+	ctx context.Context,
+	request *shared.DescribeShardOperationsRequest,
+	opts ...yarpc.CallOption) (*shared.DescribeShardOperationsResponse, error) {
+
+	var resp *shared.DescribeShardOperationsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeShardOperations(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) DescribeMutableState(
 	ctx context.Context,
 	request *h.DescribeMutableStateRequest,
@@ -110,6 +142,22 @@ func (c *retryableClient) GetMutableState(
 	return resp, err
 }
 
+func (c *retryableClient) GetMutableStates(
+	ctx context.Context,
+	request *h.GetMutableStatesRequest,
+	opts ...yarpc.CallOption) (*h.GetMutableStatesResponse, error) {
+
+	var resp *h.GetMutableStatesResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetMutableStates(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) ResetStickyTaskList(
 	ctx context.Context,
 	request *h.ResetStickyTaskListRequest,
@@ -278,6 +326,62 @@ func (c *retryableClient) SignalWorkflowExecution(
 	return backoff.Retry(op, c.policy, c.isRetryable)
 }
 
+func (c *retryableClient) UpdateWorkflowExecutionOptions(
+	ctx context.Context,
+	request *h.UpdateWorkflowExecutionOptionsRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.UpdateWorkflowExecutionOptions(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *h.DeleteWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.DeleteWorkflowExecution(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) UpdateWorkflowExecution(
+	ctx context.Context,
+	request *h.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*shared.UpdateWorkflowExecutionResponse, error) {
+
+	var resp *shared.UpdateWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) FixWorkflowExecution(
+	ctx context.Context,
+	request *h.FixWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*h.FixWorkflowExecutionResponse, error) {
+
+	var resp *h.FixWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.FixWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) SignalWithStartWorkflowExecution(
 	ctx context.Context,
 	request *h.SignalWithStartWorkflowExecutionRequest,
@@ -354,6 +458,43 @@ func (c *retryableClient) ReplicateEvents(
 	return backoff.Retry(op, c.policy, c.isRetryable)
 }
 
+func (c *retryableClient) RefreshDomainCache(
+	ctx context.Context,
+	request *shared.RefreshDomainCacheRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.RefreshDomainCache(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) RatelimitUpdate(
+	ctx context.Context,
+	request *h.RatelimitUpdateRequest,
+	opts ...yarpc.CallOption) (*h.RatelimitUpdateResponse, error) {
+
+	// only the usages of the shards which failed are sent again, the others are already aggregated by their owners
+	resp := &h.RatelimitUpdateResponse{}
+	op := func() error {
+		shardsResp, err := c.client.RatelimitUpdate(ctx, request, opts...)
+		if shardsResp != nil {
+			resp.Quotas = append(resp.Quotas, shardsResp.Quotas...)
+		}
+		if updateErr, ok := err.(*ratelimitUpdateError); ok {
+			retryRequest := *request
+			retryRequest.Usages = updateErr.usages
+			request = &retryRequest
+			return updateErr.cause
+		}
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) SyncShardStatus(
 	ctx context.Context,
 	request *h.SyncShardStatusRequest,
@@ -365,3 +506,15 @@ func (c *retryableClient) SyncShardStatus(
 
 	return backoff.Retry(op, c.policy, c.isRetryable)
 }
+
+func (c *retryableClient) SyncActivity(
+	ctx context.Context,
+	request *h.SyncActivityRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.SyncActivity(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
@@ -40,6 +40,9 @@ type clientImpl struct {
 	thriftCacheLock sync.RWMutex
 	thriftCache     map[string]matchingserviceclient.Interface
 	rpcFactory      common.RPCFactory
+	movedLock       sync.Mutex
+	movedHosts      map[string]map[string]bool
+	movedRefreshed  time.Time
 }
 
 // NewClient creates a new history service TChannel client
@@ -157,11 +160,124 @@ func (c *clientImpl) DescribeTaskList(ctx context.Context, request *m.DescribeTa
 	return client.DescribeTaskList(ctx, request, opts...)
 }
 
+func (c *clientImpl) GetTaskListsByDomain(ctx context.Context, request *m.GetTaskListsByDomainRequest, opts ...yarpc.CallOption) (*workflow.GetTaskListsByDomainResponse, error) {
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	// task lists of a domain are not owned by a single host, any host can scan them
+	client, err := c.getHostForRequest(request.GetDomainUUID())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetTaskListsByDomain(ctx, request, opts...)
+}
+
+func (c *clientImpl) RecordWorkerHeartbeat(ctx context.Context, request *m.RecordWorkerHeartbeatRequest, opts ...yarpc.CallOption) error {
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getHostForRequest(request.HeartbeatRequest.TaskList.GetName())
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.RecordWorkerHeartbeat(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListTaskListDLQTasks(ctx context.Context, request *m.ListTaskListDLQTasksRequest, opts ...yarpc.CallOption) (*workflow.ListTaskListDLQTasksResponse, error) {
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getHostForRequest(request.ListRequest.TaskList.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ListTaskListDLQTasks(ctx, request, opts...)
+}
+
+func (c *clientImpl) RequeueTaskListDLQTasks(ctx context.Context, request *m.RequeueTaskListDLQTasksRequest, opts ...yarpc.CallOption) (*workflow.RequeueTaskListDLQTasksResponse, error) {
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getHostForRequest(request.RequeueRequest.TaskList.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.RequeueTaskListDLQTasks(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeTaskListLatency(ctx context.Context, request *m.DescribeTaskListLatencyRequest, opts ...yarpc.CallOption) (*workflow.DescribeTaskListLatencyResponse, error) {
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getHostForRequest(request.DescribeRequest.TaskList.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeTaskListLatency(ctx, request, opts...)
+}
+
+func (c *clientImpl) ForceUnloadTaskList(ctx context.Context, request *m.ForceUnloadTaskListRequest, opts ...yarpc.CallOption) (*workflow.ForceUnloadTaskListResponse, error) {
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	var client matchingserviceclient.Interface
+	if request.UnloadRequest.HostAddress != nil {
+		client = c.getThriftClient(request.UnloadRequest.GetHostAddress())
+	} else {
+		var err error
+		client, err = c.getHostForRequest(request.UnloadRequest.TaskList.GetName())
+		if err != nil {
+			return nil, err
+		}
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ForceUnloadTaskList(ctx, request, opts...)
+}
+
+func (c *clientImpl) RefreshDomainCache(ctx context.Context, request *workflow.RefreshDomainCacheRequest, opts ...yarpc.CallOption) error {
+	var addresses []string
+	if request.HostAddress != nil {
+		addresses = append(addresses, request.GetHostAddress())
+	} else {
+		// the domain cache of every matching host has to be refreshed
+		hosts, err := c.resolver.Members()
+		if err != nil {
+			return err
+		}
+		for _, host := range hosts {
+			addresses = append(addresses, host.GetAddress())
+		}
+	}
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	errs := make([]error, len(addresses))
+	var wg sync.WaitGroup
+	for i, address := range addresses {
+		wg.Add(1)
+		go func(i int, address string) {
+			defer wg.Done()
+			hostRequest := *request
+			hostRequest.HostAddress = common.StringPtr(address)
+			ctx, cancel := c.createContext(ctx)
+			defer cancel()
+			errs[i] = c.getThriftClient(address).RefreshDomainCache(ctx, &hostRequest, opts...)
+		}(i, address)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *clientImpl) getHostForRequest(key string) (matchingserviceclient.Interface, error) {
 	host, err := c.resolver.Lookup(key)
 	if err != nil {
 		return nil, err
 	}
+	host = c.getMovedTaskListHost(key, host)
 	return c.getThriftClient(host.GetAddress()), nil
 }
 
@@ -0,0 +1,152 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/.gen/go/matching/matchingserviceclient"
+	"github.com/uber/cadence/.gen/go/matching/matchingservicetest"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/mocks"
+)
+
+type (
+	clientSuite struct {
+		suite.Suite
+		mockCtrl     *gomock.Controller
+		mockResolver *mocks.ServiceResolver
+		mockHosts    map[string]*matchingservicetest.MockClient
+		client       *clientImpl
+	}
+)
+
+const (
+	testTaskList  = "test-tasklist"
+	testOwnerHost = "matching-host-0"
+	testOtherHost = "matching-host-1"
+)
+
+func TestClientSuite(t *testing.T) {
+	s := new(clientSuite)
+	suite.Run(t, s)
+}
+
+func (s *clientSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockResolver = &mocks.ServiceResolver{}
+	s.client = &clientImpl{
+		resolver:    s.mockResolver,
+		thriftCache: make(map[string]matchingserviceclient.Interface),
+	}
+	s.mockHosts = make(map[string]*matchingservicetest.MockClient)
+	for _, address := range []string{testOwnerHost, testOtherHost} {
+		host := matchingservicetest.NewMockClient(s.mockCtrl)
+		s.mockHosts[address] = host
+		s.client.thriftCache[address] = host
+	}
+	s.mockResolver.On("Lookup", testTaskList).Return(membership.NewHostInfo(testOwnerHost, nil), nil)
+}
+
+func (s *clientSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+	s.mockResolver.AssertExpectations(s.T())
+}
+
+func (s *clientSuite) TestGetHostForRequest_RingOwner() {
+	s.mockResolver.On("Members").Return([]*membership.HostInfo{
+		membership.NewHostInfo(testOwnerHost, nil),
+		s.newMovedHost(testOtherHost, time.Now().Add(time.Minute)),
+	}, nil).Once()
+
+	host, err := s.client.getHostForRequest(testTaskList)
+	s.NoError(err)
+	s.Equal(s.mockHosts[testOwnerHost], host)
+}
+
+func (s *clientSuite) TestGetHostForRequest_MovedTaskList() {
+	s.mockResolver.On("Members").Return([]*membership.HostInfo{
+		s.newMovedHost(testOwnerHost, time.Now().Add(time.Minute)),
+		membership.NewHostInfo(testOtherHost, nil),
+	}, nil).Once()
+	// the first derived key still maps to the owner which moved the task list away
+	s.mockResolver.On("Lookup", testTaskList+"/1").Return(membership.NewHostInfo(testOwnerHost, nil), nil).Once()
+	s.mockResolver.On("Lookup", testTaskList+"/2").Return(membership.NewHostInfo(testOtherHost, nil), nil).Once()
+
+	host, err := s.client.getHostForRequest(testTaskList)
+	s.NoError(err)
+	s.Equal(s.mockHosts[testOtherHost], host)
+}
+
+func (s *clientSuite) TestGetHostForRequest_ExpiredMove() {
+	s.mockResolver.On("Members").Return([]*membership.HostInfo{
+		s.newMovedHost(testOwnerHost, time.Now().Add(-time.Second)),
+		membership.NewHostInfo(testOtherHost, nil),
+	}, nil).Once()
+
+	host, err := s.client.getHostForRequest(testTaskList)
+	s.NoError(err)
+	s.Equal(s.mockHosts[testOwnerHost], host)
+}
+
+func (s *clientSuite) TestGetHostForRequest_MovedFromAllHosts() {
+	expiry := time.Now().Add(time.Minute)
+	s.mockResolver.On("Members").Return([]*membership.HostInfo{
+		s.newMovedHost(testOwnerHost, expiry),
+		s.newMovedHost(testOtherHost, expiry),
+	}, nil).Once()
+	for i := 1; i <= movedTaskListMaxLookups; i++ {
+		s.mockResolver.On("Lookup", fmt.Sprintf("%v/%v", testTaskList, i)).Return(
+			membership.NewHostInfo(testOtherHost, nil), nil).Once()
+	}
+
+	// the ring owner keeps the task list when no host is left to move it to
+	host, err := s.client.getHostForRequest(testTaskList)
+	s.NoError(err)
+	s.Equal(s.mockHosts[testOwnerHost], host)
+}
+
+func (s *clientSuite) TestGetMovedTaskLists_Cached() {
+	s.mockResolver.On("Members").Return([]*membership.HostInfo{
+		s.newMovedHost(testOwnerHost, time.Now().Add(time.Minute)),
+	}, nil).Once()
+
+	moved := s.client.getMovedTaskLists()
+	s.True(moved[testOwnerHost][testTaskList])
+	// the labels are read again only after movedTaskListsRefreshInterval
+	s.Equal(moved, s.client.getMovedTaskLists())
+
+	s.client.movedRefreshed = time.Now().Add(-movedTaskListsRefreshInterval)
+	s.mockResolver.On("Members").Return([]*membership.HostInfo{
+		membership.NewHostInfo(testOwnerHost, nil),
+	}, nil).Once()
+	s.False(s.client.getMovedTaskLists()[testOwnerHost][testTaskList])
+}
+
+func (s *clientSuite) newMovedHost(address string, expiry time.Time) *membership.HostInfo {
+	return membership.NewHostInfo(address, map[string]string{
+		MovedTaskListsLabel: EncodeMovedTaskLists(map[string]time.Time{testTaskList: expiry}),
+	})
+}
@@ -179,3 +179,122 @@ func (c *metricClient) DescribeTaskList(
 
 	return resp, err
 }
+
+func (c *metricClient) GetTaskListsByDomain(
+	ctx context.Context,
+	request *m.GetTaskListsByDomainRequest,
+	opts ...yarpc.CallOption) (*workflow.GetTaskListsByDomainResponse, error) {
+	c.metricsClient.IncCounter(metrics.MatchingClientGetTaskListsByDomainScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientGetTaskListsByDomainScope, metrics.CadenceLatency)
+	resp, err := c.client.GetTaskListsByDomain(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientGetTaskListsByDomainScope, metrics.CadenceFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) RecordWorkerHeartbeat(
+	ctx context.Context,
+	request *m.RecordWorkerHeartbeatRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.MatchingClientRecordWorkerHeartbeatScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientRecordWorkerHeartbeatScope, metrics.CadenceLatency)
+	err := c.client.RecordWorkerHeartbeat(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientRecordWorkerHeartbeatScope, metrics.CadenceFailures)
+	}
+
+	return err
+}
+
+func (c *metricClient) ListTaskListDLQTasks(
+	ctx context.Context,
+	request *m.ListTaskListDLQTasksRequest,
+	opts ...yarpc.CallOption) (*workflow.ListTaskListDLQTasksResponse, error) {
+	c.metricsClient.IncCounter(metrics.MatchingClientListTaskListDLQTasksScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientListTaskListDLQTasksScope, metrics.CadenceLatency)
+	resp, err := c.client.ListTaskListDLQTasks(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientListTaskListDLQTasksScope, metrics.CadenceFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) RequeueTaskListDLQTasks(
+	ctx context.Context,
+	request *m.RequeueTaskListDLQTasksRequest,
+	opts ...yarpc.CallOption) (*workflow.RequeueTaskListDLQTasksResponse, error) {
+	c.metricsClient.IncCounter(metrics.MatchingClientRequeueTaskListDLQTasksScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientRequeueTaskListDLQTasksScope, metrics.CadenceLatency)
+	resp, err := c.client.RequeueTaskListDLQTasks(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientRequeueTaskListDLQTasksScope, metrics.CadenceFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) DescribeTaskListLatency(
+	ctx context.Context,
+	request *m.DescribeTaskListLatencyRequest,
+	opts ...yarpc.CallOption) (*workflow.DescribeTaskListLatencyResponse, error) {
+	c.metricsClient.IncCounter(metrics.MatchingClientDescribeTaskListLatencyScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientDescribeTaskListLatencyScope, metrics.CadenceLatency)
+	resp, err := c.client.DescribeTaskListLatency(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientDescribeTaskListLatencyScope, metrics.CadenceFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) ForceUnloadTaskList(
+	ctx context.Context,
+	request *m.ForceUnloadTaskListRequest,
+	opts ...yarpc.CallOption) (*workflow.ForceUnloadTaskListResponse, error) {
+	c.metricsClient.IncCounter(metrics.MatchingClientForceUnloadTaskListScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientForceUnloadTaskListScope, metrics.CadenceLatency)
+	resp, err := c.client.ForceUnloadTaskList(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientForceUnloadTaskListScope, metrics.CadenceFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) RefreshDomainCache(
+	ctx context.Context,
+	request *workflow.RefreshDomainCacheRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.MatchingClientRefreshDomainCacheScope, metrics.CadenceRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientRefreshDomainCacheScope, metrics.CadenceLatency)
+	err := c.client.RefreshDomainCache(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientRefreshDomainCacheScope, metrics.CadenceFailures)
+	}
+
+	return err
+}
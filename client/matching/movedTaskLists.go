@@ -0,0 +1,115 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/uber/cadence/common/membership"
+)
+
+const (
+	// MovedTaskListsLabel is the membership label carrying the task lists force unloaded from a matching host,
+	// encoded as a JSON object of task list name to the unix nanos their move expires at
+	MovedTaskListsLabel = "movedTaskLists"
+
+	// movedTaskListsRefreshInterval is how long the client reuses the moved task lists read from the labels
+	movedTaskListsRefreshInterval = time.Second
+	// movedTaskListMaxLookups bounds the lookups for a host which did not move a task list away
+	movedTaskListMaxLookups = 5
+)
+
+// EncodeMovedTaskLists encodes the task lists moved away from a matching host for its membership label
+func EncodeMovedTaskLists(moved map[string]time.Time) string {
+	if len(moved) == 0 {
+		return ""
+	}
+	expiries := make(map[string]int64, len(moved))
+	for name, expiry := range moved {
+		expiries[name] = expiry.UnixNano()
+	}
+	value, _ := json.Marshal(expiries)
+	return string(value)
+}
+
+// DecodeMovedTaskLists decodes the membership label of a matching host, the expired moves are dropped
+func DecodeMovedTaskLists(value string, now time.Time) map[string]bool {
+	moved := make(map[string]bool)
+	if value == "" {
+		return moved
+	}
+	var expiries map[string]int64
+	if err := json.Unmarshal([]byte(value), &expiries); err != nil {
+		return moved
+	}
+	for name, expiry := range expiries {
+		if expiry > now.UnixNano() {
+			moved[name] = true
+		}
+	}
+	return moved
+}
+
+// getMovedTaskListHost returns the host serving a task list, which is its owner on the ring unless the owner
+// moved the task list away.  The other hosts are found by looking up derived keys, so all clients agree on them.
+func (c *clientImpl) getMovedTaskListHost(taskListName string, owner *membership.HostInfo) *membership.HostInfo {
+	moved := c.getMovedTaskLists()
+	if !moved[owner.GetAddress()][taskListName] {
+		return owner
+	}
+	for i := 1; i <= movedTaskListMaxLookups; i++ {
+		host, err := c.resolver.Lookup(fmt.Sprintf("%v/%v", taskListName, i))
+		if err != nil {
+			break
+		}
+		if !moved[host.GetAddress()][taskListName] {
+			return host
+		}
+	}
+	return owner
+}
+
+// getMovedTaskLists returns the task lists moved away by each matching host, read from their membership labels
+func (c *clientImpl) getMovedTaskLists() map[string]map[string]bool {
+	c.movedLock.Lock()
+	defer c.movedLock.Unlock()
+
+	now := time.Now()
+	if c.movedHosts != nil && now.Sub(c.movedRefreshed) < movedTaskListsRefreshInterval {
+		return c.movedHosts
+	}
+	members, err := c.resolver.Members()
+	if err != nil {
+		// keep routing with the last known moves until the members can be read again
+		return c.movedHosts
+	}
+	movedHosts := make(map[string]map[string]bool)
+	for _, member := range members {
+		if value, ok := member.Label(MovedTaskListsLabel); ok {
+			movedHosts[member.GetAddress()] = DecodeMovedTaskLists(value, now)
+		}
+	}
+	c.movedHosts = movedHosts
+	c.movedRefreshed = now
+	return movedHosts
+}
@@ -156,3 +156,107 @@ func (c *retryableClient) DescribeTaskList(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) GetTaskListsByDomain(
+	ctx context.Context,
+	request *m.GetTaskListsByDomainRequest,
+	opts ...yarpc.CallOption) (*workflow.GetTaskListsByDomainResponse, error) {
+
+	var resp *workflow.GetTaskListsByDomainResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetTaskListsByDomain(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) RecordWorkerHeartbeat(
+	ctx context.Context,
+	request *m.RecordWorkerHeartbeatRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.RecordWorkerHeartbeat(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) ListTaskListDLQTasks(
+	ctx context.Context,
+	request *m.ListTaskListDLQTasksRequest,
+	opts ...yarpc.CallOption) (*workflow.ListTaskListDLQTasksResponse, error) {
+
+	var resp *workflow.ListTaskListDLQTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListTaskListDLQTasks(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) RequeueTaskListDLQTasks(
+	ctx context.Context,
+	request *m.RequeueTaskListDLQTasksRequest,
+	opts ...yarpc.CallOption) (*workflow.RequeueTaskListDLQTasksResponse, error) {
+
+	var resp *workflow.RequeueTaskListDLQTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.RequeueTaskListDLQTasks(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeTaskListLatency(
+	ctx context.Context,
+	request *m.DescribeTaskListLatencyRequest,
+	opts ...yarpc.CallOption) (*workflow.DescribeTaskListLatencyResponse, error) {
+
+	var resp *workflow.DescribeTaskListLatencyResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeTaskListLatency(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ForceUnloadTaskList(
+	ctx context.Context,
+	request *m.ForceUnloadTaskListRequest,
+	opts ...yarpc.CallOption) (*workflow.ForceUnloadTaskListResponse, error) {
+
+	var resp *workflow.ForceUnloadTaskListResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ForceUnloadTaskList(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) RefreshDomainCache(
+	ctx context.Context,
+	request *workflow.RefreshDomainCacheRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.RefreshDomainCache(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/audit"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/service/config"
@@ -43,6 +44,8 @@ type (
 		cfg    *config.Config
 		doneC  chan struct{}
 		daemon common.Daemon
+		// auditSink is closed once the frontend stopped
+		auditSink audit.Sink
 	}
 )
 
@@ -88,6 +91,13 @@ func (s *server) Stop() {
 			log.Printf("timed out waiting for server %v to exit\n", s.name)
 		}
 	}
+
+	if s.auditSink != nil {
+		if err := s.auditSink.Close(); err != nil {
+			log.Printf("error closing audit sink of server %v: %v\n", s.name, err)
+		}
+		s.auditSink = nil
+	}
 }
 
 // startService starts a service with the given name and config
@@ -98,7 +108,13 @@ func (s *server) startService() common.Daemon {
 	params := service.BootstrapParams{}
 	params.Name = "cadence-" + s.name
 	params.Logger = s.cfg.Log.NewBarkLogger()
+	params.LogLevel = s.cfg.Log.Level
 	params.CassandraConfig = s.cfg.Cassandra
+	params.CassandraCredentials, err = s.cfg.Cassandra.Credentials.NewProvider(s.cfg.Cassandra.User,
+		s.cfg.Cassandra.Password)
+	if err != nil {
+		log.Fatalf("error creating cassandra credentials provider: %v", err)
+	}
 
 	params.RingpopFactory, err = s.cfg.Ringpop.NewFactory()
 	if err != nil {
@@ -119,6 +135,7 @@ func (s *server) startService() common.Daemon {
 		s.cfg.ClustersInfo.MasterClusterName,
 		s.cfg.ClustersInfo.CurrentClusterName,
 		s.cfg.ClustersInfo.ClusterInitialFailoverVersions,
+		s.cfg.ClustersInfo.ClusterAddress,
 	)
 	// TODO: We need to switch Cadence to use zap logger, until then just pass zap.NewNop
 	if params.ClusterMetadata.IsGlobalDomainEnabled() {
@@ -131,7 +148,15 @@ func (s *server) startService() common.Daemon {
 
 	switch s.name {
 	case frontendService:
-		daemon = frontend.NewService(&params)
+		var interceptors []frontend.Interceptor
+		s.auditSink, err = s.cfg.Audit.NewSink(&s.cfg.Kafka, params.Logger)
+		if err != nil {
+			log.Fatalf("error creating audit sink: %v", err)
+		}
+		if s.auditSink != nil {
+			interceptors = append(interceptors, frontend.NewAuditInterceptor(s.auditSink, params.Logger))
+		}
+		daemon = frontend.NewService(&params, interceptors...)
 	case historyService:
 		daemon = history.NewService(&params)
 	case matchingService:
@@ -0,0 +1,86 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	fileSinkSuite struct {
+		suite.Suite
+		dir string
+	}
+)
+
+func TestFileSinkSuite(t *testing.T) {
+	s := new(fileSinkSuite)
+	suite.Run(t, s)
+}
+
+func (s *fileSinkSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "audit")
+	s.NoError(err)
+	s.dir = dir
+}
+
+func (s *fileSinkSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *fileSinkSuite) TestAppend() {
+	path := filepath.Join(s.dir, "audit.log")
+
+	sink, err := NewFileSink(path)
+	s.NoError(err)
+	s.NoError(sink.Write(&Record{Operation: "TerminateWorkflowExecution", Domain: "d1"}))
+	s.NoError(sink.Close())
+
+	// reopening the file keeps the existing records
+	sink, err = NewFileSink(path)
+	s.NoError(err)
+	s.NoError(sink.Write(&Record{Operation: "UpdateDomain", Domain: "d2", Error: "failed"}))
+	s.NoError(sink.Close())
+
+	file, err := os.Open(path)
+	s.NoError(err)
+	defer file.Close()
+
+	var records []*Record
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := &Record{}
+		s.NoError(json.Unmarshal(scanner.Bytes(), record))
+		records = append(records, record)
+	}
+	s.Len(records, 2)
+	s.Equal("TerminateWorkflowExecution", records[0].Operation)
+	s.Equal("d1", records[0].Domain)
+	s.Equal("UpdateDomain", records[1].Operation)
+	s.Equal("failed", records[1].Error)
+}
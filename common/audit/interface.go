@@ -0,0 +1,50 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"time"
+)
+
+type (
+	// Record is an entry of the audit log, describing an administrative or mutating operation
+	Record struct {
+		Timestamp  time.Time `json:"timestamp"`
+		Service    string    `json:"service"`
+		Operation  string    `json:"operation"`
+		Domain     string    `json:"domain,omitempty"`
+		WorkflowID string    `json:"workflowId,omitempty"`
+		RunID      string    `json:"runId,omitempty"`
+		// Caller is the name of the service which issued the call, as reported by the transport
+		Caller string `json:"caller,omitempty"`
+		// Identity is the identity the caller claims on the request
+		Identity string `json:"identity,omitempty"`
+		// Request is the request of the operation, with its payloads redacted by RedactRequest
+		Request interface{} `json:"request,omitempty"`
+		Error   string      `json:"error,omitempty"`
+	}
+
+	// Sink is an append only store of audit records
+	Sink interface {
+		Write(record *Record) error
+		Close() error
+	}
+)
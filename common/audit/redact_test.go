@@ -0,0 +1,86 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+type (
+	redactSuite struct {
+		suite.Suite
+	}
+)
+
+func TestRedactSuite(t *testing.T) {
+	s := new(redactSuite)
+	suite.Run(t, s)
+}
+
+func (s *redactSuite) TestPayloadsRedacted() {
+	request := &shared.SignalWorkflowExecutionRequest{
+		Domain: common.StringPtr("domain"),
+		WorkflowExecution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr("wid"),
+		},
+		SignalName: common.StringPtr("signal"),
+		Input:      []byte("secret payload"),
+		Identity:   common.StringPtr("operator"),
+	}
+
+	redacted := RedactRequest(request).(map[string]interface{})
+	s.Equal("domain", redacted["domain"])
+	s.Equal(map[string]interface{}{"workflowId": "wid"}, redacted["workflowExecution"])
+	s.Equal("signal", redacted["signalName"])
+	s.Equal("<redacted 14 bytes>", redacted["input"])
+	s.Equal("operator", redacted["identity"])
+	// unset fields are left out
+	s.NotContains(redacted, "control")
+
+	payload, err := json.Marshal(&Record{Request: redacted})
+	s.NoError(err)
+	s.NotContains(string(payload), "secret")
+}
+
+func (s *redactSuite) TestLongStringsTruncated() {
+	request := &shared.UpdateDomainRequest{
+		Name: common.StringPtr("domain"),
+		UpdatedInfo: &shared.UpdateDomainInfo{
+			Description: common.StringPtr(strings.Repeat("a", maxStringFieldLength+10)),
+			Data:        map[string]string{"key": strings.Repeat("b", maxStringFieldLength+1)},
+		},
+	}
+
+	redacted := RedactRequest(request).(map[string]interface{})
+	info := redacted["updatedInfo"].(map[string]interface{})
+	s.Equal(strings.Repeat("a", maxStringFieldLength)+"<truncated 10 bytes>", info["description"])
+	s.Equal(map[string]interface{}{"key": strings.Repeat("b", maxStringFieldLength) + "<truncated 1 bytes>"}, info["data"])
+}
+
+func (s *redactSuite) TestNilRequest() {
+	s.Nil(RedactRequest(nil))
+}
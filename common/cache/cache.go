@@ -22,6 +22,8 @@ package cache
 
 import (
 	"time"
+
+	"github.com/uber/cadence/common"
 )
 
 // A Cache is a generalized interface to a cache.  See cache.LRU for a specific
@@ -40,10 +42,19 @@ type Cache interface {
 	// Delete deletes an element in the cache
 	Delete(key interface{})
 
+	// Pin increments the ref count of an element without accessing it, returning
+	// false if the element does not exist. Every Pin needs a matching Release.
+	Pin(key interface{}) bool
+
 	// Release decrements the ref count of a pinned element. If the ref count
 	// drops to 0, the element can be evicted from the cache.
 	Release(key interface{})
 
+	// EvictExpired removes the elements that have outlived their TTL or IdleTTL,
+	// returning how many were removed. Expired elements are otherwise only removed
+	// when they are accessed.
+	EvictExpired() int
+
 	// Iterator returns the iterator of the cache
 	Iterator() Iterator
 
@@ -57,6 +68,11 @@ type Options struct {
 	// are older than the TTL will not be returned
 	TTL time.Duration
 
+	// IdleTTL controls how long a cache entry is kept after it was last used,
+	// which is when it was last accessed or, in Pin mode, released. Entries
+	// that are in use never expire.
+	IdleTTL time.Duration
+
 	// InitialCapacity controls the initial capacity of the cache
 	InitialCapacity int
 
@@ -66,6 +82,10 @@ type Options struct {
 	// RemovedFunc is an optional function called when an element
 	// is scheduled for deletion
 	RemovedFunc RemovedFunc
+
+	// TimeSource is the clock the TTL and IdleTTL are measured with, the real
+	// time if nil
+	TimeSource common.TimeSource
 }
 
 // RemovedFunc is a type for notifying applications when an item is
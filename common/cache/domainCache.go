@@ -45,6 +45,8 @@ const (
 	// DomainCacheRefreshInterval domain cache refresh interval
 	DomainCacheRefreshInterval = 10 * time.Second
 	domainCacheRefreshPageSize = 100
+	// domainCacheMinRefreshInterval is the minimal interval between refreshes triggered by domain updates
+	domainCacheMinRefreshInterval = time.Second
 
 	domainCacheLocked   int32 = 0
 	domainCacheReleased int32 = 1
@@ -76,11 +78,13 @@ type (
 		GetDomainNotificationVersion() int64
 		GetAllDomain() map[string]*DomainCacheEntry
 		GetCacheSize() (sizeOfCacheByName int64, sizeOfCacheByID int64)
+		TriggerRefresh()
 	}
 
 	domainCache struct {
 		status          int32
 		shutdownChan    chan struct{}
+		refreshChan     chan struct{}
 		cacheNameToID   Cache
 		cacheByID       Cache
 		metadataMgr     persistence.MetadataManager
@@ -123,6 +127,7 @@ func NewDomainCache(metadataMgr persistence.MetadataManager, clusterMetadata clu
 	return &domainCache{
 		status:          domainCacheInitialized,
 		shutdownChan:    make(chan struct{}),
+		refreshChan:     make(chan struct{}, 1),
 		cacheNameToID:   New(domainCacheMaxSize, opts),
 		cacheByID:       New(domainCacheMaxSize, opts),
 		metadataMgr:     metadataMgr,
@@ -162,6 +167,15 @@ func (c *domainCache) Stop() {
 	close(c.shutdownChan)
 }
 
+// TriggerRefresh asks for the domains to be refreshed right away instead of on the next periodic refresh, it is used
+// when a domain is known to have changed.  Triggers received while a refresh is pending are coalesced.
+func (c *domainCache) TriggerRefresh() {
+	select {
+	case c.refreshChan <- struct{}{}:
+	default:
+	}
+}
+
 func (c *domainCache) GetDomainNotificationVersion() int64 {
 	c.RLock()
 	defer c.RUnlock()
@@ -254,6 +268,7 @@ func (c *domainCache) GetDomainID(name string) (string, error) {
 func (c *domainCache) refreshLoop() {
 	timer := time.NewTimer(DomainCacheRefreshInterval)
 	defer timer.Stop()
+	lastRefreshTime := c.timeSource.Now()
 	for {
 		select {
 		case <-c.shutdownChan:
@@ -264,6 +279,22 @@ func (c *domainCache) refreshLoop() {
 			if err != nil {
 				c.logger.Errorf("Error refreshing domain cache: %v", err)
 			}
+			lastRefreshTime = c.timeSource.Now()
+		case <-c.refreshChan:
+			// a burst of domain updates should not turn into a burst of refreshes
+			if wait := lastRefreshTime.Add(domainCacheMinRefreshInterval).Sub(c.timeSource.Now()); wait > 0 {
+				select {
+				case <-c.shutdownChan:
+					return
+				case <-time.After(wait):
+				}
+			}
+			c.metricsClient.IncCounter(metrics.DomainCacheScope, metrics.DomainCacheRefreshTriggeredCounter)
+			err := c.refreshDomains()
+			if err != nil {
+				c.logger.Errorf("Error refreshing domain cache: %v", err)
+			}
+			lastRefreshTime = c.timeSource.Now()
 		}
 	}
 }
@@ -505,7 +536,8 @@ func (entry *DomainCacheEntry) duplicate() *DomainCacheEntry {
 	result.info = &*entry.info
 	result.config = &*entry.config
 	result.replicationConfig = &persistence.DomainReplicationConfig{
-		ActiveClusterName: entry.replicationConfig.ActiveClusterName,
+		ActiveClusterName:        entry.replicationConfig.ActiveClusterName,
+		FailoverDrillClusterName: entry.replicationConfig.FailoverDrillClusterName,
 	}
 	for _, cluster := range entry.replicationConfig.Clusters {
 		result.replicationConfig.Clusters = append(result.replicationConfig.Clusters, &*cluster)
@@ -532,6 +564,28 @@ func (entry *DomainCacheEntry) GetConfig() *persistence.DomainConfig {
 	return entry.config
 }
 
+// GetHistoryEncoding return the encoding new history batches of the domain should be written in
+func (entry *DomainCacheEntry) GetHistoryEncoding() common.EncodingType {
+	if entry.config == nil || len(entry.config.HistoryEncoding) == 0 {
+		return persistence.DefaultEncodingType
+	}
+	return entry.config.HistoryEncoding
+}
+
+// IsBinaryChecksumSuspect returns whether workers of the binary are suspected of failing decisions of the domain
+// with non-determinism errors
+func (entry *DomainCacheEntry) IsBinaryChecksumSuspect(binaryChecksum string) bool {
+	if entry.config == nil || len(binaryChecksum) == 0 {
+		return false
+	}
+	for _, suspect := range entry.config.SuspectBinaryChecksums {
+		if suspect == binaryChecksum {
+			return true
+		}
+	}
+	return false
+}
+
 // GetReplicationConfig return the domain replication config
 func (entry *DomainCacheEntry) GetReplicationConfig() *persistence.DomainReplicationConfig {
 	return entry.replicationConfig
@@ -571,6 +625,12 @@ func (entry *DomainCacheEntry) IsDomainActive() bool {
 	return entry.clusterMetadata.GetCurrentClusterName() == entry.replicationConfig.ActiveClusterName
 }
 
+// IsFailoverDrillTarget return whether a failover drill of the domain is verifying the current cluster could take it over
+func (entry *DomainCacheEntry) IsFailoverDrillTarget() bool {
+	return entry.isGlobalDomain &&
+		entry.replicationConfig.FailoverDrillClusterName == entry.clusterMetadata.GetCurrentClusterName()
+}
+
 // CanReplicateEvent return whether the workflows within this domain should be replicated
 func (entry *DomainCacheEntry) CanReplicateEvent() bool {
 	// frontend guarantee that the clusters always contains the active domain, so if the # of clusters is 1
@@ -160,6 +160,11 @@ func (_m *DomainCacheMock) Stop() {
 	_m.Called()
 }
 
+// TriggerRefresh provides a mock function with given fields:
+func (_m *DomainCacheMock) TriggerRefresh() {
+	_m.Called()
+}
+
 // UnregisterDomainChangeCallback provides a mock function with given fields: shard
 func (_m *DomainCacheMock) UnregisterDomainChangeCallback(shard int) {
 	_m.Called(shard)
@@ -433,6 +433,13 @@ func (s *domainCacheSuite) TestUpdateCache_ListTrigger() {
 	s.Equal([]*DomainCacheEntry{entry2New, entry1New}, entriesNewAfter)
 }
 
+func (s *domainCacheSuite) TestTriggerRefresh_Coalesced() {
+	s.domainCache.TriggerRefresh()
+	s.domainCache.TriggerRefresh()
+	s.domainCache.TriggerRefresh()
+	s.Equal(1, len(s.domainCache.refreshChan))
+}
+
 func (s *domainCacheSuite) TestUpdateCache_GetNotTrigger() {
 	s.clusterMetadata.On("IsGlobalDomainEnabled").Return(true)
 	domainRecordOld := &persistence.GetDomainResponse{
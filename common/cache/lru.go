@@ -25,6 +25,8 @@ import (
 	"errors"
 	"sync"
 	"time"
+
+	"github.com/uber/cadence/common"
 )
 
 var (
@@ -35,13 +37,15 @@ var (
 // lru is a concurrent fixed size cache that evicts elements in lru order
 type (
 	lru struct {
-		mut      sync.Mutex
-		byAccess *list.List
-		byKey    map[interface{}]*list.Element
-		maxSize  int
-		ttl      time.Duration
-		pin      bool
-		rmFunc   RemovedFunc
+		mut        sync.Mutex
+		byAccess   *list.List
+		byKey      map[interface{}]*list.Element
+		maxSize    int
+		ttl        time.Duration
+		idleTTL    time.Duration
+		pin        bool
+		rmFunc     RemovedFunc
+		timeSource common.TimeSource
 	}
 
 	iteratorImpl struct {
@@ -55,6 +59,8 @@ type (
 		createTime time.Time
 		value      interface{}
 		refCount   int
+		// lastUsedTime is only maintained when the cache has an idle TTL
+		lastUsedTime time.Time
 	}
 )
 
@@ -106,7 +112,7 @@ func (c *lru) Iterator() Iterator {
 	c.mut.Lock()
 	iterator := &iteratorImpl{
 		lru:        c,
-		createTime: time.Now(),
+		createTime: c.timeSource.Now(),
 		nextItem:   c.byAccess.Front(),
 	}
 	iterator.prepareNext()
@@ -130,14 +136,20 @@ func New(maxSize int, opts *Options) Cache {
 	if opts == nil {
 		opts = &Options{}
 	}
+	timeSource := opts.TimeSource
+	if timeSource == nil {
+		timeSource = common.NewRealTimeSource()
+	}
 
 	return &lru{
-		byAccess: list.New(),
-		byKey:    make(map[interface{}]*list.Element, opts.InitialCapacity),
-		ttl:      opts.TTL,
-		maxSize:  maxSize,
-		pin:      opts.Pin,
-		rmFunc:   opts.RemovedFunc,
+		byAccess:   list.New(),
+		byKey:      make(map[interface{}]*list.Element, opts.InitialCapacity),
+		ttl:        opts.TTL,
+		idleTTL:    opts.IdleTTL,
+		maxSize:    maxSize,
+		pin:        opts.Pin,
+		rmFunc:     opts.RemovedFunc,
+		timeSource: timeSource,
 	}
 }
 
@@ -167,7 +179,7 @@ func (c *lru) Get(key interface{}) interface{} {
 
 	entry := element.Value.(*entryImpl)
 
-	if c.isEntryExpired(entry, time.Now()) {
+	if c.isEntryExpired(entry, c.timeSource.Now()) {
 		// Entry has expired
 		c.deleteInternal(element)
 		return nil
@@ -176,6 +188,7 @@ func (c *lru) Get(key interface{}) interface{} {
 	if c.pin {
 		entry.refCount++
 	}
+	c.markUsed(entry)
 	c.byAccess.MoveToFront(element)
 	return entry.value
 }
@@ -215,6 +228,25 @@ func (c *lru) Delete(key interface{}) {
 	}
 }
 
+// Pin increments the ref count of an element without moving it in the lru order
+func (c *lru) Pin(key interface{}) bool {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	element := c.byKey[key]
+	if element == nil {
+		return false
+	}
+
+	entry := element.Value.(*entryImpl)
+	if c.isEntryExpired(entry, c.timeSource.Now()) {
+		c.deleteInternal(element)
+		return false
+	}
+	entry.refCount++
+	return true
+}
+
 // Release decrements the ref count of a pinned element.
 func (c *lru) Release(key interface{}) {
 	c.mut.Lock()
@@ -223,6 +255,25 @@ func (c *lru) Release(key interface{}) {
 	elt := c.byKey[key]
 	entry := elt.Value.(*entryImpl)
 	entry.refCount--
+	c.markUsed(entry)
+}
+
+// EvictExpired removes all the expired elements
+func (c *lru) EvictExpired() int {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	evicted := 0
+	now := c.timeSource.Now()
+	for element := c.byAccess.Back(); element != nil; {
+		prev := element.Prev()
+		if c.isEntryExpired(element.Value.(*entryImpl), now) {
+			c.deleteInternal(element)
+			evicted++
+		}
+		element = prev
+	}
+	return evicted
 }
 
 // Size returns the number of entries currently in the lru, useful if cache is not full
@@ -242,7 +293,7 @@ func (c *lru) putInternal(key interface{}, value interface{}, allowUpdate bool)
 	elt := c.byKey[key]
 	if elt != nil {
 		entry := elt.Value.(*entryImpl)
-		if c.isEntryExpired(entry, time.Now()) {
+		if c.isEntryExpired(entry, c.timeSource.Now()) {
 			// Entry has expired
 			c.deleteInternal(elt)
 		} else {
@@ -250,7 +301,7 @@ func (c *lru) putInternal(key interface{}, value interface{}, allowUpdate bool)
 			if allowUpdate {
 				entry.value = value
 				if c.ttl != 0 {
-					entry.createTime = time.Now()
+					entry.createTime = c.timeSource.Now()
 				}
 			}
 
@@ -258,6 +309,7 @@ func (c *lru) putInternal(key interface{}, value interface{}, allowUpdate bool)
 			if c.pin {
 				entry.refCount++
 			}
+			c.markUsed(entry)
 			return existing, nil
 		}
 	}
@@ -272,21 +324,27 @@ func (c *lru) putInternal(key interface{}, value interface{}, allowUpdate bool)
 	}
 
 	if c.ttl != 0 {
-		entry.createTime = time.Now()
+		entry.createTime = c.timeSource.Now()
 	}
+	c.markUsed(entry)
 
 	c.byKey[key] = c.byAccess.PushFront(entry)
 	if len(c.byKey) == c.maxSize {
-		oldest := c.byAccess.Back().Value.(*entryImpl)
+		// evict the least recently used element that is not pinned, pinned elements
+		// can stay in use for long and must not block the rest of the cache
+		oldest := c.byAccess.Back()
+		for oldest != c.byAccess.Front() && oldest.Value.(*entryImpl).refCount > 0 {
+			oldest = oldest.Prev()
+		}
 
-		if oldest.refCount > 0 {
+		if oldest == c.byAccess.Front() {
 			// Cache is full with pinned elements
 			// revert the insert and return
 			c.deleteInternal(c.byAccess.Front())
 			return nil, ErrCacheFull
 		}
 
-		c.deleteInternal(c.byAccess.Back())
+		c.deleteInternal(oldest)
 	}
 
 	return nil, nil
@@ -300,6 +358,18 @@ func (c *lru) deleteInternal(element *list.Element) {
 	delete(c.byKey, entry.key)
 }
 
+func (c *lru) markUsed(entry *entryImpl) {
+	if c.idleTTL != 0 {
+		entry.lastUsedTime = c.timeSource.Now()
+	}
+}
+
 func (c *lru) isEntryExpired(entry *entryImpl, currentTime time.Time) bool {
-	return entry.refCount == 0 && !entry.createTime.IsZero() && currentTime.After(entry.createTime.Add(c.ttl))
+	if entry.refCount > 0 {
+		return false
+	}
+	if !entry.createTime.IsZero() && currentTime.After(entry.createTime.Add(c.ttl)) {
+		return true
+	}
+	return !entry.lastUsedTime.IsZero() && currentTime.After(entry.lastUsedTime.Add(c.idleTTL))
 }
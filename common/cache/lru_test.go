@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/uber/cadence/common"
 )
 
 type keyType struct {
@@ -263,3 +264,68 @@ func TestIterator(t *testing.T) {
 	it.Close()
 	assert.Equal(t, expected, actual)
 }
+
+func TestLRUWithIdleTTL(t *testing.T) {
+	timeSource := common.NewEventTimeSource().Update(time.Now())
+	cache := New(5, &Options{
+		IdleTTL:    time.Millisecond * 100,
+		TimeSource: timeSource,
+	})
+	cache.Put("A", "foo")
+	cache.Put("B", "bar")
+	for i := 0; i < 3; i++ {
+		timeSource.Update(timeSource.Now().Add(time.Millisecond * 50))
+		// accessing A keeps it from going idle
+		assert.Equal(t, "foo", cache.Get("A"))
+	}
+	assert.Equal(t, 1, cache.EvictExpired())
+	assert.Equal(t, 1, cache.Size())
+	assert.Nil(t, cache.Get("B"))
+
+	timeSource.Update(timeSource.Now().Add(time.Millisecond * 150))
+	assert.Equal(t, 1, cache.EvictExpired())
+	assert.Equal(t, 0, cache.Size())
+}
+
+func TestLRUWithIdleTTL_Pin(t *testing.T) {
+	timeSource := common.NewEventTimeSource().Update(time.Now())
+	cache := New(5, &Options{
+		IdleTTL:    time.Millisecond * 50,
+		Pin:        true,
+		TimeSource: timeSource,
+	})
+	cache.PutIfNotExist("A", "foo")
+	assert.True(t, cache.Pin("A"))
+	assert.False(t, cache.Pin("B"))
+	cache.Release("A")
+	timeSource.Update(timeSource.Now().Add(time.Millisecond * 100))
+	// still pinned
+	assert.Equal(t, 0, cache.EvictExpired())
+
+	cache.Release("A")
+	assert.Equal(t, 0, cache.EvictExpired())
+	timeSource.Update(timeSource.Now().Add(time.Millisecond * 100))
+	assert.Equal(t, 1, cache.EvictExpired())
+	assert.False(t, cache.Pin("A"))
+}
+
+func TestLRUEvictsOldestUnpinned(t *testing.T) {
+	cache := New(3, &Options{Pin: true})
+	cache.PutIfNotExist("A", "foo")
+	cache.PutIfNotExist("B", "bar")
+	cache.Release("B")
+
+	// A is the least recently used but pinned, B goes instead
+	_, err := cache.PutIfNotExist("C", "baz")
+	assert.NoError(t, err)
+	cache.Release("C")
+	assert.Equal(t, 2, cache.Size())
+	assert.Equal(t, "foo", cache.Get("A"))
+	cache.Release("A")
+	assert.Nil(t, cache.Get("B"))
+
+	assert.True(t, cache.Pin("A"))
+	assert.True(t, cache.Pin("C"))
+	_, err = cache.PutIfNotExist("D", "qux")
+	assert.Equal(t, ErrCacheFull, err)
+}
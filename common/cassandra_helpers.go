@@ -26,6 +26,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/uber/cadence/common/credentials"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/tools/cassandra"
 
@@ -33,8 +34,13 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+type cassandraAuthenticator struct {
+	credentialsProvider credentials.Provider
+}
+
 // NewCassandraCluster creates a cassandra cluster given comma separated list of clusterHosts
-func NewCassandraCluster(clusterHosts string, port int, user, password, dc string) *gocql.ClusterConfig {
+func NewCassandraCluster(clusterHosts string, port int, credentialsProvider credentials.Provider,
+	dc string) *gocql.ClusterConfig {
 	var hosts []string
 	for _, h := range strings.Split(clusterHosts, ",") {
 		if host := strings.TrimSpace(h); len(host) > 0 {
@@ -47,11 +53,8 @@ func NewCassandraCluster(clusterHosts string, port int, user, password, dc strin
 	if port > 0 {
 		cluster.Port = port
 	}
-	if user != "" && password != "" {
-		cluster.Authenticator = gocql.PasswordAuthenticator{
-			Username: user,
-			Password: password,
-		}
+	if credentialsProvider != nil {
+		cluster.Authenticator = &cassandraAuthenticator{credentialsProvider: credentialsProvider}
 	}
 	if dc != "" {
 		cluster.HostFilter = gocql.DataCentreHostFilter(dc)
@@ -59,6 +62,24 @@ func NewCassandraCluster(clusterHosts string, port int, user, password, dc strin
 	return cluster
 }
 
+// Challenge authenticates a new connection with the current credentials of the provider, so rotated credentials
+// are picked up by the connections opened after the rotation
+func (a *cassandraAuthenticator) Challenge(req []byte) ([]byte, gocql.Authenticator, error) {
+	creds, err := a.credentialsProvider.GetCredentials()
+	if err != nil {
+		return nil, nil, err
+	}
+	return gocql.PasswordAuthenticator{
+		Username: creds.User,
+		Password: creds.Password,
+	}.Challenge(req)
+}
+
+// Success is called once the connection is authenticated
+func (a *cassandraAuthenticator) Success(data []byte) error {
+	return nil
+}
+
 // CreateCassandraKeyspace creates the keyspace using this session for given replica count
 func CreateCassandraKeyspace(s *gocql.Session, keyspace string, replicas int, overwrite bool) (err error) {
 	// if overwrite flag is set, drop the keyspace and create a new one
@@ -23,6 +23,7 @@ package cluster
 import (
 	"fmt"
 
+	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
@@ -46,6 +47,8 @@ type (
 		GetAllClusterFailoverVersions() map[string]int64
 		// ClusterNameForFailoverVersion return the corresponding cluster name for a given failover version
 		ClusterNameForFailoverVersion(failoverVersion int64) string
+		// GetAllClientAddress return the frontend address for each cluster name
+		GetAllClientAddress() map[string]config.Address
 	}
 
 	metadataImpl struct {
@@ -63,12 +66,15 @@ type (
 		clusterInitialFailoverVersions map[string]int64
 		// clusterInitialFailoverVersions contains all initial failover version -> corresponding cluster name
 		initialFailoverVersionClusters map[int64]string
+		// clusterAddress contains all cluster name -> corresponding frontend address
+		clusterAddress map[string]config.Address
 	}
 )
 
 // NewMetadata create a new instance of Metadata
 func NewMetadata(enableGlobalDomain dynamicconfig.BoolPropertyFn, failoverVersionIncrement int64,
-	masterClusterName string, currentClusterName string, clusterInitialFailoverVersions map[string]int64) Metadata {
+	masterClusterName string, currentClusterName string, clusterInitialFailoverVersions map[string]int64,
+	clusterAddress map[string]config.Address) Metadata {
 
 	if len(clusterInitialFailoverVersions) < 0 {
 		panic("Empty initial failover versions for cluster")
@@ -101,6 +107,11 @@ func NewMetadata(enableGlobalDomain dynamicconfig.BoolPropertyFn, failoverVersio
 	if len(initialFailoverVersionClusters) != len(clusterInitialFailoverVersions) {
 		panic("Cluster to initial failover versions have duplicate initial versions")
 	}
+	for clusterName := range clusterAddress {
+		if _, ok := clusterInitialFailoverVersions[clusterName]; !ok {
+			panic(fmt.Sprintf("Cluster %v in cluster address is not specified in all cluster names", clusterName))
+		}
+	}
 
 	return &metadataImpl{
 		enableGlobalDomain:             enableGlobalDomain,
@@ -109,6 +120,7 @@ func NewMetadata(enableGlobalDomain dynamicconfig.BoolPropertyFn, failoverVersio
 		currentClusterName:             currentClusterName,
 		clusterInitialFailoverVersions: clusterInitialFailoverVersions,
 		initialFailoverVersionClusters: initialFailoverVersionClusters,
+		clusterAddress:                 clusterAddress,
 	}
 }
 
@@ -173,3 +185,8 @@ func (metadata *metadataImpl) ClusterNameForFailoverVersion(failoverVersion int6
 	}
 	return clusterName
 }
+
+// GetAllClientAddress return the frontend address for each cluster name
+func (metadata *metadataImpl) GetAllClientAddress() map[string]config.Address {
+	return metadata.clusterAddress
+}
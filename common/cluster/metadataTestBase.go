@@ -20,7 +20,10 @@
 
 package cluster
 
-import "github.com/uber/cadence/common/service/dynamicconfig"
+import (
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
 
 const (
 	// TestCurrentClusterInitialFailoverVersion is initial failover version for current cluster
@@ -33,6 +36,10 @@ const (
 	TestCurrentClusterName = "active"
 	// TestAlternativeClusterName is alternative cluster used for test
 	TestAlternativeClusterName = "standby"
+	// TestCurrentClusterFrontendAddress is the frontend address used for current cluster in test
+	TestCurrentClusterFrontendAddress = "127.0.0.1:7104"
+	// TestAlternativeClusterFrontendAddress is the frontend address used for alternative cluster in test
+	TestAlternativeClusterFrontendAddress = "127.0.0.1:8104"
 )
 
 var (
@@ -43,6 +50,11 @@ var (
 		TestCurrentClusterName:     TestCurrentClusterInitialFailoverVersion,
 		TestAlternativeClusterName: TestAlternativeClusterInitialFailoverVersion,
 	}
+	// TestAllClusterAddress is the frontend address of all clusters used for test
+	TestAllClusterAddress = map[string]config.Address{
+		TestCurrentClusterName:     {RPCName: "cadence-frontend", RPCAddress: TestCurrentClusterFrontendAddress},
+		TestAlternativeClusterName: {RPCName: "cadence-frontend", RPCAddress: TestAlternativeClusterFrontendAddress},
+	}
 )
 
 // GetTestClusterMetadata return an cluster metadata instance, which is initialized
@@ -57,5 +69,6 @@ func GetTestClusterMetadata(enableGlobalDomain bool, isMasterCluster bool) Metad
 		masterClusterName,
 		TestCurrentClusterName,
 		TestAllClusterFailoverVersions,
+		TestAllClusterAddress,
 	)
 }
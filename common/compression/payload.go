@@ -0,0 +1,227 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+)
+
+// EncodingGzip is the payload encoding of gzip compressed payloads
+const EncodingGzip = "gzip"
+
+// Compress returns the gzip compressed payload
+func Compress(payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress returns the payload compressed by Compress
+func Decompress(payload []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// CompressHistory returns a copy of the history with the payloads of its events compressed. The history itself
+// is left untouched, it may be shared with a cache.
+func CompressHistory(history *gen.History) (*gen.History, error) {
+	return mapHistoryPayloads(history, Compress)
+}
+
+// DecompressHistory returns a copy of the history with the payloads of its events decompressed
+func DecompressHistory(history *gen.History) (*gen.History, error) {
+	return mapHistoryPayloads(history, Decompress)
+}
+
+// HistoryPayloadSize returns the total size in bytes of the payloads of the history events
+func HistoryPayloadSize(history *gen.History) int {
+	size := 0
+	if history == nil {
+		return size
+	}
+	for _, event := range history.Events {
+		MapEventPayloads(event, func(payload []byte) ([]byte, error) {
+			size += len(payload)
+			return payload, nil
+		})
+	}
+	return size
+}
+
+func mapHistoryPayloads(history *gen.History, fn func([]byte) ([]byte, error)) (*gen.History, error) {
+	if history == nil {
+		return nil, nil
+	}
+	result := &gen.History{Events: make([]*gen.HistoryEvent, 0, len(history.Events))}
+	for _, event := range history.Events {
+		mapped, err := MapEventPayloads(event, fn)
+		if err != nil {
+			return nil, err
+		}
+		result.Events = append(result.Events, mapped)
+	}
+	return result, nil
+}
+
+// MapEventPayloads returns a copy of the event with fn applied to each of its non empty payloads
+func MapEventPayloads(event *gen.HistoryEvent, fn func([]byte) ([]byte, error)) (*gen.HistoryEvent, error) {
+	var err error
+	apply := func(payload []byte) []byte {
+		if err != nil || len(payload) == 0 {
+			return payload
+		}
+		var mapped []byte
+		mapped, err = fn(payload)
+		return mapped
+	}
+
+	result := *event
+	switch {
+	case event.WorkflowExecutionStartedEventAttributes != nil:
+		attributes := *event.WorkflowExecutionStartedEventAttributes
+		attributes.Input = apply(attributes.Input)
+		result.WorkflowExecutionStartedEventAttributes = &attributes
+	case event.WorkflowExecutionCompletedEventAttributes != nil:
+		attributes := *event.WorkflowExecutionCompletedEventAttributes
+		attributes.Result = apply(attributes.Result)
+		result.WorkflowExecutionCompletedEventAttributes = &attributes
+	case event.WorkflowExecutionFailedEventAttributes != nil:
+		attributes := *event.WorkflowExecutionFailedEventAttributes
+		attributes.Details = apply(attributes.Details)
+		result.WorkflowExecutionFailedEventAttributes = &attributes
+	case event.WorkflowExecutionContinuedAsNewEventAttributes != nil:
+		attributes := *event.WorkflowExecutionContinuedAsNewEventAttributes
+		attributes.Input = apply(attributes.Input)
+		result.WorkflowExecutionContinuedAsNewEventAttributes = &attributes
+	case event.WorkflowExecutionCanceledEventAttributes != nil:
+		attributes := *event.WorkflowExecutionCanceledEventAttributes
+		attributes.Details = apply(attributes.Details)
+		result.WorkflowExecutionCanceledEventAttributes = &attributes
+	case event.WorkflowExecutionTerminatedEventAttributes != nil:
+		attributes := *event.WorkflowExecutionTerminatedEventAttributes
+		attributes.Details = apply(attributes.Details)
+		result.WorkflowExecutionTerminatedEventAttributes = &attributes
+	case event.WorkflowExecutionSignaledEventAttributes != nil:
+		attributes := *event.WorkflowExecutionSignaledEventAttributes
+		attributes.Input = apply(attributes.Input)
+		result.WorkflowExecutionSignaledEventAttributes = &attributes
+	case event.WorkflowExecutionUpdateRequestedEventAttributes != nil:
+		attributes := *event.WorkflowExecutionUpdateRequestedEventAttributes
+		attributes.Input = apply(attributes.Input)
+		result.WorkflowExecutionUpdateRequestedEventAttributes = &attributes
+	case event.WorkflowExecutionUpdateCompletedEventAttributes != nil:
+		attributes := *event.WorkflowExecutionUpdateCompletedEventAttributes
+		attributes.Result = apply(attributes.Result)
+		result.WorkflowExecutionUpdateCompletedEventAttributes = &attributes
+	case event.DecisionTaskCompletedEventAttributes != nil:
+		attributes := *event.DecisionTaskCompletedEventAttributes
+		attributes.ExecutionContext = apply(attributes.ExecutionContext)
+		result.DecisionTaskCompletedEventAttributes = &attributes
+	case event.DecisionTaskFailedEventAttributes != nil:
+		attributes := *event.DecisionTaskFailedEventAttributes
+		attributes.Details = apply(attributes.Details)
+		result.DecisionTaskFailedEventAttributes = &attributes
+	case event.ActivityTaskScheduledEventAttributes != nil:
+		attributes := *event.ActivityTaskScheduledEventAttributes
+		attributes.Input = apply(attributes.Input)
+		result.ActivityTaskScheduledEventAttributes = &attributes
+	case event.ActivityTaskCompletedEventAttributes != nil:
+		attributes := *event.ActivityTaskCompletedEventAttributes
+		attributes.Result = apply(attributes.Result)
+		result.ActivityTaskCompletedEventAttributes = &attributes
+	case event.ActivityTaskFailedEventAttributes != nil:
+		attributes := *event.ActivityTaskFailedEventAttributes
+		attributes.Details = apply(attributes.Details)
+		result.ActivityTaskFailedEventAttributes = &attributes
+	case event.ActivityTaskTimedOutEventAttributes != nil:
+		attributes := *event.ActivityTaskTimedOutEventAttributes
+		attributes.Details = apply(attributes.Details)
+		result.ActivityTaskTimedOutEventAttributes = &attributes
+	case event.ActivityTaskCanceledEventAttributes != nil:
+		attributes := *event.ActivityTaskCanceledEventAttributes
+		attributes.Details = apply(attributes.Details)
+		result.ActivityTaskCanceledEventAttributes = &attributes
+	case event.MarkerRecordedEventAttributes != nil:
+		attributes := *event.MarkerRecordedEventAttributes
+		attributes.Details = apply(attributes.Details)
+		result.MarkerRecordedEventAttributes = &attributes
+	case event.RequestCancelExternalWorkflowExecutionInitiatedEventAttributes != nil:
+		attributes := *event.RequestCancelExternalWorkflowExecutionInitiatedEventAttributes
+		attributes.Control = apply(attributes.Control)
+		result.RequestCancelExternalWorkflowExecutionInitiatedEventAttributes = &attributes
+	case event.RequestCancelExternalWorkflowExecutionFailedEventAttributes != nil:
+		attributes := *event.RequestCancelExternalWorkflowExecutionFailedEventAttributes
+		attributes.Control = apply(attributes.Control)
+		result.RequestCancelExternalWorkflowExecutionFailedEventAttributes = &attributes
+	case event.SignalExternalWorkflowExecutionInitiatedEventAttributes != nil:
+		attributes := *event.SignalExternalWorkflowExecutionInitiatedEventAttributes
+		attributes.Input = apply(attributes.Input)
+		attributes.Control = apply(attributes.Control)
+		result.SignalExternalWorkflowExecutionInitiatedEventAttributes = &attributes
+	case event.SignalExternalWorkflowExecutionFailedEventAttributes != nil:
+		attributes := *event.SignalExternalWorkflowExecutionFailedEventAttributes
+		attributes.Control = apply(attributes.Control)
+		result.SignalExternalWorkflowExecutionFailedEventAttributes = &attributes
+	case event.ExternalWorkflowExecutionSignaledEventAttributes != nil:
+		attributes := *event.ExternalWorkflowExecutionSignaledEventAttributes
+		attributes.Control = apply(attributes.Control)
+		result.ExternalWorkflowExecutionSignaledEventAttributes = &attributes
+	case event.StartChildWorkflowExecutionInitiatedEventAttributes != nil:
+		attributes := *event.StartChildWorkflowExecutionInitiatedEventAttributes
+		attributes.Input = apply(attributes.Input)
+		attributes.Control = apply(attributes.Control)
+		result.StartChildWorkflowExecutionInitiatedEventAttributes = &attributes
+	case event.StartChildWorkflowExecutionFailedEventAttributes != nil:
+		attributes := *event.StartChildWorkflowExecutionFailedEventAttributes
+		attributes.Control = apply(attributes.Control)
+		result.StartChildWorkflowExecutionFailedEventAttributes = &attributes
+	case event.ChildWorkflowExecutionCompletedEventAttributes != nil:
+		attributes := *event.ChildWorkflowExecutionCompletedEventAttributes
+		attributes.Result = apply(attributes.Result)
+		result.ChildWorkflowExecutionCompletedEventAttributes = &attributes
+	case event.ChildWorkflowExecutionFailedEventAttributes != nil:
+		attributes := *event.ChildWorkflowExecutionFailedEventAttributes
+		attributes.Details = apply(attributes.Details)
+		result.ChildWorkflowExecutionFailedEventAttributes = &attributes
+	case event.ChildWorkflowExecutionCanceledEventAttributes != nil:
+		attributes := *event.ChildWorkflowExecutionCanceledEventAttributes
+		attributes.Details = apply(attributes.Details)
+		result.ChildWorkflowExecutionCanceledEventAttributes = &attributes
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compression
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+func TestCompressRoundTrip(t *testing.T) {
+	payload := bytes.Repeat([]byte("payload"), 1000)
+	compressed, err := Compress(payload)
+	assert.NoError(t, err)
+	assert.True(t, len(compressed) < len(payload))
+
+	decompressed, err := Decompress(compressed)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, decompressed)
+
+	_, err = Decompress(payload)
+	assert.Error(t, err)
+}
+
+func TestCompressHistory(t *testing.T) {
+	input := bytes.Repeat([]byte("input"), 100)
+	result := bytes.Repeat([]byte("result"), 100)
+	history := &gen.History{Events: []*gen.HistoryEvent{
+		{
+			EventId:   common.Int64Ptr(1),
+			EventType: gen.EventTypeWorkflowExecutionStarted.Ptr(),
+			WorkflowExecutionStartedEventAttributes: &gen.WorkflowExecutionStartedEventAttributes{
+				Input: input,
+			},
+		},
+		{
+			EventId:   common.Int64Ptr(2),
+			EventType: gen.EventTypeDecisionTaskScheduled.Ptr(),
+			DecisionTaskScheduledEventAttributes: &gen.DecisionTaskScheduledEventAttributes{
+				StartToCloseTimeoutSeconds: common.Int32Ptr(10),
+			},
+		},
+		{
+			EventId:   common.Int64Ptr(3),
+			EventType: gen.EventTypeWorkflowExecutionCompleted.Ptr(),
+			WorkflowExecutionCompletedEventAttributes: &gen.WorkflowExecutionCompletedEventAttributes{
+				Result: result,
+			},
+		},
+	}}
+	assert.Equal(t, len(input)+len(result), HistoryPayloadSize(history))
+
+	compressed, err := CompressHistory(history)
+	assert.NoError(t, err)
+	assert.True(t, HistoryPayloadSize(compressed) < HistoryPayloadSize(history))
+	assert.Equal(t, input, history.Events[0].WorkflowExecutionStartedEventAttributes.Input)
+	assert.Equal(t, history.Events[1], compressed.Events[1])
+
+	decompressed, err := DecompressHistory(compressed)
+	assert.NoError(t, err)
+	assert.Equal(t, history, decompressed)
+}
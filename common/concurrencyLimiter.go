@@ -0,0 +1,130 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// ConcurrencyLimiter bounds the number of requests of each API that are in flight at the
+	// same time. Requests over the limit wait for a free slot until a timeout elapses.
+	ConcurrencyLimiter interface {
+		// Acquire waits for an in flight slot of the given API. Returns true if a slot was
+		// taken, false if none became available before the wait timeout or ctx expired.
+		Acquire(ctx context.Context, api string) bool
+		// Release returns a slot taken by a successful Acquire
+		Release(api string)
+	}
+
+	concurrencyLimiterImpl struct {
+		sync.Mutex
+		maxConcurrency func(api string) int
+		waitTimeout    func() time.Duration
+		apis           map[string]*apiConcurrency
+	}
+
+	apiConcurrency struct {
+		inFlight int
+		// released is closed, and replaced, every time a slot is released to wake up waiters
+		released chan struct{}
+	}
+)
+
+// NewConcurrencyLimiter creates a limiter which allows up to maxConcurrency(api) requests of
+// each API in flight. A limit less than or equal to zero disables the limit for that API.
+func NewConcurrencyLimiter(maxConcurrency func(api string) int, waitTimeout func() time.Duration) ConcurrencyLimiter {
+	return &concurrencyLimiterImpl{
+		maxConcurrency: maxConcurrency,
+		waitTimeout:    waitTimeout,
+		apis:           make(map[string]*apiConcurrency),
+	}
+}
+
+func (l *concurrencyLimiterImpl) Acquire(ctx context.Context, api string) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var timer *time.Timer
+	for {
+		l.Lock()
+		state := l.getAPIConcurrencyLocked(api)
+		max := l.maxConcurrency(api)
+		if max <= 0 || state.inFlight < max {
+			state.inFlight++
+			l.Unlock()
+			return true
+		}
+		released := state.released
+		l.Unlock()
+
+		if timer == nil {
+			timer = time.NewTimer(l.waitTimeout())
+			defer timer.Stop()
+		}
+		select {
+		case <-released:
+		case <-timer.C:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+func (l *concurrencyLimiterImpl) Release(api string) {
+	l.Lock()
+	defer l.Unlock()
+
+	state := l.getAPIConcurrencyLocked(api)
+	if state.inFlight > 0 {
+		state.inFlight--
+	}
+	close(state.released)
+	state.released = make(chan struct{})
+}
+
+func (l *concurrencyLimiterImpl) getAPIConcurrencyLocked(api string) *apiConcurrency {
+	state, ok := l.apis[api]
+	if !ok {
+		state = &apiConcurrency{released: make(chan struct{})}
+		l.apis[api] = state
+	}
+	return state
+}
+
+// ConcurrencyLimitsFromMap adapts a map of API name to max in flight requests, as read from
+// dynamic config, into the per API limit function used by NewConcurrencyLimiter. APIs which
+// are missing from the map, or have a non numeric limit, are not limited.
+func ConcurrencyLimitsFromMap(limits func() map[string]interface{}) func(api string) int {
+	return func(api string) int {
+		switch limit := limits()[api].(type) {
+		case int:
+			return limit
+		case float64:
+			return int(limit)
+		default:
+			return 0
+		}
+	}
+}
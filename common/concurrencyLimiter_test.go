@@ -0,0 +1,115 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	ConcurrencyLimiterSuite struct {
+		*require.Assertions
+		suite.Suite
+	}
+)
+
+func TestConcurrencyLimiterSuite(t *testing.T) {
+	suite.Run(t, new(ConcurrencyLimiterSuite))
+}
+
+func (s *ConcurrencyLimiterSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *ConcurrencyLimiterSuite) TestAcquireRelease() {
+	limiter := NewConcurrencyLimiter(
+		func(api string) int { return 2 },
+		func() time.Duration { return 10 * time.Millisecond },
+	)
+
+	s.True(limiter.Acquire(context.Background(), "GetWorkflowExecutionHistory"))
+	s.True(limiter.Acquire(context.Background(), "GetWorkflowExecutionHistory"))
+	s.False(limiter.Acquire(context.Background(), "GetWorkflowExecutionHistory"))
+	// limits are tracked per API
+	s.True(limiter.Acquire(context.Background(), "DescribeWorkflowExecution"))
+
+	limiter.Release("GetWorkflowExecutionHistory")
+	s.True(limiter.Acquire(context.Background(), "GetWorkflowExecutionHistory"))
+}
+
+func (s *ConcurrencyLimiterSuite) TestWaiterWokenUpOnRelease() {
+	limiter := NewConcurrencyLimiter(
+		func(api string) int { return 1 },
+		func() time.Duration { return time.Minute },
+	)
+	s.True(limiter.Acquire(context.Background(), "api"))
+
+	acquired := make(chan bool)
+	go func() {
+		acquired <- limiter.Acquire(context.Background(), "api")
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	limiter.Release("api")
+	select {
+	case ok := <-acquired:
+		s.True(ok)
+	case <-time.After(time.Second):
+		s.Fail("waiter was not woken up by release")
+	}
+}
+
+func (s *ConcurrencyLimiterSuite) TestContextCancelled() {
+	limiter := NewConcurrencyLimiter(
+		func(api string) int { return 1 },
+		func() time.Duration { return time.Minute },
+	)
+	s.True(limiter.Acquire(context.Background(), "api"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	s.False(limiter.Acquire(ctx, "api"))
+}
+
+func (s *ConcurrencyLimiterSuite) TestUnlimited() {
+	limiter := NewConcurrencyLimiter(
+		func(api string) int { return 0 },
+		func() time.Duration { return 0 },
+	)
+	for i := 0; i < 100; i++ {
+		s.True(limiter.Acquire(context.Background(), "api"))
+	}
+}
+
+func (s *ConcurrencyLimiterSuite) TestConcurrencyLimitsFromMap() {
+	limits := ConcurrencyLimitsFromMap(func() map[string]interface{} {
+		return map[string]interface{}{"a": 5, "b": float64(7), "c": "10"}
+	})
+	s.Equal(5, limits("a"))
+	s.Equal(7, limits("b"))
+	s.Equal(0, limits("c"))
+	s.Equal(0, limits("d"))
+}
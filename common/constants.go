@@ -48,8 +48,13 @@ const (
 
 // Data encoding types
 const (
-	EncodingTypeJSON EncodingType = "json"
-	EncodingTypeGob               = "gob"
+	EncodingTypeJSON     EncodingType = "json"
+	EncodingTypeGob                   = "gob"
+	EncodingTypeThriftRW EncodingType = "thriftrw"
+	// EncodingTypeJSONGzip and EncodingTypeThriftRWGzip are the gzip compressed variants of the encodings above,
+	// only used for persisted history
+	EncodingTypeJSONGzip     EncodingType = "json+gzip"
+	EncodingTypeThriftRWGzip EncodingType = "thriftrw+gzip"
 )
 
 type (
@@ -57,5 +62,12 @@ type (
 	EncodingType string
 )
 
+// names under which the task queues of a history shard report their backlog age, transfer and timer queues are per cluster
+const (
+	TransferQueueBacklogPrefix = "transfer/"
+	TimerQueueBacklogPrefix    = "timer/"
+	ReplicatorQueueBacklogName = "replicator"
+)
+
 // MaxTaskTimeout is maximum task timeout allowed. 366 days in seconds
 const MaxTaskTimeout = 31622400
@@ -94,6 +94,16 @@ func TimeoutTypePtr(t s.TimeoutType) *s.TimeoutType {
 	return &t
 }
 
+// EncodingTypePtr makes a copy and returns the pointer to a EncodingType.
+func EncodingTypePtr(t s.EncodingType) *s.EncodingType {
+	return &t
+}
+
+// TaskListTypePtr makes a copy and returns the pointer to a TaskListType.
+func TaskListTypePtr(t s.TaskListType) *s.TaskListType {
+	return &t
+}
+
 // TaskListKindPtr makes a copy and returns the pointer to a TaskListKind.
 func TaskListKindPtr(t s.TaskListKind) *s.TaskListKind {
 	return &t
@@ -124,6 +134,11 @@ func ChildWorkflowExecutionFailedCausePtr(t s.ChildWorkflowExecutionFailedCause)
 	return &t
 }
 
+// WorkflowExecutionCloseStatusPtr makes a copy and returns the pointer to a WorkflowExecutionCloseStatus.
+func WorkflowExecutionCloseStatusPtr(t s.WorkflowExecutionCloseStatus) *s.WorkflowExecutionCloseStatus {
+	return &t
+}
+
 // StringDefault returns value if string pointer is set otherwise default value of string
 func StringDefault(v *string) string {
 	var defaultString string
@@ -0,0 +1,107 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package credentials
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	// Credentials is the user and the password used to authenticate against a store
+	Credentials struct {
+		User     string
+		Password string
+	}
+
+	// Provider provides the credentials used to authenticate against a store. It is called every time a new
+	// connection is authenticated, so credentials rotated in the backing secret store are picked up without
+	// a restart.
+	Provider interface {
+		GetCredentials() (Credentials, error)
+	}
+
+	// Config contains the config items of the provider of the credentials of a store
+	Config struct {
+		// Provider is the kind of provider: static, env, file or vault, defaults to static which uses
+		// the user and the password set on the store config
+		Provider string `yaml:"provider"`
+		// UserEnv is the environment variable the env provider reads the user from, the user set on
+		// the store config is used if empty
+		UserEnv string `yaml:"userEnv"`
+		// PasswordEnv is the environment variable the env provider reads the password from
+		PasswordEnv string `yaml:"passwordEnv"`
+		// File is the path of the yaml file, with a user and a password key, read by the file provider
+		File string `yaml:"file"`
+		// Vault is the config of the vault provider
+		Vault Vault `yaml:"vault"`
+	}
+
+	// Vault contains the config items of the provider reading the credentials from a vault secret
+	Vault struct {
+		// Address is the address of the vault server, e.g. https://vault:8200
+		Address string `yaml:"address"`
+		// Token is the vault token, read from the VAULT_TOKEN environment variable if empty
+		Token string `yaml:"token"`
+		// Path is the path of the secret, e.g. secret/data/cadence/cassandra
+		Path string `yaml:"path"`
+		// UserKey is the key of the user in the secret, defaults to user
+		UserKey string `yaml:"userKey"`
+		// PasswordKey is the key of the password in the secret, defaults to password
+		PasswordKey string `yaml:"passwordKey"`
+		// RefreshInterval is how long the secret is cached before it is read again, defaults to 5 minutes
+		RefreshInterval time.Duration `yaml:"refreshInterval"`
+	}
+)
+
+const (
+	// ProviderStatic uses the user and the password set on the store config
+	ProviderStatic = "static"
+	// ProviderEnv reads the credentials from environment variables
+	ProviderEnv = "env"
+	// ProviderFile reads the credentials from a file, e.g. a mounted kubernetes secret
+	ProviderFile = "file"
+	// ProviderVault reads the credentials from a vault secret
+	ProviderVault = "vault"
+)
+
+// NewProvider returns the provider described by the config, user and password are the static credentials set on
+// the store config
+func (cfg *Config) NewProvider(user, password string) (Provider, error) {
+	switch cfg.Provider {
+	case "", ProviderStatic:
+		return NewStaticProvider(user, password), nil
+	case ProviderEnv:
+		if cfg.PasswordEnv == "" {
+			return nil, fmt.Errorf("passwordEnv is required by the %v credentials provider", ProviderEnv)
+		}
+		return NewEnvProvider(user, cfg.UserEnv, cfg.PasswordEnv), nil
+	case ProviderFile:
+		if cfg.File == "" {
+			return nil, fmt.Errorf("file is required by the %v credentials provider", ProviderFile)
+		}
+		return NewFileProvider(user, cfg.File), nil
+	case ProviderVault:
+		return NewVaultProvider(user, &cfg.Vault)
+	default:
+		return nil, fmt.Errorf("unknown credentials provider: %v", cfg.Provider)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package credentials
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+type (
+	staticProvider struct {
+		credentials Credentials
+	}
+
+	envProvider struct {
+		user        string
+		userEnv     string
+		passwordEnv string
+	}
+
+	fileProvider struct {
+		user string
+		path string
+	}
+)
+
+// NewStaticProvider returns a provider which always returns the given credentials
+func NewStaticProvider(user, password string) Provider {
+	return &staticProvider{credentials: Credentials{User: user, Password: password}}
+}
+
+func (p *staticProvider) GetCredentials() (Credentials, error) {
+	return p.credentials, nil
+}
+
+// NewEnvProvider returns a provider which reads the user and the password from the given environment variables
+// on every call, the user falls back to the given one when userEnv is empty
+func NewEnvProvider(user, userEnv, passwordEnv string) Provider {
+	return &envProvider{user: user, userEnv: userEnv, passwordEnv: passwordEnv}
+}
+
+func (p *envProvider) GetCredentials() (Credentials, error) {
+	credentials := Credentials{User: p.user}
+	if p.userEnv != "" {
+		credentials.User = os.Getenv(p.userEnv)
+	}
+	password, ok := os.LookupEnv(p.passwordEnv)
+	if !ok {
+		return Credentials{}, fmt.Errorf("environment variable %v is not set", p.passwordEnv)
+	}
+	credentials.Password = password
+	return credentials, nil
+}
+
+// NewFileProvider returns a provider which reads the credentials from the given yaml file on every call, so
+// a secret rewritten in place is picked up. The user falls back to the given one when the file has none.
+func NewFileProvider(user, path string) Provider {
+	return &fileProvider{user: user, path: path}
+}
+
+func (p *fileProvider) GetCredentials() (Credentials, error) {
+	content, err := ioutil.ReadFile(p.path)
+	if err != nil {
+		return Credentials{}, err
+	}
+	var file struct {
+		User     string `yaml:"user"`
+		Password string `yaml:"password"`
+	}
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return Credentials{}, fmt.Errorf("failed to parse credentials file %v: %v", p.path, err)
+	}
+	if file.User == "" {
+		file.User = p.user
+	}
+	return Credentials{User: file.User, Password: file.Password}, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package credentials
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	providersSuite struct {
+		suite.Suite
+		dir string
+	}
+)
+
+func TestProvidersSuite(t *testing.T) {
+	s := new(providersSuite)
+	suite.Run(t, s)
+}
+
+func (s *providersSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "credentials")
+	s.NoError(err)
+	s.dir = dir
+}
+
+func (s *providersSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *providersSuite) TestNewProvider() {
+	cfg := &Config{}
+	provider, err := cfg.NewProvider("cadence", "secret")
+	s.NoError(err)
+	credentials, err := provider.GetCredentials()
+	s.NoError(err)
+	s.Equal(Credentials{User: "cadence", Password: "secret"}, credentials)
+
+	_, err = (&Config{Provider: ProviderEnv}).NewProvider("cadence", "")
+	s.Error(err)
+	_, err = (&Config{Provider: ProviderFile}).NewProvider("cadence", "")
+	s.Error(err)
+	_, err = (&Config{Provider: ProviderVault}).NewProvider("cadence", "")
+	s.Error(err)
+	_, err = (&Config{Provider: "unknown"}).NewProvider("cadence", "")
+	s.Error(err)
+}
+
+func (s *providersSuite) TestEnvProvider() {
+	provider := NewEnvProvider("cadence", "", "CADENCE_TEST_PASSWORD")
+	_, err := provider.GetCredentials()
+	s.Error(err)
+
+	os.Setenv("CADENCE_TEST_PASSWORD", "secret")
+	defer os.Unsetenv("CADENCE_TEST_PASSWORD")
+	credentials, err := provider.GetCredentials()
+	s.NoError(err)
+	s.Equal(Credentials{User: "cadence", Password: "secret"}, credentials)
+
+	// rotated in place
+	os.Setenv("CADENCE_TEST_PASSWORD", "rotated")
+	credentials, err = provider.GetCredentials()
+	s.NoError(err)
+	s.Equal("rotated", credentials.Password)
+}
+
+func (s *providersSuite) TestFileProvider() {
+	path := filepath.Join(s.dir, "cassandra.yaml")
+	provider := NewFileProvider("cadence", path)
+	_, err := provider.GetCredentials()
+	s.Error(err)
+
+	s.NoError(ioutil.WriteFile(path, []byte("password: secret\n"), 0600))
+	credentials, err := provider.GetCredentials()
+	s.NoError(err)
+	s.Equal(Credentials{User: "cadence", Password: "secret"}, credentials)
+
+	// rotated in place
+	s.NoError(ioutil.WriteFile(path, []byte("user: admin\npassword: rotated\n"), 0600))
+	credentials, err = provider.GetCredentials()
+	s.NoError(err)
+	s.Equal(Credentials{User: "admin", Password: "rotated"}, credentials)
+}
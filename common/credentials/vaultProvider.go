@@ -0,0 +1,215 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package credentials
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	vaultTokenEnv               = "VAULT_TOKEN"
+	defaultVaultUserKey         = "user"
+	defaultVaultPasswordKey     = "password"
+	defaultVaultRefreshInterval = 5 * time.Minute
+	vaultRequestTimeout         = 10 * time.Second
+	vaultInitialRetryInterval   = time.Second
+)
+
+type (
+	vaultProvider struct {
+		user            string
+		url             string
+		token           string
+		userKey         string
+		passwordKey     string
+		refreshInterval time.Duration
+		client          *http.Client
+		timeNow         func() time.Time
+
+		sync.Mutex
+		credentials *Credentials
+		expiry      time.Time
+		// refreshCh is closed when the read of the secret in flight completes, it is nil when none is
+		refreshCh chan struct{}
+		// failures counts the consecutive failed reads, no read is attempted before retryAfter once one failed
+		failures   int
+		retryAfter time.Time
+		lastErr    error
+	}
+
+	vaultSecret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+)
+
+// NewVaultProvider returns a provider which reads the credentials from a secret of the vault KV engine, version
+// 1 or 2. The secret is cached for the refresh interval, and the last credentials read are kept on when vault
+// cannot be reached, so an outage of vault does not prevent new connections.
+func NewVaultProvider(user string, cfg *Vault) (Provider, error) {
+	if cfg.Address == "" || cfg.Path == "" {
+		return nil, errors.New("address and path are required by the vault credentials provider")
+	}
+	p := &vaultProvider{
+		user:            user,
+		url:             strings.TrimSuffix(cfg.Address, "/") + "/v1/" + strings.TrimPrefix(cfg.Path, "/"),
+		token:           cfg.Token,
+		userKey:         cfg.UserKey,
+		passwordKey:     cfg.PasswordKey,
+		refreshInterval: cfg.RefreshInterval,
+		client:          &http.Client{Timeout: vaultRequestTimeout},
+		timeNow:         time.Now,
+	}
+	if p.token == "" {
+		p.token = os.Getenv(vaultTokenEnv)
+	}
+	if p.userKey == "" {
+		p.userKey = defaultVaultUserKey
+	}
+	if p.passwordKey == "" {
+		p.passwordKey = defaultVaultPasswordKey
+	}
+	if p.refreshInterval <= 0 {
+		p.refreshInterval = defaultVaultRefreshInterval
+	}
+	return p, nil
+}
+
+// GetCredentials returns the cached credentials, or reads the secret again once they expire. The secret is read by
+// one caller at a time and outside of the lock, the callers arriving meanwhile get the credentials cached before,
+// or wait for the read if there are none. A failed read is not retried before a backoff, doubling up to the
+// refresh interval, elapses.
+func (p *vaultProvider) GetCredentials() (Credentials, error) {
+	for {
+		now := p.timeNow()
+		p.Lock()
+		if p.credentials != nil && now.Before(p.expiry) {
+			credentials := *p.credentials
+			p.Unlock()
+			return credentials, nil
+		}
+		if p.refreshCh == nil && now.Before(p.retryAfter) {
+			credentials, err := p.cachedLocked()
+			p.Unlock()
+			return credentials, err
+		}
+		if p.refreshCh != nil {
+			if p.credentials != nil {
+				credentials := *p.credentials
+				p.Unlock()
+				return credentials, nil
+			}
+			refreshCh := p.refreshCh
+			p.Unlock()
+			<-refreshCh
+			continue
+		}
+
+		refreshCh := make(chan struct{})
+		p.refreshCh = refreshCh
+		p.Unlock()
+
+		credentials, err := p.readSecret()
+
+		p.Lock()
+		close(refreshCh)
+		p.refreshCh = nil
+		if err != nil {
+			p.failures++
+			p.retryAfter = now.Add(p.retryIntervalLocked())
+			p.lastErr = err
+			credentials, err = p.cachedLocked()
+			p.Unlock()
+			return credentials, err
+		}
+		p.failures = 0
+		p.retryAfter = time.Time{}
+		p.lastErr = nil
+		p.credentials = &credentials
+		p.expiry = now.Add(p.refreshInterval)
+		p.Unlock()
+		return credentials, nil
+	}
+}
+
+// cachedLocked returns the last credentials read, which are kept on while vault fails, or the error of the last
+// read if there are none
+func (p *vaultProvider) cachedLocked() (Credentials, error) {
+	if p.credentials != nil {
+		return *p.credentials, nil
+	}
+	return Credentials{}, p.lastErr
+}
+
+func (p *vaultProvider) retryIntervalLocked() time.Duration {
+	interval := vaultInitialRetryInterval
+	for i := 1; i < p.failures && interval < p.refreshInterval; i++ {
+		interval *= 2
+	}
+	if interval > p.refreshInterval {
+		interval = p.refreshInterval
+	}
+	return interval
+}
+
+func (p *vaultProvider) readSecret() (Credentials, error) {
+	request, err := http.NewRequest(http.MethodGet, p.url, nil)
+	if err != nil {
+		return Credentials{}, err
+	}
+	request.Header.Set("X-Vault-Token", p.token)
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return Credentials{}, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return Credentials{}, fmt.Errorf("failed to read vault secret %v: %v", p.url, response.Status)
+	}
+
+	var secret vaultSecret
+	if err := json.NewDecoder(response.Body).Decode(&secret); err != nil {
+		return Credentials{}, err
+	}
+	data := secret.Data
+	// the KV engine version 2 nests the secret under data.data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		data = nested
+	}
+
+	credentials := Credentials{User: p.user}
+	if user, ok := data[p.userKey].(string); ok && user != "" {
+		credentials.User = user
+	}
+	password, ok := data[p.passwordKey].(string)
+	if !ok {
+		return Credentials{}, fmt.Errorf("vault secret %v has no %v key", p.url, p.passwordKey)
+	}
+	credentials.Password = password
+	return credentials, nil
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package credentials
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	vaultProviderSuite struct {
+		suite.Suite
+		server   *httptest.Server
+		secret   string
+		status   int
+		requests int
+		now      time.Time
+		// when set, the handler signals arrivedCh and waits on releaseCh before it responds
+		arrivedCh chan struct{}
+		releaseCh chan struct{}
+	}
+)
+
+func TestVaultProviderSuite(t *testing.T) {
+	s := new(vaultProviderSuite)
+	suite.Run(t, s)
+}
+
+func (s *vaultProviderSuite) SetupTest() {
+	s.status = http.StatusOK
+	s.requests = 0
+	s.now = time.Unix(1000, 0)
+	s.arrivedCh = nil
+	s.releaseCh = nil
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests++
+		s.Equal("/v1/secret/data/cassandra", r.URL.Path)
+		s.Equal("token", r.Header.Get("X-Vault-Token"))
+		if s.releaseCh != nil {
+			s.arrivedCh <- struct{}{}
+			<-s.releaseCh
+		}
+		w.WriteHeader(s.status)
+		w.Write([]byte(s.secret))
+	}))
+}
+
+func (s *vaultProviderSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *vaultProviderSuite) newProvider() Provider {
+	provider, err := NewVaultProvider("cadence", &Vault{
+		Address:         s.server.URL + "/",
+		Token:           "token",
+		Path:            "secret/data/cassandra",
+		RefreshInterval: time.Minute,
+	})
+	s.NoError(err)
+	provider.(*vaultProvider).timeNow = func() time.Time { return s.now }
+	return provider
+}
+
+func (s *vaultProviderSuite) TestGetCredentials_KVVersion1() {
+	s.secret = `{"data": {"password": "secret"}}`
+	credentials, err := s.newProvider().GetCredentials()
+	s.NoError(err)
+	s.Equal(Credentials{User: "cadence", Password: "secret"}, credentials)
+}
+
+func (s *vaultProviderSuite) TestGetCredentials_KVVersion2() {
+	s.secret = `{"data": {"data": {"user": "admin", "password": "secret"}, "metadata": {"version": 3}}}`
+	credentials, err := s.newProvider().GetCredentials()
+	s.NoError(err)
+	s.Equal(Credentials{User: "admin", Password: "secret"}, credentials)
+}
+
+func (s *vaultProviderSuite) TestGetCredentials_Refresh() {
+	provider := s.newProvider()
+	s.secret = `{"data": {"password": "secret"}}`
+	credentials, err := provider.GetCredentials()
+	s.NoError(err)
+	s.Equal("secret", credentials.Password)
+
+	// cached until the refresh interval elapses
+	s.secret = `{"data": {"password": "rotated"}}`
+	s.now = s.now.Add(30 * time.Second)
+	credentials, err = provider.GetCredentials()
+	s.NoError(err)
+	s.Equal("secret", credentials.Password)
+	s.Equal(1, s.requests)
+
+	s.now = s.now.Add(time.Minute)
+	credentials, err = provider.GetCredentials()
+	s.NoError(err)
+	s.Equal("rotated", credentials.Password)
+	s.Equal(2, s.requests)
+
+	// the last credentials are kept on while vault fails
+	s.status = http.StatusServiceUnavailable
+	s.now = s.now.Add(2 * time.Minute)
+	credentials, err = provider.GetCredentials()
+	s.NoError(err)
+	s.Equal("rotated", credentials.Password)
+}
+
+func (s *vaultProviderSuite) TestGetCredentials_Error() {
+	s.status = http.StatusForbidden
+	_, err := s.newProvider().GetCredentials()
+	s.Error(err)
+
+	s.status = http.StatusOK
+	s.secret = `{"data": {"user": "admin"}}`
+	_, err = s.newProvider().GetCredentials()
+	s.Error(err)
+}
+
+func (s *vaultProviderSuite) TestGetCredentials_Backoff() {
+	provider := s.newProvider()
+	s.status = http.StatusServiceUnavailable
+	_, err := provider.GetCredentials()
+	s.Error(err)
+	s.Equal(1, s.requests)
+
+	// vault is not read again before the backoff elapses
+	_, err = provider.GetCredentials()
+	s.Error(err)
+	s.Equal(1, s.requests)
+
+	s.now = s.now.Add(time.Second)
+	_, err = provider.GetCredentials()
+	s.Error(err)
+	s.Equal(2, s.requests)
+
+	// the backoff doubles with every failure
+	s.now = s.now.Add(time.Second)
+	_, err = provider.GetCredentials()
+	s.Error(err)
+	s.Equal(2, s.requests)
+
+	s.status = http.StatusOK
+	s.secret = `{"data": {"password": "secret"}}`
+	s.now = s.now.Add(time.Second)
+	credentials, err := provider.GetCredentials()
+	s.NoError(err)
+	s.Equal("secret", credentials.Password)
+	s.Equal(3, s.requests)
+}
+
+func (s *vaultProviderSuite) TestGetCredentials_ReadOutsideLock() {
+	provider := s.newProvider()
+	s.secret = `{"data": {"password": "secret"}}`
+	_, err := provider.GetCredentials()
+	s.NoError(err)
+
+	s.arrivedCh = make(chan struct{})
+	s.releaseCh = make(chan struct{})
+	s.secret = `{"data": {"password": "rotated"}}`
+	s.now = s.now.Add(2 * time.Minute)
+	refreshedCh := make(chan Credentials)
+	go func() {
+		credentials, _ := provider.GetCredentials()
+		refreshedCh <- credentials
+	}()
+
+	// the callers arriving while the secret is read get the credentials cached before
+	<-s.arrivedCh
+	credentials, err := provider.GetCredentials()
+	s.NoError(err)
+	s.Equal("secret", credentials.Password)
+
+	close(s.releaseCh)
+	s.Equal("rotated", (<-refreshedCh).Password)
+	s.Equal(2, s.requests)
+}
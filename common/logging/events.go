@@ -35,6 +35,7 @@ const (
 	PersistentStoreErrorEventID        = 2010
 	HistorySerializationErrorEventID   = 2020
 	HistoryDeserializationErrorEventID = 2021
+	HistoryEventsCorruptedEventID      = 2022
 	DuplicateTaskEventID               = 2030
 	MultipleCompletionDecisionsEventID = 2040
 	DuplicateTransferTaskEventID       = 2050
@@ -106,6 +106,14 @@ func LogHistoryDeserializationErrorEvent(logger bark.Logger, err error, msg stri
 	}).Errorf("Error deserializing workflow execution history.  Msg: %v", msg)
 }
 
+// LogHistoryEventsCorruptedEvent is used to log execution history whose events are not contiguous
+func LogHistoryEventsCorruptedEvent(logger bark.Logger, err error) {
+	logger.WithFields(bark.Fields{
+		TagWorkflowEventID: HistoryEventsCorruptedEventID,
+		TagWorkflowErr:     err,
+	}).Error("Workflow execution history is corrupted.")
+}
+
 // LogHistoryEngineStartingEvent is used to log history engine starting
 func LogHistoryEngineStartingEvent(logger bark.Logger) {
 	logger.WithFields(bark.Fields{
@@ -443,6 +451,16 @@ func LogDecisionTimeoutTooLarge(logger bark.Logger, t int32, domain, wid, wfType
 	}).Warn("Decision timeout is too large")
 }
 
+// LogBlobSizeTooLarge is used to log warning msg for request payload which is larger than the warn limit
+func LogBlobSizeTooLarge(logger bark.Logger, domain, wid, blobName string, size int) {
+	logger.WithFields(bark.Fields{
+		"Domain":     domain,
+		"WorkflowID": wid,
+		"BlobName":   blobName,
+		"BlobSize":   size,
+	}).Warn("Blob size is too large")
+}
+
 // LogDecisionTimeoutLargerThanWorkflowTimeout is used to log warning msg for workflow that contains large decision timeout
 func LogDecisionTimeoutLargerThanWorkflowTimeout(logger bark.Logger, t int32, domain, wid, wfType string) {
 	logger.WithFields(bark.Fields{
@@ -63,6 +63,8 @@ const (
 	TagAttemptCount         = "attempt-count"
 	TagAttemptStart         = "attempt-start"
 	TagAttemptEnd           = "attempt-end"
+	TagScheduleID           = "schedule-id"
+	TagBinaryChecksum       = "binary-checksum"
 
 	// workflow logging tag values
 	// TagWorkflowComponent Values
@@ -77,6 +79,11 @@ const (
 	TagValueReplicatorComponent               = "replicator"
 	TagValueReplicationTaskProcessorComponent = "replication-task-processor"
 	TagValueHistoryReplicatorComponent        = "history-replicator"
+	TagValueScheduleProcessorComponent        = "schedule-processor"
+	TagValueVisibilityPrunerComponent         = "visibility-pruner"
+	TagValueHistoryMigratorComponent          = "history-migrator"
+	TagValueDomainRegistrationComponent       = "domain-registration-processor"
+	TagValueStickyPoisonDetectorComponent     = "sticky-poison-detector"
 
 	// TagHistoryBuilderAction values
 	TagValueActionWorkflowStarted                 = "add-workflowexecution-started-event"
@@ -116,6 +123,9 @@ const (
 	TagValueActionWorkflowCancelFailed            = "add-workflow-execution-cancel-failed-event"
 	TagValueActionWorkflowSignalRequested         = "add-workflow-execution-signal-requested-event"
 	TagValueActionWorkflowSignalFailed            = "add-workflow-execution-signal-failed-event"
+	TagValueActionWorkflowUpdateRequested         = "add-workflowexecution-update-requested-event"
+	TagValueActionWorkflowUpdateCompleted         = "add-workflowexecution-update-completed-event"
+	TagValueActionWorkflowTaskListChanged         = "add-workflowexecution-tasklist-changed-event"
 	TagValueActionUnknownEvent                    = "add-unknown-event"
 
 	// TagStoreOperation values
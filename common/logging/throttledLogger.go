@@ -0,0 +1,141 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package logging
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/uber-common/bark"
+	"golang.org/x/time/rate"
+)
+
+// maxThrottledLogKeys bounds the number of rate limiters kept by a throttled logger, messages with
+// keys beyond it share a single rate limiter
+const maxThrottledLogKeys = 1000
+
+type (
+	throttledLogger struct {
+		bark.Logger
+		throttle *logThrottle
+	}
+
+	logThrottle struct {
+		level      logrus.Level
+		rps        func() int
+		sampleRate func() float64
+
+		sync.Mutex
+		limiters map[string]*rate.Limiter
+	}
+)
+
+// NewThrottledLogger returns a logger which samples debug and info messages with sampleRate and then
+// limits them to rps per message, keyed by the format string or the message itself. A sampleRate of
+// 1 keeps all messages and an rps of 0 or less disables the limit. Warnings and errors are never dropped.
+// Loggers derived through WithField(s) and WithError share the limits of their parent. Messages above
+// level are dropped before they are keyed, so disabled debug logs are never formatted.
+func NewThrottledLogger(
+	logger bark.Logger,
+	level logrus.Level,
+	rps func() int,
+	sampleRate func() float64,
+) bark.Logger {
+	return &throttledLogger{
+		Logger: logger,
+		throttle: &logThrottle{
+			level:      level,
+			rps:        rps,
+			sampleRate: sampleRate,
+			limiters:   make(map[string]*rate.Limiter),
+		},
+	}
+}
+
+func (l *throttledLogger) Debug(args ...interface{}) {
+	if l.throttle.enabled(logrus.DebugLevel) && l.throttle.allow(fmt.Sprint(args...)) {
+		l.Logger.Debug(args...)
+	}
+}
+
+func (l *throttledLogger) Debugf(format string, args ...interface{}) {
+	if l.throttle.enabled(logrus.DebugLevel) && l.throttle.allow(format) {
+		l.Logger.Debugf(format, args...)
+	}
+}
+
+func (l *throttledLogger) Info(args ...interface{}) {
+	if l.throttle.enabled(logrus.InfoLevel) && l.throttle.allow(fmt.Sprint(args...)) {
+		l.Logger.Info(args...)
+	}
+}
+
+func (l *throttledLogger) Infof(format string, args ...interface{}) {
+	if l.throttle.enabled(logrus.InfoLevel) && l.throttle.allow(format) {
+		l.Logger.Infof(format, args...)
+	}
+}
+
+func (l *throttledLogger) WithField(key string, value interface{}) bark.Logger {
+	return &throttledLogger{Logger: l.Logger.WithField(key, value), throttle: l.throttle}
+}
+
+func (l *throttledLogger) WithFields(keyValues bark.LogFields) bark.Logger {
+	return &throttledLogger{Logger: l.Logger.WithFields(keyValues), throttle: l.throttle}
+}
+
+func (l *throttledLogger) WithError(err error) bark.Logger {
+	return &throttledLogger{Logger: l.Logger.WithError(err), throttle: l.throttle}
+}
+
+func (t *logThrottle) enabled(level logrus.Level) bool {
+	return t.level >= level
+}
+
+func (t *logThrottle) allow(key string) bool {
+	if sampleRate := t.sampleRate(); sampleRate < 1 && rand.Float64() >= sampleRate {
+		return false
+	}
+	rps := t.rps()
+	if rps <= 0 {
+		return true
+	}
+	return t.getLimiter(key, rps).Allow()
+}
+
+func (t *logThrottle) getLimiter(key string, rps int) *rate.Limiter {
+	t.Lock()
+	defer t.Unlock()
+	limiter, ok := t.limiters[key]
+	if !ok && len(t.limiters) >= maxThrottledLogKeys {
+		key = ""
+		limiter, ok = t.limiters[key]
+	}
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(rps), rps)
+		t.limiters[key] = limiter
+	} else if limiter.Limit() != rate.Limit(rps) {
+		limiter.SetLimit(rate.Limit(rps))
+	}
+	return limiter
+}
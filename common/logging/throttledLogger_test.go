@@ -0,0 +1,110 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package logging
+
+import (
+	"io/ioutil"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-common/bark"
+)
+
+type countingHook struct {
+	counts map[log.Level]int
+}
+
+func (h *countingHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h *countingHook) Fire(entry *log.Entry) error {
+	h.counts[entry.Level]++
+	return nil
+}
+
+func newCountingLogger() (bark.Logger, *countingHook) {
+	hook := &countingHook{counts: make(map[log.Level]int)}
+	logger := log.New()
+	logger.Out = ioutil.Discard
+	logger.Level = log.DebugLevel
+	logger.Hooks.Add(hook)
+	return bark.NewLoggerFromLogrus(logger), hook
+}
+
+func TestThrottledLogger_LimitsPerMessage(t *testing.T) {
+	logger, hook := newCountingLogger()
+	throttled := NewThrottledLogger(logger, log.DebugLevel, func() int { return 2 }, func() float64 { return 1 })
+
+	for i := 0; i < 10; i++ {
+		throttled.Debugf("processing task %v", i)
+		throttled.WithField("attempt", i).Debugf("retrying task %v", i)
+		throttled.Warnf("warning %v", i)
+	}
+	// each format string is limited on its own, derived loggers share the limit
+	require.Equal(t, 4, hook.counts[log.DebugLevel])
+	require.Equal(t, 10, hook.counts[log.WarnLevel])
+}
+
+func TestThrottledLogger_Sampling(t *testing.T) {
+	logger, hook := newCountingLogger()
+	sampleRate := float64(0)
+	throttled := NewThrottledLogger(logger, log.DebugLevel, func() int { return 0 }, func() float64 { return sampleRate })
+
+	for i := 0; i < 10; i++ {
+		throttled.Info("task processed")
+	}
+	require.Equal(t, 0, hook.counts[log.InfoLevel])
+
+	sampleRate = 1
+	for i := 0; i < 10; i++ {
+		throttled.Info("task processed")
+	}
+	require.Equal(t, 10, hook.counts[log.InfoLevel])
+}
+
+type countingStringer struct {
+	calls int
+}
+
+func (s *countingStringer) String() string {
+	s.calls++
+	return "task"
+}
+
+func TestThrottledLogger_SkipsDisabledLevels(t *testing.T) {
+	logger, hook := newCountingLogger()
+	throttled := NewThrottledLogger(logger, log.InfoLevel, func() int { return 10 }, func() float64 { return 1 })
+
+	arg := &countingStringer{}
+	for i := 0; i < 10; i++ {
+		throttled.Debug("processing ", arg)
+		throttled.Debugf("processing %v", arg)
+	}
+	// disabled debug logs are neither keyed nor formatted
+	require.Equal(t, 0, arg.calls)
+	require.Equal(t, 0, hook.counts[log.DebugLevel])
+
+	throttled.Info("processed ", arg)
+	require.Equal(t, 2, arg.calls)
+	require.Equal(t, 1, hook.counts[log.InfoLevel])
+}
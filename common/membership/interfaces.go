@@ -50,6 +50,8 @@ type (
 		Start() error
 		Stop()
 		WhoAmI() (*HostInfo, error)
+		// SetLabel sets a label on the current host, labels are gossiped to all members of the cluster
+		SetLabel(key string, value string) error
 		Lookup(service string, key string) (*HostInfo, error)
 		GetResolver(service string) (ServiceResolver, error)
 		// AddListener adds a listener for this service.
@@ -67,6 +69,8 @@ type (
 	// It can be used to resolve which member host is responsible for serving a given key.
 	ServiceResolver interface {
 		Lookup(key string) (*HostInfo, error)
+		// Members returns all reachable hosts of the service, along with their labels
+		Members() ([]*HostInfo, error)
 		// AddListener adds a listener which will get notified on the given
 		// channel, whenever membership changes.
 		// @name: The name for identifying the listener
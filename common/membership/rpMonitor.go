@@ -99,6 +99,14 @@ func (rpo *ringpopMonitor) WhoAmI() (*HostInfo, error) {
 	return NewHostInfo(address, labels.AsMap()), nil
 }
 
+func (rpo *ringpopMonitor) SetLabel(key string, value string) error {
+	labels, err := rpo.rp.Labels()
+	if err != nil {
+		return err
+	}
+	return labels.Set(key, value)
+}
+
 func (rpo *ringpopMonitor) GetResolver(service string) (ServiceResolver, error) {
 	ring, found := rpo.rings[service]
 	if !found {
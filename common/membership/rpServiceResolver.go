@@ -135,6 +135,25 @@ func (r *ringpopServiceResolver) Lookup(key string) (*HostInfo, error) {
 	return NewHostInfo(addr, r.getLabelsMap()), nil
 }
 
+// Members returns all reachable hosts of the service, unlike Lookup the labels of each host are fetched from ringpop
+func (r *ringpopServiceResolver) Members() ([]*HostInfo, error) {
+	var hosts []*HostInfo
+	// ringpop only hands out member addresses, so collect the labels while it evaluates the predicates
+	collectLabels := func(member swim.Member) bool {
+		labels := make(map[string]string, len(member.Labels))
+		for key, value := range member.Labels {
+			labels[key] = value
+		}
+		hosts = append(hosts, NewHostInfo(member.Address, labels))
+		return true
+	}
+
+	if _, err := r.rp.GetReachableMembers(swim.MemberWithLabelAndValue(RoleKey, r.service), collectLabels); err != nil {
+		return nil, err
+	}
+	return hosts, nil
+}
+
 func (r *ringpopServiceResolver) AddListener(name string, notifyChannel chan<- *ChangedEvent) error {
 	r.listenerLock.Lock()
 	defer r.listenerLock.Unlock()
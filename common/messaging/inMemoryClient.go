@@ -0,0 +1,435 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber-go/kafka-client/kafka"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/common/logging"
+)
+
+type (
+	// InMemoryTransport is an in process replacement of the Kafka cluster used for cross cluster replication.
+	// Replication tasks published by a cluster are kept in a topic named after that cluster, and are handed to
+	// consumers of that topic in publish order.  Delivery of each topic can be paused and stepped manually, which
+	// lets integration tests reorder replication with respect to other operations deterministically.
+	InMemoryTransport struct {
+		sync.Mutex
+		topics map[string]*inMemoryTopic
+		logger bark.Logger
+	}
+
+	inMemoryTopic struct {
+		name        string
+		msgCh       chan kafka.Message
+		held        []*inMemoryMessage
+		paused      bool
+		nextOffset  int64
+		outstanding int64
+		acked       int64
+		dlq         []*replicator.ReplicationTask
+	}
+
+	inMemoryClient struct {
+		transport *InMemoryTransport
+	}
+
+	inMemoryProducer struct {
+		topic     string
+		transport *InMemoryTransport
+		logger    bark.Logger
+	}
+
+	inMemoryConsumer struct {
+		name      string
+		topic     *inMemoryTopic
+		closeOnce sync.Once
+		closedCh  chan struct{}
+	}
+
+	inMemoryMessage struct {
+		topic     *inMemoryTopic
+		transport *InMemoryTransport
+		key       []byte
+		value     []byte
+		offset    int64
+		timestamp time.Time
+		task      *replicator.ReplicationTask
+		completed int32
+	}
+)
+
+const (
+	inMemoryTopicCapacity = 10000
+	inMemoryDrainInterval = 10 * time.Millisecond
+)
+
+var (
+	// ErrInMemoryTopicFull is returned when a topic of the in memory transport can not buffer any more messages
+	ErrInMemoryTopicFull = errors.New("in memory replication topic is full")
+	// ErrInMemoryDrainTimeout is returned when delivered messages are not acknowledged in time
+	ErrInMemoryDrainTimeout   = errors.New("timed out waiting for in memory replication topic to drain")
+	errInMemoryOpNotSupported = errors.New("operation not supported by in memory consumer")
+)
+
+var _ Client = (*inMemoryClient)(nil)
+var _ Producer = (*inMemoryProducer)(nil)
+var _ kafka.Consumer = (*inMemoryConsumer)(nil)
+var _ kafka.Message = (*inMemoryMessage)(nil)
+
+// NewInMemoryTransport creates the in process transport shared by all clusters of a test
+func NewInMemoryTransport(logger bark.Logger) *InMemoryTransport {
+	return &InMemoryTransport{
+		topics: make(map[string]*inMemoryTopic),
+		logger: logger,
+	}
+}
+
+// NewClient returns a messaging client backed by the transport.  All clients created from the same transport
+// observe the same topics, so each cluster of a test should get its own client of one shared transport.
+func (t *InMemoryTransport) NewClient() Client {
+	return &inMemoryClient{transport: t}
+}
+
+// Pause stops delivery of newly published messages of the given source cluster until Resume or Deliver is called
+func (t *InMemoryTransport) Pause(sourceCluster string) {
+	t.Lock()
+	defer t.Unlock()
+	t.getTopicLocked(sourceCluster).paused = true
+}
+
+// Resume delivers all held messages of the given source cluster and switches the topic back to immediate delivery
+func (t *InMemoryTransport) Resume(sourceCluster string) {
+	t.Lock()
+	defer t.Unlock()
+	topic := t.getTopicLocked(sourceCluster)
+	topic.paused = false
+	t.deliverLocked(topic, len(topic.held))
+}
+
+// Deliver hands up to count held messages of a paused topic to its consumers and returns how many were delivered
+func (t *InMemoryTransport) Deliver(sourceCluster string, count int) int {
+	t.Lock()
+	defer t.Unlock()
+	return t.deliverLocked(t.getTopicLocked(sourceCluster), count)
+}
+
+// DeliverTaskType hands up to count held messages of the given task type to the consumers of a paused topic, ahead
+// of held messages of other types which keep their order.  Kafka only keeps the order of messages sharing a key,
+// so a domain task may overtake history tasks published before it.
+func (t *InMemoryTransport) DeliverTaskType(sourceCluster string, taskType replicator.ReplicationTaskType,
+	count int) int {
+	t.Lock()
+	defer t.Unlock()
+	topic := t.getTopicLocked(sourceCluster)
+
+	delivered := 0
+	var remaining []*inMemoryMessage
+	for i, msg := range topic.held {
+		if delivered >= count {
+			remaining = append(remaining, topic.held[i:]...)
+			break
+		}
+		if msg.task.GetTaskType() != taskType {
+			remaining = append(remaining, msg)
+			continue
+		}
+		select {
+		case topic.msgCh <- msg:
+			topic.outstanding++
+			delivered++
+		default:
+			// consumer side buffer is full, keep the rest held back
+			remaining = append(remaining, topic.held[i:]...)
+			topic.held = remaining
+			return delivered
+		}
+	}
+	topic.held = remaining
+	return delivered
+}
+
+// Pending returns the number of messages of the given source cluster which are held back by Pause
+func (t *InMemoryTransport) Pending(sourceCluster string) int {
+	t.Lock()
+	defer t.Unlock()
+	return len(t.getTopicLocked(sourceCluster).held)
+}
+
+// Acked returns the number of messages of the given source cluster which were acknowledged by consumers
+func (t *InMemoryTransport) Acked(sourceCluster string) int64 {
+	t.Lock()
+	defer t.Unlock()
+	return t.getTopicLocked(sourceCluster).acked
+}
+
+// DLQ returns the replication tasks of the given source cluster which were rejected by consumers
+func (t *InMemoryTransport) DLQ(sourceCluster string) []*replicator.ReplicationTask {
+	t.Lock()
+	defer t.Unlock()
+	topic := t.getTopicLocked(sourceCluster)
+	result := make([]*replicator.ReplicationTask, len(topic.dlq))
+	copy(result, topic.dlq)
+	return result
+}
+
+// Drain blocks until every delivered message of the given source cluster is either acked or nacked
+func (t *InMemoryTransport) Drain(sourceCluster string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		t.Lock()
+		outstanding := t.getTopicLocked(sourceCluster).outstanding
+		t.Unlock()
+		if outstanding == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrInMemoryDrainTimeout
+		}
+		time.Sleep(inMemoryDrainInterval)
+	}
+}
+
+func (t *InMemoryTransport) publish(topicName string, key []byte, task *replicator.ReplicationTask,
+	payload []byte) error {
+	t.Lock()
+	defer t.Unlock()
+
+	topic := t.getTopicLocked(topicName)
+	msg := &inMemoryMessage{
+		topic:     topic,
+		transport: t,
+		key:       key,
+		value:     payload,
+		offset:    topic.nextOffset,
+		timestamp: time.Now(),
+		task:      task,
+	}
+	if len(topic.held)+len(topic.msgCh) >= inMemoryTopicCapacity {
+		return ErrInMemoryTopicFull
+	}
+	topic.nextOffset++
+	topic.held = append(topic.held, msg)
+	if !topic.paused {
+		t.deliverLocked(topic, len(topic.held))
+	}
+	return nil
+}
+
+func (t *InMemoryTransport) deliverLocked(topic *inMemoryTopic, count int) int {
+	delivered := 0
+	for delivered < count && len(topic.held) > 0 {
+		msg := topic.held[0]
+		select {
+		case topic.msgCh <- msg:
+		default:
+			// consumer side buffer is full, keep the rest held back
+			return delivered
+		}
+		topic.held = topic.held[1:]
+		topic.outstanding++
+		delivered++
+	}
+	return delivered
+}
+
+func (t *InMemoryTransport) complete(msg *inMemoryMessage, ack bool) {
+	if !atomic.CompareAndSwapInt32(&msg.completed, 0, 1) {
+		return
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	msg.topic.outstanding--
+	if ack {
+		msg.topic.acked++
+		return
+	}
+	msg.topic.dlq = append(msg.topic.dlq, msg.task)
+	t.logger.WithFields(bark.Fields{
+		logging.TagTopicName: msg.topic.name,
+		logging.TagOffset:    msg.offset,
+	}).Warn("Replication task moved to in memory DLQ")
+}
+
+func (t *InMemoryTransport) getTopicLocked(name string) *inMemoryTopic {
+	topic, ok := t.topics[name]
+	if !ok {
+		topic = &inMemoryTopic{
+			name:  name,
+			msgCh: make(chan kafka.Message, inMemoryTopicCapacity),
+		}
+		t.topics[name] = topic
+	}
+	return topic
+}
+
+// NewConsumer is used to create a consumer of replication tasks published by the source cluster
+func (c *inMemoryClient) NewConsumer(currentCluster, sourceCluster, consumerName string, concurrency int) (kafka.Consumer, error) {
+	c.transport.Lock()
+	defer c.transport.Unlock()
+	return &inMemoryConsumer{
+		name:     consumerName,
+		topic:    c.transport.getTopicLocked(sourceCluster),
+		closedCh: make(chan struct{}),
+	}, nil
+}
+
+// NewProducer is used to create a producer for shipping replication tasks of the source cluster
+func (c *inMemoryClient) NewProducer(sourceCluster string) (Producer, error) {
+	return &inMemoryProducer{
+		topic:     sourceCluster,
+		transport: c.transport,
+		logger: c.transport.logger.WithFields(bark.Fields{
+			logging.TagTopicName: sourceCluster,
+		}),
+	}, nil
+}
+
+// Publish is used to send messages to other clusters through the in memory topic
+func (p *inMemoryProducer) Publish(task *replicator.ReplicationTask) error {
+	payload, err := json.Marshal(task)
+	if err != nil {
+		p.logger.WithFields(bark.Fields{
+			logging.TagErr: err,
+		}).Error("Failed to serialize replication task")
+
+		return err
+	}
+
+	return p.transport.publish(p.topic, p.getKey(task), task, payload)
+}
+
+// PublishBatch is used to send messages to other clusters through the in memory topic
+func (p *inMemoryProducer) PublishBatch(tasks []*replicator.ReplicationTask) error {
+	for _, task := range tasks {
+		if err := p.Publish(task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close is a no-op, the topic is owned by the transport
+func (p *inMemoryProducer) Close() error {
+	return nil
+}
+
+func (p *inMemoryProducer) getKey(task *replicator.ReplicationTask) []byte {
+	switch task.GetTaskType() {
+	case replicator.ReplicationTaskTypeHistory:
+		return []byte(task.HistoryTaskAttributes.GetWorkflowId())
+	case replicator.ReplicationTaskTypeSyncActivity:
+		return []byte(task.SyncActivityTaskAttributes.GetWorkflowId())
+	}
+	return nil
+}
+
+func (c *inMemoryConsumer) Name() string {
+	return c.name
+}
+
+func (c *inMemoryConsumer) Topics() kafka.ConsumerTopicList {
+	return kafka.ConsumerTopicList{
+		kafka.ConsumerTopic{
+			Topic: kafka.Topic{Name: c.topic.name},
+		},
+	}
+}
+
+func (c *inMemoryConsumer) Start() error {
+	return nil
+}
+
+func (c *inMemoryConsumer) Stop() {
+	c.closeOnce.Do(func() {
+		close(c.closedCh)
+	})
+}
+
+func (c *inMemoryConsumer) Closed() <-chan struct{} {
+	return c.closedCh
+}
+
+func (c *inMemoryConsumer) Messages() <-chan kafka.Message {
+	return c.topic.msgCh
+}
+
+func (c *inMemoryConsumer) MergeDLQ(topic kafka.ConsumerTopic, offsets map[int32]kafka.OffsetRange) error {
+	return errInMemoryOpNotSupported
+}
+
+func (c *inMemoryConsumer) ResetOffset(cluster, topic string, partition int32, offsetRange kafka.OffsetRange) error {
+	return errInMemoryOpNotSupported
+}
+
+func (m *inMemoryMessage) Key() []byte {
+	return m.key
+}
+
+func (m *inMemoryMessage) Value() []byte {
+	return m.value
+}
+
+func (m *inMemoryMessage) Topic() string {
+	return m.topic.name
+}
+
+func (m *inMemoryMessage) Partition() int32 {
+	return 0
+}
+
+func (m *inMemoryMessage) Offset() int64 {
+	return m.offset
+}
+
+func (m *inMemoryMessage) Timestamp() time.Time {
+	return m.timestamp
+}
+
+func (m *inMemoryMessage) RetryCount() int64 {
+	return 0
+}
+
+func (m *inMemoryMessage) Ack() error {
+	m.transport.complete(m, true)
+	return nil
+}
+
+func (m *inMemoryMessage) Nack() error {
+	m.transport.complete(m, false)
+	return nil
+}
+
+func (m *inMemoryMessage) MarshalLogObject(e zapcore.ObjectEncoder) error {
+	e.AddString("topic", m.topic.name)
+	e.AddInt64("offset", m.offset)
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/common"
+)
+
+type (
+	inMemoryClientSuite struct {
+		suite.Suite
+		*require.Assertions
+		transport *InMemoryTransport
+	}
+)
+
+func TestInMemoryClientSuite(t *testing.T) {
+	s := new(inMemoryClientSuite)
+	suite.Run(t, s)
+}
+
+func (s *inMemoryClientSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.transport = NewInMemoryTransport(bark.NewLoggerFromLogrus(log.New()))
+}
+
+func (s *inMemoryClientSuite) TestPublishConsume() {
+	client := s.transport.NewClient()
+	producer, err := client.NewProducer("active")
+	s.NoError(err)
+	consumer, err := client.NewConsumer("standby", "active", "consumer", 1)
+	s.NoError(err)
+	s.NoError(consumer.Start())
+
+	s.NoError(producer.Publish(newInMemoryTestTask("wid", 1)))
+	msg := <-consumer.Messages()
+	s.Equal(int64(0), msg.Offset())
+	s.Equal([]byte("wid"), msg.Key())
+	s.NoError(msg.Ack())
+	s.NoError(s.transport.Drain("active", time.Second))
+	s.Equal(int64(1), s.transport.Acked("active"))
+
+	consumer.Stop()
+	_, ok := <-consumer.Closed()
+	s.False(ok)
+}
+
+func (s *inMemoryClientSuite) TestPauseDeliver() {
+	client := s.transport.NewClient()
+	producer, err := client.NewProducer("active")
+	s.NoError(err)
+	consumer, err := client.NewConsumer("standby", "active", "consumer", 1)
+	s.NoError(err)
+
+	s.transport.Pause("active")
+	s.NoError(producer.PublishBatch([]*replicator.ReplicationTask{
+		newInMemoryTestTask("wid", 1),
+		newInMemoryTestTask("wid", 2),
+		newInMemoryTestTask("wid", 3),
+	}))
+	s.Equal(3, s.transport.Pending("active"))
+	s.Equal(0, len(consumer.Messages()))
+
+	s.Equal(1, s.transport.Deliver("active", 1))
+	s.Equal(2, s.transport.Pending("active"))
+	msg := <-consumer.Messages()
+	s.Equal(int64(0), msg.Offset())
+	s.Equal(ErrInMemoryDrainTimeout, s.transport.Drain("active", 0))
+	s.NoError(msg.Nack())
+	s.NoError(s.transport.Drain("active", time.Second))
+	s.Equal(1, len(s.transport.DLQ("active")))
+
+	s.transport.Resume("active")
+	s.Equal(0, s.transport.Pending("active"))
+	s.Equal(int64(1), (<-consumer.Messages()).Offset())
+	s.Equal(int64(2), (<-consumer.Messages()).Offset())
+}
+
+func (s *inMemoryClientSuite) TestDeliverTaskType() {
+	client := s.transport.NewClient()
+	producer, err := client.NewProducer("active")
+	s.NoError(err)
+	consumer, err := client.NewConsumer("standby", "active", "consumer", 1)
+	s.NoError(err)
+
+	s.transport.Pause("active")
+	s.NoError(producer.PublishBatch([]*replicator.ReplicationTask{
+		newInMemoryTestTask("wid", 1),
+		{
+			TaskType:             replicator.ReplicationTaskTypeDomain.Ptr(),
+			DomainTaskAttributes: &replicator.DomainTaskAttributes{ID: common.StringPtr("domain-id")},
+		},
+		newInMemoryTestTask("wid", 2),
+	}))
+
+	s.Equal(1, s.transport.DeliverTaskType("active", replicator.ReplicationTaskTypeDomain, 10))
+	s.Equal(2, s.transport.Pending("active"))
+	msg := <-consumer.Messages()
+	s.Equal(int64(1), msg.Offset())
+	s.NoError(msg.Ack())
+	s.Equal(0, s.transport.DeliverTaskType("active", replicator.ReplicationTaskTypeDomain, 10))
+
+	s.Equal(2, s.transport.Deliver("active", 2))
+	s.Equal(int64(0), (<-consumer.Messages()).Offset())
+	s.Equal(int64(2), (<-consumer.Messages()).Offset())
+}
+
+func newInMemoryTestTask(workflowID string, firstEventID int64) *replicator.ReplicationTask {
+	return &replicator.ReplicationTask{
+		TaskType: replicator.ReplicationTaskTypeHistory.Ptr(),
+		HistoryTaskAttributes: &replicator.HistoryTaskAttributes{
+			WorkflowId:   common.StringPtr(workflowID),
+			FirstEventId: common.Int64Ptr(firstEventID),
+		},
+	}
+}
@@ -37,11 +37,11 @@ type (
 
 // NewConsumer is used to create a Kafka consumer
 func (c *kafkaClient) NewConsumer(currentCluster, sourceCluster, consumerName string, concurrency int) (kafka.Consumer, error) {
-	currentTopics := c.config.getTopicsForCadenceCluster(currentCluster)
 	sourceTopics := c.config.getTopicsForCadenceCluster(sourceCluster)
+	dlqTopic := c.config.getDLQTopic(currentCluster, sourceCluster)
 
 	topicKafkaCluster := c.config.getKafkaClusterForTopic(sourceTopics.Topic)
-	dqlTopicKafkaCluster := c.config.getKafkaClusterForTopic(currentTopics.DLQTopic)
+	dqlTopicKafkaCluster := c.config.getKafkaClusterForTopic(dlqTopic)
 	topicList := kafka.ConsumerTopicList{
 		kafka.ConsumerTopic{
 			Topic: kafka.Topic{
@@ -50,7 +50,7 @@ func (c *kafkaClient) NewConsumer(currentCluster, sourceCluster, consumerName st
 				BrokerList: c.config.getBrokersForKafkaCluster(topicKafkaCluster),
 			},
 			DLQ: kafka.Topic{
-				Name:       currentTopics.DLQTopic,
+				Name:       dlqTopic,
 				Cluster:    dqlTopicKafkaCluster,
 				BrokerList: c.config.getBrokersForKafkaCluster(dqlTopicKafkaCluster),
 			},
@@ -71,7 +71,13 @@ func (c *kafkaClient) NewProducer(sourceCluster string) (Producer, error) {
 	kafkaClusterName := c.config.getKafkaClusterForTopic(topics.Topic)
 	brokers := c.config.getBrokersForKafkaCluster(kafkaClusterName)
 
-	producer, err := sarama.NewSyncProducer(brokers, nil)
+	producer, err := NewRefreshingSyncProducer(func() (sarama.SyncProducer, error) {
+		config, err := c.config.NewProducerConfig(topics.Topic)
+		if err != nil {
+			return nil, err
+		}
+		return sarama.NewSyncProducer(brokers, config)
+	}, c.logger)
 	if err != nil {
 		return nil, err
 	}
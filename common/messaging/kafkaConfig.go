@@ -24,11 +24,14 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Shopify/sarama"
 	"github.com/uber-common/bark"
 	"github.com/uber-go/kafka-client"
 	"github.com/uber-go/kafka-client/kafka"
 	"github.com/uber-go/tally"
 	"go.uber.org/zap"
+
+	"github.com/uber/cadence/common/credentials"
 )
 
 type (
@@ -42,6 +45,13 @@ type (
 	// ClusterConfig describes the configuration for a single Kafka cluster
 	ClusterConfig struct {
 		Brokers []string `yaml:"brokers"`
+		// User and Password enable the SASL/PLAIN authentication of the producers against the cluster, the clusters
+		// hosting replication topics cannot have any as the consumers of these do not authenticate
+		User     string `yaml:"user"`
+		Password string `yaml:"password"`
+		// Credentials is the provider of the user and the password, used instead of the static ones above when
+		// they should not be kept in the config file
+		Credentials credentials.Config `yaml:"credentials"`
 	}
 
 	// TopicConfig describes the mapping from topic to Kafka cluster
@@ -54,6 +64,9 @@ type (
 		Topic      string `yaml:"topic"`
 		RetryTopic string `yaml:"retry-topic"`
 		DLQTopic   string `yaml:"dlq-topic"`
+		// DLQTopics optionally maps a source cluster to the DLQ topic of the tasks replicated from it,
+		// tasks of sources not listed go to DLQTopic
+		DLQTopics map[string]string `yaml:"dlq-topics"`
 	}
 )
 
@@ -107,6 +120,10 @@ func (k *KafkaConfig) validate() {
 			panic(fmt.Sprintf("Missing Kafka Cluster Config for Cluster %v", topicConfig.Cluster))
 		} else if len(clusterConfig.Brokers) == 0 {
 			panic(fmt.Sprintf("Missing Kafka Brokers Config for Cluster %v", topicConfig.Cluster))
+		} else if clusterConfig.hasCredentials() {
+			// the replication topics are consumed, and their DLQ and retry topics produced to, by the kafka client
+			// library, which cannot authenticate
+			panic(fmt.Sprintf("Kafka Cluster %v of Replication Topic %v cannot use credentials", topicConfig.Cluster, topic))
 		}
 	}
 
@@ -114,13 +131,63 @@ func (k *KafkaConfig) validate() {
 		validateTopicsFn(topics.Topic)
 		validateTopicsFn(topics.RetryTopic)
 		validateTopicsFn(topics.DLQTopic)
+		for sourceCluster, dlqTopic := range topics.DLQTopics {
+			if _, ok := k.ClusterToTopic[sourceCluster]; !ok {
+				panic(fmt.Sprintf("Unknown source cluster %v of DLQ topic %v", sourceCluster, dlqTopic))
+			}
+			validateTopicsFn(dlqTopic)
+		}
+	}
+}
+
+// GetBrokersForTopic returns the brokers of the kafka cluster which hosts the given topic
+func (k *KafkaConfig) GetBrokersForTopic(topic string) []string {
+	return k.getBrokersForKafkaCluster(k.getKafkaClusterForTopic(topic))
+}
+
+// NewProducerConfig returns the sarama config of a producer of the given topic. The producer authenticates with
+// the credentials its kafka cluster provides at the time it is created, the config is nil if the cluster has none.
+// Only the producers of topics outside of the replication topics, such as the audit topic, can authenticate.
+func (k *KafkaConfig) NewProducerConfig(topic string) (*sarama.Config, error) {
+	cluster := k.Clusters[k.getKafkaClusterForTopic(topic)]
+	credentialsProvider, err := cluster.Credentials.NewProvider(cluster.User, cluster.Password)
+	if err != nil {
+		return nil, err
 	}
+	creds, err := credentialsProvider.GetCredentials()
+	if err != nil {
+		return nil, err
+	}
+	if creds.User == "" {
+		return nil, nil
+	}
+
+	config := sarama.NewConfig()
+	// required by the sync producers
+	config.Producer.Return.Successes = true
+	config.Net.SASL.Enable = true
+	config.Net.SASL.User = creds.User
+	config.Net.SASL.Password = creds.Password
+	return config, nil
+}
+
+func (c *ClusterConfig) hasCredentials() bool {
+	return c.User != "" || c.Password != "" || c.Credentials.Provider != ""
 }
 
 func (k *KafkaConfig) getTopicsForCadenceCluster(cadenceCluster string) TopicList {
 	return k.ClusterToTopic[cadenceCluster]
 }
 
+// getDLQTopic returns the DLQ topic of the tasks the current cluster consumes from the source cluster
+func (k *KafkaConfig) getDLQTopic(currentCluster, sourceCluster string) string {
+	topics := k.getTopicsForCadenceCluster(currentCluster)
+	if dlqTopic, ok := topics.DLQTopics[sourceCluster]; ok {
+		return dlqTopic
+	}
+	return topics.DLQTopic
+}
+
 func (k *KafkaConfig) getKafkaClusterForTopic(topic string) string {
 	return k.Topics[topic].Cluster
 }
@@ -0,0 +1,127 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/uber/cadence/common/credentials"
+)
+
+type (
+	kafkaConfigSuite struct {
+		suite.Suite
+	}
+)
+
+func TestKafkaConfigSuite(t *testing.T) {
+	s := new(kafkaConfigSuite)
+	suite.Run(t, s)
+}
+
+func (s *kafkaConfigSuite) newConfig() *KafkaConfig {
+	topics := map[string]TopicConfig{}
+	for _, topic := range []string{"a", "a-retry", "a-dlq", "b", "b-retry", "b-dlq", "c", "c-retry", "c-dlq", "c-dlq-from-a"} {
+		topics[topic] = TopicConfig{Cluster: "kafka"}
+	}
+	topics["audit"] = TopicConfig{Cluster: "audit-kafka"}
+	return &KafkaConfig{
+		Clusters: map[string]ClusterConfig{
+			"kafka":       {Brokers: []string{"127.0.0.1:9092"}},
+			"audit-kafka": {Brokers: []string{"127.0.0.2:9092"}},
+		},
+		Topics: topics,
+		ClusterToTopic: map[string]TopicList{
+			"a": {Topic: "a", RetryTopic: "a-retry", DLQTopic: "a-dlq"},
+			"b": {Topic: "b", RetryTopic: "b-retry", DLQTopic: "b-dlq"},
+			"c": {Topic: "c", RetryTopic: "c-retry", DLQTopic: "c-dlq", DLQTopics: map[string]string{"a": "c-dlq-from-a"}},
+		},
+	}
+}
+
+func (s *kafkaConfigSuite) TestGetDLQTopic() {
+	config := s.newConfig()
+	s.NotPanics(config.validate)
+
+	s.Equal("c-dlq-from-a", config.getDLQTopic("c", "a"))
+	s.Equal("c-dlq", config.getDLQTopic("c", "b"))
+	s.Equal("a-dlq", config.getDLQTopic("a", "c"))
+}
+
+func (s *kafkaConfigSuite) TestValidateDLQTopics() {
+	config := s.newConfig()
+	config.ClusterToTopic["b"] = TopicList{Topic: "b", RetryTopic: "b-retry", DLQTopic: "b-dlq",
+		DLQTopics: map[string]string{"unknown": "b-dlq"}}
+	s.Panics(config.validate)
+
+	config = s.newConfig()
+	config.ClusterToTopic["b"] = TopicList{Topic: "b", RetryTopic: "b-retry", DLQTopic: "b-dlq",
+		DLQTopics: map[string]string{"a": "missing-topic"}}
+	s.Panics(config.validate)
+}
+
+func (s *kafkaConfigSuite) TestNewProducerConfig() {
+	config := s.newConfig()
+	producerConfig, err := config.NewProducerConfig("audit")
+	s.NoError(err)
+	s.Nil(producerConfig)
+
+	config.Clusters["audit-kafka"] = ClusterConfig{
+		Brokers:  []string{"127.0.0.2:9092"},
+		User:     "cadence",
+		Password: "secret",
+	}
+	s.NotPanics(config.validate)
+	producerConfig, err = config.NewProducerConfig("audit")
+	s.NoError(err)
+	s.True(producerConfig.Producer.Return.Successes)
+	s.True(producerConfig.Net.SASL.Enable)
+	s.Equal("cadence", producerConfig.Net.SASL.User)
+	s.Equal("secret", producerConfig.Net.SASL.Password)
+
+	config.Clusters["audit-kafka"] = ClusterConfig{
+		Brokers:     []string{"127.0.0.2:9092"},
+		User:        "cadence",
+		Credentials: credentials.Config{Provider: credentials.ProviderEnv, PasswordEnv: "CADENCE_TEST_KAFKA_PASSWORD"},
+	}
+	_, err = config.NewProducerConfig("audit")
+	s.Error(err)
+}
+
+func (s *kafkaConfigSuite) TestValidateCredentials() {
+	// the kafka client library consuming the replication topics cannot authenticate
+	config := s.newConfig()
+	config.Clusters["kafka"] = ClusterConfig{
+		Brokers:  []string{"127.0.0.1:9092"},
+		User:     "cadence",
+		Password: "secret",
+	}
+	s.Panics(config.validate)
+
+	config = s.newConfig()
+	config.Clusters["kafka"] = ClusterConfig{
+		Brokers:     []string{"127.0.0.1:9092"},
+		Credentials: credentials.Config{Provider: credentials.ProviderEnv, PasswordEnv: "CADENCE_TEST_KAFKA_PASSWORD"},
+	}
+	s.Panics(config.validate)
+}
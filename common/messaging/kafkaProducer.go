@@ -136,6 +136,11 @@ func (p *kafkaProducer) getKey(task *replicator.ReplicationTask) sarama.Encoder
 		// the messaging layer perspective
 		attributes := task.HistoryTaskAttributes
 		return sarama.StringEncoder(attributes.GetWorkflowId())
+	case replicator.ReplicationTaskTypeSyncActivity:
+		// same partition as the history replication tasks of the workflow, so the activity is synced after the
+		// events scheduling it
+		attributes := task.SyncActivityTaskAttributes
+		return sarama.StringEncoder(attributes.GetWorkflowId())
 	}
 
 	return nil
@@ -0,0 +1,121 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/logging"
+)
+
+type (
+	refreshingSyncProducer struct {
+		newProducer func() (sarama.SyncProducer, error)
+		logger      bark.Logger
+
+		sync.RWMutex
+		producer sarama.SyncProducer
+	}
+)
+
+// NewRefreshingSyncProducer returns a sync producer which is re-created by newProducer when the brokers cannot be
+// reached. The brokers close the connection of a producer whose credentials they reject, so a producer created with
+// credentials rotated since then fails with no broker left, and its replacement authenticates with the current ones.
+func NewRefreshingSyncProducer(newProducer func() (sarama.SyncProducer, error), logger bark.Logger) (sarama.SyncProducer, error) {
+	producer, err := newProducer()
+	if err != nil {
+		return nil, err
+	}
+	return &refreshingSyncProducer{
+		newProducer: newProducer,
+		logger:      logger,
+		producer:    producer,
+	}, nil
+}
+
+func (p *refreshingSyncProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	producer := p.getProducer()
+	partition, offset, err := producer.SendMessage(msg)
+	if isBrokerUnavailableError(err) {
+		p.refresh(producer)
+	}
+	return partition, offset, err
+}
+
+func (p *refreshingSyncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	producer := p.getProducer()
+	err := producer.SendMessages(msgs)
+	if isBrokerUnavailableError(err) {
+		p.refresh(producer)
+	}
+	return err
+}
+
+func (p *refreshingSyncProducer) Close() error {
+	p.Lock()
+	defer p.Unlock()
+	return p.producer.Close()
+}
+
+func (p *refreshingSyncProducer) getProducer() sarama.SyncProducer {
+	p.RLock()
+	defer p.RUnlock()
+	return p.producer
+}
+
+// refresh replaces the failed producer, unless a concurrent send replaced it already. The failed producer is kept
+// when no new one can be created, the next failed send tries again.
+func (p *refreshingSyncProducer) refresh(failed sarama.SyncProducer) {
+	p.Lock()
+	defer p.Unlock()
+	if p.producer != failed {
+		return
+	}
+
+	producer, err := p.newProducer()
+	if err != nil {
+		p.logger.WithFields(bark.Fields{
+			logging.TagErr: err,
+		}).Warn("Failed to re-create kafka producer")
+		return
+	}
+	if err := failed.Close(); err != nil {
+		p.logger.WithFields(bark.Fields{
+			logging.TagErr: err,
+		}).Warn("Failed to close replaced kafka producer")
+	}
+	p.producer = producer
+	p.logger.Info("Re-created kafka producer")
+}
+
+func isBrokerUnavailableError(err error) bool {
+	if errs, ok := err.(sarama.ProducerErrors); ok {
+		for _, producerErr := range errs {
+			if producerErr.Err == sarama.ErrOutOfBrokers {
+				return true
+			}
+		}
+		return false
+	}
+	return err == sarama.ErrOutOfBrokers
+}
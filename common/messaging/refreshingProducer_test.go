@@ -0,0 +1,127 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+)
+
+type (
+	refreshingProducerSuite struct {
+		suite.Suite
+		producers []*fakeSyncProducer
+		createErr error
+		producer  sarama.SyncProducer
+	}
+
+	fakeSyncProducer struct {
+		sendErr error
+		sent    int
+		closed  bool
+	}
+)
+
+func TestRefreshingProducerSuite(t *testing.T) {
+	s := new(refreshingProducerSuite)
+	suite.Run(t, s)
+}
+
+func (s *refreshingProducerSuite) SetupTest() {
+	s.producers = nil
+	s.createErr = nil
+	producer, err := NewRefreshingSyncProducer(func() (sarama.SyncProducer, error) {
+		if s.createErr != nil {
+			return nil, s.createErr
+		}
+		producer := &fakeSyncProducer{}
+		s.producers = append(s.producers, producer)
+		return producer, nil
+	}, bark.NewLoggerFromLogrus(log.New()))
+	s.NoError(err)
+	s.producer = producer
+}
+
+func (s *refreshingProducerSuite) TestSendMessage_Refresh() {
+	_, _, err := s.producer.SendMessage(&sarama.ProducerMessage{})
+	s.NoError(err)
+	s.Equal(1, len(s.producers))
+
+	// errors other than the brokers being unavailable keep the producer
+	s.producers[0].sendErr = sarama.ErrMessageSizeTooLarge
+	_, _, err = s.producer.SendMessage(&sarama.ProducerMessage{})
+	s.Equal(sarama.ErrMessageSizeTooLarge, err)
+	s.Equal(1, len(s.producers))
+
+	s.producers[0].sendErr = sarama.ErrOutOfBrokers
+	_, _, err = s.producer.SendMessage(&sarama.ProducerMessage{})
+	s.Equal(sarama.ErrOutOfBrokers, err)
+	s.Equal(2, len(s.producers))
+	s.True(s.producers[0].closed)
+
+	_, _, err = s.producer.SendMessage(&sarama.ProducerMessage{})
+	s.NoError(err)
+	s.Equal(1, s.producers[1].sent)
+}
+
+func (s *refreshingProducerSuite) TestSendMessages_RefreshFailure() {
+	s.producers[0].sendErr = sarama.ProducerErrors{&sarama.ProducerError{Err: sarama.ErrOutOfBrokers}}
+	s.createErr = errors.New("some random error")
+	s.Error(s.producer.SendMessages([]*sarama.ProducerMessage{{}}))
+	// the failed producer is kept until a new one can be created
+	s.Equal(1, len(s.producers))
+	s.False(s.producers[0].closed)
+
+	s.createErr = nil
+	s.Error(s.producer.SendMessages([]*sarama.ProducerMessage{{}}))
+	s.Equal(2, len(s.producers))
+	s.True(s.producers[0].closed)
+
+	s.NoError(s.producer.SendMessages([]*sarama.ProducerMessage{{}}))
+	s.NoError(s.producer.Close())
+	s.True(s.producers[1].closed)
+}
+
+func (p *fakeSyncProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	if p.sendErr != nil {
+		return -1, -1, p.sendErr
+	}
+	p.sent++
+	return 0, int64(p.sent), nil
+}
+
+func (p *fakeSyncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	if p.sendErr != nil {
+		return p.sendErr
+	}
+	p.sent += len(msgs)
+	return nil
+}
+
+func (p *fakeSyncProducer) Close() error {
+	p.closed = true
+	return nil
+}
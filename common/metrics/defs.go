@@ -67,6 +67,14 @@ const (
 	OperationTagName = "operation"
 	// ShardTagName is temporary until we can get all metric data removed for the service
 	ShardTagName = "shard"
+	// DomainTagName, SourceClusterTagName, TargetClusterTagName and EventTypeTagName are used by replication metrics
+	DomainTagName        = "domain"
+	SourceClusterTagName = "source_cluster"
+	TargetClusterTagName = "target_cluster"
+	EventTypeTagName     = "event_type"
+	// TaskListTagName and TaskListTypeTagName are used by the schedule-to-start latency of matching
+	TaskListTagName     = "tasklist"
+	TaskListTypeTagName = "tasklist_type"
 )
 
 // This package should hold all the metrics and tags for cadence
@@ -74,6 +82,10 @@ const (
 	UnknownDirectoryTagValue = "Unknown"
 	AllShardsTagValue        = "ALL"
 	NoneShardsTagValue       = "NONE"
+	// NoneDomainTagValue tags the replication tasks which could not be decoded
+	NoneDomainTagValue = "NONE"
+	// OtherTaskListsTagValue tags the task lists beyond the limit of tagged task lists
+	OtherTaskListsTagValue = "_other_"
 )
 
 // Common service base metrics
@@ -128,6 +140,8 @@ const (
 	PersistenceResetMutableStateScope
 	// PersistenceDeleteWorkflowExecutionScope tracks DeleteWorkflowExecution calls made by service to persistence layer
 	PersistenceDeleteWorkflowExecutionScope
+	// PersistenceDeleteCurrentWorkflowExecutionScope tracks DeleteCurrentWorkflowExecution calls made by service to persistence layer
+	PersistenceDeleteCurrentWorkflowExecutionScope
 	// PersistenceGetCurrentExecutionScope tracks GetCurrentExecution calls made by service to persistence layer
 	PersistenceGetCurrentExecutionScope
 	// PersistenceGetTransferTasksScope tracks GetTransferTasks calls made by service to persistence layer
@@ -138,6 +152,8 @@ const (
 	PersistenceCompleteTransferTaskScope
 	// PersistenceCompleteReplicationTaskScope tracks CompleteReplicationTasks calls made by service to persistence layer
 	PersistenceCompleteReplicationTaskScope
+	// PersistenceRangeCompleteReplicationTaskScope tracks RangeCompleteReplicationTask calls made by service to persistence layer
+	PersistenceRangeCompleteReplicationTaskScope
 	// PersistenceGetTimerIndexTasksScope tracks GetTimerIndexTasks calls made by service to persistence layer
 	PersistenceGetTimerIndexTasksScope
 	// PersistenceCompleteTimerTaskScope tracks CompleteTimerTasks calls made by service to persistence layer
@@ -152,12 +168,26 @@ const (
 	PersistenceLeaseTaskListScope
 	// PersistenceUpdateTaskListScope tracks PersistenceUpdateTaskListScope calls made by service to persistence layer
 	PersistenceUpdateTaskListScope
+	// PersistenceListTaskListScope tracks ListTaskList calls made by service to persistence layer
+	PersistenceListTaskListScope
+	// PersistenceCreateDLQTaskScope tracks CreateDLQTask calls made by service to persistence layer
+	PersistenceCreateDLQTaskScope
+	// PersistenceGetDLQTasksScope tracks GetDLQTasks calls made by service to persistence layer
+	PersistenceGetDLQTasksScope
+	// PersistenceDeleteDLQTaskScope tracks DeleteDLQTask calls made by service to persistence layer
+	PersistenceDeleteDLQTaskScope
 	// PersistenceAppendHistoryEventsScope tracks AppendHistoryEvents calls made by service to persistence layer
 	PersistenceAppendHistoryEventsScope
 	// PersistenceGetWorkflowExecutionHistoryScope tracks GetWorkflowExecutionHistory calls made by service to persistence layer
 	PersistenceGetWorkflowExecutionHistoryScope
 	// PersistenceDeleteWorkflowExecutionHistoryScope tracks DeleteWorkflowExecutionHistory calls made by service to persistence layer
 	PersistenceDeleteWorkflowExecutionHistoryScope
+	// PersistenceListHistoryBatchesScope tracks ListHistoryBatches calls made by service to persistence layer
+	PersistenceListHistoryBatchesScope
+	// PersistenceReencodeHistoryBatchScope tracks ReencodeHistoryBatch calls made by service to persistence layer
+	PersistenceReencodeHistoryBatchScope
+	// PersistenceDeleteHistoryBatchScope tracks DeleteHistoryBatch calls made by service to persistence layer
+	PersistenceDeleteHistoryBatchScope
 	// PersistenceCreateDomainScope tracks CreateDomain calls made by service to persistence layer
 	PersistenceCreateDomainScope
 	// PersistenceGetDomainScope tracks GetDomain calls made by service to persistence layer
@@ -190,8 +220,22 @@ const (
 	PersistenceListClosedWorkflowExecutionsByWorkflowIDScope
 	// PersistenceListClosedWorkflowExecutionsByStatusScope tracks ListClosedWorkflowExecutionsByStatus calls made by service to persistence layer
 	PersistenceListClosedWorkflowExecutionsByStatusScope
+	// PersistenceListOpenWorkflowExecutionsByTagScope tracks ListOpenWorkflowExecutionsByTag calls made by service to persistence layer
+	PersistenceListOpenWorkflowExecutionsByTagScope
+	// PersistenceListClosedWorkflowExecutionsByTagScope tracks ListClosedWorkflowExecutionsByTag calls made by service to persistence layer
+	PersistenceListClosedWorkflowExecutionsByTagScope
+	// PersistenceListOpenWorkflowExecutionsByFirstRunIDScope tracks ListOpenWorkflowExecutionsByFirstRunID calls made by service to persistence layer
+	PersistenceListOpenWorkflowExecutionsByFirstRunIDScope
+	// PersistenceListClosedWorkflowExecutionsByFirstRunIDScope tracks ListClosedWorkflowExecutionsByFirstRunID calls made by service to persistence layer
+	PersistenceListClosedWorkflowExecutionsByFirstRunIDScope
 	// PersistenceGetClosedWorkflowExecutionScope tracks GetClosedWorkflowExecution calls made by service to persistence layer
 	PersistenceGetClosedWorkflowExecutionScope
+	// PersistenceVisibilityDeleteWorkflowExecutionScope tracks visibility DeleteWorkflowExecution calls made by service to persistence layer
+	PersistenceVisibilityDeleteWorkflowExecutionScope
+	// PersistencePruneClosedWorkflowExecutionsScope tracks PruneClosedWorkflowExecutions calls made by service to persistence layer
+	PersistencePruneClosedWorkflowExecutionsScope
+	// PersistenceListAllWorkflowExecutionsScope tracks ListAllWorkflowExecutions calls made by service to persistence layer
+	PersistenceListAllWorkflowExecutionsScope
 	// HistoryClientStartWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientStartWorkflowExecutionScope
 	// HistoryClientRecordActivityTaskHeartbeatScope tracks RPC calls to history service
@@ -208,6 +252,8 @@ const (
 	HistoryClientRespondActivityTaskCanceledScope
 	// HistoryClientGetMutableStateScope tracks RPC calls to history service
 	HistoryClientGetMutableStateScope
+	// HistoryClientGetMutableStatesScope tracks RPC calls to history service
+	HistoryClientGetMutableStatesScope
 	// HistoryClientResetStickyTaskListScope tracks RPC calls to history service
 	HistoryClientResetStickyTaskListScope
 	// HistoryClientDescribeWorkflowExecutionScope tracks RPC calls to history service
@@ -220,6 +266,10 @@ const (
 	HistoryClientRequestCancelWorkflowExecutionScope
 	// HistoryClientSignalWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientSignalWorkflowExecutionScope
+	// HistoryClientUpdateWorkflowExecutionOptionsScope tracks RPC calls to history service
+	HistoryClientUpdateWorkflowExecutionOptionsScope
+	// HistoryClientDeleteWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientDeleteWorkflowExecutionScope
 	// HistoryClientSignalWithStartWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientSignalWithStartWorkflowExecutionScope
 	// HistoryClientRemoveSignalMutableStateScope tracks RPC calls to history service
@@ -234,6 +284,10 @@ const (
 	HistoryClientReplicateEventsScope
 	// HistoryClientSyncShardStatusScope tracks RPC calls to history service
 	HistoryClientSyncShardStatusScope
+	// HistoryClientSyncActivityScope tracks RPC calls to history service
+	HistoryClientSyncActivityScope
+	// HistoryClientRefreshDomainCacheScope tracks RPC calls to history service
+	HistoryClientRefreshDomainCacheScope
 	// MatchingClientPollForDecisionTaskScope tracks RPC calls to matching service
 	MatchingClientPollForDecisionTaskScope
 	// MatchingClientPollForActivityTaskScope tracks RPC calls to matching service
@@ -250,8 +304,46 @@ const (
 	MatchingClientCancelOutstandingPollScope
 	// MatchingClientDescribeTaskListScope tracks RPC calls to matching service
 	MatchingClientDescribeTaskListScope
+	// MatchingClientGetTaskListsByDomainScope tracks RPC calls to matching service
+	MatchingClientGetTaskListsByDomainScope
 	// DomainCacheScope tracks domain cache callbacks
 	DomainCacheScope
+	// HistoryClientUpdateWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientUpdateWorkflowExecutionScope
+	// MatchingClientRecordWorkerHeartbeatScope tracks RPC calls to matching service
+	MatchingClientRecordWorkerHeartbeatScope
+	// MatchingClientListTaskListDLQTasksScope tracks RPC calls to matching service
+	MatchingClientListTaskListDLQTasksScope
+	// MatchingClientRequeueTaskListDLQTasksScope tracks RPC calls to matching service
+	MatchingClientRequeueTaskListDLQTasksScope
+	// MatchingClientDescribeTaskListLatencyScope tracks RPC calls to matching service
+	MatchingClientDescribeTaskListLatencyScope
+	// MatchingClientForceUnloadTaskListScope tracks RPC calls to matching service
+	MatchingClientForceUnloadTaskListScope
+	// MatchingClientRefreshDomainCacheScope tracks RPC calls to matching service
+	MatchingClientRefreshDomainCacheScope
+	// HistoryClientRatelimitUpdateScope tracks RPC calls to history service
+	HistoryClientRatelimitUpdateScope
+	// PersistenceCreateScheduleScope tracks CreateSchedule calls made by service to persistence layer
+	PersistenceCreateScheduleScope
+	// PersistenceGetScheduleScope tracks GetSchedule calls made by service to persistence layer
+	PersistenceGetScheduleScope
+	// PersistenceUpdateScheduleScope tracks UpdateSchedule calls made by service to persistence layer
+	PersistenceUpdateScheduleScope
+	// PersistenceDeleteScheduleScope tracks DeleteSchedule calls made by service to persistence layer
+	PersistenceDeleteScheduleScope
+	// PersistenceListSchedulesScope tracks ListSchedules calls made by service to persistence layer
+	PersistenceListSchedulesScope
+	// HistoryClientFixWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientFixWorkflowExecutionScope
+	// PersistencePutReplicationDLQMessageScope tracks PutReplicationDLQMessage calls made by service to persistence layer
+	PersistencePutReplicationDLQMessageScope
+	// PersistenceDeleteReplicationDLQMessageScope tracks DeleteReplicationDLQMessage calls made by service to persistence layer
+	PersistenceDeleteReplicationDLQMessageScope
+	// PersistenceGetReplicationDLQSummaryScope tracks GetReplicationDLQSummary calls made by service to persistence layer
+	PersistenceGetReplicationDLQSummaryScope
+	// PersistenceListDomainExecutionsScope tracks ListDomainExecutions calls made by service to persistence layer
+	PersistenceListDomainExecutionsScope
 
 	NumCommonScopes
 )
@@ -290,6 +382,8 @@ const (
 	FrontendGetWorkflowExecutionHistoryScope
 	// FrontendSignalWorkflowExecutionScope is the metric scope for frontend.SignalWorkflowExecution
 	FrontendSignalWorkflowExecutionScope
+	// FrontendUpdateWorkflowExecutionOptionsScope is the metric scope for frontend.UpdateWorkflowExecutionOptions
+	FrontendUpdateWorkflowExecutionOptionsScope
 	// FrontendSignalWithStartWorkflowExecutionScope is the metric scope for frontend.SignalWithStartWorkflowExecution
 	FrontendSignalWithStartWorkflowExecutionScope
 	// FrontendTerminateWorkflowExecutionScope is the metric scope for frontend.TerminateWorkflowExecution
@@ -314,10 +408,32 @@ const (
 	FrontendDescribeWorkflowExecutionScope
 	// FrontendDescribeTaskListScope is the metric scope for frontend.DescribeTaskList
 	FrontendDescribeTaskListScope
+	// FrontendGetTaskListsByDomainScope is the metric scope for frontend.GetTaskListsByDomain
+	FrontendGetTaskListsByDomainScope
 	// FrontendResetStickyTaskListScope is the metric scope for frontend.ResetStickyTaskList
 	FrontendResetStickyTaskListScope
 	// FrontendListDomainsScope is the metric scope for frontend.ListDomain
 	FrontendListDomainsScope
+	// FrontendUpdateWorkflowExecutionScope is the metric scope for frontend.UpdateWorkflowExecution
+	FrontendUpdateWorkflowExecutionScope
+	// FrontendRecordWorkerHeartbeatScope is the metric scope for frontend.RecordWorkerHeartbeat
+	FrontendRecordWorkerHeartbeatScope
+	// FrontendGetWorkflowExecutionResultScope is the metric scope for frontend.GetWorkflowExecutionResult
+	FrontendGetWorkflowExecutionResultScope
+	// FrontendGetWorkflowExecutionGraphScope is the metric scope for frontend.GetWorkflowExecutionGraph
+	FrontendGetWorkflowExecutionGraphScope
+	// FrontendCreateScheduleScope is the metric scope for frontend.CreateSchedule
+	FrontendCreateScheduleScope
+	// FrontendDescribeScheduleScope is the metric scope for frontend.DescribeSchedule
+	FrontendDescribeScheduleScope
+	// FrontendUpdateScheduleScope is the metric scope for frontend.UpdateSchedule
+	FrontendUpdateScheduleScope
+	// FrontendDeleteScheduleScope is the metric scope for frontend.DeleteSchedule
+	FrontendDeleteScheduleScope
+	// FrontendListSchedulesScope is the metric scope for frontend.ListSchedules
+	FrontendListSchedulesScope
+	// FrontendListWorkflowExecutionsScope is the metric scope for frontend.ListWorkflowExecutions
+	FrontendListWorkflowExecutionsScope
 
 	NumFrontendScopes
 )
@@ -340,6 +456,8 @@ const (
 	HistoryRespondActivityTaskCanceledScope
 	// HistoryGetMutableStateScope tracks GetMutableStateScope API calls received by service
 	HistoryGetMutableStateScope
+	// HistoryGetMutableStatesScope tracks GetMutableStates API calls received by service
+	HistoryGetMutableStatesScope
 	// HistoryResetStickyTaskListScope tracks ResetStickyTaskListScope API calls received by service
 	HistoryResetStickyTaskListScope
 	// HistoryDescribeWorkflowExecutionScope tracks DescribeWorkflowExecution API calls received by service
@@ -350,6 +468,10 @@ const (
 	HistoryRecordActivityTaskStartedScope
 	// HistorySignalWorkflowExecutionScope tracks SignalWorkflowExecution API calls received by service
 	HistorySignalWorkflowExecutionScope
+	// HistoryUpdateWorkflowExecutionOptionsScope tracks UpdateWorkflowExecutionOptions API calls received by service
+	HistoryUpdateWorkflowExecutionOptionsScope
+	// HistoryDeleteWorkflowExecutionScope tracks DeleteWorkflowExecution API calls received by service
+	HistoryDeleteWorkflowExecutionScope
 	// HistorySignalWithStartWorkflowExecutionScope tracks SignalWithStartWorkflowExecution API calls received by service
 	HistorySignalWithStartWorkflowExecutionScope
 	// HistoryRemoveSignalMutableStateScope tracks RemoveSignalMutableState API calls received by service
@@ -366,6 +488,8 @@ const (
 	HistoryReplicateEventsScope
 	// HistorySyncShardStatusScope tracks ReplicateEvents API calls received by service
 	HistorySyncShardStatusScope
+	// HistorySyncActivityScope tracks SyncActivity API calls received by service
+	HistorySyncActivityScope
 	// HistoryShardControllerScope is the scope used by shard controller
 	HistoryShardControllerScope
 	// TransferQueueProcessorScope is the scope used by all metric emitted by transfer queue processor
@@ -434,10 +558,24 @@ const (
 	ReplicatorQueueProcessorScope
 	// ReplicatorTaskHistoryScope is the scope used for history task processing by replicator queue processor
 	ReplicatorTaskHistoryScope
+	// ReplicatorTaskSyncActivityScope is the scope used for sync activity task processing by replicator queue processor
+	ReplicatorTaskSyncActivityScope
 	// ReplicateHistoryEventsScope is the scope used by historyReplicator API for applying events
 	ReplicateHistoryEventsScope
 	// ShardInfoScope is the scope used when updating shard info
 	ShardInfoScope
+	// HistoryShardRebalancerScope is the scope used by shard rebalancer
+	HistoryShardRebalancerScope
+	// WorkflowContextUpdateScope tracks mutable state updates written by the workflow execution context
+	WorkflowContextUpdateScope
+	// HistoryUpdateWorkflowExecutionScope tracks UpdateWorkflowExecution API calls received by service
+	HistoryUpdateWorkflowExecutionScope
+	// HistoryRatelimitUpdateScope tracks RatelimitUpdate API calls received by service
+	HistoryRatelimitUpdateScope
+	// HistoryCacheWarmupScope is the scope used by the warm-up of the history cache after a shard is acquired
+	HistoryCacheWarmupScope
+	// HistoryFixWorkflowExecutionScope tracks FixWorkflowExecution API calls received by service
+	HistoryFixWorkflowExecutionScope
 
 	NumHistoryScopes
 )
@@ -462,6 +600,20 @@ const (
 	MatchingCancelOutstandingPollScope
 	// MatchingDescribeTaskListScope tracks DescribeTaskList API calls received by service
 	MatchingDescribeTaskListScope
+	// MatchingGetTaskListsByDomainScope tracks GetTaskListsByDomain API calls received by service
+	MatchingGetTaskListsByDomainScope
+	// MatchingRecordWorkerHeartbeatScope tracks RecordWorkerHeartbeat API calls received by service
+	MatchingRecordWorkerHeartbeatScope
+	// MatchingListTaskListDLQTasksScope tracks ListTaskListDLQTasks API calls received by service
+	MatchingListTaskListDLQTasksScope
+	// MatchingRequeueTaskListDLQTasksScope tracks RequeueTaskListDLQTasks API calls received by service
+	MatchingRequeueTaskListDLQTasksScope
+	// MatchingDescribeTaskListLatencyScope tracks DescribeTaskListLatency API calls received by service
+	MatchingDescribeTaskListLatencyScope
+	// MatchingForceUnloadTaskListScope tracks ForceUnloadTaskList API calls received by service
+	MatchingForceUnloadTaskListScope
+	// MatchingRefreshDomainCacheScope tracks RefreshDomainCache API calls received by service
+	MatchingRefreshDomainCacheScope
 
 	NumMatchingScopes
 )
@@ -476,6 +628,16 @@ const (
 	HistoryReplicationTaskScope
 	// SyncShardTaskScope is the scope used by sync shrad information processing
 	SyncShardTaskScope
+	// SyncActivityTaskScope is the scope used by sync activity information processing
+	SyncActivityTaskScope
+	// ScheduleProcessorScope is the scope used by the schedule processor
+	ScheduleProcessorScope
+	// VisibilityPrunerScope is the scope used by the visibility pruner
+	VisibilityPrunerScope
+	// HistoryMigratorScope is the scope used by the history migrator
+	HistoryMigratorScope
+	// DomainRegistrationScope is the scope used by the domain registration processor
+	DomainRegistrationScope
 
 	NumWorkerScopes
 )
@@ -492,11 +654,13 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceUpdateWorkflowExecutionScope:                  {operation: "UpdateWorkflowExecution"},
 		PersistenceResetMutableStateScope:                        {operation: "ResetMutableState"},
 		PersistenceDeleteWorkflowExecutionScope:                  {operation: "DeleteWorkflowExecution"},
+		PersistenceDeleteCurrentWorkflowExecutionScope:           {operation: "DeleteCurrentWorkflowExecution"},
 		PersistenceGetCurrentExecutionScope:                      {operation: "GetCurrentExecution"},
 		PersistenceGetTransferTasksScope:                         {operation: "GetTransferTasks"},
 		PersistenceGetReplicationTasksScope:                      {operation: "GetReplicationTasks"},
 		PersistenceCompleteTransferTaskScope:                     {operation: "CompleteTransferTask"},
 		PersistenceCompleteReplicationTaskScope:                  {operation: "CompleteReplicationTask"},
+		PersistenceRangeCompleteReplicationTaskScope:             {operation: "RangeCompleteReplicationTask"},
 		PersistenceGetTimerIndexTasksScope:                       {operation: "GetTimerIndexTasks"},
 		PersistenceCompleteTimerTaskScope:                        {operation: "CompleteTimerTask"},
 		PersistenceCreateTaskScope:                               {operation: "CreateTask", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
@@ -504,9 +668,16 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceCompleteTaskScope:                             {operation: "CompleteTask", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceLeaseTaskListScope:                            {operation: "LeaseTaskList", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceUpdateTaskListScope:                           {operation: "UpdateTaskList", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceListTaskListScope:                             {operation: "ListTaskList", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceCreateDLQTaskScope:                            {operation: "CreateDLQTask", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceGetDLQTasksScope:                              {operation: "GetDLQTasks", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceDeleteDLQTaskScope:                            {operation: "DeleteDLQTask", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceAppendHistoryEventsScope:                      {operation: "AppendHistoryEvents", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceGetWorkflowExecutionHistoryScope:              {operation: "GetWorkflowExecutionHistory", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceDeleteWorkflowExecutionHistoryScope:           {operation: "DeleteWorkflowExecutionHistory", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceListHistoryBatchesScope:                       {operation: "ListHistoryBatches", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceReencodeHistoryBatchScope:                     {operation: "ReencodeHistoryBatch", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceDeleteHistoryBatchScope:                       {operation: "DeleteHistoryBatch", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceCreateDomainScope:                             {operation: "CreateDomain", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceGetDomainScope:                                {operation: "GetDomain", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceUpdateDomainScope:                             {operation: "UpdateDomain", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
@@ -523,7 +694,14 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceListOpenWorkflowExecutionsByWorkflowIDScope:   {operation: "ListOpenWorkflowExecutionsByWorkflowID"},
 		PersistenceListClosedWorkflowExecutionsByWorkflowIDScope: {operation: "ListClosedWorkflowExecutionsByWorkflowID"},
 		PersistenceListClosedWorkflowExecutionsByStatusScope:     {operation: "ListClosedWorkflowExecutionsByStatus"},
+		PersistenceListOpenWorkflowExecutionsByTagScope:          {operation: "ListOpenWorkflowExecutionsByTag"},
+		PersistenceListClosedWorkflowExecutionsByTagScope:        {operation: "ListClosedWorkflowExecutionsByTag"},
+		PersistenceListOpenWorkflowExecutionsByFirstRunIDScope:   {operation: "ListOpenWorkflowExecutionsByFirstRunID"},
+		PersistenceListClosedWorkflowExecutionsByFirstRunIDScope: {operation: "ListClosedWorkflowExecutionsByFirstRunID"},
 		PersistenceGetClosedWorkflowExecutionScope:               {operation: "GetClosedWorkflowExecution"},
+		PersistenceVisibilityDeleteWorkflowExecutionScope:        {operation: "VisibilityDeleteWorkflowExecution"},
+		PersistencePruneClosedWorkflowExecutionsScope:            {operation: "PruneClosedWorkflowExecutions"},
+		PersistenceListAllWorkflowExecutionsScope:                {operation: "ListAllWorkflowExecutions"},
 
 		HistoryClientStartWorkflowExecutionScope:           {operation: "HistoryClientStartWorkflowExecution"},
 		HistoryClientRecordActivityTaskHeartbeatScope:      {operation: "HistoryClientRecordActivityTaskHeartbeat"},
@@ -533,12 +711,15 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientRespondActivityTaskFailedScope:        {operation: "HistoryClientRespondActivityTaskFailed"},
 		HistoryClientRespondActivityTaskCanceledScope:      {operation: "HistoryClientRespondActivityTaskCanceled"},
 		HistoryClientGetMutableStateScope:                  {operation: "HistoryClientGetMutableState"},
+		HistoryClientGetMutableStatesScope:                 {operation: "HistoryClientGetMutableStates"},
 		HistoryClientResetStickyTaskListScope:              {operation: "HistoryClientResetStickyTaskListScope"},
 		HistoryClientDescribeWorkflowExecutionScope:        {operation: "HistoryClientDescribeWorkflowExecution"},
 		HistoryClientRecordDecisionTaskStartedScope:        {operation: "HistoryClientRecordDecisionTaskStarted"},
 		HistoryClientRecordActivityTaskStartedScope:        {operation: "HistoryClientRecordActivityTaskStarted"},
 		HistoryClientRequestCancelWorkflowExecutionScope:   {operation: "HistoryClientRequestCancelWorkflowExecution"},
 		HistoryClientSignalWorkflowExecutionScope:          {operation: "HistoryClientSignalWorkflowExecution"},
+		HistoryClientUpdateWorkflowExecutionOptionsScope:   {operation: "HistoryClientUpdateWorkflowExecutionOptions"},
+		HistoryClientDeleteWorkflowExecutionScope:          {operation: "HistoryClientDeleteWorkflowExecution"},
 		HistoryClientSignalWithStartWorkflowExecutionScope: {operation: "HistoryClientSignalWithStartWorkflowExecution"},
 		HistoryClientRemoveSignalMutableStateScope:         {operation: "HistoryClientRemoveSignalMutableStateScope"},
 		HistoryClientTerminateWorkflowExecutionScope:       {operation: "HistoryClientTerminateWorkflowExecution"},
@@ -546,6 +727,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientRecordChildExecutionCompletedScope:    {operation: "HistoryClientRecordChildExecutionCompleted"},
 		HistoryClientReplicateEventsScope:                  {operation: "HistoryClientReplicateEvents"},
 		HistoryClientSyncShardStatusScope:                  {operation: "HistoryClientSyncShardStatusScope"},
+		HistoryClientSyncActivityScope:                     {operation: "HistoryClientSyncActivity"},
+		HistoryClientRefreshDomainCacheScope:               {operation: "HistoryClientRefreshDomainCache"},
 		MatchingClientPollForDecisionTaskScope:             {operation: "MatchingClientPollForDecisionTask"},
 		MatchingClientPollForActivityTaskScope:             {operation: "MatchingClientPollForActivityTask"},
 		MatchingClientAddActivityTaskScope:                 {operation: "MatchingClientAddActivityTask"},
@@ -554,7 +737,26 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingClientRespondQueryTaskCompletedScope:       {operation: "MatchingClientRespondQueryTaskCompleted"},
 		MatchingClientCancelOutstandingPollScope:           {operation: "MatchingClientCancelOutstandingPoll"},
 		MatchingClientDescribeTaskListScope:                {operation: "MatchingClientDescribeTaskList"},
+		MatchingClientGetTaskListsByDomainScope:            {operation: "MatchingClientGetTaskListsByDomain"},
 		DomainCacheScope:                                   {operation: "DomainCache"},
+		HistoryClientUpdateWorkflowExecutionScope:          {operation: "HistoryClientUpdateWorkflowExecution"},
+		MatchingClientRecordWorkerHeartbeatScope:           {operation: "MatchingClientRecordWorkerHeartbeat"},
+		MatchingClientListTaskListDLQTasksScope:            {operation: "MatchingClientListTaskListDLQTasks"},
+		MatchingClientRequeueTaskListDLQTasksScope:         {operation: "MatchingClientRequeueTaskListDLQTasks"},
+		MatchingClientDescribeTaskListLatencyScope:         {operation: "MatchingClientDescribeTaskListLatency"},
+		MatchingClientForceUnloadTaskListScope:             {operation: "MatchingClientForceUnloadTaskList"},
+		MatchingClientRefreshDomainCacheScope:              {operation: "MatchingClientRefreshDomainCache"},
+		HistoryClientRatelimitUpdateScope:                  {operation: "HistoryClientRatelimitUpdate"},
+		PersistenceCreateScheduleScope:                     {operation: "CreateSchedule"},
+		PersistenceGetScheduleScope:                        {operation: "GetSchedule"},
+		PersistenceUpdateScheduleScope:                     {operation: "UpdateSchedule"},
+		PersistenceDeleteScheduleScope:                     {operation: "DeleteSchedule"},
+		PersistenceListSchedulesScope:                      {operation: "ListSchedules"},
+		HistoryClientFixWorkflowExecutionScope:             {operation: "HistoryClientFixWorkflowExecution"},
+		PersistencePutReplicationDLQMessageScope:           {operation: "PutReplicationDLQMessage"},
+		PersistenceDeleteReplicationDLQMessageScope:        {operation: "DeleteReplicationDLQMessage"},
+		PersistenceGetReplicationDLQSummaryScope:           {operation: "GetReplicationDLQSummary"},
+		PersistenceListDomainExecutionsScope:               {operation: "ListDomainExecutions"},
 	},
 	// Frontend Scope Names
 	Frontend: {
@@ -574,6 +776,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendRespondActivityTaskCanceledByIDScope:  {operation: "RespondActivityTaskCanceledByID"},
 		FrontendGetWorkflowExecutionHistoryScope:      {operation: "GetWorkflowExecutionHistory"},
 		FrontendSignalWorkflowExecutionScope:          {operation: "SignalWorkflowExecution"},
+		FrontendUpdateWorkflowExecutionOptionsScope:   {operation: "UpdateWorkflowExecutionOptions"},
 		FrontendSignalWithStartWorkflowExecutionScope: {operation: "SignalWithStartWorkflowExecution"},
 		FrontendTerminateWorkflowExecutionScope:       {operation: "TerminateWorkflowExecution"},
 		FrontendRequestCancelWorkflowExecutionScope:   {operation: "RequestCancelWorkflowExecution"},
@@ -587,6 +790,17 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendQueryWorkflowScope:                    {operation: "QueryWorkflow"},
 		FrontendDescribeWorkflowExecutionScope:        {operation: "DescribeWorkflowExecution"},
 		FrontendDescribeTaskListScope:                 {operation: "DescribeTaskList"},
+		FrontendGetTaskListsByDomainScope:             {operation: "GetTaskListsByDomain"},
+		FrontendUpdateWorkflowExecutionScope:          {operation: "UpdateWorkflowExecution"},
+		FrontendRecordWorkerHeartbeatScope:            {operation: "RecordWorkerHeartbeat"},
+		FrontendGetWorkflowExecutionResultScope:       {operation: "GetWorkflowExecutionResult"},
+		FrontendGetWorkflowExecutionGraphScope:        {operation: "GetWorkflowExecutionGraph"},
+		FrontendCreateScheduleScope:                   {operation: "CreateSchedule"},
+		FrontendDescribeScheduleScope:                 {operation: "DescribeSchedule"},
+		FrontendUpdateScheduleScope:                   {operation: "UpdateSchedule"},
+		FrontendDeleteScheduleScope:                   {operation: "DeleteSchedule"},
+		FrontendListSchedulesScope:                    {operation: "ListSchedules"},
+		FrontendListWorkflowExecutionsScope:           {operation: "ListWorkflowExecutions"},
 	},
 	// History Scope Names
 	History: {
@@ -598,11 +812,14 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryRespondActivityTaskFailedScope:        {operation: "RespondActivityTaskFailed"},
 		HistoryRespondActivityTaskCanceledScope:      {operation: "RespondActivityTaskCanceled"},
 		HistoryGetMutableStateScope:                  {operation: "GetMutableState"},
+		HistoryGetMutableStatesScope:                 {operation: "GetMutableStates"},
 		HistoryResetStickyTaskListScope:              {operation: "ResetStickyTaskListScope"},
 		HistoryDescribeWorkflowExecutionScope:        {operation: "DescribeWorkflowExecution"},
 		HistoryRecordDecisionTaskStartedScope:        {operation: "RecordDecisionTaskStarted"},
 		HistoryRecordActivityTaskStartedScope:        {operation: "RecordActivityTaskStarted"},
 		HistorySignalWorkflowExecutionScope:          {operation: "SignalWorkflowExecution"},
+		HistoryUpdateWorkflowExecutionOptionsScope:   {operation: "UpdateWorkflowExecutionOptions"},
+		HistoryDeleteWorkflowExecutionScope:          {operation: "DeleteWorkflowExecution"},
 		HistorySignalWithStartWorkflowExecutionScope: {operation: "SignalWithStartWorkflowExecution"},
 		HistoryRemoveSignalMutableStateScope:         {operation: "RemoveSignalMutableState"},
 		HistoryTerminateWorkflowExecutionScope:       {operation: "TerminateWorkflowExecution"},
@@ -611,6 +828,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryRequestCancelWorkflowExecutionScope:   {operation: "RequestCancelWorkflowExecution"},
 		HistoryReplicateEventsScope:                  {operation: "ReplicateEvents"},
 		HistorySyncShardStatusScope:                  {operation: "SyncShardStatus"},
+		HistorySyncActivityScope:                     {operation: "SyncActivity"},
 		HistoryShardControllerScope:                  {operation: "ShardController"},
 		TransferQueueProcessorScope:                  {operation: "TransferQueueProcessor"},
 		TransferActiveQueueProcessorScope:            {operation: "TransferActiveQueueProcessor"},
@@ -645,8 +863,15 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryEventNotificationScope:                {operation: "HistoryEventNotification"},
 		ReplicatorQueueProcessorScope:                {operation: "ReplicatorQueueProcessor"},
 		ReplicatorTaskHistoryScope:                   {operation: "ReplicatorTaskHistory"},
+		ReplicatorTaskSyncActivityScope:              {operation: "ReplicatorTaskSyncActivity"},
 		ReplicateHistoryEventsScope:                  {operation: "ReplicateHistoryEvents"},
 		ShardInfoScope:                               {operation: "ShardInfo"},
+		HistoryShardRebalancerScope:                  {operation: "ShardRebalancer"},
+		WorkflowContextUpdateScope:                   {operation: "WorkflowContextUpdate"},
+		HistoryUpdateWorkflowExecutionScope:          {operation: "UpdateWorkflowExecution"},
+		HistoryRatelimitUpdateScope:                  {operation: "RatelimitUpdate"},
+		HistoryCacheWarmupScope:                      {operation: "HistoryCacheWarmup"},
+		HistoryFixWorkflowExecutionScope:             {operation: "FixWorkflowExecution"},
 	},
 	// Matching Scope Names
 	Matching: {
@@ -659,6 +884,13 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingRespondQueryTaskCompletedScope: {operation: "RespondQueryTaskCompleted"},
 		MatchingCancelOutstandingPollScope:     {operation: "CancelOutstandingPoll"},
 		MatchingDescribeTaskListScope:          {operation: "DescribeTaskList"},
+		MatchingGetTaskListsByDomainScope:      {operation: "GetTaskListsByDomain"},
+		MatchingRecordWorkerHeartbeatScope:     {operation: "RecordWorkerHeartbeat"},
+		MatchingListTaskListDLQTasksScope:      {operation: "ListTaskListDLQTasks"},
+		MatchingRequeueTaskListDLQTasksScope:   {operation: "RequeueTaskListDLQTasks"},
+		MatchingDescribeTaskListLatencyScope:   {operation: "DescribeTaskListLatency"},
+		MatchingForceUnloadTaskListScope:       {operation: "ForceUnloadTaskList"},
+		MatchingRefreshDomainCacheScope:        {operation: "RefreshDomainCache"},
 	},
 	// Worker Scope Names
 	Worker: {
@@ -666,6 +898,11 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		DomainReplicationTaskScope:  {operation: "DomainReplicationTask"},
 		HistoryReplicationTaskScope: {operation: "HistoryReplicationTask"},
 		SyncShardTaskScope:          {operation: "SyncShardTask"},
+		SyncActivityTaskScope:       {operation: "SyncActivityTask"},
+		ScheduleProcessorScope:      {operation: "ScheduleProcessor"},
+		VisibilityPrunerScope:       {operation: "VisibilityPruner"},
+		HistoryMigratorScope:        {operation: "HistoryMigrator"},
+		DomainRegistrationScope:     {operation: "DomainRegistration"},
 	},
 }
 
@@ -686,6 +923,7 @@ const (
 	CadenceErrLimitExceededCounter
 	CadenceErrContextTimeoutCounter
 	CadenceErrRetryTaskCounter
+	CadenceErrHistoryEventsCorruptedCounter
 	PersistenceRequests
 	PersistenceFailures
 	PersistenceLatency
@@ -694,6 +932,8 @@ const (
 	PersistenceErrConditionFailedCounter
 	PersistenceErrTimeoutCounter
 	PersistenceErrBusyCounter
+	PersistenceHedgedRequests
+	PersistenceHedgeWins
 
 	HistoryClientFailures
 	MatchingClientFailures
@@ -701,10 +941,21 @@ const (
 	DomainCacheTotalCallbacksLatency
 	DomainCacheBeforeCallbackLatency
 	DomainCacheAfterCallbackLatency
+	DomainCacheRefreshTriggeredCounter
 
 	NumCommonMetrics // Needs to be last on this list for iota numbering
 )
 
+// Frontend metrics enum
+const (
+	HistoryReadForwardedCounter = iota + NumCommonMetrics
+	HistoryPageCacheHitCounter
+	HistoryPageCacheMissCounter
+	PayloadCompressedCounter
+	PayloadCompressionSavedBytes
+	DecisionChunkForwardedCounter
+)
+
 // History Metrics enum
 const (
 	TaskRequests = iota + NumCommonMetrics
@@ -720,6 +971,7 @@ const (
 	DecisionTypeCancelActivityCounter
 	DecisionTypeCancelTimerCounter
 	DecisionTypeRecordMarkerCounter
+	DecisionTypeCompleteWorkflowUpdateCounter
 	DecisionTypeCancelExternalWorkflowCounter
 	DecisionTypeChildWorkflowCounter
 	DecisionTypeContinueAsNewCounter
@@ -764,6 +1016,61 @@ const (
 	HistoryTaskStandbyRetryCounter
 	HistoryTaskNotActiveCounter
 	HistoryTaskBatchCompleteCounter
+	ShardRebalanceHandoffCounter
+	ShardRebalanceReclaimCounter
+	ShardRebalanceCancelCounter
+	ShardRebalanceHostLoadGauge
+	ShardRebalanceLatency
+	ReplicationEventsEndToEndLatency
+	DecisionTimeoutScaledCounter
+	ReplicationEventsAppliedCounter
+	AsyncDecisionDispatchCounter
+	AsyncDecisionBufferFullCounter
+	HistoryTaskPausedCounter
+	ActivityScheduleToStartTimeoutCappedCounter
+	TaskBacklogAgeGauge
+	ReplicatorTaskPurgeCounter
+	ReplicatorTaskPurgeFailures
+	RetryEntityNotExistsCounter
+	RetryBufferEventsCounter
+	RetryExistingWorkflowCounter
+	RetryExecutionAlreadyStartedCounter
+	CloseEventPublishedCounter
+	CloseEventPublishFailedCounter
+	CloseEventDLQCounter
+	CloseEventDroppedCounter
+	ReplicationTaskNoTargetCounter
+	TimerClockJumpCounter
+	SignalAfterCloseDroppedCounter
+	SignalAfterCloseStartedCounter
+	MutableStateUpdateSizeTimer
+	MutableStateSnapshotCounter
+	ActivityEagerExecutionCounter
+	DecisionEagerExecutionCounter
+	TaskSkippedByFailoverCounter
+	ActivityHeartbeatDeferredCounter
+	FailoverProcessingCompleteCounter
+	CacheWarmupLoadedCounter
+	RetryTimerSuppressedCounter
+	ReplicationPublishShapingLatency
+	ReplicationPublishInFlight
+	StickyNonDeterministicFailureCounter
+	SuspectBinaryChecksumMarkedCounter
+	CompleteDecisionWithSuspectBinaryCounter
+	AppendHistoryEventsFencedCounter
+	ShardTransferAckLevelGauge
+	ShardTransferLagGauge
+	ShardTimerAckLevelGauge
+	ShardTimerLagGauge
+	ShardReplicationAckLevelGauge
+	ShardReplicationLagGauge
+	ShardTransferMaxLagGauge
+	ShardTimerMaxLagGauge
+	ShardReplicationMaxLagGauge
+	QueueProcessorTunedPollRPSGauge
+	QueueProcessorTunedWorkerCountGauge
+	QueueProcessorTuningBackoffCounter
+	PostCloseEventsRecordedCounter
 )
 
 // Matching metrics enum
@@ -777,6 +1084,14 @@ const (
 	RespondQueryTaskFailedCounter
 	SyncThrottleCounter
 	BufferThrottleCounter
+	SyncMatchBudgetExceededCounter
+	SyncMatchPollerGoneCounter
+	IdleTaskListUnloadedCounter
+	IsolationGroupMatchCounter
+	DrainedIsolationGroupPollCounter
+	TaskMovedToDLQCounter
+	TaskRequeuedFromDLQCounter
+	ScheduleToStartLatency
 )
 
 // Worker metrics enum
@@ -784,6 +1099,23 @@ const (
 	ReplicatorMessages = iota + NumCommonMetrics
 	ReplicatorFailures
 	ReplicatorLatency
+	ReplicatorMessagesDLQ
+	ReplicatorMessagesNotTargeted
+	ScheduleRunStarted
+	ScheduleRunSkipped
+	ScheduleRunBuffered
+	ScheduleRunCancelled
+	ScheduleProcessorFailures
+	VisibilityPrunedBuckets
+	VisibilityPrunerFailures
+	ReplicatorDLQDepth
+	ReplicatorDLQOldestMessageAge
+	HistoryMigratorReencodedBatches
+	HistoryMigratorConflicts
+	HistoryMigratorFailures
+	DomainRegistrationApproved
+	DomainRegistrationRejected
+	DomainRegistrationFailures
 )
 
 // MetricDefs record the metrics for all services
@@ -804,6 +1136,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		CadenceErrLimitExceededCounter:                {metricName: "cadence.errors.limit-exceeded", metricType: Counter},
 		CadenceErrContextTimeoutCounter:               {metricName: "cadence.errors.context-timeout", metricType: Counter},
 		CadenceErrRetryTaskCounter:                    {metricName: "cadence.errors.retry-task", metricType: Counter},
+		CadenceErrHistoryEventsCorruptedCounter:       {metricName: "cadence.errors.history-events-corrupted", metricType: Counter},
 		PersistenceRequests:                           {metricName: "persistence.requests", metricType: Counter},
 		PersistenceFailures:                           {metricName: "persistence.errors", metricType: Counter},
 		PersistenceLatency:                            {metricName: "persistence.latency", metricType: Timer},
@@ -812,13 +1145,23 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		PersistenceErrConditionFailedCounter:          {metricName: "persistence.errors.condition-failed", metricType: Counter},
 		PersistenceErrTimeoutCounter:                  {metricName: "persistence.errors.timeout", metricType: Counter},
 		PersistenceErrBusyCounter:                     {metricName: "persistence.errors.busy", metricType: Counter},
+		PersistenceHedgedRequests:                     {metricName: "persistence.hedged-requests", metricType: Counter},
+		PersistenceHedgeWins:                          {metricName: "persistence.hedge-wins", metricType: Counter},
 		HistoryClientFailures:                         {metricName: "client.history.errors", metricType: Counter},
 		MatchingClientFailures:                        {metricName: "client.matching.errors", metricType: Counter},
 		DomainCacheTotalCallbacksLatency:              {metricName: "domain-cache.total-callbacks.latency", metricType: Timer},
 		DomainCacheBeforeCallbackLatency:              {metricName: "domain-cache.before-callbacks.latency", metricType: Timer},
 		DomainCacheAfterCallbackLatency:               {metricName: "domain-cache.after-callbacks.latency", metricType: Timer},
+		DomainCacheRefreshTriggeredCounter:            {metricName: "domain-cache.refresh-triggered", metricType: Counter},
+	},
+	Frontend: {
+		HistoryReadForwardedCounter:   {metricName: "history-read-forwarded", metricType: Counter},
+		HistoryPageCacheHitCounter:    {metricName: "history-page-cache-hit", metricType: Counter},
+		HistoryPageCacheMissCounter:   {metricName: "history-page-cache-miss", metricType: Counter},
+		PayloadCompressedCounter:      {metricName: "payload-compressed", metricType: Counter},
+		PayloadCompressionSavedBytes:  {metricName: "payload-compression-saved-bytes", metricType: Counter},
+		DecisionChunkForwardedCounter: {metricName: "decision-chunk-forwarded", metricType: Counter},
 	},
-	Frontend: {},
 	History: {
 		TaskRequests:                                 {metricName: "task.requests", metricType: Counter},
 		TaskFailures:                                 {metricName: "task.errors", metricType: Counter},
@@ -833,6 +1176,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		DecisionTypeCancelActivityCounter:            {metricName: "cancel-activity-decision", metricType: Counter},
 		DecisionTypeCancelTimerCounter:               {metricName: "cancel-timer-decision", metricType: Counter},
 		DecisionTypeRecordMarkerCounter:              {metricName: "record-marker-decision", metricType: Counter},
+		DecisionTypeCompleteWorkflowUpdateCounter:    {metricName: "complete-workflow-update-decision", metricType: Counter},
 		DecisionTypeCancelExternalWorkflowCounter:    {metricName: "cancel-external-workflow-decision", metricType: Counter},
 		DecisionTypeContinueAsNewCounter:             {metricName: "continue-as-new-decision", metricType: Counter},
 		DecisionTypeChildWorkflowCounter:             {metricName: "child-workflow-decision", metricType: Counter},
@@ -876,22 +1220,102 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		HistoryTaskStandbyRetryCounter:               {metricName: "history-task-standby-retry-counter", metricType: Counter},
 		HistoryTaskNotActiveCounter:                  {metricName: "history-task-not-active-counter", metricType: Counter},
 		HistoryTaskBatchCompleteCounter:              {metricName: "history-task-batch-complete-counter", metricType: Counter},
+		ShardRebalanceHandoffCounter:                 {metricName: "shard-rebalance-handoff-count", metricType: Counter},
+		ShardRebalanceReclaimCounter:                 {metricName: "shard-rebalance-reclaim-count", metricType: Counter},
+		ShardRebalanceCancelCounter:                  {metricName: "shard-rebalance-cancel-count", metricType: Counter},
+		ShardRebalanceHostLoadGauge:                  {metricName: "shard-rebalance-host-load", metricType: Gauge},
+		ShardRebalanceLatency:                        {metricName: "shard-rebalance-latency", metricType: Timer},
+		ReplicationEventsEndToEndLatency:             {metricName: "replication-events-end-to-end-latency", metricType: Timer},
+		DecisionTimeoutScaledCounter:                 {metricName: "decision-timeout-scaled", metricType: Counter},
+		ReplicationEventsAppliedCounter:              {metricName: "replication-events-applied", metricType: Counter},
+		AsyncDecisionDispatchCounter:                 {metricName: "async-decision-dispatch", metricType: Counter},
+		AsyncDecisionBufferFullCounter:               {metricName: "async-decision-buffer-full", metricType: Counter},
+		HistoryTaskPausedCounter:                     {metricName: "history-task-paused-counter", metricType: Counter},
+		ActivityScheduleToStartTimeoutCappedCounter:  {metricName: "activity-schedule-to-start-timeout-capped", metricType: Counter},
+		TaskBacklogAgeGauge:                          {metricName: "task-backlog-age", metricType: Gauge},
+		ReplicatorTaskPurgeCounter:                   {metricName: "replicator-task-purge", metricType: Counter},
+		ReplicatorTaskPurgeFailures:                  {metricName: "replicator-task-purge-failures", metricType: Counter},
+		RetryEntityNotExistsCounter:                  {metricName: "replication-retry-entity-not-exists", metricType: Counter},
+		RetryBufferEventsCounter:                     {metricName: "replication-retry-buffer-events", metricType: Counter},
+		RetryExistingWorkflowCounter:                 {metricName: "replication-retry-existing-workflow", metricType: Counter},
+		RetryExecutionAlreadyStartedCounter:          {metricName: "replication-retry-execution-already-started", metricType: Counter},
+		CloseEventPublishedCounter:                   {metricName: "close-event-published", metricType: Counter},
+		CloseEventPublishFailedCounter:               {metricName: "close-event-publish-failed", metricType: Counter},
+		CloseEventDLQCounter:                         {metricName: "close-event-dlq", metricType: Counter},
+		CloseEventDroppedCounter:                     {metricName: "close-event-dropped", metricType: Counter},
+		ReplicationTaskNoTargetCounter:               {metricName: "replication-task-no-target", metricType: Counter},
+		TimerClockJumpCounter:                        {metricName: "timer-clock-jump", metricType: Counter},
+		SignalAfterCloseDroppedCounter:               {metricName: "signal-after-close-dropped", metricType: Counter},
+		SignalAfterCloseStartedCounter:               {metricName: "signal-after-close-started", metricType: Counter},
+		MutableStateUpdateSizeTimer:                  {metricName: "mutable-state-update-size", metricType: Timer},
+		MutableStateSnapshotCounter:                  {metricName: "mutable-state-snapshot", metricType: Counter},
+		ActivityEagerExecutionCounter:                {metricName: "activity-eager-execution", metricType: Counter},
+		DecisionEagerExecutionCounter:                {metricName: "decision-eager-execution", metricType: Counter},
+		TaskSkippedByFailoverCounter:                 {metricName: "task-skipped-by-failover", metricType: Counter},
+		ActivityHeartbeatDeferredCounter:             {metricName: "activity-heartbeat-deferred", metricType: Counter},
+		FailoverProcessingCompleteCounter:            {metricName: "failover-processing-complete", metricType: Counter},
+		CacheWarmupLoadedCounter:                     {metricName: "cache-warmup-loaded", metricType: Counter},
+		RetryTimerSuppressedCounter:                  {metricName: "retry-timer-suppressed", metricType: Counter},
+		ReplicationPublishShapingLatency:             {metricName: "replication-publish-shaping-latency", metricType: Timer},
+		ReplicationPublishInFlight:                   {metricName: "replication-publish-inflight", metricType: Gauge},
+		StickyNonDeterministicFailureCounter:         {metricName: "sticky-non-deterministic-failures", metricType: Counter},
+		SuspectBinaryChecksumMarkedCounter:           {metricName: "suspect-binary-checksum-marked", metricType: Counter},
+		CompleteDecisionWithSuspectBinaryCounter:     {metricName: "complete-decision-suspect-binary-count", metricType: Counter},
+		AppendHistoryEventsFencedCounter:             {metricName: "append-history-events-fenced", metricType: Counter},
+		ShardTransferAckLevelGauge:                   {metricName: "shard-transfer-ack-level", metricType: Gauge},
+		ShardTransferLagGauge:                        {metricName: "shard-transfer-lag", metricType: Gauge},
+		ShardTimerAckLevelGauge:                      {metricName: "shard-timer-ack-level", metricType: Gauge},
+		ShardTimerLagGauge:                           {metricName: "shard-timer-lag", metricType: Gauge},
+		ShardReplicationAckLevelGauge:                {metricName: "shard-replication-ack-level", metricType: Gauge},
+		ShardReplicationLagGauge:                     {metricName: "shard-replication-lag", metricType: Gauge},
+		ShardTransferMaxLagGauge:                     {metricName: "shard-transfer-max-lag", metricType: Gauge},
+		ShardTimerMaxLagGauge:                        {metricName: "shard-timer-max-lag", metricType: Gauge},
+		ShardReplicationMaxLagGauge:                  {metricName: "shard-replication-max-lag", metricType: Gauge},
+		QueueProcessorTunedPollRPSGauge:              {metricName: "queue-processor-tuned-poll-rps", metricType: Gauge},
+		QueueProcessorTunedWorkerCountGauge:          {metricName: "queue-processor-tuned-worker-count", metricType: Gauge},
+		QueueProcessorTuningBackoffCounter:           {metricName: "queue-processor-tuning-backoff", metricType: Counter},
+		PostCloseEventsRecordedCounter:               {metricName: "post-close-events-recorded", metricType: Counter},
 	},
 	Matching: {
-		PollSuccessCounter:            {metricName: "poll.success"},
-		PollTimeoutCounter:            {metricName: "poll.timeouts"},
-		PollSuccessWithSyncCounter:    {metricName: "poll.success.sync"},
-		LeaseRequestCounter:           {metricName: "lease.requests"},
-		LeaseFailureCounter:           {metricName: "lease.failures"},
-		ConditionFailedErrorCounter:   {metricName: "condition-failed-errors"},
-		RespondQueryTaskFailedCounter: {metricName: "respond-query-failed"},
-		SyncThrottleCounter:           {metricName: "sync.throttle.count"},
-		BufferThrottleCounter:         {metricName: "buffer.throttle.count"},
+		PollSuccessCounter:               {metricName: "poll.success"},
+		PollTimeoutCounter:               {metricName: "poll.timeouts"},
+		PollSuccessWithSyncCounter:       {metricName: "poll.success.sync"},
+		LeaseRequestCounter:              {metricName: "lease.requests"},
+		LeaseFailureCounter:              {metricName: "lease.failures"},
+		ConditionFailedErrorCounter:      {metricName: "condition-failed-errors"},
+		RespondQueryTaskFailedCounter:    {metricName: "respond-query-failed"},
+		SyncThrottleCounter:              {metricName: "sync.throttle.count"},
+		BufferThrottleCounter:            {metricName: "buffer.throttle.count"},
+		SyncMatchBudgetExceededCounter:   {metricName: "sync.budget.exceeded.count"},
+		SyncMatchPollerGoneCounter:       {metricName: "sync.poller.gone.count"},
+		IdleTaskListUnloadedCounter:      {metricName: "idle.unload.count"},
+		IsolationGroupMatchCounter:       {metricName: "isolation.group.match.count"},
+		DrainedIsolationGroupPollCounter: {metricName: "drained.isolation.group.poll.count"},
+		TaskMovedToDLQCounter:            {metricName: "dlq.moved.count"},
+		TaskRequeuedFromDLQCounter:       {metricName: "dlq.requeued.count"},
+		ScheduleToStartLatency:           {metricName: "schedule-to-start.latency", metricType: Timer},
 	},
 	Worker: {
-		ReplicatorMessages: {metricName: "replicator.messages"},
-		ReplicatorFailures: {metricName: "replicator.errors"},
-		ReplicatorLatency:  {metricName: "replicator.latency"},
+		ReplicatorMessages:              {metricName: "replicator.messages"},
+		ReplicatorFailures:              {metricName: "replicator.errors"},
+		ReplicatorLatency:               {metricName: "replicator.latency"},
+		ReplicatorMessagesDLQ:           {metricName: "replicator.dlq"},
+		ReplicatorMessagesNotTargeted:   {metricName: "replicator.not-targeted"},
+		ScheduleRunStarted:              {metricName: "schedule.run.started"},
+		ScheduleRunSkipped:              {metricName: "schedule.run.skipped"},
+		ScheduleRunBuffered:             {metricName: "schedule.run.buffered"},
+		ScheduleRunCancelled:            {metricName: "schedule.run.cancelled-other"},
+		ScheduleProcessorFailures:       {metricName: "schedule.processor.errors"},
+		VisibilityPrunedBuckets:         {metricName: "visibility.pruner.pruned-buckets"},
+		VisibilityPrunerFailures:        {metricName: "visibility.pruner.errors"},
+		ReplicatorDLQDepth:              {metricName: "replicator.dlq.depth", metricType: Gauge},
+		ReplicatorDLQOldestMessageAge:   {metricName: "replicator.dlq.oldest-message-age", metricType: Gauge},
+		HistoryMigratorReencodedBatches: {metricName: "history.migrator.reencoded-batches"},
+		HistoryMigratorConflicts:        {metricName: "history.migrator.conflicts"},
+		HistoryMigratorFailures:         {metricName: "history.migrator.errors"},
+		DomainRegistrationApproved:      {metricName: "domain.registration.approved"},
+		DomainRegistrationRejected:      {metricName: "domain.registration.rejected"},
+		DomainRegistrationFailures:      {metricName: "domain.registration.errors"},
 	},
 }
 
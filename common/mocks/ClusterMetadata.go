@@ -20,7 +20,10 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	mock "github.com/stretchr/testify/mock"
+	"github.com/uber/cadence/common/service/config"
+)
 
 // ClusterMetadata is an autogenerated mock type for the Metadata type
 type ClusterMetadata struct {
@@ -41,6 +44,22 @@ func (_m *ClusterMetadata) ClusterNameForFailoverVersion(failoverVersion int64)
 	return r0
 }
 
+// GetAllClientAddress provides a mock function with given fields:
+func (_m *ClusterMetadata) GetAllClientAddress() map[string]config.Address {
+	ret := _m.Called()
+
+	var r0 map[string]config.Address
+	if rf, ok := ret.Get(0).(func() map[string]config.Address); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]config.Address)
+		}
+	}
+
+	return r0
+}
+
 // GetAllClusterFailoverVersions provides a mock function with given fields:
 func (_m *ClusterMetadata) GetAllClusterFailoverVersions() map[string]int64 {
 	ret := _m.Called()
@@ -61,6 +61,52 @@ func (_m *HistoryClient) DescribeHistoryHost(ctx context.Context, request *share
 	return r0, r1
 }
 
+// DescribeShardBacklogs provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) DescribeShardBacklogs(ctx context.Context, request *shared.DescribeShardBacklogsRequest, opts ...yarpc.CallOption) (*shared.DescribeShardBacklogsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.DescribeShardBacklogsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.DescribeShardBacklogsRequest) *shared.DescribeShardBacklogsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeShardBacklogsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.DescribeShardBacklogsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeShardOperations provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) DescribeShardOperations(ctx context.Context, request *shared.DescribeShardOperationsRequest, opts ...yarpc.CallOption) (*shared.DescribeShardOperationsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.DescribeShardOperationsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.DescribeShardOperationsRequest) *shared.DescribeShardOperationsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeShardOperationsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.DescribeShardOperationsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DescribeMutableState provides a mock function with given fields: ctx, getRequest
 func (_m *HistoryClient) DescribeMutableState(ctx context.Context, request *history.DescribeMutableStateRequest, opts ...yarpc.CallOption) (*history.DescribeMutableStateResponse, error) {
 	ret := _m.Called(ctx, request)
@@ -107,6 +153,29 @@ func (_m *HistoryClient) GetMutableState(ctx context.Context, getRequest *histor
 	return r0, r1
 }
 
+// GetMutableStates provides a mock function with given fields: ctx, getRequest
+func (_m *HistoryClient) GetMutableStates(ctx context.Context, getRequest *history.GetMutableStatesRequest, opts ...yarpc.CallOption) (*history.GetMutableStatesResponse, error) {
+	ret := _m.Called(ctx, getRequest)
+
+	var r0 *history.GetMutableStatesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *history.GetMutableStatesRequest) *history.GetMutableStatesResponse); ok {
+		r0 = rf(ctx, getRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*history.GetMutableStatesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *history.GetMutableStatesRequest) error); ok {
+		r1 = rf(ctx, getRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResetStickyTaskList provides a mock function with given fields: ctx, getRequest
 func (_m *HistoryClient) ResetStickyTaskList(ctx context.Context, request *history.ResetStickyTaskListRequest, opts ...yarpc.CallOption) (*history.ResetStickyTaskListResponse, error) {
 	ret := _m.Called(ctx, request)
@@ -315,6 +384,81 @@ func (_m *HistoryClient) SignalWorkflowExecution(ctx context.Context, signalRequ
 	return r0
 }
 
+// UpdateWorkflowExecutionOptions provides a mock function with given fields: ctx, updateRequest
+func (_m *HistoryClient) UpdateWorkflowExecutionOptions(ctx context.Context, updateRequest *history.UpdateWorkflowExecutionOptionsRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, updateRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.UpdateWorkflowExecutionOptionsRequest) error); ok {
+		r0 = rf(ctx, updateRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FixWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) FixWorkflowExecution(ctx context.Context, request *history.FixWorkflowExecutionRequest, opts ...yarpc.CallOption) (*history.FixWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *history.FixWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *history.FixWorkflowExecutionRequest) *history.FixWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*history.FixWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *history.FixWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWorkflowExecution provides a mock function with given fields: ctx, deleteRequest
+func (_m *HistoryClient) DeleteWorkflowExecution(ctx context.Context, deleteRequest *history.DeleteWorkflowExecutionRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, deleteRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.DeleteWorkflowExecutionRequest) error); ok {
+		r0 = rf(ctx, deleteRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateWorkflowExecution provides a mock function with given fields: ctx, updateRequest
+func (_m *HistoryClient) UpdateWorkflowExecution(ctx context.Context, updateRequest *history.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*shared.UpdateWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, updateRequest)
+
+	var r0 *shared.UpdateWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *history.UpdateWorkflowExecutionRequest) *shared.UpdateWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, updateRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.UpdateWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *history.UpdateWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, updateRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SignalWithStartWorkflowExecution provides a mock function with given fields: ctx, signalWithStartRequest
 func (_m *HistoryClient) SignalWithStartWorkflowExecution(ctx context.Context,
 	signalWithStartRequest *history.SignalWithStartWorkflowExecutionRequest,
@@ -447,6 +591,43 @@ func (_m *HistoryClient) ReplicateEvents(ctx context.Context, request *history.R
 	return r0
 }
 
+// RefreshDomainCache provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) RefreshDomainCache(ctx context.Context, request *shared.RefreshDomainCacheRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.RefreshDomainCacheRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RatelimitUpdate provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) RatelimitUpdate(ctx context.Context, request *history.RatelimitUpdateRequest, opts ...yarpc.CallOption) (*history.RatelimitUpdateResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *history.RatelimitUpdateResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *history.RatelimitUpdateRequest) *history.RatelimitUpdateResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*history.RatelimitUpdateResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *history.RatelimitUpdateRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SyncShardStatus provides a mock function with given fields: ctx, request
 func (_m *HistoryClient) SyncShardStatus(ctx context.Context, request *history.SyncShardStatusRequest, opts ...yarpc.CallOption) error {
 	ret := _m.Called(ctx, request)
@@ -460,3 +641,17 @@ func (_m *HistoryClient) SyncShardStatus(ctx context.Context, request *history.S
 
 	return r0
 }
+
+// SyncActivity provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) SyncActivity(ctx context.Context, request *history.SyncActivityRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.SyncActivityRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -61,6 +61,58 @@ func (_m *HistoryManager) DeleteWorkflowExecutionHistory(request *persistence.De
 	return r0
 }
 
+// ListHistoryBatches provides a mock function with given fields: request
+func (_m *HistoryManager) ListHistoryBatches(
+	request *persistence.ListHistoryBatchesRequest) (*persistence.ListHistoryBatchesResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListHistoryBatchesResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListHistoryBatchesRequest) *persistence.ListHistoryBatchesResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListHistoryBatchesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListHistoryBatchesRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReencodeHistoryBatch provides a mock function with given fields: request
+func (_m *HistoryManager) ReencodeHistoryBatch(request *persistence.ReencodeHistoryBatchRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.ReencodeHistoryBatchRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteHistoryBatch provides a mock function with given fields: request
+func (_m *HistoryManager) DeleteHistoryBatch(request *persistence.DeleteHistoryBatchRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.DeleteHistoryBatchRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetWorkflowExecutionHistory provides a mock function with given fields: request
 func (_m *HistoryManager) GetWorkflowExecutionHistory(
 	request *persistence.GetWorkflowExecutionHistoryRequest) (*persistence.GetWorkflowExecutionHistoryResponse, error) {
@@ -188,3 +188,153 @@ func (_m *MatchingClient) DescribeTaskList(ctx context.Context,
 
 	return r0, r1
 }
+
+// GetTaskListsByDomain provides a mock function with given fields: ctx, request
+func (_m *MatchingClient) GetTaskListsByDomain(ctx context.Context,
+	request *matching.GetTaskListsByDomainRequest, opts ...yarpc.CallOption) (*shared.GetTaskListsByDomainResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.GetTaskListsByDomainResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *matching.GetTaskListsByDomainRequest) *shared.GetTaskListsByDomainResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.GetTaskListsByDomainResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *matching.GetTaskListsByDomainRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordWorkerHeartbeat provides a mock function with given fields: ctx, request
+func (_m *MatchingClient) RecordWorkerHeartbeat(ctx context.Context,
+	request *matching.RecordWorkerHeartbeatRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *matching.RecordWorkerHeartbeatRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListTaskListDLQTasks provides a mock function with given fields: ctx, request
+func (_m *MatchingClient) ListTaskListDLQTasks(ctx context.Context,
+	request *matching.ListTaskListDLQTasksRequest, opts ...yarpc.CallOption) (*shared.ListTaskListDLQTasksResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.ListTaskListDLQTasksResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *matching.ListTaskListDLQTasksRequest) *shared.ListTaskListDLQTasksResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.ListTaskListDLQTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *matching.ListTaskListDLQTasksRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequeueTaskListDLQTasks provides a mock function with given fields: ctx, request
+func (_m *MatchingClient) RequeueTaskListDLQTasks(ctx context.Context,
+	request *matching.RequeueTaskListDLQTasksRequest, opts ...yarpc.CallOption) (*shared.RequeueTaskListDLQTasksResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.RequeueTaskListDLQTasksResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *matching.RequeueTaskListDLQTasksRequest) *shared.RequeueTaskListDLQTasksResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.RequeueTaskListDLQTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *matching.RequeueTaskListDLQTasksRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeTaskListLatency provides a mock function with given fields: ctx, request
+func (_m *MatchingClient) DescribeTaskListLatency(ctx context.Context,
+	request *matching.DescribeTaskListLatencyRequest, opts ...yarpc.CallOption) (*shared.DescribeTaskListLatencyResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.DescribeTaskListLatencyResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *matching.DescribeTaskListLatencyRequest) *shared.DescribeTaskListLatencyResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeTaskListLatencyResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *matching.DescribeTaskListLatencyRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForceUnloadTaskList provides a mock function with given fields: ctx, request
+func (_m *MatchingClient) ForceUnloadTaskList(ctx context.Context,
+	request *matching.ForceUnloadTaskListRequest, opts ...yarpc.CallOption) (*shared.ForceUnloadTaskListResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.ForceUnloadTaskListResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *matching.ForceUnloadTaskListRequest) *shared.ForceUnloadTaskListResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.ForceUnloadTaskListResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *matching.ForceUnloadTaskListRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshDomainCache provides a mock function with given fields: ctx, request
+func (_m *MatchingClient) RefreshDomainCache(ctx context.Context,
+	request *shared.RefreshDomainCacheRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.RefreshDomainCacheRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
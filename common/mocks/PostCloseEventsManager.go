@@ -0,0 +1,73 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mocks
+
+import "github.com/uber/cadence/common/persistence"
+import "github.com/stretchr/testify/mock"
+
+// PostCloseEventsManager is an autogenerated mock type for the PostCloseEventsManager type
+type PostCloseEventsManager struct {
+	mock.Mock
+}
+
+// Close provides a mock function with given fields:
+func (_m *PostCloseEventsManager) Close() {
+	_m.Called()
+}
+
+// ListPostCloseEvents provides a mock function with given fields: request
+func (_m *PostCloseEventsManager) ListPostCloseEvents(request *persistence.ListPostCloseEventsRequest) (*persistence.ListPostCloseEventsResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListPostCloseEventsResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListPostCloseEventsRequest) *persistence.ListPostCloseEventsResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListPostCloseEventsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListPostCloseEventsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordPostCloseEvents provides a mock function with given fields: request
+func (_m *PostCloseEventsManager) RecordPostCloseEvents(request *persistence.RecordPostCloseEventsRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.RecordPostCloseEventsRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+var _ persistence.PostCloseEventsManager = (*PostCloseEventsManager)(nil)
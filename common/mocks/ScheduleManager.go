@@ -0,0 +1,124 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mocks
+
+import "github.com/uber/cadence/common/persistence"
+import "github.com/stretchr/testify/mock"
+
+// ScheduleManager is an autogenerated mock type for the ScheduleManager type
+type ScheduleManager struct {
+	mock.Mock
+}
+
+// Close provides a mock function with given fields:
+func (_m *ScheduleManager) Close() {
+	_m.Called()
+}
+
+// CreateSchedule provides a mock function with given fields: request
+func (_m *ScheduleManager) CreateSchedule(request *persistence.CreateScheduleRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.CreateScheduleRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSchedule provides a mock function with given fields: request
+func (_m *ScheduleManager) DeleteSchedule(request *persistence.DeleteScheduleRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.DeleteScheduleRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetSchedule provides a mock function with given fields: request
+func (_m *ScheduleManager) GetSchedule(request *persistence.GetScheduleRequest) (*persistence.GetScheduleResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.GetScheduleResponse
+	if rf, ok := ret.Get(0).(func(*persistence.GetScheduleRequest) *persistence.GetScheduleResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetScheduleResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.GetScheduleRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSchedules provides a mock function with given fields: request
+func (_m *ScheduleManager) ListSchedules(request *persistence.ListSchedulesRequest) (*persistence.ListSchedulesResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListSchedulesResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListSchedulesRequest) *persistence.ListSchedulesResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListSchedulesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListSchedulesRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateSchedule provides a mock function with given fields: request
+func (_m *ScheduleManager) UpdateSchedule(request *persistence.UpdateScheduleRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.UpdateScheduleRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+var _ persistence.ScheduleManager = (*ScheduleManager)(nil)
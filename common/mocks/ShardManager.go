@@ -84,4 +84,78 @@ func (_m *ShardManager) UpdateShard(request *persistence.UpdateShardRequest) err
 	return r0
 }
 
+// PutReplicationDLQMessage provides a mock function with given fields: request
+func (_m *ShardManager) PutReplicationDLQMessage(request *persistence.PutReplicationDLQMessageRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.PutReplicationDLQMessageRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteReplicationDLQMessage provides a mock function with given fields: request
+func (_m *ShardManager) DeleteReplicationDLQMessage(request *persistence.DeleteReplicationDLQMessageRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.DeleteReplicationDLQMessageRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetReplicationDLQSummary provides a mock function with given fields: request
+func (_m *ShardManager) GetReplicationDLQSummary(request *persistence.GetReplicationDLQSummaryRequest) (*persistence.GetReplicationDLQSummaryResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.GetReplicationDLQSummaryResponse
+	if rf, ok := ret.Get(0).(func(*persistence.GetReplicationDLQSummaryRequest) *persistence.GetReplicationDLQSummaryResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetReplicationDLQSummaryResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.GetReplicationDLQSummaryRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDomainExecutions provides a mock function with given fields: request
+func (_m *ShardManager) ListDomainExecutions(request *persistence.ListDomainExecutionsRequest) (*persistence.ListDomainExecutionsResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListDomainExecutionsResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListDomainExecutionsRequest) *persistence.ListDomainExecutionsResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListDomainExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListDomainExecutionsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 var _ persistence.ShardManager = (*ShardManager)(nil)
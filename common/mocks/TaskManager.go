@@ -134,3 +134,77 @@ func (_m *TaskManager) GetTasks(request *persistence.GetTasksRequest) (*persiste
 
 	return r0, r1
 }
+
+// ListTaskList provides a mock function with given fields: request
+func (_m *TaskManager) ListTaskList(request *persistence.ListTaskListRequest) (*persistence.ListTaskListResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListTaskListResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListTaskListRequest) *persistence.ListTaskListResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListTaskListResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListTaskListRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDLQTask provides a mock function with given fields: request
+func (_m *TaskManager) CreateDLQTask(request *persistence.CreateDLQTaskRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.CreateDLQTaskRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDLQTasks provides a mock function with given fields: request
+func (_m *TaskManager) GetDLQTasks(request *persistence.GetDLQTasksRequest) (*persistence.GetDLQTasksResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.GetDLQTasksResponse
+	if rf, ok := ret.Get(0).(func(*persistence.GetDLQTasksRequest) *persistence.GetDLQTasksResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetDLQTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.GetDLQTasksRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteDLQTask provides a mock function with given fields: request
+func (_m *TaskManager) DeleteDLQTask(request *persistence.DeleteDLQTaskRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.DeleteDLQTaskRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
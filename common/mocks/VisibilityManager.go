@@ -56,6 +56,43 @@ func (_m *VisibilityManager) GetClosedWorkflowExecution(request *persistence.Get
 	return r0, r1
 }
 
+// DeleteWorkflowExecution provides a mock function with given fields: request
+func (_m *VisibilityManager) DeleteWorkflowExecution(request *persistence.VisibilityDeleteWorkflowExecutionRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.VisibilityDeleteWorkflowExecutionRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PruneClosedWorkflowExecutions provides a mock function with given fields: request
+func (_m *VisibilityManager) PruneClosedWorkflowExecutions(request *persistence.PruneClosedWorkflowExecutionsRequest) (*persistence.PruneClosedWorkflowExecutionsResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.PruneClosedWorkflowExecutionsResponse
+	if rf, ok := ret.Get(0).(func(*persistence.PruneClosedWorkflowExecutionsRequest) *persistence.PruneClosedWorkflowExecutionsResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.PruneClosedWorkflowExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.PruneClosedWorkflowExecutionsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListClosedWorkflowExecutions provides a mock function with given fields: request
 func (_m *VisibilityManager) ListClosedWorkflowExecutions(request *persistence.ListWorkflowExecutionsRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
 	ret := _m.Called(request)
@@ -79,6 +116,121 @@ func (_m *VisibilityManager) ListClosedWorkflowExecutions(request *persistence.L
 	return r0, r1
 }
 
+// ListOpenWorkflowExecutionsByTag provides a mock function with given fields: request
+func (_m *VisibilityManager) ListOpenWorkflowExecutionsByTag(request *persistence.ListWorkflowExecutionsByTagRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListWorkflowExecutionsResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsByTagRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsByTagRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListClosedWorkflowExecutionsByTag provides a mock function with given fields: request
+func (_m *VisibilityManager) ListClosedWorkflowExecutionsByTag(request *persistence.ListWorkflowExecutionsByTagRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListWorkflowExecutionsResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsByTagRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsByTagRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOpenWorkflowExecutionsByFirstRunID provides a mock function with given fields: request
+func (_m *VisibilityManager) ListOpenWorkflowExecutionsByFirstRunID(request *persistence.ListWorkflowExecutionsByFirstRunIDRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListWorkflowExecutionsResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsByFirstRunIDRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsByFirstRunIDRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListClosedWorkflowExecutionsByFirstRunID provides a mock function with given fields: request
+func (_m *VisibilityManager) ListClosedWorkflowExecutionsByFirstRunID(request *persistence.ListWorkflowExecutionsByFirstRunIDRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListWorkflowExecutionsResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListWorkflowExecutionsByFirstRunIDRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListWorkflowExecutionsByFirstRunIDRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAllWorkflowExecutions provides a mock function with given fields: request
+func (_m *VisibilityManager) ListAllWorkflowExecutions(request *persistence.ListAllWorkflowExecutionsRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListWorkflowExecutionsResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListAllWorkflowExecutionsRequest) *persistence.ListWorkflowExecutionsResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListWorkflowExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListAllWorkflowExecutionsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListClosedWorkflowExecutionsByStatus provides a mock function with given fields: request
 func (_m *VisibilityManager) ListClosedWorkflowExecutionsByStatus(request *persistence.ListClosedWorkflowExecutionsByStatusRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
 	ret := _m.Called(request)
@@ -0,0 +1,166 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/uber-common/bark"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/credentials"
+)
+
+const (
+	templatePutHistoryPayloadQuery = `INSERT INTO history_payloads (domain_id, workflow_id, run_id, hash, data) ` +
+		`VALUES (?, ?, ?, ?, ?)`
+
+	templateGetHistoryPayloadsQuery = `SELECT hash, data ` +
+		`FROM history_payloads ` +
+		`WHERE domain_id = ? ` +
+		`AND workflow_id = ? ` +
+		`AND run_id = ? ` +
+		`AND hash IN ?`
+
+	templateDeleteHistoryPayloadsQuery = `DELETE FROM history_payloads ` +
+		`WHERE domain_id = ? ` +
+		`AND workflow_id = ? ` +
+		`AND run_id = ?`
+)
+
+type (
+	cassandraHistoryPayloadPersistence struct {
+		session *gocql.Session
+		logger  bark.Logger
+	}
+)
+
+// NewCassandraHistoryPayloadPersistence is used to create an instance of HistoryPayloadManager implementation
+func NewCassandraHistoryPayloadPersistence(
+	hosts string, port int, credentialsProvider credentials.Provider, dc string, keyspace string,
+	logger bark.Logger) (HistoryPayloadManager, error) {
+	cluster := common.NewCassandraCluster(hosts, port, credentialsProvider, dc)
+	cluster.Keyspace = keyspace
+	cluster.ProtoVersion = cassandraProtoVersion
+	cluster.Consistency = gocql.LocalQuorum
+	cluster.SerialConsistency = gocql.LocalSerial
+	cluster.Timeout = defaultSessionTimeout
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cassandraHistoryPayloadPersistence{session: session, logger: logger}, nil
+}
+
+// Close releases the resources held by this object
+func (m *cassandraHistoryPayloadPersistence) Close() {
+	if m.session != nil {
+		m.session.Close()
+	}
+}
+
+// PutHistoryPayloads writes the payloads in a single batch, the payloads of a run share a partition.
+// Writing a payload again overwrites it with the same data, as it is stored under its hash.
+func (m *cassandraHistoryPayloadPersistence) PutHistoryPayloads(request *PutHistoryPayloadsRequest) error {
+	execution := request.Execution
+	batch := m.session.NewBatch(gocql.UnloggedBatch)
+	for hash, data := range request.Payloads {
+		batch.Query(templatePutHistoryPayloadQuery,
+			request.DomainID,
+			execution.GetWorkflowId(),
+			execution.GetRunId(),
+			hash,
+			data)
+	}
+
+	if err := m.session.ExecuteBatch(batch); err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("PutHistoryPayloads operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("PutHistoryPayloads operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
+func (m *cassandraHistoryPayloadPersistence) GetHistoryPayloads(
+	request *GetHistoryPayloadsRequest) (*GetHistoryPayloadsResponse, error) {
+	execution := request.Execution
+	query := m.session.Query(templateGetHistoryPayloadsQuery,
+		request.DomainID,
+		execution.GetWorkflowId(),
+		execution.GetRunId(),
+		request.Hashes)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "GetHistoryPayloads operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &GetHistoryPayloadsResponse{Payloads: make(map[string][]byte, len(request.Hashes))}
+	var hash string
+	var data []byte
+	for iter.Scan(&hash, &data) {
+		response.Payloads[hash] = data
+		data = nil
+	}
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("GetHistoryPayloads operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetHistoryPayloads operation failed. Error: %v", err),
+		}
+	}
+	return response, nil
+}
+
+func (m *cassandraHistoryPayloadPersistence) DeleteHistoryPayloads(request *DeleteHistoryPayloadsRequest) error {
+	execution := request.Execution
+	query := m.session.Query(templateDeleteHistoryPayloadsQuery,
+		request.DomainID,
+		execution.GetWorkflowId(),
+		execution.GetRunId())
+
+	if err := query.Exec(); err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteHistoryPayloads operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteHistoryPayloads operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
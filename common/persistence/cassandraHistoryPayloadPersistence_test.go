@@ -0,0 +1,127 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pborman/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+type (
+	historyPayloadPersistenceSuite struct {
+		suite.Suite
+		TestBase
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+	}
+)
+
+func TestHistoryPayloadPersistenceSuite(t *testing.T) {
+	s := new(historyPayloadPersistenceSuite)
+	suite.Run(t, s)
+}
+
+func (s *historyPayloadPersistenceSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+
+	s.SetupWorkflowStore()
+}
+
+func (s *historyPayloadPersistenceSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+}
+
+func (s *historyPayloadPersistenceSuite) TearDownSuite() {
+	s.TearDownWorkflowStore()
+}
+
+func (s *historyPayloadPersistenceSuite) TestHistoryPayloadsCRUD() {
+	domainID := uuid.New()
+	execution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("history-payloads-test"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	otherExecution := gen.WorkflowExecution{
+		WorkflowId: execution.WorkflowId,
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	s.NoError(s.HistoryPayloadMgr.PutHistoryPayloads(&PutHistoryPayloadsRequest{
+		DomainID:  domainID,
+		Execution: execution,
+		Payloads:  map[string][]byte{"hash1": []byte("payload1"), "hash2": []byte("payload2"), "hash3": []byte("payload3")},
+	}))
+	s.NoError(s.HistoryPayloadMgr.PutHistoryPayloads(&PutHistoryPayloadsRequest{
+		DomainID:  domainID,
+		Execution: otherExecution,
+		Payloads:  map[string][]byte{"hash4": []byte("payload4")},
+	}))
+
+	// the payloads are read by hash within the run, the unknown hashes are left out
+	resp, err := s.HistoryPayloadMgr.GetHistoryPayloads(&GetHistoryPayloadsRequest{
+		DomainID:  domainID,
+		Execution: execution,
+		Hashes:    []string{"hash1", "hash3", "hash4", "unknown"},
+	})
+	s.NoError(err)
+	s.Equal(map[string][]byte{"hash1": []byte("payload1"), "hash3": []byte("payload3")}, resp.Payloads)
+
+	// a hash referenced several times is read once
+	resp, err = s.HistoryPayloadMgr.GetHistoryPayloads(&GetHistoryPayloadsRequest{
+		DomainID:  domainID,
+		Execution: execution,
+		Hashes:    []string{"hash2", "hash2"},
+	})
+	s.NoError(err)
+	s.Equal(map[string][]byte{"hash2": []byte("payload2")}, resp.Payloads)
+
+	s.NoError(s.HistoryPayloadMgr.DeleteHistoryPayloads(&DeleteHistoryPayloadsRequest{
+		DomainID:  domainID,
+		Execution: execution,
+	}))
+	resp, err = s.HistoryPayloadMgr.GetHistoryPayloads(&GetHistoryPayloadsRequest{
+		DomainID:  domainID,
+		Execution: execution,
+		Hashes:    []string{"hash1", "hash2", "hash3"},
+	})
+	s.NoError(err)
+	s.Empty(resp.Payloads)
+
+	// the payloads of the other run are kept
+	resp, err = s.HistoryPayloadMgr.GetHistoryPayloads(&GetHistoryPayloadsRequest{
+		DomainID:  domainID,
+		Execution: otherExecution,
+		Hashes:    []string{"hash4"},
+	})
+	s.NoError(err)
+	s.Equal(map[string][]byte{"hash4": []byte("payload4")}, resp.Payloads)
+}
@@ -28,6 +28,7 @@ import (
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/credentials"
 )
 
 const (
@@ -51,6 +52,22 @@ const (
 		`WHERE domain_id = ? ` +
 		`AND workflow_id = ? ` +
 		`AND run_id = ? `
+
+	templateListHistoryBatches = `SELECT first_event_id, tx_id, data, data_encoding, data_version ` +
+		`FROM events ` +
+		`WHERE domain_id = ? ` +
+		`AND workflow_id = ? ` +
+		`AND run_id = ?`
+
+	// the batch is only replaced if history did not overwrite it since it was read
+	templateReencodeHistoryBatch = `UPDATE events ` +
+		`SET data = ?, data_encoding = ?, data_version = ? ` +
+		`WHERE domain_id = ? AND workflow_id = ? AND run_id = ? AND first_event_id = ? ` +
+		`IF tx_id = ? AND data_encoding = ? AND data_version = ?`
+
+	templateDeleteHistoryBatch = `DELETE FROM events ` +
+		`WHERE domain_id = ? AND workflow_id = ? AND run_id = ? AND first_event_id = ? ` +
+		`IF range_id = ? AND tx_id = ?`
 )
 
 type (
@@ -61,10 +78,11 @@ type (
 )
 
 // NewCassandraHistoryPersistence is used to create an instance of HistoryManager implementation
-func NewCassandraHistoryPersistence(hosts string, port int, user, password, dc string, keyspace string,
+func NewCassandraHistoryPersistence(hosts string, port int,
+	credentialsProvider credentials.Provider, dc string, keyspace string,
 	numConns int, logger bark.Logger) (HistoryManager,
 	error) {
-	cluster := common.NewCassandraCluster(hosts, port, user, password, dc)
+	cluster := common.NewCassandraCluster(hosts, port, credentialsProvider, dc)
 	cluster.Keyspace = keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
@@ -151,6 +169,7 @@ func (h *cassandraHistoryPersistence) GetWorkflowExecutionHistory(request *GetWo
 		request.FirstEventID,
 		request.NextEventID)
 
+	query = withQueryContext(query, request.ctx)
 	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
 	if iter == nil {
 		return nil, &workflow.InternalServiceError{
@@ -211,3 +230,116 @@ func (h *cassandraHistoryPersistence) DeleteWorkflowExecutionHistory(
 
 	return nil
 }
+
+func (h *cassandraHistoryPersistence) ListHistoryBatches(request *ListHistoryBatchesRequest) (
+	*ListHistoryBatchesResponse, error) {
+	query := h.session.Query(templateListHistoryBatches,
+		request.DomainID,
+		*request.Execution.WorkflowId,
+		*request.Execution.RunId)
+	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListHistoryBatches operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &ListHistoryBatchesResponse{}
+	var firstEventID, txID int64
+	events := &SerializedHistoryEventBatch{}
+	for iter.Scan(&firstEventID, &txID, &events.Data, &events.EncodingType, &events.Version) {
+		response.Batches = append(response.Batches, &HistoryBatch{
+			Execution:     request.Execution,
+			FirstEventID:  firstEventID,
+			TransactionID: txID,
+			Events:        events,
+		})
+		events = &SerializedHistoryEventBatch{}
+	}
+
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListHistoryBatches operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListHistoryBatches operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
+func (h *cassandraHistoryPersistence) ReencodeHistoryBatch(request *ReencodeHistoryBatchRequest) error {
+	query := h.session.Query(templateReencodeHistoryBatch,
+		request.Events.Data,
+		request.Events.EncodingType,
+		request.Events.Version,
+		request.DomainID,
+		*request.Execution.WorkflowId,
+		*request.Execution.RunId,
+		request.FirstEventID,
+		request.TransactionID,
+		request.PreviousEncodingType,
+		request.PreviousVersion)
+
+	previous := make(map[string]interface{})
+	applied, err := query.MapScanCAS(previous)
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ReencodeHistoryBatch operation failed. Error: %v", err),
+			}
+		} else if isTimeoutError(err) {
+			return &TimeoutError{Msg: fmt.Sprintf("ReencodeHistoryBatch timed out. Error: %v", err)}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ReencodeHistoryBatch operation failed. Error: %v", err),
+		}
+	}
+
+	if !applied {
+		return &ConditionFailedError{
+			Msg: "Failed to reencode history batch, it was written again since it was read.",
+		}
+	}
+
+	return nil
+}
+
+func (h *cassandraHistoryPersistence) DeleteHistoryBatch(request *DeleteHistoryBatchRequest) error {
+	query := h.session.Query(templateDeleteHistoryBatch,
+		request.DomainID,
+		*request.Execution.WorkflowId,
+		*request.Execution.RunId,
+		request.FirstEventID,
+		request.RangeID,
+		request.TransactionID)
+
+	previous := make(map[string]interface{})
+	applied, err := query.MapScanCAS(previous)
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteHistoryBatch operation failed. Error: %v", err),
+			}
+		} else if isTimeoutError(err) {
+			return &TimeoutError{Msg: fmt.Sprintf("DeleteHistoryBatch timed out. Error: %v", err)}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteHistoryBatch operation failed. Error: %v", err),
+		}
+	}
+
+	if !applied {
+		return &ConditionFailedError{
+			Msg: "Failed to delete history batch, it was written again by another shard owner.",
+		}
+	}
+
+	return nil
+}
@@ -239,6 +239,116 @@ func (s *historyPersistenceSuite) TestAppendAndGet() {
 	}
 }
 
+func (s *historyPersistenceSuite) TestListAndReencodeHistoryBatches() {
+	domainID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("list-and-reencode-test"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	for i := 0; i < 3; i++ {
+		batch := NewSerializedHistoryEventBatch([]byte(fmt.Sprintf("event%v", i)), common.EncodingTypeJSON, 1)
+		err0 := s.AppendHistoryEvents(domainID, workflowExecution, int64(i), 1, int64(i), batch, false)
+		s.Nil(err0)
+	}
+
+	var batches []*HistoryBatch
+	var token []byte
+	for {
+		resp, err1 := s.HistoryMgr.ListHistoryBatches(&ListHistoryBatchesRequest{
+			DomainID:      domainID,
+			Execution:     workflowExecution,
+			PageSize:      2,
+			NextPageToken: token,
+		})
+		s.Nil(err1)
+		batches = append(batches, resp.Batches...)
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			break
+		}
+	}
+	s.Equal(3, len(batches))
+	for i, batch := range batches {
+		s.Equal(workflowExecution, batch.Execution)
+		s.Equal(int64(i), batch.FirstEventID)
+		s.Equal(int64(i), batch.TransactionID)
+		s.Equal(common.EncodingTypeJSON, batch.Events.EncodingType)
+	}
+
+	reencoded := NewSerializedHistoryEventBatch([]byte("event1-gzip"), common.EncodingTypeJSONGzip, 1)
+	request := &ReencodeHistoryBatchRequest{
+		DomainID:             domainID,
+		Execution:            workflowExecution,
+		FirstEventID:         1,
+		TransactionID:        1,
+		PreviousEncodingType: common.EncodingTypeJSON,
+		PreviousVersion:      1,
+		Events:               reencoded,
+	}
+	err2 := s.HistoryMgr.ReencodeHistoryBatch(request)
+	s.Nil(err2)
+
+	history, _, err3 := s.GetWorkflowExecutionHistory(domainID, workflowExecution, 1, 2, 10, nil)
+	s.Nil(err3)
+	s.Equal(1, len(history))
+	s.Equal(*reencoded, history[0])
+
+	// the batch is not json anymore
+	err4 := s.HistoryMgr.ReencodeHistoryBatch(request)
+	s.IsType(&ConditionFailedError{}, err4)
+
+	// the batch was overwritten by history since it was read
+	err5 := s.AppendHistoryEvents(domainID, workflowExecution, 2, 1, 5,
+		NewSerializedHistoryEventBatch([]byte("event2new"), common.EncodingTypeJSON, 1), true)
+	s.Nil(err5)
+	request.FirstEventID = 2
+	request.TransactionID = 2
+	err6 := s.HistoryMgr.ReencodeHistoryBatch(request)
+	s.IsType(&ConditionFailedError{}, err6)
+}
+
+func (s *historyPersistenceSuite) TestDeleteHistoryBatch() {
+	domainID := "9ea1c5d3-7f5b-4a1e-9c4e-5d2a0e8a1b37"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("delete-history-batch-test"),
+		RunId:      common.StringPtr("4c7d0a52-3a0e-4f4c-9e43-2bb2a6a5d0f1"),
+	}
+
+	err0 := s.AppendHistoryEvents(domainID, workflowExecution, 1, 1, 1,
+		NewSerializedHistoryEventBatch([]byte("event1;event2"), common.EncodingTypeJSON, 1), false)
+	s.Nil(err0)
+	err1 := s.AppendHistoryEvents(domainID, workflowExecution, 3, 1, 2,
+		NewSerializedHistoryEventBatch([]byte("event3"), common.EncodingTypeJSON, 1), false)
+	s.Nil(err1)
+
+	// the batch was written again by the new shard owner
+	err2 := s.AppendHistoryEvents(domainID, workflowExecution, 3, 2, 3,
+		NewSerializedHistoryEventBatch([]byte("event3new"), common.EncodingTypeJSON, 1), true)
+	s.Nil(err2)
+	err3 := s.HistoryMgr.DeleteHistoryBatch(&DeleteHistoryBatchRequest{
+		DomainID:      domainID,
+		Execution:     workflowExecution,
+		FirstEventID:  3,
+		RangeID:       1,
+		TransactionID: 2,
+	})
+	s.IsType(&ConditionFailedError{}, err3)
+
+	err4 := s.HistoryMgr.DeleteHistoryBatch(&DeleteHistoryBatchRequest{
+		DomainID:      domainID,
+		Execution:     workflowExecution,
+		FirstEventID:  3,
+		RangeID:       2,
+		TransactionID: 3,
+	})
+	s.Nil(err4)
+
+	history, _, err5 := s.GetWorkflowExecutionHistory(domainID, workflowExecution, 1, 4, 10, nil)
+	s.Nil(err5)
+	s.Equal(1, len(history))
+	s.Equal([]byte("event1;event2"), history[0].Data)
+}
+
 func (s *historyPersistenceSuite) AppendHistoryEvents(domainID string, workflowExecution gen.WorkflowExecution,
 	firstEventID, rangeID, txID int64, eventsBatch *SerializedHistoryEventBatch, overwrite bool) error {
 
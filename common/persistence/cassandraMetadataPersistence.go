@@ -28,6 +28,7 @@ import (
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/credentials"
 )
 
 const (
@@ -42,12 +43,15 @@ const (
 
 	templateDomainConfigType = `{` +
 		`retention: ?, ` +
-		`emit_metric: ?` +
+		`emit_metric: ?, ` +
+		`history_encoding: ?, ` +
+		`suspect_binary_checksums: ?` +
 		`}`
 
 	templateDomainReplicationConfigType = `{` +
 		`active_cluster_name: ?, ` +
-		`clusters: ? ` +
+		`clusters: ?, ` +
+		`failover_drill_cluster_name: ? ` +
 		`}`
 
 	templateCreateDomainQuery = `INSERT INTO domains (` +
@@ -62,14 +66,17 @@ const (
 		`FROM domains ` +
 		`WHERE id = ?`
 
-	templateGetDomainByNameQuery = `SELECT domain.id, domain.name, domain.status, domain.description, ` +
-		`domain.owner_email, domain.data, config.retention, config.emit_metric, ` +
-		`replication_config.active_cluster_name, replication_config.clusters, ` +
+	templateListDomainQuery = `SELECT domain.id, domain.name, domain.status, domain.description, ` +
+		`domain.owner_email, domain.data, config.retention, config.emit_metric, config.history_encoding, ` +
+		`config.suspect_binary_checksums, ` +
+		`replication_config.active_cluster_name, replication_config.clusters, replication_config.failover_drill_cluster_name, ` +
 		`is_global_domain, ` +
 		`config_version, ` +
 		`failover_version, ` +
 		`db_version ` +
-		`FROM domains_by_name ` +
+		`FROM domains_by_name `
+
+	templateGetDomainByNameQuery = templateListDomainQuery +
 		`WHERE name = ?`
 
 	templateUpdateDomainByNameQuery = `UPDATE domains_by_name ` +
@@ -98,10 +105,11 @@ type (
 )
 
 // NewCassandraMetadataPersistence is used to create an instance of HistoryManager implementation
-func NewCassandraMetadataPersistence(hosts string, port int, user, password, dc string, keyspace string,
+func NewCassandraMetadataPersistence(hosts string, port int,
+	credentialsProvider credentials.Provider, dc string, keyspace string,
 	currentClusterName string, logger bark.Logger) (MetadataManager,
 	error) {
-	cluster := common.NewCassandraCluster(hosts, port, user, password, dc)
+	cluster := common.NewCassandraCluster(hosts, port, credentialsProvider, dc)
 	cluster.Keyspace = keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
@@ -155,8 +163,11 @@ func (m *cassandraMetadataPersistence) CreateDomain(request *CreateDomainRequest
 		request.Info.Data,
 		request.Config.Retention,
 		request.Config.EmitMetric,
+		request.Config.HistoryEncoding,
+		request.Config.SuspectBinaryChecksums,
 		request.ReplicationConfig.ActiveClusterName,
 		serializeClusterConfigs(request.ReplicationConfig.Clusters),
+		request.ReplicationConfig.FailoverDrillClusterName,
 		request.IsGlobalDomain,
 		request.ConfigVersion,
 		request.FailoverVersion,
@@ -248,8 +259,11 @@ func (m *cassandraMetadataPersistence) GetDomain(request *GetDomainRequest) (*Ge
 		&info.Data,
 		&config.Retention,
 		&config.EmitMetric,
+		&config.HistoryEncoding,
+		&config.SuspectBinaryChecksums,
 		&replicationConfig.ActiveClusterName,
 		&replicationClusters,
+		&replicationConfig.FailoverDrillClusterName,
 		&isGlobalDomain,
 		&configVersion,
 		&failoverVersion,
@@ -291,8 +305,11 @@ func (m *cassandraMetadataPersistence) UpdateDomain(request *UpdateDomainRequest
 		request.Info.Data,
 		request.Config.Retention,
 		request.Config.EmitMetric,
+		request.Config.HistoryEncoding,
+		request.Config.SuspectBinaryChecksums,
 		request.ReplicationConfig.ActiveClusterName,
 		serializeClusterConfigs(request.ReplicationConfig.Clusters),
+		request.ReplicationConfig.FailoverDrillClusterName,
 		request.ConfigVersion,
 		request.FailoverVersion,
 		nextVersion,
@@ -342,8 +359,50 @@ func (m *cassandraMetadataPersistence) DeleteDomainByName(request *DeleteDomainB
 	return m.deleteDomain(request.Name, ID)
 }
 
+// ListDomains pages through the whole domains_by_name table, which has a partition per domain
 func (m *cassandraMetadataPersistence) ListDomains(request *ListDomainsRequest) (*ListDomainsResponse, error) {
-	panic("cassandraMetadataPersistence do not support list domain operation.")
+	query := m.session.Query(templateListDomainQuery)
+	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListDomains operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	domain := &GetDomainResponse{
+		Info:              &DomainInfo{},
+		Config:            &DomainConfig{},
+		ReplicationConfig: &DomainReplicationConfig{},
+	}
+	var replicationClusters []map[string]interface{}
+	response := &ListDomainsResponse{}
+	for iter.Scan(
+		&domain.Info.ID, &domain.Info.Name, &domain.Info.Status, &domain.Info.Description, &domain.Info.OwnerEmail, &domain.Info.Data,
+		&domain.Config.Retention, &domain.Config.EmitMetric, &domain.Config.HistoryEncoding, &domain.Config.SuspectBinaryChecksums,
+		&domain.ReplicationConfig.ActiveClusterName, &replicationClusters, &domain.ReplicationConfig.FailoverDrillClusterName,
+		&domain.IsGlobalDomain, &domain.ConfigVersion, &domain.FailoverVersion, &domain.NotificationVersion,
+	) {
+		domain.ReplicationConfig.ActiveClusterName = GetOrUseDefaultActiveCluster(m.currentClusterName, domain.ReplicationConfig.ActiveClusterName)
+		domain.ReplicationConfig.Clusters = deserializeClusterConfigs(replicationClusters)
+		domain.ReplicationConfig.Clusters = GetOrUseDefaultClusters(m.currentClusterName, domain.ReplicationConfig.Clusters)
+		response.Domains = append(response.Domains, domain)
+		domain = &GetDomainResponse{
+			Info:              &DomainInfo{},
+			Config:            &DomainConfig{},
+			ReplicationConfig: &DomainReplicationConfig{},
+		}
+	}
+
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+	if err := iter.Close(); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListDomains operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
 }
 
 func (m *cassandraMetadataPersistence) GetMetadata() (*GetMetadataResponse, error) {
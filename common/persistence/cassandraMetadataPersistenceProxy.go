@@ -25,6 +25,7 @@ import (
 
 	"github.com/uber-common/bark"
 	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/credentials"
 )
 
 type (
@@ -38,13 +39,16 @@ type (
 )
 
 // NewMetadataManagerProxy is used for merging the functionality the v1 and v2 MetadataManager
-func NewMetadataManagerProxy(hosts string, port int, user, password, dc string, keyspace string,
+func NewMetadataManagerProxy(hosts string, port int,
+	credentialsProvider credentials.Provider, dc string, keyspace string,
 	currentClusterName string, logger bark.Logger) (MetadataManager, error) {
-	metadataMgr, err := NewCassandraMetadataPersistence(hosts, port, user, password, dc, keyspace, currentClusterName, logger)
+	metadataMgr, err := NewCassandraMetadataPersistence(hosts, port, credentialsProvider, dc, keyspace,
+		currentClusterName, logger)
 	if err != nil {
 		return nil, err
 	}
-	metadataMgrV2, err := NewCassandraMetadataPersistenceV2(hosts, port, user, password, dc, keyspace, currentClusterName, logger)
+	metadataMgrV2, err := NewCassandraMetadataPersistenceV2(hosts, port, credentialsProvider, dc, keyspace,
+		currentClusterName, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -71,8 +75,42 @@ func (m *metadataManagerProxy) GetDomain(request *GetDomainRequest) (*GetDomainR
 	return resp, err
 }
 
+// ListDomains lists the domains of the v2 table, followed by the ones of the v1 table if the request includes them.
+// The first byte of the page token is then the version of the table the token belongs to.
 func (m *metadataManagerProxy) ListDomains(request *ListDomainsRequest) (*ListDomainsResponse, error) {
-	return m.metadataMgrV2.ListDomains(request)
+	if !request.IncludeV1Domains {
+		return m.metadataMgrV2.ListDomains(request)
+	}
+
+	tableVersion := DomainTableVersionV2
+	var token []byte
+	if len(request.NextPageToken) > 0 {
+		tableVersion = int(request.NextPageToken[0])
+		token = request.NextPageToken[1:]
+	}
+
+	metadataMgr := m.metadataMgrV2
+	switch tableVersion {
+	case DomainTableVersionV1:
+		metadataMgr = m.metadataMgr
+	case DomainTableVersionV2:
+	default:
+		return nil, &shared.BadRequestError{Message: "ListDomains operation failed.  Invalid page token."}
+	}
+
+	resp, err := metadataMgr.ListDomains(&ListDomainsRequest{PageSize: request.PageSize, NextPageToken: token})
+	if err != nil {
+		return nil, err
+	}
+	for _, domain := range resp.Domains {
+		domain.TableVersion = tableVersion
+	}
+	if len(resp.NextPageToken) > 0 {
+		resp.NextPageToken = append([]byte{byte(tableVersion)}, resp.NextPageToken...)
+	} else if tableVersion == DomainTableVersionV2 {
+		resp.NextPageToken = []byte{byte(DomainTableVersionV1)}
+	}
+	return resp, nil
 }
 
 func (m *metadataManagerProxy) GetMetadata() (*GetMetadataResponse, error) {
@@ -28,6 +28,7 @@ import (
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/credentials"
 )
 
 const constDomainPartition = 0
@@ -40,8 +41,9 @@ const (
 		`VALUES(?, ?, ` + templateDomainInfoType + `, ` + templateDomainConfigType + `, ` + templateDomainReplicationConfigType + `, ?, ?, ?, ?, ?) IF NOT EXISTS`
 
 	templateGetDomainByNameQueryV2 = `SELECT domain.id, domain.name, domain.status, domain.description, ` +
-		`domain.owner_email, domain.data, config.retention, config.emit_metric, ` +
-		`replication_config.active_cluster_name, replication_config.clusters, ` +
+		`domain.owner_email, domain.data, config.retention, config.emit_metric, config.history_encoding, ` +
+		`config.suspect_binary_checksums, ` +
+		`replication_config.active_cluster_name, replication_config.clusters, replication_config.failover_drill_cluster_name, ` +
 		`is_global_domain, ` +
 		`config_version, ` +
 		`failover_version, ` +
@@ -78,8 +80,9 @@ const (
 		`and name = ?`
 
 	templateListDomainQueryV2 = `SELECT name, domain.id, domain.name, domain.status, domain.description, ` +
-		`domain.owner_email, domain.data, config.retention, config.emit_metric, ` +
-		`replication_config.active_cluster_name, replication_config.clusters, ` +
+		`domain.owner_email, domain.data, config.retention, config.emit_metric, config.history_encoding, ` +
+		`config.suspect_binary_checksums, ` +
+		`replication_config.active_cluster_name, replication_config.clusters, replication_config.failover_drill_cluster_name, ` +
 		`is_global_domain, ` +
 		`config_version, ` +
 		`failover_version, ` +
@@ -98,9 +101,10 @@ type (
 )
 
 // NewCassandraMetadataPersistenceV2 is used to create an instance of HistoryManager implementation
-func NewCassandraMetadataPersistenceV2(hosts string, port int, user, password, dc string, keyspace string,
+func NewCassandraMetadataPersistenceV2(hosts string, port int,
+	credentialsProvider credentials.Provider, dc string, keyspace string,
 	currentClusterName string, logger bark.Logger) (MetadataManager, error) {
-	cluster := common.NewCassandraCluster(hosts, port, user, password, dc)
+	cluster := common.NewCassandraCluster(hosts, port, credentialsProvider, dc)
 	cluster.Keyspace = keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
@@ -161,8 +165,11 @@ func (m *cassandraMetadataPersistenceV2) CreateDomain(request *CreateDomainReque
 		request.Info.Data,
 		request.Config.Retention,
 		request.Config.EmitMetric,
+		request.Config.HistoryEncoding,
+		request.Config.SuspectBinaryChecksums,
 		request.ReplicationConfig.ActiveClusterName,
 		serializeClusterConfigs(request.ReplicationConfig.Clusters),
+		request.ReplicationConfig.FailoverDrillClusterName,
 		request.IsGlobalDomain,
 		request.ConfigVersion,
 		request.FailoverVersion,
@@ -217,8 +224,11 @@ func (m *cassandraMetadataPersistenceV2) UpdateDomain(request *UpdateDomainReque
 		request.Info.Data,
 		request.Config.Retention,
 		request.Config.EmitMetric,
+		request.Config.HistoryEncoding,
+		request.Config.SuspectBinaryChecksums,
 		request.ReplicationConfig.ActiveClusterName,
 		serializeClusterConfigs(request.ReplicationConfig.Clusters),
+		request.ReplicationConfig.FailoverDrillClusterName,
 		request.ConfigVersion,
 		request.FailoverVersion,
 		request.FailoverNotificationVersion,
@@ -307,8 +317,11 @@ func (m *cassandraMetadataPersistenceV2) GetDomain(request *GetDomainRequest) (*
 		&info.Data,
 		&config.Retention,
 		&config.EmitMetric,
+		&config.HistoryEncoding,
+		&config.SuspectBinaryChecksums,
 		&replicationConfig.ActiveClusterName,
 		&replicationClusters,
+		&replicationConfig.FailoverDrillClusterName,
 		&isGlobalDomain,
 		&configVersion,
 		&failoverVersion,
@@ -358,8 +371,8 @@ func (m *cassandraMetadataPersistenceV2) ListDomains(request *ListDomainsRequest
 	for iter.Scan(
 		&name,
 		&domain.Info.ID, &domain.Info.Name, &domain.Info.Status, &domain.Info.Description, &domain.Info.OwnerEmail, &domain.Info.Data,
-		&domain.Config.Retention, &domain.Config.EmitMetric,
-		&domain.ReplicationConfig.ActiveClusterName, &replicationClusters,
+		&domain.Config.Retention, &domain.Config.EmitMetric, &domain.Config.HistoryEncoding, &domain.Config.SuspectBinaryChecksums,
+		&domain.ReplicationConfig.ActiveClusterName, &replicationClusters, &domain.ReplicationConfig.FailoverDrillClusterName,
 		&domain.IsGlobalDomain, &domain.ConfigVersion, &domain.FailoverVersion,
 		&domain.FailoverNotificationVersion, &domain.NotificationVersion,
 	) {
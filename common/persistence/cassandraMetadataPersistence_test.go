@@ -708,6 +708,59 @@ func (m *metadataPersistenceSuite) TestDeleteDomain() {
 	m.Nil(resp9)
 }
 
+func (m *metadataPersistenceSuite) TestListDomains() {
+	domainV1 := []string{uuid.New(), uuid.New()}
+	for i, id := range domainV1 {
+		_, err := m.CreateDomain(
+			&DomainInfo{ID: id, Name: fmt.Sprintf("list-domain-test-name-v1-%v", i), Status: DomainStatusRegistered},
+			&DomainConfig{Retention: 10},
+			&DomainReplicationConfig{},
+			false,
+			0,
+			0,
+		)
+		m.Nil(err)
+	}
+	domainV2 := uuid.New()
+	_, err := m.MetadataManagerV2.CreateDomain(&CreateDomainRequest{
+		Info:              &DomainInfo{ID: domainV2, Name: "list-domain-test-name-v2", Status: DomainStatusRegistered},
+		Config:            &DomainConfig{Retention: 10},
+		ReplicationConfig: &DomainReplicationConfig{},
+		IsGlobalDomain:    true,
+	})
+	m.Nil(err)
+
+	// the v1 table is listed after the v2 one through the proxy
+	var token []byte
+	tableVersions := make(map[string]int)
+	for {
+		resp, err := m.MetadataProxy.ListDomains(&ListDomainsRequest{
+			PageSize:         1,
+			NextPageToken:    token,
+			IncludeV1Domains: true,
+		})
+		m.Nil(err)
+		for _, domain := range resp.Domains {
+			tableVersions[domain.Info.ID] = domain.TableVersion
+		}
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			break
+		}
+	}
+	m.Equal(DomainTableVersionV2, tableVersions[domainV2])
+	for _, id := range domainV1 {
+		m.Equal(DomainTableVersionV1, tableVersions[id])
+	}
+
+	// the v1 table is not listed by default
+	resp, err := m.MetadataProxy.ListDomains(&ListDomainsRequest{PageSize: 100})
+	m.Nil(err)
+	for _, domain := range resp.Domains {
+		m.NotContains(domainV1, domain.Info.ID)
+	}
+}
+
 func (m *metadataPersistenceSuite) CreateDomain(info *DomainInfo, config *DomainConfig,
 	replicationConfig *DomainReplicationConfig, isGlobaldomain bool, configVersion int64, failoverVersion int64) (*CreateDomainResponse, error) {
 	return m.MetadataManager.CreateDomain(&CreateDomainRequest{
@@ -21,7 +21,12 @@
 package persistence
 
 import (
+	"context"
+	"encoding/binary"
 	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,6 +35,7 @@ import (
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/credentials"
 )
 
 // Guidelines for creating new special UUID constants
@@ -65,6 +71,10 @@ const (
 	rowTypeReplicationDomainID   = "10000000-5000-f000-f000-000000000000"
 	rowTypeReplicationWorkflowID = "20000000-5000-f000-f000-000000000000"
 	rowTypeReplicationRunID      = "30000000-5000-f000-f000-000000000000"
+
+	// the workflow ID of replication DLQ message rows is the source cluster
+	rowTypeReplicationDLQDomainID = "10000000-6000-f000-f000-000000000000"
+	rowTypeReplicationDLQRunID    = "30000000-6000-f000-f000-000000000000"
 	// Special TaskId constants
 	rowTypeExecutionTaskID  = int64(-10)
 	rowTypeShardTaskID      = int64(-11)
@@ -84,12 +94,16 @@ const (
 	rowTypeTransferTask
 	rowTypeTimerTask
 	rowTypeReplicationTask
+	rowTypeReplicationDLQMessage
 )
 
 const (
 	// Row types for table tasks
 	rowTypeTask = iota
 	rowTypeTaskList
+	rowTypeDLQTask
+	// the tasks of priority level 1 and above, the tasks of level 0 are rowTypeTask rows
+	rowTypePriorityTask
 )
 
 const (
@@ -147,7 +161,14 @@ const (
 		`sticky_schedule_to_start_timeout: ?,` +
 		`client_library_version: ?, ` +
 		`client_feature_version: ?, ` +
-		`client_impl: ?` +
+		`client_impl: ?, ` +
+		`sticky_invalidation_count: ?, ` +
+		`tags: ?, ` +
+		`continue_as_new_task_list: ?, ` +
+		`history_size: ?, ` +
+		`isolation_group: ?, ` +
+		`priority: ?, ` +
+		`first_run_id: ?` +
 		`}`
 
 	templateReplicationStateType = `{` +
@@ -183,7 +204,9 @@ const (
 		`first_event_id: ?,` +
 		`next_event_id: ?,` +
 		`version: ?,` +
-		`last_replication_info: ?` +
+		`last_replication_info: ?,` +
+		`history_size: ?,` +
+		`scheduled_id: ?` +
 		`}`
 
 	templateTimerTaskType = `{` +
@@ -262,6 +285,13 @@ const (
 		`control: ?` +
 		`}`
 
+	templateUpdateInfoType = `{` +
+		`version: ?,` +
+		`update_id: ?, ` +
+		`completed: ?, ` +
+		`result: ?` +
+		`}`
+
 	templateBufferedReplicationTaskInfoType = `{` +
 		`first_event_id: ?, ` +
 		`next_event_id: ?, ` +
@@ -295,7 +325,11 @@ const (
 		`domain_id: ?, ` +
 		`workflow_id: ?, ` +
 		`run_id: ?, ` +
-		`schedule_id: ?` +
+		`schedule_id: ?, ` +
+		`isolation_group: ?, ` +
+		`dispatch_attempts: ?, ` +
+		`priority: ?, ` +
+		`created_time: ?` +
 		`}`
 
 	templateCreateShardQuery = `INSERT INTO executions (` +
@@ -354,6 +388,36 @@ const (
 		`shard_id, type, domain_id, workflow_id, run_id, replication, visibility_ts, task_id) ` +
 		`VALUES(?, ?, ?, ?, ?, ` + templateReplicationTaskType + `, ?, ?)`
 
+	// a message moved to the DLQ again keeps the write time of its row
+	templateCreateReplicationDLQMessageQuery = `INSERT INTO executions (` +
+		`shard_id, type, domain_id, workflow_id, run_id, replication, visibility_ts, task_id) ` +
+		`VALUES(?, ?, ?, ?, ?, ` + templateReplicationTaskType + `, ?, ?) IF NOT EXISTS`
+
+	templateDeleteReplicationDLQMessageQuery = `DELETE FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ?`
+
+	// the write time of a message row is the time the message was moved to the DLQ
+	templateGetReplicationDLQMessagesQuery = `SELECT writetime(replication) ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ?`
+
+	// domain_id follows type in the clustering key, the executions of a domain are a slice of the shard partition
+	templateListDomainExecutionsQuery = `SELECT workflow_id, run_id ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ?`
+
 	templateCreateTimerTaskQuery = `INSERT INTO executions (` +
 		`shard_id, type, domain_id, workflow_id, run_id, timer, visibility_ts, task_id) ` +
 		`VALUES(?, ?, ?, ?, ?, ` + templateTimerTaskType + `, ?, ?)`
@@ -369,7 +433,7 @@ const (
 		`and task_id = ? ` +
 		`IF range_id = ?`
 
-	templateGetWorkflowExecutionQuery = `SELECT execution, replication_state, activity_map, timer_map, child_executions_map, request_cancel_map, signal_map, signal_requested, buffered_events_list, buffered_replication_tasks_map ` +
+	templateGetWorkflowExecutionQuery = `SELECT execution, replication_state, activity_map, timer_map, child_executions_map, request_cancel_map, signal_map, signal_requested, update_map, buffered_events_list, buffered_replication_tasks_map ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
@@ -543,6 +607,28 @@ const (
 		`and task_id = ? ` +
 		`IF next_event_id = ?`
 
+	templateUpdateUpdateInfoQuery = `UPDATE executions ` +
+		`SET update_map[ ? ] =` + templateUpdateInfoType + ` ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ? ` +
+		`IF next_event_id = ?`
+
+	templateResetUpdateInfoQuery = `UPDATE executions ` +
+		`SET update_map = ?` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ? ` +
+		`IF next_event_id = ?`
+
 	templateAppendBufferedEventsQuery = `UPDATE executions ` +
 		`SET buffered_events_list = buffered_events_list + ? ` +
 		`WHERE shard_id = ? ` +
@@ -554,6 +640,17 @@ const (
 		`and task_id = ? ` +
 		`IF next_event_id = ?`
 
+	templateReplaceBufferedEventsQuery = `UPDATE executions ` +
+		`SET buffered_events_list = ? ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ? ` +
+		`IF next_event_id = ?`
+
 	templateDeleteBufferedEventsQuery = `UPDATE executions ` +
 		`SET buffered_events_list = [] ` +
 		`WHERE shard_id = ? ` +
@@ -677,6 +774,16 @@ const (
 		`and visibility_ts = ? ` +
 		`and task_id = ? `
 
+	templateDeleteCurrentWorkflowExecutionQuery = `DELETE FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ? ` +
+		`IF current_run_id = ?`
+
 	templateDeleteWorkflowExecutionSignalRequestedQuery = `UPDATE executions ` +
 		`SET signal_requested = signal_requested - ? ` +
 		`WHERE shard_id = ? ` +
@@ -719,6 +826,15 @@ const (
 		`and visibility_ts = ? ` +
 		`and task_id = ?`
 
+	templateRangeCompleteReplicationTaskQuery = `DELETE FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id <= ?`
+
 	templateGetTimerTasksQuery = `SELECT timer ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
@@ -762,9 +878,17 @@ const (
 		`and type = ? ` +
 		`and task_id = ?`
 
+	templateGetDLQTasksQuery = `SELECT task_id, task ` +
+		`FROM tasks ` +
+		`WHERE domain_id = ? ` +
+		`and task_list_name = ? ` +
+		`and task_list_type = ? ` +
+		`and type = ?`
+
 	templateGetTaskList = `SELECT ` +
 		`range_id, ` +
-		`task_list ` +
+		`task_list, ` +
+		`task_list_pollers ` +
 		`FROM tasks ` +
 		`WHERE domain_id = ? ` +
 		`and task_list_name = ? ` +
@@ -772,6 +896,20 @@ const (
 		`and type = ? ` +
 		`and task_id = ?`
 
+	templateListTaskListQuery = `SELECT ` +
+		`task_list_name, ` +
+		`task_list_type ` +
+		`FROM task_lists_by_domain ` +
+		`WHERE domain_id = ?`
+
+	templateInsertTaskListByDomainQuery = `INSERT INTO task_lists_by_domain (` +
+		`domain_id, ` +
+		`task_list_name, ` +
+		`task_list_type) ` +
+		`VALUES (?, ?, ?)`
+
+	templateInsertTaskListByDomainQueryWithTTL = templateInsertTaskListByDomainQuery + ` USING TTL ?`
+
 	templateInsertTaskListQuery = `INSERT INTO tasks (` +
 		`domain_id, ` +
 		`task_list_name, ` +
@@ -792,6 +930,17 @@ const (
 		`and task_id = ? ` +
 		`IF range_id = ?`
 
+	templateUpdateTaskListWithPollersQuery = `UPDATE tasks SET ` +
+		`range_id = ?, ` +
+		`task_list = ` + templateTaskListType + `, ` +
+		`task_list_pollers = ? ` +
+		`WHERE domain_id = ? ` +
+		`and task_list_name = ? ` +
+		`and task_list_type = ? ` +
+		`and type = ? ` +
+		`and task_id = ? ` +
+		`IF range_id = ?`
+
 	templateUpdateTaskListQueryWithTTL = `INSERT INTO tasks (` +
 		`domain_id, ` +
 		`task_list_name, ` +
@@ -799,8 +948,9 @@ const (
 		`type, ` +
 		`task_id, ` +
 		`range_id, ` +
-		`task_list ` +
-		`) VALUES (?, ?, ?, ?, ?, ?, ` + templateTaskListType + `) USING TTL ?`
+		`task_list, ` +
+		`task_list_pollers ` +
+		`) VALUES (?, ?, ?, ?, ?, ?, ` + templateTaskListType + `, ?) USING TTL ?`
 )
 
 var (
@@ -818,9 +968,10 @@ type (
 )
 
 // NewCassandraShardPersistence is used to create an instance of ShardManager implementation
-func NewCassandraShardPersistence(hosts string, port int, user, password, dc string, keyspace string,
+func NewCassandraShardPersistence(hosts string, port int,
+	credentialsProvider credentials.Provider, dc string, keyspace string,
 	currentClusterName string, logger bark.Logger) (ShardManager, error) {
-	cluster := common.NewCassandraCluster(hosts, port, user, password, dc)
+	cluster := common.NewCassandraCluster(hosts, port, credentialsProvider, dc)
 	cluster.Keyspace = keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
@@ -842,9 +993,10 @@ func NewCassandraWorkflowExecutionPersistence(shardID int, session *gocql.Sessio
 }
 
 // NewCassandraTaskPersistence is used to create an instance of TaskManager implementation
-func NewCassandraTaskPersistence(hosts string, port int, user, password, dc string, keyspace string,
+func NewCassandraTaskPersistence(hosts string, port int,
+	credentialsProvider credentials.Provider, dc string, keyspace string,
 	logger bark.Logger) (TaskManager, error) {
-	cluster := common.NewCassandraCluster(hosts, port, user, password, dc)
+	cluster := common.NewCassandraCluster(hosts, port, credentialsProvider, dc)
 	cluster.Keyspace = keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
@@ -1001,6 +1153,169 @@ func (d *cassandraPersistence) UpdateShard(request *UpdateShardRequest) error {
 	return nil
 }
 
+func (d *cassandraPersistence) PutReplicationDLQMessage(request *PutReplicationDLQMessageRequest) error {
+	query := d.session.Query(templateCreateReplicationDLQMessageQuery,
+		request.ShardID,
+		rowTypeReplicationDLQMessage,
+		rowTypeReplicationDLQDomainID,
+		request.SourceCluster,
+		rowTypeReplicationDLQRunID,
+		request.DomainID,
+		request.WorkflowID,
+		request.RunID,
+		replicationDLQMessageTaskID(&request.ReplicationDLQMessageKey),
+		ReplicationTaskTypeHistory,
+		request.FirstEventID,
+		request.NextEventID,
+		request.Version,
+		nil,
+		0,
+		common.EmptyEventID,
+		defaultVisibilityTimestamp,
+		replicationDLQMessageTaskID(&request.ReplicationDLQMessageKey))
+
+	if _, err := query.MapScanCAS(make(map[string]interface{})); err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("PutReplicationDLQMessage operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("PutReplicationDLQMessage operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+func (d *cassandraPersistence) DeleteReplicationDLQMessage(request *DeleteReplicationDLQMessageRequest) error {
+	query := d.session.Query(templateDeleteReplicationDLQMessageQuery,
+		request.ShardID,
+		rowTypeReplicationDLQMessage,
+		rowTypeReplicationDLQDomainID,
+		request.SourceCluster,
+		rowTypeReplicationDLQRunID,
+		defaultVisibilityTimestamp,
+		replicationDLQMessageTaskID(&request.ReplicationDLQMessageKey))
+
+	if err := query.Exec(); err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteReplicationDLQMessage operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteReplicationDLQMessage operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+func (d *cassandraPersistence) GetReplicationDLQSummary(request *GetReplicationDLQSummaryRequest) (
+	*GetReplicationDLQSummaryResponse, error) {
+	response := &GetReplicationDLQSummaryResponse{}
+	query := d.session.Query(templateGetReplicationDLQMessagesQuery,
+		request.ShardID,
+		rowTypeReplicationDLQMessage,
+		rowTypeReplicationDLQDomainID,
+		request.SourceCluster,
+		rowTypeReplicationDLQRunID)
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "GetReplicationDLQSummary operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	var oldest int64
+	var writeTime int64
+	for iter.Scan(&writeTime) {
+		if response.MessageCount == 0 || writeTime < oldest {
+			oldest = writeTime
+		}
+		response.MessageCount++
+	}
+	if err := iter.Close(); err != nil {
+		return nil, convertReplicationDLQSummaryError(err)
+	}
+	if response.MessageCount > 0 {
+		// write times are in microseconds
+		response.OldestMessageTimestamp = time.Unix(0, oldest*int64(time.Microsecond))
+	}
+
+	return response, nil
+}
+
+func convertReplicationDLQSummaryError(err error) error {
+	if isThrottlingError(err) {
+		return &workflow.ServiceBusyError{
+			Message: fmt.Sprintf("GetReplicationDLQSummary operation failed. Error: %v", err),
+		}
+	}
+	return &workflow.InternalServiceError{
+		Message: fmt.Sprintf("GetReplicationDLQSummary operation failed. Error: %v", err),
+	}
+}
+
+// replicationDLQMessageTaskID derives the task ID of the row of a message from the replication task it carries, so
+// the same task moved to the DLQ twice has a single row, and the row can be found again once the task is applied
+func replicationDLQMessageTaskID(key *ReplicationDLQMessageKey) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(key.DomainID))
+	hash.Write([]byte{0})
+	hash.Write([]byte(key.WorkflowID))
+	hash.Write([]byte{0})
+	hash.Write([]byte(key.RunID))
+	hash.Write([]byte{0})
+	hash.Write([]byte(strconv.FormatInt(key.FirstEventID, 10)))
+	return int64(hash.Sum64() & math.MaxInt64)
+}
+
+func (d *cassandraPersistence) ListDomainExecutions(request *ListDomainExecutionsRequest) (
+	*ListDomainExecutionsResponse, error) {
+	query := d.session.Query(templateListDomainExecutionsQuery,
+		request.ShardID,
+		rowTypeExecution,
+		request.DomainID)
+	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListDomainExecutions operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &ListDomainExecutionsResponse{}
+	var workflowID string
+	var runID gocql.UUID
+	for iter.Scan(&workflowID, &runID) {
+		if runID.String() == permanentRunID {
+			// the current execution row of the workflow
+			continue
+		}
+		response.Executions = append(response.Executions, workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(workflowID),
+			RunId:      common.StringPtr(runID.String()),
+		})
+	}
+
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListDomainExecutions operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListDomainExecutions operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
 func (d *cassandraPersistence) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (
 	*CreateWorkflowExecutionResponse, error) {
 	cqlNowTimestamp := common.UnixNanoToCQLTimestamp(time.Now().UnixNano())
@@ -1214,7 +1529,7 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *Creat
 			request.DecisionVersion,
 			request.DecisionScheduleID,
 			request.DecisionStartedID,
-			"", // Decision Start Request ID
+			request.DecisionRequestID,
 			request.DecisionStartToCloseTimeout,
 			0,
 			0,
@@ -1225,6 +1540,13 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *Creat
 			"", // client_library_version
 			"", // client_feature_version
 			"", // client_impl
+			0,  // sticky_invalidation_count
+			request.Tags,
+			"", // continue_as_new_task_list
+			request.HistorySize,
+			"", // isolation_group
+			request.Priority,
+			request.FirstRunID,
 			request.NextEventID,
 			defaultVisibilityTimestamp,
 			rowTypeExecutionTaskID)
@@ -1264,7 +1586,7 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *Creat
 			request.DecisionVersion,
 			request.DecisionScheduleID,
 			request.DecisionStartedID,
-			"", // Decision Start Request ID
+			request.DecisionRequestID,
 			request.DecisionStartToCloseTimeout,
 			0,
 			0,
@@ -1275,6 +1597,13 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *Creat
 			"", // client_library_version
 			"", // client_feature_version
 			"", // client_impl
+			0,  // sticky_invalidation_count
+			request.Tags,
+			"", // continue_as_new_task_list
+			request.HistorySize,
+			"", // isolation_group
+			request.Priority,
+			request.FirstRunID,
 			request.ReplicationState.CurrentVersion,
 			request.ReplicationState.StartVersion,
 			request.ReplicationState.LastWriteVersion,
@@ -1370,6 +1699,14 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *GetWorkflowExecutio
 	}
 	state.SignalRequestedIDs = signalRequestedIDs
 
+	updateInfos := make(map[string]*UpdateInfo)
+	uMap := result["update_map"].(map[string]map[string]interface{})
+	for key, value := range uMap {
+		info := createUpdateInfo(value)
+		updateInfos[key] = info
+	}
+	state.UpdateInfos = updateInfos
+
 	eList := result["buffered_events_list"].([]map[string]interface{})
 	bufferedEvents := make([]*SerializedHistoryEventBatch, 0, len(eList))
 	for _, v := range eList {
@@ -1396,7 +1733,8 @@ func (d *cassandraPersistence) UpdateWorkflowExecution(request *UpdateWorkflowEx
 	replicationState := request.ReplicationState
 
 	if replicationState == nil {
-		// Updates will be called with null ReplicationState while the feature is disabled
+		// Updates will be called with null ReplicationState while the feature is disabled, or when the replication
+		// state did not change since it was last written
 		batch.Query(templateUpdateWorkflowExecutionQuery,
 			executionInfo.DomainID,
 			executionInfo.WorkflowID,
@@ -1433,6 +1771,13 @@ func (d *cassandraPersistence) UpdateWorkflowExecution(request *UpdateWorkflowEx
 			executionInfo.ClientLibraryVersion,
 			executionInfo.ClientFeatureVersion,
 			executionInfo.ClientImpl,
+			executionInfo.StickyInvalidationCount,
+			executionInfo.Tags,
+			executionInfo.ContinueAsNewTaskList,
+			executionInfo.HistorySize,
+			executionInfo.IsolationGroup,
+			executionInfo.Priority,
+			executionInfo.FirstRunID,
 			executionInfo.NextEventID,
 			d.shardID,
 			rowTypeExecution,
@@ -1484,6 +1829,13 @@ func (d *cassandraPersistence) UpdateWorkflowExecution(request *UpdateWorkflowEx
 			executionInfo.ClientLibraryVersion,
 			executionInfo.ClientFeatureVersion,
 			executionInfo.ClientImpl,
+			executionInfo.StickyInvalidationCount,
+			executionInfo.Tags,
+			executionInfo.ContinueAsNewTaskList,
+			executionInfo.HistorySize,
+			executionInfo.IsolationGroup,
+			executionInfo.Priority,
+			executionInfo.FirstRunID,
 			replicationState.CurrentVersion,
 			replicationState.StartVersion,
 			replicationState.LastWriteVersion,
@@ -1527,6 +1879,9 @@ func (d *cassandraPersistence) UpdateWorkflowExecution(request *UpdateWorkflowEx
 	d.updateSignalsRequested(batch, request.UpsertSignalRequestedIDs, request.DeleteSignalRequestedID,
 		executionInfo.DomainID, executionInfo.WorkflowID, executionInfo.RunID, request.Condition, request.RangeID)
 
+	d.updateUpdateInfos(batch, request.UpsertUpdateInfos, executionInfo.DomainID, executionInfo.WorkflowID,
+		executionInfo.RunID, request.Condition)
+
 	d.updateBufferedEvents(batch, request.NewBufferedEvents, request.ClearBufferedEvents,
 		executionInfo.DomainID, executionInfo.WorkflowID, executionInfo.RunID, request.Condition, request.RangeID)
 
@@ -1710,6 +2065,13 @@ func (d *cassandraPersistence) ResetMutableState(request *ResetMutableStateReque
 		executionInfo.ClientLibraryVersion,
 		executionInfo.ClientFeatureVersion,
 		executionInfo.ClientImpl,
+		executionInfo.StickyInvalidationCount,
+		executionInfo.Tags,
+		executionInfo.ContinueAsNewTaskList,
+		executionInfo.HistorySize,
+		executionInfo.IsolationGroup,
+		executionInfo.Priority,
+		executionInfo.FirstRunID,
 		replicationState.CurrentVersion,
 		replicationState.StartVersion,
 		replicationState.LastWriteVersion,
@@ -1743,6 +2105,9 @@ func (d *cassandraPersistence) ResetMutableState(request *ResetMutableStateReque
 	d.resetSignalRequested(batch, request.InsertSignalRequestedIDs, executionInfo.DomainID, executionInfo.WorkflowID,
 		executionInfo.RunID, request.Condition)
 
+	d.resetUpdateInfos(batch, request.InsertUpdateInfos, executionInfo.DomainID, executionInfo.WorkflowID,
+		executionInfo.RunID, request.Condition)
+
 	d.resetBufferedEvents(batch, executionInfo.DomainID, executionInfo.WorkflowID, executionInfo.RunID, request.Condition)
 
 	// Verifies that the RangeID has not changed
@@ -1862,6 +2227,34 @@ func (d *cassandraPersistence) DeleteWorkflowExecution(request *DeleteWorkflowEx
 	return nil
 }
 
+func (d *cassandraPersistence) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
+	query := d.session.Query(templateDeleteCurrentWorkflowExecutionQuery,
+		d.shardID,
+		rowTypeExecution,
+		request.DomainID,
+		request.WorkflowID,
+		permanentRunID,
+		defaultVisibilityTimestamp,
+		rowTypeExecutionTaskID,
+		request.RunID)
+
+	// the delete is not applied when the current execution already points to another run, which is left untouched
+	previous := make(map[string]interface{})
+	_, err := query.MapScanCAS(previous)
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteCurrentWorkflowExecution operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteCurrentWorkflowExecution operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
 func (d *cassandraPersistence) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse,
 	error) {
 	query := d.session.Query(templateGetCurrentExecutionQuery,
@@ -1944,9 +2337,21 @@ func (d *cassandraPersistence) GetTransferTasks(request *GetTransferTasksRequest
 	return response, nil
 }
 
+// GetReplicationTasks pages by task ID rather than by the cassandra page state, so that a page cut short by
+// MaxBatchSizeBytes resumes right after its last task. The NextPageToken is the ID of that task.
 func (d *cassandraPersistence) GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse,
 	error) {
 
+	readLevel := request.ReadLevel
+	if len(request.NextPageToken) > 0 {
+		if len(request.NextPageToken) != 8 {
+			return nil, &workflow.BadRequestError{
+				Message: "GetReplicationTasks operation failed.  Invalid next page token.",
+			}
+		}
+		readLevel = int64(binary.BigEndian.Uint64(request.NextPageToken))
+	}
+
 	// Reading replication tasks need to be quorum level consistent, otherwise we could loose task
 	query := d.session.Query(templateGetReplicationTasksQuery,
 		d.shardID,
@@ -1955,9 +2360,9 @@ func (d *cassandraPersistence) GetReplicationTasks(request *GetReplicationTasksR
 		rowTypeReplicationWorkflowID,
 		rowTypeReplicationRunID,
 		defaultVisibilityTimestamp,
-		request.ReadLevel,
+		readLevel,
 		request.MaxReadLevel,
-	).PageSize(request.BatchSize).PageState(request.NextPageToken)
+	).PageSize(request.BatchSize)
 
 	iter := query.Iter()
 	if iter == nil {
@@ -1968,16 +2373,22 @@ func (d *cassandraPersistence) GetReplicationTasks(request *GetReplicationTasksR
 
 	response := &GetReplicationTasksResponse{}
 	task := make(map[string]interface{})
-	for iter.MapScan(task) {
+	var batchSizeBytes int64
+	isFull := false
+	for !isFull && iter.MapScan(task) {
 		t := createReplicationTaskInfo(task["replication"].(map[string]interface{}))
 		// Reset task map to get it ready for next scan
 		task = make(map[string]interface{})
 
 		response.Tasks = append(response.Tasks, t)
+		batchSizeBytes += t.HistorySize
+		isFull = (request.BatchSize > 0 && len(response.Tasks) >= request.BatchSize) ||
+			(request.MaxBatchSizeBytes > 0 && batchSizeBytes >= request.MaxBatchSizeBytes)
+	}
+	if isFull {
+		response.NextPageToken = make([]byte, 8)
+		binary.BigEndian.PutUint64(response.NextPageToken, uint64(response.Tasks[len(response.Tasks)-1].TaskID))
 	}
-	nextPageToken := iter.PageState()
-	response.NextPageToken = make([]byte, len(nextPageToken))
-	copy(response.NextPageToken, nextPageToken)
 
 	if err := iter.Close(); err != nil {
 		return nil, &workflow.InternalServiceError{
@@ -2038,6 +2449,31 @@ func (d *cassandraPersistence) CompleteReplicationTask(request *CompleteReplicat
 	return nil
 }
 
+func (d *cassandraPersistence) RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error {
+	query := d.session.Query(templateRangeCompleteReplicationTaskQuery,
+		d.shardID,
+		rowTypeReplicationTask,
+		rowTypeReplicationDomainID,
+		rowTypeReplicationWorkflowID,
+		rowTypeReplicationRunID,
+		defaultVisibilityTimestamp,
+		request.InclusiveEndTaskID)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("RangeCompleteReplicationTask operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("RangeCompleteReplicationTask operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
 func (d *cassandraPersistence) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
 	ts := common.UnixNanoToCQLTimestamp(request.VisibilityTimestamp.UnixNano())
 	query := d.session.Query(templateCompleteTimerTaskQuery,
@@ -2080,7 +2516,8 @@ func (d *cassandraPersistence) LeaseTaskList(request *LeaseTaskListRequest) (*Le
 	)
 	var rangeID, ackLevel int64
 	var tlDB map[string]interface{}
-	err := query.Scan(&rangeID, &tlDB)
+	var pollersDB []map[string]interface{}
+	err := query.Scan(&rangeID, &tlDB, &pollersDB)
 	if err != nil {
 		if err == gocql.ErrNotFound { // First time task list is used
 			query = d.session.Query(templateInsertTaskListQuery,
@@ -2144,9 +2581,35 @@ func (d *cassandraPersistence) LeaseTaskList(request *LeaseTaskListRequest) (*Le
 		}
 	}
 	tli := &TaskListInfo{DomainID: request.DomainID, Name: request.TaskList, TaskType: request.TaskType, RangeID: rangeID + 1, AckLevel: ackLevel, Kind: request.TaskListKind}
+	for _, v := range pollersDB {
+		tli.Pollers = append(tli.Pollers, createTaskListPollerInfo(v))
+	}
+	// the task list is indexed on every lease, which also indexes the task lists created before the index existed
+	if err := d.insertTaskListByDomain(tli, "LeaseTaskList"); err != nil {
+		return nil, err
+	}
 	return &LeaseTaskListResponse{TaskListInfo: tli}, nil
 }
 
+func (d *cassandraPersistence) insertTaskListByDomain(tli *TaskListInfo, operation string) error {
+	query := d.session.Query(templateInsertTaskListByDomainQuery, tli.DomainID, tli.Name, tli.TaskType)
+	if tli.Kind == TaskListKindSticky { // sticky task lists expire along with their task list row
+		query = d.session.Query(templateInsertTaskListByDomainQueryWithTTL, tli.DomainID, tli.Name, tli.TaskType,
+			stickyTaskListTTL)
+	}
+	if err := query.Exec(); err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("%v operation failed. Error: %v", operation, err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("%v operation failed. Error: %v", operation, err),
+		}
+	}
+	return nil
+}
+
 // From TaskManager interface
 func (d *cassandraPersistence) UpdateTaskList(request *UpdateTaskListRequest) (*UpdateTaskListResponse, error) {
 	tli := request.TaskListInfo
@@ -2164,6 +2627,7 @@ func (d *cassandraPersistence) UpdateTaskList(request *UpdateTaskListRequest) (*
 			tli.TaskType,
 			tli.AckLevel,
 			tli.Kind,
+			createTaskListPollersList(tli.Pollers),
 			stickyTaskListTTL,
 		)
 		err := query.Exec()
@@ -2177,16 +2641,21 @@ func (d *cassandraPersistence) UpdateTaskList(request *UpdateTaskListRequest) (*
 				Message: fmt.Sprintf("UpdateTaskList operation failed. Error: %v", err),
 			}
 		}
+		// the TTL of the index row is refreshed together with the one of the task list row
+		if err := d.insertTaskListByDomain(tli, "UpdateTaskList"); err != nil {
+			return nil, err
+		}
 		return &UpdateTaskListResponse{}, nil
 	}
 
-	query := d.session.Query(templateUpdateTaskListQuery,
+	query := d.session.Query(templateUpdateTaskListWithPollersQuery,
 		tli.RangeID,
 		tli.DomainID,
 		&tli.Name,
 		tli.TaskType,
 		tli.AckLevel,
 		tli.Kind,
+		createTaskListPollersList(tli.Pollers),
 		tli.DomainID,
 		&tli.Name,
 		tli.TaskType,
@@ -2234,28 +2703,37 @@ func (d *cassandraPersistence) CreateTasks(request *CreateTasksRequest) (*Create
 
 	for _, task := range request.Tasks {
 		scheduleID := task.Data.ScheduleID
+		rowType := taskRowType(TaskPriorityLevel(task.Data.Priority))
 		if task.Data.ScheduleToStartTimeout == 0 {
 			batch.Query(templateCreateTaskQuery,
 				domainID,
 				taskList,
 				taskListType,
-				rowTypeTask,
+				rowType,
 				task.TaskID,
 				domainID,
 				task.Execution.GetWorkflowId(),
 				task.Execution.GetRunId(),
-				scheduleID)
+				scheduleID,
+				task.Data.IsolationGroup,
+				task.Data.DispatchAttempts,
+				task.Data.Priority,
+				task.Data.CreatedTime)
 		} else {
 			batch.Query(templateCreateTaskWithTTLQuery,
 				domainID,
 				taskList,
 				taskListType,
-				rowTypeTask,
+				rowType,
 				task.TaskID,
 				domainID,
 				task.Execution.GetWorkflowId(),
 				task.Execution.GetRunId(),
 				scheduleID,
+				task.Data.IsolationGroup,
+				task.Data.DispatchAttempts,
+				task.Data.Priority,
+				task.Data.CreatedTime,
 				task.Data.ScheduleToStartTimeout)
 		}
 	}
@@ -2299,6 +2777,15 @@ func (d *cassandraPersistence) CreateTasks(request *CreateTasksRequest) (*Create
 	return &CreateTasksResponse{}, nil
 }
 
+// taskRowType returns the row type of the tasks of a priority level. The levels are clustered apart in the
+// partition of the task list, so each of them is read in task ID order on its own.
+func taskRowType(priorityLevel int) int {
+	if priorityLevel <= 0 {
+		return rowTypeTask
+	}
+	return rowTypePriorityTask + priorityLevel - 1
+}
+
 // From TaskManager interface
 func (d *cassandraPersistence) GetTasks(request *GetTasksRequest) (*GetTasksResponse, error) {
 	if request.ReadLevel > request.MaxReadLevel {
@@ -2310,7 +2797,7 @@ func (d *cassandraPersistence) GetTasks(request *GetTasksRequest) (*GetTasksResp
 		request.DomainID,
 		request.TaskList,
 		request.TaskType,
-		rowTypeTask,
+		taskRowType(request.PriorityLevel),
 		request.ReadLevel,
 		request.MaxReadLevel,
 	).PageSize(request.BatchSize)
@@ -2355,7 +2842,7 @@ func (d *cassandraPersistence) CompleteTask(request *CompleteTaskRequest) error
 		tli.DomainID,
 		tli.Name,
 		tli.TaskType,
-		rowTypeTask,
+		taskRowType(request.PriorityLevel),
 		request.TaskID)
 
 	err := query.Exec()
@@ -2373,6 +2860,177 @@ func (d *cassandraPersistence) CompleteTask(request *CompleteTaskRequest) error
 	return nil
 }
 
+// From TaskManager interface
+func (d *cassandraPersistence) CreateDLQTask(request *CreateDLQTaskRequest) error {
+	tli := request.TaskList
+	task := request.Task
+	// DLQ tasks share the partition of their task list, but are not covered by its range
+	query := d.session.Query(templateCreateTaskQuery,
+		tli.DomainID,
+		tli.Name,
+		tli.TaskType,
+		rowTypeDLQTask,
+		task.TaskID,
+		tli.DomainID,
+		task.WorkflowID,
+		task.RunID,
+		task.ScheduleID,
+		task.IsolationGroup,
+		task.DispatchAttempts,
+		task.Priority,
+		task.CreatedTime)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("CreateDLQTask operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CreateDLQTask operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+// From TaskManager interface
+func (d *cassandraPersistence) GetDLQTasks(request *GetDLQTasksRequest) (*GetDLQTasksResponse, error) {
+	query := d.session.Query(templateGetDLQTasksQuery,
+		request.DomainID,
+		request.TaskList,
+		request.TaskType,
+		rowTypeDLQTask,
+	)
+	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "GetDLQTasks operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &GetDLQTasksResponse{}
+	task := make(map[string]interface{})
+	for iter.MapScan(task) {
+		taskID, ok := task["task_id"]
+		if !ok { // no tasks, but static column record returned
+			continue
+		}
+		t := createTaskInfo(task["task"].(map[string]interface{}))
+		t.TaskID = taskID.(int64)
+		response.Tasks = append(response.Tasks, t)
+		task = make(map[string]interface{}) // Reinitialize map as initialized fails on unmarshalling
+	}
+
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("GetDLQTasks operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetDLQTasks operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
+// From TaskManager interface
+func (d *cassandraPersistence) DeleteDLQTask(request *DeleteDLQTaskRequest) error {
+	tli := request.TaskList
+	query := d.session.Query(templateCompleteTaskQuery,
+		tli.DomainID,
+		tli.Name,
+		tli.TaskType,
+		rowTypeDLQTask,
+		request.TaskID)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteDLQTask operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteDLQTask operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+// From TaskManager interface
+func (d *cassandraPersistence) ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error) {
+	query := d.session.Query(templateListTaskListQuery, request.DomainID)
+	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListTaskList operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	var name string
+	var taskType int
+	var indexed []*TaskListInfo
+	for iter.Scan(&name, &taskType) {
+		indexed = append(indexed, &TaskListInfo{DomainID: request.DomainID, Name: name, TaskType: taskType})
+	}
+
+	nextPageToken := iter.PageState()
+	response := &ListTaskListResponse{NextPageToken: make([]byte, len(nextPageToken))}
+	copy(response.NextPageToken, nextPageToken)
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListTaskList operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListTaskList operation failed. Error: %v", err),
+		}
+	}
+
+	for _, tli := range indexed {
+		query := d.session.Query(templateGetTaskList,
+			tli.DomainID,
+			tli.Name,
+			tli.TaskType,
+			rowTypeTaskList,
+			taskListTaskID,
+		)
+		var tlDB map[string]interface{}
+		var pollersDB []map[string]interface{}
+		if err := query.Scan(&tli.RangeID, &tlDB, &pollersDB); err != nil {
+			if err == gocql.ErrNotFound {
+				// the task list row expired before its index row
+				continue
+			}
+			if isThrottlingError(err) {
+				return nil, &workflow.ServiceBusyError{
+					Message: fmt.Sprintf("ListTaskList operation failed. Error: %v", err),
+				}
+			}
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("ListTaskList operation failed. Error: %v", err),
+			}
+		}
+		tli.AckLevel = tlDB["ack_level"].(int64)
+		tli.Kind = tlDB["kind"].(int)
+		for _, v := range pollersDB {
+			tli.Pollers = append(tli.Pollers, createTaskListPollerInfo(v))
+		}
+		response.Items = append(response.Items, tli)
+	}
+
+	return response, nil
+}
+
 func (d *cassandraPersistence) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse,
 	error) {
 	// Reading timer tasks need to be quorum level consistent, otherwise we could loose task
@@ -2388,7 +3046,7 @@ func (d *cassandraPersistence) GetTimerIndexTasks(request *GetTimerIndexTasksReq
 		maxTimestamp,
 	).PageSize(request.BatchSize).PageState(request.NextPageToken)
 
-	iter := query.Iter()
+	iter := withQueryContext(query, request.ctx).Iter()
 	if iter == nil {
 		return nil, &workflow.InternalServiceError{
 			Message: "GetTimerTasks operation failed.  Not able to create query iterator.",
@@ -2507,6 +3165,8 @@ func (d *cassandraPersistence) createReplicationTasks(batch *gocql.Batch, replic
 		firstEventID := common.EmptyEventID
 		nextEventID := common.EmptyEventID
 		version := int64(0)
+		historySize := int64(0)
+		scheduledID := common.EmptyEventID
 		var lastReplicationInfo map[string]map[string]interface{}
 
 		switch task.GetType() {
@@ -2514,11 +3174,16 @@ func (d *cassandraPersistence) createReplicationTasks(batch *gocql.Batch, replic
 			firstEventID = task.(*HistoryReplicationTask).FirstEventID
 			nextEventID = task.(*HistoryReplicationTask).NextEventID
 			version = task.GetVersion()
+			historySize = task.(*HistoryReplicationTask).HistorySize
 			lastReplicationInfo = make(map[string]map[string]interface{})
 			for k, v := range task.(*HistoryReplicationTask).LastReplicationInfo {
 				lastReplicationInfo[k] = createReplicationInfoMap(v)
 			}
 
+		case ReplicationTaskTypeSyncActivity:
+			version = task.GetVersion()
+			scheduledID = task.(*SyncActivityTask).ScheduledID
+
 		default:
 			d.logger.Fatal("Unknown Replication Task.")
 		}
@@ -2538,6 +3203,8 @@ func (d *cassandraPersistence) createReplicationTasks(batch *gocql.Batch, replic
 			nextEventID,
 			version,
 			lastReplicationInfo,
+			historySize,
+			scheduledID,
 			defaultVisibilityTimestamp,
 			task.GetTaskID())
 	}
@@ -2942,10 +3609,62 @@ func (d *cassandraPersistence) resetSignalRequested(batch *gocql.Batch, signalRe
 		condition)
 }
 
+func (d *cassandraPersistence) updateUpdateInfos(batch *gocql.Batch, updateInfos []*UpdateInfo,
+	domainID, workflowID, runID string, condition int64) {
+
+	for _, u := range updateInfos {
+		batch.Query(templateUpdateUpdateInfoQuery,
+			u.UpdateID,
+			u.Version,
+			u.UpdateID,
+			u.Completed,
+			u.Result,
+			d.shardID,
+			rowTypeExecution,
+			domainID,
+			workflowID,
+			runID,
+			defaultVisibilityTimestamp,
+			rowTypeExecutionTaskID,
+			condition)
+	}
+}
+
+func (d *cassandraPersistence) resetUpdateInfos(batch *gocql.Batch, updateInfos []*UpdateInfo,
+	domainID, workflowID, runID string, condition int64) {
+	batch.Query(templateResetUpdateInfoQuery,
+		resetUpdateInfoMap(updateInfos),
+		d.shardID,
+		rowTypeExecution,
+		domainID,
+		workflowID,
+		runID,
+		defaultVisibilityTimestamp,
+		rowTypeExecutionTaskID,
+		condition)
+}
+
 func (d *cassandraPersistence) updateBufferedEvents(batch *gocql.Batch, newBufferedEvents *SerializedHistoryEventBatch,
 	clearBufferedEvents bool, domainID, workflowID, runID string, condition int64, rangeID int64) {
 
-	if clearBufferedEvents {
+	if clearBufferedEvents && newBufferedEvents != nil {
+		// the existing buffered events are replaced, e.g. after they got re-encoded
+		values := make(map[string]interface{})
+		values["encoding_type"] = newBufferedEvents.EncodingType
+		values["version"] = newBufferedEvents.Version
+		values["data"] = newBufferedEvents.Data
+		newEventValues := []map[string]interface{}{values}
+		batch.Query(templateReplaceBufferedEventsQuery,
+			newEventValues,
+			d.shardID,
+			rowTypeExecution,
+			domainID,
+			workflowID,
+			runID,
+			defaultVisibilityTimestamp,
+			rowTypeExecutionTaskID,
+			condition)
+	} else if clearBufferedEvents {
 		batch.Query(templateDeleteBufferedEventsQuery,
 			d.shardID,
 			rowTypeExecution,
@@ -3150,6 +3869,20 @@ func createWorkflowExecutionInfo(result map[string]interface{}) *WorkflowExecuti
 			info.ClientFeatureVersion = v.(string)
 		case "client_impl":
 			info.ClientImpl = v.(string)
+		case "sticky_invalidation_count":
+			info.StickyInvalidationCount = v.(int64)
+		case "tags":
+			info.Tags = v.(map[string]string)
+		case "continue_as_new_task_list":
+			info.ContinueAsNewTaskList = v.(string)
+		case "history_size":
+			info.HistorySize = v.(int64)
+		case "isolation_group":
+			info.IsolationGroup = v.(string)
+		case "priority":
+			info.Priority = int32(v.(int))
+		case "first_run_id":
+			info.FirstRunID = v.(string)
 		}
 	}
 
@@ -3249,6 +3982,10 @@ func createReplicationTaskInfo(result map[string]interface{}) *ReplicationTaskIn
 			for key, value := range replicationInfoMap {
 				info.LastReplicationInfo[key] = createReplicationInfo(value)
 			}
+		case "history_size":
+			info.HistorySize = v.(int64)
+		case "scheduled_id":
+			info.ScheduledID = v.(int64)
 		}
 	}
 
@@ -3400,6 +4137,24 @@ func createSignalInfo(result map[string]interface{}) *SignalInfo {
 	return info
 }
 
+func createUpdateInfo(result map[string]interface{}) *UpdateInfo {
+	info := &UpdateInfo{}
+	for k, v := range result {
+		switch k {
+		case "version":
+			info.Version = v.(int64)
+		case "update_id":
+			info.UpdateID = v.(string)
+		case "completed":
+			info.Completed = v.(bool)
+		case "result":
+			info.Result = v.([]byte)
+		}
+	}
+
+	return info
+}
+
 func createBufferedReplicationTaskInfo(result map[string]interface{}) *BufferedReplicationTask {
 	info := &BufferedReplicationTask{}
 	for k, v := range result {
@@ -3525,6 +4280,21 @@ func resetSignalInfoMap(signalInfos []*SignalInfo) map[int64]map[string]interfac
 	return sMap
 }
 
+func resetUpdateInfoMap(updateInfos []*UpdateInfo) map[string]map[string]interface{} {
+	uMap := make(map[string]map[string]interface{})
+	for _, u := range updateInfos {
+		uInfo := make(map[string]interface{})
+		uInfo["version"] = u.Version
+		uInfo["update_id"] = u.UpdateID
+		uInfo["completed"] = u.Completed
+		uInfo["result"] = u.Result
+
+		uMap[u.UpdateID] = uInfo
+	}
+
+	return uMap
+}
+
 func createSerializedHistoryEventBatch(result map[string]interface{}) *SerializedHistoryEventBatch {
 	// TODO: default to JSON, update this when we support different encoding types.
 	eventBatch := &SerializedHistoryEventBatch{EncodingType: common.EncodingTypeJSON}
@@ -3552,6 +4322,14 @@ func createTaskInfo(result map[string]interface{}) *TaskInfo {
 			info.RunID = v.(gocql.UUID).String()
 		case "schedule_id":
 			info.ScheduleID = v.(int64)
+		case "isolation_group":
+			info.IsolationGroup = v.(string)
+		case "dispatch_attempts":
+			info.DispatchAttempts = int32(v.(int))
+		case "priority":
+			info.Priority = int32(v.(int))
+		case "created_time":
+			info.CreatedTime = v.(time.Time)
 		}
 	}
 
@@ -3610,6 +4388,35 @@ func createReplicationInfoMap(info *ReplicationInfo) map[string]interface{} {
 	return rInfoMap
 }
 
+func createTaskListPollerInfo(result map[string]interface{}) *TaskListPollerInfo {
+	info := &TaskListPollerInfo{}
+	for k, v := range result {
+		switch k {
+		case "identity":
+			info.Identity = v.(string)
+		case "version":
+			info.Version = v.(string)
+		case "last_access_time":
+			info.LastAccessTime = v.(time.Time)
+		}
+	}
+
+	return info
+}
+
+func createTaskListPollersList(pollers []*TaskListPollerInfo) []map[string]interface{} {
+	pollersList := make([]map[string]interface{}, 0, len(pollers))
+	for _, poller := range pollers {
+		pollersList = append(pollersList, map[string]interface{}{
+			"identity":         poller.Identity,
+			"version":          poller.Version,
+			"last_access_time": poller.LastAccessTime,
+		})
+	}
+
+	return pollersList
+}
+
 func isTimeoutError(err error) bool {
 	if err == gocql.ErrTimeoutNoResponse {
 		return true
@@ -3621,6 +4428,15 @@ func isTimeoutError(err error) bool {
 	return ok
 }
 
+// withQueryContext binds the query to the context of the request, if any, so that the query is abandoned once the
+// context is cancelled
+func withQueryContext(query *gocql.Query, ctx context.Context) *gocql.Query {
+	if ctx == nil {
+		return query
+	}
+	return query.WithContext(ctx)
+}
+
 func isThrottlingError(err error) bool {
 	if req, ok := err.(gocql.RequestError); ok {
 		// gocql does not expose the constant errOverloaded = 0x1001
@@ -24,6 +24,7 @@ import (
 	"github.com/gocql/gocql"
 	"github.com/uber-common/bark"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/credentials"
 	"github.com/uber/cadence/common/metrics"
 )
 
@@ -37,9 +38,10 @@ type (
 )
 
 // NewCassandraPersistenceClientFactory is used to create an instance of ExecutionManagerFactory implementation
-func NewCassandraPersistenceClientFactory(hosts string, port int, user, password, dc string, keyspace string,
+func NewCassandraPersistenceClientFactory(hosts string, port int,
+	credentialsProvider credentials.Provider, dc string, keyspace string,
 	numConns int, logger bark.Logger, rateLimiter common.TokenBucket, metricsClient metrics.Client) (ExecutionManagerFactory, error) {
-	cluster := common.NewCassandraCluster(hosts, port, user, password, dc)
+	cluster := common.NewCassandraCluster(hosts, port, credentialsProvider, dc)
 	cluster.Keyspace = keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
@@ -21,6 +21,8 @@
 package persistence
 
 import (
+	"fmt"
+	"math"
 	"os"
 	"testing"
 	"time"
@@ -270,6 +272,7 @@ func (s *cassandraPersistenceSuite) TestUpdateWorkflow() {
 	updatedInfo.ClientLibraryVersion = "random client library version"
 	updatedInfo.ClientFeatureVersion = "random client feature version"
 	updatedInfo.ClientImpl = "random client impl"
+	updatedInfo.HistorySize = 4096
 	err2 := s.UpdateWorkflowExecution(updatedInfo, []int64{int64(4)}, nil, int64(3), nil, nil, nil, nil, nil, nil)
 	s.Nil(err2, "No error expected.")
 
@@ -301,6 +304,7 @@ func (s *cassandraPersistenceSuite) TestUpdateWorkflow() {
 	s.Equal(updatedInfo.ClientLibraryVersion, info1.ClientLibraryVersion)
 	s.Equal(updatedInfo.ClientFeatureVersion, info1.ClientFeatureVersion)
 	s.Equal(updatedInfo.ClientImpl, info1.ClientImpl)
+	s.Equal(int64(4096), info1.HistorySize)
 
 	log.Infof("Workflow execution last updated: %v", info1.LastUpdatedTimestamp)
 
@@ -456,6 +460,48 @@ func (s *cassandraPersistenceSuite) TestGetCurrentWorkflow() {
 	s.Empty(task1, "Expected empty task identifier.")
 }
 
+func (s *cassandraPersistenceSuite) TestListDomainExecutions() {
+	domainID := uuid.New()
+	runIDs := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		workflowExecution := gen.WorkflowExecution{
+			WorkflowId: common.StringPtr(fmt.Sprintf("list-domain-executions-test-%v", i)),
+			RunId:      common.StringPtr(uuid.New()),
+		}
+		_, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+		s.Nil(err0)
+		runIDs[workflowExecution.GetRunId()] = true
+	}
+	// another domain
+	_, err1 := s.CreateWorkflowExecution(uuid.New(), gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("list-domain-executions-test-0"),
+		RunId:      common.StringPtr(uuid.New()),
+	}, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.Nil(err1)
+
+	var executions []gen.WorkflowExecution
+	var token []byte
+	for {
+		resp, err2 := s.ShardMgr.ListDomainExecutions(&ListDomainExecutionsRequest{
+			ShardID:       s.ShardInfo.ShardID,
+			DomainID:      domainID,
+			PageSize:      2,
+			NextPageToken: token,
+		})
+		s.Nil(err2)
+		executions = append(executions, resp.Executions...)
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			break
+		}
+	}
+	// the current execution rows are skipped
+	s.Equal(3, len(executions))
+	for _, execution := range executions {
+		s.True(runIDs[execution.GetRunId()])
+	}
+}
+
 func (s *cassandraPersistenceSuite) TestTransferTasksThroughUpdate() {
 	domainID := "b785a8ba-bd7d-4760-bb05-41b115f3e10a"
 	workflowExecution := gen.WorkflowExecution{
@@ -816,6 +862,62 @@ func (s *cassandraPersistenceSuite) TestCompleteDecisionTask() {
 	}
 }
 
+func (s *cassandraPersistenceSuite) TestTaskListDLQ() {
+	domainID := "5a8f2d37-3c4a-4a35-9e5f-8c6f3a1d2b90"
+	workflowExecution := gen.WorkflowExecution{WorkflowId: common.StringPtr("task-list-dlq-test"),
+		RunId: common.StringPtr("0f3c2e4b-7a61-4d8e-b0a9-6b1e5d4c3a27")}
+	taskList := "6b1e5d4c3a27"
+	_, err0 := s.CreateActivityTasks(domainID, workflowExecution, map[int64]string{10: taskList})
+	s.Nil(err0, "No error expected.")
+
+	tasksResponse, err1 := s.GetTasks(domainID, taskList, TaskListTypeActivity, 1)
+	s.Nil(err1, "No error expected.")
+	s.Equal(1, len(tasksResponse.Tasks))
+	task := tasksResponse.Tasks[0]
+	s.Equal(int32(0), task.DispatchAttempts)
+
+	tli := &TaskListInfo{DomainID: domainID, Name: taskList, TaskType: TaskListTypeActivity}
+	task.DispatchAttempts = 3
+	task.Priority = 5
+	// cassandra timestamps are kept with millisecond precision
+	task.CreatedTime = time.Unix(0, time.Now().UnixNano()/int64(time.Millisecond)*int64(time.Millisecond)).UTC()
+	err2 := s.TaskMgr.CreateDLQTask(&CreateDLQTaskRequest{TaskList: tli, Task: task})
+	s.Nil(err2, "No error expected.")
+	err3 := s.CompleteTask(domainID, taskList, TaskListTypeActivity, task.TaskID, 100)
+	s.Nil(err3, "No error expected.")
+
+	tasksResponse, err4 := s.GetTasks(domainID, taskList, TaskListTypeActivity, 1)
+	s.Nil(err4, "No error expected.")
+	s.Equal(0, len(tasksResponse.Tasks), "DLQ tasks should not be read as tasks.")
+
+	dlqResponse, err5 := s.TaskMgr.GetDLQTasks(&GetDLQTasksRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: TaskListTypeActivity,
+		PageSize: 10,
+	})
+	s.Nil(err5, "No error expected.")
+	s.Equal(1, len(dlqResponse.Tasks))
+	s.Equal(task.TaskID, dlqResponse.Tasks[0].TaskID)
+	s.Equal(*workflowExecution.WorkflowId, dlqResponse.Tasks[0].WorkflowID)
+	s.Equal(*workflowExecution.RunId, dlqResponse.Tasks[0].RunID)
+	s.Equal(int64(10), dlqResponse.Tasks[0].ScheduleID)
+	s.Equal(int32(3), dlqResponse.Tasks[0].DispatchAttempts)
+	s.Equal(int32(5), dlqResponse.Tasks[0].Priority)
+	s.Equal(task.CreatedTime, dlqResponse.Tasks[0].CreatedTime.UTC())
+
+	err6 := s.TaskMgr.DeleteDLQTask(&DeleteDLQTaskRequest{TaskList: tli, TaskID: task.TaskID})
+	s.Nil(err6, "No error expected.")
+	dlqResponse, err7 := s.TaskMgr.GetDLQTasks(&GetDLQTasksRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: TaskListTypeActivity,
+		PageSize: 10,
+	})
+	s.Nil(err7, "No error expected.")
+	s.Equal(0, len(dlqResponse.Tasks))
+}
+
 func (s *cassandraPersistenceSuite) TestLeaseAndUpdateTaskList() {
 	domainID := "00136543-72ad-4615-b7e9-44bca9775b45"
 	taskList := "aaaaaaa"
@@ -888,6 +990,139 @@ func (s *cassandraPersistenceSuite) TestLeaseAndUpdateTaskList_Sticky() {
 	s.NoError(err) // because update with ttl doesn't check rangeID
 }
 
+func (s *cassandraPersistenceSuite) TestUpdateTaskList_StickyRefreshesIndex() {
+	domainID := uuid.New()
+	taskList := "sticky-indexed"
+	response, err := s.TaskMgr.LeaseTaskList(&LeaseTaskListRequest{
+		DomainID:     domainID,
+		TaskList:     taskList,
+		TaskType:     TaskListTypeDecision,
+		TaskListKind: TaskListKindSticky,
+	})
+	s.NoError(err)
+
+	// stands for the index row expiring while the task list row is kept alive by its updates
+	err = s.CassandraTestCluster.session.Query(`DELETE FROM task_lists_by_domain WHERE domain_id = ?`, domainID).Exec()
+	s.NoError(err)
+	listResponse, err := s.TaskMgr.ListTaskList(&ListTaskListRequest{DomainID: domainID, PageSize: 10})
+	s.NoError(err)
+	s.Empty(listResponse.Items)
+
+	_, err = s.TaskMgr.UpdateTaskList(&UpdateTaskListRequest{TaskListInfo: response.TaskListInfo})
+	s.NoError(err)
+	listResponse, err = s.TaskMgr.ListTaskList(&ListTaskListRequest{DomainID: domainID, PageSize: 10})
+	s.NoError(err)
+	s.Equal(1, len(listResponse.Items))
+	s.Equal(taskList, listResponse.Items[0].Name)
+	s.Equal(TaskListKindSticky, listResponse.Items[0].Kind)
+}
+
+func (s *cassandraPersistenceSuite) TestListTaskList() {
+	domainID := uuid.New()
+	for _, taskList := range []string{"list-a", "list-b", "list-c"} {
+		_, err := s.TaskMgr.LeaseTaskList(&LeaseTaskListRequest{
+			DomainID: domainID,
+			TaskList: taskList,
+			TaskType: TaskListTypeActivity,
+		})
+		s.NoError(err)
+	}
+	_, err := s.TaskMgr.LeaseTaskList(&LeaseTaskListRequest{
+		DomainID: uuid.New(),
+		TaskList: "list-a",
+		TaskType: TaskListTypeActivity,
+	})
+	s.NoError(err)
+
+	var names []string
+	var token []byte
+	for {
+		response, err := s.TaskMgr.ListTaskList(&ListTaskListRequest{
+			DomainID:      domainID,
+			PageSize:      2,
+			NextPageToken: token,
+		})
+		s.NoError(err)
+		for _, tli := range response.Items {
+			s.Equal(domainID, tli.DomainID)
+			s.Equal(TaskListTypeActivity, tli.TaskType)
+			s.EqualValues(1, tli.RangeID)
+			names = append(names, tli.Name)
+		}
+		token = response.NextPageToken
+		if len(token) == 0 {
+			break
+		}
+	}
+	s.Equal([]string{"list-a", "list-b", "list-c"}, names)
+}
+
+func (s *cassandraPersistenceSuite) TestGetTasksByPriorityLevel() {
+	domainID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{WorkflowId: common.StringPtr("get-tasks-by-priority-test"),
+		RunId: common.StringPtr(uuid.New())}
+	taskList := "priority-task-list"
+	leaseResponse, err := s.TaskMgr.LeaseTaskList(&LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: TaskListTypeActivity,
+	})
+	s.NoError(err)
+
+	var tasks []*CreateTaskInfo
+	for i, priority := range []int32{0, 3, 0, 3} {
+		taskID := s.GetNextSequenceNumber()
+		tasks = append(tasks, &CreateTaskInfo{
+			TaskID:    taskID,
+			Execution: workflowExecution,
+			Data: &TaskInfo{
+				DomainID:   domainID,
+				WorkflowID: *workflowExecution.WorkflowId,
+				RunID:      *workflowExecution.RunId,
+				TaskID:     taskID,
+				ScheduleID: int64(i),
+				Priority:   priority,
+			},
+		})
+	}
+	_, err = s.TaskMgr.CreateTasks(&CreateTasksRequest{
+		TaskListInfo: leaseResponse.TaskListInfo,
+		Tasks:        tasks,
+	})
+	s.NoError(err)
+
+	getTasks := func(priorityLevel int) []*TaskInfo {
+		response, err := s.TaskMgr.GetTasks(&GetTasksRequest{
+			DomainID:      domainID,
+			TaskList:      taskList,
+			TaskType:      TaskListTypeActivity,
+			PriorityLevel: priorityLevel,
+			BatchSize:     10,
+			RangeID:       leaseResponse.TaskListInfo.RangeID,
+			MaxReadLevel:  math.MaxInt64,
+		})
+		s.NoError(err)
+		return response.Tasks
+	}
+	highTasks := getTasks(TaskPriorityLevel(3))
+	s.Equal(2, len(highTasks))
+	s.Equal(tasks[1].TaskID, highTasks[0].TaskID)
+	s.Equal(tasks[3].TaskID, highTasks[1].TaskID)
+	lowTasks := getTasks(0)
+	s.Equal(2, len(lowTasks))
+	s.Equal(tasks[0].TaskID, lowTasks[0].TaskID)
+	s.Equal(tasks[2].TaskID, lowTasks[1].TaskID)
+
+	err = s.TaskMgr.CompleteTask(&CompleteTaskRequest{
+		TaskList:      leaseResponse.TaskListInfo,
+		TaskID:        tasks[1].TaskID,
+		PriorityLevel: TaskPriorityLevel(3),
+	})
+	s.NoError(err)
+	s.Equal(1, len(getTasks(TaskPriorityLevel(3))))
+	s.Equal(2, len(getTasks(0)))
+}
+
 func (s *cassandraPersistenceSuite) TestReplicationTasks() {
 	domainID := "2466d7de-6602-4ad8-b939-fb8f8c36c711"
 	workflowExecution := gen.WorkflowExecution{
@@ -952,6 +1187,159 @@ func (s *cassandraPersistenceSuite) TestReplicationTasks() {
 	}
 }
 
+func (s *cassandraPersistenceSuite) TestRangeCompleteReplicationTasks() {
+	domainID := "7d4e1c2b-0f2a-4b86-a0a5-3c8d1e5f9b61"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("range-complete-replication-tasks-test"),
+		RunId:      common.StringPtr("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"),
+	}
+
+	task0, err := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.Nil(err, "No error expected.")
+	s.NotNil(task0, "Expected non empty task identifier.")
+	taskD, err := s.GetTransferTasks(1, false)
+	s.Equal(1, len(taskD), "Expected 1 decision task.")
+	err = s.CompleteTransferTask(taskD[0].TaskID)
+	s.Nil(err)
+
+	state1, err := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err, "No error expected.")
+	info1 := state1.ExecutionInfo
+	s.NotNil(info1, "Valid Workflow info expected.")
+	updatedInfo1 := copyWorkflowExecutionInfo(info1)
+
+	replicationTasks := []Task{
+		&HistoryReplicationTask{
+			TaskID:       s.GetNextSequenceNumber(),
+			FirstEventID: int64(1),
+			NextEventID:  int64(3),
+			Version:      123,
+			LastReplicationInfo: map[string]*ReplicationInfo{
+				"dc1": &ReplicationInfo{
+					Version:     int64(3),
+					LastEventID: int64(1),
+				},
+			},
+		},
+		&HistoryReplicationTask{
+			TaskID:       s.GetNextSequenceNumber(),
+			FirstEventID: int64(1),
+			NextEventID:  int64(3),
+			Version:      456,
+			LastReplicationInfo: map[string]*ReplicationInfo{
+				"dc1": &ReplicationInfo{
+					Version:     int64(3),
+					LastEventID: int64(1),
+				},
+			},
+		},
+		&HistoryReplicationTask{
+			TaskID:       s.GetNextSequenceNumber(),
+			FirstEventID: int64(3),
+			NextEventID:  int64(5),
+			Version:      456,
+			LastReplicationInfo: map[string]*ReplicationInfo{
+				"dc1": &ReplicationInfo{
+					Version:     int64(3),
+					LastEventID: int64(1),
+				},
+			},
+		},
+	}
+	err = s.UpdateWorklowStateAndReplication(updatedInfo1, nil, nil, nil, int64(3), replicationTasks)
+	s.Nil(err, "No error expected.")
+
+	readLevel := s.GetReplicationReadLevel()
+	repTasks, err := s.GetReplicationTasks(1, true)
+	s.Nil(err)
+	s.Equal(len(replicationTasks), len(repTasks))
+
+	// the tasks after the end of the range are kept
+	err = s.RangeCompleteReplicationTask(repTasks[1].GetTaskID())
+	s.Nil(err, "No error expected.")
+
+	s.SetReplicationReadLevel(readLevel)
+	remaining, err := s.GetReplicationTasks(1, true)
+	s.Nil(err)
+	s.Equal(1, len(remaining))
+	s.Equal(repTasks[2].GetTaskID(), remaining[0].GetTaskID())
+
+	// completing the same range again is a no-op
+	err = s.RangeCompleteReplicationTask(repTasks[1].GetTaskID())
+	s.Nil(err, "No error expected.")
+	s.SetReplicationReadLevel(readLevel)
+	remaining, err = s.GetReplicationTasks(1, true)
+	s.Nil(err)
+	s.Equal(1, len(remaining))
+
+	err = s.RangeCompleteReplicationTask(repTasks[2].GetTaskID())
+	s.Nil(err, "No error expected.")
+	s.SetReplicationReadLevel(readLevel)
+	remaining, err = s.GetReplicationTasks(1, true)
+	s.Nil(err)
+	s.Equal(0, len(remaining))
+}
+
+func (s *cassandraPersistenceSuite) TestGetReplicationTasksSizeLimit() {
+	domainID := "2c0b6a3e-9d41-4f5c-8e7a-6b1d3f9e0a52"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("get-replication-tasks-size-limit-test"),
+		RunId:      common.StringPtr("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"),
+	}
+
+	task0, err := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.Nil(err, "No error expected.")
+	s.NotNil(task0, "Expected non empty task identifier.")
+	taskD, err := s.GetTransferTasks(1, false)
+	s.Equal(1, len(taskD), "Expected 1 decision task.")
+	err = s.CompleteTransferTask(taskD[0].TaskID)
+	s.Nil(err)
+
+	state1, err := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err, "No error expected.")
+	updatedInfo1 := copyWorkflowExecutionInfo(state1.ExecutionInfo)
+
+	var replicationTasks []Task
+	for _, historySize := range []int64{100, 600, 200, 100} {
+		replicationTasks = append(replicationTasks, &HistoryReplicationTask{
+			TaskID:       s.GetNextSequenceNumber(),
+			FirstEventID: int64(1),
+			NextEventID:  int64(3),
+			Version:      123,
+			HistorySize:  historySize,
+		})
+	}
+	err = s.UpdateWorklowStateAndReplication(updatedInfo1, nil, nil, nil, int64(3), replicationTasks)
+	s.Nil(err, "No error expected.")
+
+	var pages [][]int64
+	var token []byte
+	for {
+		response, err := s.WorkflowMgr.GetReplicationTasks(&GetReplicationTasksRequest{
+			ReadLevel:         s.GetReplicationReadLevel(),
+			MaxReadLevel:      int64(math.MaxInt64),
+			BatchSize:         10,
+			MaxBatchSizeBytes: 500,
+			NextPageToken:     token,
+		})
+		s.Nil(err, "No error expected.")
+		var sizes []int64
+		for _, task := range response.Tasks {
+			sizes = append(sizes, task.HistorySize)
+		}
+		pages = append(pages, sizes)
+		token = response.NextPageToken
+		if len(token) == 0 {
+			break
+		}
+	}
+	// a page ends with the task that makes it reach the size limit
+	s.Equal([][]int64{{100, 600}, {200, 100}}, pages)
+
+	err = s.RangeCompleteReplicationTask(replicationTasks[len(replicationTasks)-1].GetTaskID())
+	s.Nil(err, "No error expected.")
+}
+
 func (s *cassandraPersistenceSuite) TestTransferTasks() {
 	domainID := "8bfb47be-5b57-4d55-9109-5fb35e20b1d7"
 	workflowExecution := gen.WorkflowExecution{
@@ -1444,6 +1832,58 @@ func (s *cassandraPersistenceSuite) TestWorkflowMutableState_SignalRequested() {
 	s.Equal(0, len(state.SignalRequestedIDs))
 }
 
+func (s *cassandraPersistenceSuite) TestWorkflowMutableState_UpdateInfo() {
+	domainID := uuid.New()
+	runID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-workflow-mutable-update-info-test"),
+		RunId:      common.StringPtr(runID),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "taskList", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.Nil(err0, "No error expected.")
+	s.NotNil(task0, "Expected non empty task identifier.")
+
+	state0, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	info0 := state0.ExecutionInfo
+	s.NotNil(info0, "Valid Workflow info expected.")
+
+	updatedInfo := copyWorkflowExecutionInfo(info0)
+	updatedInfo.NextEventID = int64(5)
+	updatedInfo.LastProcessedEvent = int64(2)
+	updateID := uuid.New()
+	updateInfo := &UpdateInfo{
+		Version:  7789,
+		UpdateID: updateID,
+	}
+	err2 := s.UpsertUpdateInfoState(updatedInfo, int64(3), []*UpdateInfo{updateInfo})
+	s.Nil(err2, "No error expected.")
+
+	state, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	s.NotNil(state, "expected valid state.")
+	s.Equal(1, len(state.UpdateInfos))
+	ui, ok := state.UpdateInfos[updateID]
+	s.True(ok)
+	s.Equal(int64(7789), ui.Version)
+	s.Equal(updateID, ui.UpdateID)
+	s.False(ui.Completed)
+
+	updatedInfo.NextEventID = int64(6)
+	updateInfo.Completed = true
+	updateInfo.Result = []byte("update result")
+	err2 = s.UpsertUpdateInfoState(updatedInfo, int64(5), []*UpdateInfo{updateInfo})
+	s.Nil(err2, "No error expected.")
+
+	state, err1 = s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.Nil(err1, "No error expected.")
+	s.Equal(1, len(state.UpdateInfos))
+	ui = state.UpdateInfos[updateID]
+	s.True(ui.Completed)
+	s.Equal([]byte("update result"), ui.Result)
+}
+
 func (s *cassandraPersistenceSuite) TestWorkflowMutableState_BufferedReplicationTasks() {
 	domainID := "714f8491-a34e-4301-a5af-f0cf5d8660c6"
 	workflowExecution := gen.WorkflowExecution{
@@ -2451,6 +2891,10 @@ func copyWorkflowExecutionInfo(sourceInfo *WorkflowExecutionInfo) *WorkflowExecu
 		DecisionStartedID:    sourceInfo.DecisionStartedID,
 		DecisionRequestID:    sourceInfo.DecisionRequestID,
 		DecisionTimeout:      sourceInfo.DecisionTimeout,
+		HistorySize:          sourceInfo.HistorySize,
+		IsolationGroup:       sourceInfo.IsolationGroup,
+		Priority:             sourceInfo.Priority,
+		FirstRunID:           sourceInfo.FirstRunID,
 	}
 }
 
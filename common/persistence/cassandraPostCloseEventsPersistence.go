@@ -0,0 +1,158 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/uber-common/bark"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/credentials"
+)
+
+const (
+	templateRecordPostCloseEventsQuery = `INSERT INTO post_close_events (` +
+		`domain_id, workflow_id, run_id, version, first_event_id, source_cluster, recorded_time, ` +
+		`data, data_encoding, data_version) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) USING TTL ?`
+
+	templateListPostCloseEventsQuery = `SELECT version, first_event_id, source_cluster, recorded_time, ` +
+		`data, data_encoding, data_version ` +
+		`FROM post_close_events ` +
+		`WHERE domain_id = ? ` +
+		`AND workflow_id = ? ` +
+		`AND run_id = ?`
+)
+
+type (
+	cassandraPostCloseEventsPersistence struct {
+		session *gocql.Session
+		logger  bark.Logger
+	}
+)
+
+// NewCassandraPostCloseEventsPersistence is used to create an instance of PostCloseEventsManager implementation
+func NewCassandraPostCloseEventsPersistence(
+	hosts string, port int, credentialsProvider credentials.Provider, dc string, keyspace string,
+	logger bark.Logger) (PostCloseEventsManager, error) {
+	cluster := common.NewCassandraCluster(hosts, port, credentialsProvider, dc)
+	cluster.Keyspace = keyspace
+	cluster.ProtoVersion = cassandraProtoVersion
+	cluster.Consistency = gocql.LocalQuorum
+	cluster.SerialConsistency = gocql.LocalSerial
+	cluster.Timeout = defaultSessionTimeout
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cassandraPostCloseEventsPersistence{session: session, logger: logger}, nil
+}
+
+// Close releases the resources held by this object
+func (m *cassandraPostCloseEventsPersistence) Close() {
+	if m.session != nil {
+		m.session.Close()
+	}
+}
+
+// RecordPostCloseEvents writes the batch, recording the same batch again overwrites it
+func (m *cassandraPostCloseEventsPersistence) RecordPostCloseEvents(request *RecordPostCloseEventsRequest) error {
+	execution := request.Execution
+	batch := request.Batch
+	query := m.session.Query(templateRecordPostCloseEventsQuery,
+		request.DomainID,
+		execution.GetWorkflowId(),
+		execution.GetRunId(),
+		batch.Version,
+		batch.FirstEventID,
+		batch.SourceCluster,
+		batch.RecordedTime,
+		batch.Events.Data,
+		batch.Events.EncodingType,
+		batch.Events.Version,
+		request.TTLSeconds)
+
+	if err := query.Exec(); err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("RecordPostCloseEvents operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("RecordPostCloseEvents operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
+func (m *cassandraPostCloseEventsPersistence) ListPostCloseEvents(
+	request *ListPostCloseEventsRequest) (*ListPostCloseEventsResponse, error) {
+	execution := request.Execution
+	query := m.session.Query(templateListPostCloseEventsQuery,
+		request.DomainID,
+		execution.GetWorkflowId(),
+		execution.GetRunId())
+
+	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListPostCloseEvents operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &ListPostCloseEventsResponse{}
+	var version, firstEventID int64
+	var sourceCluster string
+	var recordedTime time.Time
+	events := &SerializedHistoryEventBatch{}
+	for iter.Scan(&version, &firstEventID, &sourceCluster, &recordedTime, &events.Data, &events.EncodingType,
+		&events.Version) {
+		response.Batches = append(response.Batches, &PostCloseEventBatch{
+			Version:       version,
+			FirstEventID:  firstEventID,
+			SourceCluster: sourceCluster,
+			RecordedTime:  recordedTime,
+			Events:        events,
+		})
+		events = &SerializedHistoryEventBatch{}
+	}
+
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListPostCloseEvents operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListPostCloseEvents operation failed. Error: %v", err),
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pborman/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+type (
+	postCloseEventsPersistenceSuite struct {
+		suite.Suite
+		TestBase
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+	}
+)
+
+func TestPostCloseEventsPersistenceSuite(t *testing.T) {
+	s := new(postCloseEventsPersistenceSuite)
+	suite.Run(t, s)
+}
+
+func (s *postCloseEventsPersistenceSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+
+	s.SetupWorkflowStore()
+}
+
+func (s *postCloseEventsPersistenceSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+}
+
+func (s *postCloseEventsPersistenceSuite) TearDownSuite() {
+	s.TearDownWorkflowStore()
+}
+
+func (s *postCloseEventsPersistenceSuite) TestRecordAndListPostCloseEvents() {
+	domainID := uuid.New()
+	execution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("post-close-events-test"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	recordedTime := time.Now().Truncate(time.Millisecond)
+	record := func(version int64, firstEventID int64, data string) {
+		s.NoError(s.PostCloseEventsMgr.RecordPostCloseEvents(&RecordPostCloseEventsRequest{
+			DomainID:  domainID,
+			Execution: execution,
+			Batch: PostCloseEventBatch{
+				Version:       version,
+				FirstEventID:  firstEventID,
+				SourceCluster: "standby",
+				RecordedTime:  recordedTime,
+				Events:        NewSerializedHistoryEventBatch([]byte(data), common.EncodingTypeJSON, 1),
+			},
+			TTLSeconds: 60,
+		}))
+	}
+	record(110, 20, "second")
+	record(100, 10, "first")
+	record(110, 30, "third")
+	// recording the same batch again overwrites it
+	record(110, 30, "third again")
+
+	var batches []*PostCloseEventBatch
+	var token []byte
+	for {
+		resp, err := s.PostCloseEventsMgr.ListPostCloseEvents(&ListPostCloseEventsRequest{
+			DomainID:      domainID,
+			Execution:     execution,
+			PageSize:      2,
+			NextPageToken: token,
+		})
+		s.NoError(err)
+		batches = append(batches, resp.Batches...)
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			break
+		}
+	}
+
+	s.Equal(3, len(batches))
+	// the batches are ordered by version and first event id
+	s.Equal(int64(100), batches[0].Version)
+	s.Equal(int64(10), batches[0].FirstEventID)
+	s.Equal([]byte("first"), batches[0].Events.Data)
+	s.Equal(int64(110), batches[1].Version)
+	s.Equal(int64(20), batches[1].FirstEventID)
+	s.Equal(int64(30), batches[2].FirstEventID)
+	s.Equal([]byte("third again"), batches[2].Events.Data)
+	s.Equal(common.EncodingTypeJSON, batches[2].Events.EncodingType)
+	s.Equal(1, batches[2].Events.Version)
+	s.Equal("standby", batches[2].SourceCluster)
+	s.Equal(recordedTime.UnixNano(), batches[2].RecordedTime.UnixNano())
+
+	resp, err := s.PostCloseEventsMgr.ListPostCloseEvents(&ListPostCloseEventsRequest{
+		DomainID:  domainID,
+		Execution: gen.WorkflowExecution{WorkflowId: execution.WorkflowId, RunId: common.StringPtr(uuid.New())},
+		PageSize:  2,
+	})
+	s.NoError(err)
+	s.Equal(0, len(resp.Batches))
+}
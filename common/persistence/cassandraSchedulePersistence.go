@@ -0,0 +1,294 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/uber-common/bark"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/credentials"
+)
+
+const (
+	templateScheduleColumns = `interval_seconds, offset_seconds, start_time, end_time, ` +
+		`workflow_id_prefix, workflow_type_name, task_list, input, ` +
+		`execution_start_to_close_timeout, task_start_to_close_timeout, overlap_policy, paused, ` +
+		`next_run_time, last_run_time, last_workflow_id, last_run_id, buffered_run_times, version`
+
+	templateCreateScheduleQuery = `INSERT INTO schedules (domain_id, schedule_id, ` + templateScheduleColumns + `) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) IF NOT EXISTS`
+
+	templateGetScheduleQuery = `SELECT schedule_id, ` + templateScheduleColumns + ` ` +
+		`FROM schedules ` +
+		`WHERE domain_id = ? ` +
+		`AND schedule_id = ?`
+
+	templateUpdateScheduleQuery = `UPDATE schedules ` +
+		`SET interval_seconds = ?, ` +
+		`offset_seconds = ?, ` +
+		`start_time = ?, ` +
+		`end_time = ?, ` +
+		`workflow_id_prefix = ?, ` +
+		`workflow_type_name = ?, ` +
+		`task_list = ?, ` +
+		`input = ?, ` +
+		`execution_start_to_close_timeout = ?, ` +
+		`task_start_to_close_timeout = ?, ` +
+		`overlap_policy = ?, ` +
+		`paused = ?, ` +
+		`next_run_time = ?, ` +
+		`last_run_time = ?, ` +
+		`last_workflow_id = ?, ` +
+		`last_run_id = ?, ` +
+		`buffered_run_times = ?, ` +
+		`version = ? ` +
+		`WHERE domain_id = ? ` +
+		`AND schedule_id = ? ` +
+		`IF version = ?`
+
+	templateDeleteScheduleQuery = `DELETE FROM schedules ` +
+		`WHERE domain_id = ? ` +
+		`AND schedule_id = ?`
+
+	templateListSchedulesQuery = `SELECT schedule_id, ` + templateScheduleColumns + ` ` +
+		`FROM schedules ` +
+		`WHERE domain_id = ?`
+)
+
+type (
+	cassandraSchedulePersistence struct {
+		session *gocql.Session
+		logger  bark.Logger
+	}
+)
+
+// NewCassandraSchedulePersistence is used to create an instance of ScheduleManager implementation
+func NewCassandraSchedulePersistence(
+	hosts string, port int, credentialsProvider credentials.Provider, dc string, keyspace string,
+	logger bark.Logger) (ScheduleManager, error) {
+	cluster := common.NewCassandraCluster(hosts, port, credentialsProvider, dc)
+	cluster.Keyspace = keyspace
+	cluster.ProtoVersion = cassandraProtoVersion
+	cluster.Consistency = gocql.LocalQuorum
+	cluster.SerialConsistency = gocql.LocalSerial
+	cluster.Timeout = defaultSessionTimeout
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cassandraSchedulePersistence{session: session, logger: logger}, nil
+}
+
+// Close releases the resources held by this object
+func (m *cassandraSchedulePersistence) Close() {
+	if m.session != nil {
+		m.session.Close()
+	}
+}
+
+func (m *cassandraSchedulePersistence) CreateSchedule(request *CreateScheduleRequest) error {
+	schedule := request.Schedule
+	query := m.session.Query(templateCreateScheduleQuery,
+		schedule.DomainID,
+		schedule.ScheduleID,
+		schedule.IntervalInSeconds,
+		schedule.OffsetInSeconds,
+		schedule.StartTime,
+		schedule.EndTime,
+		schedule.WorkflowIDPrefix,
+		schedule.WorkflowTypeName,
+		schedule.TaskList,
+		schedule.Input,
+		schedule.ExecutionStartToCloseTimeoutSeconds,
+		schedule.TaskStartToCloseTimeoutSeconds,
+		schedule.OverlapPolicy,
+		schedule.Paused,
+		schedule.NextRunTime,
+		schedule.LastRunTime,
+		schedule.LastWorkflowID,
+		schedule.LastRunID,
+		schedule.BufferedRunTimes,
+		schedule.Version)
+
+	previous := make(map[string]interface{})
+	applied, err := query.MapScanCAS(previous)
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("CreateSchedule operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CreateSchedule operation failed. Error: %v", err),
+		}
+	}
+	if !applied {
+		return &ConditionFailedError{
+			Msg: fmt.Sprintf("Schedule already exists. ScheduleId: %v", schedule.ScheduleID),
+		}
+	}
+	return nil
+}
+
+func (m *cassandraSchedulePersistence) GetSchedule(request *GetScheduleRequest) (*GetScheduleResponse, error) {
+	query := m.session.Query(templateGetScheduleQuery, request.DomainID, request.ScheduleID)
+	schedule := &ScheduleInfo{DomainID: request.DomainID}
+	err := query.Scan(scheduleScanArgs(schedule)...)
+	if err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, &workflow.EntityNotExistsError{
+				Message: fmt.Sprintf("Schedule not found. ScheduleId: %v", request.ScheduleID),
+			}
+		} else if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("GetSchedule operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetSchedule operation failed. Error: %v", err),
+		}
+	}
+	return &GetScheduleResponse{Schedule: schedule}, nil
+}
+
+func (m *cassandraSchedulePersistence) UpdateSchedule(request *UpdateScheduleRequest) error {
+	schedule := request.Schedule
+	query := m.session.Query(templateUpdateScheduleQuery,
+		schedule.IntervalInSeconds,
+		schedule.OffsetInSeconds,
+		schedule.StartTime,
+		schedule.EndTime,
+		schedule.WorkflowIDPrefix,
+		schedule.WorkflowTypeName,
+		schedule.TaskList,
+		schedule.Input,
+		schedule.ExecutionStartToCloseTimeoutSeconds,
+		schedule.TaskStartToCloseTimeoutSeconds,
+		schedule.OverlapPolicy,
+		schedule.Paused,
+		schedule.NextRunTime,
+		schedule.LastRunTime,
+		schedule.LastWorkflowID,
+		schedule.LastRunID,
+		schedule.BufferedRunTimes,
+		schedule.Version,
+		schedule.DomainID,
+		schedule.ScheduleID,
+		request.PreviousVersion)
+
+	previous := make(map[string]interface{})
+	applied, err := query.MapScanCAS(previous)
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("UpdateSchedule operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateSchedule operation failed. Error: %v", err),
+		}
+	}
+	if !applied {
+		return &ConditionFailedError{
+			Msg: fmt.Sprintf("Failed to update schedule. ScheduleId: %v, previous version: %v, actual version: %v",
+				schedule.ScheduleID, request.PreviousVersion, previous["version"]),
+		}
+	}
+	return nil
+}
+
+func (m *cassandraSchedulePersistence) DeleteSchedule(request *DeleteScheduleRequest) error {
+	query := m.session.Query(templateDeleteScheduleQuery, request.DomainID, request.ScheduleID)
+	if err := query.Exec(); err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteSchedule operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteSchedule operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
+func (m *cassandraSchedulePersistence) ListSchedules(request *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	query := m.session.Query(templateListSchedulesQuery, request.DomainID)
+	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListSchedules operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &ListSchedulesResponse{}
+	schedule := &ScheduleInfo{DomainID: request.DomainID}
+	for iter.Scan(scheduleScanArgs(schedule)...) {
+		response.Schedules = append(response.Schedules, schedule)
+		schedule = &ScheduleInfo{DomainID: request.DomainID}
+	}
+
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListSchedules operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListSchedules operation failed. Error: %v", err),
+		}
+	}
+	return response, nil
+}
+
+// scheduleScanArgs returns the destinations of the columns selected by the schedule queries
+func scheduleScanArgs(schedule *ScheduleInfo) []interface{} {
+	return []interface{}{
+		&schedule.ScheduleID,
+		&schedule.IntervalInSeconds,
+		&schedule.OffsetInSeconds,
+		&schedule.StartTime,
+		&schedule.EndTime,
+		&schedule.WorkflowIDPrefix,
+		&schedule.WorkflowTypeName,
+		&schedule.TaskList,
+		&schedule.Input,
+		&schedule.ExecutionStartToCloseTimeoutSeconds,
+		&schedule.TaskStartToCloseTimeoutSeconds,
+		&schedule.OverlapPolicy,
+		&schedule.Paused,
+		&schedule.NextRunTime,
+		&schedule.LastRunTime,
+		&schedule.LastWorkflowID,
+		&schedule.LastRunID,
+		&schedule.BufferedRunTimes,
+		&schedule.Version,
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pborman/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+)
+
+type (
+	schedulePersistenceSuite struct {
+		suite.Suite
+		TestBase
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+	}
+)
+
+func TestSchedulePersistenceSuite(t *testing.T) {
+	s := new(schedulePersistenceSuite)
+	suite.Run(t, s)
+}
+
+func (s *schedulePersistenceSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+
+	s.SetupWorkflowStore()
+}
+
+func (s *schedulePersistenceSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+}
+
+func (s *schedulePersistenceSuite) TearDownSuite() {
+	s.TearDownWorkflowStore()
+}
+
+func (s *schedulePersistenceSuite) TestScheduleCRUD() {
+	domainID := uuid.New()
+	nextRunTime := time.Now().Add(time.Minute).Truncate(time.Millisecond)
+	schedule := &ScheduleInfo{
+		DomainID:                            domainID,
+		ScheduleID:                          "schedule-crud-test",
+		IntervalInSeconds:                   60,
+		WorkflowTypeName:                    "schedule-workflow-type",
+		TaskList:                            "schedule-task-list",
+		Input:                               []byte("input"),
+		ExecutionStartToCloseTimeoutSeconds: 100,
+		TaskStartToCloseTimeoutSeconds:      10,
+		OverlapPolicy:                       int(gen.ScheduleOverlapPolicyBuffer),
+		NextRunTime:                         nextRunTime,
+	}
+	s.NoError(s.ScheduleMgr.CreateSchedule(&CreateScheduleRequest{Schedule: schedule}))
+
+	err := s.ScheduleMgr.CreateSchedule(&CreateScheduleRequest{Schedule: schedule})
+	s.IsType(&ConditionFailedError{}, err)
+
+	resp, err := s.ScheduleMgr.GetSchedule(&GetScheduleRequest{DomainID: domainID, ScheduleID: schedule.ScheduleID})
+	s.NoError(err)
+	s.Equal(int64(60), resp.Schedule.IntervalInSeconds)
+	s.Equal("schedule-task-list", resp.Schedule.TaskList)
+	s.Equal([]byte("input"), resp.Schedule.Input)
+	s.Equal(int(gen.ScheduleOverlapPolicyBuffer), resp.Schedule.OverlapPolicy)
+	s.Equal(nextRunTime.UnixNano(), resp.Schedule.NextRunTime.UnixNano())
+	s.Equal(int64(0), resp.Schedule.Version)
+
+	updated := *resp.Schedule
+	updated.LastRunTime = nextRunTime
+	updated.LastWorkflowID = "schedule-crud-test-run"
+	updated.LastRunID = uuid.New()
+	updated.BufferedRunTimes = []time.Time{nextRunTime.Add(time.Minute)}
+	updated.Version = 1
+	s.NoError(s.ScheduleMgr.UpdateSchedule(&UpdateScheduleRequest{Schedule: &updated, PreviousVersion: 0}))
+
+	err = s.ScheduleMgr.UpdateSchedule(&UpdateScheduleRequest{Schedule: &updated, PreviousVersion: 0})
+	s.IsType(&ConditionFailedError{}, err)
+
+	listResp, err := s.ScheduleMgr.ListSchedules(&ListSchedulesRequest{DomainID: domainID, PageSize: 10})
+	s.NoError(err)
+	s.Equal(1, len(listResp.Schedules))
+	s.Equal("schedule-crud-test-run", listResp.Schedules[0].LastWorkflowID)
+	s.Equal(1, len(listResp.Schedules[0].BufferedRunTimes))
+	s.Equal(int64(1), listResp.Schedules[0].Version)
+
+	s.NoError(s.ScheduleMgr.DeleteSchedule(&DeleteScheduleRequest{DomainID: domainID, ScheduleID: schedule.ScheduleID}))
+	_, err = s.ScheduleMgr.GetSchedule(&GetScheduleRequest{DomainID: domainID, ScheduleID: schedule.ScheduleID})
+	s.IsType(&gen.EntityNotExistsError{}, err)
+}
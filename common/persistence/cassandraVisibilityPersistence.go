@@ -21,6 +21,7 @@
 package persistence
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/credentials"
 )
 
 // Fixed domain values for now
@@ -38,16 +40,24 @@ const (
 	openExecutionTTLBuffer = int64(86400) // setting it to a day to account for shard going down
 
 	maxCassandraTTL = int64(630720000) // Cassandra TTL maximum, 20 years in second
+
+	// Closed records are partitioned by the day they closed in, so a day past the retention of its domain is dropped
+	// by the visibility pruner with a single partition delete
+	closedExecutionBucketSize = int64(24 * time.Hour)
+	// The TTL of closed records only bounds the ones the pruner misses, it has to outlive their bucket
+	closedExecutionTTLBufferSeconds = int64(2 * 86400)
+	// legacyCloseBucket stands for the closed_executions table, written before closed records were bucketed
+	legacyCloseBucket = int64(-1)
 )
 
 const (
 	templateCreateWorkflowExecutionStartedWithTTL = `INSERT INTO open_executions (` +
-		`domain_id, domain_partition, workflow_id, run_id, start_time, workflow_type_name) ` +
-		`VALUES (?, ?, ?, ?, ?, ?) using TTL ?`
+		`domain_id, domain_partition, workflow_id, run_id, start_time, workflow_type_name, tags, first_run_id) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
 
 	templateCreateWorkflowExecutionStarted = `INSERT INTO open_executions (` +
-		`domain_id, domain_partition, workflow_id, run_id, start_time, workflow_type_name) ` +
-		`VALUES (?, ?, ?, ?, ?, ?)`
+		`domain_id, domain_partition, workflow_id, run_id, start_time, workflow_type_name, tags, first_run_id) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
 
 	templateDeleteWorkflowExecutionStarted = `DELETE FROM open_executions ` +
 		`WHERE domain_id = ? ` +
@@ -55,29 +65,97 @@ const (
 		`AND start_time = ? ` +
 		`AND run_id = ?`
 
-	templateCreateWorkflowExecutionClosedWithTTL = `INSERT INTO closed_executions (` +
-		`domain_id, domain_partition, workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
+	templateDeleteWorkflowExecutionClosed = `DELETE FROM closed_executions ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND start_time = ? ` +
+		`AND run_id = ?`
+
+	templateCreateWorkflowExecutionClosedV2WithTTL = `INSERT INTO closed_executions_v2 (` +
+		`domain_id, domain_partition, close_bucket, workflow_id, run_id, start_time, close_time, workflow_type_name, ` +
+		`status, history_length, tags, execution_time, duration, first_run_id) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
+
+	templateCreateWorkflowExecutionClosedV2 = `INSERT INTO closed_executions_v2 (` +
+		`domain_id, domain_partition, close_bucket, workflow_id, run_id, start_time, close_time, workflow_type_name, ` +
+		`status, history_length, tags, execution_time, duration, first_run_id) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	templateDeleteWorkflowExecutionClosedV2 = `DELETE FROM closed_executions_v2 ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND close_bucket = ? ` +
+		`AND start_time = ? ` +
+		`AND run_id = ?`
+
+	templateDeleteClosedExecutionsBucketPartition = `DELETE FROM closed_executions_v2 ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND close_bucket = ?`
+
+	templateCreateClosedExecutionBucketWithTTL = `INSERT INTO closed_execution_buckets (` +
+		`domain_id, domain_partition, close_bucket) ` +
+		`VALUES (?, ?, ?) using TTL ?`
+
+	templateCreateClosedExecutionBucket = `INSERT INTO closed_execution_buckets (` +
+		`domain_id, domain_partition, close_bucket) ` +
+		`VALUES (?, ?, ?)`
+
+	templateGetClosedExecutionBuckets = `SELECT close_bucket ` +
+		`FROM closed_execution_buckets ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND close_bucket >= ?`
+
+	templateDeleteClosedExecutionBucket = `DELETE FROM closed_execution_buckets ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND close_bucket = ?`
+
+	templateCreateClosedExecutionLookupWithTTL = `INSERT INTO closed_execution_lookup (` +
+		`domain_id, workflow_id, run_id, close_bucket, start_time) ` +
+		`VALUES (?, ?, ?, ?, ?) using TTL ?`
+
+	templateCreateClosedExecutionLookup = `INSERT INTO closed_execution_lookup (` +
+		`domain_id, workflow_id, run_id, close_bucket, start_time) ` +
+		`VALUES (?, ?, ?, ?, ?)`
 
-	templateCreateWorkflowExecutionClosed = `INSERT INTO closed_executions (` +
-		`domain_id, domain_partition, workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	templateGetClosedExecutionLookup = `SELECT close_bucket, start_time ` +
+		`FROM closed_execution_lookup ` +
+		`WHERE domain_id = ? ` +
+		`AND workflow_id = ? ` +
+		`AND run_id = ?`
 
-	templateGetOpenWorkflowExecutions = `SELECT workflow_id, run_id, start_time, workflow_type_name ` +
+	templateDeleteClosedExecutionLookup = `DELETE FROM closed_execution_lookup ` +
+		`WHERE domain_id = ? ` +
+		`AND workflow_id = ? ` +
+		`AND run_id = ?`
+
+	templateGetOpenWorkflowExecutions = `SELECT workflow_id, run_id, start_time, workflow_type_name, tags, first_run_id ` +
 		`FROM open_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition IN (?) ` +
 		`AND start_time >= ? ` +
 		`AND start_time <= ? `
 
-	templateGetClosedWorkflowExecutions = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length ` +
+	templateGetClosedWorkflowExecutions = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length, tags, ` +
+		`execution_time, duration, first_run_id ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition IN (?) ` +
 		`AND start_time >= ? ` +
 		`AND start_time <= ? `
 
-	templateGetOpenWorkflowExecutionsByType = `SELECT workflow_id, run_id, start_time, workflow_type_name ` +
+	templateGetClosedWorkflowExecutionsV2 = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length, tags, ` +
+		`execution_time, duration, first_run_id ` +
+		`FROM closed_executions_v2 ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND close_bucket = ? ` +
+		`AND start_time >= ? ` +
+		`AND start_time <= ? `
+
+	templateGetOpenWorkflowExecutionsByType = `SELECT workflow_id, run_id, start_time, workflow_type_name, tags, first_run_id ` +
 		`FROM open_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -85,7 +163,8 @@ const (
 		`AND start_time <= ? ` +
 		`AND workflow_type_name = ? `
 
-	templateGetClosedWorkflowExecutionsByType = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length ` +
+	templateGetClosedWorkflowExecutionsByType = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length, tags, ` +
+		`execution_time, duration, first_run_id ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -93,7 +172,17 @@ const (
 		`AND start_time <= ? ` +
 		`AND workflow_type_name = ? `
 
-	templateGetOpenWorkflowExecutionsByID = `SELECT workflow_id, run_id, start_time, workflow_type_name ` +
+	templateGetClosedWorkflowExecutionsByTypeV2 = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length, tags, ` +
+		`execution_time, duration, first_run_id ` +
+		`FROM closed_executions_v2 ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND close_bucket = ? ` +
+		`AND start_time >= ? ` +
+		`AND start_time <= ? ` +
+		`AND workflow_type_name = ? `
+
+	templateGetOpenWorkflowExecutionsByID = `SELECT workflow_id, run_id, start_time, workflow_type_name, tags, first_run_id ` +
 		`FROM open_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -101,7 +190,8 @@ const (
 		`AND start_time <= ? ` +
 		`AND workflow_id = ? `
 
-	templateGetClosedWorkflowExecutionsByID = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length ` +
+	templateGetClosedWorkflowExecutionsByID = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length, tags, ` +
+		`execution_time, duration, first_run_id ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -109,7 +199,18 @@ const (
 		`AND start_time <= ? ` +
 		`AND workflow_id = ? `
 
-	templateGetClosedWorkflowExecutionsByStatus = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length ` +
+	templateGetClosedWorkflowExecutionsByIDV2 = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length, tags, ` +
+		`execution_time, duration, first_run_id ` +
+		`FROM closed_executions_v2 ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND close_bucket = ? ` +
+		`AND start_time >= ? ` +
+		`AND start_time <= ? ` +
+		`AND workflow_id = ? `
+
+	templateGetClosedWorkflowExecutionsByStatus = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length, tags, ` +
+		`execution_time, duration, first_run_id ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
@@ -117,12 +218,78 @@ const (
 		`AND start_time <= ? ` +
 		`AND status = ? `
 
-	templateGetClosedWorkflowExecution = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length ` +
+	templateGetClosedWorkflowExecutionsByStatusV2 = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length, tags, ` +
+		`execution_time, duration, first_run_id ` +
+		`FROM closed_executions_v2 ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND close_bucket = ? ` +
+		`AND start_time >= ? ` +
+		`AND start_time <= ? ` +
+		`AND status = ? `
+
+	templateGetOpenWorkflowExecutionsByTag = `SELECT workflow_id, run_id, start_time, workflow_type_name, tags, first_run_id ` +
+		`FROM open_executions ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND start_time >= ? ` +
+		`AND start_time <= ? ` +
+		`AND tags[?] = ? `
+
+	templateGetClosedWorkflowExecutionsByTag = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length, tags, ` +
+		`execution_time, duration, first_run_id ` +
+		`FROM closed_executions ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND start_time >= ? ` +
+		`AND start_time <= ? ` +
+		`AND tags[?] = ? `
+
+	templateGetClosedWorkflowExecutionsByTagV2 = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length, tags, ` +
+		`execution_time, duration, first_run_id ` +
+		`FROM closed_executions_v2 ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND close_bucket = ? ` +
+		`AND start_time >= ? ` +
+		`AND start_time <= ? ` +
+		`AND tags[?] = ? `
+
+	templateGetOpenWorkflowExecutionsByFirstRunID = `SELECT workflow_id, run_id, start_time, workflow_type_name, tags, first_run_id ` +
+		`FROM open_executions ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND start_time >= ? ` +
+		`AND start_time <= ? ` +
+		`AND first_run_id = ? `
+
+	templateGetClosedWorkflowExecutionsByFirstRunIDV2 = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length, tags, ` +
+		`execution_time, duration, first_run_id ` +
+		`FROM closed_executions_v2 ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND close_bucket = ? ` +
+		`AND start_time >= ? ` +
+		`AND start_time <= ? ` +
+		`AND first_run_id = ? `
+
+	// records closed before closed records were bucketed have no lookup row
+	templateGetClosedWorkflowExecution = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length, tags, ` +
+		`execution_time, duration, first_run_id ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
 		`AND domain_partition = ? ` +
 		`AND workflow_id = ? ` +
 		`AND run_id = ? ALLOW FILTERING `
+
+	templateGetClosedWorkflowExecutionV2 = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length, tags, ` +
+		`execution_time, duration, first_run_id ` +
+		`FROM closed_executions_v2 ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ? ` +
+		`AND close_bucket = ? ` +
+		`AND start_time = ? ` +
+		`AND run_id = ?`
 )
 
 type (
@@ -131,12 +298,27 @@ type (
 		lowConslevel gocql.Consistency
 		logger       bark.Logger
 	}
+
+	// closedExecutionsPageToken is the next page token of the closed executions lists, which read the close buckets
+	// from the newest to the oldest one and the legacy table last
+	closedExecutionsPageToken struct {
+		CloseBucket int64
+		PageState   []byte
+	}
+
+	// allExecutionsPageToken is the next page token of ListAllWorkflowExecutions, which lists the open executions
+	// before the closed ones
+	allExecutionsPageToken struct {
+		Closed    bool
+		PageToken []byte
+	}
 )
 
 // NewCassandraVisibilityPersistence is used to create an instance of VisibilityManager implementation
 func NewCassandraVisibilityPersistence(
-	hosts string, port int, user, password, dc string, keyspace string, logger bark.Logger) (VisibilityManager, error) {
-	cluster := common.NewCassandraCluster(hosts, port, user, password, dc)
+	hosts string, port int, credentialsProvider credentials.Provider, dc string, keyspace string,
+	logger bark.Logger) (VisibilityManager, error) {
+	cluster := common.NewCassandraCluster(hosts, port, credentialsProvider, dc)
 	cluster.Keyspace = keyspace
 	cluster.ProtoVersion = cassandraProtoVersion
 	cluster.Consistency = gocql.LocalQuorum
@@ -170,6 +352,8 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionStarted(
 			*request.Execution.RunId,
 			common.UnixNanoToCQLTimestamp(request.StartTimestamp),
 			request.WorkflowTypeName,
+			request.Tags,
+			request.FirstRunID,
 		)
 	} else {
 		query = v.session.Query(templateCreateWorkflowExecutionStartedWithTTL,
@@ -179,6 +363,8 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionStarted(
 			*request.Execution.RunId,
 			common.UnixNanoToCQLTimestamp(request.StartTimestamp),
 			request.WorkflowTypeName,
+			request.Tags,
+			request.FirstRunID,
 			ttl,
 		)
 	}
@@ -210,18 +396,28 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(
 		*request.Execution.RunId,
 	)
 
-	// Next, add a row in the closed table.
+	// Next, add a row in the closed table, in the bucket of the close time.
+
+	// Execution time and duration are stored so readers don't need the history for basic stats
+	executionTimestamp := request.ExecutionTimestamp
+	if executionTimestamp == 0 {
+		executionTimestamp = request.StartTimestamp
+	}
+	duration := request.CloseTimestamp - executionTimestamp
+	closeBucket := common.UnixNanoToCQLTimestamp(getCloseBucket(request.CloseTimestamp))
 
 	// Find how long to keep the row
 	retention := request.RetentionSeconds
 	if retention == 0 {
 		retention = defaultCloseTTLSeconds
 	}
+	ttl := retention + closedExecutionTTLBufferSeconds
 
-	if retention > maxCassandraTTL {
-		batch.Query(templateCreateWorkflowExecutionClosed,
+	if ttl > maxCassandraTTL {
+		batch.Query(templateCreateWorkflowExecutionClosedV2,
 			request.DomainUUID,
 			domainPartition,
+			closeBucket,
 			*request.Execution.WorkflowId,
 			*request.Execution.RunId,
 			common.UnixNanoToCQLTimestamp(request.StartTimestamp),
@@ -229,11 +425,28 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(
 			request.WorkflowTypeName,
 			request.Status,
 			request.HistoryLength,
+			request.Tags,
+			common.UnixNanoToCQLTimestamp(executionTimestamp),
+			duration,
+			request.FirstRunID,
+		)
+		batch.Query(templateCreateClosedExecutionBucket,
+			request.DomainUUID,
+			domainPartition,
+			closeBucket,
+		)
+		batch.Query(templateCreateClosedExecutionLookup,
+			request.DomainUUID,
+			*request.Execution.WorkflowId,
+			*request.Execution.RunId,
+			closeBucket,
+			common.UnixNanoToCQLTimestamp(request.StartTimestamp),
 		)
 	} else {
-		batch.Query(templateCreateWorkflowExecutionClosedWithTTL,
+		batch.Query(templateCreateWorkflowExecutionClosedV2WithTTL,
 			request.DomainUUID,
 			domainPartition,
+			closeBucket,
 			*request.Execution.WorkflowId,
 			*request.Execution.RunId,
 			common.UnixNanoToCQLTimestamp(request.StartTimestamp),
@@ -241,7 +454,26 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(
 			request.WorkflowTypeName,
 			request.Status,
 			request.HistoryLength,
-			retention,
+			request.Tags,
+			common.UnixNanoToCQLTimestamp(executionTimestamp),
+			duration,
+			request.FirstRunID,
+			ttl,
+		)
+		// every close refreshes the TTL of its bucket, so the bucket outlives all its records
+		batch.Query(templateCreateClosedExecutionBucketWithTTL,
+			request.DomainUUID,
+			domainPartition,
+			closeBucket,
+			ttl,
+		)
+		batch.Query(templateCreateClosedExecutionLookupWithTTL,
+			request.DomainUUID,
+			*request.Execution.WorkflowId,
+			*request.Execution.RunId,
+			closeBucket,
+			common.UnixNanoToCQLTimestamp(request.StartTimestamp),
+			ttl,
 		)
 	}
 
@@ -302,42 +534,8 @@ func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutions(
 
 func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutions(
 	request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
-	query := v.session.Query(templateGetClosedWorkflowExecutions,
-		request.DomainUUID,
-		domainPartition,
-		common.UnixNanoToCQLTimestamp(request.EarliestStartTime),
-		common.UnixNanoToCQLTimestamp(request.LatestStartTime)).Consistency(v.lowConslevel)
-	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
-	if iter == nil {
-		// TODO: should return a bad request error if the token is invalid
-		return nil, &workflow.InternalServiceError{
-			Message: "ListClosedWorkflowExecutions operation failed.  Not able to create query iterator.",
-		}
-	}
-
-	response := &ListWorkflowExecutionsResponse{}
-	response.Executions = make([]*workflow.WorkflowExecutionInfo, 0)
-	wfexecution, has := readClosedWorkflowExecutionRecord(iter)
-	for has {
-		response.Executions = append(response.Executions, wfexecution)
-		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
-	}
-
-	nextPageToken := iter.PageState()
-	response.NextPageToken = make([]byte, len(nextPageToken))
-	copy(response.NextPageToken, nextPageToken)
-	if err := iter.Close(); err != nil {
-		if isThrottlingError(err) {
-			return nil, &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("ListClosedWorkflowExecutions operation failed. Error: %v", err),
-			}
-		}
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListClosedWorkflowExecutions operation failed. Error: %v", err),
-		}
-	}
-
-	return response, nil
+	return v.listClosedWorkflowExecutions("ListClosedWorkflowExecutions", request,
+		templateGetClosedWorkflowExecutionsV2, templateGetClosedWorkflowExecutions)
 }
 
 func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByType(
@@ -383,26 +581,33 @@ func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByType(
 
 func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByType(
 	request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
-	query := v.session.Query(templateGetClosedWorkflowExecutionsByType,
+	return v.listClosedWorkflowExecutions("ListClosedWorkflowExecutionsByType", &request.ListWorkflowExecutionsRequest,
+		templateGetClosedWorkflowExecutionsByTypeV2, templateGetClosedWorkflowExecutionsByType,
+		request.WorkflowTypeName)
+}
+
+func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByWorkflowID(
+	request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	query := v.session.Query(templateGetOpenWorkflowExecutionsByID,
 		request.DomainUUID,
 		domainPartition,
 		common.UnixNanoToCQLTimestamp(request.EarliestStartTime),
 		common.UnixNanoToCQLTimestamp(request.LatestStartTime),
-		request.WorkflowTypeName).Consistency(v.lowConslevel)
+		request.WorkflowID).Consistency(v.lowConslevel)
 	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
 	if iter == nil {
 		// TODO: should return a bad request error if the token is invalid
 		return nil, &workflow.InternalServiceError{
-			Message: "ListClosedWorkflowExecutionsByType operation failed.  Not able to create query iterator.",
+			Message: "ListOpenWorkflowExecutionsByWorkflowID operation failed.  Not able to create query iterator.",
 		}
 	}
 
 	response := &ListWorkflowExecutionsResponse{}
 	response.Executions = make([]*workflow.WorkflowExecutionInfo, 0)
-	wfexecution, has := readClosedWorkflowExecutionRecord(iter)
+	wfexecution, has := readOpenWorkflowExecutionRecord(iter)
 	for has {
 		response.Executions = append(response.Executions, wfexecution)
-		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
+		wfexecution, has = readOpenWorkflowExecutionRecord(iter)
 	}
 
 	nextPageToken := iter.PageState()
@@ -411,30 +616,45 @@ func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByType(
 	if err := iter.Close(); err != nil {
 		if isThrottlingError(err) {
 			return nil, &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("ListClosedWorkflowExecutionsByType operation failed. Error: %v", err),
+				Message: fmt.Sprintf("ListOpenWorkflowExecutionsByWorkflowID operation failed. Error: %v", err),
 			}
 		}
 		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListClosedWorkflowExecutionsByType operation failed. Error: %v", err),
+			Message: fmt.Sprintf("ListOpenWorkflowExecutionsByWorkflowID operation failed. Error: %v", err),
 		}
 	}
 
 	return response, nil
 }
 
-func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByWorkflowID(
+func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByWorkflowID(
 	request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
-	query := v.session.Query(templateGetOpenWorkflowExecutionsByID,
+	return v.listClosedWorkflowExecutions("ListClosedWorkflowExecutionsByWorkflowID", &request.ListWorkflowExecutionsRequest,
+		templateGetClosedWorkflowExecutionsByIDV2, templateGetClosedWorkflowExecutionsByID,
+		request.WorkflowID)
+}
+
+func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByStatus(
+	request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
+	return v.listClosedWorkflowExecutions("ListClosedWorkflowExecutionsByStatus", &request.ListWorkflowExecutionsRequest,
+		templateGetClosedWorkflowExecutionsByStatusV2, templateGetClosedWorkflowExecutionsByStatus,
+		request.Status)
+}
+
+func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByTag(
+	request *ListWorkflowExecutionsByTagRequest) (*ListWorkflowExecutionsResponse, error) {
+	query := v.session.Query(templateGetOpenWorkflowExecutionsByTag,
 		request.DomainUUID,
 		domainPartition,
 		common.UnixNanoToCQLTimestamp(request.EarliestStartTime),
 		common.UnixNanoToCQLTimestamp(request.LatestStartTime),
-		request.WorkflowID).Consistency(v.lowConslevel)
+		request.TagKey,
+		request.TagValue).Consistency(v.lowConslevel)
 	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
 	if iter == nil {
 		// TODO: should return a bad request error if the token is invalid
 		return nil, &workflow.InternalServiceError{
-			Message: "ListOpenWorkflowExecutionsByWorkflowID operation failed.  Not able to create query iterator.",
+			Message: "ListOpenWorkflowExecutionsByTag operation failed.  Not able to create query iterator.",
 		}
 	}
 
@@ -452,107 +672,188 @@ func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByWorkflowID(
 	if err := iter.Close(); err != nil {
 		if isThrottlingError(err) {
 			return nil, &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("ListOpenWorkflowExecutionsByWorkflowID operation failed. Error: %v", err),
+				Message: fmt.Sprintf("ListOpenWorkflowExecutionsByTag operation failed. Error: %v", err),
 			}
 		}
 		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListOpenWorkflowExecutionsByWorkflowID operation failed. Error: %v", err),
+			Message: fmt.Sprintf("ListOpenWorkflowExecutionsByTag operation failed. Error: %v", err),
 		}
 	}
 
 	return response, nil
 }
 
-func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByWorkflowID(
-	request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
-	query := v.session.Query(templateGetClosedWorkflowExecutionsByID,
+func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByTag(
+	request *ListWorkflowExecutionsByTagRequest) (*ListWorkflowExecutionsResponse, error) {
+	return v.listClosedWorkflowExecutions("ListClosedWorkflowExecutionsByTag", &request.ListWorkflowExecutionsRequest,
+		templateGetClosedWorkflowExecutionsByTagV2, templateGetClosedWorkflowExecutionsByTag,
+		request.TagKey, request.TagValue)
+}
+
+func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutionsByFirstRunID(
+	request *ListWorkflowExecutionsByFirstRunIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	query := v.session.Query(templateGetOpenWorkflowExecutionsByFirstRunID,
 		request.DomainUUID,
 		domainPartition,
 		common.UnixNanoToCQLTimestamp(request.EarliestStartTime),
 		common.UnixNanoToCQLTimestamp(request.LatestStartTime),
-		request.WorkflowID).Consistency(v.lowConslevel)
+		request.FirstRunID).Consistency(v.lowConslevel)
 	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
 	if iter == nil {
-		// TODO: should return a bad request error if the token is invalid
 		return nil, &workflow.InternalServiceError{
-			Message: "ListClosedWorkflowExecutionsByWorkflowID operation failed.  Not able to create query iterator.",
+			Message: "ListOpenWorkflowExecutionsByFirstRunID operation failed.  Not able to create query iterator.",
 		}
 	}
 
 	response := &ListWorkflowExecutionsResponse{}
 	response.Executions = make([]*workflow.WorkflowExecutionInfo, 0)
-	wfexecution, has := readClosedWorkflowExecutionRecord(iter)
+	wfexecution, has := readOpenWorkflowExecutionRecord(iter)
 	for has {
 		response.Executions = append(response.Executions, wfexecution)
-		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
+		wfexecution, has = readOpenWorkflowExecutionRecord(iter)
 	}
 
 	nextPageToken := iter.PageState()
 	response.NextPageToken = make([]byte, len(nextPageToken))
 	copy(response.NextPageToken, nextPageToken)
 	if err := iter.Close(); err != nil {
-		if isThrottlingError(err) {
-			return nil, &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("ListClosedWorkflowExecutionsByWorkflowID operation failed. Error: %v", err),
-			}
-		}
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListClosedWorkflowExecutionsByWorkflowID operation failed. Error: %v", err),
-		}
+		return nil, convertVisibilityError("ListOpenWorkflowExecutionsByFirstRunID", err)
 	}
 
 	return response, nil
 }
 
-func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByStatus(
-	request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
-	query := v.session.Query(templateGetClosedWorkflowExecutionsByStatus,
-		request.DomainUUID,
-		domainPartition,
-		common.UnixNanoToCQLTimestamp(request.EarliestStartTime),
-		common.UnixNanoToCQLTimestamp(request.LatestStartTime),
-		request.Status).Consistency(v.lowConslevel)
-	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
-	if iter == nil {
-		// TODO: should return a bad request error if the token is invalid
-		return nil, &workflow.InternalServiceError{
-			Message: "ListClosedWorkflowExecutionsByStatus operation failed.  Not able to create query iterator.",
+// ListClosedWorkflowExecutionsByFirstRunID skips the legacy closed executions table, its records were written before
+// the first run ID was and can't match
+func (v *cassandraVisibilityPersistence) ListClosedWorkflowExecutionsByFirstRunID(
+	request *ListWorkflowExecutionsByFirstRunIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	return v.listClosedWorkflowExecutions("ListClosedWorkflowExecutionsByFirstRunID", &request.ListWorkflowExecutionsRequest,
+		templateGetClosedWorkflowExecutionsByFirstRunIDV2, "",
+		request.FirstRunID)
+}
+
+// ListAllWorkflowExecutions lists the open executions matching the filter, then the closed ones. A page running out of
+// open executions is filled up with closed ones, so only the last page can be shorter than the page size.
+func (v *cassandraVisibilityPersistence) ListAllWorkflowExecutions(
+	request *ListAllWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	token := &allExecutionsPageToken{}
+	if len(request.NextPageToken) > 0 {
+		if err := json.Unmarshal(request.NextPageToken, token); err != nil {
+			return nil, &workflow.BadRequestError{
+				Message: fmt.Sprintf("ListAllWorkflowExecutions operation failed. Invalid next page token: %v", err),
+			}
 		}
 	}
 
 	response := &ListWorkflowExecutionsResponse{}
 	response.Executions = make([]*workflow.WorkflowExecutionInfo, 0)
-	wfexecution, has := readClosedWorkflowExecutionRecord(iter)
-	for has {
-		response.Executions = append(response.Executions, wfexecution)
-		wfexecution, has = readClosedWorkflowExecutionRecord(iter)
+	if !token.Closed {
+		page := request.ListWorkflowExecutionsRequest
+		page.NextPageToken = token.PageToken
+		openResponse, err := v.listAllWorkflowExecutions(request, page, false)
+		if err != nil {
+			return nil, err
+		}
+		response.Executions = append(response.Executions, openResponse.Executions...)
+		if len(openResponse.NextPageToken) > 0 {
+			return setAllExecutionsPageToken(response, false, openResponse.NextPageToken)
+		}
+		token = &allExecutionsPageToken{Closed: true}
 	}
 
-	nextPageToken := iter.PageState()
-	response.NextPageToken = make([]byte, len(nextPageToken))
-	copy(response.NextPageToken, nextPageToken)
-	if err := iter.Close(); err != nil {
-		if isThrottlingError(err) {
-			return nil, &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("ListClosedWorkflowExecutionsByStatus operation failed. Error: %v", err),
-			}
+	if len(response.Executions) >= request.PageSize {
+		return setAllExecutionsPageToken(response, true, nil)
+	}
+
+	page := request.ListWorkflowExecutionsRequest
+	page.PageSize -= len(response.Executions)
+	page.NextPageToken = token.PageToken
+	closedResponse, err := v.listAllWorkflowExecutions(request, page, true)
+	if err != nil {
+		return nil, err
+	}
+	response.Executions = append(response.Executions, closedResponse.Executions...)
+	if len(closedResponse.NextPageToken) > 0 {
+		return setAllExecutionsPageToken(response, true, closedResponse.NextPageToken)
+	}
+	return response, nil
+}
+
+// listAllWorkflowExecutions reads a page of the open or the closed executions matching the filter of the request
+func (v *cassandraVisibilityPersistence) listAllWorkflowExecutions(request *ListAllWorkflowExecutionsRequest,
+	page ListWorkflowExecutionsRequest, closed bool) (*ListWorkflowExecutionsResponse, error) {
+	switch {
+	case request.WorkflowID != "":
+		filtered := &ListWorkflowExecutionsByWorkflowIDRequest{ListWorkflowExecutionsRequest: page, WorkflowID: request.WorkflowID}
+		if closed {
+			return v.ListClosedWorkflowExecutionsByWorkflowID(filtered)
 		}
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("ListClosedWorkflowExecutionsByStatus operation failed. Error: %v", err),
+		return v.ListOpenWorkflowExecutionsByWorkflowID(filtered)
+	case request.WorkflowTypeName != "":
+		filtered := &ListWorkflowExecutionsByTypeRequest{ListWorkflowExecutionsRequest: page, WorkflowTypeName: request.WorkflowTypeName}
+		if closed {
+			return v.ListClosedWorkflowExecutionsByType(filtered)
+		}
+		return v.ListOpenWorkflowExecutionsByType(filtered)
+	case request.TagKey != "":
+		filtered := &ListWorkflowExecutionsByTagRequest{ListWorkflowExecutionsRequest: page, TagKey: request.TagKey,
+			TagValue: request.TagValue}
+		if closed {
+			return v.ListClosedWorkflowExecutionsByTag(filtered)
+		}
+		return v.ListOpenWorkflowExecutionsByTag(filtered)
+	case request.FirstRunID != "":
+		filtered := &ListWorkflowExecutionsByFirstRunIDRequest{ListWorkflowExecutionsRequest: page, FirstRunID: request.FirstRunID}
+		if closed {
+			return v.ListClosedWorkflowExecutionsByFirstRunID(filtered)
 		}
+		return v.ListOpenWorkflowExecutionsByFirstRunID(filtered)
 	}
+	if closed {
+		return v.ListClosedWorkflowExecutions(&page)
+	}
+	return v.ListOpenWorkflowExecutions(&page)
+}
 
+func setAllExecutionsPageToken(response *ListWorkflowExecutionsResponse, closed bool,
+	pageToken []byte) (*ListWorkflowExecutionsResponse, error) {
+	token, err := json.Marshal(&allExecutionsPageToken{
+		Closed:    closed,
+		PageToken: pageToken,
+	})
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListAllWorkflowExecutions operation failed. Error: %v", err),
+		}
+	}
+	response.NextPageToken = token
 	return response, nil
 }
 
 func (v *cassandraVisibilityPersistence) GetClosedWorkflowExecution(
 	request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
 	execution := request.Execution
-	query := v.session.Query(templateGetClosedWorkflowExecution,
-		request.DomainUUID,
-		domainPartition,
-		*execution.WorkflowId,
+	closeBucket, startTime, found, err := v.getClosedExecutionLookup(request.DomainUUID, *execution.WorkflowId,
 		*execution.RunId)
+	if err != nil {
+		return nil, convertVisibilityError("GetClosedWorkflowExecution", err)
+	}
+
+	var query *gocql.Query
+	if found {
+		query = v.session.Query(templateGetClosedWorkflowExecutionV2,
+			request.DomainUUID,
+			domainPartition,
+			closeBucket,
+			startTime,
+			*execution.RunId)
+	} else {
+		query = v.session.Query(templateGetClosedWorkflowExecution,
+			request.DomainUUID,
+			domainPartition,
+			*execution.WorkflowId,
+			*execution.RunId)
+	}
 
 	iter := query.Iter()
 	if iter == nil {
@@ -562,6 +863,9 @@ func (v *cassandraVisibilityPersistence) GetClosedWorkflowExecution(
 	}
 
 	wfexecution, has := readClosedWorkflowExecutionRecord(iter)
+	if err := iter.Close(); err != nil {
+		return nil, convertVisibilityError("GetClosedWorkflowExecution", err)
+	}
 	if !has {
 		return nil, &workflow.EntityNotExistsError{
 			Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v, RunId: %v",
@@ -569,20 +873,245 @@ func (v *cassandraVisibilityPersistence) GetClosedWorkflowExecution(
 		}
 	}
 
-	if err := iter.Close(); err != nil {
-		if isThrottlingError(err) {
-			return nil, &workflow.ServiceBusyError{
-				Message: fmt.Sprintf("GetClosedWorkflowExecution operation failed. Error: %v", err),
+	return &GetClosedWorkflowExecutionResponse{
+		Execution: wfexecution,
+	}, nil
+}
+
+// DeleteWorkflowExecution deletes the open and closed records of an execution, the closed record is found through
+// its lookup row so the batch has the same few statements whatever the age of the execution
+func (v *cassandraVisibilityPersistence) DeleteWorkflowExecution(
+	request *VisibilityDeleteWorkflowExecutionRequest) error {
+	closeBucket, startTime, found, err := v.getClosedExecutionLookup(request.DomainUUID, request.WorkflowID,
+		request.RunID)
+	if err != nil {
+		return convertVisibilityError("DeleteWorkflowExecution", err)
+	}
+
+	batch := v.session.NewBatch(gocql.LoggedBatch)
+	batch.Query(templateDeleteWorkflowExecutionStarted,
+		request.DomainUUID,
+		domainPartition,
+		common.UnixNanoToCQLTimestamp(request.StartTimestamp),
+		request.RunID,
+	)
+	batch.Query(templateDeleteWorkflowExecutionClosed,
+		request.DomainUUID,
+		domainPartition,
+		common.UnixNanoToCQLTimestamp(request.StartTimestamp),
+		request.RunID,
+	)
+	if found {
+		batch.Query(templateDeleteWorkflowExecutionClosedV2,
+			request.DomainUUID,
+			domainPartition,
+			closeBucket,
+			startTime,
+			request.RunID,
+		)
+		batch.Query(templateDeleteClosedExecutionLookup,
+			request.DomainUUID,
+			request.WorkflowID,
+			request.RunID,
+		)
+	}
+
+	err = v.session.ExecuteBatch(batch)
+	if err != nil {
+		return convertVisibilityError("DeleteWorkflowExecution", err)
+	}
+
+	return nil
+}
+
+// PruneClosedWorkflowExecutions drops the close buckets of a domain which only hold records closed before the given
+// time. A bucket is dropped with a single partition delete, before its entry in the bucket table so a failed prune
+// is picked up again by the next one.
+func (v *cassandraVisibilityPersistence) PruneClosedWorkflowExecutions(
+	request *PruneClosedWorkflowExecutionsRequest) (*PruneClosedWorkflowExecutionsResponse, error) {
+	buckets, err := v.getCloseBuckets(request.DomainUUID, 0)
+	if err != nil {
+		return nil, convertVisibilityError("PruneClosedWorkflowExecutions", err)
+	}
+
+	response := &PruneClosedWorkflowExecutionsResponse{}
+	for _, bucket := range buckets {
+		if bucket == legacyCloseBucket || bucket+closedExecutionBucketSize > request.CloseTimeBefore {
+			continue
+		}
+
+		err := v.session.Query(templateDeleteClosedExecutionsBucketPartition,
+			request.DomainUUID,
+			domainPartition,
+			common.UnixNanoToCQLTimestamp(bucket),
+		).Exec()
+		if err != nil {
+			return response, convertVisibilityError("PruneClosedWorkflowExecutions", err)
+		}
+		err = v.session.Query(templateDeleteClosedExecutionBucket,
+			request.DomainUUID,
+			domainPartition,
+			common.UnixNanoToCQLTimestamp(bucket),
+		).Exec()
+		if err != nil {
+			return response, convertVisibilityError("PruneClosedWorkflowExecutions", err)
+		}
+		response.PrunedBuckets++
+	}
+
+	return response, nil
+}
+
+// listClosedWorkflowExecutions reads a page of closed executions, filling it from the close buckets starting with
+// the newest one and the legacy table last, unless there is no legacy template. Within a bucket the executions are
+// ordered by their start time.
+func (v *cassandraVisibilityPersistence) listClosedWorkflowExecutions(operation string,
+	request *ListWorkflowExecutionsRequest, bucketTemplate string, legacyTemplate string,
+	filters ...interface{}) (*ListWorkflowExecutionsResponse, error) {
+	// an execution never closes before it starts, older buckets can't hold any match
+	buckets, err := v.getCloseBuckets(request.DomainUUID, request.EarliestStartTime)
+	if err != nil {
+		return nil, convertVisibilityError(operation, err)
+	}
+	if legacyTemplate == "" {
+		buckets = buckets[:len(buckets)-1]
+	}
+
+	index := 0
+	var pageState []byte
+	if len(request.NextPageToken) > 0 {
+		token := &closedExecutionsPageToken{}
+		if err := json.Unmarshal(request.NextPageToken, token); err != nil {
+			return nil, &workflow.BadRequestError{
+				Message: fmt.Sprintf("%v operation failed. Invalid next page token: %v", operation, err),
 			}
 		}
-		return nil, &workflow.InternalServiceError{
-			Message: fmt.Sprintf("GetClosedWorkflowExecution operation failed. Error: %v", err),
+		// buckets created since the previous page are newer than the one it stopped in
+		for index < len(buckets) && buckets[index] > token.CloseBucket {
+			index++
+		}
+		// the page state is only valid for the bucket it was read from, which may have been pruned since
+		if index < len(buckets) && buckets[index] == token.CloseBucket {
+			pageState = token.PageState
 		}
 	}
 
-	return &GetClosedWorkflowExecutionResponse{
-		Execution: wfexecution,
-	}, nil
+	response := &ListWorkflowExecutionsResponse{}
+	response.Executions = make([]*workflow.WorkflowExecutionInfo, 0)
+	for index < len(buckets) && len(response.Executions) < request.PageSize {
+		var query *gocql.Query
+		if buckets[index] == legacyCloseBucket {
+			args := []interface{}{
+				request.DomainUUID,
+				domainPartition,
+				common.UnixNanoToCQLTimestamp(request.EarliestStartTime),
+				common.UnixNanoToCQLTimestamp(request.LatestStartTime),
+			}
+			query = v.session.Query(legacyTemplate, append(args, filters...)...)
+		} else {
+			args := []interface{}{
+				request.DomainUUID,
+				domainPartition,
+				common.UnixNanoToCQLTimestamp(buckets[index]),
+				common.UnixNanoToCQLTimestamp(request.EarliestStartTime),
+				common.UnixNanoToCQLTimestamp(request.LatestStartTime),
+			}
+			query = v.session.Query(bucketTemplate, append(args, filters...)...)
+		}
+		iter := query.Consistency(v.lowConslevel).PageSize(request.PageSize - len(response.Executions)).
+			PageState(pageState).Iter()
+		if iter == nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("%v operation failed.  Not able to create query iterator.", operation),
+			}
+		}
+
+		wfexecution, has := readClosedWorkflowExecutionRecord(iter)
+		for has {
+			response.Executions = append(response.Executions, wfexecution)
+			wfexecution, has = readClosedWorkflowExecutionRecord(iter)
+		}
+
+		nextPageState := iter.PageState()
+		pageState = make([]byte, len(nextPageState))
+		copy(pageState, nextPageState)
+		if err := iter.Close(); err != nil {
+			return nil, convertVisibilityError(operation, err)
+		}
+		if len(pageState) == 0 {
+			index++
+		}
+	}
+
+	if index < len(buckets) {
+		token, err := json.Marshal(&closedExecutionsPageToken{
+			CloseBucket: buckets[index],
+			PageState:   pageState,
+		})
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("%v operation failed. Error: %v", operation, err),
+			}
+		}
+		response.NextPageToken = token
+	}
+
+	return response, nil
+}
+
+// getClosedExecutionLookup returns the close bucket and the start time of the closed record of an execution, found is
+// false for an execution which is not closed or was closed before closed records were bucketed
+func (v *cassandraVisibilityPersistence) getClosedExecutionLookup(domainID string, workflowID string,
+	runID string) (closeBucket time.Time, startTime time.Time, found bool, err error) {
+	query := v.session.Query(templateGetClosedExecutionLookup,
+		domainID,
+		workflowID,
+		runID,
+	)
+	err = query.Scan(&closeBucket, &startTime)
+	if err == gocql.ErrNotFound {
+		return closeBucket, startTime, false, nil
+	}
+	return closeBucket, startTime, err == nil, err
+}
+
+// getCloseBuckets returns the close buckets of a domain starting from the bucket of the given time, newest first and
+// followed by the legacy bucket
+func (v *cassandraVisibilityPersistence) getCloseBuckets(domainID string, since int64) ([]int64, error) {
+	query := v.session.Query(templateGetClosedExecutionBuckets,
+		domainID,
+		domainPartition,
+		common.UnixNanoToCQLTimestamp(getCloseBucket(since)),
+	).Consistency(v.lowConslevel)
+	iter := query.Iter()
+	if iter == nil {
+		return nil, fmt.Errorf("not able to create query iterator")
+	}
+
+	var buckets []int64
+	var bucket time.Time
+	for iter.Scan(&bucket) {
+		buckets = append(buckets, bucket.UnixNano())
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return append(buckets, legacyCloseBucket), nil
+}
+
+func getCloseBucket(closeTimestamp int64) int64 {
+	return closeTimestamp - closeTimestamp%closedExecutionBucketSize
+}
+
+func convertVisibilityError(operation string, err error) error {
+	if isThrottlingError(err) {
+		return &workflow.ServiceBusyError{
+			Message: fmt.Sprintf("%v operation failed. Error: %v", operation, err),
+		}
+	}
+	return &workflow.InternalServiceError{
+		Message: fmt.Sprintf("%v operation failed. Error: %v", operation, err),
+	}
 }
 
 func readOpenWorkflowExecutionRecord(iter *gocql.Iter) (*workflow.WorkflowExecutionInfo, bool) {
@@ -590,7 +1119,9 @@ func readOpenWorkflowExecutionRecord(iter *gocql.Iter) (*workflow.WorkflowExecut
 	var runID gocql.UUID
 	var typeName string
 	var startTime time.Time
-	if iter.Scan(&workflowID, &runID, &startTime, &typeName) {
+	var tags map[string]string
+	var firstRunID string
+	if iter.Scan(&workflowID, &runID, &startTime, &typeName, &tags, &firstRunID) {
 		execution := &workflow.WorkflowExecution{}
 		execution.WorkflowId = common.StringPtr(workflowID)
 		execution.RunId = common.StringPtr(runID.String())
@@ -602,6 +1133,10 @@ func readOpenWorkflowExecutionRecord(iter *gocql.Iter) (*workflow.WorkflowExecut
 		record.Execution = execution
 		record.StartTime = common.Int64Ptr(startTime.UnixNano())
 		record.Type = wfType
+		record.Tags = tags
+		if firstRunID != "" {
+			record.FirstRunId = common.StringPtr(firstRunID)
+		}
 		return record, true
 	}
 	return nil, false
@@ -615,7 +1150,12 @@ func readClosedWorkflowExecutionRecord(iter *gocql.Iter) (*workflow.WorkflowExec
 	var closeTime time.Time
 	var status workflow.WorkflowExecutionCloseStatus
 	var historyLength int64
-	if iter.Scan(&workflowID, &runID, &startTime, &closeTime, &typeName, &status, &historyLength) {
+	var tags map[string]string
+	var executionTime time.Time
+	var duration int64
+	var firstRunID string
+	if iter.Scan(&workflowID, &runID, &startTime, &closeTime, &typeName, &status, &historyLength, &tags,
+		&executionTime, &duration, &firstRunID) {
 		execution := &workflow.WorkflowExecution{}
 		execution.WorkflowId = common.StringPtr(workflowID)
 		execution.RunId = common.StringPtr(runID.String())
@@ -630,6 +1170,15 @@ func readClosedWorkflowExecutionRecord(iter *gocql.Iter) (*workflow.WorkflowExec
 		record.Type = wfType
 		record.CloseStatus = &status
 		record.HistoryLength = common.Int64Ptr(historyLength)
+		record.Tags = tags
+		// records closed before the execution time was stored don't have it
+		if !executionTime.IsZero() {
+			record.ExecutionTime = common.Int64Ptr(executionTime.UnixNano())
+			record.ExecutionDuration = common.Int64Ptr(duration)
+		}
+		if firstRunID != "" {
+			record.FirstRunId = common.StringPtr(firstRunID)
+		}
 		return record, true
 	}
 	return nil, false
@@ -21,6 +21,7 @@
 package persistence
 
 import (
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -93,12 +94,14 @@ func (s *visibilityPersistenceSuite) TestBasicVisibility() {
 	s.Equal(1, len(resp.Executions))
 	s.Equal(workflowExecution.WorkflowId, resp.Executions[0].Execution.WorkflowId)
 
+	closeTime := time.Now().UnixNano()
 	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&RecordWorkflowExecutionClosedRequest{
 		DomainUUID:       testDomainUUID,
 		Execution:        workflowExecution,
 		WorkflowTypeName: "visibility-workflow",
 		StartTimestamp:   startTime,
-		CloseTimestamp:   time.Now().UnixNano(),
+		CloseTimestamp:   closeTime,
+		HistoryLength:    5,
 	})
 	s.Nil(err2)
 
@@ -119,6 +122,9 @@ func (s *visibilityPersistenceSuite) TestBasicVisibility() {
 	})
 	s.Nil(err4)
 	s.Equal(1, len(resp.Executions))
+	s.Equal(resp.Executions[0].GetStartTime(), resp.Executions[0].GetExecutionTime())
+	s.Equal(closeTime-startTime, resp.Executions[0].GetExecutionDuration())
+	s.Equal(int64(5), resp.Executions[0].GetHistoryLength())
 }
 
 func (s *visibilityPersistenceSuite) TestVisibilityPagination() {
@@ -340,6 +346,236 @@ func (s *visibilityPersistenceSuite) TestFilteringByWorkflowID() {
 	s.Equal(workflowExecution2.WorkflowId, resp.Executions[0].Execution.WorkflowId)
 }
 
+func (s *visibilityPersistenceSuite) TestFilteringByFirstRunID() {
+	testDomainUUID := uuid.New()
+	startTime := time.Now().UnixNano()
+	firstRunID := "2a7e9b5e-3f4b-4c1e-9a6f-1f0d8c2b7e31"
+
+	// Create the first run of a chain and an unrelated execution
+	workflowExecution1 := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-chain-test"),
+		RunId:      common.StringPtr(firstRunID),
+	}
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        workflowExecution1,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		FirstRunID:       firstRunID,
+	})
+	s.Nil(err0)
+
+	workflowExecution2 := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-chain-test-other"),
+		RunId:      common.StringPtr("6a0c9b86-0f8e-4b33-8d27-4bd1d3f1a0e2"),
+	}
+	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        workflowExecution2,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		FirstRunID:       workflowExecution2.GetRunId(),
+	})
+	s.Nil(err1)
+
+	// Continue the chain as new
+	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&RecordWorkflowExecutionClosedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        workflowExecution1,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		CloseTimestamp:   time.Now().UnixNano(),
+		Status:           gen.WorkflowExecutionCloseStatusContinuedAsNew,
+		FirstRunID:       firstRunID,
+	})
+	s.Nil(err2)
+
+	workflowExecution3 := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-chain-test"),
+		RunId:      common.StringPtr("c3d5e0a4-8a43-4f0b-a0f8-5d7c0f0b9a61"),
+	}
+	err3 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        workflowExecution3,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		FirstRunID:       firstRunID,
+	})
+	s.Nil(err3)
+
+	listRequest := &ListWorkflowExecutionsByFirstRunIDRequest{
+		ListWorkflowExecutionsRequest: ListWorkflowExecutionsRequest{
+			DomainUUID:        testDomainUUID,
+			PageSize:          2,
+			EarliestStartTime: startTime,
+			LatestStartTime:   startTime,
+		},
+		FirstRunID: firstRunID,
+	}
+
+	resp, err4 := s.VisibilityMgr.ListOpenWorkflowExecutionsByFirstRunID(listRequest)
+	s.Nil(err4)
+	s.Equal(1, len(resp.Executions))
+	s.Equal(workflowExecution3.RunId, resp.Executions[0].Execution.RunId)
+	s.Equal(firstRunID, resp.Executions[0].GetFirstRunId())
+
+	resp, err5 := s.VisibilityMgr.ListClosedWorkflowExecutionsByFirstRunID(listRequest)
+	s.Nil(err5)
+	s.Equal(1, len(resp.Executions))
+	s.Equal(workflowExecution1.RunId, resp.Executions[0].Execution.RunId)
+	s.Equal(firstRunID, resp.Executions[0].GetFirstRunId())
+}
+
+func (s *visibilityPersistenceSuite) TestListAllWorkflowExecutions() {
+	testDomainUUID := uuid.New()
+	startTime := time.Now().UnixNano()
+
+	// Create 4 executions and close 2 of them
+	for i := 0; i < 4; i++ {
+		workflowExecution := gen.WorkflowExecution{
+			WorkflowId: common.StringPtr(fmt.Sprintf("visibility-list-all-test-%v", i)),
+			RunId:      common.StringPtr(uuid.New()),
+		}
+		err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+			DomainUUID:       testDomainUUID,
+			Execution:        workflowExecution,
+			WorkflowTypeName: "visibility-workflow",
+			StartTimestamp:   startTime,
+		})
+		s.Nil(err0)
+		if i%2 == 0 {
+			continue
+		}
+		err1 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&RecordWorkflowExecutionClosedRequest{
+			DomainUUID:       testDomainUUID,
+			Execution:        workflowExecution,
+			WorkflowTypeName: "visibility-workflow",
+			StartTimestamp:   startTime,
+			CloseTimestamp:   time.Now().UnixNano(),
+		})
+		s.Nil(err1)
+	}
+
+	// The first page runs out of open executions and is filled up with a closed one
+	request := &ListAllWorkflowExecutionsRequest{
+		ListWorkflowExecutionsRequest: ListWorkflowExecutionsRequest{
+			DomainUUID:        testDomainUUID,
+			PageSize:          3,
+			EarliestStartTime: startTime,
+			LatestStartTime:   startTime,
+		},
+	}
+	resp, err2 := s.VisibilityMgr.ListAllWorkflowExecutions(request)
+	s.Nil(err2)
+	s.Equal(3, len(resp.Executions))
+	s.Nil(resp.Executions[0].CloseStatus)
+	s.Nil(resp.Executions[1].CloseStatus)
+	s.NotNil(resp.Executions[2].CloseStatus)
+	s.NotEmpty(resp.NextPageToken)
+
+	request.NextPageToken = resp.NextPageToken
+	resp, err3 := s.VisibilityMgr.ListAllWorkflowExecutions(request)
+	s.Nil(err3)
+	s.Equal(1, len(resp.Executions))
+	s.NotNil(resp.Executions[0].CloseStatus)
+	s.Empty(resp.NextPageToken)
+
+	// Filters apply to both the open and the closed executions
+	request.NextPageToken = nil
+	request.WorkflowID = "visibility-list-all-test-1"
+	resp, err4 := s.VisibilityMgr.ListAllWorkflowExecutions(request)
+	s.Nil(err4)
+	s.Equal(1, len(resp.Executions))
+	s.Equal("visibility-list-all-test-1", resp.Executions[0].Execution.GetWorkflowId())
+}
+
+func (s *visibilityPersistenceSuite) TestFilteringByTag() {
+	testDomainUUID := uuid.New()
+	startTime := time.Now().UnixNano()
+
+	// Create 2 executions with different teams
+	workflowExecution1 := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-filtering-test1"),
+		RunId:      common.StringPtr("5f4d2a1e-0c6b-4b4e-9a0e-3c1e4f2d7a61"),
+	}
+	tags1 := map[string]string{"team": "payments"}
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        workflowExecution1,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		Tags:             tags1,
+	})
+	s.Nil(err0)
+
+	workflowExecution2 := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-filtering-test2"),
+		RunId:      common.StringPtr("9b3c7e52-8d1f-4a2b-b6c4-0e7f5a9d3b18"),
+	}
+	tags2 := map[string]string{"team": "search"}
+	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&RecordWorkflowExecutionStartedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        workflowExecution2,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		Tags:             tags2,
+	})
+	s.Nil(err1)
+
+	// List open with filtering
+	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutionsByTag(&ListWorkflowExecutionsByTagRequest{
+		ListWorkflowExecutionsRequest: ListWorkflowExecutionsRequest{
+			DomainUUID:        testDomainUUID,
+			PageSize:          2,
+			EarliestStartTime: startTime,
+			LatestStartTime:   startTime,
+		},
+		TagKey:   "team",
+		TagValue: "payments",
+	})
+	s.Nil(err2)
+	s.Equal(1, len(resp.Executions))
+	s.Equal(workflowExecution1.WorkflowId, resp.Executions[0].Execution.WorkflowId)
+	s.Equal(tags1, resp.Executions[0].Tags)
+
+	// Close both executions
+	err3 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&RecordWorkflowExecutionClosedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        workflowExecution1,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		CloseTimestamp:   time.Now().UnixNano(),
+		Tags:             tags1,
+	})
+	s.Nil(err3)
+
+	err4 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&RecordWorkflowExecutionClosedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        workflowExecution2,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		CloseTimestamp:   time.Now().UnixNano(),
+		Tags:             tags2,
+	})
+	s.Nil(err4)
+
+	// List closed with filtering
+	resp, err5 := s.VisibilityMgr.ListClosedWorkflowExecutionsByTag(&ListWorkflowExecutionsByTagRequest{
+		ListWorkflowExecutionsRequest: ListWorkflowExecutionsRequest{
+			DomainUUID:        testDomainUUID,
+			PageSize:          2,
+			EarliestStartTime: startTime,
+			LatestStartTime:   startTime,
+		},
+		TagKey:   "team",
+		TagValue: "search",
+	})
+	s.Nil(err5)
+	s.Equal(1, len(resp.Executions))
+	s.Equal(workflowExecution2.WorkflowId, resp.Executions[0].Execution.WorkflowId)
+	s.Equal(tags2, resp.Executions[0].Tags)
+}
+
 func (s *visibilityPersistenceSuite) TestFilteringByCloseStatus() {
 	testDomainUUID := uuid.New()
 	startTime := time.Now().UnixNano()
@@ -446,3 +682,131 @@ func (s *visibilityPersistenceSuite) TestGetClosedExecution() {
 	s.Equal(workflowExecution.WorkflowId, resp.Execution.Execution.WorkflowId)
 	s.Equal(int64(3), *resp.Execution.HistoryLength)
 }
+
+func (s *visibilityPersistenceSuite) TestDeleteClosedExecution() {
+	testDomainUUID := uuid.New()
+
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-delete-test"),
+		RunId:      common.StringPtr("5b8c2e7a-1f3d-4c6e-8a9b-0d1e2f3a4b5c"),
+	}
+
+	// the execution ran for a week, its closed record is in the bucket of its close day
+	startTime := time.Now().Add(-7 * 24 * time.Hour).UnixNano()
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&RecordWorkflowExecutionClosedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        workflowExecution,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		CloseTimestamp:   time.Now().UnixNano(),
+		HistoryLength:    3,
+	})
+	s.Nil(err0)
+
+	err1 := s.VisibilityMgr.DeleteWorkflowExecution(&VisibilityDeleteWorkflowExecutionRequest{
+		DomainUUID:     testDomainUUID,
+		WorkflowID:     workflowExecution.GetWorkflowId(),
+		RunID:          workflowExecution.GetRunId(),
+		StartTimestamp: startTime,
+	})
+	s.Nil(err1)
+
+	_, err2 := s.VisibilityMgr.GetClosedWorkflowExecution(&GetClosedWorkflowExecutionRequest{
+		DomainUUID: testDomainUUID,
+		Execution:  workflowExecution,
+	})
+	s.IsType(&gen.EntityNotExistsError{}, err2)
+
+	resp, err3 := s.VisibilityMgr.ListClosedWorkflowExecutions(&ListWorkflowExecutionsRequest{
+		DomainUUID:        testDomainUUID,
+		PageSize:          10,
+		EarliestStartTime: startTime,
+		LatestStartTime:   time.Now().UnixNano(),
+	})
+	s.Nil(err3)
+	s.Equal(0, len(resp.Executions))
+}
+
+func (s *visibilityPersistenceSuite) TestCloseBucketsListingAndPruning() {
+	testDomainUUID := uuid.New()
+
+	// one execution closed three days ago, the other one now
+	now := time.Now()
+	oldStartTime := now.Add(-4 * 24 * time.Hour).UnixNano()
+	oldCloseTime := now.Add(-3 * 24 * time.Hour).UnixNano()
+	newStartTime := now.Add(-time.Minute).UnixNano()
+	newCloseTime := now.UnixNano()
+	oldExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-bucket-test-old"),
+		RunId:      common.StringPtr("2a3e1d3c-8f1e-4b0c-9d7a-3c9b6f3a1e01"),
+	}
+	newExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-bucket-test-new"),
+		RunId:      common.StringPtr("2a3e1d3c-8f1e-4b0c-9d7a-3c9b6f3a1e02"),
+	}
+	for _, r := range []*RecordWorkflowExecutionClosedRequest{
+		{
+			DomainUUID:       testDomainUUID,
+			Execution:        oldExecution,
+			WorkflowTypeName: "visibility-workflow",
+			StartTimestamp:   oldStartTime,
+			CloseTimestamp:   oldCloseTime,
+		},
+		{
+			DomainUUID:       testDomainUUID,
+			Execution:        newExecution,
+			WorkflowTypeName: "visibility-workflow",
+			StartTimestamp:   newStartTime,
+			CloseTimestamp:   newCloseTime,
+		},
+	} {
+		err := s.VisibilityMgr.RecordWorkflowExecutionClosed(r)
+		s.Nil(err)
+	}
+
+	// the pages are filled from the newest bucket to the oldest one
+	resp, err := s.VisibilityMgr.ListClosedWorkflowExecutions(&ListWorkflowExecutionsRequest{
+		DomainUUID:        testDomainUUID,
+		PageSize:          1,
+		EarliestStartTime: oldStartTime,
+		LatestStartTime:   newStartTime,
+	})
+	s.Nil(err)
+	s.Equal(1, len(resp.Executions))
+	s.Equal(newExecution.GetWorkflowId(), resp.Executions[0].Execution.GetWorkflowId())
+	s.NotEmpty(resp.NextPageToken)
+
+	resp, err = s.VisibilityMgr.ListClosedWorkflowExecutions(&ListWorkflowExecutionsRequest{
+		DomainUUID:        testDomainUUID,
+		PageSize:          1,
+		EarliestStartTime: oldStartTime,
+		LatestStartTime:   newStartTime,
+		NextPageToken:     resp.NextPageToken,
+	})
+	s.Nil(err)
+	s.Equal(1, len(resp.Executions))
+	s.Equal(oldExecution.GetWorkflowId(), resp.Executions[0].Execution.GetWorkflowId())
+
+	pruneResp, err := s.VisibilityMgr.PruneClosedWorkflowExecutions(&PruneClosedWorkflowExecutionsRequest{
+		DomainUUID:      testDomainUUID,
+		CloseTimeBefore: now.Add(-24 * time.Hour).UnixNano(),
+	})
+	s.Nil(err)
+	s.Equal(1, pruneResp.PrunedBuckets)
+
+	resp, err = s.VisibilityMgr.ListClosedWorkflowExecutions(&ListWorkflowExecutionsRequest{
+		DomainUUID:        testDomainUUID,
+		PageSize:          10,
+		EarliestStartTime: oldStartTime,
+		LatestStartTime:   newStartTime,
+	})
+	s.Nil(err)
+	s.Equal(1, len(resp.Executions))
+	s.Equal(newExecution.GetWorkflowId(), resp.Executions[0].Execution.GetWorkflowId())
+
+	_, err = s.VisibilityMgr.GetClosedWorkflowExecution(&GetClosedWorkflowExecutionRequest{
+		DomainUUID: testDomainUUID,
+		Execution:  oldExecution,
+	})
+	s.IsType(&gen.EntityNotExistsError{}, err)
+}
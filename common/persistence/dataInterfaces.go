@@ -21,6 +21,7 @@
 package persistence
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -41,8 +42,13 @@ const (
 	DomainStatusRegistered = iota
 	DomainStatusDeprecated
 	DomainStatusDeleted
+	DomainStatusPendingApproval
 )
 
+// DomainDataKeyRegistrationRejectedReason is the key of the domain data holding the reason a pending domain
+// registration was rejected for, the rejected domain is deleted
+const DomainDataKeyRegistrationRejectedReason = "cadence:registration-rejected-reason"
+
 // Workflow execution states
 const (
 	WorkflowStateCreated = iota
@@ -73,6 +79,21 @@ const (
 	TaskListKindSticky
 )
 
+// TaskPriorityLevels is the number of priority levels the tasks of a task list are persisted in, the tasks of a
+// higher level are read first. Tasks of priority 0 are in level 0, priorities above the last level are in the last.
+const TaskPriorityLevels = 4
+
+// TaskPriorityLevel returns the level the tasks of the given priority are persisted in
+func TaskPriorityLevel(priority int32) int {
+	if priority <= 0 {
+		return 0
+	}
+	if priority >= TaskPriorityLevels-1 {
+		return TaskPriorityLevels - 1
+	}
+	return int(priority)
+}
+
 // Transfer task types
 const (
 	TransferTaskTypeDecisionTask = iota
@@ -87,6 +108,7 @@ const (
 const (
 	ReplicationTaskTypeHistory = iota
 	ReplicationTaskTypeHeartbeat
+	ReplicationTaskTypeSyncActivity
 )
 
 // Types of timers
@@ -131,6 +153,12 @@ type (
 		Msg string
 	}
 
+	// HistoryEventsCorruptedError is returned when the events read from the history of a workflow execution skip
+	// or repeat event IDs, or go back in version, as left behind by partially overwritten history batches
+	HistoryEventsCorruptedError struct {
+		Msg string
+	}
+
 	// ShardInfo describes a shard
 	ShardInfo struct {
 		ShardID                   int
@@ -150,10 +178,11 @@ type (
 
 	// TransferFailoverLevel contains corresponding start / end level
 	TransferFailoverLevel struct {
-		MinLevel     int64
-		CurrentLevel int64
-		MaxLevel     int64
-		DomainIDs    []string
+		MinLevel        int64
+		CurrentLevel    int64
+		MaxLevel        int64
+		FailoverVersion int64
+		DomainIDs       []string
 	}
 
 	// TimerFailoverLevel contains domain IDs and corresponding start / end level
@@ -201,6 +230,15 @@ type (
 		ClientLibraryVersion         string
 		ClientFeatureVersion         string
 		ClientImpl                   string
+		StickyInvalidationCount      int64
+		Tags                         map[string]string
+		ContinueAsNewTaskList        string
+		HistorySize                  int64
+		IsolationGroup               string
+		Priority                     int32
+		// FirstRunID is the run which started the continue-as-new chain of the execution, its own run ID when
+		// the execution was not continued from another run
+		FirstRunID string
 	}
 
 	// ReplicationState represents mutable state information for global domains.
@@ -241,6 +279,8 @@ type (
 		NextEventID         int64
 		Version             int64
 		LastReplicationInfo map[string]*ReplicationInfo
+		HistorySize         int64
+		ScheduledID         int64
 	}
 
 	// TimerTaskInfo describes a timer task.
@@ -265,6 +305,15 @@ type (
 		RangeID  int64
 		AckLevel int64
 		Kind     int
+		// Pollers is the most recent poller history, kept so it survives task list reloads
+		Pollers []*TaskListPollerInfo
+	}
+
+	// TaskListPollerInfo describes a poller recently seen on a task list
+	TaskListPollerInfo struct {
+		Identity       string
+		Version        string
+		LastAccessTime time.Time
 	}
 
 	// TaskInfo describes either activity or decision task
@@ -275,6 +324,12 @@ type (
 		TaskID                 int64
 		ScheduleID             int64
 		ScheduleToStartTimeout int32
+		IsolationGroup         string
+		// number of times matching failed to start the task with history
+		DispatchAttempts int32
+		Priority         int32
+		// time history scheduled the task, where its schedule-to-start latency starts
+		CreatedTime time.Time
 	}
 
 	// Task is the generic interface for workflow tasks
@@ -408,6 +463,16 @@ type (
 		NextEventID         int64
 		Version             int64
 		LastReplicationInfo map[string]*ReplicationInfo
+		HistorySize         int64 // serialized size of the replicated event batch
+	}
+
+	// SyncActivityTask is the replication task created for shipping the retry state of an activity, which is kept in
+	// mutable state only, to other clusters
+	SyncActivityTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		Version             int64
+		ScheduledID         int64
 	}
 
 	// ReplicationInfo represents the information stored for last replication event details per cluster
@@ -424,6 +489,7 @@ type (
 		RequestCancelInfos       map[int64]*RequestCancelInfo
 		SignalInfos              map[int64]*SignalInfo
 		SignalRequestedIDs       map[string]struct{}
+		UpdateInfos              map[string]*UpdateInfo
 		ExecutionInfo            *WorkflowExecutionInfo
 		ReplicationState         *ReplicationState
 		BufferedEvents           []*SerializedHistoryEventBatch
@@ -502,6 +568,15 @@ type (
 		Control         []byte
 	}
 
+	// UpdateInfo has details for a workflow update, it is kept once completed so the result can be returned to a
+	// caller retrying the update
+	UpdateInfo struct {
+		Version   int64
+		UpdateID  string
+		Completed bool
+		Result    []byte
+	}
+
 	// BufferedReplicationTask has details to handle out of order receive of history events
 	BufferedReplicationTask struct {
 		FirstEventID  int64
@@ -532,6 +607,58 @@ type (
 		PreviousRangeID int64
 	}
 
+	// ReplicationDLQMessageKey identifies a history replication task moved to the DLQ by the workflow execution and
+	// the first event it carries, the history shard is the one of the workflow execution
+	ReplicationDLQMessageKey struct {
+		ShardID       int
+		SourceCluster string
+		DomainID      string
+		WorkflowID    string
+		RunID         string
+		FirstEventID  int64
+	}
+
+	// PutReplicationDLQMessageRequest is used to record a history replication task moved to the DLQ
+	PutReplicationDLQMessageRequest struct {
+		ReplicationDLQMessageKey
+		NextEventID int64
+		Version     int64
+	}
+
+	// DeleteReplicationDLQMessageRequest is used to forget a replication task which left the DLQ
+	DeleteReplicationDLQMessageRequest struct {
+		ReplicationDLQMessageKey
+	}
+
+	// GetReplicationDLQSummaryRequest is used to summarize the DLQ of the replication tasks of a history shard
+	// replicated from a source cluster
+	GetReplicationDLQSummaryRequest struct {
+		ShardID       int
+		SourceCluster string
+	}
+
+	// GetReplicationDLQSummaryResponse is the response to GetReplicationDLQSummary, OldestMessageTimestamp is the time
+	// the oldest message was moved to the DLQ, zero when the DLQ has no messages
+	GetReplicationDLQSummaryResponse struct {
+		MessageCount           int64
+		OldestMessageTimestamp time.Time
+	}
+
+	// ListDomainExecutionsRequest is used to page through the workflow executions of a domain in a shard, open and
+	// closed ones which are not deleted yet
+	ListDomainExecutionsRequest struct {
+		ShardID       int
+		DomainID      string
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// ListDomainExecutionsResponse is the response to ListDomainExecutions
+	ListDomainExecutionsResponse struct {
+		Executions    []workflow.WorkflowExecution
+		NextPageToken []byte
+	}
+
 	// CreateWorkflowExecutionRequest is used to write a new workflow execution
 	CreateWorkflowExecutionRequest struct {
 		RequestID                   string
@@ -554,10 +681,15 @@ type (
 		DecisionVersion             int64
 		DecisionScheduleID          int64
 		DecisionStartedID           int64
+		DecisionRequestID           string
 		DecisionStartToCloseTimeout int32
 		ContinueAsNew               bool
 		PreviousRunID               string
 		ReplicationState            *ReplicationState
+		Tags                        map[string]string
+		HistorySize                 int64
+		Priority                    int32
+		FirstRunID                  string
 	}
 
 	// CreateWorkflowExecutionResponse is the response to CreateWorkflowExecutionRequest
@@ -592,7 +724,7 @@ type (
 	// UpdateWorkflowExecutionRequest is used to update a workflow execution
 	UpdateWorkflowExecutionRequest struct {
 		ExecutionInfo        *WorkflowExecutionInfo
-		ReplicationState     *ReplicationState
+		ReplicationState     *ReplicationState // nil leaves the persisted replication state unchanged
 		TransferTasks        []Task
 		TimerTasks           []Task
 		ReplicationTasks     []Task
@@ -616,6 +748,7 @@ type (
 		DeleteSignalInfo              *int64
 		UpsertSignalRequestedIDs      []string
 		DeleteSignalRequestedID       string
+		UpsertUpdateInfos             []*UpdateInfo
 		NewBufferedEvents             *SerializedHistoryEventBatch
 		ClearBufferedEvents           bool
 		NewBufferedReplicationTask    *BufferedReplicationTask
@@ -636,6 +769,7 @@ type (
 		InsertRequestCancelInfos  []*RequestCancelInfo
 		InsertSignalInfos         []*SignalInfo
 		InsertSignalRequestedIDs  []string
+		InsertUpdateInfos         []*UpdateInfo
 	}
 
 	// DeleteWorkflowExecutionRequest is used to delete a workflow execution
@@ -645,6 +779,14 @@ type (
 		RunID      string
 	}
 
+	// DeleteCurrentWorkflowExecutionRequest is used to delete the current execution pointer of a workflow, it is only
+	// deleted while it points to the given run
+	DeleteCurrentWorkflowExecutionRequest struct {
+		DomainID   string
+		WorkflowID string
+		RunID      string
+	}
+
 	// GetTransferTasksRequest is used to read tasks from the transfer task queue
 	GetTransferTasksRequest struct {
 		ReadLevel     int64
@@ -661,10 +803,13 @@ type (
 
 	// GetReplicationTasksRequest is used to read tasks from the replication task queue
 	GetReplicationTasksRequest struct {
-		ReadLevel     int64
-		MaxReadLevel  int64
-		BatchSize     int
-		NextPageToken []byte
+		ReadLevel    int64
+		MaxReadLevel int64
+		BatchSize    int
+		// MaxBatchSizeBytes ends the page once the history size of its tasks reaches it, 0 means no limit.
+		// A page always holds at least one task.
+		MaxBatchSizeBytes int64
+		NextPageToken     []byte
 	}
 
 	// GetReplicationTasksResponse is the response to GetReplicationTask
@@ -683,6 +828,11 @@ type (
 		TaskID int64
 	}
 
+	// RangeCompleteReplicationTaskRequest is used to complete a range of tasks in the replication task queue
+	RangeCompleteReplicationTaskRequest struct {
+		InclusiveEndTaskID int64
+	}
+
 	// CompleteTimerTaskRequest is used to complete a task in the timer task queue
 	CompleteTimerTaskRequest struct {
 		VisibilityTimestamp time.Time
@@ -728,15 +878,16 @@ type (
 	CreateTasksResponse struct {
 	}
 
-	// GetTasksRequest is used to retrieve tasks of a task list
+	// GetTasksRequest is used to retrieve the tasks of a priority level of a task list
 	GetTasksRequest struct {
-		DomainID     string
-		TaskList     string
-		TaskType     int
-		ReadLevel    int64
-		MaxReadLevel int64 // inclusive
-		BatchSize    int
-		RangeID      int64
+		DomainID      string
+		TaskList      string
+		TaskType      int
+		PriorityLevel int
+		ReadLevel     int64
+		MaxReadLevel  int64 // inclusive
+		BatchSize     int
+		RangeID       int64
 	}
 
 	// GetTasksResponse is the response to GetTasksRequests
@@ -744,8 +895,50 @@ type (
 		Tasks []*TaskInfo
 	}
 
-	// CompleteTaskRequest is used to complete a task
+	// ListTaskListRequest is used to list the task lists of a domain
+	ListTaskListRequest struct {
+		DomainID      string
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// ListTaskListResponse is the response to ListTaskList. The task lists of a domain are indexed when they are
+	// leased, a page can hold less than PageSize items when indexed task lists expired.
+	ListTaskListResponse struct {
+		Items         []*TaskListInfo
+		NextPageToken []byte
+	}
+
+	// CompleteTaskRequest is used to complete a task of the given priority level
 	CompleteTaskRequest struct {
+		TaskList      *TaskListInfo
+		PriorityLevel int
+		TaskID        int64
+	}
+
+	// CreateDLQTaskRequest is used to move a task which repeatedly failed to dispatch to the DLQ of its task list
+	CreateDLQTaskRequest struct {
+		TaskList *TaskListInfo
+		Task     *TaskInfo
+	}
+
+	// GetDLQTasksRequest is used to list the DLQ tasks of a task list
+	GetDLQTasksRequest struct {
+		DomainID      string
+		TaskList      string
+		TaskType      int
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// GetDLQTasksResponse is the response to GetDLQTasks
+	GetDLQTasksResponse struct {
+		Tasks         []*TaskInfo
+		NextPageToken []byte
+	}
+
+	// DeleteDLQTaskRequest is used to delete a task from the DLQ of its task list
+	DeleteDLQTaskRequest struct {
 		TaskList *TaskListInfo
 		TaskID   int64
 	}
@@ -757,6 +950,9 @@ type (
 		MaxTimestamp  time.Time
 		BatchSize     int
 		NextPageToken []byte
+
+		// ctx cancels the read once it lost a hedged read, see hedgedReader
+		ctx context.Context
 	}
 
 	// GetTimerIndexTasksResponse is the response for GetTimerIndexTasks
@@ -801,6 +997,9 @@ type (
 		PageSize int
 		// Token to continue reading next page of history append transactions.  Pass in empty slice for first page
 		NextPageToken []byte
+
+		// ctx cancels the read once it lost a hedged read, see hedgedReader
+		ctx context.Context
 	}
 
 	// GetWorkflowExecutionHistoryResponse is the response to GetWorkflowExecutionHistoryRequest
@@ -818,6 +1017,51 @@ type (
 		Execution workflow.WorkflowExecution
 	}
 
+	// ListHistoryBatchesRequest is used to page through the history event batches of a workflow execution
+	ListHistoryBatchesRequest struct {
+		DomainID      string
+		Execution     workflow.WorkflowExecution
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// ListHistoryBatchesResponse is the response to ListHistoryBatchesRequest
+	ListHistoryBatchesResponse struct {
+		Batches       []*HistoryBatch
+		NextPageToken []byte
+	}
+
+	// HistoryBatch is a persisted history event batch of a workflow execution
+	HistoryBatch struct {
+		Execution     workflow.WorkflowExecution
+		FirstEventID  int64
+		TransactionID int64
+		Events        *SerializedHistoryEventBatch
+	}
+
+	// ReencodeHistoryBatchRequest is used to replace the serialized events of a history batch with the same events
+	// in another encoding. The batch is only replaced if it was not written again since it was read.
+	ReencodeHistoryBatchRequest struct {
+		DomainID      string
+		Execution     workflow.WorkflowExecution
+		FirstEventID  int64
+		TransactionID int64
+		// the encoding and version of the batch when it was read
+		PreviousEncodingType common.EncodingType
+		PreviousVersion      int
+		Events               *SerializedHistoryEventBatch
+	}
+
+	// DeleteHistoryBatchRequest is used to delete a history batch written by a shard owner which lost the shard
+	// before the batch was referenced by the mutable state. The batch is only deleted if it was not written again.
+	DeleteHistoryBatchRequest struct {
+		DomainID      string
+		Execution     workflow.WorkflowExecution
+		FirstEventID  int64
+		RangeID       int64
+		TransactionID int64
+	}
+
 	// DomainInfo describes the domain entity
 	DomainInfo struct {
 		ID          string
@@ -833,12 +1077,21 @@ type (
 		// NOTE: this retention is in days, not in seconds
 		Retention  int32
 		EmitMetric bool
+		// HistoryEncoding is the encoding new history batches of the domain are written in,
+		// empty means DefaultEncodingType
+		HistoryEncoding common.EncodingType
+		// SuspectBinaryChecksums are the binaries of workers repeatedly failing decisions with
+		// non-determinism errors, workflows are not kept sticky on their workers
+		SuspectBinaryChecksums []string
 	}
 
 	// DomainReplicationConfig describes the cross DC domain replication configuration
 	DomainReplicationConfig struct {
 		ActiveClusterName string
 		Clusters          []*ClusterReplicationConfig
+		// FailoverDrillClusterName is the standby cluster verifying it could take over the domain,
+		// empty when no failover drill is in progress
+		FailoverDrillClusterName string
 	}
 
 	// ClusterReplicationConfig describes the cross DC cluster replication configuration
@@ -906,6 +1159,8 @@ type (
 	ListDomainsRequest struct {
 		PageSize      int
 		NextPageToken []byte
+		// IncludeV1Domains lists the domains of the v1 table as well, only the metadata manager proxy can do that
+		IncludeV1Domains bool
 	}
 
 	// ListDomainsResponse is the response for GetDomain
@@ -930,6 +1185,12 @@ type (
 		CreateShard(request *CreateShardRequest) error
 		GetShard(request *GetShardRequest) (*GetShardResponse, error)
 		UpdateShard(request *UpdateShardRequest) error
+		PutReplicationDLQMessage(request *PutReplicationDLQMessageRequest) error
+		DeleteReplicationDLQMessage(request *DeleteReplicationDLQMessageRequest) error
+		GetReplicationDLQSummary(request *GetReplicationDLQSummaryRequest) (*GetReplicationDLQSummaryResponse, error)
+		// ListDomainExecutions pages through the workflow executions of a domain in a shard, it is only meant for
+		// background jobs
+		ListDomainExecutions(request *ListDomainExecutionsRequest) (*ListDomainExecutionsResponse, error)
 	}
 
 	// ExecutionManager is used to manage workflow executions
@@ -940,6 +1201,7 @@ type (
 		UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) error
 		ResetMutableState(request *ResetMutableStateRequest) error
 		DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error
+		DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error
 		GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
 		GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
 		CompleteTransferTask(request *CompleteTransferTaskRequest) error
@@ -947,6 +1209,7 @@ type (
 		// Replication task related methods
 		GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error)
 		CompleteReplicationTask(request *CompleteReplicationTaskRequest) error
+		RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error
 
 		// Timer related methods.
 		GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
@@ -967,6 +1230,10 @@ type (
 		CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error)
 		GetTasks(request *GetTasksRequest) (*GetTasksResponse, error)
 		CompleteTask(request *CompleteTaskRequest) error
+		ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error)
+		CreateDLQTask(request *CreateDLQTaskRequest) error
+		GetDLQTasks(request *GetDLQTasksRequest) (*GetDLQTasksResponse, error)
+		DeleteDLQTask(request *DeleteDLQTaskRequest) error
 	}
 
 	// HistoryManager is used to manage Workflow Execution HistoryEventBatch
@@ -977,6 +1244,11 @@ type (
 		GetWorkflowExecutionHistory(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse,
 			error)
 		DeleteWorkflowExecutionHistory(request *DeleteWorkflowExecutionHistoryRequest) error
+		// ListHistoryBatches pages through the history event batches of a workflow execution, with their transaction
+		// IDs, it is only meant for background jobs
+		ListHistoryBatches(request *ListHistoryBatchesRequest) (*ListHistoryBatchesResponse, error)
+		ReencodeHistoryBatch(request *ReencodeHistoryBatchRequest) error
+		DeleteHistoryBatch(request *DeleteHistoryBatchRequest) error
 	}
 
 	// MetadataManager is used to manage metadata CRUD for domain entities
@@ -1012,6 +1284,10 @@ func (e *TimeoutError) Error() string {
 	return e.Msg
 }
 
+func (e *HistoryEventsCorruptedError) Error() string {
+	return e.Msg
+}
+
 // GetType returns the type of the activity task
 func (a *ActivityTask) GetType() int {
 	return TransferTaskTypeActivityTask
@@ -1467,6 +1743,41 @@ func (a *HistoryReplicationTask) SetVisibilityTimestamp(timestamp time.Time) {
 	a.VisibilityTimestamp = timestamp
 }
 
+// GetType returns the type of the sync activity replication task
+func (a *SyncActivityTask) GetType() int {
+	return ReplicationTaskTypeSyncActivity
+}
+
+// GetVersion returns the version of the sync activity replication task
+func (a *SyncActivityTask) GetVersion() int64 {
+	return a.Version
+}
+
+// SetVersion returns the version of the sync activity replication task
+func (a *SyncActivityTask) SetVersion(version int64) {
+	a.Version = version
+}
+
+// GetTaskID returns the sequence ID of the sync activity replication task
+func (a *SyncActivityTask) GetTaskID() int64 {
+	return a.TaskID
+}
+
+// SetTaskID sets the sequence ID of the sync activity replication task
+func (a *SyncActivityTask) SetTaskID(id int64) {
+	a.TaskID = id
+}
+
+// GetVisibilityTimestamp get the visibility timestamp
+func (a *SyncActivityTask) GetVisibilityTimestamp() time.Time {
+	return a.VisibilityTimestamp
+}
+
+// SetVisibilityTimestamp set the visibility timestamp
+func (a *SyncActivityTask) SetVisibilityTimestamp(timestamp time.Time) {
+	a.VisibilityTimestamp = timestamp
+}
+
 // GetTaskID returns the task ID for transfer task
 func (t *TransferTaskInfo) GetTaskID() int64 {
 	return t.TaskID
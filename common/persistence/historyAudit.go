@@ -0,0 +1,53 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+// AuditHistoryEvents verifies that the event IDs of events follow one another without gaps and that their versions
+// never decrease, starting from the event with previousEventID and previousVersion. Passing common.EmptyEventID as
+// previousEventID audits the events only against each other. It returns the ID and version of the last event, so the
+// audit can be carried over to the next batch or page of the same history.
+func AuditHistoryEvents(events []*workflow.HistoryEvent, previousEventID, previousVersion int64) (int64, int64, error) {
+	for _, event := range events {
+		if previousEventID != common.EmptyEventID {
+			if event.GetEventId() != previousEventID+1 {
+				return previousEventID, previousVersion, &HistoryEventsCorruptedError{
+					Msg: fmt.Sprintf("history event %v follows event %v", event.GetEventId(), previousEventID),
+				}
+			}
+			if event.GetVersion() < previousVersion {
+				return previousEventID, previousVersion, &HistoryEventsCorruptedError{
+					Msg: fmt.Sprintf("history event %v has version %v lower than version %v of event %v",
+						event.GetEventId(), event.GetVersion(), previousVersion, previousEventID),
+				}
+			}
+		}
+		previousEventID = event.GetEventId()
+		previousVersion = event.GetVersion()
+	}
+	return previousEventID, previousVersion, nil
+}
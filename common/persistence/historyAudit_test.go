@@ -0,0 +1,85 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+func auditTestEvents(idsAndVersions ...int64) []*workflow.HistoryEvent {
+	var events []*workflow.HistoryEvent
+	for i := 0; i < len(idsAndVersions); i += 2 {
+		events = append(events, &workflow.HistoryEvent{
+			EventId: common.Int64Ptr(idsAndVersions[i]),
+			Version: common.Int64Ptr(idsAndVersions[i+1]),
+		})
+	}
+	return events
+}
+
+func TestAuditHistoryEvents(t *testing.T) {
+	lastEventID, lastVersion, err := AuditHistoryEvents(auditTestEvents(1, 0, 2, 0, 3, 5), 0, common.EmptyVersion)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), lastEventID)
+	require.Equal(t, int64(5), lastVersion)
+
+	// carried over from the previous page
+	lastEventID, lastVersion, err = AuditHistoryEvents(auditTestEvents(4, 5, 5, 7), lastEventID, lastVersion)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), lastEventID)
+	require.Equal(t, int64(7), lastVersion)
+
+	// no events keep the previous event
+	lastEventID, lastVersion, err = AuditHistoryEvents(nil, lastEventID, lastVersion)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), lastEventID)
+	require.Equal(t, int64(7), lastVersion)
+
+	// unknown start only audits the events against each other
+	lastEventID, _, err = AuditHistoryEvents(auditTestEvents(10, 1, 11, 1), common.EmptyEventID, common.EmptyVersion)
+	require.NoError(t, err)
+	require.Equal(t, int64(11), lastEventID)
+}
+
+func TestAuditHistoryEventsCorrupted(t *testing.T) {
+	// gap
+	lastEventID, _, err := AuditHistoryEvents(auditTestEvents(1, 0, 2, 0, 4, 0), 0, common.EmptyVersion)
+	require.IsType(t, &HistoryEventsCorruptedError{}, err)
+	require.Equal(t, int64(2), lastEventID)
+
+	// repeated
+	_, _, err = AuditHistoryEvents(auditTestEvents(1, 0, 2, 0, 2, 0), 0, common.EmptyVersion)
+	require.IsType(t, &HistoryEventsCorruptedError{}, err)
+
+	// gap at the page boundary
+	_, _, err = AuditHistoryEvents(auditTestEvents(6, 0), 4, 0)
+	require.IsType(t, &HistoryEventsCorruptedError{}, err)
+
+	// version going back
+	_, _, err = AuditHistoryEvents(auditTestEvents(1, 3, 2, 3, 3, 2), 0, common.EmptyVersion)
+	require.IsType(t, &HistoryEventsCorruptedError{}, err)
+	_, _, err = AuditHistoryEvents(auditTestEvents(4, 2), 3, 3)
+	require.IsType(t, &HistoryEventsCorruptedError{}, err)
+}
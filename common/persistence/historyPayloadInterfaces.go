@@ -0,0 +1,62 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import workflow "github.com/uber/cadence/.gen/go/shared"
+
+// Interfaces for the History Payload Store.
+// Large payloads of the history events of a run are stored once under their hash, and the events carry a
+// reference to them instead, see NewHistoryPersistencePayloadDedupClient.
+
+type (
+	// PutHistoryPayloadsRequest is used to store payloads of the history of a run, keyed by their hash
+	PutHistoryPayloadsRequest struct {
+		DomainID  string
+		Execution workflow.WorkflowExecution
+		Payloads  map[string][]byte
+	}
+
+	// GetHistoryPayloadsRequest is used to read payloads of the history of a run by their hash
+	GetHistoryPayloadsRequest struct {
+		DomainID  string
+		Execution workflow.WorkflowExecution
+		Hashes    []string
+	}
+
+	// GetHistoryPayloadsResponse is the response to GetHistoryPayloadsRequest, hashes without payload are left out
+	GetHistoryPayloadsResponse struct {
+		Payloads map[string][]byte
+	}
+
+	// DeleteHistoryPayloadsRequest is used to delete all the payloads of the history of a run
+	DeleteHistoryPayloadsRequest struct {
+		DomainID  string
+		Execution workflow.WorkflowExecution
+	}
+
+	// HistoryPayloadManager is used to manage the history payload store
+	HistoryPayloadManager interface {
+		Closeable
+		PutHistoryPayloads(request *PutHistoryPayloadsRequest) error
+		GetHistoryPayloads(request *GetHistoryPayloadsRequest) (*GetHistoryPayloadsResponse, error)
+		DeleteHistoryPayloads(request *DeleteHistoryPayloadsRequest) error
+	}
+)
@@ -21,10 +21,14 @@
 package persistence
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/compression"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
 	"sync/atomic"
 )
 
@@ -73,8 +77,19 @@ type (
 
 	jsonHistorySerializer struct{}
 
+	thriftRWHistorySerializer struct{}
+
+	// gzipHistorySerializer compresses the batches encoded by another serializer
+	gzipHistorySerializer struct {
+		serializer   HistorySerializer
+		encodingType common.EncodingType
+	}
+
 	serializerFactoryImpl struct {
-		jsonSerializer HistorySerializer
+		jsonSerializer         HistorySerializer
+		thriftRWSerializer     HistorySerializer
+		jsonGzipSerializer     HistorySerializer
+		thriftRWGzipSerializer HistorySerializer
 	}
 )
 
@@ -120,11 +135,92 @@ func (j *jsonHistorySerializer) Deserialize(batch *SerializedHistoryEventBatch)
 	return &HistoryEventBatch{Version: batch.Version, Events: events}, nil
 }
 
+// NewThriftRWHistorySerializer returns a HistorySerializer which encodes
+// event batches with the thriftrw binary protocol
+func NewThriftRWHistorySerializer() HistorySerializer {
+	return &thriftRWHistorySerializer{}
+}
+
+func (t *thriftRWHistorySerializer) Serialize(batch *HistoryEventBatch) (*SerializedHistoryEventBatch, error) {
+
+	if batch.Version > GetMaxSupportedHistoryVersion() {
+		err := NewHistoryVersionCompatibilityError(batch.Version, GetMaxSupportedHistoryVersion())
+		return nil, &HistorySerializationError{msg: err.Error()}
+	}
+
+	history := &workflow.History{Events: batch.Events}
+	value, err := history.ToWire()
+	if err != nil {
+		return nil, &HistorySerializationError{msg: err.Error()}
+	}
+
+	var buf bytes.Buffer
+	if err := protocol.Binary.Encode(value, &buf); err != nil {
+		return nil, &HistorySerializationError{msg: err.Error()}
+	}
+	return NewSerializedHistoryEventBatch(buf.Bytes(), common.EncodingTypeThriftRW, batch.Version), nil
+}
+
+func (t *thriftRWHistorySerializer) Deserialize(batch *SerializedHistoryEventBatch) (*HistoryEventBatch, error) {
+
+	if batch.Version > GetMaxSupportedHistoryVersion() {
+		err := NewHistoryVersionCompatibilityError(batch.Version, GetMaxSupportedHistoryVersion())
+		return nil, &HistoryDeserializationError{msg: err.Error()}
+	}
+
+	value, err := protocol.Binary.Decode(bytes.NewReader(batch.Data), wire.TStruct)
+	if err != nil {
+		return nil, &HistoryDeserializationError{msg: err.Error()}
+	}
+
+	var history workflow.History
+	if err := history.FromWire(value); err != nil {
+		return nil, &HistoryDeserializationError{msg: err.Error()}
+	}
+	return &HistoryEventBatch{Version: batch.Version, Events: history.Events}, nil
+}
+
+// NewGzipHistorySerializer returns a HistorySerializer which gzip compresses
+// the batches encoded by the given serializer
+func NewGzipHistorySerializer(serializer HistorySerializer, encodingType common.EncodingType) HistorySerializer {
+	return &gzipHistorySerializer{serializer: serializer, encodingType: encodingType}
+}
+
+func (g *gzipHistorySerializer) Serialize(batch *HistoryEventBatch) (*SerializedHistoryEventBatch, error) {
+	serialized, err := g.serializer.Serialize(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := compression.Compress(serialized.Data)
+	if err != nil {
+		return nil, &HistorySerializationError{msg: err.Error()}
+	}
+	return NewSerializedHistoryEventBatch(data, g.encodingType, serialized.Version), nil
+}
+
+func (g *gzipHistorySerializer) Deserialize(batch *SerializedHistoryEventBatch) (*HistoryEventBatch, error) {
+	data, err := compression.Decompress(batch.Data)
+	if err != nil {
+		return nil, &HistoryDeserializationError{msg: err.Error()}
+	}
+	return g.serializer.Deserialize(&SerializedHistoryEventBatch{
+		EncodingType: batch.EncodingType,
+		Version:      batch.Version,
+		Data:         data,
+	})
+}
+
 // NewHistorySerializerFactory creates and returns an instance
 // of HistorySerializerFactory
 func NewHistorySerializerFactory() HistorySerializerFactory {
+	jsonSerializer := NewJSONHistorySerializer()
+	thriftRWSerializer := NewThriftRWHistorySerializer()
 	return &serializerFactoryImpl{
-		jsonSerializer: NewJSONHistorySerializer(),
+		jsonSerializer:         jsonSerializer,
+		thriftRWSerializer:     thriftRWSerializer,
+		jsonGzipSerializer:     NewGzipHistorySerializer(jsonSerializer, common.EncodingTypeJSONGzip),
+		thriftRWGzipSerializer: NewGzipHistorySerializer(thriftRWSerializer, common.EncodingTypeThriftRWGzip),
 	}
 }
 
@@ -133,6 +229,12 @@ func (f *serializerFactoryImpl) Get(encodingType common.EncodingType) (HistorySe
 	switch encodingType {
 	case common.EncodingTypeJSON:
 		return f.jsonSerializer, nil
+	case common.EncodingTypeThriftRW:
+		return f.thriftRWSerializer, nil
+	case common.EncodingTypeJSONGzip:
+		return f.jsonGzipSerializer, nil
+	case common.EncodingTypeThriftRWGzip:
+		return f.thriftRWGzipSerializer, nil
 	default:
 		return nil, NewUnknownEncodingTypeError(encodingType)
 	}
@@ -134,3 +134,85 @@ func (s *historySerializerSuite) TestSerializerFactory() {
 	succ := common.AwaitWaitGroup(&doneWG, 10*time.Second)
 	s.True(succ, "test timed out")
 }
+
+func (s *historySerializerSuite) TestThriftRWSerializer() {
+	factory := NewHistorySerializerFactory()
+
+	event1 := &workflow.HistoryEvent{
+		EventId:   common.Int64Ptr(999),
+		Timestamp: common.Int64Ptr(time.Now().UnixNano()),
+		EventType: common.EventTypePtr(workflow.EventTypeActivityTaskCompleted),
+		ActivityTaskCompletedEventAttributes: &workflow.ActivityTaskCompletedEventAttributes{
+			Result:           []byte("result-1-event-1"),
+			ScheduledEventId: common.Int64Ptr(4),
+			StartedEventId:   common.Int64Ptr(5),
+			Identity:         common.StringPtr("event-1"),
+		},
+	}
+
+	serializer, err := factory.Get(common.EncodingTypeThriftRW)
+	s.Nil(err)
+	_, ok := serializer.(*thriftRWHistorySerializer)
+	s.True(ok)
+
+	sh, err := serializer.Serialize(NewHistoryEventBatch(1, []*workflow.HistoryEvent{event1}))
+	s.Nil(err)
+	s.Equal(1, sh.Version)
+	s.Equal(common.EncodingTypeThriftRW, sh.EncodingType)
+
+	// the serialized batch is not json, so reading it back has to go through the factory
+	jsonSerializer, err := factory.Get(common.EncodingTypeJSON)
+	s.Nil(err)
+	_, err = jsonSerializer.Deserialize(sh)
+	s.NotNil(err)
+
+	dh, err := serializer.Deserialize(sh)
+	s.Nil(err)
+	s.Equal(1, dh.Version)
+	s.Equal(1, len(dh.Events))
+	s.Equal(event1, dh.Events[0])
+
+	sh.Version = GetMaxSupportedHistoryVersion() + 1
+	_, err = serializer.Deserialize(sh)
+	s.NotNil(err)
+	_, ok = err.(*HistoryDeserializationError)
+	s.True(ok)
+}
+
+func (s *historySerializerSuite) TestGzipSerializers() {
+	factory := NewHistorySerializerFactory()
+
+	event1 := &workflow.HistoryEvent{
+		EventId:   common.Int64Ptr(999),
+		Timestamp: common.Int64Ptr(time.Now().UnixNano()),
+		EventType: common.EventTypePtr(workflow.EventTypeActivityTaskCompleted),
+		ActivityTaskCompletedEventAttributes: &workflow.ActivityTaskCompletedEventAttributes{
+			Result:           []byte("result-1-event-1"),
+			ScheduledEventId: common.Int64Ptr(4),
+			StartedEventId:   common.Int64Ptr(5),
+			Identity:         common.StringPtr("event-1"),
+		},
+	}
+
+	for _, encodingType := range []common.EncodingType{common.EncodingTypeJSONGzip, common.EncodingTypeThriftRWGzip} {
+		serializer, err := factory.Get(encodingType)
+		s.Nil(err)
+
+		sh, err := serializer.Serialize(NewHistoryEventBatch(1, []*workflow.HistoryEvent{event1}))
+		s.Nil(err)
+		s.Equal(1, sh.Version)
+		s.Equal(encodingType, sh.EncodingType)
+
+		dh, err := serializer.Deserialize(sh)
+		s.Nil(err)
+		s.Equal(1, dh.Version)
+		s.Equal(1, len(dh.Events))
+		s.Equal(event1, dh.Events[0])
+
+		sh.Data = []byte("not gzip")
+		_, err = serializer.Deserialize(sh)
+		s.NotNil(err)
+		_, ok := err.(*HistoryDeserializationError)
+		s.True(ok)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/uber-common/bark"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+type (
+	inMemoryHistoryPersistence struct {
+		sync.Mutex
+		// execution -> first event ID of the batch -> batch
+		histories map[inMemoryRunKey]map[int64]*inMemoryHistoryBatch
+		logger    bark.Logger
+	}
+
+	inMemoryHistoryBatch struct {
+		rangeID       int64
+		transactionID int64
+		events        *SerializedHistoryEventBatch
+	}
+)
+
+var _ HistoryManager = (*inMemoryHistoryPersistence)(nil)
+
+// NewInMemoryHistoryPersistence creates a history manager keeping the histories of a cluster in memory
+func NewInMemoryHistoryPersistence(logger bark.Logger) HistoryManager {
+	return &inMemoryHistoryPersistence{
+		histories: make(map[inMemoryRunKey]map[int64]*inMemoryHistoryBatch),
+		logger:    logger,
+	}
+}
+
+func (h *inMemoryHistoryPersistence) Close() {
+}
+
+func (h *inMemoryHistoryPersistence) AppendHistoryEvents(request *AppendHistoryEventsRequest) error {
+	h.Lock()
+	defer h.Unlock()
+
+	key := inMemoryRunKey{request.DomainID, request.Execution.GetWorkflowId(), request.Execution.GetRunId()}
+	history, ok := h.histories[key]
+	if !ok {
+		history = make(map[int64]*inMemoryHistoryBatch)
+		h.histories[key] = history
+	}
+
+	// a batch is only overwritten by a shard owner with a range at least as recent and a later transaction
+	batch, ok := history[request.FirstEventID]
+	applied := !ok
+	if request.Overwrite {
+		applied = ok && batch.rangeID <= request.RangeID && batch.transactionID < request.TransactionID
+	}
+	if !applied {
+		return &ConditionFailedError{
+			Msg: "Failed to append history events.",
+		}
+	}
+
+	history[request.FirstEventID] = &inMemoryHistoryBatch{
+		rangeID:       request.RangeID,
+		transactionID: request.TransactionID,
+		events:        cloneSerializedHistoryEventBatch(request.Events),
+	}
+	return nil
+}
+
+// GetWorkflowExecutionHistory pages by first event ID, the NextPageToken is the first event ID of the next page
+func (h *inMemoryHistoryPersistence) GetWorkflowExecutionHistory(request *GetWorkflowExecutionHistoryRequest) (
+	*GetWorkflowExecutionHistoryResponse, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	execution := request.Execution
+	firstEventID := request.FirstEventID
+	if len(request.NextPageToken) > 0 {
+		if len(request.NextPageToken) != 8 {
+			return nil, &workflow.BadRequestError{
+				Message: "GetWorkflowExecutionHistory operation failed.  Invalid next page token.",
+			}
+		}
+		firstEventID = int64(binary.BigEndian.Uint64(request.NextPageToken))
+	}
+
+	key := inMemoryRunKey{request.DomainID, execution.GetWorkflowId(), execution.GetRunId()}
+	history := h.histories[key]
+	firstEventIDs := h.getFirstEventIDs(history, firstEventID, request.NextEventID)
+
+	response := &GetWorkflowExecutionHistoryResponse{}
+	for _, id := range firstEventIDs {
+		if request.PageSize > 0 && len(response.Events) == request.PageSize {
+			response.NextPageToken = make([]byte, 8)
+			binary.BigEndian.PutUint64(response.NextPageToken, uint64(id))
+			break
+		}
+		response.Events = append(response.Events, *cloneSerializedHistoryEventBatch(history[id].events))
+	}
+
+	if len(response.Events) == 0 && len(request.NextPageToken) == 0 {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Workflow execution history not found.  WorkflowId: %v, RunId: %v",
+				execution.GetWorkflowId(), execution.GetRunId()),
+		}
+	}
+
+	return response, nil
+}
+
+func (h *inMemoryHistoryPersistence) DeleteWorkflowExecutionHistory(
+	request *DeleteWorkflowExecutionHistoryRequest) error {
+	h.Lock()
+	defer h.Unlock()
+
+	execution := request.Execution
+	delete(h.histories, inMemoryRunKey{request.DomainID, execution.GetWorkflowId(), execution.GetRunId()})
+	return nil
+}
+
+func (h *inMemoryHistoryPersistence) ListHistoryBatches(request *ListHistoryBatchesRequest) (
+	*ListHistoryBatchesResponse, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	execution := request.Execution
+	history := h.histories[inMemoryRunKey{request.DomainID, execution.GetWorkflowId(), execution.GetRunId()}]
+	firstEventIDs := h.getFirstEventIDs(history, -1<<63, 1<<63-1)
+
+	start, end, nextPageToken, err := getInMemoryPage(len(firstEventIDs), request.PageSize, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	response := &ListHistoryBatchesResponse{NextPageToken: nextPageToken}
+	for _, id := range firstEventIDs[start:end] {
+		response.Batches = append(response.Batches, &HistoryBatch{
+			Execution:     request.Execution,
+			FirstEventID:  id,
+			TransactionID: history[id].transactionID,
+			Events:        cloneSerializedHistoryEventBatch(history[id].events),
+		})
+	}
+	return response, nil
+}
+
+func (h *inMemoryHistoryPersistence) ReencodeHistoryBatch(request *ReencodeHistoryBatchRequest) error {
+	h.Lock()
+	defer h.Unlock()
+
+	execution := request.Execution
+	history := h.histories[inMemoryRunKey{request.DomainID, execution.GetWorkflowId(), execution.GetRunId()}]
+	batch, ok := history[request.FirstEventID]
+	if !ok || batch.transactionID != request.TransactionID ||
+		batch.events.EncodingType != request.PreviousEncodingType || batch.events.Version != request.PreviousVersion {
+		return &ConditionFailedError{
+			Msg: "Failed to reencode history batch, it was written again since it was read.",
+		}
+	}
+
+	batch.events = cloneSerializedHistoryEventBatch(request.Events)
+	return nil
+}
+
+func (h *inMemoryHistoryPersistence) DeleteHistoryBatch(request *DeleteHistoryBatchRequest) error {
+	h.Lock()
+	defer h.Unlock()
+
+	execution := request.Execution
+	history := h.histories[inMemoryRunKey{request.DomainID, execution.GetWorkflowId(), execution.GetRunId()}]
+	batch, ok := history[request.FirstEventID]
+	if !ok || batch.rangeID != request.RangeID || batch.transactionID != request.TransactionID {
+		return &ConditionFailedError{
+			Msg: "Failed to delete history batch, it was written again by another shard owner.",
+		}
+	}
+
+	delete(history, request.FirstEventID)
+	return nil
+}
+
+// getFirstEventIDs returns the sorted first event IDs of the batches of a history within [min, max)
+func (h *inMemoryHistoryPersistence) getFirstEventIDs(history map[int64]*inMemoryHistoryBatch, min,
+	max int64) []int64 {
+	var firstEventIDs []int64
+	for id := range history {
+		if id >= min && id < max {
+			firstEventIDs = append(firstEventIDs, id)
+		}
+	}
+	sort.Slice(firstEventIDs, func(i, j int) bool { return firstEventIDs[i] < firstEventIDs[j] })
+	return firstEventIDs
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/uber-common/bark"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+type (
+	// inMemoryMetadataPersistence keeps the domains as the v2 Cassandra metadata persistence does, with a
+	// notification version bumped on every write
+	inMemoryMetadataPersistence struct {
+		sync.Mutex
+		currentClusterName  string
+		domains             map[string]*GetDomainResponse // domain ID -> domain
+		domainIDsByName     map[string]string
+		notificationVersion int64
+		logger              bark.Logger
+	}
+)
+
+var _ MetadataManager = (*inMemoryMetadataPersistence)(nil)
+
+// NewInMemoryMetadataPersistence creates a metadata manager keeping the domains of a cluster in memory
+func NewInMemoryMetadataPersistence(currentClusterName string, logger bark.Logger) MetadataManager {
+	return &inMemoryMetadataPersistence{
+		currentClusterName: currentClusterName,
+		domains:            make(map[string]*GetDomainResponse),
+		domainIDsByName:    make(map[string]string),
+		logger:             logger,
+	}
+}
+
+func (m *inMemoryMetadataPersistence) Close() {
+}
+
+func (m *inMemoryMetadataPersistence) CreateDomain(request *CreateDomainRequest) (*CreateDomainResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	if _, ok := m.domains[request.Info.ID]; ok {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CreateDomain operation failed because of uuid collision."),
+		}
+	}
+	if domainID, ok := m.domainIDsByName[request.Info.Name]; ok {
+		return nil, &workflow.DomainAlreadyExistsError{
+			Message: fmt.Sprintf("Domain already exists.  DomainId: %v", domainID),
+		}
+	}
+
+	m.domains[request.Info.ID] = cloneDomain(&GetDomainResponse{
+		Info:                        request.Info,
+		Config:                      request.Config,
+		ReplicationConfig:           request.ReplicationConfig,
+		IsGlobalDomain:              request.IsGlobalDomain,
+		ConfigVersion:               request.ConfigVersion,
+		FailoverVersion:             request.FailoverVersion,
+		FailoverNotificationVersion: initialFailoverNotificationVersion,
+		NotificationVersion:         m.notificationVersion,
+		TableVersion:                DomainTableVersionV2,
+	})
+	m.domainIDsByName[request.Info.Name] = request.Info.ID
+	m.notificationVersion++
+
+	return &CreateDomainResponse{ID: request.Info.ID}, nil
+}
+
+func (m *inMemoryMetadataPersistence) GetDomain(request *GetDomainRequest) (*GetDomainResponse, error) {
+	if len(request.ID) > 0 && len(request.Name) > 0 {
+		return nil, &workflow.BadRequestError{
+			Message: "GetDomain operation failed.  Both ID and Name specified in request.",
+		}
+	} else if len(request.ID) == 0 && len(request.Name) == 0 {
+		return nil, &workflow.BadRequestError{
+			Message: "GetDomain operation failed.  Both ID and Name are empty.",
+		}
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	identity := request.ID
+	domainID := request.ID
+	if len(request.Name) > 0 {
+		identity = request.Name
+		domainID = m.domainIDsByName[request.Name]
+	}
+	domain, ok := m.domains[domainID]
+	if !ok {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Domain %s does not exist.", identity),
+		}
+	}
+
+	return m.toDomainResponse(domain), nil
+}
+
+func (m *inMemoryMetadataPersistence) UpdateDomain(request *UpdateDomainRequest) error {
+	m.Lock()
+	defer m.Unlock()
+
+	domain, ok := m.domains[request.Info.ID]
+	if !ok || request.NotificationVersion != m.notificationVersion {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateDomain operation failed because of conditional failure."),
+		}
+	}
+
+	m.domains[request.Info.ID] = cloneDomain(&GetDomainResponse{
+		Info:                        request.Info,
+		Config:                      request.Config,
+		ReplicationConfig:           request.ReplicationConfig,
+		IsGlobalDomain:              domain.IsGlobalDomain,
+		ConfigVersion:               request.ConfigVersion,
+		FailoverVersion:             request.FailoverVersion,
+		FailoverNotificationVersion: request.FailoverNotificationVersion,
+		NotificationVersion:         request.NotificationVersion,
+		TableVersion:                DomainTableVersionV2,
+	})
+	m.notificationVersion++
+
+	return nil
+}
+
+func (m *inMemoryMetadataPersistence) DeleteDomain(request *DeleteDomainRequest) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if domain, ok := m.domains[request.ID]; ok {
+		delete(m.domainIDsByName, domain.Info.Name)
+		delete(m.domains, request.ID)
+	}
+	return nil
+}
+
+func (m *inMemoryMetadataPersistence) DeleteDomainByName(request *DeleteDomainByNameRequest) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if domainID, ok := m.domainIDsByName[request.Name]; ok {
+		delete(m.domains, domainID)
+		delete(m.domainIDsByName, request.Name)
+	}
+	return nil
+}
+
+func (m *inMemoryMetadataPersistence) ListDomains(request *ListDomainsRequest) (*ListDomainsResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	var names []string
+	for name := range m.domainIDsByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	start, end, nextPageToken, err := getInMemoryPage(len(names), request.PageSize, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	response := &ListDomainsResponse{NextPageToken: nextPageToken}
+	for _, name := range names[start:end] {
+		response.Domains = append(response.Domains, m.toDomainResponse(m.domains[m.domainIDsByName[name]]))
+	}
+	return response, nil
+}
+
+func (m *inMemoryMetadataPersistence) GetMetadata() (*GetMetadataResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	return &GetMetadataResponse{NotificationVersion: m.notificationVersion}, nil
+}
+
+func (m *inMemoryMetadataPersistence) toDomainResponse(domain *GetDomainResponse) *GetDomainResponse {
+	response := cloneDomain(domain)
+	response.ReplicationConfig.ActiveClusterName = GetOrUseDefaultActiveCluster(m.currentClusterName,
+		response.ReplicationConfig.ActiveClusterName)
+	response.ReplicationConfig.Clusters = GetOrUseDefaultClusters(m.currentClusterName,
+		response.ReplicationConfig.Clusters)
+	return response
+}
+
+func cloneDomain(domain *GetDomainResponse) *GetDomainResponse {
+	copied := *domain
+
+	info := *domain.Info
+	info.Data = make(map[string]string)
+	for k, v := range domain.Info.Data {
+		info.Data[k] = v
+	}
+	copied.Info = &info
+
+	config := *domain.Config
+	config.SuspectBinaryChecksums = append([]string(nil), domain.Config.SuspectBinaryChecksums...)
+	copied.Config = &config
+
+	replicationConfig := DomainReplicationConfig{}
+	if domain.ReplicationConfig != nil {
+		replicationConfig = *domain.ReplicationConfig
+		replicationConfig.Clusters = nil
+		for _, cluster := range domain.ReplicationConfig.Clusters {
+			c := *cluster
+			replicationConfig.Clusters = append(replicationConfig.Clusters, &c)
+		}
+	}
+	copied.ReplicationConfig = &replicationConfig
+	return &copied
+}
@@ -0,0 +1,1147 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+// The in-memory persistence keeps the state of a cluster in process, so integration tests can run full clusters
+// without Cassandra.  It follows the conditional writes of the Cassandra persistence, and returns the same errors
+// when a condition fails, but keeps nothing once the process exits.  Records written with a TTL are kept until
+// they are deleted, except tasks of a task list which are skipped once their schedule to start timeout passed.
+
+type (
+	// inMemoryShardPersistence is both the shard manager and the factory of the execution managers of a cluster,
+	// as the Cassandra persistence the shard row and the rows of the executions of a shard share their partition
+	inMemoryShardPersistence struct {
+		sync.Mutex
+		currentClusterName string
+		shards             map[int]*inMemoryShard
+		logger             bark.Logger
+	}
+
+	// inMemoryShard is the partition of a shard, info is nil until the shard is created
+	inMemoryShard struct {
+		info              *ShardInfo
+		currentExecutions map[inMemoryWorkflowKey]*inMemoryCurrentExecution
+		executions        map[inMemoryRunKey]*WorkflowMutableState
+		transferTasks     map[int64]*TransferTaskInfo
+		replicationTasks  map[int64]*ReplicationTaskInfo
+		timerTasks        map[inMemoryTimerKey]*TimerTaskInfo
+		// source cluster -> message -> time the message was moved to the DLQ
+		replicationDLQ map[string]map[ReplicationDLQMessageKey]time.Time
+	}
+
+	inMemoryWorkflowKey struct {
+		domainID   string
+		workflowID string
+	}
+
+	inMemoryRunKey struct {
+		domainID   string
+		workflowID string
+		runID      string
+	}
+
+	inMemoryTimerKey struct {
+		visibilityTimestamp int64 // milliseconds, as precise as a Cassandra timestamp
+		taskID              int64
+	}
+
+	inMemoryCurrentExecution struct {
+		runID           string
+		createRequestID string
+		state           int
+		closeStatus     int
+		startVersion    int64
+	}
+
+	inMemoryExecutionPersistence struct {
+		shardID int
+		store   *inMemoryShardPersistence
+		logger  bark.Logger
+	}
+)
+
+var _ ShardManager = (*inMemoryShardPersistence)(nil)
+var _ ExecutionManagerFactory = (*inMemoryShardPersistence)(nil)
+var _ ExecutionManager = (*inMemoryExecutionPersistence)(nil)
+
+// NewInMemoryShardPersistence creates a shard manager keeping the shards of a cluster in memory, along with the
+// factory of the execution managers of those shards
+func NewInMemoryShardPersistence(currentClusterName string, logger bark.Logger) (ShardManager,
+	ExecutionManagerFactory) {
+	store := &inMemoryShardPersistence{
+		currentClusterName: currentClusterName,
+		shards:             make(map[int]*inMemoryShard),
+		logger:             logger,
+	}
+	return store, store
+}
+
+func (d *inMemoryShardPersistence) Close() {
+}
+
+func (d *inMemoryShardPersistence) CreateExecutionManager(shardID int) (ExecutionManager, error) {
+	return &inMemoryExecutionPersistence{shardID: shardID, store: d, logger: d.logger}, nil
+}
+
+func (d *inMemoryShardPersistence) getShard(shardID int) *inMemoryShard {
+	shard, ok := d.shards[shardID]
+	if !ok {
+		shard = &inMemoryShard{
+			currentExecutions: make(map[inMemoryWorkflowKey]*inMemoryCurrentExecution),
+			executions:        make(map[inMemoryRunKey]*WorkflowMutableState),
+			transferTasks:     make(map[int64]*TransferTaskInfo),
+			replicationTasks:  make(map[int64]*ReplicationTaskInfo),
+			timerTasks:        make(map[inMemoryTimerKey]*TimerTaskInfo),
+			replicationDLQ:    make(map[string]map[ReplicationDLQMessageKey]time.Time),
+		}
+		d.shards[shardID] = shard
+	}
+	return shard
+}
+
+func (d *inMemoryShardPersistence) CreateShard(request *CreateShardRequest) error {
+	d.Lock()
+	defer d.Unlock()
+
+	shard := d.getShard(request.ShardInfo.ShardID)
+	if shard.info != nil {
+		return &ShardAlreadyExistError{
+			Msg: fmt.Sprintf("Shard already exists in executions table.  ShardId: %v, RangeId: %v",
+				shard.info.ShardID, shard.info.RangeID),
+		}
+	}
+
+	shard.info = d.cloneShardInfo(request.ShardInfo)
+	shard.info.UpdatedAt = time.Now()
+	return nil
+}
+
+func (d *inMemoryShardPersistence) GetShard(request *GetShardRequest) (*GetShardResponse, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	shard := d.getShard(request.ShardID)
+	if shard.info == nil {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Shard not found.  ShardId: %v", request.ShardID),
+		}
+	}
+
+	return &GetShardResponse{ShardInfo: d.cloneShardInfo(shard.info)}, nil
+}
+
+func (d *inMemoryShardPersistence) UpdateShard(request *UpdateShardRequest) error {
+	d.Lock()
+	defer d.Unlock()
+
+	shard := d.getShard(request.ShardInfo.ShardID)
+	if shard.info == nil || shard.info.RangeID != request.PreviousRangeID {
+		return &ShardOwnershipLostError{
+			ShardID: request.ShardInfo.ShardID,
+			Msg:     fmt.Sprintf("Failed to update shard.  previous_range_id: %v", request.PreviousRangeID),
+		}
+	}
+
+	shard.info = d.cloneShardInfo(request.ShardInfo)
+	shard.info.UpdatedAt = time.Now()
+	return nil
+}
+
+func (d *inMemoryShardPersistence) PutReplicationDLQMessage(request *PutReplicationDLQMessageRequest) error {
+	d.Lock()
+	defer d.Unlock()
+
+	shard := d.getShard(request.ShardID)
+	messages, ok := shard.replicationDLQ[request.SourceCluster]
+	if !ok {
+		messages = make(map[ReplicationDLQMessageKey]time.Time)
+		shard.replicationDLQ[request.SourceCluster] = messages
+	}
+	// a message moved to the DLQ again keeps the time it was first moved
+	if _, ok := messages[request.ReplicationDLQMessageKey]; !ok {
+		messages[request.ReplicationDLQMessageKey] = time.Now()
+	}
+	return nil
+}
+
+func (d *inMemoryShardPersistence) DeleteReplicationDLQMessage(request *DeleteReplicationDLQMessageRequest) error {
+	d.Lock()
+	defer d.Unlock()
+
+	shard := d.getShard(request.ShardID)
+	delete(shard.replicationDLQ[request.SourceCluster], request.ReplicationDLQMessageKey)
+	return nil
+}
+
+func (d *inMemoryShardPersistence) GetReplicationDLQSummary(request *GetReplicationDLQSummaryRequest) (
+	*GetReplicationDLQSummaryResponse, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	response := &GetReplicationDLQSummaryResponse{}
+	for _, movedTime := range d.getShard(request.ShardID).replicationDLQ[request.SourceCluster] {
+		if response.MessageCount == 0 || movedTime.Before(response.OldestMessageTimestamp) {
+			response.OldestMessageTimestamp = movedTime
+		}
+		response.MessageCount++
+	}
+	return response, nil
+}
+
+func (d *inMemoryShardPersistence) ListDomainExecutions(request *ListDomainExecutionsRequest) (
+	*ListDomainExecutionsResponse, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	var keys []inMemoryRunKey
+	for key := range d.getShard(request.ShardID).executions {
+		if key.domainID == request.DomainID {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].workflowID != keys[j].workflowID {
+			return keys[i].workflowID < keys[j].workflowID
+		}
+		return keys[i].runID < keys[j].runID
+	})
+
+	start, end, nextPageToken, err := getInMemoryPage(len(keys), request.PageSize, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	response := &ListDomainExecutionsResponse{NextPageToken: nextPageToken}
+	for _, key := range keys[start:end] {
+		response.Executions = append(response.Executions, workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(key.workflowID),
+			RunId:      common.StringPtr(key.runID),
+		})
+	}
+	return response, nil
+}
+
+// cloneShardInfo copies the shard info as the Cassandra persistence round trips it, the failover levels are not
+// persisted
+func (d *inMemoryShardPersistence) cloneShardInfo(info *ShardInfo) *ShardInfo {
+	copied := *info
+	copied.TransferFailoverLevels = nil
+	copied.TimerFailoverLevels = nil
+	copied.ClusterTransferAckLevel = make(map[string]int64)
+	for k, v := range info.ClusterTransferAckLevel {
+		copied.ClusterTransferAckLevel[k] = v
+	}
+	if len(info.ClusterTransferAckLevel) == 0 {
+		copied.ClusterTransferAckLevel[d.currentClusterName] = info.TransferAckLevel
+	}
+	copied.ClusterTimerAckLevel = make(map[string]time.Time)
+	for k, v := range info.ClusterTimerAckLevel {
+		copied.ClusterTimerAckLevel[k] = v
+	}
+	if len(info.ClusterTimerAckLevel) == 0 {
+		copied.ClusterTimerAckLevel[d.currentClusterName] = info.TimerAckLevel
+	}
+	return &copied
+}
+
+func (m *inMemoryExecutionPersistence) Close() {
+}
+
+func (m *inMemoryExecutionPersistence) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (
+	*CreateWorkflowExecutionResponse, error) {
+	m.store.Lock()
+	defer m.store.Unlock()
+
+	shard := m.store.getShard(m.shardID)
+	if err := m.validateRangeID(shard, request.RangeID, "Failed to create workflow execution"); err != nil {
+		return nil, err
+	}
+	if err := m.validateCreateWorkflowExecution(shard, request); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	domainID := request.DomainID
+	workflowID := request.Execution.GetWorkflowId()
+	runID := request.Execution.GetRunId()
+	m.createWorkflowExecution(shard, request, now)
+	m.createTransferTasks(shard, request.TransferTasks, domainID, workflowID, runID)
+	m.createReplicationTasks(shard, request.ReplicationTasks, domainID, workflowID, runID)
+	m.createTimerTasks(shard, request.TimerTasks, nil, domainID, workflowID, runID)
+
+	return &CreateWorkflowExecutionResponse{}, nil
+}
+
+func (m *inMemoryExecutionPersistence) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (
+	*GetWorkflowExecutionResponse, error) {
+	m.store.Lock()
+	defer m.store.Unlock()
+
+	execution := request.Execution
+	shard := m.store.getShard(m.shardID)
+	state, ok := shard.executions[inMemoryRunKey{request.DomainID, execution.GetWorkflowId(), execution.GetRunId()}]
+	if !ok {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v, RunId: %v",
+				execution.GetWorkflowId(), execution.GetRunId()),
+		}
+	}
+
+	return &GetWorkflowExecutionResponse{State: cloneWorkflowMutableState(state)}, nil
+}
+
+func (m *inMemoryExecutionPersistence) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) error {
+	m.store.Lock()
+	defer m.store.Unlock()
+
+	shard := m.store.getShard(m.shardID)
+	executionInfo := request.ExecutionInfo
+	if err := m.validateRangeID(shard, request.RangeID, "Failed to update workflow execution"); err != nil {
+		return err
+	}
+	state, err := m.getExecutionForCondition(shard, executionInfo, request.Condition, request.RangeID,
+		"Failed to update workflow execution")
+	if err != nil {
+		return err
+	}
+	if request.ContinueAsNew != nil {
+		if err := m.validateCreateWorkflowExecution(shard, request.ContinueAsNew); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	domainID := executionInfo.DomainID
+	workflowID := executionInfo.WorkflowID
+	runID := executionInfo.RunID
+
+	state.ExecutionInfo = cloneWorkflowExecutionInfo(executionInfo)
+	state.ExecutionInfo.LastUpdatedTimestamp = now
+	if request.ReplicationState != nil {
+		state.ReplicationState = cloneReplicationState(request.ReplicationState)
+	}
+
+	m.createTransferTasks(shard, request.TransferTasks, domainID, workflowID, runID)
+	m.createReplicationTasks(shard, request.ReplicationTasks, domainID, workflowID, runID)
+	m.createTimerTasks(shard, request.TimerTasks, request.DeleteTimerTask, domainID, workflowID, runID)
+
+	for _, a := range request.UpsertActivityInfos {
+		state.ActivitInfos[a.ScheduleID] = cloneActivityInfo(a)
+	}
+	for _, scheduleID := range request.DeleteActivityInfos {
+		delete(state.ActivitInfos, scheduleID)
+	}
+	for _, t := range request.UpserTimerInfos {
+		state.TimerInfos[t.TimerID] = cloneTimerInfo(t)
+	}
+	for _, timerID := range request.DeleteTimerInfos {
+		delete(state.TimerInfos, timerID)
+	}
+	for _, c := range request.UpsertChildExecutionInfos {
+		state.ChildExecutionInfos[c.InitiatedID] = cloneChildExecutionInfo(c)
+	}
+	if request.DeleteChildExecutionInfo != nil {
+		delete(state.ChildExecutionInfos, *request.DeleteChildExecutionInfo)
+	}
+	for _, c := range request.UpsertRequestCancelInfos {
+		state.RequestCancelInfos[c.InitiatedID] = cloneRequestCancelInfo(c)
+	}
+	if request.DeleteRequestCancelInfo != nil {
+		delete(state.RequestCancelInfos, *request.DeleteRequestCancelInfo)
+	}
+	for _, s := range request.UpsertSignalInfos {
+		state.SignalInfos[s.InitiatedID] = cloneSignalInfo(s)
+	}
+	if request.DeleteSignalInfo != nil {
+		delete(state.SignalInfos, *request.DeleteSignalInfo)
+	}
+	for _, signalRequestID := range request.UpsertSignalRequestedIDs {
+		state.SignalRequestedIDs[signalRequestID] = struct{}{}
+	}
+	if request.DeleteSignalRequestedID != "" {
+		delete(state.SignalRequestedIDs, request.DeleteSignalRequestedID)
+	}
+	for _, u := range request.UpsertUpdateInfos {
+		state.UpdateInfos[u.UpdateID] = cloneUpdateInfo(u)
+	}
+
+	if request.ClearBufferedEvents {
+		// the existing buffered events are replaced when new ones come along, e.g. after they got re-encoded
+		state.BufferedEvents = nil
+	}
+	if request.NewBufferedEvents != nil {
+		state.BufferedEvents = append(state.BufferedEvents, cloneSerializedHistoryEventBatch(request.NewBufferedEvents))
+	}
+	if request.NewBufferedReplicationTask != nil {
+		task := cloneBufferedReplicationTask(request.NewBufferedReplicationTask)
+		state.BufferedReplicationTasks[task.FirstEventID] = task
+	}
+	if request.DeleteBufferedReplicationTask != nil {
+		delete(state.BufferedReplicationTasks, *request.DeleteBufferedReplicationTask)
+	}
+
+	if request.ContinueAsNew != nil {
+		startReq := request.ContinueAsNew
+		m.createWorkflowExecution(shard, startReq, now)
+		m.createTransferTasks(shard, startReq.TransferTasks, startReq.DomainID, startReq.Execution.GetWorkflowId(),
+			startReq.Execution.GetRunId())
+		m.createTimerTasks(shard, startReq.TimerTasks, nil, startReq.DomainID, startReq.Execution.GetWorkflowId(),
+			startReq.Execution.GetRunId())
+	} else if request.FinishExecution {
+		// the current execution keeps pointing to the finished run, the start version is left as is
+		key := inMemoryWorkflowKey{domainID, workflowID}
+		current, ok := shard.currentExecutions[key]
+		if !ok {
+			current = &inMemoryCurrentExecution{}
+			shard.currentExecutions[key] = current
+		}
+		current.runID = runID
+		current.createRequestID = executionInfo.CreateRequestID
+		current.state = executionInfo.State
+		current.closeStatus = executionInfo.CloseStatus
+	}
+
+	return nil
+}
+
+func (m *inMemoryExecutionPersistence) ResetMutableState(request *ResetMutableStateRequest) error {
+	m.store.Lock()
+	defer m.store.Unlock()
+
+	shard := m.store.getShard(m.shardID)
+	if err := m.validateRangeID(shard, request.RangeID, "Failed to reset mutable state"); err != nil {
+		return err
+	}
+	state, err := m.getExecutionForCondition(shard, request.ExecutionInfo, request.Condition, request.RangeID,
+		"Failed to reset mutable state")
+	if err != nil {
+		return err
+	}
+
+	state.ExecutionInfo = cloneWorkflowExecutionInfo(request.ExecutionInfo)
+	state.ExecutionInfo.LastUpdatedTimestamp = time.Now()
+	if state.ExecutionInfo.ParentDomainID == "" {
+		state.ExecutionInfo.ParentDomainID = emptyDomainID
+	}
+	if state.ExecutionInfo.ParentRunID == "" {
+		state.ExecutionInfo.ParentRunID = emptyRunID
+	}
+	state.ReplicationState = cloneReplicationState(request.ReplicationState)
+
+	state.ActivitInfos = make(map[int64]*ActivityInfo)
+	for _, a := range request.InsertActivityInfos {
+		state.ActivitInfos[a.ScheduleID] = cloneActivityInfo(a)
+	}
+	state.TimerInfos = make(map[string]*TimerInfo)
+	for _, t := range request.InsertTimerInfos {
+		state.TimerInfos[t.TimerID] = cloneTimerInfo(t)
+	}
+	state.ChildExecutionInfos = make(map[int64]*ChildExecutionInfo)
+	for _, c := range request.InsertChildExecutionInfos {
+		state.ChildExecutionInfos[c.InitiatedID] = cloneChildExecutionInfo(c)
+	}
+	state.RequestCancelInfos = make(map[int64]*RequestCancelInfo)
+	for _, c := range request.InsertRequestCancelInfos {
+		state.RequestCancelInfos[c.InitiatedID] = cloneRequestCancelInfo(c)
+	}
+	state.SignalInfos = make(map[int64]*SignalInfo)
+	for _, s := range request.InsertSignalInfos {
+		state.SignalInfos[s.InitiatedID] = cloneSignalInfo(s)
+	}
+	state.SignalRequestedIDs = make(map[string]struct{})
+	for _, signalRequestID := range request.InsertSignalRequestedIDs {
+		state.SignalRequestedIDs[signalRequestID] = struct{}{}
+	}
+	state.UpdateInfos = make(map[string]*UpdateInfo)
+	for _, u := range request.InsertUpdateInfos {
+		state.UpdateInfos[u.UpdateID] = cloneUpdateInfo(u)
+	}
+	state.BufferedEvents = nil
+	state.BufferedReplicationTasks = make(map[int64]*BufferedReplicationTask)
+
+	return nil
+}
+
+func (m *inMemoryExecutionPersistence) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
+	m.store.Lock()
+	defer m.store.Unlock()
+
+	shard := m.store.getShard(m.shardID)
+	delete(shard.executions, inMemoryRunKey{request.DomainID, request.WorkflowID, request.RunID})
+	return nil
+}
+
+func (m *inMemoryExecutionPersistence) DeleteCurrentWorkflowExecution(
+	request *DeleteCurrentWorkflowExecutionRequest) error {
+	m.store.Lock()
+	defer m.store.Unlock()
+
+	// the current execution is left untouched when it already points to another run
+	shard := m.store.getShard(m.shardID)
+	key := inMemoryWorkflowKey{request.DomainID, request.WorkflowID}
+	if current, ok := shard.currentExecutions[key]; ok && current.runID == request.RunID {
+		delete(shard.currentExecutions, key)
+	}
+	return nil
+}
+
+func (m *inMemoryExecutionPersistence) GetCurrentExecution(request *GetCurrentExecutionRequest) (
+	*GetCurrentExecutionResponse, error) {
+	m.store.Lock()
+	defer m.store.Unlock()
+
+	shard := m.store.getShard(m.shardID)
+	current, ok := shard.currentExecutions[inMemoryWorkflowKey{request.DomainID, request.WorkflowID}]
+	if !ok {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v", request.WorkflowID),
+		}
+	}
+
+	return &GetCurrentExecutionResponse{
+		RunID:          current.runID,
+		StartRequestID: current.createRequestID,
+		State:          current.state,
+		CloseStatus:    current.closeStatus,
+	}, nil
+}
+
+// GetTransferTasks pages by task ID, the NextPageToken is the ID of the last task of the page
+func (m *inMemoryExecutionPersistence) GetTransferTasks(request *GetTransferTasksRequest) (
+	*GetTransferTasksResponse, error) {
+	m.store.Lock()
+	defer m.store.Unlock()
+
+	readLevel, err := getInMemoryTaskPageReadLevel(request.ReadLevel, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	shard := m.store.getShard(m.shardID)
+	var taskIDs []int64
+	for taskID := range shard.transferTasks {
+		if taskID > readLevel && taskID <= request.MaxReadLevel {
+			taskIDs = append(taskIDs, taskID)
+		}
+	}
+	sort.Slice(taskIDs, func(i, j int) bool { return taskIDs[i] < taskIDs[j] })
+
+	response := &GetTransferTasksResponse{}
+	for _, taskID := range taskIDs {
+		if request.BatchSize > 0 && len(response.Tasks) == request.BatchSize {
+			response.NextPageToken = getInMemoryTaskPageToken(response.Tasks[len(response.Tasks)-1].TaskID)
+			break
+		}
+		task := *shard.transferTasks[taskID]
+		response.Tasks = append(response.Tasks, &task)
+	}
+	return response, nil
+}
+
+func (m *inMemoryExecutionPersistence) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
+	m.store.Lock()
+	defer m.store.Unlock()
+
+	delete(m.store.getShard(m.shardID).transferTasks, request.TaskID)
+	return nil
+}
+
+// GetReplicationTasks pages by task ID, the NextPageToken is the ID of the last task of the page
+func (m *inMemoryExecutionPersistence) GetReplicationTasks(request *GetReplicationTasksRequest) (
+	*GetReplicationTasksResponse, error) {
+	m.store.Lock()
+	defer m.store.Unlock()
+
+	readLevel, err := getInMemoryTaskPageReadLevel(request.ReadLevel, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	shard := m.store.getShard(m.shardID)
+	var taskIDs []int64
+	for taskID := range shard.replicationTasks {
+		if taskID > readLevel && taskID <= request.MaxReadLevel {
+			taskIDs = append(taskIDs, taskID)
+		}
+	}
+	sort.Slice(taskIDs, func(i, j int) bool { return taskIDs[i] < taskIDs[j] })
+
+	response := &GetReplicationTasksResponse{}
+	var batchSizeBytes int64
+	for _, taskID := range taskIDs {
+		isFull := (request.BatchSize > 0 && len(response.Tasks) >= request.BatchSize) ||
+			(request.MaxBatchSizeBytes > 0 && batchSizeBytes >= request.MaxBatchSizeBytes)
+		if isFull {
+			response.NextPageToken = getInMemoryTaskPageToken(response.Tasks[len(response.Tasks)-1].TaskID)
+			break
+		}
+		task := shard.replicationTasks[taskID]
+		response.Tasks = append(response.Tasks, cloneReplicationTaskInfo(task))
+		batchSizeBytes += task.HistorySize
+	}
+	return response, nil
+}
+
+func (m *inMemoryExecutionPersistence) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
+	m.store.Lock()
+	defer m.store.Unlock()
+
+	delete(m.store.getShard(m.shardID).replicationTasks, request.TaskID)
+	return nil
+}
+
+func (m *inMemoryExecutionPersistence) RangeCompleteReplicationTask(
+	request *RangeCompleteReplicationTaskRequest) error {
+	m.store.Lock()
+	defer m.store.Unlock()
+
+	shard := m.store.getShard(m.shardID)
+	for taskID := range shard.replicationTasks {
+		if taskID <= request.InclusiveEndTaskID {
+			delete(shard.replicationTasks, taskID)
+		}
+	}
+	return nil
+}
+
+// GetTimerIndexTasks pages by visibility timestamp and task ID, the NextPageToken is the key of the last timer of
+// the page
+func (m *inMemoryExecutionPersistence) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (
+	*GetTimerIndexTasksResponse, error) {
+	m.store.Lock()
+	defer m.store.Unlock()
+
+	minKey := inMemoryTimerKey{visibilityTimestamp: getInMemoryTimestamp(request.MinTimestamp), taskID: -1 << 63}
+	if len(request.NextPageToken) > 0 {
+		if len(request.NextPageToken) != 16 {
+			return nil, &workflow.BadRequestError{
+				Message: "GetTimerIndexTasks operation failed.  Invalid next page token.",
+			}
+		}
+		minKey.visibilityTimestamp = int64(binary.BigEndian.Uint64(request.NextPageToken))
+		minKey.taskID = int64(binary.BigEndian.Uint64(request.NextPageToken[8:])) + 1
+	}
+	maxTimestamp := getInMemoryTimestamp(request.MaxTimestamp)
+
+	shard := m.store.getShard(m.shardID)
+	var keys []inMemoryTimerKey
+	for key := range shard.timerTasks {
+		if !key.less(minKey) && key.visibilityTimestamp < maxTimestamp {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].less(keys[j]) })
+
+	response := &GetTimerIndexTasksResponse{}
+	for _, key := range keys {
+		if request.BatchSize > 0 && len(response.Timers) == request.BatchSize {
+			last := keys[len(response.Timers)-1]
+			response.NextPageToken = make([]byte, 16)
+			binary.BigEndian.PutUint64(response.NextPageToken, uint64(last.visibilityTimestamp))
+			binary.BigEndian.PutUint64(response.NextPageToken[8:], uint64(last.taskID))
+			break
+		}
+		timer := *shard.timerTasks[key]
+		response.Timers = append(response.Timers, &timer)
+	}
+	return response, nil
+}
+
+func (m *inMemoryExecutionPersistence) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
+	m.store.Lock()
+	defer m.store.Unlock()
+
+	shard := m.store.getShard(m.shardID)
+	delete(shard.timerTasks, inMemoryTimerKey{getInMemoryTimestamp(request.VisibilityTimestamp), request.TaskID})
+	return nil
+}
+
+func (m *inMemoryExecutionPersistence) validateRangeID(shard *inMemoryShard, rangeID int64, msg string) error {
+	if shard.info == nil || shard.info.RangeID != rangeID {
+		actualRangeID := int64(0)
+		if shard.info != nil {
+			actualRangeID = shard.info.RangeID
+		}
+		return &ShardOwnershipLostError{
+			ShardID: m.shardID,
+			Msg:     fmt.Sprintf("%v.  Request RangeID: %v, Actual RangeID: %v", msg, rangeID, actualRangeID),
+		}
+	}
+	return nil
+}
+
+// validateCreateWorkflowExecution checks the current execution of the workflow, a new run can only become the
+// current one when there is no current run, and a continued run only while its previous run is the current one
+func (m *inMemoryExecutionPersistence) validateCreateWorkflowExecution(shard *inMemoryShard,
+	request *CreateWorkflowExecutionRequest) error {
+	current, ok := shard.currentExecutions[inMemoryWorkflowKey{request.DomainID, request.Execution.GetWorkflowId()}]
+	if request.ContinueAsNew {
+		if !ok || current.runID != request.PreviousRunID {
+			// as Cassandra, which does not tell why the current execution did not match
+			return &ShardOwnershipLostError{
+				ShardID: m.shardID,
+				Msg: fmt.Sprintf("Failed to create workflow execution.  Request RangeID: %v, PreviousRunID: %v",
+					request.RangeID, request.PreviousRunID),
+			}
+		}
+		return nil
+	}
+
+	if ok {
+		return &WorkflowExecutionAlreadyStartedError{
+			Msg: fmt.Sprintf("Workflow execution already running. WorkflowId: %v, RunId: %v, rangeID: %v",
+				request.Execution.GetWorkflowId(), current.runID, request.RangeID),
+			StartRequestID: current.createRequestID,
+			RunID:          current.runID,
+			State:          current.state,
+			CloseStatus:    current.closeStatus,
+			StartVersion:   current.startVersion,
+		}
+	}
+	return nil
+}
+
+// getExecutionForCondition returns the execution to update, when its next event ID is the condition of the update
+func (m *inMemoryExecutionPersistence) getExecutionForCondition(shard *inMemoryShard,
+	executionInfo *WorkflowExecutionInfo, condition int64, rangeID int64, msg string) (*WorkflowMutableState, error) {
+	state, ok := shard.executions[inMemoryRunKey{executionInfo.DomainID, executionInfo.WorkflowID,
+		executionInfo.RunID}]
+	if !ok {
+		// as Cassandra, which has no row to tell why the update did not apply
+		return nil, &ShardOwnershipLostError{
+			ShardID: m.shardID,
+			Msg:     fmt.Sprintf("%v.  RangeID: %v, Condition: %v", msg, rangeID, condition),
+		}
+	}
+	if state.ExecutionInfo.NextEventID != condition {
+		return nil, &ConditionFailedError{
+			Msg: fmt.Sprintf("%v.  Request Condition: %v, Actual Value: %v", msg, condition,
+				state.ExecutionInfo.NextEventID),
+		}
+	}
+	return state, nil
+}
+
+func (m *inMemoryExecutionPersistence) createWorkflowExecution(shard *inMemoryShard,
+	request *CreateWorkflowExecutionRequest, now time.Time) {
+	parentDomainID := emptyDomainID
+	parentWorkflowID := ""
+	parentRunID := emptyRunID
+	initiatedID := emptyInitiatedID
+	state := WorkflowStateRunning
+	if request.ParentExecution != nil {
+		parentDomainID = request.ParentDomainID
+		parentWorkflowID = request.ParentExecution.GetWorkflowId()
+		parentRunID = request.ParentExecution.GetRunId()
+		initiatedID = request.InitiatedID
+		state = WorkflowStateCreated
+	}
+
+	startVersion := common.EmptyVersion
+	if request.ReplicationState != nil {
+		startVersion = request.ReplicationState.StartVersion
+	}
+	workflowID := request.Execution.GetWorkflowId()
+	runID := request.Execution.GetRunId()
+	shard.currentExecutions[inMemoryWorkflowKey{request.DomainID, workflowID}] = &inMemoryCurrentExecution{
+		runID:           runID,
+		createRequestID: request.RequestID,
+		state:           state,
+		closeStatus:     WorkflowCloseStatusNone,
+		startVersion:    startVersion,
+	}
+
+	tags := make(map[string]string)
+	for k, v := range request.Tags {
+		tags[k] = v
+	}
+	shard.executions[inMemoryRunKey{request.DomainID, workflowID, runID}] = &WorkflowMutableState{
+		ExecutionInfo: &WorkflowExecutionInfo{
+			DomainID:             request.DomainID,
+			WorkflowID:           workflowID,
+			RunID:                runID,
+			ParentDomainID:       parentDomainID,
+			ParentWorkflowID:     parentWorkflowID,
+			ParentRunID:          parentRunID,
+			InitiatedID:          initiatedID,
+			TaskList:             request.TaskList,
+			WorkflowTypeName:     request.WorkflowTypeName,
+			WorkflowTimeout:      request.WorkflowTimeout,
+			DecisionTimeoutValue: request.DecisionTimeoutValue,
+			ExecutionContext:     request.ExecutionContext,
+			State:                WorkflowStateCreated,
+			CloseStatus:          WorkflowCloseStatusNone,
+			LastFirstEventID:     common.FirstEventID,
+			NextEventID:          request.NextEventID,
+			LastProcessedEvent:   request.LastProcessedEvent,
+			StartTimestamp:       now,
+			LastUpdatedTimestamp: now,
+			CreateRequestID:      request.RequestID,
+			DecisionVersion:      request.DecisionVersion,
+			DecisionScheduleID:   request.DecisionScheduleID,
+			DecisionStartedID:    request.DecisionStartedID,
+			DecisionRequestID:    request.DecisionRequestID,
+			DecisionTimeout:      request.DecisionStartToCloseTimeout,
+			Tags:                 tags,
+			HistorySize:          request.HistorySize,
+			Priority:             request.Priority,
+			FirstRunID:           request.FirstRunID,
+		},
+		ReplicationState:         cloneReplicationState(request.ReplicationState),
+		ActivitInfos:             make(map[int64]*ActivityInfo),
+		TimerInfos:               make(map[string]*TimerInfo),
+		ChildExecutionInfos:      make(map[int64]*ChildExecutionInfo),
+		RequestCancelInfos:       make(map[int64]*RequestCancelInfo),
+		SignalInfos:              make(map[int64]*SignalInfo),
+		SignalRequestedIDs:       make(map[string]struct{}),
+		UpdateInfos:              make(map[string]*UpdateInfo),
+		BufferedReplicationTasks: make(map[int64]*BufferedReplicationTask),
+	}
+}
+
+func (m *inMemoryExecutionPersistence) createTransferTasks(shard *inMemoryShard, transferTasks []Task, domainID,
+	workflowID, runID string) {
+	for _, task := range transferTasks {
+		info := &TransferTaskInfo{
+			DomainID:            domainID,
+			WorkflowID:          workflowID,
+			RunID:               runID,
+			VisibilityTimestamp: task.GetVisibilityTimestamp(),
+			TaskID:              task.GetTaskID(),
+			TargetDomainID:      domainID,
+			TargetWorkflowID:    transferTaskTransferTargetWorkflowID,
+			TaskType:            task.GetType(),
+			Version:             task.GetVersion(),
+		}
+
+		switch t := task.(type) {
+		case *ActivityTask:
+			info.TargetDomainID = t.DomainID
+			info.TaskList = t.TaskList
+			info.ScheduleID = t.ScheduleID
+		case *DecisionTask:
+			info.TargetDomainID = t.DomainID
+			info.TaskList = t.TaskList
+			info.ScheduleID = t.ScheduleID
+		case *CancelExecutionTask:
+			info.TargetDomainID = t.TargetDomainID
+			info.TargetWorkflowID = t.TargetWorkflowID
+			info.TargetRunID = t.TargetRunID
+			info.TargetChildWorkflowOnly = t.TargetChildWorkflowOnly
+			info.ScheduleID = t.InitiatedID
+		case *SignalExecutionTask:
+			info.TargetDomainID = t.TargetDomainID
+			info.TargetWorkflowID = t.TargetWorkflowID
+			info.TargetRunID = t.TargetRunID
+			info.TargetChildWorkflowOnly = t.TargetChildWorkflowOnly
+			info.ScheduleID = t.InitiatedID
+		case *StartChildExecutionTask:
+			info.TargetDomainID = t.TargetDomainID
+			info.TargetWorkflowID = t.TargetWorkflowID
+			info.ScheduleID = t.InitiatedID
+		case *CloseExecutionTask:
+			// No explicit property needs to be set
+		default:
+			m.logger.Fatal("Unknown Transfer Task.")
+		}
+
+		shard.transferTasks[info.TaskID] = info
+	}
+}
+
+func (m *inMemoryExecutionPersistence) createReplicationTasks(shard *inMemoryShard, replicationTasks []Task,
+	domainID, workflowID, runID string) {
+	for _, task := range replicationTasks {
+		info := &ReplicationTaskInfo{
+			DomainID:     domainID,
+			WorkflowID:   workflowID,
+			RunID:        runID,
+			TaskID:       task.GetTaskID(),
+			TaskType:     task.GetType(),
+			FirstEventID: common.EmptyEventID,
+			NextEventID:  common.EmptyEventID,
+			Version:      task.GetVersion(),
+			ScheduledID:  common.EmptyEventID,
+		}
+
+		switch t := task.(type) {
+		case *HistoryReplicationTask:
+			info.FirstEventID = t.FirstEventID
+			info.NextEventID = t.NextEventID
+			info.LastReplicationInfo = t.LastReplicationInfo
+			info.HistorySize = t.HistorySize
+		case *SyncActivityTask:
+			info.ScheduledID = t.ScheduledID
+		default:
+			m.logger.Fatal("Unknown Replication Task.")
+		}
+
+		shard.replicationTasks[info.TaskID] = cloneReplicationTaskInfo(info)
+	}
+}
+
+func (m *inMemoryExecutionPersistence) createTimerTasks(shard *inMemoryShard, timerTasks []Task, deleteTimerTask Task,
+	domainID, workflowID, runID string) {
+	for _, task := range timerTasks {
+		info := &TimerTaskInfo{
+			DomainID:   domainID,
+			WorkflowID: workflowID,
+			RunID:      runID,
+			TaskID:     task.GetTaskID(),
+			TaskType:   task.GetType(),
+			Version:    task.GetVersion(),
+		}
+
+		switch t := task.(type) {
+		case *DecisionTimeoutTask:
+			info.EventID = t.EventID
+			info.TimeoutType = t.TimeoutType
+			info.ScheduleAttempt = t.ScheduleAttempt
+		case *ActivityTimeoutTask:
+			info.EventID = t.EventID
+			info.TimeoutType = t.TimeoutType
+			info.ScheduleAttempt = t.Attempt
+		case *UserTimerTask:
+			info.EventID = t.EventID
+		case *RetryTimerTask:
+			info.EventID = t.EventID
+			info.ScheduleAttempt = int64(t.Attempt)
+		}
+
+		key := inMemoryTimerKey{getInMemoryTimestamp(GetVisibilityTSFrom(task)), task.GetTaskID()}
+		info.VisibilityTimestamp = time.Unix(0, key.visibilityTimestamp*int64(time.Millisecond))
+		shard.timerTasks[key] = info
+	}
+
+	if deleteTimerTask != nil {
+		delete(shard.timerTasks, inMemoryTimerKey{getInMemoryTimestamp(GetVisibilityTSFrom(deleteTimerTask)),
+			deleteTimerTask.GetTaskID()})
+	}
+}
+
+func (k inMemoryTimerKey) less(other inMemoryTimerKey) bool {
+	if k.visibilityTimestamp != other.visibilityTimestamp {
+		return k.visibilityTimestamp < other.visibilityTimestamp
+	}
+	return k.taskID < other.taskID
+}
+
+// getInMemoryTimestamp truncates the time to milliseconds, the timers are keyed as precisely as Cassandra does
+func getInMemoryTimestamp(t time.Time) int64 {
+	return common.UnixNanoToCQLTimestamp(t.UnixNano())
+}
+
+func getInMemoryTaskPageToken(lastTaskID int64) []byte {
+	token := make([]byte, 8)
+	binary.BigEndian.PutUint64(token, uint64(lastTaskID))
+	return token
+}
+
+func getInMemoryTaskPageReadLevel(readLevel int64, nextPageToken []byte) (int64, error) {
+	if len(nextPageToken) == 0 {
+		return readLevel, nil
+	}
+	if len(nextPageToken) != 8 {
+		return 0, &workflow.BadRequestError{Message: "Invalid next page token."}
+	}
+	return int64(binary.BigEndian.Uint64(nextPageToken)), nil
+}
+
+// getInMemoryPage returns the bounds of the page of a list of the given length, the page token is the offset of the
+// page in the list
+func getInMemoryPage(length int, pageSize int, pageToken []byte) (int, int, []byte, error) {
+	start := 0
+	if len(pageToken) > 0 {
+		if len(pageToken) != 8 {
+			return 0, 0, nil, &workflow.BadRequestError{Message: "Invalid next page token."}
+		}
+		start = int(binary.BigEndian.Uint64(pageToken))
+	}
+	if start > length {
+		start = length
+	}
+
+	end := length
+	if pageSize > 0 && start+pageSize < length {
+		end = start + pageSize
+	}
+	var nextPageToken []byte
+	if end < length {
+		nextPageToken = make([]byte, 8)
+		binary.BigEndian.PutUint64(nextPageToken, uint64(end))
+	}
+	return start, end, nextPageToken, nil
+}
+
+func cloneWorkflowMutableState(state *WorkflowMutableState) *WorkflowMutableState {
+	copied := &WorkflowMutableState{
+		ExecutionInfo:            cloneWorkflowExecutionInfo(state.ExecutionInfo),
+		ReplicationState:         cloneReplicationState(state.ReplicationState),
+		ActivitInfos:             make(map[int64]*ActivityInfo),
+		TimerInfos:               make(map[string]*TimerInfo),
+		ChildExecutionInfos:      make(map[int64]*ChildExecutionInfo),
+		RequestCancelInfos:       make(map[int64]*RequestCancelInfo),
+		SignalInfos:              make(map[int64]*SignalInfo),
+		SignalRequestedIDs:       make(map[string]struct{}),
+		UpdateInfos:              make(map[string]*UpdateInfo),
+		BufferedEvents:           make([]*SerializedHistoryEventBatch, 0, len(state.BufferedEvents)),
+		BufferedReplicationTasks: make(map[int64]*BufferedReplicationTask),
+	}
+	for k, v := range state.ActivitInfos {
+		copied.ActivitInfos[k] = cloneActivityInfo(v)
+	}
+	for k, v := range state.TimerInfos {
+		copied.TimerInfos[k] = cloneTimerInfo(v)
+	}
+	for k, v := range state.ChildExecutionInfos {
+		copied.ChildExecutionInfos[k] = cloneChildExecutionInfo(v)
+	}
+	for k, v := range state.RequestCancelInfos {
+		copied.RequestCancelInfos[k] = cloneRequestCancelInfo(v)
+	}
+	for k, v := range state.SignalInfos {
+		copied.SignalInfos[k] = cloneSignalInfo(v)
+	}
+	for k := range state.SignalRequestedIDs {
+		copied.SignalRequestedIDs[k] = struct{}{}
+	}
+	for k, v := range state.UpdateInfos {
+		copied.UpdateInfos[k] = cloneUpdateInfo(v)
+	}
+	for _, v := range state.BufferedEvents {
+		copied.BufferedEvents = append(copied.BufferedEvents, cloneSerializedHistoryEventBatch(v))
+	}
+	for k, v := range state.BufferedReplicationTasks {
+		copied.BufferedReplicationTasks[k] = cloneBufferedReplicationTask(v)
+	}
+	return copied
+}
+
+func cloneWorkflowExecutionInfo(info *WorkflowExecutionInfo) *WorkflowExecutionInfo {
+	copied := *info
+	copied.CompletionEvent = cloneBytes(info.CompletionEvent)
+	copied.ExecutionContext = cloneBytes(info.ExecutionContext)
+	copied.Tags = make(map[string]string)
+	for k, v := range info.Tags {
+		copied.Tags[k] = v
+	}
+	return &copied
+}
+
+func cloneReplicationState(state *ReplicationState) *ReplicationState {
+	if state == nil {
+		return nil
+	}
+	copied := *state
+	copied.LastReplicationInfo = cloneReplicationInfoMap(state.LastReplicationInfo)
+	return &copied
+}
+
+func cloneReplicationInfoMap(infos map[string]*ReplicationInfo) map[string]*ReplicationInfo {
+	copied := make(map[string]*ReplicationInfo)
+	for k, v := range infos {
+		info := *v
+		copied[k] = &info
+	}
+	return copied
+}
+
+func cloneReplicationTaskInfo(task *ReplicationTaskInfo) *ReplicationTaskInfo {
+	copied := *task
+	copied.LastReplicationInfo = cloneReplicationInfoMap(task.LastReplicationInfo)
+	return &copied
+}
+
+// cloneActivityInfo leaves out LastTimeoutVisibility, it is not persisted
+func cloneActivityInfo(info *ActivityInfo) *ActivityInfo {
+	copied := *info
+	copied.ScheduledEvent = cloneBytes(info.ScheduledEvent)
+	copied.StartedEvent = cloneBytes(info.StartedEvent)
+	copied.Details = cloneBytes(info.Details)
+	copied.NonRetriableErrors = append([]string(nil), info.NonRetriableErrors...)
+	copied.LastTimeoutVisibility = 0
+	return &copied
+}
+
+func cloneTimerInfo(info *TimerInfo) *TimerInfo {
+	copied := *info
+	return &copied
+}
+
+func cloneChildExecutionInfo(info *ChildExecutionInfo) *ChildExecutionInfo {
+	copied := *info
+	copied.InitiatedEvent = cloneBytes(info.InitiatedEvent)
+	copied.StartedEvent = cloneBytes(info.StartedEvent)
+	return &copied
+}
+
+func cloneRequestCancelInfo(info *RequestCancelInfo) *RequestCancelInfo {
+	copied := *info
+	return &copied
+}
+
+func cloneSignalInfo(info *SignalInfo) *SignalInfo {
+	copied := *info
+	copied.Input = cloneBytes(info.Input)
+	copied.Control = cloneBytes(info.Control)
+	return &copied
+}
+
+func cloneUpdateInfo(info *UpdateInfo) *UpdateInfo {
+	copied := *info
+	copied.Result = cloneBytes(info.Result)
+	return &copied
+}
+
+func cloneSerializedHistoryEventBatch(batch *SerializedHistoryEventBatch) *SerializedHistoryEventBatch {
+	if batch == nil {
+		return nil
+	}
+	copied := *batch
+	copied.Data = cloneBytes(batch.Data)
+	return &copied
+}
+
+func cloneBufferedReplicationTask(task *BufferedReplicationTask) *BufferedReplicationTask {
+	copied := *task
+	copied.History = cloneSerializedHistoryEventBatch(task.History)
+	copied.NewRunHistory = cloneSerializedHistoryEventBatch(task.NewRunHistory)
+	return &copied
+}
+
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte(nil), b...)
+}
@@ -0,0 +1,378 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pborman/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cluster"
+)
+
+type (
+	inMemoryPersistenceSuite struct {
+		suite.Suite
+		TestBase
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+	}
+)
+
+func TestInMemoryPersistenceSuite(t *testing.T) {
+	s := new(inMemoryPersistenceSuite)
+	suite.Run(t, s)
+}
+
+func (s *inMemoryPersistenceSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+}
+
+func (s *inMemoryPersistenceSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+	// every test gets its own store
+	s.SetupInMemoryWorkflowStore(cluster.GetTestClusterMetadata(false, false))
+}
+
+func (s *inMemoryPersistenceSuite) TearDownTest() {
+	s.TearDownWorkflowStore()
+}
+
+func (s *inMemoryPersistenceSuite) TestStartWorkflow() {
+	domainID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("in-memory-start-workflow-test"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	_, err := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err)
+
+	_, err = s.CreateWorkflowExecution(domainID, gen.WorkflowExecution{
+		WorkflowId: workflowExecution.WorkflowId,
+		RunId:      common.StringPtr(uuid.New()),
+	}, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	startedErr, ok := err.(*WorkflowExecutionAlreadyStartedError)
+	s.True(ok, "%v", err)
+	s.Equal(workflowExecution.GetRunId(), startedErr.RunID)
+	s.Equal(WorkflowStateRunning, startedErr.State)
+	s.Equal(common.EmptyVersion, startedErr.StartVersion)
+
+	s.ShardInfo.RangeID++
+	_, err = s.CreateWorkflowExecution(domainID, gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("in-memory-start-workflow-test-stale-range"),
+		RunId:      common.StringPtr(uuid.New()),
+	}, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.IsType(&ShardOwnershipLostError{}, err)
+}
+
+func (s *inMemoryPersistenceSuite) TestUpdateWorkflowCondition() {
+	domainID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("in-memory-update-workflow-test"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	_, err := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err)
+
+	state, err := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err)
+	info := state.ExecutionInfo
+	s.Equal(WorkflowStateCreated, info.State)
+	s.Equal(emptyDomainID, info.ParentDomainID)
+	s.Equal(emptyInitiatedID, info.InitiatedID)
+
+	info.NextEventID = 5
+	err = s.UpdateWorkflowExecution(info, nil, nil, int64(3), nil, nil,
+		[]*ActivityInfo{{ScheduleID: 4, NonRetriableErrors: []string{"error"}}}, nil, nil, nil)
+	s.NoError(err)
+
+	// the mutable state read back is a copy, changing it does not change the store
+	state, err = s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err)
+	state.ActivitInfos[4].NonRetriableErrors[0] = "changed"
+	state, err = s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err)
+	s.Equal(int64(5), state.ExecutionInfo.NextEventID)
+	s.Equal([]string{"error"}, state.ActivitInfos[4].NonRetriableErrors)
+
+	err = s.UpdateWorkflowExecution(info, nil, nil, int64(3), nil, nil, nil, nil, nil, nil)
+	s.IsType(&ConditionFailedError{}, err)
+
+	err = s.UpdateWorkflowExecutionWithRangeID(info, nil, nil, s.ShardInfo.RangeID+1, int64(5), nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, "")
+	s.IsType(&ShardOwnershipLostError{}, err)
+}
+
+func (s *inMemoryPersistenceSuite) TestContinueAsNew() {
+	domainID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("in-memory-continue-as-new-test"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	_, err := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err)
+	state, err := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err)
+
+	newExecution := gen.WorkflowExecution{
+		WorkflowId: workflowExecution.WorkflowId,
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	info := state.ExecutionInfo
+	info.State = WorkflowStateCompleted
+	info.CloseStatus = WorkflowCloseStatusContinuedAsNew
+	err = s.ContinueAsNewExecution(info, info.NextEventID, newExecution, int64(3), int64(2))
+	s.NoError(err)
+
+	runID, err := s.GetCurrentWorkflowRunID(domainID, workflowExecution.GetWorkflowId())
+	s.NoError(err)
+	s.Equal(newExecution.GetRunId(), runID)
+
+	// the first run is no longer the current one, it can't be continued again
+	info.NextEventID++
+	err = s.ContinueAsNewExecution(info, info.NextEventID-1, gen.WorkflowExecution{
+		WorkflowId: workflowExecution.WorkflowId,
+		RunId:      common.StringPtr(uuid.New()),
+	}, int64(3), int64(2))
+	s.IsType(&ShardOwnershipLostError{}, err)
+}
+
+func (s *inMemoryPersistenceSuite) TestTransferTasksPaging() {
+	domainID := uuid.New()
+	for i := 0; i < 3; i++ {
+		_, err := s.CreateWorkflowExecution(domainID, gen.WorkflowExecution{
+			WorkflowId: common.StringPtr(uuid.New()),
+			RunId:      common.StringPtr(uuid.New()),
+		}, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+		s.NoError(err)
+	}
+
+	tasks, err := s.GetTransferTasks(2, false)
+	s.NoError(err)
+	s.Len(tasks, 2)
+	s.Equal(transferTaskTransferTargetWorkflowID, tasks[0].TargetWorkflowID)
+
+	tasks, err = s.GetTransferTasks(2, true)
+	s.NoError(err)
+	s.Len(tasks, 1)
+	s.NoError(s.CompleteTransferTask(tasks[0].TaskID))
+}
+
+func (s *inMemoryPersistenceSuite) TestRangeCompleteReplicationTasks() {
+	domainID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("in-memory-range-complete-replication-tasks-test"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	_, err := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err)
+	state, err := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err)
+
+	var replicationTasks []Task
+	for i := 0; i < 3; i++ {
+		replicationTasks = append(replicationTasks, &HistoryReplicationTask{
+			TaskID:       s.GetNextSequenceNumber(),
+			FirstEventID: int64(1),
+			NextEventID:  int64(3),
+			Version:      int64(i),
+		})
+	}
+	err = s.UpdateWorklowStateAndReplication(state.ExecutionInfo, nil, nil, nil, int64(3), replicationTasks)
+	s.NoError(err)
+
+	readLevel := s.GetReplicationReadLevel()
+	tasks, err := s.GetReplicationTasks(10, true)
+	s.NoError(err)
+	s.Len(tasks, 3)
+
+	// the tasks after the end of the range are kept
+	s.NoError(s.RangeCompleteReplicationTask(tasks[1].TaskID))
+	s.SetReplicationReadLevel(readLevel)
+	remaining, err := s.GetReplicationTasks(10, true)
+	s.NoError(err)
+	s.Len(remaining, 1)
+	s.Equal(tasks[2].TaskID, remaining[0].TaskID)
+
+	s.NoError(s.RangeCompleteReplicationTask(tasks[2].TaskID))
+	s.SetReplicationReadLevel(readLevel)
+	remaining, err = s.GetReplicationTasks(10, true)
+	s.NoError(err)
+	s.Empty(remaining)
+}
+
+func (s *inMemoryPersistenceSuite) TestTaskListRange() {
+	domainID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("in-memory-task-list-test"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	_, err := s.CreateDecisionTask(domainID, workflowExecution, "tasklist", 2)
+	s.NoError(err)
+
+	response, err := s.TaskMgr.LeaseTaskList(&LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: "tasklist",
+		TaskType: TaskListTypeDecision,
+	})
+	s.NoError(err)
+	stale := *response.TaskListInfo
+	_, err = s.TaskMgr.LeaseTaskList(&LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: "tasklist",
+		TaskType: TaskListTypeDecision,
+	})
+	s.NoError(err)
+
+	_, err = s.TaskMgr.CreateTasks(&CreateTasksRequest{
+		TaskListInfo: &stale,
+		Tasks: []*CreateTaskInfo{{
+			TaskID:    s.GetNextSequenceNumber(),
+			Execution: workflowExecution,
+			Data:      &TaskInfo{ScheduleID: 3},
+		}},
+	})
+	s.IsType(&ConditionFailedError{}, err)
+
+	tasks, err := s.GetTasks(domainID, "tasklist", TaskListTypeDecision, 10)
+	s.NoError(err)
+	s.Len(tasks.Tasks, 1)
+	s.Equal(int64(2), tasks.Tasks[0].ScheduleID)
+
+	listResponse, err := s.TaskMgr.ListTaskList(&ListTaskListRequest{DomainID: domainID, PageSize: 10})
+	s.NoError(err)
+	s.Len(listResponse.Items, 1)
+	s.Empty(listResponse.NextPageToken)
+}
+
+func (s *inMemoryPersistenceSuite) TestHistory() {
+	domainID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("in-memory-history-test"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	for i := int64(1); i <= 3; i++ {
+		err := s.HistoryMgr.AppendHistoryEvents(&AppendHistoryEventsRequest{
+			DomainID:      domainID,
+			Execution:     workflowExecution,
+			FirstEventID:  i,
+			TransactionID: i,
+			Events:        NewSerializedHistoryEventBatch([]byte{byte(i)}, common.EncodingTypeJSON, 1),
+		})
+		s.NoError(err)
+	}
+
+	err := s.HistoryMgr.AppendHistoryEvents(&AppendHistoryEventsRequest{
+		DomainID:      domainID,
+		Execution:     workflowExecution,
+		FirstEventID:  2,
+		TransactionID: 4,
+		Events:        NewSerializedHistoryEventBatch([]byte{4}, common.EncodingTypeJSON, 1),
+	})
+	s.IsType(&ConditionFailedError{}, err)
+
+	err = s.HistoryMgr.AppendHistoryEvents(&AppendHistoryEventsRequest{
+		DomainID:      domainID,
+		Execution:     workflowExecution,
+		FirstEventID:  2,
+		TransactionID: 4,
+		Events:        NewSerializedHistoryEventBatch([]byte{4}, common.EncodingTypeJSON, 1),
+		Overwrite:     true,
+	})
+	s.NoError(err)
+
+	var data []byte
+	var token []byte
+	for {
+		response, err := s.HistoryMgr.GetWorkflowExecutionHistory(&GetWorkflowExecutionHistoryRequest{
+			DomainID:      domainID,
+			Execution:     workflowExecution,
+			FirstEventID:  1,
+			NextEventID:   4,
+			PageSize:      2,
+			NextPageToken: token,
+		})
+		s.NoError(err)
+		for _, batch := range response.Events {
+			data = append(data, batch.Data...)
+		}
+		token = response.NextPageToken
+		if len(token) == 0 {
+			break
+		}
+	}
+	s.Equal([]byte{1, 4, 3}, data)
+
+	_, err = s.HistoryMgr.GetWorkflowExecutionHistory(&GetWorkflowExecutionHistoryRequest{
+		DomainID:     domainID,
+		Execution:    workflowExecution,
+		FirstEventID: 4,
+		NextEventID:  5,
+		PageSize:     2,
+	})
+	s.IsType(&gen.EntityNotExistsError{}, err)
+}
+
+func (s *inMemoryPersistenceSuite) TestUpdateDomain() {
+	_, err := s.MetadataManager.CreateDomain(&CreateDomainRequest{
+		Info:              &DomainInfo{ID: uuid.New(), Name: "in-memory-domain-test"},
+		Config:            &DomainConfig{Retention: 1},
+		ReplicationConfig: &DomainReplicationConfig{},
+	})
+	s.NoError(err)
+
+	_, err = s.MetadataManager.CreateDomain(&CreateDomainRequest{
+		Info:              &DomainInfo{ID: uuid.New(), Name: "in-memory-domain-test"},
+		Config:            &DomainConfig{Retention: 1},
+		ReplicationConfig: &DomainReplicationConfig{},
+	})
+	s.IsType(&gen.DomainAlreadyExistsError{}, err)
+
+	domain, err := s.MetadataManager.GetDomain(&GetDomainRequest{Name: "in-memory-domain-test"})
+	s.NoError(err)
+	s.Equal(cluster.TestCurrentClusterName, domain.ReplicationConfig.ActiveClusterName)
+	metadata, err := s.MetadataManager.GetMetadata()
+	s.NoError(err)
+
+	update := &UpdateDomainRequest{
+		Info:                domain.Info,
+		Config:              &DomainConfig{Retention: 2},
+		ReplicationConfig:   domain.ReplicationConfig,
+		NotificationVersion: metadata.NotificationVersion,
+	}
+	s.NoError(s.MetadataManager.UpdateDomain(update))
+	// the notification version moved on with the first update
+	s.IsType(&gen.InternalServiceError{}, s.MetadataManager.UpdateDomain(update))
+
+	domain, err = s.MetadataManager.GetDomain(&GetDomainRequest{ID: domain.Info.ID})
+	s.NoError(err)
+	s.Equal(int32(2), domain.Config.Retention)
+}
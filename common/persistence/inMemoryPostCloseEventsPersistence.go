@@ -0,0 +1,107 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/uber-common/bark"
+)
+
+type (
+	inMemoryPostCloseEventsPersistence struct {
+		sync.Mutex
+		// execution -> batches
+		batches map[inMemoryRunKey]map[inMemoryPostCloseEventBatchKey]*PostCloseEventBatch
+		logger  bark.Logger
+	}
+
+	inMemoryPostCloseEventBatchKey struct {
+		version      int64
+		firstEventID int64
+	}
+)
+
+var _ PostCloseEventsManager = (*inMemoryPostCloseEventsPersistence)(nil)
+
+// NewInMemoryPostCloseEventsPersistence creates a post close events manager keeping the events recorded for the
+// closed runs of a cluster in memory
+func NewInMemoryPostCloseEventsPersistence(logger bark.Logger) PostCloseEventsManager {
+	return &inMemoryPostCloseEventsPersistence{
+		batches: make(map[inMemoryRunKey]map[inMemoryPostCloseEventBatchKey]*PostCloseEventBatch),
+		logger:  logger,
+	}
+}
+
+func (m *inMemoryPostCloseEventsPersistence) Close() {
+}
+
+// RecordPostCloseEvents overwrites the batch when the same events are recorded again, the TTL is not enforced
+func (m *inMemoryPostCloseEventsPersistence) RecordPostCloseEvents(request *RecordPostCloseEventsRequest) error {
+	m.Lock()
+	defer m.Unlock()
+
+	key := inMemoryRunKey{request.DomainID, request.Execution.GetWorkflowId(), request.Execution.GetRunId()}
+	batches, ok := m.batches[key]
+	if !ok {
+		batches = make(map[inMemoryPostCloseEventBatchKey]*PostCloseEventBatch)
+		m.batches[key] = batches
+	}
+	batches[inMemoryPostCloseEventBatchKey{request.Batch.Version, request.Batch.FirstEventID}] =
+		clonePostCloseEventBatch(&request.Batch)
+	return nil
+}
+
+func (m *inMemoryPostCloseEventsPersistence) ListPostCloseEvents(request *ListPostCloseEventsRequest) (
+	*ListPostCloseEventsResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	batches := m.batches[inMemoryRunKey{request.DomainID, request.Execution.GetWorkflowId(),
+		request.Execution.GetRunId()}]
+	var keys []inMemoryPostCloseEventBatchKey
+	for key := range batches {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].version != keys[j].version {
+			return keys[i].version < keys[j].version
+		}
+		return keys[i].firstEventID < keys[j].firstEventID
+	})
+
+	start, end, nextPageToken, err := getInMemoryPage(len(keys), request.PageSize, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	response := &ListPostCloseEventsResponse{NextPageToken: nextPageToken}
+	for _, key := range keys[start:end] {
+		response.Batches = append(response.Batches, clonePostCloseEventBatch(batches[key]))
+	}
+	return response, nil
+}
+
+func clonePostCloseEventBatch(batch *PostCloseEventBatch) *PostCloseEventBatch {
+	copied := *batch
+	copied.Events = cloneSerializedHistoryEventBatch(batch.Events)
+	return &copied
+}
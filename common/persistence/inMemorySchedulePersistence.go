@@ -0,0 +1,146 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+type (
+	inMemorySchedulePersistence struct {
+		sync.Mutex
+		// domain ID -> schedule ID -> schedule
+		schedules map[string]map[string]*ScheduleInfo
+		logger    bark.Logger
+	}
+)
+
+var _ ScheduleManager = (*inMemorySchedulePersistence)(nil)
+
+// NewInMemorySchedulePersistence creates a schedule manager keeping the schedules of a cluster in memory
+func NewInMemorySchedulePersistence(logger bark.Logger) ScheduleManager {
+	return &inMemorySchedulePersistence{
+		schedules: make(map[string]map[string]*ScheduleInfo),
+		logger:    logger,
+	}
+}
+
+func (m *inMemorySchedulePersistence) Close() {
+}
+
+func (m *inMemorySchedulePersistence) CreateSchedule(request *CreateScheduleRequest) error {
+	m.Lock()
+	defer m.Unlock()
+
+	schedule := request.Schedule
+	schedules, ok := m.schedules[schedule.DomainID]
+	if !ok {
+		schedules = make(map[string]*ScheduleInfo)
+		m.schedules[schedule.DomainID] = schedules
+	}
+	if _, ok := schedules[schedule.ScheduleID]; ok {
+		return &ConditionFailedError{
+			Msg: fmt.Sprintf("Schedule already exists. ScheduleId: %v", schedule.ScheduleID),
+		}
+	}
+
+	schedules[schedule.ScheduleID] = cloneScheduleInfo(schedule)
+	return nil
+}
+
+func (m *inMemorySchedulePersistence) GetSchedule(request *GetScheduleRequest) (*GetScheduleResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	schedule, ok := m.schedules[request.DomainID][request.ScheduleID]
+	if !ok {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Schedule not found. ScheduleId: %v", request.ScheduleID),
+		}
+	}
+
+	return &GetScheduleResponse{Schedule: cloneScheduleInfo(schedule)}, nil
+}
+
+func (m *inMemorySchedulePersistence) UpdateSchedule(request *UpdateScheduleRequest) error {
+	m.Lock()
+	defer m.Unlock()
+
+	schedule := request.Schedule
+	previous, ok := m.schedules[schedule.DomainID][schedule.ScheduleID]
+	if !ok || previous.Version != request.PreviousVersion {
+		var actualVersion interface{}
+		if ok {
+			actualVersion = previous.Version
+		}
+		return &ConditionFailedError{
+			Msg: fmt.Sprintf("Failed to update schedule. ScheduleId: %v, previous version: %v, actual version: %v",
+				schedule.ScheduleID, request.PreviousVersion, actualVersion),
+		}
+	}
+
+	m.schedules[schedule.DomainID][schedule.ScheduleID] = cloneScheduleInfo(schedule)
+	return nil
+}
+
+func (m *inMemorySchedulePersistence) DeleteSchedule(request *DeleteScheduleRequest) error {
+	m.Lock()
+	defer m.Unlock()
+
+	delete(m.schedules[request.DomainID], request.ScheduleID)
+	return nil
+}
+
+func (m *inMemorySchedulePersistence) ListSchedules(request *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	schedules := m.schedules[request.DomainID]
+	var scheduleIDs []string
+	for scheduleID := range schedules {
+		scheduleIDs = append(scheduleIDs, scheduleID)
+	}
+	sort.Strings(scheduleIDs)
+
+	start, end, nextPageToken, err := getInMemoryPage(len(scheduleIDs), request.PageSize, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	response := &ListSchedulesResponse{NextPageToken: nextPageToken}
+	for _, scheduleID := range scheduleIDs[start:end] {
+		response.Schedules = append(response.Schedules, cloneScheduleInfo(schedules[scheduleID]))
+	}
+	return response, nil
+}
+
+func cloneScheduleInfo(schedule *ScheduleInfo) *ScheduleInfo {
+	copied := *schedule
+	copied.Input = cloneBytes(schedule.Input)
+	copied.BufferedRunTimes = append([]time.Time(nil), schedule.BufferedRunTimes...)
+	return &copied
+}
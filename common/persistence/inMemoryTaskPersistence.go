@@ -0,0 +1,315 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+type (
+	inMemoryTaskPersistence struct {
+		sync.Mutex
+		taskLists map[inMemoryTaskListKey]*inMemoryTaskList
+		// domain ID -> task lists indexed for the domain
+		domainIndex map[string]map[inMemoryTaskListKey]struct{}
+		logger      bark.Logger
+	}
+
+	inMemoryTaskListKey struct {
+		domainID string
+		name     string
+		taskType int
+	}
+
+	// inMemoryTaskList is the partition of a task list, info is nil until the task list is leased
+	inMemoryTaskList struct {
+		info *TaskListInfo
+		// priority level -> task ID -> task
+		tasks    map[int]map[int64]*inMemoryTask
+		dlqTasks map[int64]*TaskInfo
+	}
+
+	inMemoryTask struct {
+		info *TaskInfo
+		// zero when the task does not expire
+		expiry time.Time
+	}
+)
+
+var _ TaskManager = (*inMemoryTaskPersistence)(nil)
+
+// NewInMemoryTaskPersistence creates a task manager keeping the task lists of a cluster in memory
+func NewInMemoryTaskPersistence(logger bark.Logger) TaskManager {
+	return &inMemoryTaskPersistence{
+		taskLists:   make(map[inMemoryTaskListKey]*inMemoryTaskList),
+		domainIndex: make(map[string]map[inMemoryTaskListKey]struct{}),
+		logger:      logger,
+	}
+}
+
+func (d *inMemoryTaskPersistence) Close() {
+}
+
+func (d *inMemoryTaskPersistence) getTaskList(domainID, name string, taskType int) *inMemoryTaskList {
+	key := inMemoryTaskListKey{domainID, name, taskType}
+	taskList, ok := d.taskLists[key]
+	if !ok {
+		taskList = &inMemoryTaskList{
+			tasks:    make(map[int]map[int64]*inMemoryTask),
+			dlqTasks: make(map[int64]*TaskInfo),
+		}
+		d.taskLists[key] = taskList
+	}
+	return taskList
+}
+
+func (d *inMemoryTaskPersistence) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
+	if len(request.TaskList) == 0 {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("LeaseTaskList requires non empty task list"),
+		}
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	taskList := d.getTaskList(request.DomainID, request.TaskList, request.TaskType)
+	if taskList.info == nil { // First time task list is used
+		taskList.info = &TaskListInfo{
+			DomainID: request.DomainID,
+			Name:     request.TaskList,
+			TaskType: request.TaskType,
+			RangeID:  initialRangeID,
+			Kind:     request.TaskListKind,
+		}
+	} else {
+		taskList.info.RangeID++
+	}
+
+	tli := cloneTaskListInfo(taskList.info)
+	tli.Kind = request.TaskListKind
+	// the task list is indexed on every lease, as the Cassandra persistence does
+	key := inMemoryTaskListKey{request.DomainID, request.TaskList, request.TaskType}
+	if _, ok := d.domainIndex[request.DomainID]; !ok {
+		d.domainIndex[request.DomainID] = make(map[inMemoryTaskListKey]struct{})
+	}
+	d.domainIndex[request.DomainID][key] = struct{}{}
+	return &LeaseTaskListResponse{TaskListInfo: tli}, nil
+}
+
+func (d *inMemoryTaskPersistence) UpdateTaskList(request *UpdateTaskListRequest) (*UpdateTaskListResponse, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	tli := request.TaskListInfo
+	taskList := d.getTaskList(tli.DomainID, tli.Name, tli.TaskType)
+	// sticky task lists are updated without checking their range
+	if tli.Kind != TaskListKindSticky && (taskList.info == nil || taskList.info.RangeID != tli.RangeID) {
+		return nil, &ConditionFailedError{
+			Msg: fmt.Sprintf("Failed to update task list. name: %v, type: %v, rangeID: %v, db rangeID: %v",
+				tli.Name, tli.TaskType, tli.RangeID, getInMemoryTaskListRangeID(taskList)),
+		}
+	}
+
+	taskList.info = cloneTaskListInfo(tli)
+	return &UpdateTaskListResponse{}, nil
+}
+
+func (d *inMemoryTaskPersistence) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	tli := request.TaskListInfo
+	taskList := d.getTaskList(tli.DomainID, tli.Name, tli.TaskType)
+	if taskList.info == nil || taskList.info.RangeID != tli.RangeID {
+		return nil, &ConditionFailedError{
+			Msg: fmt.Sprintf("Failed to create task. TaskList: %v, taskListType: %v, rangeID: %v, db rangeID: %v",
+				tli.Name, tli.TaskType, tli.RangeID, getInMemoryTaskListRangeID(taskList)),
+		}
+	}
+
+	now := time.Now()
+	for _, task := range request.Tasks {
+		level := TaskPriorityLevel(task.Data.Priority)
+		if _, ok := taskList.tasks[level]; !ok {
+			taskList.tasks[level] = make(map[int64]*inMemoryTask)
+		}
+		stored := &inMemoryTask{
+			info: &TaskInfo{
+				DomainID:         tli.DomainID,
+				WorkflowID:       task.Execution.GetWorkflowId(),
+				RunID:            task.Execution.GetRunId(),
+				TaskID:           task.TaskID,
+				ScheduleID:       task.Data.ScheduleID,
+				IsolationGroup:   task.Data.IsolationGroup,
+				DispatchAttempts: task.Data.DispatchAttempts,
+				Priority:         task.Data.Priority,
+				CreatedTime:      task.Data.CreatedTime,
+			},
+		}
+		if task.Data.ScheduleToStartTimeout > 0 {
+			stored.expiry = now.Add(time.Duration(task.Data.ScheduleToStartTimeout) * time.Second)
+		}
+		taskList.tasks[level][task.TaskID] = stored
+	}
+	taskList.info.AckLevel = tli.AckLevel
+	taskList.info.Kind = tli.Kind
+
+	return &CreateTasksResponse{}, nil
+}
+
+func (d *inMemoryTaskPersistence) GetTasks(request *GetTasksRequest) (*GetTasksResponse, error) {
+	if request.ReadLevel > request.MaxReadLevel {
+		return &GetTasksResponse{}, nil
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	now := time.Now()
+	tasks := d.getTaskList(request.DomainID, request.TaskList, request.TaskType).tasks[request.PriorityLevel]
+	var taskIDs []int64
+	for taskID, task := range tasks {
+		expired := !task.expiry.IsZero() && !now.Before(task.expiry)
+		if taskID > request.ReadLevel && taskID <= request.MaxReadLevel && !expired {
+			taskIDs = append(taskIDs, taskID)
+		}
+	}
+	sort.Slice(taskIDs, func(i, j int) bool { return taskIDs[i] < taskIDs[j] })
+	if request.BatchSize > 0 && len(taskIDs) > request.BatchSize {
+		taskIDs = taskIDs[:request.BatchSize]
+	}
+
+	response := &GetTasksResponse{}
+	for _, taskID := range taskIDs {
+		task := *tasks[taskID].info
+		response.Tasks = append(response.Tasks, &task)
+	}
+	return response, nil
+}
+
+func (d *inMemoryTaskPersistence) CompleteTask(request *CompleteTaskRequest) error {
+	d.Lock()
+	defer d.Unlock()
+
+	tli := request.TaskList
+	delete(d.getTaskList(tli.DomainID, tli.Name, tli.TaskType).tasks[request.PriorityLevel], request.TaskID)
+	return nil
+}
+
+func (d *inMemoryTaskPersistence) CreateDLQTask(request *CreateDLQTaskRequest) error {
+	d.Lock()
+	defer d.Unlock()
+
+	// DLQ tasks share the partition of their task list, but are not covered by its range
+	tli := request.TaskList
+	task := *request.Task
+	task.DomainID = tli.DomainID
+	task.ScheduleToStartTimeout = 0
+	d.getTaskList(tli.DomainID, tli.Name, tli.TaskType).dlqTasks[task.TaskID] = &task
+	return nil
+}
+
+func (d *inMemoryTaskPersistence) GetDLQTasks(request *GetDLQTasksRequest) (*GetDLQTasksResponse, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	dlqTasks := d.getTaskList(request.DomainID, request.TaskList, request.TaskType).dlqTasks
+	var taskIDs []int64
+	for taskID := range dlqTasks {
+		taskIDs = append(taskIDs, taskID)
+	}
+	sort.Slice(taskIDs, func(i, j int) bool { return taskIDs[i] < taskIDs[j] })
+
+	start, end, nextPageToken, err := getInMemoryPage(len(taskIDs), request.PageSize, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	response := &GetDLQTasksResponse{NextPageToken: nextPageToken}
+	for _, taskID := range taskIDs[start:end] {
+		task := *dlqTasks[taskID]
+		response.Tasks = append(response.Tasks, &task)
+	}
+	return response, nil
+}
+
+func (d *inMemoryTaskPersistence) DeleteDLQTask(request *DeleteDLQTaskRequest) error {
+	d.Lock()
+	defer d.Unlock()
+
+	tli := request.TaskList
+	delete(d.getTaskList(tli.DomainID, tli.Name, tli.TaskType).dlqTasks, request.TaskID)
+	return nil
+}
+
+func (d *inMemoryTaskPersistence) ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	var keys []inMemoryTaskListKey
+	for key := range d.domainIndex[request.DomainID] {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].taskType < keys[j].taskType
+	})
+
+	start, end, nextPageToken, err := getInMemoryPage(len(keys), request.PageSize, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	response := &ListTaskListResponse{NextPageToken: nextPageToken}
+	for _, key := range keys[start:end] {
+		taskList, ok := d.taskLists[key]
+		if !ok || taskList.info == nil {
+			continue
+		}
+		response.Items = append(response.Items, cloneTaskListInfo(taskList.info))
+	}
+	return response, nil
+}
+
+func getInMemoryTaskListRangeID(taskList *inMemoryTaskList) interface{} {
+	if taskList.info == nil {
+		return nil
+	}
+	return taskList.info.RangeID
+}
+
+func cloneTaskListInfo(info *TaskListInfo) *TaskListInfo {
+	copied := *info
+	copied.Pollers = nil
+	for _, poller := range info.Pollers {
+		p := *poller
+		copied.Pollers = append(copied.Pollers, &p)
+	}
+	return &copied
+}
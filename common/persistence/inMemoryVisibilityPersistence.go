@@ -0,0 +1,405 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+type (
+	inMemoryVisibilityPersistence struct {
+		sync.Mutex
+		// domain ID -> run ID -> record
+		openRecords   map[string]map[string]*inMemoryVisibilityRecord
+		closedRecords map[string]map[string]*inMemoryVisibilityRecord
+		logger        bark.Logger
+	}
+
+	// inMemoryVisibilityRecord keeps its timestamps as precise as Cassandra does, in nanoseconds truncated to
+	// milliseconds
+	inMemoryVisibilityRecord struct {
+		workflowID         string
+		runID              string
+		workflowTypeName   string
+		startTimestamp     int64
+		closeTimestamp     int64
+		closeBucket        int64
+		executionTimestamp int64
+		duration           int64
+		status             workflow.WorkflowExecutionCloseStatus
+		historyLength      int64
+		tags               map[string]string
+		firstRunID         string
+	}
+)
+
+var _ VisibilityManager = (*inMemoryVisibilityPersistence)(nil)
+
+// NewInMemoryVisibilityPersistence creates a visibility manager keeping the visibility records of a cluster in
+// memory
+func NewInMemoryVisibilityPersistence(logger bark.Logger) VisibilityManager {
+	return &inMemoryVisibilityPersistence{
+		openRecords:   make(map[string]map[string]*inMemoryVisibilityRecord),
+		closedRecords: make(map[string]map[string]*inMemoryVisibilityRecord),
+		logger:        logger,
+	}
+}
+
+func (v *inMemoryVisibilityPersistence) Close() {
+}
+
+func (v *inMemoryVisibilityPersistence) RecordWorkflowExecutionStarted(
+	request *RecordWorkflowExecutionStartedRequest) error {
+	v.Lock()
+	defer v.Unlock()
+
+	v.getRecords(v.openRecords, request.DomainUUID)[request.Execution.GetRunId()] = &inMemoryVisibilityRecord{
+		workflowID:       request.Execution.GetWorkflowId(),
+		runID:            request.Execution.GetRunId(),
+		workflowTypeName: request.WorkflowTypeName,
+		startTimestamp:   getInMemoryVisibilityTimestamp(request.StartTimestamp),
+		tags:             cloneTags(request.Tags),
+		firstRunID:       request.FirstRunID,
+	}
+	return nil
+}
+
+func (v *inMemoryVisibilityPersistence) RecordWorkflowExecutionClosed(
+	request *RecordWorkflowExecutionClosedRequest) error {
+	v.Lock()
+	defer v.Unlock()
+
+	startTimestamp := getInMemoryVisibilityTimestamp(request.StartTimestamp)
+	runID := request.Execution.GetRunId()
+	v.deleteRecord(v.openRecords, request.DomainUUID, runID, startTimestamp)
+
+	executionTimestamp := request.ExecutionTimestamp
+	if executionTimestamp == 0 {
+		executionTimestamp = request.StartTimestamp
+	}
+	v.getRecords(v.closedRecords, request.DomainUUID)[runID] = &inMemoryVisibilityRecord{
+		workflowID:         request.Execution.GetWorkflowId(),
+		runID:              runID,
+		workflowTypeName:   request.WorkflowTypeName,
+		startTimestamp:     startTimestamp,
+		closeTimestamp:     getInMemoryVisibilityTimestamp(request.CloseTimestamp),
+		closeBucket:        getCloseBucket(request.CloseTimestamp),
+		executionTimestamp: getInMemoryVisibilityTimestamp(executionTimestamp),
+		duration:           request.CloseTimestamp - executionTimestamp,
+		status:             request.Status,
+		historyLength:      request.HistoryLength,
+		tags:               cloneTags(request.Tags),
+		firstRunID:         request.FirstRunID,
+	}
+	return nil
+}
+
+func (v *inMemoryVisibilityPersistence) ListOpenWorkflowExecutions(
+	request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	return v.listWorkflowExecutions(request, false, func(*inMemoryVisibilityRecord) bool { return true })
+}
+
+func (v *inMemoryVisibilityPersistence) ListClosedWorkflowExecutions(
+	request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	return v.listWorkflowExecutions(request, true, func(*inMemoryVisibilityRecord) bool { return true })
+}
+
+func (v *inMemoryVisibilityPersistence) ListOpenWorkflowExecutionsByType(
+	request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	return v.listWorkflowExecutions(&request.ListWorkflowExecutionsRequest, false,
+		func(record *inMemoryVisibilityRecord) bool {
+			return record.workflowTypeName == request.WorkflowTypeName
+		})
+}
+
+func (v *inMemoryVisibilityPersistence) ListClosedWorkflowExecutionsByType(
+	request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	return v.listWorkflowExecutions(&request.ListWorkflowExecutionsRequest, true,
+		func(record *inMemoryVisibilityRecord) bool {
+			return record.workflowTypeName == request.WorkflowTypeName
+		})
+}
+
+func (v *inMemoryVisibilityPersistence) ListOpenWorkflowExecutionsByWorkflowID(
+	request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	return v.listWorkflowExecutions(&request.ListWorkflowExecutionsRequest, false,
+		func(record *inMemoryVisibilityRecord) bool { return record.workflowID == request.WorkflowID })
+}
+
+func (v *inMemoryVisibilityPersistence) ListClosedWorkflowExecutionsByWorkflowID(
+	request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	return v.listWorkflowExecutions(&request.ListWorkflowExecutionsRequest, true,
+		func(record *inMemoryVisibilityRecord) bool { return record.workflowID == request.WorkflowID })
+}
+
+func (v *inMemoryVisibilityPersistence) ListClosedWorkflowExecutionsByStatus(
+	request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
+	return v.listWorkflowExecutions(&request.ListWorkflowExecutionsRequest, true,
+		func(record *inMemoryVisibilityRecord) bool { return record.status == request.Status })
+}
+
+func (v *inMemoryVisibilityPersistence) ListOpenWorkflowExecutionsByTag(
+	request *ListWorkflowExecutionsByTagRequest) (*ListWorkflowExecutionsResponse, error) {
+	return v.listWorkflowExecutions(&request.ListWorkflowExecutionsRequest, false,
+		func(record *inMemoryVisibilityRecord) bool {
+			return hasInMemoryTag(record, request.TagKey, request.TagValue)
+		})
+}
+
+func (v *inMemoryVisibilityPersistence) ListClosedWorkflowExecutionsByTag(
+	request *ListWorkflowExecutionsByTagRequest) (*ListWorkflowExecutionsResponse, error) {
+	return v.listWorkflowExecutions(&request.ListWorkflowExecutionsRequest, true,
+		func(record *inMemoryVisibilityRecord) bool {
+			return hasInMemoryTag(record, request.TagKey, request.TagValue)
+		})
+}
+
+func (v *inMemoryVisibilityPersistence) ListOpenWorkflowExecutionsByFirstRunID(
+	request *ListWorkflowExecutionsByFirstRunIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	return v.listWorkflowExecutions(&request.ListWorkflowExecutionsRequest, false,
+		func(record *inMemoryVisibilityRecord) bool { return record.firstRunID == request.FirstRunID })
+}
+
+func (v *inMemoryVisibilityPersistence) ListClosedWorkflowExecutionsByFirstRunID(
+	request *ListWorkflowExecutionsByFirstRunIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	return v.listWorkflowExecutions(&request.ListWorkflowExecutionsRequest, true,
+		func(record *inMemoryVisibilityRecord) bool { return record.firstRunID == request.FirstRunID })
+}
+
+// ListAllWorkflowExecutions lists the open executions first, then fills the page up with the closed ones, the page
+// token tells which of them the next page continues with
+func (v *inMemoryVisibilityPersistence) ListAllWorkflowExecutions(
+	request *ListAllWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	token := &allExecutionsPageToken{}
+	if len(request.NextPageToken) > 0 {
+		if err := json.Unmarshal(request.NextPageToken, token); err != nil {
+			return nil, &workflow.BadRequestError{
+				Message: fmt.Sprintf("ListAllWorkflowExecutions operation failed. Invalid next page token: %v", err),
+			}
+		}
+	}
+
+	filter := func(record *inMemoryVisibilityRecord) bool {
+		switch {
+		case request.WorkflowID != "":
+			return record.workflowID == request.WorkflowID
+		case request.WorkflowTypeName != "":
+			return record.workflowTypeName == request.WorkflowTypeName
+		case request.TagKey != "":
+			return hasInMemoryTag(record, request.TagKey, request.TagValue)
+		case request.FirstRunID != "":
+			return record.firstRunID == request.FirstRunID
+		}
+		return true
+	}
+
+	response := &ListWorkflowExecutionsResponse{}
+	response.Executions = make([]*workflow.WorkflowExecutionInfo, 0)
+	if !token.Closed {
+		page := request.ListWorkflowExecutionsRequest
+		page.NextPageToken = token.PageToken
+		openResponse, err := v.listWorkflowExecutions(&page, false, filter)
+		if err != nil {
+			return nil, err
+		}
+		response.Executions = append(response.Executions, openResponse.Executions...)
+		if len(openResponse.NextPageToken) > 0 {
+			return setAllExecutionsPageToken(response, false, openResponse.NextPageToken)
+		}
+		token = &allExecutionsPageToken{Closed: true}
+	}
+
+	if len(response.Executions) >= request.PageSize {
+		return setAllExecutionsPageToken(response, true, nil)
+	}
+
+	page := request.ListWorkflowExecutionsRequest
+	page.PageSize -= len(response.Executions)
+	page.NextPageToken = token.PageToken
+	closedResponse, err := v.listWorkflowExecutions(&page, true, filter)
+	if err != nil {
+		return nil, err
+	}
+	response.Executions = append(response.Executions, closedResponse.Executions...)
+	if len(closedResponse.NextPageToken) > 0 {
+		return setAllExecutionsPageToken(response, true, closedResponse.NextPageToken)
+	}
+	return response, nil
+}
+
+func (v *inMemoryVisibilityPersistence) GetClosedWorkflowExecution(
+	request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
+	v.Lock()
+	defer v.Unlock()
+
+	execution := request.Execution
+	record, ok := v.closedRecords[request.DomainUUID][execution.GetRunId()]
+	if !ok || record.workflowID != execution.GetWorkflowId() {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v, RunId: %v",
+				execution.GetWorkflowId(), execution.GetRunId()),
+		}
+	}
+
+	return &GetClosedWorkflowExecutionResponse{
+		Execution: record.toWorkflowExecutionInfo(true),
+	}, nil
+}
+
+func (v *inMemoryVisibilityPersistence) DeleteWorkflowExecution(
+	request *VisibilityDeleteWorkflowExecutionRequest) error {
+	v.Lock()
+	defer v.Unlock()
+
+	startTimestamp := getInMemoryVisibilityTimestamp(request.StartTimestamp)
+	v.deleteRecord(v.openRecords, request.DomainUUID, request.RunID, startTimestamp)
+	v.deleteRecord(v.closedRecords, request.DomainUUID, request.RunID, startTimestamp)
+	return nil
+}
+
+// PruneClosedWorkflowExecutions drops the close buckets of a domain which only hold records closed before the given
+// time, along with all their records
+func (v *inMemoryVisibilityPersistence) PruneClosedWorkflowExecutions(
+	request *PruneClosedWorkflowExecutionsRequest) (*PruneClosedWorkflowExecutionsResponse, error) {
+	v.Lock()
+	defer v.Unlock()
+
+	prunedBuckets := make(map[int64]struct{})
+	records := v.closedRecords[request.DomainUUID]
+	for runID, record := range records {
+		if record.closeBucket+closedExecutionBucketSize <= request.CloseTimeBefore {
+			prunedBuckets[record.closeBucket] = struct{}{}
+			delete(records, runID)
+		}
+	}
+
+	return &PruneClosedWorkflowExecutionsResponse{PrunedBuckets: len(prunedBuckets)}, nil
+}
+
+// listWorkflowExecutions reads a page of the records started within the time range of the request. The open
+// executions are ordered by their start time, the closed ones by their close bucket first, newest first.
+func (v *inMemoryVisibilityPersistence) listWorkflowExecutions(request *ListWorkflowExecutionsRequest,
+	closed bool, filter func(*inMemoryVisibilityRecord) bool) (*ListWorkflowExecutionsResponse, error) {
+	v.Lock()
+	defer v.Unlock()
+
+	records := v.openRecords[request.DomainUUID]
+	if closed {
+		records = v.closedRecords[request.DomainUUID]
+	}
+	earliestStartTime := getInMemoryVisibilityTimestamp(request.EarliestStartTime)
+	latestStartTime := getInMemoryVisibilityTimestamp(request.LatestStartTime)
+
+	var matches []*inMemoryVisibilityRecord
+	for _, record := range records {
+		if record.startTimestamp >= earliestStartTime && record.startTimestamp <= latestStartTime && filter(record) {
+			matches = append(matches, record)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].closeBucket != matches[j].closeBucket {
+			return matches[i].closeBucket > matches[j].closeBucket
+		}
+		if matches[i].startTimestamp != matches[j].startTimestamp {
+			return matches[i].startTimestamp > matches[j].startTimestamp
+		}
+		return matches[i].runID < matches[j].runID
+	})
+
+	start, end, nextPageToken, err := getInMemoryPage(len(matches), request.PageSize, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	response := &ListWorkflowExecutionsResponse{NextPageToken: nextPageToken}
+	response.Executions = make([]*workflow.WorkflowExecutionInfo, 0, end-start)
+	for _, record := range matches[start:end] {
+		response.Executions = append(response.Executions, record.toWorkflowExecutionInfo(closed))
+	}
+	return response, nil
+}
+
+func (v *inMemoryVisibilityPersistence) getRecords(records map[string]map[string]*inMemoryVisibilityRecord,
+	domainID string) map[string]*inMemoryVisibilityRecord {
+	domainRecords, ok := records[domainID]
+	if !ok {
+		domainRecords = make(map[string]*inMemoryVisibilityRecord)
+		records[domainID] = domainRecords
+	}
+	return domainRecords
+}
+
+// deleteRecord deletes the record of a run, the records are keyed by their start time as well in Cassandra
+func (v *inMemoryVisibilityPersistence) deleteRecord(records map[string]map[string]*inMemoryVisibilityRecord,
+	domainID string, runID string, startTimestamp int64) {
+	if record, ok := records[domainID][runID]; ok && record.startTimestamp == startTimestamp {
+		delete(records[domainID], runID)
+	}
+}
+
+func (r *inMemoryVisibilityRecord) toWorkflowExecutionInfo(closed bool) *workflow.WorkflowExecutionInfo {
+	record := &workflow.WorkflowExecutionInfo{
+		Execution: &workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(r.workflowID),
+			RunId:      common.StringPtr(r.runID),
+		},
+		Type:      &workflow.WorkflowType{Name: common.StringPtr(r.workflowTypeName)},
+		StartTime: common.Int64Ptr(r.startTimestamp),
+		Tags:      cloneTags(r.tags),
+	}
+	if closed {
+		status := r.status
+		record.CloseTime = common.Int64Ptr(r.closeTimestamp)
+		record.CloseStatus = &status
+		record.HistoryLength = common.Int64Ptr(r.historyLength)
+		record.ExecutionTime = common.Int64Ptr(r.executionTimestamp)
+		record.ExecutionDuration = common.Int64Ptr(r.duration)
+	}
+	if r.firstRunID != "" {
+		record.FirstRunId = common.StringPtr(r.firstRunID)
+	}
+	return record
+}
+
+func hasInMemoryTag(record *inMemoryVisibilityRecord, key string, value string) bool {
+	tagValue, ok := record.tags[key]
+	return ok && tagValue == value
+}
+
+func getInMemoryVisibilityTimestamp(timestamp int64) int64 {
+	return common.UnixNanoToCQLTimestamp(timestamp) * int64(time.Millisecond)
+}
+
+func cloneTags(tags map[string]string) map[string]string {
+	copied := make(map[string]string)
+	for k, v := range tags {
+		copied[k] = v
+	}
+	return copied
+}
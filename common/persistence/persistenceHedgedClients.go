@@ -0,0 +1,188 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// hedgedReader runs idempotent reads a second time when the first attempt did not return within the hedge
+	// delay. The second attempt goes through the same session, whose host selection picks the next coordinator,
+	// so that a read stuck on a slow Cassandra node does not wait for the node.
+	hedgedReader struct {
+		hedgeDelay   dynamicconfig.DurationPropertyFn
+		metricClient metrics.Client
+		// newTimer starts the timer of the hedge delay, it returns the channel of the timer and its stop function
+		newTimer func(time.Duration) (<-chan time.Time, func() bool)
+	}
+
+	// hedgedResult is the result of one attempt of a hedged read
+	hedgedResult struct {
+		response interface{}
+		err      error
+		hedge    bool
+	}
+
+	historyHedgedPersistenceClient struct {
+		HistoryManager
+		reader *hedgedReader
+	}
+
+	workflowExecutionHedgedPersistenceClient struct {
+		ExecutionManager
+		reader *hedgedReader
+	}
+
+	hedgedPersistenceClientFactory struct {
+		ExecutionManagerFactory
+		reader *hedgedReader
+	}
+)
+
+var _ HistoryManager = (*historyHedgedPersistenceClient)(nil)
+var _ ExecutionManager = (*workflowExecutionHedgedPersistenceClient)(nil)
+var _ ExecutionManagerFactory = (*hedgedPersistenceClientFactory)(nil)
+
+// NewHistoryPersistenceHedgedClient creates a HistoryManager client which hedges the reads of history once they
+// take longer than the hedge delay, a zero delay disables hedging
+func NewHistoryPersistenceHedgedClient(persistence HistoryManager, hedgeDelay dynamicconfig.DurationPropertyFn,
+	metricClient metrics.Client) HistoryManager {
+	return &historyHedgedPersistenceClient{
+		HistoryManager: persistence,
+		reader:         newHedgedReader(hedgeDelay, metricClient),
+	}
+}
+
+// NewWorkflowExecutionPersistenceHedgedClient creates an ExecutionManager client which hedges the reads of timer
+// tasks once they take longer than the hedge delay, a zero delay disables hedging
+func NewWorkflowExecutionPersistenceHedgedClient(persistence ExecutionManager, hedgeDelay dynamicconfig.DurationPropertyFn,
+	metricClient metrics.Client) ExecutionManager {
+	return &workflowExecutionHedgedPersistenceClient{
+		ExecutionManager: persistence,
+		reader:           newHedgedReader(hedgeDelay, metricClient),
+	}
+}
+
+// NewHedgedPersistenceClientFactory creates a factory of the execution managers of the given factory wrapped by
+// NewWorkflowExecutionPersistenceHedgedClient
+func NewHedgedPersistenceClientFactory(factory ExecutionManagerFactory, hedgeDelay dynamicconfig.DurationPropertyFn,
+	metricClient metrics.Client) ExecutionManagerFactory {
+	return &hedgedPersistenceClientFactory{
+		ExecutionManagerFactory: factory,
+		reader:                  newHedgedReader(hedgeDelay, metricClient),
+	}
+}
+
+func newHedgedReader(hedgeDelay dynamicconfig.DurationPropertyFn, metricClient metrics.Client) *hedgedReader {
+	return &hedgedReader{
+		hedgeDelay:   hedgeDelay,
+		metricClient: metricClient,
+		newTimer: func(d time.Duration) (<-chan time.Time, func() bool) {
+			timer := time.NewTimer(d)
+			return timer.C, timer.Stop
+		},
+	}
+}
+
+func (f *hedgedPersistenceClientFactory) CreateExecutionManager(shardID int) (ExecutionManager, error) {
+	mgr, err := f.ExecutionManagerFactory.CreateExecutionManager(shardID)
+	if err != nil {
+		return nil, err
+	}
+	return &workflowExecutionHedgedPersistenceClient{ExecutionManager: mgr, reader: f.reader}, nil
+}
+
+func (p *historyHedgedPersistenceClient) GetWorkflowExecutionHistory(
+	request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
+	scope := metrics.PersistenceGetWorkflowExecutionHistoryScope
+	response, err := p.reader.read(scope, func(ctx context.Context) (interface{}, error) {
+		attempt := *request
+		attempt.ctx = ctx
+		return p.HistoryManager.GetWorkflowExecutionHistory(&attempt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.(*GetWorkflowExecutionHistoryResponse), nil
+}
+
+func (p *workflowExecutionHedgedPersistenceClient) GetTimerIndexTasks(
+	request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
+	scope := metrics.PersistenceGetTimerIndexTasksScope
+	response, err := p.reader.read(scope, func(ctx context.Context) (interface{}, error) {
+		attempt := *request
+		attempt.ctx = ctx
+		return p.ExecutionManager.GetTimerIndexTasks(&attempt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.(*GetTimerIndexTasksResponse), nil
+}
+
+// read returns the response of the first attempt of the operation which succeeds. The operation is attempted a
+// second time if the first attempt did not return within the hedge delay, an error is only returned once every
+// started attempt failed. The attempt which lost is cancelled through the context passed to the operation.
+func (r *hedgedReader) read(scope int, operation func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	hedgeDelay := r.hedgeDelay()
+	if hedgeDelay <= 0 {
+		return operation(context.Background())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// buffered so that the attempt which loses does not block
+	results := make(chan hedgedResult, 2)
+	attempt := func(hedge bool) {
+		response, err := operation(ctx)
+		results <- hedgedResult{response: response, err: err, hedge: hedge}
+	}
+	go attempt(false)
+
+	timerCh, stopTimer := r.newTimer(hedgeDelay)
+	defer stopTimer()
+	pending := 1
+	for {
+		select {
+		case <-timerCh:
+			r.metricClient.IncCounter(scope, metrics.PersistenceHedgedRequests)
+			pending++
+			go attempt(true)
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				if result.hedge {
+					r.metricClient.IncCounter(scope, metrics.PersistenceHedgeWins)
+				}
+				return result.response, nil
+			}
+			if pending == 0 {
+				return nil, result.err
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// hedgedTestHistoryManager sends i on started when call i starts, and answers it with the error received on
+	// answers[i], or with the error of the context of the call once it is cancelled, in which case i is sent on
+	// cancelled
+	hedgedTestHistoryManager struct {
+		HistoryManager
+		calls     int32
+		answers   []chan error
+		started   chan int
+		cancelled chan int
+	}
+)
+
+func newHedgedTestHistoryManager(attempts int) *hedgedTestHistoryManager {
+	mgr := &hedgedTestHistoryManager{started: make(chan int, attempts), cancelled: make(chan int, attempts)}
+	for i := 0; i < attempts; i++ {
+		mgr.answers = append(mgr.answers, make(chan error, 1))
+	}
+	return mgr
+}
+
+func (m *hedgedTestHistoryManager) GetWorkflowExecutionHistory(
+	request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
+	call := int(atomic.AddInt32(&m.calls, 1)) - 1
+	m.started <- call
+	select {
+	case err := <-m.answers[call]:
+		if err != nil {
+			return nil, err
+		}
+		return &GetWorkflowExecutionHistoryResponse{NextPageToken: []byte{byte(call)}}, nil
+	case <-request.ctx.Done():
+		m.cancelled <- call
+		return nil, request.ctx.Err()
+	}
+}
+
+// newHedgedTestClient creates a client whose hedge delay expires when a value is sent on the returned channel
+func newHedgedTestClient(delay time.Duration, mgr HistoryManager) (HistoryManager, chan time.Time, tally.TestScope) {
+	scope := tally.NewTestScope("", nil)
+	reader := newHedgedReader(dynamicconfig.GetDurationPropertyFn(delay), metrics.NewClient(scope, metrics.History))
+	timerCh := make(chan time.Time)
+	reader.newTimer = func(time.Duration) (<-chan time.Time, func() bool) {
+		return timerCh, func() bool { return true }
+	}
+	return &historyHedgedPersistenceClient{HistoryManager: mgr, reader: reader}, timerCh, scope
+}
+
+func hedgedTestCounter(scope tally.TestScope, name string) int64 {
+	for _, counter := range scope.Snapshot().Counters() {
+		if counter.Name() == name {
+			return counter.Value()
+		}
+	}
+	return 0
+}
+
+// getHistoryAsync reads the history in the background and returns once the first attempt started, the result is
+// sent on the returned channel
+func getHistoryAsync(client HistoryManager, mgr *hedgedTestHistoryManager) chan hedgedResult {
+	resultCh := make(chan hedgedResult, 1)
+	go func() {
+		response, err := client.GetWorkflowExecutionHistory(&GetWorkflowExecutionHistoryRequest{})
+		resultCh <- hedgedResult{response: response, err: err}
+	}()
+	<-mgr.started
+	return resultCh
+}
+
+func TestHedgedReadDisabled(t *testing.T) {
+	mgr := newHedgedTestHistoryManager(1)
+	mgr.answers[0] <- nil
+	client, _, scope := newHedgedTestClient(0, mgr)
+
+	response, err := client.GetWorkflowExecutionHistory(&GetWorkflowExecutionHistoryRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0}, response.NextPageToken)
+	require.Equal(t, int32(1), atomic.LoadInt32(&mgr.calls))
+	require.Equal(t, int64(0), hedgedTestCounter(scope, "persistence.hedged-requests"))
+}
+
+func TestHedgedReadFirstAttemptWins(t *testing.T) {
+	mgr := newHedgedTestHistoryManager(1)
+	mgr.answers[0] <- nil
+	client, _, scope := newHedgedTestClient(time.Second, mgr)
+
+	response, err := client.GetWorkflowExecutionHistory(&GetWorkflowExecutionHistoryRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0}, response.NextPageToken)
+	require.Equal(t, int32(1), atomic.LoadInt32(&mgr.calls))
+	require.Equal(t, int64(0), hedgedTestCounter(scope, "persistence.hedged-requests"))
+}
+
+func TestHedgedReadHedgeWinsAndCancelsFirstAttempt(t *testing.T) {
+	mgr := newHedgedTestHistoryManager(2)
+	client, timerCh, scope := newHedgedTestClient(time.Second, mgr)
+
+	resultCh := getHistoryAsync(client, mgr)
+	timerCh <- time.Now()
+	mgr.answers[1] <- nil
+
+	result := <-resultCh
+	require.NoError(t, result.err)
+	require.Equal(t, []byte{1}, result.response.(*GetWorkflowExecutionHistoryResponse).NextPageToken)
+	require.Equal(t, 0, <-mgr.cancelled)
+	require.Equal(t, int64(1), hedgedTestCounter(scope, "persistence.hedged-requests"))
+	require.Equal(t, int64(1), hedgedTestCounter(scope, "persistence.hedge-wins"))
+}
+
+func TestHedgedReadFirstAttemptWinsAndCancelsHedge(t *testing.T) {
+	mgr := newHedgedTestHistoryManager(2)
+	client, timerCh, scope := newHedgedTestClient(time.Second, mgr)
+
+	resultCh := getHistoryAsync(client, mgr)
+	timerCh <- time.Now()
+	mgr.answers[0] <- nil
+
+	result := <-resultCh
+	require.NoError(t, result.err)
+	require.Equal(t, []byte{0}, result.response.(*GetWorkflowExecutionHistoryResponse).NextPageToken)
+	require.Equal(t, 1, <-mgr.cancelled)
+	require.Equal(t, int64(1), hedgedTestCounter(scope, "persistence.hedged-requests"))
+	require.Equal(t, int64(0), hedgedTestCounter(scope, "persistence.hedge-wins"))
+}
+
+func TestHedgedReadFailsOnceEveryAttemptFailed(t *testing.T) {
+	firstErr := errors.New("first attempt failed")
+	secondErr := errors.New("hedge failed")
+
+	// the first attempt failing is not returned while the hedge is still running
+	mgr := newHedgedTestHistoryManager(2)
+	client, timerCh, _ := newHedgedTestClient(time.Second, mgr)
+	resultCh := getHistoryAsync(client, mgr)
+	timerCh <- time.Now()
+	mgr.answers[0] <- firstErr
+	mgr.answers[1] <- nil
+	result := <-resultCh
+	require.NoError(t, result.err)
+	require.Equal(t, []byte{1}, result.response.(*GetWorkflowExecutionHistoryResponse).NextPageToken)
+
+	mgr = newHedgedTestHistoryManager(2)
+	client, timerCh, _ = newHedgedTestClient(time.Second, mgr)
+	resultCh = getHistoryAsync(client, mgr)
+	timerCh <- time.Now()
+	mgr.answers[0] <- firstErr
+	mgr.answers[1] <- secondErr
+	result = <-resultCh
+	require.Error(t, result.err)
+	require.Contains(t, []error{firstErr, secondErr}, result.err)
+}
+
+func TestHedgedReadRequestIsNotModified(t *testing.T) {
+	mgr := newHedgedTestHistoryManager(1)
+	mgr.answers[0] <- nil
+	client, _, _ := newHedgedTestClient(time.Second, mgr)
+
+	request := &GetWorkflowExecutionHistoryRequest{PageSize: 10}
+	_, err := client.GetWorkflowExecutionHistory(request)
+	require.NoError(t, err)
+	require.Nil(t, request.ctx)
+}
@@ -25,6 +25,7 @@ import (
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/serviceerror"
 )
 
 type (
@@ -63,6 +64,12 @@ type (
 		persistence  VisibilityManager
 		logger       bark.Logger
 	}
+
+	schedulePersistenceClient struct {
+		metricClient metrics.Client
+		persistence  ScheduleManager
+		logger       bark.Logger
+	}
 )
 
 var _ ShardManager = (*shardPersistenceClient)(nil)
@@ -71,6 +78,7 @@ var _ TaskManager = (*taskPersistenceClient)(nil)
 var _ HistoryManager = (*historyPersistenceClient)(nil)
 var _ MetadataManager = (*metadataPersistenceClient)(nil)
 var _ VisibilityManager = (*visibilityPersistenceClient)(nil)
+var _ ScheduleManager = (*schedulePersistenceClient)(nil)
 
 // NewShardPersistenceMetricsClient creates a client to manage shards
 func NewShardPersistenceMetricsClient(persistence ShardManager, metricClient metrics.Client, logger bark.Logger) ShardManager {
@@ -126,6 +134,15 @@ func NewVisibilityPersistenceMetricsClient(persistence VisibilityManager, metric
 	}
 }
 
+// NewSchedulePersistenceMetricsClient creates a client to manage schedules
+func NewSchedulePersistenceMetricsClient(persistence ScheduleManager, metricClient metrics.Client, logger bark.Logger) ScheduleManager {
+	return &schedulePersistenceClient{
+		persistence:  persistence,
+		metricClient: metricClient,
+		logger:       logger,
+	}
+}
+
 func (p *shardPersistenceClient) CreateShard(request *CreateShardRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCreateShardScope, metrics.PersistenceRequests)
 
@@ -157,7 +174,7 @@ func (p *shardPersistenceClient) GetShard(
 	sw.Stop()
 
 	if err != nil {
-		switch err.(type) {
+		switch serviceerror.Cause(err).(type) {
 		case *workflow.EntityNotExistsError:
 			p.metricClient.IncCounter(metrics.PersistenceGetShardScope, metrics.CadenceErrEntityNotExistsCounter)
 		default:
@@ -194,6 +211,76 @@ func (p *shardPersistenceClient) UpdateShard(request *UpdateShardRequest) error
 	return err
 }
 
+func (p *shardPersistenceClient) PutReplicationDLQMessage(request *PutReplicationDLQMessageRequest) error {
+	p.metricClient.IncCounter(metrics.PersistencePutReplicationDLQMessageScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistencePutReplicationDLQMessageScope, metrics.PersistenceLatency)
+	err := p.persistence.PutReplicationDLQMessage(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistencePutReplicationDLQMessageScope, err)
+	}
+
+	return err
+}
+
+func (p *shardPersistenceClient) DeleteReplicationDLQMessage(request *DeleteReplicationDLQMessageRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceDeleteReplicationDLQMessageScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteReplicationDLQMessageScope, metrics.PersistenceLatency)
+	err := p.persistence.DeleteReplicationDLQMessage(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceDeleteReplicationDLQMessageScope, err)
+	}
+
+	return err
+}
+
+func (p *shardPersistenceClient) GetReplicationDLQSummary(request *GetReplicationDLQSummaryRequest) (*GetReplicationDLQSummaryResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetReplicationDLQSummaryScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetReplicationDLQSummaryScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetReplicationDLQSummary(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetReplicationDLQSummaryScope, err)
+	}
+
+	return response, err
+}
+
+func (p *shardPersistenceClient) ListDomainExecutions(request *ListDomainExecutionsRequest) (*ListDomainExecutionsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListDomainExecutionsScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceListDomainExecutionsScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListDomainExecutions(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListDomainExecutionsScope, err)
+	}
+
+	return response, err
+}
+
+func (p *shardPersistenceClient) updateErrorMetric(scope int, err error) {
+	switch serviceerror.Cause(err).(type) {
+	case *workflow.ServiceBusyError:
+		p.metricClient.IncCounter(scope, metrics.PersistenceErrBusyCounter)
+		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
+	default:
+		p.logger.WithFields(bark.Fields{
+			logging.TagScope: scope,
+			logging.TagErr:   err,
+		}).Error("Operation failed with internal error.")
+		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
+	}
+}
+
 func (p *shardPersistenceClient) Close() {
 	p.persistence.Close()
 }
@@ -268,6 +355,20 @@ func (p *workflowExecutionPersistenceClient) DeleteWorkflowExecution(request *De
 	return err
 }
 
+func (p *workflowExecutionPersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceDeleteCurrentWorkflowExecutionScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteCurrentWorkflowExecutionScope, metrics.PersistenceLatency)
+	err := p.persistence.DeleteCurrentWorkflowExecution(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceDeleteCurrentWorkflowExecutionScope, err)
+	}
+
+	return err
+}
+
 func (p *workflowExecutionPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetCurrentExecutionScope, metrics.PersistenceRequests)
 
@@ -338,6 +439,20 @@ func (p *workflowExecutionPersistenceClient) CompleteReplicationTask(request *Co
 	return err
 }
 
+func (p *workflowExecutionPersistenceClient) RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceRangeCompleteReplicationTaskScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceRangeCompleteReplicationTaskScope, metrics.PersistenceLatency)
+	err := p.persistence.RangeCompleteReplicationTask(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceRangeCompleteReplicationTaskScope, err)
+	}
+
+	return err
+}
+
 func (p *workflowExecutionPersistenceClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetTimerIndexTasksScope, metrics.PersistenceRequests)
 
@@ -367,7 +482,7 @@ func (p *workflowExecutionPersistenceClient) CompleteTimerTask(request *Complete
 }
 
 func (p *workflowExecutionPersistenceClient) updateErrorMetric(scope int, err error) {
-	switch err.(type) {
+	switch serviceerror.Cause(err).(type) {
 	case *WorkflowExecutionAlreadyStartedError:
 		p.metricClient.IncCounter(scope, metrics.CadenceErrExecutionAlreadyStartedCounter)
 	case *workflow.EntityNotExistsError:
@@ -437,6 +552,62 @@ func (p *taskPersistenceClient) CompleteTask(request *CompleteTaskRequest) error
 	return err
 }
 
+func (p *taskPersistenceClient) ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListTaskListScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceListTaskListScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListTaskList(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListTaskListScope, err)
+	}
+
+	return response, err
+}
+
+func (p *taskPersistenceClient) CreateDLQTask(request *CreateDLQTaskRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceCreateDLQTaskScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceCreateDLQTaskScope, metrics.PersistenceLatency)
+	err := p.persistence.CreateDLQTask(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceCreateDLQTaskScope, err)
+	}
+
+	return err
+}
+
+func (p *taskPersistenceClient) GetDLQTasks(request *GetDLQTasksRequest) (*GetDLQTasksResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetDLQTasksScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetDLQTasksScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetDLQTasks(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetDLQTasksScope, err)
+	}
+
+	return response, err
+}
+
+func (p *taskPersistenceClient) DeleteDLQTask(request *DeleteDLQTaskRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceDeleteDLQTaskScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteDLQTaskScope, metrics.PersistenceLatency)
+	err := p.persistence.DeleteDLQTask(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceDeleteDLQTaskScope, err)
+	}
+
+	return err
+}
+
 func (p *taskPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceLeaseTaskListScope, metrics.PersistenceRequests)
 
@@ -466,7 +637,7 @@ func (p *taskPersistenceClient) UpdateTaskList(request *UpdateTaskListRequest) (
 }
 
 func (p *taskPersistenceClient) updateErrorMetric(scope int, err error) {
-	switch err.(type) {
+	switch serviceerror.Cause(err).(type) {
 	case *ConditionFailedError:
 		p.metricClient.IncCounter(scope, metrics.PersistenceErrConditionFailedCounter)
 	case *TimeoutError:
@@ -529,8 +700,51 @@ func (p *historyPersistenceClient) DeleteWorkflowExecutionHistory(
 	return err
 }
 
+func (p *historyPersistenceClient) ListHistoryBatches(
+	request *ListHistoryBatchesRequest) (*ListHistoryBatchesResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListHistoryBatchesScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceListHistoryBatchesScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListHistoryBatches(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListHistoryBatchesScope, err)
+	}
+
+	return response, err
+}
+
+func (p *historyPersistenceClient) ReencodeHistoryBatch(request *ReencodeHistoryBatchRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceReencodeHistoryBatchScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceReencodeHistoryBatchScope, metrics.PersistenceLatency)
+	err := p.persistence.ReencodeHistoryBatch(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceReencodeHistoryBatchScope, err)
+	}
+
+	return err
+}
+
+func (p *historyPersistenceClient) DeleteHistoryBatch(request *DeleteHistoryBatchRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceDeleteHistoryBatchScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteHistoryBatchScope, metrics.PersistenceLatency)
+	err := p.persistence.DeleteHistoryBatch(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceDeleteHistoryBatchScope, err)
+	}
+
+	return err
+}
+
 func (p *historyPersistenceClient) updateErrorMetric(scope int, err error) {
-	switch err.(type) {
+	switch serviceerror.Cause(err).(type) {
 	case *workflow.EntityNotExistsError:
 		p.metricClient.IncCounter(scope, metrics.CadenceErrEntityNotExistsCounter)
 	case *ConditionFailedError:
@@ -654,7 +868,7 @@ func (p *metadataPersistenceClient) Close() {
 }
 
 func (p *metadataPersistenceClient) updateErrorMetric(scope int, err error) {
-	switch err.(type) {
+	switch serviceerror.Cause(err).(type) {
 	case *workflow.DomainAlreadyExistsError:
 		p.metricClient.IncCounter(scope, metrics.CadenceErrDomainAlreadyExistsCounter)
 	case *workflow.EntityNotExistsError:
@@ -796,6 +1010,76 @@ func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByStatus(reque
 	return response, err
 }
 
+func (p *visibilityPersistenceClient) ListOpenWorkflowExecutionsByTag(request *ListWorkflowExecutionsByTagRequest) (*ListWorkflowExecutionsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListOpenWorkflowExecutionsByTagScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceListOpenWorkflowExecutionsByTagScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListOpenWorkflowExecutionsByTag(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListOpenWorkflowExecutionsByTagScope, err)
+	}
+
+	return response, err
+}
+
+func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByTag(request *ListWorkflowExecutionsByTagRequest) (*ListWorkflowExecutionsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListClosedWorkflowExecutionsByTagScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceListClosedWorkflowExecutionsByTagScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListClosedWorkflowExecutionsByTag(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListClosedWorkflowExecutionsByTagScope, err)
+	}
+
+	return response, err
+}
+
+func (p *visibilityPersistenceClient) ListOpenWorkflowExecutionsByFirstRunID(request *ListWorkflowExecutionsByFirstRunIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListOpenWorkflowExecutionsByFirstRunIDScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceListOpenWorkflowExecutionsByFirstRunIDScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListOpenWorkflowExecutionsByFirstRunID(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListOpenWorkflowExecutionsByFirstRunIDScope, err)
+	}
+
+	return response, err
+}
+
+func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByFirstRunID(request *ListWorkflowExecutionsByFirstRunIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListClosedWorkflowExecutionsByFirstRunIDScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceListClosedWorkflowExecutionsByFirstRunIDScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListClosedWorkflowExecutionsByFirstRunID(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListClosedWorkflowExecutionsByFirstRunIDScope, err)
+	}
+
+	return response, err
+}
+
+func (p *visibilityPersistenceClient) ListAllWorkflowExecutions(request *ListAllWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListAllWorkflowExecutionsScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceListAllWorkflowExecutionsScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListAllWorkflowExecutions(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListAllWorkflowExecutionsScope, err)
+	}
+
+	return response, err
+}
+
 func (p *visibilityPersistenceClient) GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetClosedWorkflowExecutionScope, metrics.PersistenceRequests)
 
@@ -810,8 +1094,37 @@ func (p *visibilityPersistenceClient) GetClosedWorkflowExecution(request *GetClo
 	return response, err
 }
 
+func (p *visibilityPersistenceClient) DeleteWorkflowExecution(request *VisibilityDeleteWorkflowExecutionRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceVisibilityDeleteWorkflowExecutionScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceVisibilityDeleteWorkflowExecutionScope, metrics.PersistenceLatency)
+	err := p.persistence.DeleteWorkflowExecution(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceVisibilityDeleteWorkflowExecutionScope, err)
+	}
+
+	return err
+}
+
+func (p *visibilityPersistenceClient) PruneClosedWorkflowExecutions(
+	request *PruneClosedWorkflowExecutionsRequest) (*PruneClosedWorkflowExecutionsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistencePruneClosedWorkflowExecutionsScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistencePruneClosedWorkflowExecutionsScope, metrics.PersistenceLatency)
+	response, err := p.persistence.PruneClosedWorkflowExecutions(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistencePruneClosedWorkflowExecutionsScope, err)
+	}
+
+	return response, err
+}
+
 func (p *visibilityPersistenceClient) updateErrorMetric(scope int, err error) {
-	switch err.(type) {
+	switch serviceerror.Cause(err).(type) {
 	case *ConditionFailedError:
 		p.metricClient.IncCounter(scope, metrics.PersistenceErrConditionFailedCounter)
 	case *TimeoutError:
@@ -834,3 +1147,95 @@ func (p *visibilityPersistenceClient) updateErrorMetric(scope int, err error) {
 func (p *visibilityPersistenceClient) Close() {
 	p.persistence.Close()
 }
+
+func (p *schedulePersistenceClient) CreateSchedule(request *CreateScheduleRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceCreateScheduleScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceCreateScheduleScope, metrics.PersistenceLatency)
+	err := p.persistence.CreateSchedule(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceCreateScheduleScope, err)
+	}
+
+	return err
+}
+
+func (p *schedulePersistenceClient) GetSchedule(request *GetScheduleRequest) (*GetScheduleResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetScheduleScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetScheduleScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetSchedule(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetScheduleScope, err)
+	}
+
+	return response, err
+}
+
+func (p *schedulePersistenceClient) UpdateSchedule(request *UpdateScheduleRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceUpdateScheduleScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateScheduleScope, metrics.PersistenceLatency)
+	err := p.persistence.UpdateSchedule(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceUpdateScheduleScope, err)
+	}
+
+	return err
+}
+
+func (p *schedulePersistenceClient) DeleteSchedule(request *DeleteScheduleRequest) error {
+	p.metricClient.IncCounter(metrics.PersistenceDeleteScheduleScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteScheduleScope, metrics.PersistenceLatency)
+	err := p.persistence.DeleteSchedule(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceDeleteScheduleScope, err)
+	}
+
+	return err
+}
+
+func (p *schedulePersistenceClient) ListSchedules(request *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListSchedulesScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceListSchedulesScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListSchedules(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListSchedulesScope, err)
+	}
+
+	return response, err
+}
+
+func (p *schedulePersistenceClient) updateErrorMetric(scope int, err error) {
+	switch serviceerror.Cause(err).(type) {
+	case *ConditionFailedError:
+		p.metricClient.IncCounter(scope, metrics.PersistenceErrConditionFailedCounter)
+	case *workflow.EntityNotExistsError:
+		p.metricClient.IncCounter(scope, metrics.CadenceErrEntityNotExistsCounter)
+	case *workflow.ServiceBusyError:
+		p.metricClient.IncCounter(scope, metrics.PersistenceErrBusyCounter)
+		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
+	default:
+		p.logger.WithFields(bark.Fields{
+			logging.TagScope: scope,
+			logging.TagErr:   err,
+		}).Error("Operation failed with internal error.")
+		p.metricClient.IncCounter(scope, metrics.PersistenceFailures)
+	}
+}
+
+func (p *schedulePersistenceClient) Close() {
+	p.persistence.Close()
+}
@@ -0,0 +1,282 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/compression"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/common/serviceerror"
+)
+
+type (
+	// historyPayloadDedupPersistenceClient stores the large payloads of the appended history events once per run
+	// in the history payload store, and replaces them in the events with a reference to their hash. A workflow
+	// fanning out the same input to many activities then stores the input once. The references are resolved
+	// again when the history is read, so the readers of the history never see them.
+	historyPayloadDedupPersistenceClient struct {
+		HistoryManager
+		payloadMgr        HistoryPayloadManager
+		serializerFactory HistorySerializerFactory
+		threshold         dynamicconfig.IntPropertyFn
+	}
+)
+
+var _ HistoryManager = (*historyPayloadDedupPersistenceClient)(nil)
+
+// historyPayloadRefPrefix starts the references replacing the deduplicated payloads. Its length is a multiple of 3,
+// so the base64 encoding of a reference found in the JSON encoded batches starts with the encoding of the prefix.
+var historyPayloadRefPrefix = []byte("\x00cadence-payload-ref:")
+var historyPayloadRefPrefixBase64 = []byte(base64.StdEncoding.EncodeToString(historyPayloadRefPrefix))
+
+// historyPayloadRefLength is the length of a reference, the prefix followed by the hex encoded sha256 of the payload
+var historyPayloadRefLength = len(historyPayloadRefPrefix) + hex.EncodedLen(sha256.Size)
+
+// NewHistoryPersistencePayloadDedupClient creates a HistoryManager client which moves the event payloads of at
+// least threshold bytes to the payload store. The references in the history are resolved whatever the threshold,
+// a nil threshold or one that is not positive only disables the deduplication of the appended events.
+func NewHistoryPersistencePayloadDedupClient(persistence HistoryManager, payloadMgr HistoryPayloadManager,
+	threshold dynamicconfig.IntPropertyFn) HistoryManager {
+	return &historyPayloadDedupPersistenceClient{
+		HistoryManager:    persistence,
+		payloadMgr:        payloadMgr,
+		serializerFactory: NewHistorySerializerFactory(),
+		threshold:         threshold,
+	}
+}
+
+func (p *historyPayloadDedupPersistenceClient) Close() {
+	p.HistoryManager.Close()
+	p.payloadMgr.Close()
+}
+
+func (p *historyPayloadDedupPersistenceClient) AppendHistoryEvents(request *AppendHistoryEventsRequest) error {
+	threshold := 0
+	if p.threshold != nil {
+		threshold = p.threshold()
+	}
+	if threshold <= 0 || request.Events == nil {
+		return p.HistoryManager.AppendHistoryEvents(request)
+	}
+	// a payload is only worth a reference when it is larger than the reference
+	if threshold <= historyPayloadRefLength {
+		threshold = historyPayloadRefLength + 1
+	}
+
+	serializer, err := p.serializerFactory.Get(request.Events.EncodingType)
+	if err != nil {
+		return err
+	}
+	batch, err := serializer.Deserialize(request.Events)
+	if err != nil {
+		return err
+	}
+
+	payloads := make(map[string][]byte)
+	events := make([]*workflow.HistoryEvent, 0, len(batch.Events))
+	for _, event := range batch.Events {
+		event, err = compression.MapEventPayloads(event, func(payload []byte) ([]byte, error) {
+			if len(payload) < threshold || isHistoryPayloadRef(payload) {
+				return payload, nil
+			}
+			hash := historyPayloadHash(payload)
+			payloads[hash] = payload
+			return newHistoryPayloadRef(hash), nil
+		})
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+	}
+	if len(payloads) == 0 {
+		return p.HistoryManager.AppendHistoryEvents(request)
+	}
+
+	// the payloads are stored before the events, so a reference in the history never misses its payload
+	err = p.payloadMgr.PutHistoryPayloads(&PutHistoryPayloadsRequest{
+		DomainID:  request.DomainID,
+		Execution: request.Execution,
+		Payloads:  payloads,
+	})
+	if err != nil {
+		return serviceerror.Wrapf(err, "failed to store history payloads of workflow %v", request.Execution.GetWorkflowId())
+	}
+	serialized, err := serializer.Serialize(&HistoryEventBatch{Version: batch.Version, Events: events})
+	if err != nil {
+		return err
+	}
+	deduped := *request
+	deduped.Events = serialized
+	return p.HistoryManager.AppendHistoryEvents(&deduped)
+}
+
+func (p *historyPayloadDedupPersistenceClient) GetWorkflowExecutionHistory(
+	request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
+	response, err := p.HistoryManager.GetWorkflowExecutionHistory(request)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, batch := range response.Events {
+		// a compressed batch is decompressed once, for both the lookup of the references and their resolution
+		plain, err := decompressHistoryBatch(&batch)
+		if err != nil {
+			return nil, err
+		}
+		if !mayContainHistoryPayloadRefs(plain) {
+			continue
+		}
+		resolved, err := p.resolveHistoryPayloadRefs(request.DomainID, request.Execution, &batch, plain)
+		if err != nil {
+			return nil, err
+		}
+		response.Events[i] = *resolved
+	}
+	return response, nil
+}
+
+func (p *historyPayloadDedupPersistenceClient) DeleteWorkflowExecutionHistory(
+	request *DeleteWorkflowExecutionHistoryRequest) error {
+	if err := p.HistoryManager.DeleteWorkflowExecutionHistory(request); err != nil {
+		return err
+	}
+	return p.payloadMgr.DeleteHistoryPayloads(&DeleteHistoryPayloadsRequest{
+		DomainID:  request.DomainID,
+		Execution: request.Execution,
+	})
+}
+
+// resolveHistoryPayloadRefs returns the batch with the payload references of its events replaced by the payloads,
+// the events are read from the decompressed plain batch and encoded again like the batch
+func (p *historyPayloadDedupPersistenceClient) resolveHistoryPayloadRefs(domainID string,
+	execution workflow.WorkflowExecution, batch *SerializedHistoryEventBatch,
+	plain *SerializedHistoryEventBatch) (*SerializedHistoryEventBatch, error) {
+	deserializer, err := p.serializerFactory.Get(plain.EncodingType)
+	if err != nil {
+		return nil, err
+	}
+	serializer, err := p.serializerFactory.Get(batch.EncodingType)
+	if err != nil {
+		return nil, err
+	}
+	events, err := deserializer.Deserialize(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashes []string
+	seen := make(map[string]struct{})
+	for _, event := range events.Events {
+		compression.MapEventPayloads(event, func(payload []byte) ([]byte, error) {
+			if !isHistoryPayloadRef(payload) {
+				return payload, nil
+			}
+			hash := historyPayloadRefHash(payload)
+			if _, ok := seen[hash]; !ok {
+				seen[hash] = struct{}{}
+				hashes = append(hashes, hash)
+			}
+			return payload, nil
+		})
+	}
+	if len(hashes) == 0 {
+		return batch, nil
+	}
+
+	response, err := p.payloadMgr.GetHistoryPayloads(&GetHistoryPayloadsRequest{
+		DomainID:  domainID,
+		Execution: execution,
+		Hashes:    hashes,
+	})
+	if err != nil {
+		return nil, serviceerror.Wrapf(err, "failed to load history payloads of workflow %v", execution.GetWorkflowId())
+	}
+	resolved := make([]*workflow.HistoryEvent, 0, len(events.Events))
+	for _, event := range events.Events {
+		event, err = compression.MapEventPayloads(event, func(payload []byte) ([]byte, error) {
+			if !isHistoryPayloadRef(payload) {
+				return payload, nil
+			}
+			hash := historyPayloadRefHash(payload)
+			data, ok := response.Payloads[hash]
+			if !ok {
+				return nil, &HistoryDeserializationError{msg: fmt.Sprintf("history payload %v not found", hash)}
+			}
+			return data, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, event)
+	}
+	return serializer.Serialize(&HistoryEventBatch{Version: events.Version, Events: resolved})
+}
+
+// decompressHistoryBatch returns the batch of a gzip compressed encoding decompressed, with the encoding it was
+// compressed from, the batches of the other encodings are returned as is
+func decompressHistoryBatch(batch *SerializedHistoryEventBatch) (*SerializedHistoryEventBatch, error) {
+	var encodingType common.EncodingType
+	switch batch.EncodingType {
+	case common.EncodingTypeJSONGzip:
+		encodingType = common.EncodingTypeJSON
+	case common.EncodingTypeThriftRWGzip:
+		encodingType = common.EncodingTypeThriftRW
+	default:
+		return batch, nil
+	}
+	data, err := compression.Decompress(batch.Data)
+	if err != nil {
+		return nil, &HistoryDeserializationError{msg: err.Error()}
+	}
+	return NewSerializedHistoryEventBatch(data, encodingType, batch.Version), nil
+}
+
+// mayContainHistoryPayloadRefs looks for the reference prefix in the decompressed encoded batch, so only the batches
+// which may carry references are decoded
+func mayContainHistoryPayloadRefs(batch *SerializedHistoryEventBatch) bool {
+	return bytes.Contains(batch.Data, historyPayloadRefPrefix) || bytes.Contains(batch.Data, historyPayloadRefPrefixBase64)
+}
+
+func historyPayloadHash(payload []byte) string {
+	hash := sha256.Sum256(payload)
+	return hex.EncodeToString(hash[:])
+}
+
+func newHistoryPayloadRef(hash string) []byte {
+	ref := make([]byte, 0, historyPayloadRefLength)
+	ref = append(ref, historyPayloadRefPrefix...)
+	return append(ref, hash...)
+}
+
+func isHistoryPayloadRef(payload []byte) bool {
+	return len(payload) == historyPayloadRefLength && bytes.HasPrefix(payload, historyPayloadRefPrefix)
+}
+
+func historyPayloadRefHash(ref []byte) string {
+	return string(ref[len(historyPayloadRefPrefix):])
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/common/serviceerror"
+)
+
+type (
+	// dedupTestHistoryManager keeps the batches appended to a single history in memory
+	dedupTestHistoryManager struct {
+		HistoryManager
+		batches []SerializedHistoryEventBatch
+		deleted bool
+	}
+
+	// dedupTestPayloadManager keeps the payloads of a single history in memory
+	dedupTestPayloadManager struct {
+		payloads map[string][]byte
+		puts     int
+		// gets are the hashes of each get of payloads
+		gets    [][]string
+		deleted bool
+		// err fails the puts and the gets of payloads when set
+		err error
+	}
+)
+
+func (m *dedupTestHistoryManager) AppendHistoryEvents(request *AppendHistoryEventsRequest) error {
+	m.batches = append(m.batches, *request.Events)
+	return nil
+}
+
+func (m *dedupTestHistoryManager) GetWorkflowExecutionHistory(
+	request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
+	return &GetWorkflowExecutionHistoryResponse{Events: append([]SerializedHistoryEventBatch(nil), m.batches...)}, nil
+}
+
+func (m *dedupTestHistoryManager) DeleteWorkflowExecutionHistory(request *DeleteWorkflowExecutionHistoryRequest) error {
+	m.deleted = true
+	return nil
+}
+
+func (m *dedupTestPayloadManager) Close() {}
+
+func (m *dedupTestPayloadManager) PutHistoryPayloads(request *PutHistoryPayloadsRequest) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.puts++
+	for hash, data := range request.Payloads {
+		m.payloads[hash] = data
+	}
+	return nil
+}
+
+func (m *dedupTestPayloadManager) GetHistoryPayloads(
+	request *GetHistoryPayloadsRequest) (*GetHistoryPayloadsResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.gets = append(m.gets, request.Hashes)
+	response := &GetHistoryPayloadsResponse{Payloads: make(map[string][]byte)}
+	for _, hash := range request.Hashes {
+		if data, ok := m.payloads[hash]; ok {
+			response.Payloads[hash] = data
+		}
+	}
+	return response, nil
+}
+
+func (m *dedupTestPayloadManager) DeleteHistoryPayloads(request *DeleteHistoryPayloadsRequest) error {
+	m.deleted = true
+	return nil
+}
+
+func newDedupTestClient(threshold int) (HistoryManager, *dedupTestHistoryManager, *dedupTestPayloadManager) {
+	historyMgr := &dedupTestHistoryManager{}
+	payloadMgr := &dedupTestPayloadManager{payloads: make(map[string][]byte)}
+	client := NewHistoryPersistencePayloadDedupClient(historyMgr, payloadMgr, dynamicconfig.GetIntPropertyFn(threshold))
+	return client, historyMgr, payloadMgr
+}
+
+func dedupTestScheduledEvents(firstEventID int64, inputs ...[]byte) []*workflow.HistoryEvent {
+	var events []*workflow.HistoryEvent
+	for i, input := range inputs {
+		events = append(events, &workflow.HistoryEvent{
+			EventId:   common.Int64Ptr(firstEventID + int64(i)),
+			EventType: common.EventTypePtr(workflow.EventTypeActivityTaskScheduled),
+			ActivityTaskScheduledEventAttributes: &workflow.ActivityTaskScheduledEventAttributes{
+				ActivityId: common.StringPtr("activity"),
+				Input:      input,
+			},
+		})
+	}
+	return events
+}
+
+func appendDedupTestEvents(t *testing.T, client HistoryManager, encodingType common.EncodingType,
+	events []*workflow.HistoryEvent) {
+	serializer, err := NewHistorySerializerFactory().Get(encodingType)
+	require.NoError(t, err)
+	batch, err := serializer.Serialize(NewHistoryEventBatch(GetDefaultHistoryVersion(), events))
+	require.NoError(t, err)
+	require.NoError(t, client.AppendHistoryEvents(&AppendHistoryEventsRequest{
+		DomainID:     "domain",
+		Execution:    workflow.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr("rid")},
+		FirstEventID: events[0].GetEventId(),
+		Events:       batch,
+	}))
+}
+
+func readDedupTestEvents(t *testing.T, client HistoryManager) []*workflow.HistoryEvent {
+	response, err := client.GetWorkflowExecutionHistory(&GetWorkflowExecutionHistoryRequest{DomainID: "domain"})
+	require.NoError(t, err)
+	var events []*workflow.HistoryEvent
+	factory := NewHistorySerializerFactory()
+	for _, batch := range response.Events {
+		serializer, err := factory.Get(batch.EncodingType)
+		require.NoError(t, err)
+		deserialized, err := serializer.Deserialize(&batch)
+		require.NoError(t, err)
+		events = append(events, deserialized.Events...)
+	}
+	return events
+}
+
+func TestHistoryPayloadDedup(t *testing.T) {
+	large := bytes.Repeat([]byte("large input "), 100)
+	small := []byte("small input")
+	encodingTypes := []common.EncodingType{
+		common.EncodingTypeJSON,
+		common.EncodingTypeThriftRW,
+		common.EncodingTypeJSONGzip,
+		common.EncodingTypeThriftRWGzip,
+	}
+	for _, encodingType := range encodingTypes {
+		client, historyMgr, payloadMgr := newDedupTestClient(256)
+		appendDedupTestEvents(t, client, encodingType, dedupTestScheduledEvents(1, large, large, small))
+		appendDedupTestEvents(t, client, encodingType, dedupTestScheduledEvents(4, large))
+
+		require.Equal(t, 1, len(payloadMgr.payloads), "%v", encodingType)
+		require.Equal(t, 2, payloadMgr.puts, "%v", encodingType)
+		for _, batch := range historyMgr.batches {
+			plain, err := decompressHistoryBatch(&batch)
+			require.NoError(t, err)
+			require.True(t, mayContainHistoryPayloadRefs(plain), "%v", encodingType)
+		}
+
+		events := readDedupTestEvents(t, client)
+		require.Equal(t, 4, len(events), "%v", encodingType)
+		// the payload referenced twice by the first batch is read once
+		require.Equal(t, 2, len(payloadMgr.gets), "%v", encodingType)
+		require.Equal(t, 1, len(payloadMgr.gets[0]), "%v", encodingType)
+		for i, input := range [][]byte{large, large, small, large} {
+			require.Equal(t, input, events[i].ActivityTaskScheduledEventAttributes.Input, "%v", encodingType)
+		}
+	}
+}
+
+func TestHistoryPayloadDedup_CorruptedGzipBatch(t *testing.T) {
+	client, historyMgr, _ := newDedupTestClient(256)
+	historyMgr.batches = append(historyMgr.batches,
+		*NewSerializedHistoryEventBatch([]byte("not gzip"), common.EncodingTypeJSONGzip, GetDefaultHistoryVersion()))
+
+	_, err := client.GetWorkflowExecutionHistory(&GetWorkflowExecutionHistoryRequest{DomainID: "domain"})
+	require.IsType(t, &HistoryDeserializationError{}, err)
+}
+
+func TestHistoryPayloadDedup_Disabled(t *testing.T) {
+	large := bytes.Repeat([]byte("large input "), 100)
+	client, historyMgr, payloadMgr := newDedupTestClient(0)
+	appendDedupTestEvents(t, client, common.EncodingTypeThriftRW, dedupTestScheduledEvents(1, large, large))
+
+	require.Empty(t, payloadMgr.payloads)
+	require.False(t, mayContainHistoryPayloadRefs(&historyMgr.batches[0]))
+	events := readDedupTestEvents(t, client)
+	require.Equal(t, large, events[1].ActivityTaskScheduledEventAttributes.Input)
+}
+
+func TestHistoryPayloadDedup_ReadAfterDisabled(t *testing.T) {
+	large := bytes.Repeat([]byte("large input "), 100)
+	client, historyMgr, payloadMgr := newDedupTestClient(256)
+	appendDedupTestEvents(t, client, common.EncodingTypeJSON, dedupTestScheduledEvents(1, large))
+
+	reader := NewHistoryPersistencePayloadDedupClient(historyMgr, payloadMgr, nil)
+	events := readDedupTestEvents(t, reader)
+	require.Equal(t, large, events[0].ActivityTaskScheduledEventAttributes.Input)
+}
+
+func TestHistoryPayloadDedup_MissingPayload(t *testing.T) {
+	large := bytes.Repeat([]byte("large input "), 100)
+	client, _, payloadMgr := newDedupTestClient(256)
+	appendDedupTestEvents(t, client, common.EncodingTypeThriftRW, dedupTestScheduledEvents(1, large))
+	payloadMgr.payloads = make(map[string][]byte)
+
+	_, err := client.GetWorkflowExecutionHistory(&GetWorkflowExecutionHistoryRequest{DomainID: "domain"})
+	require.IsType(t, &HistoryDeserializationError{}, err)
+}
+
+func TestHistoryPayloadDedup_PayloadStoreError(t *testing.T) {
+	large := bytes.Repeat([]byte("large input "), 100)
+	client, _, payloadMgr := newDedupTestClient(256)
+	appendDedupTestEvents(t, client, common.EncodingTypeJSON, dedupTestScheduledEvents(1, large))
+	busy := &workflow.ServiceBusyError{Message: "payload store busy"}
+	payloadMgr.err = busy
+
+	// the errors of the payload store are wrapped, the callers still see where they originate from
+	_, err := client.GetWorkflowExecutionHistory(&GetWorkflowExecutionHistoryRequest{DomainID: "domain"})
+	require.Equal(t, busy, serviceerror.Cause(err))
+	require.True(t, common.IsPersistenceTransientError(err))
+
+	serializer, err := NewHistorySerializerFactory().Get(common.EncodingTypeJSON)
+	require.NoError(t, err)
+	events := dedupTestScheduledEvents(2, large)
+	batch, err := serializer.Serialize(NewHistoryEventBatch(GetDefaultHistoryVersion(), events))
+	require.NoError(t, err)
+	err = client.AppendHistoryEvents(&AppendHistoryEventsRequest{
+		DomainID:     "domain",
+		Execution:    workflow.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr("rid")},
+		FirstEventID: 2,
+		Events:       batch,
+	})
+	require.Equal(t, busy, serviceerror.Cause(err))
+	require.Contains(t, err.Error(), "failed to store history payloads of workflow wid")
+}
+
+func TestHistoryPayloadDedup_Delete(t *testing.T) {
+	client, historyMgr, payloadMgr := newDedupTestClient(256)
+	require.NoError(t, client.DeleteWorkflowExecutionHistory(&DeleteWorkflowExecutionHistoryRequest{DomainID: "domain"}))
+	require.True(t, historyMgr.deleted)
+	require.True(t, payloadMgr.deleted)
+}
@@ -67,6 +67,12 @@ type (
 		persistence VisibilityManager
 		logger      bark.Logger
 	}
+
+	scheduleRateLimitedPersistenceClient struct {
+		rateLimiter common.TokenBucket
+		persistence ScheduleManager
+		logger      bark.Logger
+	}
 )
 
 var _ ShardManager = (*shardRateLimitedPersistenceClient)(nil)
@@ -75,6 +81,7 @@ var _ TaskManager = (*taskRateLimitedPersistenceClient)(nil)
 var _ HistoryManager = (*historyRateLimitedPersistenceClient)(nil)
 var _ MetadataManager = (*metadataRateLimitedPersistenceClient)(nil)
 var _ VisibilityManager = (*visibilityRateLimitedPersistenceClient)(nil)
+var _ ScheduleManager = (*scheduleRateLimitedPersistenceClient)(nil)
 
 // NewShardPersistenceRateLimitedClient creates a client to manage shards
 func NewShardPersistenceRateLimitedClient(persistence ShardManager, rateLimiter common.TokenBucket, logger bark.Logger) ShardManager {
@@ -130,6 +137,15 @@ func NewVisibilityPersistenceRateLimitedClient(persistence VisibilityManager, ra
 	}
 }
 
+// NewSchedulePersistenceRateLimitedClient creates a client to manage schedules
+func NewSchedulePersistenceRateLimitedClient(persistence ScheduleManager, rateLimiter common.TokenBucket, logger bark.Logger) ScheduleManager {
+	return &scheduleRateLimitedPersistenceClient{
+		persistence: persistence,
+		rateLimiter: rateLimiter,
+		logger:      logger,
+	}
+}
+
 func (p *shardRateLimitedPersistenceClient) CreateShard(request *CreateShardRequest) error {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return ErrPersistenceLimitExceeded
@@ -157,6 +173,44 @@ func (p *shardRateLimitedPersistenceClient) UpdateShard(request *UpdateShardRequ
 	return err
 }
 
+func (p *shardRateLimitedPersistenceClient) PutReplicationDLQMessage(request *PutReplicationDLQMessageRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.PutReplicationDLQMessage(request)
+	return err
+}
+
+func (p *shardRateLimitedPersistenceClient) DeleteReplicationDLQMessage(request *DeleteReplicationDLQMessageRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.DeleteReplicationDLQMessage(request)
+	return err
+}
+
+func (p *shardRateLimitedPersistenceClient) GetReplicationDLQSummary(
+	request *GetReplicationDLQSummaryRequest) (*GetReplicationDLQSummaryResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.GetReplicationDLQSummary(request)
+	return response, err
+}
+
+func (p *shardRateLimitedPersistenceClient) ListDomainExecutions(
+	request *ListDomainExecutionsRequest) (*ListDomainExecutionsResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.ListDomainExecutions(request)
+	return response, err
+}
+
 func (p *shardRateLimitedPersistenceClient) Close() {
 	p.persistence.Close()
 }
@@ -206,6 +260,15 @@ func (p *workflowExecutionRateLimitedPersistenceClient) DeleteWorkflowExecution(
 	return err
 }
 
+func (p *workflowExecutionRateLimitedPersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.DeleteCurrentWorkflowExecution(request)
+	return err
+}
+
 func (p *workflowExecutionRateLimitedPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
@@ -251,6 +314,15 @@ func (p *workflowExecutionRateLimitedPersistenceClient) CompleteReplicationTask(
 	return err
 }
 
+func (p *workflowExecutionRateLimitedPersistenceClient) RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.RangeCompleteReplicationTask(request)
+	return err
+}
+
 func (p *workflowExecutionRateLimitedPersistenceClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
@@ -300,6 +372,42 @@ func (p *taskRateLimitedPersistenceClient) CompleteTask(request *CompleteTaskReq
 	return err
 }
 
+func (p *taskRateLimitedPersistenceClient) ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.ListTaskList(request)
+	return response, err
+}
+
+func (p *taskRateLimitedPersistenceClient) CreateDLQTask(request *CreateDLQTaskRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.CreateDLQTask(request)
+	return err
+}
+
+func (p *taskRateLimitedPersistenceClient) GetDLQTasks(request *GetDLQTasksRequest) (*GetDLQTasksResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.GetDLQTasks(request)
+	return response, err
+}
+
+func (p *taskRateLimitedPersistenceClient) DeleteDLQTask(request *DeleteDLQTaskRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.DeleteDLQTask(request)
+	return err
+}
+
 func (p *taskRateLimitedPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
@@ -349,6 +457,33 @@ func (p *historyRateLimitedPersistenceClient) DeleteWorkflowExecutionHistory(req
 	return err
 }
 
+func (p *historyRateLimitedPersistenceClient) ListHistoryBatches(request *ListHistoryBatchesRequest) (*ListHistoryBatchesResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.ListHistoryBatches(request)
+	return response, err
+}
+
+func (p *historyRateLimitedPersistenceClient) ReencodeHistoryBatch(request *ReencodeHistoryBatchRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.ReencodeHistoryBatch(request)
+	return err
+}
+
+func (p *historyRateLimitedPersistenceClient) DeleteHistoryBatch(request *DeleteHistoryBatchRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.DeleteHistoryBatch(request)
+	return err
+}
+
 func (p *historyRateLimitedPersistenceClient) Close() {
 	p.persistence.Close()
 }
@@ -501,6 +636,51 @@ func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutionsByS
 	return response, err
 }
 
+func (p *visibilityRateLimitedPersistenceClient) ListOpenWorkflowExecutionsByTag(request *ListWorkflowExecutionsByTagRequest) (*ListWorkflowExecutionsResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.ListOpenWorkflowExecutionsByTag(request)
+	return response, err
+}
+
+func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutionsByTag(request *ListWorkflowExecutionsByTagRequest) (*ListWorkflowExecutionsResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.ListClosedWorkflowExecutionsByTag(request)
+	return response, err
+}
+
+func (p *visibilityRateLimitedPersistenceClient) ListOpenWorkflowExecutionsByFirstRunID(request *ListWorkflowExecutionsByFirstRunIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.ListOpenWorkflowExecutionsByFirstRunID(request)
+	return response, err
+}
+
+func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutionsByFirstRunID(request *ListWorkflowExecutionsByFirstRunIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.ListClosedWorkflowExecutionsByFirstRunID(request)
+	return response, err
+}
+
+func (p *visibilityRateLimitedPersistenceClient) ListAllWorkflowExecutions(request *ListAllWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.ListAllWorkflowExecutions(request)
+	return response, err
+}
+
 func (p *visibilityRateLimitedPersistenceClient) GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
@@ -510,6 +690,74 @@ func (p *visibilityRateLimitedPersistenceClient) GetClosedWorkflowExecution(requ
 	return response, err
 }
 
+func (p *visibilityRateLimitedPersistenceClient) DeleteWorkflowExecution(request *VisibilityDeleteWorkflowExecutionRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.DeleteWorkflowExecution(request)
+	return err
+}
+
+func (p *visibilityRateLimitedPersistenceClient) PruneClosedWorkflowExecutions(
+	request *PruneClosedWorkflowExecutionsRequest) (*PruneClosedWorkflowExecutionsResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.PruneClosedWorkflowExecutions(request)
+	return response, err
+}
+
 func (p *visibilityRateLimitedPersistenceClient) Close() {
 	p.persistence.Close()
 }
+
+func (p *scheduleRateLimitedPersistenceClient) CreateSchedule(request *CreateScheduleRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.CreateSchedule(request)
+	return err
+}
+
+func (p *scheduleRateLimitedPersistenceClient) GetSchedule(request *GetScheduleRequest) (*GetScheduleResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.GetSchedule(request)
+	return response, err
+}
+
+func (p *scheduleRateLimitedPersistenceClient) UpdateSchedule(request *UpdateScheduleRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.UpdateSchedule(request)
+	return err
+}
+
+func (p *scheduleRateLimitedPersistenceClient) DeleteSchedule(request *DeleteScheduleRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	err := p.persistence.DeleteSchedule(request)
+	return err
+}
+
+func (p *scheduleRateLimitedPersistenceClient) ListSchedules(request *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.ListSchedules(request)
+	return response, err
+}
+
+func (p *scheduleRateLimitedPersistenceClient) Close() {
+	p.persistence.Close()
+}
@@ -30,6 +30,7 @@ import (
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/credentials"
 	"github.com/uber/cadence/common/logging"
 
 	"github.com/gocql/gocql"
@@ -80,6 +81,9 @@ type (
 		MetadataManagerV2    MetadataManager
 		MetadataProxy        MetadataManager
 		VisibilityMgr        VisibilityManager
+		ScheduleMgr          ScheduleManager
+		PostCloseEventsMgr   PostCloseEventsManager
+		HistoryPayloadMgr    HistoryPayloadManager
 		ShardInfo            *ShardInfo
 		TaskIDGenerator      TransferTaskIDGenerator
 		ClusterMetadata      cluster.Metadata
@@ -129,14 +133,15 @@ func (s *TestBase) SetupWorkflowStoreWithOptions(options TestBaseOptions, metada
 	// Setup Workflow keyspace and deploy schema for tests
 	s.CassandraTestCluster.setupTestCluster(options)
 	shardID := 0
+	credentialsProvider := credentials.NewStaticProvider(options.ClusterUser, options.ClusterPassword)
 	var err error
-	s.ShardMgr, err = NewCassandraShardPersistence(options.ClusterHost, options.ClusterPort, options.ClusterUser,
-		options.ClusterPassword, options.Datacenter, s.CassandraTestCluster.keyspace, currentClusterName, log)
+	s.ShardMgr, err = NewCassandraShardPersistence(options.ClusterHost, options.ClusterPort, credentialsProvider,
+		options.Datacenter, s.CassandraTestCluster.keyspace, currentClusterName, log)
 	if err != nil {
 		log.Fatal(err)
 	}
 	s.ExecutionMgrFactory, err = NewCassandraPersistenceClientFactory(options.ClusterHost, options.ClusterPort,
-		options.ClusterUser, options.ClusterPassword, options.Datacenter, s.CassandraTestCluster.keyspace, 2, log, nil, nil)
+		credentialsProvider, options.Datacenter, s.CassandraTestCluster.keyspace, 2, log, nil, nil)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -145,45 +150,96 @@ func (s *TestBase) SetupWorkflowStoreWithOptions(options TestBaseOptions, metada
 	if err != nil {
 		log.Fatal(err)
 	}
-	s.TaskMgr, err = NewCassandraTaskPersistence(options.ClusterHost, options.ClusterPort, options.ClusterUser,
-		options.ClusterPassword, options.Datacenter, s.CassandraTestCluster.keyspace,
+	s.TaskMgr, err = NewCassandraTaskPersistence(options.ClusterHost, options.ClusterPort, credentialsProvider,
+		options.Datacenter, s.CassandraTestCluster.keyspace,
 		log)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	s.HistoryMgr, err = NewCassandraHistoryPersistence(options.ClusterHost, options.ClusterPort, options.ClusterUser,
-		options.ClusterPassword, options.Datacenter, s.CassandraTestCluster.keyspace, 2, log)
+	s.HistoryMgr, err = NewCassandraHistoryPersistence(options.ClusterHost, options.ClusterPort, credentialsProvider,
+		options.Datacenter, s.CassandraTestCluster.keyspace, 2, log)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	s.MetadataManager, err = NewCassandraMetadataPersistence(options.ClusterHost, options.ClusterPort, options.ClusterUser,
-		options.ClusterPassword, options.Datacenter, s.CassandraTestCluster.keyspace, currentClusterName, log)
+	s.MetadataManager, err = NewCassandraMetadataPersistence(options.ClusterHost, options.ClusterPort, credentialsProvider,
+		options.Datacenter, s.CassandraTestCluster.keyspace, currentClusterName, log)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	s.MetadataManagerV2, err = NewCassandraMetadataPersistenceV2(options.ClusterHost, options.ClusterPort, options.ClusterUser,
-		options.ClusterPassword, options.Datacenter, s.CassandraTestCluster.keyspace, currentClusterName, log)
+	s.MetadataManagerV2, err = NewCassandraMetadataPersistenceV2(options.ClusterHost, options.ClusterPort, credentialsProvider,
+		options.Datacenter, s.CassandraTestCluster.keyspace, currentClusterName, log)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	s.MetadataProxy, err = NewMetadataManagerProxy(options.ClusterHost, options.ClusterPort, options.ClusterUser,
-		options.ClusterPassword, options.Datacenter, s.CassandraTestCluster.keyspace, currentClusterName, log)
+	s.MetadataProxy, err = NewMetadataManagerProxy(options.ClusterHost, options.ClusterPort, credentialsProvider,
+		options.Datacenter, s.CassandraTestCluster.keyspace, currentClusterName, log)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	s.VisibilityMgr, err = NewCassandraVisibilityPersistence(options.ClusterHost, options.ClusterPort,
-		options.ClusterUser, options.ClusterPassword, options.Datacenter, s.CassandraTestCluster.keyspace, log)
+		credentialsProvider, options.Datacenter, s.CassandraTestCluster.keyspace, log)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	s.ScheduleMgr, err = NewCassandraSchedulePersistence(options.ClusterHost, options.ClusterPort,
+		credentialsProvider, options.Datacenter, s.CassandraTestCluster.keyspace, log)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s.PostCloseEventsMgr, err = NewCassandraPostCloseEventsPersistence(options.ClusterHost, options.ClusterPort,
+		credentialsProvider, options.Datacenter, s.CassandraTestCluster.keyspace, log)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s.HistoryPayloadMgr, err = NewCassandraHistoryPayloadPersistence(options.ClusterHost, options.ClusterPort,
+		credentialsProvider, options.Datacenter, s.CassandraTestCluster.keyspace, log)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s.TaskIDGenerator = &testTransferTaskIDGenerator{}
+	s.setupTestShard(shardID, currentClusterName, log)
+}
+
+// SetupInMemoryWorkflowStore sets up the workflow test base on the in-memory persistence, for tests which need no
+// Cassandra
+func (s *TestBase) SetupInMemoryWorkflowStore(metadata cluster.Metadata) {
+	log := bark.NewLoggerFromLogrus(log.New())
+
+	s.ClusterMetadata = metadata
+	log = log.WithField("Cluster", metadata.GetCurrentClusterName())
+	currentClusterName := s.ClusterMetadata.GetCurrentClusterName()
+
+	shardID := 0
+	var err error
+	s.ShardMgr, s.ExecutionMgrFactory = NewInMemoryShardPersistence(currentClusterName, log)
+	s.WorkflowMgr, err = s.ExecutionMgrFactory.CreateExecutionManager(shardID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s.TaskMgr = NewInMemoryTaskPersistence(log)
+	s.HistoryMgr = NewInMemoryHistoryPersistence(log)
+	// the in-memory metadata only has the v2 domains, it stands for the v1 and v2 managers and their proxy
+	s.MetadataManagerV2 = NewInMemoryMetadataPersistence(currentClusterName, log)
+	s.MetadataManager = s.MetadataManagerV2
+	s.MetadataProxy = s.MetadataManagerV2
+	s.VisibilityMgr = NewInMemoryVisibilityPersistence(log)
+	s.ScheduleMgr = NewInMemorySchedulePersistence(log)
+	s.PostCloseEventsMgr = NewInMemoryPostCloseEventsPersistence(log)
+
 	s.TaskIDGenerator = &testTransferTaskIDGenerator{}
+	s.setupTestShard(shardID, currentClusterName, log)
+}
 
+func (s *TestBase) setupTestShard(shardID int, currentClusterName string, log bark.Logger) {
 	// Create a shard for test
 	s.readLevel = 0
 	s.replicationReadLevel = 0
@@ -708,6 +764,17 @@ func (s *TestBase) UpdateWorkflowExecutionForSignal(
 	})
 }
 
+// UpsertUpdateInfoState is a utility method to update mutable state of workflow execution
+func (s *TestBase) UpsertUpdateInfoState(updatedInfo *WorkflowExecutionInfo, condition int64,
+	upsertUpdateInfos []*UpdateInfo) error {
+	return s.WorkflowMgr.UpdateWorkflowExecution(&UpdateWorkflowExecutionRequest{
+		ExecutionInfo:     updatedInfo,
+		Condition:         condition,
+		UpsertUpdateInfos: upsertUpdateInfos,
+		RangeID:           s.ShardInfo.RangeID,
+	})
+}
+
 // UpdateWorkflowExecutionForBufferEvents is a utility method to update workflow execution
 func (s *TestBase) UpdateWorkflowExecutionForBufferEvents(
 	updatedInfo *WorkflowExecutionInfo, rState *ReplicationState, condition int64,
@@ -871,6 +938,13 @@ func (s *TestBase) CompleteReplicationTask(taskID int64) error {
 	})
 }
 
+// RangeCompleteReplicationTask is a utility method to complete a range of replication tasks
+func (s *TestBase) RangeCompleteReplicationTask(inclusiveEndTaskID int64) error {
+	return s.WorkflowMgr.RangeCompleteReplicationTask(&RangeCompleteReplicationTaskRequest{
+		InclusiveEndTaskID: inclusiveEndTaskID,
+	})
+}
+
 // GetTimerIndexTasks is a utility method to get tasks from transfer task queue
 func (s *TestBase) GetTimerIndexTasks(batchSize int, getAll bool) ([]*TimerTaskInfo, error) {
 	result := []*TimerTaskInfo{}
@@ -1053,7 +1127,10 @@ func (s *TestBase) SetupWorkflowStore() {
 
 // TearDownWorkflowStore to cleanup
 func (s *TestBase) TearDownWorkflowStore() {
-	s.CassandraTestCluster.tearDownTestCluster()
+	// the in-memory workflow store has no cluster to tear down
+	if s.CassandraTestCluster.session != nil {
+		s.CassandraTestCluster.tearDownTestCluster()
+	}
 }
 
 // GetNextSequenceNumber generates a unique sequence number for can be used for transfer queue taskId
@@ -1072,6 +1149,11 @@ func (s *TestBase) GetReplicationReadLevel() int64 {
 	return atomic.LoadInt64(&s.replicationReadLevel)
 }
 
+// SetReplicationReadLevel sets the read level for shard, so that the replication tasks after it are read again
+func (s *TestBase) SetReplicationReadLevel(readLevel int64) {
+	atomic.StoreInt64(&s.replicationReadLevel, readLevel)
+}
+
 // ClearTasks completes all transfer tasks and replication tasks
 func (s *TestBase) ClearTasks() {
 	s.ClearTransferQueue()
@@ -1124,7 +1206,7 @@ func (s *CassandraTestCluster) setupTestCluster(options TestBaseOptions) {
 		keySpace = generateRandomKeyspace(10)
 	}
 	s.createCluster(
-		testWorkflowClusterHosts, options.ClusterPort, testUser, testPassword, testDatacenter,
+		testWorkflowClusterHosts, options.ClusterPort, credentials.NewStaticProvider(testUser, testPassword), testDatacenter,
 		gocql.Consistency(1), keySpace,
 	)
 	s.createKeyspace(1, options.DropKeySpace)
@@ -1138,9 +1220,9 @@ func (s *CassandraTestCluster) tearDownTestCluster() {
 }
 
 func (s *CassandraTestCluster) createCluster(
-	clusterHosts string, port int, user, password, dc string,
+	clusterHosts string, port int, credentialsProvider credentials.Provider, dc string,
 	cons gocql.Consistency, keyspace string) {
-	s.cluster = common.NewCassandraCluster(clusterHosts, port, user, password, dc)
+	s.cluster = common.NewCassandraCluster(clusterHosts, port, credentialsProvider, dc)
 	s.cluster.Consistency = cons
 	s.cluster.Keyspace = "system"
 	s.cluster.Timeout = 40 * time.Second
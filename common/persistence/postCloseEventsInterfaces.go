@@ -0,0 +1,72 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"time"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+// Interfaces for the Post Close Events Store.
+// Externally generated events replicated from a remote cluster for a run which was already closed in the current
+// cluster cannot be applied to the run, they are recorded there instead of being dropped.
+
+type (
+	// PostCloseEventBatch is a batch of replicated events recorded for a closed run
+	PostCloseEventBatch struct {
+		Version       int64
+		FirstEventID  int64
+		SourceCluster string
+		RecordedTime  time.Time
+		Events        *SerializedHistoryEventBatch
+	}
+
+	// RecordPostCloseEventsRequest is used to record a batch of replicated events for a closed run
+	RecordPostCloseEventsRequest struct {
+		DomainID  string
+		Execution workflow.WorkflowExecution
+		Batch     PostCloseEventBatch
+		// TTLSeconds is how long the batch is kept, 0 keeps it forever
+		TTLSeconds int64
+	}
+
+	// ListPostCloseEventsRequest is used to read the replicated events recorded for a closed run
+	ListPostCloseEventsRequest struct {
+		DomainID      string
+		Execution     workflow.WorkflowExecution
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// ListPostCloseEventsResponse is the response to ListPostCloseEventsRequest
+	ListPostCloseEventsResponse struct {
+		Batches       []*PostCloseEventBatch
+		NextPageToken []byte
+	}
+
+	// PostCloseEventsManager is used to manage the post close events store
+	PostCloseEventsManager interface {
+		Closeable
+		RecordPostCloseEvents(request *RecordPostCloseEventsRequest) error
+		ListPostCloseEvents(request *ListPostCloseEventsRequest) (*ListPostCloseEventsResponse, error)
+	}
+)
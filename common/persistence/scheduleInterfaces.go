@@ -0,0 +1,132 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import "time"
+
+// Interfaces for the Schedule Store.
+// Schedules start runs of a workflow each time their spec is due, they are
+// stored per cluster and are not replicated.
+
+type (
+	// ScheduleInfo describes a schedule and the state of its runs
+	ScheduleInfo struct {
+		DomainID   string
+		ScheduleID string
+		// spec, a zero StartTime or EndTime leaves the schedule unbounded
+		IntervalInSeconds int64
+		OffsetInSeconds   int64
+		StartTime         time.Time
+		EndTime           time.Time
+		// action
+		WorkflowIDPrefix                    string
+		WorkflowTypeName                    string
+		TaskList                            string
+		Input                               []byte
+		ExecutionStartToCloseTimeoutSeconds int32
+		TaskStartToCloseTimeoutSeconds      int32
+		OverlapPolicy                       int
+		Paused                              bool
+		// state
+		NextRunTime      time.Time
+		LastRunTime      time.Time
+		LastWorkflowID   string
+		LastRunID        string
+		BufferedRunTimes []time.Time
+		Version          int64
+	}
+
+	// CreateScheduleRequest is used to create a schedule
+	CreateScheduleRequest struct {
+		Schedule *ScheduleInfo
+	}
+
+	// GetScheduleRequest is used to read a schedule
+	GetScheduleRequest struct {
+		DomainID   string
+		ScheduleID string
+	}
+
+	// GetScheduleResponse is the response to GetScheduleRequest
+	GetScheduleResponse struct {
+		Schedule *ScheduleInfo
+	}
+
+	// UpdateScheduleRequest is used to overwrite a schedule, it fails with a
+	// ConditionFailedError when the schedule is no longer at PreviousVersion
+	UpdateScheduleRequest struct {
+		Schedule        *ScheduleInfo
+		PreviousVersion int64
+	}
+
+	// DeleteScheduleRequest is used to delete a schedule
+	DeleteScheduleRequest struct {
+		DomainID   string
+		ScheduleID string
+	}
+
+	// ListSchedulesRequest is used to list the schedules of a domain
+	ListSchedulesRequest struct {
+		DomainID      string
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// ListSchedulesResponse is the response to ListSchedulesRequest
+	ListSchedulesResponse struct {
+		Schedules     []*ScheduleInfo
+		NextPageToken []byte
+	}
+
+	// ScheduleManager is used to manage the schedule store
+	ScheduleManager interface {
+		Closeable
+		CreateSchedule(request *CreateScheduleRequest) error
+		GetSchedule(request *GetScheduleRequest) (*GetScheduleResponse, error)
+		UpdateSchedule(request *UpdateScheduleRequest) error
+		DeleteSchedule(request *DeleteScheduleRequest) error
+		ListSchedules(request *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	}
+)
+
+// GetNextRunTime returns the first time the schedule is due strictly after the given time,
+// or a zero time when the schedule is no longer due after it
+func (s *ScheduleInfo) GetNextRunTime(after time.Time) time.Time {
+	interval := s.IntervalInSeconds * int64(time.Second)
+	if interval <= 0 {
+		return time.Time{}
+	}
+	if !s.StartTime.IsZero() && s.StartTime.After(after) {
+		after = s.StartTime.Add(-time.Nanosecond)
+	}
+
+	offset := s.OffsetInSeconds * int64(time.Second)
+	elapsed := after.UnixNano() - offset
+	intervals := elapsed / interval
+	if elapsed < 0 && elapsed%interval != 0 {
+		intervals--
+	}
+	next := time.Unix(0, (intervals+1)*interval+offset)
+	if !s.EndTime.IsZero() && next.After(s.EndTime) {
+		return time.Time{}
+	}
+	return next
+}
@@ -167,3 +167,81 @@ func copyShardInfo(sourceInfo *ShardInfo) *ShardInfo {
 		TimerAckLevel:       sourceInfo.TimerAckLevel,
 	}
 }
+
+func (s *shardPersistenceSuite) TestReplicationDLQ() {
+	shardID := 21
+	sourceCluster := "test_replication_dlq_source"
+	summary, err := s.ShardMgr.GetReplicationDLQSummary(&GetReplicationDLQSummaryRequest{
+		ShardID:       shardID,
+		SourceCluster: sourceCluster,
+	})
+	s.Nil(err)
+	s.Equal(int64(0), summary.MessageCount)
+	s.True(summary.OldestMessageTimestamp.IsZero())
+
+	newKey := func(runID string, firstEventID int64) ReplicationDLQMessageKey {
+		return ReplicationDLQMessageKey{
+			ShardID:       shardID,
+			SourceCluster: sourceCluster,
+			DomainID:      "2e5a1b36-e0a3-4b53-8b40-df5a4c94a4a1",
+			WorkflowID:    "test-replication-dlq",
+			RunID:         runID,
+			FirstEventID:  firstEventID,
+		}
+	}
+	keys := []ReplicationDLQMessageKey{
+		newKey("2b3e7b46-3b3c-4ea4-bd5d-7b3a1c9d6c60", 5),
+		newKey("2b3e7b46-3b3c-4ea4-bd5d-7b3a1c9d6c60", 8),
+		newKey("7c1f0b8e-5d2a-4c1e-9a3b-1e2d3c4b5a60", 5),
+	}
+	put := func(key ReplicationDLQMessageKey) error {
+		return s.ShardMgr.PutReplicationDLQMessage(&PutReplicationDLQMessageRequest{
+			ReplicationDLQMessageKey: key,
+			NextEventID:              key.FirstEventID + 3,
+			Version:                  1,
+		})
+	}
+	before := time.Now().Add(-time.Second)
+	for _, key := range keys {
+		s.Nil(put(key))
+	}
+	after := time.Now().Add(time.Second)
+	// a task moved to the DLQ again is not counted twice
+	s.Nil(put(keys[0]))
+
+	summary, err = s.ShardMgr.GetReplicationDLQSummary(&GetReplicationDLQSummaryRequest{
+		ShardID:       shardID,
+		SourceCluster: sourceCluster,
+	})
+	s.Nil(err)
+	s.Equal(int64(3), summary.MessageCount)
+	s.True(summary.OldestMessageTimestamp.After(before))
+	s.True(summary.OldestMessageTimestamp.Before(after))
+
+	// other source clusters and shards have their own DLQ
+	summary, err = s.ShardMgr.GetReplicationDLQSummary(&GetReplicationDLQSummaryRequest{
+		ShardID:       shardID + 1,
+		SourceCluster: sourceCluster,
+	})
+	s.Nil(err)
+	s.Equal(int64(0), summary.MessageCount)
+
+	err = s.ShardMgr.DeleteReplicationDLQMessage(&DeleteReplicationDLQMessageRequest{ReplicationDLQMessageKey: keys[1]})
+	s.Nil(err)
+	summary, err = s.ShardMgr.GetReplicationDLQSummary(&GetReplicationDLQSummaryRequest{
+		ShardID:       shardID,
+		SourceCluster: sourceCluster,
+	})
+	s.Nil(err)
+	s.Equal(int64(2), summary.MessageCount)
+
+	// deleting a task which is not in the DLQ is a no-op
+	err = s.ShardMgr.DeleteReplicationDLQMessage(&DeleteReplicationDLQMessageRequest{ReplicationDLQMessageKey: keys[1]})
+	s.Nil(err)
+	summary, err = s.ShardMgr.GetReplicationDLQSummary(&GetReplicationDLQSummaryRequest{
+		ShardID:       shardID,
+		SourceCluster: sourceCluster,
+	})
+	s.Nil(err)
+	s.Equal(int64(2), summary.MessageCount)
+}
@@ -37,6 +37,9 @@ type (
 		WorkflowTypeName string
 		StartTimestamp   int64
 		WorkflowTimeout  int64
+		Tags             map[string]string
+		// FirstRunID is the run ID of the first execution of the continue as new chain
+		FirstRunID string
 	}
 
 	// RecordWorkflowExecutionClosedRequest is used to add a record of a newly
@@ -46,10 +49,15 @@ type (
 		Execution        s.WorkflowExecution
 		WorkflowTypeName string
 		StartTimestamp   int64
-		CloseTimestamp   int64
-		Status           s.WorkflowExecutionCloseStatus
-		HistoryLength    int64
-		RetentionSeconds int64
+		// ExecutionTimestamp is when the execution began running, defaults to StartTimestamp
+		ExecutionTimestamp int64
+		CloseTimestamp     int64
+		Status             s.WorkflowExecutionCloseStatus
+		HistoryLength      int64
+		RetentionSeconds   int64
+		Tags               map[string]string
+		// FirstRunID is the run ID of the first execution of the continue as new chain
+		FirstRunID string
 	}
 
 	// ListWorkflowExecutionsRequest is used to list executions in a domain
@@ -93,6 +101,32 @@ type (
 		Status s.WorkflowExecutionCloseStatus
 	}
 
+	// ListWorkflowExecutionsByTagRequest is used to list executions that
+	// were started with a specific tag value in a domain
+	ListWorkflowExecutionsByTagRequest struct {
+		ListWorkflowExecutionsRequest
+		TagKey   string
+		TagValue string
+	}
+
+	// ListWorkflowExecutionsByFirstRunIDRequest is used to list the executions
+	// of a continue as new chain in a domain
+	ListWorkflowExecutionsByFirstRunIDRequest struct {
+		ListWorkflowExecutionsRequest
+		FirstRunID string
+	}
+
+	// ListAllWorkflowExecutionsRequest is used to list both the open and the closed
+	// executions in a domain, filtered by at most one of the filter fields
+	ListAllWorkflowExecutionsRequest struct {
+		ListWorkflowExecutionsRequest
+		WorkflowID       string
+		WorkflowTypeName string
+		TagKey           string
+		TagValue         string
+		FirstRunID       string
+	}
+
 	// GetClosedWorkflowExecutionRequest is used retrieve the record for a specific execution
 	GetClosedWorkflowExecutionRequest struct {
 		DomainUUID string
@@ -104,6 +138,27 @@ type (
 		Execution *s.WorkflowExecutionInfo
 	}
 
+	// VisibilityDeleteWorkflowExecutionRequest is used to delete the open and closed records of a specific execution
+	VisibilityDeleteWorkflowExecutionRequest struct {
+		DomainUUID     string
+		WorkflowID     string
+		RunID          string
+		StartTimestamp int64
+	}
+
+	// PruneClosedWorkflowExecutionsRequest is used to drop the closed records of a domain past its retention
+	PruneClosedWorkflowExecutionsRequest struct {
+		DomainUUID string
+		// Records closed before this time are dropped, the ones sharing a close bucket with a later record are kept
+		// until the whole bucket can go
+		CloseTimeBefore int64
+	}
+
+	// PruneClosedWorkflowExecutionsResponse is the response to PruneClosedWorkflowExecutionsRequest
+	PruneClosedWorkflowExecutionsResponse struct {
+		PrunedBuckets int
+	}
+
 	// VisibilityManager is used to manage the visibility store
 	VisibilityManager interface {
 		Closeable
@@ -116,6 +171,13 @@ type (
 		ListOpenWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error)
 		ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error)
 		ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error)
+		ListOpenWorkflowExecutionsByTag(request *ListWorkflowExecutionsByTagRequest) (*ListWorkflowExecutionsResponse, error)
+		ListClosedWorkflowExecutionsByTag(request *ListWorkflowExecutionsByTagRequest) (*ListWorkflowExecutionsResponse, error)
+		ListOpenWorkflowExecutionsByFirstRunID(request *ListWorkflowExecutionsByFirstRunIDRequest) (*ListWorkflowExecutionsResponse, error)
+		ListClosedWorkflowExecutionsByFirstRunID(request *ListWorkflowExecutionsByFirstRunIDRequest) (*ListWorkflowExecutionsResponse, error)
+		ListAllWorkflowExecutions(request *ListAllWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error)
 		GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error)
+		DeleteWorkflowExecution(request *VisibilityDeleteWorkflowExecutionRequest) error
+		PruneClosedWorkflowExecutions(request *PruneClosedWorkflowExecutionsRequest) (*PruneClosedWorkflowExecutionsResponse, error)
 	}
 )
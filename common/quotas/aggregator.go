@@ -0,0 +1,138 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+const (
+	// usageDecay is the weight of the previous request rate of a host when it reports a new usage
+	usageDecay = 0.5
+	// idleHostShare is the share of the limit split equally among the hosts of a key, regardless of
+	// their usage, so a host which saw no recent request can still admit a few
+	idleHostShare = 0.1
+)
+
+type (
+	// Usage is the number of requests admitted and rejected by the limiter of a key since its last update
+	Usage struct {
+		Allowed  int64
+		Rejected int64
+	}
+
+	// Aggregator shares out the global limit of each key among the hosts reporting its usage, in
+	// proportion to the request rate each host saw recently. Hosts which stop reporting a key are
+	// forgotten after the host TTL.
+	Aggregator struct {
+		sync.Mutex
+		hostTTL    dynamicconfig.DurationPropertyFn
+		timeSource common.TimeSource
+		keys       map[string]map[string]*hostUsage
+		lastGC     time.Time
+	}
+
+	hostUsage struct {
+		rps        float64
+		lastUpdate time.Time
+	}
+)
+
+// NewAggregator creates a new aggregator
+func NewAggregator(hostTTL dynamicconfig.DurationPropertyFn, timeSource common.TimeSource) *Aggregator {
+	return &Aggregator{
+		hostTTL:    hostTTL,
+		timeSource: timeSource,
+		keys:       make(map[string]map[string]*hostUsage),
+		lastGC:     timeSource.Now(),
+	}
+}
+
+// Update records the usage the host saw over the elapsed time, and returns the share of the
+// global limit of each reported key the host should admit, between 0 and 1
+func (a *Aggregator) Update(host string, elapsed time.Duration, usages map[string]Usage) map[string]float64 {
+	now := a.timeSource.Now()
+	hostTTL := a.hostTTL()
+
+	a.Lock()
+	defer a.Unlock()
+
+	if now.Sub(a.lastGC) >= hostTTL {
+		a.gcLocked(now, hostTTL)
+	}
+
+	weights := make(map[string]float64, len(usages))
+	for key, usage := range usages {
+		hosts, ok := a.keys[key]
+		if !ok {
+			hosts = make(map[string]*hostUsage)
+			a.keys[key] = hosts
+		}
+
+		rps := float64(0)
+		if elapsed > 0 {
+			rps = float64(usage.Allowed+usage.Rejected) / elapsed.Seconds()
+		}
+		if previous, ok := hosts[host]; ok && now.Sub(previous.lastUpdate) < hostTTL {
+			previous.rps = usageDecay*previous.rps + (1-usageDecay)*rps
+			previous.lastUpdate = now
+		} else {
+			hosts[host] = &hostUsage{rps: rps, lastUpdate: now}
+		}
+		weights[key] = a.weightLocked(hosts, host, now, hostTTL)
+	}
+	return weights
+}
+
+func (a *Aggregator) weightLocked(hosts map[string]*hostUsage, host string, now time.Time, hostTTL time.Duration) float64 {
+	total := float64(0)
+	for name, usage := range hosts {
+		if now.Sub(usage.lastUpdate) >= hostTTL {
+			delete(hosts, name)
+			continue
+		}
+		total += usage.rps
+	}
+
+	equalShare := 1 / float64(len(hosts))
+	if total == 0 {
+		return equalShare
+	}
+	return idleHostShare*equalShare + (1-idleHostShare)*hosts[host].rps/total
+}
+
+func (a *Aggregator) gcLocked(now time.Time, hostTTL time.Duration) {
+	for key, hosts := range a.keys {
+		for name, usage := range hosts {
+			if now.Sub(usage.lastUpdate) >= hostTTL {
+				delete(hosts, name)
+			}
+		}
+		if len(hosts) == 0 {
+			delete(a.keys, key)
+		}
+	}
+	a.lastGC = now
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+func TestAggregatorWeightsFollowUsage(t *testing.T) {
+	timeSource := common.NewEventTimeSource().Update(time.Now())
+	aggregator := NewAggregator(dynamicconfig.GetDurationPropertyFn(time.Minute), timeSource)
+
+	weights := aggregator.Update("host-a", time.Second, map[string]Usage{"key": {Allowed: 80, Rejected: 10}})
+	assert.InDelta(t, 1, weights["key"], 0.0001)
+
+	weights = aggregator.Update("host-b", time.Second, map[string]Usage{"key": {Allowed: 10}})
+	assert.InDelta(t, 0.05+0.9*0.1, weights["key"], 0.0001)
+
+	weights = aggregator.Update("host-a", time.Second, map[string]Usage{"key": {Allowed: 90}})
+	assert.InDelta(t, 0.05+0.9*0.9, weights["key"], 0.0001)
+}
+
+func TestAggregatorEqualShareWhenIdle(t *testing.T) {
+	timeSource := common.NewEventTimeSource().Update(time.Now())
+	aggregator := NewAggregator(dynamicconfig.GetDurationPropertyFn(time.Minute), timeSource)
+
+	aggregator.Update("host-a", time.Second, map[string]Usage{"key": {}})
+	weights := aggregator.Update("host-b", time.Second, map[string]Usage{"key": {}})
+	assert.InDelta(t, 0.5, weights["key"], 0.0001)
+}
+
+func TestAggregatorExpiresHosts(t *testing.T) {
+	now := time.Now()
+	timeSource := common.NewEventTimeSource().Update(now)
+	aggregator := NewAggregator(dynamicconfig.GetDurationPropertyFn(time.Minute), timeSource)
+
+	aggregator.Update("host-a", time.Second, map[string]Usage{"key": {Allowed: 90}})
+	weights := aggregator.Update("host-b", time.Second, map[string]Usage{"key": {Allowed: 10}})
+	assert.True(t, weights["key"] < 0.5)
+
+	timeSource.Update(now.Add(time.Minute))
+	weights = aggregator.Update("host-b", time.Second, map[string]Usage{"key": {Allowed: 10}})
+	assert.InDelta(t, 1, weights["key"], 0.0001)
+	assert.Equal(t, 1, len(aggregator.keys["key"]))
+}
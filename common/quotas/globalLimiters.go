@@ -0,0 +1,239 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"golang.org/x/time/rate"
+)
+
+// maxIdleUpdates is the number of updates without usage after which the limiter of a key is dropped
+const maxIdleUpdates = 10
+
+type (
+	// UpdateFn reports the usage of the limiters of a host over the elapsed time, and returns the
+	// share of the global limit of each key the host should admit. A failed update may still return
+	// the shares of the keys which were updated.
+	UpdateFn func(usages map[string]Usage, elapsed time.Duration) (map[string]float64, error)
+
+	// LimitFn returns the global limit in requests per second of a name, a limit <= 0 means unlimited
+	LimitFn func(name string) float64
+
+	// GlobalLimiters enforces limits shared by all the hosts using the same names, rather than
+	// admitting the whole limit on every host. The usage of each name is periodically reported
+	// with the update function, and the host admits the share of the limit it gets back. Until
+	// the first share is known, or if the updates fail, the host admits the whole limit.
+	GlobalLimiters struct {
+		prefix         string
+		limit          LimitFn
+		updateInterval dynamicconfig.DurationPropertyFn
+		logger         bark.Logger
+		update         UpdateFn
+
+		sync.RWMutex
+		limiters   map[string]*globalLimiter
+		lastUpdate time.Time
+		shutdownCh chan struct{}
+		shutdownWG sync.WaitGroup
+	}
+
+	globalLimiter struct {
+		allowed  int64
+		rejected int64
+		limiter  atomic.Value // *rate.Limiter
+		// below are only accessed by the update loop
+		rps         float64
+		weight      float64
+		idleUpdates int
+	}
+)
+
+// NewGlobalLimiters creates the limiters of the names, the prefix makes the keys reported for the
+// names distinct from the keys of other limiters sharing the same aggregator
+func NewGlobalLimiters(prefix string, limit LimitFn, updateInterval dynamicconfig.DurationPropertyFn,
+	logger bark.Logger) *GlobalLimiters {
+	return &GlobalLimiters{
+		prefix:         prefix,
+		limit:          limit,
+		updateInterval: updateInterval,
+		logger:         logger,
+		limiters:       make(map[string]*globalLimiter),
+		shutdownCh:     make(chan struct{}),
+	}
+}
+
+// Start starts reporting the usage of the limiters with the update function
+func (c *GlobalLimiters) Start(update UpdateFn) {
+	c.update = update
+	c.lastUpdate = time.Now()
+	c.shutdownWG.Add(1)
+	go c.updateLoop()
+}
+
+// Stop stops reporting the usage of the limiters
+func (c *GlobalLimiters) Stop() {
+	close(c.shutdownCh)
+	c.shutdownWG.Wait()
+}
+
+// Allow returns whether a request of the name is admitted right away, a nil collection admits everything
+func (c *GlobalLimiters) Allow(name string) bool {
+	if c == nil || c.limit(name) <= 0 {
+		return true
+	}
+
+	l := c.getLimiter(name)
+	if l.limiter.Load().(*rate.Limiter).Allow() {
+		atomic.AddInt64(&l.allowed, 1)
+		return true
+	}
+	atomic.AddInt64(&l.rejected, 1)
+	return false
+}
+
+// Wait blocks until a request of the name is admitted, a request which had to wait is reported as
+// rejected since the host would have needed a larger share to admit it right away
+func (c *GlobalLimiters) Wait(ctx context.Context, name string) error {
+	if c == nil || c.limit(name) <= 0 {
+		return nil
+	}
+
+	l := c.getLimiter(name)
+	rsv := l.limiter.Load().(*rate.Limiter).Reserve()
+	delay := rsv.Delay()
+	if delay == 0 {
+		atomic.AddInt64(&l.allowed, 1)
+		return nil
+	}
+	atomic.AddInt64(&l.rejected, 1)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		rsv.Cancel()
+		return ctx.Err()
+	}
+}
+
+func (c *GlobalLimiters) getLimiter(name string) *globalLimiter {
+	c.RLock()
+	l, ok := c.limiters[name]
+	c.RUnlock()
+	if ok {
+		return l
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if l, ok = c.limiters[name]; ok {
+		return l
+	}
+	l = &globalLimiter{weight: 1}
+	l.setRPS(c.limit(name))
+	c.limiters[name] = l
+	return l
+}
+
+func (c *GlobalLimiters) updateLoop() {
+	defer c.shutdownWG.Done()
+
+	timer := time.NewTimer(c.updateInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-c.shutdownCh:
+			return
+		case <-timer.C:
+			c.updateLimiters()
+			timer.Reset(c.updateInterval())
+		}
+	}
+}
+
+func (c *GlobalLimiters) updateLimiters() {
+	now := time.Now()
+	elapsed := now.Sub(c.lastUpdate)
+	c.lastUpdate = now
+
+	c.Lock()
+	limiters := make(map[string]*globalLimiter, len(c.limiters))
+	usages := make(map[string]Usage)
+	for name, l := range c.limiters {
+		usage := Usage{
+			Allowed:  atomic.SwapInt64(&l.allowed, 0),
+			Rejected: atomic.SwapInt64(&l.rejected, 0),
+		}
+		if usage.Allowed == 0 && usage.Rejected == 0 {
+			l.idleUpdates++
+			if l.idleUpdates >= maxIdleUpdates {
+				delete(c.limiters, name)
+				continue
+			}
+		} else {
+			l.idleUpdates = 0
+			usages[c.prefix+name] = usage
+		}
+		limiters[name] = l
+	}
+	c.Unlock()
+
+	if len(usages) != 0 {
+		weights, err := c.update(usages, elapsed)
+		if err != nil {
+			// the keys without a new share keep admitting the previous one until an update succeeds
+			c.logger.WithFields(bark.Fields{
+				logging.TagErr: err,
+			}).Warn("Failed to update global rate limits.")
+		}
+		for name, l := range limiters {
+			if weight, ok := weights[c.prefix+name]; ok {
+				l.weight = weight
+			}
+		}
+	}
+
+	// the limits are dynamic config, so refresh all of them
+	for name, l := range limiters {
+		l.setRPS(c.limit(name) * l.weight)
+	}
+}
+
+func (l *globalLimiter) setRPS(rps float64) {
+	if l.limiter.Load() != nil && rps == l.rps {
+		return
+	}
+	l.rps = rps
+	burst := int(rps)
+	if burst < 1 {
+		burst = 1
+	}
+	l.limiter.Store(rate.NewLimiter(rate.Limit(rps), burst))
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+func newTestGlobalLimiters(limit float64) *GlobalLimiters {
+	return NewGlobalLimiters(
+		"test:",
+		func(name string) float64 { return limit },
+		dynamicconfig.GetDurationPropertyFn(time.Second),
+		bark.NewNopLogger(),
+	)
+}
+
+func TestGlobalLimitersNilAdmitsEverything(t *testing.T) {
+	var limiters *GlobalLimiters
+	assert.True(t, limiters.Allow("domain"))
+	assert.NoError(t, limiters.Wait(context.Background(), "domain"))
+}
+
+func TestGlobalLimitersUnlimited(t *testing.T) {
+	limiters := newTestGlobalLimiters(0)
+	for i := 0; i < 100; i++ {
+		assert.True(t, limiters.Allow("domain"))
+	}
+	assert.Empty(t, limiters.limiters)
+}
+
+func TestGlobalLimitersAllow(t *testing.T) {
+	limiters := newTestGlobalLimiters(10)
+	for i := 0; i < 10; i++ {
+		assert.True(t, limiters.Allow("domain"))
+	}
+	assert.False(t, limiters.Allow("domain"))
+}
+
+func TestGlobalLimitersUpdateAppliesWeights(t *testing.T) {
+	limiters := newTestGlobalLimiters(10)
+	var reported map[string]Usage
+	limiters.update = func(usages map[string]Usage, elapsed time.Duration) (map[string]float64, error) {
+		reported = usages
+		return map[string]float64{"test:domain": 0.5}, nil
+	}
+
+	for i := 0; i < 11; i++ {
+		limiters.Allow("domain")
+	}
+	limiters.updateLimiters()
+	assert.Equal(t, map[string]Usage{"test:domain": {Allowed: 10, Rejected: 1}}, reported)
+
+	for i := 0; i < 5; i++ {
+		assert.True(t, limiters.Allow("domain"))
+	}
+	assert.False(t, limiters.Allow("domain"))
+}
+
+func TestGlobalLimitersUpdateFailureKeepsWeights(t *testing.T) {
+	limiters := newTestGlobalLimiters(10)
+	limiters.update = func(usages map[string]Usage, elapsed time.Duration) (map[string]float64, error) {
+		return nil, errors.New("update failed")
+	}
+
+	limiters.Allow("domain")
+	limiters.updateLimiters()
+	assert.Equal(t, float64(1), limiters.limiters["domain"].weight)
+	assert.Equal(t, float64(10), limiters.limiters["domain"].rps)
+}
+
+func TestGlobalLimitersDropIdleLimiters(t *testing.T) {
+	limiters := newTestGlobalLimiters(10)
+	limiters.update = func(usages map[string]Usage, elapsed time.Duration) (map[string]float64, error) {
+		return nil, nil
+	}
+
+	limiters.Allow("domain")
+	for i := 0; i <= maxIdleUpdates; i++ {
+		limiters.updateLimiters()
+	}
+	assert.Empty(t, limiters.limiters)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"context"
+	"time"
+
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common"
+)
+
+// updateTimeout bounds the time spent reporting the usage of the limiters of a host
+const updateTimeout = 5 * time.Second
+
+// NewHistoryUpdateFn returns an update function reporting the usage of the limiters of the host to
+// the history hosts, which aggregate the usage of each key on the owner of the shard of the key
+func NewHistoryUpdateFn(client history.Client, host string) UpdateFn {
+	return func(usages map[string]Usage, elapsed time.Duration) (map[string]float64, error) {
+		request := &h.RatelimitUpdateRequest{
+			Host:          common.StringPtr(host),
+			ElapsedMillis: common.Int64Ptr(int64(elapsed / time.Millisecond)),
+		}
+		for key, usage := range usages {
+			request.Usages = append(request.Usages, &h.RatelimitUsage{
+				Key:      common.StringPtr(key),
+				Allowed:  common.Int64Ptr(usage.Allowed),
+				Rejected: common.Int64Ptr(usage.Rejected),
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), updateTimeout)
+		defer cancel()
+		// the shards are updated independently, the quotas of the shards which were updated are returned with the
+		// error of the others
+		response, err := client.RatelimitUpdate(ctx, request)
+		if response == nil {
+			return nil, err
+		}
+
+		weights := make(map[string]float64, len(response.Quotas))
+		for _, quota := range response.Quotas {
+			weights[quota.GetKey()] = quota.GetWeight()
+		}
+		return weights, err
+	}
+}
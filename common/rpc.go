@@ -43,6 +43,16 @@ const (
 	// ClientImplHeaderName refers to the name of the
 	// header that contains the client implementation
 	ClientImplHeaderName = "cadence-client-name"
+
+	// AcceptPayloadEncodingHeaderName refers to the name of the
+	// header listing the payload encodings the client can
+	// decode, separated by commas
+	AcceptPayloadEncodingHeaderName = "cadence-accept-payload-encoding"
+
+	// PayloadEncodingHeaderName refers to the name of the
+	// response header that contains the encoding the payloads
+	// of the response were compressed with
+	PayloadEncodingHeaderName = "cadence-payload-encoding"
 )
 
 type (
@@ -0,0 +1,72 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/audit"
+	"github.com/uber/cadence/common/messaging"
+)
+
+const (
+	// AuditSinkFile appends the audit log to a local file
+	AuditSinkFile = "file"
+	// AuditSinkKafka publishes the audit log to a kafka topic
+	AuditSinkKafka = "kafka"
+)
+
+// NewSink creates the audit sink described by the config, it returns nil when the audit log is disabled
+func (cfg *Audit) NewSink(kafkaConfig *messaging.KafkaConfig, logger bark.Logger) (audit.Sink, error) {
+	switch cfg.Sink {
+	case "":
+		return nil, nil
+	case AuditSinkFile:
+		if cfg.File == "" {
+			return nil, fmt.Errorf("audit file not set for the %v sink", cfg.Sink)
+		}
+		return audit.NewFileSink(cfg.File)
+	case AuditSinkKafka:
+		if cfg.Topic == "" {
+			return nil, fmt.Errorf("audit topic not set for the %v sink", cfg.Sink)
+		}
+		brokers := kafkaConfig.GetBrokersForTopic(cfg.Topic)
+		if len(brokers) == 0 {
+			return nil, fmt.Errorf("no kafka brokers configured for audit topic %v", cfg.Topic)
+		}
+		// the producer is re-created with the current credentials once the brokers reject the ones it was created with
+		producer, err := messaging.NewRefreshingSyncProducer(func() (sarama.SyncProducer, error) {
+			producerConfig, err := kafkaConfig.NewProducerConfig(cfg.Topic)
+			if err != nil {
+				return nil, err
+			}
+			return sarama.NewSyncProducer(brokers, producerConfig)
+		}, logger)
+		if err != nil {
+			return nil, err
+		}
+		return audit.NewKafkaSink(cfg.Topic, producer), nil
+	default:
+		return nil, fmt.Errorf("unknown audit sink: %v", cfg.Sink)
+	}
+}
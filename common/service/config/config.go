@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/uber-go/tally/m3"
+	"github.com/uber/cadence/common/credentials"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/ringpop-go/discovery"
 )
@@ -44,6 +45,8 @@ type (
 		Services map[string]Service `yaml:"services"`
 		// Kafka is the config for connecting to kafka
 		Kafka messaging.KafkaConfig `yaml:"kafka"`
+		// Audit is the config for the audit log of the frontend
+		Audit Audit `yaml:"audit"`
 	}
 
 	// Service contains the service specific config items
@@ -100,6 +103,9 @@ type (
 		User string `yaml:"user"`
 		// Password is the cassandra password used for authentication by gocql client
 		Password string `yaml:"password"`
+		// Credentials is the provider of the user and the password, used instead of the static ones above when
+		// they should not be kept in the config file
+		Credentials credentials.Config `yaml:"credentials"`
 		// Keyspace is the cassandra keyspace
 		Keyspace string `yaml:"keyspace" validate:"nonzero"`
 		// VisibilityKeyspace is the cassandra keyspace for visibility store
@@ -116,6 +122,16 @@ type (
 	Replicator struct {
 	}
 
+	// Audit contains the config items for the audit log of administrative and mutating operations
+	Audit struct {
+		// Sink is the kind of store the audit log is written to, file or kafka, empty disables the audit log
+		Sink string `yaml:"sink"`
+		// File is the path of the file the audit log is appended to by the file sink
+		File string `yaml:"file"`
+		// Topic is the kafka topic the audit log is published to by the kafka sink
+		Topic string `yaml:"topic"`
+	}
+
 	// Logger contains the config items for logger
 	Logger struct {
 		// Stdout is true if the output needs to goto standard out
@@ -140,6 +156,16 @@ type (
 		CurrentClusterName string `yaml:"currentClusterName"`
 		// ClusterInitialFailoverVersions contains all cluster names to corresponding initial failover version
 		ClusterInitialFailoverVersions map[string]int64 `yaml:"clusterInitialFailoverVersion"`
+		// ClusterAddress contains all cluster names to corresponding frontend address
+		ClusterAddress map[string]Address `yaml:"clusterAddress"`
+	}
+
+	// Address indicates the remote cluster's service name and address
+	Address struct {
+		// RPCName indicate the remote service name
+		RPCName string `yaml:"rpcName"`
+		// RPCAddress indicate the remote service address(Host:Port)
+		RPCAddress string `yaml:"rpcAddress"`
 	}
 
 	// Metrics contains the config items for metrics subsystem
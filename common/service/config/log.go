@@ -39,7 +39,7 @@ func (cfg *Logger) NewBarkLogger() bark.Logger {
 
 	logger := logrus.New()
 	logger.Out = ioutil.Discard
-	logger.Level = parseLogrusLevel(cfg.Level)
+	logger.Level = ParseLogrusLevel(cfg.Level)
 	logger.Formatter = getFormatter()
 
 	if cfg.Stdout {
@@ -77,9 +77,9 @@ func createLogFile(path string) *os.File {
 	return file
 }
 
-// parseLogrusLevel converts the string log
+// ParseLogrusLevel converts the string log
 // level into a logrus level
-func parseLogrusLevel(level string) logrus.Level {
+func ParseLogrusLevel(level string) logrus.Level {
 	switch strings.ToLower(level) {
 	case "debug":
 		return logrus.DebugLevel
@@ -43,12 +43,12 @@ func (s *LogSuite) SetupTest() {
 }
 
 func (s *LogSuite) TestParseLogLevel() {
-	s.Equal(logrus.DebugLevel, parseLogrusLevel("debug"))
-	s.Equal(logrus.InfoLevel, parseLogrusLevel("info"))
-	s.Equal(logrus.WarnLevel, parseLogrusLevel("warn"))
-	s.Equal(logrus.ErrorLevel, parseLogrusLevel("error"))
-	s.Equal(logrus.FatalLevel, parseLogrusLevel("fatal"))
-	s.Equal(logrus.InfoLevel, parseLogrusLevel("unknown"))
+	s.Equal(logrus.DebugLevel, ParseLogrusLevel("debug"))
+	s.Equal(logrus.InfoLevel, ParseLogrusLevel("info"))
+	s.Equal(logrus.WarnLevel, ParseLogrusLevel("warn"))
+	s.Equal(logrus.ErrorLevel, ParseLogrusLevel("error"))
+	s.Equal(logrus.FatalLevel, ParseLogrusLevel("fatal"))
+	s.Equal(logrus.InfoLevel, ParseLogrusLevel("unknown"))
 }
 
 func (s *LogSuite) TestNewLogger() {
@@ -73,6 +73,18 @@ type BoolPropertyFn func(opts ...FilterOption) bool
 // BoolPropertyFnWithTaskListInfoFilters is a wrapper to get bool property from dynamic config with three filters: domain, taskList, taskType
 type BoolPropertyFnWithTaskListInfoFilters func(domain string, taskList string, taskType int) bool
 
+// StringPropertyFn is a wrapper to get string property from dynamic config
+type StringPropertyFn func(opts ...FilterOption) string
+
+// StringPropertyFnWithDomainFilter is a wrapper to get string property from dynamic config with domain as filter
+type StringPropertyFnWithDomainFilter func(domain string) string
+
+// MapPropertyFn is a wrapper to get map property from dynamic config
+type MapPropertyFn func(opts ...FilterOption) map[string]interface{}
+
+// MapPropertyFnWithDomainFilter is a wrapper to get map property from dynamic config with domain as filter
+type MapPropertyFnWithDomainFilter func(domain string) map[string]interface{}
+
 // GetProperty gets a eface property and returns defaultValue if property is not found
 func (c *Collection) GetProperty(key Key, defaultValue interface{}) PropertyFn {
 	return func() interface{} {
@@ -203,3 +215,47 @@ func (c *Collection) GetBoolPropertyFilteredByTaskListInfo(key Key, defaultValue
 		return val
 	}
 }
+
+// GetStringProperty gets property and asserts that it's a string
+func (c *Collection) GetStringProperty(key Key, defaultValue string) StringPropertyFn {
+	return func(opts ...FilterOption) string {
+		val, err := c.client.GetStringValue(key, getFilterMap(opts...), defaultValue)
+		if err != nil {
+			c.logNoValue(key, err)
+		}
+		return val
+	}
+}
+
+// GetStringPropertyFilteredByDomain gets property with domain filter and asserts that it's a string
+func (c *Collection) GetStringPropertyFilteredByDomain(key Key, defaultValue string) StringPropertyFnWithDomainFilter {
+	return func(domain string) string {
+		val, err := c.client.GetStringValue(key, getFilterMap(DomainFilter(domain)), defaultValue)
+		if err != nil {
+			c.logNoValue(key, err)
+		}
+		return val
+	}
+}
+
+// GetMapProperty gets property and asserts that it's a map
+func (c *Collection) GetMapProperty(key Key, defaultValue map[string]interface{}) MapPropertyFn {
+	return func(opts ...FilterOption) map[string]interface{} {
+		val, err := c.client.GetMapValue(key, getFilterMap(opts...), defaultValue)
+		if err != nil {
+			c.logNoValue(key, err)
+		}
+		return val
+	}
+}
+
+// GetMapPropertyFilteredByDomain gets property with domain filter and asserts that it's a map
+func (c *Collection) GetMapPropertyFilteredByDomain(key Key, defaultValue map[string]interface{}) MapPropertyFnWithDomainFilter {
+	return func(domain string) map[string]interface{} {
+		val, err := c.client.GetMapValue(key, getFilterMap(DomainFilter(domain)), defaultValue)
+		if err != nil {
+			c.logNoValue(key, err)
+		}
+		return val
+	}
+}
@@ -29,6 +29,11 @@ func GetIntPropertyFn(value int) func(opts ...FilterOption) int {
 	return func(...FilterOption) int { return value }
 }
 
+// GetIntPropertyFilteredByDomain returns value as IntPropertyFnWithDomainFilter
+func GetIntPropertyFilteredByDomain(value int) func(domain string) int {
+	return func(domain string) int { return value }
+}
+
 // GetIntPropertyFilteredByTaskListInfo returns value as IntPropertyFnWithTaskListInfoFilters
 func GetIntPropertyFilteredByTaskListInfo(value int) func(domain string, taskList string, taskType int) int {
 	return func(domain string, taskList string, taskType int) int { return value }
@@ -44,12 +49,42 @@ func GetBoolPropertyFn(value bool) func(opts ...FilterOption) bool {
 	return func(...FilterOption) bool { return value }
 }
 
+// GetBoolPropertyFnFilteredByTaskListInfo returns value as BoolPropertyFnWithTaskListInfoFilters
+func GetBoolPropertyFnFilteredByTaskListInfo(value bool) func(domain string, taskList string, taskType int) bool {
+	return func(domain string, taskList string, taskType int) bool { return value }
+}
+
 // GetDurationPropertyFn returns value as DurationPropertyFn
 func GetDurationPropertyFn(value time.Duration) func(opts ...FilterOption) time.Duration {
 	return func(...FilterOption) time.Duration { return value }
 }
 
+// GetDurationPropertyFnFilteredByDomain returns value as DurationPropertyFnWithDomainFilter
+func GetDurationPropertyFnFilteredByDomain(value time.Duration) func(domain string) time.Duration {
+	return func(domain string) time.Duration { return value }
+}
+
 // GetDurationPropertyFnFilteredByTaskListInfo returns value as DurationPropertyFnWithTaskListInfoFilters
 func GetDurationPropertyFnFilteredByTaskListInfo(value time.Duration) func(domain string, taskList string, taskType int) time.Duration {
 	return func(domain string, taskList string, taskType int) time.Duration { return value }
 }
+
+// GetStringPropertyFn returns value as StringPropertyFn
+func GetStringPropertyFn(value string) func(opts ...FilterOption) string {
+	return func(...FilterOption) string { return value }
+}
+
+// GetStringPropertyFnFilteredByDomain returns value as StringPropertyFnWithDomainFilter
+func GetStringPropertyFnFilteredByDomain(value string) func(domain string) string {
+	return func(domain string) string { return value }
+}
+
+// GetMapPropertyFn returns value as MapPropertyFn
+func GetMapPropertyFn(value map[string]interface{}) func(opts ...FilterOption) map[string]interface{} {
+	return func(...FilterOption) map[string]interface{} { return value }
+}
+
+// GetMapPropertyFnFilteredByDomain returns value as MapPropertyFnWithDomainFilter
+func GetMapPropertyFnFilteredByDomain(value map[string]interface{}) func(domain string) map[string]interface{} {
+	return func(domain string) map[string]interface{} { return value }
+}
@@ -221,6 +221,40 @@ func (s *configSuite) TestGetDurationPropertyFilteredByTaskListInfo() {
 	s.Equal(time.Minute, value(domain, taskList, taskType))
 }
 
+func (s *configSuite) TestGetStringProperty() {
+	key := testGetStringPropertyKey
+	value := s.cln.GetStringProperty(key, "a")
+	s.Equal("a", value())
+	s.client.SetValue(key, "b")
+	s.Equal("b", value())
+}
+
+func (s *configSuite) TestGetStringPropertyFilteredByDomain() {
+	key := testGetStringPropertyFilteredByDomainKey
+	domain := "testDomain"
+	value := s.cln.GetStringPropertyFilteredByDomain(key, "a")
+	s.Equal("a", value(domain))
+	s.client.SetValue(key, "b")
+	s.Equal("b", value(domain))
+}
+
+func (s *configSuite) TestGetMapProperty() {
+	key := testGetMapPropertyKey
+	value := s.cln.GetMapProperty(key, map[string]interface{}{"a": 1})
+	s.Equal(map[string]interface{}{"a": 1}, value())
+	s.client.SetValue(key, map[string]interface{}{"b": 2})
+	s.Equal(map[string]interface{}{"b": 2}, value())
+}
+
+func (s *configSuite) TestGetMapPropertyFilteredByDomain() {
+	key := testGetMapPropertyFilteredByDomainKey
+	domain := "testDomain"
+	value := s.cln.GetMapPropertyFilteredByDomain(key, map[string]interface{}{})
+	s.Empty(value(domain))
+	s.client.SetValue(key, map[string]interface{}{"team": 64})
+	s.Equal(map[string]interface{}{"team": 64}, value(domain))
+}
+
 func TestDynamicConfigKeyIsMapped(t *testing.T) {
 	for i := unknownKey; i < lastKeyForTest; i++ {
 		key, ok := keys[i]
@@ -46,17 +46,42 @@ var keys = map[Key]string{
 	testGetIntPropertyFilteredByTaskListInfoKey:      "testGetIntPropertyFilteredByTaskListInfoKey",
 	testGetDurationPropertyFilteredByTaskListInfoKey: "testGetDurationPropertyFilteredByTaskListInfoKey",
 	testGetBoolPropertyFilteredByTaskListInfoKey:     "testGetBoolPropertyFilteredByTaskListInfoKey",
+	testGetStringPropertyKey:                         "testGetStringPropertyKey",
+	testGetStringPropertyFilteredByDomainKey:         "testGetStringPropertyFilteredByDomainKey",
+	testGetMapPropertyKey:                            "testGetMapPropertyKey",
+	testGetMapPropertyFilteredByDomainKey:            "testGetMapPropertyFilteredByDomainKey",
 
 	// system settings
-	EnableGlobalDomain: "system.enableGlobalDomain",
+	EnableGlobalDomain:              "system.enableGlobalDomain",
+	GlobalRatelimiterUpdateInterval: "system.globalRatelimiterUpdateInterval",
 
 	// frontend settings
-	FrontendPersistenceMaxQPS:      "frontend.persistenceMaxQPS",
-	FrontendVisibilityMaxPageSize:  "frontend.visibilityMaxPageSize",
-	FrontendHistoryMaxPageSize:     "frontend.historyMaxPageSize",
-	FrontendRPS:                    "frontend.rps",
-	FrontendHistoryMgrNumConns:     "frontend.historyMgrNumConns",
-	MaxDecisionStartToCloseTimeout: "frontend.maxDecisionStartToCloseTimeout",
+	FrontendPersistenceMaxQPS:                "frontend.persistenceMaxQPS",
+	FrontendVisibilityMaxPageSize:            "frontend.visibilityMaxPageSize",
+	FrontendHistoryMaxPageSize:               "frontend.historyMaxPageSize",
+	FrontendRPS:                              "frontend.rps",
+	FrontendHistoryMgrNumConns:               "frontend.historyMgrNumConns",
+	FrontendPersistenceHedgedReadDelay:       "frontend.persistenceHedgedReadDelay",
+	MaxDecisionStartToCloseTimeout:           "frontend.maxDecisionStartToCloseTimeout",
+	FrontendExecutionTagQuotas:               "frontend.executionTagQuotas",
+	FrontendMaxConcurrentRequests:            "frontend.maxConcurrentRequests",
+	FrontendConcurrentRequestsWaitTimeout:    "frontend.concurrentRequestsWaitTimeout",
+	FrontendBlobSizeLimitWarn:                "frontend.blobSizeLimitWarn",
+	FrontendBlobSizeLimitError:               "frontend.blobSizeLimitError",
+	FrontendMaxDecisionChunks:                "frontend.maxDecisionChunks",
+	FrontendDecisionChunkTimeout:             "frontend.decisionChunkTimeout",
+	FrontendDecisionChunkBufferMaxBytes:      "frontend.decisionChunkBufferMaxBytes",
+	FrontendEnableHistoryReadRouting:         "frontend.enableHistoryReadRouting",
+	FrontendHistoryPageCacheSize:             "frontend.historyPageCacheSize",
+	FrontendHistoryPageCacheTTL:              "frontend.historyPageCacheTTL",
+	FrontendPageTokenSigningKey:              "frontend.pageTokenSigningKey",
+	FrontendPageTokenTTL:                     "frontend.pageTokenTTL",
+	FrontendAcceptUnsignedPageTokens:         "frontend.acceptUnsignedPageTokens",
+	FrontendGlobalDomainRPS:                  "frontend.globalDomainRPS",
+	FrontendFailoverDrillMaxReplicationLag:   "frontend.failoverDrillMaxReplicationLag",
+	FrontendScheduleMinInterval:              "frontend.scheduleMinInterval",
+	FrontendPayloadCompressionThreshold:      "frontend.payloadCompressionThreshold",
+	FrontendEnableDomainRegistrationApproval: "frontend.enableDomainRegistrationApproval",
 
 	// matching settings
 	MatchingPersistenceMaxQPS:               "matching.persistenceMaxQPS",
@@ -69,7 +94,17 @@ var keys = map[Key]string{
 	MaxTasklistIdleTime:                     "matching.maxTasklistIdleTime",
 	MatchingOutstandingTaskAppendsThreshold: "matching.outstandingTaskAppendsThreshold",
 	MatchingMaxTaskBatchSize:                "matching.maxTaskBatchSize",
+	MatchingMaxTaskBatchWait:                "matching.maxTaskBatchWait",
 	MatchingRPS:                             "matching.rps",
+	MatchingSyncMatchPersistReserve:         "matching.syncMatchPersistReserve",
+	MatchingEnableIsolationGroups:           "matching.enableIsolationGroups",
+	MatchingDrainedIsolationGroups:          "matching.drainedIsolationGroups",
+	MatchingMaxTaskDispatchAttempts:         "matching.maxTaskDispatchAttempts",
+	MatchingGlobalDomainDispatchRPS:         "matching.globalDomainDispatchRPS",
+	MatchingPriorityStarvationLimit:         "matching.priorityStarvationLimit",
+	MatchingMaxTaskListLatencyMetricTags:    "matching.maxTaskListLatencyMetricTags",
+	MatchingMovedTaskListTTL:                "matching.movedTaskListTTL",
+	MatchingLoadSheddingPollBackoff:         "matching.loadSheddingPollBackoff",
 
 	// history settings
 	HistoryPersistenceMaxQPS:                            "history.persistenceMaxQPS",
@@ -115,14 +150,81 @@ var keys = map[Key]string{
 	ReplicatorProcessorMaxPollInterval:                  "history.replicatorProcessorMaxPollInterval",
 	ReplicatorProcessorMaxPollIntervalJitterCoefficient: "history.replicatorProcessorMaxPollIntervalJitterCoefficient",
 	ReplicatorProcessorUpdateAckInterval:                "history.replicatorProcessorUpdateAckInterval",
+	EnableQueueProcessorAutoTuning:                      "history.enableQueueProcessorAutoTuning",
+	QueueProcessorAutoTuningInterval:                    "history.queueProcessorAutoTuningInterval",
+	QueueProcessorAutoTuningTargetLatency:               "history.queueProcessorAutoTuningTargetLatency",
+	QueueProcessorAutoTuningMaxErrorRate:                "history.queueProcessorAutoTuningMaxErrorRate",
+	QueueProcessorAutoTuningMaxPollRPS:                  "history.queueProcessorAutoTuningMaxPollRPS",
+	QueueProcessorAutoTuningMaxWorkerCount:              "history.queueProcessorAutoTuningMaxWorkerCount",
 	ExecutionMgrNumConns:                                "history.executionMgrNumConns",
 	HistoryMgrNumConns:                                  "history.historyMgrNumConns",
+	HistoryPersistenceHedgedReadDelay:                   "history.persistenceHedgedReadDelay",
+	HistoryPayloadDedupThreshold:                        "history.payloadDedupThreshold",
 	MaximumBufferedEventsBatch:                          "history.maximumBufferedEventsBatch",
 	ShardUpdateMinInterval:                              "history.shardUpdateMinInterval",
 	ShardSyncMinInterval:                                "history.shardSyncMinInterval",
+	EnableShardRebalance:                                "history.enableShardRebalance",
+	ShardRebalanceInterval:                              "history.shardRebalanceInterval",
+	ShardRebalanceMaxMoves:                              "history.shardRebalanceMaxMoves",
+	ShardRebalanceMaxHandoffs:                           "history.shardRebalanceMaxHandoffs",
+	ShardRebalanceLoadThreshold:                         "history.shardRebalanceLoadThreshold",
+	ShardRebalanceCooldown:                              "history.shardRebalanceCooldown",
+	ShardRebalanceCacheWeight:                           "history.shardRebalanceCacheWeight",
+	ShardLagEmitInterval:                                "history.shardLagEmitInterval",
+	ShardLagEmitTopK:                                    "history.shardLagEmitTopK",
+	DecisionTimeoutScaleEventsPerSecond:                 "history.decisionTimeoutScaleEventsPerSecond",
+	MaxScaledDecisionStartToCloseTimeout:                "history.maxScaledDecisionStartToCloseTimeout",
+	TransferProcessorEnableAsyncDecisionDispatch:        "history.transferProcessorEnableAsyncDecisionDispatch",
+	TransferProcessorAsyncDecisionBufferSize:            "history.transferProcessorAsyncDecisionBufferSize",
+	TransferProcessorAsyncDecisionDispatcherCount:       "history.transferProcessorAsyncDecisionDispatcherCount",
+	MaxGetMutableStatesBatchSize:                        "history.maxGetMutableStatesBatchSize",
+	HistoryMaxConcurrentRequests:                        "history.maxConcurrentRequests",
+	HistoryConcurrentRequestsWaitTimeout:                "history.concurrentRequestsWaitTimeout",
+	TimerTaskPaused:                                     "history.timerTaskPaused",
+	TransferTaskPaused:                                  "history.transferTaskPaused",
+	PausedTaskCheckInterval:                             "history.pausedTaskCheckInterval",
+	MaxActivityScheduleToStartTimeout:                   "history.maxActivityScheduleToStartTimeout",
+	ReplicatorTaskPurgeInterval:                         "history.replicatorTaskPurgeInterval",
+	StandbyTaskRedeliveryInterval:                       "history.standbyTaskRedeliveryInterval",
+	StandbyTaskRedeliveryMaxInterval:                    "history.standbyTaskRedeliveryMaxInterval",
+	ContinueAsNewSuggestedHistorySize:                   "history.continueAsNewSuggestedHistorySize",
+	ContinueAsNewSuggestedHistoryCount:                  "history.continueAsNewSuggestedHistoryCount",
+	ReplicatorTaskBatchSizeBytes:                        "history.replicatorTaskBatchSizeBytes",
+	ThrottledLogRPS:                                     "history.throttledLogRPS",
+	ThrottledLogSampleRate:                              "history.throttledLogSampleRate",
+	HistoryCloseEventSink:                               "history.closeEventSink",
+	TimerProcessorMaxClockSkew:                          "history.timerProcessorMaxClockSkew",
+	SignalAfterClosePolicy:                              "history.signalAfterClosePolicy",
+	ShardOperationLogSize:                               "history.shardOperationLogSize",
+	TimerProcessorMaxIdlePollInterval:                   "history.timerProcessorMaxIdlePollInterval",
+	MutableStateSnapshotInterval:                        "history.mutableStateSnapshotInterval",
+	MaximumUpdatesPerExecution:                          "history.maximumUpdatesPerExecution",
+	EnableActivityEagerExecution:                        "history.enableActivityEagerExecution",
+	MaxActivityEagerExecutionsPerDecision:               "history.maxActivityEagerExecutionsPerDecision",
+	EnableDecisionEagerExecution:                        "history.enableDecisionEagerExecution",
+	HistoryGlobalRatelimiterHostTTL:                     "history.globalRatelimiterHostTTL",
+	HistoryActivityHeartbeatPersistInterval:             "history.activityHeartbeatPersistInterval",
+	HistoryCacheWarmupMaxExecutions:                     "history.cacheWarmupMaxExecutions",
+	HistoryCacheWarmupTimerWindow:                       "history.cacheWarmupTimerWindow",
+	ReplicatorPublishRPS:                                "history.replicatorPublishRPS",
+	ReplicatorPublishMaxInFlight:                        "history.replicatorPublishMaxInFlight",
+	HistoryCacheIdleTTL:                                 "history.cacheIdleTTL",
+	HistoryCacheEvictionInterval:                        "history.cacheEvictionInterval",
+	StickyPoisonFailureThreshold:                        "history.stickyPoisonFailureThreshold",
+	StickyPoisonWindow:                                  "history.stickyPoisonWindow",
+	EnableAppendHistoryFencing:                          "history.enableAppendHistoryFencing",
 
 	// worker settings
-	WorkerPersistenceMaxQPS: "worker.persistenceMaxQPS",
+	WorkerPersistenceMaxQPS:                "worker.persistenceMaxQPS",
+	WorkerScheduleProcessorRefreshInterval: "worker.scheduleProcessorRefreshInterval",
+	WorkerScheduleMaxBufferedRuns:          "worker.scheduleMaxBufferedRuns",
+	WorkerVisibilityPrunerInterval:         "worker.visibilityPrunerInterval",
+	WorkerReplicatorDLQMetricsInterval:     "worker.replicatorDLQMetricsInterval",
+	WorkerHistoryMigrationTargetEncoding:   "worker.historyMigrationTargetEncoding",
+	WorkerHistoryMigrationInterval:         "worker.historyMigrationInterval",
+	WorkerHistoryMigrationPagesPerInterval: "worker.historyMigrationPagesPerInterval",
+	WorkerDomainRegistrationInterval:       "worker.domainRegistrationInterval",
+	WorkerDomainRegistrationEmailDomains:   "worker.domainRegistrationEmailDomains",
 }
 
 const (
@@ -139,9 +241,15 @@ const (
 	testGetIntPropertyFilteredByTaskListInfoKey
 	testGetDurationPropertyFilteredByTaskListInfoKey
 	testGetBoolPropertyFilteredByTaskListInfoKey
+	testGetStringPropertyKey
+	testGetStringPropertyFilteredByDomainKey
+	testGetMapPropertyKey
+	testGetMapPropertyFilteredByDomainKey
 
 	// EnableGlobalDomain is key for enable global domain
 	EnableGlobalDomain
+	// GlobalRatelimiterUpdateInterval is the interval at which the hosts enforcing global rate limits report their usage
+	GlobalRatelimiterUpdateInterval
 
 	// key for frontend
 
@@ -155,8 +263,49 @@ const (
 	FrontendRPS
 	// FrontendHistoryMgrNumConns is for persistence cluster.NumConns
 	FrontendHistoryMgrNumConns
+	// FrontendPersistenceHedgedReadDelay is how long a history read waits before it is sent again to another coordinator, zero disables it
+	FrontendPersistenceHedgedReadDelay
 	// MaxDecisionStartToCloseTimeout is max decision timeout in seconds
 	MaxDecisionStartToCloseTimeout
+	// FrontendExecutionTagQuotas maps the tag keys a domain may attach to new executions to the max length of each value
+	FrontendExecutionTagQuotas
+	// FrontendMaxConcurrentRequests maps API names to the max number of in flight requests of that API
+	FrontendMaxConcurrentRequests
+	// FrontendConcurrentRequestsWaitTimeout is how long a request waits for an in flight slot of its API
+	FrontendConcurrentRequestsWaitTimeout
+	// FrontendBlobSizeLimitWarn is the per domain payload size in bytes above which the frontend logs a warning
+	FrontendBlobSizeLimitWarn
+	// FrontendBlobSizeLimitError is the per domain payload size in bytes above which the frontend rejects the request
+	FrontendBlobSizeLimitError
+	// FrontendMaxDecisionChunks is the max number of chunks a decision task completion can be split in
+	FrontendMaxDecisionChunks
+	// FrontendDecisionChunkTimeout is how long the chunks of a decision task completion are buffered waiting for the last one
+	FrontendDecisionChunkTimeout
+	// FrontendDecisionChunkBufferMaxBytes is the max encoded size of the decision chunks buffered by a frontend host
+	FrontendDecisionChunkBufferMaxBytes
+	// FrontendEnableHistoryReadRouting routes the history reads of a workflow to the frontend host owning it on the frontend ring
+	FrontendEnableHistoryReadRouting
+	// FrontendHistoryPageCacheSize is the max number of history pages of closed runs cached by a frontend host
+	FrontendHistoryPageCacheSize
+	// FrontendHistoryPageCacheTTL is how long a history page of a closed run is cached by a frontend host
+	FrontendHistoryPageCacheTTL
+	// FrontendPageTokenSigningKey is the secret key used to sign the page tokens returned to clients
+	FrontendPageTokenSigningKey
+	// FrontendPageTokenTTL is the duration after which the page tokens returned to clients expire
+	FrontendPageTokenTTL
+	// FrontendAcceptUnsignedPageTokens accepts the unsigned page tokens handed out before signing was enabled
+	FrontendAcceptUnsignedPageTokens
+	// FrontendGlobalDomainRPS is the requests per second a domain may send to all the frontend hosts of the cluster, 0 disables the limit
+	FrontendGlobalDomainRPS
+	// FrontendFailoverDrillMaxReplicationLag is the standby task processing lag above which a failover drill reports the standby cluster as not ready
+	FrontendFailoverDrillMaxReplicationLag
+	// FrontendScheduleMinInterval is the shortest interval a schedule can be created with
+	FrontendScheduleMinInterval
+	// FrontendPayloadCompressionThreshold is the payload size in bytes of a history page or activity input from which
+	// it is compressed for the clients accepting compressed payloads, 0 disables the compression
+	FrontendPayloadCompressionThreshold
+	// FrontendEnableDomainRegistrationApproval registers new domains as pending until the worker domain registration processor approves them
+	FrontendEnableDomainRegistrationApproval
 
 	// key for matching
 
@@ -180,8 +329,28 @@ const (
 	MatchingOutstandingTaskAppendsThreshold
 	// MatchingMaxTaskBatchSize is max batch size for task writer
 	MatchingMaxTaskBatchSize
+	// MatchingMaxTaskBatchWait is the max time the task writer waits for more tasks to fill a batch, 0 writes the tasks already queued right away
+	MatchingMaxTaskBatchWait
 	// MatchingRPS is request rate per second for each matching host
 	MatchingRPS
+	// MatchingSyncMatchPersistReserve is the part of the add task deadline kept for persisting a task when sync match does not complete in time
+	MatchingSyncMatchPersistReserve
+	// MatchingEnableIsolationGroups enables dispatching tasks to pollers of their origin isolation group first
+	MatchingEnableIsolationGroups
+	// MatchingDrainedIsolationGroups maps isolation groups of a domain to true to drain them: their pollers receive no tasks
+	MatchingDrainedIsolationGroups
+	// MatchingMaxTaskDispatchAttempts is the max number of failed dispatches of a task before it is moved to the task list DLQ
+	MatchingMaxTaskDispatchAttempts
+	// MatchingGlobalDomainDispatchRPS is the tasks per second of a domain all the matching hosts of the cluster may dispatch, 0 disables the limit
+	MatchingGlobalDomainDispatchRPS
+	// MatchingPriorityStarvationLimit is the number of times the oldest buffered task of a task list can be passed over by tasks of higher priority, 0 dispatches in task ID order
+	MatchingPriorityStarvationLimit
+	// MatchingMaxTaskListLatencyMetricTags is the max number of task lists tagged on the schedule-to-start latency metric of a host
+	MatchingMaxTaskListLatencyMetricTags
+	// MatchingMovedTaskListTTL is how long a task list force unloaded from a matching host is served by another host, 0 lets the host load it again right away
+	MatchingMovedTaskListTTL
+	// MatchingLoadSheddingPollBackoff is the backoff suggested to the pollers of a matching host for this long after it rejected a request on its RPS limit, 0 disables it
+	MatchingLoadSheddingPollBackoff
 
 	// key for history
 
@@ -271,21 +440,166 @@ const (
 	ReplicatorProcessorMaxPollIntervalJitterCoefficient
 	// ReplicatorProcessorUpdateAckInterval is update interval for ReplicatorProcessor
 	ReplicatorProcessorUpdateAckInterval
+	// EnableQueueProcessorAutoTuning is whether the poll rate and the worker count of the transfer, timer and
+	// replicator queue processors are tuned per shard from the observed persistence latency and errors, the static
+	// max poll rps and worker count are then only the starting point
+	EnableQueueProcessorAutoTuning
+	// QueueProcessorAutoTuningInterval is how often the tuned poll rate and worker count are adjusted
+	QueueProcessorAutoTuningInterval
+	// QueueProcessorAutoTuningTargetLatency is the average latency of the persistence calls of the shard above which
+	// the tuned poll rate and worker count are cut
+	QueueProcessorAutoTuningTargetLatency
+	// QueueProcessorAutoTuningMaxErrorRate is the ratio of persistence calls of the shard failing with timeouts, busy
+	// or internal errors above which the tuned poll rate and worker count are cut
+	QueueProcessorAutoTuningMaxErrorRate
+	// QueueProcessorAutoTuningMaxPollRPS is the highest poll rate per second the tuning can reach
+	QueueProcessorAutoTuningMaxPollRPS
+	// QueueProcessorAutoTuningMaxWorkerCount is the highest worker count the tuning can reach
+	QueueProcessorAutoTuningMaxWorkerCount
 	// ExecutionMgrNumConns is persistence connections number for ExecutionManager
 	ExecutionMgrNumConns
 	// HistoryMgrNumConns is persistence connections number for HistoryManager
 	HistoryMgrNumConns
+	// HistoryPersistenceHedgedReadDelay is how long a history or timer task read waits before it is sent again to another coordinator, zero disables it
+	HistoryPersistenceHedgedReadDelay
+	// HistoryPayloadDedupThreshold is the size in bytes from which the payloads of the appended history events are
+	// stored once per run in the history payload store, 0 disables it
+	HistoryPayloadDedupThreshold
 	// MaximumBufferedEventsBatch is max number of buffer event in mutable state
 	MaximumBufferedEventsBatch
 	// ShardUpdateMinInterval is the minimal time interval which the shard info can be updated
 	ShardUpdateMinInterval
 	// ShardSyncMinInterval is the minimal time interval which the shard info should be sync to remote
 	ShardSyncMinInterval
+	// EnableShardRebalance is whether load based shard rebalancing is enabled
+	EnableShardRebalance
+	// ShardRebalanceInterval is the interval at which shard load is evaluated for rebalancing
+	ShardRebalanceInterval
+	// ShardRebalanceMaxMoves is the max number of shards a host hands off in one rebalance round
+	ShardRebalanceMaxMoves
+	// ShardRebalanceMaxHandoffs is the max number of shards a host keeps handed off at the same time
+	ShardRebalanceMaxHandoffs
+	// ShardRebalanceLoadThreshold is the ratio above the average load at which a host is considered hot
+	ShardRebalanceLoadThreshold
+	// ShardRebalanceCooldown is the minimal time before a handed off shard is considered for rebalancing again
+	ShardRebalanceCooldown
+	// ShardRebalanceCacheWeight is the weight of one cached workflow in the shard load, relative to one task per second
+	ShardRebalanceCacheWeight
+	// ShardLagEmitInterval is the interval at which the ack levels and lags of the task queues of the shards are emitted
+	ShardLagEmitInterval
+	// ShardLagEmitTopK is the number of shards with the highest lag, per task queue, emitted with a shard tag, 0 emits all shards
+	ShardLagEmitTopK
+	// DecisionTimeoutScaleEventsPerSecond is the number of history events a worker is expected to replay per second, used to extend the decision start to close timeout of workflows with large history, 0 disables the scaling
+	DecisionTimeoutScaleEventsPerSecond
+	// MaxScaledDecisionStartToCloseTimeout is the upper bound of the decision start to close timeout after scaling by history size
+	MaxScaledDecisionStartToCloseTimeout
+	// TransferProcessorEnableAsyncDecisionDispatch is whether the transfer processor hands AddDecisionTask calls to a buffered background dispatcher
+	TransferProcessorEnableAsyncDecisionDispatch
+	// TransferProcessorAsyncDecisionBufferSize is the max number of decision tasks buffered for async dispatch to matching
+	TransferProcessorAsyncDecisionBufferSize
+	// TransferProcessorAsyncDecisionDispatcherCount is the number of goroutines dispatching buffered decision tasks to matching
+	TransferProcessorAsyncDecisionDispatcherCount
+	// MaxGetMutableStatesBatchSize is the max number of executions in one GetMutableStates call
+	MaxGetMutableStatesBatchSize
+	// HistoryMaxConcurrentRequests maps API names to the max number of in flight requests of that API
+	HistoryMaxConcurrentRequests
+	// HistoryConcurrentRequestsWaitTimeout is how long a request waits for an in flight slot of its API
+	HistoryConcurrentRequestsWaitTimeout
+	// TimerTaskPaused pauses processing of timer tasks of the task type given as filter
+	TimerTaskPaused
+	// TransferTaskPaused pauses processing of transfer tasks of the task type given as filter
+	TransferTaskPaused
+	// PausedTaskCheckInterval is how often a paused task checks whether its task type was resumed
+	PausedTaskCheckInterval
+	// MaxActivityScheduleToStartTimeout is the cap applied to activity ScheduleToStart timeouts, filtered by domain and task list, 0 disables it
+	MaxActivityScheduleToStartTimeout
+	// ReplicatorTaskPurgeInterval is the interval at which replication tasks below the replicator ack level are purged
+	ReplicatorTaskPurgeInterval
+	// StandbyTaskRedeliveryInterval is the initial backoff before a standby task that cannot be verified yet is redelivered, and how often due tasks are checked
+	StandbyTaskRedeliveryInterval
+	// StandbyTaskRedeliveryMaxInterval is the maximum backoff before a standby task that cannot be verified yet is redelivered
+	StandbyTaskRedeliveryMaxInterval
+	// ContinueAsNewSuggestedHistorySize is the history size in bytes from which workers are suggested to continue the workflow as new, 0 disables it
+	ContinueAsNewSuggestedHistorySize
+	// ContinueAsNewSuggestedHistoryCount is the history event count from which workers are suggested to continue the workflow as new, 0 disables it
+	ContinueAsNewSuggestedHistoryCount
+	// ReplicatorTaskBatchSizeBytes caps the aggregate history bytes of a batch of replication tasks read at once, 0 means no cap
+	ReplicatorTaskBatchSizeBytes
+	// ThrottledLogRPS is the rate per message at which queue processors log debug and info messages, 0 means no limit
+	ThrottledLogRPS
+	// ThrottledLogSampleRate is the fraction of debug and info messages of queue processors that are logged
+	ThrottledLogSampleRate
+	// HistoryCloseEventSink describes the sink a domain publishes the summaries of its closed workflows to: type kafka
+	// with brokers and topic, or type webhook with url, and optionally a dlq sink of the same format for the summaries
+	// the sink keeps rejecting. Publishing is disabled when empty
+	HistoryCloseEventSink
+	// TimerProcessorMaxClockSkew is the wall clock jump above which the timer gate of the active timer processor is re-armed
+	TimerProcessorMaxClockSkew
+	// SignalAfterClosePolicy is the per domain handling of signals to a closed workflow: reject, drop, or start a new run carrying the signal
+	SignalAfterClosePolicy
+	// ShardOperationLogSize is the number of recent operations each shard keeps in memory for DescribeShardOperations
+	ShardOperationLogSize
+	// TimerProcessorMaxIdlePollInterval is the poll interval the timer processor backs off to while a shard has no timers
+	TimerProcessorMaxIdlePollInterval
+	// MutableStateSnapshotInterval is the number of mutable state updates between full writes of the replication state and buffered events
+	MutableStateSnapshotInterval
+	// MaximumUpdatesPerExecution is the max number of updates that can be requested on a workflow run
+	MaximumUpdatesPerExecution
+	// EnableActivityEagerExecution is whether activities can be dispatched to the worker completing the decision which scheduled them
+	EnableActivityEagerExecution
+	// MaxActivityEagerExecutionsPerDecision is the max number of activities dispatched to the worker completing a decision
+	MaxActivityEagerExecutionsPerDecision
+	// EnableDecisionEagerExecution EnableDecisionEagerExecution is whether the first decision task of a workflow can be returned to the caller starting it
+	EnableDecisionEagerExecution
+	// HistoryGlobalRatelimiterHostTTL is the duration after which a host which stopped reporting the usage of a global rate limit no longer gets a share of it
+	HistoryGlobalRatelimiterHostTTL
+	// HistoryActivityHeartbeatPersistInterval is the min interval between writes of the heartbeats of an activity to persistence, heartbeats in between are kept in the cached mutable state; 0 writes every heartbeat
+	HistoryActivityHeartbeatPersistInterval
+	// HistoryCacheWarmupMaxExecutions is the max number of executions loaded into the history cache after a shard is acquired, 0 disables the warm-up
+	HistoryCacheWarmupMaxExecutions
+	// HistoryCacheWarmupTimerWindow is how soon a timer has to fire for its execution to be loaded by the history cache warm-up
+	HistoryCacheWarmupTimerWindow
+	// ReplicatorPublishRPS is the rate of replication tasks a history host publishes for a remote cluster, filtered by cluster name, 0 disables the limit
+	ReplicatorPublishRPS
+	// ReplicatorPublishMaxInFlight is the max number of replication tasks a history host publishes concurrently for a remote cluster, filtered by cluster name, 0 disables the limit
+	ReplicatorPublishMaxInFlight
+	// HistoryCacheIdleTTL is how long a workflow execution context stays in the history cache after it was last used
+	HistoryCacheIdleTTL
+	// HistoryCacheEvictionInterval is how often expired workflow execution contexts are evicted from the history cache
+	HistoryCacheEvictionInterval
+	// StickyPoisonFailureThreshold is the number of sticky decisions a binary may fail with non-determinism errors
+	// within StickyPoisonWindow before it is marked suspect in the domain metadata, zero disables the detection.
+	// It is split evenly between the history hosts, each of them counts the failures of the shards it owns.
+	StickyPoisonFailureThreshold
+	// StickyPoisonWindow is the window the non-determinism failures of a binary are counted in
+	StickyPoisonWindow
+	// EnableAppendHistoryFencing verifies the shard is still owned after each history append, and deletes the
+	// batch again if it was appended after the shard was lost
+	EnableAppendHistoryFencing
 
 	// key for histoworkerry
 
 	// WorkerPersistenceMaxQPS is the max qps worker host can querty DB
 	WorkerPersistenceMaxQPS
+	// WorkerScheduleProcessorRefreshInterval is how often the schedule processor looks for due schedules
+	WorkerScheduleProcessorRefreshInterval
+	// WorkerScheduleMaxBufferedRuns is the max number of runs a schedule with the buffer overlap policy holds behind its open run
+	WorkerScheduleMaxBufferedRuns
+	// WorkerVisibilityPrunerInterval is how often the visibility pruner drops the closed execution partitions past the retention of their domain
+	WorkerVisibilityPrunerInterval
+	// WorkerReplicatorDLQMetricsInterval is how often the depth and oldest message age of the replication DLQs are reported
+	WorkerReplicatorDLQMetricsInterval
+	// WorkerHistoryMigrationTargetEncoding is the encoding the persisted history of a domain is migrated to, empty disables the migration
+	WorkerHistoryMigrationTargetEncoding
+	// WorkerHistoryMigrationInterval is how often the history migrator reencodes the next pages of history of the domains it migrates
+	WorkerHistoryMigrationInterval
+	// WorkerHistoryMigrationPagesPerInterval is the max number of pages of history batches reencoded for a domain in each interval
+	WorkerHistoryMigrationPagesPerInterval
+	// WorkerDomainRegistrationInterval is how often the domain registration processor reviews the domains pending approval
+	WorkerDomainRegistrationInterval
+	// WorkerDomainRegistrationEmailDomains is the comma separated email domains the default registration policy approves the
+	// owner email of a pending domain from, empty approves every registration
+	WorkerDomainRegistrationEmailDomains
 
 	// lastKeyForTest must be the last one in this const group for testing purpose
 	lastKeyForTest
@@ -295,7 +609,7 @@ const (
 type Filter int
 
 func (f Filter) String() string {
-	if f <= unknownFilter || f > TaskListName {
+	if f <= unknownFilter || f >= lastFilterTypeForTest {
 		return filters[unknownFilter]
 	}
 	return filters[f]
@@ -306,6 +620,7 @@ var filters = []string{
 	"domainName",
 	"taskListName",
 	"taskType",
+	"clusterName",
 }
 
 const (
@@ -314,8 +629,10 @@ const (
 	DomainName
 	// TaskListName is the tasklist name
 	TaskListName
-	// TaskType is the task type (0:Decision, 1:Activity)
+	// TaskType is the task type (0:Decision, 1:Activity for task lists, or the timer / transfer task type in history)
 	TaskType
+	// ClusterName is the name of a cluster
+	ClusterName
 
 	// lastFilterTypeForTest must be the last one in this const group for testing purpose
 	lastFilterTypeForTest
@@ -344,3 +661,10 @@ func TaskTypeFilter(taskType int) FilterOption {
 		filterMap[TaskType] = taskType
 	}
 }
+
+// ClusterNameFilter filters by cluster name
+func ClusterNameFilter(name string) FilterOption {
+	return func(filterMap map[Filter]interface{}) {
+		filterMap[ClusterName] = name
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/credentials"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/messaging"
@@ -54,6 +55,7 @@ type (
 	BootstrapParams struct {
 		Name             string
 		Logger           bark.Logger
+		LogLevel         string
 		MetricScope      tally.Scope
 		RingpopFactory   RingpopFactory
 		RPCFactory       common.RPCFactory
@@ -63,6 +65,9 @@ type (
 		ReplicatorConfig config.Replicator
 		MessagingClient  messaging.Client
 		DynamicConfig    dynamicconfig.Client
+
+		// CassandraCredentials provides the user and the password to authenticate against cassandra with
+		CassandraCredentials credentials.Provider
 	}
 
 	// RingpopFactory provides a bootstrapped ringpop
@@ -0,0 +1,148 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package serviceerror
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+type (
+	// wrappedError adds context to the error it wraps while keeping it reachable through Unwrap, Cause, Is and As
+	wrappedError struct {
+		msg   string
+		cause error
+	}
+
+	// thriftError is implemented by the exceptions of the thrift IDL, the only errors which can be returned over RPC
+	thriftError interface {
+		error
+		ToWire() (wire.Value, error)
+	}
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Wrap returns an error annotating err with msg, nil if err is nil
+func Wrap(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return &wrappedError{msg: msg, cause: err}
+}
+
+// Wrapf returns an error annotating err with the formatted message, nil if err is nil
+func Wrapf(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return &wrappedError{msg: fmt.Sprintf(format, args...), cause: err}
+}
+
+func (e *wrappedError) Error() string {
+	return e.msg + ": " + e.cause.Error()
+}
+
+func (e *wrappedError) Unwrap() error {
+	return e.cause
+}
+
+// Unwrap returns the error wrapped by err, nil if err does not wrap another error
+func Unwrap(err error) error {
+	u, ok := err.(interface {
+		Unwrap() error
+	})
+	if !ok {
+		return nil
+	}
+	return u.Unwrap()
+}
+
+// Cause returns the error the chain of err originates from, err itself if it does not wrap another error
+func Cause(err error) error {
+	for {
+		cause := Unwrap(err)
+		if cause == nil {
+			return err
+		}
+		err = cause
+	}
+}
+
+// Is reports whether any error in the chain of err equals target, an error of the chain can define what it equals
+// with an Is(error) bool method
+func Is(err, target error) bool {
+	if target == nil {
+		return err == target
+	}
+
+	comparable := reflect.TypeOf(target).Comparable()
+	for err != nil {
+		if comparable && err == target {
+			return true
+		}
+		if x, ok := err.(interface {
+			Is(error) bool
+		}); ok && x.Is(target) {
+			return true
+		}
+		err = Unwrap(err)
+	}
+	return false
+}
+
+// As finds the first error in the chain of err assignable to the value target points to, sets target to it and
+// returns true, e.g. var notExists *shared.EntityNotExistsError; As(err, &notExists). It panics if target is not a
+// non-nil pointer to an error type or to an interface.
+func As(err error, target interface{}) bool {
+	if target == nil {
+		panic("serviceerror: target cannot be nil")
+	}
+	val := reflect.ValueOf(target)
+	typ := val.Type()
+	if typ.Kind() != reflect.Ptr || val.IsNil() {
+		panic("serviceerror: target must be a non-nil pointer")
+	}
+	targetType := typ.Elem()
+	if targetType.Kind() != reflect.Interface && !targetType.Implements(errorType) {
+		panic("serviceerror: target must point to an interface or to a type implementing error")
+	}
+
+	for err != nil {
+		if reflect.TypeOf(err).AssignableTo(targetType) {
+			val.Elem().Set(reflect.ValueOf(err))
+			return true
+		}
+		err = Unwrap(err)
+	}
+	return false
+}
+
+// ToThrift returns the error a handler should return for err: the thrift exception the chain of err originates from,
+// err itself otherwise. Wrapped errors are not part of the IDL and would reach the caller as unknown errors.
+func ToThrift(err error) error {
+	if cause, ok := Cause(err).(thriftError); ok {
+		return cause
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package serviceerror
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+type (
+	serviceErrorSuite struct {
+		suite.Suite
+	}
+)
+
+func TestServiceErrorSuite(t *testing.T) {
+	s := new(serviceErrorSuite)
+	suite.Run(t, s)
+}
+
+func (s *serviceErrorSuite) TestWrap() {
+	s.Nil(Wrap(nil, "message"))
+	s.Nil(Wrapf(nil, "message %v", 1))
+
+	cause := &workflow.EntityNotExistsError{Message: "workflow not found"}
+	err := Wrapf(Wrap(cause, "load mutable state"), "apply events of %v", "wid")
+	s.Equal("apply events of wid: load mutable state: EntityNotExistsError{Message: workflow not found}", err.Error())
+	s.Equal(cause, Cause(err))
+	s.Equal(cause, Unwrap(Unwrap(err)))
+	s.Nil(Unwrap(cause))
+	s.Equal(cause, Cause(cause))
+	s.Nil(Cause(nil))
+}
+
+func (s *serviceErrorSuite) TestIs() {
+	sentinel := errors.New("sentinel")
+	s.True(Is(sentinel, sentinel))
+	s.True(Is(Wrap(Wrap(sentinel, "inner"), "outer"), sentinel))
+	s.False(Is(Wrap(errors.New("sentinel"), "outer"), sentinel))
+	s.False(Is(nil, sentinel))
+	s.True(Is(nil, nil))
+}
+
+func (s *serviceErrorSuite) TestAs() {
+	cause := &workflow.ServiceBusyError{Message: "busy"}
+	err := Wrap(cause, "outer")
+
+	var busy *workflow.ServiceBusyError
+	s.True(As(err, &busy))
+	s.Equal(cause, busy)
+
+	var notExists *workflow.EntityNotExistsError
+	s.False(As(err, &notExists))
+	s.Nil(notExists)
+
+	var thrift thriftError
+	s.True(As(err, &thrift))
+	s.Equal(cause, thrift)
+
+	s.Panics(func() { As(err, nil) })
+	s.Panics(func() { As(err, busy) })
+	s.Panics(func() { As(err, new(string)) })
+}
+
+func (s *serviceErrorSuite) TestToThrift() {
+	cause := &workflow.BadRequestError{Message: "bad request"}
+	s.Equal(cause, ToThrift(cause))
+	s.Equal(cause, ToThrift(Wrap(Wrap(cause, "inner"), "outer")))
+
+	err := Wrap(errors.New("not a thrift error"), "outer")
+	s.Equal(err, ToThrift(err))
+	s.Nil(ToThrift(nil))
+}
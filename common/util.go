@@ -22,6 +22,7 @@ package common
 
 import (
 	"encoding/json"
+	"sort"
 	"sync"
 	"time"
 
@@ -37,6 +38,7 @@ import (
 	m "github.com/uber/cadence/.gen/go/matching"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/serviceerror"
 )
 
 const (
@@ -136,7 +138,7 @@ func CreateMatchingRetryPolicy() backoff.RetryPolicy {
 
 // IsPersistenceTransientError checks if the error is a transient persistence error
 func IsPersistenceTransientError(err error) bool {
-	switch err.(type) {
+	switch serviceerror.Cause(err).(type) {
 	case *workflow.InternalServiceError, *workflow.ServiceBusyError:
 		return true
 	}
@@ -151,7 +153,7 @@ func IsServiceTransientError(err error) bool {
 
 // IsServiceNonRetryableError checks if the error is a non retryable error.
 func IsServiceNonRetryableError(err error) bool {
-	switch err.(type) {
+	switch err := serviceerror.Cause(err).(type) {
 	case *workflow.EntityNotExistsError:
 		return true
 	case *workflow.BadRequestError:
@@ -159,8 +161,7 @@ func IsServiceNonRetryableError(err error) bool {
 	case *workflow.CancellationAlreadyRequestedError:
 		return true
 	case *yarpcerrors.Status:
-		rpcErr := err.(*yarpcerrors.Status)
-		if rpcErr.Code() != yarpcerrors.CodeDeadlineExceeded {
+		if err.Code() != yarpcerrors.CodeDeadlineExceeded {
 			return true
 		}
 		return false
@@ -171,11 +172,11 @@ func IsServiceNonRetryableError(err error) bool {
 
 // IsWhitelistServiceTransientError checks if the error is a transient error.
 func IsWhitelistServiceTransientError(err error) bool {
-	if err == context.DeadlineExceeded {
+	if serviceerror.Is(err, context.DeadlineExceeded) {
 		return true
 	}
 
-	switch err.(type) {
+	switch err := serviceerror.Cause(err).(type) {
 	case *workflow.InternalServiceError:
 		return true
 	case *workflow.ServiceBusyError:
@@ -247,14 +248,16 @@ func GenerateRandomString(n int) string {
 // CreateMatchingPollForDecisionTaskResponse create response for matching's PollForDecisionTask
 func CreateMatchingPollForDecisionTaskResponse(historyResponse *h.RecordDecisionTaskStartedResponse, workflowExecution *workflow.WorkflowExecution, token []byte) *m.PollForDecisionTaskResponse {
 	matchingResp := &m.PollForDecisionTaskResponse{
-		WorkflowExecution:      workflowExecution,
-		TaskToken:              token,
-		Attempt:                Int64Ptr(historyResponse.GetAttempt()),
-		WorkflowType:           historyResponse.WorkflowType,
-		StartedEventId:         historyResponse.StartedEventId,
-		StickyExecutionEnabled: historyResponse.StickyExecutionEnabled,
-		NextEventId:            historyResponse.NextEventId,
-		DecisionInfo:           historyResponse.DecisionInfo,
+		WorkflowExecution:       workflowExecution,
+		TaskToken:               token,
+		Attempt:                 Int64Ptr(historyResponse.GetAttempt()),
+		WorkflowType:            historyResponse.WorkflowType,
+		StartedEventId:          historyResponse.StartedEventId,
+		StickyExecutionEnabled:  historyResponse.StickyExecutionEnabled,
+		NextEventId:             historyResponse.NextEventId,
+		DecisionInfo:            historyResponse.DecisionInfo,
+		StickyInvalidationCount: historyResponse.StickyInvalidationCount,
+		ContinueAsNewSuggested:  historyResponse.ContinueAsNewSuggested,
 	}
 	if historyResponse.GetPreviousStartedEventId() != EmptyEventID {
 		matchingResp.PreviousStartedEventId = historyResponse.PreviousStartedEventId
@@ -262,6 +265,91 @@ func CreateMatchingPollForDecisionTaskResponse(historyResponse *h.RecordDecision
 	return matchingResp
 }
 
+// CreatePollForActivityTaskResponse create response for PollForActivityTask from the started activity
+func CreatePollForActivityTaskResponse(historyResponse *h.RecordActivityTaskStartedResponse,
+	workflowExecution *workflow.WorkflowExecution, token []byte) *workflow.PollForActivityTaskResponse {
+	scheduledEvent := historyResponse.ScheduledEvent
+	if scheduledEvent.ActivityTaskScheduledEventAttributes == nil {
+		panic("GetActivityTaskScheduledEventAttributes is not set")
+	}
+	attributes := scheduledEvent.ActivityTaskScheduledEventAttributes
+	if attributes.ActivityId == nil {
+		panic("ActivityTaskScheduledEventAttributes.ActivityID is not set")
+	}
+
+	response := &workflow.PollForActivityTaskResponse{}
+	response.TaskToken = token
+	response.ActivityId = attributes.ActivityId
+	response.ActivityType = attributes.ActivityType
+	response.Input = attributes.Input
+	response.WorkflowExecution = workflowExecution
+	response.ScheduledTimestampOfThisAttempt = historyResponse.ScheduledTimestampOfThisAttempt
+	response.ScheduledTimestamp = Int64Ptr(*scheduledEvent.Timestamp)
+	response.ScheduleToCloseTimeoutSeconds = Int32Ptr(*attributes.ScheduleToCloseTimeoutSeconds)
+	response.StartedTimestamp = historyResponse.StartedTimestamp
+	response.StartToCloseTimeoutSeconds = Int32Ptr(*attributes.StartToCloseTimeoutSeconds)
+	response.HeartbeatTimeoutSeconds = Int32Ptr(*attributes.HeartbeatTimeoutSeconds)
+	response.Attempt = Int32Ptr(int32(historyResponse.GetAttempt()))
+
+	// the activity times out at the earlier of the two deadlines, the schedule to close timeout runs from the
+	// schedule of the current attempt, falling back to the scheduled event for the history hosts not returning it
+	scheduledTimestamp := scheduledEvent.GetTimestamp()
+	if historyResponse.ScheduledTimestampOfThisAttempt != nil {
+		scheduledTimestamp = historyResponse.GetScheduledTimestampOfThisAttempt()
+	}
+	response.ScheduleToCloseDeadline = Int64Ptr(activityDeadline(scheduledTimestamp,
+		attributes.GetScheduleToCloseTimeoutSeconds()))
+	if historyResponse.StartedTimestamp != nil {
+		response.StartToCloseDeadline = Int64Ptr(activityDeadline(historyResponse.GetStartedTimestamp(),
+			attributes.GetStartToCloseTimeoutSeconds()))
+	}
+	return response
+}
+
+// activityDeadline returns the unix nanos at which a timeout of timeoutSeconds started at timestamp expires
+func activityDeadline(timestamp int64, timeoutSeconds int32) int64 {
+	return time.Unix(0, timestamp).Add(time.Duration(timeoutSeconds) * time.Second).UnixNano()
+}
+
+// EncodingTypeFromThrift converts the encoding type of the API to the one persisted with the domain,
+// an unset encoding converts to empty
+func EncodingTypeFromThrift(encoding *workflow.EncodingType) EncodingType {
+	if encoding == nil {
+		return ""
+	}
+	switch *encoding {
+	case workflow.EncodingTypeThriftRW:
+		return EncodingTypeThriftRW
+	default:
+		return EncodingTypeJSON
+	}
+}
+
+// EncodingTypeToThrift converts the encoding type persisted with the domain to the one of the API,
+// an empty encoding converts to nil
+func EncodingTypeToThrift(encoding EncodingType) *workflow.EncodingType {
+	switch encoding {
+	case EncodingTypeThriftRW:
+		return EncodingTypePtr(workflow.EncodingTypeThriftRW)
+	case EncodingTypeJSON:
+		return EncodingTypePtr(workflow.EncodingTypeJSON)
+	default:
+		return nil
+	}
+}
+
+// TopShardBacklogs orders the shard backlogs by decreasing max backlog age and keeps the first maximumShards of them,
+// all are kept if maximumShards is not positive
+func TopShardBacklogs(shards []*workflow.ShardBacklogInfo, maximumShards int) []*workflow.ShardBacklogInfo {
+	sort.SliceStable(shards, func(i, j int) bool {
+		return shards[i].GetMaxBacklogAgeInSeconds() > shards[j].GetMaxBacklogAgeInSeconds()
+	})
+	if maximumShards > 0 && len(shards) > maximumShards {
+		shards = shards[:maximumShards]
+	}
+	return shards
+}
+
 // MinInt32 return smaller one of two inputs int32
 func MinInt32(a, b int32) int32 {
 	if a < b {
@@ -33,6 +33,7 @@ import (
 	"github.com/uber-common/bark"
 	"github.com/uber-go/tally"
 	"github.com/uber/cadence/.gen/go/cadence/workflowserviceclient"
+	"github.com/uber/cadence/client"
 	fecli "github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cluster"
@@ -59,7 +60,6 @@ const maxRpJoinTimeout = 30 * time.Second
 
 var (
 	integration = flag.Bool("integration", true, "run integration tests")
-	topicName   = []string{"active", "standby"}
 )
 
 const (
@@ -91,6 +91,8 @@ type (
 		historyMgr            persistence.HistoryManager
 		taskMgr               persistence.TaskManager
 		visibilityMgr         persistence.VisibilityManager
+		scheduleMgr           persistence.ScheduleManager
+		postCloseEventsMgr    persistence.PostCloseEventsManager
 		executionMgrFactory   persistence.ExecutionManagerFactory
 		shutdownCh            chan struct{}
 		shutdownWG            sync.WaitGroup
@@ -109,7 +111,8 @@ type (
 func NewCadence(clusterMetadata cluster.Metadata, messagingClient messaging.Client, metadataMgr persistence.MetadataManager,
 	shardMgr persistence.ShardManager, historyMgr persistence.HistoryManager,
 	executionMgrFactory persistence.ExecutionManagerFactory, taskMgr persistence.TaskManager,
-	visibilityMgr persistence.VisibilityManager, numberOfHistoryShards, numberOfHistoryHosts int,
+	visibilityMgr persistence.VisibilityManager, scheduleMgr persistence.ScheduleManager,
+	postCloseEventsMgr persistence.PostCloseEventsManager, numberOfHistoryShards, numberOfHistoryHosts int,
 	logger bark.Logger, clusterNo int, enableWorker bool) Cadence {
 
 	return &cadenceImpl{
@@ -120,6 +123,8 @@ func NewCadence(clusterMetadata cluster.Metadata, messagingClient messaging.Clie
 		messagingClient:       messagingClient,
 		metadataMgr:           metadataMgr,
 		visibilityMgr:         visibilityMgr,
+		scheduleMgr:           scheduleMgr,
+		postCloseEventsMgr:    postCloseEventsMgr,
 		shardMgr:              shardMgr,
 		historyMgr:            historyMgr,
 		taskMgr:               taskMgr,
@@ -278,7 +283,7 @@ func (c *cadenceImpl) startFrontend(rpHosts []string, startWG *sync.WaitGroup) {
 	var kafkaProducer messaging.Producer
 	var err error
 	if c.enableWorker() {
-		kafkaProducer, err = c.messagingClient.NewProducer(topicName[c.clusterNo])
+		kafkaProducer, err = c.messagingClient.NewProducer(c.clusterMetadata.GetCurrentClusterName())
 		if err != nil {
 			c.logger.WithField("error", err).Fatal("Failed to create kafka producer when start frontend")
 		}
@@ -289,7 +294,8 @@ func (c *cadenceImpl) startFrontend(rpHosts []string, startWG *sync.WaitGroup) {
 
 	c.frontEndService = service.New(params)
 	c.frontendHandler = frontend.NewWorkflowHandler(
-		c.frontEndService, frontend.NewConfig(dynamicconfig.NewNopCollection()), c.metadataMgr, c.historyMgr, c.visibilityMgr, kafkaProducer)
+		c.frontEndService, frontend.NewConfig(dynamicconfig.NewNopCollection()), c.metadataMgr, c.historyMgr, c.visibilityMgr, c.scheduleMgr,
+		kafkaProducer)
 	err = c.frontendHandler.Start()
 	if err != nil {
 		c.logger.WithField("error", err).Fatal("Failed to start frontend")
@@ -318,7 +324,7 @@ func (c *cadenceImpl) startHistory(rpHosts []string, startWG *sync.WaitGroup) {
 		historyConfig.HistoryMgrNumConns = dynamicconfig.GetIntPropertyFn(c.numberOfHistoryShards)
 		historyConfig.ExecutionMgrNumConns = dynamicconfig.GetIntPropertyFn(c.numberOfHistoryShards)
 		handler := history.NewHandler(service, historyConfig, c.shardMgr, c.metadataMgr,
-			c.visibilityMgr, c.historyMgr, c.executionMgrFactory)
+			c.visibilityMgr, c.historyMgr, c.postCloseEventsMgr, c.executionMgrFactory)
 		handler.Start()
 		c.historyHandlers = append(c.historyHandlers, handler)
 	}
@@ -367,7 +373,24 @@ func (c *cadenceImpl) startWorker(rpHosts []string, startWG *sync.WaitGroup) {
 	}
 	metadataManager := persistence.NewMetadataPersistenceMetricsClient(c.metadataMgr, service.GetMetricsClient(), c.logger)
 
-	c.replicator = worker.NewReplicator(c.clusterMetadata, metadataManager, historyClient,
+	resolver, err := service.GetMembershipMonitor().GetResolver(common.WorkerServiceName)
+	if err != nil {
+		c.logger.WithField("error", err).Fatal("Failed to get worker service resolver when start worker")
+	}
+
+	matchingClient, err := service.GetClientFactory().NewMatchingClient()
+	if err != nil {
+		c.logger.WithField("error", err).Fatal("Failed to create matching service client when start worker")
+	}
+	frontendResolver, err := service.GetMembershipMonitor().GetResolver(common.FrontendServiceName)
+	if err != nil {
+		c.logger.WithField("error", err).Fatal("Failed to get frontend service resolver when start worker")
+	}
+	domainRefresher := client.NewDomainCacheRefresher(service.GetClientFactory(), frontendResolver, historyClient,
+		matchingClient)
+
+	c.replicator = worker.NewReplicator(c.clusterMetadata, metadataManager, c.shardMgr, c.numberOfHistoryShards,
+		historyClient, domainRefresher, resolver, service.GetHostInfo().Identity(),
 		worker.NewConfig(dynamicconfig.NewNopCollection()), c.messagingClient, c.logger, service.GetMetricsClient())
 	if err := c.replicator.Start(); err != nil {
 		c.replicator.Stop()
@@ -0,0 +1,188 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package host
+
+import (
+	"time"
+
+	"github.com/uber-common/bark"
+	wsc "github.com/uber/cadence/.gen/go/cadence/workflowserviceclient"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// XDCTestHarness hosts two cadence clusters in one process with cross cluster replication wired through
+	// messaging.InMemoryTransport instead of Kafka.  Each cluster keeps its state in its own in-memory
+	// persistence, so neither Kafka nor Cassandra is needed.  Replication of either cluster can be paused and
+	// stepped, so conflict scenarios of the history replicator can be reproduced deterministically.
+	XDCTestHarness struct {
+		Transport *messaging.InMemoryTransport
+		Clusters  []*XDCTestCluster
+		logger    bark.Logger
+	}
+
+	// XDCTestCluster is a single cluster of XDCTestHarness
+	XDCTestCluster struct {
+		persistence.TestBase
+		Name   string
+		Host   Cadence
+		Engine wsc.Interface
+		logger bark.Logger
+	}
+
+	// XDCTestHarnessOptions are the options to create XDCTestHarness
+	XDCTestHarnessOptions struct {
+		// ClusterNames are the names of the two clusters, the first one is the master cluster
+		ClusterNames             []string
+		FailoverVersionIncrement int64
+	}
+)
+
+const (
+	xdcTestClusterCount                = 2
+	xdcDefaultFailoverVersionIncrement = 10
+)
+
+// NewXDCTestHarness creates the harness, clusters are not started until Start is called
+func NewXDCTestHarness(options XDCTestHarnessOptions, logger bark.Logger) *XDCTestHarness {
+	if len(options.ClusterNames) != xdcTestClusterCount {
+		options.ClusterNames = []string{"active", "standby"}
+	}
+	if options.FailoverVersionIncrement == 0 {
+		options.FailoverVersionIncrement = xdcDefaultFailoverVersionIncrement
+	}
+
+	h := &XDCTestHarness{
+		Transport: messaging.NewInMemoryTransport(logger),
+		logger:    logger,
+	}
+
+	initialFailoverVersions := make(map[string]int64)
+	clusterAddress := make(map[string]config.Address)
+	for i, name := range options.ClusterNames {
+		initialFailoverVersions[name] = int64(i)
+		// matches the frontend address picked by onebox for the cluster number
+		rpcAddress := cluster.TestCurrentClusterFrontendAddress
+		if i != 0 {
+			rpcAddress = cluster.TestAlternativeClusterFrontendAddress
+		}
+		clusterAddress[name] = config.Address{RPCName: common.FrontendServiceName, RPCAddress: rpcAddress}
+	}
+
+	for i, name := range options.ClusterNames {
+		h.Clusters = append(h.Clusters, &XDCTestCluster{
+			Name:   name,
+			logger: logger.WithField("Cluster", name),
+		})
+		h.Clusters[i].setup(i, options, initialFailoverVersions, clusterAddress)
+	}
+	return h
+}
+
+// Start starts cadence services of both clusters
+func (h *XDCTestHarness) Start() {
+	for i, c := range h.Clusters {
+		c.Host = NewCadence(c.ClusterMetadata, h.Transport.NewClient(), c.MetadataProxy, c.ShardMgr, c.HistoryMgr,
+			c.ExecutionMgrFactory, c.TaskMgr, c.VisibilityMgr, c.ScheduleMgr, c.PostCloseEventsMgr, testNumberOfHistoryShards,
+			testNumberOfHistoryHosts, c.logger, i, true)
+		c.Host.Start()
+		c.Engine = c.Host.GetFrontendClient()
+	}
+}
+
+// Stop stops cadence services of both clusters
+func (h *XDCTestHarness) Stop() {
+	for _, c := range h.Clusters {
+		if c.Host != nil {
+			c.Host.Stop()
+			c.Host = nil
+			c.Engine = nil
+		}
+		c.TearDownWorkflowStore()
+	}
+}
+
+// Active returns the master cluster of the harness
+func (h *XDCTestHarness) Active() *XDCTestCluster {
+	return h.Clusters[0]
+}
+
+// Standby returns the non master cluster of the harness
+func (h *XDCTestHarness) Standby() *XDCTestCluster {
+	return h.Clusters[1]
+}
+
+// PauseReplication holds back replication tasks published by the source cluster
+func (h *XDCTestHarness) PauseReplication(sourceCluster string) {
+	h.Transport.Pause(sourceCluster)
+}
+
+// ResumeReplication releases held replication tasks of the source cluster and resumes immediate delivery
+func (h *XDCTestHarness) ResumeReplication(sourceCluster string) {
+	h.Transport.Resume(sourceCluster)
+}
+
+// DeliverReplicationTasks releases up to count held replication tasks of the source cluster, and waits until
+// the target cluster has processed them
+func (h *XDCTestHarness) DeliverReplicationTasks(sourceCluster string, count int, timeout time.Duration) (int, error) {
+	delivered := h.Transport.Deliver(sourceCluster, count)
+	return delivered, h.Transport.Drain(sourceCluster, timeout)
+}
+
+// DeliverDomainReplicationTasks releases the held domain replication tasks of the source cluster ahead of its held
+// history replication tasks, and waits until the target cluster has processed them
+func (h *XDCTestHarness) DeliverDomainReplicationTasks(sourceCluster string, timeout time.Duration) (int, error) {
+	delivered := h.Transport.DeliverTaskType(sourceCluster, replicator.ReplicationTaskTypeDomain,
+		h.Transport.Pending(sourceCluster))
+	return delivered, h.Transport.Drain(sourceCluster, timeout)
+}
+
+// WaitForReplication waits until all delivered replication tasks of the source cluster are processed
+func (h *XDCTestHarness) WaitForReplication(sourceCluster string, timeout time.Duration) error {
+	return h.Transport.Drain(sourceCluster, timeout)
+}
+
+func (c *XDCTestCluster) setup(no int, harnessOptions XDCTestHarnessOptions, initialFailoverVersions map[string]int64,
+	clusterAddress map[string]config.Address) {
+	metadata := cluster.NewMetadata(
+		dynamicconfig.GetBoolPropertyFn(true),
+		harnessOptions.FailoverVersionIncrement,
+		harnessOptions.ClusterNames[0],
+		c.Name,
+		initialFailoverVersions,
+		clusterAddress,
+	)
+	c.SetupInMemoryWorkflowStore(metadata)
+
+	// shard 0 is always created, we create additional shards if needed
+	for shardID := 1; shardID < testNumberOfHistoryShards; shardID++ {
+		err := c.CreateShard(shardID, "", 0)
+		if err != nil {
+			c.logger.WithField("error", err).Fatal("Failed to create shard")
+		}
+	}
+}
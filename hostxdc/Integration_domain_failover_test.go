@@ -30,22 +30,22 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pborman/uuid"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
 
-	"github.com/uber-go/tally"
 	wsc "github.com/uber/cadence/.gen/go/cadence/workflowserviceclient"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
-	"github.com/uber/cadence/common/cluster"
-	"github.com/uber/cadence/common/messaging"
-	"github.com/uber/cadence/common/persistence"
-	"github.com/uber/cadence/common/service/config"
-	"github.com/uber/cadence/common/service/dynamicconfig"
 	"github.com/uber/cadence/host"
-	"go.uber.org/zap"
+)
+
+const (
+	replicationTimeout = 10 * time.Second
+	waitRetryCount     = 100
+	waitRetryInterval  = 100 * time.Millisecond
 )
 
 type (
@@ -54,45 +54,17 @@ type (
 		// not merely log an error
 		*require.Assertions
 		suite.Suite
-		cluster1 *testCluster
-		cluster2 *testCluster
+		harness  *host.XDCTestHarness
+		cluster1 *host.XDCTestCluster
+		cluster2 *host.XDCTestCluster
 		logger   bark.Logger
 	}
-
-	testCluster struct {
-		persistence.TestBase
-		host   host.Cadence
-		engine wsc.Interface
-		logger bark.Logger
-	}
-)
-
-const (
-	testNumberOfHistoryShards = 4
-	testNumberOfHistoryHosts  = 1
 )
 
 var (
-	integration  = flag.Bool("integration2", true, "run integration tests")
-	domainName   = "integration-cross-dc-test-domain"
-	clusterName  = []string{"active", "standby"}
-	topicName    = []string{"active", "standby"}
-	clustersInfo = []*config.ClustersInfo{
-		{
-			EnableGlobalDomain:             true,
-			FailoverVersionIncrement:       10,
-			MasterClusterName:              clusterName[0],
-			CurrentClusterName:             clusterName[0],
-			ClusterInitialFailoverVersions: map[string]int64{clusterName[0]: 0, clusterName[1]: 1},
-		},
-		{
-			EnableGlobalDomain:             true,
-			FailoverVersionIncrement:       10,
-			MasterClusterName:              clusterName[0],
-			CurrentClusterName:             clusterName[1],
-			ClusterInitialFailoverVersions: map[string]int64{clusterName[0]: 0, clusterName[1]: 1},
-		},
-	}
+	integration = flag.Bool("integration2", true, "run integration tests")
+	clusterName = []string{"active", "standby"}
+
 	clusterReplicationConfig = []*workflow.ClusterReplicationConfiguration{
 		{
 			ClusterName: common.StringPtr(clusterName[0]),
@@ -103,93 +75,6 @@ var (
 	}
 )
 
-func (s *integrationClustersTestSuite) newTestCluster(no int) *testCluster {
-	c := &testCluster{logger: s.logger.WithField("Cluster", clusterName[no])}
-	c.setupCluster(no)
-	return c
-}
-
-func (s *testCluster) setupCluster(no int) {
-	options := persistence.TestBaseOptions{}
-	options.ClusterHost = "127.0.0.1"
-	options.KeySpace = "integration_" + clusterName[no]
-	options.DropKeySpace = true
-	options.SchemaDir = ".."
-	clusterInfo := clustersInfo[no]
-	metadata := cluster.NewMetadata(
-		dynamicconfig.GetBoolPropertyFn(clusterInfo.EnableGlobalDomain),
-		clusterInfo.FailoverVersionIncrement,
-		clusterInfo.MasterClusterName,
-		clusterInfo.CurrentClusterName,
-		clusterInfo.ClusterInitialFailoverVersions,
-	)
-	s.SetupWorkflowStoreWithOptions(options, metadata)
-	s.setupShards()
-	messagingClient := s.createMessagingClient()
-	s.host = host.NewCadence(s.ClusterMetadata, messagingClient, s.MetadataProxy, s.ShardMgr, s.HistoryMgr, s.ExecutionMgrFactory, s.TaskMgr,
-		s.VisibilityMgr, testNumberOfHistoryShards, testNumberOfHistoryHosts, s.logger, no, true)
-	s.host.Start()
-}
-
-func (s *testCluster) tearDownCluster() {
-	s.host.Stop()
-	s.host = nil
-	s.TearDownWorkflowStore()
-}
-
-func (s *testCluster) createMessagingClient() messaging.Client {
-	clusters := make(map[string]messaging.ClusterConfig)
-	clusters["test"] = messaging.ClusterConfig{
-		Brokers: []string{"127.0.0.1:9092"},
-	}
-	topics := make(map[string]messaging.TopicConfig)
-	topics[topicName[0]] = messaging.TopicConfig{
-		Cluster: "test",
-	}
-	topics[topicName[1]] = messaging.TopicConfig{
-		Cluster: "test",
-	}
-	topics[topicName[0]+"-dlq"] = messaging.TopicConfig{
-		Cluster: "test",
-	}
-	topics[topicName[1]+"-dlq"] = messaging.TopicConfig{
-		Cluster: "test",
-	}
-	topics[topicName[0]+"-retry"] = messaging.TopicConfig{
-		Cluster: "test",
-	}
-	topics[topicName[1]+"-retry"] = messaging.TopicConfig{
-		Cluster: "test",
-	}
-	clusterToTopic := make(map[string]messaging.TopicList)
-	clusterToTopic[clusterName[0]] = getTopicList(topicName[0])
-	clusterToTopic[clusterName[1]] = getTopicList(topicName[1])
-	kafkaConfig := messaging.KafkaConfig{
-		Clusters:       clusters,
-		Topics:         topics,
-		ClusterToTopic: clusterToTopic,
-	}
-	return kafkaConfig.NewKafkaClient(zap.NewNop(), s.logger, tally.NoopScope)
-}
-
-func getTopicList(topicName string) messaging.TopicList {
-	return messaging.TopicList{
-		Topic:      topicName,
-		RetryTopic: topicName + "-retry",
-		DLQTopic:   topicName + "-dlq",
-	}
-}
-
-func (s *testCluster) setupShards() {
-	// shard 0 is always created, we create additional shards if needed
-	for shardID := 1; shardID < testNumberOfHistoryShards; shardID++ {
-		err := s.CreateShard(shardID, "", 0)
-		if err != nil {
-			s.logger.WithField("error", err).Fatal("Failed to create shard")
-		}
-	}
-}
-
 func TestIntegrationClustersTestSuite(t *testing.T) {
 	flag.Parse()
 	if *integration {
@@ -216,18 +101,21 @@ func (s *integrationClustersTestSuite) SetupSuite() {
 func (s *integrationClustersTestSuite) SetupTest() {
 	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
 	s.Assertions = require.New(s.T())
-	s.cluster1 = s.newTestCluster(0)
-	s.cluster2 = s.newTestCluster(1)
+	s.harness = host.NewXDCTestHarness(host.XDCTestHarnessOptions{
+		ClusterNames: clusterName,
+	}, s.logger)
+	s.harness.Start()
+	s.cluster1 = s.harness.Active()
+	s.cluster2 = s.harness.Standby()
 }
 
 func (s *integrationClustersTestSuite) TearDownTest() {
-	s.cluster1.tearDownCluster()
-	s.cluster2.tearDownCluster()
+	s.harness.Stop()
 }
 
 func (s *integrationClustersTestSuite) TestDomainFailover() {
 	domainName := "test-domain-for-fail-over-" + common.GenerateRandomString(5)
-	client1 := s.cluster1.host.GetFrontendClient() // active
+	client1 := s.cluster1.Engine // active
 	regReq := &workflow.RegisterDomainRequest{
 		Name:              common.StringPtr(domainName),
 		Clusters:          clusterReplicationConfig,
@@ -244,7 +132,7 @@ func (s *integrationClustersTestSuite) TestDomainFailover() {
 	s.NotNil(resp)
 
 	//// uncommented when domain cache background update is ready
-	//client2 := s.cluster2.host.GetFrontendClient() // standby
+	//client2 := s.cluster2.Engine // standby
 	//var resp2 *workflow.DescribeDomainResponse
 	//for i := 0; i < 20; i++ { // retry to wait domain been replicated to cluster2
 	//	if resp2, err = client2.DescribeDomain(createContext(), descReq); err != nil {
@@ -290,6 +178,141 @@ func (s *integrationClustersTestSuite) TestDomainFailover() {
 	//fmt.Println(resp3)
 }
 
+func (s *integrationClustersTestSuite) TestConflictResolution() {
+	domainName := "test-domain-for-conflict-" + common.GenerateRandomString(5)
+	client1 := s.cluster1.Engine // active
+	client2 := s.cluster2.Engine // standby
+	regReq := &workflow.RegisterDomainRequest{
+		Name:                                   common.StringPtr(domainName),
+		Clusters:                               clusterReplicationConfig,
+		ActiveClusterName:                      common.StringPtr(clusterName[0]),
+		WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(1),
+	}
+	s.NoError(client1.RegisterDomain(createContext(), regReq))
+	s.NoError(s.harness.WaitForReplication(clusterName[0], replicationTimeout))
+
+	id := "integration-conflict-resolution-test"
+	we, err := client1.StartWorkflowExecution(createContext(), &workflow.StartWorkflowExecutionRequest{
+		RequestId:                           common.StringPtr(uuid.New()),
+		Domain:                              common.StringPtr(domainName),
+		WorkflowId:                          common.StringPtr(id),
+		WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr("integration-conflict-resolution-test-type")},
+		TaskList:                            &workflow.TaskList{Name: common.StringPtr("integration-conflict-resolution-test-tasklist")},
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(100),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(10),
+		Identity:                            common.StringPtr("worker1"),
+	})
+	s.NoError(err)
+	execution := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(id),
+		RunId:      we.RunId,
+	}
+	// started and decision scheduled events are replicated to the standby cluster
+	s.waitForHistory(client2, domainName, execution, func(events []*workflow.HistoryEvent) bool {
+		return len(events) == 2
+	})
+
+	// the signal of the active cluster is held back, then the domain fails over to the standby cluster, and the
+	// domain update overtakes the signal
+	s.harness.PauseReplication(clusterName[0])
+	s.signal(client1, domainName, execution, "signal-from-active")
+	s.waitForPendingReplication(clusterName[0], 1)
+	updateResp, err := client1.UpdateDomain(createContext(), &workflow.UpdateDomainRequest{
+		Name: common.StringPtr(domainName),
+		ReplicationConfiguration: &workflow.DomainReplicationConfiguration{
+			ActiveClusterName: common.StringPtr(clusterName[1]),
+		},
+	})
+	s.NoError(err)
+	failoverVersion := updateResp.GetFailoverVersion()
+	delivered, err := s.harness.DeliverDomainReplicationTasks(clusterName[0], replicationTimeout)
+	s.NoError(err)
+	s.Equal(1, delivered)
+
+	// the standby cluster writes its own event 3 with the new version, the active cluster has to reset its event 3
+	for i := 0; ; i++ {
+		err = client2.SignalWorkflowExecution(createContext(), &workflow.SignalWorkflowExecutionRequest{
+			Domain:            common.StringPtr(domainName),
+			WorkflowExecution: execution,
+			SignalName:        common.StringPtr("signal-from-standby"),
+			Identity:          common.StringPtr("worker2"),
+		})
+		if _, ok := err.(*workflow.DomainNotActiveError); !ok || i == waitRetryCount {
+			break
+		}
+		// the history hosts of the standby cluster refresh their domain cache asynchronously
+		time.Sleep(waitRetryInterval)
+	}
+	s.NoError(err)
+	s.waitForHistory(client1, domainName, execution, func(events []*workflow.HistoryEvent) bool {
+		return len(events) == 3 && events[2].GetVersion() == failoverVersion
+	})
+
+	// the held signal of the old version is stale by now and dropped by the standby cluster
+	s.harness.ResumeReplication(clusterName[0])
+	s.NoError(s.harness.WaitForReplication(clusterName[0], replicationTimeout))
+	s.Empty(s.harness.Transport.DLQ(clusterName[0]))
+	s.Empty(s.harness.Transport.DLQ(clusterName[1]))
+
+	events1 := s.getHistory(client1, domainName, execution)
+	events2 := s.getHistory(client2, domainName, execution)
+	s.Equal(events2, events1)
+	s.Equal(3, len(events1))
+	s.Equal(workflow.EventTypeWorkflowExecutionSignaled, events1[2].GetEventType())
+	s.Equal("signal-from-standby", events1[2].WorkflowExecutionSignaledEventAttributes.GetSignalName())
+	s.Equal(failoverVersion, events1[2].GetVersion())
+}
+
+func (s *integrationClustersTestSuite) signal(client wsc.Interface, domainName string,
+	execution *workflow.WorkflowExecution, signalName string) {
+	err := client.SignalWorkflowExecution(createContext(), &workflow.SignalWorkflowExecutionRequest{
+		Domain:            common.StringPtr(domainName),
+		WorkflowExecution: execution,
+		SignalName:        common.StringPtr(signalName),
+		Identity:          common.StringPtr("worker1"),
+	})
+	s.NoError(err)
+}
+
+func (s *integrationClustersTestSuite) getHistory(client wsc.Interface, domainName string,
+	execution *workflow.WorkflowExecution) []*workflow.HistoryEvent {
+	historyResponse, err := client.GetWorkflowExecutionHistory(createContext(), &workflow.GetWorkflowExecutionHistoryRequest{
+		Domain:          common.StringPtr(domainName),
+		Execution:       execution,
+		MaximumPageSize: common.Int32Ptr(100),
+	})
+	s.NoError(err)
+	return historyResponse.History.Events
+}
+
+// waitForHistory polls the history of the workflow until the condition holds, replication tasks are published by
+// the history queues in the background so there is nothing to wait on directly
+func (s *integrationClustersTestSuite) waitForHistory(client wsc.Interface, domainName string,
+	execution *workflow.WorkflowExecution, condition func([]*workflow.HistoryEvent) bool) {
+	for i := 0; i < waitRetryCount; i++ {
+		historyResponse, err := client.GetWorkflowExecutionHistory(createContext(), &workflow.GetWorkflowExecutionHistoryRequest{
+			Domain:          common.StringPtr(domainName),
+			Execution:       execution,
+			MaximumPageSize: common.Int32Ptr(100),
+		})
+		if err == nil && condition(historyResponse.History.Events) {
+			return
+		}
+		time.Sleep(waitRetryInterval)
+	}
+	s.FailNow("Timed out waiting for the workflow history")
+}
+
+func (s *integrationClustersTestSuite) waitForPendingReplication(sourceCluster string, count int) {
+	for i := 0; i < waitRetryCount; i++ {
+		if s.harness.Transport.Pending(sourceCluster) >= count {
+			return
+		}
+		time.Sleep(waitRetryInterval)
+	}
+	s.FailNow("Timed out waiting for the replication tasks to be published")
+}
+
 func createContext() context.Context {
 	ctx, _ := context.WithTimeout(context.Background(), 90*time.Second)
 	return ctx
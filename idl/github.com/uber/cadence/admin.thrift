@@ -46,6 +46,184 @@ service AdminService {
         2: shared.InternalServiceError  internalServiceError,
         3: shared.AccessDeniedError     accessDeniedError,
       )
+
+  /**
+  * DescribeShardBacklogs returns the shards with the oldest unacked timer, transfer and replication tasks
+  * across the history hosts, so stuck shards can be spotted.
+  **/
+  shared.DescribeShardBacklogsResponse DescribeShardBacklogs(1: shared.DescribeShardBacklogsRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * DescribeShardOperations returns the recent significant operations of a history shard, such as processed tasks,
+  * ack level moves, resolved conflicts and range renewals, most recent first.
+  **/
+  shared.DescribeShardOperationsResponse DescribeShardOperations(1: shared.DescribeShardOperationsRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * DeleteWorkflowExecution force deletes a workflow execution run: its visibility records, the current
+  * execution pointer when it points to the run, its history and finally its mutable state. A running
+  * execution is only deleted when force is set.
+  **/
+  void DeleteWorkflowExecution(1: DeleteWorkflowExecutionRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.ServiceBusyError        serviceBusyError,
+      5: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * GetWorkflowExecutionHistoryBatches returns the history of a workflow execution run page by page,
+  * keeping the events grouped in the batches they were persisted with.
+  **/
+  GetWorkflowExecutionHistoryBatchesResponse GetWorkflowExecutionHistoryBatches(1: GetWorkflowExecutionHistoryBatchesRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.ServiceBusyError        serviceBusyError,
+      5: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * ListPostCloseEvents returns the externally generated events, such as signals, which were replicated from a remote
+  * cluster for a workflow execution run after it was closed in the current cluster. They could not be applied to the
+  * run and were recorded instead of being dropped.
+  **/
+  ListPostCloseEventsResponse ListPostCloseEvents(1: ListPostCloseEventsRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.ServiceBusyError        serviceBusyError,
+      5: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * DiffWorkflowExecutionHistory compares the history of a workflow execution run in the current cluster
+  * with the one in a remote cluster, and returns the first event where the two diverge.
+  **/
+  DiffWorkflowExecutionHistoryResponse DiffWorkflowExecutionHistory(1: DiffWorkflowExecutionHistoryRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.ServiceBusyError        serviceBusyError,
+      5: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * ListTaskListDLQTasks returns the tasks of a tasklist which were moved to its DLQ after repeatedly failing
+  * to be dispatched.
+  **/
+  shared.ListTaskListDLQTasksResponse ListTaskListDLQTasks(1: shared.ListTaskListDLQTasksRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.ServiceBusyError        serviceBusyError,
+      5: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * RefreshDomainCache asks a frontend host to refresh its domain cache right away, it is called after a domain
+  * got updated so that the change is not picked up only by the periodic refresh
+  **/
+  void RefreshDomainCache(1: shared.RefreshDomainCacheRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+    )
+
+  /**
+  * RequeueTaskListDLQTasks writes the given tasks of a tasklist DLQ, or all of them when none are given, back to
+  * the tasklist so they are dispatched again.
+  **/
+  shared.RequeueTaskListDLQTasksResponse RequeueTaskListDLQTasks(1: shared.RequeueTaskListDLQTasksRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.ServiceBusyError        serviceBusyError,
+      5: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * DescribeFailoverDrill reports whether the current cluster, as the target of a failover drill of the domain,
+  * could take it over: how far behind the active cluster its standby task processing is, and whether workers
+  * poll the task lists of the domain in this cluster.
+  **/
+  DescribeFailoverDrillResponse DescribeFailoverDrill(1: DescribeFailoverDrillRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.ServiceBusyError        serviceBusyError,
+      5: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * DescribeTaskListLatency returns the percentiles of the schedule-to-start latency of the tasks recently
+  * dispatched from a tasklist, the time they waited for a worker to pick them up.
+  **/
+  shared.DescribeTaskListLatencyResponse DescribeTaskListLatency(1: shared.DescribeTaskListLatencyRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.ServiceBusyError        serviceBusyError,
+      5: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * ForceUnloadTaskList drops the manager of a tasklist from the matching host it is loaded on, so that it is
+  * placed again by membership on the next request. It is used to move hot tasklists off a degraded matching host.
+  **/
+  shared.ForceUnloadTaskListResponse ForceUnloadTaskList(1: shared.ForceUnloadTaskListRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.EntityNotExistsError    entityNotExistError,
+      4: shared.ServiceBusyError        serviceBusyError,
+      5: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * FixWorkflowExecutions applies targeted fixes to the workflow execution runs reported inconsistent by a scanner:
+  * missing tasks are regenerated, corrupted mutable states are rebuilt from history and orphan runs are deleted.
+  * Each run is fixed independently and reported in the results, nothing is changed on a dry run.
+  **/
+  FixWorkflowExecutionsResponse FixWorkflowExecutions(1: FixWorkflowExecutionsRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.ServiceBusyError        serviceBusyError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
+
+  /**
+  * DescribeReplicationDLQ summarizes the replication tasks from a remote cluster which the current cluster failed
+  * to apply and moved to the DLQ: how many there are and how old the oldest one is, for each history shard of a page
+  * of shards which has any.
+  **/
+  DescribeReplicationDLQResponse DescribeReplicationDLQ(1: DescribeReplicationDLQRequest request)
+    throws (
+      1: shared.BadRequestError         badRequestError,
+      2: shared.InternalServiceError    internalServiceError,
+      3: shared.ServiceBusyError        serviceBusyError,
+      4: shared.AccessDeniedError       accessDeniedError,
+    )
 }
 
 struct DescribeWorkflowExecutionRequest {
@@ -58,4 +236,139 @@ struct DescribeWorkflowExecutionResponse{
   20: optional string historyAddr
   40: optional string mutableStateInCache
   50: optional string mutableStateInDatabase
-}
\ No newline at end of file
+}
+
+struct DeleteWorkflowExecutionRequest {
+  10: optional string                       domain
+  20: optional shared.WorkflowExecution     execution
+  30: optional bool                         force
+}
+
+struct GetWorkflowExecutionHistoryBatchesRequest {
+  10: optional string                       domain
+  20: optional shared.WorkflowExecution     execution
+  30: optional i32                          maximumPageSize
+  40: optional binary                       nextPageToken
+}
+
+struct GetWorkflowExecutionHistoryBatchesResponse {
+  10: optional list<shared.History>         historyBatches
+  20: optional binary                       nextPageToken
+}
+
+struct ListPostCloseEventsRequest {
+  10: optional string                       domain
+  20: optional shared.WorkflowExecution     execution
+  30: optional i32                          maximumPageSize
+  40: optional binary                       nextPageToken
+}
+
+struct PostCloseEventBatch {
+  10: optional string                       sourceCluster
+  // time the events were recorded in the current cluster, in unix nanoseconds
+  20: optional i64                          recordedTimestamp
+  30: optional shared.History               history
+}
+
+struct ListPostCloseEventsResponse {
+  10: optional list<PostCloseEventBatch>    batches
+  20: optional binary                       nextPageToken
+}
+
+struct DiffWorkflowExecutionHistoryRequest {
+  10: optional string                       domain
+  20: optional shared.WorkflowExecution     execution
+  30: optional string                       remoteCluster
+  40: optional i32                          maximumPageSize
+}
+
+struct HistoryDivergence {
+  10: optional i32                          batchIndex
+  20: optional i64                          eventId
+  30: optional i64                          localEventId
+  40: optional i64                          remoteEventId
+  50: optional i64                          localVersion
+  60: optional i64                          remoteVersion
+  70: optional shared.EventType             localEventType
+  80: optional shared.EventType             remoteEventType
+  90: optional string                       reason
+}
+
+struct DiffWorkflowExecutionHistoryResponse {
+  10: optional bool                         identical
+  20: optional i64                          comparedEventCount
+  30: optional HistoryDivergence            divergence
+}
+
+struct DescribeFailoverDrillRequest {
+  10: optional string                       domain
+  // task lists which must have pollers in this cluster, the task lists of the domain known to this cluster
+  // are checked when not set
+  20: optional list<shared.TaskList>        taskLists
+}
+
+struct FailoverDrillTaskListStatus {
+  10: optional shared.TaskList              taskList
+  20: optional shared.TaskListType          taskListType
+  30: optional i32                          pollerCount
+}
+
+struct DescribeFailoverDrillResponse {
+  10: optional string                       domain
+  20: optional string                       activeClusterName
+  30: optional string                       drillClusterName
+  // age of the oldest unprocessed standby task replicated from the active cluster, across all shards
+  40: optional i64                          replicationLagInSeconds
+  50: optional list<FailoverDrillTaskListStatus> taskLists
+  // whether the drill found nothing preventing a failover to this cluster
+  60: optional bool                         ready
+  // what prevents a failover to this cluster, empty when ready
+  70: optional list<string>                 issues
+}
+
+struct WorkflowExecutionIssue {
+  10: optional string                       domain
+  20: optional shared.WorkflowExecution     execution
+  30: optional shared.WorkflowExecutionIssueType issueType
+}
+
+struct FixWorkflowExecutionsRequest {
+  10: optional list<WorkflowExecutionIssue> issues
+  20: optional bool                         dryRun
+}
+
+struct WorkflowExecutionFixResult {
+  10: optional WorkflowExecutionIssue       issue
+  // whether the fix was applied, never set on a dry run
+  20: optional bool                         fixed
+  // what was done to fix the run, or what would be done on a dry run
+  30: optional string                       action
+  // why the run could not be fixed
+  40: optional string                       error
+}
+
+struct FixWorkflowExecutionsResponse {
+  10: optional list<WorkflowExecutionFixResult> results
+}
+
+struct DescribeReplicationDLQRequest {
+  10: optional string                       sourceCluster
+  // first shard of the page of shards to describe
+  20: optional i32                          startShardId
+  30: optional i32                          maximumShardCount
+}
+
+struct ReplicationDLQSummary {
+  10: optional i32                          shardId
+  20: optional string                       sourceCluster
+  30: optional i64                          messageCount
+  // time the oldest message was published by the source cluster, in unix nanoseconds
+  40: optional i64                          oldestMessageTimestamp
+}
+
+struct DescribeReplicationDLQResponse {
+  // summaries of the shards of the page with messages in the DLQ
+  10: optional list<ReplicationDLQSummary>  summaries
+  // first shard of the next page, not set after the last shard
+  20: optional i32                          nextShardId
+}
@@ -114,6 +114,31 @@ service WorkflowService {
       4: shared.ServiceBusyError serviceBusyError,
     )
 
+  /**
+  * GetWorkflowExecutionResult long polls for the specified workflow execution to close, and returns only the result
+  * or failure recorded by its close event.  If the execution is still running when the long poll times out, the
+  * response will not have a close status and the caller is expected to call again.
+  **/
+  shared.GetWorkflowExecutionResultResponse GetWorkflowExecutionResult(1: shared.GetWorkflowExecutionResultRequest getRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * GetWorkflowExecutionGraph returns the activities, timers, child workflows and received signals of the specified
+  * workflow execution with their state and timestamps, so the execution can be rendered without reading its history.
+  **/
+  shared.GetWorkflowExecutionGraphResponse GetWorkflowExecutionGraph(1: shared.GetWorkflowExecutionGraphRequest getRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
   /**
   * PollForDecisionTask is called by application worker to process DecisionTask from a specific taskList.  A
   * DecisionTask is dispatched to callers for active workflow executions, with pending decisions.
@@ -136,6 +161,9 @@ service WorkflowService {
   * event in the history for that session.  Use the 'taskToken' provided as response of PollForDecisionTask API call
   * for completing the DecisionTask.
   * The response could contain a new decision task if there is one or if the request asking for one.
+  * Decisions too large for a single call can be split in 'chunkCount' calls sent in order of 'chunkIndex' to the
+  * same frontend host, all with the same 'taskToken'. The decisions of the chunks are concatenated and the other
+  * fields are taken from the last chunk, which completes the DecisionTask.
   **/
   shared.RespondDecisionTaskCompletedResponse RespondDecisionTaskCompleted(1: shared.RespondDecisionTaskCompletedRequest completeRequest)
     throws (
@@ -337,6 +365,36 @@ service WorkflowService {
       6: shared.LimitExceededError limitExceededError,
     )
 
+  /**
+  * UpdateWorkflowExecutionOptions changes options of a running workflow execution, such as the task list
+  * inherited by the next run when the workflow continues as new.
+  **/
+  void UpdateWorkflowExecutionOptions(1: shared.UpdateWorkflowExecutionOptionsRequest updateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+    )
+
+  /**
+  * UpdateWorkflowExecution requests an update of a running workflow execution and returns its result. It results in
+  * a WorkflowExecutionUpdateRequested event recorded in the history and a decision task being created for the
+  * execution. The call blocks until the worker completes the update with a CompleteWorkflowUpdate decision. When the
+  * call times out the update stays accepted, and calling again with the same updateId waits for its result.
+  **/
+  shared.UpdateWorkflowExecutionResponse UpdateWorkflowExecution(1: shared.UpdateWorkflowExecutionRequest updateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+    )
+
   /**
   * SignalWithStartWorkflowExecution is used to ensure sending signal to a workflow.
   * If the workflow is running, this results in WorkflowExecutionSignaled event being recorded in the history
@@ -391,6 +449,18 @@ service WorkflowService {
       4: shared.ServiceBusyError serviceBusyError,
     )
 
+  /**
+  * ListWorkflowExecutions is a visibility API to list both the open and the closed executions in a specific domain,
+  * with the filters of ListOpenWorkflowExecutions.  The open executions are listed first, then the closed ones.
+  **/
+  shared.ListWorkflowExecutionsResponse ListWorkflowExecutions(1: shared.ListWorkflowExecutionsRequest listRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
   /**
   * RespondQueryTaskCompleted is called by application worker to complete a QueryTask (which is a DecisionTask for query)
   * as a result of 'PollForDecisionTask' API call. Completing a QueryTask will unblock the client call to 'QueryWorkflow'
@@ -456,4 +526,86 @@ service WorkflowService {
       4: shared.LimitExceededError limitExceededError,
     )
 
+  /**
+  * GetTaskListsByDomain returns all the decision and activity tasklists of a domain which were used recently,
+  * together with their recent pollers. It helps to discover orphaned or misspelled tasklists.
+  **/
+  shared.GetTaskListsByDomainResponse GetTaskListsByDomain(1: shared.GetTaskListsByDomainRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.LimitExceededError limitExceededError,
+      5: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * RecordWorkerHeartbeat is called by workers to report their capabilities and current load on a tasklist. Workers
+  * which keep heartbeating but stop polling or stay fully loaded can be told apart by DescribeTaskList.
+  **/
+  void RecordWorkerHeartbeat(1: shared.RecordWorkerHeartbeatRequest heartbeatRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.LimitExceededError limitExceededError,
+      5: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * CreateSchedule creates a schedule which starts a run of a workflow each time its spec is due. The overlap policy
+  * decides what happens when a run is due while the previous run of the schedule is still open.
+  **/
+  void CreateSchedule(1: shared.CreateScheduleRequest createRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * DescribeSchedule returns the spec, action and overlap policy of a schedule together with its runs state.
+  **/
+  shared.DescribeScheduleResponse DescribeSchedule(1: shared.DescribeScheduleRequest describeRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * UpdateSchedule changes the fields set on the request of an existing schedule, it also pauses and unpauses it.
+  **/
+  void UpdateSchedule(1: shared.UpdateScheduleRequest updateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * DeleteSchedule deletes a schedule, the runs it already started are left open.
+  **/
+  void DeleteSchedule(1: shared.DeleteScheduleRequest deleteRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * ListSchedules returns the schedules of a domain.
+  **/
+  shared.ListSchedulesResponse ListSchedules(1: shared.ListSchedulesRequest listRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
 }
@@ -54,6 +54,24 @@ struct DescribeMutableStateResponse{
   40: optional string mutableStateInDatabase
 }
 
+struct FixWorkflowExecutionRequest {
+  10: optional string domainUUID
+  20: optional shared.WorkflowExecution execution
+  30: optional shared.WorkflowExecutionIssueType issueType
+  40: optional bool dryRun
+}
+
+struct FixWorkflowExecutionResponse {
+  // what was done to fix the run, or what would be done on a dry run
+  10: optional string action
+}
+
+struct DeleteWorkflowExecutionRequest {
+  10: optional string domainUUID
+  20: optional shared.WorkflowExecution execution
+  30: optional bool force
+}
+
 struct GetMutableStateRequest {
   10: optional string domainUUID
   20: optional shared.WorkflowExecution execution
@@ -74,14 +92,24 @@ struct GetMutableStateResponse {
   110: optional i32 stickyTaskListScheduleToStartTimeout
 }
 
+struct GetMutableStatesRequest {
+  10: optional string domainUUID
+  20: optional list<shared.WorkflowExecution> executions
+}
+
+struct GetMutableStatesResponse {
+  10: optional list<GetMutableStateResponse> states
+  // executions in the request which are unknown to the service
+  20: optional list<shared.WorkflowExecution> notFound
+}
+
 struct ResetStickyTaskListRequest {
   10: optional string domainUUID
   20: optional shared.WorkflowExecution execution
 }
 
 struct ResetStickyTaskListResponse {
-  // The reason to keep this response is to allow returning
-  // information in the future.
+  10: optional i64 (js.type = "Long") stickyInvalidationCount
 }
 
 struct RespondDecisionTaskCompletedRequest {
@@ -91,6 +119,7 @@ struct RespondDecisionTaskCompletedRequest {
 
 struct RespondDecisionTaskCompletedResponse {
   10: optional RecordDecisionTaskStartedResponse startedResponse
+  20: optional list<RecordActivityTaskStartedResponse> activitiesToDispatchLocally
 }
 
 struct RespondDecisionTaskFailedRequest {
@@ -152,6 +181,8 @@ struct RecordDecisionTaskStartedResponse {
   60: optional i64 (js.type = "Long") attempt
   70: optional bool stickyExecutionEnabled
   80: optional shared.TransientDecisionInfo decisionInfo
+  90: optional i64 (js.type = "Long") stickyInvalidationCount
+  100: optional bool continueAsNewSuggested
 }
 
 struct SignalWorkflowExecutionRequest {
@@ -161,6 +192,16 @@ struct SignalWorkflowExecutionRequest {
   40: optional bool childWorkflowOnly
 }
 
+struct UpdateWorkflowExecutionOptionsRequest {
+  10: optional string domainUUID
+  20: optional shared.UpdateWorkflowExecutionOptionsRequest updateRequest
+}
+
+struct UpdateWorkflowExecutionRequest {
+  10: optional string domainUUID
+  20: optional shared.UpdateWorkflowExecutionRequest updateRequest
+}
+
 struct SignalWithStartWorkflowExecutionRequest {
   10: optional string domainUUID
   20: optional shared.SignalWithStartWorkflowExecutionRequest signalWithStartRequest
@@ -226,6 +267,7 @@ struct ReplicateEventsRequest {
   80: optional shared.History history
   90: optional shared.History newRunHistory
   100: optional bool forceBufferEvents
+  110: optional i64 (js.type = "Long") sourceTimestamp
 }
 
 struct SyncShardStatusRequest {
@@ -234,6 +276,43 @@ struct SyncShardStatusRequest {
   30: optional i64 (js.type = "Long") timestamp
 }
 
+struct SyncActivityRequest {
+  10: optional string domainId
+  20: optional string workflowId
+  30: optional string runId
+  40: optional i64 (js.type = "Long") version
+  50: optional i64 (js.type = "Long") scheduledId
+  60: optional i64 (js.type = "Long") scheduledTime
+  70: optional i64 (js.type = "Long") startedId
+  80: optional i64 (js.type = "Long") startedTime
+  90: optional i32 attempt
+}
+
+struct RatelimitUsage {
+  10: optional string key
+  // requests admitted and rejected by the limiter of the key since the last update
+  20: optional i64 (js.type = "Long") allowed
+  30: optional i64 (js.type = "Long") rejected
+}
+
+struct RatelimitUpdateRequest {
+  // host reporting the usage, the quotas are shared out among the hosts reporting the same key
+  10: optional string host
+  // time elapsed since the last update of the host
+  20: optional i64 (js.type = "Long") elapsedMillis
+  30: optional list<RatelimitUsage> usages
+}
+
+struct RatelimitQuota {
+  10: optional string key
+  // share of the global limit of the key assigned to the host, between 0 and 1
+  20: optional double weight
+}
+
+struct RatelimitUpdateResponse {
+  10: optional list<RatelimitQuota> quotas
+}
+
 /**
 * HistoryService provides API to start a new long running workflow instance, as well as query and update the history
 * of workflow instances already created.
@@ -266,6 +345,20 @@ service HistoryService {
       3: shared.EntityNotExistsError entityNotExistError,
       4: ShardOwnershipLostError shardOwnershipLostError,
       5: shared.LimitExceededError limitExceededError,
+      6: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * Returns the information from mutable state for a batch of workflow executions. Executions unknown
+  * to the service are listed in 'notFound' instead of failing the whole call.
+  **/
+  GetMutableStatesResponse GetMutableStates(1: GetMutableStatesRequest getRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: ShardOwnershipLostError shardOwnershipLostError,
+      4: shared.LimitExceededError limitExceededError,
+      5: shared.ServiceBusyError serviceBusyError,
     )
 
   /**
@@ -433,6 +526,36 @@ service HistoryService {
       7: shared.LimitExceededError limitExceededError,
     )
 
+  /**
+  * UpdateWorkflowExecutionOptions changes options of a running workflow execution which are kept in its
+  * mutable state and honored when the execution continues as new. No history event is recorded.
+  **/
+  void UpdateWorkflowExecutionOptions(1: UpdateWorkflowExecutionOptionsRequest updateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.ServiceBusyError serviceBusyError,
+      7: shared.LimitExceededError limitExceededError,
+    )
+
+  /**
+  * UpdateWorkflowExecution records a WorkflowExecutionUpdateRequested event for a running workflow execution and
+  * schedules a decision task, then waits until the update is completed by the worker and returns its result.
+  **/
+  shared.UpdateWorkflowExecutionResponse UpdateWorkflowExecution(1: UpdateWorkflowExecutionRequest updateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.ServiceBusyError serviceBusyError,
+      7: shared.LimitExceededError limitExceededError,
+    )
+
   /**
   * SignalWithStartWorkflowExecution is used to ensure sending a signal event to a workflow execution.
   * If workflow is running, this results in WorkflowExecutionSignaled event recorded in the history
@@ -534,6 +657,7 @@ service HistoryService {
       3: shared.EntityNotExistsError entityNotExistError,
       4: ShardOwnershipLostError shardOwnershipLostError,
       5: shared.LimitExceededError limitExceededError,
+      6: shared.ServiceBusyError serviceBusyError,
     )
 
   void ReplicateEvents(1: ReplicateEventsRequest replicateRequest)
@@ -557,6 +681,19 @@ service HistoryService {
       5: shared.LimitExceededError limitExceededError,
     )
 
+  /**
+  * SyncActivity sync the retry state of an activity, which is kept in mutable state only, from the active cluster
+  **/
+  void SyncActivity(1: SyncActivityRequest syncActivityRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+    )
+
   /**
   * DescribeMutableState returns information about the internal states of workflow mutable state.
   **/
@@ -570,6 +707,32 @@ service HistoryService {
       6: shared.LimitExceededError limitExceededError,
     )
 
+  /**
+  * DeleteWorkflowExecution force deletes a workflow execution run, a running execution is only deleted when
+  * force is set.
+  **/
+  void DeleteWorkflowExecution(1: DeleteWorkflowExecutionRequest deleteRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * FixWorkflowExecution repairs a workflow execution run found inconsistent by a scanner, the fix applied depends
+  * on the type of the issue. Nothing is changed on a dry run.
+  **/
+  FixWorkflowExecutionResponse FixWorkflowExecution(1: FixWorkflowExecutionRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.ServiceBusyError serviceBusyError,
+    )
+
   /**
   * DescribeHistoryHost returns information about the internal states of a history host
   **/
@@ -579,4 +742,47 @@ service HistoryService {
       2: shared.InternalServiceError internalServiceError,
       3: shared.AccessDeniedError accessDeniedError,
     )
+
+  /**
+  * DescribeShardBacklogs returns the shards of a history host with the oldest unacked queue tasks
+  **/
+  shared.DescribeShardBacklogsResponse DescribeShardBacklogs(1: shared.DescribeShardBacklogsRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.AccessDeniedError accessDeniedError,
+    )
+
+  /**
+  * DescribeShardOperations returns the recent significant operations recorded by a shard owned by this host
+  **/
+  shared.DescribeShardOperationsResponse DescribeShardOperations(1: shared.DescribeShardOperationsRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.AccessDeniedError accessDeniedError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+    )
+
+  /**
+  * RefreshDomainCache asks a history host to refresh its domain cache right away, it is called after a domain
+  * got updated so that the change is not picked up only by the periodic refresh
+  **/
+  void RefreshDomainCache(1: shared.RefreshDomainCacheRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+    )
+
+  /**
+  * RatelimitUpdate is called by the hosts enforcing a global rate limit to report the usage of their limiters.  The
+  * history host owning the shard of a key aggregates the usage reported by all hosts, and returns the share of the
+  * global limit each host should admit, in proportion to its recent usage.
+  **/
+  RatelimitUpdateResponse RatelimitUpdate(1: RatelimitUpdateRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: ShardOwnershipLostError shardOwnershipLostError,
+    )
 }
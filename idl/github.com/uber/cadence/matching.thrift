@@ -40,6 +40,9 @@ struct PollForDecisionTaskResponse {
   70: optional bool stickyExecutionEnabled
   80: optional shared.WorkflowQuery query
   90: optional shared.TransientDecisionInfo decisionInfo
+  100: optional i64 (js.type = "Long") stickyInvalidationCount
+  110: optional bool continueAsNewSuggested
+  120: optional i64 (js.type = "Long") pollBackoffMillis
 }
 
 struct PollForActivityTaskRequest {
@@ -54,6 +57,9 @@ struct AddDecisionTaskRequest {
   30: optional shared.TaskList taskList
   40: optional i64 (js.type = "Long") scheduleId
   50: optional i32 scheduleToStartTimeoutSeconds
+  60: optional string isolationGroup
+  70: optional i32 priority
+  80: optional i64 (js.type = "Long") scheduledTimestamp
 }
 
 struct AddActivityTaskRequest {
@@ -63,6 +69,9 @@ struct AddActivityTaskRequest {
   40: optional shared.TaskList taskList
   50: optional i64 (js.type = "Long") scheduleId
   60: optional i32 scheduleToStartTimeoutSeconds
+  70: optional string isolationGroup
+  80: optional i32 priority
+  90: optional i64 (js.type = "Long") scheduledTimestamp
 }
 
 struct QueryWorkflowRequest {
@@ -90,6 +99,36 @@ struct DescribeTaskListRequest {
   20: optional shared.DescribeTaskListRequest descRequest
 }
 
+struct GetTaskListsByDomainRequest {
+  10: optional string domainUUID
+  20: optional shared.GetTaskListsByDomainRequest listRequest
+}
+
+struct RecordWorkerHeartbeatRequest {
+  10: optional string domainUUID
+  20: optional shared.RecordWorkerHeartbeatRequest heartbeatRequest
+}
+
+struct ListTaskListDLQTasksRequest {
+  10: optional string domainUUID
+  20: optional shared.ListTaskListDLQTasksRequest listRequest
+}
+
+struct RequeueTaskListDLQTasksRequest {
+  10: optional string domainUUID
+  20: optional shared.RequeueTaskListDLQTasksRequest requeueRequest
+}
+
+struct DescribeTaskListLatencyRequest {
+  10: optional string domainUUID
+  20: optional shared.DescribeTaskListLatencyRequest describeRequest
+}
+
+struct ForceUnloadTaskListRequest {
+  10: optional string domainUUID
+  20: optional shared.ForceUnloadTaskListRequest unloadRequest
+}
+
 /**
 * MatchingService API is exposed to provide support for polling from long running applications.
 * Such applications are expected to have a worker which regularly polls for DecisionTask and ActivityTask.  For each
@@ -198,4 +237,79 @@ service MatchingService {
         3: shared.EntityNotExistsError entityNotExistError,
         4: shared.ServiceBusyError serviceBusyError,
       )
+
+  /**
+  * GetTaskListsByDomain returns the decision and activity tasklists of a domain, together with their recent
+  * pollers, by scanning the persisted tasklist metadata.
+  **/
+  shared.GetTaskListsByDomainResponse GetTaskListsByDomain(1: GetTaskListsByDomainRequest request)
+    throws (
+        1: shared.BadRequestError badRequestError,
+        2: shared.InternalServiceError internalServiceError,
+        3: shared.ServiceBusyError serviceBusyError,
+      )
+
+  /**
+  * RecordWorkerHeartbeat is called by frontend to record the liveness and load of a worker on a tasklist, so that
+  * it can be surfaced by DescribeTaskList.
+  **/
+  void RecordWorkerHeartbeat(1: RecordWorkerHeartbeatRequest request)
+    throws (
+        1: shared.BadRequestError badRequestError,
+        2: shared.InternalServiceError internalServiceError,
+        3: shared.ServiceBusyError serviceBusyError,
+      )
+
+  /**
+  * ListTaskListDLQTasks returns the tasks of a tasklist which were moved to its DLQ after repeatedly failing
+  * to be dispatched.
+  **/
+  shared.ListTaskListDLQTasksResponse ListTaskListDLQTasks(1: ListTaskListDLQTasksRequest request)
+    throws (
+        1: shared.BadRequestError badRequestError,
+        2: shared.InternalServiceError internalServiceError,
+        3: shared.ServiceBusyError serviceBusyError,
+      )
+
+  /**
+  * RefreshDomainCache asks a matching host to refresh its domain cache right away, it is called after a domain
+  * got updated so that the change is not picked up only by the periodic refresh
+  **/
+  void RefreshDomainCache(1: shared.RefreshDomainCacheRequest request)
+    throws (
+        1: shared.BadRequestError badRequestError,
+        2: shared.InternalServiceError internalServiceError,
+      )
+
+  /**
+  * RequeueTaskListDLQTasks writes tasks of the tasklist DLQ back to the tasklist so they are dispatched again.
+  **/
+  shared.RequeueTaskListDLQTasksResponse RequeueTaskListDLQTasks(1: RequeueTaskListDLQTasksRequest request)
+    throws (
+        1: shared.BadRequestError badRequestError,
+        2: shared.InternalServiceError internalServiceError,
+        3: shared.ServiceBusyError serviceBusyError,
+      )
+
+  /**
+  * DescribeTaskListLatency returns the percentiles of the schedule-to-start latency of the tasks recently
+  * dispatched from a tasklist.
+  **/
+  shared.DescribeTaskListLatencyResponse DescribeTaskListLatency(1: DescribeTaskListLatencyRequest request)
+    throws (
+        1: shared.BadRequestError badRequestError,
+        2: shared.InternalServiceError internalServiceError,
+        3: shared.ServiceBusyError serviceBusyError,
+      )
+
+  /**
+  * ForceUnloadTaskList stops the manager of a tasklist loaded on this host, the tasklist is loaded again by
+  * whichever host owns it on the ring when the next request for it arrives.
+  **/
+  shared.ForceUnloadTaskListResponse ForceUnloadTaskList(1: ForceUnloadTaskListRequest request)
+    throws (
+        1: shared.BadRequestError badRequestError,
+        2: shared.InternalServiceError internalServiceError,
+        3: shared.ServiceBusyError serviceBusyError,
+      )
 }
@@ -36,6 +36,8 @@ exception WorkflowExecutionAlreadyStartedError {
   10: optional string message
   20: optional string startRequestId
   30: optional string runId
+  // set when the existing run has already closed, e.g. when rejected by the workflow ID reuse policy
+  40: optional WorkflowExecutionCloseStatus closeStatus
 }
 
 exception EntityNotExistsError {
@@ -44,6 +46,10 @@ exception EntityNotExistsError {
 
 exception ServiceBusyError {
   1: required string message
+  // how long the caller should wait before retrying, set when the service is shedding load
+  2: optional i64 (js.type = "Long") retryAfterMillis
+  // approximate number of requests already queued on the busy resource, when known
+  3: optional i64 (js.type = "Long") backlogCountHint
 }
 
 exception CancellationAlreadyRequestedError {
@@ -95,6 +101,8 @@ enum DomainStatus {
   REGISTERED,
   DEPRECATED,
   DELETED,
+  // registered while domain registration approval is enabled, the domain cannot be used until approved
+  PENDING_APPROVAL,
 }
 
 enum TimeoutType {
@@ -121,6 +129,7 @@ enum DecisionType {
   ContinueAsNewWorkflowExecution,
   StartChildWorkflowExecution,
   SignalExternalWorkflowExecution,
+  CompleteWorkflowUpdate,
 }
 
 enum EventType {
@@ -165,6 +174,9 @@ enum EventType {
   SignalExternalWorkflowExecutionInitiated,
   SignalExternalWorkflowExecutionFailed,
   ExternalWorkflowExecutionSignaled,
+  WorkflowExecutionUpdateRequested,
+  WorkflowExecutionUpdateCompleted,
+  WorkflowExecutionTaskListChanged,
 }
 
 enum DecisionTaskFailedCause {
@@ -185,6 +197,8 @@ enum DecisionTaskFailedCause {
   BAD_SIGNAL_WORKFLOW_EXECUTION_ATTRIBUTES,
   BAD_START_CHILD_EXECUTION_ATTRIBUTES,
   FORCE_CLOSE_DECISION,
+  BAD_COMPLETE_WORKFLOW_UPDATE_ATTRIBUTES,
+  NON_DETERMINISTIC_ERROR,
 }
 
 enum CancelExternalWorkflowExecutionFailedCause {
@@ -225,9 +239,28 @@ enum PendingActivityState {
   CANCEL_REQUESTED,
 }
 
+enum ExecutionGraphNodeType {
+  ACTIVITY,
+  TIMER,
+  CHILD_WORKFLOW,
+  SIGNAL,
+}
+
+enum ExecutionGraphNodeState {
+  SCHEDULED,
+  STARTED,
+  COMPLETED,
+  FAILED,
+  TIMED_OUT,
+  CANCELED,
+  TERMINATED,
+}
+
 enum HistoryEventFilterType {
   ALL_EVENT,
   CLOSE_EVENT,
+  // the milestone events of the history only, with their payloads omitted
+  SUMMARY_EVENT,
 }
 
 enum TaskListKind {
@@ -235,6 +268,30 @@ enum TaskListKind {
   STICKY,
 }
 
+enum EncodingType {
+  ThriftRW,
+  JSON,
+}
+
+enum ScheduleOverlapPolicy {
+  // skip a run while the previous run of the schedule is still open
+  SKIP,
+  // buffer the runs due while the previous run is open and start them one after another
+  BUFFER,
+  // request cancellation of the open run and start the new run right away
+  CANCEL_OTHER,
+}
+
+// inconsistency of a workflow execution run found by a scanner
+enum WorkflowExecutionIssueType {
+  // transfer or timer tasks which the mutable state calls for are missing, fixed by regenerating them
+  MISSING_TASKS,
+  // the mutable state does not match the history, fixed by rebuilding it from the history
+  CORRUPTED_MUTABLE_STATE,
+  // the run cannot make progress, for example because its history is gone, fixed by deleting it
+  ORPHAN_EXECUTION,
+}
+
 struct Header {
     10: optional map<string, binary> fields
 }
@@ -268,6 +325,10 @@ struct WorkflowExecutionInfo {
   40: optional i64 (js.type = "Long") closeTime
   50: optional WorkflowExecutionCloseStatus closeStatus
   60: optional i64 (js.type = "Long") historyLength
+  70: optional map<string,string> tags
+  80: optional i64 (js.type = "Long") executionTime
+  90: optional i64 (js.type = "Long") executionDuration
+  100: optional string firstRunId
 }
 
 struct WorkflowExecutionConfiguration {
@@ -293,6 +354,7 @@ struct ScheduleActivityTaskDecisionAttributes {
   55: optional i32 startToCloseTimeoutSeconds
   60: optional i32 heartbeatTimeoutSeconds
   70: optional RetryPolicy retryPolicy
+  80: optional bool requestEagerExecution
 }
 
 struct RequestCancelActivityTaskDecisionAttributes {
@@ -344,6 +406,11 @@ struct RecordMarkerDecisionAttributes {
   30: optional Header header
 }
 
+struct CompleteWorkflowUpdateDecisionAttributes {
+  10: optional string updateId
+  20: optional binary result
+}
+
 struct ContinueAsNewWorkflowExecutionDecisionAttributes {
   10: optional WorkflowType workflowType
   20: optional TaskList taskList
@@ -379,6 +446,7 @@ struct Decision {
   90:  optional ContinueAsNewWorkflowExecutionDecisionAttributes continueAsNewWorkflowExecutionDecisionAttributes
   100: optional StartChildWorkflowExecutionDecisionAttributes startChildWorkflowExecutionDecisionAttributes
   110: optional SignalExternalWorkflowExecutionDecisionAttributes signalExternalWorkflowExecutionDecisionAttributes
+  120: optional CompleteWorkflowUpdateDecisionAttributes completeWorkflowUpdateDecisionAttributes
 }
 
 struct WorkflowExecutionStartedEventAttributes {
@@ -392,7 +460,10 @@ struct WorkflowExecutionStartedEventAttributes {
   50: optional i32 taskStartToCloseTimeoutSeconds
   52: optional ChildPolicy childPolicy
   54: optional string continuedExecutionRunId
+  56: optional string firstExecutionRunId
   60: optional string identity
+  70: optional map<string,string> tags
+  80: optional i32 priority
 }
 
 struct WorkflowExecutionCompletedEventAttributes {
@@ -565,6 +636,24 @@ struct WorkflowExecutionSignaledEventAttributes {
   30: optional string identity
 }
 
+struct WorkflowExecutionUpdateRequestedEventAttributes {
+  10: optional string updateId
+  20: optional string updateName
+  30: optional binary input
+  40: optional string identity
+}
+
+struct WorkflowExecutionUpdateCompletedEventAttributes {
+  10: optional string updateId
+  20: optional binary result
+  30: optional i64 (js.type = "Long") decisionTaskCompletedEventId
+}
+
+struct WorkflowExecutionTaskListChangedEventAttributes {
+  10: optional TaskList taskList
+  20: optional string identity
+}
+
 struct WorkflowExecutionTerminatedEventAttributes {
   10: optional string reason
   20: optional binary details
@@ -742,6 +831,9 @@ struct HistoryEvent {
   420: optional SignalExternalWorkflowExecutionInitiatedEventAttributes signalExternalWorkflowExecutionInitiatedEventAttributes
   430: optional SignalExternalWorkflowExecutionFailedEventAttributes signalExternalWorkflowExecutionFailedEventAttributes
   440: optional ExternalWorkflowExecutionSignaledEventAttributes externalWorkflowExecutionSignaledEventAttributes
+  450: optional WorkflowExecutionUpdateRequestedEventAttributes workflowExecutionUpdateRequestedEventAttributes
+  460: optional WorkflowExecutionUpdateCompletedEventAttributes workflowExecutionUpdateCompletedEventAttributes
+  470: optional WorkflowExecutionTaskListChangedEventAttributes workflowExecutionTaskListChangedEventAttributes
 }
 
 struct History {
@@ -750,12 +842,19 @@ struct History {
 
 struct WorkflowExecutionFilter {
   10: optional string workflowId
+  // lists the runs of the continue-as-new chain started by this run
+  20: optional string firstRunId
 }
 
 struct WorkflowTypeFilter {
   10: optional string name
 }
 
+struct TagFilter {
+  10: optional string key
+  20: optional string value
+}
+
 struct StartTimeFilter {
   10: optional i64 (js.type = "Long") earliestTime
   20: optional i64 (js.type = "Long") latestTime
@@ -773,12 +872,17 @@ struct DomainInfo {
 struct DomainConfiguration {
   10: optional i32 workflowExecutionRetentionPeriodInDays
   20: optional bool emitMetric
+  30: optional EncodingType historyEncoding
+  // binary checksums of workers repeatedly failing decisions of the domain with non-determinism errors,
+  // workflows are not kept sticky on the workers of these binaries
+  40: optional list<string> suspectBinaryChecksums
 }
 
 struct UpdateDomainInfo {
   10: optional string description
   20: optional string ownerEmail
-  // A key-value map for any customized purpose
+  // A key-value map for any customized purpose, the keys are merged into the data of the domain
+  // and a key set to an empty value is removed
   30: optional map<string,string> data
 }
 
@@ -789,6 +893,8 @@ struct ClusterReplicationConfiguration {
 struct DomainReplicationConfiguration {
  10: optional string activeClusterName
  20: optional list<ClusterReplicationConfiguration> clusters
+ // standby cluster running a failover drill of the domain, an empty name ends the drill
+ 30: optional string failoverDrillClusterName
 }
 
 struct RegisterDomainRequest {
@@ -801,6 +907,7 @@ struct RegisterDomainRequest {
   70: optional string activeClusterName
   // A key-value map for any customized purpose
   80: optional map<string,string> data
+  90: optional EncodingType historyEncoding
 }
 
 struct ListDomainsRequest {
@@ -856,16 +963,26 @@ struct StartWorkflowExecutionRequest {
   90: optional string requestId
   100: optional WorkflowIdReusePolicy workflowIdReusePolicy
   110: optional ChildPolicy childPolicy
+  // Immutable labels attached at start, e.g. for cost attribution
+  120: optional map<string,string> tags
+  // requestEagerExecution asks for the first decision task to be returned in the response instead of going through matching
+  130: optional bool requestEagerExecution
+  // priority orders the decision and activity tasks of the workflow in task list backlogs, higher values are dispatched first
+  140: optional i32 priority
 }
 
 struct StartWorkflowExecutionResponse {
   10: optional string runId
+  // decisionTask is the first decision task of the run, set when it was dispatched eagerly to the caller
+  20: optional PollForDecisionTaskResponse decisionTask
 }
 
 struct PollForDecisionTaskRequest {
   10: optional string domain
   20: optional TaskList taskList
   30: optional string identity
+  // isolationGroup is the group (e.g. zone) of the poller, tasks originating from it are dispatched there first
+  40: optional string isolationGroup
 }
 
 struct PollForDecisionTaskResponse {
@@ -879,6 +996,11 @@ struct PollForDecisionTaskResponse {
   60: optional History history
   70: optional binary nextPageToken
   80: optional WorkflowQuery query
+  90: optional i64 (js.type = "Long") stickyInvalidationCount
+  // continueAsNewSuggested is set once the history grows past the soft limits configured for the domain
+  100: optional bool continueAsNewSuggested
+  // how long the worker should wait before its next poll, set when the service is shedding load
+  110: optional i64 (js.type = "Long") pollBackoffMillis
 }
 
 struct StickyExecutionAttributes {
@@ -894,10 +1016,14 @@ struct RespondDecisionTaskCompletedRequest {
   50: optional StickyExecutionAttributes stickyAttributes
   60: optional bool returnNewDecisionTask
   70: optional bool forceCreateNewDecisionTask
+  80: optional i32 chunkIndex
+  90: optional i32 chunkCount
+  100: optional string binaryChecksum
 }
 
 struct RespondDecisionTaskCompletedResponse {
   10: optional PollForDecisionTaskResponse decisionTask
+  20: optional list<PollForActivityTaskResponse> activitiesToDispatchLocally
 }
 
 struct RespondDecisionTaskFailedRequest {
@@ -905,6 +1031,7 @@ struct RespondDecisionTaskFailedRequest {
   20: optional DecisionTaskFailedCause cause
   30: optional binary details
   40: optional string identity
+  50: optional string binaryChecksum
 }
 
 struct PollForActivityTaskRequest {
@@ -912,6 +1039,7 @@ struct PollForActivityTaskRequest {
   20: optional TaskList taskList
   30: optional string identity
   40: optional TaskListMetadata taskListMetadata
+  50: optional string isolationGroup
 }
 
 struct PollForActivityTaskResponse {
@@ -927,6 +1055,11 @@ struct PollForActivityTaskResponse {
   110: optional i32 heartbeatTimeoutSeconds
   120: optional i32 attempt
   130: optional i64 (js.type = "Long") scheduledTimestampOfThisAttempt
+  // absolute deadlines in unix nanos, so workers can set the deadline of the activity context precisely
+  140: optional i64 (js.type = "Long") scheduleToCloseDeadline
+  150: optional i64 (js.type = "Long") startToCloseDeadline
+  // how long the worker should wait before its next poll, set when the service is shedding load
+  160: optional i64 (js.type = "Long") pollBackoffMillis
 }
 
 struct RecordActivityTaskHeartbeatRequest {
@@ -1016,6 +1149,63 @@ struct GetWorkflowExecutionHistoryResponse {
   20: optional binary nextPageToken
 }
 
+struct GetWorkflowExecutionResultRequest {
+  10: optional string domain
+  20: optional WorkflowExecution execution
+}
+
+struct GetWorkflowExecutionResultResponse {
+  10: optional WorkflowExecution execution
+  // not set if the workflow execution is still running
+  20: optional WorkflowExecutionCloseStatus closeStatus
+  // result of a completed workflow execution
+  30: optional binary result
+  // reason of a failed or terminated workflow execution
+  40: optional string reason
+  // details of a failed, canceled or terminated workflow execution
+  50: optional binary details
+  60: optional TimeoutType timeoutType
+  // run ID of the new execution when the workflow execution is continued as new
+  70: optional string newExecutionRunId
+}
+
+struct GetWorkflowExecutionGraphRequest {
+  10: optional string domain
+  20: optional WorkflowExecution execution
+}
+
+// ExecutionGraphNode is an activity, timer, child workflow or received signal of a workflow execution
+struct ExecutionGraphNode {
+  10: optional ExecutionGraphNodeType type
+  // activity ID, timer ID or child workflow ID, the signal name for a signal
+  20: optional string id
+  // activity type or child workflow type
+  30: optional string name
+  40: optional ExecutionGraphNodeState state
+  // ID of the event which created the node, identifies the node within the execution
+  50: optional i64 (js.type = "Long") initiatedEventId
+  // ID of the decision completed event which created the node, not set for signals
+  60: optional i64 (js.type = "Long") decisionTaskCompletedEventId
+  70: optional i64 (js.type = "Long") scheduledTimestamp
+  80: optional i64 (js.type = "Long") startedTimestamp
+  90: optional i64 (js.type = "Long") closeTimestamp
+  // attempt of a started activity
+  100: optional i32 attempt
+  // run ID of a started child workflow
+  110: optional string childRunId
+}
+
+struct GetWorkflowExecutionGraphResponse {
+  10: optional WorkflowExecution execution
+  20: optional WorkflowType workflowType
+  30: optional i64 (js.type = "Long") startTimestamp
+  // not set if the workflow execution is still running
+  40: optional i64 (js.type = "Long") closeTimestamp
+  50: optional WorkflowExecutionCloseStatus closeStatus
+  // nodes in the order they were created
+  60: optional list<ExecutionGraphNode> nodes
+}
+
 struct SignalWorkflowExecutionRequest {
   10: optional string domain
   20: optional WorkflowExecution workflowExecution
@@ -1026,6 +1216,33 @@ struct SignalWorkflowExecutionRequest {
   70: optional binary control
 }
 
+struct UpdateWorkflowExecutionOptionsRequest {
+  10: optional string domain
+  20: optional WorkflowExecution workflowExecution
+  // task list the next run inherits on continue-as-new when the decision does not name one,
+  // an empty name clears a previous override
+  30: optional TaskList continueAsNewTaskList
+  40: optional string identity
+  // decision task list of the running workflow, the change is recorded in history and takes effect
+  // when the next decision is scheduled, a decision already scheduled stays on its task list
+  50: optional TaskList taskList
+}
+
+struct UpdateWorkflowExecutionRequest {
+  10: optional string domain
+  20: optional WorkflowExecution workflowExecution
+  30: optional string updateName
+  40: optional binary input
+  50: optional string identity
+  // identifies the update, a request retried with the same ID waits for the same update instead of
+  // requesting a new one
+  60: optional string updateId
+}
+
+struct UpdateWorkflowExecutionResponse {
+  10: optional binary result
+}
+
 struct SignalWithStartWorkflowExecutionRequest {
   10: optional string domain
   20: optional string workflowId
@@ -1040,6 +1257,8 @@ struct SignalWithStartWorkflowExecutionRequest {
   110: optional string signalName
   120: optional binary signalInput
   130: optional binary control
+  140: optional map<string,string> tags
+  150: optional i32 priority
 }
 
 struct TerminateWorkflowExecutionRequest {
@@ -1057,6 +1276,7 @@ struct ListOpenWorkflowExecutionsRequest {
   40: optional StartTimeFilter StartTimeFilter
   50: optional WorkflowExecutionFilter executionFilter
   60: optional WorkflowTypeFilter typeFilter
+  70: optional TagFilter tagFilter
 }
 
 struct ListOpenWorkflowExecutionsResponse {
@@ -1072,6 +1292,7 @@ struct ListClosedWorkflowExecutionsRequest {
   50: optional WorkflowExecutionFilter executionFilter
   60: optional WorkflowTypeFilter typeFilter
   70: optional WorkflowExecutionCloseStatus statusFilter
+  80: optional TagFilter tagFilter
 }
 
 struct ListClosedWorkflowExecutionsResponse {
@@ -1079,6 +1300,21 @@ struct ListClosedWorkflowExecutionsResponse {
   20: optional binary nextPageToken
 }
 
+struct ListWorkflowExecutionsRequest {
+  10: optional string domain
+  20: optional i32 maximumPageSize
+  30: optional binary nextPageToken
+  40: optional StartTimeFilter StartTimeFilter
+  50: optional WorkflowExecutionFilter executionFilter
+  60: optional WorkflowTypeFilter typeFilter
+  70: optional TagFilter tagFilter
+}
+
+struct ListWorkflowExecutionsResponse {
+  10: optional list<WorkflowExecutionInfo> executions
+  20: optional binary nextPageToken
+}
+
 struct QueryWorkflowRequest {
   10: optional string domain
   20: optional WorkflowExecution execution
@@ -1100,8 +1336,8 @@ struct ResetStickyTaskListRequest {
 }
 
 struct ResetStickyTaskListResponse {
-    // The reason to keep this response is to allow returning
-    // information in the future.
+  // stickyInvalidationCount is bumped every time the stickiness of the workflow is reset
+  10: optional i64 (js.type = "Long") stickyInvalidationCount
 }
 
 struct RespondQueryTaskCompletedRequest {
@@ -1124,10 +1360,17 @@ struct PendingActivityInfo {
   50: optional i64 (js.type = "Long") lastHeartbeatTimestamp
 }
 
+struct WorkflowExecutionStats {
+  10: optional i64 (js.type = "Long") historySize
+  20: optional i64 (js.type = "Long") historyEventsCount
+  30: optional i64 (js.type = "Long") mutableStateSize
+}
+
 struct DescribeWorkflowExecutionResponse {
   10: optional WorkflowExecutionConfiguration executionConfiguration
   20: optional WorkflowExecutionInfo workflowExecutionInfo
   30: optional list<PendingActivityInfo> pendingActivities
+  40: optional WorkflowExecutionStats executionStats
 }
 
 struct DescribeTaskListRequest {
@@ -1138,6 +1381,170 @@ struct DescribeTaskListRequest {
 
 struct DescribeTaskListResponse {
   10: optional list<PollerInfo> pollers
+  // workers which heartbeated on this tasklist in last few minutes
+  20: optional list<WorkerInfo> workers
+}
+
+struct DescribeTaskListLatencyRequest {
+  10: optional string domain
+  20: optional TaskList taskList
+  30: optional TaskListType taskListType
+}
+
+struct DescribeTaskListLatencyResponse {
+  // number of recent dispatches the percentiles are computed over
+  10: optional i32 sampleCount
+  20: optional i64 (js.type = "Long") scheduleToStartP50Millis
+  30: optional i64 (js.type = "Long") scheduleToStartP90Millis
+  40: optional i64 (js.type = "Long") scheduleToStartP99Millis
+  50: optional i64 (js.type = "Long") scheduleToStartMaxMillis
+}
+
+struct ForceUnloadTaskListRequest {
+  10: optional string domain
+  20: optional TaskList taskList
+  30: optional TaskListType taskListType
+  // matching host to unload the tasklist from, defaults to the owner of the tasklist on the ring
+  40: optional string hostAddress
+}
+
+struct ForceUnloadTaskListResponse {
+  // whether the tasklist was loaded on the host
+  10: optional bool unloaded
+}
+
+struct RecordWorkerHeartbeatRequest {
+  10: optional string domain
+  20: optional TaskList taskList
+  30: optional TaskListType taskListType
+  40: optional string identity
+  // capabilities advertised by the worker, e.g. the workflow or activity types it has registered
+  50: optional list<string> capabilities
+  // number of tasks the worker is processing right now
+  60: optional i32 currentLoad
+}
+
+struct ListTaskListDLQTasksRequest {
+  10: optional string domain
+  20: optional TaskList taskList
+  30: optional TaskListType taskListType
+  40: optional i32 maximumPageSize
+  50: optional binary nextPageToken
+}
+
+struct ListTaskListDLQTasksResponse {
+  10: optional list<TaskListDLQTaskInfo> tasks
+  20: optional binary nextPageToken
+}
+
+struct RequeueTaskListDLQTasksRequest {
+  10: optional string domain
+  20: optional TaskList taskList
+  30: optional TaskListType taskListType
+  // tasks to write back to the tasklist, all the DLQ tasks of the tasklist when empty
+  40: optional list<i64> taskIds
+}
+
+struct RequeueTaskListDLQTasksResponse {
+  10: optional i32 requeuedCount
+}
+
+struct GetTaskListsByDomainRequest {
+  10: optional string domain
+  20: optional i32 pageSize
+  30: optional binary nextPageToken
+}
+
+struct TaskListByDomainInfo {
+  10: optional TaskList taskList
+  20: optional TaskListType taskListType
+  // recent pollers as last persisted by the matching host owning the task list
+  30: optional list<PollerInfo> pollers
+}
+
+struct GetTaskListsByDomainResponse {
+  10: optional list<TaskListByDomainInfo> taskLists
+  20: optional binary nextPageToken
+}
+
+struct ScheduleSpec {
+  // runs are due at every multiple of the interval since the unix epoch, shifted by offsetInSeconds
+  10: optional i64 (js.type = "Long") intervalInSeconds
+  20: optional i64 (js.type = "Long") offsetInSeconds
+  // Unix Nano, when set no run is due before startTime or after endTime
+  30: optional i64 (js.type = "Long") startTime
+  40: optional i64 (js.type = "Long") endTime
+}
+
+struct ScheduleAction {
+  // the workflow ID of a run is this prefix, the schedule ID by default, followed by the time the run was due
+  10: optional string workflowIdPrefix
+  20: optional WorkflowType workflowType
+  30: optional TaskList taskList
+  40: optional binary input
+  50: optional i32 executionStartToCloseTimeoutSeconds
+  60: optional i32 taskStartToCloseTimeoutSeconds
+}
+
+struct ScheduleState {
+  // Unix Nano
+  10: optional i64 (js.type = "Long") nextRunTime
+  20: optional i64 (js.type = "Long") lastRunTime
+  30: optional WorkflowExecution lastExecution
+  // Unix Nano times the runs buffered behind the open run were due at
+  40: optional list<i64> bufferedRunTimes
+  50: optional bool paused
+}
+
+struct CreateScheduleRequest {
+  10: optional string domain
+  20: optional string scheduleId
+  30: optional ScheduleSpec spec
+  40: optional ScheduleAction action
+  50: optional ScheduleOverlapPolicy overlapPolicy
+  60: optional bool paused
+  70: optional string identity
+}
+
+struct DescribeScheduleRequest {
+  10: optional string domain
+  20: optional string scheduleId
+}
+
+struct DescribeScheduleResponse {
+  10: optional string scheduleId
+  20: optional ScheduleSpec spec
+  30: optional ScheduleAction action
+  40: optional ScheduleOverlapPolicy overlapPolicy
+  50: optional ScheduleState state
+}
+
+// only the fields which are set are updated
+struct UpdateScheduleRequest {
+  10: optional string domain
+  20: optional string scheduleId
+  30: optional ScheduleSpec spec
+  40: optional ScheduleAction action
+  50: optional ScheduleOverlapPolicy overlapPolicy
+  60: optional bool paused
+  70: optional string identity
+}
+
+struct DeleteScheduleRequest {
+  10: optional string domain
+  20: optional string scheduleId
+  30: optional string identity
+}
+
+struct ListSchedulesRequest {
+  10: optional string domain
+  20: optional i32 pageSize
+  30: optional binary nextPageToken
+}
+
+struct ListSchedulesResponse {
+  10: optional list<DescribeScheduleResponse> schedules
+  20: optional binary nextPageToken
 }
 
 //At least one of the parameters needs to be provided
@@ -1155,6 +1562,50 @@ struct DescribeHistoryHostResponse{
   50: optional string               address
 }
 
+struct DescribeShardBacklogsRequest {
+  // only describe the shards owned by this history host, all history hosts are asked when not set
+  10: optional string hostAddress //ip:port
+  20: optional i32    maximumShards
+}
+
+struct ShardBacklogInfo {
+  10: optional i32              shardId
+  20: optional string           hostAddress
+  // age of the oldest unacked task of each task queue of the shard
+  30: optional map<string, i64> queueBacklogAgeInSeconds
+  40: optional i64              maxBacklogAgeInSeconds
+}
+
+struct DescribeShardBacklogsResponse {
+  // shards ordered by decreasing maxBacklogAgeInSeconds
+  10: optional list<ShardBacklogInfo> shards
+}
+
+struct RefreshDomainCacheRequest {
+  // only refresh the domain cache of this history host, all history hosts are notified when not set
+  10: optional string hostAddress //ip:port
+  // the domain which changed, for logging purposes
+  20: optional string domainId
+}
+
+struct DescribeShardOperationsRequest {
+  10: optional i32 shardId
+  20: optional i32 maximumOperations
+}
+
+struct ShardOperation {
+  10: optional i64    timestamp
+  20: optional string operation
+  30: optional string details
+}
+
+struct DescribeShardOperationsResponse {
+  10: optional i32                  shardId
+  20: optional string               hostAddress
+  // most recent operation first
+  30: optional list<ShardOperation> operations
+}
+
 struct DomainCacheInfo{
   10: optional i64 numOfItemsInCacheByID
   20: optional i64 numOfItemsInCacheByName
@@ -1175,6 +1626,25 @@ struct PollerInfo {
   // Unix Nano
   10: optional i64 (js.type = "Long")  lastAccessTime
   20: optional string identity
+  30: optional string version
+}
+
+struct WorkerInfo {
+  10: optional string identity
+  20: optional list<string> capabilities
+  30: optional i32 currentLoad
+  // Unix Nano
+  40: optional i64 (js.type = "Long") lastHeartbeatTime
+  // Unix Nano, unset when the worker did not poll the tasklist in last few minutes
+  50: optional i64 (js.type = "Long") lastPollTime
+}
+
+struct TaskListDLQTaskInfo {
+  10: optional i64 (js.type = "Long") taskId
+  20: optional WorkflowExecution workflowExecution
+  30: optional i64 (js.type = "Long") scheduleId
+  // number of failed dispatches before the task was moved to the DLQ
+  40: optional i32 dispatchAttempts
 }
 
 struct RetryPolicy {
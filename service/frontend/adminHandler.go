@@ -22,40 +22,111 @@ package frontend
 
 import (
 	"context"
-
+	"fmt"
 	"strconv"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
 
 	"github.com/uber/cadence/.gen/go/admin"
 	"github.com/uber/cadence/.gen/go/admin/adminserviceserver"
 	hist "github.com/uber/cadence/.gen/go/history"
+	m "github.com/uber/cadence/.gen/go/matching"
 	gen "github.com/uber/cadence/.gen/go/shared"
+	adminClient "github.com/uber/cadence/client/admin"
 	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/serviceerror"
 )
 
 var _ adminserviceserver.Interface = (*AdminHandler)(nil)
 
+const (
+	// defaultHistoryBatchesPageSize is the number of history batches fetched per page when the request does not specify one
+	defaultHistoryBatchesPageSize = 100
+	// defaultMaximumBacklogShards is the number of shards returned by DescribeShardBacklogs when the request does not specify one
+	defaultMaximumBacklogShards = 10
+	// defaultDLQTasksPageSize is the number of tasklist DLQ tasks returned per page when the request does not specify one
+	defaultDLQTasksPageSize = 100
+	// defaultFailoverDrillTaskListsPageSize is the page size used to list the task lists checked by a failover drill
+	defaultFailoverDrillTaskListsPageSize = 100
+	// defaultMaximumDLQShards is the number of shards summarized by DescribeReplicationDLQ when the request does not specify one
+	defaultMaximumDLQShards = 100
+	// maxFixWorkflowExecutionIssues is the max number of runs which a FixWorkflowExecutions request can fix
+	maxFixWorkflowExecutionIssues = 1000
+
+	divergenceEventIDMismatch   = "event ID mismatch"
+	divergenceVersionMismatch   = "event version mismatch"
+	divergenceEventTypeMismatch = "event type mismatch"
+	divergenceMissingInLocal    = "event missing in local history"
+	divergenceMissingInRemote   = "event missing in remote history"
+)
+
+var (
+	errRemoteClusterNotSet    = &gen.BadRequestError{Message: "RemoteCluster is not set on request."}
+	errRemoteClusterIsCurrent = &gen.BadRequestError{Message: "RemoteCluster cannot be the current cluster."}
+	errRemoteClusterNoAddress = &gen.BadRequestError{Message: "RemoteCluster has no configured address."}
+	errInvalidMaximumPageSize = &gen.BadRequestError{Message: "MaximumPageSize cannot be negative."}
+	errInvalidMaximumShards   = &gen.BadRequestError{Message: "MaximumShards cannot be negative."}
+	errInvalidShardID         = &gen.BadRequestError{Message: "ShardId is not a valid history shard."}
+	errInvalidMaximumOps      = &gen.BadRequestError{Message: "MaximumOperations cannot be negative."}
+	errNoFailoverDrill        = &gen.BadRequestError{Message: "Domain has no failover drill targeting the current cluster."}
+	errIssuesNotSet           = &gen.BadRequestError{Message: "Issues are not set on request."}
+	errTooManyIssues          = &gen.BadRequestError{Message: fmt.Sprintf("Request has more than %v issues.", maxFixWorkflowExecutionIssues)}
+	errIssueTypeNotSet        = &gen.BadRequestError{Message: "IssueType is not set on issue."}
+	errSourceClusterNotSet    = &gen.BadRequestError{Message: "SourceCluster is not set on request."}
+	errSourceClusterIsCurrent = &gen.BadRequestError{Message: "SourceCluster cannot be the current cluster."}
+	errUnknownSourceCluster   = &gen.BadRequestError{Message: "SourceCluster is not a known cluster."}
+	errInvalidMaximumShardCnt = &gen.BadRequestError{Message: "MaximumShardCount cannot be negative."}
+	errUnknownMatchingHost    = &gen.BadRequestError{Message: "HostAddress is not a member of the matching ring."}
+)
+
 type (
 	// AdminHandler - Thrift handler inteface for admin service
 	AdminHandler struct {
 		numberOfHistoryShards int
+		config                *Config
 		service.Service
-		history     history.Client
-		domainCache cache.DomainCache
+		history            history.Client
+		matching           matching.Client
+		domainCache        cache.DomainCache
+		wfDomainCache      cache.DomainCache
+		historyMgr         persistence.HistoryManager
+		shardMgr           persistence.ShardManager
+		postCloseEventsMgr persistence.PostCloseEventsManager
+		hSerializerFactory persistence.HistorySerializerFactory
+
+		sync.Mutex
+		remoteAdminClients map[string]adminClient.Client
+
+		interceptors []Interceptor
 	}
 )
 
 // NewAdminHandler creates a thrift handler for the cadence admin service
 func NewAdminHandler(
-	sVice service.Service, numberOfHistoryShards int, metadataMgr persistence.MetadataManager) *AdminHandler {
+	sVice service.Service, numberOfHistoryShards int, config *Config, metadataMgr persistence.MetadataManager,
+	historyMgr persistence.HistoryManager, shardMgr persistence.ShardManager,
+	postCloseEventsMgr persistence.PostCloseEventsManager, wfDomainCache cache.DomainCache,
+	interceptors ...Interceptor) *AdminHandler {
 	handler := &AdminHandler{
 		numberOfHistoryShards: numberOfHistoryShards,
+		config:                config,
 		Service:               sVice,
 		domainCache:           cache.NewDomainCache(metadataMgr, sVice.GetClusterMetadata(), sVice.GetMetricsClient(), sVice.GetLogger()),
+		wfDomainCache:         wfDomainCache,
+		historyMgr:            historyMgr,
+		shardMgr:              shardMgr,
+		postCloseEventsMgr:    postCloseEventsMgr,
+		hSerializerFactory:    persistence.NewHistorySerializerFactory(),
+		remoteAdminClients:    make(map[string]adminClient.Client),
+		interceptors:          interceptors,
 	}
 	return handler
 }
@@ -63,13 +134,17 @@ func NewAdminHandler(
 // Start starts the handler
 func (adh *AdminHandler) Start() error {
 	adh.domainCache.Start()
-	adh.Service.GetDispatcher().Register(adminserviceserver.New(adh))
+	adh.Service.GetDispatcher().Register(adminserviceserver.New(newInterceptedAdminHandler(adh, adh.interceptors)))
 	adh.Service.Start()
 	var err error
 	adh.history, err = adh.Service.GetClientFactory().NewHistoryClient()
 	if err != nil {
 		return err
 	}
+	adh.matching, err = adh.Service.GetClientFactory().NewMatchingClient()
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -116,6 +191,36 @@ func (adh *AdminHandler) DescribeWorkflowExecution(ctx context.Context, request
 	}, err
 }
 
+// DeleteWorkflowExecution force deletes a workflow execution run, including its history and visibility records
+func (adh *AdminHandler) DeleteWorkflowExecution(ctx context.Context, request *admin.DeleteWorkflowExecutionRequest) error {
+	if request == nil {
+		return adh.error(errRequestNotSet)
+	}
+
+	if request.Domain == nil || request.GetDomain() == "" {
+		return adh.error(errDomainNotSet)
+	}
+
+	if err := validateExecution(request.Execution); err != nil {
+		return adh.error(err)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return adh.error(err)
+	}
+
+	err = adh.history.DeleteWorkflowExecution(ctx, &hist.DeleteWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  request.Execution,
+		Force:      request.Force,
+	})
+	if err != nil {
+		return adh.error(err)
+	}
+	return nil
+}
+
 // DescribeHistoryHost returns information about the internal states of a history host
 func (adh *AdminHandler) DescribeHistoryHost(ctx context.Context, request *gen.DescribeHistoryHostRequest) (*gen.DescribeHistoryHostResponse, error) {
 	if request == nil || (request.ShardIdForHost == nil && request.ExecutionForHost == nil && request.HostAddress == nil) {
@@ -132,19 +237,878 @@ func (adh *AdminHandler) DescribeHistoryHost(ctx context.Context, request *gen.D
 	return resp, err
 }
 
+// DescribeShardBacklogs returns the shards with the oldest unacked tasks in their task queues, either across all
+// history hosts or for the requested one
+func (adh *AdminHandler) DescribeShardBacklogs(ctx context.Context,
+	request *gen.DescribeShardBacklogsRequest) (*gen.DescribeShardBacklogsResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	if request.GetMaximumShards() < 0 {
+		return nil, adh.error(errInvalidMaximumShards)
+	}
+	maximumShards := int(request.GetMaximumShards())
+	if maximumShards == 0 {
+		maximumShards = defaultMaximumBacklogShards
+	}
+
+	shards, err := adh.describeShardBacklogs(ctx, request.HostAddress, maximumShards)
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	return &gen.DescribeShardBacklogsResponse{
+		Shards: common.TopShardBacklogs(shards, maximumShards),
+	}, nil
+}
+
+// describeShardBacklogs collects the backlogs of up to maximumShards shards of the given history host, or of each
+// history host when the address is not set
+func (adh *AdminHandler) describeShardBacklogs(ctx context.Context, hostAddress *string,
+	maximumShards int) ([]*gen.ShardBacklogInfo, error) {
+	var addresses []string
+	if hostAddress != nil {
+		addresses = append(addresses, *hostAddress)
+	} else {
+		resolver, err := adh.GetMembershipMonitor().GetResolver(common.HistoryServiceName)
+		if err != nil {
+			return nil, err
+		}
+		hosts, err := resolver.Members()
+		if err != nil {
+			return nil, err
+		}
+		for _, host := range hosts {
+			addresses = append(addresses, host.GetAddress())
+		}
+	}
+
+	shards := []*gen.ShardBacklogInfo{}
+	for _, address := range addresses {
+		resp, err := adh.history.DescribeShardBacklogs(ctx, &gen.DescribeShardBacklogsRequest{
+			HostAddress:   common.StringPtr(address),
+			MaximumShards: common.Int32Ptr(int32(maximumShards)),
+		})
+		if err != nil {
+			if hostAddress != nil {
+				return nil, err
+			}
+			// a single unreachable host should not hide the backlogs of the others
+			adh.Service.GetLogger().WithFields(bark.Fields{
+				logging.TagHostname: address,
+				logging.TagErr:      err,
+			}).Warn("Failed to describe shard backlogs of history host.")
+			continue
+		}
+		shards = append(shards, resp.Shards...)
+	}
+	return shards, nil
+}
+
+// DescribeShardOperations returns the recent significant operations recorded by a history shard, most recent first
+func (adh *AdminHandler) DescribeShardOperations(ctx context.Context,
+	request *gen.DescribeShardOperationsRequest) (*gen.DescribeShardOperationsResponse, error) {
+	if request == nil || request.ShardId == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	if request.GetShardId() < 0 || int(request.GetShardId()) >= adh.numberOfHistoryShards {
+		return nil, adh.error(errInvalidShardID)
+	}
+	if request.GetMaximumOperations() < 0 {
+		return nil, adh.error(errInvalidMaximumOps)
+	}
+
+	resp, err := adh.history.DescribeShardOperations(ctx, request)
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	return resp, nil
+}
+
+// GetWorkflowExecutionHistoryBatches returns the history of a workflow execution run page by page, keeping
+// the events grouped in the batches they were persisted with
+func (adh *AdminHandler) GetWorkflowExecutionHistoryBatches(ctx context.Context,
+	request *admin.GetWorkflowExecutionHistoryBatchesRequest) (*admin.GetWorkflowExecutionHistoryBatchesResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err)
+	}
+	if request.GetMaximumPageSize() < 0 {
+		return nil, adh.error(errInvalidMaximumPageSize)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err)
+	}
+
+	batches, nextPageToken, err := adh.getHistoryBatches(ctx, domainID, *request.Execution,
+		request.GetMaximumPageSize(), request.NextPageToken)
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	return &admin.GetWorkflowExecutionHistoryBatchesResponse{
+		HistoryBatches: batches,
+		NextPageToken:  nextPageToken,
+	}, nil
+}
+
+// ListPostCloseEvents returns page by page the externally generated events replicated from a remote cluster for a
+// workflow execution run which was already closed in the current cluster
+func (adh *AdminHandler) ListPostCloseEvents(ctx context.Context,
+	request *admin.ListPostCloseEventsRequest) (*admin.ListPostCloseEventsResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err)
+	}
+	if request.Execution.GetRunId() == "" {
+		return nil, adh.error(errRunIDNotSet)
+	}
+	if request.GetMaximumPageSize() < 0 {
+		return nil, adh.error(errInvalidMaximumPageSize)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err)
+	}
+
+	pageSize := request.GetMaximumPageSize()
+	if pageSize == 0 {
+		pageSize = defaultHistoryBatchesPageSize
+	}
+	response, err := adh.postCloseEventsMgr.ListPostCloseEvents(&persistence.ListPostCloseEventsRequest{
+		DomainID:      domainID,
+		Execution:     *request.Execution,
+		PageSize:      int(pageSize),
+		NextPageToken: request.NextPageToken,
+	})
+	if err != nil {
+		return nil, adh.error(err)
+	}
+
+	batches := []*admin.PostCloseEventBatch{}
+	for _, batch := range response.Batches {
+		persistence.SetSerializedHistoryDefaults(batch.Events)
+		s, _ := adh.hSerializerFactory.Get(batch.Events.EncodingType)
+		history, err := s.Deserialize(batch.Events)
+		if err != nil {
+			return nil, adh.error(err)
+		}
+		batches = append(batches, &admin.PostCloseEventBatch{
+			SourceCluster:     common.StringPtr(batch.SourceCluster),
+			RecordedTimestamp: common.Int64Ptr(batch.RecordedTime.UnixNano()),
+			History:           &gen.History{Events: history.Events},
+		})
+	}
+
+	var nextPageToken []byte
+	if len(response.NextPageToken) != 0 {
+		nextPageToken = response.NextPageToken
+	}
+	return &admin.ListPostCloseEventsResponse{
+		Batches:       batches,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// DiffWorkflowExecutionHistory compares the history of a workflow execution run in the current cluster with the
+// one in a remote cluster batch by batch, and returns the first event where the two diverge
+func (adh *AdminHandler) DiffWorkflowExecutionHistory(ctx context.Context,
+	request *admin.DiffWorkflowExecutionHistoryRequest) (*admin.DiffWorkflowExecutionHistoryResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err)
+	}
+	if request.GetRemoteCluster() == "" {
+		return nil, adh.error(errRemoteClusterNotSet)
+	}
+	if request.GetMaximumPageSize() < 0 {
+		return nil, adh.error(errInvalidMaximumPageSize)
+	}
+
+	remote, err := adh.getRemoteAdminClient(request.GetRemoteCluster())
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err)
+	}
+
+	// pin the run ID, so both clusters are compared on the same run even if the current run changes
+	execution := gen.WorkflowExecution{
+		WorkflowId: request.Execution.WorkflowId,
+		RunId:      request.Execution.RunId,
+	}
+	if execution.GetRunId() == "" {
+		response, err := adh.history.GetMutableState(ctx, &hist.GetMutableStateRequest{
+			DomainUUID: common.StringPtr(domainID),
+			Execution:  &execution,
+		})
+		if err != nil {
+			return nil, adh.error(err)
+		}
+		execution.RunId = response.Execution.RunId
+	}
+
+	localReader := &historyBatchReader{
+		read: func(token []byte) ([]*gen.History, []byte, error) {
+			return adh.getHistoryBatches(ctx, domainID, execution, request.GetMaximumPageSize(), token)
+		},
+	}
+	remoteReader := &historyBatchReader{
+		read: func(token []byte) ([]*gen.History, []byte, error) {
+			response, err := remote.GetWorkflowExecutionHistoryBatches(ctx, &admin.GetWorkflowExecutionHistoryBatchesRequest{
+				Domain:          request.Domain,
+				Execution:       &gen.WorkflowExecution{WorkflowId: execution.WorkflowId, RunId: execution.RunId},
+				MaximumPageSize: request.MaximumPageSize,
+				NextPageToken:   token,
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			return response.HistoryBatches, response.NextPageToken, nil
+		},
+	}
+
+	result := &admin.DiffWorkflowExecutionHistoryResponse{
+		Identical:          common.BoolPtr(true),
+		ComparedEventCount: common.Int64Ptr(0),
+	}
+	for batchIndex := int32(0); ; batchIndex++ {
+		localBatch, err := localReader.next()
+		if err != nil {
+			return nil, adh.error(err)
+		}
+		remoteBatch, err := remoteReader.next()
+		if err != nil {
+			if _, ok := err.(*gen.EntityNotExistsError); !ok || localBatch == nil {
+				return nil, adh.error(err)
+			}
+			// the run does not exist in remote cluster, report the first local event as missing
+			remoteReader.done = true
+		}
+		if localBatch == nil && remoteBatch == nil {
+			return result, nil
+		}
+
+		divergence, matched := compareHistoryBatch(batchIndex, localBatch, remoteBatch)
+		result.ComparedEventCount = common.Int64Ptr(result.GetComparedEventCount() + matched)
+		if divergence != nil {
+			result.Identical = common.BoolPtr(false)
+			result.Divergence = divergence
+			return result, nil
+		}
+	}
+}
+
+// DescribeFailoverDrill reports whether the current cluster, as the target of a failover drill of the domain, could
+// take it over. The drill is not ready while the standby processing of the tasks replicated from the active cluster
+// lags behind, or while a task list of the domain has no poller in this cluster.
+func (adh *AdminHandler) DescribeFailoverDrill(ctx context.Context,
+	request *admin.DescribeFailoverDrillRequest) (*admin.DescribeFailoverDrillResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet)
+	}
+
+	domainEntry, err := adh.domainCache.GetDomain(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	if !domainEntry.IsFailoverDrillTarget() {
+		return nil, adh.error(errNoFailoverDrill)
+	}
+	activeClusterName := domainEntry.GetReplicationConfig().ActiveClusterName
+
+	// every shard runs standby queues for the active cluster, so all of them are needed
+	shards, err := adh.describeShardBacklogs(ctx, nil, adh.numberOfHistoryShards)
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	replicationLag := standbyBacklogAge(shards, activeClusterName)
+
+	taskLists, err := adh.getFailoverDrillTaskLists(ctx, domainEntry.GetInfo().ID, request.TaskLists)
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	for _, status := range taskLists {
+		resp, err := adh.matching.DescribeTaskList(ctx, &m.DescribeTaskListRequest{
+			DomainUUID: common.StringPtr(domainEntry.GetInfo().ID),
+			DescRequest: &gen.DescribeTaskListRequest{
+				Domain:       request.Domain,
+				TaskList:     status.TaskList,
+				TaskListType: status.TaskListType,
+			},
+		})
+		if err != nil {
+			return nil, adh.error(err)
+		}
+		status.PollerCount = common.Int32Ptr(int32(len(resp.Pollers)))
+	}
+	issues := failoverDrillIssues(replicationLag, adh.config.FailoverDrillMaxReplicationLag(), taskLists)
+
+	return &admin.DescribeFailoverDrillResponse{
+		Domain:                  request.Domain,
+		ActiveClusterName:       common.StringPtr(activeClusterName),
+		DrillClusterName:        common.StringPtr(adh.GetClusterMetadata().GetCurrentClusterName()),
+		ReplicationLagInSeconds: common.Int64Ptr(int64(replicationLag.Seconds())),
+		TaskLists:               taskLists,
+		Ready:                   common.BoolPtr(len(issues) == 0),
+		Issues:                  issues,
+	}, nil
+}
+
+// FixWorkflowExecutions applies targeted fixes to the workflow execution runs reported inconsistent by a scanner.
+// Each run is fixed on its own by the history host owning it, a run which cannot be fixed is reported with its error
+// rather than failing the request.
+func (adh *AdminHandler) FixWorkflowExecutions(ctx context.Context,
+	request *admin.FixWorkflowExecutionsRequest) (*admin.FixWorkflowExecutionsResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	if len(request.Issues) == 0 {
+		return nil, adh.error(errIssuesNotSet)
+	}
+	if len(request.Issues) > maxFixWorkflowExecutionIssues {
+		return nil, adh.error(errTooManyIssues)
+	}
+
+	dryRun := request.GetDryRun()
+	results := make([]*admin.WorkflowExecutionFixResult, 0, len(request.Issues))
+	for _, issue := range request.Issues {
+		result := &admin.WorkflowExecutionFixResult{
+			Issue: issue,
+			Fixed: common.BoolPtr(false),
+		}
+		action, err := adh.fixWorkflowExecution(ctx, issue, dryRun)
+		if err != nil {
+			result.Error = common.StringPtr(err.Error())
+		} else {
+			result.Action = common.StringPtr(action)
+			result.Fixed = common.BoolPtr(!dryRun)
+		}
+		results = append(results, result)
+	}
+	return &admin.FixWorkflowExecutionsResponse{Results: results}, nil
+}
+
+func (adh *AdminHandler) fixWorkflowExecution(ctx context.Context, issue *admin.WorkflowExecutionIssue,
+	dryRun bool) (string, error) {
+	if issue == nil || issue.GetDomain() == "" {
+		return "", errDomainNotSet
+	}
+	if err := validateExecution(issue.Execution); err != nil {
+		return "", err
+	}
+	// a fix always targets the run found by the scanner, never the current one
+	if issue.Execution.GetRunId() == "" {
+		return "", errRunIDNotSet
+	}
+	if issue.IssueType == nil {
+		return "", errIssueTypeNotSet
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(issue.GetDomain())
+	if err != nil {
+		return "", err
+	}
+	resp, err := adh.history.FixWorkflowExecution(ctx, &hist.FixWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  issue.Execution,
+		IssueType:  issue.IssueType,
+		DryRun:     common.BoolPtr(dryRun),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetAction(), nil
+}
+
+// standbyBacklogAge returns the age of the oldest unprocessed task of the standby queues of the given active cluster
+// across the shards
+func standbyBacklogAge(shards []*gen.ShardBacklogInfo, activeClusterName string) time.Duration {
+	var maxAge int64
+	for _, shard := range shards {
+		for _, queueName := range []string{
+			common.TransferQueueBacklogPrefix + activeClusterName,
+			common.TimerQueueBacklogPrefix + activeClusterName,
+		} {
+			if age := shard.QueueBacklogAgeInSeconds[queueName]; age > maxAge {
+				maxAge = age
+			}
+		}
+	}
+	return time.Duration(maxAge) * time.Second
+}
+
+// failoverDrillIssues returns what prevents a failover given the replication lag and the pollers of the task lists
+func failoverDrillIssues(replicationLag time.Duration, maxReplicationLag time.Duration,
+	taskLists []*admin.FailoverDrillTaskListStatus) []string {
+	issues := []string{}
+	if replicationLag > maxReplicationLag {
+		issues = append(issues, fmt.Sprintf("Standby task processing lags %v behind the active cluster, more than %v.",
+			replicationLag, maxReplicationLag))
+	}
+	for _, status := range taskLists {
+		if status.GetPollerCount() == 0 {
+			issues = append(issues, fmt.Sprintf("No poller on %v task list %v.",
+				status.GetTaskListType(), status.TaskList.GetName()))
+		}
+	}
+	return issues
+}
+
+// getFailoverDrillTaskLists returns the task lists a failover drill checks the pollers of, the requested task lists
+// with both of their types, or else all the task lists of the domain known to this cluster
+func (adh *AdminHandler) getFailoverDrillTaskLists(ctx context.Context, domainID string,
+	requested []*gen.TaskList) ([]*admin.FailoverDrillTaskListStatus, error) {
+	taskLists := []*admin.FailoverDrillTaskListStatus{}
+	if len(requested) != 0 {
+		for _, taskList := range requested {
+			for _, taskListType := range []gen.TaskListType{gen.TaskListTypeDecision, gen.TaskListTypeActivity} {
+				taskLists = append(taskLists, &admin.FailoverDrillTaskListStatus{
+					TaskList:     taskList,
+					TaskListType: common.TaskListTypePtr(taskListType),
+				})
+			}
+		}
+		return taskLists, nil
+	}
+
+	var token []byte
+	for {
+		resp, err := adh.matching.GetTaskListsByDomain(ctx, &m.GetTaskListsByDomainRequest{
+			DomainUUID: common.StringPtr(domainID),
+			ListRequest: &gen.GetTaskListsByDomainRequest{
+				PageSize:      common.Int32Ptr(defaultFailoverDrillTaskListsPageSize),
+				NextPageToken: token,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range resp.TaskLists {
+			taskLists = append(taskLists, &admin.FailoverDrillTaskListStatus{
+				TaskList:     info.TaskList,
+				TaskListType: info.TaskListType,
+			})
+		}
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			return taskLists, nil
+		}
+	}
+}
+
+// getHistoryBatches reads one page of history batches of the given run from the local history store,
+// the returned token carries the run's next event ID so paging is stable while the run makes progress
+// ListTaskListDLQTasks returns the tasks of a tasklist which were moved to its DLQ after repeatedly failing to be
+// dispatched
+func (adh *AdminHandler) ListTaskListDLQTasks(ctx context.Context,
+	request *gen.ListTaskListDLQTasksRequest) (*gen.ListTaskListDLQTasksResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	domainID, err := adh.validateTaskListRequest(request.GetDomain(), request.TaskList, request.TaskListType)
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	if request.GetMaximumPageSize() < 0 {
+		return nil, adh.error(errInvalidMaximumPageSize)
+	}
+	if request.GetMaximumPageSize() == 0 {
+		request.MaximumPageSize = common.Int32Ptr(defaultDLQTasksPageSize)
+	}
+
+	resp, err := adh.matching.ListTaskListDLQTasks(ctx, &m.ListTaskListDLQTasksRequest{
+		DomainUUID:  common.StringPtr(domainID),
+		ListRequest: request,
+	})
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	return resp, nil
+}
+
+// RequeueTaskListDLQTasks writes the given tasks of a tasklist DLQ, or all of them, back to the tasklist
+func (adh *AdminHandler) RequeueTaskListDLQTasks(ctx context.Context,
+	request *gen.RequeueTaskListDLQTasksRequest) (*gen.RequeueTaskListDLQTasksResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	domainID, err := adh.validateTaskListRequest(request.GetDomain(), request.TaskList, request.TaskListType)
+	if err != nil {
+		return nil, adh.error(err)
+	}
+
+	resp, err := adh.matching.RequeueTaskListDLQTasks(ctx, &m.RequeueTaskListDLQTasksRequest{
+		DomainUUID:     common.StringPtr(domainID),
+		RequeueRequest: request,
+	})
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	return resp, nil
+}
+
+// RefreshDomainCache refreshes the domain caches of the admin and workflow handlers of this host right away, it is
+// called after a domain got updated
+func (adh *AdminHandler) RefreshDomainCache(ctx context.Context, request *gen.RefreshDomainCacheRequest) error {
+	adh.domainCache.TriggerRefresh()
+	adh.wfDomainCache.TriggerRefresh()
+	return nil
+}
+
+// DescribeTaskListLatency returns the percentiles of the schedule-to-start latency of the tasks recently dispatched
+// from a tasklist
+func (adh *AdminHandler) DescribeTaskListLatency(ctx context.Context,
+	request *gen.DescribeTaskListLatencyRequest) (*gen.DescribeTaskListLatencyResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	domainID, err := adh.validateTaskListRequest(request.GetDomain(), request.TaskList, request.TaskListType)
+	if err != nil {
+		return nil, adh.error(err)
+	}
+
+	resp, err := adh.matching.DescribeTaskListLatency(ctx, &m.DescribeTaskListLatencyRequest{
+		DomainUUID:      common.StringPtr(domainID),
+		DescribeRequest: request,
+	})
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	return resp, nil
+}
+
+// ForceUnloadTaskList drops the manager of a tasklist from the matching host it is loaded on, by default the owner
+// of the tasklist on the ring. The next request for the tasklist loads it again on whichever host owns it then, which
+// moves hot tasklists off a degraded matching host without waiting for it to leave the ring.
+func (adh *AdminHandler) ForceUnloadTaskList(ctx context.Context,
+	request *gen.ForceUnloadTaskListRequest) (*gen.ForceUnloadTaskListResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	domainID, err := adh.validateTaskListRequest(request.GetDomain(), request.TaskList, request.TaskListType)
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	if request.HostAddress != nil {
+		if err := adh.validateMatchingHost(request.GetHostAddress()); err != nil {
+			return nil, adh.error(err)
+		}
+	}
+
+	resp, err := adh.matching.ForceUnloadTaskList(ctx, &m.ForceUnloadTaskListRequest{
+		DomainUUID:    common.StringPtr(domainID),
+		UnloadRequest: request,
+	})
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	return resp, nil
+}
+
+// validateMatchingHost checks that a host address belongs to the matching ring, so that requests are not sent to
+// arbitrary addresses
+func (adh *AdminHandler) validateMatchingHost(hostAddress string) error {
+	resolver, err := adh.GetMembershipMonitor().GetResolver(common.MatchingServiceName)
+	if err != nil {
+		return err
+	}
+	hosts, err := resolver.Members()
+	if err != nil {
+		return err
+	}
+	for _, host := range hosts {
+		if host.GetAddress() == hostAddress {
+			return nil
+		}
+	}
+	return errUnknownMatchingHost
+}
+
+func (adh *AdminHandler) validateTaskListRequest(domain string, taskList *gen.TaskList,
+	taskListType *gen.TaskListType) (string, error) {
+	if domain == "" {
+		return "", errDomainNotSet
+	}
+	if taskList == nil || taskList.GetName() == "" {
+		return "", errTaskListNotSet
+	}
+	if taskListType == nil {
+		return "", errTaskListTypeNotSet
+	}
+	return adh.domainCache.GetDomainID(domain)
+}
+
+func (adh *AdminHandler) getHistoryBatches(ctx context.Context, domainID string, execution gen.WorkflowExecution,
+	pageSize int32, nextPageToken []byte) ([]*gen.History, []byte, error) {
+	if pageSize <= 0 {
+		pageSize = defaultHistoryBatchesPageSize
+	}
+
+	token := &getHistoryContinuationToken{}
+	if len(nextPageToken) != 0 {
+		var err error
+		token, err = deserializeHistoryToken(nextPageToken)
+		if err != nil {
+			return nil, nil, errInvalidNextPageToken
+		}
+		if execution.GetRunId() != "" && execution.GetRunId() != token.RunID {
+			return nil, nil, errNextPageTokenRunIDMismatch
+		}
+	} else {
+		response, err := adh.history.GetMutableState(ctx, &hist.GetMutableStateRequest{
+			DomainUUID: common.StringPtr(domainID),
+			Execution:  &execution,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		token.RunID = response.Execution.GetRunId()
+		token.FirstEventID = common.FirstEventID
+		token.NextEventID = response.GetNextEventId()
+	}
+	execution.RunId = common.StringPtr(token.RunID)
+
+	response, err := adh.historyMgr.GetWorkflowExecutionHistory(&persistence.GetWorkflowExecutionHistoryRequest{
+		DomainID:      domainID,
+		Execution:     execution,
+		FirstEventID:  token.FirstEventID,
+		NextEventID:   token.NextEventID,
+		PageSize:      int(pageSize),
+		NextPageToken: token.PersistenceToken,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	batches := []*gen.History{}
+	for _, e := range response.Events {
+		persistence.SetSerializedHistoryDefaults(&e)
+		s, _ := adh.hSerializerFactory.Get(e.EncodingType)
+		history, err := s.Deserialize(&e)
+		if err != nil {
+			return nil, nil, err
+		}
+		batches = append(batches, &gen.History{Events: history.Events})
+	}
+
+	if len(response.NextPageToken) == 0 {
+		return batches, nil, nil
+	}
+	token.PersistenceToken = response.NextPageToken
+	nextPageToken, err = serializeHistoryToken(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	return batches, nextPageToken, nil
+}
+
+// DescribeReplicationDLQ summarizes the DLQ of the replication tasks from the given source cluster for a page of
+// history shards, only the shards with messages are returned
+func (adh *AdminHandler) DescribeReplicationDLQ(ctx context.Context,
+	request *admin.DescribeReplicationDLQRequest) (*admin.DescribeReplicationDLQResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	sourceCluster := request.GetSourceCluster()
+	if sourceCluster == "" {
+		return nil, adh.error(errSourceClusterNotSet)
+	}
+	if sourceCluster == adh.GetClusterMetadata().GetCurrentClusterName() {
+		return nil, adh.error(errSourceClusterIsCurrent)
+	}
+	if _, ok := adh.GetClusterMetadata().GetAllClusterFailoverVersions()[sourceCluster]; !ok {
+		return nil, adh.error(errUnknownSourceCluster)
+	}
+	startShardID := int(request.GetStartShardId())
+	if startShardID < 0 || startShardID >= adh.numberOfHistoryShards {
+		return nil, adh.error(errInvalidShardID)
+	}
+	if request.GetMaximumShardCount() < 0 {
+		return nil, adh.error(errInvalidMaximumShardCnt)
+	}
+	maximumShardCount := int(request.GetMaximumShardCount())
+	if maximumShardCount == 0 {
+		maximumShardCount = defaultMaximumDLQShards
+	}
+
+	resp, err := adh.describeReplicationDLQ(sourceCluster, startShardID, maximumShardCount)
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	return resp, nil
+}
+
+func (adh *AdminHandler) describeReplicationDLQ(sourceCluster string, startShardID,
+	maximumShardCount int) (*admin.DescribeReplicationDLQResponse, error) {
+	response := &admin.DescribeReplicationDLQResponse{}
+	endShardID := startShardID + maximumShardCount
+	if endShardID >= adh.numberOfHistoryShards {
+		endShardID = adh.numberOfHistoryShards
+	} else {
+		response.NextShardId = common.Int32Ptr(int32(endShardID))
+	}
+
+	for shardID := startShardID; shardID < endShardID; shardID++ {
+		summary, err := adh.shardMgr.GetReplicationDLQSummary(&persistence.GetReplicationDLQSummaryRequest{
+			ShardID:       shardID,
+			SourceCluster: sourceCluster,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if summary.MessageCount == 0 {
+			continue
+		}
+		response.Summaries = append(response.Summaries, &admin.ReplicationDLQSummary{
+			ShardId:                common.Int32Ptr(int32(shardID)),
+			SourceCluster:          common.StringPtr(sourceCluster),
+			MessageCount:           common.Int64Ptr(summary.MessageCount),
+			OldestMessageTimestamp: common.Int64Ptr(summary.OldestMessageTimestamp.UnixNano()),
+		})
+	}
+	return response, nil
+}
+
+func (adh *AdminHandler) getRemoteAdminClient(clusterName string) (adminClient.Client, error) {
+	if clusterName == adh.GetClusterMetadata().GetCurrentClusterName() {
+		return nil, errRemoteClusterIsCurrent
+	}
+
+	adh.Lock()
+	defer adh.Unlock()
+	if client, ok := adh.remoteAdminClients[clusterName]; ok {
+		return client, nil
+	}
+	address, ok := adh.GetClusterMetadata().GetAllClientAddress()[clusterName]
+	if !ok || address.RPCAddress == "" {
+		return nil, errRemoteClusterNoAddress
+	}
+	client, err := adh.GetClientFactory().NewRemoteAdminClient(address.RPCName, address.RPCAddress)
+	if err != nil {
+		return nil, err
+	}
+	adh.remoteAdminClients[clusterName] = client
+	return client, nil
+}
+
 func (adh *AdminHandler) error(err error) error {
-	switch err.(type) {
+	cause := serviceerror.Cause(err)
+	switch cause.(type) {
 	case *gen.InternalServiceError:
-		logging.LogInternalServiceError(adh.Service.GetLogger(), err)
-		return err
+		logging.LogInternalServiceError(adh.Service.GetLogger(), cause)
+		return cause
 	case *gen.BadRequestError:
-		return err
+		return cause
 	case *gen.ServiceBusyError:
-		return err
+		return cause
 	case *gen.EntityNotExistsError:
-		return err
+		return cause
 	default:
 		logging.LogUncategorizedError(adh.Service.GetLogger(), err)
 		return &gen.InternalServiceError{Message: err.Error()}
 	}
 }
+
+type (
+	// historyBatchReader iterates the history batches of a run across pages
+	historyBatchReader struct {
+		read    func(token []byte) ([]*gen.History, []byte, error)
+		batches []*gen.History
+		token   []byte
+		started bool
+		done    bool
+	}
+)
+
+// next returns the next history batch, or nil once the history is exhausted
+func (r *historyBatchReader) next() (*gen.History, error) {
+	for len(r.batches) == 0 {
+		if r.done || (r.started && len(r.token) == 0) {
+			return nil, nil
+		}
+		batches, token, err := r.read(r.token)
+		if err != nil {
+			return nil, err
+		}
+		r.started = true
+		r.batches = batches
+		r.token = token
+	}
+	batch := r.batches[0]
+	r.batches = r.batches[1:]
+	return batch, nil
+}
+
+// compareHistoryBatch compares the local and remote batch at the same index event by event, returns the first
+// divergence if any, along with the number of events that matched before it
+func compareHistoryBatch(batchIndex int32, local *gen.History, remote *gen.History) (*admin.HistoryDivergence, int64) {
+	var localEvents, remoteEvents []*gen.HistoryEvent
+	if local != nil {
+		localEvents = local.Events
+	}
+	if remote != nil {
+		remoteEvents = remote.Events
+	}
+
+	matched := int64(0)
+	for i := 0; i < len(localEvents) || i < len(remoteEvents); i++ {
+		divergence := &admin.HistoryDivergence{BatchIndex: common.Int32Ptr(batchIndex)}
+		var localEvent, remoteEvent *gen.HistoryEvent
+		if i < len(localEvents) {
+			localEvent = localEvents[i]
+			divergence.EventId = localEvent.EventId
+			divergence.LocalEventId = localEvent.EventId
+			divergence.LocalVersion = localEvent.Version
+			divergence.LocalEventType = localEvent.EventType
+		}
+		if i < len(remoteEvents) {
+			remoteEvent = remoteEvents[i]
+			if divergence.EventId == nil {
+				divergence.EventId = remoteEvent.EventId
+			}
+			divergence.RemoteEventId = remoteEvent.EventId
+			divergence.RemoteVersion = remoteEvent.Version
+			divergence.RemoteEventType = remoteEvent.EventType
+		}
+
+		switch {
+		case localEvent == nil:
+			divergence.Reason = common.StringPtr(divergenceMissingInLocal)
+		case remoteEvent == nil:
+			divergence.Reason = common.StringPtr(divergenceMissingInRemote)
+		case localEvent.GetEventId() != remoteEvent.GetEventId():
+			divergence.Reason = common.StringPtr(divergenceEventIDMismatch)
+		case localEvent.GetVersion() != remoteEvent.GetVersion():
+			divergence.Reason = common.StringPtr(divergenceVersionMismatch)
+		case localEvent.GetEventType() != remoteEvent.GetEventType():
+			divergence.Reason = common.StringPtr(divergenceEventTypeMismatch)
+		default:
+			matched++
+			continue
+		}
+		return divergence, matched
+	}
+	return nil, matched
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/.gen/go/admin"
+	"github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	adminHandlerSuite struct {
+		suite.Suite
+	}
+)
+
+func TestAdminHandlerSuite(t *testing.T) {
+	s := new(adminHandlerSuite)
+	suite.Run(t, s)
+}
+
+func (s *adminHandlerSuite) TestCompareHistoryBatch_Identical() {
+	local := newTestHistoryBatch(1, 10, shared.EventTypeWorkflowExecutionStarted, shared.EventTypeDecisionTaskScheduled)
+	remote := newTestHistoryBatch(1, 10, shared.EventTypeWorkflowExecutionStarted, shared.EventTypeDecisionTaskScheduled)
+
+	divergence, matched := compareHistoryBatch(0, local, remote)
+	s.Nil(divergence)
+	s.Equal(int64(2), matched)
+}
+
+func (s *adminHandlerSuite) TestCompareHistoryBatch_VersionMismatch() {
+	local := newTestHistoryBatch(5, 10, shared.EventTypeDecisionTaskStarted, shared.EventTypeDecisionTaskCompleted)
+	remote := newTestHistoryBatch(5, 10, shared.EventTypeDecisionTaskStarted, shared.EventTypeDecisionTaskCompleted)
+	remote.Events[1].Version = common.Int64Ptr(11)
+
+	divergence, matched := compareHistoryBatch(3, local, remote)
+	s.NotNil(divergence)
+	s.Equal(int64(1), matched)
+	s.Equal(int32(3), divergence.GetBatchIndex())
+	s.Equal(int64(6), divergence.GetEventId())
+	s.Equal(int64(10), divergence.GetLocalVersion())
+	s.Equal(int64(11), divergence.GetRemoteVersion())
+	s.Equal(divergenceVersionMismatch, divergence.GetReason())
+}
+
+func (s *adminHandlerSuite) TestCompareHistoryBatch_EventTypeMismatch() {
+	local := newTestHistoryBatch(5, 10, shared.EventTypeDecisionTaskTimedOut)
+	remote := newTestHistoryBatch(5, 10, shared.EventTypeDecisionTaskCompleted)
+
+	divergence, matched := compareHistoryBatch(0, local, remote)
+	s.NotNil(divergence)
+	s.Equal(int64(0), matched)
+	s.Equal(shared.EventTypeDecisionTaskTimedOut, divergence.GetLocalEventType())
+	s.Equal(shared.EventTypeDecisionTaskCompleted, divergence.GetRemoteEventType())
+	s.Equal(divergenceEventTypeMismatch, divergence.GetReason())
+}
+
+func (s *adminHandlerSuite) TestCompareHistoryBatch_MissingEvents() {
+	local := newTestHistoryBatch(5, 10, shared.EventTypeDecisionTaskStarted, shared.EventTypeDecisionTaskCompleted)
+	remote := newTestHistoryBatch(5, 10, shared.EventTypeDecisionTaskStarted)
+
+	divergence, matched := compareHistoryBatch(0, local, remote)
+	s.Equal(int64(1), matched)
+	s.Equal(int64(6), divergence.GetEventId())
+	s.Nil(divergence.RemoteEventId)
+	s.Equal(divergenceMissingInRemote, divergence.GetReason())
+
+	divergence, matched = compareHistoryBatch(0, nil, remote)
+	s.Equal(int64(0), matched)
+	s.Equal(int64(5), divergence.GetEventId())
+	s.Nil(divergence.LocalEventId)
+	s.Equal(divergenceMissingInLocal, divergence.GetReason())
+}
+
+func (s *adminHandlerSuite) TestHistoryBatchReader_Paging() {
+	pages := map[string][]*shared.History{
+		"":      {newTestHistoryBatch(1, 1, shared.EventTypeWorkflowExecutionStarted)},
+		"page2": {},
+		"page3": {newTestHistoryBatch(2, 1, shared.EventTypeDecisionTaskScheduled), newTestHistoryBatch(3, 1, shared.EventTypeDecisionTaskStarted)},
+	}
+	tokens := map[string][]byte{"": []byte("page2"), "page2": []byte("page3"), "page3": nil}
+	reads := 0
+	reader := &historyBatchReader{
+		read: func(token []byte) ([]*shared.History, []byte, error) {
+			reads++
+			return pages[string(token)], tokens[string(token)], nil
+		},
+	}
+
+	for eventID := int64(1); eventID <= 3; eventID++ {
+		batch, err := reader.next()
+		s.NoError(err)
+		s.Equal(eventID, batch.Events[0].GetEventId())
+	}
+	batch, err := reader.next()
+	s.NoError(err)
+	s.Nil(batch)
+	s.Equal(3, reads)
+}
+
+func (s *adminHandlerSuite) TestStandbyBacklogAge() {
+	shards := []*shared.ShardBacklogInfo{
+		{QueueBacklogAgeInSeconds: map[string]int64{
+			common.TransferQueueBacklogPrefix + "active": 30,
+			common.TransferQueueBacklogPrefix + "other":  600,
+		}},
+		{QueueBacklogAgeInSeconds: map[string]int64{
+			common.TimerQueueBacklogPrefix + "active": 90,
+			common.ReplicatorQueueBacklogName:         900,
+		}},
+	}
+	s.Equal(90*time.Second, standbyBacklogAge(shards, "active"))
+	s.Equal(time.Duration(0), standbyBacklogAge(shards, "missing"))
+}
+
+func (s *adminHandlerSuite) TestFailoverDrillIssues() {
+	taskLists := []*admin.FailoverDrillTaskListStatus{
+		{
+			TaskList:     &shared.TaskList{Name: common.StringPtr("polled")},
+			TaskListType: common.TaskListTypePtr(shared.TaskListTypeDecision),
+			PollerCount:  common.Int32Ptr(2),
+		},
+		{
+			TaskList:     &shared.TaskList{Name: common.StringPtr("idle")},
+			TaskListType: common.TaskListTypePtr(shared.TaskListTypeActivity),
+			PollerCount:  common.Int32Ptr(0),
+		},
+	}
+
+	s.Empty(failoverDrillIssues(time.Second, time.Minute, taskLists[:1]))
+
+	issues := failoverDrillIssues(2*time.Minute, time.Minute, taskLists)
+	s.Equal(2, len(issues))
+	s.Contains(issues[0], "2m0s")
+	s.Contains(issues[1], "idle")
+}
+
+func (s *adminHandlerSuite) TestFixWorkflowExecutions() {
+	historyClient := &mocks.HistoryClient{}
+	domainCache := &cache.DomainCacheMock{}
+	adh := &AdminHandler{history: historyClient, domainCache: domainCache}
+
+	domainCache.On("GetDomainID", "test-domain").Return("test-domain-id", nil)
+	historyClient.On("FixWorkflowExecution", mock.Anything, &history.FixWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr("test-domain-id"),
+		Execution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr("wid"),
+			RunId:      common.StringPtr("4b8a0cd2-1d9a-4a2c-9c8e-2a7d4e4ab1f1"),
+		},
+		IssueType: shared.WorkflowExecutionIssueTypeMissingTasks.Ptr(),
+		DryRun:    common.BoolPtr(false),
+	}).Return(&history.FixWorkflowExecutionResponse{Action: common.StringPtr("regenerate tasks")}, nil).Once()
+
+	resp, err := adh.FixWorkflowExecutions(context.Background(), &admin.FixWorkflowExecutionsRequest{
+		Issues: []*admin.WorkflowExecutionIssue{
+			{
+				Domain: common.StringPtr("test-domain"),
+				Execution: &shared.WorkflowExecution{
+					WorkflowId: common.StringPtr("wid"),
+					RunId:      common.StringPtr("4b8a0cd2-1d9a-4a2c-9c8e-2a7d4e4ab1f1"),
+				},
+				IssueType: shared.WorkflowExecutionIssueTypeMissingTasks.Ptr(),
+			},
+			{
+				Domain:    common.StringPtr("test-domain"),
+				Execution: &shared.WorkflowExecution{WorkflowId: common.StringPtr("wid")},
+				IssueType: shared.WorkflowExecutionIssueTypeOrphanExecution.Ptr(),
+			},
+		},
+	})
+	s.NoError(err)
+	s.Equal(2, len(resp.Results))
+	s.True(resp.Results[0].GetFixed())
+	s.Equal("regenerate tasks", resp.Results[0].GetAction())
+	s.Nil(resp.Results[0].Error)
+	s.False(resp.Results[1].GetFixed())
+	s.Equal(errRunIDNotSet.Error(), resp.Results[1].GetError())
+	historyClient.AssertExpectations(s.T())
+}
+
+func newTestHistoryBatch(firstEventID int64, version int64, eventTypes ...shared.EventType) *shared.History {
+	history := &shared.History{}
+	for i, eventType := range eventTypes {
+		history.Events = append(history.Events, &shared.HistoryEvent{
+			EventId:   common.Int64Ptr(firstEventID + int64(i)),
+			Version:   common.Int64Ptr(version),
+			EventType: eventType.Ptr(),
+		})
+	}
+	return history
+}
+
+func (s *adminHandlerSuite) TestDescribeReplicationDLQ() {
+	shardMgr := &mocks.ShardManager{}
+	adh := &AdminHandler{numberOfHistoryShards: 5, shardMgr: shardMgr}
+
+	oldest := time.Unix(0, 1000)
+	shardMgr.On("GetReplicationDLQSummary", &persistence.GetReplicationDLQSummaryRequest{
+		ShardID:       1,
+		SourceCluster: "standby",
+	}).Return(&persistence.GetReplicationDLQSummaryResponse{}, nil).Once()
+	shardMgr.On("GetReplicationDLQSummary", &persistence.GetReplicationDLQSummaryRequest{
+		ShardID:       2,
+		SourceCluster: "standby",
+	}).Return(&persistence.GetReplicationDLQSummaryResponse{MessageCount: 3, OldestMessageTimestamp: oldest}, nil).Once()
+
+	resp, err := adh.describeReplicationDLQ("standby", 1, 2)
+	s.NoError(err)
+	s.Equal(int32(3), resp.GetNextShardId())
+	s.Equal(1, len(resp.Summaries))
+	s.Equal(int32(2), resp.Summaries[0].GetShardId())
+	s.Equal("standby", resp.Summaries[0].GetSourceCluster())
+	s.Equal(int64(3), resp.Summaries[0].GetMessageCount())
+	s.Equal(int64(1000), resp.Summaries[0].GetOldestMessageTimestamp())
+
+	shardMgr.On("GetReplicationDLQSummary", mock.Anything).Return(&persistence.GetReplicationDLQSummaryResponse{}, nil).Twice()
+	resp, err = adh.describeReplicationDLQ("standby", 3, 100)
+	s.NoError(err)
+	s.Nil(resp.NextShardId)
+	s.Empty(resp.Summaries)
+	shardMgr.AssertExpectations(s.T())
+}
+
+func (s *adminHandlerSuite) TestListPostCloseEvents() {
+	postCloseEventsMgr := &mocks.PostCloseEventsManager{}
+	domainCache := &cache.DomainCacheMock{}
+	adh := &AdminHandler{
+		domainCache:        domainCache,
+		postCloseEventsMgr: postCloseEventsMgr,
+		hSerializerFactory: persistence.NewHistorySerializerFactory(),
+	}
+
+	execution := shared.WorkflowExecution{
+		WorkflowId: common.StringPtr("wid"),
+		RunId:      common.StringPtr("4b8a0cd2-1d9a-4a2c-9c8e-2a7d4e4ab1f1"),
+	}
+	signal := newTestHistoryBatch(10, 3, shared.EventTypeWorkflowExecutionSignaled)
+	events, err := persistence.NewJSONHistorySerializer().Serialize(persistence.NewHistoryEventBatch(
+		persistence.GetDefaultHistoryVersion(), signal.Events))
+	s.NoError(err)
+	recordedTime := time.Unix(0, 2000)
+
+	domainCache.On("GetDomainID", "test-domain").Return("test-domain-id", nil)
+	postCloseEventsMgr.On("ListPostCloseEvents", &persistence.ListPostCloseEventsRequest{
+		DomainID:  "test-domain-id",
+		Execution: execution,
+		PageSize:  defaultHistoryBatchesPageSize,
+	}).Return(&persistence.ListPostCloseEventsResponse{
+		Batches: []*persistence.PostCloseEventBatch{{
+			Version:       3,
+			FirstEventID:  10,
+			SourceCluster: "standby",
+			RecordedTime:  recordedTime,
+			Events:        events,
+		}},
+	}, nil).Once()
+
+	resp, err := adh.ListPostCloseEvents(context.Background(), &admin.ListPostCloseEventsRequest{
+		Domain:    common.StringPtr("test-domain"),
+		Execution: &execution,
+	})
+	s.NoError(err)
+	s.Nil(resp.NextPageToken)
+	s.Equal(1, len(resp.Batches))
+	s.Equal("standby", resp.Batches[0].GetSourceCluster())
+	s.Equal(int64(2000), resp.Batches[0].GetRecordedTimestamp())
+	s.Equal(signal.Events, resp.Batches[0].History.Events)
+
+	_, err = adh.ListPostCloseEvents(context.Background(), &admin.ListPostCloseEventsRequest{
+		Domain:    common.StringPtr("test-domain"),
+		Execution: &shared.WorkflowExecution{WorkflowId: common.StringPtr("wid")},
+	})
+	s.Equal(errRunIDNotSet, err)
+	postCloseEventsMgr.AssertExpectations(s.T())
+}
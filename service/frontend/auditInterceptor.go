@@ -0,0 +1,137 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"time"
+
+	"github.com/uber-common/bark"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/audit"
+	"github.com/uber/cadence/common/logging"
+	"go.uber.org/yarpc"
+)
+
+const (
+	// auditOperationFailoverDomain is recorded instead of UpdateDomain for updates changing the active cluster
+	auditOperationFailoverDomain = "FailoverDomain"
+)
+
+// auditedWorkflowAPIs are the mutating workflow service APIs recorded in the audit log,
+// every admin service API is recorded
+var auditedWorkflowAPIs = map[string]bool{
+	"RegisterDomain":                   true,
+	"UpdateDomain":                     true,
+	"DeprecateDomain":                  true,
+	"TerminateWorkflowExecution":       true,
+	"SignalWorkflowExecution":          true,
+	"SignalWithStartWorkflowExecution": true,
+	"RequestCancelWorkflowExecution":   true,
+	"CreateSchedule":                   true,
+	"UpdateSchedule":                   true,
+	"DeleteSchedule":                   true,
+}
+
+type (
+	// the accessors generated for the thrift requests, used to fill in the audit records
+	domainGetter interface {
+		GetDomain() string
+	}
+	nameGetter interface {
+		GetName() string
+	}
+	workflowIDGetter interface {
+		GetWorkflowId() string
+	}
+	workflowExecutionGetter interface {
+		GetWorkflowExecution() *gen.WorkflowExecution
+	}
+	executionGetter interface {
+		GetExecution() *gen.WorkflowExecution
+	}
+	identityGetter interface {
+		GetIdentity() string
+	}
+)
+
+// NewAuditInterceptor returns an interceptor recording the administrative and mutating
+// frontend APIs into the given sink, together with their outcome and the identity of the caller
+func NewAuditInterceptor(sink audit.Sink, logger bark.Logger) Interceptor {
+	return func(ctx context.Context, info *RequestInfo, request interface{}, handler RequestHandler) (interface{}, error) {
+		if info.Service != adminServiceName && !auditedWorkflowAPIs[info.Method] {
+			return handler(ctx, request)
+		}
+
+		resp, err := handler(ctx, request)
+
+		record := newAuditRecord(ctx, info, request)
+		if err != nil {
+			record.Error = err.Error()
+		}
+		if writeErr := sink.Write(record); writeErr != nil {
+			logger.WithFields(bark.Fields{
+				logging.TagErr: writeErr,
+			}).Errorf("Failed to write audit record of %v.", info.Method)
+		}
+		return resp, err
+	}
+}
+
+func newAuditRecord(ctx context.Context, info *RequestInfo, request interface{}) *audit.Record {
+	record := &audit.Record{
+		Timestamp: time.Now(),
+		Service:   info.Service,
+		Operation: info.Method,
+		Request:   audit.RedactRequest(request),
+	}
+	if call := yarpc.CallFromContext(ctx); call != nil {
+		record.Caller = call.Caller()
+	}
+
+	if r, ok := request.(domainGetter); ok {
+		record.Domain = r.GetDomain()
+	} else if r, ok := request.(nameGetter); ok {
+		record.Domain = r.GetName()
+	}
+	if r, ok := request.(identityGetter); ok {
+		record.Identity = r.GetIdentity()
+	}
+
+	var execution *gen.WorkflowExecution
+	if r, ok := request.(workflowExecutionGetter); ok {
+		execution = r.GetWorkflowExecution()
+	} else if r, ok := request.(executionGetter); ok {
+		execution = r.GetExecution()
+	}
+	if execution != nil {
+		record.WorkflowID = execution.GetWorkflowId()
+		record.RunID = execution.GetRunId()
+	} else if r, ok := request.(workflowIDGetter); ok {
+		record.WorkflowID = r.GetWorkflowId()
+	}
+
+	if r, ok := request.(*gen.UpdateDomainRequest); ok &&
+		r.ReplicationConfiguration != nil && r.ReplicationConfiguration.ActiveClusterName != nil {
+		record.Operation = auditOperationFailoverDomain
+	}
+	return record
+}
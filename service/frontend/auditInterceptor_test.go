@@ -0,0 +1,133 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/admin"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/audit"
+)
+
+type (
+	auditInterceptorSuite struct {
+		suite.Suite
+		sink        *memoryAuditSink
+		interceptor Interceptor
+	}
+
+	memoryAuditSink struct {
+		records []*audit.Record
+	}
+)
+
+func TestAuditInterceptorSuite(t *testing.T) {
+	s := new(auditInterceptorSuite)
+	suite.Run(t, s)
+}
+
+func (s *memoryAuditSink) Write(record *audit.Record) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *memoryAuditSink) Close() error {
+	return nil
+}
+
+func (s *auditInterceptorSuite) SetupTest() {
+	s.sink = &memoryAuditSink{}
+	s.interceptor = NewAuditInterceptor(s.sink, bark.NewNopLogger())
+}
+
+func (s *auditInterceptorSuite) call(service, method string, request interface{}, err error) {
+	info := &RequestInfo{Service: service, Method: method}
+	_, callErr := s.interceptor(context.Background(), info, request, func(ctx context.Context, request interface{}) (interface{}, error) {
+		return nil, err
+	})
+	s.Equal(err, callErr)
+}
+
+func (s *auditInterceptorSuite) TestMutatingWorkflowAPI() {
+	request := &shared.TerminateWorkflowExecutionRequest{
+		Domain: common.StringPtr("domain"),
+		WorkflowExecution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr("wid"),
+			RunId:      common.StringPtr("rid"),
+		},
+		Identity: common.StringPtr("operator"),
+	}
+	s.call(workflowServiceName, "TerminateWorkflowExecution", request, errors.New("not found"))
+
+	s.Len(s.sink.records, 1)
+	record := s.sink.records[0]
+	s.Equal(workflowServiceName, record.Service)
+	s.Equal("TerminateWorkflowExecution", record.Operation)
+	s.Equal("domain", record.Domain)
+	s.Equal("wid", record.WorkflowID)
+	s.Equal("rid", record.RunID)
+	s.Equal("operator", record.Identity)
+	s.Equal("not found", record.Error)
+	s.Equal(audit.RedactRequest(request), record.Request)
+}
+
+func (s *auditInterceptorSuite) TestReadOnlyWorkflowAPINotAudited() {
+	s.call(workflowServiceName, "DescribeWorkflowExecution", &shared.DescribeWorkflowExecutionRequest{}, nil)
+	s.Empty(s.sink.records)
+}
+
+func (s *auditInterceptorSuite) TestDomainFailover() {
+	s.call(workflowServiceName, "UpdateDomain", &shared.UpdateDomainRequest{
+		Name:        common.StringPtr("domain"),
+		UpdatedInfo: &shared.UpdateDomainInfo{Description: common.StringPtr("desc")},
+	}, nil)
+	s.call(workflowServiceName, "UpdateDomain", &shared.UpdateDomainRequest{
+		Name: common.StringPtr("domain"),
+		ReplicationConfiguration: &shared.DomainReplicationConfiguration{
+			ActiveClusterName: common.StringPtr("standby"),
+		},
+	}, nil)
+
+	s.Len(s.sink.records, 2)
+	s.Equal("UpdateDomain", s.sink.records[0].Operation)
+	s.Equal("domain", s.sink.records[0].Domain)
+	s.Equal(auditOperationFailoverDomain, s.sink.records[1].Operation)
+	s.Equal("domain", s.sink.records[1].Domain)
+}
+
+func (s *auditInterceptorSuite) TestAdminAPI() {
+	s.call(adminServiceName, "DeleteWorkflowExecution", &admin.DeleteWorkflowExecutionRequest{
+		Domain:    common.StringPtr("domain"),
+		Execution: &shared.WorkflowExecution{WorkflowId: common.StringPtr("wid")},
+	}, nil)
+
+	s.Len(s.sink.records, 1)
+	s.Equal(adminServiceName, s.sink.records[0].Service)
+	s.Equal("domain", s.sink.records[0].Domain)
+	s.Equal("wid", s.sink.records[0].WorkflowID)
+	s.Empty(s.sink.records[0].Error)
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"go.uber.org/thriftrw/protocol"
+)
+
+const decisionChunkBufferMaxSize = 10000
+
+type (
+	// decisionChunkBuffer reassembles a decision task completion split in several RespondDecisionTaskCompleted
+	// calls. Chunks are buffered per task token on the frontend host owning the token on the frontend ring, see
+	// decisionChunkRouter. The encoded size of the buffered chunks is bounded by maxBytes.
+	decisionChunkBuffer struct {
+		completions cache.Cache
+		maxChunks   dynamicconfig.IntPropertyFn
+		maxBytes    dynamicconfig.IntPropertyFn
+		bytes       int64
+	}
+
+	decisionChunks struct {
+		sync.Mutex
+		requests []*gen.RespondDecisionTaskCompletedRequest
+		sizes    []int64
+		bytes    int64
+		removed  bool
+	}
+
+	byteCounter struct {
+		count int64
+	}
+)
+
+var (
+	errInvalidDecisionChunk    = &gen.BadRequestError{Message: "Invalid ChunkIndex or ChunkCount."}
+	errDecisionChunksMissing   = &gen.BadRequestError{Message: "Decision task completion is missing chunks, they expired or were sent to another host."}
+	errDecisionChunkBufferFull = &gen.ServiceBusyError{Message: "Too many decision chunks buffered, retry later."}
+)
+
+func newDecisionChunkBuffer(maxChunks dynamicconfig.IntPropertyFn, maxBytes dynamicconfig.IntPropertyFn,
+	timeout time.Duration) *decisionChunkBuffer {
+	b := &decisionChunkBuffer{
+		maxChunks: maxChunks,
+		maxBytes:  maxBytes,
+	}
+	b.completions = cache.New(decisionChunkBufferMaxSize, &cache.Options{
+		TTL:         timeout,
+		RemovedFunc: b.onRemoved,
+	})
+	return b
+}
+
+// add buffers a chunk of a decision task completion. The completion is returned once its last chunk is
+// added, nil is returned for the chunks before.
+func (b *decisionChunkBuffer) add(
+	request *gen.RespondDecisionTaskCompletedRequest) (*gen.RespondDecisionTaskCompletedRequest, error) {
+	count := int(request.GetChunkCount())
+	index := int(request.GetChunkIndex())
+	if count > b.maxChunks() || index < 0 || index >= count {
+		return nil, errInvalidDecisionChunk
+	}
+	size, err := encodedSize(request)
+	if err != nil {
+		return nil, err
+	}
+
+	key := string(request.TaskToken)
+	value, err := b.completions.PutIfNotExist(key, &decisionChunks{
+		requests: make([]*gen.RespondDecisionTaskCompletedRequest, count),
+		sizes:    make([]int64, count),
+	})
+	if err != nil {
+		return nil, err
+	}
+	chunks := value.(*decisionChunks)
+
+	chunks.Lock()
+	defer chunks.Unlock()
+
+	if chunks.removed {
+		// expired or evicted between the lookup and the lock
+		return nil, errDecisionChunksMissing
+	}
+	if len(chunks.requests) != count {
+		b.remove(key, chunks)
+		return nil, errInvalidDecisionChunk
+	}
+	if index < count-1 {
+		// the last chunk completes the buffered ones so it is never rejected
+		delta := size - chunks.sizes[index]
+		if delta > 0 && atomic.LoadInt64(&b.bytes)+delta > int64(b.maxBytes()) {
+			b.completions.EvictExpired()
+			if atomic.LoadInt64(&b.bytes)+delta > int64(b.maxBytes()) {
+				return nil, errDecisionChunkBufferFull
+			}
+		}
+		chunks.requests[index] = request
+		chunks.sizes[index] = size
+		chunks.bytes += delta
+		atomic.AddInt64(&b.bytes, delta)
+		return nil, nil
+	}
+
+	chunks.requests[index] = request
+	b.remove(key, chunks)
+	return chunks.assemble()
+}
+
+// remove drops the chunks from the buffer and releases their bytes, the lock of the chunks must be held
+func (b *decisionChunkBuffer) remove(key string, chunks *decisionChunks) {
+	b.release(chunks)
+	b.completions.Delete(key)
+}
+
+// onRemoved releases the bytes of chunks which expired or were evicted from the cache
+func (b *decisionChunkBuffer) onRemoved(value interface{}) {
+	chunks := value.(*decisionChunks)
+	chunks.Lock()
+	defer chunks.Unlock()
+	b.release(chunks)
+}
+
+// release gives the bytes of the chunks back to the buffer once, the lock of the chunks must be held
+func (b *decisionChunkBuffer) release(chunks *decisionChunks) {
+	if chunks.removed {
+		return
+	}
+	chunks.removed = true
+	atomic.AddInt64(&b.bytes, -chunks.bytes)
+	chunks.bytes = 0
+}
+
+// assemble concatenates the decisions of all chunks into the last one, the lock must be held
+func (c *decisionChunks) assemble() (*gen.RespondDecisionTaskCompletedRequest, error) {
+	var decisions []*gen.Decision
+	for _, request := range c.requests {
+		if request == nil {
+			return nil, errDecisionChunksMissing
+		}
+		decisions = append(decisions, request.Decisions...)
+	}
+
+	completion := *c.requests[len(c.requests)-1]
+	completion.Decisions = decisions
+	completion.ChunkIndex = nil
+	completion.ChunkCount = nil
+	return &completion, nil
+}
+
+// encodedSize is the size of the request encoded with the thrift binary protocol
+func encodedSize(request *gen.RespondDecisionTaskCompletedRequest) (int64, error) {
+	value, err := request.ToWire()
+	if err != nil {
+		return 0, err
+	}
+	counter := &byteCounter{}
+	if err := protocol.Binary.Encode(value, counter); err != nil {
+		return 0, err
+	}
+	return counter.count, nil
+}
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	c.count += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+func newDecisionChunk(token string, index, count int32, activityID string) *gen.RespondDecisionTaskCompletedRequest {
+	decisionType := gen.DecisionTypeScheduleActivityTask
+	return &gen.RespondDecisionTaskCompletedRequest{
+		TaskToken: []byte(token),
+		Decisions: []*gen.Decision{{
+			DecisionType: &decisionType,
+			ScheduleActivityTaskDecisionAttributes: &gen.ScheduleActivityTaskDecisionAttributes{
+				ActivityId: common.StringPtr(activityID),
+			},
+		}},
+		Identity:   common.StringPtr(activityID),
+		ChunkIndex: common.Int32Ptr(index),
+		ChunkCount: common.Int32Ptr(count),
+	}
+}
+
+func TestDecisionChunkBuffer_Assemble(t *testing.T) {
+	buffer := newDecisionChunkBuffer(dynamicconfig.GetIntPropertyFn(10), dynamicconfig.GetIntPropertyFn(1024*1024), time.Minute)
+
+	completion, err := buffer.add(newDecisionChunk("token", 0, 3, "a0"))
+	assert.Nil(t, err)
+	assert.Nil(t, completion)
+	completion, err = buffer.add(newDecisionChunk("other token", 0, 2, "b0"))
+	assert.Nil(t, err)
+	assert.Nil(t, completion)
+	completion, err = buffer.add(newDecisionChunk("token", 1, 3, "a1"))
+	assert.Nil(t, err)
+	assert.Nil(t, completion)
+
+	completion, err = buffer.add(newDecisionChunk("token", 2, 3, "a2"))
+	assert.Nil(t, err)
+	assert.NotNil(t, completion)
+	assert.Equal(t, 3, len(completion.Decisions))
+	for i, activityID := range []string{"a0", "a1", "a2"} {
+		assert.Equal(t, activityID, completion.Decisions[i].ScheduleActivityTaskDecisionAttributes.GetActivityId())
+	}
+	assert.Equal(t, "a2", completion.GetIdentity())
+	assert.Nil(t, completion.ChunkIndex)
+	assert.Nil(t, completion.ChunkCount)
+	assert.Equal(t, 1, buffer.completions.Size())
+}
+
+func TestDecisionChunkBuffer_MissingChunk(t *testing.T) {
+	buffer := newDecisionChunkBuffer(dynamicconfig.GetIntPropertyFn(10), dynamicconfig.GetIntPropertyFn(1024*1024), time.Minute)
+
+	completion, err := buffer.add(newDecisionChunk("token", 0, 3, "a0"))
+	assert.Nil(t, err)
+	assert.Nil(t, completion)
+
+	completion, err = buffer.add(newDecisionChunk("token", 2, 3, "a2"))
+	assert.Equal(t, errDecisionChunksMissing, err)
+	assert.Nil(t, completion)
+	assert.Equal(t, 0, buffer.completions.Size())
+	assert.Equal(t, int64(0), atomic.LoadInt64(&buffer.bytes))
+}
+
+func TestDecisionChunkBuffer_InvalidChunk(t *testing.T) {
+	buffer := newDecisionChunkBuffer(dynamicconfig.GetIntPropertyFn(3), dynamicconfig.GetIntPropertyFn(1024*1024), time.Minute)
+
+	_, err := buffer.add(newDecisionChunk("token", 0, 4, "a0"))
+	assert.Equal(t, errInvalidDecisionChunk, err)
+	_, err = buffer.add(newDecisionChunk("token", 2, 2, "a0"))
+	assert.Equal(t, errInvalidDecisionChunk, err)
+
+	_, err = buffer.add(newDecisionChunk("token", 0, 3, "a0"))
+	assert.Nil(t, err)
+	_, err = buffer.add(newDecisionChunk("token", 1, 2, "a1"))
+	assert.Equal(t, errInvalidDecisionChunk, err)
+	assert.Equal(t, 0, buffer.completions.Size())
+	assert.Equal(t, int64(0), atomic.LoadInt64(&buffer.bytes))
+}
+
+func TestDecisionChunkBuffer_MaxBytes(t *testing.T) {
+	size, err := encodedSize(newDecisionChunk("token", 0, 3, "a0"))
+	assert.Nil(t, err)
+	buffer := newDecisionChunkBuffer(dynamicconfig.GetIntPropertyFn(10), dynamicconfig.GetIntPropertyFn(int(2*size)),
+		time.Minute)
+
+	_, err = buffer.add(newDecisionChunk("token", 0, 3, "a0"))
+	assert.Nil(t, err)
+	_, err = buffer.add(newDecisionChunk("other", 0, 3, "b0"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2*size, atomic.LoadInt64(&buffer.bytes))
+
+	// a chunk above the budget is rejected, re-sending a buffered one is not
+	_, err = buffer.add(newDecisionChunk("token", 1, 3, "a1"))
+	assert.Equal(t, errDecisionChunkBufferFull, err)
+	_, err = buffer.add(newDecisionChunk("token", 0, 3, "a0"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2*size, atomic.LoadInt64(&buffer.bytes))
+
+	// the last chunk is accepted and releases the bytes of the completion
+	_, err = buffer.add(newDecisionChunk("other", 1, 3, "b1"))
+	assert.Equal(t, errDecisionChunkBufferFull, err)
+	_, err = buffer.add(newDecisionChunk("other", 2, 3, "b2"))
+	assert.Equal(t, errDecisionChunksMissing, err)
+	assert.Equal(t, size, atomic.LoadInt64(&buffer.bytes))
+
+	_, err = buffer.add(newDecisionChunk("token", 1, 3, "a1"))
+	assert.Nil(t, err)
+	completion, err := buffer.add(newDecisionChunk("token", 2, 3, "a2"))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(completion.Decisions))
+	assert.Equal(t, int64(0), atomic.LoadInt64(&buffer.bytes))
+}
+
+func TestDecisionChunkBuffer_ExpiredChunksReleased(t *testing.T) {
+	buffer := newDecisionChunkBuffer(dynamicconfig.GetIntPropertyFn(10), dynamicconfig.GetIntPropertyFn(1024*1024),
+		10*time.Millisecond)
+
+	_, err := buffer.add(newDecisionChunk("token", 0, 3, "a0"))
+	assert.Nil(t, err)
+	assert.True(t, atomic.LoadInt64(&buffer.bytes) > 0)
+
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 1, buffer.completions.EvictExpired())
+	// the bytes are released asynchronously by the cache
+	for i := 0; i < 100 && atomic.LoadInt64(&buffer.bytes) != 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, int64(0), atomic.LoadInt64(&buffer.bytes))
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+
+	"github.com/uber-common/bark"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"go.uber.org/yarpc"
+)
+
+// decisionChunkForwardedHeaderName marks a decision chunk forwarded by another frontend host, so it is buffered
+// locally even when the frontend ring changed in between
+const decisionChunkForwardedHeaderName = "cadence-decision-chunk-forwarded"
+
+type (
+	// decisionChunkRouter sends the chunks of a decision task completion to the frontend host owning the task
+	// token on the frontend ring, so all the chunks of a completion are buffered by a single host whichever
+	// host the client sent them to.
+	decisionChunkRouter struct {
+		peers         *frontendPeers
+		metricsClient metrics.Client
+		logger        bark.Logger
+	}
+)
+
+func newDecisionChunkRouter(resolver membership.ServiceResolver, self *membership.HostInfo,
+	clientFactory client.Factory, metricsClient metrics.Client, logger bark.Logger) *decisionChunkRouter {
+	return &decisionChunkRouter{
+		peers:         newFrontendPeers(resolver, self, clientFactory),
+		metricsClient: metricsClient,
+		logger:        logger,
+	}
+}
+
+// forward sends the chunk to the frontend host owning its task token. The returned bool is false when the
+// chunk has to be buffered by this host: this host owns the task token, the chunk was already forwarded, or
+// the owner could not be reached.
+func (r *decisionChunkRouter) forward(ctx context.Context,
+	request *gen.RespondDecisionTaskCompletedRequest) (*gen.RespondDecisionTaskCompletedResponse, bool, error) {
+	if r == nil || isForwardedRequest(ctx, decisionChunkForwardedHeaderName) {
+		return nil, false, nil
+	}
+
+	owner, client, ok := r.peers.owner(string(request.TaskToken))
+	if !ok {
+		return nil, false, nil
+	}
+
+	opts := append(common.AggregateYarpcOptions(ctx), yarpc.WithHeader(decisionChunkForwardedHeaderName, "true"))
+	response, err := client.RespondDecisionTaskCompleted(ctx, request, opts...)
+	if err != nil && !isForwardedResult(err) {
+		r.logger.WithFields(bark.Fields{
+			logging.TagErr: err,
+		}).Warnf("Failed to forward decision chunk to %v, buffering it locally.", owner.GetAddress())
+		return nil, false, nil
+	}
+	r.metricsClient.IncCounter(metrics.FrontendRespondDecisionTaskCompletedScope, metrics.DecisionChunkForwardedCounter)
+	return response, true, err
+}
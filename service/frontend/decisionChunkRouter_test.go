@@ -0,0 +1,51 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+)
+
+func TestDecisionChunkRouter_BufferedLocally(t *testing.T) {
+	resolver := &mocks.ServiceResolver{}
+	resolver.On("Lookup", "token").Return(membership.NewHostInfo("self:7933", nil), nil)
+	router := newDecisionChunkRouter(resolver, membership.NewHostInfo("self:7933", nil), nil,
+		metrics.NewClient(tally.NoopScope, metrics.Frontend), bark.NewNopLogger())
+
+	// this host owns the task token
+	_, forwarded, err := router.forward(context.Background(), newDecisionChunk("token", 0, 2, "a0"))
+	assert.False(t, forwarded)
+	assert.Nil(t, err)
+	resolver.AssertCalled(t, "Lookup", "token")
+
+	var nilRouter *decisionChunkRouter
+	_, forwarded, err = nilRouter.forward(context.Background(), newDecisionChunk("token", 0, 2, "a0"))
+	assert.False(t, forwarded)
+	assert.Nil(t, err)
+}
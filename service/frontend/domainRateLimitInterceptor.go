@@ -0,0 +1,52 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"fmt"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/quotas"
+)
+
+// frontendDomainLimiterPrefix namespaces the frontend domain limits in the history aggregators
+const frontendDomainLimiterPrefix = "frontend.domain:"
+
+// newDomainRateLimitInterceptor returns an interceptor rejecting the workflow API calls of a domain
+// once the share of its global rate limit given to this host is used up
+func newDomainRateLimitInterceptor(limiters *quotas.GlobalLimiters) Interceptor {
+	return func(ctx context.Context, info *RequestInfo, request interface{}, handler RequestHandler) (interface{}, error) {
+		if info.Service != workflowServiceName {
+			return handler(ctx, request)
+		}
+		r, ok := request.(domainGetter)
+		if !ok || r.GetDomain() == "" {
+			return handler(ctx, request)
+		}
+		if !limiters.Allow(r.GetDomain()) {
+			return nil, &gen.ServiceBusyError{
+				Message: fmt.Sprintf("Domain %v is over its global rate limit.", r.GetDomain()),
+			}
+		}
+		return handler(ctx, request)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/quotas"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+func TestDomainRateLimitInterceptor(t *testing.T) {
+	limiters := quotas.NewGlobalLimiters(
+		frontendDomainLimiterPrefix,
+		func(domain string) float64 {
+			if domain == "limited" {
+				return 1
+			}
+			return 0
+		},
+		dynamicconfig.GetDurationPropertyFn(time.Second),
+		bark.NewNopLogger(),
+	)
+	interceptor := newDomainRateLimitInterceptor(limiters)
+	call := func(service string, request interface{}) error {
+		info := &RequestInfo{Service: service, Method: "DescribeWorkflowExecution"}
+		_, err := interceptor(context.Background(), info, request, func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+	request := func(domain string) interface{} {
+		return &shared.DescribeWorkflowExecutionRequest{Domain: common.StringPtr(domain)}
+	}
+
+	assert.NoError(t, call(workflowServiceName, request("limited")))
+	assert.IsType(t, &shared.ServiceBusyError{}, call(workflowServiceName, request("limited")))
+	assert.NoError(t, call(adminServiceName, request("limited")))
+	assert.NoError(t, call(workflowServiceName, request("unlimited")))
+	assert.NoError(t, call(workflowServiceName, request("unlimited")))
+}
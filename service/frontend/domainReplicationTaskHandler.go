@@ -78,14 +78,18 @@ func (domainReplicator *domainReplicatorImpl) HandleTransmissionTask(domainOpera
 			Status:      status,
 			Description: common.StringPtr(info.Description),
 			OwnerEmail:  common.StringPtr(info.OwnerEmail),
+			Data:        info.Data,
 		},
 		Config: &shared.DomainConfiguration{
 			WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(config.Retention),
 			EmitMetric:                             common.BoolPtr(config.EmitMetric),
+			HistoryEncoding:                        common.EncodingTypeToThrift(config.HistoryEncoding),
+			SuspectBinaryChecksums:                 config.SuspectBinaryChecksums,
 		},
 		ReplicationConfig: &shared.DomainReplicationConfiguration{
-			ActiveClusterName: common.StringPtr(replicationConfig.ActiveClusterName),
-			Clusters:          domainReplicator.convertClusterReplicationConfigToThrift(replicationConfig.Clusters),
+			ActiveClusterName:        common.StringPtr(replicationConfig.ActiveClusterName),
+			Clusters:                 domainReplicator.convertClusterReplicationConfigToThrift(replicationConfig.Clusters),
+			FailoverDrillClusterName: common.StringPtr(replicationConfig.FailoverDrillClusterName),
 		},
 		ConfigVersion:   common.Int64Ptr(configVersion),
 		FailoverVersion: common.Int64Ptr(failoverVersion),
@@ -78,6 +78,7 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_RegisterDomainTask()
 	status := shared.DomainStatusRegistered
 	description := "some random test description"
 	ownerEmail := "some random test owner"
+	data := map[string]string{"k": "v"}
 	retention := int32(10)
 	emitMetric := true
 	clusterActive := "some random active cluster name"
@@ -100,6 +101,7 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_RegisterDomainTask()
 		Status:      persistence.DomainStatusRegistered,
 		Description: description,
 		OwnerEmail:  ownerEmail,
+		Data:        data,
 	}
 	config := &persistence.DomainConfig{
 		Retention:  retention,
@@ -120,14 +122,16 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_RegisterDomainTask()
 				Status:      &status,
 				Description: common.StringPtr(description),
 				OwnerEmail:  common.StringPtr(ownerEmail),
+				Data:        data,
 			},
 			Config: &shared.DomainConfiguration{
 				WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(retention),
 				EmitMetric:                             common.BoolPtr(emitMetric),
 			},
 			ReplicationConfig: &shared.DomainReplicationConfiguration{
-				ActiveClusterName: common.StringPtr(clusterActive),
-				Clusters:          s.domainReplicator.convertClusterReplicationConfigToThrift(clusters),
+				ActiveClusterName:        common.StringPtr(clusterActive),
+				Clusters:                 s.domainReplicator.convertClusterReplicationConfigToThrift(clusters),
+				FailoverDrillClusterName: common.StringPtr(""),
 			},
 			ConfigVersion:   common.Int64Ptr(configVersion),
 			FailoverVersion: common.Int64Ptr(failoverVersion),
@@ -145,6 +149,7 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_UpdateDomainTask() {
 	status := shared.DomainStatusDeprecated
 	description := "some random test description"
 	ownerEmail := "some random test owner"
+	data := map[string]string{"k": "v"}
 	retention := int32(10)
 	emitMetric := true
 	clusterActive := "some random active cluster name"
@@ -167,14 +172,16 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_UpdateDomainTask() {
 		Status:      persistence.DomainStatusDeprecated,
 		Description: description,
 		OwnerEmail:  ownerEmail,
+		Data:        data,
 	}
 	config := &persistence.DomainConfig{
 		Retention:  retention,
 		EmitMetric: emitMetric,
 	}
 	replicationConfig := &persistence.DomainReplicationConfig{
-		ActiveClusterName: clusterActive,
-		Clusters:          clusters,
+		ActiveClusterName:        clusterActive,
+		Clusters:                 clusters,
+		FailoverDrillClusterName: clusterStandby,
 	}
 
 	s.kafkaProducer.On("Publish", &replicator.ReplicationTask{
@@ -187,14 +194,16 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_UpdateDomainTask() {
 				Status:      &status,
 				Description: common.StringPtr(description),
 				OwnerEmail:  common.StringPtr(ownerEmail),
+				Data:        data,
 			},
 			Config: &shared.DomainConfiguration{
 				WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(retention),
 				EmitMetric:                             common.BoolPtr(emitMetric),
 			},
 			ReplicationConfig: &shared.DomainReplicationConfiguration{
-				ActiveClusterName: common.StringPtr(clusterActive),
-				Clusters:          s.domainReplicator.convertClusterReplicationConfigToThrift(clusters),
+				ActiveClusterName:        common.StringPtr(clusterActive),
+				Clusters:                 s.domainReplicator.convertClusterReplicationConfigToThrift(clusters),
+				FailoverDrillClusterName: common.StringPtr(clusterStandby),
 			},
 			ConfigVersion:   common.Int64Ptr(configVersion),
 			FailoverVersion: common.Int64Ptr(failoverVersion),
@@ -0,0 +1,204 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+// executionGraphBuilder folds the history events of a workflow execution into the nodes of its execution
+// graph. Nodes are keyed by the ID of the event which created them, which the later events of the node
+// refer to as their scheduled, started or initiated event ID.
+type executionGraphBuilder struct {
+	response *gen.GetWorkflowExecutionGraphResponse
+	nodes    map[int64]*gen.ExecutionGraphNode
+	// activities holds the last activity node scheduled with an activity ID, the ID of a closed activity
+	// can be reused
+	activities map[string]*gen.ExecutionGraphNode
+}
+
+func newExecutionGraphBuilder(execution *gen.WorkflowExecution,
+	workflowType *gen.WorkflowType) *executionGraphBuilder {
+	return &executionGraphBuilder{
+		response: &gen.GetWorkflowExecutionGraphResponse{
+			Execution:    execution,
+			WorkflowType: workflowType,
+			Nodes:        []*gen.ExecutionGraphNode{},
+		},
+		nodes:      make(map[int64]*gen.ExecutionGraphNode),
+		activities: make(map[string]*gen.ExecutionGraphNode),
+	}
+}
+
+func (b *executionGraphBuilder) applyEvents(events []*gen.HistoryEvent) {
+	for _, event := range events {
+		b.applyEvent(event)
+	}
+}
+
+func (b *executionGraphBuilder) applyEvent(event *gen.HistoryEvent) {
+	timestamp := event.Timestamp
+	switch event.GetEventType() {
+	case gen.EventTypeWorkflowExecutionStarted:
+		b.response.StartTimestamp = timestamp
+	case gen.EventTypeWorkflowExecutionCompleted:
+		b.closeExecution(gen.WorkflowExecutionCloseStatusCompleted, timestamp)
+	case gen.EventTypeWorkflowExecutionFailed:
+		b.closeExecution(gen.WorkflowExecutionCloseStatusFailed, timestamp)
+	case gen.EventTypeWorkflowExecutionTimedOut:
+		b.closeExecution(gen.WorkflowExecutionCloseStatusTimedOut, timestamp)
+	case gen.EventTypeWorkflowExecutionCanceled:
+		b.closeExecution(gen.WorkflowExecutionCloseStatusCanceled, timestamp)
+	case gen.EventTypeWorkflowExecutionTerminated:
+		b.closeExecution(gen.WorkflowExecutionCloseStatusTerminated, timestamp)
+	case gen.EventTypeWorkflowExecutionContinuedAsNew:
+		b.closeExecution(gen.WorkflowExecutionCloseStatusContinuedAsNew, timestamp)
+
+	case gen.EventTypeActivityTaskScheduled:
+		attributes := event.ActivityTaskScheduledEventAttributes
+		node := b.addNode(event, gen.ExecutionGraphNodeTypeActivity, attributes.DecisionTaskCompletedEventId)
+		node.ID = attributes.ActivityId
+		node.Name = attributes.ActivityType.Name
+		b.activities[attributes.GetActivityId()] = node
+	case gen.EventTypeActivityTaskStarted:
+		attributes := event.ActivityTaskStartedEventAttributes
+		if node := b.startNode(attributes.GetScheduledEventId(), timestamp); node != nil {
+			node.Attempt = attributes.Attempt
+		}
+	case gen.EventTypeActivityTaskCompleted:
+		b.closeNode(event.ActivityTaskCompletedEventAttributes.GetScheduledEventId(),
+			gen.ExecutionGraphNodeStateCompleted, timestamp)
+	case gen.EventTypeActivityTaskFailed:
+		b.closeNode(event.ActivityTaskFailedEventAttributes.GetScheduledEventId(),
+			gen.ExecutionGraphNodeStateFailed, timestamp)
+	case gen.EventTypeActivityTaskTimedOut:
+		b.closeNode(event.ActivityTaskTimedOutEventAttributes.GetScheduledEventId(),
+			gen.ExecutionGraphNodeStateTimedOut, timestamp)
+	case gen.EventTypeActivityTaskCanceled:
+		b.closeNode(event.ActivityTaskCanceledEventAttributes.GetScheduledEventId(),
+			gen.ExecutionGraphNodeStateCanceled, timestamp)
+
+	case gen.EventTypeTimerStarted:
+		attributes := event.TimerStartedEventAttributes
+		node := b.addNode(event, gen.ExecutionGraphNodeTypeTimer, attributes.DecisionTaskCompletedEventId)
+		node.ID = attributes.TimerId
+		b.startNode(event.GetEventId(), timestamp)
+	case gen.EventTypeTimerFired:
+		b.closeNode(event.TimerFiredEventAttributes.GetStartedEventId(),
+			gen.ExecutionGraphNodeStateCompleted, timestamp)
+	case gen.EventTypeTimerCanceled:
+		b.closeNode(event.TimerCanceledEventAttributes.GetStartedEventId(),
+			gen.ExecutionGraphNodeStateCanceled, timestamp)
+
+	case gen.EventTypeStartChildWorkflowExecutionInitiated:
+		attributes := event.StartChildWorkflowExecutionInitiatedEventAttributes
+		node := b.addNode(event, gen.ExecutionGraphNodeTypeChildWorkflow, attributes.DecisionTaskCompletedEventId)
+		node.ID = attributes.WorkflowId
+		node.Name = attributes.WorkflowType.Name
+	case gen.EventTypeStartChildWorkflowExecutionFailed:
+		b.closeNode(event.StartChildWorkflowExecutionFailedEventAttributes.GetInitiatedEventId(),
+			gen.ExecutionGraphNodeStateFailed, timestamp)
+	case gen.EventTypeChildWorkflowExecutionStarted:
+		attributes := event.ChildWorkflowExecutionStartedEventAttributes
+		if node := b.startNode(attributes.GetInitiatedEventId(), timestamp); node != nil {
+			node.ChildRunId = attributes.WorkflowExecution.RunId
+		}
+	case gen.EventTypeChildWorkflowExecutionCompleted:
+		b.closeNode(event.ChildWorkflowExecutionCompletedEventAttributes.GetInitiatedEventId(),
+			gen.ExecutionGraphNodeStateCompleted, timestamp)
+	case gen.EventTypeChildWorkflowExecutionFailed:
+		b.closeNode(event.ChildWorkflowExecutionFailedEventAttributes.GetInitiatedEventId(),
+			gen.ExecutionGraphNodeStateFailed, timestamp)
+	case gen.EventTypeChildWorkflowExecutionTimedOut:
+		b.closeNode(event.ChildWorkflowExecutionTimedOutEventAttributes.GetInitiatedEventId(),
+			gen.ExecutionGraphNodeStateTimedOut, timestamp)
+	case gen.EventTypeChildWorkflowExecutionCanceled:
+		b.closeNode(event.ChildWorkflowExecutionCanceledEventAttributes.GetInitiatedEventId(),
+			gen.ExecutionGraphNodeStateCanceled, timestamp)
+	case gen.EventTypeChildWorkflowExecutionTerminated:
+		b.closeNode(event.ChildWorkflowExecutionTerminatedEventAttributes.GetInitiatedEventId(),
+			gen.ExecutionGraphNodeStateTerminated, timestamp)
+
+	case gen.EventTypeWorkflowExecutionSignaled:
+		// a signal is received at once, so it is closed as soon as it is created
+		node := b.addNode(event, gen.ExecutionGraphNodeTypeSignal, nil)
+		node.ID = event.WorkflowExecutionSignaledEventAttributes.SignalName
+		b.closeNode(event.GetEventId(), gen.ExecutionGraphNodeStateCompleted, timestamp)
+	}
+}
+
+// applyPendingActivities marks the pending activities of the mutable state of the execution started which have
+// no started event in its history yet
+func (b *executionGraphBuilder) applyPendingActivities(activities []*gen.PendingActivityInfo) {
+	for _, activity := range activities {
+		if activity.GetState() != gen.PendingActivityStateStarted {
+			continue
+		}
+		node, ok := b.activities[activity.GetActivityID()]
+		if ok && node.GetState() == gen.ExecutionGraphNodeStateScheduled {
+			node.State = gen.ExecutionGraphNodeStateStarted.Ptr()
+		}
+	}
+}
+
+func (b *executionGraphBuilder) build() *gen.GetWorkflowExecutionGraphResponse {
+	return b.response
+}
+
+func (b *executionGraphBuilder) closeExecution(status gen.WorkflowExecutionCloseStatus, timestamp *int64) {
+	b.response.CloseStatus = status.Ptr()
+	b.response.CloseTimestamp = timestamp
+}
+
+func (b *executionGraphBuilder) addNode(event *gen.HistoryEvent, nodeType gen.ExecutionGraphNodeType,
+	decisionTaskCompletedEventID *int64) *gen.ExecutionGraphNode {
+	node := &gen.ExecutionGraphNode{
+		Type:                         nodeType.Ptr(),
+		State:                        gen.ExecutionGraphNodeStateScheduled.Ptr(),
+		InitiatedEventId:             common.Int64Ptr(event.GetEventId()),
+		DecisionTaskCompletedEventId: decisionTaskCompletedEventID,
+		ScheduledTimestamp:           event.Timestamp,
+	}
+	b.nodes[event.GetEventId()] = node
+	b.response.Nodes = append(b.response.Nodes, node)
+	return node
+}
+
+// startNode returns nil if the node was created before the events the graph is built from
+func (b *executionGraphBuilder) startNode(initiatedEventID int64, timestamp *int64) *gen.ExecutionGraphNode {
+	node, ok := b.nodes[initiatedEventID]
+	if !ok {
+		return nil
+	}
+	node.State = gen.ExecutionGraphNodeStateStarted.Ptr()
+	node.StartedTimestamp = timestamp
+	return node
+}
+
+func (b *executionGraphBuilder) closeNode(initiatedEventID int64, state gen.ExecutionGraphNodeState, timestamp *int64) {
+	node, ok := b.nodes[initiatedEventID]
+	if !ok {
+		return
+	}
+	node.State = state.Ptr()
+	node.CloseTimestamp = timestamp
+}
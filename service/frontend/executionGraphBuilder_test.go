@@ -0,0 +1,170 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+func newGraphTestEvent(eventID int64, eventType gen.EventType) *gen.HistoryEvent {
+	return &gen.HistoryEvent{
+		EventId:   common.Int64Ptr(eventID),
+		EventType: eventType.Ptr(),
+		Timestamp: common.Int64Ptr(eventID * 100),
+	}
+}
+
+func TestExecutionGraphBuilder(t *testing.T) {
+	execution := &gen.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr("rid")}
+	builder := newExecutionGraphBuilder(execution, &gen.WorkflowType{Name: common.StringPtr("workflow")})
+
+	started := newGraphTestEvent(1, gen.EventTypeWorkflowExecutionStarted)
+	activityScheduled := newGraphTestEvent(5, gen.EventTypeActivityTaskScheduled)
+	activityScheduled.ActivityTaskScheduledEventAttributes = &gen.ActivityTaskScheduledEventAttributes{
+		ActivityId:                   common.StringPtr("activity"),
+		ActivityType:                 &gen.ActivityType{Name: common.StringPtr("activity-type")},
+		DecisionTaskCompletedEventId: common.Int64Ptr(4),
+	}
+	timerStarted := newGraphTestEvent(6, gen.EventTypeTimerStarted)
+	timerStarted.TimerStartedEventAttributes = &gen.TimerStartedEventAttributes{
+		TimerId:                      common.StringPtr("timer"),
+		DecisionTaskCompletedEventId: common.Int64Ptr(4),
+	}
+	childInitiated := newGraphTestEvent(7, gen.EventTypeStartChildWorkflowExecutionInitiated)
+	childInitiated.StartChildWorkflowExecutionInitiatedEventAttributes = &gen.StartChildWorkflowExecutionInitiatedEventAttributes{
+		WorkflowId:                   common.StringPtr("child"),
+		WorkflowType:                 &gen.WorkflowType{Name: common.StringPtr("child-type")},
+		DecisionTaskCompletedEventId: common.Int64Ptr(4),
+	}
+	activityStarted := newGraphTestEvent(8, gen.EventTypeActivityTaskStarted)
+	activityStarted.ActivityTaskStartedEventAttributes = &gen.ActivityTaskStartedEventAttributes{
+		ScheduledEventId: common.Int64Ptr(5),
+		Attempt:          common.Int32Ptr(2),
+	}
+	activityFailed := newGraphTestEvent(9, gen.EventTypeActivityTaskFailed)
+	activityFailed.ActivityTaskFailedEventAttributes = &gen.ActivityTaskFailedEventAttributes{
+		ScheduledEventId: common.Int64Ptr(5),
+	}
+	childStarted := newGraphTestEvent(10, gen.EventTypeChildWorkflowExecutionStarted)
+	childStarted.ChildWorkflowExecutionStartedEventAttributes = &gen.ChildWorkflowExecutionStartedEventAttributes{
+		InitiatedEventId:  common.Int64Ptr(7),
+		WorkflowExecution: &gen.WorkflowExecution{WorkflowId: common.StringPtr("child"), RunId: common.StringPtr("child-rid")},
+	}
+	timerFired := newGraphTestEvent(11, gen.EventTypeTimerFired)
+	timerFired.TimerFiredEventAttributes = &gen.TimerFiredEventAttributes{StartedEventId: common.Int64Ptr(6)}
+	signaled := newGraphTestEvent(12, gen.EventTypeWorkflowExecutionSignaled)
+	signaled.WorkflowExecutionSignaledEventAttributes = &gen.WorkflowExecutionSignaledEventAttributes{
+		SignalName: common.StringPtr("signal"),
+	}
+
+	builder.applyEvents([]*gen.HistoryEvent{
+		started, activityScheduled, timerStarted, childInitiated, activityStarted, activityFailed, childStarted,
+	})
+	builder.applyEvents([]*gen.HistoryEvent{timerFired, signaled})
+	resp := builder.build()
+
+	assert.Equal(t, execution, resp.Execution)
+	assert.Equal(t, "workflow", resp.WorkflowType.GetName())
+	assert.Equal(t, int64(100), resp.GetStartTimestamp())
+	assert.Nil(t, resp.CloseStatus)
+	assert.Equal(t, 4, len(resp.Nodes))
+
+	activity := resp.Nodes[0]
+	assert.Equal(t, gen.ExecutionGraphNodeTypeActivity, activity.GetType())
+	assert.Equal(t, "activity", activity.GetID())
+	assert.Equal(t, "activity-type", activity.GetName())
+	assert.Equal(t, gen.ExecutionGraphNodeStateFailed, activity.GetState())
+	assert.Equal(t, int64(5), activity.GetInitiatedEventId())
+	assert.Equal(t, int64(4), activity.GetDecisionTaskCompletedEventId())
+	assert.Equal(t, int64(500), activity.GetScheduledTimestamp())
+	assert.Equal(t, int64(800), activity.GetStartedTimestamp())
+	assert.Equal(t, int64(900), activity.GetCloseTimestamp())
+	assert.Equal(t, int32(2), activity.GetAttempt())
+
+	timer := resp.Nodes[1]
+	assert.Equal(t, gen.ExecutionGraphNodeTypeTimer, timer.GetType())
+	assert.Equal(t, "timer", timer.GetID())
+	assert.Equal(t, gen.ExecutionGraphNodeStateCompleted, timer.GetState())
+	assert.Equal(t, int64(600), timer.GetStartedTimestamp())
+	assert.Equal(t, int64(1100), timer.GetCloseTimestamp())
+
+	child := resp.Nodes[2]
+	assert.Equal(t, gen.ExecutionGraphNodeTypeChildWorkflow, child.GetType())
+	assert.Equal(t, "child", child.GetID())
+	assert.Equal(t, "child-type", child.GetName())
+	assert.Equal(t, gen.ExecutionGraphNodeStateStarted, child.GetState())
+	assert.Equal(t, "child-rid", child.GetChildRunId())
+	assert.Nil(t, child.CloseTimestamp)
+
+	signal := resp.Nodes[3]
+	assert.Equal(t, gen.ExecutionGraphNodeTypeSignal, signal.GetType())
+	assert.Equal(t, "signal", signal.GetID())
+	assert.Equal(t, gen.ExecutionGraphNodeStateCompleted, signal.GetState())
+	assert.Nil(t, signal.DecisionTaskCompletedEventId)
+	assert.Equal(t, int64(1200), signal.GetCloseTimestamp())
+
+	completed := newGraphTestEvent(13, gen.EventTypeWorkflowExecutionCompleted)
+	builder.applyEvents([]*gen.HistoryEvent{completed})
+	resp = builder.build()
+	assert.Equal(t, gen.WorkflowExecutionCloseStatusCompleted, resp.GetCloseStatus())
+	assert.Equal(t, int64(1300), resp.GetCloseTimestamp())
+}
+
+func TestExecutionGraphBuilder_PendingActivities(t *testing.T) {
+	execution := &gen.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr("rid")}
+	builder := newExecutionGraphBuilder(execution, &gen.WorkflowType{Name: common.StringPtr("workflow")})
+
+	newActivityScheduled := func(eventID int64, activityID string) *gen.HistoryEvent {
+		event := newGraphTestEvent(eventID, gen.EventTypeActivityTaskScheduled)
+		event.ActivityTaskScheduledEventAttributes = &gen.ActivityTaskScheduledEventAttributes{
+			ActivityId:   common.StringPtr(activityID),
+			ActivityType: &gen.ActivityType{Name: common.StringPtr("activity-type")},
+		}
+		return event
+	}
+	firstRetried := newActivityScheduled(5, "retried")
+	firstRetriedCompleted := newGraphTestEvent(6, gen.EventTypeActivityTaskCompleted)
+	firstRetriedCompleted.ActivityTaskCompletedEventAttributes = &gen.ActivityTaskCompletedEventAttributes{
+		ScheduledEventId: common.Int64Ptr(5),
+	}
+	// the activity ID is reused once the first activity is closed
+	retried := newActivityScheduled(7, "retried")
+	scheduled := newActivityScheduled(8, "scheduled")
+
+	builder.applyEvents([]*gen.HistoryEvent{firstRetried, firstRetriedCompleted, retried, scheduled})
+	builder.applyPendingActivities([]*gen.PendingActivityInfo{
+		{ActivityID: common.StringPtr("retried"), State: gen.PendingActivityStateStarted.Ptr()},
+		{ActivityID: common.StringPtr("scheduled"), State: gen.PendingActivityStateScheduled.Ptr()},
+		// scheduled after the history was read
+		{ActivityID: common.StringPtr("unknown"), State: gen.PendingActivityStateStarted.Ptr()},
+	})
+	resp := builder.build()
+
+	assert.Equal(t, 3, len(resp.Nodes))
+	assert.Equal(t, gen.ExecutionGraphNodeStateCompleted, resp.Nodes[0].GetState())
+	assert.Equal(t, gen.ExecutionGraphNodeStateStarted, resp.Nodes[1].GetState())
+	assert.Equal(t, int64(7), resp.Nodes[1].GetInitiatedEventId())
+	assert.Equal(t, gen.ExecutionGraphNodeStateScheduled, resp.Nodes[2].GetState())
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"sync"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common/membership"
+	"go.uber.org/yarpc"
+)
+
+type (
+	// frontendPeers resolves the frontend host owning a key on the frontend ring and caches a client per
+	// frontend host, requests which have to land on a single host are forwarded through it
+	frontendPeers struct {
+		resolver      membership.ServiceResolver
+		self          *membership.HostInfo
+		clientFactory client.Factory
+
+		sync.Mutex
+		clients map[string]frontend.Client
+	}
+)
+
+func newFrontendPeers(resolver membership.ServiceResolver, self *membership.HostInfo,
+	clientFactory client.Factory) *frontendPeers {
+	return &frontendPeers{
+		resolver:      resolver,
+		self:          self,
+		clientFactory: clientFactory,
+		clients:       make(map[string]frontend.Client),
+	}
+}
+
+// owner returns the frontend host owning the key and a client to it. The returned bool is false when this
+// host owns the key or the owner could not be resolved.
+func (p *frontendPeers) owner(key string) (*membership.HostInfo, frontend.Client, bool) {
+	owner, err := p.resolver.Lookup(key)
+	if err != nil || owner.Identity() == p.self.Identity() {
+		return nil, nil, false
+	}
+	client, err := p.getClient(owner.GetAddress())
+	if err != nil {
+		return nil, nil, false
+	}
+	return owner, client, true
+}
+
+func (p *frontendPeers) getClient(address string) (frontend.Client, error) {
+	p.Lock()
+	defer p.Unlock()
+	if client, ok := p.clients[address]; ok {
+		return client, nil
+	}
+	client, err := p.clientFactory.NewFrontendClientForHost(address)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[address] = client
+	return client, nil
+}
+
+// isForwardedRequest is true when the request was forwarded by another frontend host with the header set
+func isForwardedRequest(ctx context.Context, header string) bool {
+	call := yarpc.CallFromContext(ctx)
+	return call != nil && call.Header(header) != ""
+}
+
+// isForwardedResult is true for the errors returned by the owner itself, which are the result of the
+// request. Any other error means the owner could not be reached.
+func isForwardedResult(err error) bool {
+	switch err.(type) {
+	case *gen.BadRequestError, *gen.EntityNotExistsError, *gen.InternalServiceError, *gen.ServiceBusyError,
+		*gen.LimitExceededError, *gen.AccessDeniedError:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"fmt"
+	"time"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// historyPageCache caches the history pages of closed runs. The history of a closed run never changes, so a
+	// page is identified by the range and paging token it was read with, and dashboards reading the same
+	// execution over and over are served without going to persistence. The pages are kept serialized, so every
+	// hit decodes its own copy of the events, which the caller is free to change.
+	historyPageCache struct {
+		pages      cache.Cache
+		serializer persistence.HistorySerializer
+	}
+
+	historyPage struct {
+		events        *persistence.SerializedHistoryEventBatch
+		nextPageToken []byte
+	}
+)
+
+// newHistoryPageCache returns nil when maxSize is not positive, which disables caching
+func newHistoryPageCache(maxSize int, ttl time.Duration) *historyPageCache {
+	if maxSize <= 0 {
+		return nil
+	}
+	return &historyPageCache{
+		pages:      cache.New(maxSize, &cache.Options{TTL: ttl}),
+		serializer: persistence.NewThriftRWHistorySerializer(),
+	}
+}
+
+func historyPageKey(domainID string, execution gen.WorkflowExecution, firstEventID, nextEventID int64,
+	pageSize int32, nextPageToken []byte) string {
+	return fmt.Sprintf("%v/%v/%v/%v/%v/%v/%x", domainID, execution.GetWorkflowId(), execution.GetRunId(),
+		firstEventID, nextEventID, pageSize, nextPageToken)
+}
+
+func (c *historyPageCache) get(key string) (*gen.History, []byte, bool) {
+	value := c.pages.Get(key)
+	if value == nil {
+		return nil, nil, false
+	}
+	page := value.(*historyPage)
+	batch, err := c.serializer.Deserialize(page.events)
+	if err != nil {
+		// the page is read from persistence again
+		return nil, nil, false
+	}
+	return &gen.History{Events: batch.Events}, page.nextPageToken, true
+}
+
+func (c *historyPageCache) put(key string, history *gen.History, nextPageToken []byte) {
+	events, err := c.serializer.Serialize(persistence.NewHistoryEventBatch(persistence.GetDefaultHistoryVersion(),
+		history.Events))
+	if err != nil {
+		return
+	}
+	c.pages.Put(key, &historyPage{events: events, nextPageToken: nextPageToken})
+}
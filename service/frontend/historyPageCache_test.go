@@ -0,0 +1,66 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+func TestHistoryPageCache_GetPut(t *testing.T) {
+	cache := newHistoryPageCache(10, time.Minute)
+	execution := gen.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr("rid")}
+	key := historyPageKey("domain", execution, 1, 10, 100, nil)
+
+	_, _, ok := cache.get(key)
+	assert.False(t, ok)
+
+	events := []*gen.HistoryEvent{{EventId: common.Int64Ptr(1)}, {EventId: common.Int64Ptr(2)}}
+	cache.put(key, &gen.History{Events: events}, []byte("next"))
+
+	history, token, ok := cache.get(key)
+	assert.True(t, ok)
+	assert.Equal(t, events, history.Events)
+	assert.Equal(t, []byte("next"), token)
+
+	// changing a returned history or the one put does not change the cached page
+	history.Events[0].EventId = common.Int64Ptr(3)
+	history.Events = history.Events[1:]
+	events[1].EventId = common.Int64Ptr(4)
+	history, _, ok = cache.get(key)
+	assert.True(t, ok)
+	assert.Equal(t, 2, len(history.Events))
+	assert.Equal(t, int64(1), history.Events[0].GetEventId())
+	assert.Equal(t, int64(2), history.Events[1].GetEventId())
+
+	_, _, ok = cache.get(historyPageKey("domain", execution, 1, 10, 100, []byte("next")))
+	assert.False(t, ok)
+	_, _, ok = cache.get(historyPageKey("domain", execution, 1, 10, 50, nil))
+	assert.False(t, ok)
+}
+
+func TestHistoryPageCache_Disabled(t *testing.T) {
+	assert.Nil(t, newHistoryPageCache(0, time.Minute))
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+
+	"github.com/uber-common/bark"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"go.uber.org/yarpc"
+)
+
+// historyReadForwardedHeaderName marks a history read forwarded by another frontend host, so it is served
+// locally even when the frontend ring changed in between
+const historyReadForwardedHeaderName = "cadence-history-read-forwarded"
+
+type (
+	// historyReadRouter sends the history reads of a workflow to the frontend host owning the workflow ID on the
+	// frontend ring. Repeated reads of the same execution then land on a single host, where the pages of closed
+	// runs are served from its history page cache.
+	historyReadRouter struct {
+		enabled       dynamicconfig.BoolPropertyFn
+		peers         *frontendPeers
+		metricsClient metrics.Client
+		logger        bark.Logger
+	}
+)
+
+func newHistoryReadRouter(enabled dynamicconfig.BoolPropertyFn, resolver membership.ServiceResolver,
+	self *membership.HostInfo, clientFactory client.Factory, metricsClient metrics.Client,
+	logger bark.Logger) *historyReadRouter {
+	return &historyReadRouter{
+		enabled:       enabled,
+		peers:         newFrontendPeers(resolver, self, clientFactory),
+		metricsClient: metricsClient,
+		logger:        logger,
+	}
+}
+
+// forward serves the history read from the frontend host owning the workflow. The returned bool is false when
+// the read has to be served by this host: routing is disabled, this host owns the workflow, the read is a long
+// poll or was already forwarded, or the owner could not be reached.
+func (r *historyReadRouter) forward(ctx context.Context,
+	request *gen.GetWorkflowExecutionHistoryRequest) (*gen.GetWorkflowExecutionHistoryResponse, bool, error) {
+	if r == nil || !r.enabled() || request.GetWaitForNewEvent() ||
+		isForwardedRequest(ctx, historyReadForwardedHeaderName) {
+		return nil, false, nil
+	}
+
+	owner, client, ok := r.peers.owner(request.Execution.GetWorkflowId())
+	if !ok {
+		return nil, false, nil
+	}
+
+	opts := append(common.AggregateYarpcOptions(ctx), yarpc.WithHeader(historyReadForwardedHeaderName, "true"))
+	response, err := client.GetWorkflowExecutionHistory(ctx, request, opts...)
+	if err != nil && !isForwardedResult(err) {
+		r.logger.WithFields(bark.Fields{
+			logging.TagErr:                 err,
+			logging.TagWorkflowExecutionID: request.Execution.GetWorkflowId(),
+		}).Warnf("Failed to forward history read to %v, serving it locally.", owner.GetAddress())
+		return nil, false, nil
+	}
+	r.metricsClient.IncCounter(metrics.FrontendGetWorkflowExecutionHistoryScope, metrics.HistoryReadForwardedCounter)
+	return response, true, err
+}
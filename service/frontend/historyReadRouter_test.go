@@ -0,0 +1,84 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+func newTestHistoryReadRouter(enabled bool, resolver membership.ServiceResolver) *historyReadRouter {
+	return newHistoryReadRouter(dynamicconfig.GetBoolPropertyFn(enabled), resolver,
+		membership.NewHostInfo("self:7933", nil), nil, metrics.NewClient(tally.NoopScope, metrics.Frontend),
+		bark.NewNopLogger())
+}
+
+func TestHistoryReadRouter_ServedLocally(t *testing.T) {
+	resolver := &mocks.ServiceResolver{}
+	resolver.On("Lookup", "wid").Return(membership.NewHostInfo("self:7933", nil), nil)
+	request := &gen.GetWorkflowExecutionHistoryRequest{
+		Domain:    common.StringPtr("domain"),
+		Execution: &gen.WorkflowExecution{WorkflowId: common.StringPtr("wid")},
+	}
+
+	_, forwarded, err := newTestHistoryReadRouter(false, resolver).forward(context.Background(), request)
+	assert.False(t, forwarded)
+	assert.Nil(t, err)
+	resolver.AssertNotCalled(t, "Lookup", "wid")
+
+	// this host owns the workflow
+	_, forwarded, err = newTestHistoryReadRouter(true, resolver).forward(context.Background(), request)
+	assert.False(t, forwarded)
+	assert.Nil(t, err)
+	resolver.AssertCalled(t, "Lookup", "wid")
+
+	// long polls stay on the host receiving them
+	longPoll := &gen.GetWorkflowExecutionHistoryRequest{
+		Domain:          common.StringPtr("domain"),
+		Execution:       &gen.WorkflowExecution{WorkflowId: common.StringPtr("other")},
+		WaitForNewEvent: common.BoolPtr(true),
+	}
+	_, forwarded, err = newTestHistoryReadRouter(true, resolver).forward(context.Background(), longPoll)
+	assert.False(t, forwarded)
+	assert.Nil(t, err)
+	resolver.AssertNotCalled(t, "Lookup", "other")
+
+	var router *historyReadRouter
+	_, forwarded, err = router.forward(context.Background(), request)
+	assert.False(t, forwarded)
+	assert.Nil(t, err)
+}
+
+func TestIsForwardedResult(t *testing.T) {
+	assert.True(t, isForwardedResult(&gen.EntityNotExistsError{}))
+	assert.True(t, isForwardedResult(&gen.BadRequestError{}))
+	assert.False(t, isForwardedResult(context.DeadlineExceeded))
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	gen "github.com/uber/cadence/.gen/go/shared"
+)
+
+// summarizeHistory returns the milestone events of a history page with their payloads omitted. The events are
+// copied, the page itself may be shared with the history page cache.
+func summarizeHistory(history *gen.History) *gen.History {
+	summary := &gen.History{Events: []*gen.HistoryEvent{}}
+	for _, event := range history.Events {
+		if summaryEvent := summarizeHistoryEvent(event); summaryEvent != nil {
+			summary.Events = append(summary.Events, summaryEvent)
+		}
+	}
+	return summary
+}
+
+// summarizeHistoryEvent returns a copy of a milestone event without its payload, or nil for any other event
+func summarizeHistoryEvent(event *gen.HistoryEvent) *gen.HistoryEvent {
+	summary := &gen.HistoryEvent{
+		EventId:   event.EventId,
+		Timestamp: event.Timestamp,
+		EventType: event.EventType,
+		Version:   event.Version,
+	}
+
+	switch event.GetEventType() {
+	case gen.EventTypeWorkflowExecutionStarted:
+		attributes := *event.WorkflowExecutionStartedEventAttributes
+		attributes.Input = nil
+		summary.WorkflowExecutionStartedEventAttributes = &attributes
+	case gen.EventTypeDecisionTaskCompleted:
+		attributes := *event.DecisionTaskCompletedEventAttributes
+		attributes.ExecutionContext = nil
+		summary.DecisionTaskCompletedEventAttributes = &attributes
+	case gen.EventTypeActivityTaskScheduled:
+		attributes := *event.ActivityTaskScheduledEventAttributes
+		attributes.Input = nil
+		summary.ActivityTaskScheduledEventAttributes = &attributes
+	case gen.EventTypeActivityTaskCompleted:
+		attributes := *event.ActivityTaskCompletedEventAttributes
+		attributes.Result = nil
+		summary.ActivityTaskCompletedEventAttributes = &attributes
+	case gen.EventTypeActivityTaskFailed:
+		attributes := *event.ActivityTaskFailedEventAttributes
+		attributes.Details = nil
+		summary.ActivityTaskFailedEventAttributes = &attributes
+	case gen.EventTypeWorkflowExecutionSignaled:
+		attributes := *event.WorkflowExecutionSignaledEventAttributes
+		attributes.Input = nil
+		summary.WorkflowExecutionSignaledEventAttributes = &attributes
+	case gen.EventTypeWorkflowExecutionCompleted:
+		attributes := *event.WorkflowExecutionCompletedEventAttributes
+		attributes.Result = nil
+		summary.WorkflowExecutionCompletedEventAttributes = &attributes
+	case gen.EventTypeWorkflowExecutionFailed:
+		attributes := *event.WorkflowExecutionFailedEventAttributes
+		attributes.Details = nil
+		summary.WorkflowExecutionFailedEventAttributes = &attributes
+	case gen.EventTypeWorkflowExecutionTimedOut:
+		summary.WorkflowExecutionTimedOutEventAttributes = event.WorkflowExecutionTimedOutEventAttributes
+	case gen.EventTypeWorkflowExecutionCanceled:
+		attributes := *event.WorkflowExecutionCanceledEventAttributes
+		attributes.Details = nil
+		summary.WorkflowExecutionCanceledEventAttributes = &attributes
+	case gen.EventTypeWorkflowExecutionTerminated:
+		attributes := *event.WorkflowExecutionTerminatedEventAttributes
+		attributes.Details = nil
+		summary.WorkflowExecutionTerminatedEventAttributes = &attributes
+	case gen.EventTypeWorkflowExecutionContinuedAsNew:
+		attributes := *event.WorkflowExecutionContinuedAsNewEventAttributes
+		attributes.Input = nil
+		summary.WorkflowExecutionContinuedAsNewEventAttributes = &attributes
+	default:
+		return nil
+	}
+	return summary
+}
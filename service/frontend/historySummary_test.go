@@ -0,0 +1,77 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+func TestSummarizeHistory(t *testing.T) {
+	started := &gen.HistoryEvent{
+		EventId:   common.Int64Ptr(1),
+		EventType: gen.EventTypeWorkflowExecutionStarted.Ptr(),
+		WorkflowExecutionStartedEventAttributes: &gen.WorkflowExecutionStartedEventAttributes{
+			WorkflowType: &gen.WorkflowType{Name: common.StringPtr("wType")},
+			Input:        []byte("input"),
+		},
+	}
+	decisionScheduled := &gen.HistoryEvent{
+		EventId:                              common.Int64Ptr(2),
+		EventType:                            gen.EventTypeDecisionTaskScheduled.Ptr(),
+		DecisionTaskScheduledEventAttributes: &gen.DecisionTaskScheduledEventAttributes{},
+	}
+	activityCompleted := &gen.HistoryEvent{
+		EventId:   common.Int64Ptr(3),
+		EventType: gen.EventTypeActivityTaskCompleted.Ptr(),
+		ActivityTaskCompletedEventAttributes: &gen.ActivityTaskCompletedEventAttributes{
+			Result:           []byte("result"),
+			ScheduledEventId: common.Int64Ptr(5),
+		},
+	}
+	completed := &gen.HistoryEvent{
+		EventId:   common.Int64Ptr(4),
+		EventType: gen.EventTypeWorkflowExecutionCompleted.Ptr(),
+		WorkflowExecutionCompletedEventAttributes: &gen.WorkflowExecutionCompletedEventAttributes{
+			Result: []byte("result"),
+		},
+	}
+
+	history := &gen.History{Events: []*gen.HistoryEvent{started, decisionScheduled, activityCompleted, completed}}
+	summary := summarizeHistory(history)
+	assert.Equal(t, 3, len(summary.Events))
+
+	assert.Equal(t, int64(1), summary.Events[0].GetEventId())
+	assert.Equal(t, "wType", summary.Events[0].WorkflowExecutionStartedEventAttributes.WorkflowType.GetName())
+	assert.Nil(t, summary.Events[0].WorkflowExecutionStartedEventAttributes.Input)
+	assert.Equal(t, int64(5), summary.Events[1].ActivityTaskCompletedEventAttributes.GetScheduledEventId())
+	assert.Nil(t, summary.Events[1].ActivityTaskCompletedEventAttributes.Result)
+	assert.Equal(t, gen.EventTypeWorkflowExecutionCompleted, summary.Events[2].GetEventType())
+	assert.Nil(t, summary.Events[2].WorkflowExecutionCompletedEventAttributes.Result)
+
+	// the summarized page is left untouched
+	assert.Equal(t, 4, len(history.Events))
+	assert.Equal(t, []byte("input"), started.WorkflowExecutionStartedEventAttributes.Input)
+	assert.Equal(t, []byte("result"), activityCompleted.ActivityTaskCompletedEventAttributes.Result)
+}
@@ -0,0 +1,743 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+
+	"github.com/uber/cadence/.gen/go/admin"
+	"github.com/uber/cadence/.gen/go/admin/adminserviceserver"
+	"github.com/uber/cadence/.gen/go/cadence/workflowserviceserver"
+	gen "github.com/uber/cadence/.gen/go/shared"
+)
+
+const (
+	workflowServiceName = "WorkflowService"
+	adminServiceName    = "AdminService"
+)
+
+var _ workflowserviceserver.Interface = (*interceptedWorkflowHandler)(nil)
+var _ adminserviceserver.Interface = (*interceptedAdminHandler)(nil)
+
+type (
+	// RequestInfo describes the frontend API call being intercepted
+	RequestInfo struct {
+		// Service is the thrift service of the API, WorkflowService or AdminService
+		Service string
+		// Method is the name of the API, e.g. StartWorkflowExecution
+		Method string
+	}
+
+	// RequestHandler processes a frontend API request, the response is nil for APIs without response
+	RequestHandler func(ctx context.Context, request interface{}) (interface{}, error)
+
+	// Interceptor is invoked on every frontend API call with the request and the handler which
+	// serves the rest of the chain. It may reject the call by returning an error without calling
+	// the handler, or inspect and replace the response and error the handler returns.
+	// Interceptors are registered when the frontend service is created and run in registration order.
+	Interceptor func(ctx context.Context, info *RequestInfo, request interface{}, handler RequestHandler) (interface{}, error)
+
+	interceptedWorkflowHandler struct {
+		handler     workflowserviceserver.Interface
+		interceptor Interceptor
+	}
+
+	interceptedAdminHandler struct {
+		handler     adminserviceserver.Interface
+		interceptor Interceptor
+	}
+)
+
+// chainInterceptors combines the interceptors into one, the first interceptor being the outermost
+func chainInterceptors(interceptors []Interceptor) Interceptor {
+	return func(ctx context.Context, info *RequestInfo, request interface{}, handler RequestHandler) (interface{}, error) {
+		var next func(index int) RequestHandler
+		next = func(index int) RequestHandler {
+			if index == len(interceptors) {
+				return handler
+			}
+			return func(ctx context.Context, request interface{}) (interface{}, error) {
+				return interceptors[index](ctx, info, request, next(index+1))
+			}
+		}
+		return next(0)(ctx, request)
+	}
+}
+
+// newInterceptedWorkflowHandler returns a workflow service handler which runs every call through the
+// interceptors before handing it to the given handler
+func newInterceptedWorkflowHandler(handler workflowserviceserver.Interface, interceptors []Interceptor) workflowserviceserver.Interface {
+	if len(interceptors) == 0 {
+		return handler
+	}
+	return &interceptedWorkflowHandler{
+		handler:     handler,
+		interceptor: chainInterceptors(interceptors),
+	}
+}
+
+// newInterceptedAdminHandler returns an admin service handler which runs every call through the
+// interceptors before handing it to the given handler
+func newInterceptedAdminHandler(handler adminserviceserver.Interface, interceptors []Interceptor) adminserviceserver.Interface {
+	if len(interceptors) == 0 {
+		return handler
+	}
+	return &interceptedAdminHandler{
+		handler:     handler,
+		interceptor: chainInterceptors(interceptors),
+	}
+}
+
+// CreateSchedule intercepts the CreateSchedule API
+func (h *interceptedWorkflowHandler) CreateSchedule(ctx context.Context, request *gen.CreateScheduleRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "CreateSchedule"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.CreateSchedule(ctx, request.(*gen.CreateScheduleRequest))
+		})
+	return err
+}
+
+// DeleteSchedule intercepts the DeleteSchedule API
+func (h *interceptedWorkflowHandler) DeleteSchedule(ctx context.Context, request *gen.DeleteScheduleRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "DeleteSchedule"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.DeleteSchedule(ctx, request.(*gen.DeleteScheduleRequest))
+		})
+	return err
+}
+
+// DeprecateDomain intercepts the DeprecateDomain API
+func (h *interceptedWorkflowHandler) DeprecateDomain(ctx context.Context, request *gen.DeprecateDomainRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "DeprecateDomain"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.DeprecateDomain(ctx, request.(*gen.DeprecateDomainRequest))
+		})
+	return err
+}
+
+// DescribeDomain intercepts the DescribeDomain API
+func (h *interceptedWorkflowHandler) DescribeDomain(ctx context.Context, request *gen.DescribeDomainRequest) (*gen.DescribeDomainResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "DescribeDomain"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.DescribeDomain(ctx, request.(*gen.DescribeDomainRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.DescribeDomainResponse), err
+}
+
+// DescribeSchedule intercepts the DescribeSchedule API
+func (h *interceptedWorkflowHandler) DescribeSchedule(ctx context.Context, request *gen.DescribeScheduleRequest) (*gen.DescribeScheduleResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "DescribeSchedule"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.DescribeSchedule(ctx, request.(*gen.DescribeScheduleRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.DescribeScheduleResponse), err
+}
+
+// DescribeTaskList intercepts the DescribeTaskList API
+func (h *interceptedWorkflowHandler) DescribeTaskList(ctx context.Context, request *gen.DescribeTaskListRequest) (*gen.DescribeTaskListResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "DescribeTaskList"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.DescribeTaskList(ctx, request.(*gen.DescribeTaskListRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.DescribeTaskListResponse), err
+}
+
+// DescribeWorkflowExecution intercepts the DescribeWorkflowExecution API
+func (h *interceptedWorkflowHandler) DescribeWorkflowExecution(ctx context.Context, request *gen.DescribeWorkflowExecutionRequest) (*gen.DescribeWorkflowExecutionResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "DescribeWorkflowExecution"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.DescribeWorkflowExecution(ctx, request.(*gen.DescribeWorkflowExecutionRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.DescribeWorkflowExecutionResponse), err
+}
+
+// GetTaskListsByDomain intercepts the GetTaskListsByDomain API
+func (h *interceptedWorkflowHandler) GetTaskListsByDomain(ctx context.Context, request *gen.GetTaskListsByDomainRequest) (*gen.GetTaskListsByDomainResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "GetTaskListsByDomain"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.GetTaskListsByDomain(ctx, request.(*gen.GetTaskListsByDomainRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.GetTaskListsByDomainResponse), err
+}
+
+// GetWorkflowExecutionGraph intercepts the GetWorkflowExecutionGraph API
+func (h *interceptedWorkflowHandler) GetWorkflowExecutionGraph(ctx context.Context, request *gen.GetWorkflowExecutionGraphRequest) (*gen.GetWorkflowExecutionGraphResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "GetWorkflowExecutionGraph"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.GetWorkflowExecutionGraph(ctx, request.(*gen.GetWorkflowExecutionGraphRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.GetWorkflowExecutionGraphResponse), err
+}
+
+// GetWorkflowExecutionHistory intercepts the GetWorkflowExecutionHistory API
+func (h *interceptedWorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, request *gen.GetWorkflowExecutionHistoryRequest) (*gen.GetWorkflowExecutionHistoryResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "GetWorkflowExecutionHistory"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.GetWorkflowExecutionHistory(ctx, request.(*gen.GetWorkflowExecutionHistoryRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.GetWorkflowExecutionHistoryResponse), err
+}
+
+// GetWorkflowExecutionResult intercepts the GetWorkflowExecutionResult API
+func (h *interceptedWorkflowHandler) GetWorkflowExecutionResult(ctx context.Context, request *gen.GetWorkflowExecutionResultRequest) (*gen.GetWorkflowExecutionResultResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "GetWorkflowExecutionResult"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.GetWorkflowExecutionResult(ctx, request.(*gen.GetWorkflowExecutionResultRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.GetWorkflowExecutionResultResponse), err
+}
+
+// ListClosedWorkflowExecutions intercepts the ListClosedWorkflowExecutions API
+func (h *interceptedWorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context, request *gen.ListClosedWorkflowExecutionsRequest) (*gen.ListClosedWorkflowExecutionsResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "ListClosedWorkflowExecutions"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.ListClosedWorkflowExecutions(ctx, request.(*gen.ListClosedWorkflowExecutionsRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.ListClosedWorkflowExecutionsResponse), err
+}
+
+// ListDomains intercepts the ListDomains API
+func (h *interceptedWorkflowHandler) ListDomains(ctx context.Context, request *gen.ListDomainsRequest) (*gen.ListDomainsResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "ListDomains"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.ListDomains(ctx, request.(*gen.ListDomainsRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.ListDomainsResponse), err
+}
+
+// ListOpenWorkflowExecutions intercepts the ListOpenWorkflowExecutions API
+func (h *interceptedWorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context, request *gen.ListOpenWorkflowExecutionsRequest) (*gen.ListOpenWorkflowExecutionsResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "ListOpenWorkflowExecutions"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.ListOpenWorkflowExecutions(ctx, request.(*gen.ListOpenWorkflowExecutionsRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.ListOpenWorkflowExecutionsResponse), err
+}
+
+// ListSchedules intercepts the ListSchedules API
+func (h *interceptedWorkflowHandler) ListSchedules(ctx context.Context, request *gen.ListSchedulesRequest) (*gen.ListSchedulesResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "ListSchedules"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.ListSchedules(ctx, request.(*gen.ListSchedulesRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.ListSchedulesResponse), err
+}
+
+// ListWorkflowExecutions intercepts the ListWorkflowExecutions API
+func (h *interceptedWorkflowHandler) ListWorkflowExecutions(ctx context.Context, request *gen.ListWorkflowExecutionsRequest) (*gen.ListWorkflowExecutionsResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "ListWorkflowExecutions"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.ListWorkflowExecutions(ctx, request.(*gen.ListWorkflowExecutionsRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.ListWorkflowExecutionsResponse), err
+}
+
+// PollForActivityTask intercepts the PollForActivityTask API
+func (h *interceptedWorkflowHandler) PollForActivityTask(ctx context.Context, request *gen.PollForActivityTaskRequest) (*gen.PollForActivityTaskResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "PollForActivityTask"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.PollForActivityTask(ctx, request.(*gen.PollForActivityTaskRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.PollForActivityTaskResponse), err
+}
+
+// PollForDecisionTask intercepts the PollForDecisionTask API
+func (h *interceptedWorkflowHandler) PollForDecisionTask(ctx context.Context, request *gen.PollForDecisionTaskRequest) (*gen.PollForDecisionTaskResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "PollForDecisionTask"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.PollForDecisionTask(ctx, request.(*gen.PollForDecisionTaskRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.PollForDecisionTaskResponse), err
+}
+
+// QueryWorkflow intercepts the QueryWorkflow API
+func (h *interceptedWorkflowHandler) QueryWorkflow(ctx context.Context, request *gen.QueryWorkflowRequest) (*gen.QueryWorkflowResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "QueryWorkflow"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.QueryWorkflow(ctx, request.(*gen.QueryWorkflowRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.QueryWorkflowResponse), err
+}
+
+// RecordActivityTaskHeartbeat intercepts the RecordActivityTaskHeartbeat API
+func (h *interceptedWorkflowHandler) RecordActivityTaskHeartbeat(ctx context.Context, request *gen.RecordActivityTaskHeartbeatRequest) (*gen.RecordActivityTaskHeartbeatResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "RecordActivityTaskHeartbeat"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.RecordActivityTaskHeartbeat(ctx, request.(*gen.RecordActivityTaskHeartbeatRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.RecordActivityTaskHeartbeatResponse), err
+}
+
+// RecordActivityTaskHeartbeatByID intercepts the RecordActivityTaskHeartbeatByID API
+func (h *interceptedWorkflowHandler) RecordActivityTaskHeartbeatByID(ctx context.Context, request *gen.RecordActivityTaskHeartbeatByIDRequest) (*gen.RecordActivityTaskHeartbeatResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "RecordActivityTaskHeartbeatByID"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.RecordActivityTaskHeartbeatByID(ctx, request.(*gen.RecordActivityTaskHeartbeatByIDRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.RecordActivityTaskHeartbeatResponse), err
+}
+
+// RecordWorkerHeartbeat intercepts the RecordWorkerHeartbeat API
+func (h *interceptedWorkflowHandler) RecordWorkerHeartbeat(ctx context.Context, request *gen.RecordWorkerHeartbeatRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "RecordWorkerHeartbeat"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.RecordWorkerHeartbeat(ctx, request.(*gen.RecordWorkerHeartbeatRequest))
+		})
+	return err
+}
+
+// RegisterDomain intercepts the RegisterDomain API
+func (h *interceptedWorkflowHandler) RegisterDomain(ctx context.Context, request *gen.RegisterDomainRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "RegisterDomain"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.RegisterDomain(ctx, request.(*gen.RegisterDomainRequest))
+		})
+	return err
+}
+
+// RequestCancelWorkflowExecution intercepts the RequestCancelWorkflowExecution API
+func (h *interceptedWorkflowHandler) RequestCancelWorkflowExecution(ctx context.Context, request *gen.RequestCancelWorkflowExecutionRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "RequestCancelWorkflowExecution"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.RequestCancelWorkflowExecution(ctx, request.(*gen.RequestCancelWorkflowExecutionRequest))
+		})
+	return err
+}
+
+// ResetStickyTaskList intercepts the ResetStickyTaskList API
+func (h *interceptedWorkflowHandler) ResetStickyTaskList(ctx context.Context, request *gen.ResetStickyTaskListRequest) (*gen.ResetStickyTaskListResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "ResetStickyTaskList"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.ResetStickyTaskList(ctx, request.(*gen.ResetStickyTaskListRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.ResetStickyTaskListResponse), err
+}
+
+// RespondActivityTaskCanceled intercepts the RespondActivityTaskCanceled API
+func (h *interceptedWorkflowHandler) RespondActivityTaskCanceled(ctx context.Context, request *gen.RespondActivityTaskCanceledRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "RespondActivityTaskCanceled"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.RespondActivityTaskCanceled(ctx, request.(*gen.RespondActivityTaskCanceledRequest))
+		})
+	return err
+}
+
+// RespondActivityTaskCanceledByID intercepts the RespondActivityTaskCanceledByID API
+func (h *interceptedWorkflowHandler) RespondActivityTaskCanceledByID(ctx context.Context, request *gen.RespondActivityTaskCanceledByIDRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "RespondActivityTaskCanceledByID"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.RespondActivityTaskCanceledByID(ctx, request.(*gen.RespondActivityTaskCanceledByIDRequest))
+		})
+	return err
+}
+
+// RespondActivityTaskCompleted intercepts the RespondActivityTaskCompleted API
+func (h *interceptedWorkflowHandler) RespondActivityTaskCompleted(ctx context.Context, request *gen.RespondActivityTaskCompletedRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "RespondActivityTaskCompleted"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.RespondActivityTaskCompleted(ctx, request.(*gen.RespondActivityTaskCompletedRequest))
+		})
+	return err
+}
+
+// RespondActivityTaskCompletedByID intercepts the RespondActivityTaskCompletedByID API
+func (h *interceptedWorkflowHandler) RespondActivityTaskCompletedByID(ctx context.Context, request *gen.RespondActivityTaskCompletedByIDRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "RespondActivityTaskCompletedByID"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.RespondActivityTaskCompletedByID(ctx, request.(*gen.RespondActivityTaskCompletedByIDRequest))
+		})
+	return err
+}
+
+// RespondActivityTaskFailed intercepts the RespondActivityTaskFailed API
+func (h *interceptedWorkflowHandler) RespondActivityTaskFailed(ctx context.Context, request *gen.RespondActivityTaskFailedRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "RespondActivityTaskFailed"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.RespondActivityTaskFailed(ctx, request.(*gen.RespondActivityTaskFailedRequest))
+		})
+	return err
+}
+
+// RespondActivityTaskFailedByID intercepts the RespondActivityTaskFailedByID API
+func (h *interceptedWorkflowHandler) RespondActivityTaskFailedByID(ctx context.Context, request *gen.RespondActivityTaskFailedByIDRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "RespondActivityTaskFailedByID"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.RespondActivityTaskFailedByID(ctx, request.(*gen.RespondActivityTaskFailedByIDRequest))
+		})
+	return err
+}
+
+// RespondDecisionTaskCompleted intercepts the RespondDecisionTaskCompleted API
+func (h *interceptedWorkflowHandler) RespondDecisionTaskCompleted(ctx context.Context, request *gen.RespondDecisionTaskCompletedRequest) (*gen.RespondDecisionTaskCompletedResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "RespondDecisionTaskCompleted"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.RespondDecisionTaskCompleted(ctx, request.(*gen.RespondDecisionTaskCompletedRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.RespondDecisionTaskCompletedResponse), err
+}
+
+// RespondDecisionTaskFailed intercepts the RespondDecisionTaskFailed API
+func (h *interceptedWorkflowHandler) RespondDecisionTaskFailed(ctx context.Context, request *gen.RespondDecisionTaskFailedRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "RespondDecisionTaskFailed"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.RespondDecisionTaskFailed(ctx, request.(*gen.RespondDecisionTaskFailedRequest))
+		})
+	return err
+}
+
+// RespondQueryTaskCompleted intercepts the RespondQueryTaskCompleted API
+func (h *interceptedWorkflowHandler) RespondQueryTaskCompleted(ctx context.Context, request *gen.RespondQueryTaskCompletedRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "RespondQueryTaskCompleted"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.RespondQueryTaskCompleted(ctx, request.(*gen.RespondQueryTaskCompletedRequest))
+		})
+	return err
+}
+
+// SignalWithStartWorkflowExecution intercepts the SignalWithStartWorkflowExecution API
+func (h *interceptedWorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context, request *gen.SignalWithStartWorkflowExecutionRequest) (*gen.StartWorkflowExecutionResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "SignalWithStartWorkflowExecution"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.SignalWithStartWorkflowExecution(ctx, request.(*gen.SignalWithStartWorkflowExecutionRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.StartWorkflowExecutionResponse), err
+}
+
+// SignalWorkflowExecution intercepts the SignalWorkflowExecution API
+func (h *interceptedWorkflowHandler) SignalWorkflowExecution(ctx context.Context, request *gen.SignalWorkflowExecutionRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "SignalWorkflowExecution"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.SignalWorkflowExecution(ctx, request.(*gen.SignalWorkflowExecutionRequest))
+		})
+	return err
+}
+
+// StartWorkflowExecution intercepts the StartWorkflowExecution API
+func (h *interceptedWorkflowHandler) StartWorkflowExecution(ctx context.Context, request *gen.StartWorkflowExecutionRequest) (*gen.StartWorkflowExecutionResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "StartWorkflowExecution"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.StartWorkflowExecution(ctx, request.(*gen.StartWorkflowExecutionRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.StartWorkflowExecutionResponse), err
+}
+
+// TerminateWorkflowExecution intercepts the TerminateWorkflowExecution API
+func (h *interceptedWorkflowHandler) TerminateWorkflowExecution(ctx context.Context, request *gen.TerminateWorkflowExecutionRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "TerminateWorkflowExecution"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.TerminateWorkflowExecution(ctx, request.(*gen.TerminateWorkflowExecutionRequest))
+		})
+	return err
+}
+
+// UpdateDomain intercepts the UpdateDomain API
+func (h *interceptedWorkflowHandler) UpdateDomain(ctx context.Context, request *gen.UpdateDomainRequest) (*gen.UpdateDomainResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "UpdateDomain"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.UpdateDomain(ctx, request.(*gen.UpdateDomainRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.UpdateDomainResponse), err
+}
+
+// UpdateSchedule intercepts the UpdateSchedule API
+func (h *interceptedWorkflowHandler) UpdateSchedule(ctx context.Context, request *gen.UpdateScheduleRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "UpdateSchedule"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.UpdateSchedule(ctx, request.(*gen.UpdateScheduleRequest))
+		})
+	return err
+}
+
+// UpdateWorkflowExecutionOptions intercepts the UpdateWorkflowExecutionOptions API
+func (h *interceptedWorkflowHandler) UpdateWorkflowExecutionOptions(ctx context.Context, request *gen.UpdateWorkflowExecutionOptionsRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "UpdateWorkflowExecutionOptions"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.UpdateWorkflowExecutionOptions(ctx, request.(*gen.UpdateWorkflowExecutionOptionsRequest))
+		})
+	return err
+}
+
+// UpdateWorkflowExecution intercepts the UpdateWorkflowExecution API
+func (h *interceptedWorkflowHandler) UpdateWorkflowExecution(ctx context.Context, request *gen.UpdateWorkflowExecutionRequest) (*gen.UpdateWorkflowExecutionResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: workflowServiceName, Method: "UpdateWorkflowExecution"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.UpdateWorkflowExecution(ctx, request.(*gen.UpdateWorkflowExecutionRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.UpdateWorkflowExecutionResponse), err
+}
+
+// DeleteWorkflowExecution intercepts the DeleteWorkflowExecution API
+func (h *interceptedAdminHandler) DeleteWorkflowExecution(ctx context.Context, request *admin.DeleteWorkflowExecutionRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "DeleteWorkflowExecution"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.DeleteWorkflowExecution(ctx, request.(*admin.DeleteWorkflowExecutionRequest))
+		})
+	return err
+}
+
+// DescribeFailoverDrill intercepts the DescribeFailoverDrill API
+func (h *interceptedAdminHandler) DescribeFailoverDrill(ctx context.Context, request *admin.DescribeFailoverDrillRequest) (*admin.DescribeFailoverDrillResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "DescribeFailoverDrill"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.DescribeFailoverDrill(ctx, request.(*admin.DescribeFailoverDrillRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*admin.DescribeFailoverDrillResponse), err
+}
+
+// FixWorkflowExecutions intercepts the FixWorkflowExecutions API
+func (h *interceptedAdminHandler) FixWorkflowExecutions(ctx context.Context, request *admin.FixWorkflowExecutionsRequest) (*admin.FixWorkflowExecutionsResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "FixWorkflowExecutions"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.FixWorkflowExecutions(ctx, request.(*admin.FixWorkflowExecutionsRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*admin.FixWorkflowExecutionsResponse), err
+}
+
+// DescribeHistoryHost intercepts the DescribeHistoryHost API
+func (h *interceptedAdminHandler) DescribeHistoryHost(ctx context.Context, request *gen.DescribeHistoryHostRequest) (*gen.DescribeHistoryHostResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "DescribeHistoryHost"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.DescribeHistoryHost(ctx, request.(*gen.DescribeHistoryHostRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.DescribeHistoryHostResponse), err
+}
+
+// DescribeShardBacklogs intercepts the DescribeShardBacklogs API
+func (h *interceptedAdminHandler) DescribeShardBacklogs(ctx context.Context, request *gen.DescribeShardBacklogsRequest) (*gen.DescribeShardBacklogsResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "DescribeShardBacklogs"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.DescribeShardBacklogs(ctx, request.(*gen.DescribeShardBacklogsRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.DescribeShardBacklogsResponse), err
+}
+
+// DescribeShardOperations intercepts the DescribeShardOperations API
+func (h *interceptedAdminHandler) DescribeShardOperations(ctx context.Context, request *gen.DescribeShardOperationsRequest) (*gen.DescribeShardOperationsResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "DescribeShardOperations"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.DescribeShardOperations(ctx, request.(*gen.DescribeShardOperationsRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.DescribeShardOperationsResponse), err
+}
+
+// DescribeWorkflowExecution intercepts the DescribeWorkflowExecution API
+func (h *interceptedAdminHandler) DescribeWorkflowExecution(ctx context.Context, request *admin.DescribeWorkflowExecutionRequest) (*admin.DescribeWorkflowExecutionResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "DescribeWorkflowExecution"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.DescribeWorkflowExecution(ctx, request.(*admin.DescribeWorkflowExecutionRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*admin.DescribeWorkflowExecutionResponse), err
+}
+
+// DescribeReplicationDLQ intercepts the DescribeReplicationDLQ API
+func (h *interceptedAdminHandler) DescribeReplicationDLQ(ctx context.Context, request *admin.DescribeReplicationDLQRequest) (*admin.DescribeReplicationDLQResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "DescribeReplicationDLQ"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.DescribeReplicationDLQ(ctx, request.(*admin.DescribeReplicationDLQRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*admin.DescribeReplicationDLQResponse), err
+}
+
+// DiffWorkflowExecutionHistory intercepts the DiffWorkflowExecutionHistory API
+func (h *interceptedAdminHandler) DiffWorkflowExecutionHistory(ctx context.Context, request *admin.DiffWorkflowExecutionHistoryRequest) (*admin.DiffWorkflowExecutionHistoryResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "DiffWorkflowExecutionHistory"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.DiffWorkflowExecutionHistory(ctx, request.(*admin.DiffWorkflowExecutionHistoryRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*admin.DiffWorkflowExecutionHistoryResponse), err
+}
+
+// ForceUnloadTaskList intercepts the ForceUnloadTaskList API
+func (h *interceptedAdminHandler) ForceUnloadTaskList(ctx context.Context, request *gen.ForceUnloadTaskListRequest) (*gen.ForceUnloadTaskListResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "ForceUnloadTaskList"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.ForceUnloadTaskList(ctx, request.(*gen.ForceUnloadTaskListRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.ForceUnloadTaskListResponse), err
+}
+
+// GetWorkflowExecutionHistoryBatches intercepts the GetWorkflowExecutionHistoryBatches API
+func (h *interceptedAdminHandler) GetWorkflowExecutionHistoryBatches(ctx context.Context, request *admin.GetWorkflowExecutionHistoryBatchesRequest) (*admin.GetWorkflowExecutionHistoryBatchesResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "GetWorkflowExecutionHistoryBatches"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.GetWorkflowExecutionHistoryBatches(ctx, request.(*admin.GetWorkflowExecutionHistoryBatchesRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*admin.GetWorkflowExecutionHistoryBatchesResponse), err
+}
+
+// ListPostCloseEvents intercepts the ListPostCloseEvents API
+func (h *interceptedAdminHandler) ListPostCloseEvents(ctx context.Context, request *admin.ListPostCloseEventsRequest) (*admin.ListPostCloseEventsResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "ListPostCloseEvents"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.ListPostCloseEvents(ctx, request.(*admin.ListPostCloseEventsRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*admin.ListPostCloseEventsResponse), err
+}
+
+// ListTaskListDLQTasks intercepts the ListTaskListDLQTasks API
+func (h *interceptedAdminHandler) ListTaskListDLQTasks(ctx context.Context, request *gen.ListTaskListDLQTasksRequest) (*gen.ListTaskListDLQTasksResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "ListTaskListDLQTasks"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.ListTaskListDLQTasks(ctx, request.(*gen.ListTaskListDLQTasksRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.ListTaskListDLQTasksResponse), err
+}
+
+// RequeueTaskListDLQTasks intercepts the RequeueTaskListDLQTasks API
+func (h *interceptedAdminHandler) RequeueTaskListDLQTasks(ctx context.Context, request *gen.RequeueTaskListDLQTasksRequest) (*gen.RequeueTaskListDLQTasksResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "RequeueTaskListDLQTasks"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.RequeueTaskListDLQTasks(ctx, request.(*gen.RequeueTaskListDLQTasksRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.RequeueTaskListDLQTasksResponse), err
+}
+
+// RefreshDomainCache intercepts the RefreshDomainCache API
+func (h *interceptedAdminHandler) RefreshDomainCache(ctx context.Context, request *gen.RefreshDomainCacheRequest) error {
+	_, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "RefreshDomainCache"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, h.handler.RefreshDomainCache(ctx, request.(*gen.RefreshDomainCacheRequest))
+		})
+	return err
+}
+
+// DescribeTaskListLatency intercepts the DescribeTaskListLatency API
+func (h *interceptedAdminHandler) DescribeTaskListLatency(ctx context.Context, request *gen.DescribeTaskListLatencyRequest) (*gen.DescribeTaskListLatencyResponse, error) {
+	resp, err := h.interceptor(ctx, &RequestInfo{Service: adminServiceName, Method: "DescribeTaskListLatency"}, request,
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			return h.handler.DescribeTaskListLatency(ctx, request.(*gen.DescribeTaskListLatencyRequest))
+		})
+	if resp == nil {
+		return nil, err
+	}
+	return resp.(*gen.DescribeTaskListLatencyResponse), err
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/.gen/go/admin"
+	"github.com/uber/cadence/.gen/go/admin/adminserviceserver"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+type (
+	interceptorSuite struct {
+		suite.Suite
+	}
+
+	stubAdminHandler struct {
+		adminserviceserver.Interface
+		hostRequests   int
+		deleteRequests int
+		lastDelete     *admin.DeleteWorkflowExecutionRequest
+	}
+)
+
+func TestInterceptorSuite(t *testing.T) {
+	s := new(interceptorSuite)
+	suite.Run(t, s)
+}
+
+func (h *stubAdminHandler) DescribeHistoryHost(ctx context.Context,
+	request *shared.DescribeHistoryHostRequest) (*shared.DescribeHistoryHostResponse, error) {
+	h.hostRequests++
+	return &shared.DescribeHistoryHostResponse{NumberOfShards: common.Int32Ptr(4)}, nil
+}
+
+func (h *stubAdminHandler) DeleteWorkflowExecution(ctx context.Context, request *admin.DeleteWorkflowExecutionRequest) error {
+	h.deleteRequests++
+	h.lastDelete = request
+	return nil
+}
+
+func (s *interceptorSuite) TestChainOrder() {
+	var calls []string
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, info *RequestInfo, request interface{}, handler RequestHandler) (interface{}, error) {
+			calls = append(calls, "pre-"+name+"-"+info.Method)
+			resp, err := handler(ctx, request)
+			calls = append(calls, "post-"+name)
+			return resp, err
+		}
+	}
+	stub := &stubAdminHandler{}
+	handler := newInterceptedAdminHandler(stub, []Interceptor{record("a"), record("b")})
+
+	resp, err := handler.DescribeHistoryHost(context.Background(), &shared.DescribeHistoryHostRequest{})
+	s.NoError(err)
+	s.Equal(int32(4), resp.GetNumberOfShards())
+	s.Equal(1, stub.hostRequests)
+	s.Equal([]string{"pre-a-DescribeHistoryHost", "pre-b-DescribeHistoryHost", "post-b", "post-a"}, calls)
+}
+
+func (s *interceptorSuite) TestRejectCall() {
+	errDenied := &shared.BadRequestError{Message: "denied"}
+	var info *RequestInfo
+	deny := func(ctx context.Context, i *RequestInfo, request interface{}, handler RequestHandler) (interface{}, error) {
+		info = i
+		return nil, errDenied
+	}
+	stub := &stubAdminHandler{}
+	handler := newInterceptedAdminHandler(stub, []Interceptor{deny})
+
+	resp, err := handler.DescribeHistoryHost(context.Background(), &shared.DescribeHistoryHostRequest{})
+	s.Nil(resp)
+	s.Equal(errDenied, err)
+	s.Equal(0, stub.hostRequests)
+	s.Equal(adminServiceName, info.Service)
+
+	err = handler.DeleteWorkflowExecution(context.Background(), &admin.DeleteWorkflowExecutionRequest{})
+	s.Equal(errDenied, err)
+	s.Equal(0, stub.deleteRequests)
+	s.Equal("DeleteWorkflowExecution", info.Method)
+}
+
+func (s *interceptorSuite) TestReplaceRequestAndError() {
+	errAudit := errors.New("audit failed")
+	replace := func(ctx context.Context, info *RequestInfo, request interface{}, handler RequestHandler) (interface{}, error) {
+		_, err := handler(ctx, &admin.DeleteWorkflowExecutionRequest{Domain: common.StringPtr("replaced")})
+		s.NoError(err)
+		return nil, errAudit
+	}
+	stub := &stubAdminHandler{}
+	handler := newInterceptedAdminHandler(stub, []Interceptor{replace})
+
+	err := handler.DeleteWorkflowExecution(context.Background(), &admin.DeleteWorkflowExecutionRequest{})
+	s.Equal(errAudit, err)
+	s.Equal(1, stub.deleteRequests)
+	s.Equal("replaced", stub.lastDelete.GetDomain())
+}
+
+func (s *interceptorSuite) TestNoInterceptors() {
+	stub := &stubAdminHandler{}
+	s.Equal(stub, newInterceptedAdminHandler(stub, nil))
+	wh := &WorkflowHandler{}
+	s.Equal(wh, newInterceptedWorkflowHandler(wh, nil))
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+const (
+	// pageTokenVersion is the version of the signed page token envelope, tokens of any other version are rejected
+	pageTokenVersion = 1
+
+	historyPageTokenStore          = "history"
+	openVisibilityPageTokenStore   = "visibility.open"
+	closedVisibilityPageTokenStore = "visibility.closed"
+	allVisibilityPageTokenStore    = "visibility.all"
+)
+
+type (
+	// pageTokenSigner wraps the page tokens handed out to clients in a versioned envelope signed with
+	// an HMAC, so a token which is tampered with, expired, or issued by another store or domain is
+	// rejected with a BadRequestError instead of being passed down to persistence. Tokens are neither
+	// signed nor verified while the signing key is empty, a key-less HMAC could be forged by anyone.
+	pageTokenSigner struct {
+		signingKey     dynamicconfig.StringPropertyFn
+		ttl            dynamicconfig.DurationPropertyFn
+		acceptUnsigned dynamicconfig.BoolPropertyFn
+		timeSource     common.TimeSource
+	}
+
+	signedPageToken struct {
+		Version   int
+		Store     string
+		DomainID  string
+		ExpiresAt int64
+		Token     []byte
+		Signature []byte
+	}
+)
+
+var (
+	errPageTokenExpired       = &gen.BadRequestError{Message: "NextPageToken has expired."}
+	errPageTokenStoreMismatch = &gen.BadRequestError{Message: "NextPageToken was not issued for this request."}
+)
+
+func newPageTokenSigner(signingKey dynamicconfig.StringPropertyFn, ttl dynamicconfig.DurationPropertyFn,
+	acceptUnsigned dynamicconfig.BoolPropertyFn, timeSource common.TimeSource) *pageTokenSigner {
+	return &pageTokenSigner{
+		signingKey:     signingKey,
+		ttl:            ttl,
+		acceptUnsigned: acceptUnsigned,
+		timeSource:     timeSource,
+	}
+}
+
+// sign wraps the token of the given store and domain, an empty token stays empty since it marks the last page
+func (s *pageTokenSigner) sign(store string, domainID string, token []byte) ([]byte, error) {
+	if len(token) == 0 || s.signingKey() == "" {
+		return token, nil
+	}
+
+	signed := &signedPageToken{
+		Version:   pageTokenVersion,
+		Store:     store,
+		DomainID:  domainID,
+		ExpiresAt: s.timeSource.Now().Add(s.ttl()).UnixNano(),
+		Token:     token,
+	}
+	signed.Signature = s.signature(signed)
+	return json.Marshal(signed)
+}
+
+// verify returns the token wrapped by sign, after checking it was signed for the given store and domain. An
+// unsigned token, handed out before signing was enabled, is returned as is while unsigned tokens are accepted.
+func (s *pageTokenSigner) verify(store string, domainID string, token []byte) ([]byte, error) {
+	if len(token) == 0 || s.signingKey() == "" {
+		return token, nil
+	}
+
+	if isUnsignedPageToken(token) {
+		if s.acceptUnsigned() {
+			return token, nil
+		}
+		return nil, errInvalidNextPageToken
+	}
+
+	signed := &signedPageToken{}
+	if err := json.Unmarshal(token, signed); err != nil {
+		return nil, errInvalidNextPageToken
+	}
+	if signed.Version != pageTokenVersion || !hmac.Equal(signed.Signature, s.signature(signed)) {
+		return nil, errInvalidNextPageToken
+	}
+	if signed.Store != store || signed.DomainID != domainID {
+		return nil, errPageTokenStoreMismatch
+	}
+	if s.timeSource.Now().UnixNano() > signed.ExpiresAt {
+		return nil, errPageTokenExpired
+	}
+	return signed.Token, nil
+}
+
+func (s *pageTokenSigner) signature(signed *signedPageToken) []byte {
+	mac := hmac.New(sha256.New, []byte(s.signingKey()))
+	// strings are length prefixed so fields cannot be shifted into one another
+	for _, field := range []string{signed.Store, signed.DomainID, string(signed.Token)} {
+		binary.Write(mac, binary.BigEndian, int64(len(field)))
+		mac.Write([]byte(field))
+	}
+	binary.Write(mac, binary.BigEndian, int64(signed.Version))
+	binary.Write(mac, binary.BigEndian, signed.ExpiresAt)
+	return mac.Sum(nil)
+}
+
+// isUnsignedPageToken is true for a token without the envelope version, only the version is decoded so a
+// tampered envelope is still rejected rather than taken for an unsigned token
+func isUnsignedPageToken(token []byte) bool {
+	envelope := struct{ Version int }{}
+	return json.Unmarshal(token, &envelope) != nil || envelope.Version == 0
+}
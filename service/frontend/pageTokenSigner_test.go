@@ -0,0 +1,124 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+func newTestPageTokenSigner(key string, timeSource common.TimeSource) *pageTokenSigner {
+	return newPageTokenSigner(
+		func(opts ...dynamicconfig.FilterOption) string { return key },
+		dynamicconfig.GetDurationPropertyFn(time.Hour),
+		dynamicconfig.GetBoolPropertyFn(false),
+		timeSource,
+	)
+}
+
+func TestPageTokenSigner_SignVerify(t *testing.T) {
+	signer := newTestPageTokenSigner("key", common.NewRealTimeSource())
+
+	signed, err := signer.sign(historyPageTokenStore, "domain", []byte("token"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, []byte("token"), signed)
+
+	token, err := signer.verify(historyPageTokenStore, "domain", signed)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("token"), token)
+
+	// the last page has no token
+	signed, err = signer.sign(historyPageTokenStore, "domain", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, signed)
+	token, err = signer.verify(historyPageTokenStore, "domain", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+}
+
+func TestPageTokenSigner_Rejected(t *testing.T) {
+	timeSource := common.NewEventTimeSource().Update(time.Now())
+	signer := newTestPageTokenSigner("key", timeSource)
+	signed, err := signer.sign(openVisibilityPageTokenStore, "domain", []byte("token"))
+	assert.NoError(t, err)
+
+	_, err = signer.verify(openVisibilityPageTokenStore, "domain", []byte("token"))
+	assert.Equal(t, errInvalidNextPageToken, err)
+
+	tampered := append([]byte{}, signed...)
+	tampered[len(tampered)-5]++
+	_, err = signer.verify(openVisibilityPageTokenStore, "domain", tampered)
+	assert.Equal(t, errInvalidNextPageToken, err)
+
+	_, err = newTestPageTokenSigner("another key", timeSource).verify(openVisibilityPageTokenStore, "domain", signed)
+	assert.Equal(t, errInvalidNextPageToken, err)
+
+	_, err = signer.verify(closedVisibilityPageTokenStore, "domain", signed)
+	assert.Equal(t, errPageTokenStoreMismatch, err)
+	_, err = signer.verify(openVisibilityPageTokenStore, "another domain", signed)
+	assert.Equal(t, errPageTokenStoreMismatch, err)
+
+	timeSource.Update(timeSource.Now().Add(2 * time.Hour))
+	_, err = signer.verify(openVisibilityPageTokenStore, "domain", signed)
+	assert.Equal(t, errPageTokenExpired, err)
+}
+
+func TestPageTokenSigner_NoSigningKey(t *testing.T) {
+	signer := newTestPageTokenSigner("", common.NewRealTimeSource())
+
+	signed, err := signer.sign(historyPageTokenStore, "domain", []byte("token"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("token"), signed)
+
+	token, err := signer.verify(historyPageTokenStore, "domain", []byte("token"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("token"), token)
+}
+
+func TestPageTokenSigner_AcceptUnsigned(t *testing.T) {
+	signer := newTestPageTokenSigner("key", common.NewRealTimeSource())
+	signer.acceptUnsigned = dynamicconfig.GetBoolPropertyFn(true)
+
+	// raw and json tokens handed out before signing was enabled
+	for _, unsigned := range [][]byte{[]byte("token"), []byte(`{"RunID":"rid","NextEventID":5}`)} {
+		token, err := signer.verify(historyPageTokenStore, "domain", unsigned)
+		assert.NoError(t, err)
+		assert.Equal(t, unsigned, token)
+	}
+
+	// signed tokens are still verified
+	signed, err := signer.sign(historyPageTokenStore, "domain", []byte("token"))
+	assert.NoError(t, err)
+	_, err = signer.verify(historyPageTokenStore, "another domain", signed)
+	assert.Equal(t, errPageTokenStoreMismatch, err)
+	tampered := append([]byte{}, signed...)
+	tampered[len(tampered)-5]++
+	_, err = signer.verify(historyPageTokenStore, "domain", tampered)
+	assert.Equal(t, errInvalidNextPageToken, err)
+
+	signer.acceptUnsigned = dynamicconfig.GetBoolPropertyFn(false)
+	_, err = signer.verify(historyPageTokenStore, "domain", []byte(`{"RunID":"rid","NextEventID":5}`))
+	assert.Equal(t, errInvalidNextPageToken, err)
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/uber-common/bark"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/compression"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"go.uber.org/yarpc"
+)
+
+// compressedResponseScopes are the metric scopes of the APIs whose response payloads can be compressed
+var compressedResponseScopes = map[string]int{
+	"GetWorkflowExecutionHistory": metrics.FrontendGetWorkflowExecutionHistoryScope,
+	"PollForDecisionTask":         metrics.FrontendPollForDecisionTaskScope,
+	"PollForActivityTask":         metrics.FrontendPollForActivityTaskScope,
+}
+
+// newPayloadCompressionInterceptor returns an interceptor compressing the payloads of the history pages and
+// activity inputs returned to clients accepting gzip encoded payloads, once their size reaches the threshold.
+// The encoding is reported to the client in the payload encoding response header.
+func newPayloadCompressionInterceptor(threshold dynamicconfig.IntPropertyFn, metricsClient metrics.Client,
+	logger bark.Logger) Interceptor {
+	return func(ctx context.Context, info *RequestInfo, request interface{}, handler RequestHandler) (interface{}, error) {
+		scope, ok := compressedResponseScopes[info.Method]
+		if !ok || info.Service != workflowServiceName || !acceptsGzipPayloads(ctx) {
+			return handler(ctx, request)
+		}
+		response, err := handler(ctx, request)
+		if err != nil || response == nil {
+			return response, err
+		}
+
+		compressed, size, compressedSize, cerr := compressResponsePayloads(response, threshold())
+		if cerr != nil {
+			logger.WithField(logging.TagErr, cerr).Warnf("Failed to compress %v response payloads.", info.Method)
+			return response, nil
+		}
+		if compressed == nil {
+			return response, nil
+		}
+		if werr := yarpc.CallFromContext(ctx).WriteResponseHeader(common.PayloadEncodingHeaderName, compression.EncodingGzip); werr != nil {
+			logger.WithField(logging.TagErr, werr).Warnf("Failed to write %v payload encoding header.", info.Method)
+			return response, nil
+		}
+		metricsClient.IncCounter(scope, metrics.PayloadCompressedCounter)
+		metricsClient.AddCounter(scope, metrics.PayloadCompressionSavedBytes, int64(size-compressedSize))
+		return compressed, nil
+	}
+}
+
+// acceptsGzipPayloads is true when the caller listed gzip in its accepted payload encodings. Forwarded history
+// reads are never compressed, the forwarding host compresses the response for its own caller.
+func acceptsGzipPayloads(ctx context.Context) bool {
+	call := yarpc.CallFromContext(ctx)
+	if call == nil || call.Header(historyReadForwardedHeaderName) != "" {
+		return false
+	}
+	for _, encoding := range strings.Split(call.Header(common.AcceptPayloadEncodingHeaderName), ",") {
+		if strings.TrimSpace(encoding) == compression.EncodingGzip {
+			return true
+		}
+	}
+	return false
+}
+
+// compressResponsePayloads returns a copy of the response with its payloads compressed, together with the
+// payload size before and after compression. The returned response is nil when the payloads are smaller than
+// the threshold, or the threshold is not positive.
+func compressResponsePayloads(response interface{}, threshold int) (interface{}, int, int, error) {
+	if threshold <= 0 {
+		return nil, 0, 0, nil
+	}
+
+	switch r := response.(type) {
+	case *gen.GetWorkflowExecutionHistoryResponse:
+		size := compression.HistoryPayloadSize(r.History)
+		if size < threshold {
+			return nil, 0, 0, nil
+		}
+		history, err := compression.CompressHistory(r.History)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		compressed := *r
+		compressed.History = history
+		return &compressed, size, compression.HistoryPayloadSize(history), nil
+	case *gen.PollForDecisionTaskResponse:
+		size := compression.HistoryPayloadSize(r.History)
+		if size < threshold {
+			return nil, 0, 0, nil
+		}
+		history, err := compression.CompressHistory(r.History)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		compressed := *r
+		compressed.History = history
+		return &compressed, size, compression.HistoryPayloadSize(history), nil
+	case *gen.PollForActivityTaskResponse:
+		size := len(r.Input)
+		if size < threshold {
+			return nil, 0, 0, nil
+		}
+		input, err := compression.Compress(r.Input)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		compressed := *r
+		compressed.Input = input
+		return &compressed, size, len(input), nil
+	}
+	return nil, 0, 0, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/compression"
+)
+
+func TestCompressResponsePayloadsHistory(t *testing.T) {
+	input := bytes.Repeat([]byte("input"), 100)
+	response := &gen.GetWorkflowExecutionHistoryResponse{
+		History: &gen.History{Events: []*gen.HistoryEvent{{
+			EventId:   common.Int64Ptr(1),
+			EventType: gen.EventTypeWorkflowExecutionStarted.Ptr(),
+			WorkflowExecutionStartedEventAttributes: &gen.WorkflowExecutionStartedEventAttributes{
+				Input: input,
+			},
+		}}},
+		NextPageToken: []byte("token"),
+	}
+
+	compressed, _, _, err := compressResponsePayloads(response, 0)
+	assert.NoError(t, err)
+	assert.Nil(t, compressed)
+	compressed, _, _, err = compressResponsePayloads(response, len(input)+1)
+	assert.NoError(t, err)
+	assert.Nil(t, compressed)
+
+	compressed, size, compressedSize, err := compressResponsePayloads(response, len(input))
+	assert.NoError(t, err)
+	assert.Equal(t, len(input), size)
+	assert.True(t, compressedSize < size)
+	assert.Equal(t, input, response.History.Events[0].WorkflowExecutionStartedEventAttributes.Input)
+
+	compressedResponse := compressed.(*gen.GetWorkflowExecutionHistoryResponse)
+	assert.Equal(t, response.NextPageToken, compressedResponse.NextPageToken)
+	history, err := compression.DecompressHistory(compressedResponse.History)
+	assert.NoError(t, err)
+	assert.Equal(t, response.History, history)
+}
+
+func TestCompressResponsePayloadsActivityInput(t *testing.T) {
+	input := bytes.Repeat([]byte("input"), 100)
+	response := &gen.PollForActivityTaskResponse{
+		ActivityId: common.StringPtr("activity"),
+		Input:      input,
+	}
+
+	compressed, size, compressedSize, err := compressResponsePayloads(response, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, len(input), size)
+	assert.Equal(t, compressedSize, len(compressed.(*gen.PollForActivityTaskResponse).Input))
+	assert.Equal(t, input, response.Input)
+
+	decompressed, err := compression.Decompress(compressed.(*gen.PollForActivityTaskResponse).Input)
+	assert.NoError(t, err)
+	assert.Equal(t, input, decompressed)
+	assert.Equal(t, "activity", compressed.(*gen.PollForActivityTaskResponse).GetActivityId())
+
+	compressed, _, _, err = compressResponsePayloads(&gen.DescribeDomainResponse{}, 10)
+	assert.NoError(t, err)
+	assert.Nil(t, compressed)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"fmt"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+)
+
+// newPendingDomainInterceptor returns an interceptor rejecting the workflow API calls of the domains
+// still pending registration approval or whose registration was rejected, the domain APIs keep working so the owner
+// can follow the registration
+func newPendingDomainInterceptor(domainCache cache.DomainCache) Interceptor {
+	return func(ctx context.Context, info *RequestInfo, request interface{}, handler RequestHandler) (interface{}, error) {
+		if info.Service != workflowServiceName {
+			return handler(ctx, request)
+		}
+		r, ok := request.(domainGetter)
+		if !ok || r.GetDomain() == "" {
+			return handler(ctx, request)
+		}
+		// lookup failures are left to the handler, which reports them the same way
+		entry, err := domainCache.GetDomain(r.GetDomain())
+		if err != nil {
+			return handler(ctx, request)
+		}
+		switch {
+		case entry.GetInfo().Status == persistence.DomainStatusPendingApproval:
+			return nil, &gen.BadRequestError{
+				Message: fmt.Sprintf("Domain %v is pending registration approval.", r.GetDomain()),
+			}
+		case isRejectedDomain(entry.GetInfo()):
+			return nil, &gen.BadRequestError{
+				Message: fmt.Sprintf("Domain %v registration was rejected: %v.", r.GetDomain(),
+					entry.GetInfo().Data[persistence.DomainDataKeyRegistrationRejectedReason]),
+			}
+		}
+		return handler(ctx, request)
+	}
+}
+
+// isRejectedDomain returns whether the domain was deleted by the rejection of its registration
+func isRejectedDomain(info *persistence.DomainInfo) bool {
+	if info.Status != persistence.DomainStatusDeleted {
+		return false
+	}
+	_, ok := info.Data[persistence.DomainDataKeyRegistrationRejectedReason]
+	return ok
+}
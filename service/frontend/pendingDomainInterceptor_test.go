@@ -0,0 +1,71 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+)
+
+func TestPendingDomainInterceptor(t *testing.T) {
+	pending := cache.CreateDomainCacheEntry("pending")
+	pending.GetInfo().Status = persistence.DomainStatusPendingApproval
+	rejected := cache.CreateDomainCacheEntry("rejected")
+	rejected.GetInfo().Status = persistence.DomainStatusDeleted
+	rejected.GetInfo().Data = map[string]string{persistence.DomainDataKeyRegistrationRejectedReason: "some reason"}
+	deleted := cache.CreateDomainCacheEntry("deleted")
+	deleted.GetInfo().Status = persistence.DomainStatusDeleted
+	domainCache := &cache.DomainCacheMock{}
+	domainCache.On("GetDomain", "pending").Return(pending, nil)
+	domainCache.On("GetDomain", "rejected").Return(rejected, nil)
+	domainCache.On("GetDomain", "deleted").Return(deleted, nil)
+	domainCache.On("GetDomain", "registered").Return(cache.CreateDomainCacheEntry("registered"), nil)
+	domainCache.On("GetDomain", "unknown").Return(nil, &shared.EntityNotExistsError{})
+
+	interceptor := newPendingDomainInterceptor(domainCache)
+	call := func(service string, request interface{}) error {
+		info := &RequestInfo{Service: service, Method: "StartWorkflowExecution"}
+		_, err := interceptor(context.Background(), info, request, func(ctx context.Context, request interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+	request := func(domain string) interface{} {
+		return &shared.StartWorkflowExecutionRequest{Domain: common.StringPtr(domain)}
+	}
+
+	assert.IsType(t, &shared.BadRequestError{}, call(workflowServiceName, request("pending")))
+	assert.NoError(t, call(workflowServiceName, &shared.DescribeDomainRequest{Name: common.StringPtr("pending")}))
+	err := call(workflowServiceName, request("rejected"))
+	if assert.IsType(t, &shared.BadRequestError{}, err) {
+		assert.Contains(t, err.(*shared.BadRequestError).Message, "some reason")
+	}
+	assert.NoError(t, call(workflowServiceName, request("deleted")))
+	assert.NoError(t, call(adminServiceName, request("pending")))
+	assert.NoError(t, call(workflowServiceName, request("registered")))
+	assert.NoError(t, call(workflowServiceName, request("unknown")))
+}
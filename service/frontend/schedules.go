@@ -0,0 +1,205 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"fmt"
+	"time"
+
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	// defaultListSchedulesPageSize is the page size of the ListSchedules requests which do not set one
+	defaultListSchedulesPageSize = 100
+	// updateScheduleMaxAttempts bounds the attempts of a schedule update racing with the schedule processor
+	updateScheduleMaxAttempts = 3
+)
+
+var (
+	errScheduleIDNotSet           = &gen.BadRequestError{Message: "ScheduleId is not set on request."}
+	errScheduleSpecNotSet         = &gen.BadRequestError{Message: "Spec is not set on request."}
+	errScheduleActionNotSet       = &gen.BadRequestError{Message: "Action is not set on request."}
+	errInvalidScheduleOffset      = &gen.BadRequestError{Message: "OffsetInSeconds cannot be negative and must be smaller than IntervalInSeconds."}
+	errInvalidScheduleEndTime     = &gen.BadRequestError{Message: "EndTime must be after StartTime."}
+	errInvalidScheduleOverlap     = &gen.BadRequestError{Message: "Invalid OverlapPolicy."}
+	errScheduleNotDue             = &gen.BadRequestError{Message: "Schedule has no run due in the future."}
+	errScheduleAlreadyExists      = &gen.BadRequestError{Message: "Schedule already exists."}
+	errScheduleGlobalDomain       = &gen.BadRequestError{Message: "Schedules are not replicated, they cannot be created in a global domain."}
+	errScheduleUpdateConflict     = &gen.ServiceBusyError{Message: "Schedule was updated concurrently, retry the update."}
+	errScheduleWorkflowTypeNotSet = &gen.BadRequestError{Message: "WorkflowType is not set on request."}
+)
+
+// validateScheduleSpec checks the spec of a schedule, whose runs cannot be closer than minInterval
+func validateScheduleSpec(spec *gen.ScheduleSpec, minInterval time.Duration) error {
+	if spec == nil {
+		return errScheduleSpecNotSet
+	}
+	interval := time.Duration(spec.GetIntervalInSeconds()) * time.Second
+	if interval <= 0 || interval < minInterval {
+		return &gen.BadRequestError{Message: fmt.Sprintf("IntervalInSeconds must be at least %v.", minInterval)}
+	}
+	if spec.GetOffsetInSeconds() < 0 || spec.GetOffsetInSeconds() >= spec.GetIntervalInSeconds() {
+		return errInvalidScheduleOffset
+	}
+	if spec.StartTime != nil && spec.EndTime != nil && spec.GetEndTime() <= spec.GetStartTime() {
+		return errInvalidScheduleEndTime
+	}
+	return nil
+}
+
+// validateScheduleAction checks the workflow started by a schedule the same way StartWorkflowExecution does
+func validateScheduleAction(action *gen.ScheduleAction, maxDecisionTimeout int32) error {
+	if action == nil {
+		return errScheduleActionNotSet
+	}
+	if action.WorkflowType == nil || action.WorkflowType.GetName() == "" {
+		return errScheduleWorkflowTypeNotSet
+	}
+	if action.TaskList == nil || action.TaskList.GetName() == "" {
+		return errTaskListNotSet
+	}
+	if action.GetExecutionStartToCloseTimeoutSeconds() <= 0 {
+		return &gen.BadRequestError{Message: "A valid ExecutionStartToCloseTimeoutSeconds is not set on request."}
+	}
+	if action.GetTaskStartToCloseTimeoutSeconds() <= 0 {
+		return &gen.BadRequestError{Message: "A valid TaskStartToCloseTimeoutSeconds is not set on request."}
+	}
+	if action.GetTaskStartToCloseTimeoutSeconds() > action.GetExecutionStartToCloseTimeoutSeconds() ||
+		action.GetTaskStartToCloseTimeoutSeconds() > maxDecisionTimeout {
+		return &gen.BadRequestError{
+			Message: fmt.Sprintf("TaskStartToCloseTimeoutSeconds is larger than ExecutionStartToCloseTimeout or MaxDecisionStartToCloseTimeout (%ds).", maxDecisionTimeout)}
+	}
+	return nil
+}
+
+func validateScheduleOverlapPolicy(policy *gen.ScheduleOverlapPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	switch *policy {
+	case gen.ScheduleOverlapPolicySkip, gen.ScheduleOverlapPolicyBuffer, gen.ScheduleOverlapPolicyCancelOther:
+		return nil
+	default:
+		return errInvalidScheduleOverlap
+	}
+}
+
+func setScheduleSpec(schedule *persistence.ScheduleInfo, spec *gen.ScheduleSpec) {
+	schedule.IntervalInSeconds = spec.GetIntervalInSeconds()
+	schedule.OffsetInSeconds = spec.GetOffsetInSeconds()
+	schedule.StartTime = time.Time{}
+	if spec.StartTime != nil {
+		schedule.StartTime = time.Unix(0, spec.GetStartTime())
+	}
+	schedule.EndTime = time.Time{}
+	if spec.EndTime != nil {
+		schedule.EndTime = time.Unix(0, spec.GetEndTime())
+	}
+}
+
+func setScheduleAction(schedule *persistence.ScheduleInfo, action *gen.ScheduleAction) {
+	schedule.WorkflowIDPrefix = action.GetWorkflowIdPrefix()
+	schedule.WorkflowTypeName = action.WorkflowType.GetName()
+	schedule.TaskList = action.TaskList.GetName()
+	schedule.Input = action.Input
+	schedule.ExecutionStartToCloseTimeoutSeconds = action.GetExecutionStartToCloseTimeoutSeconds()
+	schedule.TaskStartToCloseTimeoutSeconds = action.GetTaskStartToCloseTimeoutSeconds()
+}
+
+// applyScheduleUpdate sets the fields of the update request on the schedule, the next run is computed
+// again from now when the spec changes or the schedule is unpaused
+func applyScheduleUpdate(schedule *persistence.ScheduleInfo, request *gen.UpdateScheduleRequest, now time.Time) error {
+	reschedule := false
+	if request.Spec != nil {
+		setScheduleSpec(schedule, request.Spec)
+		reschedule = true
+	}
+	if request.Action != nil {
+		setScheduleAction(schedule, request.Action)
+	}
+	if request.OverlapPolicy != nil {
+		schedule.OverlapPolicy = int(request.GetOverlapPolicy())
+	}
+	if request.Paused != nil {
+		reschedule = reschedule || (schedule.Paused && !request.GetPaused())
+		schedule.Paused = request.GetPaused()
+	}
+
+	if reschedule {
+		schedule.NextRunTime = schedule.GetNextRunTime(now)
+		if schedule.NextRunTime.IsZero() {
+			return errScheduleNotDue
+		}
+	}
+	return nil
+}
+
+func createDescribeScheduleResponse(schedule *persistence.ScheduleInfo) *gen.DescribeScheduleResponse {
+	spec := &gen.ScheduleSpec{
+		IntervalInSeconds: common.Int64Ptr(schedule.IntervalInSeconds),
+		OffsetInSeconds:   common.Int64Ptr(schedule.OffsetInSeconds),
+	}
+	if !schedule.StartTime.IsZero() {
+		spec.StartTime = common.Int64Ptr(schedule.StartTime.UnixNano())
+	}
+	if !schedule.EndTime.IsZero() {
+		spec.EndTime = common.Int64Ptr(schedule.EndTime.UnixNano())
+	}
+
+	action := &gen.ScheduleAction{
+		WorkflowIdPrefix:                    common.StringPtr(schedule.WorkflowIDPrefix),
+		WorkflowType:                        &gen.WorkflowType{Name: common.StringPtr(schedule.WorkflowTypeName)},
+		TaskList:                            &gen.TaskList{Name: common.StringPtr(schedule.TaskList)},
+		Input:                               schedule.Input,
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(schedule.ExecutionStartToCloseTimeoutSeconds),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(schedule.TaskStartToCloseTimeoutSeconds),
+	}
+
+	state := &gen.ScheduleState{
+		Paused: common.BoolPtr(schedule.Paused),
+	}
+	if !schedule.NextRunTime.IsZero() {
+		state.NextRunTime = common.Int64Ptr(schedule.NextRunTime.UnixNano())
+	}
+	if !schedule.LastRunTime.IsZero() {
+		state.LastRunTime = common.Int64Ptr(schedule.LastRunTime.UnixNano())
+	}
+	if schedule.LastWorkflowID != "" {
+		state.LastExecution = &gen.WorkflowExecution{
+			WorkflowId: common.StringPtr(schedule.LastWorkflowID),
+			RunId:      common.StringPtr(schedule.LastRunID),
+		}
+	}
+	for _, runTime := range schedule.BufferedRunTimes {
+		state.BufferedRunTimes = append(state.BufferedRunTimes, runTime.UnixNano())
+	}
+
+	return &gen.DescribeScheduleResponse{
+		ScheduleId:    common.StringPtr(schedule.ScheduleID),
+		Spec:          spec,
+		Action:        action,
+		OverlapPolicy: gen.ScheduleOverlapPolicy(schedule.OverlapPolicy).Ptr(),
+		State:         state,
+	}
+}
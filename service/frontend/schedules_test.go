@@ -0,0 +1,107 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+)
+
+func TestValidateScheduleSpec(t *testing.T) {
+	assert.Equal(t, errScheduleSpecNotSet, validateScheduleSpec(nil, time.Minute))
+
+	spec := &gen.ScheduleSpec{IntervalInSeconds: common.Int64Ptr(30)}
+	assert.IsType(t, &gen.BadRequestError{}, validateScheduleSpec(spec, time.Minute))
+
+	spec.IntervalInSeconds = common.Int64Ptr(60)
+	assert.NoError(t, validateScheduleSpec(spec, time.Minute))
+
+	spec.OffsetInSeconds = common.Int64Ptr(60)
+	assert.Equal(t, errInvalidScheduleOffset, validateScheduleSpec(spec, time.Minute))
+
+	spec.OffsetInSeconds = common.Int64Ptr(15)
+	spec.StartTime = common.Int64Ptr(100)
+	spec.EndTime = common.Int64Ptr(100)
+	assert.Equal(t, errInvalidScheduleEndTime, validateScheduleSpec(spec, time.Minute))
+}
+
+func TestApplyScheduleUpdate(t *testing.T) {
+	now := time.Unix(1000, 0)
+	schedule := &persistence.ScheduleInfo{
+		IntervalInSeconds: 60,
+		NextRunTime:       time.Unix(60, 0),
+		Paused:            true,
+	}
+
+	// pausing and policy changes do not move the next run
+	assert.NoError(t, applyScheduleUpdate(schedule, &gen.UpdateScheduleRequest{
+		OverlapPolicy: gen.ScheduleOverlapPolicyBuffer.Ptr(),
+	}, now))
+	assert.Equal(t, int(gen.ScheduleOverlapPolicyBuffer), schedule.OverlapPolicy)
+	assert.Equal(t, time.Unix(60, 0), schedule.NextRunTime)
+
+	// unpausing skips the runs missed while paused
+	assert.NoError(t, applyScheduleUpdate(schedule, &gen.UpdateScheduleRequest{
+		Paused: common.BoolPtr(false),
+	}, now))
+	assert.False(t, schedule.Paused)
+	assert.Equal(t, time.Unix(1020, 0), schedule.NextRunTime)
+
+	assert.NoError(t, applyScheduleUpdate(schedule, &gen.UpdateScheduleRequest{
+		Spec: &gen.ScheduleSpec{IntervalInSeconds: common.Int64Ptr(300), OffsetInSeconds: common.Int64Ptr(10)},
+	}, now))
+	assert.Equal(t, time.Unix(1210, 0), schedule.NextRunTime)
+
+	err := applyScheduleUpdate(schedule, &gen.UpdateScheduleRequest{
+		Spec: &gen.ScheduleSpec{IntervalInSeconds: common.Int64Ptr(60), EndTime: common.Int64Ptr(now.UnixNano())},
+	}, now)
+	assert.Equal(t, errScheduleNotDue, err)
+}
+
+func TestCreateDescribeScheduleResponse(t *testing.T) {
+	schedule := &persistence.ScheduleInfo{
+		ScheduleID:        "nightly",
+		IntervalInSeconds: 86400,
+		StartTime:         time.Unix(0, 100),
+		WorkflowTypeName:  "report",
+		TaskList:          "reports",
+		OverlapPolicy:     int(gen.ScheduleOverlapPolicyCancelOther),
+		NextRunTime:       time.Unix(86400, 0),
+		LastWorkflowID:    "nightly-1970-01-01T00:00:00Z",
+		LastRunID:         "run",
+		BufferedRunTimes:  []time.Time{time.Unix(1, 0)},
+	}
+
+	resp := createDescribeScheduleResponse(schedule)
+	assert.Equal(t, "nightly", resp.GetScheduleId())
+	assert.Equal(t, int64(100), resp.Spec.GetStartTime())
+	assert.Nil(t, resp.Spec.EndTime)
+	assert.Equal(t, "report", resp.Action.WorkflowType.GetName())
+	assert.Equal(t, gen.ScheduleOverlapPolicyCancelOther, resp.GetOverlapPolicy())
+	assert.Equal(t, time.Unix(86400, 0).UnixNano(), resp.State.GetNextRunTime())
+	assert.Equal(t, "run", resp.State.LastExecution.GetRunId())
+	assert.Equal(t, []int64{time.Unix(1, 0).UnixNano()}, resp.State.BufferedRunTimes)
+}
@@ -21,6 +21,8 @@
 package frontend
 
 import (
+	"time"
+
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/mocks"
@@ -38,19 +40,93 @@ type Config struct {
 
 	// Persistence settings
 	HistoryMgrNumConns dynamicconfig.IntPropertyFn
+	// PersistenceHedgedReadDelay is how long history reads wait before they are hedged
+	PersistenceHedgedReadDelay dynamicconfig.DurationPropertyFn
 
 	MaxDecisionStartToCloseTimeout dynamicconfig.IntPropertyFnWithDomainFilter
+
+	// Concurrency limits of in flight requests, per API name
+	MaxConcurrentRequests         dynamicconfig.MapPropertyFn
+	ConcurrentRequestsWaitTimeout dynamicconfig.DurationPropertyFn
+
+	// ExecutionTagQuotas lists the tag keys a domain may set at start, mapped to the max value length
+	ExecutionTagQuotas dynamicconfig.MapPropertyFnWithDomainFilter
+
+	// Payload size limits in bytes, above which a warning is logged or the request is rejected
+	BlobSizeLimitWarn  dynamicconfig.IntPropertyFnWithDomainFilter
+	BlobSizeLimitError dynamicconfig.IntPropertyFnWithDomainFilter
+
+	// Decision task completions split in chunks, see RespondDecisionTaskCompleted
+	MaxDecisionChunks           dynamicconfig.IntPropertyFn
+	DecisionChunkTimeout        dynamicconfig.DurationPropertyFn
+	DecisionChunkBufferMaxBytes dynamicconfig.IntPropertyFn
+
+	// History reads of a workflow served by the frontend host owning it on the frontend ring, which caches
+	// the pages of closed runs
+	EnableHistoryReadRouting dynamicconfig.BoolPropertyFn
+	HistoryPageCacheSize     dynamicconfig.IntPropertyFn
+	HistoryPageCacheTTL      dynamicconfig.DurationPropertyFn
+
+	// Page tokens handed out to clients are signed with the key and expire after the TTL, changing the key
+	// invalidates the tokens already handed out. Signing is disabled while the key is empty, unsigned tokens
+	// are accepted while AcceptUnsignedPageTokens is set, which is meant for the time signing is rolled out.
+	PageTokenSigningKey      dynamicconfig.StringPropertyFn
+	PageTokenTTL             dynamicconfig.DurationPropertyFn
+	AcceptUnsignedPageTokens dynamicconfig.BoolPropertyFn
+
+	// GlobalDomainRPS is the rate limit of a domain shared by all frontend hosts, which report their usage
+	// every GlobalRatelimiterUpdateInterval to get their share of it
+	GlobalDomainRPS                 dynamicconfig.IntPropertyFnWithDomainFilter
+	GlobalRatelimiterUpdateInterval dynamicconfig.DurationPropertyFn
+
+	// standby task processing lag above which a failover drill reports the cluster as not ready
+	FailoverDrillMaxReplicationLag dynamicconfig.DurationPropertyFn
+
+	// ScheduleMinInterval is the shortest interval between the runs of a schedule
+	ScheduleMinInterval dynamicconfig.DurationPropertyFn
+
+	// PayloadCompressionThreshold is the payload size in bytes of the history pages and activity inputs from
+	// which they are compressed for the clients accepting it
+	PayloadCompressionThreshold dynamicconfig.IntPropertyFn
+
+	// EnableDomainRegistrationApproval registers new domains as pending until the worker approves them
+	EnableDomainRegistrationApproval dynamicconfig.BoolPropertyFn
+
+	// Interceptors are run around every workflow and admin API call, see Interceptor
+	Interceptors []Interceptor
 }
 
 // NewConfig returns new service config with default values
 func NewConfig(dc *dynamicconfig.Collection) *Config {
 	return &Config{
-		PersistenceMaxQPS:              dc.GetFloat64Property(dynamicconfig.FrontendPersistenceMaxQPS, 2000),
-		VisibilityMaxPageSize:          dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendVisibilityMaxPageSize, 1000),
-		HistoryMaxPageSize:             dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendHistoryMaxPageSize, 1000),
-		RPS:                            dc.GetIntProperty(dynamicconfig.FrontendRPS, 1200),
-		HistoryMgrNumConns:             dc.GetIntProperty(dynamicconfig.FrontendHistoryMgrNumConns, 10),
-		MaxDecisionStartToCloseTimeout: dc.GetIntPropertyFilteredByDomain(dynamicconfig.MaxDecisionStartToCloseTimeout, 600),
+		PersistenceMaxQPS:               dc.GetFloat64Property(dynamicconfig.FrontendPersistenceMaxQPS, 2000),
+		VisibilityMaxPageSize:           dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendVisibilityMaxPageSize, 1000),
+		HistoryMaxPageSize:              dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendHistoryMaxPageSize, 1000),
+		RPS:                             dc.GetIntProperty(dynamicconfig.FrontendRPS, 1200),
+		HistoryMgrNumConns:              dc.GetIntProperty(dynamicconfig.FrontendHistoryMgrNumConns, 10),
+		PersistenceHedgedReadDelay:      dc.GetDurationProperty(dynamicconfig.FrontendPersistenceHedgedReadDelay, 0),
+		MaxDecisionStartToCloseTimeout:  dc.GetIntPropertyFilteredByDomain(dynamicconfig.MaxDecisionStartToCloseTimeout, 600),
+		ExecutionTagQuotas:              dc.GetMapPropertyFilteredByDomain(dynamicconfig.FrontendExecutionTagQuotas, map[string]interface{}{}),
+		MaxConcurrentRequests:           dc.GetMapProperty(dynamicconfig.FrontendMaxConcurrentRequests, map[string]interface{}{}),
+		ConcurrentRequestsWaitTimeout:   dc.GetDurationProperty(dynamicconfig.FrontendConcurrentRequestsWaitTimeout, time.Second),
+		BlobSizeLimitWarn:               dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendBlobSizeLimitWarn, 256*1024),
+		BlobSizeLimitError:              dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendBlobSizeLimitError, 2*1024*1024),
+		MaxDecisionChunks:               dc.GetIntProperty(dynamicconfig.FrontendMaxDecisionChunks, 64),
+		DecisionChunkTimeout:            dc.GetDurationProperty(dynamicconfig.FrontendDecisionChunkTimeout, time.Minute),
+		DecisionChunkBufferMaxBytes:     dc.GetIntProperty(dynamicconfig.FrontendDecisionChunkBufferMaxBytes, 64*1024*1024),
+		EnableHistoryReadRouting:        dc.GetBoolProperty(dynamicconfig.FrontendEnableHistoryReadRouting, false),
+		HistoryPageCacheSize:            dc.GetIntProperty(dynamicconfig.FrontendHistoryPageCacheSize, 256),
+		HistoryPageCacheTTL:             dc.GetDurationProperty(dynamicconfig.FrontendHistoryPageCacheTTL, 5*time.Minute),
+		PageTokenSigningKey:             dc.GetStringProperty(dynamicconfig.FrontendPageTokenSigningKey, ""),
+		PageTokenTTL:                    dc.GetDurationProperty(dynamicconfig.FrontendPageTokenTTL, 24*time.Hour),
+		AcceptUnsignedPageTokens:        dc.GetBoolProperty(dynamicconfig.FrontendAcceptUnsignedPageTokens, false),
+		GlobalDomainRPS:                 dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendGlobalDomainRPS, 0),
+		GlobalRatelimiterUpdateInterval: dc.GetDurationProperty(dynamicconfig.GlobalRatelimiterUpdateInterval, 3*time.Second),
+		FailoverDrillMaxReplicationLag:  dc.GetDurationProperty(dynamicconfig.FrontendFailoverDrillMaxReplicationLag, time.Minute),
+		ScheduleMinInterval:             dc.GetDurationProperty(dynamicconfig.FrontendScheduleMinInterval, time.Minute),
+		PayloadCompressionThreshold:     dc.GetIntProperty(dynamicconfig.FrontendPayloadCompressionThreshold, 64*1024),
+
+		EnableDomainRegistrationApproval: dc.GetBoolProperty(dynamicconfig.FrontendEnableDomainRegistrationApproval, false),
 	}
 }
 
@@ -61,11 +137,14 @@ type Service struct {
 	params *service.BootstrapParams
 }
 
-// NewService builds a new cadence-frontend service
-func NewService(params *service.BootstrapParams) common.Daemon {
+// NewService builds a new cadence-frontend service, the given interceptors are run
+// around every API call served by the service
+func NewService(params *service.BootstrapParams, interceptors ...Interceptor) common.Daemon {
+	config := NewConfig(dynamicconfig.NewCollection(params.DynamicConfig, params.Logger))
+	config.Interceptors = interceptors
 	return &Service{
 		params: params,
-		config: NewConfig(dynamicconfig.NewCollection(params.DynamicConfig, params.Logger)),
+		config: config,
 		stopC:  make(chan struct{}),
 	}
 }
@@ -85,8 +164,7 @@ func (s *Service) Start() {
 
 	metadata, err := persistence.NewMetadataManagerProxy(p.CassandraConfig.Hosts,
 		p.CassandraConfig.Port,
-		p.CassandraConfig.User,
-		p.CassandraConfig.Password,
+		p.CassandraCredentials,
 		p.CassandraConfig.Datacenter,
 		p.CassandraConfig.Keyspace,
 		p.ClusterMetadata.GetCurrentClusterName(),
@@ -100,8 +178,7 @@ func (s *Service) Start() {
 
 	visibility, err := persistence.NewCassandraVisibilityPersistence(p.CassandraConfig.Hosts,
 		p.CassandraConfig.Port,
-		p.CassandraConfig.User,
-		p.CassandraConfig.Password,
+		p.CassandraCredentials,
 		p.CassandraConfig.Datacenter,
 		p.CassandraConfig.VisibilityKeyspace,
 		p.Logger)
@@ -112,10 +189,22 @@ func (s *Service) Start() {
 	visibility = persistence.NewVisibilityPersistenceRateLimitedClient(visibility, persistenceRateLimiter, log)
 	visibility = persistence.NewVisibilityPersistenceMetricsClient(visibility, base.GetMetricsClient(), log)
 
+	schedule, err := persistence.NewCassandraSchedulePersistence(p.CassandraConfig.Hosts,
+		p.CassandraConfig.Port,
+		p.CassandraCredentials,
+		p.CassandraConfig.Datacenter,
+		p.CassandraConfig.Keyspace,
+		p.Logger)
+
+	if err != nil {
+		log.Fatalf("failed to create schedule manager: %v", err)
+	}
+	schedule = persistence.NewSchedulePersistenceRateLimitedClient(schedule, persistenceRateLimiter, log)
+	schedule = persistence.NewSchedulePersistenceMetricsClient(schedule, base.GetMetricsClient(), log)
+
 	history, err := persistence.NewCassandraHistoryPersistence(p.CassandraConfig.Hosts,
 		p.CassandraConfig.Port,
-		p.CassandraConfig.User,
-		p.CassandraConfig.Password,
+		p.CassandraCredentials,
 		p.CassandraConfig.Datacenter,
 		p.CassandraConfig.Keyspace,
 		s.config.HistoryMgrNumConns(),
@@ -124,8 +213,21 @@ func (s *Service) Start() {
 	if err != nil {
 		log.Fatalf("Creating Cassandra history manager persistence failed: %v", err)
 	}
+	historyPayload, err := persistence.NewCassandraHistoryPayloadPersistence(p.CassandraConfig.Hosts,
+		p.CassandraConfig.Port,
+		p.CassandraCredentials,
+		p.CassandraConfig.Datacenter,
+		p.CassandraConfig.Keyspace,
+		p.Logger)
+	if err != nil {
+		log.Fatalf("Creating Cassandra history payload persistence failed: %v", err)
+	}
+	// the frontend only reads history, it resolves the payloads deduplicated by the history hosts
+	history = persistence.NewHistoryPersistencePayloadDedupClient(history, historyPayload, nil)
 	history = persistence.NewHistoryPersistenceRateLimitedClient(history, persistenceRateLimiter, log)
 	history = persistence.NewHistoryPersistenceMetricsClient(history, base.GetMetricsClient(), log)
+	history = persistence.NewHistoryPersistenceHedgedClient(history, s.config.PersistenceHedgedReadDelay,
+		base.GetMetricsClient())
 
 	// TODO when global domain is enabled, uncomment the line below and remove the line after
 	var kafkaProducer messaging.Producer
@@ -138,10 +240,35 @@ func (s *Service) Start() {
 		kafkaProducer = &mocks.KafkaProducer{}
 	}
 
-	wfHandler := NewWorkflowHandler(base, s.config, metadata, history, visibility, kafkaProducer)
+	wfHandler := NewWorkflowHandler(base, s.config, metadata, history, visibility, schedule, kafkaProducer)
 	wfHandler.Start()
 
-	adminHandler := NewAdminHandler(base, p.CassandraConfig.NumHistoryShards, metadata)
+	shard, err := persistence.NewCassandraShardPersistence(p.CassandraConfig.Hosts,
+		p.CassandraConfig.Port,
+		p.CassandraCredentials,
+		p.CassandraConfig.Datacenter,
+		p.CassandraConfig.Keyspace,
+		p.ClusterMetadata.GetCurrentClusterName(),
+		p.Logger)
+
+	if err != nil {
+		log.Fatalf("failed to create shard manager: %v", err)
+	}
+	shard = persistence.NewShardPersistenceRateLimitedClient(shard, persistenceRateLimiter, log)
+	shard = persistence.NewShardPersistenceMetricsClient(shard, base.GetMetricsClient(), log)
+
+	postCloseEvents, err := persistence.NewCassandraPostCloseEventsPersistence(p.CassandraConfig.Hosts,
+		p.CassandraConfig.Port,
+		p.CassandraCredentials,
+		p.CassandraConfig.Datacenter,
+		p.CassandraConfig.Keyspace,
+		p.Logger)
+	if err != nil {
+		log.Fatalf("Creating Cassandra post close events persistence failed: %v", err)
+	}
+
+	adminHandler := NewAdminHandler(base, p.CassandraConfig.NumHistoryShards, s.config, metadata, history, shard,
+		postCloseEvents, wfHandler.domainCache, s.config.Interceptors...)
 	adminHandler.Start()
 
 	log.Infof("%v started", common.FrontendServiceName)
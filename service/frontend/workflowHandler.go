@@ -40,6 +40,7 @@ import (
 	m "github.com/uber/cadence/.gen/go/matching"
 	"github.com/uber/cadence/.gen/go/replicator"
 	gen "github.com/uber/cadence/.gen/go/shared"
+	cadenceClient "github.com/uber/cadence/client"
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
@@ -49,7 +50,9 @@ import (
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/serviceerror"
 	"go.uber.org/yarpc/yarpcerrors"
 )
 
@@ -62,16 +65,25 @@ type (
 		metadataMgr        persistence.MetadataManager
 		historyMgr         persistence.HistoryManager
 		visibitiltyMgr     persistence.VisibilityManager
+		scheduleMgr        persistence.ScheduleManager
 		history            history.Client
 		matching           matching.Client
 		matchingRawClient  matching.Client
+		domainRefresher    cadenceClient.DomainCacheRefresher
 		tokenSerializer    common.TaskTokenSerializer
 		hSerializerFactory persistence.HistorySerializerFactory
 		metricsClient      metrics.Client
 		startWG            sync.WaitGroup
 		rateLimiter        common.TokenBucket
+		concurrencyLimiter common.ConcurrencyLimiter
 		config             *Config
 		domainReplicator   DomainReplicator
+		decisionChunks     *decisionChunkBuffer
+		historyReadRouter  *historyReadRouter
+		chunkRouter        *decisionChunkRouter
+		historyPageCache   *historyPageCache
+		pageTokenSigner    *pageTokenSigner
+		domainLimiters     *quotas.GlobalLimiters
 		service.Service
 	}
 
@@ -82,6 +94,10 @@ type (
 		IsWorkflowRunning bool
 		PersistenceToken  []byte
 		TransientDecision *gen.TransientDecisionInfo
+		// LastEventID and LastEventVersion are of the last persisted event returned so far, the next page is
+		// audited to continue from it
+		LastEventID      int64
+		LastEventVersion int64
 	}
 )
 
@@ -102,6 +118,12 @@ var (
 	errQueryNotSet                = &gen.BadRequestError{Message: "WorkflowQuery is not set on request."}
 	errQueryTypeNotSet            = &gen.BadRequestError{Message: "QueryType is not set on request."}
 	errRequestNotSet              = &gen.BadRequestError{Message: "Request is nil."}
+	errTagFilterKeyNotSet         = &gen.BadRequestError{Message: "Key is not set on TagFilter."}
+	errIdentityNotSet             = &gen.BadRequestError{Message: "Identity is not set on request."}
+	errInvalidCurrentLoad         = &gen.BadRequestError{Message: "CurrentLoad cannot be negative."}
+	errInvalidPriority            = &gen.BadRequestError{Message: "Priority cannot be negative."}
+
+	errTooManyConcurrentRequests = &gen.ServiceBusyError{Message: "Too many concurrent requests for this API"}
 
 	// err indicating that this cluster is not the master, so cannot do domain registration or update
 	errNotMasterCluster                = &gen.BadRequestError{Message: "Cluster is not master cluster, cannot do domain registration or domain update."}
@@ -109,25 +131,48 @@ var (
 	errCannotRemoveClustersFromDomain  = &gen.BadRequestError{Message: "Cannot remove existing replicated clusters from a domain."}
 	errActiveClusterNotInClusters      = &gen.BadRequestError{Message: "Active cluster is not contained in all clusters."}
 	errCannotDoDomainFailoverAndUpdate = &gen.BadRequestError{Message: "Cannot set active cluster to current cluster when other parameters are set."}
+	errFailoverDrillOfLocalDomain      = &gen.BadRequestError{Message: "Cannot run a failover drill of a local domain."}
+	errFailoverDrillClusterNotStandby  = &gen.BadRequestError{Message: "Failover drill cluster must be a standby cluster of the domain."}
+
+	// pending domains are not replicated, so registration approval is limited to clusters without global domains
+	errDomainRegistrationApprovalGlobalDomain = &gen.BadRequestError{Message: "Domain registration approval is not supported with global domains."}
 
 	frontendServiceRetryPolicy = common.CreateFrontendServiceRetryPolicy()
 )
 
+// domainUpdateNotificationTimeout bounds the time spent notifying the hosts of a domain update
+const domainUpdateNotificationTimeout = 5 * time.Second
+
 // NewWorkflowHandler creates a thrift handler for the cadence service
 func NewWorkflowHandler(sVice service.Service, config *Config, metadataMgr persistence.MetadataManager,
 	historyMgr persistence.HistoryManager, visibilityMgr persistence.VisibilityManager,
-	kafkaProducer messaging.Producer) *WorkflowHandler {
+	scheduleMgr persistence.ScheduleManager, kafkaProducer messaging.Producer) *WorkflowHandler {
+	concurrencyLimiter := common.NewConcurrencyLimiter(
+		common.ConcurrencyLimitsFromMap(func() map[string]interface{} { return config.MaxConcurrentRequests() }),
+		func() time.Duration { return config.ConcurrentRequestsWaitTimeout() },
+	)
 	handler := &WorkflowHandler{
 		Service:            sVice,
 		config:             config,
 		metadataMgr:        metadataMgr,
 		historyMgr:         historyMgr,
 		visibitiltyMgr:     visibilityMgr,
+		scheduleMgr:        scheduleMgr,
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
 		hSerializerFactory: persistence.NewHistorySerializerFactory(),
 		domainCache:        cache.NewDomainCache(metadataMgr, sVice.GetClusterMetadata(), sVice.GetMetricsClient(), sVice.GetLogger()),
 		rateLimiter:        common.NewTokenBucket(config.RPS(), common.NewRealTimeSource()),
+		concurrencyLimiter: concurrencyLimiter,
 		domainReplicator:   NewDomainReplicator(kafkaProducer, sVice.GetLogger()),
+		decisionChunks:     newDecisionChunkBuffer(config.MaxDecisionChunks, config.DecisionChunkBufferMaxBytes, config.DecisionChunkTimeout()),
+		historyPageCache:   newHistoryPageCache(config.HistoryPageCacheSize(), config.HistoryPageCacheTTL()),
+		pageTokenSigner:    newPageTokenSigner(config.PageTokenSigningKey, config.PageTokenTTL, config.AcceptUnsignedPageTokens, common.NewRealTimeSource()),
+		domainLimiters: quotas.NewGlobalLimiters(
+			frontendDomainLimiterPrefix,
+			func(domain string) float64 { return float64(config.GlobalDomainRPS(domain)) },
+			config.GlobalRatelimiterUpdateInterval,
+			sVice.GetLogger(),
+		),
 	}
 	// prevent us from trying to serve requests before handler's Start() is complete
 	handler.startWG.Add(1)
@@ -136,7 +181,13 @@ func NewWorkflowHandler(sVice service.Service, config *Config, metadataMgr persi
 
 // Start starts the handler
 func (wh *WorkflowHandler) Start() error {
-	wh.Service.GetDispatcher().Register(workflowserviceserver.New(wh))
+	interceptors := append([]Interceptor{
+		newPayloadCompressionInterceptor(wh.config.PayloadCompressionThreshold, wh.Service.GetMetricsClient(),
+			wh.Service.GetLogger()),
+		newDomainRateLimitInterceptor(wh.domainLimiters),
+		newPendingDomainInterceptor(wh.domainCache),
+	}, wh.config.Interceptors...)
+	wh.Service.GetDispatcher().Register(workflowserviceserver.New(newInterceptedWorkflowHandler(wh, interceptors)))
 	wh.Service.GetDispatcher().Register(metaserver.New(wh))
 	wh.Service.Start()
 	wh.domainCache.Start()
@@ -145,6 +196,7 @@ func (wh *WorkflowHandler) Start() error {
 	if err != nil {
 		return err
 	}
+	wh.domainLimiters.Start(quotas.NewHistoryUpdateFn(wh.history, wh.Service.GetHostInfo().Identity()))
 	wh.matchingRawClient, err = wh.Service.GetClientFactory().NewMatchingClient()
 	if err != nil {
 		return err
@@ -152,12 +204,26 @@ func (wh *WorkflowHandler) Start() error {
 	wh.matching = matching.NewRetryableClient(wh.matchingRawClient, common.CreateMatchingRetryPolicy(),
 		common.IsWhitelistServiceTransientError)
 	wh.metricsClient = wh.Service.GetMetricsClient()
+	frontendResolver, err := wh.Service.GetMembershipMonitor().GetResolver(common.FrontendServiceName)
+	if err != nil {
+		return err
+	}
+	wh.domainRefresher = cadenceClient.NewDomainCacheRefresher(wh.Service.GetClientFactory(), frontendResolver,
+		wh.history, wh.matchingRawClient)
+	wh.historyReadRouter = newHistoryReadRouter(wh.config.EnableHistoryReadRouting, frontendResolver,
+		wh.Service.GetHostInfo(), wh.Service.GetClientFactory(), wh.metricsClient, wh.Service.GetLogger())
+	wh.chunkRouter = newDecisionChunkRouter(frontendResolver, wh.Service.GetHostInfo(),
+		wh.Service.GetClientFactory(), wh.metricsClient, wh.Service.GetLogger())
+	if wh.config.PageTokenSigningKey() == "" {
+		wh.Service.GetLogger().Warn("Page token signing key is not set, page tokens handed out to clients are not signed.")
+	}
 	wh.startWG.Done()
 	return nil
 }
 
 // Stop stops the handler
 func (wh *WorkflowHandler) Stop() {
+	wh.domainLimiters.Stop()
 	wh.domainCache.Stop()
 	wh.metadataMgr.Close()
 	wh.visibitiltyMgr.Close()
@@ -230,18 +296,28 @@ func (wh *WorkflowHandler) RegisterDomain(ctx context.Context, registerRequest *
 		return wh.error(errActiveClusterNotInClusters, scope)
 	}
 
+	// with approval enabled the domain is only usable once the worker domain registration processor approves it
+	status := persistence.DomainStatusRegistered
+	if wh.config.EnableDomainRegistrationApproval() {
+		if clusterMetadata.IsGlobalDomainEnabled() {
+			return wh.error(errDomainRegistrationApprovalGlobalDomain, scope)
+		}
+		status = persistence.DomainStatusPendingApproval
+	}
+
 	domainRequest := &persistence.CreateDomainRequest{
 		Info: &persistence.DomainInfo{
 			ID:          uuid.New(),
 			Name:        registerRequest.GetName(),
-			Status:      persistence.DomainStatusRegistered,
+			Status:      status,
 			OwnerEmail:  registerRequest.GetOwnerEmail(),
 			Description: registerRequest.GetDescription(),
 			Data:        registerRequest.Data,
 		},
 		Config: &persistence.DomainConfig{
-			Retention:  registerRequest.GetWorkflowExecutionRetentionPeriodInDays(),
-			EmitMetric: registerRequest.GetEmitMetric(),
+			Retention:       registerRequest.GetWorkflowExecutionRetentionPeriodInDays(),
+			EmitMetric:      registerRequest.GetEmitMetric(),
+			HistoryEncoding: common.EncodingTypeFromThrift(registerRequest.HistoryEncoding),
 		},
 		ReplicationConfig: &persistence.DomainReplicationConfig{
 			ActiveClusterName: activeClusterName,
@@ -251,6 +327,20 @@ func (wh *WorkflowHandler) RegisterDomain(ctx context.Context, registerRequest *
 		FailoverVersion: clusterMetadata.GetNextFailoverVersion(activeClusterName, 0),
 	}
 
+	// a domain whose registration was rejected gives its name back
+	existing, err := wh.metadataMgr.GetDomain(&persistence.GetDomainRequest{Name: registerRequest.GetName()})
+	switch err.(type) {
+	case nil:
+		if isRejectedDomain(existing.Info) {
+			if err := wh.metadataMgr.DeleteDomain(&persistence.DeleteDomainRequest{ID: existing.Info.ID}); err != nil {
+				return wh.error(err, scope)
+			}
+		}
+	case *gen.EntityNotExistsError:
+	default:
+		return wh.error(err, scope)
+	}
+
 	domainResponse, err := wh.metadataMgr.CreateDomain(domainRequest)
 	if err != nil {
 		return wh.error(err, scope)
@@ -469,6 +559,15 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 			configurationChanged = true
 			config.Retention = updatedConfig.GetWorkflowExecutionRetentionPeriodInDays()
 		}
+		if updatedConfig.HistoryEncoding != nil {
+			configurationChanged = true
+			config.HistoryEncoding = common.EncodingTypeFromThrift(updatedConfig.HistoryEncoding)
+		}
+		if updatedConfig.SuspectBinaryChecksums != nil {
+			// replaces the list, operators clear a binary once its deploy is fixed
+			configurationChanged = true
+			config.SuspectBinaryChecksums = updatedConfig.SuspectBinaryChecksums
+		}
 	}
 	if updateRequest.ReplicationConfiguration != nil {
 		updateReplicationConfig := updateRequest.ReplicationConfiguration
@@ -476,6 +575,18 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 			updateReplicationConfig.ActiveClusterName, updateReplicationConfig.Clusters); err != nil {
 			return nil, wh.error(err, scope)
 		}
+		if updateReplicationConfig.FailoverDrillClusterName != nil {
+			drillClusterName := updateReplicationConfig.GetFailoverDrillClusterName()
+			if err := validateFailoverDrillCluster(getResponse.IsGlobalDomain, replicationConfig, drillClusterName); err != nil {
+				return nil, wh.error(err, scope)
+			}
+			configurationChanged = true
+			replicationConfig.FailoverDrillClusterName = drillClusterName
+		}
+	}
+	if activeClusterChanged {
+		// a failover ends the drill, whichever cluster became active
+		replicationConfig.FailoverDrillClusterName = ""
 	}
 
 	if configurationChanged && activeClusterChanged {
@@ -518,6 +629,7 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 		if err != nil {
 			return nil, wh.error(err, scope)
 		}
+		wh.notifyDomainUpdated(info.ID)
 
 		// TODO remove the IsGlobalDomainEnabled check once cross DC is public
 		if clusterMetadata.IsGlobalDomainEnabled() {
@@ -542,11 +654,35 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 	return response, nil
 }
 
+// notifyDomainUpdated refreshes the domain cache of this host and of the other frontend, history and matching hosts,
+// so that they pick up a domain update, and in particular a failover, right away instead of on their next periodic
+// refresh
+func (wh *WorkflowHandler) notifyDomainUpdated(domainID string) {
+	wh.domainCache.TriggerRefresh()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), domainUpdateNotificationTimeout)
+		defer cancel()
+		err := wh.domainRefresher.RefreshDomainCache(ctx, domainID)
+		if err != nil {
+			// the hosts still pick up the update on their periodic refresh
+			wh.Service.GetLogger().WithFields(bark.Fields{
+				logging.TagDomainID: domainID,
+				logging.TagErr:      err,
+			}).Warn("Failed to notify hosts of domain update.")
+		}
+	}()
+}
+
+// mergeDomainData sets the keys of new on the domain data, a key set to an empty value is removed
 func (wh *WorkflowHandler) mergeDomainData(old map[string]string, new map[string]string) map[string]string {
 	if old == nil {
 		old = map[string]string{}
 	}
 	for k, v := range new {
+		if v == "" {
+			delete(old, k)
+			continue
+		}
 		old[k] = v
 	}
 	return old
@@ -614,6 +750,7 @@ func (wh *WorkflowHandler) DeprecateDomain(ctx context.Context, deprecateRequest
 	if err != nil {
 		return wh.error(err, scope)
 	}
+	wh.notifyDomainUpdated(getResponse.Info.ID)
 
 	if err != nil {
 		return wh.error(errDomainNotSet, scope)
@@ -634,8 +771,8 @@ func (wh *WorkflowHandler) PollForActivityTask(
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
 	}
 
 	wh.Service.GetLogger().Debug("Received PollForActivityTask")
@@ -664,7 +801,7 @@ func (wh *WorkflowHandler) PollForActivityTask(
 		return err
 	}
 
-	err = backoff.Retry(op, frontendServiceRetryPolicy, common.IsServiceTransientError)
+	err = backoff.Retry(op, frontendServiceRetryPolicy, isPollTransientError)
 	if err != nil {
 		err = wh.cancelOutstandingPoll(ctx, err, domainID, persistence.TaskListTypeActivity, pollRequest.TaskList, pollerID)
 		if err != nil {
@@ -690,8 +827,8 @@ func (wh *WorkflowHandler) PollForDecisionTask(
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
 	}
 
 	wh.Service.GetLogger().Debug("Received PollForDecisionTask")
@@ -723,7 +860,7 @@ func (wh *WorkflowHandler) PollForDecisionTask(
 		return err
 	}
 
-	err = backoff.Retry(op, frontendServiceRetryPolicy, common.IsServiceTransientError)
+	err = backoff.Retry(op, frontendServiceRetryPolicy, isPollTransientError)
 	if err != nil {
 		err = wh.cancelOutstandingPoll(ctx, err, domainID, persistence.TaskListTypeDecision, pollRequest.TaskList, pollerID)
 		if err != nil {
@@ -797,6 +934,11 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeat(
 		return nil, wh.error(errDomainNotSet, scope)
 	}
 
+	if err := wh.checkBlobSize(heartbeatRequest.Details, "RecordActivityTaskHeartbeat Details", taskToken.DomainID,
+		taskToken.WorkflowID, scope); err != nil {
+		return nil, err
+	}
+
 	resp, err := wh.history.RecordActivityTaskHeartbeat(ctx, &h.RecordActivityTaskHeartbeatRequest{
 		DomainUUID:       common.StringPtr(taskToken.DomainID),
 		HeartbeatRequest: heartbeatRequest,
@@ -842,6 +984,11 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeatByID(
 		return nil, wh.error(errActivityIDNotSet, scope)
 	}
 
+	if err := wh.checkBlobSize(heartbeatRequest.Details, "RecordActivityTaskHeartbeatByID Details", domainID,
+		workflowID, scope); err != nil {
+		return nil, err
+	}
+
 	taskToken := &common.TaskToken{
 		DomainID:   domainID,
 		RunID:      runID,
@@ -897,6 +1044,11 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(
 		return wh.error(errDomainNotSet, scope)
 	}
 
+	if err := wh.checkBlobSize(completeRequest.Result, "RespondActivityTaskCompleted Result", taskToken.DomainID,
+		taskToken.WorkflowID, scope); err != nil {
+		return err
+	}
+
 	err = wh.history.RespondActivityTaskCompleted(ctx, &h.RespondActivityTaskCompletedRequest{
 		DomainUUID:      common.StringPtr(taskToken.DomainID),
 		CompleteRequest: completeRequest,
@@ -941,6 +1093,11 @@ func (wh *WorkflowHandler) RespondActivityTaskCompletedByID(
 		return wh.error(errActivityIDNotSet, scope)
 	}
 
+	if err := wh.checkBlobSize(completeRequest.Result, "RespondActivityTaskCompletedByID Result", domainID,
+		workflowID, scope); err != nil {
+		return err
+	}
+
 	taskToken := &common.TaskToken{
 		DomainID:   domainID,
 		RunID:      runID,
@@ -1195,6 +1352,23 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(
 		return nil, wh.error(errDomainNotSet, scope)
 	}
 
+	if completeRequest.GetChunkCount() > 1 {
+		if response, forwarded, err := wh.chunkRouter.forward(ctx, completeRequest); forwarded {
+			if err != nil {
+				return nil, wh.error(err, scope)
+			}
+			return response, nil
+		}
+		completeRequest, err = wh.decisionChunks.add(completeRequest)
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		if completeRequest == nil {
+			// more chunks to come
+			return &gen.RespondDecisionTaskCompletedResponse{}, nil
+		}
+	}
+
 	histResp, err := wh.history.RespondDecisionTaskCompleted(ctx, &h.RespondDecisionTaskCompletedRequest{
 		DomainUUID:      common.StringPtr(taskToken.DomainID),
 		CompleteRequest: completeRequest},
@@ -1225,6 +1399,24 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(
 		}
 		completedResp.DecisionTask = newDecisionTask
 	}
+	if histResp != nil {
+		workflowExecution := &gen.WorkflowExecution{
+			WorkflowId: common.StringPtr(taskToken.WorkflowID),
+			RunId:      common.StringPtr(taskToken.RunID),
+		}
+		for _, startedResp := range histResp.ActivitiesToDispatchLocally {
+			activityToken := &common.TaskToken{
+				DomainID:        taskToken.DomainID,
+				WorkflowID:      taskToken.WorkflowID,
+				RunID:           taskToken.RunID,
+				ScheduleID:      startedResp.ScheduledEvent.GetEventId(),
+				ScheduleAttempt: startedResp.GetAttempt(),
+			}
+			token, _ := wh.tokenSerializer.Serialize(activityToken)
+			completedResp.ActivitiesToDispatchLocally = append(completedResp.ActivitiesToDispatchLocally,
+				common.CreatePollForActivityTaskResponse(startedResp, workflowExecution, token))
+		}
+	}
 
 	return completedResp, nil
 }
@@ -1320,8 +1512,8 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
 	}
 
 	if startRequest.GetDomain() == "" {
@@ -1380,6 +1572,14 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 			Message: fmt.Sprintf("TaskStartToCloseTimeoutSeconds is larger than ExecutionStartToCloseTimeout or MaxDecisionStartToCloseTimeout (%ds).", maxDecisionTimeout)}, scope)
 	}
 
+	if err := wh.validateExecutionTags(startRequest.GetDomain(), startRequest.Tags, scope); err != nil {
+		return nil, err
+	}
+
+	if startRequest.GetPriority() < 0 {
+		return nil, wh.error(errInvalidPriority, scope)
+	}
+
 	domainName := startRequest.GetDomain()
 	wh.Service.GetLogger().Debugf("Start workflow execution request domain: %v", domainName)
 	domainID, err := wh.domainCache.GetDomainID(domainName)
@@ -1389,6 +1589,11 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 
 	wh.Service.GetLogger().Debugf("Start workflow execution request domainID: %v", domainID)
 
+	if err := wh.checkBlobSize(startRequest.Input, "StartWorkflowExecution Input", domainID,
+		startRequest.GetWorkflowId(), scope); err != nil {
+		return nil, err
+	}
+
 	resp, err := wh.history.StartWorkflowExecution(ctx, &h.StartWorkflowExecutionRequest{
 		DomainUUID:   common.StringPtr(domainID),
 		StartRequest: startRequest,
@@ -1412,10 +1617,15 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
 	}
 
+	if !wh.concurrencyLimiter.Acquire(ctx, "GetWorkflowExecutionHistory") {
+		return nil, wh.error(errTooManyConcurrentRequests, scope)
+	}
+	defer wh.concurrencyLimiter.Release("GetWorkflowExecutionHistory")
+
 	if getRequest.GetDomain() == "" {
 		return nil, wh.error(errDomainNotSet, scope)
 	}
@@ -1424,6 +1634,13 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		return nil, err
 	}
 
+	if response, forwarded, err := wh.historyReadRouter.forward(ctx, getRequest); forwarded {
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		return response, nil
+	}
+
 	if getRequest.GetMaximumPageSize() <= 0 {
 		getRequest.MaximumPageSize = common.Int32Ptr(int32(wh.config.HistoryMaxPageSize(getRequest.GetDomain())))
 	}
@@ -1454,6 +1671,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 
 	isLongPoll := getRequest.GetWaitForNewEvent()
 	isCloseEventOnly := getRequest.GetHistoryEventFilterType() == gen.HistoryEventFilterTypeCloseEvent
+	isSummaryEventOnly := getRequest.GetHistoryEventFilterType() == gen.HistoryEventFilterTypeSummaryEvent
 	execution := getRequest.Execution
 	token := &getHistoryContinuationToken{}
 
@@ -1465,7 +1683,12 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 	// process the token for paging
 	queryNextEventID := common.EndEventID
 	if getRequest.NextPageToken != nil {
-		token, err = deserializeHistoryToken(getRequest.NextPageToken)
+		var historyToken []byte
+		historyToken, err = wh.pageTokenSigner.verify(historyPageTokenStore, domainID, getRequest.NextPageToken)
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		token, err = deserializeHistoryToken(historyToken)
 		if err != nil {
 			return nil, wh.error(errInvalidNextPageToken, scope)
 		}
@@ -1511,8 +1734,8 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 	history.Events = []*gen.HistoryEvent{}
 	if isCloseEventOnly {
 		if !isWorkflowRunning {
-			history, _, err = wh.getHistory(domainID, *execution, lastFirstEventID, nextEventID,
-				getRequest.GetMaximumPageSize(), nil, token.TransientDecision)
+			history, _, err = wh.getHistoryOfClosedRun(domainID, *execution, lastFirstEventID, nextEventID,
+				getRequest.GetMaximumPageSize(), nil, token.TransientDecision, scope)
 			if err != nil {
 				return nil, wh.error(err, scope)
 			}
@@ -1533,6 +1756,19 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 			if !isWorkflowRunning {
 				token = nil
 			}
+		} else if !token.IsWorkflowRunning {
+			history, token.PersistenceToken, err =
+				wh.getHistoryOfClosedRun(domainID, *execution, token.FirstEventID, token.NextEventID,
+					getRequest.GetMaximumPageSize(), token.PersistenceToken, token.TransientDecision, scope)
+			if err != nil {
+				return nil, wh.error(err, scope)
+			}
+			if err := auditHistoryPage(token, history); err != nil {
+				return nil, wh.error(err, scope)
+			}
+			if len(token.PersistenceToken) == 0 {
+				token = nil
+			}
 		} else {
 			history, token.PersistenceToken, err =
 				wh.getHistory(domainID, *execution, token.FirstEventID, token.NextEventID,
@@ -1540,6 +1776,9 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 			if err != nil {
 				return nil, wh.error(err, scope)
 			}
+			if err := auditHistoryPage(token, history); err != nil {
+				return nil, wh.error(err, scope)
+			}
 
 			// here, for long pull on history events, we need to intercept the paging token from cassandra
 			// and do something clever
@@ -1548,15 +1787,182 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 				token = nil
 			}
 		}
+		if isSummaryEventOnly {
+			// the summary pages through the same events, a page may come out empty while more follow
+			history = summarizeHistory(history)
+		}
 	}
 
 	nextToken, err := serializeHistoryToken(token)
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	nextToken, err = wh.pageTokenSigner.sign(historyPageTokenStore, domainID, nextToken)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
 	return createGetWorkflowExecutionHistoryResponse(history, nextToken), nil
 }
 
+// GetWorkflowExecutionResult - long polls for the workflow execution to close and returns the result or failure
+// recorded by its close event, without transferring the rest of the history
+func (wh *WorkflowHandler) GetWorkflowExecutionResult(
+	ctx context.Context,
+	getRequest *gen.GetWorkflowExecutionResultRequest) (*gen.GetWorkflowExecutionResultResponse, error) {
+
+	scope := metrics.FrontendGetWorkflowExecutionResultScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if getRequest == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
+	}
+
+	if getRequest.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+
+	if err := wh.validateExecutionAndEmitMetrics(getRequest.Execution, scope); err != nil {
+		return nil, err
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(getRequest.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	// no event will ever be at the end event ID, so this blocks until the workflow closes or the long poll times out
+	response, err := wh.history.GetMutableState(ctx, &h.GetMutableStateRequest{
+		DomainUUID:          common.StringPtr(domainID),
+		Execution:           getRequest.Execution,
+		ExpectedNextEventId: common.Int64Ptr(common.EndEventID),
+	})
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	execution := gen.WorkflowExecution{
+		WorkflowId: getRequest.Execution.WorkflowId,
+		RunId:      response.Execution.RunId,
+	}
+	if response.GetIsWorkflowRunning() {
+		return &gen.GetWorkflowExecutionResultResponse{Execution: &execution}, nil
+	}
+
+	// the close event is the last event of the last batch
+	pageSize := int32(wh.config.HistoryMaxPageSize(getRequest.GetDomain()))
+	var closeEvent *gen.HistoryEvent
+	var token []byte
+	for {
+		var history *gen.History
+		history, token, err = wh.getHistoryOfClosedRun(domainID, execution, response.GetLastFirstEventId(),
+			response.GetNextEventId(), pageSize, token, nil, scope)
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		// a page may come back empty, the close event is kept from the last page with events
+		if len(history.Events) > 0 {
+			closeEvent = history.Events[len(history.Events)-1]
+		}
+		if len(token) == 0 {
+			break
+		}
+	}
+
+	result, err := createGetWorkflowExecutionResultResponse(&execution, closeEvent)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+	return result, nil
+}
+
+// GetWorkflowExecutionGraph returns the activities, timers, child workflows and received signals of a workflow
+// execution with their state and timestamps, built from the history and the mutable state of the execution.
+func (wh *WorkflowHandler) GetWorkflowExecutionGraph(
+	ctx context.Context,
+	getRequest *gen.GetWorkflowExecutionGraphRequest) (*gen.GetWorkflowExecutionGraphResponse, error) {
+
+	scope := metrics.FrontendGetWorkflowExecutionGraphScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if getRequest == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
+	}
+
+	if getRequest.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+
+	if err := wh.validateExecutionAndEmitMetrics(getRequest.Execution, scope); err != nil {
+		return nil, err
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(getRequest.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	response, err := wh.history.GetMutableState(ctx, &h.GetMutableStateRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  getRequest.Execution,
+	})
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	execution := gen.WorkflowExecution{
+		WorkflowId: getRequest.Execution.WorkflowId,
+		RunId:      response.Execution.RunId,
+	}
+	builder := newExecutionGraphBuilder(&execution, response.WorkflowType)
+	pageSize := int32(wh.config.HistoryMaxPageSize(getRequest.GetDomain()))
+	var token []byte
+	for {
+		var history *gen.History
+		if response.GetIsWorkflowRunning() {
+			history, token, err = wh.getHistory(domainID, execution, common.FirstEventID,
+				response.GetNextEventId(), pageSize, token, nil)
+		} else {
+			history, token, err = wh.getHistoryOfClosedRun(domainID, execution, common.FirstEventID,
+				response.GetNextEventId(), pageSize, token, nil, scope)
+		}
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		builder.applyEvents(history.Events)
+		if len(token) == 0 {
+			break
+		}
+	}
+
+	if response.GetIsWorkflowRunning() {
+		// an activity with a retry policy records its started event only once it is closed, the mutable state
+		// of the execution tells whether it is running
+		describeResponse, err := wh.history.DescribeWorkflowExecution(ctx, &h.DescribeWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(domainID),
+			Request: &gen.DescribeWorkflowExecutionRequest{
+				Domain:    getRequest.Domain,
+				Execution: &execution,
+			},
+		})
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		builder.applyPendingActivities(describeResponse.PendingActivities)
+	}
+
+	return builder.build(), nil
+}
+
 // SignalWorkflowExecution is used to send a signal event to running workflow execution.  This results in
 // WorkflowExecutionSignaled event recorded in the history and a decision task being created for the execution.
 func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context,
@@ -1570,8 +1976,8 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context,
 		return wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return wh.error(createServiceBusyError(), scope)
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return wh.error(createServiceBusyError(retryAfter), scope)
 	}
 
 	if signalRequest.GetDomain() == "" {
@@ -1591,6 +1997,11 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context,
 		return wh.error(err, scope)
 	}
 
+	if err := wh.checkBlobSize(signalRequest.Input, "SignalWorkflowExecution Input", domainID,
+		signalRequest.WorkflowExecution.GetWorkflowId(), scope); err != nil {
+		return err
+	}
+
 	err = wh.history.SignalWorkflowExecution(ctx, &h.SignalWorkflowExecutionRequest{
 		DomainUUID:    common.StringPtr(domainID),
 		SignalRequest: signalRequest,
@@ -1602,88 +2013,213 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context,
 	return nil
 }
 
-// SignalWithStartWorkflowExecution is used to ensure sending a signal event to a workflow execution.
-// If workflow is running, this results in WorkflowExecutionSignaled event recorded in the history
-// and a decision task being created for the execution.
-// If workflow is not running or not found, this results in WorkflowExecutionStarted and WorkflowExecutionSignaled
-// event recorded in history, and a decision task being created for the execution
-func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
-	signalWithStartRequest *gen.SignalWithStartWorkflowExecutionRequest) (*gen.StartWorkflowExecutionResponse, error) {
+// UpdateWorkflowExecution is used to request an update on a running workflow execution.  This results in
+// WorkflowExecutionUpdateRequested event recorded in the history and a decision task being created for the execution.
+// The call blocks until the workflow completes the update with a CompleteWorkflowUpdate decision and returns its
+// result.  Retrying with the same UpdateId waits on the update already requested instead of requesting it again.
+func (wh *WorkflowHandler) UpdateWorkflowExecution(ctx context.Context,
+	updateRequest *gen.UpdateWorkflowExecutionRequest) (*gen.UpdateWorkflowExecutionResponse, error) {
 
-	scope := metrics.FrontendSignalWithStartWorkflowExecutionScope
+	scope := metrics.FrontendUpdateWorkflowExecutionScope
 	sw := wh.startRequestProfile(scope)
 	defer sw.Stop()
 
-	if signalWithStartRequest == nil {
+	if updateRequest == nil {
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
 	}
 
-	if signalWithStartRequest.GetDomain() == "" {
+	if updateRequest.GetDomain() == "" {
 		return nil, wh.error(errDomainNotSet, scope)
 	}
 
-	if signalWithStartRequest.GetWorkflowId() == "" {
-		return nil, wh.error(&gen.BadRequestError{Message: "WorkflowId is not set on request."}, scope)
-	}
-
-	if signalWithStartRequest.GetSignalName() == "" {
-		return nil, wh.error(&gen.BadRequestError{Message: "SignalName is not set on request."}, scope)
-	}
-
-	if signalWithStartRequest.WorkflowType == nil || signalWithStartRequest.WorkflowType.GetName() == "" {
-		return nil, wh.error(&gen.BadRequestError{Message: "WorkflowType is not set on request."}, scope)
-	}
-
-	if err := wh.validateTaskList(signalWithStartRequest.TaskList, scope); err != nil {
+	if err := wh.validateExecutionAndEmitMetrics(updateRequest.WorkflowExecution, scope); err != nil {
 		return nil, err
 	}
 
-	if signalWithStartRequest.GetExecutionStartToCloseTimeoutSeconds() <= 0 {
-		return nil, wh.error(&gen.BadRequestError{
-			Message: "A valid ExecutionStartToCloseTimeoutSeconds is not set on request."}, scope)
+	if updateRequest.GetUpdateName() == "" {
+		return nil, wh.error(&gen.BadRequestError{Message: "UpdateName is not set on request."}, scope)
 	}
 
-	if signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds() <= 0 {
-		return nil, wh.error(&gen.BadRequestError{
-			Message: "A valid TaskStartToCloseTimeoutSeconds is not set on request."}, scope)
+	if updateRequest.GetUpdateId() == "" {
+		return nil, wh.error(&gen.BadRequestError{Message: "UpdateId is not set on request."}, scope)
 	}
 
-	maxDecisionTimeout := int32(wh.config.MaxDecisionStartToCloseTimeout(signalWithStartRequest.GetDomain()))
-	// TODO: remove this assignment and logging in future, so that frontend will just return bad request for large decision timeout
-	if signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds() > signalWithStartRequest.GetExecutionStartToCloseTimeoutSeconds() {
-		logging.LogDecisionTimeoutLargerThanWorkflowTimeout(wh.Service.GetLogger(),
-			signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds(),
-			signalWithStartRequest.GetDomain(),
-			signalWithStartRequest.GetWorkflowId(),
-			signalWithStartRequest.WorkflowType.GetName(),
-		)
-		signalWithStartRequest.TaskStartToCloseTimeoutSeconds = common.Int32Ptr(signalWithStartRequest.GetExecutionStartToCloseTimeoutSeconds())
-	}
-	if signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds() > maxDecisionTimeout {
-		logging.LogDecisionTimeoutTooLarge(wh.Service.GetLogger(),
-			signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds(),
-			signalWithStartRequest.GetDomain(),
-			signalWithStartRequest.GetWorkflowId(),
-			signalWithStartRequest.WorkflowType.GetName(),
-		)
-		signalWithStartRequest.TaskStartToCloseTimeoutSeconds = common.Int32Ptr(maxDecisionTimeout)
+	domainID, err := wh.domainCache.GetDomainID(updateRequest.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
 	}
-	if signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds() > signalWithStartRequest.GetExecutionStartToCloseTimeoutSeconds() ||
-		signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds() > maxDecisionTimeout {
-		return nil, wh.error(&gen.BadRequestError{
-			Message: fmt.Sprintf("TaskStartToCloseTimeoutSeconds is larger than ExecutionStartToCloseTimeout or MaxDecisionStartToCloseTimeout (%ds).", maxDecisionTimeout)}, scope)
+
+	if err := wh.checkBlobSize(updateRequest.Input, "UpdateWorkflowExecution Input", domainID,
+		updateRequest.WorkflowExecution.GetWorkflowId(), scope); err != nil {
+		return nil, err
 	}
 
-	domainID, err := wh.domainCache.GetDomainID(signalWithStartRequest.GetDomain())
+	resp, err := wh.history.UpdateWorkflowExecution(ctx, &h.UpdateWorkflowExecutionRequest{
+		DomainUUID:    common.StringPtr(domainID),
+		UpdateRequest: updateRequest,
+	})
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
 
-	resp, err := wh.history.SignalWithStartWorkflowExecution(ctx, &h.SignalWithStartWorkflowExecutionRequest{
+	return resp, nil
+}
+
+// UpdateWorkflowExecutionOptions changes options of a running workflow execution, such as the task list inherited
+// by the next run when the workflow continues as new, or the task list its decisions are scheduled on.  Moving
+// decisions to another task list lets operators migrate workflows off a deprecated task list without terminating them.
+func (wh *WorkflowHandler) UpdateWorkflowExecutionOptions(ctx context.Context,
+	updateRequest *gen.UpdateWorkflowExecutionOptionsRequest) error {
+
+	scope := metrics.FrontendUpdateWorkflowExecutionOptionsScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if updateRequest == nil {
+		return wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return wh.error(createServiceBusyError(retryAfter), scope)
+	}
+
+	if updateRequest.GetDomain() == "" {
+		return wh.error(errDomainNotSet, scope)
+	}
+
+	if err := wh.validateExecutionAndEmitMetrics(updateRequest.WorkflowExecution, scope); err != nil {
+		return err
+	}
+
+	if updateRequest.ContinueAsNewTaskList == nil && updateRequest.TaskList == nil {
+		return wh.error(&gen.BadRequestError{Message: "No workflow execution option is set on request."}, scope)
+	}
+
+	if updateRequest.TaskList != nil {
+		if err := wh.validateTaskList(updateRequest.TaskList, scope); err != nil {
+			return err
+		}
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(updateRequest.GetDomain())
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	err = wh.history.UpdateWorkflowExecutionOptions(ctx, &h.UpdateWorkflowExecutionOptionsRequest{
+		DomainUUID:    common.StringPtr(domainID),
+		UpdateRequest: updateRequest,
+	})
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	return nil
+}
+
+// SignalWithStartWorkflowExecution is used to ensure sending a signal event to a workflow execution.
+// If workflow is running, this results in WorkflowExecutionSignaled event recorded in the history
+// and a decision task being created for the execution.
+// If workflow is not running or not found, this results in WorkflowExecutionStarted and WorkflowExecutionSignaled
+// event recorded in history, and a decision task being created for the execution
+func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
+	signalWithStartRequest *gen.SignalWithStartWorkflowExecutionRequest) (*gen.StartWorkflowExecutionResponse, error) {
+
+	scope := metrics.FrontendSignalWithStartWorkflowExecutionScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if signalWithStartRequest == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
+	}
+
+	if signalWithStartRequest.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+
+	if signalWithStartRequest.GetWorkflowId() == "" {
+		return nil, wh.error(&gen.BadRequestError{Message: "WorkflowId is not set on request."}, scope)
+	}
+
+	if signalWithStartRequest.GetSignalName() == "" {
+		return nil, wh.error(&gen.BadRequestError{Message: "SignalName is not set on request."}, scope)
+	}
+
+	if signalWithStartRequest.WorkflowType == nil || signalWithStartRequest.WorkflowType.GetName() == "" {
+		return nil, wh.error(&gen.BadRequestError{Message: "WorkflowType is not set on request."}, scope)
+	}
+
+	if err := wh.validateTaskList(signalWithStartRequest.TaskList, scope); err != nil {
+		return nil, err
+	}
+
+	if signalWithStartRequest.GetExecutionStartToCloseTimeoutSeconds() <= 0 {
+		return nil, wh.error(&gen.BadRequestError{
+			Message: "A valid ExecutionStartToCloseTimeoutSeconds is not set on request."}, scope)
+	}
+
+	if signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds() <= 0 {
+		return nil, wh.error(&gen.BadRequestError{
+			Message: "A valid TaskStartToCloseTimeoutSeconds is not set on request."}, scope)
+	}
+
+	maxDecisionTimeout := int32(wh.config.MaxDecisionStartToCloseTimeout(signalWithStartRequest.GetDomain()))
+	// TODO: remove this assignment and logging in future, so that frontend will just return bad request for large decision timeout
+	if signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds() > signalWithStartRequest.GetExecutionStartToCloseTimeoutSeconds() {
+		logging.LogDecisionTimeoutLargerThanWorkflowTimeout(wh.Service.GetLogger(),
+			signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds(),
+			signalWithStartRequest.GetDomain(),
+			signalWithStartRequest.GetWorkflowId(),
+			signalWithStartRequest.WorkflowType.GetName(),
+		)
+		signalWithStartRequest.TaskStartToCloseTimeoutSeconds = common.Int32Ptr(signalWithStartRequest.GetExecutionStartToCloseTimeoutSeconds())
+	}
+	if signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds() > maxDecisionTimeout {
+		logging.LogDecisionTimeoutTooLarge(wh.Service.GetLogger(),
+			signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds(),
+			signalWithStartRequest.GetDomain(),
+			signalWithStartRequest.GetWorkflowId(),
+			signalWithStartRequest.WorkflowType.GetName(),
+		)
+		signalWithStartRequest.TaskStartToCloseTimeoutSeconds = common.Int32Ptr(maxDecisionTimeout)
+	}
+	if signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds() > signalWithStartRequest.GetExecutionStartToCloseTimeoutSeconds() ||
+		signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds() > maxDecisionTimeout {
+		return nil, wh.error(&gen.BadRequestError{
+			Message: fmt.Sprintf("TaskStartToCloseTimeoutSeconds is larger than ExecutionStartToCloseTimeout or MaxDecisionStartToCloseTimeout (%ds).", maxDecisionTimeout)}, scope)
+	}
+
+	if err := wh.validateExecutionTags(signalWithStartRequest.GetDomain(), signalWithStartRequest.Tags, scope); err != nil {
+		return nil, err
+	}
+
+	if signalWithStartRequest.GetPriority() < 0 {
+		return nil, wh.error(errInvalidPriority, scope)
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(signalWithStartRequest.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := wh.checkBlobSize(signalWithStartRequest.SignalInput, "SignalWithStartWorkflowExecution SignalInput",
+		domainID, signalWithStartRequest.GetWorkflowId(), scope); err != nil {
+		return nil, err
+	}
+	if err := wh.checkBlobSize(signalWithStartRequest.Input, "SignalWithStartWorkflowExecution Input",
+		domainID, signalWithStartRequest.GetWorkflowId(), scope); err != nil {
+		return nil, err
+	}
+
+	resp, err := wh.history.SignalWithStartWorkflowExecution(ctx, &h.SignalWithStartWorkflowExecutionRequest{
 		DomainUUID:             common.StringPtr(domainID),
 		SignalWithStartRequest: signalWithStartRequest,
 	})
@@ -1707,8 +2243,8 @@ func (wh *WorkflowHandler) TerminateWorkflowExecution(ctx context.Context,
 		return wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return wh.error(createServiceBusyError(), scope)
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return wh.error(createServiceBusyError(retryAfter), scope)
 	}
 
 	if terminateRequest.GetDomain() == "" {
@@ -1748,8 +2284,8 @@ func (wh *WorkflowHandler) RequestCancelWorkflowExecution(
 		return wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return wh.error(createServiceBusyError(), scope)
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return wh.error(createServiceBusyError(retryAfter), scope)
 	}
 
 	if cancelRequest.GetDomain() == "" {
@@ -1788,10 +2324,15 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
 	}
 
+	if !wh.concurrencyLimiter.Acquire(ctx, "ListOpenWorkflowExecutions") {
+		return nil, wh.error(errTooManyConcurrentRequests, scope)
+	}
+	defer wh.concurrencyLimiter.Release("ListOpenWorkflowExecutions")
+
 	if listRequest.GetDomain() == "" {
 		return nil, wh.error(errDomainNotSet, scope)
 	}
@@ -1808,9 +2349,24 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context,
 		return nil, wh.error(&gen.BadRequestError{Message: "LatestTime in StartTimeFilter is required"}, scope)
 	}
 
-	if listRequest.ExecutionFilter != nil && listRequest.TypeFilter != nil {
+	filterCount := 0
+	if listRequest.ExecutionFilter != nil {
+		filterCount++
+	}
+	if listRequest.TypeFilter != nil {
+		filterCount++
+	}
+	if listRequest.TagFilter != nil {
+		filterCount++
+	}
+
+	if filterCount > 1 {
 		return nil, wh.error(&gen.BadRequestError{
-			Message: "Only one of ExecutionFilter or TypeFilter is allowed"}, scope)
+			Message: "Only one of ExecutionFilter, TypeFilter or TagFilter is allowed"}, scope)
+	}
+
+	if listRequest.TagFilter != nil && listRequest.TagFilter.GetKey() == "" {
+		return nil, wh.error(errTagFilterKeyNotSet, scope)
 	}
 
 	if listRequest.GetMaximumPageSize() <= 0 {
@@ -1822,16 +2378,27 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context,
 		return nil, wh.error(err, scope)
 	}
 
+	nextPageToken, err := wh.pageTokenSigner.verify(openVisibilityPageTokenStore, domainID, listRequest.NextPageToken)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	baseReq := persistence.ListWorkflowExecutionsRequest{
 		DomainUUID:        domainID,
 		PageSize:          int(listRequest.GetMaximumPageSize()),
-		NextPageToken:     listRequest.NextPageToken,
+		NextPageToken:     nextPageToken,
 		EarliestStartTime: listRequest.StartTimeFilter.GetEarliestTime(),
 		LatestStartTime:   listRequest.StartTimeFilter.GetLatestTime(),
 	}
 
 	var persistenceResp *persistence.ListWorkflowExecutionsResponse
-	if listRequest.ExecutionFilter != nil {
+	if listRequest.ExecutionFilter != nil && listRequest.ExecutionFilter.GetFirstRunId() != "" {
+		persistenceResp, err = wh.visibitiltyMgr.ListOpenWorkflowExecutionsByFirstRunID(
+			&persistence.ListWorkflowExecutionsByFirstRunIDRequest{
+				ListWorkflowExecutionsRequest: baseReq,
+				FirstRunID:                    listRequest.ExecutionFilter.GetFirstRunId(),
+			})
+	} else if listRequest.ExecutionFilter != nil {
 		persistenceResp, err = wh.visibitiltyMgr.ListOpenWorkflowExecutionsByWorkflowID(
 			&persistence.ListWorkflowExecutionsByWorkflowIDRequest{
 				ListWorkflowExecutionsRequest: baseReq,
@@ -1842,6 +2409,12 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context,
 			ListWorkflowExecutionsRequest: baseReq,
 			WorkflowTypeName:              listRequest.TypeFilter.GetName(),
 		})
+	} else if listRequest.TagFilter != nil {
+		persistenceResp, err = wh.visibitiltyMgr.ListOpenWorkflowExecutionsByTag(&persistence.ListWorkflowExecutionsByTagRequest{
+			ListWorkflowExecutionsRequest: baseReq,
+			TagKey:                        listRequest.TagFilter.GetKey(),
+			TagValue:                      listRequest.TagFilter.GetValue(),
+		})
 	} else {
 		persistenceResp, err = wh.visibitiltyMgr.ListOpenWorkflowExecutions(&baseReq)
 	}
@@ -1852,7 +2425,10 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context,
 
 	resp := &gen.ListOpenWorkflowExecutionsResponse{}
 	resp.Executions = persistenceResp.Executions
-	resp.NextPageToken = persistenceResp.NextPageToken
+	resp.NextPageToken, err = wh.pageTokenSigner.sign(openVisibilityPageTokenStore, domainID, persistenceResp.NextPageToken)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
 	return resp, nil
 }
 
@@ -1868,9 +2444,14 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
+	}
+
+	if !wh.concurrencyLimiter.Acquire(ctx, "ListClosedWorkflowExecutions") {
+		return nil, wh.error(errTooManyConcurrentRequests, scope)
 	}
+	defer wh.concurrencyLimiter.Release("ListClosedWorkflowExecutions")
 
 	if listRequest.GetDomain() == "" {
 		return nil, wh.error(errDomainNotSet, scope)
@@ -1898,10 +2479,17 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context,
 	if listRequest.StatusFilter != nil {
 		filterCount++
 	}
+	if listRequest.TagFilter != nil {
+		filterCount++
+	}
 
 	if filterCount > 1 {
 		return nil, wh.error(&gen.BadRequestError{
-			Message: "Only one of ExecutionFilter, TypeFilter or StatusFilter is allowed"}, scope)
+			Message: "Only one of ExecutionFilter, TypeFilter, StatusFilter or TagFilter is allowed"}, scope)
+	}
+
+	if listRequest.TagFilter != nil && listRequest.TagFilter.GetKey() == "" {
+		return nil, wh.error(errTagFilterKeyNotSet, scope)
 	}
 
 	if listRequest.GetMaximumPageSize() <= 0 {
@@ -1913,16 +2501,27 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context,
 		return nil, wh.error(err, scope)
 	}
 
+	nextPageToken, err := wh.pageTokenSigner.verify(closedVisibilityPageTokenStore, domainID, listRequest.NextPageToken)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	baseReq := persistence.ListWorkflowExecutionsRequest{
 		DomainUUID:        domainID,
 		PageSize:          int(listRequest.GetMaximumPageSize()),
-		NextPageToken:     listRequest.NextPageToken,
+		NextPageToken:     nextPageToken,
 		EarliestStartTime: listRequest.StartTimeFilter.GetEarliestTime(),
 		LatestStartTime:   listRequest.StartTimeFilter.GetLatestTime(),
 	}
 
 	var persistenceResp *persistence.ListWorkflowExecutionsResponse
-	if listRequest.ExecutionFilter != nil {
+	if listRequest.ExecutionFilter != nil && listRequest.ExecutionFilter.GetFirstRunId() != "" {
+		persistenceResp, err = wh.visibitiltyMgr.ListClosedWorkflowExecutionsByFirstRunID(
+			&persistence.ListWorkflowExecutionsByFirstRunIDRequest{
+				ListWorkflowExecutionsRequest: baseReq,
+				FirstRunID:                    listRequest.ExecutionFilter.GetFirstRunId(),
+			})
+	} else if listRequest.ExecutionFilter != nil {
 		persistenceResp, err = wh.visibitiltyMgr.ListClosedWorkflowExecutionsByWorkflowID(
 			&persistence.ListWorkflowExecutionsByWorkflowIDRequest{
 				ListWorkflowExecutionsRequest: baseReq,
@@ -1938,6 +2537,12 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context,
 			ListWorkflowExecutionsRequest: baseReq,
 			Status: listRequest.GetStatusFilter(),
 		})
+	} else if listRequest.TagFilter != nil {
+		persistenceResp, err = wh.visibitiltyMgr.ListClosedWorkflowExecutionsByTag(&persistence.ListWorkflowExecutionsByTagRequest{
+			ListWorkflowExecutionsRequest: baseReq,
+			TagKey:                        listRequest.TagFilter.GetKey(),
+			TagValue:                      listRequest.TagFilter.GetValue(),
+		})
 	} else {
 		persistenceResp, err = wh.visibitiltyMgr.ListClosedWorkflowExecutions(&baseReq)
 	}
@@ -1948,7 +2553,118 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context,
 
 	resp := &gen.ListClosedWorkflowExecutionsResponse{}
 	resp.Executions = persistenceResp.Executions
-	resp.NextPageToken = persistenceResp.NextPageToken
+	resp.NextPageToken, err = wh.pageTokenSigner.sign(closedVisibilityPageTokenStore, domainID, persistenceResp.NextPageToken)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+	return resp, nil
+}
+
+// ListWorkflowExecutions - retrieves info for both open and closed workflow executions in a domain, the open ones
+// first
+func (wh *WorkflowHandler) ListWorkflowExecutions(ctx context.Context,
+	listRequest *gen.ListWorkflowExecutionsRequest) (*gen.ListWorkflowExecutionsResponse, error) {
+
+	scope := metrics.FrontendListWorkflowExecutionsScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if listRequest == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
+	}
+
+	if !wh.concurrencyLimiter.Acquire(ctx, "ListWorkflowExecutions") {
+		return nil, wh.error(errTooManyConcurrentRequests, scope)
+	}
+	defer wh.concurrencyLimiter.Release("ListWorkflowExecutions")
+
+	if listRequest.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+
+	if listRequest.StartTimeFilter == nil {
+		return nil, wh.error(&gen.BadRequestError{Message: "StartTimeFilter is required"}, scope)
+	}
+
+	if listRequest.StartTimeFilter.EarliestTime == nil {
+		return nil, wh.error(&gen.BadRequestError{Message: "EarliestTime in StartTimeFilter is required"}, scope)
+	}
+
+	if listRequest.StartTimeFilter.LatestTime == nil {
+		return nil, wh.error(&gen.BadRequestError{Message: "LatestTime in StartTimeFilter is required"}, scope)
+	}
+
+	filterCount := 0
+	if listRequest.ExecutionFilter != nil {
+		filterCount++
+	}
+	if listRequest.TypeFilter != nil {
+		filterCount++
+	}
+	if listRequest.TagFilter != nil {
+		filterCount++
+	}
+
+	if filterCount > 1 {
+		return nil, wh.error(&gen.BadRequestError{
+			Message: "Only one of ExecutionFilter, TypeFilter or TagFilter is allowed"}, scope)
+	}
+
+	if listRequest.TagFilter != nil && listRequest.TagFilter.GetKey() == "" {
+		return nil, wh.error(errTagFilterKeyNotSet, scope)
+	}
+
+	if listRequest.GetMaximumPageSize() <= 0 {
+		listRequest.MaximumPageSize = common.Int32Ptr(int32(wh.config.VisibilityMaxPageSize(listRequest.GetDomain())))
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(listRequest.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	nextPageToken, err := wh.pageTokenSigner.verify(allVisibilityPageTokenStore, domainID, listRequest.NextPageToken)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	persistenceReq := &persistence.ListAllWorkflowExecutionsRequest{
+		ListWorkflowExecutionsRequest: persistence.ListWorkflowExecutionsRequest{
+			DomainUUID:        domainID,
+			PageSize:          int(listRequest.GetMaximumPageSize()),
+			NextPageToken:     nextPageToken,
+			EarliestStartTime: listRequest.StartTimeFilter.GetEarliestTime(),
+			LatestStartTime:   listRequest.StartTimeFilter.GetLatestTime(),
+		},
+	}
+	if listRequest.ExecutionFilter != nil {
+		if listRequest.ExecutionFilter.GetFirstRunId() != "" {
+			persistenceReq.FirstRunID = listRequest.ExecutionFilter.GetFirstRunId()
+		} else {
+			persistenceReq.WorkflowID = listRequest.ExecutionFilter.GetWorkflowId()
+		}
+	} else if listRequest.TypeFilter != nil {
+		persistenceReq.WorkflowTypeName = listRequest.TypeFilter.GetName()
+	} else if listRequest.TagFilter != nil {
+		persistenceReq.TagKey = listRequest.TagFilter.GetKey()
+		persistenceReq.TagValue = listRequest.TagFilter.GetValue()
+	}
+
+	persistenceResp, err := wh.visibitiltyMgr.ListAllWorkflowExecutions(persistenceReq)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	resp := &gen.ListWorkflowExecutionsResponse{}
+	resp.Executions = persistenceResp.Executions
+	resp.NextPageToken, err = wh.pageTokenSigner.sign(allVisibilityPageTokenStore, domainID, persistenceResp.NextPageToken)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
 	return resp, nil
 }
 
@@ -1975,14 +2691,16 @@ func (wh *WorkflowHandler) ResetStickyTaskList(ctx context.Context, resetRequest
 		return nil, wh.error(err, scope)
 	}
 
-	_, err = wh.history.ResetStickyTaskList(ctx, &h.ResetStickyTaskListRequest{
+	resp, err := wh.history.ResetStickyTaskList(ctx, &h.ResetStickyTaskListRequest{
 		DomainUUID: common.StringPtr(domainID),
 		Execution:  resetRequest.Execution,
 	})
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
-	return &gen.ResetStickyTaskListResponse{}, nil
+	return &gen.ResetStickyTaskListResponse{
+		StickyInvalidationCount: resp.StickyInvalidationCount,
+	}, nil
 }
 
 // QueryWorkflow returns query result for a specified workflow execution
@@ -1997,6 +2715,11 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
+	if !wh.concurrencyLimiter.Acquire(ctx, "QueryWorkflow") {
+		return nil, wh.error(errTooManyConcurrentRequests, scope)
+	}
+	defer wh.concurrencyLimiter.Release("QueryWorkflow")
+
 	if queryRequest.GetDomain() == "" {
 		return nil, wh.error(errDomainNotSet, scope)
 	}
@@ -2094,9 +2817,14 @@ func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx context.Context, reques
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
+	}
+
+	if !wh.concurrencyLimiter.Acquire(ctx, "DescribeWorkflowExecution") {
+		return nil, wh.error(errTooManyConcurrentRequests, scope)
 	}
+	defer wh.concurrencyLimiter.Release("DescribeWorkflowExecution")
 
 	if request.GetDomain() == "" {
 		return nil, wh.error(errDomainNotSet, scope)
@@ -2134,8 +2862,8 @@ func (wh *WorkflowHandler) DescribeTaskList(ctx context.Context, request *gen.De
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
 	}
 
 	if request.GetDomain() == "" {
@@ -2172,9 +2900,407 @@ func (wh *WorkflowHandler) DescribeTaskList(ctx context.Context, request *gen.De
 	return response, nil
 }
 
-func (wh *WorkflowHandler) getHistory(domainID string, execution gen.WorkflowExecution,
-	firstEventID, nextEventID int64, pageSize int32, nextPageToken []byte,
-	transientDecision *gen.TransientDecisionInfo) (*gen.History, []byte, error) {
+// GetTaskListsByDomain returns all the decision and activity tasklists of a domain which were used recently,
+// together with their recent pollers.
+func (wh *WorkflowHandler) GetTaskListsByDomain(ctx context.Context,
+	request *gen.GetTaskListsByDomainRequest) (*gen.GetTaskListsByDomainResponse, error) {
+
+	scope := metrics.FrontendGetTaskListsByDomainScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
+	}
+
+	if request.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+	domainID, err := wh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if request.GetPageSize() <= 0 {
+		request.PageSize = common.Int32Ptr(100)
+	}
+
+	var response *gen.GetTaskListsByDomainResponse
+	op := func() error {
+		var err error
+		response, err = wh.matching.GetTaskListsByDomain(ctx, &m.GetTaskListsByDomainRequest{
+			DomainUUID:  common.StringPtr(domainID),
+			ListRequest: request,
+		})
+		return err
+	}
+
+	err = backoff.Retry(op, frontendServiceRetryPolicy, common.IsServiceTransientError)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	return response, nil
+}
+
+// RecordWorkerHeartbeat records the capabilities and current load of a worker on a tasklist, the workers which
+// heartbeated recently are returned by DescribeTaskList.
+func (wh *WorkflowHandler) RecordWorkerHeartbeat(ctx context.Context, request *gen.RecordWorkerHeartbeatRequest) error {
+
+	scope := metrics.FrontendRecordWorkerHeartbeatScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return wh.error(createServiceBusyError(retryAfter), scope)
+	}
+
+	if request.GetDomain() == "" {
+		return wh.error(errDomainNotSet, scope)
+	}
+	domainID, err := wh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	if err := wh.validateTaskList(request.TaskList, scope); err != nil {
+		return err
+	}
+
+	if err := wh.validateTaskListType(request.TaskListType, scope); err != nil {
+		return err
+	}
+
+	if request.GetIdentity() == "" {
+		return wh.error(errIdentityNotSet, scope)
+	}
+
+	if request.GetCurrentLoad() < 0 {
+		return wh.error(errInvalidCurrentLoad, scope)
+	}
+
+	op := func() error {
+		return wh.matching.RecordWorkerHeartbeat(ctx, &m.RecordWorkerHeartbeatRequest{
+			DomainUUID:       common.StringPtr(domainID),
+			HeartbeatRequest: request,
+		})
+	}
+
+	err = backoff.Retry(op, frontendServiceRetryPolicy, common.IsServiceTransientError)
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	return nil
+}
+
+// CreateSchedule creates a schedule starting a run of a workflow each time its spec is due
+func (wh *WorkflowHandler) CreateSchedule(ctx context.Context, createRequest *gen.CreateScheduleRequest) error {
+
+	scope := metrics.FrontendCreateScheduleScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if createRequest == nil {
+		return wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return wh.error(createServiceBusyError(retryAfter), scope)
+	}
+
+	if createRequest.GetDomain() == "" {
+		return wh.error(errDomainNotSet, scope)
+	}
+
+	if createRequest.GetScheduleId() == "" {
+		return wh.error(errScheduleIDNotSet, scope)
+	}
+
+	if err := validateScheduleSpec(createRequest.Spec, wh.config.ScheduleMinInterval()); err != nil {
+		return wh.error(err, scope)
+	}
+
+	maxDecisionTimeout := int32(wh.config.MaxDecisionStartToCloseTimeout(createRequest.GetDomain()))
+	if err := validateScheduleAction(createRequest.Action, maxDecisionTimeout); err != nil {
+		return wh.error(err, scope)
+	}
+
+	if err := validateScheduleOverlapPolicy(createRequest.OverlapPolicy); err != nil {
+		return wh.error(err, scope)
+	}
+
+	domainEntry, err := wh.domainCache.GetDomain(createRequest.GetDomain())
+	if err != nil {
+		return wh.error(err, scope)
+	}
+	// the schedules are stored per cluster, the schedules of a global domain would be lost on failover
+	if domainEntry.IsGlobalDomain() {
+		return wh.error(errScheduleGlobalDomain, scope)
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	if err := wh.checkBlobSize(createRequest.Action.Input, "CreateSchedule Input", domainID,
+		createRequest.GetScheduleId(), scope); err != nil {
+		return err
+	}
+
+	schedule := &persistence.ScheduleInfo{
+		DomainID:      domainID,
+		ScheduleID:    createRequest.GetScheduleId(),
+		OverlapPolicy: int(createRequest.GetOverlapPolicy()),
+		Paused:        createRequest.GetPaused(),
+	}
+	setScheduleSpec(schedule, createRequest.Spec)
+	setScheduleAction(schedule, createRequest.Action)
+	schedule.NextRunTime = schedule.GetNextRunTime(time.Now())
+	if schedule.NextRunTime.IsZero() {
+		return wh.error(errScheduleNotDue, scope)
+	}
+
+	err = wh.scheduleMgr.CreateSchedule(&persistence.CreateScheduleRequest{Schedule: schedule})
+	if err != nil {
+		if _, ok := err.(*persistence.ConditionFailedError); ok {
+			return wh.error(errScheduleAlreadyExists, scope)
+		}
+		return wh.error(err, scope)
+	}
+
+	return nil
+}
+
+// DescribeSchedule returns a schedule and the state of its runs
+func (wh *WorkflowHandler) DescribeSchedule(ctx context.Context,
+	describeRequest *gen.DescribeScheduleRequest) (*gen.DescribeScheduleResponse, error) {
+
+	scope := metrics.FrontendDescribeScheduleScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if describeRequest == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
+	}
+
+	if describeRequest.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+
+	if describeRequest.GetScheduleId() == "" {
+		return nil, wh.error(errScheduleIDNotSet, scope)
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(describeRequest.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	resp, err := wh.scheduleMgr.GetSchedule(&persistence.GetScheduleRequest{
+		DomainID:   domainID,
+		ScheduleID: describeRequest.GetScheduleId(),
+	})
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	return createDescribeScheduleResponse(resp.Schedule), nil
+}
+
+// UpdateSchedule updates the fields set on the request of a schedule
+func (wh *WorkflowHandler) UpdateSchedule(ctx context.Context, updateRequest *gen.UpdateScheduleRequest) error {
+
+	scope := metrics.FrontendUpdateScheduleScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if updateRequest == nil {
+		return wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return wh.error(createServiceBusyError(retryAfter), scope)
+	}
+
+	if updateRequest.GetDomain() == "" {
+		return wh.error(errDomainNotSet, scope)
+	}
+
+	if updateRequest.GetScheduleId() == "" {
+		return wh.error(errScheduleIDNotSet, scope)
+	}
+
+	if updateRequest.Spec != nil {
+		if err := validateScheduleSpec(updateRequest.Spec, wh.config.ScheduleMinInterval()); err != nil {
+			return wh.error(err, scope)
+		}
+	}
+
+	if updateRequest.Action != nil {
+		maxDecisionTimeout := int32(wh.config.MaxDecisionStartToCloseTimeout(updateRequest.GetDomain()))
+		if err := validateScheduleAction(updateRequest.Action, maxDecisionTimeout); err != nil {
+			return wh.error(err, scope)
+		}
+	}
+
+	if err := validateScheduleOverlapPolicy(updateRequest.OverlapPolicy); err != nil {
+		return wh.error(err, scope)
+	}
+
+	domainID, err := wh.getActiveDomainID(updateRequest.GetDomain())
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	if updateRequest.Action != nil {
+		if err := wh.checkBlobSize(updateRequest.Action.Input, "UpdateSchedule Input", domainID,
+			updateRequest.GetScheduleId(), scope); err != nil {
+			return err
+		}
+	}
+
+	// the schedule processor updates the state of the schedule each time it starts a run,
+	// a conflicting update is applied again on the new version of the schedule
+	for attempt := 0; attempt < updateScheduleMaxAttempts; attempt++ {
+		resp, err := wh.scheduleMgr.GetSchedule(&persistence.GetScheduleRequest{
+			DomainID:   domainID,
+			ScheduleID: updateRequest.GetScheduleId(),
+		})
+		if err != nil {
+			return wh.error(err, scope)
+		}
+
+		schedule := resp.Schedule
+		if err := applyScheduleUpdate(schedule, updateRequest, time.Now()); err != nil {
+			return wh.error(err, scope)
+		}
+		previousVersion := schedule.Version
+		schedule.Version++
+		err = wh.scheduleMgr.UpdateSchedule(&persistence.UpdateScheduleRequest{
+			Schedule:        schedule,
+			PreviousVersion: previousVersion,
+		})
+		if err == nil {
+			return nil
+		}
+		if _, ok := err.(*persistence.ConditionFailedError); !ok {
+			return wh.error(err, scope)
+		}
+	}
+
+	return wh.error(errScheduleUpdateConflict, scope)
+}
+
+// DeleteSchedule deletes a schedule, leaving the runs it started open
+func (wh *WorkflowHandler) DeleteSchedule(ctx context.Context, deleteRequest *gen.DeleteScheduleRequest) error {
+
+	scope := metrics.FrontendDeleteScheduleScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if deleteRequest == nil {
+		return wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return wh.error(createServiceBusyError(retryAfter), scope)
+	}
+
+	if deleteRequest.GetDomain() == "" {
+		return wh.error(errDomainNotSet, scope)
+	}
+
+	if deleteRequest.GetScheduleId() == "" {
+		return wh.error(errScheduleIDNotSet, scope)
+	}
+
+	domainID, err := wh.getActiveDomainID(deleteRequest.GetDomain())
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	// the delete itself succeeds on a missing schedule, read it first to report it
+	_, err = wh.scheduleMgr.GetSchedule(&persistence.GetScheduleRequest{
+		DomainID:   domainID,
+		ScheduleID: deleteRequest.GetScheduleId(),
+	})
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	err = wh.scheduleMgr.DeleteSchedule(&persistence.DeleteScheduleRequest{
+		DomainID:   domainID,
+		ScheduleID: deleteRequest.GetScheduleId(),
+	})
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	return nil
+}
+
+// ListSchedules returns a page of the schedules of a domain
+func (wh *WorkflowHandler) ListSchedules(ctx context.Context,
+	listRequest *gen.ListSchedulesRequest) (*gen.ListSchedulesResponse, error) {
+
+	scope := metrics.FrontendListSchedulesScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if listRequest == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, retryAfter := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(retryAfter), scope)
+	}
+
+	if listRequest.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(listRequest.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	pageSize := int(listRequest.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultListSchedulesPageSize
+	}
+
+	resp, err := wh.scheduleMgr.ListSchedules(&persistence.ListSchedulesRequest{
+		DomainID:      domainID,
+		PageSize:      pageSize,
+		NextPageToken: listRequest.NextPageToken,
+	})
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	schedules := []*gen.DescribeScheduleResponse{}
+	for _, schedule := range resp.Schedules {
+		schedules = append(schedules, createDescribeScheduleResponse(schedule))
+	}
+	return &gen.ListSchedulesResponse{
+		Schedules:     schedules,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+func (wh *WorkflowHandler) getHistory(domainID string, execution gen.WorkflowExecution,
+	firstEventID, nextEventID int64, pageSize int32, nextPageToken []byte,
+	transientDecision *gen.TransientDecisionInfo) (*gen.History, []byte, error) {
 
 	historyEvents := []*gen.HistoryEvent{}
 
@@ -2201,6 +3327,18 @@ func (wh *WorkflowHandler) getHistory(domainID string, execution gen.WorkflowExe
 		historyEvents = append(historyEvents, history.Events...)
 	}
 
+	// only the first event of a run is known in advance, later ranges may start in the middle of a batch
+	previousEventID := common.EmptyEventID
+	if firstEventID == common.FirstEventID && len(nextPageToken) == 0 {
+		previousEventID = common.FirstEventID - 1
+	}
+	if _, _, err := persistence.AuditHistoryEvents(historyEvents, previousEventID, common.EmptyVersion); err != nil {
+		return nil, nil, &persistence.HistoryEventsCorruptedError{
+			Msg: fmt.Sprintf("%v, domainID: %v, workflowID: %v, runID: %v",
+				err.Error(), domainID, execution.GetWorkflowId(), execution.GetRunId()),
+		}
+	}
+
 	nextPageToken = response.NextPageToken
 	if len(nextPageToken) == 0 && transientDecision != nil {
 		// Append the transient decision events once we are done enumerating everything from the events table
@@ -2212,6 +3350,58 @@ func (wh *WorkflowHandler) getHistory(domainID string, execution gen.WorkflowExe
 	return executionHistory, nextPageToken, nil
 }
 
+// auditHistoryPage checks that a page of history read with the continuation token picks up where the previous page
+// left off, and records the last persisted event of the page in the token for the next one
+func auditHistoryPage(token *getHistoryContinuationToken, history *gen.History) error {
+	var lastEvent *gen.HistoryEvent
+	for i := len(history.Events) - 1; i >= 0; i-- {
+		// transient decision events are not persisted yet and are skipped
+		if history.Events[i].GetEventId() < token.NextEventID {
+			lastEvent = history.Events[i]
+			break
+		}
+	}
+	if lastEvent == nil {
+		return nil
+	}
+
+	// tokens issued before the audit do not carry the last event
+	if token.LastEventID != 0 {
+		if _, _, err := persistence.AuditHistoryEvents(history.Events[:1], token.LastEventID,
+			token.LastEventVersion); err != nil {
+			return &persistence.HistoryEventsCorruptedError{
+				Msg: fmt.Sprintf("%v, runID: %v", err.Error(), token.RunID),
+			}
+		}
+	}
+	token.LastEventID = lastEvent.GetEventId()
+	token.LastEventVersion = lastEvent.GetVersion()
+	return nil
+}
+
+// getHistoryOfClosedRun reads a history page of a closed run through the history page cache
+func (wh *WorkflowHandler) getHistoryOfClosedRun(domainID string, execution gen.WorkflowExecution,
+	firstEventID, nextEventID int64, pageSize int32, nextPageToken []byte,
+	transientDecision *gen.TransientDecisionInfo, scope int) (*gen.History, []byte, error) {
+	if transientDecision != nil || wh.historyPageCache == nil {
+		return wh.getHistory(domainID, execution, firstEventID, nextEventID, pageSize, nextPageToken, transientDecision)
+	}
+
+	key := historyPageKey(domainID, execution, firstEventID, nextEventID, pageSize, nextPageToken)
+	if history, token, ok := wh.historyPageCache.get(key); ok {
+		wh.metricsClient.IncCounter(scope, metrics.HistoryPageCacheHitCounter)
+		return history, token, nil
+	}
+	wh.metricsClient.IncCounter(scope, metrics.HistoryPageCacheMissCounter)
+
+	history, token, err := wh.getHistory(domainID, execution, firstEventID, nextEventID, pageSize, nextPageToken, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	wh.historyPageCache.put(key, history, token)
+	return history, token, nil
+}
+
 func (wh *WorkflowHandler) getLoggerForTask(taskToken []byte) bark.Logger {
 	logger := wh.Service.GetLogger()
 	task, err := wh.tokenSerializer.Deserialize(taskToken)
@@ -2234,42 +3424,48 @@ func (wh *WorkflowHandler) startRequestProfile(scope int) tally.Stopwatch {
 }
 
 func (wh *WorkflowHandler) error(err error, scope int) error {
-	switch err := err.(type) {
+	// the errors wrapped on their way up are reported by the error they originate from
+	switch cause := serviceerror.Cause(err).(type) {
 	case *gen.InternalServiceError:
-		logging.LogInternalServiceError(wh.Service.GetLogger(), err)
+		logging.LogInternalServiceError(wh.Service.GetLogger(), cause)
 		wh.metricsClient.IncCounter(scope, metrics.CadenceFailures)
-		return err
+		return cause
 	case *gen.BadRequestError:
 		wh.metricsClient.IncCounter(scope, metrics.CadenceErrBadRequestCounter)
-		return err
+		return cause
 	case *gen.DomainNotActiveError:
 		wh.metricsClient.IncCounter(scope, metrics.CadenceErrBadRequestCounter)
-		return err
+		return cause
 	case *gen.ServiceBusyError:
 		wh.metricsClient.IncCounter(scope, metrics.CadenceErrServiceBusyCounter)
-		return err
+		return cause
 	case *gen.EntityNotExistsError:
 		wh.metricsClient.IncCounter(scope, metrics.CadenceErrEntityNotExistsCounter)
-		return err
+		return cause
 	case *gen.WorkflowExecutionAlreadyStartedError:
 		wh.metricsClient.IncCounter(scope, metrics.CadenceErrExecutionAlreadyStartedCounter)
-		return err
+		return cause
 	case *gen.DomainAlreadyExistsError:
 		wh.metricsClient.IncCounter(scope, metrics.CadenceErrDomainAlreadyExistsCounter)
-		return err
+		return cause
 	case *gen.CancellationAlreadyRequestedError:
 		wh.metricsClient.IncCounter(scope, metrics.CadenceErrCancellationAlreadyRequestedCounter)
-		return err
+		return cause
 	case *gen.QueryFailedError:
 		wh.metricsClient.IncCounter(scope, metrics.CadenceErrQueryFailedCounter)
-		return err
+		return cause
 	case *gen.LimitExceededError:
 		wh.metricsClient.IncCounter(scope, metrics.CadenceErrLimitExceededCounter)
-		return err
+		return cause
+	case *persistence.HistoryEventsCorruptedError:
+		logging.LogHistoryEventsCorruptedEvent(wh.Service.GetLogger(), cause)
+		wh.metricsClient.IncCounter(scope, metrics.CadenceErrHistoryEventsCorruptedCounter)
+		wh.metricsClient.IncCounter(scope, metrics.CadenceFailures)
+		return &gen.InternalServiceError{Message: err.Error()}
 	case *yarpcerrors.Status:
-		if err.Code() == yarpcerrors.CodeDeadlineExceeded {
+		if cause.Code() == yarpcerrors.CodeDeadlineExceeded {
 			wh.metricsClient.IncCounter(scope, metrics.CadenceErrContextTimeoutCounter)
-			return err
+			return cause
 		}
 	}
 
@@ -2292,6 +3488,83 @@ func (wh *WorkflowHandler) validateTaskList(t *gen.TaskList, scope int) error {
 	return nil
 }
 
+// getActiveDomainID returns the ID of a domain active in this cluster, the schedules of a domain are run by the
+// workers of its active cluster so they are only changed there
+func (wh *WorkflowHandler) getActiveDomainID(domain string) (string, error) {
+	domainEntry, err := wh.domainCache.GetDomain(domain)
+	if err != nil {
+		return "", err
+	}
+	if err := domainEntry.GetDomainNotActiveErr(); err != nil {
+		return "", err
+	}
+	return domainEntry.GetInfo().ID, nil
+}
+
+// checkBlobSize logs a warning for a payload larger than the warn limit of the domain, and rejects a payload
+// larger than the error limit with the offending size
+func (wh *WorkflowHandler) checkBlobSize(blob []byte, blobName string, domainID string, workflowID string,
+	scope int) error {
+	size := len(blob)
+	if size <= 0 {
+		return nil
+	}
+	domainEntry, err := wh.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return wh.error(err, scope)
+	}
+	domain := domainEntry.GetInfo().Name
+
+	if err := validateBlobSize(blobName, size, wh.config.BlobSizeLimitError(domain)); err != nil {
+		return wh.error(err, scope)
+	}
+	if size > wh.config.BlobSizeLimitWarn(domain) {
+		logging.LogBlobSizeTooLarge(wh.Service.GetLogger(), domain, workflowID, blobName, size)
+	}
+	return nil
+}
+
+// validateBlobSize rejects a payload larger than the limit, reporting its size
+func validateBlobSize(blobName string, size int, limit int) error {
+	if size > limit {
+		return &gen.BadRequestError{
+			Message: fmt.Sprintf("%v size of %d bytes exceeds the limit of %d bytes.", blobName, size, limit)}
+	}
+	return nil
+}
+
+func (wh *WorkflowHandler) validateExecutionTags(domain string, tags map[string]string, scope int) error {
+	if err := validateExecutionTags(tags, wh.config.ExecutionTagQuotas(domain)); err != nil {
+		return wh.error(err, scope)
+	}
+	return nil
+}
+
+// validateExecutionTags checks tags against the per domain quotas, which map each allowed
+// tag key to the max length of its value. Keys missing from the quotas are rejected.
+func validateExecutionTags(tags map[string]string, quotas map[string]interface{}) error {
+	for key, value := range tags {
+		quota, ok := quotas[key]
+		if !ok {
+			return &gen.BadRequestError{Message: fmt.Sprintf("Tag key %q is not allowed for the domain.", key)}
+		}
+		var maxLength int
+		switch q := quota.(type) {
+		case int:
+			maxLength = q
+		case float64:
+			maxLength = int(q)
+		default:
+			return &gen.BadRequestError{Message: fmt.Sprintf("Tag key %q has no valid quota for the domain.", key)}
+		}
+		if len(value) > maxLength {
+			return &gen.BadRequestError{
+				Message: fmt.Sprintf("Value of tag %q exceeds the max length of %d.", key, maxLength)}
+		}
+	}
+	return nil
+}
+
 func (wh *WorkflowHandler) validateExecutionAndEmitMetrics(w *gen.WorkflowExecution, scope int) error {
 	err := validateExecution(w)
 	if err != nil {
@@ -2324,6 +3597,9 @@ func getDomainStatus(info *persistence.DomainInfo) *gen.DomainStatus {
 	case persistence.DomainStatusDeleted:
 		v := gen.DomainStatusDeleted
 		return &v
+	case persistence.DomainStatusPendingApproval:
+		v := gen.DomainStatusPendingApproval
+		return &v
 	}
 
 	return nil
@@ -2344,6 +3620,8 @@ func createDomainResponse(info *persistence.DomainInfo, config *persistence.Doma
 	configResult := &gen.DomainConfiguration{
 		EmitMetric:                             common.BoolPtr(config.EmitMetric),
 		WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(config.Retention),
+		HistoryEncoding:                        common.EncodingTypeToThrift(config.HistoryEncoding),
+		SuspectBinaryChecksums:                 config.SuspectBinaryChecksums,
 	}
 
 	clusters := []*gen.ClusterReplicationConfiguration{}
@@ -2354,8 +3632,9 @@ func createDomainResponse(info *persistence.DomainInfo, config *persistence.Doma
 	}
 
 	replicationConfigResult := &gen.DomainReplicationConfiguration{
-		ActiveClusterName: common.StringPtr(replicationConfig.ActiveClusterName),
-		Clusters:          clusters,
+		ActiveClusterName:        common.StringPtr(replicationConfig.ActiveClusterName),
+		Clusters:                 clusters,
+		FailoverDrillClusterName: common.StringPtr(replicationConfig.FailoverDrillClusterName),
 	}
 
 	return infoResult, configResult, replicationConfigResult
@@ -2366,7 +3645,7 @@ func (wh *WorkflowHandler) createPollForDecisionTaskResponse(ctx context.Context
 
 	if matchingResp.WorkflowExecution == nil {
 		// this will happen if there is no decision task to be send to worker / caller
-		return &gen.PollForDecisionTaskResponse{}, nil
+		return &gen.PollForDecisionTaskResponse{PollBackoffMillis: matchingResp.PollBackoffMillis}, nil
 	}
 
 	var history *gen.History
@@ -2411,13 +3690,21 @@ func (wh *WorkflowHandler) createPollForDecisionTaskResponse(ctx context.Context
 		}
 
 		if len(persistenceToken) != 0 {
-			continuation, err = serializeHistoryToken(&getHistoryContinuationToken{
+			token := &getHistoryContinuationToken{
 				RunID:             matchingResp.WorkflowExecution.GetRunId(),
 				FirstEventID:      firstEventID,
 				NextEventID:       nextEventID,
 				PersistenceToken:  persistenceToken,
 				TransientDecision: matchingResp.DecisionInfo,
-			})
+			}
+			if err = auditHistoryPage(token, history); err != nil {
+				return nil, err
+			}
+			continuation, err = serializeHistoryToken(token)
+			if err != nil {
+				return nil, err
+			}
+			continuation, err = wh.pageTokenSigner.sign(historyPageTokenStore, domainID, continuation)
 			if err != nil {
 				return nil, err
 			}
@@ -2425,16 +3712,19 @@ func (wh *WorkflowHandler) createPollForDecisionTaskResponse(ctx context.Context
 	}
 
 	resp := &gen.PollForDecisionTaskResponse{
-		TaskToken:              matchingResp.TaskToken,
-		WorkflowExecution:      matchingResp.WorkflowExecution,
-		WorkflowType:           matchingResp.WorkflowType,
-		PreviousStartedEventId: matchingResp.PreviousStartedEventId,
-		StartedEventId:         matchingResp.StartedEventId,
-		Query:                  matchingResp.Query,
-		BacklogCountHint:       matchingResp.BacklogCountHint,
-		Attempt:                matchingResp.Attempt,
-		History:                history,
-		NextPageToken:          continuation,
+		TaskToken:               matchingResp.TaskToken,
+		WorkflowExecution:       matchingResp.WorkflowExecution,
+		WorkflowType:            matchingResp.WorkflowType,
+		PreviousStartedEventId:  matchingResp.PreviousStartedEventId,
+		StartedEventId:          matchingResp.StartedEventId,
+		Query:                   matchingResp.Query,
+		BacklogCountHint:        matchingResp.BacklogCountHint,
+		Attempt:                 matchingResp.Attempt,
+		History:                 history,
+		NextPageToken:           continuation,
+		StickyInvalidationCount: matchingResp.StickyInvalidationCount,
+		ContinueAsNewSuggested:  matchingResp.ContinueAsNewSuggested,
+		PollBackoffMillis:       matchingResp.PollBackoffMillis,
 	}
 
 	return resp, nil
@@ -2448,6 +3738,47 @@ func createGetWorkflowExecutionHistoryResponse(
 	return resp
 }
 
+func createGetWorkflowExecutionResultResponse(
+	execution *gen.WorkflowExecution, closeEvent *gen.HistoryEvent) (*gen.GetWorkflowExecutionResultResponse, error) {
+	if closeEvent == nil {
+		return nil, &gen.InternalServiceError{Message: "Close event of closed workflow execution not found."}
+	}
+	resp := &gen.GetWorkflowExecutionResultResponse{Execution: execution}
+	switch closeEvent.GetEventType() {
+	case gen.EventTypeWorkflowExecutionCompleted:
+		attributes := closeEvent.WorkflowExecutionCompletedEventAttributes
+		resp.CloseStatus = gen.WorkflowExecutionCloseStatusCompleted.Ptr()
+		resp.Result = attributes.Result
+	case gen.EventTypeWorkflowExecutionFailed:
+		attributes := closeEvent.WorkflowExecutionFailedEventAttributes
+		resp.CloseStatus = gen.WorkflowExecutionCloseStatusFailed.Ptr()
+		resp.Reason = attributes.Reason
+		resp.Details = attributes.Details
+	case gen.EventTypeWorkflowExecutionCanceled:
+		attributes := closeEvent.WorkflowExecutionCanceledEventAttributes
+		resp.CloseStatus = gen.WorkflowExecutionCloseStatusCanceled.Ptr()
+		resp.Details = attributes.Details
+	case gen.EventTypeWorkflowExecutionTerminated:
+		attributes := closeEvent.WorkflowExecutionTerminatedEventAttributes
+		resp.CloseStatus = gen.WorkflowExecutionCloseStatusTerminated.Ptr()
+		resp.Reason = attributes.Reason
+		resp.Details = attributes.Details
+	case gen.EventTypeWorkflowExecutionContinuedAsNew:
+		attributes := closeEvent.WorkflowExecutionContinuedAsNewEventAttributes
+		resp.CloseStatus = gen.WorkflowExecutionCloseStatusContinuedAsNew.Ptr()
+		resp.NewExecutionRunId = attributes.NewExecutionRunId
+	case gen.EventTypeWorkflowExecutionTimedOut:
+		attributes := closeEvent.WorkflowExecutionTimedOutEventAttributes
+		resp.CloseStatus = gen.WorkflowExecutionCloseStatusTimedOut.Ptr()
+		resp.TimeoutType = attributes.TimeoutType
+	default:
+		return nil, &gen.InternalServiceError{
+			Message: fmt.Sprintf("Last event of closed workflow execution is not a close event: %v.", closeEvent.GetEventType()),
+		}
+	}
+	return resp, nil
+}
+
 func deserializeHistoryToken(bytes []byte) (*getHistoryContinuationToken, error) {
 	token := &getHistoryContinuationToken{}
 	err := json.Unmarshal(bytes, token)
@@ -2463,12 +3794,42 @@ func serializeHistoryToken(token *getHistoryContinuationToken) ([]byte, error) {
 	return bytes, err
 }
 
-func createServiceBusyError() *gen.ServiceBusyError {
+func createServiceBusyError(retryAfter time.Duration) *gen.ServiceBusyError {
 	err := &gen.ServiceBusyError{}
 	err.Message = "Too many outstanding requests to the cadence service"
+	err.RetryAfterMillis = common.Int64Ptr(int64(retryAfter / time.Millisecond))
 	return err
 }
 
+// isPollTransientError is used to retry polls on matching. A busy matching host is not polled
+// again; its error, including the retry hint, is returned to the worker instead.
+func isPollTransientError(err error) bool {
+	if _, ok := err.(*gen.ServiceBusyError); ok {
+		return false
+	}
+	return common.IsServiceTransientError(err)
+}
+
+// validateFailoverDrillCluster checks a failover drill can target the cluster, an empty name ends the drill
+func validateFailoverDrillCluster(isGlobalDomain bool, replicationConfig *persistence.DomainReplicationConfig,
+	drillClusterName string) error {
+	if drillClusterName == "" {
+		return nil
+	}
+	if !isGlobalDomain {
+		return errFailoverDrillOfLocalDomain
+	}
+	if drillClusterName == replicationConfig.ActiveClusterName {
+		return errFailoverDrillClusterNotStandby
+	}
+	for _, cluster := range replicationConfig.Clusters {
+		if cluster.ClusterName == drillClusterName {
+			return nil
+		}
+	}
+	return errFailoverDrillClusterNotStandby
+}
+
 func (wh *WorkflowHandler) validateClusterName(clusterName string) error {
 	clusterMetadata := wh.GetClusterMetadata()
 	if _, ok := clusterMetadata.GetAllClusterFailoverVersions()[clusterName]; !ok {
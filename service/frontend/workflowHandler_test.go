@@ -21,9 +21,23 @@
 package frontend
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	h "github.com/uber/cadence/.gen/go/history"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
 func TestMergeDomainData_Overriding(t *testing.T) {
@@ -92,3 +106,492 @@ func TestMergeDomainData_Nil(t *testing.T) {
 		"k1": "v2",
 	}, out)
 }
+
+func TestMergeDomainData_Removing(t *testing.T) {
+	wh := &WorkflowHandler{}
+	out := wh.mergeDomainData(
+		map[string]string{
+			"k0": "v0",
+			"k1": "v1",
+		},
+		map[string]string{
+			"k0": "",
+			"k2": "",
+		},
+	)
+
+	assert.Equal(t, map[string]string{
+		"k1": "v1",
+	}, out)
+}
+
+func TestCreateServiceBusyError_RetryAfter(t *testing.T) {
+	err := createServiceBusyError(1500 * time.Millisecond)
+	assert.Equal(t, int64(1500), err.GetRetryAfterMillis())
+}
+
+func TestIsPollTransientError(t *testing.T) {
+	assert.False(t, isPollTransientError(&gen.ServiceBusyError{}))
+	assert.False(t, isPollTransientError(&gen.BadRequestError{}))
+	assert.True(t, isPollTransientError(&gen.InternalServiceError{}))
+}
+
+func TestValidateExecutionTags(t *testing.T) {
+	quotas := map[string]interface{}{"team": 8, "cost-center": float64(4)}
+
+	assert.NoError(t, validateExecutionTags(nil, quotas))
+	assert.NoError(t, validateExecutionTags(map[string]string{"team": "payments", "cost-center": "1234"}, quotas))
+
+	err := validateExecutionTags(map[string]string{"owner": "bob"}, quotas)
+	assert.IsType(t, &gen.BadRequestError{}, err)
+
+	err = validateExecutionTags(map[string]string{"team": "payments-core"}, quotas)
+	assert.IsType(t, &gen.BadRequestError{}, err)
+
+	err = validateExecutionTags(map[string]string{"cost-center": "12345"}, quotas)
+	assert.IsType(t, &gen.BadRequestError{}, err)
+
+	err = validateExecutionTags(map[string]string{"team": "a"}, map[string]interface{}{"team": "8"})
+	assert.IsType(t, &gen.BadRequestError{}, err)
+}
+
+func TestValidateBlobSize(t *testing.T) {
+	assert.NoError(t, validateBlobSize("Input", 1024, 1024))
+
+	err := validateBlobSize("Input", 1025, 1024)
+	assert.IsType(t, &gen.BadRequestError{}, err)
+	assert.Equal(t, "Input size of 1025 bytes exceeds the limit of 1024 bytes.", err.(*gen.BadRequestError).Message)
+}
+
+func TestCreateGetWorkflowExecutionResultResponse(t *testing.T) {
+	execution := &gen.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr("rid")}
+
+	resp, err := createGetWorkflowExecutionResultResponse(execution, &gen.HistoryEvent{
+		EventType: gen.EventTypeWorkflowExecutionCompleted.Ptr(),
+		WorkflowExecutionCompletedEventAttributes: &gen.WorkflowExecutionCompletedEventAttributes{
+			Result: []byte("result"),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, execution, resp.Execution)
+	assert.Equal(t, gen.WorkflowExecutionCloseStatusCompleted, resp.GetCloseStatus())
+	assert.Equal(t, []byte("result"), resp.Result)
+
+	resp, err = createGetWorkflowExecutionResultResponse(execution, &gen.HistoryEvent{
+		EventType: gen.EventTypeWorkflowExecutionFailed.Ptr(),
+		WorkflowExecutionFailedEventAttributes: &gen.WorkflowExecutionFailedEventAttributes{
+			Reason:  common.StringPtr("reason"),
+			Details: []byte("details"),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, gen.WorkflowExecutionCloseStatusFailed, resp.GetCloseStatus())
+	assert.Equal(t, "reason", resp.GetReason())
+	assert.Equal(t, []byte("details"), resp.Details)
+	assert.Nil(t, resp.Result)
+
+	resp, err = createGetWorkflowExecutionResultResponse(execution, &gen.HistoryEvent{
+		EventType: gen.EventTypeWorkflowExecutionContinuedAsNew.Ptr(),
+		WorkflowExecutionContinuedAsNewEventAttributes: &gen.WorkflowExecutionContinuedAsNewEventAttributes{
+			NewExecutionRunId: common.StringPtr("new-rid"),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, gen.WorkflowExecutionCloseStatusContinuedAsNew, resp.GetCloseStatus())
+	assert.Equal(t, "new-rid", resp.GetNewExecutionRunId())
+
+	_, err = createGetWorkflowExecutionResultResponse(execution, &gen.HistoryEvent{
+		EventType: gen.EventTypeDecisionTaskCompleted.Ptr(),
+	})
+	assert.IsType(t, &gen.InternalServiceError{}, err)
+
+	// no page of the history had any event
+	_, err = createGetWorkflowExecutionResultResponse(execution, nil)
+	assert.IsType(t, &gen.InternalServiceError{}, err)
+}
+
+func TestValidateFailoverDrillCluster(t *testing.T) {
+	replicationConfig := &persistence.DomainReplicationConfig{
+		ActiveClusterName: "active",
+		Clusters: []*persistence.ClusterReplicationConfig{
+			{ClusterName: "active"},
+			{ClusterName: "standby"},
+		},
+	}
+
+	assert.NoError(t, validateFailoverDrillCluster(false, replicationConfig, ""))
+	assert.NoError(t, validateFailoverDrillCluster(true, replicationConfig, "standby"))
+	assert.Equal(t, errFailoverDrillOfLocalDomain, validateFailoverDrillCluster(false, replicationConfig, "standby"))
+	assert.Equal(t, errFailoverDrillClusterNotStandby, validateFailoverDrillCluster(true, replicationConfig, "active"))
+	assert.Equal(t, errFailoverDrillClusterNotStandby, validateFailoverDrillCluster(true, replicationConfig, "unknown"))
+}
+
+func TestAuditHistoryPage(t *testing.T) {
+	event := func(eventID, version int64) *gen.HistoryEvent {
+		return &gen.HistoryEvent{EventId: common.Int64Ptr(eventID), Version: common.Int64Ptr(version)}
+	}
+	token := &getHistoryContinuationToken{RunID: "run", FirstEventID: 1, NextEventID: 6}
+
+	// tokens without the last event do not audit the boundary
+	assert.NoError(t, auditHistoryPage(token, &gen.History{Events: []*gen.HistoryEvent{event(1, 1), event(2, 1)}}))
+	assert.Equal(t, int64(2), token.LastEventID)
+	assert.Equal(t, int64(1), token.LastEventVersion)
+
+	// the transient decision events are not recorded as the last event
+	assert.NoError(t, auditHistoryPage(token, &gen.History{Events: []*gen.HistoryEvent{
+		event(3, 1), event(4, 2), event(5, 2), event(6, 2), event(7, 2),
+	}}))
+	assert.Equal(t, int64(5), token.LastEventID)
+	assert.Equal(t, int64(2), token.LastEventVersion)
+
+	token.NextEventID = 10
+	err := auditHistoryPage(token, &gen.History{Events: []*gen.HistoryEvent{event(7, 2)}})
+	assert.IsType(t, &persistence.HistoryEventsCorruptedError{}, err)
+	err = auditHistoryPage(token, &gen.History{Events: []*gen.HistoryEvent{event(6, 1)}})
+	assert.IsType(t, &persistence.HistoryEventsCorruptedError{}, err)
+	assert.Equal(t, int64(5), token.LastEventID)
+}
+
+// newTestWorkflowHandler returns a handler serving requests without being started, the tests set the domain
+// cache, clients and managers the requests they make use
+func newTestWorkflowHandler() *WorkflowHandler {
+	config := NewConfig(dynamicconfig.NewNopCollection())
+	metricsClient := metrics.NewClient(tally.NoopScope, metrics.Frontend)
+	return &WorkflowHandler{
+		Service:            service.NewTestService(nil, nil, metricsClient, bark.NewNopLogger()),
+		config:             config,
+		metricsClient:      metricsClient,
+		rateLimiter:        common.NewTokenBucket(config.RPS(), common.NewRealTimeSource()),
+		hSerializerFactory: persistence.NewHistorySerializerFactory(),
+		concurrencyLimiter: common.NewConcurrencyLimiter(
+			common.ConcurrencyLimitsFromMap(func() map[string]interface{} { return config.MaxConcurrentRequests() }),
+			func() time.Duration { return config.ConcurrentRequestsWaitTimeout() },
+		),
+		pageTokenSigner: newPageTokenSigner(config.PageTokenSigningKey, config.PageTokenTTL,
+			config.AcceptUnsignedPageTokens, common.NewRealTimeSource()),
+	}
+}
+
+func newTestSerializedHistory(t *testing.T, events ...*gen.HistoryEvent) []persistence.SerializedHistoryEventBatch {
+	batch, err := persistence.NewJSONHistorySerializer().Serialize(persistence.NewHistoryEventBatch(
+		persistence.GetDefaultHistoryVersion(), events))
+	assert.NoError(t, err)
+	return []persistence.SerializedHistoryEventBatch{*batch}
+}
+
+func TestGetWorkflowExecutionGraph(t *testing.T) {
+	domainCache := &cache.DomainCacheMock{}
+	historyClient := &mocks.HistoryClient{}
+	historyMgr := &mocks.HistoryManager{}
+	wh := newTestWorkflowHandler()
+	wh.domainCache = domainCache
+	wh.history = historyClient
+	wh.historyMgr = historyMgr
+
+	execution := &gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("wid"),
+		RunId:      common.StringPtr("4b8a0cd2-1d9a-4a2c-9c8e-2a7d4e4ab1f1"),
+	}
+	retriedScheduled := newGraphTestEvent(5, gen.EventTypeActivityTaskScheduled)
+	retriedScheduled.ActivityTaskScheduledEventAttributes = &gen.ActivityTaskScheduledEventAttributes{
+		ActivityId:   common.StringPtr("retried"),
+		ActivityType: &gen.ActivityType{Name: common.StringPtr("activity-type")},
+		RetryPolicy:  &gen.RetryPolicy{MaximumAttempts: common.Int32Ptr(3)},
+	}
+	activityScheduled := newGraphTestEvent(6, gen.EventTypeActivityTaskScheduled)
+	activityScheduled.ActivityTaskScheduledEventAttributes = &gen.ActivityTaskScheduledEventAttributes{
+		ActivityId:   common.StringPtr("activity"),
+		ActivityType: &gen.ActivityType{Name: common.StringPtr("activity-type")},
+	}
+
+	domainCache.On("GetDomainID", "test-domain").Return("test-domain-id", nil)
+	historyClient.On("GetMutableState", mock.Anything, &h.GetMutableStateRequest{
+		DomainUUID: common.StringPtr("test-domain-id"),
+		Execution:  execution,
+	}).Return(&h.GetMutableStateResponse{
+		Execution:         execution,
+		WorkflowType:      &gen.WorkflowType{Name: common.StringPtr("workflow")},
+		NextEventId:       common.Int64Ptr(7),
+		IsWorkflowRunning: common.BoolPtr(true),
+	}, nil).Once()
+	historyMgr.On("GetWorkflowExecutionHistory", mock.Anything).Return(&persistence.GetWorkflowExecutionHistoryResponse{
+		Events: newTestSerializedHistory(t,
+			newGraphTestEvent(1, gen.EventTypeWorkflowExecutionStarted),
+			newGraphTestEvent(2, gen.EventTypeDecisionTaskScheduled),
+			newGraphTestEvent(3, gen.EventTypeDecisionTaskStarted),
+			newGraphTestEvent(4, gen.EventTypeDecisionTaskCompleted),
+			retriedScheduled,
+			activityScheduled,
+		),
+	}, nil).Once()
+	// the retried activity is running, its started event is recorded once it is closed
+	historyClient.On("DescribeWorkflowExecution", mock.Anything, &h.DescribeWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr("test-domain-id"),
+		Request: &gen.DescribeWorkflowExecutionRequest{
+			Domain:    common.StringPtr("test-domain"),
+			Execution: execution,
+		},
+	}).Return(&gen.DescribeWorkflowExecutionResponse{
+		PendingActivities: []*gen.PendingActivityInfo{
+			{ActivityID: common.StringPtr("retried"), State: gen.PendingActivityStateStarted.Ptr()},
+			{ActivityID: common.StringPtr("activity"), State: gen.PendingActivityStateScheduled.Ptr()},
+		},
+	}, nil).Once()
+
+	resp, err := wh.GetWorkflowExecutionGraph(context.Background(), &gen.GetWorkflowExecutionGraphRequest{
+		Domain:    common.StringPtr("test-domain"),
+		Execution: execution,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, execution, resp.Execution)
+	assert.Equal(t, "workflow", resp.WorkflowType.GetName())
+	assert.Equal(t, int64(100), resp.GetStartTimestamp())
+	assert.Nil(t, resp.CloseStatus)
+	assert.Equal(t, 2, len(resp.Nodes))
+	assert.Equal(t, "retried", resp.Nodes[0].GetID())
+	assert.Equal(t, gen.ExecutionGraphNodeStateStarted, resp.Nodes[0].GetState())
+	assert.Equal(t, "activity", resp.Nodes[1].GetID())
+	assert.Equal(t, gen.ExecutionGraphNodeStateScheduled, resp.Nodes[1].GetState())
+	historyClient.AssertExpectations(t)
+	historyMgr.AssertExpectations(t)
+}
+
+func TestGetWorkflowExecutionGraph_Closed(t *testing.T) {
+	domainCache := &cache.DomainCacheMock{}
+	historyClient := &mocks.HistoryClient{}
+	historyMgr := &mocks.HistoryManager{}
+	wh := newTestWorkflowHandler()
+	wh.domainCache = domainCache
+	wh.history = historyClient
+	wh.historyMgr = historyMgr
+
+	execution := &gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("wid"),
+		RunId:      common.StringPtr("4b8a0cd2-1d9a-4a2c-9c8e-2a7d4e4ab1f1"),
+	}
+	domainCache.On("GetDomainID", "test-domain").Return("test-domain-id", nil)
+	historyClient.On("GetMutableState", mock.Anything, mock.Anything).Return(&h.GetMutableStateResponse{
+		Execution:         execution,
+		WorkflowType:      &gen.WorkflowType{Name: common.StringPtr("workflow")},
+		NextEventId:       common.Int64Ptr(5),
+		IsWorkflowRunning: common.BoolPtr(false),
+	}, nil).Once()
+	historyMgr.On("GetWorkflowExecutionHistory", mock.Anything).Return(&persistence.GetWorkflowExecutionHistoryResponse{
+		Events: newTestSerializedHistory(t,
+			newGraphTestEvent(1, gen.EventTypeWorkflowExecutionStarted),
+			newGraphTestEvent(2, gen.EventTypeDecisionTaskScheduled),
+			newGraphTestEvent(3, gen.EventTypeDecisionTaskStarted),
+			newGraphTestEvent(4, gen.EventTypeWorkflowExecutionTerminated),
+		),
+	}, nil).Once()
+
+	// a closed run has no pending activities, its mutable state is not described
+	resp, err := wh.GetWorkflowExecutionGraph(context.Background(), &gen.GetWorkflowExecutionGraphRequest{
+		Domain:    common.StringPtr("test-domain"),
+		Execution: execution,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, gen.WorkflowExecutionCloseStatusTerminated, resp.GetCloseStatus())
+	assert.Equal(t, int64(400), resp.GetCloseTimestamp())
+	assert.Empty(t, resp.Nodes)
+	historyClient.AssertExpectations(t)
+	historyMgr.AssertExpectations(t)
+}
+
+func TestGetWorkflowExecutionHistory_SummaryEvents(t *testing.T) {
+	domainCache := &cache.DomainCacheMock{}
+	historyClient := &mocks.HistoryClient{}
+	historyMgr := &mocks.HistoryManager{}
+	wh := newTestWorkflowHandler()
+	wh.domainCache = domainCache
+	wh.history = historyClient
+	wh.historyMgr = historyMgr
+
+	newEvent := func(eventID int64, eventType gen.EventType) *gen.HistoryEvent {
+		return &gen.HistoryEvent{EventId: common.Int64Ptr(eventID), EventType: eventType.Ptr()}
+	}
+	started := newEvent(1, gen.EventTypeWorkflowExecutionStarted)
+	started.WorkflowExecutionStartedEventAttributes = &gen.WorkflowExecutionStartedEventAttributes{
+		Input: []byte("input"),
+	}
+	decisionCompleted := newEvent(4, gen.EventTypeDecisionTaskCompleted)
+	decisionCompleted.DecisionTaskCompletedEventAttributes = &gen.DecisionTaskCompletedEventAttributes{
+		ScheduledEventId: common.Int64Ptr(2),
+		ExecutionContext: []byte("context"),
+	}
+	activityScheduled := newEvent(5, gen.EventTypeActivityTaskScheduled)
+	activityScheduled.ActivityTaskScheduledEventAttributes = &gen.ActivityTaskScheduledEventAttributes{
+		ActivityId: common.StringPtr("activity"),
+		Input:      []byte("input"),
+	}
+	activityCompleted := newEvent(7, gen.EventTypeActivityTaskCompleted)
+	activityCompleted.ActivityTaskCompletedEventAttributes = &gen.ActivityTaskCompletedEventAttributes{
+		ScheduledEventId: common.Int64Ptr(5),
+		Result:           []byte("result"),
+	}
+	completed := newEvent(8, gen.EventTypeWorkflowExecutionCompleted)
+	completed.WorkflowExecutionCompletedEventAttributes = &gen.WorkflowExecutionCompletedEventAttributes{
+		Result: []byte("result"),
+	}
+
+	execution := &gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("wid"),
+		RunId:      common.StringPtr("4b8a0cd2-1d9a-4a2c-9c8e-2a7d4e4ab1f1"),
+	}
+	domainCache.On("GetDomainID", "test-domain").Return("test-domain-id", nil)
+	historyClient.On("GetMutableState", mock.Anything, mock.Anything).Return(&h.GetMutableStateResponse{
+		Execution:         execution,
+		LastFirstEventId:  common.Int64Ptr(8),
+		NextEventId:       common.Int64Ptr(9),
+		IsWorkflowRunning: common.BoolPtr(false),
+	}, nil).Once()
+	historyMgr.On("GetWorkflowExecutionHistory", mock.Anything).Return(&persistence.GetWorkflowExecutionHistoryResponse{
+		Events: newTestSerializedHistory(t,
+			started,
+			newEvent(2, gen.EventTypeDecisionTaskScheduled),
+			newEvent(3, gen.EventTypeDecisionTaskStarted),
+			decisionCompleted,
+		),
+		NextPageToken: []byte("page2"),
+	}, nil).Once()
+	historyMgr.On("GetWorkflowExecutionHistory", mock.Anything).Return(&persistence.GetWorkflowExecutionHistoryResponse{
+		Events: newTestSerializedHistory(t,
+			activityScheduled,
+			newEvent(6, gen.EventTypeActivityTaskStarted),
+			activityCompleted,
+			completed,
+		),
+	}, nil).Once()
+
+	resp, err := wh.GetWorkflowExecutionHistory(context.Background(), &gen.GetWorkflowExecutionHistoryRequest{
+		Domain:                 common.StringPtr("test-domain"),
+		Execution:              &gen.WorkflowExecution{WorkflowId: execution.WorkflowId, RunId: execution.RunId},
+		HistoryEventFilterType: gen.HistoryEventFilterTypeSummaryEvent.Ptr(),
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.NextPageToken)
+	assert.Equal(t, 2, len(resp.History.Events))
+	assert.Equal(t, int64(1), resp.History.Events[0].GetEventId())
+	assert.Nil(t, resp.History.Events[0].WorkflowExecutionStartedEventAttributes.Input)
+	assert.Equal(t, int64(4), resp.History.Events[1].GetEventId())
+	assert.Equal(t, int64(2), resp.History.Events[1].DecisionTaskCompletedEventAttributes.GetScheduledEventId())
+	assert.Nil(t, resp.History.Events[1].DecisionTaskCompletedEventAttributes.ExecutionContext)
+
+	resp, err = wh.GetWorkflowExecutionHistory(context.Background(), &gen.GetWorkflowExecutionHistoryRequest{
+		Domain:                 common.StringPtr("test-domain"),
+		Execution:              &gen.WorkflowExecution{WorkflowId: execution.WorkflowId, RunId: execution.RunId},
+		HistoryEventFilterType: gen.HistoryEventFilterTypeSummaryEvent.Ptr(),
+		NextPageToken:          resp.NextPageToken,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.NextPageToken)
+	assert.Equal(t, 3, len(resp.History.Events))
+	assert.Equal(t, "activity", resp.History.Events[0].ActivityTaskScheduledEventAttributes.GetActivityId())
+	assert.Nil(t, resp.History.Events[0].ActivityTaskScheduledEventAttributes.Input)
+	assert.Equal(t, int64(5), resp.History.Events[1].ActivityTaskCompletedEventAttributes.GetScheduledEventId())
+	assert.Nil(t, resp.History.Events[1].ActivityTaskCompletedEventAttributes.Result)
+	assert.Equal(t, gen.EventTypeWorkflowExecutionCompleted, resp.History.Events[2].GetEventType())
+	assert.Nil(t, resp.History.Events[2].WorkflowExecutionCompletedEventAttributes.Result)
+	historyClient.AssertExpectations(t)
+	historyMgr.AssertExpectations(t)
+}
+
+func TestListWorkflowExecutions(t *testing.T) {
+	domainCache := &cache.DomainCacheMock{}
+	visibilityMgr := &mocks.VisibilityManager{}
+	wh := newTestWorkflowHandler()
+	wh.domainCache = domainCache
+	wh.visibitiltyMgr = visibilityMgr
+	wh.pageTokenSigner = newPageTokenSigner(
+		func(...dynamicconfig.FilterOption) string { return "test-key" },
+		func(...dynamicconfig.FilterOption) time.Duration { return time.Minute },
+		func(...dynamicconfig.FilterOption) bool { return false },
+		common.NewRealTimeSource(),
+	)
+
+	executions := []*gen.WorkflowExecutionInfo{
+		{Execution: &gen.WorkflowExecution{WorkflowId: common.StringPtr("open")}},
+		{Execution: &gen.WorkflowExecution{WorkflowId: common.StringPtr("closed")}},
+	}
+	newRequest := func(nextPageToken []byte) *gen.ListWorkflowExecutionsRequest {
+		return &gen.ListWorkflowExecutionsRequest{
+			Domain: common.StringPtr("test-domain"),
+			StartTimeFilter: &gen.StartTimeFilter{
+				EarliestTime: common.Int64Ptr(100),
+				LatestTime:   common.Int64Ptr(200),
+			},
+			TypeFilter:    &gen.WorkflowTypeFilter{Name: common.StringPtr("workflow")},
+			NextPageToken: nextPageToken,
+		}
+	}
+	newPersistenceRequest := func(nextPageToken []byte) *persistence.ListAllWorkflowExecutionsRequest {
+		return &persistence.ListAllWorkflowExecutionsRequest{
+			ListWorkflowExecutionsRequest: persistence.ListWorkflowExecutionsRequest{
+				DomainUUID:        "test-domain-id",
+				EarliestStartTime: 100,
+				LatestStartTime:   200,
+				PageSize:          wh.config.VisibilityMaxPageSize("test-domain"),
+				NextPageToken:     nextPageToken,
+			},
+			WorkflowTypeName: "workflow",
+		}
+	}
+
+	domainCache.On("GetDomainID", "test-domain").Return("test-domain-id", nil)
+	visibilityMgr.On("ListAllWorkflowExecutions", newPersistenceRequest(nil)).Return(
+		&persistence.ListWorkflowExecutionsResponse{
+			Executions:    executions[:1],
+			NextPageToken: []byte("closed-page"),
+		}, nil).Once()
+	visibilityMgr.On("ListAllWorkflowExecutions", newPersistenceRequest([]byte("closed-page"))).Return(
+		&persistence.ListWorkflowExecutionsResponse{Executions: executions[1:]}, nil).Once()
+
+	resp, err := wh.ListWorkflowExecutions(context.Background(), newRequest(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, executions[:1], resp.Executions)
+	// the persistence token is handed out signed
+	assert.NotEmpty(t, resp.NextPageToken)
+	assert.NotEqual(t, []byte("closed-page"), resp.NextPageToken)
+
+	resp, err = wh.ListWorkflowExecutions(context.Background(), newRequest(resp.NextPageToken))
+	assert.NoError(t, err)
+	assert.Equal(t, executions[1:], resp.Executions)
+	assert.Empty(t, resp.NextPageToken)
+
+	// a token of the closed executions does not page through both lists
+	closedToken, err := wh.pageTokenSigner.sign(closedVisibilityPageTokenStore, "test-domain-id", []byte("closed-page"))
+	assert.NoError(t, err)
+	_, err = wh.ListWorkflowExecutions(context.Background(), newRequest(closedToken))
+	assert.Equal(t, errPageTokenStoreMismatch, err)
+	visibilityMgr.AssertExpectations(t)
+}
+
+func TestListWorkflowExecutions_InvalidRequest(t *testing.T) {
+	wh := newTestWorkflowHandler()
+	startTimeFilter := &gen.StartTimeFilter{EarliestTime: common.Int64Ptr(100), LatestTime: common.Int64Ptr(200)}
+
+	requests := []*gen.ListWorkflowExecutionsRequest{
+		{StartTimeFilter: startTimeFilter},
+		{Domain: common.StringPtr("test-domain")},
+		{
+			Domain:          common.StringPtr("test-domain"),
+			StartTimeFilter: &gen.StartTimeFilter{EarliestTime: common.Int64Ptr(100)},
+		},
+		{
+			Domain:          common.StringPtr("test-domain"),
+			StartTimeFilter: startTimeFilter,
+			ExecutionFilter: &gen.WorkflowExecutionFilter{WorkflowId: common.StringPtr("wid")},
+			TypeFilter:      &gen.WorkflowTypeFilter{Name: common.StringPtr("workflow")},
+		},
+		{
+			Domain:          common.StringPtr("test-domain"),
+			StartTimeFilter: startTimeFilter,
+			TagFilter:       &gen.TagFilter{Value: common.StringPtr("payments")},
+		},
+	}
+	// the requests are rejected before the domain or the visibility store is read
+	for _, request := range requests {
+		_, err := wh.ListWorkflowExecutions(context.Background(), request)
+		assert.IsType(t, &gen.BadRequestError{}, err)
+	}
+}
@@ -34,15 +34,15 @@ type mockConflictResolver struct {
 var _ conflictResolver = (*mockConflictResolver)(nil)
 
 // reset is mock implementation for reset of conflictResolver
-func (_m *mockConflictResolver) reset(requestID string, replayEventID int64, startTime time.Time) (mutableState, error) {
+func (_m *mockConflictResolver) reset(requestID string, replayEventID int64, startTime time.Time) (MutableState, error) {
 	ret := _m.Called(requestID, replayEventID, startTime)
 
-	var r0 mutableState
-	if rf, ok := ret.Get(0).(func(string, int64, time.Time) mutableState); ok {
+	var r0 MutableState
+	if rf, ok := ret.Get(0).(func(string, int64, time.Time) MutableState); ok {
 		r0 = rf(requestID, replayEventID, startTime)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(mutableState)
+			r0 = ret.Get(0).(MutableState)
 		}
 	}
 
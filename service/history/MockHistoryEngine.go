@@ -334,6 +334,81 @@ func (_m *MockHistoryEngine) SignalWorkflowExecution(ctx context.Context, reques
 	return r0
 }
 
+// UpdateWorkflowExecutionOptions is mock implementation for UpdateWorkflowExecutionOptions of HistoryEngine
+func (_m *MockHistoryEngine) UpdateWorkflowExecutionOptions(ctx context.Context, request *gohistory.UpdateWorkflowExecutionOptionsRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.UpdateWorkflowExecutionOptionsRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FixWorkflowExecution is mock implementation for FixWorkflowExecution of HistoryEngine
+func (_m *MockHistoryEngine) FixWorkflowExecution(ctx context.Context, request *gohistory.FixWorkflowExecutionRequest) (*gohistory.FixWorkflowExecutionResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *gohistory.FixWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(*gohistory.FixWorkflowExecutionRequest) *gohistory.FixWorkflowExecutionResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gohistory.FixWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gohistory.FixWorkflowExecutionRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWorkflowExecution is mock implementation for DeleteWorkflowExecution of HistoryEngine
+func (_m *MockHistoryEngine) DeleteWorkflowExecution(ctx context.Context, request *gohistory.DeleteWorkflowExecutionRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.DeleteWorkflowExecutionRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateWorkflowExecution is mock implementation for UpdateWorkflowExecution of HistoryEngine
+func (_m *MockHistoryEngine) UpdateWorkflowExecution(ctx context.Context, request *gohistory.UpdateWorkflowExecutionRequest) (
+	*shared.UpdateWorkflowExecutionResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *shared.UpdateWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(*gohistory.UpdateWorkflowExecutionRequest) *shared.UpdateWorkflowExecutionResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.UpdateWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gohistory.UpdateWorkflowExecutionRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SignalWithStartWorkflowExecution is mock implementation for SignalWithStartWorkflowExecution of HistoryEngine
 func (_m *MockHistoryEngine) SignalWithStartWorkflowExecution(ctx context.Context, request *gohistory.SignalWithStartWorkflowExecutionRequest) (
 	*shared.StartWorkflowExecutionResponse, error) {
@@ -442,4 +517,18 @@ func (_m *MockHistoryEngine) SyncShardStatus(ctx context.Context, request *gohis
 	return r0
 }
 
+// SyncActivity is mock implementation for SyncActivity of HistoryEngine
+func (_m *MockHistoryEngine) SyncActivity(ctx context.Context, request *gohistory.SyncActivityRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.SyncActivityRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 var _ Engine = (*MockHistoryEngine)(nil)
@@ -21,9 +21,12 @@
 package history
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 	h "github.com/uber/cadence/.gen/go/history"
 	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/persistence"
 )
@@ -33,7 +36,7 @@ type mockMutableState struct {
 	mock.Mock
 }
 
-var _ mutableState = (*mockMutableState)(nil)
+var _ MutableState = (*mockMutableState)(nil)
 
 // AddActivityTaskCancelRequestedEvent provides a mock function with given fields: _a0, _a1, _a2
 func (_m *mockMutableState) AddActivityTaskCancelRequestedEvent(_a0 int64, _a1 string, _a2 string) (*shared.HistoryEvent, *persistence.ActivityInfo, bool) {
@@ -301,7 +304,7 @@ func (_m *mockMutableState) AddCompletedWorkflowEvent(_a0 int64, _a1 *shared.Com
 }
 
 // AddContinueAsNewEvent provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
-func (_m *mockMutableState) AddContinueAsNewEvent(_a0 int64, _a1 *cache.DomainCacheEntry, _a2 string, _a3 string, _a4 *shared.ContinueAsNewWorkflowExecutionDecisionAttributes) (*shared.HistoryEvent, mutableState, error) {
+func (_m *mockMutableState) AddContinueAsNewEvent(_a0 int64, _a1 *cache.DomainCacheEntry, _a2 string, _a3 string, _a4 *shared.ContinueAsNewWorkflowExecutionDecisionAttributes) (*shared.HistoryEvent, MutableState, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3, _a4)
 
 	var r0 *shared.HistoryEvent
@@ -313,12 +316,12 @@ func (_m *mockMutableState) AddContinueAsNewEvent(_a0 int64, _a1 *cache.DomainCa
 		}
 	}
 
-	var r1 mutableState
-	if rf, ok := ret.Get(1).(func(int64, *cache.DomainCacheEntry, string, string, *shared.ContinueAsNewWorkflowExecutionDecisionAttributes) mutableState); ok {
+	var r1 MutableState
+	if rf, ok := ret.Get(1).(func(int64, *cache.DomainCacheEntry, string, string, *shared.ContinueAsNewWorkflowExecutionDecisionAttributes) MutableState); ok {
 		r1 = rf(_a0, _a1, _a2, _a3, _a4)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(mutableState)
+			r1 = ret.Get(1).(MutableState)
 		}
 	}
 
@@ -381,15 +384,15 @@ func (_m *mockMutableState) AddDecisionTaskScheduleToStartTimeoutEvent(_a0 int64
 }
 
 // AddDecisionTaskScheduledEvent provides a mock function with given fields:
-func (_m *mockMutableState) AddDecisionTaskScheduledEvent() *decisionInfo {
+func (_m *mockMutableState) AddDecisionTaskScheduledEvent() *DecisionInfo {
 	ret := _m.Called()
 
-	var r0 *decisionInfo
-	if rf, ok := ret.Get(0).(func() *decisionInfo); ok {
+	var r0 *DecisionInfo
+	if rf, ok := ret.Get(0).(func() *DecisionInfo); ok {
 		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*decisionInfo)
+			r0 = ret.Get(0).(*DecisionInfo)
 		}
 	}
 
@@ -397,7 +400,7 @@ func (_m *mockMutableState) AddDecisionTaskScheduledEvent() *decisionInfo {
 }
 
 // AddDecisionTaskStartedEvent provides a mock function with given fields: _a0, _a1, _a2
-func (_m *mockMutableState) AddDecisionTaskStartedEvent(_a0 int64, _a1 string, _a2 *shared.PollForDecisionTaskRequest) (*shared.HistoryEvent, *decisionInfo) {
+func (_m *mockMutableState) AddDecisionTaskStartedEvent(_a0 int64, _a1 string, _a2 *shared.PollForDecisionTaskRequest) (*shared.HistoryEvent, *DecisionInfo) {
 	ret := _m.Called(_a0, _a1, _a2)
 
 	var r0 *shared.HistoryEvent
@@ -409,12 +412,12 @@ func (_m *mockMutableState) AddDecisionTaskStartedEvent(_a0 int64, _a1 string, _
 		}
 	}
 
-	var r1 *decisionInfo
-	if rf, ok := ret.Get(1).(func(int64, string, *shared.PollForDecisionTaskRequest) *decisionInfo); ok {
+	var r1 *DecisionInfo
+	if rf, ok := ret.Get(1).(func(int64, string, *shared.PollForDecisionTaskRequest) *DecisionInfo); ok {
 		r1 = rf(_a0, _a1, _a2)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*decisionInfo)
+			r1 = ret.Get(1).(*DecisionInfo)
 		}
 	}
 
@@ -783,11 +786,11 @@ func (_m *mockMutableState) AddWorkflowExecutionStartedEvent(_a0 shared.Workflow
 }
 
 // AddWorkflowExecutionStartedEventForContinueAsNew provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
-func (_m *mockMutableState) AddWorkflowExecutionStartedEventForContinueAsNew(_a0 string, _a1 *h.ParentExecutionInfo, _a2 shared.WorkflowExecution, _a3 mutableState, _a4 *shared.ContinueAsNewWorkflowExecutionDecisionAttributes) *shared.HistoryEvent {
+func (_m *mockMutableState) AddWorkflowExecutionStartedEventForContinueAsNew(_a0 string, _a1 *h.ParentExecutionInfo, _a2 shared.WorkflowExecution, _a3 MutableState, _a4 *shared.ContinueAsNewWorkflowExecutionDecisionAttributes) *shared.HistoryEvent {
 	ret := _m.Called(_a0, _a1, _a2, _a3, _a4)
 
 	var r0 *shared.HistoryEvent
-	if rf, ok := ret.Get(0).(func(string, *h.ParentExecutionInfo, shared.WorkflowExecution, mutableState, *shared.ContinueAsNewWorkflowExecutionDecisionAttributes) *shared.HistoryEvent); ok {
+	if rf, ok := ret.Get(0).(func(string, *h.ParentExecutionInfo, shared.WorkflowExecution, MutableState, *shared.ContinueAsNewWorkflowExecutionDecisionAttributes) *shared.HistoryEvent); ok {
 		r0 = rf(_a0, _a1, _a2, _a3, _a4)
 	} else {
 		if ret.Get(0) != nil {
@@ -798,6 +801,22 @@ func (_m *mockMutableState) AddWorkflowExecutionStartedEventForContinueAsNew(_a0
 	return r0
 }
 
+// AddWorkflowExecutionTaskListChangedEvent provides a mock function with given fields: _a0, _a1
+func (_m *mockMutableState) AddWorkflowExecutionTaskListChangedEvent(_a0 *shared.TaskList, _a1 string) *shared.HistoryEvent {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *shared.HistoryEvent
+	if rf, ok := ret.Get(0).(func(*shared.TaskList, string) *shared.HistoryEvent); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.HistoryEvent)
+		}
+	}
+
+	return r0
+}
+
 // AddWorkflowExecutionTerminatedEvent provides a mock function with given fields: _a0
 func (_m *mockMutableState) AddWorkflowExecutionTerminatedEvent(_a0 *shared.TerminateWorkflowExecutionRequest) *shared.HistoryEvent {
 	ret := _m.Called(_a0)
@@ -814,6 +833,38 @@ func (_m *mockMutableState) AddWorkflowExecutionTerminatedEvent(_a0 *shared.Term
 	return r0
 }
 
+// AddWorkflowExecutionUpdateCompletedEvent provides a mock function with given fields: _a0, _a1
+func (_m *mockMutableState) AddWorkflowExecutionUpdateCompletedEvent(_a0 int64, _a1 *shared.CompleteWorkflowUpdateDecisionAttributes) *shared.HistoryEvent {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *shared.HistoryEvent
+	if rf, ok := ret.Get(0).(func(int64, *shared.CompleteWorkflowUpdateDecisionAttributes) *shared.HistoryEvent); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.HistoryEvent)
+		}
+	}
+
+	return r0
+}
+
+// AddWorkflowExecutionUpdateRequestedEvent provides a mock function with given fields: _a0
+func (_m *mockMutableState) AddWorkflowExecutionUpdateRequestedEvent(_a0 *shared.UpdateWorkflowExecutionRequest) *shared.HistoryEvent {
+	ret := _m.Called(_a0)
+
+	var r0 *shared.HistoryEvent
+	if rf, ok := ret.Get(0).(func(*shared.UpdateWorkflowExecutionRequest) *shared.HistoryEvent); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.HistoryEvent)
+		}
+	}
+
+	return r0
+}
+
 // AfterAddDecisionTaskCompletedEvent provides a mock function with given fields: _a0
 func (_m *mockMutableState) AfterAddDecisionTaskCompletedEvent(_a0 int64) {
 	_m.Called(_a0)
@@ -844,15 +895,15 @@ func (_m *mockMutableState) ClearStickyness() {
 }
 
 // CloseUpdateSession provides a mock function with given fields:
-func (_m *mockMutableState) CloseUpdateSession() (*mutableStateSessionUpdates, error) {
+func (_m *mockMutableState) CloseUpdateSession() (*MutableStateSessionUpdates, error) {
 	ret := _m.Called()
 
-	var r0 *mutableStateSessionUpdates
-	if rf, ok := ret.Get(0).(func() *mutableStateSessionUpdates); ok {
+	var r0 *MutableStateSessionUpdates
+	if rf, ok := ret.Get(0).(func() *MutableStateSessionUpdates); ok {
 		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*mutableStateSessionUpdates)
+			r0 = ret.Get(0).(*MutableStateSessionUpdates)
 		}
 	}
 
@@ -947,11 +998,11 @@ func (_m *mockMutableState) CreateRetryTimer(_a0 *persistence.ActivityInfo, _a1
 }
 
 // CreateTransientDecisionEvents provides a mock function with given fields: di, identity
-func (_m *mockMutableState) CreateTransientDecisionEvents(di *decisionInfo, identity string) (*shared.HistoryEvent, *shared.HistoryEvent) {
+func (_m *mockMutableState) CreateTransientDecisionEvents(di *DecisionInfo, identity string) (*shared.HistoryEvent, *shared.HistoryEvent) {
 	ret := _m.Called(di, identity)
 
 	var r0 *shared.HistoryEvent
-	if rf, ok := ret.Get(0).(func(*decisionInfo, string) *shared.HistoryEvent); ok {
+	if rf, ok := ret.Get(0).(func(*DecisionInfo, string) *shared.HistoryEvent); ok {
 		r0 = rf(di, identity)
 	} else {
 		if ret.Get(0) != nil {
@@ -960,7 +1011,7 @@ func (_m *mockMutableState) CreateTransientDecisionEvents(di *decisionInfo, iden
 	}
 
 	var r1 *shared.HistoryEvent
-	if rf, ok := ret.Get(1).(func(*decisionInfo, string) *shared.HistoryEvent); ok {
+	if rf, ok := ret.Get(1).(func(*DecisionInfo, string) *shared.HistoryEvent); ok {
 		r1 = rf(di, identity)
 	} else {
 		if ret.Get(1) != nil {
@@ -1309,15 +1360,15 @@ func (_m *mockMutableState) GetExecutionInfo() *persistence.WorkflowExecutionInf
 }
 
 // GetHistoryBuilder provides a mock function with given fields:
-func (_m *mockMutableState) GetHistoryBuilder() *historyBuilder {
+func (_m *mockMutableState) GetHistoryBuilder() *HistoryBuilder {
 	ret := _m.Called()
 
-	var r0 *historyBuilder
-	if rf, ok := ret.Get(0).(func() *historyBuilder); ok {
+	var r0 *HistoryBuilder
+	if rf, ok := ret.Get(0).(func() *HistoryBuilder); ok {
 		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*historyBuilder)
+			r0 = ret.Get(0).(*HistoryBuilder)
 		}
 	}
 
@@ -1347,15 +1398,15 @@ func (_m *mockMutableState) GetHistoryEvent(serializedEvent []byte) (*shared.His
 	return r0, r1
 }
 
-func (_m *mockMutableState) GetInFlightDecisionTask() (*decisionInfo, bool) {
+func (_m *mockMutableState) GetInFlightDecisionTask() (*DecisionInfo, bool) {
 	ret := _m.Called()
 
-	var r0 *decisionInfo
-	if rf, ok := ret.Get(0).(func() *decisionInfo); ok {
+	var r0 *DecisionInfo
+	if rf, ok := ret.Get(0).(func() *DecisionInfo); ok {
 		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*decisionInfo)
+			r0 = ret.Get(0).(*DecisionInfo)
 		}
 	}
 
@@ -1458,15 +1509,15 @@ func (_m *mockMutableState) GetPendingChildExecutionInfos() map[int64]*persisten
 }
 
 // GetPendingDecision provides a mock function with given fields: _a0
-func (_m *mockMutableState) GetPendingDecision(_a0 int64) (*decisionInfo, bool) {
+func (_m *mockMutableState) GetPendingDecision(_a0 int64) (*DecisionInfo, bool) {
 	ret := _m.Called(_a0)
 
-	var r0 *decisionInfo
-	if rf, ok := ret.Get(0).(func(int64) *decisionInfo); ok {
+	var r0 *DecisionInfo
+	if rf, ok := ret.Get(0).(func(int64) *DecisionInfo); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*decisionInfo)
+			r0 = ret.Get(0).(*DecisionInfo)
 		}
 	}
 
@@ -1496,6 +1547,20 @@ func (_m *mockMutableState) GetPendingTimerInfos() map[string]*persistence.Timer
 	return r0
 }
 
+// GetPersistedActivityHeartbeat provides a mock function with given fields: _a0
+func (_m *mockMutableState) GetPersistedActivityHeartbeat(_a0 int64) time.Time {
+	ret := _m.Called(_a0)
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func(int64) time.Time); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
 // GetReplicationState provides a mock function with given fields:
 func (_m *mockMutableState) GetReplicationState() *persistence.ReplicationState {
 	ret := _m.Called()
@@ -1593,6 +1658,43 @@ func (_m *mockMutableState) GetStartVersion() int64 {
 	return r0
 }
 
+// GetUpdateInfo provides a mock function with given fields: _a0
+func (_m *mockMutableState) GetUpdateInfo(_a0 string) (*persistence.UpdateInfo, bool) {
+	ret := _m.Called(_a0)
+
+	var r0 *persistence.UpdateInfo
+	if rf, ok := ret.Get(0).(func(string) *persistence.UpdateInfo); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.UpdateInfo)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// GetUpdateInfoCount provides a mock function with given fields:
+func (_m *mockMutableState) GetUpdateInfoCount() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // GetUserTimer provides a mock function with given fields: _a0
 func (_m *mockMutableState) GetUserTimer(_a0 string) (bool, *persistence.TimerInfo) {
 	ret := _m.Called(_a0)
@@ -1702,6 +1804,20 @@ func (_m *mockMutableState) HasPendingDecisionTask() bool {
 	return r0
 }
 
+// InvalidateStickyness provides a mock function with given fields:
+func (_m *mockMutableState) InvalidateStickyness() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
 // IsCancelRequested provides a mock function with given fields:
 func (_m *mockMutableState) IsCancelRequested() (bool, string) {
 	ret := _m.Called()
@@ -1902,15 +2018,15 @@ func (_m *mockMutableState) ReplicateDecisionTaskFailedEvent(_a0 int64, _a1 int6
 }
 
 // ReplicateDecisionTaskScheduledEvent provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *mockMutableState) ReplicateDecisionTaskScheduledEvent(_a0 int64, _a1 int64, _a2 string, _a3 int32) *decisionInfo {
+func (_m *mockMutableState) ReplicateDecisionTaskScheduledEvent(_a0 int64, _a1 int64, _a2 string, _a3 int32) *DecisionInfo {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
 
-	var r0 *decisionInfo
-	if rf, ok := ret.Get(0).(func(int64, int64, string, int32) *decisionInfo); ok {
+	var r0 *DecisionInfo
+	if rf, ok := ret.Get(0).(func(int64, int64, string, int32) *DecisionInfo); ok {
 		r0 = rf(_a0, _a1, _a2, _a3)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*decisionInfo)
+			r0 = ret.Get(0).(*DecisionInfo)
 		}
 	}
 
@@ -1918,15 +2034,15 @@ func (_m *mockMutableState) ReplicateDecisionTaskScheduledEvent(_a0 int64, _a1 i
 }
 
 // ReplicateDecisionTaskStartedEvent provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4, _a5
-func (_m *mockMutableState) ReplicateDecisionTaskStartedEvent(_a0 *decisionInfo, _a1 int64, _a2 int64, _a3 int64, _a4 string, _a5 int64) *decisionInfo {
+func (_m *mockMutableState) ReplicateDecisionTaskStartedEvent(_a0 *DecisionInfo, _a1 int64, _a2 int64, _a3 int64, _a4 string, _a5 int64) *DecisionInfo {
 	ret := _m.Called(_a0, _a1, _a2, _a3, _a4, _a5)
 
-	var r0 *decisionInfo
-	if rf, ok := ret.Get(0).(func(*decisionInfo, int64, int64, int64, string, int64) *decisionInfo); ok {
+	var r0 *DecisionInfo
+	if rf, ok := ret.Get(0).(func(*DecisionInfo, int64, int64, int64, string, int64) *DecisionInfo); ok {
 		r0 = rf(_a0, _a1, _a2, _a3, _a4, _a5)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*decisionInfo)
+			r0 = ret.Get(0).(*DecisionInfo)
 		}
 	}
 
@@ -2053,7 +2169,7 @@ func (_m *mockMutableState) ReplicateWorkflowExecutionCompletedEvent(_a0 *shared
 }
 
 // ReplicateWorkflowExecutionContinuedAsNewEvent provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4, _a5
-func (_m *mockMutableState) ReplicateWorkflowExecutionContinuedAsNewEvent(_a0 string, _a1 string, _a2 *shared.HistoryEvent, _a3 *shared.HistoryEvent, _a4 *decisionInfo, _a5 mutableState) {
+func (_m *mockMutableState) ReplicateWorkflowExecutionContinuedAsNewEvent(_a0 string, _a1 string, _a2 *shared.HistoryEvent, _a3 *shared.HistoryEvent, _a4 *DecisionInfo, _a5 MutableState) {
 	_m.Called(_a0, _a1, _a2, _a3, _a4, _a5)
 }
 
@@ -2067,6 +2183,11 @@ func (_m *mockMutableState) ReplicateWorkflowExecutionStartedEvent(_a0 string, _
 	_m.Called(_a0, _a1, _a2, _a3, _a4)
 }
 
+// ReplicateWorkflowExecutionTaskListChangedEvent provides a mock function with given fields: _a0
+func (_m *mockMutableState) ReplicateWorkflowExecutionTaskListChangedEvent(_a0 *shared.HistoryEvent) {
+	_m.Called(_a0)
+}
+
 // ReplicateWorkflowExecutionTerminatedEvent provides a mock function with given fields: _a0
 func (_m *mockMutableState) ReplicateWorkflowExecutionTerminatedEvent(_a0 *shared.HistoryEvent) {
 	_m.Called(_a0)
@@ -2077,6 +2198,27 @@ func (_m *mockMutableState) ReplicateWorkflowExecutionTimedoutEvent(_a0 *shared.
 	_m.Called(_a0)
 }
 
+// ReplicateWorkflowExecutionUpdateCompletedEvent provides a mock function with given fields: _a0
+func (_m *mockMutableState) ReplicateWorkflowExecutionUpdateCompletedEvent(_a0 *shared.HistoryEvent) {
+	_m.Called(_a0)
+}
+
+// ReplicateWorkflowExecutionUpdateRequestedEvent provides a mock function with given fields: _a0
+func (_m *mockMutableState) ReplicateWorkflowExecutionUpdateRequestedEvent(_a0 *shared.HistoryEvent) *persistence.UpdateInfo {
+	ret := _m.Called(_a0)
+
+	var r0 *persistence.UpdateInfo
+	if rf, ok := ret.Get(0).(func(*shared.HistoryEvent) *persistence.UpdateInfo); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.UpdateInfo)
+		}
+	}
+
+	return r0
+}
+
 // ResetSnapshot provides a mock function with given fields:
 func (_m *mockMutableState) ResetSnapshot() *persistence.ResetMutableStateRequest {
 	ret := _m.Called()
@@ -2094,10 +2236,15 @@ func (_m *mockMutableState) ResetSnapshot() *persistence.ResetMutableStateReques
 }
 
 // SetHistoryBuilder provides a mock function with given fields: hBuilder
-func (_m *mockMutableState) SetHistoryBuilder(hBuilder *historyBuilder) {
+func (_m *mockMutableState) SetHistoryBuilder(hBuilder *HistoryBuilder) {
 	_m.Called(hBuilder)
 }
 
+// SetHistoryEncodingFn provides a mock function with given fields: encodingFn
+func (_m *mockMutableState) SetHistoryEncodingFn(encodingFn func() common.EncodingType) {
+	_m.Called(encodingFn)
+}
+
 // UpdateActivity provides a mock function with given fields: _a0
 func (_m *mockMutableState) UpdateActivity(_a0 *persistence.ActivityInfo) error {
 	ret := _m.Called(_a0)
@@ -2118,7 +2265,7 @@ func (_m *mockMutableState) UpdateActivityProgress(ai *persistence.ActivityInfo,
 }
 
 // UpdateDecision provides a mock function with given fields: _a0
-func (_m *mockMutableState) UpdateDecision(_a0 *decisionInfo) {
+func (_m *mockMutableState) UpdateDecision(_a0 *DecisionInfo) {
 	_m.Called(_a0)
 }
 
@@ -95,7 +95,7 @@ func (_m *mockStateBuilder) getNewRunTimerTasks() []persistence.Task {
 
 // applyEvents provides a mock function with given fields: domainID, requestID, execution, _a3, newRunHistory
 func (_m *mockStateBuilder) applyEvents(domainID string, requestID string, execution shared.WorkflowExecution, _a3 *shared.History,
-	newRunHistory *shared.History) (*shared.HistoryEvent, *decisionInfo, mutableState, error) {
+	newRunHistory *shared.History) (*shared.HistoryEvent, *DecisionInfo, MutableState, error) {
 
 	ret := _m.Called(domainID, requestID, execution, _a3, newRunHistory)
 
@@ -108,21 +108,21 @@ func (_m *mockStateBuilder) applyEvents(domainID string, requestID string, execu
 		}
 	}
 
-	var r1 *decisionInfo
-	if rf, ok := ret.Get(1).(func(string, string, shared.WorkflowExecution, *shared.History, *shared.History) *decisionInfo); ok {
+	var r1 *DecisionInfo
+	if rf, ok := ret.Get(1).(func(string, string, shared.WorkflowExecution, *shared.History, *shared.History) *DecisionInfo); ok {
 		r1 = rf(domainID, requestID, execution, _a3, newRunHistory)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*decisionInfo)
+			r1 = ret.Get(1).(*DecisionInfo)
 		}
 	}
 
-	var r2 mutableState
-	if rf, ok := ret.Get(2).(func(string, string, shared.WorkflowExecution, *shared.History, *shared.History) mutableState); ok {
+	var r2 MutableState
+	if rf, ok := ret.Get(2).(func(string, string, shared.WorkflowExecution, *shared.History, *shared.History) MutableState); ok {
 		r2 = rf(domainID, requestID, execution, _a3, newRunHistory)
 	} else {
 		if ret.Get(2) != nil {
-			r2 = ret.Get(2).(mutableState)
+			r2 = ret.Get(2).(MutableState)
 		}
 	}
 
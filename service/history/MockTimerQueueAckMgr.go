@@ -32,10 +32,10 @@ type MockTimerQueueAckMgr struct {
 	mock.Mock
 }
 
-var _ timerQueueAckMgr = (*MockTimerQueueAckMgr)(nil)
+var _ TimerQueueAckMgr = (*MockTimerQueueAckMgr)(nil)
 
-// getFinishedChan is mock implementation for readTimerTasks of TimerQueueAckMgr
-func (_m *MockTimerQueueAckMgr) getFinishedChan() <-chan struct{} {
+// GetFinishedChan is mock implementation for GetFinishedChan of TimerQueueAckMgr
+func (_m *MockTimerQueueAckMgr) GetFinishedChan() <-chan struct{} {
 	ret := _m.Called()
 
 	var r0 <-chan struct{}
@@ -49,8 +49,8 @@ func (_m *MockTimerQueueAckMgr) getFinishedChan() <-chan struct{} {
 	return r0
 }
 
-// readTimerTasks is mock implementation for readTimerTasks of TimerQueueAckMgr
-func (_m *MockTimerQueueAckMgr) readTimerTasks() ([]*persistence.TimerTaskInfo, *persistence.TimerTaskInfo, bool, error) {
+// ReadTimerTasks is mock implementation for ReadTimerTasks of TimerQueueAckMgr
+func (_m *MockTimerQueueAckMgr) ReadTimerTasks() ([]*persistence.TimerTaskInfo, *persistence.TimerTaskInfo, bool, error) {
 	ret := _m.Called()
 
 	var r0 []*persistence.TimerTaskInfo
@@ -88,11 +88,11 @@ func (_m *MockTimerQueueAckMgr) readTimerTasks() ([]*persistence.TimerTaskInfo,
 	return r0, r1, r2, r3
 }
 
-func (_m *MockTimerQueueAckMgr) completeTimerTask(timerTask *persistence.TimerTaskInfo) {
+func (_m *MockTimerQueueAckMgr) CompleteTimerTask(timerTask *persistence.TimerTaskInfo) {
 	_m.Called(timerTask)
 }
 
-func (_m *MockTimerQueueAckMgr) getAckLevel() TimerSequenceID {
+func (_m *MockTimerQueueAckMgr) GetAckLevel() TimerSequenceID {
 	ret := _m.Called()
 
 	var r0 TimerSequenceID
@@ -106,7 +106,7 @@ func (_m *MockTimerQueueAckMgr) getAckLevel() TimerSequenceID {
 	return r0
 }
 
-func (_m *MockTimerQueueAckMgr) getReadLevel() TimerSequenceID {
+func (_m *MockTimerQueueAckMgr) GetReadLevel() TimerSequenceID {
 	ret := _m.Called()
 
 	var r0 TimerSequenceID
@@ -120,7 +120,7 @@ func (_m *MockTimerQueueAckMgr) getReadLevel() TimerSequenceID {
 	return r0
 }
 
-func (_m *MockTimerQueueAckMgr) updateAckLevel() {
+func (_m *MockTimerQueueAckMgr) UpdateAckLevel() {
 	_m.Called()
 }
 
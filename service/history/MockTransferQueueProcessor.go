@@ -41,8 +41,8 @@ func (_m *MockTransferQueueProcessor) Stop() {
 }
 
 // FailoverDomain is mock implementation for FailoverDomain of Processor
-func (_m *MockTransferQueueProcessor) FailoverDomain(domainID string) {
-	_m.Called(domainID)
+func (_m *MockTransferQueueProcessor) FailoverDomain(domainID string, failoverVersion int64) {
+	_m.Called(domainID, failoverVersion)
 }
 
 // NotifyNewTask is mock implementation for NotifyNewTask of Processor
@@ -21,6 +21,7 @@
 package history
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/uber-common/bark"
@@ -33,20 +34,20 @@ import (
 
 type (
 	conflictResolver interface {
-		reset(requestID string, replayEventID int64, startTime time.Time) (mutableState, error)
+		reset(requestID string, replayEventID int64, startTime time.Time) (MutableState, error)
 	}
 
 	conflictResolverImpl struct {
 		shard              ShardContext
 		clusterMetadata    cluster.Metadata
-		context            *workflowExecutionContext
+		context            *WorkflowExecutionContext
 		historyMgr         persistence.HistoryManager
 		hSerializerFactory persistence.HistorySerializerFactory
 		logger             bark.Logger
 	}
 )
 
-func newConflictResolver(shard ShardContext, context *workflowExecutionContext, historyMgr persistence.HistoryManager,
+func newConflictResolver(shard ShardContext, context *WorkflowExecutionContext, historyMgr persistence.HistoryManager,
 	logger bark.Logger) *conflictResolverImpl {
 
 	return &conflictResolverImpl{
@@ -59,7 +60,7 @@ func newConflictResolver(shard ShardContext, context *workflowExecutionContext,
 	}
 }
 
-func (r *conflictResolverImpl) reset(requestID string, replayEventID int64, startTime time.Time) (mutableState, error) {
+func (r *conflictResolverImpl) reset(requestID string, replayEventID int64, startTime time.Time) (MutableState, error) {
 	domainID := r.context.domainID
 	execution := r.context.workflowExecution
 	replayNextEventID := replayEventID + 1
@@ -124,8 +125,12 @@ func (r *conflictResolverImpl) reset(requestID string, replayEventID int64, star
 	msBuilder, err := r.context.resetWorkflowExecution(resetMutableStateBuilder)
 	if err != nil {
 		r.logError("Conflict resolution err reset workflow.", err)
+		return msBuilder, err
 	}
-	return msBuilder, err
+	r.shard.RecordOperation(shardOperationConflictResolved,
+		fmt.Sprintf("domain: %v, workflow: %v, run: %v, reset to event: %v",
+			domainID, execution.GetWorkflowId(), execution.GetRunId(), replayEventID))
+	return msBuilder, nil
 }
 
 func (r *conflictResolverImpl) getHistory(domainID string, execution shared.WorkflowExecution, firstEventID,
@@ -56,7 +56,7 @@ type (
 		mockMessagingClient messaging.Client
 		mockService         service.Service
 		mockShard           *shardContextImpl
-		mockContext         *workflowExecutionContext
+		mockContext         *WorkflowExecutionContext
 
 		conflictResolver *conflictResolverImpl
 	}
@@ -274,6 +274,7 @@ func (s *conflictResolverSuite) TestReset() {
 		InsertRequestCancelInfos:  []*persistence.RequestCancelInfo{},
 		InsertSignalInfos:         []*persistence.SignalInfo{},
 		InsertSignalRequestedIDs:  []string{},
+		InsertUpdateInfos:         []*persistence.UpdateInfo{},
 	}).Return(nil).Once()
 	s.mockExecutionMgr.On("GetWorkflowExecution", &persistence.GetWorkflowExecutionRequest{
 		DomainID:  domainID,
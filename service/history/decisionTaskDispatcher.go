@@ -0,0 +1,173 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+
+	m "github.com/uber/cadence/.gen/go/matching"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/matching"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// decisionDispatchRequest is an AddDecisionTask call handed off by the transfer queue processor.
+	// postDispatch runs after matching accepted the task and onComplete once the whole dispatch succeeded.
+	decisionDispatchRequest struct {
+		request      *m.AddDecisionTaskRequest
+		postDispatch func() error
+		onComplete   func()
+	}
+
+	// decisionTaskDispatcher makes AddDecisionTask calls to matching in the background, so transfer
+	// queue workers do not wait on the RPC. The transfer task is only acked through onComplete, so
+	// requests still buffered when the shard goes away are replayed from the transfer queue.
+	decisionTaskDispatcher struct {
+		matchingClient  matching.Client
+		enabled         dynamicconfig.BoolPropertyFn
+		dispatcherCount int
+		maxRetryCount   dynamicconfig.IntPropertyFn
+		retryPolicy     backoff.RetryPolicy
+		logger          bark.Logger
+		metricsClient   metrics.Client
+
+		requestCh  chan *decisionDispatchRequest
+		status     int32
+		shutdownWG sync.WaitGroup
+		shutdownCh chan struct{}
+	}
+)
+
+func newDecisionTaskDispatcher(shard ShardContext, matchingClient matching.Client, maxRetryCount dynamicconfig.IntPropertyFn,
+	logger bark.Logger, metricsClient metrics.Client) *decisionTaskDispatcher {
+	config := shard.GetConfig()
+	return &decisionTaskDispatcher{
+		matchingClient:  matchingClient,
+		enabled:         config.TransferProcessorEnableAsyncDecisionDispatch,
+		dispatcherCount: config.TransferProcessorAsyncDecisionDispatcherCount(),
+		maxRetryCount:   maxRetryCount,
+		retryPolicy:     common.CreatePersistanceRetryPolicy(),
+		logger:          logger,
+		metricsClient:   metricsClient,
+		requestCh:       make(chan *decisionDispatchRequest, config.TransferProcessorAsyncDecisionBufferSize()),
+		status:          common.DaemonStatusInitialized,
+		shutdownCh:      make(chan struct{}),
+	}
+}
+
+func (d *decisionTaskDispatcher) Start() {
+	if !atomic.CompareAndSwapInt32(&d.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+
+	d.shutdownWG.Add(d.dispatcherCount)
+	for i := 0; i < d.dispatcherCount; i++ {
+		go d.dispatchLoop()
+	}
+}
+
+func (d *decisionTaskDispatcher) Stop() {
+	if !atomic.CompareAndSwapInt32(&d.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+
+	close(d.shutdownCh)
+	if success := common.AwaitWaitGroup(&d.shutdownWG, time.Minute); !success {
+		d.logger.Warn("Decision task dispatcher timed out on shutdown.")
+	}
+}
+
+// tryDispatch buffers the request for background dispatch. It returns false if async dispatch is
+// disabled, the dispatcher is not running or the buffer is full, in which case the caller has to
+// make the call itself.
+func (d *decisionTaskDispatcher) tryDispatch(request *decisionDispatchRequest) bool {
+	if !d.enabled() || atomic.LoadInt32(&d.status) != common.DaemonStatusStarted {
+		return false
+	}
+
+	select {
+	case d.requestCh <- request:
+		d.metricsClient.IncCounter(metrics.TransferActiveTaskDecisionScope, metrics.AsyncDecisionDispatchCounter)
+		return true
+	default:
+		d.metricsClient.IncCounter(metrics.TransferActiveTaskDecisionScope, metrics.AsyncDecisionBufferFullCounter)
+		return false
+	}
+}
+
+func (d *decisionTaskDispatcher) dispatchLoop() {
+	defer d.shutdownWG.Done()
+
+	for {
+		select {
+		case <-d.shutdownCh:
+			return
+		case request := <-d.requestCh:
+			d.dispatchWithRetry(request)
+		}
+	}
+}
+
+func (d *decisionTaskDispatcher) dispatchWithRetry(request *decisionDispatchRequest) {
+	op := func() error {
+		err := d.matchingClient.AddDecisionTask(nil, request.request)
+		if err == nil {
+			err = request.postDispatch()
+		}
+		if _, ok := err.(*workflow.EntityNotExistsError); ok {
+			// duplicate processing of the task, the execution is already gone
+			return nil
+		}
+		return err
+	}
+
+	var err error
+	for retryCount := 0; retryCount < d.maxRetryCount(); retryCount++ {
+		select {
+		case <-d.shutdownCh:
+			// not acked, the task will be replayed from the transfer queue
+			return
+		default:
+			if err = backoff.Retry(op, d.retryPolicy, nil); err == nil {
+				request.onComplete()
+				return
+			}
+			logging.LogTaskProcessingFailedEvent(d.logger, err)
+		}
+	}
+
+	// same handling as a transfer task exhausting its retries in the queue processor
+	if _, ok := err.(*workflow.LimitExceededError); ok {
+		logging.LogCriticalErrorEvent(d.logger, "Critical error dispatching decision task.  Skipping.", err)
+		d.metricsClient.IncCounter(metrics.TransferQueueProcessorScope, metrics.CadenceCriticalFailures)
+		return
+	}
+	logging.LogOperationPanicEvent(d.logger, "Retry count exceeded for decision task dispatch", err)
+}
@@ -23,12 +23,17 @@ package history
 import (
 	"github.com/uber-common/bark"
 	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
 // verifyActiveTask, will return true if task activeness check is successful
-func verifyActiveTask(shard ShardContext, logger bark.Logger, taskDomainID string, task interface{}) (bool, error) {
+// taskVersion should be common.EmptyVersion for tasks which are not subject to failover version checks
+func verifyActiveTask(shard ShardContext, logger bark.Logger, taskDomainID string, taskID int64, taskVersion int64,
+	task interface{}) (bool, error) {
 	currentClusterName := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
 	domainEntry, err := shard.GetDomainCache().GetDomainByID(taskDomainID)
 	if err != nil {
@@ -46,18 +51,69 @@ func verifyActiveTask(shard ShardContext, logger bark.Logger, taskDomainID strin
 		logger.Debugf("DomainID: %v is not active, skip task: %v.", taskDomainID, task)
 		return false, nil
 	}
+	if taskVersion != common.EmptyVersion && isTaskOwnedByFailover(shard, domainEntry, taskID, taskVersion) {
+		// task was created by the previously active cluster, the failover processor will take care of it
+		logger.Debugf("DomainID: %v is active, task: %v is owned by failover, version: %v.", taskDomainID, task, taskVersion)
+		shard.GetMetricsClient().IncCounter(metrics.TransferActiveQueueProcessorScope, metrics.TaskSkippedByFailoverCounter)
+		return false, nil
+	}
 	logger.Debugf("DomainID: %v is active, process task: %v.", taskDomainID, task)
 	return true, nil
 }
 
 // verifyFailoverActiveTask, will return true if task activeness check is successful
-func verifyFailoverActiveTask(logger bark.Logger, targetDomainID string, taskDomainID string, task interface{}) (bool, error) {
-	if targetDomainID == taskDomainID {
-		logger.Debugf("Failover DomainID: %v is active, process task: %v.", taskDomainID, task)
-		return true, nil
+// taskVersion should be common.EmptyVersion for tasks which are not subject to failover version checks
+func verifyFailoverActiveTask(logger bark.Logger, targetDomainID string, taskDomainID string, failoverVersion int64,
+	taskVersion int64, task interface{}) (bool, error) {
+	if targetDomainID != taskDomainID {
+		logger.Debugf("Failover DomainID: %v is not active, skip task: %v.", taskDomainID, task)
+		return false, nil
+	}
+	if taskVersion != common.EmptyVersion && taskVersion >= failoverVersion {
+		// task is created after the failover, the active processor will take care of it
+		logger.Debugf("Failover DomainID: %v is active, task: %v version: %v >= failover version: %v, skip.",
+			taskDomainID, task, taskVersion, failoverVersion)
+		return false, nil
+	}
+	logger.Debugf("Failover DomainID: %v is active, process task: %v.", taskDomainID, task)
+	return true, nil
+}
+
+// isTaskOwnedByFailover, will return true if the task is created by another cluster before the domain
+// failover to the current cluster, and still falls within the range of an ongoing failover
+func isTaskOwnedByFailover(shard ShardContext, domainEntry *cache.DomainCacheEntry, taskID int64, taskVersion int64) bool {
+	clusterMetadata := shard.GetService().GetClusterMetadata()
+	if !clusterMetadata.IsGlobalDomainEnabled() || !domainEntry.IsGlobalDomain() {
+		return false
+	}
+	if clusterMetadata.ClusterNameForFailoverVersion(taskVersion) == clusterMetadata.GetCurrentClusterName() {
+		return false
+	}
+
+	domainID := domainEntry.GetInfo().ID
+	for _, level := range shard.GetAllTransferFailoverLevels() {
+		// the failover level max is exclusive
+		if taskVersion >= level.FailoverVersion || taskID < level.MinLevel || taskID >= level.MaxLevel {
+			continue
+		}
+		for _, id := range level.DomainIDs {
+			if id == domainID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getTransferTaskFailoverVersion, will return the version used for failover checks of the transfer task,
+// only activity and decision tasks, which dispatch to matching, are checked
+func getTransferTaskFailoverVersion(task *persistence.TransferTaskInfo) int64 {
+	switch task.TaskType {
+	case persistence.TransferTaskTypeActivityTask, persistence.TransferTaskTypeDecisionTask:
+		return task.Version
+	default:
+		return common.EmptyVersion
 	}
-	logger.Debugf("Failover DomainID: %v is not active, skip task: %v.", taskDomainID, task)
-	return false, nil
 }
 
 // verifyStandbyTask, will return true if task standbyness check is successful
@@ -86,6 +142,22 @@ func verifyStandbyTask(shard ShardContext, logger bark.Logger, standbyCluster st
 	return true, nil
 }
 
+// verifyTimerTaskNotPaused, will return ErrTaskPaused if processing of the timer task type is paused
+func verifyTimerTaskNotPaused(shard ShardContext, taskType int) error {
+	if shard.GetConfig().TimerTaskPaused(dynamicconfig.TaskTypeFilter(taskType)) {
+		return ErrTaskPaused
+	}
+	return nil
+}
+
+// verifyTransferTaskNotPaused, will return ErrTaskPaused if processing of the transfer task type is paused
+func verifyTransferTaskNotPaused(shard ShardContext, taskType int) error {
+	if shard.GetConfig().TransferTaskPaused(dynamicconfig.TaskTypeFilter(taskType)) {
+		return ErrTaskPaused
+	}
+	return nil
+}
+
 // verifyTaskVersion, will return true if failover version check is successful
 func verifyTaskVersion(shard ShardContext, logger bark.Logger, domainID string, version int64, taskVersion int64, task interface{}) (bool, error) {
 	if !shard.GetService().GetClusterMetadata().IsGlobalDomainEnabled() {
@@ -111,7 +183,7 @@ func verifyTaskVersion(shard ShardContext, logger bark.Logger, domainID string,
 
 // load mutable state, if mutable state's next event ID <= task ID, will attempt to refresh
 // if still mutable state's next event ID <= task ID, will return nil, nil
-func loadMutableStateForTransferTask(context *workflowExecutionContext, transferTask *persistence.TransferTaskInfo, metricsClient metrics.Client, logger bark.Logger) (mutableState, error) {
+func loadMutableStateForTransferTask(context *WorkflowExecutionContext, transferTask *persistence.TransferTaskInfo, metricsClient metrics.Client, logger bark.Logger) (MutableState, error) {
 	msBuilder, err := context.loadWorkflowExecution()
 	if err != nil {
 		if _, ok := err.(*workflow.EntityNotExistsError); ok {
@@ -151,7 +223,7 @@ func loadMutableStateForTransferTask(context *workflowExecutionContext, transfer
 
 // load mutable state, if mutable state's next event ID <= task ID, will attempt to refresh
 // if still mutable state's next event ID <= task ID, will return nil, nil
-func loadMutableStateForTimerTask(context *workflowExecutionContext, timerTask *persistence.TimerTaskInfo, metricsClient metrics.Client, logger bark.Logger) (mutableState, error) {
+func loadMutableStateForTimerTask(context *WorkflowExecutionContext, timerTask *persistence.TimerTaskInfo, metricsClient metrics.Client, logger bark.Logger) (MutableState, error) {
 	msBuilder, err := context.loadWorkflowExecution()
 	if err != nil {
 		if _, ok := err.(*workflow.EntityNotExistsError); ok {
@@ -24,6 +24,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/pborman/uuid"
 	"github.com/uber/cadence/.gen/go/health"
@@ -39,7 +40,9 @@ import (
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/serviceerror"
 	"go.uber.org/yarpc/yarpcerrors"
 )
 
@@ -51,6 +54,7 @@ type (
 		metadataMgr           persistence.MetadataManager
 		visibilityMgr         persistence.VisibilityManager
 		historyMgr            persistence.HistoryManager
+		postCloseEventsMgr    persistence.PostCloseEventsManager
 		executionMgrFactory   persistence.ExecutionManagerFactory
 		domainCache           cache.DomainCache
 		historyServiceClient  hc.Client
@@ -63,6 +67,11 @@ type (
 		config                *Config
 		historyEventNotifier  historyEventNotifier
 		publisher             messaging.Producer
+		replicationPublisher  *replicationPublisher
+		outboundProcessor     *outboundProcessor
+		stickyPoisonDetector  *stickyPoisonDetector
+		concurrencyLimiter    common.ConcurrencyLimiter
+		ratelimitAggregator   *quotas.Aggregator
 		service.Service
 	}
 )
@@ -79,21 +88,29 @@ var (
 	errSourceClusterNotSet     = &gen.BadRequestError{Message: "Source Cluster not set on request."}
 	errShardIDNotSet           = &gen.BadRequestError{Message: "Shard ID not set on request."}
 	errTimestampNotSet         = &gen.BadRequestError{Message: "Timestamp not set on request."}
+	errHostNotSet              = &gen.BadRequestError{Message: "Host not set on request."}
 )
 
 // NewHandler creates a thrift handler for the history service
 func NewHandler(sVice service.Service, config *Config, shardManager persistence.ShardManager,
 	metadataMgr persistence.MetadataManager, visibilityMgr persistence.VisibilityManager,
-	historyMgr persistence.HistoryManager, executionMgrFactory persistence.ExecutionManagerFactory) *Handler {
+	historyMgr persistence.HistoryManager, postCloseEventsMgr persistence.PostCloseEventsManager,
+	executionMgrFactory persistence.ExecutionManagerFactory) *Handler {
 	handler := &Handler{
 		Service:             sVice,
 		config:              config,
 		shardManager:        shardManager,
 		metadataMgr:         metadataMgr,
 		historyMgr:          historyMgr,
+		postCloseEventsMgr:  postCloseEventsMgr,
 		visibilityMgr:       visibilityMgr,
 		executionMgrFactory: executionMgrFactory,
 		tokenSerializer:     common.NewJSONTaskTokenSerializer(),
+		concurrencyLimiter: common.NewConcurrencyLimiter(
+			common.ConcurrencyLimitsFromMap(func() map[string]interface{} { return config.MaxConcurrentRequests() }),
+			func() time.Duration { return config.ConcurrentRequestsWaitTimeout() },
+		),
+		ratelimitAggregator: quotas.NewAggregator(config.GlobalRatelimiterHostTTL, common.NewRealTimeSource()),
 	}
 
 	// prevent us from trying to serve requests before shard controller is started and ready
@@ -126,11 +143,12 @@ func (h *Handler) Start() error {
 
 	// TODO when global domain is enabled, uncomment the line below and remove the line after
 	if h.GetClusterMetadata().IsGlobalDomainEnabled() {
-		var err error
-		h.publisher, err = h.GetMessagingClient().NewProducer(h.GetClusterMetadata().GetCurrentClusterName())
+		producer, err := h.GetMessagingClient().NewProducer(h.GetClusterMetadata().GetCurrentClusterName())
 		if err != nil {
 			h.GetLogger().Fatalf("Creating kafka producer failed: %v", err)
 		}
+		h.replicationPublisher = newReplicationPublisher(producer, h.config, h.GetMetricsClient(), h.GetLogger())
+		h.publisher = h.replicationPublisher
 	}
 
 	h.domainCache = cache.NewDomainCache(h.metadataMgr, h.GetClusterMetadata(), h.GetMetricsClient(), h.GetLogger())
@@ -139,6 +157,10 @@ func (h *Handler) Start() error {
 		h.domainCache, h.executionMgrFactory, h, h.config, h.GetLogger(), h.GetMetricsClient())
 	h.metricsClient = h.GetMetricsClient()
 	h.historyEventNotifier = newHistoryEventNotifier(h.GetMetricsClient(), h.config.GetShardID)
+	h.outboundProcessor = newOutboundProcessor(h.config, h.GetMetricsClient(), h.GetLogger())
+	h.outboundProcessor.Start()
+	h.stickyPoisonDetector = newStickyPoisonDetector(h.config, h.metadataMgr, h.domainCache, hServiceResolver,
+		h.GetClusterMetadata(), h.GetClientFactory(), h.GetMetricsClient(), h.GetLogger())
 	// events notifier must starts before controller
 	h.historyEventNotifier.Start()
 	h.controller.Start()
@@ -148,6 +170,10 @@ func (h *Handler) Start() error {
 
 // Stop stops the handler
 func (h *Handler) Stop() {
+	if h.replicationPublisher != nil {
+		// release the replicator queue processors waiting on the publish limits before the shards are stopped
+		h.replicationPublisher.Stop()
+	}
 	h.domainCache.Stop()
 	h.controller.Stop()
 	h.shardManager.Close()
@@ -157,11 +183,13 @@ func (h *Handler) Stop() {
 	h.visibilityMgr.Close()
 	h.Service.Stop()
 	h.historyEventNotifier.Stop()
+	h.outboundProcessor.Stop()
 }
 
 // CreateEngine is implementation for HistoryEngineFactory used for creating the engine instance for shard
 func (h *Handler) CreateEngine(context ShardContext) Engine {
-	return NewEngineWithShardContext(context, h.visibilityMgr, h.matchingServiceClient, h.historyServiceClient, h.historyEventNotifier, h.publisher)
+	return NewEngineWithShardContext(context, h.visibilityMgr, h.postCloseEventsMgr, h.matchingServiceClient,
+		h.historyServiceClient, h.historyEventNotifier, h.publisher, h.outboundProcessor, h.stickyPoisonDetector)
 }
 
 // Health is for health check
@@ -566,6 +594,123 @@ func (h *Handler) DescribeHistoryHost(ctx context.Context,
 	return resp, nil
 }
 
+// DescribeShardBacklogs returns the age of the oldest unacked task of the task queues of the shards owned by this
+// host, ordered by decreasing max age
+func (h *Handler) DescribeShardBacklogs(ctx context.Context,
+	request *gen.DescribeShardBacklogsRequest) (*gen.DescribeShardBacklogsResponse, error) {
+	h.startWG.Wait()
+
+	address := h.GetHostInfo().GetAddress()
+	shards := []*gen.ShardBacklogInfo{}
+	for shardID, ages := range h.controller.shardBacklogs() {
+		var maxAge time.Duration
+		queueAges := make(map[string]int64, len(ages))
+		for queueName, age := range ages {
+			queueAges[queueName] = int64(age.Seconds())
+			if age > maxAge {
+				maxAge = age
+			}
+		}
+		shards = append(shards, &gen.ShardBacklogInfo{
+			ShardId:                  common.Int32Ptr(int32(shardID)),
+			HostAddress:              common.StringPtr(address),
+			QueueBacklogAgeInSeconds: queueAges,
+			MaxBacklogAgeInSeconds:   common.Int64Ptr(int64(maxAge.Seconds())),
+		})
+	}
+
+	return &gen.DescribeShardBacklogsResponse{
+		Shards: common.TopShardBacklogs(shards, int(request.GetMaximumShards())),
+	}, nil
+}
+
+// DescribeShardOperations returns the recent significant operations recorded by a shard owned by this host, most
+// recent first
+func (h *Handler) DescribeShardOperations(ctx context.Context,
+	request *gen.DescribeShardOperationsRequest) (*gen.DescribeShardOperationsResponse, error) {
+	h.startWG.Wait()
+
+	if request.ShardId == nil {
+		return nil, errShardIDNotSet
+	}
+
+	engine, err := h.controller.getEngineForShard(int(request.GetShardId()))
+	if err != nil {
+		return nil, h.convertError(err)
+	}
+	reporter, ok := engine.(shardOperationReporter)
+	if !ok {
+		return nil, &gen.InternalServiceError{Message: "Shard operations are not available."}
+	}
+
+	operations := []*gen.ShardOperation{}
+	for _, operation := range reporter.getRecentShardOperations(int(request.GetMaximumOperations())) {
+		operations = append(operations, &gen.ShardOperation{
+			Timestamp: common.Int64Ptr(operation.timestamp.UnixNano()),
+			Operation: common.StringPtr(operation.operation),
+			Details:   common.StringPtr(operation.details),
+		})
+	}
+
+	return &gen.DescribeShardOperationsResponse{
+		ShardId:     request.ShardId,
+		HostAddress: common.StringPtr(h.GetHostInfo().GetAddress()),
+		Operations:  operations,
+	}, nil
+}
+
+// RefreshDomainCache refreshes the domain cache of this host right away, it is called after a domain got updated
+func (h *Handler) RefreshDomainCache(ctx context.Context, request *gen.RefreshDomainCacheRequest) error {
+	h.startWG.Wait()
+
+	h.domainCache.TriggerRefresh()
+	return nil
+}
+
+// RatelimitUpdate aggregates the usage of the global rate limits reported by a host, and returns the share of
+// each limit the host should admit
+func (h *Handler) RatelimitUpdate(ctx context.Context,
+	request *hist.RatelimitUpdateRequest) (*hist.RatelimitUpdateResponse, error) {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryRatelimitUpdateScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryRatelimitUpdateScope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if request.GetHost() == "" {
+		h.updateErrorMetric(metrics.HistoryRatelimitUpdateScope, errHostNotSet)
+		return nil, errHostNotSet
+	}
+	if len(request.Usages) == 0 {
+		return &hist.RatelimitUpdateResponse{}, nil
+	}
+
+	// the client sends the keys of one shard per request, make sure this host still owns it
+	if _, err := h.controller.GetEngine(request.Usages[0].GetKey()); err != nil {
+		h.updateErrorMetric(metrics.HistoryRatelimitUpdateScope, err)
+		return nil, err
+	}
+
+	usages := make(map[string]quotas.Usage, len(request.Usages))
+	for _, usage := range request.Usages {
+		usages[usage.GetKey()] = quotas.Usage{
+			Allowed:  usage.GetAllowed(),
+			Rejected: usage.GetRejected(),
+		}
+	}
+	elapsed := time.Duration(request.GetElapsedMillis()) * time.Millisecond
+	weights := h.ratelimitAggregator.Update(request.GetHost(), elapsed, usages)
+
+	resp := &hist.RatelimitUpdateResponse{}
+	for key, weight := range weights {
+		resp.Quotas = append(resp.Quotas, &hist.RatelimitQuota{
+			Key:    common.StringPtr(key),
+			Weight: common.Float64Ptr(weight),
+		})
+	}
+	return resp, nil
+}
+
 // DescribeMutableState - returns the internal analysis of workflow execution state
 func (h *Handler) DescribeMutableState(ctx context.Context,
 	request *hist.DescribeMutableStateRequest) (*hist.DescribeMutableStateResponse, error) {
@@ -601,6 +746,13 @@ func (h *Handler) GetMutableState(ctx context.Context,
 		return nil, errDomainNotSet
 	}
 
+	if !h.concurrencyLimiter.Acquire(ctx, "GetMutableState") {
+		err := h.createTooManyConcurrentRequestsError()
+		h.updateErrorMetric(metrics.HistoryGetMutableStateScope, err)
+		return nil, err
+	}
+	defer h.concurrencyLimiter.Release("GetMutableState")
+
 	workflowExecution := getRequest.Execution
 	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
 	if err1 != nil {
@@ -616,6 +768,64 @@ func (h *Handler) GetMutableState(ctx context.Context,
 	return resp, nil
 }
 
+// GetMutableStates returns the mutable state summaries of a batch of workflow executions.
+func (h *Handler) GetMutableStates(ctx context.Context,
+	getRequest *hist.GetMutableStatesRequest) (*hist.GetMutableStatesResponse, error) {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryGetMutableStatesScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryGetMutableStatesScope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if getRequest.GetDomainUUID() == "" {
+		return nil, errDomainNotSet
+	}
+
+	if !h.concurrencyLimiter.Acquire(ctx, "GetMutableStates") {
+		err := h.createTooManyConcurrentRequestsError()
+		h.updateErrorMetric(metrics.HistoryGetMutableStatesScope, err)
+		return nil, err
+	}
+	defer h.concurrencyLimiter.Release("GetMutableStates")
+
+	maxBatchSize := h.config.MaxGetMutableStatesBatchSize()
+	if len(getRequest.Executions) > maxBatchSize {
+		err := &gen.BadRequestError{
+			Message: fmt.Sprintf("Too many executions on request, max batch size is %v.", maxBatchSize),
+		}
+		h.updateErrorMetric(metrics.HistoryGetMutableStatesScope, err)
+		return nil, err
+	}
+
+	resp := &hist.GetMutableStatesResponse{}
+	for _, workflowExecution := range getRequest.Executions {
+		if workflowExecution == nil {
+			return nil, errWorkflowExecutionNotSet
+		}
+
+		engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
+		if err1 != nil {
+			h.updateErrorMetric(metrics.HistoryGetMutableStatesScope, err1)
+			return nil, err1
+		}
+
+		state, err2 := engine.GetMutableState(ctx, &hist.GetMutableStateRequest{
+			DomainUUID: getRequest.DomainUUID,
+			Execution:  workflowExecution,
+		})
+		if err2 != nil {
+			if _, ok := err2.(*gen.EntityNotExistsError); ok {
+				resp.NotFound = append(resp.NotFound, workflowExecution)
+				continue
+			}
+			h.updateErrorMetric(metrics.HistoryGetMutableStatesScope, h.convertError(err2))
+			return nil, h.convertError(err2)
+		}
+		resp.States = append(resp.States, state)
+	}
+	return resp, nil
+}
+
 // DescribeWorkflowExecution returns information about the specified workflow execution.
 func (h *Handler) DescribeWorkflowExecution(ctx context.Context, request *hist.DescribeWorkflowExecutionRequest) (*gen.DescribeWorkflowExecutionResponse, error) {
 	h.startWG.Wait()
@@ -628,6 +838,13 @@ func (h *Handler) DescribeWorkflowExecution(ctx context.Context, request *hist.D
 		return nil, errDomainNotSet
 	}
 
+	if !h.concurrencyLimiter.Acquire(ctx, "DescribeWorkflowExecution") {
+		err := h.createTooManyConcurrentRequestsError()
+		h.updateErrorMetric(metrics.HistoryDescribeWorkflowExecutionScope, err)
+		return nil, err
+	}
+	defer h.concurrencyLimiter.Release("DescribeWorkflowExecution")
+
 	workflowExecution := request.Request.Execution
 	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
 	if err1 != nil {
@@ -708,6 +925,123 @@ func (h *Handler) SignalWorkflowExecution(ctx context.Context,
 	return nil
 }
 
+// UpdateWorkflowExecutionOptions changes options of a running workflow execution which are kept in its mutable state.
+func (h *Handler) UpdateWorkflowExecutionOptions(ctx context.Context,
+	wrappedRequest *hist.UpdateWorkflowExecutionOptionsRequest) error {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryUpdateWorkflowExecutionOptionsScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryUpdateWorkflowExecutionOptionsScope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if wrappedRequest.GetDomainUUID() == "" {
+		return errDomainNotSet
+	}
+
+	workflowExecution := wrappedRequest.UpdateRequest.WorkflowExecution
+	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
+	if err1 != nil {
+		h.updateErrorMetric(metrics.HistoryUpdateWorkflowExecutionOptionsScope, err1)
+		return err1
+	}
+
+	err2 := engine.UpdateWorkflowExecutionOptions(ctx, wrappedRequest)
+	if err2 != nil {
+		h.updateErrorMetric(metrics.HistoryUpdateWorkflowExecutionOptionsScope, h.convertError(err2))
+		return h.convertError(err2)
+	}
+
+	return nil
+}
+
+// DeleteWorkflowExecution force deletes a workflow execution run along with its history and visibility records.
+func (h *Handler) DeleteWorkflowExecution(ctx context.Context,
+	wrappedRequest *hist.DeleteWorkflowExecutionRequest) error {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryDeleteWorkflowExecutionScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryDeleteWorkflowExecutionScope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if wrappedRequest.GetDomainUUID() == "" {
+		return errDomainNotSet
+	}
+
+	workflowExecution := wrappedRequest.Execution
+	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
+	if err1 != nil {
+		h.updateErrorMetric(metrics.HistoryDeleteWorkflowExecutionScope, err1)
+		return err1
+	}
+
+	err2 := engine.DeleteWorkflowExecution(ctx, wrappedRequest)
+	if err2 != nil {
+		h.updateErrorMetric(metrics.HistoryDeleteWorkflowExecutionScope, h.convertError(err2))
+		return h.convertError(err2)
+	}
+
+	return nil
+}
+
+// UpdateWorkflowExecution records an update request on a running workflow execution and waits for a decision to
+// complete it.
+func (h *Handler) UpdateWorkflowExecution(ctx context.Context,
+	wrappedRequest *hist.UpdateWorkflowExecutionRequest) (*gen.UpdateWorkflowExecutionResponse, error) {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryUpdateWorkflowExecutionScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryUpdateWorkflowExecutionScope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if wrappedRequest.GetDomainUUID() == "" {
+		return nil, errDomainNotSet
+	}
+
+	workflowExecution := wrappedRequest.UpdateRequest.WorkflowExecution
+	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
+	if err1 != nil {
+		h.updateErrorMetric(metrics.HistoryUpdateWorkflowExecutionScope, err1)
+		return nil, err1
+	}
+
+	resp, err2 := engine.UpdateWorkflowExecution(ctx, wrappedRequest)
+	if err2 != nil {
+		h.updateErrorMetric(metrics.HistoryUpdateWorkflowExecutionScope, h.convertError(err2))
+		return nil, h.convertError(err2)
+	}
+
+	return resp, nil
+}
+
+// FixWorkflowExecution repairs a workflow execution run found inconsistent by a scanner.
+func (h *Handler) FixWorkflowExecution(ctx context.Context,
+	wrappedRequest *hist.FixWorkflowExecutionRequest) (*hist.FixWorkflowExecutionResponse, error) {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistoryFixWorkflowExecutionScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistoryFixWorkflowExecutionScope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if wrappedRequest.GetDomainUUID() == "" {
+		return nil, errDomainNotSet
+	}
+
+	workflowExecution := wrappedRequest.Execution
+	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
+	if err1 != nil {
+		h.updateErrorMetric(metrics.HistoryFixWorkflowExecutionScope, err1)
+		return nil, err1
+	}
+
+	resp, err2 := engine.FixWorkflowExecution(ctx, wrappedRequest)
+	if err2 != nil {
+		h.updateErrorMetric(metrics.HistoryFixWorkflowExecutionScope, h.convertError(err2))
+		return nil, h.convertError(err2)
+	}
+
+	return resp, nil
+}
+
 // SignalWithStartWorkflowExecution is used to ensure sending a signal event to a workflow execution.
 // If workflow is running, this results in WorkflowExecutionSignaled event recorded in the history
 // and a decision task being created for the execution.
@@ -963,32 +1297,77 @@ func (h *Handler) SyncShardStatus(ctx context.Context, syncShardStatusRequest *h
 	return nil
 }
 
+// SyncActivity is called by processor to sync the retry state of an activity from the active cluster
+func (h *Handler) SyncActivity(ctx context.Context, syncActivityRequest *hist.SyncActivityRequest) error {
+	h.startWG.Wait()
+
+	h.metricsClient.IncCounter(metrics.HistorySyncActivityScope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(metrics.HistorySyncActivityScope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if syncActivityRequest.GetDomainId() == "" {
+		return errDomainNotSet
+	}
+
+	if syncActivityRequest.GetWorkflowId() == "" {
+		return errWorkflowIDNotSet
+	}
+
+	if uuid.Parse(syncActivityRequest.GetRunId()) == nil {
+		return errRunIDNotValid
+	}
+
+	engine, err := h.controller.GetEngine(syncActivityRequest.GetWorkflowId())
+	if err != nil {
+		h.updateErrorMetric(metrics.HistorySyncActivityScope, err)
+		return err
+	}
+
+	err = engine.SyncActivity(ctx, syncActivityRequest)
+	if err != nil {
+		h.updateErrorMetric(metrics.HistorySyncActivityScope, h.convertError(err))
+		return h.convertError(err)
+	}
+
+	return nil
+}
+
 // convertError is a helper method to convert ShardOwnershipLostError from persistence layer returned by various
 // HistoryEngine API calls to ShardOwnershipLost error return by HistoryService for client to be redirected to the
 // correct shard.
 func (h *Handler) convertError(err error) error {
-	switch err.(type) {
+	switch cause := serviceerror.Cause(err).(type) {
 	case *persistence.ShardOwnershipLostError:
-		shardID := err.(*persistence.ShardOwnershipLostError).ShardID
-		info, err := h.hServiceResolver.Lookup(string(shardID))
+		// the shard may be handed off to a host other than its owner on the ring
+		info, err := h.controller.lookupShardOwner(cause.ShardID)
 		if err == nil {
 			return createShardOwnershipLostError(h.GetHostInfo().GetAddress(), info.GetAddress())
 		}
 		return createShardOwnershipLostError(h.GetHostInfo().GetAddress(), "")
 	case *persistence.WorkflowExecutionAlreadyStartedError:
-		err := err.(*persistence.WorkflowExecutionAlreadyStartedError)
 		return &gen.WorkflowExecutionAlreadyStartedError{
 			Message:        common.StringPtr("Workflow is already running"),
-			StartRequestId: common.StringPtr(err.StartRequestID),
-			RunId:          common.StringPtr(err.RunID),
+			StartRequestId: common.StringPtr(cause.StartRequestID),
+			RunId:          common.StringPtr(cause.RunID),
+			CloseStatus:    getAlreadyStartedCloseStatus(cause),
 		}
 	}
 
-	return err
+	// wrapped errors are not part of the IDL, the thrift error they originate from is returned instead
+	return serviceerror.ToThrift(err)
+}
+
+// createTooManyConcurrentRequestsError is returned when no in flight slot of an API frees up in time, the caller is
+// told to retry once it could have waited for a slot again
+func (h *Handler) createTooManyConcurrentRequestsError() *gen.ServiceBusyError {
+	return &gen.ServiceBusyError{
+		Message:          "Too many concurrent requests for this API",
+		RetryAfterMillis: common.Int64Ptr(int64(h.config.ConcurrentRequestsWaitTimeout() / time.Millisecond)),
+	}
 }
 
 func (h *Handler) updateErrorMetric(scope int, err error) {
-	switch err := err.(type) {
+	switch err := serviceerror.Cause(err).(type) {
 	case *hist.ShardOwnershipLostError:
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrShardOwnershipLostCounter)
 	case *hist.EventAlreadyStartedError:
@@ -1007,6 +1386,8 @@ func (h *Handler) updateErrorMetric(scope int, err error) {
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrLimitExceededCounter)
 	case *gen.RetryTaskError:
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrRetryTaskCounter)
+	case *gen.ServiceBusyError:
+		h.metricsClient.IncCounter(scope, metrics.CadenceErrServiceBusyCounter)
 	case *yarpcerrors.Status:
 		if err.Code() == yarpcerrors.CodeDeadlineExceeded {
 			h.metricsClient.IncCounter(scope, metrics.CadenceErrContextTimeoutCounter)
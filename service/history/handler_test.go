@@ -0,0 +1,181 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	hist "github.com/uber/cadence/.gen/go/history"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	handlerSuite struct {
+		suite.Suite
+		mockEngine *MockHistoryEngine
+		config     *Config
+		handler    *Handler
+	}
+)
+
+const (
+	testGetMutableStatesDomainID = "deadbeef-0123-4567-890a-bcdef0123456"
+)
+
+func TestHandlerSuite(t *testing.T) {
+	s := new(handlerSuite)
+	suite.Run(t, s)
+}
+
+func (s *handlerSuite) SetupTest() {
+	logger := bark.NewLoggerFromLogrus(log.New())
+	metricsClient := metrics.NewClient(tally.NoopScope, metrics.History)
+	// a single shard owned by this host, every workflow lands on the mock engine
+	s.config = NewConfig(dynamicconfig.NewNopCollection(), 1)
+	s.mockEngine = &MockHistoryEngine{}
+	controller := &shardController{
+		host:          membership.NewHostInfo("handler-test-host", nil),
+		logger:        logger,
+		config:        s.config,
+		metricsClient: metricsClient,
+		historyShards: map[int]*historyShardsItem{
+			0: {shardID: 0, engine: s.mockEngine},
+		},
+	}
+	s.handler = &Handler{
+		controller:    controller,
+		config:        s.config,
+		metricsClient: metricsClient,
+		concurrencyLimiter: common.NewConcurrencyLimiter(
+			common.ConcurrencyLimitsFromMap(func() map[string]interface{} { return s.config.MaxConcurrentRequests() }),
+			func() time.Duration { return s.config.ConcurrentRequestsWaitTimeout() },
+		),
+	}
+}
+
+func (s *handlerSuite) TearDownTest() {
+	s.mockEngine.AssertExpectations(s.T())
+}
+
+func (s *handlerSuite) TestGetMutableStates_NotFound() {
+	found := s.newExecution("found")
+	missing := s.newExecution("missing")
+	foundState := &hist.GetMutableStateResponse{Execution: found, NextEventId: common.Int64Ptr(5)}
+	s.mockEngine.On("GetMutableState", mock.Anything, &hist.GetMutableStateRequest{
+		DomainUUID: common.StringPtr(testGetMutableStatesDomainID),
+		Execution:  found,
+	}).Return(foundState, nil).Once()
+	s.mockEngine.On("GetMutableState", mock.Anything, &hist.GetMutableStateRequest{
+		DomainUUID: common.StringPtr(testGetMutableStatesDomainID),
+		Execution:  missing,
+	}).Return(nil, &gen.EntityNotExistsError{}).Once()
+
+	resp, err := s.handler.GetMutableStates(context.Background(), &hist.GetMutableStatesRequest{
+		DomainUUID: common.StringPtr(testGetMutableStatesDomainID),
+		Executions: []*gen.WorkflowExecution{missing, found},
+	})
+	s.NoError(err)
+	s.Equal([]*hist.GetMutableStateResponse{foundState}, resp.States)
+	s.Equal([]*gen.WorkflowExecution{missing}, resp.NotFound)
+}
+
+func (s *handlerSuite) TestGetMutableStates_Failure() {
+	execution := s.newExecution("failed")
+	s.mockEngine.On("GetMutableState", mock.Anything, mock.Anything).Return(nil,
+		errors.New("some random error")).Once()
+
+	resp, err := s.handler.GetMutableStates(context.Background(), &hist.GetMutableStatesRequest{
+		DomainUUID: common.StringPtr(testGetMutableStatesDomainID),
+		Executions: []*gen.WorkflowExecution{execution, s.newExecution("not-looked-up")},
+	})
+	s.Error(err)
+	s.Nil(resp)
+}
+
+func (s *handlerSuite) TestGetMutableStates_BatchSizeLimit() {
+	s.config.MaxGetMutableStatesBatchSize = dynamicconfig.GetIntPropertyFn(2)
+	executions := []*gen.WorkflowExecution{s.newExecution("1"), s.newExecution("2"), s.newExecution("3")}
+
+	// the whole batch is rejected before any execution is looked up
+	resp, err := s.handler.GetMutableStates(context.Background(), &hist.GetMutableStatesRequest{
+		DomainUUID: common.StringPtr(testGetMutableStatesDomainID),
+		Executions: executions,
+	})
+	s.IsType(&gen.BadRequestError{}, err)
+	s.Nil(resp)
+
+	for _, execution := range executions[:2] {
+		s.mockEngine.On("GetMutableState", mock.Anything, &hist.GetMutableStateRequest{
+			DomainUUID: common.StringPtr(testGetMutableStatesDomainID),
+			Execution:  execution,
+		}).Return(&hist.GetMutableStateResponse{Execution: execution}, nil).Once()
+	}
+	resp, err = s.handler.GetMutableStates(context.Background(), &hist.GetMutableStatesRequest{
+		DomainUUID: common.StringPtr(testGetMutableStatesDomainID),
+		Executions: executions[:2],
+	})
+	s.NoError(err)
+	s.Equal(2, len(resp.States))
+}
+
+func (s *handlerSuite) TestGetMutableStates_DomainNotSet() {
+	_, err := s.handler.GetMutableStates(context.Background(), &hist.GetMutableStatesRequest{
+		Executions: []*gen.WorkflowExecution{s.newExecution("1")},
+	})
+	s.Equal(errDomainNotSet, err)
+}
+
+func (s *handlerSuite) TestGetMutableState_TooManyConcurrentRequests() {
+	s.config.MaxConcurrentRequests = dynamicconfig.GetMapPropertyFn(map[string]interface{}{"GetMutableState": 1})
+	s.config.ConcurrentRequestsWaitTimeout = dynamicconfig.GetDurationPropertyFn(1500 * time.Millisecond)
+	s.True(s.handler.concurrencyLimiter.Acquire(context.Background(), "GetMutableState"))
+	defer s.handler.concurrencyLimiter.Release("GetMutableState")
+
+	// the request gives up on a slot right away with its context done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp, err := s.handler.GetMutableState(ctx, &hist.GetMutableStateRequest{
+		DomainUUID: common.StringPtr(testGetMutableStatesDomainID),
+		Execution:  s.newExecution("busy"),
+	})
+	s.Nil(resp)
+	s.IsType(&gen.ServiceBusyError{}, err)
+	s.Equal(int64(1500), err.(*gen.ServiceBusyError).GetRetryAfterMillis())
+}
+
+func (s *handlerSuite) newExecution(workflowID string) *gen.WorkflowExecution {
+	return &gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("get-mutable-states-test-" + workflowID),
+		RunId:      common.StringPtr("0d00698f-08e1-4d36-a3e2-3bf109f5d2d6"),
+	}
+}
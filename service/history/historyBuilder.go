@@ -30,34 +30,42 @@ import (
 )
 
 type (
-	historyBuilder struct {
-		serializer       persistence.HistorySerializer
-		transientHistory []*workflow.HistoryEvent
-		history          []*workflow.HistoryEvent
-		msBuilder        mutableState
-		logger           bark.Logger
+	// HistoryBuilder accumulates the history events added to a workflow execution by a single update
+	HistoryBuilder struct {
+		serializerFactory persistence.HistorySerializerFactory
+		encodingFn        func() common.EncodingType
+		transientHistory  []*workflow.HistoryEvent
+		history           []*workflow.HistoryEvent
+		msBuilder         MutableState
+		logger            bark.Logger
 	}
 )
 
-func newHistoryBuilder(msBuilder mutableState, logger bark.Logger) *historyBuilder {
-	return &historyBuilder{
-		serializer:       persistence.NewJSONHistorySerializer(),
-		transientHistory: []*workflow.HistoryEvent{},
-		history:          []*workflow.HistoryEvent{},
-		msBuilder:        msBuilder,
-		logger:           logger.WithField(logging.TagWorkflowComponent, logging.TagValueHistoryBuilderComponent),
+func newHistoryBuilder(msBuilder MutableState, logger bark.Logger) *HistoryBuilder {
+	return &HistoryBuilder{
+		serializerFactory: persistence.NewHistorySerializerFactory(),
+		encodingFn:        defaultHistoryEncoding,
+		transientHistory:  []*workflow.HistoryEvent{},
+		history:           []*workflow.HistoryEvent{},
+		msBuilder:         msBuilder,
+		logger:            logger.WithField(logging.TagWorkflowComponent, logging.TagValueHistoryBuilderComponent),
 	}
 }
 
-func newHistoryBuilderFromEvents(history []*workflow.HistoryEvent, logger bark.Logger) *historyBuilder {
-	return &historyBuilder{
-		serializer: persistence.NewJSONHistorySerializer(),
-		history:    history,
-		logger:     logger.WithField(logging.TagWorkflowComponent, logging.TagValueHistoryBuilderComponent),
+func defaultHistoryEncoding() common.EncodingType {
+	return persistence.DefaultEncodingType
+}
+
+func newHistoryBuilderFromEvents(history []*workflow.HistoryEvent, logger bark.Logger) *HistoryBuilder {
+	return &HistoryBuilder{
+		serializerFactory: persistence.NewHistorySerializerFactory(),
+		encodingFn:        defaultHistoryEncoding,
+		history:           history,
+		logger:            logger.WithField(logging.TagWorkflowComponent, logging.TagValueHistoryBuilderComponent),
 	}
 }
 
-func (b *historyBuilder) GetFirstEvent() *workflow.HistoryEvent {
+func (b *HistoryBuilder) GetFirstEvent() *workflow.HistoryEvent {
 	// Transient decision events are always written before other events
 	if b.transientHistory != nil && len(b.transientHistory) > 0 {
 		return b.transientHistory[0]
@@ -70,150 +78,171 @@ func (b *historyBuilder) GetFirstEvent() *workflow.HistoryEvent {
 	return nil
 }
 
-func (b *historyBuilder) HasTransientEvents() bool {
+func (b *HistoryBuilder) HasTransientEvents() bool {
 	return b.transientHistory != nil && len(b.transientHistory) > 0
 }
 
-func (b *historyBuilder) SerializeEvents(events []*workflow.HistoryEvent) (*persistence.SerializedHistoryEventBatch,
+func (b *HistoryBuilder) SerializeEvents(events []*workflow.HistoryEvent) (*persistence.SerializedHistoryEventBatch,
 	error) {
 	eventBatch := persistence.NewHistoryEventBatch(persistence.GetDefaultHistoryVersion(), events)
-	history, err := b.serializer.Serialize(eventBatch)
+	history, err := b.serialize(eventBatch)
 	if err != nil {
 		return nil, err
 	}
 	return history, nil
 }
 
-func (b *historyBuilder) Serialize() (*persistence.SerializedHistoryEventBatch, error) {
+// serialize encodes the batch with the encoding currently configured for the domain
+func (b *HistoryBuilder) serialize(eventBatch *persistence.HistoryEventBatch) (*persistence.SerializedHistoryEventBatch,
+	error) {
+	serializer, err := b.serializerFactory.Get(b.encodingFn())
+	if err != nil {
+		return nil, err
+	}
+	return serializer.Serialize(eventBatch)
+}
+
+// deserialize decodes the batch with whichever encoding it was written in
+func (b *HistoryBuilder) deserialize(history *persistence.SerializedHistoryEventBatch) (*persistence.HistoryEventBatch,
+	error) {
+	persistence.SetSerializedHistoryDefaults(history)
+	serializer, err := b.serializerFactory.Get(history.EncodingType)
+	if err != nil {
+		return nil, err
+	}
+	return serializer.Deserialize(history)
+}
+
+func (b *HistoryBuilder) Serialize() (*persistence.SerializedHistoryEventBatch, error) {
 	return b.SerializeEvents(b.history)
 }
 
-func (b *historyBuilder) AddWorkflowExecutionStartedEvent(request *h.StartWorkflowExecutionRequest,
+func (b *HistoryBuilder) AddWorkflowExecutionStartedEvent(request *h.StartWorkflowExecutionRequest,
 	previousRunID *string) *workflow.HistoryEvent {
 	event := b.newWorkflowExecutionStartedEvent(request, previousRunID)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddDecisionTaskScheduledEvent(taskList string,
+func (b *HistoryBuilder) AddDecisionTaskScheduledEvent(taskList string,
 	startToCloseTimeoutSeconds int32, attempt int64) *workflow.HistoryEvent {
 	event := b.newDecisionTaskScheduledEvent(taskList, startToCloseTimeoutSeconds, attempt)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddTransientDecisionTaskScheduledEvent(taskList string,
+func (b *HistoryBuilder) AddTransientDecisionTaskScheduledEvent(taskList string,
 	startToCloseTimeoutSeconds int32, attempt int64, timestamp int64) *workflow.HistoryEvent {
 	event := b.newTransientDecisionTaskScheduledEvent(taskList, startToCloseTimeoutSeconds, attempt, timestamp)
 
 	return b.addTransientEvent(event)
 }
 
-func (b *historyBuilder) AddDecisionTaskStartedEvent(scheduleEventID int64, requestID string,
+func (b *HistoryBuilder) AddDecisionTaskStartedEvent(scheduleEventID int64, requestID string,
 	identity string) *workflow.HistoryEvent {
 	event := b.newDecisionTaskStartedEvent(scheduleEventID, requestID, identity)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddTransientDecisionTaskStartedEvent(scheduleEventID int64, requestID string,
+func (b *HistoryBuilder) AddTransientDecisionTaskStartedEvent(scheduleEventID int64, requestID string,
 	identity string, timestamp int64) *workflow.HistoryEvent {
 	event := b.newTransientDecisionTaskStartedEvent(scheduleEventID, requestID, identity, timestamp)
 
 	return b.addTransientEvent(event)
 }
 
-func (b *historyBuilder) AddDecisionTaskCompletedEvent(scheduleEventID, startedEventID int64,
+func (b *HistoryBuilder) AddDecisionTaskCompletedEvent(scheduleEventID, startedEventID int64,
 	request *workflow.RespondDecisionTaskCompletedRequest) *workflow.HistoryEvent {
 	event := b.newDecisionTaskCompletedEvent(scheduleEventID, startedEventID, request)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddDecisionTaskTimedOutEvent(scheduleEventID int64,
+func (b *HistoryBuilder) AddDecisionTaskTimedOutEvent(scheduleEventID int64,
 	startedEventID int64, timeoutType workflow.TimeoutType) *workflow.HistoryEvent {
 	event := b.newDecisionTaskTimedOutEvent(scheduleEventID, startedEventID, timeoutType)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddDecisionTaskFailedEvent(scheduleEventID int64, startedEventID int64,
+func (b *HistoryBuilder) AddDecisionTaskFailedEvent(scheduleEventID int64, startedEventID int64,
 	cause workflow.DecisionTaskFailedCause, details []byte, identity string) *workflow.HistoryEvent {
 	event := b.newDecisionTaskFailedEvent(scheduleEventID, startedEventID, cause, details, identity)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddActivityTaskScheduledEvent(decisionCompletedEventID int64,
+func (b *HistoryBuilder) AddActivityTaskScheduledEvent(decisionCompletedEventID int64,
 	attributes *workflow.ScheduleActivityTaskDecisionAttributes) *workflow.HistoryEvent {
 	event := b.newActivityTaskScheduledEvent(decisionCompletedEventID, attributes)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddActivityTaskStartedEvent(scheduleEventID int64, attempt int32, requestID string,
+func (b *HistoryBuilder) AddActivityTaskStartedEvent(scheduleEventID int64, attempt int32, requestID string,
 	identity string) *workflow.HistoryEvent {
 	event := b.newActivityTaskStartedEvent(scheduleEventID, attempt, requestID, identity)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddActivityTaskCompletedEvent(scheduleEventID, startedEventID int64,
+func (b *HistoryBuilder) AddActivityTaskCompletedEvent(scheduleEventID, startedEventID int64,
 	request *workflow.RespondActivityTaskCompletedRequest) *workflow.HistoryEvent {
 	event := b.newActivityTaskCompletedEvent(scheduleEventID, startedEventID, request)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddActivityTaskFailedEvent(scheduleEventID, startedEventID int64,
+func (b *HistoryBuilder) AddActivityTaskFailedEvent(scheduleEventID, startedEventID int64,
 	request *workflow.RespondActivityTaskFailedRequest) *workflow.HistoryEvent {
 	event := b.newActivityTaskFailedEvent(scheduleEventID, startedEventID, request)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddActivityTaskTimedOutEvent(scheduleEventID, startedEventID int64,
+func (b *HistoryBuilder) AddActivityTaskTimedOutEvent(scheduleEventID, startedEventID int64,
 	timeoutType workflow.TimeoutType, lastHeartBeatDetails []byte) *workflow.HistoryEvent {
 	event := b.newActivityTaskTimedOutEvent(scheduleEventID, startedEventID, timeoutType, lastHeartBeatDetails)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddCompletedWorkflowEvent(decisionCompletedEventID int64,
+func (b *HistoryBuilder) AddCompletedWorkflowEvent(decisionCompletedEventID int64,
 	attributes *workflow.CompleteWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.newCompleteWorkflowExecutionEvent(decisionCompletedEventID, attributes)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddFailWorkflowEvent(decisionCompletedEventID int64,
+func (b *HistoryBuilder) AddFailWorkflowEvent(decisionCompletedEventID int64,
 	attributes *workflow.FailWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.newFailWorkflowExecutionEvent(decisionCompletedEventID, attributes)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddTimeoutWorkflowEvent() *workflow.HistoryEvent {
+func (b *HistoryBuilder) AddTimeoutWorkflowEvent() *workflow.HistoryEvent {
 	event := b.newTimeoutWorkflowExecutionEvent()
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddWorkflowExecutionTerminatedEvent(
+func (b *HistoryBuilder) AddWorkflowExecutionTerminatedEvent(
 	request *workflow.TerminateWorkflowExecutionRequest) *workflow.HistoryEvent {
 	event := b.newWorkflowExecutionTerminatedEvent(request)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddContinuedAsNewEvent(decisionCompletedEventID int64, newRunID string,
+func (b *HistoryBuilder) AddContinuedAsNewEvent(decisionCompletedEventID int64, newRunID string,
 	attributes *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.newWorkflowExecutionContinuedAsNewEvent(decisionCompletedEventID, newRunID, attributes)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddTimerStartedEvent(decisionCompletedEventID int64,
+func (b *HistoryBuilder) AddTimerStartedEvent(decisionCompletedEventID int64,
 	request *workflow.StartTimerDecisionAttributes) *workflow.HistoryEvent {
 
 	attributes := &workflow.TimerStartedEventAttributes{}
@@ -227,7 +256,7 @@ func (b *historyBuilder) AddTimerStartedEvent(decisionCompletedEventID int64,
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddTimerFiredEvent(startedEventID int64,
+func (b *HistoryBuilder) AddTimerFiredEvent(startedEventID int64,
 	timerID string) *workflow.HistoryEvent {
 
 	attributes := &workflow.TimerFiredEventAttributes{}
@@ -240,7 +269,7 @@ func (b *historyBuilder) AddTimerFiredEvent(startedEventID int64,
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddActivityTaskCancelRequestedEvent(decisionCompletedEventID int64,
+func (b *HistoryBuilder) AddActivityTaskCancelRequestedEvent(decisionCompletedEventID int64,
 	activityID string) *workflow.HistoryEvent {
 
 	attributes := &workflow.ActivityTaskCancelRequestedEventAttributes{}
@@ -253,7 +282,7 @@ func (b *historyBuilder) AddActivityTaskCancelRequestedEvent(decisionCompletedEv
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddRequestCancelActivityTaskFailedEvent(decisionCompletedEventID int64,
+func (b *HistoryBuilder) AddRequestCancelActivityTaskFailedEvent(decisionCompletedEventID int64,
 	activityID string, cause string) *workflow.HistoryEvent {
 
 	attributes := &workflow.RequestCancelActivityTaskFailedEventAttributes{}
@@ -267,7 +296,7 @@ func (b *historyBuilder) AddRequestCancelActivityTaskFailedEvent(decisionComplet
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddActivityTaskCanceledEvent(scheduleEventID, startedEventID int64,
+func (b *HistoryBuilder) AddActivityTaskCanceledEvent(scheduleEventID, startedEventID int64,
 	latestCancelRequestedEventID int64, details []byte, identity string) *workflow.HistoryEvent {
 
 	attributes := &workflow.ActivityTaskCanceledEventAttributes{}
@@ -283,7 +312,7 @@ func (b *historyBuilder) AddActivityTaskCanceledEvent(scheduleEventID, startedEv
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddTimerCanceledEvent(startedEventID int64,
+func (b *HistoryBuilder) AddTimerCanceledEvent(startedEventID int64,
 	decisionTaskCompletedEventID int64, timerID string, identity string) *workflow.HistoryEvent {
 
 	attributes := &workflow.TimerCanceledEventAttributes{}
@@ -298,7 +327,7 @@ func (b *historyBuilder) AddTimerCanceledEvent(startedEventID int64,
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddCancelTimerFailedEvent(timerID string, decisionTaskCompletedEventID int64,
+func (b *HistoryBuilder) AddCancelTimerFailedEvent(timerID string, decisionTaskCompletedEventID int64,
 	cause string, identity string) *workflow.HistoryEvent {
 
 	attributes := &workflow.CancelTimerFailedEventAttributes{}
@@ -313,28 +342,28 @@ func (b *historyBuilder) AddCancelTimerFailedEvent(timerID string, decisionTaskC
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddWorkflowExecutionCancelRequestedEvent(cause string,
+func (b *HistoryBuilder) AddWorkflowExecutionCancelRequestedEvent(cause string,
 	request *h.RequestCancelWorkflowExecutionRequest) *workflow.HistoryEvent {
 	event := b.newWorkflowExecutionCancelRequestedEvent(cause, request)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddWorkflowExecutionCanceledEvent(decisionTaskCompletedEventID int64,
+func (b *HistoryBuilder) AddWorkflowExecutionCanceledEvent(decisionTaskCompletedEventID int64,
 	attributes *workflow.CancelWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.newWorkflowExecutionCanceledEvent(decisionTaskCompletedEventID, attributes)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddRequestCancelExternalWorkflowExecutionInitiatedEvent(decisionTaskCompletedEventID int64,
+func (b *HistoryBuilder) AddRequestCancelExternalWorkflowExecutionInitiatedEvent(decisionTaskCompletedEventID int64,
 	request *workflow.RequestCancelExternalWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.newRequestCancelExternalWorkflowExecutionInitiatedEvent(decisionTaskCompletedEventID, request)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddRequestCancelExternalWorkflowExecutionFailedEvent(decisionTaskCompletedEventID, initiatedEventID int64,
+func (b *HistoryBuilder) AddRequestCancelExternalWorkflowExecutionFailedEvent(decisionTaskCompletedEventID, initiatedEventID int64,
 	domain, workflowID, runID string, cause workflow.CancelExternalWorkflowExecutionFailedCause) *workflow.HistoryEvent {
 	event := b.newRequestCancelExternalWorkflowExecutionFailedEvent(decisionTaskCompletedEventID, initiatedEventID,
 		domain, workflowID, runID, cause)
@@ -342,7 +371,7 @@ func (b *historyBuilder) AddRequestCancelExternalWorkflowExecutionFailedEvent(de
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddExternalWorkflowExecutionCancelRequested(initiatedEventID int64,
+func (b *HistoryBuilder) AddExternalWorkflowExecutionCancelRequested(initiatedEventID int64,
 	domain, workflowID, runID string) *workflow.HistoryEvent {
 	event := b.newExternalWorkflowExecutionCancelRequestedEvent(initiatedEventID,
 		domain, workflowID, runID)
@@ -350,14 +379,14 @@ func (b *historyBuilder) AddExternalWorkflowExecutionCancelRequested(initiatedEv
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddSignalExternalWorkflowExecutionInitiatedEvent(decisionTaskCompletedEventID int64,
+func (b *HistoryBuilder) AddSignalExternalWorkflowExecutionInitiatedEvent(decisionTaskCompletedEventID int64,
 	attributes *workflow.SignalExternalWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.newSignalExternalWorkflowExecutionInitiatedEvent(decisionTaskCompletedEventID, attributes)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddSignalExternalWorkflowExecutionFailedEvent(decisionTaskCompletedEventID, initiatedEventID int64,
+func (b *HistoryBuilder) AddSignalExternalWorkflowExecutionFailedEvent(decisionTaskCompletedEventID, initiatedEventID int64,
 	domain, workflowID, runID string, control []byte, cause workflow.SignalExternalWorkflowExecutionFailedCause) *workflow.HistoryEvent {
 	event := b.newSignalExternalWorkflowExecutionFailedEvent(decisionTaskCompletedEventID, initiatedEventID,
 		domain, workflowID, runID, control, cause)
@@ -365,7 +394,7 @@ func (b *historyBuilder) AddSignalExternalWorkflowExecutionFailedEvent(decisionT
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddExternalWorkflowExecutionSignaled(initiatedEventID int64,
+func (b *HistoryBuilder) AddExternalWorkflowExecutionSignaled(initiatedEventID int64,
 	domain, workflowID, runID string, control []byte) *workflow.HistoryEvent {
 	event := b.newExternalWorkflowExecutionSignaledEvent(initiatedEventID,
 		domain, workflowID, runID, control)
@@ -373,35 +402,56 @@ func (b *historyBuilder) AddExternalWorkflowExecutionSignaled(initiatedEventID i
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddMarkerRecordedEvent(decisionCompletedEventID int64,
+func (b *HistoryBuilder) AddMarkerRecordedEvent(decisionCompletedEventID int64,
 	attributes *workflow.RecordMarkerDecisionAttributes) *workflow.HistoryEvent {
 	event := b.newMarkerRecordedEventAttributes(decisionCompletedEventID, attributes)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddWorkflowExecutionSignaledEvent(
+func (b *HistoryBuilder) AddWorkflowExecutionSignaledEvent(
 	request *workflow.SignalWorkflowExecutionRequest) *workflow.HistoryEvent {
 	event := b.newWorkflowExecutionSignaledEvent(request)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddStartChildWorkflowExecutionInitiatedEvent(decisionCompletedEventID int64,
+func (b *HistoryBuilder) AddWorkflowExecutionUpdateRequestedEvent(
+	request *workflow.UpdateWorkflowExecutionRequest) *workflow.HistoryEvent {
+	event := b.newWorkflowExecutionUpdateRequestedEvent(request)
+
+	return b.addEventToHistory(event)
+}
+
+func (b *HistoryBuilder) AddWorkflowExecutionTaskListChangedEvent(
+	taskList *workflow.TaskList, identity string) *workflow.HistoryEvent {
+	event := b.newWorkflowExecutionTaskListChangedEvent(taskList, identity)
+
+	return b.addEventToHistory(event)
+}
+
+func (b *HistoryBuilder) AddWorkflowExecutionUpdateCompletedEvent(decisionCompletedEventID int64,
+	attributes *workflow.CompleteWorkflowUpdateDecisionAttributes) *workflow.HistoryEvent {
+	event := b.newWorkflowExecutionUpdateCompletedEvent(decisionCompletedEventID, attributes)
+
+	return b.addEventToHistory(event)
+}
+
+func (b *HistoryBuilder) AddStartChildWorkflowExecutionInitiatedEvent(decisionCompletedEventID int64,
 	attributes *workflow.StartChildWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.newStartChildWorkflowExecutionInitiatedEvent(decisionCompletedEventID, attributes)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddChildWorkflowExecutionStartedEvent(domain *string, execution *workflow.WorkflowExecution,
+func (b *HistoryBuilder) AddChildWorkflowExecutionStartedEvent(domain *string, execution *workflow.WorkflowExecution,
 	workflowType *workflow.WorkflowType, initiatedID int64) *workflow.HistoryEvent {
 	event := b.newChildWorkflowExecutionStartedEvent(domain, execution, workflowType, initiatedID)
 
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddStartChildWorkflowExecutionFailedEvent(initiatedID int64,
+func (b *HistoryBuilder) AddStartChildWorkflowExecutionFailedEvent(initiatedID int64,
 	cause workflow.ChildWorkflowExecutionFailedCause,
 	initiatedEventAttributes *workflow.StartChildWorkflowExecutionInitiatedEventAttributes) *workflow.HistoryEvent {
 	event := b.newStartChildWorkflowExecutionFailedEvent(initiatedID, cause, initiatedEventAttributes)
@@ -409,7 +459,7 @@ func (b *historyBuilder) AddStartChildWorkflowExecutionFailedEvent(initiatedID i
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddChildWorkflowExecutionCompletedEvent(domain *string, execution *workflow.WorkflowExecution,
+func (b *HistoryBuilder) AddChildWorkflowExecutionCompletedEvent(domain *string, execution *workflow.WorkflowExecution,
 	workflowType *workflow.WorkflowType, initiatedID, startedID int64,
 	completedAttributes *workflow.WorkflowExecutionCompletedEventAttributes) *workflow.HistoryEvent {
 	event := b.newChildWorkflowExecutionCompletedEvent(domain, execution, workflowType, initiatedID, startedID,
@@ -418,7 +468,7 @@ func (b *historyBuilder) AddChildWorkflowExecutionCompletedEvent(domain *string,
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddChildWorkflowExecutionFailedEvent(domain *string, execution *workflow.WorkflowExecution,
+func (b *HistoryBuilder) AddChildWorkflowExecutionFailedEvent(domain *string, execution *workflow.WorkflowExecution,
 	workflowType *workflow.WorkflowType, initiatedID, startedID int64,
 	failedAttributes *workflow.WorkflowExecutionFailedEventAttributes) *workflow.HistoryEvent {
 	event := b.newChildWorkflowExecutionFailedEvent(domain, execution, workflowType, initiatedID, startedID,
@@ -427,7 +477,7 @@ func (b *historyBuilder) AddChildWorkflowExecutionFailedEvent(domain *string, ex
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddChildWorkflowExecutionCanceledEvent(domain *string, execution *workflow.WorkflowExecution,
+func (b *HistoryBuilder) AddChildWorkflowExecutionCanceledEvent(domain *string, execution *workflow.WorkflowExecution,
 	workflowType *workflow.WorkflowType, initiatedID, startedID int64,
 	canceledAttributes *workflow.WorkflowExecutionCanceledEventAttributes) *workflow.HistoryEvent {
 	event := b.newChildWorkflowExecutionCanceledEvent(domain, execution, workflowType, initiatedID, startedID,
@@ -436,7 +486,7 @@ func (b *historyBuilder) AddChildWorkflowExecutionCanceledEvent(domain *string,
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddChildWorkflowExecutionTerminatedEvent(domain *string, execution *workflow.WorkflowExecution,
+func (b *HistoryBuilder) AddChildWorkflowExecutionTerminatedEvent(domain *string, execution *workflow.WorkflowExecution,
 	workflowType *workflow.WorkflowType, initiatedID, startedID int64,
 	terminatedAttributes *workflow.WorkflowExecutionTerminatedEventAttributes) *workflow.HistoryEvent {
 	event := b.newChildWorkflowExecutionTerminatedEvent(domain, execution, workflowType, initiatedID, startedID,
@@ -445,7 +495,7 @@ func (b *historyBuilder) AddChildWorkflowExecutionTerminatedEvent(domain *string
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) AddChildWorkflowExecutionTimedOutEvent(domain *string, execution *workflow.WorkflowExecution,
+func (b *HistoryBuilder) AddChildWorkflowExecutionTimedOutEvent(domain *string, execution *workflow.WorkflowExecution,
 	workflowType *workflow.WorkflowType, initiatedID, startedID int64,
 	timedOutAttributes *workflow.WorkflowExecutionTimedOutEventAttributes) *workflow.HistoryEvent {
 	event := b.newChildWorkflowExecutionTimedOutEvent(domain, execution, workflowType, initiatedID, startedID,
@@ -454,17 +504,17 @@ func (b *historyBuilder) AddChildWorkflowExecutionTimedOutEvent(domain *string,
 	return b.addEventToHistory(event)
 }
 
-func (b *historyBuilder) addEventToHistory(event *workflow.HistoryEvent) *workflow.HistoryEvent {
+func (b *HistoryBuilder) addEventToHistory(event *workflow.HistoryEvent) *workflow.HistoryEvent {
 	b.history = append(b.history, event)
 	return event
 }
 
-func (b *historyBuilder) addTransientEvent(event *workflow.HistoryEvent) *workflow.HistoryEvent {
+func (b *HistoryBuilder) addTransientEvent(event *workflow.HistoryEvent) *workflow.HistoryEvent {
 	b.transientHistory = append(b.transientHistory, event)
 	return event
 }
 
-func (b *historyBuilder) newWorkflowExecutionStartedEvent(
+func (b *HistoryBuilder) newWorkflowExecutionStartedEvent(
 	startRequest *h.StartWorkflowExecutionRequest, previousRunID *string) *workflow.HistoryEvent {
 	request := startRequest.StartRequest
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionStarted)
@@ -477,6 +527,8 @@ func (b *historyBuilder) newWorkflowExecutionStartedEvent(
 	attributes.ChildPolicy = request.ChildPolicy
 	attributes.ContinuedExecutionRunId = previousRunID
 	attributes.Identity = common.StringPtr(common.StringDefault(request.Identity))
+	attributes.Tags = request.Tags
+	attributes.Priority = request.Priority
 	parentInfo := startRequest.ParentExecutionInfo
 	if parentInfo != nil {
 		attributes.ParentWorkflowDomain = parentInfo.Domain
@@ -488,35 +540,35 @@ func (b *historyBuilder) newWorkflowExecutionStartedEvent(
 	return historyEvent
 }
 
-func (b *historyBuilder) newDecisionTaskScheduledEvent(taskList string, startToCloseTimeoutSeconds int32,
+func (b *HistoryBuilder) newDecisionTaskScheduledEvent(taskList string, startToCloseTimeoutSeconds int32,
 	attempt int64) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeDecisionTaskScheduled)
 
 	return setDecisionTaskScheduledEventInfo(historyEvent, taskList, startToCloseTimeoutSeconds, attempt)
 }
 
-func (b *historyBuilder) newTransientDecisionTaskScheduledEvent(taskList string, startToCloseTimeoutSeconds int32,
+func (b *HistoryBuilder) newTransientDecisionTaskScheduledEvent(taskList string, startToCloseTimeoutSeconds int32,
 	attempt int64, timestamp int64) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEventWithTimestamp(workflow.EventTypeDecisionTaskScheduled, timestamp)
 
 	return setDecisionTaskScheduledEventInfo(historyEvent, taskList, startToCloseTimeoutSeconds, attempt)
 }
 
-func (b *historyBuilder) newDecisionTaskStartedEvent(scheduledEventID int64, requestID string,
+func (b *HistoryBuilder) newDecisionTaskStartedEvent(scheduledEventID int64, requestID string,
 	identity string) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeDecisionTaskStarted)
 
 	return setDecisionTaskStartedEventInfo(historyEvent, scheduledEventID, requestID, identity)
 }
 
-func (b *historyBuilder) newTransientDecisionTaskStartedEvent(scheduledEventID int64, requestID string,
+func (b *HistoryBuilder) newTransientDecisionTaskStartedEvent(scheduledEventID int64, requestID string,
 	identity string, timestamp int64) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEventWithTimestamp(workflow.EventTypeDecisionTaskStarted, timestamp)
 
 	return setDecisionTaskStartedEventInfo(historyEvent, scheduledEventID, requestID, identity)
 }
 
-func (b *historyBuilder) newDecisionTaskCompletedEvent(scheduleEventID, startedEventID int64,
+func (b *HistoryBuilder) newDecisionTaskCompletedEvent(scheduleEventID, startedEventID int64,
 	request *workflow.RespondDecisionTaskCompletedRequest) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeDecisionTaskCompleted)
 	attributes := &workflow.DecisionTaskCompletedEventAttributes{}
@@ -529,7 +581,7 @@ func (b *historyBuilder) newDecisionTaskCompletedEvent(scheduleEventID, startedE
 	return historyEvent
 }
 
-func (b *historyBuilder) newDecisionTaskTimedOutEvent(scheduleEventID int64, startedEventID int64, timeoutType workflow.TimeoutType) *workflow.HistoryEvent {
+func (b *HistoryBuilder) newDecisionTaskTimedOutEvent(scheduleEventID int64, startedEventID int64, timeoutType workflow.TimeoutType) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeDecisionTaskTimedOut)
 	attributes := &workflow.DecisionTaskTimedOutEventAttributes{}
 	attributes.ScheduledEventId = common.Int64Ptr(scheduleEventID)
@@ -540,7 +592,7 @@ func (b *historyBuilder) newDecisionTaskTimedOutEvent(scheduleEventID int64, sta
 	return historyEvent
 }
 
-func (b *historyBuilder) newDecisionTaskFailedEvent(scheduleEventID int64, startedEventID int64,
+func (b *HistoryBuilder) newDecisionTaskFailedEvent(scheduleEventID int64, startedEventID int64,
 	cause workflow.DecisionTaskFailedCause, details []byte, identity string) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeDecisionTaskFailed)
 	attributes := &workflow.DecisionTaskFailedEventAttributes{}
@@ -554,7 +606,7 @@ func (b *historyBuilder) newDecisionTaskFailedEvent(scheduleEventID int64, start
 	return historyEvent
 }
 
-func (b *historyBuilder) newActivityTaskScheduledEvent(decisionTaskCompletedEventID int64,
+func (b *HistoryBuilder) newActivityTaskScheduledEvent(decisionTaskCompletedEventID int64,
 	scheduleAttributes *workflow.ScheduleActivityTaskDecisionAttributes) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeActivityTaskScheduled)
 	attributes := &workflow.ActivityTaskScheduledEventAttributes{}
@@ -573,7 +625,7 @@ func (b *historyBuilder) newActivityTaskScheduledEvent(decisionTaskCompletedEven
 	return historyEvent
 }
 
-func (b *historyBuilder) newActivityTaskStartedEvent(scheduledEventID int64, attempt int32, requestID string,
+func (b *HistoryBuilder) newActivityTaskStartedEvent(scheduledEventID int64, attempt int32, requestID string,
 	identity string) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeActivityTaskStarted)
 	attributes := &workflow.ActivityTaskStartedEventAttributes{}
@@ -586,7 +638,7 @@ func (b *historyBuilder) newActivityTaskStartedEvent(scheduledEventID int64, att
 	return historyEvent
 }
 
-func (b *historyBuilder) newActivityTaskCompletedEvent(scheduleEventID, startedEventID int64,
+func (b *HistoryBuilder) newActivityTaskCompletedEvent(scheduleEventID, startedEventID int64,
 	request *workflow.RespondActivityTaskCompletedRequest) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeActivityTaskCompleted)
 	attributes := &workflow.ActivityTaskCompletedEventAttributes{}
@@ -599,7 +651,7 @@ func (b *historyBuilder) newActivityTaskCompletedEvent(scheduleEventID, startedE
 	return historyEvent
 }
 
-func (b *historyBuilder) newActivityTaskTimedOutEvent(scheduleEventID, startedEventID int64,
+func (b *HistoryBuilder) newActivityTaskTimedOutEvent(scheduleEventID, startedEventID int64,
 	timeoutType workflow.TimeoutType, lastHeartBeatDetails []byte) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeActivityTaskTimedOut)
 	attributes := &workflow.ActivityTaskTimedOutEventAttributes{}
@@ -612,7 +664,7 @@ func (b *historyBuilder) newActivityTaskTimedOutEvent(scheduleEventID, startedEv
 	return historyEvent
 }
 
-func (b *historyBuilder) newActivityTaskFailedEvent(scheduleEventID, startedEventID int64,
+func (b *HistoryBuilder) newActivityTaskFailedEvent(scheduleEventID, startedEventID int64,
 	request *workflow.RespondActivityTaskFailedRequest) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeActivityTaskFailed)
 	attributes := &workflow.ActivityTaskFailedEventAttributes{}
@@ -626,7 +678,7 @@ func (b *historyBuilder) newActivityTaskFailedEvent(scheduleEventID, startedEven
 	return historyEvent
 }
 
-func (b *historyBuilder) newCompleteWorkflowExecutionEvent(decisionTaskCompletedEventID int64,
+func (b *HistoryBuilder) newCompleteWorkflowExecutionEvent(decisionTaskCompletedEventID int64,
 	request *workflow.CompleteWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionCompleted)
 	attributes := &workflow.WorkflowExecutionCompletedEventAttributes{}
@@ -637,7 +689,7 @@ func (b *historyBuilder) newCompleteWorkflowExecutionEvent(decisionTaskCompleted
 	return historyEvent
 }
 
-func (b *historyBuilder) newFailWorkflowExecutionEvent(decisionTaskCompletedEventID int64,
+func (b *HistoryBuilder) newFailWorkflowExecutionEvent(decisionTaskCompletedEventID int64,
 	request *workflow.FailWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionFailed)
 	attributes := &workflow.WorkflowExecutionFailedEventAttributes{}
@@ -649,7 +701,7 @@ func (b *historyBuilder) newFailWorkflowExecutionEvent(decisionTaskCompletedEven
 	return historyEvent
 }
 
-func (b *historyBuilder) newTimeoutWorkflowExecutionEvent() *workflow.HistoryEvent {
+func (b *HistoryBuilder) newTimeoutWorkflowExecutionEvent() *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionTimedOut)
 	attributes := &workflow.WorkflowExecutionTimedOutEventAttributes{}
 	attributes.TimeoutType = common.TimeoutTypePtr(workflow.TimeoutTypeStartToClose)
@@ -658,7 +710,7 @@ func (b *historyBuilder) newTimeoutWorkflowExecutionEvent() *workflow.HistoryEve
 	return historyEvent
 }
 
-func (b *historyBuilder) newWorkflowExecutionSignaledEvent(
+func (b *HistoryBuilder) newWorkflowExecutionSignaledEvent(
 	request *workflow.SignalWorkflowExecutionRequest) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionSignaled)
 	attributes := &workflow.WorkflowExecutionSignaledEventAttributes{}
@@ -670,7 +722,43 @@ func (b *historyBuilder) newWorkflowExecutionSignaledEvent(
 	return historyEvent
 }
 
-func (b *historyBuilder) newWorkflowExecutionTerminatedEvent(
+func (b *HistoryBuilder) newWorkflowExecutionUpdateRequestedEvent(
+	request *workflow.UpdateWorkflowExecutionRequest) *workflow.HistoryEvent {
+	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionUpdateRequested)
+	attributes := &workflow.WorkflowExecutionUpdateRequestedEventAttributes{}
+	attributes.UpdateId = common.StringPtr(common.StringDefault(request.UpdateId))
+	attributes.UpdateName = common.StringPtr(common.StringDefault(request.UpdateName))
+	attributes.Input = request.Input
+	attributes.Identity = common.StringPtr(common.StringDefault(request.Identity))
+	historyEvent.WorkflowExecutionUpdateRequestedEventAttributes = attributes
+
+	return historyEvent
+}
+
+func (b *HistoryBuilder) newWorkflowExecutionUpdateCompletedEvent(decisionTaskCompletedEventID int64,
+	request *workflow.CompleteWorkflowUpdateDecisionAttributes) *workflow.HistoryEvent {
+	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionUpdateCompleted)
+	attributes := &workflow.WorkflowExecutionUpdateCompletedEventAttributes{}
+	attributes.UpdateId = common.StringPtr(common.StringDefault(request.UpdateId))
+	attributes.Result = request.Result
+	attributes.DecisionTaskCompletedEventId = common.Int64Ptr(decisionTaskCompletedEventID)
+	historyEvent.WorkflowExecutionUpdateCompletedEventAttributes = attributes
+
+	return historyEvent
+}
+
+func (b *HistoryBuilder) newWorkflowExecutionTaskListChangedEvent(taskList *workflow.TaskList,
+	identity string) *workflow.HistoryEvent {
+	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionTaskListChanged)
+	attributes := &workflow.WorkflowExecutionTaskListChangedEventAttributes{}
+	attributes.TaskList = &workflow.TaskList{Name: common.StringPtr(taskList.GetName())}
+	attributes.Identity = common.StringPtr(identity)
+	historyEvent.WorkflowExecutionTaskListChangedEventAttributes = attributes
+
+	return historyEvent
+}
+
+func (b *HistoryBuilder) newWorkflowExecutionTerminatedEvent(
 	request *workflow.TerminateWorkflowExecutionRequest) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionTerminated)
 	attributes := &workflow.WorkflowExecutionTerminatedEventAttributes{}
@@ -682,7 +770,7 @@ func (b *historyBuilder) newWorkflowExecutionTerminatedEvent(
 	return historyEvent
 }
 
-func (b *historyBuilder) newMarkerRecordedEventAttributes(decisionTaskCompletedEventID int64,
+func (b *HistoryBuilder) newMarkerRecordedEventAttributes(decisionTaskCompletedEventID int64,
 	request *workflow.RecordMarkerDecisionAttributes) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeMarkerRecorded)
 	attributes := &workflow.MarkerRecordedEventAttributes{}
@@ -695,7 +783,7 @@ func (b *historyBuilder) newMarkerRecordedEventAttributes(decisionTaskCompletedE
 	return historyEvent
 }
 
-func (b *historyBuilder) newWorkflowExecutionCancelRequestedEvent(cause string,
+func (b *HistoryBuilder) newWorkflowExecutionCancelRequestedEvent(cause string,
 	request *h.RequestCancelWorkflowExecutionRequest) *workflow.HistoryEvent {
 	event := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionCancelRequested)
 	attributes := &workflow.WorkflowExecutionCancelRequestedEventAttributes{}
@@ -712,7 +800,7 @@ func (b *historyBuilder) newWorkflowExecutionCancelRequestedEvent(cause string,
 	return event
 }
 
-func (b *historyBuilder) newWorkflowExecutionCanceledEvent(decisionTaskCompletedEventID int64,
+func (b *HistoryBuilder) newWorkflowExecutionCanceledEvent(decisionTaskCompletedEventID int64,
 	request *workflow.CancelWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionCanceled)
 	attributes := &workflow.WorkflowExecutionCanceledEventAttributes{}
@@ -723,7 +811,7 @@ func (b *historyBuilder) newWorkflowExecutionCanceledEvent(decisionTaskCompleted
 	return event
 }
 
-func (b *historyBuilder) newRequestCancelExternalWorkflowExecutionInitiatedEvent(decisionTaskCompletedEventID int64,
+func (b *HistoryBuilder) newRequestCancelExternalWorkflowExecutionInitiatedEvent(decisionTaskCompletedEventID int64,
 	request *workflow.RequestCancelExternalWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeRequestCancelExternalWorkflowExecutionInitiated)
 	attributes := &workflow.RequestCancelExternalWorkflowExecutionInitiatedEventAttributes{}
@@ -740,7 +828,7 @@ func (b *historyBuilder) newRequestCancelExternalWorkflowExecutionInitiatedEvent
 	return event
 }
 
-func (b *historyBuilder) newRequestCancelExternalWorkflowExecutionFailedEvent(decisionTaskCompletedEventID, initiatedEventID int64,
+func (b *HistoryBuilder) newRequestCancelExternalWorkflowExecutionFailedEvent(decisionTaskCompletedEventID, initiatedEventID int64,
 	domain, workflowID, runID string, cause workflow.CancelExternalWorkflowExecutionFailedCause) *workflow.HistoryEvent {
 	event := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeRequestCancelExternalWorkflowExecutionFailed)
 	attributes := &workflow.RequestCancelExternalWorkflowExecutionFailedEventAttributes{}
@@ -757,7 +845,7 @@ func (b *historyBuilder) newRequestCancelExternalWorkflowExecutionFailedEvent(de
 	return event
 }
 
-func (b *historyBuilder) newExternalWorkflowExecutionCancelRequestedEvent(initiatedEventID int64,
+func (b *HistoryBuilder) newExternalWorkflowExecutionCancelRequestedEvent(initiatedEventID int64,
 	domain, workflowID, runID string) *workflow.HistoryEvent {
 	event := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeExternalWorkflowExecutionCancelRequested)
 	attributes := &workflow.ExternalWorkflowExecutionCancelRequestedEventAttributes{}
@@ -772,7 +860,7 @@ func (b *historyBuilder) newExternalWorkflowExecutionCancelRequestedEvent(initia
 	return event
 }
 
-func (b *historyBuilder) newSignalExternalWorkflowExecutionInitiatedEvent(decisionTaskCompletedEventID int64,
+func (b *HistoryBuilder) newSignalExternalWorkflowExecutionInitiatedEvent(decisionTaskCompletedEventID int64,
 	request *workflow.SignalExternalWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeSignalExternalWorkflowExecutionInitiated)
 	attributes := &workflow.SignalExternalWorkflowExecutionInitiatedEventAttributes{}
@@ -791,7 +879,7 @@ func (b *historyBuilder) newSignalExternalWorkflowExecutionInitiatedEvent(decisi
 	return event
 }
 
-func (b *historyBuilder) newSignalExternalWorkflowExecutionFailedEvent(decisionTaskCompletedEventID, initiatedEventID int64,
+func (b *HistoryBuilder) newSignalExternalWorkflowExecutionFailedEvent(decisionTaskCompletedEventID, initiatedEventID int64,
 	domain, workflowID, runID string, control []byte, cause workflow.SignalExternalWorkflowExecutionFailedCause) *workflow.HistoryEvent {
 	event := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeSignalExternalWorkflowExecutionFailed)
 	attributes := &workflow.SignalExternalWorkflowExecutionFailedEventAttributes{}
@@ -809,7 +897,7 @@ func (b *historyBuilder) newSignalExternalWorkflowExecutionFailedEvent(decisionT
 	return event
 }
 
-func (b *historyBuilder) newExternalWorkflowExecutionSignaledEvent(initiatedEventID int64,
+func (b *HistoryBuilder) newExternalWorkflowExecutionSignaledEvent(initiatedEventID int64,
 	domain, workflowID, runID string, control []byte) *workflow.HistoryEvent {
 	event := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeExternalWorkflowExecutionSignaled)
 	attributes := &workflow.ExternalWorkflowExecutionSignaledEventAttributes{}
@@ -825,7 +913,7 @@ func (b *historyBuilder) newExternalWorkflowExecutionSignaledEvent(initiatedEven
 	return event
 }
 
-func (b *historyBuilder) newWorkflowExecutionContinuedAsNewEvent(decisionTaskCompletedEventID int64,
+func (b *HistoryBuilder) newWorkflowExecutionContinuedAsNewEvent(decisionTaskCompletedEventID int64,
 	newRunID string, request *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionContinuedAsNew)
 	attributes := &workflow.WorkflowExecutionContinuedAsNewEventAttributes{}
@@ -841,7 +929,7 @@ func (b *historyBuilder) newWorkflowExecutionContinuedAsNewEvent(decisionTaskCom
 	return historyEvent
 }
 
-func (b *historyBuilder) newStartChildWorkflowExecutionInitiatedEvent(decisionTaskCompletedEventID int64,
+func (b *HistoryBuilder) newStartChildWorkflowExecutionInitiatedEvent(decisionTaskCompletedEventID int64,
 	startAttributes *workflow.StartChildWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeStartChildWorkflowExecutionInitiated)
 	attributes := &workflow.StartChildWorkflowExecutionInitiatedEventAttributes{}
@@ -861,7 +949,7 @@ func (b *historyBuilder) newStartChildWorkflowExecutionInitiatedEvent(decisionTa
 	return historyEvent
 }
 
-func (b *historyBuilder) newChildWorkflowExecutionStartedEvent(domain *string, execution *workflow.WorkflowExecution,
+func (b *HistoryBuilder) newChildWorkflowExecutionStartedEvent(domain *string, execution *workflow.WorkflowExecution,
 	workflowType *workflow.WorkflowType, initiatedID int64) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeChildWorkflowExecutionStarted)
 	attributes := &workflow.ChildWorkflowExecutionStartedEventAttributes{}
@@ -874,7 +962,7 @@ func (b *historyBuilder) newChildWorkflowExecutionStartedEvent(domain *string, e
 	return historyEvent
 }
 
-func (b *historyBuilder) newStartChildWorkflowExecutionFailedEvent(initiatedID int64,
+func (b *HistoryBuilder) newStartChildWorkflowExecutionFailedEvent(initiatedID int64,
 	cause workflow.ChildWorkflowExecutionFailedCause,
 	initiatedEventAttributes *workflow.StartChildWorkflowExecutionInitiatedEventAttributes) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeStartChildWorkflowExecutionFailed)
@@ -891,7 +979,7 @@ func (b *historyBuilder) newStartChildWorkflowExecutionFailedEvent(initiatedID i
 	return historyEvent
 }
 
-func (b *historyBuilder) newChildWorkflowExecutionCompletedEvent(domain *string, execution *workflow.WorkflowExecution,
+func (b *HistoryBuilder) newChildWorkflowExecutionCompletedEvent(domain *string, execution *workflow.WorkflowExecution,
 	workflowType *workflow.WorkflowType, initiatedID, startedID int64,
 	completedAttributes *workflow.WorkflowExecutionCompletedEventAttributes) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeChildWorkflowExecutionCompleted)
@@ -907,7 +995,7 @@ func (b *historyBuilder) newChildWorkflowExecutionCompletedEvent(domain *string,
 	return historyEvent
 }
 
-func (b *historyBuilder) newChildWorkflowExecutionFailedEvent(domain *string, execution *workflow.WorkflowExecution,
+func (b *HistoryBuilder) newChildWorkflowExecutionFailedEvent(domain *string, execution *workflow.WorkflowExecution,
 	workflowType *workflow.WorkflowType, initiatedID, startedID int64,
 	failedAttributes *workflow.WorkflowExecutionFailedEventAttributes) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeChildWorkflowExecutionFailed)
@@ -924,7 +1012,7 @@ func (b *historyBuilder) newChildWorkflowExecutionFailedEvent(domain *string, ex
 	return historyEvent
 }
 
-func (b *historyBuilder) newChildWorkflowExecutionCanceledEvent(domain *string, execution *workflow.WorkflowExecution,
+func (b *HistoryBuilder) newChildWorkflowExecutionCanceledEvent(domain *string, execution *workflow.WorkflowExecution,
 	workflowType *workflow.WorkflowType, initiatedID, startedID int64,
 	canceledAttributes *workflow.WorkflowExecutionCanceledEventAttributes) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeChildWorkflowExecutionCanceled)
@@ -940,7 +1028,7 @@ func (b *historyBuilder) newChildWorkflowExecutionCanceledEvent(domain *string,
 	return historyEvent
 }
 
-func (b *historyBuilder) newChildWorkflowExecutionTerminatedEvent(domain *string, execution *workflow.WorkflowExecution,
+func (b *HistoryBuilder) newChildWorkflowExecutionTerminatedEvent(domain *string, execution *workflow.WorkflowExecution,
 	workflowType *workflow.WorkflowType, initiatedID, startedID int64,
 	terminatedAttributes *workflow.WorkflowExecutionTerminatedEventAttributes) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeChildWorkflowExecutionTerminated)
@@ -955,7 +1043,7 @@ func (b *historyBuilder) newChildWorkflowExecutionTerminatedEvent(domain *string
 	return historyEvent
 }
 
-func (b *historyBuilder) newChildWorkflowExecutionTimedOutEvent(domain *string, execution *workflow.WorkflowExecution,
+func (b *HistoryBuilder) newChildWorkflowExecutionTimedOutEvent(domain *string, execution *workflow.WorkflowExecution,
 	workflowType *workflow.WorkflowType, initiatedID, startedID int64,
 	timedOutAttributes *workflow.WorkflowExecutionTimedOutEventAttributes) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeChildWorkflowExecutionTimedOut)
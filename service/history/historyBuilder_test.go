@@ -44,8 +44,8 @@ type (
 		// not merely log an error
 		*require.Assertions
 		domainID  string
-		msBuilder mutableState
-		builder   *historyBuilder
+		msBuilder MutableState
+		builder   *HistoryBuilder
 		logger    bark.Logger
 	}
 )
@@ -673,7 +673,7 @@ func (s *historyBuilderSuite) addWorkflowExecutionStartedEvent(we workflow.Workf
 	return e
 }
 
-func (s *historyBuilderSuite) addDecisionTaskScheduledEvent() *decisionInfo {
+func (s *historyBuilderSuite) addDecisionTaskScheduledEvent() *DecisionInfo {
 	return s.msBuilder.AddDecisionTaskScheduledEvent()
 }
 
@@ -809,7 +809,7 @@ func (s *historyBuilderSuite) validateWorkflowExecutionStartedEvent(event *workf
 	s.Equal(identity, *attributes.Identity)
 }
 
-func (s *historyBuilderSuite) validateDecisionTaskScheduledEvent(di *decisionInfo, eventID int64,
+func (s *historyBuilderSuite) validateDecisionTaskScheduledEvent(di *DecisionInfo, eventID int64,
 	taskList string, timeout int32) {
 	s.NotNil(di)
 	s.Equal(eventID, di.ScheduleID)
@@ -23,6 +23,7 @@ package history
 import (
 	"context"
 	"sync/atomic"
+	"time"
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/backoff"
@@ -36,7 +37,20 @@ import (
 )
 
 type (
-	releaseWorkflowExecutionFunc func(err error)
+	// ReleaseWorkflowExecutionFunc releases a workflow execution context acquired from the HistoryCache. A non nil
+	// error clears the mutable state of the context, as it may no longer match persistence.
+	ReleaseWorkflowExecutionFunc func(err error)
+
+	// HistoryCache caches the workflow execution contexts of a shard and serializes the access to each of them
+	HistoryCache interface {
+		GetOrCreateWorkflowExecution(domainID string,
+			execution workflow.WorkflowExecution) (*WorkflowExecutionContext, ReleaseWorkflowExecutionFunc, error)
+		GetOrCreateWorkflowExecutionWithTimeout(ctx context.Context, domainID string,
+			execution workflow.WorkflowExecution) (*WorkflowExecutionContext, ReleaseWorkflowExecutionFunc, error)
+		GetAndCreateWorkflowExecutionWithTimeout(ctx context.Context, domainID string,
+			execution workflow.WorkflowExecution) (*WorkflowExecutionContext, *WorkflowExecutionContext,
+			ReleaseWorkflowExecutionFunc, bool, error)
+	}
 
 	historyCache struct {
 		cache.Cache
@@ -45,6 +59,9 @@ type (
 		disabled         bool
 		logger           bark.Logger
 		config           *Config
+		shutdownCh       chan struct{}
+		// decisionPins is the number of contexts pinned for an in-flight decision, see pinInFlightDecision
+		decisionPins int32
 	}
 )
 
@@ -53,6 +70,8 @@ const (
 	cacheReleased    int32 = 1
 )
 
+var _ HistoryCache = (*historyCache)(nil)
+
 var (
 	// ErrTryLock is a temporary error that is thrown by the API
 	// when it loses the race to create workflow execution context
@@ -64,7 +83,9 @@ func newHistoryCache(shard ShardContext, logger bark.Logger) *historyCache {
 	config := shard.GetConfig()
 	opts.InitialCapacity = config.HistoryCacheInitialSize()
 	opts.TTL = config.HistoryCacheTTL()
+	opts.IdleTTL = config.HistoryCacheIdleTTL()
 	opts.Pin = true
+	opts.TimeSource = shard.GetTimeSource()
 
 	return &historyCache{
 		Cache:            cache.New(config.HistoryCacheMaxSize(), opts),
@@ -73,13 +94,40 @@ func newHistoryCache(shard ShardContext, logger bark.Logger) *historyCache {
 		logger: logger.WithFields(bark.Fields{
 			logging.TagWorkflowComponent: logging.TagValueHistoryCacheComponent,
 		}),
-		config: config,
+		config:     config,
+		shutdownCh: make(chan struct{}),
 	}
 }
 
-func (c *historyCache) getOrCreateWorkflowExecution(domainID string,
-	execution workflow.WorkflowExecution) (*workflowExecutionContext, releaseWorkflowExecutionFunc, error) {
-	return c.getOrCreateWorkflowExecutionWithTimeout(context.Background(), domainID, execution)
+// start evicts idle workflow execution contexts in the background, instead of waiting for them to be accessed or
+// pushed out by newer ones
+func (c *historyCache) start() {
+	go c.evictionLoop()
+}
+
+func (c *historyCache) stop() {
+	close(c.shutdownCh)
+}
+
+func (c *historyCache) evictionLoop() {
+	ticker := time.NewTicker(c.config.HistoryCacheEvictionInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.shutdownCh:
+			return
+		case <-ticker.C:
+			if evicted := c.EvictExpired(); evicted > 0 {
+				c.logger.Debugf("Evicted %v expired workflow execution contexts.", evicted)
+			}
+		}
+	}
+}
+
+func (c *historyCache) GetOrCreateWorkflowExecution(domainID string,
+	execution workflow.WorkflowExecution) (*WorkflowExecutionContext, ReleaseWorkflowExecutionFunc, error) {
+	return c.GetOrCreateWorkflowExecutionWithTimeout(context.Background(), domainID, execution)
 }
 
 func (c *historyCache) validateWorkflowExecutionInfo(domainID string, execution *workflow.WorkflowExecution) error {
@@ -106,14 +154,14 @@ func (c *historyCache) validateWorkflowExecutionInfo(domainID string, execution
 }
 
 // For analyzing mutableState, we have to try get workflowExecutionContext from cache and also load from database
-func (c *historyCache) getAndCreateWorkflowExecutionWithTimeout(ctx context.Context, domainID string,
-	execution workflow.WorkflowExecution) (*workflowExecutionContext, *workflowExecutionContext, releaseWorkflowExecutionFunc, bool, error) {
+func (c *historyCache) GetAndCreateWorkflowExecutionWithTimeout(ctx context.Context, domainID string,
+	execution workflow.WorkflowExecution) (*WorkflowExecutionContext, *WorkflowExecutionContext, ReleaseWorkflowExecutionFunc, bool, error) {
 	if err := c.validateWorkflowExecutionInfo(domainID, &execution); err != nil {
 		return nil, nil, nil, false, err
 	}
 
 	key := execution.GetRunId()
-	contextFromCache, cacheHit := c.Get(key).(*workflowExecutionContext)
+	contextFromCache, cacheHit := c.Get(key).(*WorkflowExecutionContext)
 	releaseFunc := func(error) {}
 	// If cache hit, we need to lock the cache to prevent race condition
 	if cacheHit {
@@ -130,8 +178,8 @@ func (c *historyCache) getAndCreateWorkflowExecutionWithTimeout(ctx context.Cont
 	return contextFromCache, contextFromDB, releaseFunc, cacheHit, nil
 }
 
-func (c *historyCache) getOrCreateWorkflowExecutionWithTimeout(ctx context.Context, domainID string,
-	execution workflow.WorkflowExecution) (*workflowExecutionContext, releaseWorkflowExecutionFunc, error) {
+func (c *historyCache) GetOrCreateWorkflowExecutionWithTimeout(ctx context.Context, domainID string,
+	execution workflow.WorkflowExecution) (*WorkflowExecutionContext, ReleaseWorkflowExecutionFunc, error) {
 	if err := c.validateWorkflowExecutionInfo(domainID, &execution); err != nil {
 		return nil, nil, err
 	}
@@ -142,7 +190,7 @@ func (c *historyCache) getOrCreateWorkflowExecutionWithTimeout(ctx context.Conte
 	}
 
 	key := execution.GetRunId()
-	context, cacheHit := c.Get(key).(*workflowExecutionContext)
+	context, cacheHit := c.Get(key).(*WorkflowExecutionContext)
 	if !cacheHit {
 		// Let's create the workflow execution context
 		context = newWorkflowExecutionContext(domainID, execution, c.shard, c.executionManager, c.logger)
@@ -150,7 +198,7 @@ func (c *historyCache) getOrCreateWorkflowExecutionWithTimeout(ctx context.Conte
 		if err != nil {
 			return nil, nil, err
 		}
-		context = elem.(*workflowExecutionContext)
+		context = elem.(*WorkflowExecutionContext)
 	}
 
 	// This will create a closure on every request.
@@ -165,19 +213,42 @@ func (c *historyCache) getOrCreateWorkflowExecutionWithTimeout(ctx context.Conte
 	return context, releaseFunc, nil
 }
 
-func (c *historyCache) makeReleaseFunc(key string, status int32, context *workflowExecutionContext) func(error) {
+func (c *historyCache) makeReleaseFunc(key string, status int32, context *WorkflowExecutionContext) func(error) {
 	return func(err error) {
 		if atomic.CompareAndSwapInt32(&status, cacheNotReleased, cacheReleased) {
 			if err != nil {
 				// TODO see issue #668, there are certain type or errors which can bypass the clear
 				context.clear()
 			}
+			c.pinInFlightDecision(key, context)
 			context.locker.Unlock()
 			c.Release(key)
 		}
 	}
 }
 
+// pinInFlightDecision keeps the context pinned in the cache for as long as its workflow execution has a decision in
+// flight, so the mutable state loaded when the decision was started is still there when the decision completes.
+// At most half of the cache is pinned this way, the contexts of the decisions past that are evicted as usual and
+// reloaded when their decision completes. It has to be called while holding the context lock.
+func (c *historyCache) pinInFlightDecision(key string, context *WorkflowExecutionContext) {
+	inFlight := context.msBuilder != nil && context.msBuilder.HasInFlightDecisionTask()
+	if inFlight == context.decisionPinned {
+		return
+	}
+	if !inFlight {
+		context.decisionPinned = false
+		atomic.AddInt32(&c.decisionPins, -1)
+		c.Release(key)
+		return
+	}
+	if atomic.AddInt32(&c.decisionPins, 1) > int32(c.config.HistoryCacheMaxSize()/2) || !c.Pin(key) {
+		atomic.AddInt32(&c.decisionPins, -1)
+		return
+	}
+	context.decisionPinned = true
+}
+
 func (c *historyCache) getCurrentExecutionWithRetry(
 	request *persistence.GetCurrentExecutionRequest) (*persistence.GetCurrentExecutionResponse, error) {
 	var response *persistence.GetCurrentExecutionResponse
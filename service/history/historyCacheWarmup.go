@@ -0,0 +1,138 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"time"
+
+	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// warmupExecution is an execution which the queue processors of a newly acquired shard are about to process
+	warmupExecution struct {
+		domainID  string
+		execution workflow.WorkflowExecution
+	}
+)
+
+// warmUpHistoryCache loads into the history cache the mutable state of the executions with pending decision tasks
+// in the first page of the transfer queue, or with timers about to fire in the first page of the timer queue.
+// This spreads the loads which the first tasks after a shard is acquired would otherwise all do at once.
+func (e *historyEngineImpl) warmUpHistoryCache(ctx context.Context) {
+	config := e.shard.GetConfig()
+	maxExecutions := config.HistoryCacheWarmupMaxExecutions()
+	// loading more executions than the cache holds would only evict the ones just loaded
+	if cacheSize := config.HistoryCacheMaxSize(); maxExecutions > cacheSize {
+		maxExecutions = cacheSize
+	}
+	if maxExecutions <= 0 {
+		return
+	}
+
+	sw := e.metricsClient.StartTimer(metrics.HistoryCacheWarmupScope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	executions, err := e.getWarmupExecutions(maxExecutions, config.HistoryCacheWarmupTimerWindow())
+	if err != nil {
+		e.metricsClient.IncCounter(metrics.HistoryCacheWarmupScope, metrics.CadenceFailures)
+		e.logger.Warnf("Failed to read the task queues to warm up history cache: %v", err)
+		return
+	}
+
+	loaded := 0
+	for _, w := range executions {
+		context, release, err := e.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx, w.domainID, w.execution)
+		if err != nil {
+			// the engine is stopping
+			break
+		}
+		_, err = context.loadWorkflowExecution()
+		release(err)
+		if err != nil {
+			e.logger.WithFields(bark.Fields{
+				logging.TagDomainID:            w.domainID,
+				logging.TagWorkflowExecutionID: w.execution.GetWorkflowId(),
+				logging.TagWorkflowRunID:       w.execution.GetRunId(),
+			}).Debugf("Failed to warm up history cache: %v", err)
+			continue
+		}
+		loaded++
+	}
+
+	e.metricsClient.AddCounter(metrics.HistoryCacheWarmupScope, metrics.CacheWarmupLoadedCounter, int64(loaded))
+	e.logger.Infof("History cache warmed up with %v of %v executions.", loaded, len(executions))
+}
+
+// getWarmupExecutions returns up to maxExecutions distinct executions, those with pending decision tasks first
+func (e *historyEngineImpl) getWarmupExecutions(maxExecutions int, timerWindow time.Duration) ([]warmupExecution, error) {
+	var executions []warmupExecution
+	seen := make(map[string]struct{})
+	add := func(domainID, workflowID, runID string) {
+		if _, ok := seen[runID]; ok || len(executions) >= maxExecutions {
+			return
+		}
+		seen[runID] = struct{}{}
+		executions = append(executions, warmupExecution{
+			domainID: domainID,
+			execution: workflow.WorkflowExecution{
+				WorkflowId: common.StringPtr(workflowID),
+				RunId:      common.StringPtr(runID),
+			},
+		})
+	}
+
+	transferResponse, err := e.executionManager.GetTransferTasks(&persistence.GetTransferTasksRequest{
+		ReadLevel:    e.shard.GetTransferAckLevel(),
+		MaxReadLevel: e.shard.GetTransferMaxReadLevel(),
+		BatchSize:    maxExecutions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range transferResponse.Tasks {
+		if task.TaskType == persistence.TransferTaskTypeDecisionTask {
+			add(task.DomainID, task.WorkflowID, task.RunID)
+		}
+	}
+
+	timerResponse, err := e.executionManager.GetTimerIndexTasks(&persistence.GetTimerIndexTasksRequest{
+		MinTimestamp: e.shard.GetTimerAckLevel(),
+		MaxTimestamp: e.shard.GetCurrentTime(e.currentClusterName).Add(timerWindow),
+		BatchSize:    maxExecutions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, timer := range timerResponse.Timers {
+		// the execution is deleted by the retention timer, there is no point in loading it
+		if timer.TaskType != persistence.TaskTypeDeleteHistoryEvent {
+			add(timer.DomainID, timer.WorkflowID, timer.RunID)
+		}
+	}
+	return executions, nil
+}
@@ -25,6 +25,7 @@ import (
 	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/pborman/uuid"
 	log "github.com/sirupsen/logrus"
@@ -59,6 +60,12 @@ type (
 		mockShard           *shardContextImpl
 		cache               *historyCache
 	}
+
+	// timeSourceShard overrides the time source of the wrapped shard so cache expiry can be driven by the test
+	timeSourceShard struct {
+		ShardContext
+		timeSource common.TimeSource
+	}
 )
 
 func TestHistoryCacheSuite(t *testing.T) {
@@ -66,6 +73,10 @@ func TestHistoryCacheSuite(t *testing.T) {
 	suite.Run(t, s)
 }
 
+func (s *timeSourceShard) GetTimeSource() common.TimeSource {
+	return s.timeSource
+}
+
 func (s *historyCacheSuite) SetupSuite() {
 	if testing.Verbose() {
 		log.SetOutput(os.Stdout)
@@ -116,7 +127,7 @@ func (s *historyCacheSuite) TestHistoryCachePinning() {
 		RunId:      common.StringPtr(uuid.New()),
 	}
 
-	context, release, err := s.cache.getOrCreateWorkflowExecution(domainID, we)
+	context, release, err := s.cache.GetOrCreateWorkflowExecution(domainID, we)
 	s.Nil(err)
 
 	we2 := workflow.WorkflowExecution{
@@ -125,18 +136,18 @@ func (s *historyCacheSuite) TestHistoryCachePinning() {
 	}
 
 	// Cache is full because context is pinned, should get an error now
-	_, _, err2 := s.cache.getOrCreateWorkflowExecution(domainID, we2)
+	_, _, err2 := s.cache.GetOrCreateWorkflowExecution(domainID, we2)
 	s.NotNil(err2)
 
 	// Now release the context, this should unpin it.
 	release(err2)
 
-	_, release2, err3 := s.cache.getOrCreateWorkflowExecution(domainID, we2)
+	_, release2, err3 := s.cache.GetOrCreateWorkflowExecution(domainID, we2)
 	s.Nil(err3)
 	release2(err3)
 
 	// Old context should be evicted.
-	newContext, release, err4 := s.cache.getOrCreateWorkflowExecution(domainID, we)
+	newContext, release, err4 := s.cache.GetOrCreateWorkflowExecution(domainID, we)
 	s.Nil(err4)
 	s.False(context == newContext)
 	release(err4)
@@ -151,23 +162,23 @@ func (s *historyCacheSuite) TestHistoryCacheClear() {
 		RunId:      common.StringPtr(uuid.New()),
 	}
 
-	context, release, err := s.cache.getOrCreateWorkflowExecution(domainID, we)
+	context, release, err := s.cache.GetOrCreateWorkflowExecution(domainID, we)
 	s.Nil(err)
 	// since we are just testing whether the release function will clear the cache
 	// all we need is a fake msBuilder
-	context.msBuilder = &mutableStateBuilder{}
+	context.msBuilder = newMutableStateBuilder(s.mockShard.GetConfig(), s.logger)
 	release(nil)
 
 	// since last time, the release function receive a nil error
 	// the ms builder will not be cleared
-	context, release, err = s.cache.getOrCreateWorkflowExecution(domainID, we)
+	context, release, err = s.cache.GetOrCreateWorkflowExecution(domainID, we)
 	s.Nil(err)
 	s.NotNil(context.msBuilder)
 	release(errors.New("some random error message"))
 
 	// since last time, the release function receive a non-nil error
 	// the ms builder will be cleared
-	context, release, err = s.cache.getOrCreateWorkflowExecution(domainID, we)
+	context, release, err = s.cache.GetOrCreateWorkflowExecution(domainID, we)
 	s.Nil(err)
 	s.Nil(context.msBuilder)
 	release(nil)
@@ -187,13 +198,13 @@ func (s *historyCacheSuite) TestHistoryCacheConcurrentAccess() {
 	stopChan := make(chan struct{})
 	testFn := func() {
 		<-stopChan
-		context, release, err := s.cache.getOrCreateWorkflowExecution(domainID, we)
+		context, release, err := s.cache.GetOrCreateWorkflowExecution(domainID, we)
 		s.Nil(err)
 		// since each time the builder is reset to nil
 		s.Nil(context.msBuilder)
 		// since we are just testing whether the release function will clear the cache
 		// all we need is a fake msBuilder
-		context.msBuilder = &mutableStateBuilder{}
+		context.msBuilder = newMutableStateBuilder(s.mockShard.GetConfig(), s.logger)
 		release(errors.New("some random error message"))
 		waitGroup.Done()
 	}
@@ -205,10 +216,97 @@ func (s *historyCacheSuite) TestHistoryCacheConcurrentAccess() {
 	close(stopChan)
 	waitGroup.Wait()
 
-	context, release, err := s.cache.getOrCreateWorkflowExecution(domainID, we)
+	context, release, err := s.cache.GetOrCreateWorkflowExecution(domainID, we)
 	s.Nil(err)
 	// since we are just testing whether the release function will clear the cache
 	// all we need is a fake msBuilder
 	s.Nil(context.msBuilder)
 	release(nil)
 }
+
+func (s *historyCacheSuite) TestHistoryCachePinsInFlightDecision() {
+	timeSource := common.NewEventTimeSource().Update(time.Now())
+	s.mockShard.GetConfig().HistoryCacheIdleTTL = dynamicconfig.GetDurationPropertyFn(time.Minute)
+	domainID := "test_domain_id"
+	s.cache = newHistoryCache(&timeSourceShard{ShardContext: s.mockShard, timeSource: timeSource}, s.logger)
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wf-cache-test-decision-pinning"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+
+	context, release, err := s.cache.GetOrCreateWorkflowExecution(domainID, we)
+	s.Nil(err)
+	msBuilder := newMutableStateBuilder(s.mockShard.GetConfig(), s.logger)
+	msBuilder.GetExecutionInfo().DecisionScheduleID = 4
+	msBuilder.GetExecutionInfo().DecisionStartedID = 5
+	context.msBuilder = msBuilder
+	release(nil)
+	s.True(context.decisionPinned)
+
+	// the context stays pinned after the operation is done, it does not go idle while the decision is in flight
+	timeSource.Update(timeSource.Now().Add(2 * time.Minute))
+	s.Equal(0, s.cache.EvictExpired())
+
+	// completing the decision unpins it
+	context, release, err = s.cache.GetOrCreateWorkflowExecution(domainID, we)
+	s.Nil(err)
+	s.True(context.msBuilder == msBuilder)
+	msBuilder.GetExecutionInfo().DecisionScheduleID = common.EmptyEventID
+	msBuilder.GetExecutionInfo().DecisionStartedID = common.EmptyEventID
+	release(nil)
+	s.False(context.decisionPinned)
+
+	timeSource.Update(timeSource.Now().Add(2 * time.Minute))
+	s.Equal(1, s.cache.EvictExpired())
+}
+
+func (s *historyCacheSuite) TestHistoryCacheInFlightDecisionsDoNotFillCache() {
+	s.mockShard.GetConfig().HistoryCacheMaxSize = dynamicconfig.GetIntPropertyFn(2)
+	domainID := "test_domain_id"
+	s.cache = newHistoryCache(s.mockShard, s.logger)
+
+	// start more decisions than the cache can hold, a context released with a decision in flight
+	// must still be evictable once it is no longer used
+	for i := 0; i < 5; i++ {
+		we := workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr("wf-cache-test-in-flight-decisions"),
+			RunId:      common.StringPtr(uuid.New()),
+		}
+		context, release, err := s.cache.GetOrCreateWorkflowExecution(domainID, we)
+		s.Nil(err)
+		msBuilder := newMutableStateBuilder(s.mockShard.GetConfig(), s.logger)
+		msBuilder.GetExecutionInfo().DecisionScheduleID = 4
+		msBuilder.GetExecutionInfo().DecisionStartedID = 5
+		s.True(msBuilder.HasInFlightDecisionTask())
+		context.msBuilder = msBuilder
+		release(nil)
+		// only half of the cache is pinned for in-flight decisions
+		s.Equal(i == 0, context.decisionPinned)
+	}
+	s.Equal(2, s.cache.Size())
+}
+
+func (s *historyCacheSuite) TestHistoryCacheEvictsIdleContexts() {
+	timeSource := common.NewEventTimeSource().Update(time.Now())
+	s.mockShard.GetConfig().HistoryCacheIdleTTL = dynamicconfig.GetDurationPropertyFn(50 * time.Millisecond)
+	domainID := "test_domain_id"
+	s.cache = newHistoryCache(&timeSourceShard{ShardContext: s.mockShard, timeSource: timeSource}, s.logger)
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wf-cache-test-idle"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+
+	context, release, err := s.cache.GetOrCreateWorkflowExecution(domainID, we)
+	s.Nil(err)
+	timeSource.Update(timeSource.Now().Add(100 * time.Millisecond))
+	// in use, not evicted
+	s.Equal(0, s.cache.EvictExpired())
+	release(nil)
+
+	timeSource.Update(timeSource.Now().Add(100 * time.Millisecond))
+	s.Equal(1, s.cache.EvictExpired())
+	newContext, release, err := s.cache.GetOrCreateWorkflowExecution(domainID, we)
+	s.Nil(err)
+	s.False(context == newContext)
+	release(nil)
+}
@@ -37,6 +37,7 @@ import (
 	hc "github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/messaging"
@@ -49,6 +50,14 @@ const (
 	activityCancelationMsgActivityIDUnknown  = "ACTIVITY_ID_UNKNOWN"
 	activityCancelationMsgActivityNotStarted = "ACTIVITY_ID_NOT_STARTED"
 	timerCancelationMsgTimerIDUnknown        = "TIMER_ID_UNKNOWN"
+
+	// policies for signals to a closed workflow, see dynamicconfig.SignalAfterClosePolicy
+	signalAfterClosePolicyReject = "reject"
+	signalAfterClosePolicyDrop   = "drop"
+	signalAfterClosePolicyStart  = "start"
+
+	// updateWorkflowExecutionReplyReserve is left of the deadline of an update call to reply with the timeout
+	updateWorkflowExecutionReplyReserve = time.Second
 )
 
 type (
@@ -57,6 +66,7 @@ type (
 		shard                ShardContext
 		historyMgr           persistence.HistoryManager
 		executionManager     persistence.ExecutionManager
+		visibilityMgr        persistence.VisibilityManager
 		txProcessor          transferQueueProcessor
 		timerProcessor       timerQueueProcessor
 		replicator           *historyReplicator
@@ -65,8 +75,12 @@ type (
 		tokenSerializer      common.TaskTokenSerializer
 		hSerializerFactory   persistence.HistorySerializerFactory
 		historyCache         *historyCache
+		outboundProcessor    *outboundProcessor
+		stickyPoisonDetector *stickyPoisonDetector
 		metricsClient        metrics.Client
 		logger               bark.Logger
+		// warmupCancel stops the warm-up of the history cache, nil if there is none
+		warmupCancel context.CancelFunc
 	}
 
 	// shardContextWrapper wraps ShardContext to notify transferQueueProcessor on new tasks.
@@ -85,6 +99,8 @@ var _ Engine = (*historyEngineImpl)(nil)
 var (
 	// ErrTaskRetry is the error indicating that the timer / transfer task should be retried.
 	ErrTaskRetry = errors.New("passive task should retry due to condition in mutable state is not met")
+	// ErrTaskPaused is the error indicating that processing of the timer / transfer task type is paused.
+	ErrTaskPaused = errors.New("processing of this task type is paused")
 	// ErrDuplicate is exported temporarily for integration test
 	ErrDuplicate = errors.New("Duplicate task, completing it")
 	// ErrConflict is exported temporarily for integration test
@@ -105,6 +121,13 @@ var (
 	ErrCancellationAlreadyRequested = &workflow.CancellationAlreadyRequestedError{Message: "Cancellation already requested for this workflow execution."}
 	// ErrBufferedEventsLimitExceeded is the error indicating limit reached for maximum number of buffered events
 	ErrBufferedEventsLimitExceeded = &workflow.LimitExceededError{Message: "Exceeded workflow execution limit for buffered events"}
+	// ErrUpdatesLimitExceeded is the error indicating limit reached for maximum number of updates requested on a workflow run
+	ErrUpdatesLimitExceeded = &workflow.LimitExceededError{Message: "Exceeded workflow execution limit for updates"}
+	// ErrUpdateIDNotSet is the error indicating an update request without an update ID
+	ErrUpdateIDNotSet = &workflow.BadRequestError{Message: "UpdateId is not set on request."}
+	// ErrUpdateTimedOut is the error indicating an update not completed before the deadline of the caller, a retry with
+	// the same update ID waits on it again
+	ErrUpdateTimedOut = &workflow.ServiceBusyError{Message: "Timed out waiting for the workflow update to complete."}
 	// FailedWorkflowCloseState is a set of failed workflow close states, used for start workflow policy
 	// for start workflow execution API
 	FailedWorkflowCloseState = map[int]bool{
@@ -117,7 +140,8 @@ var (
 
 // NewEngineWithShardContext creates an instance of history engine
 func NewEngineWithShardContext(shard ShardContext, visibilityMgr persistence.VisibilityManager,
-	matching matching.Client, historyClient hc.Client, historyEventNotifier historyEventNotifier, publisher messaging.Producer) Engine {
+	postCloseEventsMgr persistence.PostCloseEventsManager, matching matching.Client, historyClient hc.Client, historyEventNotifier historyEventNotifier, publisher messaging.Producer,
+	outboundProcessor *outboundProcessor, stickyPoisonDetector *stickyPoisonDetector) Engine {
 	currentClusterName := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
 	shardWrapper := &shardContextWrapper{
 		currentClusterName:   currentClusterName,
@@ -135,6 +159,7 @@ func NewEngineWithShardContext(shard ShardContext, visibilityMgr persistence.Vis
 		shard:              shard,
 		historyMgr:         historyManager,
 		executionManager:   executionManager,
+		visibilityMgr:      visibilityMgr,
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
 		hSerializerFactory: historySerializerFactory,
 		historyCache:       historyCache,
@@ -143,6 +168,8 @@ func NewEngineWithShardContext(shard ShardContext, visibilityMgr persistence.Vis
 		}),
 		metricsClient:        shard.GetMetricsClient(),
 		historyEventNotifier: historyEventNotifier,
+		outboundProcessor:    outboundProcessor,
+		stickyPoisonDetector: stickyPoisonDetector,
 	}
 	txProcessor := newTransferQueueProcessor(shard, historyEngImpl, visibilityMgr, matching, historyClient, logger)
 	historyEngImpl.timerProcessor = newTimerQueueProcessor(shard, historyEngImpl, matching, logger)
@@ -156,7 +183,7 @@ func NewEngineWithShardContext(shard ShardContext, visibilityMgr persistence.Vis
 		historyEngImpl.replicatorProcessor = replicatorProcessor
 		shardWrapper.replcatorProcessor = replicatorProcessor
 		historyEngImpl.replicator = newHistoryReplicator(shard, historyEngImpl, historyCache, shard.GetDomainCache(), historyManager,
-			logger)
+			postCloseEventsMgr, logger)
 	}
 
 	return historyEngImpl
@@ -171,11 +198,18 @@ func (e *historyEngineImpl) Start() {
 
 	e.registerDomainFailoverCallback()
 
+	e.historyCache.start()
 	e.txProcessor.Start()
 	e.timerProcessor.Start()
 	if e.replicatorProcessor != nil {
 		e.replicatorProcessor.Start()
 	}
+
+	if e.shard.GetConfig().HistoryCacheWarmupMaxExecutions() > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		e.warmupCancel = cancel
+		go e.warmUpHistoryCache(ctx)
+	}
 }
 
 // Stop the service.
@@ -183,16 +217,53 @@ func (e *historyEngineImpl) Stop() {
 	logging.LogHistoryEngineShuttingDownEvent(e.logger)
 	defer logging.LogHistoryEngineShutdownEvent(e.logger)
 
+	if e.warmupCancel != nil {
+		e.warmupCancel()
+	}
 	e.txProcessor.Stop()
 	e.timerProcessor.Stop()
 	if e.replicatorProcessor != nil {
 		e.replicatorProcessor.Stop()
 	}
+	e.historyCache.stop()
 
 	// unset the failover callback
 	e.shard.GetDomainCache().UnregisterDomainChangeCallback(e.shard.GetShardID())
 }
 
+// getShardLoad returns the raw load counters of the shard owned by this engine, used by shardRebalancer
+func (e *historyEngineImpl) getShardLoad() shardLoad {
+	return shardLoad{
+		taskCount: e.shard.GetTaskCount(),
+		cacheSize: e.historyCache.Size(),
+	}
+}
+
+// getQueueBacklogAges returns the age of the oldest unacked task by task queue of the shard owned by this engine
+func (e *historyEngineImpl) getQueueBacklogAges() map[string]time.Duration {
+	return e.shard.GetQueueBacklogAges()
+}
+
+// getShardAckLevels returns the ack levels of the task queues of the shard owned by this engine
+func (e *historyEngineImpl) getShardAckLevels() shardAckLevels {
+	levels := shardAckLevels{
+		transferMaxReadLevel: e.shard.GetTransferMaxReadLevel(),
+		transferAckLevel:     e.shard.GetTransferAckLevel(),
+		timerAckLevel:        e.shard.GetTimerAckLevel(),
+	}
+	if e.shard.GetService().GetClusterMetadata().IsGlobalDomainEnabled() {
+		levels.replicationEnabled = true
+		levels.replicatorAckLevel = e.shard.GetReplicatorAckLevel()
+	}
+	return levels
+}
+
+// getRecentShardOperations returns up to maximum of the most recent operations recorded by the shard owned by this
+// engine, most recent first
+func (e *historyEngineImpl) getRecentShardOperations(maximum int) []ShardOperation {
+	return e.shard.GetRecentOperations(maximum)
+}
+
 func (e *historyEngineImpl) registerDomainFailoverCallback() {
 
 	failoverPredicate := func(nextDomain *cache.DomainCacheEntry, action func()) {
@@ -222,7 +293,7 @@ func (e *historyEngineImpl) registerDomainFailoverCallback() {
 					e.shard.GetShardID(), nextDomain.GetInfo().Name, nextDomain.GetInfo().ID)
 
 				domainID := nextDomain.GetInfo().ID
-				e.txProcessor.FailoverDomain(domainID)
+				e.txProcessor.FailoverDomain(domainID, nextDomain.GetFailoverVersion())
 				e.timerProcessor.FailoverDomain(domainID)
 			})
 		},
@@ -279,7 +350,7 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 	// Generate first decision task event.
 	taskList := request.TaskList.GetName()
 	// TODO when the workflow is going to be replicated, use the
-	var msBuilder mutableState
+	var msBuilder MutableState
 	if e.shard.GetService().GetClusterMetadata().IsGlobalDomainEnabled() && domainEntry.IsGlobalDomain() {
 		// all workflows within a global domain should have replication state, no matter whether it will be replicated to multiple
 		// target clusters or not
@@ -287,15 +358,23 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 	} else {
 		msBuilder = newMutableStateBuilder(e.shard.GetConfig(), e.logger)
 	}
+	msBuilder.SetHistoryEncodingFn(domainEntry.GetHistoryEncoding)
 	startedEvent := msBuilder.AddWorkflowExecutionStartedEvent(execution, startRequest)
 	if startedEvent == nil {
 		return nil, &workflow.InternalServiceError{Message: "Failed to add workflow execution started event."}
 	}
 
+	duration := time.Duration(*request.ExecutionStartToCloseTimeoutSeconds) * time.Second
+	timerTasks := []persistence.Task{&persistence.WorkflowTimeoutTask{
+		VisibilityTimestamp: e.shard.GetTimeSource().Now().Add(duration),
+	}}
+
 	var transferTasks []persistence.Task
+	var eagerDecision *DecisionInfo
 	decisionVersion := common.EmptyVersion
 	decisionScheduleID := common.EmptyEventID
 	decisionStartID := common.EmptyEventID
+	decisionRequestID := ""
 	decisionTimeout := int32(0)
 	if parentInfo == nil {
 		// DecisionTask is only created when it is not a Child Workflow Execution
@@ -304,19 +383,34 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 			return nil, &workflow.InternalServiceError{Message: "Failed to add decision scheduled event."}
 		}
 
+		if e.shouldExecuteDecisionEagerly(domainEntry.GetInfo().Name, request) {
+			// Start the decision right away, it is returned to the caller instead of being dispatched by matching
+			_, di = msBuilder.AddDecisionTaskStartedEvent(di.ScheduleID, uuid.New(), &workflow.PollForDecisionTaskRequest{
+				TaskList: request.TaskList,
+				Identity: request.Identity,
+			})
+			if di == nil {
+				return nil, &workflow.InternalServiceError{Message: "Failed to add decision started event."}
+			}
+			tBuilder := e.getTimerBuilder(&execution)
+			timerTasks = append(timerTasks, tBuilder.AddStartToCloseDecisionTimoutTask(di.ScheduleID, di.Attempt,
+				di.DecisionTimeout))
+			e.metricsClient.IncCounter(metrics.HistoryStartWorkflowExecutionScope, metrics.DecisionEagerExecutionCounter)
+			eagerDecision = di
+		}
+
+		// An eager decision is not dispatched through matching, but its transfer task still records the run in the
+		// open visibility
 		transferTasks = []persistence.Task{&persistence.DecisionTask{
 			DomainID: domainID, TaskList: taskList, ScheduleID: di.ScheduleID,
 		}}
 		decisionVersion = di.Version
 		decisionScheduleID = di.ScheduleID
 		decisionStartID = di.StartedID
+		decisionRequestID = di.RequestID
 		decisionTimeout = di.DecisionTimeout
 	}
 
-	duration := time.Duration(*request.ExecutionStartToCloseTimeoutSeconds) * time.Second
-	timerTasks := []persistence.Task{&persistence.WorkflowTimeoutTask{
-		VisibilityTimestamp: e.shard.GetTimeSource().Now().Add(duration),
-	}}
 	// Serialize the history
 	serializedHistory, serializedError := msBuilder.GetHistoryBuilder().Serialize()
 	if serializedError != nil {
@@ -339,6 +433,7 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 		return nil, err
 	}
 	msBuilder.GetExecutionInfo().LastFirstEventID = startedEvent.GetEventId()
+	msBuilder.GetExecutionInfo().HistorySize = int64(len(serializedHistory.Data))
 
 	var replicationState *persistence.ReplicationState
 	var replicationTasks []persistence.Task
@@ -352,6 +447,7 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 				NextEventID:         msBuilder.GetNextEventID(),
 				Version:             msBuilder.GetCurrentVersion(),
 				LastReplicationInfo: nil,
+				HistorySize:         msBuilder.GetExecutionInfo().HistorySize,
 			}
 			replicationTasks = append(replicationTasks, replicationTask)
 		}
@@ -378,11 +474,16 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 			DecisionVersion:             decisionVersion,
 			DecisionScheduleID:          decisionScheduleID,
 			DecisionStartedID:           decisionStartID,
+			DecisionRequestID:           decisionRequestID,
 			DecisionStartToCloseTimeout: decisionTimeout,
 			TimerTasks:                  timerTasks,
 			ContinueAsNew:               !isBrandNew,
 			PreviousRunID:               prevRunID,
 			ReplicationState:            replicationState,
+			Tags:                        request.Tags,
+			HistorySize:                 msBuilder.GetExecutionInfo().HistorySize,
+			Priority:                    msBuilder.GetExecutionInfo().Priority,
+			FirstRunID:                  msBuilder.GetExecutionInfo().FirstRunID,
 		})
 
 		if err != nil {
@@ -413,6 +514,7 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 				Message:        common.StringPtr(msg),
 				StartRequestId: common.StringPtr(fmt.Sprintf("%v", createRequestID)),
 				RunId:          common.StringPtr(fmt.Sprintf("%v", runID)),
+				CloseStatus:    getAlreadyStartedCloseStatus(err),
 			}
 		}
 
@@ -459,13 +561,57 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 	if err == nil {
 		e.timerProcessor.NotifyNewTimers(e.currentClusterName, e.shard.GetCurrentTime(e.currentClusterName), timerTasks)
 
-		return &workflow.StartWorkflowExecutionResponse{
+		response := &workflow.StartWorkflowExecutionResponse{
 			RunId: common.StringPtr(resultRunID),
-		}, nil
+		}
+		// A duplicate request resolves to the run created by the first one, whose decision is not ours to return
+		if eagerDecision != nil && resultRunID == execution.GetRunId() {
+			response.DecisionTask = e.createEagerDecisionTask(domainID, execution, msBuilder, eagerDecision)
+		}
+		return response, nil
 	}
 	return nil, err
 }
 
+func (e *historyEngineImpl) shouldExecuteDecisionEagerly(domainName string,
+	request *workflow.StartWorkflowExecutionRequest) bool {
+
+	if !request.GetRequestEagerExecution() {
+		return false
+	}
+	return e.shard.GetConfig().EnableDecisionEagerExecution(domainName, request.TaskList.GetName(),
+		persistence.TaskListTypeDecision)
+}
+
+// createEagerDecisionTask builds the poll response for the first decision of a run, which was started along with the
+// run and carries its whole history.
+func (e *historyEngineImpl) createEagerDecisionTask(domainID string, execution workflow.WorkflowExecution,
+	msBuilder MutableState, di *DecisionInfo) *workflow.PollForDecisionTaskResponse {
+
+	token, err := e.tokenSerializer.Serialize(&common.TaskToken{
+		DomainID:        domainID,
+		WorkflowID:      execution.GetWorkflowId(),
+		RunID:           execution.GetRunId(),
+		ScheduleID:      di.ScheduleID,
+		ScheduleAttempt: di.Attempt,
+	})
+	if err != nil {
+		// The decision times out and is rescheduled through matching
+		e.logger.Warnf("Failed to serialize eager decision task token. WorkflowID: %v, RunID: %v, Error: %v",
+			execution.GetWorkflowId(), execution.GetRunId(), err)
+		return nil
+	}
+
+	return &workflow.PollForDecisionTaskResponse{
+		TaskToken:         token,
+		WorkflowExecution: &execution,
+		WorkflowType:      msBuilder.GetWorkflowType(),
+		StartedEventId:    common.Int64Ptr(di.StartedID),
+		Attempt:           common.Int64Ptr(di.Attempt),
+		History:           &workflow.History{Events: msBuilder.GetHistoryBuilder().history},
+	}
+}
+
 // GetMutableState retrieves the mutable state of the workflow execution
 func (e *historyEngineImpl) GetMutableState(ctx context.Context,
 	request *h.GetMutableStateRequest) (*h.GetMutableStateResponse, error) {
@@ -541,7 +687,7 @@ func (e *historyEngineImpl) GetMutableState(ctx context.Context,
 func (e *historyEngineImpl) getMutableState(ctx context.Context,
 	domainID string, execution workflow.WorkflowExecution) (retResp *h.GetMutableStateResponse, retError error) {
 
-	context, release, retError := e.historyCache.getOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	context, release, retError := e.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
 	if retError != nil {
 		return
 	}
@@ -584,7 +730,7 @@ func (e *historyEngineImpl) DescribeMutableState(ctx context.Context,
 		RunId:      request.Execution.RunId,
 	}
 
-	cacheCtx, dbCtx, release, cacheHit, err := e.historyCache.getAndCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	cacheCtx, dbCtx, release, cacheHit, err := e.historyCache.GetAndCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
 	if err != nil {
 		return nil, err
 	}
@@ -602,7 +748,7 @@ func (e *historyEngineImpl) DescribeMutableState(ctx context.Context,
 	return
 }
 
-func (e *historyEngineImpl) toMutableStateJSON(msb mutableState) (*string, error) {
+func (e *historyEngineImpl) toMutableStateJSON(msb MutableState) (*string, error) {
 	ms := msb.CopyToPersistence()
 
 	jsonBytes, err := json.Marshal(ms)
@@ -625,12 +771,14 @@ func (e *historyEngineImpl) ResetStickyTaskList(ctx context.Context, resetReques
 		return nil, err
 	}
 
+	var stickyInvalidationCount int64
 	err = e.updateWorkflowExecution(ctx, domainID, *resetRequest.Execution, false, false,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+		func(msBuilder MutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
+				stickyInvalidationCount = msBuilder.GetExecutionInfo().StickyInvalidationCount
 				return nil, nil
 			}
-			msBuilder.ClearStickyness()
+			stickyInvalidationCount = msBuilder.InvalidateStickyness()
 			return nil, nil
 		},
 	)
@@ -638,7 +786,9 @@ func (e *historyEngineImpl) ResetStickyTaskList(ctx context.Context, resetReques
 	if err != nil {
 		return nil, err
 	}
-	return &h.ResetStickyTaskListResponse{}, nil
+	return &h.ResetStickyTaskListResponse{
+		StickyInvalidationCount: common.Int64Ptr(stickyInvalidationCount),
+	}, nil
 }
 
 // DescribeWorkflowExecution returns information about the specified workflow execution.
@@ -651,7 +801,7 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(ctx context.Context,
 
 	execution := *request.Request.Execution
 
-	context, release, err0 := e.historyCache.getOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	context, release, err0 := e.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
 	if err0 != nil {
 		return nil, err0
 	}
@@ -675,7 +825,20 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(ctx context.Context,
 			Type:          &workflow.WorkflowType{Name: common.StringPtr(executionInfo.WorkflowTypeName)},
 			StartTime:     common.Int64Ptr(executionInfo.StartTimestamp.UnixNano()),
 			HistoryLength: common.Int64Ptr(msBuilder.GetNextEventID() - common.FirstEventID),
+			Tags:          executionInfo.Tags,
 		},
+		ExecutionStats: &workflow.WorkflowExecutionStats{
+			HistorySize:        common.Int64Ptr(executionInfo.HistorySize),
+			HistoryEventsCount: common.Int64Ptr(msBuilder.GetNextEventID() - common.FirstEventID),
+		},
+	}
+	// executions persisted before the first run ID was tracked don't have it
+	if executionInfo.FirstRunID != "" {
+		result.WorkflowExecutionInfo.FirstRunId = common.StringPtr(executionInfo.FirstRunID)
+	}
+	// the mutable state size is estimated from its encoded form, the same one returned by DescribeMutableState
+	if mutableStateJSON, err := e.toMutableStateJSON(msBuilder); err == nil {
+		result.ExecutionStats.MutableStateSize = common.Int64Ptr(int64(len(*mutableStateJSON)))
 	}
 	if executionInfo.State == persistence.WorkflowStateCompleted {
 		// for closed workflow
@@ -720,7 +883,7 @@ func (e *historyEngineImpl) RecordDecisionTaskStarted(ctx context.Context,
 	}
 	domainID := domainEntry.GetInfo().ID
 
-	context, release, err0 := e.historyCache.getOrCreateWorkflowExecutionWithTimeout(ctx, domainID, *request.WorkflowExecution)
+	context, release, err0 := e.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx, domainID, *request.WorkflowExecution)
 	if err0 != nil {
 		return nil, err0
 	}
@@ -779,8 +942,9 @@ Update_History_Loop:
 			return nil, &workflow.InternalServiceError{Message: "Unable to add DecisionTaskStarted event to history."}
 		}
 
-		// Start a timer for the decision task.
-		timeOutTask := tBuilder.AddStartToCloseDecisionTimoutTask(di.ScheduleID, di.Attempt, di.DecisionTimeout)
+		// Start a timer for the decision task, workers need more time to replay large history.
+		decisionTimeout := e.getScaledDecisionTimeout(domainEntry.GetInfo().Name, msBuilder.GetNextEventID(), di.DecisionTimeout)
+		timeOutTask := tBuilder.AddStartToCloseDecisionTimoutTask(di.ScheduleID, di.Attempt, decisionTimeout)
 		timerTasks := []persistence.Task{timeOutTask}
 		defer e.timerProcessor.NotifyNewTimers(e.currentClusterName, e.shard.GetCurrentTime(e.currentClusterName), timerTasks)
 
@@ -807,6 +971,88 @@ Update_History_Loop:
 	return nil, ErrMaxAttemptsExceeded
 }
 
+// getScaledDecisionTimeout extends the decision start to close timeout by the time needed to replay the history
+// of the given size, bounded by the domain's max scaled timeout. The timeout is never shortened.
+func (e *historyEngineImpl) getScaledDecisionTimeout(domainName string, nextEventID int64, timeout int32) int32 {
+	config := e.shard.GetConfig()
+	eventsPerSecond := config.DecisionTimeoutScaleEventsPerSecond(domainName)
+	if eventsPerSecond <= 0 {
+		return timeout
+	}
+
+	scaledTimeout := int64(timeout) + (nextEventID-common.FirstEventID)/int64(eventsPerSecond)
+	maxTimeout := int64(config.MaxScaledDecisionStartToCloseTimeout(domainName).Seconds())
+	if scaledTimeout > maxTimeout {
+		scaledTimeout = maxTimeout
+	}
+	if scaledTimeout <= int64(timeout) {
+		return timeout
+	}
+
+	e.metricsClient.IncCounter(metrics.HistoryRecordDecisionTaskStartedScope, metrics.DecisionTimeoutScaledCounter)
+	return int32(scaledTimeout)
+}
+
+// isContinueAsNewSuggested tells whether the history of the workflow grew past the soft limits of its domain, so the
+// worker can continue the workflow as new before the history gets any larger.
+func (e *historyEngineImpl) isContinueAsNewSuggested(domainID string, msBuilder MutableState) bool {
+	domainEntry, err := e.shard.GetDomainCache().GetDomainByID(domainID)
+	if err != nil {
+		return false
+	}
+	domainName := domainEntry.GetInfo().Name
+	config := e.shard.GetConfig()
+
+	if sizeLimit := config.ContinueAsNewSuggestedHistorySize(domainName); sizeLimit > 0 &&
+		msBuilder.GetExecutionInfo().HistorySize >= int64(sizeLimit) {
+		return true
+	}
+	if countLimit := config.ContinueAsNewSuggestedHistoryCount(domainName); countLimit > 0 &&
+		msBuilder.GetNextEventID()-common.FirstEventID >= int64(countLimit) {
+		return true
+	}
+	return false
+}
+
+// capActivityScheduleToStartTimeout bounds the schedule to start timeout of the activity by the cap configured for
+// its task list. Long queue timeouts hide worker outages and keep timers around, so capping is logged as a warning.
+func (e *historyEngineImpl) capActivityScheduleToStartTimeout(domainName string,
+	attributes *workflow.ScheduleActivityTaskDecisionAttributes) {
+
+	taskList := attributes.TaskList.GetName()
+	maxTimeout := int32(e.shard.GetConfig().MaxActivityScheduleToStartTimeout(domainName, taskList,
+		persistence.TaskListTypeActivity).Seconds())
+	if maxTimeout <= 0 || attributes.GetScheduleToStartTimeoutSeconds() <= maxTimeout {
+		return
+	}
+
+	e.logger.Warnf("Capping ScheduleToStartTimeout of activity %v from %v to %v seconds. Domain: %v, TaskList: %v.",
+		attributes.GetActivityId(), attributes.GetScheduleToStartTimeoutSeconds(), maxTimeout, domainName, taskList)
+	e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope,
+		metrics.ActivityScheduleToStartTimeoutCappedCounter)
+	attributes.ScheduleToStartTimeoutSeconds = common.Int32Ptr(maxTimeout)
+}
+
+// shouldExecuteActivityEagerly tells whether an activity scheduled by a decision is started right away and returned to
+// the worker completing the decision instead of being dispatched through matching. Only activities on the task list
+// of the workflow qualify, as the worker completing the decision is known to poll it.
+func (e *historyEngineImpl) shouldExecuteActivityEagerly(domainName string, executionInfo *persistence.WorkflowExecutionInfo,
+	attributes *workflow.ScheduleActivityTaskDecisionAttributes, eagerCount int) bool {
+
+	if !attributes.GetRequestEagerExecution() {
+		return false
+	}
+	taskList := attributes.TaskList.GetName()
+	if taskList != executionInfo.TaskList {
+		return false
+	}
+	config := e.shard.GetConfig()
+	if eagerCount >= config.MaxActivityEagerExecutionsPerDecision(domainName) {
+		return false
+	}
+	return config.EnableActivityEagerExecution(domainName, taskList, persistence.TaskListTypeActivity)
+}
+
 func (e *historyEngineImpl) RecordActivityTaskStarted(ctx context.Context,
 	request *h.RecordActivityTaskStartedRequest) (*h.RecordActivityTaskStartedResponse, error) {
 
@@ -823,7 +1069,7 @@ func (e *historyEngineImpl) RecordActivityTaskStarted(ctx context.Context,
 
 	response := &h.RecordActivityTaskStartedResponse{}
 	err = e.updateWorkflowExecution(ctx, domainID, execution, false, false,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+		func(msBuilder MutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
@@ -919,7 +1165,7 @@ func (e *historyEngineImpl) RespondDecisionTaskCompleted(ctx context.Context, re
 	clientFeatureVersion := call.Header(common.FeatureVersionHeaderName)
 	clientImpl := call.Header(common.ClientImplHeaderName)
 
-	context, release, err0 := e.historyCache.getOrCreateWorkflowExecutionWithTimeout(ctx, domainID, workflowExecution)
+	context, release, err0 := e.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx, domainID, workflowExecution)
 	if err0 != nil {
 		return nil, err0
 	}
@@ -965,18 +1211,25 @@ Update_History_Loop:
 		isComplete := false
 		transferTasks := []persistence.Task{}
 		timerTasks := []persistence.Task{}
-		var continueAsNewBuilder mutableState
+		var continueAsNewBuilder MutableState
 		var continueAsNewTimerTasks []persistence.Task
 		hasDecisionScheduleActivityTask := false
+		var eagerActivities []*persistence.ActivityInfo
 
-		if request.StickyAttributes == nil || request.StickyAttributes.WorkerTaskList == nil {
+		stickyAttributes := request.StickyAttributes
+		if stickyAttributes != nil && domainEntry.IsBinaryChecksumSuspect(request.GetBinaryChecksum()) {
+			// the workers of the binary fail decisions with non-determinism errors, do not keep the workflow on them
+			e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.CompleteDecisionWithSuspectBinaryCounter)
+			stickyAttributes = nil
+		}
+		if stickyAttributes == nil || stickyAttributes.WorkerTaskList == nil {
 			e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.CompleteDecisionWithStickyDisabledCounter)
 			executionInfo.StickyTaskList = ""
 			executionInfo.StickyScheduleToStartTimeout = 0
 		} else {
 			e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.CompleteDecisionWithStickyEnabledCounter)
-			executionInfo.StickyTaskList = request.StickyAttributes.WorkerTaskList.GetName()
-			executionInfo.StickyScheduleToStartTimeout = request.StickyAttributes.GetScheduleToStartTimeoutSeconds()
+			executionInfo.StickyTaskList = stickyAttributes.WorkerTaskList.GetName()
+			executionInfo.StickyScheduleToStartTimeout = stickyAttributes.GetScheduleToStartTimeoutSeconds()
 		}
 		executionInfo.ClientLibraryVersion = clientLibVersion
 		executionInfo.ClientFeatureVersion = clientFeatureVersion
@@ -1005,13 +1258,24 @@ Update_History_Loop:
 					failCause = workflow.DecisionTaskFailedCauseBadScheduleActivityAttributes
 					break Process_Decision_Loop
 				}
+				targetDomainName := domainEntry.GetInfo().Name
+				if attributes.Domain != nil {
+					targetDomainName = attributes.GetDomain()
+				}
+				e.capActivityScheduleToStartTimeout(targetDomainName, attributes)
 
-				scheduleEvent, _ := msBuilder.AddActivityTaskScheduledEvent(completedID, attributes)
-				transferTasks = append(transferTasks, &persistence.ActivityTask{
-					DomainID:   targetDomainID,
-					TaskList:   *attributes.TaskList.Name,
-					ScheduleID: *scheduleEvent.EventId,
-				})
+				scheduleEvent, ai := msBuilder.AddActivityTaskScheduledEvent(completedID, attributes)
+				if targetDomainID == domainID &&
+					e.shouldExecuteActivityEagerly(targetDomainName, executionInfo, attributes, len(eagerActivities)) {
+					// started once the decision is known to complete, see below
+					eagerActivities = append(eagerActivities, ai)
+				} else {
+					transferTasks = append(transferTasks, &persistence.ActivityTask{
+						DomainID:   targetDomainID,
+						TaskList:   *attributes.TaskList.Name,
+						ScheduleID: *scheduleEvent.EventId,
+					})
+				}
 				hasDecisionScheduleActivityTask = true
 
 			case workflow.DecisionTypeCompleteWorkflowExecution:
@@ -1158,6 +1422,19 @@ Update_History_Loop:
 				}
 				msBuilder.AddRecordMarkerEvent(completedID, attributes)
 
+			case workflow.DecisionTypeCompleteWorkflowUpdate:
+				e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope,
+					metrics.DecisionTypeCompleteWorkflowUpdateCounter)
+				attributes := d.CompleteWorkflowUpdateDecisionAttributes
+				if err = validateCompleteWorkflowUpdateAttributes(attributes, msBuilder); err != nil {
+					failDecision = true
+					failCause = workflow.DecisionTaskFailedCauseBadCompleteWorkflowUpdateAttributes
+					break Process_Decision_Loop
+				}
+				if msBuilder.AddWorkflowExecutionUpdateCompletedEvent(completedID, attributes) == nil {
+					return nil, &workflow.InternalServiceError{Message: "Unable to add workflow update completed event."}
+				}
+
 			case workflow.DecisionTypeRequestCancelExternalWorkflowExecution:
 				e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope,
 					metrics.DecisionTypeCancelExternalWorkflowCounter)
@@ -1332,7 +1609,25 @@ Update_History_Loop:
 			isComplete = false
 			hasUnhandledEvents = true
 			continueAsNewBuilder = nil
+			// the activities were dropped along with the rest of the decision
+			eagerActivities = nil
+		}
+
+		if isComplete || continueAsNewBuilder != nil {
+			// the run is closing, no worker is going to report back on these, leave them to matching as before
+			for _, ai := range eagerActivities {
+				transferTasks = append(transferTasks, &persistence.ActivityTask{
+					DomainID:   domainID,
+					TaskList:   ai.TaskList,
+					ScheduleID: ai.ScheduleID,
+				})
+			}
+			eagerActivities = nil
 		}
+		var eagerTransferTasks []persistence.Task
+		eagerActivities, eagerTransferTasks = e.startEagerActivities(msBuilder, domainID, eagerActivities,
+			request.GetIdentity())
+		transferTasks = append(transferTasks, eagerTransferTasks...)
 
 		if tt := tBuilder.GetUserTimerTaskIfNeeded(msBuilder); tt != nil {
 			timerTasks = append(timerTasks, tt)
@@ -1421,6 +1716,20 @@ Update_History_Loop:
 			// sticky is always enabled when worker request for new decision task from RespondDecisionTaskCompleted
 			response.StartedResponse.StickyExecutionEnabled = common.BoolPtr(true)
 		}
+		for _, ai := range eagerActivities {
+			scheduledEvent, ok := msBuilder.GetActivityScheduledEvent(ai.ScheduleID)
+			if !ok {
+				// the activity times out on its start to close timer and is retried or failed from there
+				continue
+			}
+			response.ActivitiesToDispatchLocally = append(response.ActivitiesToDispatchLocally,
+				&h.RecordActivityTaskStartedResponse{
+					ScheduledEvent:                  scheduledEvent,
+					StartedTimestamp:                common.Int64Ptr(ai.StartedTime.UnixNano()),
+					Attempt:                         common.Int64Ptr(int64(ai.Attempt)),
+					ScheduledTimestampOfThisAttempt: common.Int64Ptr(ai.ScheduledTime.UnixNano()),
+				})
+		}
 
 		return response, nil
 	}
@@ -1428,6 +1737,29 @@ Update_History_Loop:
 	return nil, ErrMaxAttemptsExceeded
 }
 
+// startEagerActivities starts the activities to be returned to the worker completing the decision, the ones which
+// fail to start are left to matching with an activity transfer task
+func (e *historyEngineImpl) startEagerActivities(msBuilder MutableState, domainID string,
+	eagerActivities []*persistence.ActivityInfo, identity string) ([]*persistence.ActivityInfo, []persistence.Task) {
+	var started []*persistence.ActivityInfo
+	var transferTasks []persistence.Task
+	for _, ai := range eagerActivities {
+		event := msBuilder.AddActivityTaskStartedEvent(ai, ai.ScheduleID, uuid.New(), identity)
+		// an activity with a retry policy records its started event only once it is closed
+		if event == nil && !ai.HasRetryPolicy {
+			transferTasks = append(transferTasks, &persistence.ActivityTask{
+				DomainID:   domainID,
+				TaskList:   ai.TaskList,
+				ScheduleID: ai.ScheduleID,
+			})
+			continue
+		}
+		e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.ActivityEagerExecutionCounter)
+		started = append(started, ai)
+	}
+	return started, transferTasks
+}
+
 func (e *historyEngineImpl) RespondDecisionTaskFailed(ctx context.Context, req *h.RespondDecisionTaskFailedRequest) error {
 
 	domainEntry, err := e.getActiveDomainEntry(req.DomainUUID)
@@ -1447,8 +1779,10 @@ func (e *historyEngineImpl) RespondDecisionTaskFailed(ctx context.Context, req *
 		RunId:      common.StringPtr(token.RunID),
 	}
 
-	return e.updateWorkflowExecution(ctx, domainID, workflowExecution, false, true,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+	// failing the decision clears the stickiness, so whether it was sticky is captured before
+	stickyNonDeterministicFailure := false
+	err = e.updateWorkflowExecution(ctx, domainID, workflowExecution, false, true,
+		func(msBuilder MutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
@@ -1459,11 +1793,17 @@ func (e *historyEngineImpl) RespondDecisionTaskFailed(ctx context.Context, req *
 				return nil, &workflow.EntityNotExistsError{Message: "Decision task not found."}
 			}
 
+			stickyNonDeterministicFailure = msBuilder.IsStickyTaskListEnabled() &&
+				request.GetCause() == workflow.DecisionTaskFailedCauseNonDeterministicError
 			msBuilder.AddDecisionTaskFailedEvent(di.ScheduleID, di.StartedID, request.GetCause(), request.Details,
 				request.GetIdentity())
 
 			return nil, nil
 		})
+	if err == nil && stickyNonDeterministicFailure && e.stickyPoisonDetector != nil {
+		e.stickyPoisonDetector.recordFailure(domainEntry, request.GetBinaryChecksum())
+	}
+	return err
 }
 
 // RespondActivityTaskCompleted completes an activity task.
@@ -1487,7 +1827,7 @@ func (e *historyEngineImpl) RespondActivityTaskCompleted(ctx context.Context, re
 	}
 
 	return e.updateWorkflowExecution(ctx, domainID, workflowExecution, false, true,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+		func(msBuilder MutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
@@ -1543,7 +1883,7 @@ func (e *historyEngineImpl) RespondActivityTaskFailed(ctx context.Context, req *
 	}
 
 	return e.updateWorkflowExecutionWithAction(ctx, domainID, workflowExecution,
-		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+		func(msBuilder MutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
@@ -1607,7 +1947,7 @@ func (e *historyEngineImpl) RespondActivityTaskCanceled(ctx context.Context, req
 	}
 
 	return e.updateWorkflowExecution(ctx, domainID, workflowExecution, false, true,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+		func(msBuilder MutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
@@ -1669,8 +2009,8 @@ func (e *historyEngineImpl) RecordActivityTaskHeartbeat(ctx context.Context,
 	}
 
 	var cancelRequested bool
-	err = e.updateWorkflowExecution(ctx, domainID, workflowExecution, false, false,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+	err = e.updateWorkflowExecutionWithAction(ctx, domainID, workflowExecution,
+		func(msBuilder MutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				e.logger.Errorf("Heartbeat failed ")
 				return nil, ErrWorkflowCompleted
@@ -1707,7 +2047,13 @@ func (e *historyEngineImpl) RecordActivityTaskHeartbeat(ctx context.Context,
 			// Save progress and last HB reported time.
 			msBuilder.UpdateActivityProgress(ai, request)
 
-			return nil, nil
+			// Heartbeats are written behind, cancel requests are served from the cached mutable state in between
+			interval := e.getActivityHeartbeatPersistInterval(domainEntry.GetInfo().Name, ai)
+			skipPersistence := ai.LastHeartBeatUpdatedTime.Sub(msBuilder.GetPersistedActivityHeartbeat(scheduleID)) < interval
+			if skipPersistence {
+				e.metricsClient.IncCounter(metrics.HistoryRecordActivityTaskHeartbeatScope, metrics.ActivityHeartbeatDeferredCounter)
+			}
+			return &updateWorkflowAction{skipPersistence: skipPersistence}, nil
 		})
 
 	if err != nil {
@@ -1717,6 +2063,19 @@ func (e *historyEngineImpl) RecordActivityTaskHeartbeat(ctx context.Context,
 	return &workflow.RecordActivityTaskHeartbeatResponse{CancelRequested: common.BoolPtr(cancelRequested)}, nil
 }
 
+// getActivityHeartbeatPersistInterval returns the min interval between writes of the heartbeats of the activity.
+// The cached mutable state holding the heartbeats in between can be evicted at any time, so the interval is kept
+// under the heartbeat timeout for a reload not to time the activity out.
+func (e *historyEngineImpl) getActivityHeartbeatPersistInterval(domain string, ai *persistence.ActivityInfo) time.Duration {
+	interval := e.shard.GetConfig().ActivityHeartbeatPersistInterval(domain)
+	if ai.HeartbeatTimeout > 0 {
+		if maxInterval := time.Duration(ai.HeartbeatTimeout) * time.Second / 2; interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+	return interval
+}
+
 // RequestCancelWorkflowExecution records request cancellation event for workflow execution
 func (e *historyEngineImpl) RequestCancelWorkflowExecution(ctx context.Context,
 	req *h.RequestCancelWorkflowExecutionRequest) error {
@@ -1736,7 +2095,7 @@ func (e *historyEngineImpl) RequestCancelWorkflowExecution(ctx context.Context,
 	}
 
 	return e.updateWorkflowExecution(ctx, domainID, execution, false, true,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+		func(msBuilder MutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
@@ -1789,9 +2148,11 @@ func (e *historyEngineImpl) SignalWorkflowExecution(ctx context.Context, signalR
 		RunId:      request.WorkflowExecution.RunId,
 	}
 
-	return e.updateWorkflowExecution(ctx, domainID, execution, false, true,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+	var closedExecutionInfo *persistence.WorkflowExecutionInfo
+	err = e.updateWorkflowExecution(ctx, domainID, execution, false, true,
+		func(msBuilder MutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
+				closedExecutionInfo = msBuilder.GetExecutionInfo()
 				return nil, ErrWorkflowCompleted
 			}
 
@@ -1819,6 +2180,281 @@ func (e *historyEngineImpl) SignalWorkflowExecution(ctx context.Context, signalR
 
 			return nil, nil
 		})
+	if err != ErrWorkflowCompleted || closedExecutionInfo == nil {
+		return err
+	}
+
+	switch e.shard.GetConfig().SignalAfterClosePolicy(domainEntry.GetInfo().Name) {
+	case signalAfterClosePolicyDrop:
+		e.metricsClient.IncCounter(metrics.HistorySignalWorkflowExecutionScope, metrics.SignalAfterCloseDroppedCounter)
+		return nil
+	case signalAfterClosePolicyStart:
+		// only a signal to the current run of a workflow starts a new one, a signal to an explicit run or
+		// from a parent workflow is meant for that run alone
+		if request.WorkflowExecution.GetRunId() != "" || childWorkflowOnly {
+			return err
+		}
+		_, err = e.SignalWithStartWorkflowExecution(ctx, &h.SignalWithStartWorkflowExecutionRequest{
+			DomainUUID:             common.StringPtr(domainID),
+			SignalWithStartRequest: getSignalWithStartRequest(domainEntry.GetInfo().Name, request, closedExecutionInfo),
+		})
+		if err == nil {
+			e.metricsClient.IncCounter(metrics.HistorySignalWorkflowExecutionScope, metrics.SignalAfterCloseStartedCounter)
+		}
+		return err
+	default:
+		return err
+	}
+}
+
+// UpdateWorkflowExecution records an update requested on a running workflow execution and schedules a decision for
+// it, then blocks until a CompleteWorkflowUpdate decision answers it or the context is done. A retry with the same
+// update ID does not request the update again, it waits on or returns the result of the original one.
+func (e *historyEngineImpl) UpdateWorkflowExecution(ctx context.Context,
+	updateRequest *h.UpdateWorkflowExecutionRequest) (*workflow.UpdateWorkflowExecutionResponse, error) {
+
+	domainEntry, err := e.getActiveDomainEntry(updateRequest.DomainUUID)
+	if err != nil {
+		return nil, err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	request := updateRequest.UpdateRequest
+	updateID := request.GetUpdateId()
+	if updateID == "" {
+		return nil, ErrUpdateIDNotSet
+	}
+	execution := workflow.WorkflowExecution{
+		WorkflowId: request.WorkflowExecution.WorkflowId,
+		RunId:      request.WorkflowExecution.RunId,
+	}
+	maxUpdates := e.shard.GetConfig().MaximumUpdatesPerExecution(domainEntry.GetInfo().Name)
+
+	var runID string
+	err = e.updateWorkflowExecutionWithAction(ctx, domainID, execution,
+		func(msBuilder MutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+			if !msBuilder.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+			runID = msBuilder.GetExecutionInfo().RunID
+
+			// deduplicate by update id, the caller waits on the update already requested without writing the
+			// execution or scheduling another decision
+			if _, ok := msBuilder.GetUpdateInfo(updateID); ok {
+				return &updateWorkflowAction{skipPersistence: true}, nil
+			}
+			if msBuilder.GetUpdateInfoCount() >= maxUpdates {
+				return nil, ErrUpdatesLimitExceeded
+			}
+
+			if msBuilder.AddWorkflowExecutionUpdateRequestedEvent(request) == nil {
+				return nil, &workflow.InternalServiceError{Message: "Unable to request workflow update."}
+			}
+
+			return &updateWorkflowAction{createDecision: true}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// pin the run the update was requested on, the watch below is per run
+	execution.RunId = common.StringPtr(runID)
+	identifier := newWorkflowIdentifier(domainID, &execution)
+	subscriberID, channel, err := e.historyEventNotifier.WatchHistoryEvent(identifier)
+	if err != nil {
+		return nil, err
+	}
+	defer e.historyEventNotifier.UnwatchHistoryEvent(identifier, subscriberID)
+
+	// the wait ends ahead of the deadline of the caller, so the caller gets the timeout as an error it can retry on
+	wait := e.shard.GetConfig().LongPollExpirationInterval(domainEntry.GetInfo().Name)
+	if deadline, ok := ctx.Deadline(); ok {
+		wait = deadline.Sub(time.Now()) - updateWorkflowExecutionReplyReserve
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		// checked after the watch is in place so a completion between the update and the watch is not missed
+		updateInfo, isRunning, err := e.getUpdateInfo(ctx, domainID, execution, updateID)
+		if err != nil {
+			return nil, err
+		}
+		if updateInfo.Completed {
+			return &workflow.UpdateWorkflowExecutionResponse{Result: updateInfo.Result}, nil
+		}
+		if !isRunning {
+			return nil, ErrWorkflowCompleted
+		}
+
+		select {
+		case <-channel:
+		case <-timer.C:
+			return nil, ErrUpdateTimedOut
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, ErrUpdateTimedOut
+			}
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (e *historyEngineImpl) getUpdateInfo(ctx context.Context, domainID string, execution workflow.WorkflowExecution,
+	updateID string) (retInfo *persistence.UpdateInfo, retIsRunning bool, retError error) {
+
+	context, release, retError := e.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	if retError != nil {
+		return
+	}
+	defer func() { release(retError) }()
+
+	msBuilder, retError := context.loadWorkflowExecution()
+	if retError != nil {
+		return
+	}
+
+	updateInfo, ok := msBuilder.GetUpdateInfo(updateID)
+	if !ok {
+		retError = &workflow.EntityNotExistsError{Message: "Workflow update not found."}
+		return
+	}
+
+	// copy as the info is owned by the cached mutable state
+	info := *updateInfo
+	return &info, msBuilder.IsWorkflowExecutionRunning(), nil
+}
+
+// UpdateWorkflowExecutionOptions changes options kept in mutable state of a running workflow execution.
+// The continue-as-new options take effect when the execution continues as new, so no history event is recorded for
+// them.  A change of the decision task list is recorded as a WorkflowExecutionTaskListChanged event, which replicates
+// the change to other clusters.  A decision scheduled but not started yet is timed out and scheduled again on the new
+// task list, a decision already started completes on the old one and the next decision is scheduled on the new one.
+func (e *historyEngineImpl) UpdateWorkflowExecutionOptions(ctx context.Context,
+	updateRequest *h.UpdateWorkflowExecutionOptionsRequest) error {
+
+	domainEntry, err := e.getActiveDomainEntry(updateRequest.DomainUUID)
+	if err != nil {
+		return err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	request := updateRequest.UpdateRequest
+	execution := workflow.WorkflowExecution{
+		WorkflowId: request.WorkflowExecution.WorkflowId,
+		RunId:      request.WorkflowExecution.RunId,
+	}
+
+	return e.updateWorkflowExecutionWithAction(ctx, domainID, execution,
+		func(msBuilder MutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+			if !msBuilder.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+			postActions := &updateWorkflowAction{}
+
+			if taskList := request.ContinueAsNewTaskList; taskList != nil {
+				msBuilder.GetExecutionInfo().ContinueAsNewTaskList = taskList.GetName()
+			}
+
+			if taskList := request.TaskList; taskList != nil && taskList.GetName() != msBuilder.GetExecutionInfo().TaskList {
+				if msBuilder.HasPendingDecisionTask() && !msBuilder.HasInFlightDecisionTask() {
+					// the scheduled decision is dispatched on the old task list, reschedule it on the new one
+					di, _ := msBuilder.GetPendingDecision(msBuilder.GetExecutionInfo().DecisionScheduleID)
+					if di.Attempt > 0 {
+						// a transient decision has no scheduled event in history
+						msBuilder.FailDecision()
+					} else if msBuilder.AddDecisionTaskScheduleToStartTimeoutEvent(di.ScheduleID) == nil {
+						return nil, &workflow.InternalServiceError{Message: "Unable to add DecisionTaskScheduleToStartTimeout event to history."}
+					}
+					postActions.createDecision = true
+				}
+				if msBuilder.AddWorkflowExecutionTaskListChangedEvent(taskList, request.GetIdentity()) == nil {
+					return nil, &workflow.InternalServiceError{Message: "Unable to add WorkflowExecutionTaskListChanged event to history."}
+				}
+			}
+
+			return postActions, nil
+		})
+}
+
+// DeleteWorkflowExecution force deletes a workflow execution run.  The records are deleted from the least to the most
+// authoritative one: visibility records, the current execution pointer, history and the mutable state last, so a
+// deletion failing half way can simply be retried as the mutable state it starts from is still there.
+func (e *historyEngineImpl) DeleteWorkflowExecution(ctx context.Context,
+	deleteRequest *h.DeleteWorkflowExecutionRequest) (retError error) {
+
+	domainID, err := validateDomainUUID(deleteRequest.DomainUUID)
+	if err != nil {
+		return err
+	}
+	if deleteRequest.Execution == nil {
+		return &workflow.BadRequestError{Message: "Execution is not set on request."}
+	}
+
+	execution := workflow.WorkflowExecution{
+		WorkflowId: deleteRequest.Execution.WorkflowId,
+		RunId:      deleteRequest.Execution.RunId,
+	}
+
+	context, release, err0 := e.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	if err0 != nil {
+		return err0
+	}
+	defer func() { release(retError) }()
+
+	msBuilder, err1 := context.loadWorkflowExecution()
+	if err1 != nil {
+		return err1
+	}
+	if msBuilder.IsWorkflowExecutionRunning() && !deleteRequest.GetForce() {
+		return &workflow.BadRequestError{Message: "Workflow execution is still running, set force to delete it."}
+	}
+
+	executionInfo := msBuilder.GetExecutionInfo()
+	execution.RunId = common.StringPtr(executionInfo.RunID)
+	ops := []func() error{
+		func() error {
+			return e.visibilityMgr.DeleteWorkflowExecution(&persistence.VisibilityDeleteWorkflowExecutionRequest{
+				DomainUUID:     domainID,
+				WorkflowID:     executionInfo.WorkflowID,
+				RunID:          executionInfo.RunID,
+				StartTimestamp: executionInfo.StartTimestamp.UnixNano(),
+			})
+		},
+		func() error {
+			return e.executionManager.DeleteCurrentWorkflowExecution(&persistence.DeleteCurrentWorkflowExecutionRequest{
+				DomainID:   domainID,
+				WorkflowID: executionInfo.WorkflowID,
+				RunID:      executionInfo.RunID,
+			})
+		},
+		func() error {
+			return e.historyMgr.DeleteWorkflowExecutionHistory(&persistence.DeleteWorkflowExecutionHistoryRequest{
+				DomainID:  domainID,
+				Execution: execution,
+			})
+		},
+		func() error {
+			return e.executionManager.DeleteWorkflowExecution(&persistence.DeleteWorkflowExecutionRequest{
+				DomainID:   domainID,
+				WorkflowID: executionInfo.WorkflowID,
+				RunID:      executionInfo.RunID,
+			})
+		},
+	}
+	for _, op := range ops {
+		if err := backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError); err != nil {
+			return err
+		}
+	}
+
+	// the run is gone, make sure the cached mutable state is not used anymore
+	context.clear()
+	e.logger.WithFields(bark.Fields{
+		logging.TagDomainID:            domainID,
+		logging.TagWorkflowExecutionID: executionInfo.WorkflowID,
+		logging.TagWorkflowRunID:       executionInfo.RunID,
+	}).Info("Workflow execution deleted.")
+	return nil
 }
 
 func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context, signalWithStartRequest *h.SignalWithStartWorkflowExecutionRequest) (
@@ -1839,7 +2475,7 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context
 	prevRunID := ""
 	attempt := 0
 
-	context, release, err0 := e.historyCache.getOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	context, release, err0 := e.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
 
 	if err0 == nil {
 		defer func() { release(retError) }()
@@ -1924,7 +2560,7 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context
 	// Generate first decision task event.
 	taskList := request.TaskList.GetName()
 	// TODO when the workflow is going to be replicated, use the
-	var msBuilder mutableState
+	var msBuilder MutableState
 	if e.shard.GetService().GetClusterMetadata().IsGlobalDomainEnabled() && domainEntry.IsGlobalDomain() {
 		// all workflows within a global domain should have replication state, no matter whether it will be replicated to multiple
 		// target clusters or not
@@ -1932,6 +2568,7 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context
 	} else {
 		msBuilder = newMutableStateBuilder(e.shard.GetConfig(), e.logger)
 	}
+	msBuilder.SetHistoryEncodingFn(domainEntry.GetHistoryEncoding)
 	startedEvent := msBuilder.AddWorkflowExecutionStartedEvent(execution, startRequest)
 	if startedEvent == nil {
 		return nil, &workflow.InternalServiceError{Message: "Failed to add workflow execution started event."}
@@ -1981,6 +2618,7 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context
 		return nil, err
 	}
 	msBuilder.GetExecutionInfo().LastFirstEventID = startedEvent.GetEventId()
+	msBuilder.GetExecutionInfo().HistorySize = int64(len(serializedHistory.Data))
 
 	var replicationState *persistence.ReplicationState
 	var replicationTasks []persistence.Task
@@ -1994,6 +2632,7 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context
 				NextEventID:         msBuilder.GetNextEventID(),
 				Version:             msBuilder.GetCurrentVersion(),
 				LastReplicationInfo: nil,
+				HistorySize:         msBuilder.GetExecutionInfo().HistorySize,
 			}
 			replicationTasks = append(replicationTasks, replicationTask)
 		}
@@ -2023,6 +2662,10 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context
 			ContinueAsNew:               !isBrandNew,
 			PreviousRunID:               prevRunID,
 			ReplicationState:            replicationState,
+			Tags:                        request.Tags,
+			HistorySize:                 msBuilder.GetExecutionInfo().HistorySize,
+			Priority:                    msBuilder.GetExecutionInfo().Priority,
+			FirstRunID:                  msBuilder.GetExecutionInfo().FirstRunID,
 		})
 
 		if err != nil {
@@ -2053,6 +2696,7 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context
 			Message:        common.StringPtr("Workflow is already running"),
 			StartRequestId: common.StringPtr(alreadyStartedErr.StartRequestID),
 			RunId:          common.StringPtr(alreadyStartedErr.RunID),
+			CloseStatus:    getAlreadyStartedCloseStatus(alreadyStartedErr),
 		}
 	}
 	return nil, err
@@ -2073,7 +2717,7 @@ func (e *historyEngineImpl) RemoveSignalMutableState(ctx context.Context, reques
 	}
 
 	return e.updateWorkflowExecution(ctx, domainID, execution, false, false,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+		func(msBuilder MutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
@@ -2099,7 +2743,7 @@ func (e *historyEngineImpl) TerminateWorkflowExecution(ctx context.Context, term
 	}
 
 	return e.updateWorkflowExecution(ctx, domainID, execution, true, false,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+		func(msBuilder MutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
@@ -2127,7 +2771,7 @@ func (e *historyEngineImpl) ScheduleDecisionTask(ctx context.Context, scheduleRe
 	}
 
 	return e.updateWorkflowExecution(ctx, domainID, execution, false, true,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+		func(msBuilder MutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
@@ -2153,7 +2797,7 @@ func (e *historyEngineImpl) RecordChildExecutionCompleted(ctx context.Context, c
 	}
 
 	return e.updateWorkflowExecution(ctx, domainID, execution, false, true,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+		func(msBuilder MutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
@@ -2208,16 +2852,23 @@ func (e *historyEngineImpl) SyncShardStatus(ctx context.Context, request *h.Sync
 	return nil
 }
 
+func (e *historyEngineImpl) SyncActivity(ctx context.Context, request *h.SyncActivityRequest) error {
+	return e.replicator.SyncActivity(ctx, request)
+}
+
 type updateWorkflowAction struct {
 	deleteWorkflow bool
 	createDecision bool
 	timerTasks     []persistence.Task
 	transferTasks  []persistence.Task
+	// the action changed nothing, or only the cached mutable state which is written by the next update of the
+	// workflow
+	skipPersistence bool
 }
 
 func (e *historyEngineImpl) updateWorkflowExecutionWithAction(ctx context.Context, domainID string, execution workflow.WorkflowExecution,
-	action func(builder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error)) (retError error) {
-	context, release, err0 := e.historyCache.getOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	action func(builder MutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error)) (retError error) {
+	context, release, err0 := e.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
 	if err0 != nil {
 		return err0
 	}
@@ -2244,6 +2895,9 @@ Update_History_Loop:
 			// Returned error back to the caller
 			return err
 		}
+		if postActions.skipPersistence {
+			return nil
+		}
 
 		transferTasks, timerTasks := postActions.transferTasks, postActions.timerTasks
 		if postActions.deleteWorkflow {
@@ -2295,9 +2949,9 @@ Update_History_Loop:
 
 func (e *historyEngineImpl) updateWorkflowExecution(ctx context.Context, domainID string, execution workflow.WorkflowExecution,
 	createDeletionTask, createDecisionTask bool,
-	action func(builder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error)) error {
+	action func(builder MutableState, tBuilder *timerBuilder) ([]persistence.Task, error)) error {
 	return e.updateWorkflowExecutionWithAction(ctx, domainID, execution,
-		func(builder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+		func(builder MutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
 			timerTasks, err := action(builder, tBuilder)
 			if err != nil {
 				return nil, err
@@ -2334,8 +2988,8 @@ func (e *historyEngineImpl) getDeleteWorkflowTasks(
 	return closeTask, cleanupTask, nil
 }
 
-func (e *historyEngineImpl) createRecordDecisionTaskStartedResponse(domainID string, msBuilder mutableState,
-	di *decisionInfo, identity string) *h.RecordDecisionTaskStartedResponse {
+func (e *historyEngineImpl) createRecordDecisionTaskStartedResponse(domainID string, msBuilder MutableState,
+	di *DecisionInfo, identity string) *h.RecordDecisionTaskStartedResponse {
 	response := &h.RecordDecisionTaskStartedResponse{}
 	response.WorkflowType = msBuilder.GetWorkflowType()
 	executionInfo := msBuilder.GetExecutionInfo()
@@ -2350,6 +3004,8 @@ func (e *historyEngineImpl) createRecordDecisionTaskStartedResponse(domainID str
 	response.StickyExecutionEnabled = common.BoolPtr(msBuilder.IsStickyTaskListEnabled())
 	response.NextEventId = common.Int64Ptr(msBuilder.GetNextEventID())
 	response.Attempt = common.Int64Ptr(di.Attempt)
+	response.StickyInvalidationCount = common.Int64Ptr(executionInfo.StickyInvalidationCount)
+	response.ContinueAsNewSuggested = common.BoolPtr(e.isContinueAsNewSuggested(domainID, msBuilder))
 	if di.Attempt > 0 {
 		// This decision is retried from mutable state
 		// Also return schedule and started which are not written to history yet
@@ -2373,8 +3029,8 @@ func (e *historyEngineImpl) deleteEvents(domainID string, execution workflow.Wor
 	})
 }
 
-func (e *historyEngineImpl) failDecision(context *workflowExecutionContext, scheduleID, startedID int64,
-	cause workflow.DecisionTaskFailedCause, request *workflow.RespondDecisionTaskCompletedRequest) (mutableState,
+func (e *historyEngineImpl) failDecision(context *WorkflowExecutionContext, scheduleID, startedID int64,
+	cause workflow.DecisionTaskFailedCause, request *workflow.RespondDecisionTaskCompletedRequest) (MutableState,
 	error) {
 	// Clear any updates we have accumulated so far
 	context.clear()
@@ -2422,7 +3078,7 @@ func (s *shardContextWrapper) CreateWorkflowExecution(request *persistence.Creat
 	return resp, err
 }
 
-func (s *shardContextWrapper) NotifyNewHistoryEvent(event *historyEventNotification) error {
+func (s *shardContextWrapper) NotifyNewHistoryEvent(event *HistoryEventNotification) error {
 	s.historyEventNotifier.NotifyNewHistoryEvent(event)
 	err := s.ShardContext.NotifyNewHistoryEvent(event)
 	return err
@@ -2544,6 +3200,24 @@ func validateRecordMarkerAttributes(attributes *workflow.RecordMarkerDecisionAtt
 	return nil
 }
 
+func validateCompleteWorkflowUpdateAttributes(attributes *workflow.CompleteWorkflowUpdateDecisionAttributes,
+	msBuilder MutableState) error {
+	if attributes == nil {
+		return &workflow.BadRequestError{Message: "CompleteWorkflowUpdateDecisionAttributes is not set on decision."}
+	}
+	if attributes.GetUpdateId() == "" {
+		return &workflow.BadRequestError{Message: "UpdateId is not set on decision."}
+	}
+	ui, ok := msBuilder.GetUpdateInfo(attributes.GetUpdateId())
+	if !ok {
+		return &workflow.BadRequestError{Message: "UpdateId is not requested on workflow execution."}
+	}
+	if ui.Completed {
+		return &workflow.BadRequestError{Message: "Workflow update is already completed."}
+	}
+	return nil
+}
+
 func validateCompleteWorkflowExecutionAttributes(attributes *workflow.CompleteWorkflowExecutionDecisionAttributes) error {
 	if attributes == nil {
 		return &workflow.BadRequestError{Message: "CompleteWorkflowExecutionDecisionAttributes is not set on decision."}
@@ -2710,7 +3384,7 @@ func (e *historyEngineImpl) getActiveDomainEntry(domainUUID *string) (*cache.Dom
 	return domainEntry, nil
 }
 
-func getScheduleID(activityID string, msBuilder mutableState) (int64, error) {
+func getScheduleID(activityID string, msBuilder MutableState) (int64, error) {
 	if activityID == "" {
 		return 0, &workflow.BadRequestError{Message: "Neither ActivityID nor ScheduleID is provided"}
 	}
@@ -2736,6 +3410,36 @@ func getSignalRequest(request *workflow.SignalWithStartWorkflowExecutionRequest)
 	return req
 }
 
+// getSignalWithStartRequest builds the request starting a new run of a closed workflow with the signal it
+// received, the new run takes over the type, task list, timeouts, tags and priority of the closed one
+func getSignalWithStartRequest(domain string, request *workflow.SignalWorkflowExecutionRequest,
+	closedExecutionInfo *persistence.WorkflowExecutionInfo) *workflow.SignalWithStartWorkflowExecutionRequest {
+	taskList := closedExecutionInfo.TaskList
+	if closedExecutionInfo.ContinueAsNewTaskList != "" {
+		taskList = closedExecutionInfo.ContinueAsNewTaskList
+	}
+	requestID := request.GetRequestId()
+	if requestID == "" {
+		requestID = uuid.New()
+	}
+
+	return &workflow.SignalWithStartWorkflowExecutionRequest{
+		Domain:                              common.StringPtr(domain),
+		WorkflowId:                          common.StringPtr(closedExecutionInfo.WorkflowID),
+		WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr(closedExecutionInfo.WorkflowTypeName)},
+		TaskList:                            &workflow.TaskList{Name: common.StringPtr(taskList)},
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(closedExecutionInfo.WorkflowTimeout),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(closedExecutionInfo.DecisionTimeoutValue),
+		Identity:                            request.Identity,
+		RequestId:                           common.StringPtr(requestID),
+		SignalName:                          request.SignalName,
+		SignalInput:                         request.Input,
+		Control:                             request.Control,
+		Tags:                                closedExecutionInfo.Tags,
+		Priority:                            common.Int32Ptr(closedExecutionInfo.Priority),
+	}
+}
+
 func getStartRequest(domainID string,
 	request *workflow.SignalWithStartWorkflowExecutionRequest) *h.StartWorkflowExecutionRequest {
 	policy := workflow.WorkflowIdReusePolicyAllowDuplicate
@@ -2750,6 +3454,8 @@ func getStartRequest(domainID string,
 		Identity:                            request.Identity,
 		RequestId:                           request.RequestId,
 		WorkflowIdReusePolicy:               &policy,
+		Tags:                                request.Tags,
+		Priority:                            request.Priority,
 	}
 
 	startRequest := &h.StartWorkflowExecutionRequest{
@@ -2769,3 +3475,12 @@ func setTaskInfo(version int64, timestamp time.Time, transferTasks []persistence
 		task.SetVersion(version)
 	}
 }
+
+// getAlreadyStartedCloseStatus returns the close status of the existing run reported by persistence,
+// or nil if that run is still open
+func getAlreadyStartedCloseStatus(err *persistence.WorkflowExecutionAlreadyStartedError) *workflow.WorkflowExecutionCloseStatus {
+	if err.State != persistence.WorkflowStateCompleted {
+		return nil
+	}
+	return common.WorkflowExecutionCloseStatusPtr(getWorkflowExecutionCloseStatus(err.CloseStatus))
+}
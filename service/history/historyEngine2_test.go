@@ -831,7 +831,7 @@ func (s *engine2Suite) TestRequestCancelWorkflowExecutionFail() {
 }
 
 func (s *engine2Suite) createExecutionStartedState(we workflow.WorkflowExecution, tl, identity string,
-	startDecision bool) mutableState {
+	startDecision bool) MutableState {
 	msBuilder := newMutableStateBuilder(s.config, s.logger)
 	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
 	di := addDecisionTaskScheduledEvent(msBuilder)
@@ -842,7 +842,7 @@ func (s *engine2Suite) createExecutionStartedState(we workflow.WorkflowExecution
 	return msBuilder
 }
 
-func (s *engine2Suite) printHistory(builder mutableState) string {
+func (s *engine2Suite) printHistory(builder MutableState) string {
 	history, err := builder.GetHistoryBuilder().Serialize()
 	if err != nil {
 		s.logger.Errorf("Error serializing history: %v", err)
@@ -959,6 +959,71 @@ func (s *engine2Suite) TestStartWorkflowExecution_BrandNew() {
 	s.NotNil(resp.RunId)
 }
 
+func (s *engine2Suite) TestStartWorkflowExecution_EagerDecision() {
+	enableEager := s.config.EnableDecisionEagerExecution
+	defer func() {
+		s.config.EnableDecisionEagerExecution = enableEager
+	}()
+	s.config.EnableDecisionEagerExecution = dynamicconfig.GetBoolPropertyFnFilteredByTaskListInfo(true)
+
+	domainID := validDomainID
+	workflowID := "workflowID"
+	workflowType := "workflowType"
+	taskList := "testTaskList"
+	identity := "testIdentity"
+
+	var createRequest *persistence.CreateWorkflowExecutionRequest
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.Anything).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Run(func(args mock.Arguments) {
+		createRequest = args.Get(0).(*persistence.CreateWorkflowExecutionRequest)
+	}).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	resp, err := s.historyEngine.StartWorkflowExecution(&h.StartWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		StartRequest: &workflow.StartWorkflowExecutionRequest{
+			Domain:                              common.StringPtr(domainID),
+			WorkflowId:                          common.StringPtr(workflowID),
+			WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr(workflowType)},
+			TaskList:                            &workflow.TaskList{Name: common.StringPtr(taskList)},
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(2),
+			Identity:                            common.StringPtr(identity),
+			RequestEagerExecution:               common.BoolPtr(true),
+		},
+	})
+	s.Nil(err)
+	s.NotNil(resp.DecisionTask)
+	s.Equal(resp.GetRunId(), resp.DecisionTask.WorkflowExecution.GetRunId())
+	s.Equal(int64(3), resp.DecisionTask.GetStartedEventId())
+	s.Equal(3, len(resp.DecisionTask.History.Events))
+	s.Equal(workflow.EventTypeDecisionTaskStarted, resp.DecisionTask.History.Events[2].GetEventType())
+
+	token, err := common.NewJSONTaskTokenSerializer().Deserialize(resp.DecisionTask.TaskToken)
+	s.Nil(err)
+	s.Equal(int64(2), token.ScheduleID)
+
+	// the decision transfer task only records the run in the open visibility
+	s.Equal(1, len(createRequest.TransferTasks))
+	s.Equal(persistence.TransferTaskTypeDecisionTask, createRequest.TransferTasks[0].GetType())
+	s.Equal(2, len(createRequest.TimerTasks))
+	s.Equal(int64(3), createRequest.DecisionStartedID)
+	s.NotEmpty(createRequest.DecisionRequestID)
+}
+
 func (s *engine2Suite) TestStartWorkflowExecution_StillRunning_Dedup() {
 	domainID := validDomainID
 	workflowID := "workflowID"
@@ -1054,9 +1119,11 @@ func (s *engine2Suite) TestStartWorkflowExecution_StillRunning_NonDeDup() {
 			RequestId:                           common.StringPtr("newRequestID"),
 		},
 	})
-	if _, ok := err.(*workflow.WorkflowExecutionAlreadyStartedError); !ok {
+	alreadyStartedErr, ok := err.(*workflow.WorkflowExecutionAlreadyStartedError)
+	if !ok {
 		s.Fail("return err is not *shared.WorkflowExecutionAlreadyStartedError")
 	}
+	s.Nil(alreadyStartedErr.CloseStatus)
 	s.Nil(resp)
 }
 
@@ -1217,9 +1284,11 @@ func (s *engine2Suite) TestStartWorkflowExecution_NotRunning_PrevFail() {
 			})
 
 			if expecedErrs[j] {
-				if _, ok := err.(*workflow.WorkflowExecutionAlreadyStartedError); !ok {
+				alreadyStartedErr, ok := err.(*workflow.WorkflowExecutionAlreadyStartedError)
+				if !ok {
 					s.Fail("return err is not *shared.WorkflowExecutionAlreadyStartedError")
 				}
+				s.Equal(getWorkflowExecutionCloseStatus(closeState), alreadyStartedErr.GetCloseStatus())
 				s.Nil(resp)
 			} else {
 				s.Nil(err)
@@ -1391,8 +1460,8 @@ func (s *engine2Suite) TestSignalWithStartWorkflowExecution_WorkflowNotRunning()
 	s.NotEqual(runID, resp.GetRunId())
 }
 
-func (s *engine2Suite) getBuilder(domainID string, we workflow.WorkflowExecution) mutableState {
-	context, release, err := s.historyEngine.historyCache.getOrCreateWorkflowExecution(domainID, we)
+func (s *engine2Suite) getBuilder(domainID string, we workflow.WorkflowExecution) MutableState {
+	context, release, err := s.historyEngine.historyCache.GetOrCreateWorkflowExecution(domainID, we)
 	if err != nil {
 		return nil
 	}
@@ -37,7 +37,8 @@ type (
 		runID      string
 	}
 
-	historyEventNotification struct {
+	// HistoryEventNotification notifies the watchers of a workflow execution that new history events were persisted
+	HistoryEventNotification struct {
 		workflowIdentifier
 		lastFirstEventID  int64
 		nextEventID       int64
@@ -66,6 +67,10 @@ type (
 		RecordActivityTaskHeartbeat(ctx context.Context, request *h.RecordActivityTaskHeartbeatRequest) (*workflow.RecordActivityTaskHeartbeatResponse, error)
 		RequestCancelWorkflowExecution(ctx context.Context, request *h.RequestCancelWorkflowExecutionRequest) error
 		SignalWorkflowExecution(ctx context.Context, request *h.SignalWorkflowExecutionRequest) error
+		UpdateWorkflowExecutionOptions(ctx context.Context, request *h.UpdateWorkflowExecutionOptionsRequest) error
+		UpdateWorkflowExecution(ctx context.Context, request *h.UpdateWorkflowExecutionRequest) (*workflow.UpdateWorkflowExecutionResponse, error)
+		DeleteWorkflowExecution(ctx context.Context, request *h.DeleteWorkflowExecutionRequest) error
+		FixWorkflowExecution(ctx context.Context, request *h.FixWorkflowExecutionRequest) (*h.FixWorkflowExecutionResponse, error)
 		SignalWithStartWorkflowExecution(ctx context.Context, request *h.SignalWithStartWorkflowExecutionRequest) (
 			*workflow.StartWorkflowExecutionResponse, error)
 		RemoveSignalMutableState(ctx context.Context, request *h.RemoveSignalMutableStateRequest) error
@@ -74,6 +79,7 @@ type (
 		RecordChildExecutionCompleted(ctx context.Context, request *h.RecordChildExecutionCompletedRequest) error
 		ReplicateEvents(ctx context.Context, request *h.ReplicateEventsRequest) error
 		SyncShardStatus(ctx context.Context, request *h.SyncShardStatusRequest) error
+		SyncActivity(ctx context.Context, request *h.SyncActivityRequest) error
 	}
 
 	// EngineFactory is used to create an instance of sharded history engine
@@ -117,7 +123,7 @@ type (
 
 	transferQueueProcessor interface {
 		common.Daemon
-		FailoverDomain(domainID string)
+		FailoverDomain(domainID string, failoverVersion int64)
 		NotifyNewTask(clusterName string, transferTasks []persistence.Task)
 	}
 
@@ -131,24 +137,26 @@ type (
 	}
 
 	timerProcessor interface {
+		Stop()
 		notifyNewTimers(timerTask []persistence.Task)
 		process(task *persistence.TimerTaskInfo) error
 		getTimerGate() TimerGate
 	}
 
-	timerQueueAckMgr interface {
-		getFinishedChan() <-chan struct{}
-		readTimerTasks() ([]*persistence.TimerTaskInfo, *persistence.TimerTaskInfo, bool, error)
-		completeTimerTask(timerTask *persistence.TimerTaskInfo)
-		getAckLevel() TimerSequenceID
-		getReadLevel() TimerSequenceID
-		updateAckLevel()
+	// TimerQueueAckMgr reads the timer tasks of a shard in order and tracks how far they are acked
+	TimerQueueAckMgr interface {
+		GetFinishedChan() <-chan struct{}
+		ReadTimerTasks() ([]*persistence.TimerTaskInfo, *persistence.TimerTaskInfo, bool, error)
+		CompleteTimerTask(timerTask *persistence.TimerTaskInfo)
+		GetAckLevel() TimerSequenceID
+		GetReadLevel() TimerSequenceID
+		UpdateAckLevel()
 	}
 
 	historyEventNotifier interface {
 		common.Daemon
-		NotifyNewHistoryEvent(event *historyEventNotification)
-		WatchHistoryEvent(identifier *workflowIdentifier) (string, chan *historyEventNotification, error)
+		NotifyNewHistoryEvent(event *HistoryEventNotification)
+		WatchHistoryEvent(identifier *workflowIdentifier) (string, chan *HistoryEventNotification, error)
 		UnwatchHistoryEvent(identifier *workflowIdentifier, subscriberID string) error
 	}
 )
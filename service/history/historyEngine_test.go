@@ -156,6 +156,7 @@ func (s *engineSuite) SetupTest() {
 		shard:                shardContextWrapper,
 		executionManager:     s.mockExecutionMgr,
 		historyMgr:           s.mockHistoryMgr,
+		visibilityMgr:        s.mockVisibilityMgr,
 		historyCache:         historyCache,
 		logger:               s.logger,
 		metricsClient:        metrics.NewClient(tally.NoopScope, metrics.History),
@@ -375,6 +376,94 @@ func (s *engineSuite) TestGetMutableStateLongPollTimeout() {
 	s.Equal(int64(4), *response.NextEventId)
 }
 
+func (s *engineSuite) TestGetScaledDecisionTimeout() {
+	eventsPerSecond := s.config.DecisionTimeoutScaleEventsPerSecond
+	maxTimeout := s.config.MaxScaledDecisionStartToCloseTimeout
+	defer func() {
+		s.config.DecisionTimeoutScaleEventsPerSecond = eventsPerSecond
+		s.config.MaxScaledDecisionStartToCloseTimeout = maxTimeout
+	}()
+	domainName := "some random domain name"
+
+	// scaling is disabled by default
+	s.Equal(int32(10), s.mockHistoryEngine.getScaledDecisionTimeout(domainName, 100001, 10))
+
+	s.config.DecisionTimeoutScaleEventsPerSecond = dynamicconfig.GetIntPropertyFilteredByDomain(1000)
+	s.config.MaxScaledDecisionStartToCloseTimeout = dynamicconfig.GetDurationPropertyFnFilteredByDomain(time.Minute)
+	s.Equal(int32(10), s.mockHistoryEngine.getScaledDecisionTimeout(domainName, 500, 10))
+	s.Equal(int32(15), s.mockHistoryEngine.getScaledDecisionTimeout(domainName, 5001, 10))
+	s.Equal(int32(60), s.mockHistoryEngine.getScaledDecisionTimeout(domainName, 100001, 10))
+	// never shorten a timeout which is already above the bound
+	s.Equal(int32(120), s.mockHistoryEngine.getScaledDecisionTimeout(domainName, 100001, 120))
+}
+
+func (s *engineSuite) TestIsContinueAsNewSuggested() {
+	historySize := s.config.ContinueAsNewSuggestedHistorySize
+	historyCount := s.config.ContinueAsNewSuggestedHistoryCount
+	defer func() {
+		s.config.ContinueAsNewSuggestedHistorySize = historySize
+		s.config.ContinueAsNewSuggestedHistoryCount = historyCount
+	}()
+	domainID := validDomainID
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:              &persistence.DomainInfo{ID: domainID, Name: "testDomain"},
+			Config:            &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{ActiveClusterName: cluster.TestCurrentClusterName},
+			TableVersion:      persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	we := workflow.WorkflowExecution{WorkflowId: common.StringPtr("wId"), RunId: common.StringPtr(validRunID)}
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", "testTaskList", []byte("input"), 100, 100, "testIdentity")
+	addDecisionTaskScheduledEvent(msBuilder)
+	msBuilder.GetExecutionInfo().HistorySize = 2048
+
+	// no suggestion by default
+	s.False(s.mockHistoryEngine.isContinueAsNewSuggested(domainID, msBuilder))
+
+	s.config.ContinueAsNewSuggestedHistorySize = dynamicconfig.GetIntPropertyFilteredByDomain(4096)
+	s.config.ContinueAsNewSuggestedHistoryCount = dynamicconfig.GetIntPropertyFilteredByDomain(10)
+	s.False(s.mockHistoryEngine.isContinueAsNewSuggested(domainID, msBuilder))
+
+	s.config.ContinueAsNewSuggestedHistorySize = dynamicconfig.GetIntPropertyFilteredByDomain(2048)
+	s.True(s.mockHistoryEngine.isContinueAsNewSuggested(domainID, msBuilder))
+
+	s.config.ContinueAsNewSuggestedHistorySize = dynamicconfig.GetIntPropertyFilteredByDomain(0)
+	s.config.ContinueAsNewSuggestedHistoryCount = dynamicconfig.GetIntPropertyFilteredByDomain(2)
+	s.True(s.mockHistoryEngine.isContinueAsNewSuggested(domainID, msBuilder))
+}
+
+func (s *engineSuite) TestCapActivityScheduleToStartTimeout() {
+	maxTimeout := s.config.MaxActivityScheduleToStartTimeout
+	defer func() {
+		s.config.MaxActivityScheduleToStartTimeout = maxTimeout
+	}()
+	domainName := "some random domain name"
+	newAttributes := func(scheduleToStart int32) *workflow.ScheduleActivityTaskDecisionAttributes {
+		return &workflow.ScheduleActivityTaskDecisionAttributes{
+			ActivityId:                    common.StringPtr("activity-1"),
+			TaskList:                      &workflow.TaskList{Name: common.StringPtr("some random task list")},
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(scheduleToStart),
+		}
+	}
+
+	// capping is disabled by default
+	attributes := newAttributes(86400)
+	s.mockHistoryEngine.capActivityScheduleToStartTimeout(domainName, attributes)
+	s.Equal(int32(86400), attributes.GetScheduleToStartTimeoutSeconds())
+
+	s.config.MaxActivityScheduleToStartTimeout = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(time.Hour)
+	attributes = newAttributes(86400)
+	s.mockHistoryEngine.capActivityScheduleToStartTimeout(domainName, attributes)
+	s.Equal(int32(3600), attributes.GetScheduleToStartTimeoutSeconds())
+	attributes = newAttributes(60)
+	s.mockHistoryEngine.capActivityScheduleToStartTimeout(domainName, attributes)
+	s.Equal(int32(60), attributes.GetScheduleToStartTimeoutSeconds())
+}
+
 func (s *engineSuite) TestRespondDecisionTaskCompletedInvalidToken() {
 	domainID := validDomainID
 	invalidToken, _ := json.Marshal("bad token")
@@ -1311,6 +1400,125 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedSingleActivityScheduledDec
 	s.Equal(int32(5), *activity1Attributes.HeartbeatTimeoutSeconds)
 }
 
+func (s *engineSuite) TestRespondDecisionTaskCompletedActivityEagerExecution() {
+	enableEager := s.config.EnableActivityEagerExecution
+	defer func() {
+		s.config.EnableActivityEagerExecution = enableEager
+	}()
+	s.config.EnableActivityEagerExecution = dynamicconfig.GetBoolPropertyFnFilteredByTaskListInfo(true)
+
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	otherTl := "otherTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: "wId",
+		RunID:      we.GetRunId(),
+		ScheduleID: 2,
+	})
+	identity := "testIdentity"
+	input := []byte("input")
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+
+	newDecision := func(activityID, taskList string) *workflow.Decision {
+		return &workflow.Decision{
+			DecisionType: common.DecisionTypePtr(workflow.DecisionTypeScheduleActivityTask),
+			ScheduleActivityTaskDecisionAttributes: &workflow.ScheduleActivityTaskDecisionAttributes{
+				ActivityId:                    common.StringPtr(activityID),
+				ActivityType:                  &workflow.ActivityType{Name: common.StringPtr("activity_type1")},
+				TaskList:                      &workflow.TaskList{Name: common.StringPtr(taskList)},
+				Input:                         input,
+				ScheduleToCloseTimeoutSeconds: common.Int32Ptr(100),
+				ScheduleToStartTimeoutSeconds: common.Int32Ptr(10),
+				StartToCloseTimeoutSeconds:    common.Int32Ptr(50),
+				HeartbeatTimeoutSeconds:       common.Int32Ptr(5),
+				RequestEagerExecution:         common.BoolPtr(true),
+			},
+		}
+	}
+	// only the activity on the task list of the workflow is executed eagerly
+	decisions := []*workflow.Decision{newDecision("activity1", tl), newDecision("activity2", otherTl)}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	var transferTasks []persistence.Task
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		transferTasks = args.Get(0).(*persistence.UpdateWorkflowExecutionRequest).TransferTasks
+	}).Once()
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	resp, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  &identity,
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	s.Equal(1, len(resp.ActivitiesToDispatchLocally))
+	s.Equal(int64(5), resp.ActivitiesToDispatchLocally[0].ScheduledEvent.GetEventId())
+	s.Equal(1, len(transferTasks))
+	s.Equal(otherTl, transferTasks[0].(*persistence.ActivityTask).TaskList)
+
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(int64(8), executionBuilder.GetExecutionInfo().NextEventID)
+	activity1, ok := executionBuilder.GetActivityInfo(5)
+	s.True(ok)
+	s.Equal(int64(7), activity1.StartedID)
+	s.Equal(identity, activity1.StartedIdentity)
+	activity2, ok := executionBuilder.GetActivityInfo(6)
+	s.True(ok)
+	s.Equal(common.EmptyEventID, activity2.StartedID)
+}
+
+func (s *engineSuite) TestStartEagerActivities_FailedStartFallsBackToMatching() {
+	msBuilder := &mockMutableState{}
+	defer msBuilder.AssertExpectations(s.T())
+	identity := "testIdentity"
+	started := &persistence.ActivityInfo{ScheduleID: 5, TaskList: "testTaskList"}
+	failed := &persistence.ActivityInfo{ScheduleID: 6, TaskList: "testTaskList"}
+	retried := &persistence.ActivityInfo{ScheduleID: 7, TaskList: "testTaskList", HasRetryPolicy: true}
+
+	msBuilder.On("AddActivityTaskStartedEvent", started, int64(5), mock.Anything, identity).Return(
+		&workflow.HistoryEvent{}).Once()
+	msBuilder.On("AddActivityTaskStartedEvent", failed, int64(6), mock.Anything, identity).Return(nil).Once()
+	// an activity with a retry policy has no started event until it is closed
+	msBuilder.On("AddActivityTaskStartedEvent", retried, int64(7), mock.Anything, identity).Return(nil).Once()
+
+	activities, transferTasks := s.mockHistoryEngine.startEagerActivities(msBuilder, validDomainID,
+		[]*persistence.ActivityInfo{started, failed, retried}, identity)
+	s.Equal([]*persistence.ActivityInfo{started, retried}, activities)
+	s.Equal([]persistence.Task{&persistence.ActivityTask{
+		DomainID:   validDomainID,
+		TaskList:   "testTaskList",
+		ScheduleID: 6,
+	}}, transferTasks)
+}
+
 func (s *engineSuite) TestRespondDecisionTaskCompletedCompleteWorkflowSuccess() {
 	domainID := validDomainID
 	we := workflow.WorkflowExecution{
@@ -1377,6 +1585,66 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedCompleteWorkflowSuccess()
 	s.False(executionBuilder.HasPendingDecisionTask())
 }
 
+func (s *engineSuite) TestRespondDecisionTaskCompletedSuspectBinaryNotSticky() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: *we.WorkflowId,
+		RunID:      *we.RunId,
+		ScheduleID: 2,
+	})
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info: &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{
+				Retention:              1,
+				SuspectBinaryChecksums: []string{"bad-binary"},
+			},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Identity:  &identity,
+			StickyAttributes: &workflow.StickyExecutionAttributes{
+				WorkerTaskList:                &workflow.TaskList{Name: common.StringPtr("sticky-worker")},
+				ScheduleToStartTimeoutSeconds: common.Int32Ptr(10),
+			},
+			BinaryChecksum: common.StringPtr("bad-binary"),
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	executionBuilder := s.getBuilder(domainID, we)
+	s.False(executionBuilder.IsStickyTaskListEnabled())
+	s.Equal("", executionBuilder.GetExecutionInfo().StickyTaskList)
+}
+
 func (s *engineSuite) TestRespondDecisionTaskCompletedFailWorkflowSuccess() {
 	domainID := validDomainID
 	we := workflow.WorkflowExecution{
@@ -3220,6 +3488,73 @@ func (s *engineSuite) TestRecordActivityTaskHeartBeatByIDSuccess() {
 	s.Nil(err)
 }
 
+func (s *engineSuite) TestRecordActivityTaskHeartBeat_WriteBehind() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: *we.WorkflowId,
+		RunID:      *we.RunId,
+		ScheduleID: 5,
+	})
+	identity := "testIdentity"
+	activityID := "activity1_id"
+	activityType := "activity_type1"
+	activityInput := []byte("input1")
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 100, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID,
+		*decisionStartedEvent.EventId, nil, identity)
+	activityScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, *decisionCompletedEvent.EventId, activityID,
+		activityType, tl, activityInput, 100, 10, 60)
+	addActivityTaskStartedEvent(msBuilder, *activityScheduledEvent.EventId, tl, identity)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	// only the first heartbeat is written, the second one is kept in the cached mutable state
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	for _, details := range []string{"details1", "details2"} {
+		response, err := s.mockHistoryEngine.RecordActivityTaskHeartbeat(context.Background(), &history.RecordActivityTaskHeartbeatRequest{
+			DomainUUID: common.StringPtr(domainID),
+			HeartbeatRequest: &workflow.RecordActivityTaskHeartbeatRequest{
+				TaskToken: taskToken,
+				Identity:  &identity,
+				Details:   []byte(details),
+			},
+		})
+		s.Nil(err)
+		s.False(response.GetCancelRequested())
+	}
+
+	executionBuilder := s.getBuilder(domainID, we)
+	ai, ok := executionBuilder.GetActivityInfo(5)
+	s.True(ok)
+	s.Equal([]byte("details2"), ai.Details)
+	s.True(ai.LastHeartBeatUpdatedTime.After(executionBuilder.GetPersistedActivityHeartbeat(5)))
+}
+
 func (s *engineSuite) TestRespondActivityTaskCanceled_Scheduled() {
 	domainID := validDomainID
 	we := workflow.WorkflowExecution{
@@ -4239,43 +4574,35 @@ func (s *engineSuite) TestSignalWorkflowExecution() {
 	s.Nil(err)
 }
 
-// Test signal decision by adding request ID
-func (s *engineSuite) TestSignalWorkflowExecution_DuplicateRequest() {
-	signalRequest := &history.SignalWorkflowExecutionRequest{}
-	err := s.mockHistoryEngine.SignalWorkflowExecution(context.Background(), signalRequest)
-	s.EqualError(err, "BadRequestError{Message: Missing domain UUID.}")
-
+func (s *engineSuite) TestSignalWorkflowExecution_TracksHistorySize() {
 	domainID := validDomainID
 	we := workflow.WorkflowExecution{
-		WorkflowId: common.StringPtr("wId2"),
+		WorkflowId: common.StringPtr("wId"),
 		RunId:      common.StringPtr(validRunID),
 	}
-	identity := "testIdentity2"
-	signalName := "my signal name 2"
-	input := []byte("test input 2")
-	requestID := uuid.New()
-	signalRequest = &history.SignalWorkflowExecutionRequest{
+	signalRequest := &history.SignalWorkflowExecutionRequest{
 		DomainUUID: common.StringPtr(domainID),
 		SignalRequest: &workflow.SignalWorkflowExecutionRequest{
 			Domain:            common.StringPtr(domainID),
 			WorkflowExecution: &we,
-			Identity:          common.StringPtr(identity),
-			SignalName:        common.StringPtr(signalName),
-			Input:             input,
-			RequestId:         common.StringPtr(requestID),
+			Identity:          common.StringPtr("testIdentity"),
+			SignalName:        common.StringPtr("my signal name"),
+			Input:             []byte("test input"),
 		},
 	}
 
 	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", "testTaskList", []byte("input"), 100, 100, "testIdentity")
 	ms := createMutableState(msBuilder)
-	// assume duplicate request id
-	ms.SignalRequestedIDs = make(map[string]struct{})
-	ms.SignalRequestedIDs[requestID] = struct{}{}
+	ms.ExecutionInfo.HistorySize = 100
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
+	var historySize int64
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
-
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		historySize = args.Get(0).(*persistence.UpdateWorkflowExecutionRequest).ExecutionInfo.HistorySize
+	}).Once()
 	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
 		&persistence.GetDomainResponse{
 			Info:   &persistence.DomainInfo{ID: domainID},
@@ -4290,40 +4617,64 @@ func (s *engineSuite) TestSignalWorkflowExecution_DuplicateRequest() {
 		},
 		nil,
 	)
-	err = s.mockHistoryEngine.SignalWorkflowExecution(context.Background(), signalRequest)
+
+	err := s.mockHistoryEngine.SignalWorkflowExecution(context.Background(), signalRequest)
 	s.Nil(err)
+	s.True(historySize > 100)
 }
 
-func (s *engineSuite) TestSignalWorkflowExecution_Failed() {
-	signalRequest := &history.SignalWorkflowExecutionRequest{}
-	err := s.mockHistoryEngine.SignalWorkflowExecution(context.Background(), signalRequest)
-	s.EqualError(err, "BadRequestError{Message: Missing domain UUID.}")
+func (s *engineSuite) TestDescribeWorkflowExecution_ExecutionStats() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", "testTaskList", []byte("input"), 100, 100, "testIdentity")
+	addDecisionTaskScheduledEvent(msBuilder)
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.HistorySize = 1234
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: ms}, nil).Once()
+
+	resp, err := s.mockHistoryEngine.DescribeWorkflowExecution(context.Background(), &history.DescribeWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Request: &workflow.DescribeWorkflowExecutionRequest{
+			Domain:    common.StringPtr(domainID),
+			Execution: &we,
+		},
+	})
+	s.Nil(err)
+	s.Equal(int64(1234), resp.ExecutionStats.GetHistorySize())
+	s.Equal(int64(2), resp.ExecutionStats.GetHistoryEventsCount())
+	s.True(resp.ExecutionStats.GetMutableStateSize() > 0)
+}
 
+func (s *engineSuite) TestUpdateWorkflowExecutionOptions() {
 	domainID := validDomainID
-	we := &workflow.WorkflowExecution{
+	we := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr("wId"),
 		RunId:      common.StringPtr(validRunID),
 	}
+	tl := "testTaskList"
 	identity := "testIdentity"
-	signalName := "my signal name"
-	input := []byte("test input")
-	signalRequest = &history.SignalWorkflowExecutionRequest{
+	updateRequest := &history.UpdateWorkflowExecutionOptionsRequest{
 		DomainUUID: common.StringPtr(domainID),
-		SignalRequest: &workflow.SignalWorkflowExecutionRequest{
-			Domain:            common.StringPtr(domainID),
-			WorkflowExecution: we,
-			Identity:          common.StringPtr(identity),
-			SignalName:        common.StringPtr(signalName),
-			Input:             input,
+		UpdateRequest: &workflow.UpdateWorkflowExecutionOptionsRequest{
+			Domain:                common.StringPtr(domainID),
+			WorkflowExecution:     &we,
+			ContinueAsNewTaskList: &workflow.TaskList{Name: common.StringPtr("newTaskList")},
+			Identity:              common.StringPtr(identity),
 		},
 	}
 
 	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 100, identity)
 	ms := createMutableState(msBuilder)
-	ms.ExecutionInfo.State = persistence.WorkflowStateCompleted
 	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
 
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
 
 	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
 		&persistence.GetDomainResponse{
@@ -4339,8 +4690,632 @@ func (s *engineSuite) TestSignalWorkflowExecution_Failed() {
 		},
 		nil,
 	)
-	err = s.mockHistoryEngine.SignalWorkflowExecution(context.Background(), signalRequest)
-	s.EqualError(err, "EntityNotExistsError{Message: Workflow execution already completed.}")
+	err := s.mockHistoryEngine.UpdateWorkflowExecutionOptions(context.Background(), updateRequest)
+	s.Nil(err)
+
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal("newTaskList", executionBuilder.GetExecutionInfo().ContinueAsNewTaskList)
+	s.Equal(tl, executionBuilder.GetExecutionInfo().TaskList)
+}
+
+func (s *engineSuite) TestUpdateWorkflowExecutionOptions_TaskList() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+	updateRequest := &history.UpdateWorkflowExecutionOptionsRequest{
+		DomainUUID: common.StringPtr(domainID),
+		UpdateRequest: &workflow.UpdateWorkflowExecutionOptionsRequest{
+			Domain:            common.StringPtr(domainID),
+			WorkflowExecution: &we,
+			TaskList:          &workflow.TaskList{Name: common.StringPtr("newTaskList")},
+			Identity:          common.StringPtr(identity),
+		},
+	}
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 100, identity)
+	msBuilder.GetExecutionInfo().StickyTaskList = "stickyTaskList"
+	msBuilder.GetExecutionInfo().StickyScheduleToStartTimeout = 10
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	err := s.mockHistoryEngine.UpdateWorkflowExecutionOptions(context.Background(), updateRequest)
+	s.Nil(err)
+
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal("newTaskList", executionBuilder.GetExecutionInfo().TaskList)
+	s.Equal("", executionBuilder.GetExecutionInfo().StickyTaskList)
+	s.False(executionBuilder.IsStickyTaskListEnabled())
+	s.Equal(int64(3), executionBuilder.GetNextEventID())
+
+	di := executionBuilder.AddDecisionTaskScheduledEvent()
+	s.NotNil(di)
+	s.Equal("newTaskList", di.TaskList)
+}
+
+func (s *engineSuite) TestUpdateWorkflowExecutionOptions_TaskList_ReschedulesDecision() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+	updateRequest := &history.UpdateWorkflowExecutionOptionsRequest{
+		DomainUUID: common.StringPtr(domainID),
+		UpdateRequest: &workflow.UpdateWorkflowExecutionOptionsRequest{
+			Domain:            common.StringPtr(domainID),
+			WorkflowExecution: &we,
+			TaskList:          &workflow.TaskList{Name: common.StringPtr("newTaskList")},
+			Identity:          common.StringPtr(identity),
+		},
+	}
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 100, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	var transferTasks []persistence.Task
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		transferTasks = args.Get(0).(*persistence.UpdateWorkflowExecutionRequest).TransferTasks
+	}).Once()
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	err := s.mockHistoryEngine.UpdateWorkflowExecutionOptions(context.Background(), updateRequest)
+	s.Nil(err)
+
+	// the scheduled decision timed out and a new one is scheduled on the new task list
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal("newTaskList", executionBuilder.GetExecutionInfo().TaskList)
+	s.True(executionBuilder.HasPendingDecisionTask())
+	s.False(executionBuilder.HasInFlightDecisionTask())
+	newDI, ok := executionBuilder.GetPendingDecision(executionBuilder.GetExecutionInfo().DecisionScheduleID)
+	s.True(ok)
+	s.NotEqual(di.ScheduleID, newDI.ScheduleID)
+	s.Equal("newTaskList", newDI.TaskList)
+	s.Equal(1, len(transferTasks))
+	decisionTask, ok := transferTasks[0].(*persistence.DecisionTask)
+	s.True(ok)
+	s.Equal("newTaskList", decisionTask.TaskList)
+	s.Equal(newDI.ScheduleID, decisionTask.ScheduleID)
+}
+
+func (s *engineSuite) TestDeleteWorkflowExecution_RunningWithoutForce() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", "testTaskList", []byte("input"), 100, 100, "testIdentity")
+	ms := createMutableState(msBuilder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: ms}, nil).Once()
+
+	err := s.mockHistoryEngine.DeleteWorkflowExecution(context.Background(), &history.DeleteWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  &we,
+	})
+	s.IsType(&workflow.BadRequestError{}, err)
+}
+
+func (s *engineSuite) TestDeleteWorkflowExecution_Force() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", "testTaskList", []byte("input"), 100, 100, "testIdentity")
+	ms := createMutableState(msBuilder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: ms}, nil).Once()
+
+	var deleted []string
+	record := func(name string) func(mock.Arguments) {
+		return func(mock.Arguments) { deleted = append(deleted, name) }
+	}
+	s.mockVisibilityMgr.On("DeleteWorkflowExecution", mock.Anything).Return(nil).Run(record("visibility")).Once()
+	s.mockExecutionMgr.On("DeleteCurrentWorkflowExecution", mock.Anything).Return(nil).Run(record("current")).Once()
+	s.mockHistoryMgr.On("DeleteWorkflowExecutionHistory", mock.Anything).Return(nil).Run(record("history")).Once()
+	s.mockExecutionMgr.On("DeleteWorkflowExecution", mock.Anything).Return(nil).Run(record("execution")).Once()
+
+	err := s.mockHistoryEngine.DeleteWorkflowExecution(context.Background(), &history.DeleteWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  &we,
+		Force:      common.BoolPtr(true),
+	})
+	s.Nil(err)
+	s.Equal([]string{"visibility", "current", "history", "execution"}, deleted)
+}
+
+// Test signal decision by adding request ID
+func (s *engineSuite) TestSignalWorkflowExecution_DuplicateRequest() {
+	signalRequest := &history.SignalWorkflowExecutionRequest{}
+	err := s.mockHistoryEngine.SignalWorkflowExecution(context.Background(), signalRequest)
+	s.EqualError(err, "BadRequestError{Message: Missing domain UUID.}")
+
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId2"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	identity := "testIdentity2"
+	signalName := "my signal name 2"
+	input := []byte("test input 2")
+	requestID := uuid.New()
+	signalRequest = &history.SignalWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		SignalRequest: &workflow.SignalWorkflowExecutionRequest{
+			Domain:            common.StringPtr(domainID),
+			WorkflowExecution: &we,
+			Identity:          common.StringPtr(identity),
+			SignalName:        common.StringPtr(signalName),
+			Input:             input,
+			RequestId:         common.StringPtr(requestID),
+		},
+	}
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	ms := createMutableState(msBuilder)
+	// assume duplicate request id
+	ms.SignalRequestedIDs = make(map[string]struct{})
+	ms.SignalRequestedIDs[requestID] = struct{}{}
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Once()
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	err = s.mockHistoryEngine.SignalWorkflowExecution(context.Background(), signalRequest)
+	s.Nil(err)
+}
+
+func (s *engineSuite) TestUpdateWorkflowExecution_DuplicateRequest() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	identity := "testIdentity"
+	updateID := uuid.New()
+	result := []byte("test result")
+	updateRequest := &history.UpdateWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		UpdateRequest: &workflow.UpdateWorkflowExecutionRequest{
+			Domain:            common.StringPtr(domainID),
+			WorkflowExecution: &we,
+			Identity:          common.StringPtr(identity),
+			UpdateName:        common.StringPtr("my update name"),
+			Input:             []byte("test input"),
+			UpdateId:          common.StringPtr(updateID),
+		},
+	}
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", "testTaskList", []byte("input"), 100, 200, identity)
+	ms := createMutableState(msBuilder)
+	// assume the update was already requested and completed
+	ms.UpdateInfos = map[string]*persistence.UpdateInfo{
+		updateID: &persistence.UpdateInfo{UpdateID: updateID, Completed: true, Result: result},
+	}
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	resp, err := s.mockHistoryEngine.UpdateWorkflowExecution(context.Background(), updateRequest)
+	s.Nil(err)
+	s.Equal(result, resp.Result)
+	// no decision is scheduled for the update already requested
+	s.mockExecutionMgr.AssertNotCalled(s.T(), "UpdateWorkflowExecution", mock.Anything)
+}
+
+func (s *engineSuite) TestUpdateWorkflowExecution_TimedOut() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	identity := "testIdentity"
+	updateID := uuid.New()
+	updateRequest := &history.UpdateWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		UpdateRequest: &workflow.UpdateWorkflowExecutionRequest{
+			Domain:            common.StringPtr(domainID),
+			WorkflowExecution: &we,
+			Identity:          common.StringPtr(identity),
+			UpdateName:        common.StringPtr("my update name"),
+			UpdateId:          common.StringPtr(updateID),
+		},
+	}
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", "testTaskList", []byte("input"), 100, 200, identity)
+	ms := createMutableState(msBuilder)
+	// assume the update was already requested and is not completed yet
+	ms.UpdateInfos = map[string]*persistence.UpdateInfo{
+		updateID: &persistence.UpdateInfo{UpdateID: updateID},
+	}
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	// the deadline of the caller leaves no time to wait on the update
+	ctx, cancel := context.WithTimeout(context.Background(), updateWorkflowExecutionReplyReserve/2)
+	defer cancel()
+	resp, err := s.mockHistoryEngine.UpdateWorkflowExecution(ctx, updateRequest)
+	s.Nil(resp)
+	s.Equal(ErrUpdateTimedOut, err)
+}
+
+func (s *engineSuite) TestUpdateWorkflowExecution_LimitExceeded() {
+	maxUpdates := s.config.MaximumUpdatesPerExecution
+	defer func() {
+		s.config.MaximumUpdatesPerExecution = maxUpdates
+	}()
+	s.config.MaximumUpdatesPerExecution = dynamicconfig.GetIntPropertyFilteredByDomain(1)
+
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	identity := "testIdentity"
+	updateRequest := &history.UpdateWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		UpdateRequest: &workflow.UpdateWorkflowExecutionRequest{
+			Domain:            common.StringPtr(domainID),
+			WorkflowExecution: &we,
+			Identity:          common.StringPtr(identity),
+			UpdateName:        common.StringPtr("my update name"),
+			UpdateId:          common.StringPtr(uuid.New()),
+		},
+	}
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", "testTaskList", []byte("input"), 100, 200, identity)
+	ms := createMutableState(msBuilder)
+	otherUpdateID := uuid.New()
+	ms.UpdateInfos = map[string]*persistence.UpdateInfo{
+		otherUpdateID: &persistence.UpdateInfo{UpdateID: otherUpdateID},
+	}
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	resp, err := s.mockHistoryEngine.UpdateWorkflowExecution(context.Background(), updateRequest)
+	s.Nil(resp)
+	s.Equal(ErrUpdatesLimitExceeded, err)
+}
+
+func (s *engineSuite) TestSignalWorkflowExecution_Failed() {
+	signalRequest := &history.SignalWorkflowExecutionRequest{}
+	err := s.mockHistoryEngine.SignalWorkflowExecution(context.Background(), signalRequest)
+	s.EqualError(err, "BadRequestError{Message: Missing domain UUID.}")
+
+	domainID := validDomainID
+	we := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	identity := "testIdentity"
+	signalName := "my signal name"
+	input := []byte("test input")
+	signalRequest = &history.SignalWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		SignalRequest: &workflow.SignalWorkflowExecutionRequest{
+			Domain:            common.StringPtr(domainID),
+			WorkflowExecution: we,
+			Identity:          common.StringPtr(identity),
+			SignalName:        common.StringPtr(signalName),
+			Input:             input,
+		},
+	}
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.State = persistence.WorkflowStateCompleted
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	err = s.mockHistoryEngine.SignalWorkflowExecution(context.Background(), signalRequest)
+	s.EqualError(err, "EntityNotExistsError{Message: Workflow execution already completed.}")
+}
+
+func (s *engineSuite) TestSignalWorkflowExecution_ClosedDropped() {
+	signalAfterClosePolicy := s.config.SignalAfterClosePolicy
+	defer func() { s.config.SignalAfterClosePolicy = signalAfterClosePolicy }()
+	s.config.SignalAfterClosePolicy = dynamicconfig.GetStringPropertyFnFilteredByDomain(signalAfterClosePolicyDrop)
+
+	domainID := validDomainID
+	signalRequest := &history.SignalWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		SignalRequest: &workflow.SignalWorkflowExecutionRequest{
+			Domain: common.StringPtr(domainID),
+			WorkflowExecution: &workflow.WorkflowExecution{
+				WorkflowId: common.StringPtr("wId"),
+				RunId:      common.StringPtr(validRunID),
+			},
+			Identity:   common.StringPtr("testIdentity"),
+			SignalName: common.StringPtr("my signal name"),
+			Input:      []byte("test input"),
+		},
+	}
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.State = persistence.WorkflowStateCompleted
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	err := s.mockHistoryEngine.SignalWorkflowExecution(context.Background(), signalRequest)
+	s.Nil(err)
+}
+
+func (s *engineSuite) TestSignalWorkflowExecution_ClosedStarted() {
+	signalAfterClosePolicy := s.config.SignalAfterClosePolicy
+	defer func() { s.config.SignalAfterClosePolicy = signalAfterClosePolicy }()
+	s.config.SignalAfterClosePolicy = dynamicconfig.GetStringPropertyFnFilteredByDomain(signalAfterClosePolicyStart)
+
+	domainID := validDomainID
+	runID := validRunID
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(runID),
+	}
+	signalRequest := &history.SignalWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		SignalRequest: &workflow.SignalWorkflowExecutionRequest{
+			Domain: common.StringPtr(domainID),
+			// a signal to the current run of the workflow
+			WorkflowExecution: &workflow.WorkflowExecution{WorkflowId: execution.WorkflowId},
+			Identity:          common.StringPtr("testIdentity"),
+			SignalName:        common.StringPtr("my signal name"),
+			Input:             []byte("test input"),
+		},
+	}
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, execution, "wType", "testTaskList", []byte("input"), 100, 200, "testIdentity")
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.State = persistence.WorkflowStateCompleted
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	gceResponse := &persistence.GetCurrentExecutionResponse{RunID: runID}
+
+	// the closed run is loaded by the signal, and again by the start of the new run
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(gceResponse, nil)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil)
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.MatchedBy(func(request *persistence.CreateWorkflowExecutionRequest) bool {
+		// the new run is linked to the closed one and takes over its type, task list and timeouts
+		s.Equal(execution.GetWorkflowId(), request.Execution.GetWorkflowId())
+		s.NotEqual(runID, request.Execution.GetRunId())
+		s.True(request.ContinueAsNew)
+		s.Equal(runID, request.PreviousRunID)
+		s.Equal("wType", request.WorkflowTypeName)
+		s.Equal("testTaskList", request.TaskList)
+		s.Equal(int32(100), request.WorkflowTimeout)
+		s.Equal(int32(200), request.DecisionTimeoutValue)
+		return true
+	})).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	err := s.mockHistoryEngine.SignalWorkflowExecution(context.Background(), signalRequest)
+	s.Nil(err)
+}
+
+func (s *engineSuite) TestSignalWorkflowExecution_ClosedStarted_ExplicitRunRejected() {
+	signalAfterClosePolicy := s.config.SignalAfterClosePolicy
+	defer func() { s.config.SignalAfterClosePolicy = signalAfterClosePolicy }()
+	s.config.SignalAfterClosePolicy = dynamicconfig.GetStringPropertyFnFilteredByDomain(signalAfterClosePolicyStart)
+
+	domainID := validDomainID
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	signalRequest := &history.SignalWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		SignalRequest: &workflow.SignalWorkflowExecutionRequest{
+			Domain:            common.StringPtr(domainID),
+			WorkflowExecution: &execution,
+			Identity:          common.StringPtr("testIdentity"),
+			SignalName:        common.StringPtr("my signal name"),
+			Input:             []byte("test input"),
+		},
+	}
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, execution, "wType", "testTaskList", []byte("input"), 100, 200, "testIdentity")
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.State = persistence.WorkflowStateCompleted
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	// a signal to an explicit run is meant for that run alone, no new run is started
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	err := s.mockHistoryEngine.SignalWorkflowExecution(context.Background(), signalRequest)
+	s.EqualError(err, "EntityNotExistsError{Message: Workflow execution already completed.}")
+}
+
+func (s *engineSuite) TestGetSignalWithStartRequest() {
+	closedExecutionInfo := &persistence.WorkflowExecutionInfo{
+		WorkflowID:            "wId",
+		WorkflowTypeName:      "wType",
+		TaskList:              "testTaskList",
+		ContinueAsNewTaskList: "newTaskList",
+		WorkflowTimeout:       100,
+		DecisionTimeoutValue:  10,
+		Tags:                  map[string]string{"team": "cadence"},
+	}
+	request := &workflow.SignalWorkflowExecutionRequest{
+		WorkflowExecution: &workflow.WorkflowExecution{WorkflowId: common.StringPtr("wId")},
+		Identity:          common.StringPtr("testIdentity"),
+		SignalName:        common.StringPtr("my signal name"),
+		Input:             []byte("test input"),
+		RequestId:         common.StringPtr("testRequestID"),
+	}
+
+	startRequest := getSignalWithStartRequest("testDomain", request, closedExecutionInfo)
+	s.Equal("testDomain", startRequest.GetDomain())
+	s.Equal("wId", startRequest.GetWorkflowId())
+	s.Equal("wType", startRequest.WorkflowType.GetName())
+	s.Equal("newTaskList", startRequest.TaskList.GetName())
+	s.Equal(int32(100), startRequest.GetExecutionStartToCloseTimeoutSeconds())
+	s.Equal(int32(10), startRequest.GetTaskStartToCloseTimeoutSeconds())
+	s.Equal("testRequestID", startRequest.GetRequestId())
+	s.Equal("my signal name", startRequest.GetSignalName())
+	s.Equal([]byte("test input"), startRequest.SignalInput)
+	s.Equal(closedExecutionInfo.Tags, startRequest.Tags)
 }
 
 func (s *engineSuite) TestRemoveSignalMutableState() {
@@ -4384,6 +5359,88 @@ func (s *engineSuite) TestRemoveSignalMutableState() {
 	s.Nil(err)
 }
 
+func (s *engineSuite) TestGetWarmupExecutions() {
+	runID1 := uuid.New()
+	runID2 := uuid.New()
+	runID3 := uuid.New()
+	s.mockExecutionMgr.On("GetTransferTasks", mock.Anything).Return(&persistence.GetTransferTasksResponse{
+		Tasks: []*persistence.TransferTaskInfo{
+			{DomainID: validDomainID, WorkflowID: "wid1", RunID: runID1, TaskType: persistence.TransferTaskTypeDecisionTask},
+			{DomainID: validDomainID, WorkflowID: "wid2", RunID: runID2, TaskType: persistence.TransferTaskTypeCloseExecution},
+		},
+	}, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(&persistence.GetTimerIndexTasksResponse{
+		Timers: []*persistence.TimerTaskInfo{
+			{DomainID: validDomainID, WorkflowID: "wid1", RunID: runID1, TaskType: persistence.TaskTypeUserTimer},
+			{DomainID: validDomainID, WorkflowID: "wid2", RunID: runID2, TaskType: persistence.TaskTypeDeleteHistoryEvent},
+			{DomainID: validDomainID, WorkflowID: "wid3", RunID: runID3, TaskType: persistence.TaskTypeActivityTimeout},
+		},
+	}, nil).Once()
+
+	executions, err := s.mockHistoryEngine.getWarmupExecutions(10, time.Minute)
+	s.Nil(err)
+	s.Equal(2, len(executions))
+	s.Equal(runID1, executions[0].execution.GetRunId())
+	s.Equal(runID3, executions[1].execution.GetRunId())
+	s.Equal("wid3", executions[1].execution.GetWorkflowId())
+	s.Equal(validDomainID, executions[1].domainID)
+}
+
+func (s *engineSuite) TestFixWorkflowExecution_MissingTasks() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilder(s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 100, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID,
+		*decisionStartedEvent.EventId, nil, identity)
+	activityScheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, *decisionCompletedEvent.EventId,
+		"activity1_id", "activity_type1", tl, []byte("input1"), 100, 10, 60)
+	di = addDecisionTaskScheduledEvent(msBuilder)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	var transferTasks []persistence.Task
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		transferTasks = args.Get(0).(*persistence.UpdateWorkflowExecutionRequest).TransferTasks
+	}).Once()
+
+	response, err := s.mockHistoryEngine.FixWorkflowExecution(context.Background(), &history.FixWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  &we,
+		IssueType:  workflow.WorkflowExecutionIssueTypeMissingTasks.Ptr(),
+	})
+	s.Nil(err)
+	s.NotEmpty(response.GetAction())
+	s.Equal(2, len(transferTasks))
+	decisionTask, ok := transferTasks[0].(*persistence.DecisionTask)
+	s.True(ok)
+	s.Equal(di.ScheduleID, decisionTask.ScheduleID)
+	activityTask, ok := transferTasks[1].(*persistence.ActivityTask)
+	s.True(ok)
+	s.Equal(*activityScheduledEvent.EventId, activityTask.ScheduleID)
+	s.Equal(domainID, activityTask.DomainID)
+}
+
+func (s *engineSuite) TestFixWorkflowExecution_IssueTypeNotSet() {
+	_, err := s.mockHistoryEngine.FixWorkflowExecution(context.Background(), &history.FixWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(validDomainID),
+		Execution: &workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr("wId"),
+			RunId:      common.StringPtr(validRunID),
+		},
+	})
+	s.IsType(&workflow.BadRequestError{}, err)
+}
+
 func (s *engineSuite) TestValidateSignalExternalWorkflowExecutionAttributes() {
 	var attributes *workflow.SignalExternalWorkflowExecutionDecisionAttributes
 	err := validateSignalExternalWorkflowExecutionAttributes(attributes)
@@ -4412,8 +5469,8 @@ func (s *engineSuite) TestValidateSignalExternalWorkflowExecutionAttributes() {
 	s.Nil(err)
 }
 
-func (s *engineSuite) getBuilder(domainID string, we workflow.WorkflowExecution) mutableState {
-	context, release, err := s.mockHistoryEngine.historyCache.getOrCreateWorkflowExecution(domainID, we)
+func (s *engineSuite) getBuilder(domainID string, we workflow.WorkflowExecution) MutableState {
+	context, release, err := s.mockHistoryEngine.historyCache.GetOrCreateWorkflowExecution(domainID, we)
 	if err != nil {
 		return nil
 	}
@@ -4422,7 +5479,7 @@ func (s *engineSuite) getBuilder(domainID string, we workflow.WorkflowExecution)
 	return context.msBuilder
 }
 
-func (s *engineSuite) getActivityScheduledEvent(msBuilder mutableState,
+func (s *engineSuite) getActivityScheduledEvent(msBuilder MutableState,
 	scheduleID int64) *workflow.HistoryEvent {
 
 	ai, ok := msBuilder.GetActivityInfo(scheduleID)
@@ -4438,7 +5495,7 @@ func (s *engineSuite) getActivityScheduledEvent(msBuilder mutableState,
 	return event
 }
 
-func (s *engineSuite) getActivityStartedEvent(msBuilder mutableState,
+func (s *engineSuite) getActivityStartedEvent(msBuilder MutableState,
 	scheduleID int64) *workflow.HistoryEvent {
 
 	ai, ok := msBuilder.GetActivityInfo(scheduleID)
@@ -4454,7 +5511,7 @@ func (s *engineSuite) getActivityStartedEvent(msBuilder mutableState,
 	return event
 }
 
-func (s *engineSuite) printHistory(builder mutableState) string {
+func (s *engineSuite) printHistory(builder MutableState) string {
 	history, err := builder.GetHistoryBuilder().Serialize()
 	if err != nil {
 		s.logger.Errorf("Error serializing history: %v", err)
@@ -4465,7 +5522,7 @@ func (s *engineSuite) printHistory(builder mutableState) string {
 	return history.String()
 }
 
-func addWorkflowExecutionStartedEventWithParent(builder mutableState, workflowExecution workflow.WorkflowExecution,
+func addWorkflowExecutionStartedEventWithParent(builder MutableState, workflowExecution workflow.WorkflowExecution,
 	workflowType, taskList string, input []byte, executionStartToCloseTimeout, taskStartToCloseTimeout int32,
 	parentInfo *history.ParentExecutionInfo, identity string) *workflow.HistoryEvent {
 	domainID := validDomainID
@@ -4488,23 +5545,23 @@ func addWorkflowExecutionStartedEventWithParent(builder mutableState, workflowEx
 	return e
 }
 
-func addWorkflowExecutionStartedEvent(builder mutableState, workflowExecution workflow.WorkflowExecution,
+func addWorkflowExecutionStartedEvent(builder MutableState, workflowExecution workflow.WorkflowExecution,
 	workflowType, taskList string, input []byte, executionStartToCloseTimeout, taskStartToCloseTimeout int32,
 	identity string) *workflow.HistoryEvent {
 	return addWorkflowExecutionStartedEventWithParent(builder, workflowExecution, workflowType, taskList, input,
 		executionStartToCloseTimeout, taskStartToCloseTimeout, nil, identity)
 }
 
-func addDecisionTaskScheduledEvent(builder mutableState) *decisionInfo {
+func addDecisionTaskScheduledEvent(builder MutableState) *DecisionInfo {
 	return builder.AddDecisionTaskScheduledEvent()
 }
 
-func addDecisionTaskStartedEvent(builder mutableState, scheduleID int64, taskList,
+func addDecisionTaskStartedEvent(builder MutableState, scheduleID int64, taskList,
 	identity string) *workflow.HistoryEvent {
 	return addDecisionTaskStartedEventWithRequestID(builder, scheduleID, validRunID, taskList, identity)
 }
 
-func addDecisionTaskStartedEventWithRequestID(builder mutableState, scheduleID int64, requestID string,
+func addDecisionTaskStartedEventWithRequestID(builder MutableState, scheduleID int64, requestID string,
 	taskList, identity string) *workflow.HistoryEvent {
 	e, _ := builder.AddDecisionTaskStartedEvent(scheduleID, requestID, &workflow.PollForDecisionTaskRequest{
 		TaskList: &workflow.TaskList{Name: common.StringPtr(taskList)},
@@ -4514,7 +5571,7 @@ func addDecisionTaskStartedEventWithRequestID(builder mutableState, scheduleID i
 	return e
 }
 
-func addDecisionTaskCompletedEvent(builder mutableState, scheduleID, startedID int64, context []byte,
+func addDecisionTaskCompletedEvent(builder MutableState, scheduleID, startedID int64, context []byte,
 	identity string) *workflow.HistoryEvent {
 	e := builder.AddDecisionTaskCompletedEvent(scheduleID, startedID, &workflow.RespondDecisionTaskCompletedRequest{
 		ExecutionContext: context,
@@ -4526,7 +5583,7 @@ func addDecisionTaskCompletedEvent(builder mutableState, scheduleID, startedID i
 	return e
 }
 
-func addActivityTaskScheduledEvent(builder mutableState, decisionCompletedID int64, activityID, activityType,
+func addActivityTaskScheduledEvent(builder MutableState, decisionCompletedID int64, activityID, activityType,
 	taskList string, input []byte, timeout, queueTimeout, heartbeatTimeout int32) (*workflow.HistoryEvent,
 	*persistence.ActivityInfo) {
 	return builder.AddActivityTaskScheduledEvent(decisionCompletedID, &workflow.ScheduleActivityTaskDecisionAttributes{
@@ -4541,13 +5598,13 @@ func addActivityTaskScheduledEvent(builder mutableState, decisionCompletedID int
 	})
 }
 
-func addActivityTaskStartedEvent(builder mutableState, scheduleID int64,
+func addActivityTaskStartedEvent(builder MutableState, scheduleID int64,
 	taskList, identity string) *workflow.HistoryEvent {
 	ai, _ := builder.GetActivityInfo(scheduleID)
 	return builder.AddActivityTaskStartedEvent(ai, scheduleID, validRunID, identity)
 }
 
-func addActivityTaskCompletedEvent(builder mutableState, scheduleID, startedID int64, result []byte,
+func addActivityTaskCompletedEvent(builder MutableState, scheduleID, startedID int64, result []byte,
 	identity string) *workflow.HistoryEvent {
 	e := builder.AddActivityTaskCompletedEvent(scheduleID, startedID, &workflow.RespondActivityTaskCompletedRequest{
 		Result:   result,
@@ -4557,7 +5614,7 @@ func addActivityTaskCompletedEvent(builder mutableState, scheduleID, startedID i
 	return e
 }
 
-func addActivityTaskFailedEvent(builder mutableState, scheduleID, startedID int64, reason string, details []byte,
+func addActivityTaskFailedEvent(builder MutableState, scheduleID, startedID int64, reason string, details []byte,
 	identity string) *workflow.HistoryEvent {
 	e := builder.AddActivityTaskFailedEvent(scheduleID, startedID, &workflow.RespondActivityTaskFailedRequest{
 		Reason:   common.StringPtr(reason),
@@ -4568,7 +5625,7 @@ func addActivityTaskFailedEvent(builder mutableState, scheduleID, startedID int6
 	return e
 }
 
-func addTimerStartedEvent(builder mutableState, decisionCompletedEventID int64, timerID string,
+func addTimerStartedEvent(builder MutableState, decisionCompletedEventID int64, timerID string,
 	timeOut int64) (*workflow.HistoryEvent, *persistence.TimerInfo) {
 	return builder.AddTimerStartedEvent(decisionCompletedEventID,
 		&workflow.StartTimerDecisionAttributes{
@@ -4577,11 +5634,11 @@ func addTimerStartedEvent(builder mutableState, decisionCompletedEventID int64,
 		})
 }
 
-func addTimerFiredEvent(builder mutableState, scheduleID int64, timerID string) *workflow.HistoryEvent {
+func addTimerFiredEvent(builder MutableState, scheduleID int64, timerID string) *workflow.HistoryEvent {
 	return builder.AddTimerFiredEvent(scheduleID, timerID)
 }
 
-func addRequestCancelInitiatedEvent(builder mutableState, decisionCompletedEventID int64,
+func addRequestCancelInitiatedEvent(builder MutableState, decisionCompletedEventID int64,
 	cancelRequestID, domain, workflowID, runID string) (*workflow.HistoryEvent, *persistence.RequestCancelInfo) {
 	event, rci := builder.AddRequestCancelExternalWorkflowExecutionInitiatedEvent(decisionCompletedEventID,
 		cancelRequestID, &workflow.RequestCancelExternalWorkflowExecutionDecisionAttributes{
@@ -4593,12 +5650,12 @@ func addRequestCancelInitiatedEvent(builder mutableState, decisionCompletedEvent
 	return event, rci
 }
 
-func addCancelRequestedEvent(builder mutableState, initiatedID int64, domain, workflowID, runID string) *workflow.HistoryEvent {
+func addCancelRequestedEvent(builder MutableState, initiatedID int64, domain, workflowID, runID string) *workflow.HistoryEvent {
 	event := builder.AddExternalWorkflowExecutionCancelRequested(initiatedID, domain, workflowID, runID)
 	return event
 }
 
-func addRequestSignalInitiatedEvent(builder mutableState, decisionCompletedEventID int64,
+func addRequestSignalInitiatedEvent(builder MutableState, decisionCompletedEventID int64,
 	signalRequestID, domain, workflowID, runID, signalName string, input, control []byte) (*workflow.HistoryEvent, *persistence.SignalInfo) {
 	event, si := builder.AddSignalExternalWorkflowExecutionInitiatedEvent(decisionCompletedEventID, signalRequestID,
 		&workflow.SignalExternalWorkflowExecutionDecisionAttributes{
@@ -4615,12 +5672,12 @@ func addRequestSignalInitiatedEvent(builder mutableState, decisionCompletedEvent
 	return event, si
 }
 
-func addSignaledEvent(builder mutableState, initiatedID int64, domain, workflowID, runID string, control []byte) *workflow.HistoryEvent {
+func addSignaledEvent(builder MutableState, initiatedID int64, domain, workflowID, runID string, control []byte) *workflow.HistoryEvent {
 	event := builder.AddExternalWorkflowExecutionSignaled(initiatedID, domain, workflowID, runID, control)
 	return event
 }
 
-func addStartChildWorkflowExecutionInitiatedEvent(builder mutableState, decisionCompletedID int64,
+func addStartChildWorkflowExecutionInitiatedEvent(builder MutableState, decisionCompletedID int64,
 	createRequestID, domain, workflowID, workflowType, tasklist string, input []byte,
 	executionStartToCloseTimeout, taskStartToCloseTimeout int32) (*workflow.HistoryEvent,
 	*persistence.ChildExecutionInfo) {
@@ -4638,7 +5695,7 @@ func addStartChildWorkflowExecutionInitiatedEvent(builder mutableState, decision
 		})
 }
 
-func addChildWorkflowExecutionStartedEvent(builder mutableState, initiatedID int64, domain, workflowID, runID string,
+func addChildWorkflowExecutionStartedEvent(builder MutableState, initiatedID int64, domain, workflowID, runID string,
 	workflowType string) *workflow.HistoryEvent {
 	event := builder.AddChildWorkflowExecutionStartedEvent(
 		common.StringPtr(domain),
@@ -4652,13 +5709,13 @@ func addChildWorkflowExecutionStartedEvent(builder mutableState, initiatedID int
 	return event
 }
 
-func addChildWorkflowExecutionCompletedEvent(builder mutableState, initiatedID int64, childExecution *workflow.WorkflowExecution,
+func addChildWorkflowExecutionCompletedEvent(builder MutableState, initiatedID int64, childExecution *workflow.WorkflowExecution,
 	attributes *workflow.WorkflowExecutionCompletedEventAttributes) *workflow.HistoryEvent {
 	event := builder.AddChildWorkflowExecutionCompletedEvent(initiatedID, childExecution, attributes)
 	return event
 }
 
-func addCompleteWorkflowEvent(builder mutableState, decisionCompletedEventID int64,
+func addCompleteWorkflowEvent(builder MutableState, decisionCompletedEventID int64,
 	result []byte) *workflow.HistoryEvent {
 	e := builder.AddCompletedWorkflowEvent(decisionCompletedEventID, &workflow.CompleteWorkflowExecutionDecisionAttributes{
 		Result: result,
@@ -4667,7 +5724,7 @@ func addCompleteWorkflowEvent(builder mutableState, decisionCompletedEventID int
 	return e
 }
 
-func createMutableState(ms mutableState) *persistence.WorkflowMutableState {
+func createMutableState(ms MutableState) *persistence.WorkflowMutableState {
 	builder := ms.(*mutableStateBuilder)
 	builder.FlushBufferedEvents()
 	info := copyWorkflowExecutionInfo(builder.executionInfo)
@@ -4745,6 +5802,10 @@ func copyWorkflowExecutionInfo(sourceInfo *persistence.WorkflowExecutionInfo) *p
 		DecisionStartedID:            sourceInfo.DecisionStartedID,
 		DecisionRequestID:            sourceInfo.DecisionRequestID,
 		DecisionTimeout:              sourceInfo.DecisionTimeout,
+		HistorySize:                  sourceInfo.HistorySize,
+		IsolationGroup:               sourceInfo.IsolationGroup,
+		Priority:                     sourceInfo.Priority,
+		FirstRunID:                   sourceInfo.FirstRunID,
 	}
 }
 
@@ -43,7 +43,7 @@ type (
 		// stop signal channel
 		closeChan chan bool
 		// this channel will never close
-		eventsChan chan *historyEventNotification
+		eventsChan chan *HistoryEventNotification
 		// function which calculate the shard ID from given workflow ID
 		workflowIDToShardID func(string) int
 
@@ -66,8 +66,8 @@ func newWorkflowIdentifier(domainID string, workflowExecution *gen.WorkflowExecu
 }
 
 func newHistoryEventNotification(domainID string, workflowExecution *gen.WorkflowExecution,
-	lastFirstEventID int64, nextEventID int64, isWorkflowRunning bool) *historyEventNotification {
-	return &historyEventNotification{
+	lastFirstEventID int64, nextEventID int64, isWorkflowRunning bool) *HistoryEventNotification {
+	return &HistoryEventNotification{
 		workflowIdentifier: workflowIdentifier{
 			domainID:   domainID,
 			workflowID: *workflowExecution.WorkflowId,
@@ -81,7 +81,7 @@ func newHistoryEventNotification(domainID string, workflowExecution *gen.Workflo
 
 func newHistoryEventNotifier(metrics metrics.Client, workflowIDToShardID func(string) int) *historyEventNotifierImpl {
 	hashFn := func(key interface{}) uint32 {
-		id, ok := key.(historyEventNotification)
+		id, ok := key.(HistoryEventNotification)
 		if !ok {
 			return 0
 		}
@@ -91,7 +91,7 @@ func newHistoryEventNotifier(metrics metrics.Client, workflowIDToShardID func(st
 		metrics:    metrics,
 		status:     common.DaemonStatusInitialized,
 		closeChan:  make(chan bool),
-		eventsChan: make(chan *historyEventNotification, eventsChanSize),
+		eventsChan: make(chan *HistoryEventNotification, eventsChanSize),
 
 		workflowIDToShardID: workflowIDToShardID,
 
@@ -100,16 +100,16 @@ func newHistoryEventNotifier(metrics metrics.Client, workflowIDToShardID func(st
 }
 
 func (notifier *historyEventNotifierImpl) WatchHistoryEvent(
-	identifier *workflowIdentifier) (string, chan *historyEventNotification, error) {
+	identifier *workflowIdentifier) (string, chan *HistoryEventNotification, error) {
 
-	channel := make(chan *historyEventNotification, 1)
+	channel := make(chan *HistoryEventNotification, 1)
 	subscriberID := uuid.New()
-	subscribers := map[string]chan *historyEventNotification{
+	subscribers := map[string]chan *HistoryEventNotification{
 		subscriberID: channel,
 	}
 
 	_, _, err := notifier.eventsPubsubs.PutOrDo(*identifier, subscribers, func(key interface{}, value interface{}) error {
-		subscribers := value.(map[string]chan *historyEventNotification)
+		subscribers := value.(map[string]chan *HistoryEventNotification)
 
 		if _, ok := subscribers[subscriberID]; ok {
 			// UUID collision
@@ -133,7 +133,7 @@ func (notifier *historyEventNotifierImpl) UnwatchHistoryEvent(
 
 	success := true
 	notifier.eventsPubsubs.RemoveIf(*identifier, func(key interface{}, value interface{}) bool {
-		subscribers := value.(map[string]chan *historyEventNotification)
+		subscribers := value.(map[string]chan *HistoryEventNotification)
 
 		if _, ok := subscribers[subscriberID]; !ok {
 			// cannot find the subscribe ID, which means there is a bug
@@ -155,13 +155,13 @@ func (notifier *historyEventNotifierImpl) UnwatchHistoryEvent(
 	return nil
 }
 
-func (notifier *historyEventNotifierImpl) dispatchHistoryEventNotification(event *historyEventNotification) {
+func (notifier *historyEventNotifierImpl) dispatchHistoryEventNotification(event *HistoryEventNotification) {
 	identifier := &event.workflowIdentifier
 
 	timer := notifier.metrics.StartTimer(metrics.HistoryEventNotificationScope, metrics.HistoryEventNotificationFanoutLatency)
 	defer timer.Stop()
 	notifier.eventsPubsubs.GetAndDo(*identifier, func(key interface{}, value interface{}) error {
-		subscribers := value.(map[string]chan *historyEventNotification)
+		subscribers := value.(map[string]chan *HistoryEventNotification)
 
 		for _, channel := range subscribers {
 			select {
@@ -175,7 +175,7 @@ func (notifier *historyEventNotifierImpl) dispatchHistoryEventNotification(event
 	})
 }
 
-func (notifier *historyEventNotifierImpl) enqueueHistoryEventNotification(event *historyEventNotification) {
+func (notifier *historyEventNotifierImpl) enqueueHistoryEventNotification(event *HistoryEventNotification) {
 	// set the timestamp just before enqueuing the event
 	event.timestamp = time.Now()
 	select {
@@ -222,6 +222,6 @@ func (notifier *historyEventNotifierImpl) Stop() {
 	close(notifier.closeChan)
 }
 
-func (notifier *historyEventNotifierImpl) NotifyNewHistoryEvent(event *historyEventNotification) {
+func (notifier *historyEventNotifierImpl) NotifyNewHistoryEvent(event *HistoryEventNotification) {
 	notifier.enqueueHistoryEventNotification(event)
 }
@@ -35,16 +35,23 @@ import (
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/serviceerror"
 )
 
 var (
 	errNoHistoryFound = errors.New("no history events found")
 )
 
+const (
+	// defaultPostCloseEventsTTL keeps the events replicated for closed workflows when the retention of their domain
+	// is unknown, a TTL of 0 would keep them forever
+	defaultPostCloseEventsTTL = 7 * 24 * time.Hour
+)
+
 type (
-	conflictResolverProvider func(ctx *workflowExecutionContext, logger bark.Logger) conflictResolver
-	stateBuilderProvider     func(msBuilder mutableState, logger bark.Logger) stateBuilder
-	mutableStateProvider     func(version int64, logger bark.Logger) mutableState
+	conflictResolverProvider func(ctx *WorkflowExecutionContext, logger bark.Logger) conflictResolver
+	stateBuilderProvider     func(msBuilder MutableState, logger bark.Logger) stateBuilder
+	mutableStateProvider     func(version int64, logger bark.Logger) MutableState
 
 	historyReplicator struct {
 		shard             ShardContext
@@ -57,6 +64,12 @@ type (
 		metricsClient     metrics.Client
 		logger            bark.Logger
 
+		// taggedMetrics caches metrics clients tagged with source cluster, domain and event type
+		taggedMetrics *metricsClientCache
+
+		// records the externally generated events replicated for runs which are already closed
+		postCloseEventsMgr persistence.PostCloseEventsManager
+
 		getNewConflictResolver conflictResolverProvider
 		getNewStateBuilder     stateBuilderProvider
 		getNewMutableState     mutableStateProvider
@@ -83,7 +96,9 @@ var (
 )
 
 func newHistoryReplicator(shard ShardContext, historyEngine *historyEngineImpl, historyCache *historyCache, domainCache cache.DomainCache,
-	historyMgr persistence.HistoryManager, logger bark.Logger) *historyReplicator {
+	historyMgr persistence.HistoryManager, postCloseEventsMgr persistence.PostCloseEventsManager,
+	logger bark.Logger) *historyReplicator {
+	taggedMetrics := newMetricsClientCache(shard.GetMetricsClient())
 	replicator := &historyReplicator{
 		shard:             shard,
 		historyEngine:     historyEngine,
@@ -93,15 +108,20 @@ func newHistoryReplicator(shard ShardContext, historyEngine *historyEngineImpl,
 		historySerializer: persistence.NewJSONHistorySerializer(),
 		clusterMetadata:   shard.GetService().GetClusterMetadata(),
 		metricsClient:     shard.GetMetricsClient(),
-		logger:            logger.WithField(logging.TagWorkflowComponent, logging.TagValueHistoryReplicatorComponent),
+		logger:            newThrottledLogger(logger, shard.GetConfig()).WithField(logging.TagWorkflowComponent, logging.TagValueHistoryReplicatorComponent),
+		taggedMetrics:     taggedMetrics,
 
-		getNewConflictResolver: func(context *workflowExecutionContext, logger bark.Logger) conflictResolver {
+		postCloseEventsMgr: postCloseEventsMgr,
+
+		getNewConflictResolver: func(context *WorkflowExecutionContext, logger bark.Logger) conflictResolver {
 			return newConflictResolver(shard, context, historyMgr, logger)
 		},
-		getNewStateBuilder: func(msBuilder mutableState, logger bark.Logger) stateBuilder {
-			return newStateBuilder(shard, msBuilder, logger)
+		getNewStateBuilder: func(msBuilder MutableState, logger bark.Logger) stateBuilder {
+			sBuilder := newStateBuilder(shard, msBuilder, logger)
+			sBuilder.taggedMetrics = taggedMetrics
+			return sBuilder
 		},
-		getNewMutableState: func(version int64, logger bark.Logger) mutableState {
+		getNewMutableState: func(version int64, logger bark.Logger) MutableState {
 			return newMutableStateBuilderWithReplicationState(shard.GetConfig(), logger, version)
 		},
 	}
@@ -109,6 +129,39 @@ func newHistoryReplicator(shard ShardContext, historyEngine *historyEngineImpl,
 	return replicator
 }
 
+// recordEndToEndLatency emits the time between the source cluster publishing the replication task
+// and this cluster applying it, tagged by source cluster and domain.
+func (r *historyReplicator) recordEndToEndLatency(request *h.ReplicateEventsRequest) {
+	if request == nil || request.SourceTimestamp == nil {
+		return
+	}
+	latency := time.Since(time.Unix(0, request.GetSourceTimestamp()))
+	if latency < 0 {
+		// clock skew between clusters
+		latency = 0
+	}
+	client := r.taggedMetrics.get(
+		metrics.SourceClusterTagName, request.GetSourceCluster(),
+		metrics.DomainTagName, getDomainNameForMetrics(r.domainCache, request.GetDomainUUID()),
+	)
+	client.RecordTimer(metrics.ReplicateHistoryEventsScope, metrics.ReplicationEventsEndToEndLatency, latency)
+}
+
+// updateRetryErrorMetric counts the retry errors returned to the replicator by kind, so it is visible why
+// replication tasks are retried
+func (r *historyReplicator) updateRetryErrorMetric(err error) {
+	switch serviceerror.Cause(err) {
+	case ErrRetryEntityNotExists:
+		r.metricsClient.IncCounter(metrics.ReplicateHistoryEventsScope, metrics.RetryEntityNotExistsCounter)
+	case ErrRetryBufferEvents:
+		r.metricsClient.IncCounter(metrics.ReplicateHistoryEventsScope, metrics.RetryBufferEventsCounter)
+	case ErrRetryExistingWorkflow:
+		r.metricsClient.IncCounter(metrics.ReplicateHistoryEventsScope, metrics.RetryExistingWorkflowCounter)
+	case ErrRetryExecutionAlreadyStarted:
+		r.metricsClient.IncCounter(metrics.ReplicateHistoryEventsScope, metrics.RetryExecutionAlreadyStartedCounter)
+	}
+}
+
 func (r *historyReplicator) ApplyEvents(ctx context.Context, request *h.ReplicateEventsRequest) (retError error) {
 	logger := r.logger.WithFields(bark.Fields{
 		logging.TagWorkflowExecutionID: request.WorkflowExecution.GetWorkflowId(),
@@ -126,19 +179,24 @@ func (r *historyReplicator) ApplyEvents(ctx context.Context, request *h.Replicat
 	)
 
 	defer func() {
-		if retError != nil {
-			switch retError.(type) {
-			case *shared.EntityNotExistsError:
-				logger.Debugf("Encounter EntityNotExistsError: %v", retError)
-				retError = ErrRetryEntityNotExists
-			case *shared.WorkflowExecutionAlreadyStartedError:
-				logger.Debugf("Encounter WorkflowExecutionAlreadyStartedError: %v", retError)
-				retError = ErrRetryExecutionAlreadyStarted
-			case *persistence.WorkflowExecutionAlreadyStartedError:
-				logger.Debugf("Encounter WorkflowExecutionAlreadyStartedError: %v", retError)
-				retError = ErrRetryExecutionAlreadyStarted
-			}
+		if retError == nil {
+			r.recordEndToEndLatency(request)
+			return
+		}
+		// the errors may be wrapped on their way up from the persistence, they are matched by the error they
+		// originate from
+		switch serviceerror.Cause(retError).(type) {
+		case *shared.EntityNotExistsError:
+			logger.Debugf("Encounter EntityNotExistsError: %v", retError)
+			retError = ErrRetryEntityNotExists
+		case *shared.WorkflowExecutionAlreadyStartedError:
+			logger.Debugf("Encounter WorkflowExecutionAlreadyStartedError: %v", retError)
+			retError = ErrRetryExecutionAlreadyStarted
+		case *persistence.WorkflowExecutionAlreadyStartedError:
+			logger.Debugf("Encounter WorkflowExecutionAlreadyStartedError: %v", retError)
+			retError = ErrRetryExecutionAlreadyStarted
 		}
+		r.updateRetryErrorMetric(retError)
 	}()
 
 	if request == nil || request.History == nil || len(request.History.Events) == 0 {
@@ -152,13 +210,20 @@ func (r *historyReplicator) ApplyEvents(ctx context.Context, request *h.Replicat
 	}
 
 	execution := *request.WorkflowExecution
-	context, release, err := r.historyCache.getOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	context, release, err := r.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
 	if err != nil {
 		// for get workflow execution context, with valid run id
 		// err will not be of type EntityNotExistsError
 		return err
 	}
-	defer func() { release(retError) }()
+	defer func() {
+		if retError == nil && context.msBuilder != nil && !context.msBuilder.IsWorkflowExecutionRunning() {
+			// the replicated events left the workflow closed, drop the cached mutable state so
+			// standby reads load the closed execution from persistence instead of the cache
+			context.clear()
+		}
+		release(retError)
+	}()
 
 	firstEvent := request.History.Events[0]
 	switch firstEvent.GetEventType() {
@@ -170,7 +235,7 @@ func (r *historyReplicator) ApplyEvents(ctx context.Context, request *h.Replicat
 			r.metricsClient.IncCounter(metrics.ReplicateHistoryEventsScope, metrics.DuplicateReplicationEventsCounter)
 			return nil
 		}
-		if _, ok := err.(*shared.EntityNotExistsError); !ok {
+		if !isEntityNotExistsError(err) {
 			// GetWorkflowExecution failed with some transient error. Return err so we can retry the task later
 			return err
 		}
@@ -181,7 +246,7 @@ func (r *historyReplicator) ApplyEvents(ctx context.Context, request *h.Replicat
 		// the continue as new + start workflow execution combination will also be processed here
 		msBuilder, err := context.loadWorkflowExecution()
 		if err != nil {
-			if _, ok := err.(*shared.EntityNotExistsError); !ok {
+			if !isEntityNotExistsError(err) {
 				return err
 			}
 			// mutable state for the target workflow ID & run ID combination does not exist
@@ -205,7 +270,88 @@ func (r *historyReplicator) ApplyEvents(ctx context.Context, request *h.Replicat
 	}
 }
 
-func (r *historyReplicator) ApplyStartEvent(ctx context.Context, context *workflowExecutionContext,
+// SyncActivity applies the retry state of an activity synced from the active cluster.  The attempt of an activity
+// being retried is kept in mutable state only, the retry timer created here lets the failover processor resume the
+// retry from the synced attempt once the domain fails over to this cluster.
+func (r *historyReplicator) SyncActivity(ctx context.Context, request *h.SyncActivityRequest) (retError error) {
+	domainID, err := validateDomainUUID(request.DomainId)
+	if err != nil {
+		return err
+	}
+
+	execution := shared.WorkflowExecution{
+		WorkflowId: request.WorkflowId,
+		RunId:      request.RunId,
+	}
+	context, release, err := r.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	msBuilder, err := context.loadWorkflowExecution()
+	if err != nil {
+		if isEntityNotExistsError(err) {
+			// the sync tasks are published to every cluster consuming the source, the domain may not be placed on
+			// this one
+			return nil
+		}
+		return err
+	}
+	if !msBuilder.IsWorkflowExecutionRunning() || request.GetVersion() < msBuilder.GetCurrentVersion() {
+		// the activity state is superseded by the events replicated from a newer failover version
+		return nil
+	}
+
+	ai, isPending := msBuilder.GetActivityInfo(request.GetScheduledId())
+	if !isPending {
+		// the activity is completed already, or the sync task arrived ahead of the events scheduling it which are
+		// kept in the replication buffer, the next sync of the activity carries the state again
+		return nil
+	}
+	if ai.Attempt > request.GetAttempt() || (ai.Attempt == request.GetAttempt() &&
+		(ai.StartedID != common.EmptyEventID || request.GetStartedId() == common.EmptyEventID)) {
+		// duplicate or stale sync task
+		return nil
+	}
+
+	ai.Version = request.GetVersion()
+	ai.Attempt = request.GetAttempt()
+	ai.ScheduledTime = time.Unix(0, request.GetScheduledTime())
+	ai.StartedID = request.GetStartedId()
+	ai.StartedTime = time.Time{}
+	if ai.StartedID != common.EmptyEventID {
+		ai.StartedTime = time.Unix(0, request.GetStartedTime())
+	}
+	if err := msBuilder.UpdateActivity(ai); err != nil {
+		return err
+	}
+	// timer tasks are created with the current version of the mutable state
+	msBuilder.UpdateReplicationStateVersion(request.GetVersion(), false)
+
+	var timerTasks []persistence.Task
+	if ai.StartedID == common.EmptyEventID && ai.Attempt > 0 {
+		timerTasks = append(timerTasks, &persistence.RetryTimerTask{
+			VisibilityTimestamp: ai.ScheduledTime,
+			EventID:             ai.ScheduleID,
+			Attempt:             ai.Attempt,
+		})
+	}
+
+	transactionID, err := r.shard.GetNextTransferTaskID()
+	if err != nil {
+		return err
+	}
+	if err := context.updateHelper(nil, timerTasks, transactionID, time.Now(), false, nil, ""); err != nil {
+		return err
+	}
+
+	sourceCluster := r.clusterMetadata.ClusterNameForFailoverVersion(request.GetVersion())
+	r.historyEngine.timerProcessor.NotifyNewTimers(sourceCluster, r.shard.GetCurrentTime(sourceCluster), timerTasks)
+	return nil
+}
+
+func (r *historyReplicator) ApplyStartEvent(ctx context.Context, context *WorkflowExecutionContext,
 	request *h.ReplicateEventsRequest,
 	logger bark.Logger) error {
 	msBuilder := r.getNewMutableState(request.GetVersion(), logger)
@@ -242,8 +388,8 @@ func (r *historyReplicator) ApplyOtherEventsMissingMutableState(ctx context.Cont
 	return ErrRetryEntityNotExists
 }
 
-func (r *historyReplicator) ApplyOtherEventsVersionChecking(ctx context.Context, context *workflowExecutionContext,
-	msBuilder mutableState, request *h.ReplicateEventsRequest, logger bark.Logger) (mutableState, error) {
+func (r *historyReplicator) ApplyOtherEventsVersionChecking(ctx context.Context, context *WorkflowExecutionContext,
+	msBuilder MutableState, request *h.ReplicateEventsRequest, logger bark.Logger) (MutableState, error) {
 	var err error
 	// check if to buffer / drop / conflict resolution
 	incomingVersion := request.GetVersion()
@@ -254,6 +400,10 @@ func (r *historyReplicator) ApplyOtherEventsVersionChecking(ctx context.Context,
 		// TODO: We need to replay external events like signal to the new version
 		logger.Info("Dropping stale replication task.")
 		r.metricsClient.IncCounter(metrics.ReplicateHistoryEventsScope, metrics.StaleReplicationEventsCounter)
+		if !msBuilder.IsWorkflowExecutionRunning() && !isReplicationTaskApplied(rState, request) {
+			// the run was closed by the new version, the events raced with the close in the source cluster
+			return nil, r.recordPostCloseEvents(msBuilder, request, logger)
+		}
 		return nil, nil
 	}
 
@@ -314,8 +464,8 @@ func (r *historyReplicator) ApplyOtherEventsVersionChecking(ctx context.Context,
 	return msBuilder, nil
 }
 
-func (r *historyReplicator) ApplyOtherEvents(ctx context.Context, context *workflowExecutionContext,
-	msBuilder mutableState, request *h.ReplicateEventsRequest, logger bark.Logger) error {
+func (r *historyReplicator) ApplyOtherEvents(ctx context.Context, context *WorkflowExecutionContext,
+	msBuilder MutableState, request *h.ReplicateEventsRequest, logger bark.Logger) error {
 	var err error
 	firstEventID := request.GetFirstEventId()
 	if firstEventID < msBuilder.GetNextEventID() {
@@ -378,8 +528,8 @@ func (r *historyReplicator) ApplyOtherEvents(ctx context.Context, context *workf
 	return err
 }
 
-func (r *historyReplicator) ApplyReplicationTask(ctx context.Context, context *workflowExecutionContext,
-	msBuilder mutableState, request *h.ReplicateEventsRequest, logger bark.Logger) error {
+func (r *historyReplicator) ApplyReplicationTask(ctx context.Context, context *WorkflowExecutionContext,
+	msBuilder MutableState, request *h.ReplicateEventsRequest, logger bark.Logger) error {
 
 	domainID, err := validateDomainUUID(request.DomainUUID)
 	if err != nil {
@@ -390,6 +540,7 @@ func (r *historyReplicator) ApplyReplicationTask(ctx context.Context, context *w
 	}
 
 	execution := *request.WorkflowExecution
+	wasRunning := msBuilder.IsWorkflowExecutionRunning()
 
 	requestID := uuid.New() // requestID used for start workflow execution request.  This is not on the history event.
 	sBuilder := r.getNewStateBuilder(msBuilder, logger)
@@ -431,12 +582,15 @@ func (r *historyReplicator) ApplyReplicationTask(ctx context.Context, context *w
 	if err == nil {
 		now := time.Unix(0, lastEvent.GetTimestamp())
 		r.notify(request.GetSourceCluster(), now, sBuilder.getTransferTasks(), sBuilder.getTimerTasks())
+		if wasRunning && !msBuilder.IsWorkflowExecutionRunning() {
+			r.notifyWorkflowClosed(domainID, execution, msBuilder)
+		}
 	}
 
 	return err
 }
 
-func (r *historyReplicator) FlushBuffer(ctx context.Context, context *workflowExecutionContext, msBuilder mutableState,
+func (r *historyReplicator) FlushBuffer(ctx context.Context, context *WorkflowExecutionContext, msBuilder MutableState,
 	logger bark.Logger) error {
 	domainID := msBuilder.GetExecutionInfo().DomainID
 	execution := shared.WorkflowExecution{
@@ -488,8 +642,8 @@ func (r *historyReplicator) FlushBuffer(ctx context.Context, context *workflowEx
 	return nil
 }
 
-func (r *historyReplicator) replicateWorkflowStarted(ctx context.Context, context *workflowExecutionContext,
-	msBuilder mutableState, di *decisionInfo,
+func (r *historyReplicator) replicateWorkflowStarted(ctx context.Context, context *WorkflowExecutionContext,
+	msBuilder MutableState, di *DecisionInfo,
 	sourceCluster string, history *shared.History, sBuilder stateBuilder, logger bark.Logger) error {
 	executionInfo := msBuilder.GetExecutionInfo()
 	domainID := executionInfo.DomainID
@@ -537,6 +691,7 @@ func (r *historyReplicator) replicateWorkflowStarted(ctx context.Context, contex
 
 	// TODO this pile of logic should be merge into workflow execution context / mutable state
 	executionInfo.LastFirstEventID = firstEvent.GetEventId()
+	executionInfo.HistorySize = int64(len(serializedHistory.Data))
 	executionInfo.NextEventID = lastEvent.GetEventId() + 1
 	incomingVersion := firstEvent.GetVersion()
 	msBuilder.UpdateReplicationStateLastEventID(sourceCluster, incomingVersion, lastEvent.GetEventId())
@@ -587,6 +742,10 @@ func (r *historyReplicator) replicateWorkflowStarted(ctx context.Context, contex
 			ContinueAsNew:               !isBrandNew,
 			PreviousRunID:               prevRunID,
 			ReplicationState:            replicationState,
+			Tags:                        executionInfo.Tags,
+			HistorySize:                 executionInfo.HistorySize,
+			Priority:                    executionInfo.Priority,
+			FirstRunID:                  executionInfo.FirstRunID,
 		})
 		return err
 	}
@@ -604,13 +763,13 @@ func (r *historyReplicator) replicateWorkflowStarted(ctx context.Context, contex
 	if err == nil {
 		return nil
 	}
-	if _, ok := err.(*persistence.WorkflowExecutionAlreadyStartedError); !ok {
+	var errExist *persistence.WorkflowExecutionAlreadyStartedError
+	if !serviceerror.As(err, &errExist) {
 		deleteHistory()
 		return err
 	}
 
 	// we have WorkflowExecutionAlreadyStartedError
-	errExist := err.(*persistence.WorkflowExecutionAlreadyStartedError)
 	currentRunID := errExist.RunID
 	currentState := errExist.State
 	currentStartVersion := errExist.StartVersion
@@ -659,7 +818,7 @@ func (r *historyReplicator) replicateWorkflowStarted(ctx context.Context, contex
 	// same workflow ID, same shard
 	err = r.terminateWorkflow(ctx, domainID, executionInfo.WorkflowID, currentRunID)
 	if err != nil {
-		if _, ok := err.(*shared.EntityNotExistsError); !ok {
+		if !isEntityNotExistsError(err) {
 			return err
 		}
 		// if workflow is completed just when the call is made, will get EntityNotExistsError
@@ -691,7 +850,7 @@ func (r *historyReplicator) flushCurrentWorkflowBuffer(ctx context.Context, doma
 }
 
 func (r *historyReplicator) conflictResolutionTerminateContinueAsNew(ctx context.Context,
-	msBuilder mutableState, logger bark.Logger) (retError error) {
+	msBuilder MutableState, logger bark.Logger) (retError error) {
 	// this function aims to solve the edge case when this workflow, when going through
 	// reset, has already started a next generation (continue as new-ed workflow)
 
@@ -808,6 +967,89 @@ func (r *historyReplicator) conflictResolutionTerminateContinueAsNew(ctx context
 	return err
 }
 
+// recordPostCloseEvents keeps the externally generated events of a replication task which cannot be applied to the
+// run because it is already closed, so that they do not silently disappear
+func (r *historyReplicator) recordPostCloseEvents(msBuilder MutableState, request *h.ReplicateEventsRequest,
+	logger bark.Logger) error {
+	var events []*shared.HistoryEvent
+	for _, event := range request.History.Events {
+		if isExternallyGeneratedEvent(event) {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	executionInfo := msBuilder.GetExecutionInfo()
+	// the events are kept as long as the history of the closed run
+	ttl := defaultPostCloseEventsTTL
+	domainEntry, err := r.domainCache.GetDomainByID(executionInfo.DomainID)
+	if err != nil {
+		if !isEntityNotExistsError(err) {
+			return err
+		}
+	} else if retentionInDays := domainEntry.GetConfig().Retention; retentionInDays > 0 {
+		ttl = time.Duration(retentionInDays) * time.Hour * 24
+	}
+
+	serializedEvents, err := r.Serialize(&shared.History{Events: events})
+	if err != nil {
+		return err
+	}
+	err = r.postCloseEventsMgr.RecordPostCloseEvents(&persistence.RecordPostCloseEventsRequest{
+		DomainID: executionInfo.DomainID,
+		Execution: shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(executionInfo.WorkflowID),
+			RunId:      common.StringPtr(executionInfo.RunID),
+		},
+		Batch: persistence.PostCloseEventBatch{
+			Version:       request.GetVersion(),
+			FirstEventID:  request.GetFirstEventId(),
+			SourceCluster: request.GetSourceCluster(),
+			RecordedTime:  time.Now(),
+			Events:        serializedEvents,
+		},
+		TTLSeconds: int64(ttl / time.Second),
+	})
+	if err != nil {
+		r.logError(logger, "Failed to record events replicated for closed workflow.", err)
+		return err
+	}
+
+	logger.Warnf("Recorded %v events replicated for closed workflow.", len(events))
+	r.metricsClient.AddCounter(metrics.ReplicateHistoryEventsScope, metrics.PostCloseEventsRecordedCounter,
+		int64(len(events)))
+	return nil
+}
+
+// isReplicationTaskApplied returns whether the events of the replication task were already applied to the run, which is
+// the case of a task redelivered after its events were replicated from the source cluster
+func isReplicationTaskApplied(rState *persistence.ReplicationState, request *h.ReplicateEventsRequest) bool {
+	info, ok := rState.LastReplicationInfo[request.GetSourceCluster()]
+	if !ok {
+		return false
+	}
+	if info.Version != request.GetVersion() {
+		return info.Version > request.GetVersion()
+	}
+	return request.GetFirstEventId() <= info.LastEventID
+}
+
+// isExternallyGeneratedEvent returns whether the event carries data from outside of the workflow, which is lost if
+// the event is dropped
+func isExternallyGeneratedEvent(event *shared.HistoryEvent) bool {
+	switch event.GetEventType() {
+	case shared.EventTypeWorkflowExecutionSignaled,
+		shared.EventTypeWorkflowExecutionCancelRequested,
+		shared.EventTypeActivityTaskCompleted,
+		shared.EventTypeActivityTaskFailed,
+		shared.EventTypeActivityTaskCanceled:
+		return true
+	}
+	return false
+}
+
 func (r *historyReplicator) Serialize(history *shared.History) (*persistence.SerializedHistoryEventBatch, error) {
 	eventBatch := persistence.NewHistoryEventBatch(persistence.GetDefaultHistoryVersion(), history.Events)
 	h, err := r.historySerializer.Serialize(eventBatch)
@@ -819,9 +1061,9 @@ func (r *historyReplicator) Serialize(history *shared.History) (*persistence.Ser
 
 // func (r *historyReplicator) getCurrentWorkflowInfo(domainID string, workflowID string) (runID string, lastWriteVersion int64, closeStatus int, retError error) {
 func (r *historyReplicator) getCurrentWorkflowMutableState(ctx context.Context, domainID string,
-	workflowID string) (*workflowExecutionContext, mutableState, releaseWorkflowExecutionFunc, error) {
+	workflowID string) (*WorkflowExecutionContext, MutableState, ReleaseWorkflowExecutionFunc, error) {
 	// we need to check the current workflow execution
-	context, release, err := r.historyCache.getOrCreateWorkflowExecutionWithTimeout(ctx,
+	context, release, err := r.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx,
 		domainID,
 		// only use the workflow ID, to get the current running one
 		shared.WorkflowExecution{WorkflowId: common.StringPtr(workflowID)},
@@ -869,8 +1111,29 @@ func (r *historyReplicator) notify(clusterName string, now time.Time, transferTa
 	r.historyEngine.timerProcessor.NotifyNewTimers(clusterName, now, timerTasks)
 }
 
+// notifyWorkflowClosed wakes up the pollers of the workflow execution closed by replicated events, the update
+// of the mutable state does not notify them when the execution is created already closed
+func (r *historyReplicator) notifyWorkflowClosed(domainID string, execution shared.WorkflowExecution,
+	msBuilder MutableState) {
+	if r.historyEngine.historyEventNotifier == nil {
+		return
+	}
+	r.historyEngine.historyEventNotifier.NotifyNewHistoryEvent(newHistoryEventNotification(
+		domainID,
+		&execution,
+		msBuilder.GetLastFirstEventID(),
+		msBuilder.GetNextEventID(),
+		false,
+	))
+}
+
 func (r *historyReplicator) logError(logger bark.Logger, msg string, err error) {
 	logger.WithFields(bark.Fields{
 		logging.TagErr: err,
 	}).Error(msg)
 }
+
+func isEntityNotExistsError(err error) bool {
+	var notExists *shared.EntityNotExistsError
+	return serviceerror.As(err, &notExists)
+}
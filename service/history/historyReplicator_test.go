@@ -44,6 +44,7 @@ import (
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/common/serviceerror"
 )
 
 type (
@@ -52,6 +53,7 @@ type (
 		logger              bark.Logger
 		mockExecutionMgr    *mocks.ExecutionManager
 		mockHistoryMgr      *mocks.HistoryManager
+		mockPostCloseMgr    *mocks.PostCloseEventsManager
 		mockShardManager    *mocks.ShardManager
 		mockClusterMetadata *mocks.ClusterMetadata
 		mockProducer        *mocks.KafkaProducer
@@ -63,8 +65,18 @@ type (
 
 		historyReplicator *historyReplicator
 	}
+
+	// recordingHistoryEventNotifier records the notifications instead of dispatching them to watchers
+	recordingHistoryEventNotifier struct {
+		historyEventNotifier
+		notifications []*HistoryEventNotification
+	}
 )
 
+func (n *recordingHistoryEventNotifier) NotifyNewHistoryEvent(event *HistoryEventNotification) {
+	n.notifications = append(n.notifications, event)
+}
+
 func TestHistoryReplicatorSuite(t *testing.T) {
 	s := new(historyReplicatorSuite)
 	suite.Run(t, s)
@@ -86,6 +98,7 @@ func (s *historyReplicatorSuite) SetupTest() {
 	log2.Level = log.DebugLevel
 	s.logger = bark.NewLoggerFromLogrus(log2)
 	s.mockHistoryMgr = &mocks.HistoryManager{}
+	s.mockPostCloseMgr = &mocks.PostCloseEventsManager{}
 	s.mockExecutionMgr = &mocks.ExecutionManager{}
 	s.mockClusterMetadata = &mocks.ClusterMetadata{}
 	s.mockShardManager = &mocks.ShardManager{}
@@ -124,12 +137,14 @@ func (s *historyReplicatorSuite) SetupTest() {
 		hSerializerFactory: persistence.NewHistorySerializerFactory(),
 		metricsClient:      s.mockShard.GetMetricsClient(),
 	}
-	s.historyReplicator = newHistoryReplicator(s.mockShard, h, historyCache, s.mockShard.domainCache, s.mockHistoryMgr, s.logger)
+	s.historyReplicator = newHistoryReplicator(s.mockShard, h, historyCache, s.mockShard.domainCache, s.mockHistoryMgr,
+		s.mockPostCloseMgr, s.logger)
 }
 
 func (s *historyReplicatorSuite) TearDownTest() {
 	s.historyReplicator = nil
 	s.mockHistoryMgr.AssertExpectations(s.T())
+	s.mockPostCloseMgr.AssertExpectations(s.T())
 	s.mockExecutionMgr.AssertExpectations(s.T())
 	s.mockShardManager.AssertExpectations(s.T())
 	s.mockProducer.AssertExpectations(s.T())
@@ -140,6 +155,86 @@ func (s *historyReplicatorSuite) TestApplyStartEvent() {
 
 }
 
+func (s *historyReplicatorSuite) TestRecordEndToEndLatency_CachedPerSourceClusterAndDomain() {
+	domainName := "some random domain name"
+	domainID := validDomainID
+
+	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: domainID}).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID, Name: domainName},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestAlternativeClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestAlternativeClusterName},
+				},
+			},
+			IsGlobalDomain: true,
+			TableVersion:   persistence.DomainTableVersionV1,
+		}, nil,
+	).Once()
+
+	// no source timestamp, nothing to record
+	s.historyReplicator.recordEndToEndLatency(&h.ReplicateEventsRequest{
+		SourceCluster: common.StringPtr(cluster.TestAlternativeClusterName),
+		DomainUUID:    common.StringPtr(domainID),
+	})
+	s.Equal(0, s.historyReplicator.taggedMetrics.size())
+
+	for i := 0; i < 2; i++ {
+		s.historyReplicator.recordEndToEndLatency(&h.ReplicateEventsRequest{
+			SourceCluster:   common.StringPtr(cluster.TestAlternativeClusterName),
+			DomainUUID:      common.StringPtr(domainID),
+			SourceTimestamp: common.Int64Ptr(time.Now().Add(-time.Second).UnixNano()),
+		})
+		s.Equal(1, s.historyReplicator.taggedMetrics.size())
+	}
+
+	s.historyReplicator.recordEndToEndLatency(&h.ReplicateEventsRequest{
+		SourceCluster:   common.StringPtr(cluster.TestCurrentClusterName),
+		DomainUUID:      common.StringPtr(domainID),
+		SourceTimestamp: common.Int64Ptr(time.Now().UnixNano()),
+	})
+	s.Equal(2, s.historyReplicator.taggedMetrics.size())
+}
+
+func (s *historyReplicatorSuite) TestUpdateRetryErrorMetric() {
+	scope := tally.NewTestScope("test", nil)
+	s.historyReplicator.metricsClient = metrics.NewClient(scope, metrics.History)
+
+	for _, err := range []error{
+		ErrRetryEntityNotExists,
+		ErrRetryBufferEvents,
+		ErrRetryBufferEvents,
+		ErrRetryExistingWorkflow,
+		ErrRetryExecutionAlreadyStarted,
+		ErrMissingReplicationInfo,
+		nil,
+	} {
+		s.historyReplicator.updateRetryErrorMetric(err)
+	}
+
+	counters := map[string]int64{}
+	for _, counter := range scope.Snapshot().Counters() {
+		counters[counter.Name()] = counter.Value()
+	}
+	s.Equal(map[string]int64{
+		"test.replication-retry-entity-not-exists":         1,
+		"test.replication-retry-buffer-events":             2,
+		"test.replication-retry-existing-workflow":         1,
+		"test.replication-retry-execution-already-started": 1,
+	}, counters)
+}
+
+func (s *historyReplicatorSuite) TestIsEntityNotExistsError() {
+	notExists := &shared.EntityNotExistsError{Message: "workflow not found"}
+	s.True(isEntityNotExistsError(notExists))
+	s.True(isEntityNotExistsError(serviceerror.Wrap(notExists, "failed to load history payloads")))
+	s.False(isEntityNotExistsError(serviceerror.Wrap(&shared.InternalServiceError{}, "failed to load history payloads")))
+	s.False(isEntityNotExistsError(nil))
+}
+
 func (s *historyReplicatorSuite) TestApplyOtherEventsMissingMutableState_IncomingNotLessThanCurrent() {
 	domainName := "some random domain name"
 	domainID := validDomainID
@@ -254,6 +349,235 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingLes
 		History: &shared.History{},
 	}
 	msBuilderIn.On("GetReplicationState").Return(&persistence.ReplicationState{LastWriteVersion: currentLastWriteVersion})
+	msBuilderIn.On("IsWorkflowExecutionRunning").Return(true)
+
+	msBuilderOut, err := s.historyReplicator.ApplyOtherEventsVersionChecking(ctx.Background(), context, msBuilderIn,
+		request, s.logger)
+	s.Nil(msBuilderOut)
+	s.Nil(err)
+}
+
+func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingLessThanCurrent_WorkflowClosed() {
+	domainID := validDomainID
+	workflowID := "some random workflow ID"
+	runID := uuid.New()
+	incomingVersion := int64(110)
+	currentLastWriteVersion := int64(123)
+
+	context := newWorkflowExecutionContext(domainID, shared.WorkflowExecution{
+		WorkflowId: common.StringPtr(workflowID),
+		RunId:      common.StringPtr(runID),
+	}, s.mockShard, s.mockExecutionMgr, s.logger)
+	msBuilderIn := &mockMutableState{}
+	context.msBuilder = msBuilderIn
+	signalEvent := &shared.HistoryEvent{
+		EventId:   common.Int64Ptr(11),
+		Version:   common.Int64Ptr(incomingVersion),
+		EventType: shared.EventTypeWorkflowExecutionSignaled.Ptr(),
+		WorkflowExecutionSignaledEventAttributes: &shared.WorkflowExecutionSignaledEventAttributes{
+			SignalName: common.StringPtr("some random signal name"),
+			Input:      []byte("some random signal input"),
+		},
+	}
+	request := &h.ReplicateEventsRequest{
+		SourceCluster: common.StringPtr(cluster.TestAlternativeClusterName),
+		Version:       common.Int64Ptr(incomingVersion),
+		FirstEventId:  common.Int64Ptr(10),
+		History: &shared.History{Events: []*shared.HistoryEvent{
+			&shared.HistoryEvent{
+				EventId:   common.Int64Ptr(10),
+				Version:   common.Int64Ptr(incomingVersion),
+				EventType: shared.EventTypeDecisionTaskScheduled.Ptr(),
+			},
+			signalEvent,
+		}},
+	}
+	msBuilderIn.On("GetReplicationState").Return(&persistence.ReplicationState{LastWriteVersion: currentLastWriteVersion})
+	msBuilderIn.On("IsWorkflowExecutionRunning").Return(false)
+	msBuilderIn.On("GetExecutionInfo").Return(&persistence.WorkflowExecutionInfo{
+		DomainID:   domainID,
+		WorkflowID: workflowID,
+		RunID:      runID,
+	})
+	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: domainID}).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID, Name: "some random domain name"},
+			Config: &persistence.DomainConfig{Retention: 2},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestAlternativeClusterName},
+				},
+			},
+			IsGlobalDomain: true,
+			TableVersion:   persistence.DomainTableVersionV1,
+		}, nil,
+	).Once()
+	var recorded *persistence.RecordPostCloseEventsRequest
+	s.mockPostCloseMgr.On("RecordPostCloseEvents", mock.Anything).Run(func(args mock.Arguments) {
+		recorded = args.Get(0).(*persistence.RecordPostCloseEventsRequest)
+	}).Return(nil).Once()
+
+	msBuilderOut, err := s.historyReplicator.ApplyOtherEventsVersionChecking(ctx.Background(), context, msBuilderIn,
+		request, s.logger)
+	s.Nil(msBuilderOut)
+	s.Nil(err)
+
+	s.Equal(domainID, recorded.DomainID)
+	s.Equal(workflowID, recorded.Execution.GetWorkflowId())
+	s.Equal(runID, recorded.Execution.GetRunId())
+	s.Equal(incomingVersion, recorded.Batch.Version)
+	s.Equal(int64(10), recorded.Batch.FirstEventID)
+	s.Equal(cluster.TestAlternativeClusterName, recorded.Batch.SourceCluster)
+	s.Equal(int64(2*24*60*60), recorded.TTLSeconds)
+	// only the signal is recorded, the decision is generated by the closed run itself
+	history, err := persistence.NewJSONHistorySerializer().Deserialize(recorded.Batch.Events)
+	s.Nil(err)
+	s.Equal([]*shared.HistoryEvent{signalEvent}, history.Events)
+}
+
+func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingLessThanCurrent_WorkflowClosed_AlreadyApplied() {
+	domainID := validDomainID
+	workflowID := "some random workflow ID"
+	runID := uuid.New()
+	incomingVersion := int64(110)
+	currentLastWriteVersion := int64(123)
+
+	context := newWorkflowExecutionContext(domainID, shared.WorkflowExecution{
+		WorkflowId: common.StringPtr(workflowID),
+		RunId:      common.StringPtr(runID),
+	}, s.mockShard, s.mockExecutionMgr, s.logger)
+	msBuilderIn := &mockMutableState{}
+	context.msBuilder = msBuilderIn
+	request := &h.ReplicateEventsRequest{
+		SourceCluster: common.StringPtr(cluster.TestAlternativeClusterName),
+		Version:       common.Int64Ptr(incomingVersion),
+		FirstEventId:  common.Int64Ptr(10),
+		History: &shared.History{Events: []*shared.HistoryEvent{
+			&shared.HistoryEvent{
+				EventId:   common.Int64Ptr(10),
+				Version:   common.Int64Ptr(incomingVersion),
+				EventType: shared.EventTypeWorkflowExecutionSignaled.Ptr(),
+				WorkflowExecutionSignaledEventAttributes: &shared.WorkflowExecutionSignaledEventAttributes{
+					SignalName: common.StringPtr("some random signal name"),
+				},
+			},
+		}},
+	}
+	// the signal was replicated before the run was closed by the new version
+	msBuilderIn.On("GetReplicationState").Return(&persistence.ReplicationState{
+		LastWriteVersion: currentLastWriteVersion,
+		LastReplicationInfo: map[string]*persistence.ReplicationInfo{
+			cluster.TestAlternativeClusterName: &persistence.ReplicationInfo{
+				Version:     incomingVersion,
+				LastEventID: 12,
+			},
+		},
+	})
+	msBuilderIn.On("IsWorkflowExecutionRunning").Return(false)
+
+	msBuilderOut, err := s.historyReplicator.ApplyOtherEventsVersionChecking(ctx.Background(), context, msBuilderIn,
+		request, s.logger)
+	s.Nil(msBuilderOut)
+	s.Nil(err)
+}
+
+func (s *historyReplicatorSuite) TestIsReplicationTaskApplied() {
+	rState := &persistence.ReplicationState{
+		LastReplicationInfo: map[string]*persistence.ReplicationInfo{
+			cluster.TestAlternativeClusterName: &persistence.ReplicationInfo{Version: 110, LastEventID: 12},
+		},
+	}
+	request := func(sourceCluster string, version int64, firstEventID int64) *h.ReplicateEventsRequest {
+		return &h.ReplicateEventsRequest{
+			SourceCluster: common.StringPtr(sourceCluster),
+			Version:       common.Int64Ptr(version),
+			FirstEventId:  common.Int64Ptr(firstEventID),
+		}
+	}
+
+	s.True(isReplicationTaskApplied(rState, request(cluster.TestAlternativeClusterName, 110, 12)))
+	s.True(isReplicationTaskApplied(rState, request(cluster.TestAlternativeClusterName, 100, 20)))
+	s.False(isReplicationTaskApplied(rState, request(cluster.TestAlternativeClusterName, 110, 13)))
+	s.False(isReplicationTaskApplied(rState, request(cluster.TestAlternativeClusterName, 120, 5)))
+	s.False(isReplicationTaskApplied(rState, request(cluster.TestCurrentClusterName, 110, 5)))
+}
+
+func (s *historyReplicatorSuite) TestRecordPostCloseEvents_DefaultTTL() {
+	domainID := validDomainID
+	msBuilder := &mockMutableState{}
+	msBuilder.On("GetExecutionInfo").Return(&persistence.WorkflowExecutionInfo{
+		DomainID:   domainID,
+		WorkflowID: "some random workflow ID",
+		RunID:      uuid.New(),
+	})
+	request := &h.ReplicateEventsRequest{
+		Version: common.Int64Ptr(110),
+		History: &shared.History{Events: []*shared.HistoryEvent{
+			&shared.HistoryEvent{
+				EventId:   common.Int64Ptr(11),
+				Version:   common.Int64Ptr(110),
+				EventType: shared.EventTypeWorkflowExecutionSignaled.Ptr(),
+				WorkflowExecutionSignaledEventAttributes: &shared.WorkflowExecutionSignaledEventAttributes{
+					SignalName: common.StringPtr("some random signal name"),
+				},
+			},
+		}},
+	}
+	var recorded []*persistence.RecordPostCloseEventsRequest
+	s.mockPostCloseMgr.On("RecordPostCloseEvents", mock.Anything).Run(func(args mock.Arguments) {
+		recorded = append(recorded, args.Get(0).(*persistence.RecordPostCloseEventsRequest))
+	}).Return(nil).Twice()
+
+	// the domain is gone
+	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: domainID}).Return(
+		nil, &shared.EntityNotExistsError{Message: "domain not found"},
+	).Once()
+	s.Nil(s.historyReplicator.recordPostCloseEvents(msBuilder, request, s.logger))
+
+	// the domain has no retention
+	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: domainID}).Return(
+		&persistence.GetDomainResponse{
+			Info:              &persistence.DomainInfo{ID: domainID, Name: "some random domain name"},
+			Config:            &persistence.DomainConfig{Retention: 0},
+			ReplicationConfig: &persistence.DomainReplicationConfig{},
+			TableVersion:      persistence.DomainTableVersionV1,
+		}, nil,
+	).Once()
+	s.Nil(s.historyReplicator.recordPostCloseEvents(msBuilder, request, s.logger))
+
+	s.Len(recorded, 2)
+	for _, r := range recorded {
+		s.Equal(int64(7*24*60*60), r.TTLSeconds)
+	}
+}
+
+func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingLessThanCurrent_WorkflowClosed_NoExternalEvents() {
+	domainID := validDomainID
+	workflowID := "some random workflow ID"
+	runID := uuid.New()
+	incomingVersion := int64(110)
+	currentLastWriteVersion := int64(123)
+
+	context := newWorkflowExecutionContext(domainID, shared.WorkflowExecution{
+		WorkflowId: common.StringPtr(workflowID),
+		RunId:      common.StringPtr(runID),
+	}, s.mockShard, s.mockExecutionMgr, s.logger)
+	msBuilderIn := &mockMutableState{}
+	context.msBuilder = msBuilderIn
+	request := &h.ReplicateEventsRequest{
+		Version: common.Int64Ptr(incomingVersion),
+		History: &shared.History{Events: []*shared.HistoryEvent{
+			&shared.HistoryEvent{
+				EventId:   common.Int64Ptr(10),
+				Version:   common.Int64Ptr(incomingVersion),
+				EventType: shared.EventTypeDecisionTaskScheduled.Ptr(),
+			},
+		}},
+	}
+	msBuilderIn.On("GetReplicationState").Return(&persistence.ReplicationState{LastWriteVersion: currentLastWriteVersion})
+	msBuilderIn.On("IsWorkflowExecutionRunning").Return(false)
 
 	msBuilderOut, err := s.historyReplicator.ApplyOtherEventsVersionChecking(ctx.Background(), context, msBuilderIn,
 		request, s.logger)
@@ -320,7 +644,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster)
 
 	mockConflictResolver := &mockConflictResolver{}
-	s.historyReplicator.getNewConflictResolver = func(context *workflowExecutionContext, logger bark.Logger) conflictResolver {
+	s.historyReplicator.getNewConflictResolver = func(context *WorkflowExecutionContext, logger bark.Logger) conflictResolver {
 		return mockConflictResolver
 	}
 	msBuilderMid := &mockMutableState{}
@@ -602,7 +926,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEvents_IncomingGreaterThanCurrent
 	msBuilder.On("GetNextEventID").Return(currentNextEventID)
 	msBuilder.On("GetReplicationState").Return(replicationState)
 	msBuilder.On("BufferReplicationTask", request).Return(nil).Once()
-	msBuilder.On("CloseUpdateSession").Return(&mutableStateSessionUpdates{
+	msBuilder.On("CloseUpdateSession").Return(&MutableStateSessionUpdates{
 		newEventsBuilder:                 newHistoryBuilder(msBuilder, s.logger),
 		newBufferedReplicationEventsInfo: bufferedReplicationTask,
 		deleteBufferedReplicationEvent:   nil,
@@ -659,6 +983,202 @@ func (s *historyReplicatorSuite) FlushBuffer() {
 	// TODO
 }
 
+func (s *historyReplicatorSuite) TestNotifyWorkflowClosed() {
+	domainID := validDomainID
+	execution := shared.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	lastFirstEventID := int64(20)
+	nextEventID := int64(25)
+
+	notifier := newHistoryEventNotifier(
+		metrics.NewClient(tally.NoopScope, metrics.History),
+		func(workflowID string) int {
+			return len(workflowID)
+		},
+	)
+	notifier.Start()
+	defer notifier.Stop()
+	s.historyReplicator.historyEngine.historyEventNotifier = notifier
+
+	subscriberID, channel, err := notifier.WatchHistoryEvent(newWorkflowIdentifier(domainID, &execution))
+	s.Nil(err)
+	defer notifier.UnwatchHistoryEvent(newWorkflowIdentifier(domainID, &execution), subscriberID)
+
+	msBuilder := &mockMutableState{}
+	msBuilder.On("GetLastFirstEventID").Return(lastFirstEventID)
+	msBuilder.On("GetNextEventID").Return(nextEventID)
+	s.historyReplicator.notifyWorkflowClosed(domainID, execution, msBuilder)
+
+	select {
+	case msg := <-channel:
+		s.Equal(execution.GetRunId(), msg.runID)
+		s.Equal(lastFirstEventID, msg.lastFirstEventID)
+		s.Equal(nextEventID, msg.nextEventID)
+		s.False(msg.isWorkflowRunning)
+	case <-time.After(2 * time.Second):
+		s.Fail("closed workflow notification not received")
+	}
+}
+
+func (s *historyReplicatorSuite) TestApplyEvents_ClosedWorkflowDropsCachedMutableState() {
+	s.applyDuplicateStartEvent(false)
+}
+
+func (s *historyReplicatorSuite) TestApplyEvents_RunningWorkflowKeepsCachedMutableState() {
+	s.applyDuplicateStartEvent(true)
+}
+
+// applyDuplicateStartEvent replicates the start event of a workflow execution whose mutable state is cached already,
+// the cached mutable state is only kept while the execution is running
+func (s *historyReplicatorSuite) applyDuplicateStartEvent(isRunning bool) {
+	domainID := validDomainID
+	execution := shared.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+
+	msBuilder := &mockMutableState{}
+	msBuilder.On("IsWorkflowExecutionRunning").Return(isRunning)
+	msBuilder.On("GetReplicationState").Return(nil)
+	msBuilder.On("HasInFlightDecisionTask").Return(false)
+	context, release, err := s.historyReplicator.historyCache.GetOrCreateWorkflowExecution(domainID, execution)
+	s.Nil(err)
+	context.msBuilder = msBuilder
+	release(nil)
+
+	err = s.historyReplicator.ApplyEvents(ctx.Background(), &h.ReplicateEventsRequest{
+		SourceCluster:     common.StringPtr(cluster.TestAlternativeClusterName),
+		DomainUUID:        common.StringPtr(domainID),
+		WorkflowExecution: &execution,
+		History: &shared.History{
+			Events: []*shared.HistoryEvent{
+				&shared.HistoryEvent{
+					EventId:   common.Int64Ptr(common.FirstEventID),
+					EventType: shared.EventTypeWorkflowExecutionStarted.Ptr(),
+				},
+			},
+		},
+	})
+	s.Nil(err)
+
+	cached, release, err := s.historyReplicator.historyCache.GetOrCreateWorkflowExecution(domainID, execution)
+	s.Nil(err)
+	defer release(nil)
+	s.Equal(context, cached)
+	if isRunning {
+		s.Equal(msBuilder, cached.msBuilder)
+	} else {
+		s.Nil(cached.msBuilder)
+	}
+}
+
+func (s *historyReplicatorSuite) TestApplyReplicationTask_NotifiesWorkflowClosed() {
+	notifications := s.applyStartReplicationTask(true)
+	s.Equal(1, len(notifications))
+	s.Equal(int64(1), notifications[0].lastFirstEventID)
+	s.Equal(int64(3), notifications[0].nextEventID)
+	s.False(notifications[0].isWorkflowRunning)
+}
+
+func (s *historyReplicatorSuite) TestApplyReplicationTask_RunningWorkflowNotNotified() {
+	notifications := s.applyStartReplicationTask(false)
+	s.Empty(notifications)
+}
+
+// applyStartReplicationTask replicates the start of a brand new workflow execution, whose replicated events close it
+// if closesWorkflow is set, and returns the history event notifications sent
+func (s *historyReplicatorSuite) applyStartReplicationTask(closesWorkflow bool) []*HistoryEventNotification {
+	domainID := validDomainID
+	execution := shared.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	version := int64(144)
+	sourceCluster := cluster.TestAlternativeClusterName
+	now := time.Now()
+	history := &shared.History{
+		Events: []*shared.HistoryEvent{
+			&shared.HistoryEvent{
+				Version:   common.Int64Ptr(version),
+				EventId:   common.Int64Ptr(1),
+				EventType: shared.EventTypeWorkflowExecutionStarted.Ptr(),
+				Timestamp: common.Int64Ptr(now.UnixNano()),
+			},
+			&shared.HistoryEvent{
+				Version:   common.Int64Ptr(version),
+				EventId:   common.Int64Ptr(2),
+				Timestamp: common.Int64Ptr(now.UnixNano()),
+			},
+		},
+	}
+	request := &h.ReplicateEventsRequest{
+		SourceCluster:     common.StringPtr(sourceCluster),
+		DomainUUID:        common.StringPtr(domainID),
+		WorkflowExecution: &execution,
+		History:           history,
+	}
+
+	context := newWorkflowExecutionContext(domainID, execution, s.mockShard, s.mockExecutionMgr, s.logger)
+	msBuilder := &mockMutableState{}
+	context.msBuilder = msBuilder
+	di := &DecisionInfo{
+		Version:    version,
+		ScheduleID: common.FirstEventID + 1,
+		StartedID:  common.EmptyEventID,
+	}
+	nextEventID := di.ScheduleID + 1
+
+	msBuilder.On("IsWorkflowExecutionRunning").Return(true).Once()
+	msBuilder.On("IsWorkflowExecutionRunning").Return(!closesWorkflow).Once()
+	msBuilder.On("GetExecutionInfo").Return(&persistence.WorkflowExecutionInfo{
+		DomainID:   domainID,
+		WorkflowID: execution.GetWorkflowId(),
+		RunID:      execution.GetRunId(),
+	})
+	msBuilder.On("UpdateReplicationStateLastEventID", sourceCluster, version, nextEventID-1).Once()
+	msBuilder.On("GetReplicationState").Return(&persistence.ReplicationState{
+		StartVersion:     version,
+		CurrentVersion:   version,
+		LastWriteVersion: version,
+		LastWriteEventID: nextEventID - 1,
+	})
+	msBuilder.On("GetCurrentVersion").Return(version)
+	msBuilder.On("GetNextEventID").Return(nextEventID)
+	msBuilder.On("GetLastFirstEventID").Return(common.FirstEventID)
+
+	sBuilder := &mockStateBuilder{}
+	sBuilder.On("applyEvents", domainID, mock.Anything, execution, history, (*shared.History)(nil)).
+		Return(history.Events[1], di, nil, nil).Once()
+	sBuilder.On("getTransferTasks").Return([]persistence.Task{})
+	sBuilder.On("getTimerTasks").Return([]persistence.Task{})
+	s.historyReplicator.getNewStateBuilder = func(msBuilder MutableState, logger bark.Logger) stateBuilder {
+		return sBuilder
+	}
+
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.Anything).
+		Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+
+	s.mockShard.standbyClusterCurrentTime = make(map[string]time.Time)
+	mockTxProcessor := &MockTransferQueueProcessor{}
+	mockTxProcessor.On("NotifyNewTask", sourceCluster, mock.Anything).Once()
+	s.historyReplicator.historyEngine.txProcessor = mockTxProcessor
+	mockTimerProcessor := &MockTimerQueueProcessor{}
+	mockTimerProcessor.On("NotifyNewTimers", sourceCluster, mock.Anything, mock.Anything).Once()
+	s.historyReplicator.historyEngine.timerProcessor = mockTimerProcessor
+	notifier := &recordingHistoryEventNotifier{}
+	s.historyReplicator.historyEngine.historyEventNotifier = notifier
+
+	err := s.historyReplicator.ApplyReplicationTask(ctx.Background(), context, msBuilder, request, s.logger)
+	s.Nil(err)
+	msBuilder.AssertExpectations(s.T())
+	mockTxProcessor.AssertExpectations(s.T())
+	mockTimerProcessor.AssertExpectations(s.T())
+	return notifier.notifications
+}
+
 func (s *historyReplicatorSuite) TestReplicateWorkflowStarted_BrandNew() {
 	domainID := validDomainID
 	workflowID := "some random workflow ID"
@@ -681,7 +1201,7 @@ func (s *historyReplicatorSuite) TestReplicateWorkflowStarted_BrandNew() {
 	}, s.mockShard, s.mockExecutionMgr, s.logger)
 	msBuilder := &mockMutableState{}
 	context.msBuilder = msBuilder
-	di := &decisionInfo{
+	di := &DecisionInfo{
 		Version:         version,
 		ScheduleID:      common.FirstEventID + 1,
 		StartedID:       common.EmptyEventID,
@@ -793,7 +1313,7 @@ func (s *historyReplicatorSuite) TestReplicateWorkflowStarted_ISE() {
 	}, s.mockShard, s.mockExecutionMgr, s.logger)
 	msBuilder := &mockMutableState{}
 	context.msBuilder = msBuilder
-	di := &decisionInfo{
+	di := &DecisionInfo{
 		Version:         version,
 		ScheduleID:      common.FirstEventID + 1,
 		StartedID:       common.EmptyEventID,
@@ -877,7 +1397,7 @@ func (s *historyReplicatorSuite) TestReplicateWorkflowStarted_SameRunID() {
 	}, s.mockShard, s.mockExecutionMgr, s.logger)
 	msBuilder := &mockMutableState{}
 	context.msBuilder = msBuilder
-	di := &decisionInfo{
+	di := &DecisionInfo{
 		Version:         version,
 		ScheduleID:      common.FirstEventID + 1,
 		StartedID:       common.EmptyEventID,
@@ -966,7 +1486,7 @@ func (s *historyReplicatorSuite) TestReplicateWorkflowStarted_CurrentComplete_In
 	}, s.mockShard, s.mockExecutionMgr, s.logger)
 	msBuilder := &mockMutableState{}
 	context.msBuilder = msBuilder
-	di := &decisionInfo{
+	di := &DecisionInfo{
 		Version:         version,
 		ScheduleID:      common.FirstEventID + 1,
 		StartedID:       common.EmptyEventID,
@@ -1056,7 +1576,7 @@ func (s *historyReplicatorSuite) TestReplicateWorkflowStarted_CurrentComplete_In
 	}, s.mockShard, s.mockExecutionMgr, s.logger)
 	msBuilder := &mockMutableState{}
 	context.msBuilder = msBuilder
-	di := &decisionInfo{
+	di := &DecisionInfo{
 		Version:         version,
 		ScheduleID:      common.FirstEventID + 1,
 		StartedID:       common.EmptyEventID,
@@ -1210,7 +1730,7 @@ func (s *historyReplicatorSuite) TestReplicateWorkflowStarted_CurrentComplete_In
 	}, s.mockShard, s.mockExecutionMgr, s.logger)
 	msBuilder := &mockMutableState{}
 	context.msBuilder = msBuilder
-	di := &decisionInfo{
+	di := &DecisionInfo{
 		Version:         version,
 		ScheduleID:      common.FirstEventID + 1,
 		StartedID:       common.EmptyEventID,
@@ -1364,7 +1884,7 @@ func (s *historyReplicatorSuite) TestReplicateWorkflowStarted_CurrentRunning_Inc
 	}, s.mockShard, s.mockExecutionMgr, s.logger)
 	msBuilder := &mockMutableState{}
 	context.msBuilder = msBuilder
-	di := &decisionInfo{
+	di := &DecisionInfo{
 		Version:         version,
 		ScheduleID:      common.FirstEventID + 1,
 		StartedID:       common.EmptyEventID,
@@ -1454,7 +1974,7 @@ func (s *historyReplicatorSuite) TestReplicateWorkflowStarted_CurrentRunning_Inc
 	}, s.mockShard, s.mockExecutionMgr, s.logger)
 	msBuilder := &mockMutableState{}
 	context.msBuilder = msBuilder
-	di := &decisionInfo{
+	di := &DecisionInfo{
 		Version:         version,
 		ScheduleID:      common.FirstEventID + 1,
 		StartedID:       common.EmptyEventID,
@@ -1518,6 +2038,7 @@ func (s *historyReplicatorSuite) TestReplicateWorkflowStarted_CurrentRunning_Inc
 	}, s.mockShard, s.mockExecutionMgr, s.logger)
 	currentMsBuilder := &mockMutableState{}
 	currentMsBuilder.On("HasBufferedReplicationTasks").Return(false)
+	currentMsBuilder.On("HasInFlightDecisionTask").Return(false)
 	// return empty since not actually used
 	currentMsBuilder.On("GetExecutionInfo").Return(&persistence.WorkflowExecutionInfo{})
 	// return nil to bypass updating the version, since this test does not test that
@@ -1564,7 +2085,7 @@ func (s *historyReplicatorSuite) TestReplicateWorkflowStarted_CurrentRunning_Inc
 	}, s.mockShard, s.mockExecutionMgr, s.logger)
 	msBuilder := &mockMutableState{}
 	context.msBuilder = msBuilder
-	di := &decisionInfo{
+	di := &DecisionInfo{
 		Version:         version,
 		ScheduleID:      common.FirstEventID + 1,
 		StartedID:       common.EmptyEventID,
@@ -1702,12 +2223,13 @@ func (s *historyReplicatorSuite) TestReplicateWorkflowStarted_CurrentRunning_Inc
 			TableVersion:    persistence.DomainTableVersionV1,
 		}, nil,
 	).Once()
-	currentContext, currentRelease, err := s.historyReplicator.historyCache.getOrCreateWorkflowExecution(domainID, shared.WorkflowExecution{
+	currentContext, currentRelease, err := s.historyReplicator.historyCache.GetOrCreateWorkflowExecution(domainID, shared.WorkflowExecution{
 		WorkflowId: common.StringPtr(workflowID),
 		RunId:      common.StringPtr(currentRunID),
 	})
 	s.Nil(err)
 	currentMsBuilder := &mockMutableState{}
+	currentMsBuilder.On("HasInFlightDecisionTask").Return(false)
 	currentContext.msBuilder = currentMsBuilder
 	currentRelease(nil)
 	// all method call to currentMsBuilder are randomly mocked
@@ -1756,7 +2278,7 @@ func (s *historyReplicatorSuite) TestConflictResolutionTerminateContinueAsNew_Ta
 	})
 
 	currentRunID := uuid.New()
-	contextCurrent, release, err := s.historyReplicator.historyCache.getOrCreateWorkflowExecution(domainID, shared.WorkflowExecution{
+	contextCurrent, release, err := s.historyReplicator.historyCache.GetOrCreateWorkflowExecution(domainID, shared.WorkflowExecution{
 		WorkflowId: common.StringPtr(workflowID),
 		RunId:      common.StringPtr(currentRunID),
 	})
@@ -1766,6 +2288,7 @@ func (s *historyReplicatorSuite) TestConflictResolutionTerminateContinueAsNew_Ta
 	msBuilderCurrent.On("GetReplicationState").Return(&persistence.ReplicationState{}) // this is used to update the version on mutable state
 	msBuilderCurrent.On("IsWorkflowExecutionRunning").Return(false)                    // this is used to update the version on mutable state
 	msBuilderCurrent.On("GetExecutionInfo").Return(&persistence.WorkflowExecutionInfo{RunID: currentRunID, CloseStatus: persistence.WorkflowCloseStatusTerminated})
+	msBuilderCurrent.On("HasInFlightDecisionTask").Return(false)
 	contextCurrent.msBuilder = msBuilderCurrent
 	release(nil)
 
@@ -1815,7 +2338,7 @@ func (s *historyReplicatorSuite) TestConflictResolutionTerminateContinueAsNew_Ta
 	).Once()
 
 	currentRunID := uuid.New()
-	contextCurrent, release, err := s.historyReplicator.historyCache.getOrCreateWorkflowExecution(domainID, shared.WorkflowExecution{
+	contextCurrent, release, err := s.historyReplicator.historyCache.GetOrCreateWorkflowExecution(domainID, shared.WorkflowExecution{
 		WorkflowId: common.StringPtr(workflowID),
 		RunId:      common.StringPtr(currentRunID),
 	})
@@ -1826,6 +2349,7 @@ func (s *historyReplicatorSuite) TestConflictResolutionTerminateContinueAsNew_Ta
 	msBuilderCurrent.On("IsWorkflowExecutionRunning").Return(true)                     // this is used to update the version on mutable state
 	msBuilderCurrent.On("GetExecutionInfo").Return(&persistence.WorkflowExecutionInfo{RunID: currentRunID, CloseStatus: persistence.WorkflowCloseStatusNone})
 	msBuilderCurrent.On("UpdateReplicationStateVersion", version, false)
+	msBuilderCurrent.On("HasInFlightDecisionTask").Return(false)
 	contextCurrent.msBuilder = msBuilderCurrent
 	release(nil)
 	s.mockExecutionMgr.On("GetCurrentExecution", &persistence.GetCurrentExecutionRequest{
@@ -1871,3 +2395,176 @@ func (s *historyReplicatorSuite) TestConflictResolutionTerminateContinueAsNew_Ta
 	_, ok := err.(*shared.InternalServiceError)
 	s.True(ok)
 }
+
+func (s *historyReplicatorSuite) TestSyncActivity_WorkflowNotFound() {
+	request := &h.SyncActivityRequest{
+		DomainId:    common.StringPtr(validDomainID),
+		WorkflowId:  common.StringPtr("some random workflow ID"),
+		RunId:       common.StringPtr(uuid.New()),
+		Version:     common.Int64Ptr(100),
+		ScheduledId: common.Int64Ptr(5),
+		Attempt:     common.Int32Ptr(1),
+	}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, &shared.EntityNotExistsError{}).Once()
+
+	err := s.historyReplicator.SyncActivity(ctx.Background(), request)
+	s.Nil(err)
+}
+
+func (s *historyReplicatorSuite) TestSyncActivity_StaleAttempt() {
+	version := int64(100)
+	execution, ai := s.prepareSyncActivity(version, 3)
+
+	request := &h.SyncActivityRequest{
+		DomainId:      common.StringPtr(validDomainID),
+		WorkflowId:    execution.WorkflowId,
+		RunId:         execution.RunId,
+		Version:       common.Int64Ptr(version),
+		ScheduledId:   common.Int64Ptr(ai.ScheduleID),
+		ScheduledTime: common.Int64Ptr(time.Now().UnixNano()),
+		StartedId:     common.Int64Ptr(common.EmptyEventID),
+		Attempt:       common.Int32Ptr(2),
+	}
+
+	// no update of the mutable state is expected
+	err := s.historyReplicator.SyncActivity(ctx.Background(), request)
+	s.Nil(err)
+}
+
+func (s *historyReplicatorSuite) TestSyncActivity_RetryScheduled() {
+	version := int64(100)
+	execution, ai := s.prepareSyncActivity(version, 0)
+	scheduledTime := time.Now().Add(time.Minute)
+
+	request := &h.SyncActivityRequest{
+		DomainId:      common.StringPtr(validDomainID),
+		WorkflowId:    execution.WorkflowId,
+		RunId:         execution.RunId,
+		Version:       common.Int64Ptr(version),
+		ScheduledId:   common.Int64Ptr(ai.ScheduleID),
+		ScheduledTime: common.Int64Ptr(scheduledTime.UnixNano()),
+		StartedId:     common.Int64Ptr(common.EmptyEventID),
+		Attempt:       common.Int32Ptr(1),
+	}
+
+	var timerTasks []persistence.Task
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(input *persistence.UpdateWorkflowExecutionRequest) bool {
+		s.Equal(1, len(input.UpsertActivityInfos))
+		s.Equal(int32(1), input.UpsertActivityInfos[0].Attempt)
+		s.Equal(common.EmptyEventID, input.UpsertActivityInfos[0].StartedID)
+		s.Equal(scheduledTime.UnixNano(), input.UpsertActivityInfos[0].ScheduledTime.UnixNano())
+		s.Empty(input.ReplicationTasks)
+		timerTasks = input.TimerTasks
+		return true
+	})).Return(nil).Once()
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", version).Return(cluster.TestAlternativeClusterName)
+	mockTimerProcessor := &MockTimerQueueProcessor{}
+	mockTimerProcessor.On("NotifyNewTimers", cluster.TestAlternativeClusterName, mock.Anything, mock.Anything).Once()
+	s.historyReplicator.historyEngine.timerProcessor = mockTimerProcessor
+
+	err := s.historyReplicator.SyncActivity(ctx.Background(), request)
+	s.Nil(err)
+	mockTimerProcessor.AssertExpectations(s.T())
+
+	// the standby holds on to the synced retry timer, which dispatches the retry once the domain fails over
+	s.Equal(1, len(timerTasks))
+	retryTimer, ok := timerTasks[0].(*persistence.RetryTimerTask)
+	s.True(ok)
+	s.Equal(ai.ScheduleID, retryTimer.EventID)
+	s.Equal(int32(1), retryTimer.Attempt)
+	s.Equal(version, retryTimer.Version)
+	s.Equal(scheduledTime.UnixNano(), retryTimer.VisibilityTimestamp.UnixNano())
+}
+
+func (s *historyReplicatorSuite) TestSyncActivity_Started() {
+	version := int64(100)
+	execution, ai := s.prepareSyncActivity(version, 1)
+	startedTime := time.Now()
+
+	request := &h.SyncActivityRequest{
+		DomainId:      common.StringPtr(validDomainID),
+		WorkflowId:    execution.WorkflowId,
+		RunId:         execution.RunId,
+		Version:       common.Int64Ptr(version),
+		ScheduledId:   common.Int64Ptr(ai.ScheduleID),
+		ScheduledTime: common.Int64Ptr(startedTime.Add(-time.Second).UnixNano()),
+		StartedId:     common.Int64Ptr(common.TransientEventID),
+		StartedTime:   common.Int64Ptr(startedTime.UnixNano()),
+		Attempt:       common.Int32Ptr(1),
+	}
+
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(input *persistence.UpdateWorkflowExecutionRequest) bool {
+		s.Equal(1, len(input.UpsertActivityInfos))
+		s.Equal(int32(1), input.UpsertActivityInfos[0].Attempt)
+		s.Equal(common.TransientEventID, input.UpsertActivityInfos[0].StartedID)
+		s.Equal(startedTime.UnixNano(), input.UpsertActivityInfos[0].StartedTime.UnixNano())
+		// the retry timer of the attempt is released by the standby processor once the start is synced
+		s.Empty(input.TimerTasks)
+		return true
+	})).Return(nil).Once()
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", version).Return(cluster.TestAlternativeClusterName)
+	mockTimerProcessor := &MockTimerQueueProcessor{}
+	mockTimerProcessor.On("NotifyNewTimers", cluster.TestAlternativeClusterName, mock.Anything, mock.Anything).Once()
+	s.historyReplicator.historyEngine.timerProcessor = mockTimerProcessor
+
+	err := s.historyReplicator.SyncActivity(ctx.Background(), request)
+	s.Nil(err)
+	mockTimerProcessor.AssertExpectations(s.T())
+
+	// the same start synced again is dropped
+	err = s.historyReplicator.SyncActivity(ctx.Background(), request)
+	s.Nil(err)
+}
+
+// prepareSyncActivity persists a running workflow of a global domain active in the alternative cluster, with one
+// activity scheduled and waiting for the given attempt.
+func (s *historyReplicatorSuite) prepareSyncActivity(version int64, attempt int32) (shared.WorkflowExecution,
+	*persistence.ActivityInfo) {
+	execution := shared.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	taskList := "some random task list"
+
+	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: validDomainID}).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: validDomainID, Name: "some random domain name"},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestAlternativeClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestAlternativeClusterName},
+				},
+			},
+			IsGlobalDomain:  true,
+			FailoverVersion: version,
+			TableVersion:    persistence.DomainTableVersionV1,
+		}, nil,
+	).Once()
+
+	msBuilder := newMutableStateBuilderWithReplicationState(s.mockShard.GetConfig(), s.logger, version)
+	msBuilder.AddWorkflowExecutionStartedEvent(
+		execution,
+		&h.StartWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(validDomainID),
+			StartRequest: &shared.StartWorkflowExecutionRequest{
+				WorkflowType:                        &shared.WorkflowType{Name: common.StringPtr("some random workflow type")},
+				TaskList:                            &shared.TaskList{Name: common.StringPtr(taskList)},
+				ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(100),
+				TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			},
+		},
+	)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	event := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, taskList, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, di.StartedID, nil, "some random identity")
+	_, ai := addActivityTaskScheduledEvent(msBuilder, event.GetEventId(), "activity", "activity type", taskList,
+		[]byte(nil), 10, 10, 10)
+	ai.Attempt = attempt
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: createMutableState(msBuilder)}, nil).Once()
+	return execution, ai
+}
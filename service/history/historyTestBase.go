@@ -75,6 +75,8 @@ type (
 		logger                    bark.Logger
 		metricsClient             metrics.Client
 		standbyClusterCurrentTime map[string]time.Time
+		queueBacklogAges          map[string]time.Duration
+		operationLog              *shardOperationLog
 	}
 
 	// TestBase wraps the base setup needed to create workflows over engine layer.
@@ -117,6 +119,7 @@ func newTestShardContext(shardInfo *persistence.ShardInfo, transferSequenceNumbe
 		logger:                    logger,
 		metricsClient:             metricsClient,
 		standbyClusterCurrentTime: standbyClusterCurrentTime,
+		operationLog:              newShardOperationLog(config.ShardOperationLogSize()),
 	}
 }
 
@@ -150,6 +153,11 @@ func (s *TestShardContext) GetNextTransferTaskID() (int64, error) {
 	return atomic.AddInt64(&s.transferSequenceNumber, 1), nil
 }
 
+// GetTaskCount test implementation
+func (s *TestShardContext) GetTaskCount() int64 {
+	return atomic.LoadInt64(&s.transferSequenceNumber)
+}
+
 // GetTransferMaxReadLevel test implementation
 func (s *TestShardContext) GetTransferMaxReadLevel() int64 {
 	return atomic.LoadInt64(&s.transferSequenceNumber)
@@ -355,7 +363,7 @@ func (s *TestShardContext) AppendHistoryEvents(request *persistence.AppendHistor
 }
 
 // NotifyNewHistoryEvent test implementation
-func (s *TestShardContext) NotifyNewHistoryEvent(event *historyEventNotification) error {
+func (s *TestShardContext) NotifyNewHistoryEvent(event *HistoryEventNotification) error {
 	return nil
 }
 
@@ -414,6 +422,37 @@ func (s *TestShardContext) GetCurrentTime(cluster string) time.Time {
 	return time.Now()
 }
 
+// UpdateQueueBacklogAge test implementation
+func (s *TestShardContext) UpdateQueueBacklogAge(scope int, queueName string, age time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+	if s.queueBacklogAges == nil {
+		s.queueBacklogAges = make(map[string]time.Duration)
+	}
+	s.queueBacklogAges[queueName] = age
+}
+
+// GetQueueBacklogAges test implementation
+func (s *TestShardContext) GetQueueBacklogAges() map[string]time.Duration {
+	s.RLock()
+	defer s.RUnlock()
+	ages := make(map[string]time.Duration, len(s.queueBacklogAges))
+	for queueName, age := range s.queueBacklogAges {
+		ages[queueName] = age
+	}
+	return ages
+}
+
+// RecordOperation test implementation
+func (s *TestShardContext) RecordOperation(operation string, details string) {
+	s.operationLog.record(time.Now(), operation, details)
+}
+
+// GetRecentOperations test implementation
+func (s *TestShardContext) GetRecentOperations(maximum int) []ShardOperation {
+	return s.operationLog.recent(maximum)
+}
+
 // SetupWorkflowStoreWithOptions to setup workflow test base
 func (s *TestBase) SetupWorkflowStoreWithOptions(options persistence.TestBaseOptions) {
 	s.TestBase.SetupWorkflowStoreWithOptions(options, nil)
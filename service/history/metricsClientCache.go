@@ -0,0 +1,86 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/metrics"
+)
+
+type (
+	// metricsClientCache hands out metrics clients tagged with a set of tags, creating a tagged client
+	// is expensive as it builds child scopes for every metric scope, so clients are created once per tag set
+	metricsClientCache struct {
+		sync.RWMutex
+		metricsClient metrics.Client
+		clients       map[string]metrics.Client
+	}
+)
+
+func newMetricsClientCache(metricsClient metrics.Client) *metricsClientCache {
+	return &metricsClientCache{
+		metricsClient: metricsClient,
+		clients:       make(map[string]metrics.Client),
+	}
+}
+
+// get returns the metrics client tagged with the given tag name / value pairs
+func (c *metricsClientCache) get(tagNameValues ...string) metrics.Client {
+	key := strings.Join(tagNameValues, "\x00")
+	c.RLock()
+	client, ok := c.clients[key]
+	c.RUnlock()
+	if ok {
+		return client
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if client, ok := c.clients[key]; ok {
+		return client
+	}
+	tags := make(map[string]string, len(tagNameValues)/2)
+	for i := 0; i+1 < len(tagNameValues); i += 2 {
+		tags[tagNameValues[i]] = tagNameValues[i+1]
+	}
+	client = c.metricsClient.Tagged(tags)
+	c.clients[key] = client
+	return client
+}
+
+// size returns the number of tagged clients created so far
+func (c *metricsClientCache) size() int {
+	c.RLock()
+	defer c.RUnlock()
+	return len(c.clients)
+}
+
+// getDomainNameForMetrics returns the domain name to tag metrics with, falling back to the domain ID
+// when the domain cannot be resolved
+func getDomainNameForMetrics(domainCache cache.DomainCache, domainID string) string {
+	if domainEntry, err := domainCache.GetDomainByID(domainID); err == nil {
+		return domainEntry.GetInfo().Name
+	}
+	return domainID
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/service/history"
+)
+
+// HistoryCache is an autogenerated mock type for the HistoryCache type
+type HistoryCache struct {
+	mock.Mock
+}
+
+var _ history.HistoryCache = (*HistoryCache)(nil)
+
+// GetAndCreateWorkflowExecutionWithTimeout provides a mock function with given fields: ctx, domainID, execution
+func (_m *HistoryCache) GetAndCreateWorkflowExecutionWithTimeout(ctx context.Context, domainID string,
+	execution shared.WorkflowExecution) (*history.WorkflowExecutionContext, *history.WorkflowExecutionContext,
+	history.ReleaseWorkflowExecutionFunc, bool, error) {
+	ret := _m.Called(ctx, domainID, execution)
+
+	var r0 *history.WorkflowExecutionContext
+	if rf, ok := ret.Get(0).(func(context.Context, string, shared.WorkflowExecution) *history.WorkflowExecutionContext); ok {
+		r0 = rf(ctx, domainID, execution)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*history.WorkflowExecutionContext)
+		}
+	}
+
+	var r1 *history.WorkflowExecutionContext
+	if rf, ok := ret.Get(1).(func(context.Context, string, shared.WorkflowExecution) *history.WorkflowExecutionContext); ok {
+		r1 = rf(ctx, domainID, execution)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*history.WorkflowExecutionContext)
+		}
+	}
+
+	var r2 history.ReleaseWorkflowExecutionFunc
+	if rf, ok := ret.Get(2).(func(context.Context, string, shared.WorkflowExecution) history.ReleaseWorkflowExecutionFunc); ok {
+		r2 = rf(ctx, domainID, execution)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(history.ReleaseWorkflowExecutionFunc)
+		}
+	}
+
+	var r3 bool
+	if rf, ok := ret.Get(3).(func(context.Context, string, shared.WorkflowExecution) bool); ok {
+		r3 = rf(ctx, domainID, execution)
+	} else {
+		r3 = ret.Get(3).(bool)
+	}
+
+	var r4 error
+	if rf, ok := ret.Get(4).(func(context.Context, string, shared.WorkflowExecution) error); ok {
+		r4 = rf(ctx, domainID, execution)
+	} else {
+		r4 = ret.Error(4)
+	}
+
+	return r0, r1, r2, r3, r4
+}
+
+// GetOrCreateWorkflowExecution provides a mock function with given fields: domainID, execution
+func (_m *HistoryCache) GetOrCreateWorkflowExecution(domainID string,
+	execution shared.WorkflowExecution) (*history.WorkflowExecutionContext, history.ReleaseWorkflowExecutionFunc, error) {
+	ret := _m.Called(domainID, execution)
+
+	var r0 *history.WorkflowExecutionContext
+	if rf, ok := ret.Get(0).(func(string, shared.WorkflowExecution) *history.WorkflowExecutionContext); ok {
+		r0 = rf(domainID, execution)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*history.WorkflowExecutionContext)
+		}
+	}
+
+	var r1 history.ReleaseWorkflowExecutionFunc
+	if rf, ok := ret.Get(1).(func(string, shared.WorkflowExecution) history.ReleaseWorkflowExecutionFunc); ok {
+		r1 = rf(domainID, execution)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(history.ReleaseWorkflowExecutionFunc)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, shared.WorkflowExecution) error); ok {
+		r2 = rf(domainID, execution)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetOrCreateWorkflowExecutionWithTimeout provides a mock function with given fields: ctx, domainID, execution
+func (_m *HistoryCache) GetOrCreateWorkflowExecutionWithTimeout(ctx context.Context, domainID string,
+	execution shared.WorkflowExecution) (*history.WorkflowExecutionContext, history.ReleaseWorkflowExecutionFunc, error) {
+	ret := _m.Called(ctx, domainID, execution)
+
+	var r0 *history.WorkflowExecutionContext
+	if rf, ok := ret.Get(0).(func(context.Context, string, shared.WorkflowExecution) *history.WorkflowExecutionContext); ok {
+		r0 = rf(ctx, domainID, execution)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*history.WorkflowExecutionContext)
+		}
+	}
+
+	var r1 history.ReleaseWorkflowExecutionFunc
+	if rf, ok := ret.Get(1).(func(context.Context, string, shared.WorkflowExecution) history.ReleaseWorkflowExecutionFunc); ok {
+		r1 = rf(ctx, domainID, execution)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(history.ReleaseWorkflowExecutionFunc)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, shared.WorkflowExecution) error); ok {
+		r2 = rf(ctx, domainID, execution)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
@@ -0,0 +1,628 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/service/history"
+)
+
+// ShardContext is an autogenerated mock type for the ShardContext type
+type ShardContext struct {
+	mock.Mock
+}
+
+var _ history.ShardContext = (*ShardContext)(nil)
+
+// GetShardID provides a mock function with given fields:
+func (_m *ShardContext) GetShardID() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetService provides a mock function with given fields:
+func (_m *ShardContext) GetService() service.Service {
+	ret := _m.Called()
+
+	var r0 service.Service
+	if rf, ok := ret.Get(0).(func() service.Service); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.Service)
+		}
+	}
+
+	return r0
+}
+
+// GetExecutionManager provides a mock function with given fields:
+func (_m *ShardContext) GetExecutionManager() persistence.ExecutionManager {
+	ret := _m.Called()
+
+	var r0 persistence.ExecutionManager
+	if rf, ok := ret.Get(0).(func() persistence.ExecutionManager); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(persistence.ExecutionManager)
+		}
+	}
+
+	return r0
+}
+
+// GetHistoryManager provides a mock function with given fields:
+func (_m *ShardContext) GetHistoryManager() persistence.HistoryManager {
+	ret := _m.Called()
+
+	var r0 persistence.HistoryManager
+	if rf, ok := ret.Get(0).(func() persistence.HistoryManager); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(persistence.HistoryManager)
+		}
+	}
+
+	return r0
+}
+
+// GetDomainCache provides a mock function with given fields:
+func (_m *ShardContext) GetDomainCache() cache.DomainCache {
+	ret := _m.Called()
+
+	var r0 cache.DomainCache
+	if rf, ok := ret.Get(0).(func() cache.DomainCache); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cache.DomainCache)
+		}
+	}
+
+	return r0
+}
+
+// GetNextTransferTaskID provides a mock function with given fields:
+func (_m *ShardContext) GetNextTransferTaskID() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskCount provides a mock function with given fields:
+func (_m *ShardContext) GetTaskCount() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// GetTransferMaxReadLevel provides a mock function with given fields:
+func (_m *ShardContext) GetTransferMaxReadLevel() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// GetTransferAckLevel provides a mock function with given fields:
+func (_m *ShardContext) GetTransferAckLevel() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// UpdateTransferAckLevel provides a mock function with given fields: ackLevel
+func (_m *ShardContext) UpdateTransferAckLevel(ackLevel int64) error {
+	ret := _m.Called(ackLevel)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(ackLevel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetTransferClusterAckLevel provides a mock function with given fields: cluster
+func (_m *ShardContext) GetTransferClusterAckLevel(cluster string) int64 {
+	ret := _m.Called(cluster)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(cluster)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// UpdateTransferClusterAckLevel provides a mock function with given fields: cluster, ackLevel
+func (_m *ShardContext) UpdateTransferClusterAckLevel(cluster string, ackLevel int64) error {
+	ret := _m.Called(cluster, ackLevel)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(cluster, ackLevel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetReplicatorAckLevel provides a mock function with given fields:
+func (_m *ShardContext) GetReplicatorAckLevel() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// UpdateReplicatorAckLevel provides a mock function with given fields: ackLevel
+func (_m *ShardContext) UpdateReplicatorAckLevel(ackLevel int64) error {
+	ret := _m.Called(ackLevel)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(ackLevel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetTimerAckLevel provides a mock function with given fields:
+func (_m *ShardContext) GetTimerAckLevel() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// UpdateTimerAckLevel provides a mock function with given fields: ackLevel
+func (_m *ShardContext) UpdateTimerAckLevel(ackLevel time.Time) error {
+	ret := _m.Called(ackLevel)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(ackLevel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetTimerClusterAckLevel provides a mock function with given fields: cluster
+func (_m *ShardContext) GetTimerClusterAckLevel(cluster string) time.Time {
+	ret := _m.Called(cluster)
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func(string) time.Time); ok {
+		r0 = rf(cluster)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// UpdateTimerClusterAckLevel provides a mock function with given fields: cluster, ackLevel
+func (_m *ShardContext) UpdateTimerClusterAckLevel(cluster string, ackLevel time.Time) error {
+	ret := _m.Called(cluster, ackLevel)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(cluster, ackLevel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTransferFailoverLevel provides a mock function with given fields: failoverID, level
+func (_m *ShardContext) UpdateTransferFailoverLevel(failoverID string, level persistence.TransferFailoverLevel) error {
+	ret := _m.Called(failoverID, level)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, persistence.TransferFailoverLevel) error); ok {
+		r0 = rf(failoverID, level)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTransferFailoverLevel provides a mock function with given fields: failoverID
+func (_m *ShardContext) DeleteTransferFailoverLevel(failoverID string) error {
+	ret := _m.Called(failoverID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(failoverID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAllTransferFailoverLevels provides a mock function with given fields:
+func (_m *ShardContext) GetAllTransferFailoverLevels() map[string]persistence.TransferFailoverLevel {
+	ret := _m.Called()
+
+	var r0 map[string]persistence.TransferFailoverLevel
+	if rf, ok := ret.Get(0).(func() map[string]persistence.TransferFailoverLevel); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]persistence.TransferFailoverLevel)
+		}
+	}
+
+	return r0
+}
+
+// UpdateTimerFailoverLevel provides a mock function with given fields: failoverID, level
+func (_m *ShardContext) UpdateTimerFailoverLevel(failoverID string, level persistence.TimerFailoverLevel) error {
+	ret := _m.Called(failoverID, level)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, persistence.TimerFailoverLevel) error); ok {
+		r0 = rf(failoverID, level)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTimerFailoverLevel provides a mock function with given fields: failoverID
+func (_m *ShardContext) DeleteTimerFailoverLevel(failoverID string) error {
+	ret := _m.Called(failoverID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(failoverID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAllTimerFailoverLevels provides a mock function with given fields:
+func (_m *ShardContext) GetAllTimerFailoverLevels() map[string]persistence.TimerFailoverLevel {
+	ret := _m.Called()
+
+	var r0 map[string]persistence.TimerFailoverLevel
+	if rf, ok := ret.Get(0).(func() map[string]persistence.TimerFailoverLevel); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]persistence.TimerFailoverLevel)
+		}
+	}
+
+	return r0
+}
+
+// GetDomainNotificationVersion provides a mock function with given fields:
+func (_m *ShardContext) GetDomainNotificationVersion() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// UpdateDomainNotificationVersion provides a mock function with given fields: domainNotificationVersion
+func (_m *ShardContext) UpdateDomainNotificationVersion(domainNotificationVersion int64) error {
+	ret := _m.Called(domainNotificationVersion)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(domainNotificationVersion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateWorkflowExecution provides a mock function with given fields: request
+func (_m *ShardContext) CreateWorkflowExecution(request *persistence.CreateWorkflowExecutionRequest) (*persistence.CreateWorkflowExecutionResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.CreateWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(*persistence.CreateWorkflowExecutionRequest) *persistence.CreateWorkflowExecutionResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.CreateWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.CreateWorkflowExecutionRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateWorkflowExecution provides a mock function with given fields: request
+func (_m *ShardContext) UpdateWorkflowExecution(request *persistence.UpdateWorkflowExecutionRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.UpdateWorkflowExecutionRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetMutableState provides a mock function with given fields: request
+func (_m *ShardContext) ResetMutableState(request *persistence.ResetMutableStateRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.ResetMutableStateRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AppendHistoryEvents provides a mock function with given fields: request
+func (_m *ShardContext) AppendHistoryEvents(request *persistence.AppendHistoryEventsRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.AppendHistoryEventsRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyNewHistoryEvent provides a mock function with given fields: event
+func (_m *ShardContext) NotifyNewHistoryEvent(event *history.HistoryEventNotification) error {
+	ret := _m.Called(event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*history.HistoryEventNotification) error); ok {
+		r0 = rf(event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetConfig provides a mock function with given fields:
+func (_m *ShardContext) GetConfig() *history.Config {
+	ret := _m.Called()
+
+	var r0 *history.Config
+	if rf, ok := ret.Get(0).(func() *history.Config); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*history.Config)
+		}
+	}
+
+	return r0
+}
+
+// GetLogger provides a mock function with given fields:
+func (_m *ShardContext) GetLogger() bark.Logger {
+	ret := _m.Called()
+
+	var r0 bark.Logger
+	if rf, ok := ret.Get(0).(func() bark.Logger); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(bark.Logger)
+		}
+	}
+
+	return r0
+}
+
+// GetMetricsClient provides a mock function with given fields:
+func (_m *ShardContext) GetMetricsClient() metrics.Client {
+	ret := _m.Called()
+
+	var r0 metrics.Client
+	if rf, ok := ret.Get(0).(func() metrics.Client); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(metrics.Client)
+		}
+	}
+
+	return r0
+}
+
+// GetTimeSource provides a mock function with given fields:
+func (_m *ShardContext) GetTimeSource() common.TimeSource {
+	ret := _m.Called()
+
+	var r0 common.TimeSource
+	if rf, ok := ret.Get(0).(func() common.TimeSource); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.TimeSource)
+		}
+	}
+
+	return r0
+}
+
+// SetCurrentTime provides a mock function with given fields: cluster, currentTime
+func (_m *ShardContext) SetCurrentTime(cluster string, currentTime time.Time) {
+	_m.Called(cluster, currentTime)
+}
+
+// GetCurrentTime provides a mock function with given fields: cluster
+func (_m *ShardContext) GetCurrentTime(cluster string) time.Time {
+	ret := _m.Called(cluster)
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func(string) time.Time); ok {
+		r0 = rf(cluster)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// UpdateQueueBacklogAge provides a mock function with given fields: scope, queueName, age
+func (_m *ShardContext) UpdateQueueBacklogAge(scope int, queueName string, age time.Duration) {
+	_m.Called(scope, queueName, age)
+}
+
+// GetQueueBacklogAges provides a mock function with given fields:
+func (_m *ShardContext) GetQueueBacklogAges() map[string]time.Duration {
+	ret := _m.Called()
+
+	var r0 map[string]time.Duration
+	if rf, ok := ret.Get(0).(func() map[string]time.Duration); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]time.Duration)
+		}
+	}
+
+	return r0
+}
+
+// RecordOperation provides a mock function with given fields: operation, details
+func (_m *ShardContext) RecordOperation(operation string, details string) {
+	_m.Called(operation, details)
+}
+
+// GetRecentOperations provides a mock function with given fields: maximum
+func (_m *ShardContext) GetRecentOperations(maximum int) []history.ShardOperation {
+	ret := _m.Called(maximum)
+
+	var r0 []history.ShardOperation
+	if rf, ok := ret.Get(0).(func(int) []history.ShardOperation); ok {
+		r0 = rf(maximum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]history.ShardOperation)
+		}
+	}
+
+	return r0
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/service/history"
+)
+
+// TimerQueueAckMgr is an autogenerated mock type for the TimerQueueAckMgr type
+type TimerQueueAckMgr struct {
+	mock.Mock
+}
+
+var _ history.TimerQueueAckMgr = (*TimerQueueAckMgr)(nil)
+
+// CompleteTimerTask provides a mock function with given fields: timerTask
+func (_m *TimerQueueAckMgr) CompleteTimerTask(timerTask *persistence.TimerTaskInfo) {
+	_m.Called(timerTask)
+}
+
+// GetAckLevel provides a mock function with given fields:
+func (_m *TimerQueueAckMgr) GetAckLevel() history.TimerSequenceID {
+	ret := _m.Called()
+
+	var r0 history.TimerSequenceID
+	if rf, ok := ret.Get(0).(func() history.TimerSequenceID); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(history.TimerSequenceID)
+	}
+
+	return r0
+}
+
+// GetFinishedChan provides a mock function with given fields:
+func (_m *TimerQueueAckMgr) GetFinishedChan() <-chan struct{} {
+	ret := _m.Called()
+
+	var r0 <-chan struct{}
+	if rf, ok := ret.Get(0).(func() <-chan struct{}); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan struct{})
+		}
+	}
+
+	return r0
+}
+
+// GetReadLevel provides a mock function with given fields:
+func (_m *TimerQueueAckMgr) GetReadLevel() history.TimerSequenceID {
+	ret := _m.Called()
+
+	var r0 history.TimerSequenceID
+	if rf, ok := ret.Get(0).(func() history.TimerSequenceID); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(history.TimerSequenceID)
+	}
+
+	return r0
+}
+
+// ReadTimerTasks provides a mock function with given fields:
+func (_m *TimerQueueAckMgr) ReadTimerTasks() ([]*persistence.TimerTaskInfo, *persistence.TimerTaskInfo, bool, error) {
+	ret := _m.Called()
+
+	var r0 []*persistence.TimerTaskInfo
+	if rf, ok := ret.Get(0).(func() []*persistence.TimerTaskInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*persistence.TimerTaskInfo)
+		}
+	}
+
+	var r1 *persistence.TimerTaskInfo
+	if rf, ok := ret.Get(1).(func() *persistence.TimerTaskInfo); ok {
+		r1 = rf()
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*persistence.TimerTaskInfo)
+		}
+	}
+
+	var r2 bool
+	if rf, ok := ret.Get(2).(func() bool); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func() error); ok {
+		r3 = rf()
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// UpdateAckLevel provides a mock function with given fields:
+func (_m *TimerQueueAckMgr) UpdateAckLevel() {
+	_m.Called()
+}
@@ -41,7 +41,8 @@ const (
 )
 
 type (
-	mutableState interface {
+	// MutableState is the in memory state of a workflow execution, updated by adding history events to it
+	MutableState interface {
 		AddActivityTaskCancelRequestedEvent(int64, string, string) (*workflow.HistoryEvent, *persistence.ActivityInfo, bool)
 		AddActivityTaskCanceledEvent(int64, int64, int64, []uint8, string) *workflow.HistoryEvent
 		AddActivityTaskCompletedEvent(int64, int64, *workflow.RespondActivityTaskCompletedRequest) *workflow.HistoryEvent
@@ -57,12 +58,12 @@ type (
 		AddChildWorkflowExecutionTerminatedEvent(int64, *workflow.WorkflowExecution, *workflow.WorkflowExecutionTerminatedEventAttributes) *workflow.HistoryEvent
 		AddChildWorkflowExecutionTimedOutEvent(int64, *workflow.WorkflowExecution, *workflow.WorkflowExecutionTimedOutEventAttributes) *workflow.HistoryEvent
 		AddCompletedWorkflowEvent(int64, *workflow.CompleteWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent
-		AddContinueAsNewEvent(int64, *cache.DomainCacheEntry, string, string, *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) (*workflow.HistoryEvent, mutableState, error)
+		AddContinueAsNewEvent(int64, *cache.DomainCacheEntry, string, string, *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) (*workflow.HistoryEvent, MutableState, error)
 		AddDecisionTaskCompletedEvent(int64, int64, *workflow.RespondDecisionTaskCompletedRequest) *workflow.HistoryEvent
 		AddDecisionTaskFailedEvent(int64, int64, workflow.DecisionTaskFailedCause, []uint8, string) *workflow.HistoryEvent
 		AddDecisionTaskScheduleToStartTimeoutEvent(int64) *workflow.HistoryEvent
-		AddDecisionTaskScheduledEvent() *decisionInfo
-		AddDecisionTaskStartedEvent(int64, string, *workflow.PollForDecisionTaskRequest) (*workflow.HistoryEvent, *decisionInfo)
+		AddDecisionTaskScheduledEvent() *DecisionInfo
+		AddDecisionTaskStartedEvent(int64, string, *workflow.PollForDecisionTaskRequest) (*workflow.HistoryEvent, *DecisionInfo)
 		AddDecisionTaskTimedOutEvent(int64, int64) *workflow.HistoryEvent
 		AddExternalWorkflowExecutionCancelRequested(int64, string, string, string) *workflow.HistoryEvent
 		AddExternalWorkflowExecutionSignaled(int64, string, string, string, []uint8) *workflow.HistoryEvent
@@ -84,19 +85,22 @@ type (
 		AddWorkflowExecutionCanceledEvent(int64, *workflow.CancelWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent
 		AddWorkflowExecutionSignaled(*workflow.SignalWorkflowExecutionRequest) *workflow.HistoryEvent
 		AddWorkflowExecutionStartedEvent(workflow.WorkflowExecution, *h.StartWorkflowExecutionRequest) *workflow.HistoryEvent
-		AddWorkflowExecutionStartedEventForContinueAsNew(string, *h.ParentExecutionInfo, workflow.WorkflowExecution, mutableState, *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent
+		AddWorkflowExecutionStartedEventForContinueAsNew(string, *h.ParentExecutionInfo, workflow.WorkflowExecution, MutableState, *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent
+		AddWorkflowExecutionTaskListChangedEvent(*workflow.TaskList, string) *workflow.HistoryEvent
 		AddWorkflowExecutionTerminatedEvent(*workflow.TerminateWorkflowExecutionRequest) *workflow.HistoryEvent
+		AddWorkflowExecutionUpdateCompletedEvent(int64, *workflow.CompleteWorkflowUpdateDecisionAttributes) *workflow.HistoryEvent
+		AddWorkflowExecutionUpdateRequestedEvent(*workflow.UpdateWorkflowExecutionRequest) *workflow.HistoryEvent
 		AfterAddDecisionTaskCompletedEvent(int64)
 		BeforeAddDecisionTaskCompletedEvent()
 		BufferReplicationTask(*h.ReplicateEventsRequest) error
 		ClearStickyness()
-		CloseUpdateSession() (*mutableStateSessionUpdates, error)
+		CloseUpdateSession() (*MutableStateSessionUpdates, error)
 		CopyToPersistence() *persistence.WorkflowMutableState
 		CreateNewHistoryEvent(eventType workflow.EventType) *workflow.HistoryEvent
 		CreateNewHistoryEventWithTimestamp(eventType workflow.EventType, timestamp int64) *workflow.HistoryEvent
 		CreateRetryTimer(*persistence.ActivityInfo, string) persistence.Task
 		CreateReplicationTask() *persistence.HistoryReplicationTask
-		CreateTransientDecisionEvents(di *decisionInfo, identity string) (*workflow.HistoryEvent, *workflow.HistoryEvent)
+		CreateTransientDecisionEvents(di *DecisionInfo, identity string) (*workflow.HistoryEvent, *workflow.HistoryEvent)
 		DeleteActivity(int64) error
 		DeleteBufferedReplicationTask(int64)
 		DeleteDecision()
@@ -120,22 +124,25 @@ type (
 		GetContinueAsNew() *persistence.CreateWorkflowExecutionRequest
 		GetCurrentVersion() int64
 		GetExecutionInfo() *persistence.WorkflowExecutionInfo
-		GetHistoryBuilder() *historyBuilder
+		GetHistoryBuilder() *HistoryBuilder
 		GetHistoryEvent(serializedEvent []byte) (*workflow.HistoryEvent, bool)
-		GetInFlightDecisionTask() (*decisionInfo, bool)
+		GetInFlightDecisionTask() (*DecisionInfo, bool)
 		GetLastFirstEventID() int64
 		GetLastUpdatedTimestamp() int64
 		GetLastWriteVersion() int64
 		GetNextEventID() int64
-		GetPendingDecision(int64) (*decisionInfo, bool)
+		GetPendingDecision(int64) (*DecisionInfo, bool)
 		GetPendingActivityInfos() map[int64]*persistence.ActivityInfo
 		GetPendingTimerInfos() map[string]*persistence.TimerInfo
+		GetPersistedActivityHeartbeat(int64) time.Time
 		GetPendingChildExecutionInfos() map[int64]*persistence.ChildExecutionInfo
 		GetReplicationState() *persistence.ReplicationState
 		GetRequestCancelInfo(int64) (*persistence.RequestCancelInfo, bool)
 		GetScheduleIDByActivityID(string) (int64, bool)
 		GetSignalInfo(int64) (*persistence.SignalInfo, bool)
 		GetStartVersion() int64
+		GetUpdateInfo(string) (*persistence.UpdateInfo, bool)
+		GetUpdateInfoCount() int
 		GetUserTimer(string) (bool, *persistence.TimerInfo)
 		GetWorkflowType() *workflow.WorkflowType
 		HasBufferedEvents() bool
@@ -143,6 +150,7 @@ type (
 		HasInFlightDecisionTask() bool
 		HasParentExecution() bool
 		HasPendingDecisionTask() bool
+		InvalidateStickyness() int64
 		IsCancelRequested() (bool, string)
 		IsSignalRequested(requestID string) bool
 		IsStickyTaskListEnabled() bool
@@ -163,8 +171,8 @@ type (
 		ReplicateChildWorkflowExecutionTimedOutEvent(*workflow.HistoryEvent)
 		ReplicateDecisionTaskCompletedEvent(int64, int64)
 		ReplicateDecisionTaskFailedEvent(int64, int64)
-		ReplicateDecisionTaskScheduledEvent(int64, int64, string, int32) *decisionInfo
-		ReplicateDecisionTaskStartedEvent(*decisionInfo, int64, int64, int64, string, int64) *decisionInfo
+		ReplicateDecisionTaskScheduledEvent(int64, int64, string, int32) *DecisionInfo
+		ReplicateDecisionTaskStartedEvent(*DecisionInfo, int64, int64, int64, string, int64) *DecisionInfo
 		ReplicateDecisionTaskTimedOutEvent(int64, int64)
 		ReplicateExternalWorkflowExecutionCancelRequested(*workflow.HistoryEvent)
 		ReplicateExternalWorkflowExecutionSignaled(*workflow.HistoryEvent)
@@ -180,16 +188,20 @@ type (
 		ReplicateWorkflowExecutionCancelRequestedEvent(*workflow.HistoryEvent)
 		ReplicateWorkflowExecutionCanceledEvent(*workflow.HistoryEvent)
 		ReplicateWorkflowExecutionCompletedEvent(*workflow.HistoryEvent)
-		ReplicateWorkflowExecutionContinuedAsNewEvent(string, string, *workflow.HistoryEvent, *workflow.HistoryEvent, *decisionInfo, mutableState)
+		ReplicateWorkflowExecutionContinuedAsNewEvent(string, string, *workflow.HistoryEvent, *workflow.HistoryEvent, *DecisionInfo, MutableState)
 		ReplicateWorkflowExecutionFailedEvent(*workflow.HistoryEvent)
 		ReplicateWorkflowExecutionStartedEvent(string, *string, workflow.WorkflowExecution, string, *workflow.WorkflowExecutionStartedEventAttributes)
+		ReplicateWorkflowExecutionTaskListChangedEvent(*workflow.HistoryEvent)
 		ReplicateWorkflowExecutionTerminatedEvent(*workflow.HistoryEvent)
 		ReplicateWorkflowExecutionTimedoutEvent(*workflow.HistoryEvent)
+		ReplicateWorkflowExecutionUpdateCompletedEvent(*workflow.HistoryEvent)
+		ReplicateWorkflowExecutionUpdateRequestedEvent(*workflow.HistoryEvent) *persistence.UpdateInfo
 		ResetSnapshot() *persistence.ResetMutableStateRequest
-		SetHistoryBuilder(hBuilder *historyBuilder)
+		SetHistoryBuilder(hBuilder *HistoryBuilder)
+		SetHistoryEncodingFn(encodingFn func() common.EncodingType)
 		UpdateActivity(*persistence.ActivityInfo) error
 		UpdateActivityProgress(ai *persistence.ActivityInfo, request *workflow.RecordActivityTaskHeartbeatRequest)
-		UpdateDecision(*decisionInfo)
+		UpdateDecision(*DecisionInfo)
 		UpdateReplicationStateVersion(int64, bool)
 		UpdateReplicationStateLastEventID(string, int64, int64)
 		UpdateUserTimer(string, *persistence.TimerInfo)
@@ -200,6 +212,8 @@ type (
 		pendingActivityInfoByActivityID map[string]int64                       // Activity ID -> Schedule Event ID of the activity.
 		updateActivityInfos             map[*persistence.ActivityInfo]struct{} // Modified activities from last update.
 		deleteActivityInfos             map[int64]struct{}                     // Deleted activities from last update.
+		persistedHeartbeats             map[int64]time.Time                    // Schedule Event ID -> Last heartbeat written to persistence.
+		syncActivityInfos               map[int64]struct{}                     // Activities whose retry state changed since last update.
 
 		pendingTimerInfoIDs map[string]*persistence.TimerInfo   // User Timer ID -> Timer Info.
 		updateTimerInfos    map[*persistence.TimerInfo]struct{} // Modified timers from last update.
@@ -221,9 +235,13 @@ type (
 		updateSignalRequestedIDs  map[string]struct{} // Set of signaled requestIds since last update
 		deleteSignalRequestedID   string              // Deleted signaled requestId
 
+		pendingUpdateInfos map[string]*persistence.UpdateInfo   // Update ID -> UpdateInfo, kept after completion for dedupe
+		updateUpdateInfos  map[*persistence.UpdateInfo]struct{} // Modified UpdateInfo since last update
+
 		bufferedEvents       []*persistence.SerializedHistoryEventBatch // buffered history events that are already persisted
 		updateBufferedEvents *persistence.SerializedHistoryEventBatch   // buffered history events that needs to be persisted
 		clearBufferedEvents  bool                                       // delete buffered events from persistence
+		updatesSinceSnapshot int                                        // update sessions closed since the last snapshot
 
 		bufferedReplicationTasks       map[int64]*persistence.BufferedReplicationTask // Storage for out of order events
 		updateBufferedReplicationTasks *persistence.BufferedReplicationTask
@@ -232,16 +250,21 @@ type (
 		executionInfo    *persistence.WorkflowExecutionInfo // Workflow mutable state info.
 		replicationState *persistence.ReplicationState
 		continueAsNew    *persistence.CreateWorkflowExecutionRequest
-		hBuilder         *historyBuilder
+		hBuilder         *HistoryBuilder
 		eventSerializer  historyEventSerializer
 		config           *Config
 		logger           bark.Logger
+
+		// resolves the encoding new history batches are written in, as configured for the domain
+		historyEncodingFn func() common.EncodingType
 	}
 
-	mutableStateSessionUpdates struct {
-		newEventsBuilder                 *historyBuilder
+	// MutableStateSessionUpdates are the changes to a MutableState to persist at the end of an update session
+	MutableStateSessionUpdates struct {
+		newEventsBuilder                 *HistoryBuilder
 		updateActivityInfos              []*persistence.ActivityInfo
 		deleteActivityInfos              []int64
+		syncActivityInfos                []int64
 		updateTimerInfos                 []*persistence.TimerInfo
 		deleteTimerInfos                 []string
 		updateChildExecutionInfos        []*persistence.ChildExecutionInfo
@@ -252,15 +275,18 @@ type (
 		deleteSignalInfo                 *int64
 		updateSignalRequestedIDs         []string
 		deleteSignalRequestedID          string
+		updateUpdateInfos                []*persistence.UpdateInfo
 		continueAsNew                    *persistence.CreateWorkflowExecutionRequest
 		newBufferedEvents                *persistence.SerializedHistoryEventBatch
 		clearBufferedEvents              bool
 		newBufferedReplicationEventsInfo *persistence.BufferedReplicationTask
 		deleteBufferedReplicationEvent   *int64
+		snapshot                         bool
 	}
 
+	// DecisionInfo is the state of the pending decision of a workflow execution
 	// TODO: This should be part of persistence layer
-	decisionInfo struct {
+	DecisionInfo struct {
 		Version         int64
 		ScheduleID      int64
 		StartedID       int64
@@ -278,6 +304,8 @@ func newMutableStateBuilder(config *Config, logger bark.Logger) *mutableStateBui
 		pendingActivityInfoIDs:          make(map[int64]*persistence.ActivityInfo),
 		pendingActivityInfoByActivityID: make(map[string]int64),
 		deleteActivityInfos:             make(map[int64]struct{}),
+		persistedHeartbeats:             make(map[int64]time.Time),
+		syncActivityInfos:               make(map[int64]struct{}),
 
 		pendingTimerInfoIDs: make(map[string]*persistence.TimerInfo),
 		updateTimerInfos:    make(map[*persistence.TimerInfo]struct{}),
@@ -299,9 +327,14 @@ func newMutableStateBuilder(config *Config, logger bark.Logger) *mutableStateBui
 		pendingSignalRequestedIDs: make(map[string]struct{}),
 		deleteSignalRequestedID:   "",
 
+		updateUpdateInfos:  make(map[*persistence.UpdateInfo]struct{}),
+		pendingUpdateInfos: make(map[string]*persistence.UpdateInfo),
+
 		eventSerializer: newJSONHistoryEventSerializer(),
 		config:          config,
 		logger:          logger,
+
+		historyEncodingFn: defaultHistoryEncoding,
 	}
 	s.executionInfo = &persistence.WorkflowExecutionInfo{
 		NextEventID:        common.FirstEventID,
@@ -335,6 +368,7 @@ func (e *mutableStateBuilder) CopyToPersistence() *persistence.WorkflowMutableSt
 	state.RequestCancelInfos = e.pendingRequestCancelInfoIDs
 	state.SignalInfos = e.pendingSignalInfoIDs
 	state.SignalRequestedIDs = e.pendingSignalRequestedIDs
+	state.UpdateInfos = e.pendingUpdateInfos
 	state.ExecutionInfo = e.executionInfo
 	state.ReplicationState = e.replicationState
 	state.BufferedEvents = e.bufferedEvents
@@ -349,6 +383,7 @@ func (e *mutableStateBuilder) Load(state *persistence.WorkflowMutableState) {
 	e.pendingRequestCancelInfoIDs = state.RequestCancelInfos
 	e.pendingSignalInfoIDs = state.SignalInfos
 	e.pendingSignalRequestedIDs = state.SignalRequestedIDs
+	e.pendingUpdateInfos = state.UpdateInfos
 	e.executionInfo = state.ExecutionInfo
 
 	e.replicationState = state.ReplicationState
@@ -356,17 +391,23 @@ func (e *mutableStateBuilder) Load(state *persistence.WorkflowMutableState) {
 	e.bufferedReplicationTasks = state.BufferedReplicationTasks
 	for _, ai := range state.ActivitInfos {
 		e.pendingActivityInfoByActivityID[ai.ActivityID] = ai.ScheduleID
+		e.persistedHeartbeats[ai.ScheduleID] = ai.LastHeartBeatUpdatedTime
 	}
 }
 
-func (e *mutableStateBuilder) GetHistoryBuilder() *historyBuilder {
+func (e *mutableStateBuilder) GetHistoryBuilder() *HistoryBuilder {
 	return e.hBuilder
 }
 
-func (e *mutableStateBuilder) SetHistoryBuilder(hBuilder *historyBuilder) {
+func (e *mutableStateBuilder) SetHistoryBuilder(hBuilder *HistoryBuilder) {
 	e.hBuilder = hBuilder
 }
 
+func (e *mutableStateBuilder) SetHistoryEncodingFn(encodingFn func() common.EncodingType) {
+	e.historyEncodingFn = encodingFn
+	e.hBuilder.encodingFn = encodingFn
+}
+
 func (e *mutableStateBuilder) GetExecutionInfo() *persistence.WorkflowExecutionInfo {
 	return e.executionInfo
 }
@@ -406,6 +447,11 @@ func (e *mutableStateBuilder) ResetSnapshot() *persistence.ResetMutableStateRequ
 		insertSignalRequested = append(insertSignalRequested, id)
 	}
 
+	insertUpdates := make([]*persistence.UpdateInfo, 0, len(e.pendingUpdateInfos))
+	for _, info := range e.pendingUpdateInfos {
+		insertUpdates = append(insertUpdates, info)
+	}
+
 	return &persistence.ResetMutableStateRequest{
 		ExecutionInfo:             e.executionInfo,
 		ReplicationState:          e.replicationState,
@@ -415,6 +461,7 @@ func (e *mutableStateBuilder) ResetSnapshot() *persistence.ResetMutableStateRequ
 		InsertRequestCancelInfos:  insertRequestCancels,
 		InsertSignalInfos:         insertSignals,
 		InsertSignalRequestedIDs:  insertSignalRequested,
+		InsertUpdateInfos:         insertUpdates,
 	}
 }
 
@@ -435,8 +482,7 @@ func (e *mutableStateBuilder) FlushBufferedEvents() error {
 	// no decision in-flight, flush all buffered events to committed bucket
 	if !e.HasInFlightDecisionTask() {
 		flush := func(bufferedEventBatch *persistence.SerializedHistoryEventBatch) error {
-			// TODO: get serializer based on eventBatch's EncodingType when we support multiple encoding
-			eventBatch, err := e.hBuilder.serializer.Deserialize(bufferedEventBatch)
+			eventBatch, err := e.hBuilder.deserialize(bufferedEventBatch)
 			if err != nil {
 				logging.LogHistoryDeserializationErrorEvent(e.logger, err, "Unable to serialize execution history for update.")
 				return err
@@ -479,7 +525,7 @@ func (e *mutableStateBuilder) FlushBufferedEvents() error {
 	if e.HasInFlightDecisionTask() && len(newBufferedEvents) > 0 {
 		// decision in-flight, and some new events needs to be buffered
 		bufferedBatch := persistence.NewHistoryEventBatch(persistence.GetDefaultHistoryVersion(), newBufferedEvents)
-		serializedEvents, err := e.hBuilder.serializer.Serialize(bufferedBatch)
+		serializedEvents, err := e.hBuilder.serialize(bufferedBatch)
 		if err != nil {
 			logging.LogHistorySerializationErrorEvent(e.logger, err, "Unable to serialize execution history for update.")
 			return err
@@ -490,6 +536,53 @@ func (e *mutableStateBuilder) FlushBufferedEvents() error {
 	return nil
 }
 
+// reencodeBufferedEvents replaces the persisted and pending buffered events with a single batch written in the
+// domain's encoding
+func (e *mutableStateBuilder) reencodeBufferedEvents() error {
+	bufferedEventBatches := make([]*persistence.SerializedHistoryEventBatch, 0, len(e.bufferedEvents)+1)
+	bufferedEventBatches = append(bufferedEventBatches, e.bufferedEvents...)
+	if e.updateBufferedEvents != nil {
+		bufferedEventBatches = append(bufferedEventBatches, e.updateBufferedEvents)
+	}
+
+	var events []*workflow.HistoryEvent
+	for _, bufferedEventBatch := range bufferedEventBatches {
+		eventBatch, err := e.hBuilder.deserialize(bufferedEventBatch)
+		if err != nil {
+			logging.LogHistoryDeserializationErrorEvent(e.logger, err, "Unable to re-encode buffered events for update.")
+			return err
+		}
+		events = append(events, eventBatch.Events...)
+	}
+
+	bufferedBatch := persistence.NewHistoryEventBatch(persistence.GetDefaultHistoryVersion(), events)
+	serializedEvents, err := e.hBuilder.serialize(bufferedBatch)
+	if err != nil {
+		logging.LogHistorySerializationErrorEvent(e.logger, err, "Unable to re-encode buffered events for update.")
+		return err
+	}
+
+	e.clearBufferedEvents = true
+	e.bufferedEvents = nil
+	e.updateBufferedEvents = serializedEvents
+	return nil
+}
+
+func (e *mutableStateBuilder) hasBufferedEventsToReencode() bool {
+	if len(e.bufferedEvents) == 0 {
+		return false
+	}
+
+	encodingType := e.historyEncodingFn()
+	for _, bufferedEventBatch := range e.bufferedEvents {
+		persistence.SetSerializedHistoryDefaults(bufferedEventBatch)
+		if bufferedEventBatch.EncodingType != encodingType {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *mutableStateBuilder) GetStartVersion() int64 {
 	if e.replicationState == nil {
 		return common.EmptyVersion
@@ -541,15 +634,29 @@ func (e *mutableStateBuilder) UpdateReplicationStateLastEventID(clusterName stri
 	}
 }
 
-func (e *mutableStateBuilder) CloseUpdateSession() (*mutableStateSessionUpdates, error) {
+func (e *mutableStateBuilder) CloseUpdateSession() (*MutableStateSessionUpdates, error) {
 	if err := e.FlushBufferedEvents(); err != nil {
 		return nil, err
 	}
 
-	updates := &mutableStateSessionUpdates{
+	// Buffered events are only appended to by regular updates, rewriting them to migrate their encoding is left to
+	// the periodic snapshot which also writes the replication state in full
+	e.updatesSinceSnapshot++
+	snapshot := e.updatesSinceSnapshot >= e.config.MutableStateSnapshotInterval()
+	if snapshot {
+		if e.hasBufferedEventsToReencode() {
+			if err := e.reencodeBufferedEvents(); err != nil {
+				return nil, err
+			}
+		}
+		e.updatesSinceSnapshot = 0
+	}
+
+	updates := &MutableStateSessionUpdates{
 		newEventsBuilder:                 e.hBuilder,
 		updateActivityInfos:              convertUpdateActivityInfos(e.updateActivityInfos),
 		deleteActivityInfos:              convertDeleteActivityInfos(e.deleteActivityInfos),
+		syncActivityInfos:                convertSyncActivityInfos(e.syncActivityInfos),
 		updateTimerInfos:                 convertUpdateTimerInfos(e.updateTimerInfos),
 		deleteTimerInfos:                 convertDeleteTimerInfos(e.deleteTimerInfos),
 		updateChildExecutionInfos:        convertUpdateChildExecutionInfos(e.updateChildExecutionInfos),
@@ -560,17 +667,29 @@ func (e *mutableStateBuilder) CloseUpdateSession() (*mutableStateSessionUpdates,
 		deleteSignalInfo:                 e.deleteSignalInfo,
 		updateSignalRequestedIDs:         convertSignalRequestedIDs(e.updateSignalRequestedIDs),
 		deleteSignalRequestedID:          e.deleteSignalRequestedID,
+		updateUpdateInfos:                convertUpdateUpdateInfos(e.updateUpdateInfos),
 		continueAsNew:                    e.continueAsNew,
 		newBufferedEvents:                e.updateBufferedEvents,
 		clearBufferedEvents:              e.clearBufferedEvents,
 		newBufferedReplicationEventsInfo: e.updateBufferedReplicationTasks,
 		deleteBufferedReplicationEvent:   e.deleteBufferedReplicationEvent,
+		snapshot:                         snapshot,
+	}
+
+	// The updates are written to persistence by the caller, which clears the cached mutable state if that fails
+	for ai := range e.updateActivityInfos {
+		e.persistedHeartbeats[ai.ScheduleID] = ai.LastHeartBeatUpdatedTime
+	}
+	for scheduleID := range e.deleteActivityInfos {
+		delete(e.persistedHeartbeats, scheduleID)
 	}
 
 	// Clear all updates to prepare for the next session
 	e.hBuilder = newHistoryBuilder(e, e.logger)
+	e.hBuilder.encodingFn = e.historyEncodingFn
 	e.updateActivityInfos = make(map[*persistence.ActivityInfo]struct{})
 	e.deleteActivityInfos = make(map[int64]struct{})
+	e.syncActivityInfos = make(map[int64]struct{})
 	e.updateTimerInfos = make(map[*persistence.TimerInfo]struct{})
 	e.deleteTimerInfos = make(map[string]struct{})
 	e.updateChildExecutionInfos = make(map[*persistence.ChildExecutionInfo]struct{})
@@ -581,6 +700,7 @@ func (e *mutableStateBuilder) CloseUpdateSession() (*mutableStateSessionUpdates,
 	e.deleteSignalInfo = nil
 	e.updateSignalRequestedIDs = make(map[string]struct{})
 	e.deleteSignalRequestedID = ""
+	e.updateUpdateInfos = make(map[*persistence.UpdateInfo]struct{})
 	e.continueAsNew = nil
 	e.clearBufferedEvents = false
 	if e.updateBufferedEvents != nil {
@@ -607,7 +727,7 @@ func (e *mutableStateBuilder) BufferReplicationTask(
 	var serializedHistoryBatch, serializedNewRunHistoryBatch *persistence.SerializedHistoryEventBatch
 	if request.History != nil {
 		historyBatch := persistence.NewHistoryEventBatch(persistence.GetDefaultHistoryVersion(), request.History.Events)
-		serializedHistoryBatch, err = e.hBuilder.serializer.Serialize(historyBatch)
+		serializedHistoryBatch, err = e.hBuilder.serialize(historyBatch)
 		if err != nil {
 			return err
 		}
@@ -616,7 +736,7 @@ func (e *mutableStateBuilder) BufferReplicationTask(
 	if request.NewRunHistory != nil {
 		newRunHistoryBatch := persistence.NewHistoryEventBatch(persistence.GetDefaultHistoryVersion(),
 			request.NewRunHistory.Events)
-		serializedNewRunHistoryBatch, err = e.hBuilder.serializer.Serialize(newRunHistoryBatch)
+		serializedNewRunHistoryBatch, err = e.hBuilder.serialize(newRunHistoryBatch)
 		if err != nil {
 			return err
 		}
@@ -652,7 +772,7 @@ func (e *mutableStateBuilder) GetBufferedHistory(
 
 	if serializedHistory != nil {
 		var history []*workflow.HistoryEvent
-		batch, err := e.hBuilder.serializer.Deserialize(serializedHistory)
+		batch, err := e.hBuilder.deserialize(serializedHistory)
 		if err != nil {
 			// TODO: return proper error here
 			return nil
@@ -687,6 +807,14 @@ func convertUpdateActivityInfos(inputs map[*persistence.ActivityInfo]struct{}) [
 	return outputs
 }
 
+func convertSyncActivityInfos(inputs map[int64]struct{}) []int64 {
+	outputs := []int64{}
+	for item := range inputs {
+		outputs = append(outputs, item)
+	}
+	return outputs
+}
+
 func convertDeleteActivityInfos(inputs map[int64]struct{}) []int64 {
 	outputs := []int64{}
 	for item := range inputs {
@@ -735,6 +863,14 @@ func convertUpdateSignalInfos(inputs map[*persistence.SignalInfo]struct{}) []*pe
 	return outputs
 }
 
+func convertUpdateUpdateInfos(inputs map[*persistence.UpdateInfo]struct{}) []*persistence.UpdateInfo {
+	outputs := []*persistence.UpdateInfo{}
+	for item := range inputs {
+		outputs = append(outputs, item)
+	}
+	return outputs
+}
+
 func convertSignalRequestedIDs(inputs map[string]struct{}) []string {
 	outputs := []string{}
 	for item := range inputs {
@@ -888,7 +1024,8 @@ func (e *mutableStateBuilder) shouldBufferEvent(eventType workflow.EventType) bo
 		workflow.EventTypeRequestCancelExternalWorkflowExecutionInitiated,
 		workflow.EventTypeMarkerRecorded,
 		workflow.EventTypeStartChildWorkflowExecutionInitiated,
-		workflow.EventTypeSignalExternalWorkflowExecutionInitiated:
+		workflow.EventTypeSignalExternalWorkflowExecutionInitiated,
+		workflow.EventTypeWorkflowExecutionUpdateCompleted:
 		// do not buffer event if event is directly generated from a corresponding decision
 
 		// sanity check there is no decision on the fly
@@ -1061,6 +1198,12 @@ func (e *mutableStateBuilder) UpdateActivityProgress(ai *persistence.ActivityInf
 	e.updateActivityInfos[ai] = struct{}{}
 }
 
+// GetPersistedActivityHeartbeat returns the heartbeat time of the activity as last written to persistence. Heartbeats
+// received since then are only applied to the mutable state cached in memory.
+func (e *mutableStateBuilder) GetPersistedActivityHeartbeat(scheduleEventID int64) time.Time {
+	return e.persistedHeartbeats[scheduleEventID]
+}
+
 // UpdateActivity updates an activity
 func (e *mutableStateBuilder) UpdateActivity(ai *persistence.ActivityInfo) error {
 	_, ok := e.pendingActivityInfoIDs[ai.ScheduleID]
@@ -1089,6 +1232,8 @@ func (e *mutableStateBuilder) DeleteActivity(scheduleEventID int64) error {
 	}
 	delete(e.pendingActivityInfoByActivityID, a.ActivityID)
 
+	// a heartbeat not yet written to persistence must not recreate the deleted activity
+	delete(e.updateActivityInfos, a)
 	e.deleteActivityInfos[scheduleEventID] = struct{}{}
 	return nil
 }
@@ -1111,8 +1256,8 @@ func (e *mutableStateBuilder) DeleteUserTimer(timerID string) {
 	e.deleteTimerInfos[timerID] = struct{}{}
 }
 
-func (e *mutableStateBuilder) getDecisionInfo() *decisionInfo {
-	return &decisionInfo{
+func (e *mutableStateBuilder) getDecisionInfo() *DecisionInfo {
+	return &DecisionInfo{
 		Version:         e.executionInfo.DecisionVersion,
 		ScheduleID:      e.executionInfo.DecisionScheduleID,
 		StartedID:       e.executionInfo.DecisionStartedID,
@@ -1124,7 +1269,7 @@ func (e *mutableStateBuilder) getDecisionInfo() *decisionInfo {
 }
 
 // GetPendingDecision returns details about the in-progress decision task
-func (e *mutableStateBuilder) GetPendingDecision(scheduleEventID int64) (*decisionInfo, bool) {
+func (e *mutableStateBuilder) GetPendingDecision(scheduleEventID int64) (*DecisionInfo, bool) {
 	di := e.getDecisionInfo()
 	if scheduleEventID == di.ScheduleID {
 		return di, true
@@ -1152,7 +1297,7 @@ func (e *mutableStateBuilder) HasInFlightDecisionTask() bool {
 	return e.executionInfo.DecisionStartedID > 0
 }
 
-func (e *mutableStateBuilder) GetInFlightDecisionTask() (*decisionInfo, bool) {
+func (e *mutableStateBuilder) GetInFlightDecisionTask() (*DecisionInfo, bool) {
 	if e.executionInfo.DecisionScheduleID == common.EmptyEventID ||
 		e.executionInfo.DecisionStartedID == common.EmptyEventID {
 		return nil, false
@@ -1185,7 +1330,7 @@ func (e *mutableStateBuilder) HasBufferedReplicationTasks() bool {
 }
 
 // UpdateDecision updates a decision task.
-func (e *mutableStateBuilder) UpdateDecision(di *decisionInfo) {
+func (e *mutableStateBuilder) UpdateDecision(di *DecisionInfo) {
 	e.executionInfo.DecisionVersion = di.Version
 	e.executionInfo.DecisionScheduleID = di.ScheduleID
 	e.executionInfo.DecisionStartedID = di.StartedID
@@ -1200,7 +1345,7 @@ func (e *mutableStateBuilder) UpdateDecision(di *decisionInfo) {
 
 // DeleteDecision deletes a decision task.
 func (e *mutableStateBuilder) DeleteDecision() {
-	emptyDecisionInfo := &decisionInfo{
+	emptyDecisionInfo := &DecisionInfo{
 		Version:         common.EmptyVersion,
 		ScheduleID:      common.EmptyEventID,
 		StartedID:       common.EmptyEventID,
@@ -1216,7 +1361,7 @@ func (e *mutableStateBuilder) FailDecision() {
 	// Clear stickiness whenever decision fails
 	e.ClearStickyness()
 
-	failDecisionInfo := &decisionInfo{
+	failDecisionInfo := &DecisionInfo{
 		Version:         common.EmptyVersion,
 		ScheduleID:      common.EmptyEventID,
 		StartedID:       common.EmptyEventID,
@@ -1235,6 +1380,14 @@ func (e *mutableStateBuilder) ClearStickyness() {
 	e.executionInfo.ClientImpl = ""
 }
 
+// InvalidateStickyness clears the sticky attributes and bumps the sticky invalidation count, so workers can tell
+// their cached copy of this workflow is no longer served from the sticky task list
+func (e *mutableStateBuilder) InvalidateStickyness() int64 {
+	e.ClearStickyness()
+	e.executionInfo.StickyInvalidationCount++
+	return e.executionInfo.StickyInvalidationCount
+}
+
 // GetLastFirstEventID returns last first event ID
 // first event ID is the ID of a batch of events in a single history events record
 func (e *mutableStateBuilder) GetLastFirstEventID() int64 {
@@ -1291,10 +1444,13 @@ func (e *mutableStateBuilder) GetHistoryEvent(serializedEvent []byte) (*workflow
 }
 
 func (e *mutableStateBuilder) AddWorkflowExecutionStartedEventForContinueAsNew(domainID string,
-	parentExecutionInfo *h.ParentExecutionInfo, execution workflow.WorkflowExecution, previousExecutionState mutableState,
+	parentExecutionInfo *h.ParentExecutionInfo, execution workflow.WorkflowExecution, previousExecutionState MutableState,
 	attributes *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	previousExecutionInfo := previousExecutionState.GetExecutionInfo()
 	taskList := previousExecutionInfo.TaskList
+	if previousExecutionInfo.ContinueAsNewTaskList != "" {
+		taskList = previousExecutionInfo.ContinueAsNewTaskList
+	}
 	if attributes.TaskList != nil {
 		taskList = attributes.TaskList.GetName()
 	}
@@ -1323,6 +1479,8 @@ func (e *mutableStateBuilder) AddWorkflowExecutionStartedEventForContinueAsNew(d
 		ExecutionStartToCloseTimeoutSeconds: attributes.ExecutionStartToCloseTimeoutSeconds,
 		Input:    attributes.Input,
 		Identity: nil,
+		Tags:     previousExecutionInfo.Tags,
+		Priority: common.Int32Ptr(previousExecutionInfo.Priority),
 	}
 
 	req := &h.StartWorkflowExecutionRequest{
@@ -1338,6 +1496,12 @@ func (e *mutableStateBuilder) AddWorkflowExecutionStartedEventForContinueAsNew(d
 	}
 
 	event := e.hBuilder.AddWorkflowExecutionStartedEvent(req, &previousExecutionInfo.RunID)
+	// runs started before the first run ID was tracked are the first of their chain as far as it is known
+	firstRunID := previousExecutionInfo.FirstRunID
+	if firstRunID == "" {
+		firstRunID = previousExecutionInfo.RunID
+	}
+	event.WorkflowExecutionStartedEventAttributes.FirstExecutionRunId = common.StringPtr(firstRunID)
 	e.ReplicateWorkflowExecutionStartedEvent(domainID, parentDomainID, execution, createRequest.GetRequestId(),
 		event.WorkflowExecutionStartedEventAttributes)
 
@@ -1354,6 +1518,7 @@ func (e *mutableStateBuilder) AddWorkflowExecutionStartedEvent(execution workflo
 	}
 
 	event := e.hBuilder.AddWorkflowExecutionStartedEvent(startRequest, nil)
+	event.WorkflowExecutionStartedEventAttributes.FirstExecutionRunId = execution.RunId
 
 	var parentDomainID *string
 	if startRequest.ParentExecutionInfo != nil {
@@ -1374,6 +1539,13 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionStartedEvent(domainID st
 	e.executionInfo.WorkflowTypeName = event.WorkflowType.GetName()
 	e.executionInfo.WorkflowTimeout = event.GetExecutionStartToCloseTimeoutSeconds()
 	e.executionInfo.DecisionTimeoutValue = event.GetTaskStartToCloseTimeoutSeconds()
+	e.executionInfo.Tags = event.Tags
+	e.executionInfo.Priority = event.GetPriority()
+	// events written before the first run ID was tracked don't have it
+	e.executionInfo.FirstRunID = event.GetFirstExecutionRunId()
+	if e.executionInfo.FirstRunID == "" {
+		e.executionInfo.FirstRunID = execution.GetRunId()
+	}
 
 	e.executionInfo.State = persistence.WorkflowStateCreated
 	e.executionInfo.CloseStatus = persistence.WorkflowCloseStatusNone
@@ -1397,7 +1569,7 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionStartedEvent(domainID st
 	}
 }
 
-func (e *mutableStateBuilder) AddDecisionTaskScheduledEvent() *decisionInfo {
+func (e *mutableStateBuilder) AddDecisionTaskScheduledEvent() *DecisionInfo {
 	if e.HasPendingDecisionTask() {
 		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionDecisionTaskScheduled, e.GetNextEventID(),
 			fmt.Sprintf("{Pending Decision ScheduleID: %v}", e.executionInfo.DecisionScheduleID))
@@ -1430,8 +1602,8 @@ func (e *mutableStateBuilder) AddDecisionTaskScheduledEvent() *decisionInfo {
 }
 
 func (e *mutableStateBuilder) ReplicateDecisionTaskScheduledEvent(version, scheduleID int64, taskList string,
-	startToCloseTimeoutSeconds int32) *decisionInfo {
-	di := &decisionInfo{
+	startToCloseTimeoutSeconds int32) *DecisionInfo {
+	di := &DecisionInfo{
 		Version:         version,
 		ScheduleID:      scheduleID,
 		StartedID:       common.EmptyEventID,
@@ -1446,7 +1618,7 @@ func (e *mutableStateBuilder) ReplicateDecisionTaskScheduledEvent(version, sched
 }
 
 func (e *mutableStateBuilder) AddDecisionTaskStartedEvent(scheduleEventID int64, requestID string,
-	request *workflow.PollForDecisionTaskRequest) (*workflow.HistoryEvent, *decisionInfo) {
+	request *workflow.PollForDecisionTaskRequest) (*workflow.HistoryEvent, *DecisionInfo) {
 	hasPendingDecision := e.HasPendingDecisionTask()
 	di, ok := e.GetPendingDecision(scheduleEventID)
 	if !hasPendingDecision || !ok || di.StartedID != common.EmptyEventID {
@@ -1475,13 +1647,15 @@ func (e *mutableStateBuilder) AddDecisionTaskStartedEvent(scheduleEventID int64,
 		startedID = event.GetEventId()
 		timestamp = int64(0)
 	}
+	// Tasks generated by this decision are dispatched to the isolation group of its worker first
+	e.executionInfo.IsolationGroup = request.GetIsolationGroup()
 
 	di = e.ReplicateDecisionTaskStartedEvent(di, e.GetCurrentVersion(), scheduleID, startedID, requestID, timestamp)
 	return event, di
 }
 
-func (e *mutableStateBuilder) ReplicateDecisionTaskStartedEvent(di *decisionInfo, version, scheduleID, startedID int64,
-	requestID string, timestamp int64) *decisionInfo {
+func (e *mutableStateBuilder) ReplicateDecisionTaskStartedEvent(di *DecisionInfo, version, scheduleID, startedID int64,
+	requestID string, timestamp int64) *DecisionInfo {
 	// Replicator calls it with a nil decision info, and it is safe to always lookup the decision in this case as it
 	// does not have to deal with transient decision case.
 	if di == nil {
@@ -1490,7 +1664,7 @@ func (e *mutableStateBuilder) ReplicateDecisionTaskStartedEvent(di *decisionInfo
 
 	e.executionInfo.State = persistence.WorkflowStateRunning
 	// Update mutable decision state
-	di = &decisionInfo{
+	di = &DecisionInfo{
 		Version:         version,
 		ScheduleID:      scheduleID,
 		StartedID:       startedID,
@@ -1504,7 +1678,7 @@ func (e *mutableStateBuilder) ReplicateDecisionTaskStartedEvent(di *decisionInfo
 	return di
 }
 
-func (e *mutableStateBuilder) CreateTransientDecisionEvents(di *decisionInfo, identity string) (*workflow.HistoryEvent,
+func (e *mutableStateBuilder) CreateTransientDecisionEvents(di *DecisionInfo, identity string) (*workflow.HistoryEvent,
 	*workflow.HistoryEvent) {
 	tasklist := e.executionInfo.TaskList
 	scheduledEvent := newDecisionTaskScheduledEventWithInfo(di.ScheduleID, di.Timestamp, tasklist, di.DecisionTimeout,
@@ -1711,11 +1885,13 @@ func (e *mutableStateBuilder) AddActivityTaskStartedEvent(ai *persistence.Activi
 
 	// we might need to retry, so do not append started event just yet,
 	// instead update mutable state and will record started event when activity task is closed
+	ai.Version = e.GetCurrentVersion()
 	ai.StartedID = common.TransientEventID
 	ai.RequestID = requestID
 	ai.StartedTime = time.Now()
 	ai.StartedIdentity = identity
 	e.UpdateActivity(ai)
+	e.syncActivityInfos[ai.ScheduleID] = struct{}{}
 	return nil
 }
 
@@ -2250,8 +2426,101 @@ func (e *mutableStateBuilder) AddWorkflowExecutionSignaled(
 	return e.hBuilder.AddWorkflowExecutionSignaledEvent(request)
 }
 
+func (e *mutableStateBuilder) AddWorkflowExecutionUpdateRequestedEvent(
+	request *workflow.UpdateWorkflowExecutionRequest) *workflow.HistoryEvent {
+	if e.executionInfo.State == persistence.WorkflowStateCompleted {
+		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionWorkflowUpdateRequested, e.GetNextEventID(), fmt.Sprintf(
+			"{State: %v}", e.executionInfo.State))
+		return nil
+	}
+
+	event := e.hBuilder.AddWorkflowExecutionUpdateRequestedEvent(request)
+	e.ReplicateWorkflowExecutionUpdateRequestedEvent(event)
+
+	return event
+}
+
+func (e *mutableStateBuilder) ReplicateWorkflowExecutionUpdateRequestedEvent(
+	event *workflow.HistoryEvent) *persistence.UpdateInfo {
+	attributes := event.WorkflowExecutionUpdateRequestedEventAttributes
+	ui := &persistence.UpdateInfo{
+		Version:  event.GetVersion(),
+		UpdateID: attributes.GetUpdateId(),
+	}
+
+	if e.pendingUpdateInfos == nil {
+		e.pendingUpdateInfos = make(map[string]*persistence.UpdateInfo)
+	}
+	e.pendingUpdateInfos[ui.UpdateID] = ui
+	e.updateUpdateInfos[ui] = struct{}{}
+
+	return ui
+}
+
+// AddWorkflowExecutionTaskListChangedEvent moves the decisions of a running workflow to another task list.  The
+// sticky task list is dropped as well, so the next decision is scheduled on the new task list.
+func (e *mutableStateBuilder) AddWorkflowExecutionTaskListChangedEvent(taskList *workflow.TaskList,
+	identity string) *workflow.HistoryEvent {
+	if e.executionInfo.State == persistence.WorkflowStateCompleted {
+		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionWorkflowTaskListChanged, e.GetNextEventID(), fmt.Sprintf(
+			"{State: %v}", e.executionInfo.State))
+		return nil
+	}
+
+	event := e.hBuilder.AddWorkflowExecutionTaskListChangedEvent(taskList, identity)
+	e.ReplicateWorkflowExecutionTaskListChangedEvent(event)
+
+	return event
+}
+
+func (e *mutableStateBuilder) ReplicateWorkflowExecutionTaskListChangedEvent(event *workflow.HistoryEvent) {
+	attributes := event.WorkflowExecutionTaskListChangedEventAttributes
+	e.executionInfo.TaskList = attributes.TaskList.GetName()
+	e.ClearStickyness()
+}
+
+func (e *mutableStateBuilder) AddWorkflowExecutionUpdateCompletedEvent(decisionCompletedEventID int64,
+	attributes *workflow.CompleteWorkflowUpdateDecisionAttributes) *workflow.HistoryEvent {
+	ui, ok := e.GetUpdateInfo(attributes.GetUpdateId())
+	if !ok || ui.Completed {
+		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionWorkflowUpdateCompleted, e.GetNextEventID(), fmt.Sprintf(
+			"{UpdateID: %v, Exist: %v}", attributes.GetUpdateId(), ok))
+		return nil
+	}
+
+	event := e.hBuilder.AddWorkflowExecutionUpdateCompletedEvent(decisionCompletedEventID, attributes)
+	e.ReplicateWorkflowExecutionUpdateCompletedEvent(event)
+
+	return event
+}
+
+func (e *mutableStateBuilder) ReplicateWorkflowExecutionUpdateCompletedEvent(event *workflow.HistoryEvent) {
+	attributes := event.WorkflowExecutionUpdateCompletedEventAttributes
+	ui, ok := e.pendingUpdateInfos[attributes.GetUpdateId()]
+	if !ok {
+		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionWorkflowUpdateCompleted, e.GetNextEventID(), fmt.Sprintf(
+			"{UpdateID: %v, Exist: %v}", attributes.GetUpdateId(), ok))
+		return
+	}
+	ui.Version = event.GetVersion()
+	ui.Completed = true
+	ui.Result = attributes.Result
+	e.updateUpdateInfos[ui] = struct{}{}
+}
+
+// GetUpdateInfo gets details about an update requested on the workflow, including completed ones.
+func (e *mutableStateBuilder) GetUpdateInfo(updateID string) (*persistence.UpdateInfo, bool) {
+	ui, ok := e.pendingUpdateInfos[updateID]
+	return ui, ok
+}
+
+// GetUpdateInfoCount returns the number of updates requested on the workflow run so far.
+func (e *mutableStateBuilder) GetUpdateInfoCount() int {
+	return len(e.pendingUpdateInfos)
+}
+
 func (e *mutableStateBuilder) AddContinueAsNewEvent(decisionCompletedEventID int64, domainEntry *cache.DomainCacheEntry, newRunID string,
-	parentDomainName string, attributes *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) (*workflow.HistoryEvent, mutableState,
+	parentDomainName string, attributes *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) (*workflow.HistoryEvent, MutableState,
 	error) {
 
 	newExecution := workflow.WorkflowExecution{
@@ -2283,6 +2552,7 @@ func (e *mutableStateBuilder) AddContinueAsNewEvent(decisionCompletedEventID int
 	} else {
 		newStateBuilder = newMutableStateBuilder(e.config, e.logger)
 	}
+	newStateBuilder.SetHistoryEncodingFn(domainEntry.GetHistoryEncoding)
 	domainID := domainEntry.GetInfo().ID
 	startedEvent := newStateBuilder.AddWorkflowExecutionStartedEventForContinueAsNew(domainID, parentInfo, newExecution, e, attributes)
 	if startedEvent == nil {
@@ -2299,8 +2569,8 @@ func (e *mutableStateBuilder) AddContinueAsNewEvent(decisionCompletedEventID int
 }
 
 func (e *mutableStateBuilder) ReplicateWorkflowExecutionContinuedAsNewEvent(sourceClusterName string, domainID string,
-	continueAsNewEvent *workflow.HistoryEvent, startedEvent *workflow.HistoryEvent, di *decisionInfo,
-	newStateBuilder mutableState) {
+	continueAsNewEvent *workflow.HistoryEvent, startedEvent *workflow.HistoryEvent, di *DecisionInfo,
+	newStateBuilder MutableState) {
 	continueAsNewAttributes := continueAsNewEvent.WorkflowExecutionContinuedAsNewEventAttributes
 	startedAttributes := startedEvent.WorkflowExecutionStartedEventAttributes
 
@@ -2368,6 +2638,9 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionContinuedAsNewEvent(sour
 		ContinueAsNew:               true,
 		PreviousRunID:               prevRunID,
 		ReplicationState:            newStateBuilder.GetReplicationState(),
+		Tags:                        newExecutionInfo.Tags,
+		Priority:                    newExecutionInfo.Priority,
+		FirstRunID:                  newExecutionInfo.FirstRunID,
 	}
 }
 
@@ -2605,7 +2878,10 @@ func (e *mutableStateBuilder) ReplicateChildWorkflowExecutionTimedOutEvent(event
 func (e *mutableStateBuilder) CreateRetryTimer(ai *persistence.ActivityInfo, failureReason string) persistence.Task {
 	retryTask := prepareNextRetry(ai, failureReason)
 	if retryTask != nil {
+		// the retry timer carries the current version, which the activity is checked against once the timer fires
+		ai.Version = e.GetCurrentVersion()
 		e.updateActivityInfos[ai] = struct{}{}
+		e.syncActivityInfos[ai.ScheduleID] = struct{}{}
 	}
 
 	return retryTask
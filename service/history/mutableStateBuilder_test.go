@@ -24,10 +24,13 @@ import (
 	"os"
 	"testing"
 
+	"github.com/pborman/uuid"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
 	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
@@ -100,6 +103,7 @@ func (s *mutableStateSuite) TestShouldBufferEvent() {
 		workflow.EventTypeMarkerRecorded:                                  true,
 		workflow.EventTypeStartChildWorkflowExecutionInitiated:            true,
 		workflow.EventTypeSignalExternalWorkflowExecutionInitiated:        true,
+		workflow.EventTypeWorkflowExecutionUpdateCompleted:                true,
 	}
 
 	// other events will not be assign event ID immediately
@@ -138,3 +142,177 @@ OtherEventsLoop:
 	s.Equal(len(workflow.DecisionType_Values())+1, len(decisionEvents),
 		"This assertaion will be broken a new decision is added and no corresponding logic added to shouldBufferEvent()")
 }
+
+func (s *mutableStateSuite) TestWorkflowUpdate() {
+	updateID := "some random update ID"
+	event := s.msBuilder.AddWorkflowExecutionUpdateRequestedEvent(&workflow.UpdateWorkflowExecutionRequest{
+		UpdateName: common.StringPtr("some random update name"),
+		UpdateId:   common.StringPtr(updateID),
+		Input:      []byte("some random input"),
+	})
+	s.NotNil(event)
+	s.Equal(1, s.msBuilder.GetUpdateInfoCount())
+	updateInfo, ok := s.msBuilder.GetUpdateInfo(updateID)
+	s.True(ok)
+	s.False(updateInfo.Completed)
+
+	attributes := &workflow.CompleteWorkflowUpdateDecisionAttributes{
+		UpdateId: common.StringPtr(updateID),
+		Result:   []byte("some random result"),
+	}
+	event = s.msBuilder.AddWorkflowExecutionUpdateCompletedEvent(123, attributes)
+	s.NotNil(event)
+	s.Equal(int64(123), event.WorkflowExecutionUpdateCompletedEventAttributes.GetDecisionTaskCompletedEventId())
+	updateInfo, ok = s.msBuilder.GetUpdateInfo(updateID)
+	s.True(ok)
+	s.True(updateInfo.Completed)
+	s.Equal(attributes.Result, updateInfo.Result)
+
+	// an update is completed only once, and one never requested cannot be completed
+	s.Nil(s.msBuilder.AddWorkflowExecutionUpdateCompletedEvent(123, attributes))
+	attributes.UpdateId = common.StringPtr("some other update ID")
+	s.Nil(s.msBuilder.AddWorkflowExecutionUpdateCompletedEvent(123, attributes))
+
+	updates, err := s.msBuilder.CloseUpdateSession()
+	s.NoError(err)
+	s.Equal(1, len(updates.updateUpdateInfos))
+	s.Equal(1, len(s.msBuilder.ResetSnapshot().InsertUpdateInfos))
+}
+
+func (s *mutableStateSuite) TestInvalidateStickyness() {
+	executionInfo := s.msBuilder.GetExecutionInfo()
+	executionInfo.StickyTaskList = "some random sticky task list"
+	executionInfo.StickyScheduleToStartTimeout = 10
+	executionInfo.ClientLibraryVersion = "0.1.0"
+
+	s.Equal(int64(1), s.msBuilder.InvalidateStickyness())
+	s.False(s.msBuilder.IsStickyTaskListEnabled())
+	s.Equal(int32(0), executionInfo.StickyScheduleToStartTimeout)
+	s.Equal("", executionInfo.ClientLibraryVersion)
+
+	// clearing stickiness without invalidation keeps the count
+	s.msBuilder.ClearStickyness()
+	s.Equal(int64(1), executionInfo.StickyInvalidationCount)
+	s.Equal(int64(2), s.msBuilder.InvalidateStickyness())
+}
+
+func (s *mutableStateSuite) TestReencodeBufferedEvents() {
+	signal := &workflow.HistoryEvent{
+		EventId:   common.Int64Ptr(common.BufferedEventID),
+		EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionSignaled),
+		WorkflowExecutionSignaledEventAttributes: &workflow.WorkflowExecutionSignaledEventAttributes{
+			SignalName: common.StringPtr("some random signal"),
+		},
+	}
+	serializedEvents, err := persistence.NewJSONHistorySerializer().Serialize(persistence.NewHistoryEventBatch(
+		persistence.GetDefaultHistoryVersion(), []*workflow.HistoryEvent{signal}))
+	s.Nil(err)
+
+	s.msBuilder.executionInfo.DecisionScheduleID = 2
+	s.msBuilder.executionInfo.DecisionStartedID = 3
+	s.msBuilder.bufferedEvents = []*persistence.SerializedHistoryEventBatch{serializedEvents}
+	s.msBuilder.SetHistoryEncodingFn(func() common.EncodingType { return common.EncodingTypeThriftRW })
+	s.msBuilder.config.MutableStateSnapshotInterval = dynamicconfig.GetIntPropertyFn(2)
+
+	// the buffered events are only rewritten by snapshots
+	updates, err := s.msBuilder.CloseUpdateSession()
+	s.Nil(err)
+	s.False(updates.snapshot)
+	s.False(updates.clearBufferedEvents)
+	s.Nil(updates.newBufferedEvents)
+
+	updates, err = s.msBuilder.CloseUpdateSession()
+	s.Nil(err)
+	s.True(updates.snapshot)
+	s.True(updates.clearBufferedEvents)
+	s.NotNil(updates.newBufferedEvents)
+	s.Equal(common.EncodingTypeThriftRW, updates.newBufferedEvents.EncodingType)
+
+	history := s.msBuilder.GetBufferedHistory(updates.newBufferedEvents)
+	s.NotNil(history)
+	s.Equal(1, len(history.Events))
+	s.Equal(signal.WorkflowExecutionSignaledEventAttributes, history.Events[0].WorkflowExecutionSignaledEventAttributes)
+}
+
+func (s *mutableStateSuite) TestReplicationStateEquals() {
+	state := &persistence.ReplicationState{
+		CurrentVersion:   10,
+		StartVersion:     1,
+		LastWriteVersion: 10,
+		LastWriteEventID: 20,
+		LastReplicationInfo: map[string]*persistence.ReplicationInfo{
+			"standby": {Version: 5, LastEventID: 15},
+		},
+	}
+	s.True(replicationStateEquals(nil, nil))
+	s.False(replicationStateEquals(state, nil))
+
+	clone := cloneReplicationState(state)
+	s.True(replicationStateEquals(state, clone))
+
+	// the clone must not share the replication info with the original
+	state.LastReplicationInfo["standby"].LastEventID = 16
+	s.False(replicationStateEquals(state, clone))
+
+	clone = cloneReplicationState(state)
+	state.LastWriteEventID = 21
+	s.False(replicationStateEquals(state, clone))
+}
+
+func (s *mutableStateSuite) TestContinueAsNewTaskListOverride() {
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	addWorkflowExecutionStartedEvent(s.msBuilder, we, "wType", "testTaskList", []byte("input"), 100, 100, "identity")
+	s.msBuilder.GetExecutionInfo().ContinueAsNewTaskList = "newTaskList"
+
+	newExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	attributes := &workflow.ContinueAsNewWorkflowExecutionDecisionAttributes{
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(100),
+	}
+
+	newBuilder := newMutableStateBuilder(s.msBuilder.config, s.logger)
+	event := newBuilder.AddWorkflowExecutionStartedEventForContinueAsNew(validDomainID, nil, newExecution, s.msBuilder, attributes)
+	s.NotNil(event)
+	s.Equal("newTaskList", newBuilder.GetExecutionInfo().TaskList)
+	s.Equal("", newBuilder.GetExecutionInfo().ContinueAsNewTaskList)
+
+	// a task list named by the decision still wins over the override
+	attributes.TaskList = &workflow.TaskList{Name: common.StringPtr("decisionTaskList")}
+	newBuilder = newMutableStateBuilder(s.msBuilder.config, s.logger)
+	newBuilder.AddWorkflowExecutionStartedEventForContinueAsNew(validDomainID, nil, newExecution, s.msBuilder, attributes)
+	s.Equal("decisionTaskList", newBuilder.GetExecutionInfo().TaskList)
+}
+
+func (s *mutableStateSuite) TestContinueAsNewFirstRunID() {
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	addWorkflowExecutionStartedEvent(s.msBuilder, we, "wType", "testTaskList", []byte("input"), 100, 100, "identity")
+	s.Equal(validRunID, s.msBuilder.GetExecutionInfo().FirstRunID)
+
+	newExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	attributes := &workflow.ContinueAsNewWorkflowExecutionDecisionAttributes{
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(100),
+	}
+
+	newBuilder := newMutableStateBuilder(s.msBuilder.config, s.logger)
+	event := newBuilder.AddWorkflowExecutionStartedEventForContinueAsNew(validDomainID, nil, newExecution, s.msBuilder, attributes)
+	s.NotNil(event)
+	s.Equal(validRunID, event.WorkflowExecutionStartedEventAttributes.GetFirstExecutionRunId())
+	s.Equal(validRunID, newBuilder.GetExecutionInfo().FirstRunID)
+
+	// a run started before the first run ID was tracked is taken as the first of its chain
+	s.msBuilder.GetExecutionInfo().FirstRunID = ""
+	newBuilder = newMutableStateBuilder(s.msBuilder.config, s.logger)
+	newBuilder.AddWorkflowExecutionStartedEventForContinueAsNew(validDomainID, nil, newExecution, s.msBuilder, attributes)
+	s.Equal(validRunID, newBuilder.GetExecutionInfo().FirstRunID)
+}
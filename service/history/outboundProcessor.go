@@ -0,0 +1,340 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+)
+
+const (
+	outboundSinkTypeKafka   = "kafka"
+	outboundSinkTypeWebhook = "webhook"
+
+	outboundWebhookTimeout = 10 * time.Second
+
+	// outboundSinkKeyDLQ is the key of the sink, in the same format, a summary goes to when its sink keeps failing
+	outboundSinkKeyDLQ = "dlq"
+
+	outboundQueueSize                 = 1000
+	outboundPublishInitialInterval    = 500 * time.Millisecond
+	outboundPublishMaximumInterval    = 10 * time.Second
+	outboundPublishMaximumAttempts    = 5
+	outboundPublishBackoffCoefficient = 2
+	outboundProcessorShutdownTimeout  = 10 * time.Second
+)
+
+type (
+	// workflowCloseSummary is the compact description of a closed workflow published to the outbound sink of its domain
+	workflowCloseSummary struct {
+		DomainID      string    `json:"domainId"`
+		Domain        string    `json:"domain"`
+		WorkflowID    string    `json:"workflowId"`
+		RunID         string    `json:"runId"`
+		WorkflowType  string    `json:"workflowType"`
+		CloseStatus   string    `json:"closeStatus"`
+		StartTime     time.Time `json:"startTime"`
+		CloseTime     time.Time `json:"closeTime"`
+		HistoryLength int64     `json:"historyLength"`
+		// Memo carries the execution tags of the workflow
+		Memo map[string]string `json:"memo,omitempty"`
+	}
+
+	outboundPublisher interface {
+		publish(summary *workflowCloseSummary) error
+		close() error
+	}
+
+	// outboundProcessor publishes the summaries of closed workflows to the sinks configured per domain,
+	// it is shared by all shards of the host so the connections to the sinks are reused.  Summaries are queued
+	// and published in the background, so an unavailable sink never holds back the transfer queue.
+	outboundProcessor struct {
+		config        *Config
+		metricsClient metrics.Client
+		logger        bark.Logger
+		newPublisher  func(sink map[string]interface{}) (outboundPublisher, error)
+		retryPolicy   backoff.RetryPolicy
+
+		status     int32
+		queue      chan *outboundMessage
+		shutdownCh chan struct{}
+		shutdownWG sync.WaitGroup
+
+		sync.Mutex
+		publishers map[string]outboundPublisher
+	}
+
+	outboundMessage struct {
+		sink    map[string]interface{}
+		summary *workflowCloseSummary
+	}
+
+	kafkaOutboundPublisher struct {
+		topic    string
+		producer sarama.SyncProducer
+	}
+
+	webhookOutboundPublisher struct {
+		url    string
+		client *http.Client
+	}
+)
+
+func newOutboundProcessor(config *Config, metricsClient metrics.Client, logger bark.Logger) *outboundProcessor {
+	retryPolicy := backoff.NewExponentialRetryPolicy(outboundPublishInitialInterval)
+	retryPolicy.SetBackoffCoefficient(outboundPublishBackoffCoefficient)
+	retryPolicy.SetMaximumInterval(outboundPublishMaximumInterval)
+	retryPolicy.SetMaximumAttempts(outboundPublishMaximumAttempts)
+
+	return &outboundProcessor{
+		config:        config,
+		metricsClient: metricsClient,
+		logger:        logger,
+		newPublisher:  newOutboundPublisher,
+		retryPolicy:   retryPolicy,
+		status:        common.DaemonStatusInitialized,
+		queue:         make(chan *outboundMessage, outboundQueueSize),
+		shutdownCh:    make(chan struct{}),
+		publishers:    make(map[string]outboundPublisher),
+	}
+}
+
+// Start starts publishing the queued summaries
+func (p *outboundProcessor) Start() {
+	if !atomic.CompareAndSwapInt32(&p.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+	p.shutdownWG.Add(1)
+	go p.processorPump()
+}
+
+// publishCloseEvent queues the summary for the sink of the domain, if any.  It never fails the close execution
+// task: a summary which does not fit in the queue goes to the dead letter sink right away.
+func (p *outboundProcessor) publishCloseEvent(domain string, summary *workflowCloseSummary) {
+	sink := p.config.CloseEventSink(domain)
+	if len(sink) == 0 {
+		return
+	}
+
+	msg := &outboundMessage{sink: sink, summary: summary}
+	select {
+	case p.queue <- msg:
+	default:
+		p.logger.WithField(logging.TagDomainID, summary.DomainID).Warn("Close event queue is full.")
+		p.publishToDLQ(msg)
+	}
+}
+
+func (p *outboundProcessor) processorPump() {
+	defer p.shutdownWG.Done()
+
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case msg := <-p.queue:
+			p.processMessage(msg)
+		}
+	}
+}
+
+// processMessage publishes the summary with a bounded number of retries, and hands it to the dead letter sink of
+// the domain once they are exhausted.  A misconfigured sink is not retried as retrying cannot fix it.
+func (p *outboundProcessor) processMessage(msg *outboundMessage) {
+	publisher, err := p.getPublisher(msg.sink)
+	if err != nil {
+		p.metricsClient.IncCounter(metrics.TransferActiveTaskCloseExecutionScope, metrics.CloseEventPublishFailedCounter)
+		p.logger.WithFields(bark.Fields{
+			logging.TagDomainID: msg.summary.DomainID,
+			logging.TagErr:      err,
+		}).Warn("Invalid close event sink of domain.")
+		p.publishToDLQ(msg)
+		return
+	}
+
+	retrier := backoff.NewRetrier(p.retryPolicy, backoff.SystemClock)
+	for {
+		err = publisher.publish(msg.summary)
+		if err == nil {
+			p.metricsClient.IncCounter(metrics.TransferActiveTaskCloseExecutionScope, metrics.CloseEventPublishedCounter)
+			return
+		}
+		p.metricsClient.IncCounter(metrics.TransferActiveTaskCloseExecutionScope, metrics.CloseEventPublishFailedCounter)
+
+		// the retrier returns a negative backoff once the attempts of the policy are exhausted
+		next := retrier.NextBackOff()
+		if next < 0 {
+			break
+		}
+		select {
+		case <-p.shutdownCh:
+			return
+		case <-time.After(next):
+		}
+	}
+
+	p.logger.WithFields(bark.Fields{
+		logging.TagDomainID:            msg.summary.DomainID,
+		logging.TagWorkflowExecutionID: msg.summary.WorkflowID,
+		logging.TagWorkflowRunID:       msg.summary.RunID,
+		logging.TagErr:                 err,
+	}).Warn("Failed to publish close event, moving it to the dead letter sink.")
+	p.publishToDLQ(msg)
+}
+
+// publishToDLQ publishes the summary once to the dead letter sink of the domain, a summary without one is dropped
+func (p *outboundProcessor) publishToDLQ(msg *outboundMessage) {
+	dlqSink, _ := msg.sink[outboundSinkKeyDLQ].(map[string]interface{})
+	if len(dlqSink) != 0 {
+		publisher, err := p.getPublisher(dlqSink)
+		if err == nil {
+			err = publisher.publish(msg.summary)
+		}
+		if err == nil {
+			p.metricsClient.IncCounter(metrics.TransferActiveTaskCloseExecutionScope, metrics.CloseEventDLQCounter)
+			return
+		}
+		p.logger.WithFields(bark.Fields{
+			logging.TagDomainID: msg.summary.DomainID,
+			logging.TagErr:      err,
+		}).Warn("Failed to publish close event to the dead letter sink.")
+	}
+
+	p.metricsClient.IncCounter(metrics.TransferActiveTaskCloseExecutionScope, metrics.CloseEventDroppedCounter)
+	p.logger.WithFields(bark.Fields{
+		logging.TagDomainID:            msg.summary.DomainID,
+		logging.TagWorkflowExecutionID: msg.summary.WorkflowID,
+		logging.TagWorkflowRunID:       msg.summary.RunID,
+	}).Error("Dropped close event.")
+}
+
+func (p *outboundProcessor) getPublisher(sink map[string]interface{}) (outboundPublisher, error) {
+	key := fmt.Sprintf("%v|%v|%v|%v", sink["type"], sink["brokers"], sink["topic"], sink["url"])
+
+	p.Lock()
+	defer p.Unlock()
+	if publisher, ok := p.publishers[key]; ok {
+		return publisher, nil
+	}
+	publisher, err := p.newPublisher(sink)
+	if err != nil {
+		return nil, err
+	}
+	p.publishers[key] = publisher
+	return publisher, nil
+}
+
+// Stop stops publishing and closes the connections to the sinks, summaries still queued are dropped
+func (p *outboundProcessor) Stop() {
+	if atomic.CompareAndSwapInt32(&p.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		close(p.shutdownCh)
+		if success := common.AwaitWaitGroup(&p.shutdownWG, outboundProcessorShutdownTimeout); !success {
+			p.logger.Warn("Close event processor timed out on shutdown.")
+		}
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	for key, publisher := range p.publishers {
+		if err := publisher.close(); err != nil {
+			p.logger.WithField(logging.TagErr, err).Warn("Failed to close close event sink.")
+		}
+		delete(p.publishers, key)
+	}
+}
+
+func newOutboundPublisher(sink map[string]interface{}) (outboundPublisher, error) {
+	getString := func(key string) string {
+		value, _ := sink[key].(string)
+		return value
+	}
+
+	switch sinkType := getString("type"); sinkType {
+	case outboundSinkTypeKafka:
+		brokers, topic := getString("brokers"), getString("topic")
+		if brokers == "" || topic == "" {
+			return nil, fmt.Errorf("brokers and topic are required by the %v sink", sinkType)
+		}
+		producer, err := sarama.NewSyncProducer(strings.Split(brokers, ","), nil)
+		if err != nil {
+			return nil, err
+		}
+		return &kafkaOutboundPublisher{topic: topic, producer: producer}, nil
+	case outboundSinkTypeWebhook:
+		url := getString("url")
+		if url == "" {
+			return nil, fmt.Errorf("url is required by the %v sink", sinkType)
+		}
+		return &webhookOutboundPublisher{url: url, client: &http.Client{Timeout: outboundWebhookTimeout}}, nil
+	default:
+		return nil, fmt.Errorf("unknown sink type: %v", sinkType)
+	}
+}
+
+func (p *kafkaOutboundPublisher) publish(summary *workflowCloseSummary) error {
+	payload, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: p.topic,
+		Key:   sarama.StringEncoder(summary.WorkflowID),
+		Value: sarama.ByteEncoder(payload),
+	})
+	return err
+}
+
+func (p *kafkaOutboundPublisher) close() error {
+	return p.producer.Close()
+}
+
+func (p *webhookOutboundPublisher) publish(summary *workflowCloseSummary) error {
+	payload, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Post(p.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook %v responded with status %v", p.url, resp.Status)
+	}
+	return nil
+}
+
+func (p *webhookOutboundPublisher) close() error {
+	return nil
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	outboundProcessorSuite struct {
+		suite.Suite
+		sinks        map[string]map[string]interface{}
+		scope        tally.TestScope
+		processor    *outboundProcessor
+		publisher    *fakeOutboundPublisher
+		dlqPublisher *fakeOutboundPublisher
+		created      int
+	}
+
+	fakeOutboundPublisher struct {
+		sync.Mutex
+		summaries   []*workflowCloseSummary
+		err         error
+		attempts    int
+		closed      bool
+		publishedCh chan *workflowCloseSummary
+	}
+)
+
+func TestOutboundProcessorSuite(t *testing.T) {
+	s := new(outboundProcessorSuite)
+	suite.Run(t, s)
+}
+
+func (p *fakeOutboundPublisher) publish(summary *workflowCloseSummary) error {
+	p.Lock()
+	defer p.Unlock()
+	p.attempts++
+	if p.err != nil {
+		return p.err
+	}
+	p.summaries = append(p.summaries, summary)
+	if p.publishedCh != nil {
+		p.publishedCh <- summary
+	}
+	return nil
+}
+
+func (p *fakeOutboundPublisher) close() error {
+	p.closed = true
+	return nil
+}
+
+func (s *outboundProcessorSuite) SetupTest() {
+	s.sinks = make(map[string]map[string]interface{})
+	s.scope = tally.NewTestScope("test", nil)
+	s.publisher = &fakeOutboundPublisher{}
+	s.dlqPublisher = &fakeOutboundPublisher{publishedCh: make(chan *workflowCloseSummary, 10)}
+	s.created = 0
+
+	config := NewConfig(dynamicconfig.NewNopCollection(), 1)
+	config.CloseEventSink = func(domain string) map[string]interface{} {
+		return s.sinks[domain]
+	}
+	s.processor = newOutboundProcessor(config, metrics.NewClient(s.scope, metrics.History), bark.NewNopLogger())
+	s.processor.newPublisher = func(sink map[string]interface{}) (outboundPublisher, error) {
+		if sink["type"] == "invalid" {
+			return nil, errors.New("invalid sink")
+		}
+		s.created++
+		if sink["url"] == "http://dlq" {
+			return s.dlqPublisher, nil
+		}
+		return s.publisher, nil
+	}
+	retryPolicy := backoff.NewExponentialRetryPolicy(time.Millisecond)
+	// the initial attempt and two retries
+	retryPolicy.SetMaximumAttempts(2)
+	s.processor.retryPolicy = retryPolicy
+}
+
+func (s *outboundProcessorSuite) TearDownTest() {
+	s.processor.Stop()
+}
+
+func (s *outboundProcessorSuite) counter(name string) int64 {
+	for _, counter := range s.scope.Snapshot().Counters() {
+		if counter.Name() == "test."+name {
+			return counter.Value()
+		}
+	}
+	return 0
+}
+
+func (s *outboundProcessorSuite) TestNoSink() {
+	s.processor.publishCloseEvent("domain", &workflowCloseSummary{WorkflowID: "wid"})
+	s.Empty(s.processor.queue)
+	s.Equal(0, s.created)
+}
+
+func (s *outboundProcessorSuite) TestPublishReusesPublisher() {
+	s.sinks["domain"] = map[string]interface{}{"type": "webhook", "url": "http://localhost"}
+
+	s.processor.publishCloseEvent("domain", &workflowCloseSummary{WorkflowID: "wid1"})
+	s.processor.publishCloseEvent("domain", &workflowCloseSummary{WorkflowID: "wid2"})
+	s.Len(s.processor.queue, 2)
+	s.processor.processMessage(<-s.processor.queue)
+	s.processor.processMessage(<-s.processor.queue)
+	s.Equal(1, s.created)
+	s.Len(s.publisher.summaries, 2)
+	s.Equal(int64(2), s.counter("close-event-published"))
+
+	s.processor.Stop()
+	s.True(s.publisher.closed)
+}
+
+func (s *outboundProcessorSuite) TestFailingSinkMovesToDLQ() {
+	s.sinks["domain"] = map[string]interface{}{
+		"type": "webhook",
+		"url":  "http://localhost",
+		"dlq":  map[string]interface{}{"type": "webhook", "url": "http://dlq"},
+	}
+	s.publisher.err = errors.New("unavailable")
+	s.processor.Start()
+
+	// queuing never fails or blocks the close execution task
+	s.processor.publishCloseEvent("domain", &workflowCloseSummary{WorkflowID: "wid"})
+	select {
+	case summary := <-s.dlqPublisher.publishedCh:
+		s.Equal("wid", summary.WorkflowID)
+	case <-time.After(10 * time.Second):
+		s.Fail("close event was not moved to the dead letter sink")
+	}
+	s.Equal(3, s.publisher.attempts)
+	s.Equal(int64(3), s.counter("close-event-publish-failed"))
+	s.Equal(int64(1), s.counter("close-event-dlq"))
+}
+
+func (s *outboundProcessorSuite) TestFailingSinkWithoutDLQIsDropped() {
+	s.sinks["domain"] = map[string]interface{}{"type": "webhook", "url": "http://localhost"}
+	s.publisher.err = errors.New("unavailable")
+
+	s.processor.publishCloseEvent("domain", &workflowCloseSummary{WorkflowID: "wid"})
+	s.processor.processMessage(<-s.processor.queue)
+	s.Equal(3, s.publisher.attempts)
+	s.Equal(int64(1), s.counter("close-event-dropped"))
+}
+
+func (s *outboundProcessorSuite) TestFullQueueMovesToDLQ() {
+	s.sinks["domain"] = map[string]interface{}{
+		"type": "webhook",
+		"url":  "http://localhost",
+		"dlq":  map[string]interface{}{"type": "webhook", "url": "http://dlq"},
+	}
+	for i := 0; i < outboundQueueSize; i++ {
+		s.processor.publishCloseEvent("domain", &workflowCloseSummary{WorkflowID: "wid"})
+	}
+	s.processor.publishCloseEvent("domain", &workflowCloseSummary{WorkflowID: "overflow"})
+	s.Len(s.processor.queue, outboundQueueSize)
+	s.Equal("overflow", (<-s.dlqPublisher.publishedCh).WorkflowID)
+}
+
+func (s *outboundProcessorSuite) TestInvalidSinkIsSkipped() {
+	s.sinks["domain"] = map[string]interface{}{"type": "invalid"}
+
+	s.processor.publishCloseEvent("domain", &workflowCloseSummary{WorkflowID: "wid"})
+	s.processor.processMessage(<-s.processor.queue)
+	s.Equal(int64(1), s.counter("close-event-publish-failed"))
+	s.Equal(int64(1), s.counter("close-event-dropped"))
+}
+
+func (s *outboundProcessorSuite) TestNewOutboundPublisherValidation() {
+	_, err := newOutboundPublisher(map[string]interface{}{"type": "kafka", "topic": "t"})
+	s.Error(err)
+	_, err = newOutboundPublisher(map[string]interface{}{"type": "webhook"})
+	s.Error(err)
+	_, err = newOutboundPublisher(map[string]interface{}{"type": "s3"})
+	s.Error(err)
+}
+
+func (s *outboundProcessorSuite) TestWebhookPublisher() {
+	var received workflowCloseSummary
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.NoError(json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	publisher, err := newOutboundPublisher(map[string]interface{}{"type": "webhook", "url": server.URL})
+	s.NoError(err)
+	summary := &workflowCloseSummary{
+		WorkflowID:  "wid",
+		RunID:       "rid",
+		CloseStatus: "COMPLETED",
+		Memo:        map[string]string{"team": "payments"},
+	}
+	s.NoError(publisher.publish(summary))
+	s.Equal("wid", received.WorkflowID)
+	s.Equal("COMPLETED", received.CloseStatus)
+	s.Equal("payments", received.Memo["team"])
+
+	status = http.StatusInternalServerError
+	s.Error(publisher.publish(summary))
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/metrics"
+)
+
+type (
+	// persistenceLoad accumulates the latency and the failures of the persistence calls of a shard, as measured by
+	// the persistence metrics client of its execution manager
+	persistenceLoad struct {
+		calls    int64
+		failures int64
+		latency  int64
+	}
+
+	// persistenceLoadSnapshot is the load accumulated by a persistenceLoad up to some point in time
+	persistenceLoadSnapshot struct {
+		calls    int64
+		failures int64
+		latency  time.Duration
+	}
+
+	// persistenceLoadMetricsClient is a metrics client which also records the persistence latency and failures it is
+	// given into a persistenceLoad
+	persistenceLoadMetricsClient struct {
+		metrics.Client
+		load *persistenceLoad
+	}
+
+	// persistenceLoadStopwatchRecorder records the latency of a persistence call once its stopwatch is stopped
+	persistenceLoadStopwatchRecorder struct {
+		stopwatch tally.Stopwatch
+		load      *persistenceLoad
+	}
+
+	// shardPersistenceLoadReporter is implemented by shard contexts which are able to report the load of their
+	// persistence calls
+	shardPersistenceLoadReporter interface {
+		getPersistenceLoad() *persistenceLoad
+	}
+)
+
+var _ metrics.Client = (*persistenceLoadMetricsClient)(nil)
+
+// getShardPersistenceLoad returns the persistence load of the shard, shards which cannot report it get a load which
+// never records anything
+func getShardPersistenceLoad(shard ShardContext) *persistenceLoad {
+	if reporter, ok := shard.(shardPersistenceLoadReporter); ok {
+		if load := reporter.getPersistenceLoad(); load != nil {
+			return load
+		}
+	}
+	return &persistenceLoad{}
+}
+
+func (l *persistenceLoad) recordLatency(latency time.Duration) {
+	atomic.AddInt64(&l.calls, 1)
+	atomic.AddInt64(&l.latency, int64(latency))
+}
+
+func (l *persistenceLoad) recordFailure() {
+	atomic.AddInt64(&l.failures, 1)
+}
+
+func (l *persistenceLoad) snapshot() persistenceLoadSnapshot {
+	return persistenceLoadSnapshot{
+		calls:    atomic.LoadInt64(&l.calls),
+		failures: atomic.LoadInt64(&l.failures),
+		latency:  time.Duration(atomic.LoadInt64(&l.latency)),
+	}
+}
+
+// sub returns the load accumulated between the previous snapshot and this one
+func (s persistenceLoadSnapshot) sub(previous persistenceLoadSnapshot) persistenceLoadSnapshot {
+	return persistenceLoadSnapshot{
+		calls:    s.calls - previous.calls,
+		failures: s.failures - previous.failures,
+		latency:  s.latency - previous.latency,
+	}
+}
+
+func newPersistenceLoadMetricsClient(metricsClient metrics.Client, load *persistenceLoad) metrics.Client {
+	return &persistenceLoadMetricsClient{Client: metricsClient, load: load}
+}
+
+func (c *persistenceLoadMetricsClient) IncCounter(scope int, counter int) {
+	c.Client.IncCounter(scope, counter)
+	if counter == metrics.PersistenceFailures {
+		c.load.recordFailure()
+	}
+}
+
+func (c *persistenceLoadMetricsClient) StartTimer(scope int, timer int) tally.Stopwatch {
+	stopwatch := c.Client.StartTimer(scope, timer)
+	if timer != metrics.PersistenceLatency {
+		return stopwatch
+	}
+	return tally.NewStopwatch(time.Now(), &persistenceLoadStopwatchRecorder{stopwatch: stopwatch, load: c.load})
+}
+
+func (c *persistenceLoadMetricsClient) RecordTimer(scope int, timer int, d time.Duration) {
+	c.Client.RecordTimer(scope, timer, d)
+	if timer == metrics.PersistenceLatency {
+		c.load.recordLatency(d)
+	}
+}
+
+func (c *persistenceLoadMetricsClient) Tagged(tags map[string]string) metrics.Client {
+	return &persistenceLoadMetricsClient{Client: c.Client.Tagged(tags), load: c.load}
+}
+
+func (r *persistenceLoadStopwatchRecorder) RecordStopwatch(stopwatchStart time.Time) {
+	r.load.recordLatency(time.Since(stopwatchStart))
+	r.stopwatch.Stop()
+}
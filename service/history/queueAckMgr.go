@@ -21,8 +21,10 @@
 package history
 
 import (
+	"fmt"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/uber-common/bark"
 
@@ -39,6 +41,7 @@ type (
 	// for the shard when all preceding tasks are acknowledged.
 	queueAckMgrImpl struct {
 		isFailover    bool
+		queueName     string
 		shard         ShardContext
 		options       *QueueProcessorOptions
 		processor     processor
@@ -48,41 +51,47 @@ type (
 
 		sync.RWMutex
 		outstandingTasks map[int64]bool
-		readLevel        int64
-		ackLevel         int64
-		isReadFinished   bool
+		// outstanding task -> time since which the task is due, used to report the backlog age
+		outstandingTaskTimes map[int64]time.Time
+		readLevel            int64
+		ackLevel             int64
+		isReadFinished       bool
 	}
 )
 
-func newQueueAckMgr(shard ShardContext, options *QueueProcessorOptions, processor processor, ackLevel int64, logger bark.Logger) *queueAckMgrImpl {
+func newQueueAckMgr(shard ShardContext, options *QueueProcessorOptions, processor processor, ackLevel int64,
+	queueName string, logger bark.Logger) *queueAckMgrImpl {
 
 	return &queueAckMgrImpl{
-		isFailover:       false,
-		shard:            shard,
-		options:          options,
-		processor:        processor,
-		outstandingTasks: make(map[int64]bool),
-		readLevel:        ackLevel,
-		ackLevel:         ackLevel,
-		logger:           logger,
-		metricsClient:    shard.GetMetricsClient(),
-		finishedChan:     nil,
+		isFailover:           false,
+		queueName:            queueName,
+		shard:                shard,
+		options:              options,
+		processor:            processor,
+		outstandingTasks:     make(map[int64]bool),
+		outstandingTaskTimes: make(map[int64]time.Time),
+		readLevel:            ackLevel,
+		ackLevel:             ackLevel,
+		logger:               logger,
+		metricsClient:        shard.GetMetricsClient(),
+		finishedChan:         nil,
 	}
 }
 
 func newQueueFailoverAckMgr(shard ShardContext, options *QueueProcessorOptions, processor processor, ackLevel int64, logger bark.Logger) *queueAckMgrImpl {
 
 	return &queueAckMgrImpl{
-		isFailover:       true,
-		shard:            shard,
-		options:          options,
-		processor:        processor,
-		outstandingTasks: make(map[int64]bool),
-		readLevel:        ackLevel,
-		ackLevel:         ackLevel,
-		logger:           logger,
-		metricsClient:    shard.GetMetricsClient(),
-		finishedChan:     make(chan struct{}, 1),
+		isFailover:           true,
+		shard:                shard,
+		options:              options,
+		processor:            processor,
+		outstandingTasks:     make(map[int64]bool),
+		outstandingTaskTimes: make(map[int64]time.Time),
+		readLevel:            ackLevel,
+		ackLevel:             ackLevel,
+		logger:               logger,
+		metricsClient:        shard.GetMetricsClient(),
+		finishedChan:         make(chan struct{}, 1),
 	}
 }
 
@@ -110,6 +119,7 @@ func (a *queueAckMgrImpl) readQueueTasks() ([]queueTaskInfo, bool, error) {
 		a.isReadFinished = true
 	}
 
+	loadTime := time.Now()
 TaskFilterLoop:
 	for _, task := range tasks {
 		_, isLoaded := a.outstandingTasks[task.GetTaskID()]
@@ -126,6 +136,12 @@ TaskFilterLoop:
 		a.logger.Debugf("Moving read level: %v", task.GetTaskID())
 		a.readLevel = task.GetTaskID()
 		a.outstandingTasks[task.GetTaskID()] = false
+		// replication tasks do not carry a visibility timestamp, the time they are loaded is used instead
+		taskTime := task.GetVisibilityTimestamp()
+		if taskTime.IsZero() {
+			taskTime = loadTime
+		}
+		a.outstandingTaskTimes[task.GetTaskID()] = taskTime
 	}
 
 	return tasks, morePage, nil
@@ -166,6 +182,7 @@ func (a *queueAckMgrImpl) updateQueueAckLevel() {
 
 	a.Lock()
 	ackLevel := a.ackLevel
+	previousAckLevel := ackLevel
 
 	a.logger.Debugf("Moving timer ack level from %v, with %v.", ackLevel, a.outstandingTasks)
 
@@ -183,12 +200,14 @@ MoveAckLevelLoop:
 		if acked {
 			ackLevel = current
 			delete(a.outstandingTasks, current)
+			delete(a.outstandingTaskTimes, current)
 			a.logger.Debugf("Moving timer ack level to %v.", ackLevel)
 		} else {
 			break MoveAckLevelLoop
 		}
 	}
 	a.ackLevel = ackLevel
+	backlogAge := a.getBacklogAgeLocked(taskIDs)
 
 	if a.isFailover && a.isReadFinished && len(a.outstandingTasks) == 0 {
 		a.Unlock()
@@ -201,8 +220,29 @@ MoveAckLevelLoop:
 	}
 
 	a.Unlock()
+	if a.queueName != "" {
+		a.shard.UpdateQueueBacklogAge(a.options.MetricScope, a.queueName, backlogAge)
+	}
+	if ackLevel != previousAckLevel {
+		a.shard.RecordOperation(shardOperationAckLevelUpdated,
+			fmt.Sprintf("queue: %v, ack level: %v -> %v", a.queueName, previousAckLevel, ackLevel))
+	}
 	if err := a.processor.updateAckLevel(ackLevel); err != nil {
 		a.metricsClient.IncCounter(a.options.MetricScope, metrics.AckLevelUpdateFailedCounter)
 		logging.LogOperationFailedEvent(a.logger, "Error updating ack level for shard", err)
 	}
 }
+
+// getBacklogAgeLocked returns how long the oldest outstanding task has been waiting, given the sorted task IDs which
+// were outstanding before the ack level moved
+func (a *queueAckMgrImpl) getBacklogAgeLocked(taskIDs []int64) time.Duration {
+	for _, taskID := range taskIDs {
+		if taskTime, ok := a.outstandingTaskTimes[taskID]; ok {
+			if age := time.Since(taskTime); age > 0 {
+				return age
+			}
+			return 0
+		}
+	}
+	return 0
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
 	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/messaging"
@@ -142,7 +143,7 @@ func (s *queueAckMgrSuite) SetupTest() {
 
 	s.queueAckMgr = newQueueAckMgr(s.mockShard, &QueueProcessorOptions{
 		MetricScope: metrics.ReplicatorQueueProcessorScope,
-	}, s.mockProcessor, 0, s.logger)
+	}, s.mockProcessor, 0, common.ReplicatorQueueBacklogName, s.logger)
 }
 
 func (s *queueAckMgrSuite) TearDownTest() {
@@ -297,6 +298,58 @@ func (s *queueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	s.Equal(taskID3, s.queueAckMgr.getQueueAckLevel())
 }
 
+func (s *queueAckMgrSuite) TestUpdateAckLevelReportsBacklogAge() {
+	readLevel := s.queueAckMgr.readLevel
+
+	moreInput := true
+	taskID1 := int64(59)
+	taskID2 := int64(60)
+	tasksInput := []queueTaskInfo{
+		&persistence.TransferTaskInfo{
+			DomainID:            "some random domain ID",
+			WorkflowID:          "some random workflow ID",
+			RunID:               uuid.New(),
+			TaskID:              taskID1,
+			TaskList:            "some random tasklist",
+			TaskType:            1,
+			ScheduleID:          28,
+			VisibilityTimestamp: time.Now().Add(-time.Hour),
+		},
+		&persistence.TransferTaskInfo{
+			DomainID:            "some random domain ID",
+			WorkflowID:          "some random workflow ID",
+			RunID:               uuid.New(),
+			TaskID:              taskID2,
+			TaskList:            "some random tasklist",
+			TaskType:            1,
+			ScheduleID:          28,
+			VisibilityTimestamp: time.Now().Add(-time.Minute),
+		},
+	}
+
+	s.mockProcessor.On("readTasks", readLevel).Return(tasksInput, moreInput, nil).Once()
+	_, _, err := s.queueAckMgr.readQueueTasks()
+	s.Nil(err)
+
+	s.mockProcessor.On("updateAckLevel", readLevel).Return(nil).Once()
+	s.queueAckMgr.updateQueueAckLevel()
+	age := s.mockShard.GetQueueBacklogAges()[common.ReplicatorQueueBacklogName]
+	s.True(age >= time.Hour && age < time.Hour+time.Minute)
+
+	s.mockProcessor.On("updateAckLevel", taskID1).Return(nil).Once()
+	s.mockProcessor.On("completeTask", taskID1).Return(nil).Once()
+	s.queueAckMgr.completeQueueTask(taskID1)
+	s.queueAckMgr.updateQueueAckLevel()
+	age = s.mockShard.GetQueueBacklogAges()[common.ReplicatorQueueBacklogName]
+	s.True(age >= time.Minute && age < 2*time.Minute)
+
+	s.mockProcessor.On("updateAckLevel", taskID2).Return(nil).Once()
+	s.mockProcessor.On("completeTask", taskID2).Return(nil).Once()
+	s.queueAckMgr.completeQueueTask(taskID2)
+	s.queueAckMgr.updateQueueAckLevel()
+	s.Equal(time.Duration(0), s.mockShard.GetQueueBacklogAges()[common.ReplicatorQueueBacklogName])
+}
+
 // Tests for failover ack manager
 func (s *queueFailoverAckMgrSuite) SetupSuite() {
 	if testing.Verbose() {
@@ -59,12 +59,14 @@ type (
 		processor     processor
 		logger        bark.Logger
 		metricsClient metrics.Client
-		rateLimiter   common.TokenBucket // Read rate limiter
+		tuner         *queueProcessorTuner // Read rate and worker concurrency limiter
 		ackMgr        queueAckMgr
 		retryPolicy   backoff.RetryPolicy
 
-		// worker coroutines notification
-		workerNotificationChans []chan struct{}
+		// standby tasks waiting for the mutable state to be replicated
+		redeliveryQueue *taskRedeliveryQueue
+		// tasks of a paused task type, checked again every paused task check interval
+		pausedQueue *taskRedeliveryQueue
 
 		lastPollTime time.Time
 
@@ -80,26 +82,32 @@ var (
 )
 
 func newQueueProcessorBase(clusterName string, shard ShardContext, options *QueueProcessorOptions, processor processor, queueAckMgr queueAckMgr, logger bark.Logger) *queueProcessorBase {
-	workerNotificationChans := []chan struct{}{}
-	for index := 0; index < options.WorkerCount(); index++ {
-		workerNotificationChans = append(workerNotificationChans, make(chan struct{}, 1))
-	}
-
+	config := shard.GetConfig()
 	p := &queueProcessorBase{
-		clusterName:             clusterName,
-		shard:                   shard,
-		options:                 options,
-		processor:               processor,
-		rateLimiter:             common.NewTokenBucket(options.MaxPollRPS(), common.NewRealTimeSource()),
-		workerNotificationChans: workerNotificationChans,
-		status:                  common.DaemonStatusInitialized,
-		notifyCh:                make(chan struct{}, 1),
-		shutdownCh:              make(chan struct{}),
-		metricsClient:           shard.GetMetricsClient(),
-		logger:                  logger,
-		ackMgr:                  queueAckMgr,
-		retryPolicy:             common.CreatePersistanceRetryPolicy(),
-		lastPollTime:            time.Time{},
+		clusterName:   clusterName,
+		shard:         shard,
+		options:       options,
+		processor:     processor,
+		status:        common.DaemonStatusInitialized,
+		notifyCh:      make(chan struct{}, 1),
+		shutdownCh:    make(chan struct{}),
+		metricsClient: shard.GetMetricsClient(),
+		logger:        logger,
+		ackMgr:        queueAckMgr,
+		retryPolicy:   common.CreatePersistanceRetryPolicy(),
+		tuner: newQueueProcessorTuner(config, options.MaxPollRPS, options.WorkerCount, getShardPersistenceLoad(shard),
+			shard.GetMetricsClient(), options.MetricScope),
+		redeliveryQueue: newTaskRedeliveryQueue(
+			config.StandbyTaskRedeliveryInterval(),
+			config.StandbyTaskRedeliveryMaxInterval(),
+			common.NewRealTimeSource(),
+		),
+		pausedQueue: newTaskRedeliveryQueue(
+			config.PausedTaskCheckInterval(),
+			config.PausedTaskCheckInterval(),
+			common.NewRealTimeSource(),
+		),
+		lastPollTime: time.Time{},
 	}
 
 	return p
@@ -148,10 +156,9 @@ func (p *queueProcessorBase) processorPump() {
 	tasksCh := make(chan queueTaskInfo, p.options.BatchSize())
 
 	var workerWG sync.WaitGroup
-	for i := 0; i < p.options.WorkerCount(); i++ {
+	for i := 0; i < p.tuner.numOfWorkers(); i++ {
 		workerWG.Add(1)
-		notificationChan := p.workerNotificationChans[i]
-		go p.taskWorker(tasksCh, notificationChan, &workerWG)
+		go p.taskWorker(tasksCh, &workerWG)
 	}
 
 	jitter := backoff.NewJitter()
@@ -161,6 +168,15 @@ func (p *queueProcessorBase) processorPump() {
 	updateAckTicker := time.NewTicker(p.options.UpdateAckInterval())
 	defer updateAckTicker.Stop()
 
+	redeliveryTicker := time.NewTicker(p.shard.GetConfig().StandbyTaskRedeliveryInterval())
+	defer redeliveryTicker.Stop()
+
+	pausedTaskTicker := time.NewTicker(p.shard.GetConfig().PausedTaskCheckInterval())
+	defer pausedTaskTicker.Stop()
+
+	tuningTicker := time.NewTicker(p.shard.GetConfig().QueueProcessorAutoTuningInterval())
+	defer tuningTicker.Stop()
+
 processorPumpLoop:
 	for {
 		select {
@@ -178,10 +194,21 @@ processorPumpLoop:
 			}
 		case <-updateAckTicker.C:
 			p.ackMgr.updateQueueAckLevel()
+		case <-redeliveryTicker.C:
+			for _, task := range p.redeliveryQueue.getDueTasks() {
+				tasksCh <- task
+			}
+		case <-pausedTaskTicker.C:
+			for _, task := range p.pausedQueue.getDueTasks() {
+				tasksCh <- task
+			}
+		case <-tuningTicker.C:
+			p.tuner.adjust()
 		}
 	}
 
 	p.logger.Info("Queue processor pump shutting down.")
+	p.tuner.stop()
 	// This is the only pump which writes to tasksCh, so it is safe to close channel here
 	close(tasksCh)
 	if success := common.AwaitWaitGroup(&workerWG, 10*time.Second); !success {
@@ -192,7 +219,7 @@ processorPumpLoop:
 
 func (p *queueProcessorBase) processBatch(tasksCh chan<- queueTaskInfo) {
 
-	if !p.rateLimiter.Consume(1, p.options.MaxPollInterval()) {
+	if !p.tuner.consumePoll(p.options.MaxPollInterval()) {
 		p.notifyNewTask() // re-enqueue the event
 		return
 	}
@@ -223,41 +250,52 @@ func (p *queueProcessorBase) processBatch(tasksCh chan<- queueTaskInfo) {
 	return
 }
 
-func (p *queueProcessorBase) taskWorker(tasksCh <-chan queueTaskInfo, notificationChan <-chan struct{}, workerWG *sync.WaitGroup) {
+func (p *queueProcessorBase) taskWorker(tasksCh <-chan queueTaskInfo, workerWG *sync.WaitGroup) {
 	defer workerWG.Done()
 
 	for {
+		// the worker only takes a task once the tuner lets it process one
+		if !p.tuner.acquireWorker() {
+			return
+		}
 		select {
 		case <-p.shutdownCh:
+			p.tuner.releaseWorker()
 			return
 		case task, ok := <-tasksCh:
 			if !ok {
+				p.tuner.releaseWorker()
 				return
 			}
-			p.processWithRetry(notificationChan, task)
+			p.processWithRetry(task)
+			p.tuner.releaseWorker()
 		}
 	}
 }
 
 func (p *queueProcessorBase) retryTasks() {
-	for _, workerNotificationChan := range p.workerNotificationChans {
-		select {
-		case workerNotificationChan <- struct{}{}:
-		default:
-		}
-	}
+	p.redeliveryQueue.redeliverAll()
 }
 
-func (p *queueProcessorBase) processWithRetry(notificationChan <-chan struct{}, task queueTaskInfo) {
+func (p *queueProcessorBase) processWithRetry(task queueTaskInfo) {
 
 	var logger bark.Logger
 	var err error
 	startTime := time.Now()
 
+	deferred := false
+	defer func() {
+		if !deferred {
+			p.redeliveryQueue.remove(task.GetTaskID())
+			p.pausedQueue.remove(task.GetTaskID())
+		}
+	}()
+
 	retryCount := 0
 	op := func() error {
-		err = p.processor.process(task)
-		if err != nil && err != ErrTaskRetry {
+		err = interceptTask(p.shard.GetConfig().TaskInterceptors, newTaskInterceptorInfo(p.shard.GetShardID(), task, retryCount),
+			func() error { return p.processor.process(task) })
+		if err != nil && err != ErrTaskRetry && err != ErrTaskPaused {
 			retryCount++
 			logger = p.initializeLoggerForTask(task, logger)
 			logging.LogTaskProcessingFailedEvent(logger, err)
@@ -271,26 +309,32 @@ ProcessRetryLoop:
 		case <-p.shutdownCh:
 			return
 		default:
-			// clear the existing notification
-			select {
-			case <-notificationChan:
-			default:
-			}
-
 			err = backoff.Retry(op, p.retryPolicy, func(err error) bool {
-				return err != ErrTaskRetry
+				return err != ErrTaskRetry && err != ErrTaskPaused
 			})
 
 			if err != nil {
 				if err == ErrTaskRetry {
+					// hand the task over to the redelivery queue instead of holding on to the worker,
+					// the task stays outstanding in the ack manager until it is redelivered and verified
 					p.metricsClient.IncCounter(p.options.MetricScope, metrics.HistoryTaskStandbyRetryCounter)
-					<-notificationChan
+					p.redeliveryQueue.add(task)
+					deferred = true
+					return
+				} else if err == ErrTaskPaused {
+					// park the task instead of holding on to the worker, it stays unacked and is handed back
+					// every check interval until its task type gets resumed
+					p.metricsClient.IncCounter(p.options.MetricScope, metrics.HistoryTaskPausedCounter)
+					p.pausedQueue.add(task)
+					deferred = true
+					return
 				} else if _, ok := err.(*workflow.DomainNotActiveError); ok && time.Now().Sub(startTime) > cache.DomainCacheRefreshInterval {
 					p.metricsClient.IncCounter(p.options.MetricScope, metrics.HistoryTaskNotActiveCounter)
 					return
 				}
 				continue ProcessRetryLoop
 			}
+			p.shard.RecordOperation(shardOperationTaskProcessed, describeProcessedTask(task, retryCount+1))
 			return
 		}
 	}
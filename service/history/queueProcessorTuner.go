@@ -0,0 +1,197 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/collection"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+const (
+	// the tuned poll rate and worker count are cut by this factor when the persistence is struggling
+	queueProcessorTuningDecreaseFactor = 0.5
+	// and grow back by these steps per tuning interval otherwise
+	queueProcessorTuningPollRPSStep     = 2
+	queueProcessorTuningWorkerCountStep = 1
+)
+
+type (
+	// queueProcessorTuner limits the poll rate and the number of concurrently processed tasks of a queue processor
+	// of one shard. With auto tuning disabled it enforces the static max poll rps and worker count, otherwise it
+	// tunes both in an AIMD fashion from the latency and the failures of the persistence calls of the shard, as
+	// measured by the persistence metrics client: they are cut in half when the persistence is struggling and grow
+	// back linearly while it keeps up.
+	queueProcessorTuner struct {
+		config        *Config
+		maxPollRPS    dynamicconfig.IntPropertyFn
+		workerCount   dynamicconfig.IntPropertyFn
+		load          *persistenceLoad
+		metricsClient metrics.Client
+		metricsScope  int
+
+		sync.Mutex
+		stopped        bool
+		pollRPS        int
+		workers        int
+		rateLimiter    common.TokenBucket
+		rateLimiterRPS int
+		inFlight       int
+		inFlightCond   *sync.Cond
+		// the persistence load as of the last adjustment
+		lastLoad persistenceLoadSnapshot
+	}
+)
+
+func newQueueProcessorTuner(config *Config, maxPollRPS dynamicconfig.IntPropertyFn,
+	workerCount dynamicconfig.IntPropertyFn, load *persistenceLoad, metricsClient metrics.Client,
+	metricsScope int) *queueProcessorTuner {
+	t := &queueProcessorTuner{
+		config:        config,
+		maxPollRPS:    maxPollRPS,
+		workerCount:   workerCount,
+		load:          load,
+		metricsClient: metricsClient,
+		metricsScope:  metricsScope,
+	}
+	t.inFlightCond = sync.NewCond(&t.Mutex)
+	t.resetLocked()
+	return t
+}
+
+// numOfWorkers returns how many task workers the processor starts, the tuner then limits how many of them process
+// tasks at the same time
+func (t *queueProcessorTuner) numOfWorkers() int {
+	if !t.config.EnableQueueProcessorAutoTuning() {
+		return t.workerCount()
+	}
+	return collection.MaxInt(t.workerCount(), t.config.QueueProcessorAutoTuningMaxWorkerCount())
+}
+
+// consumePoll waits up to timeout for the poll rate to allow another read of the queue
+func (t *queueProcessorTuner) consumePoll(timeout time.Duration) bool {
+	t.Lock()
+	rps := t.pollRPSLocked()
+	if t.rateLimiter == nil || rps != t.rateLimiterRPS {
+		t.rateLimiterRPS = rps
+		t.rateLimiter = common.NewTokenBucket(rps, common.NewRealTimeSource())
+	}
+	rateLimiter := t.rateLimiter
+	t.Unlock()
+
+	return rateLimiter.Consume(1, timeout)
+}
+
+// acquireWorker blocks until the worker is allowed to process a task, it returns false once the tuner is stopped
+func (t *queueProcessorTuner) acquireWorker() bool {
+	t.Lock()
+	defer t.Unlock()
+
+	for !t.stopped && t.inFlight >= t.workersLocked() {
+		t.inFlightCond.Wait()
+	}
+	if t.stopped {
+		return false
+	}
+	t.inFlight++
+	return true
+}
+
+func (t *queueProcessorTuner) releaseWorker() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.inFlight--
+	t.inFlightCond.Signal()
+}
+
+// adjust tunes the poll rate and the worker count from the persistence load of the shard since the previous call
+func (t *queueProcessorTuner) adjust() {
+	t.Lock()
+	defer t.Unlock()
+
+	if !t.config.EnableQueueProcessorAutoTuning() {
+		t.resetLocked()
+		return
+	}
+
+	currentLoad := t.load.snapshot()
+	load := currentLoad.sub(t.lastLoad)
+	t.lastLoad = currentLoad
+
+	maxPollRPS := t.config.QueueProcessorAutoTuningMaxPollRPS()
+	maxWorkers := t.config.QueueProcessorAutoTuningMaxWorkerCount()
+	// an idle shard says nothing about the persistence, the limits are kept as they are
+	if load.calls > 0 {
+		errorRate := float64(load.failures) / float64(load.calls)
+		avgLatency := load.latency / time.Duration(load.calls)
+		if errorRate > t.config.QueueProcessorAutoTuningMaxErrorRate() ||
+			avgLatency > t.config.QueueProcessorAutoTuningTargetLatency() {
+			t.pollRPS = int(float64(t.pollRPS) * queueProcessorTuningDecreaseFactor)
+			t.workers = int(float64(t.workers) * queueProcessorTuningDecreaseFactor)
+			t.metricsClient.IncCounter(t.metricsScope, metrics.QueueProcessorTuningBackoffCounter)
+		} else {
+			t.pollRPS += queueProcessorTuningPollRPSStep
+			t.workers += queueProcessorTuningWorkerCountStep
+		}
+	}
+	t.pollRPS = collection.MaxInt(1, collection.MinInt(t.pollRPS, maxPollRPS))
+	t.workers = collection.MaxInt(1, collection.MinInt(t.workers, maxWorkers))
+	t.inFlightCond.Broadcast()
+
+	t.metricsClient.UpdateGauge(t.metricsScope, metrics.QueueProcessorTunedPollRPSGauge, float64(t.pollRPS))
+	t.metricsClient.UpdateGauge(t.metricsScope, metrics.QueueProcessorTunedWorkerCountGauge, float64(t.workers))
+}
+
+// stop releases the workers waiting to process a task
+func (t *queueProcessorTuner) stop() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.stopped = true
+	t.inFlightCond.Broadcast()
+}
+
+func (t *queueProcessorTuner) pollRPSLocked() int {
+	if !t.config.EnableQueueProcessorAutoTuning() {
+		return t.maxPollRPS()
+	}
+	return t.pollRPS
+}
+
+func (t *queueProcessorTuner) workersLocked() int {
+	if !t.config.EnableQueueProcessorAutoTuning() {
+		return t.workerCount()
+	}
+	return t.workers
+}
+
+// resetLocked starts the tuning over from the static max poll rps and worker count
+func (t *queueProcessorTuner) resetLocked() {
+	t.pollRPS = t.maxPollRPS()
+	t.workers = t.workerCount()
+	t.lastLoad = t.load.snapshot()
+	t.inFlightCond.Broadcast()
+}
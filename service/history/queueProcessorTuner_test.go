@@ -0,0 +1,188 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	queueProcessorTunerSuite struct {
+		suite.Suite
+		config           *Config
+		mockExecutionMgr *mocks.ExecutionManager
+		executionMgr     persistence.ExecutionManager
+		loadClient       metrics.Client
+		tuner            *queueProcessorTuner
+	}
+)
+
+func TestQueueProcessorTunerSuite(t *testing.T) {
+	s := new(queueProcessorTunerSuite)
+	suite.Run(t, s)
+}
+
+func (s *queueProcessorTunerSuite) SetupTest() {
+	s.config = NewConfig(dynamicconfig.NewNopCollection(), 1)
+	s.config.EnableQueueProcessorAutoTuning = dynamicconfig.GetBoolPropertyFn(true)
+	s.config.QueueProcessorAutoTuningTargetLatency = dynamicconfig.GetDurationPropertyFn(time.Second)
+	s.config.QueueProcessorAutoTuningMaxErrorRate = dynamicconfig.GetFloatPropertyFn(0.2)
+	s.config.QueueProcessorAutoTuningMaxPollRPS = dynamicconfig.GetIntPropertyFn(24)
+	s.config.QueueProcessorAutoTuningMaxWorkerCount = dynamicconfig.GetIntPropertyFn(12)
+
+	// the tuner is fed by the persistence metrics client of the execution manager, as it is for a shard
+	metricsClient := metrics.NewClient(tally.NoopScope, metrics.History)
+	load := &persistenceLoad{}
+	s.loadClient = newPersistenceLoadMetricsClient(metricsClient, load)
+	s.mockExecutionMgr = &mocks.ExecutionManager{}
+	s.executionMgr = persistence.NewWorkflowExecutionPersistenceMetricsClient(s.mockExecutionMgr, s.loadClient,
+		bark.NewLoggerFromLogrus(log.New()))
+	s.tuner = newQueueProcessorTuner(s.config, dynamicconfig.GetIntPropertyFn(20),
+		dynamicconfig.GetIntPropertyFn(10), load, metricsClient, metrics.TransferQueueProcessorScope)
+}
+
+func (s *queueProcessorTunerSuite) TearDownTest() {
+	s.mockExecutionMgr.AssertExpectations(s.T())
+}
+
+func (s *queueProcessorTunerSuite) TestNumOfWorkers() {
+	s.Equal(12, s.tuner.numOfWorkers())
+
+	s.config.EnableQueueProcessorAutoTuning = dynamicconfig.GetBoolPropertyFn(false)
+	s.Equal(10, s.tuner.numOfWorkers())
+}
+
+func (s *queueProcessorTunerSuite) TestAdjust_AdditiveIncrease() {
+	s.persistenceCall(nil)
+	s.tuner.adjust()
+	s.Equal(22, s.tuner.pollRPS)
+	s.Equal(11, s.tuner.workers)
+
+	// capped by the configured ceilings
+	for i := 0; i < 5; i++ {
+		s.persistenceCall(nil)
+		s.tuner.adjust()
+	}
+	s.Equal(24, s.tuner.pollRPS)
+	s.Equal(12, s.tuner.workers)
+}
+
+func (s *queueProcessorTunerSuite) TestAdjust_MultiplicativeDecrease_Errors() {
+	s.persistenceCall(nil)
+	s.persistenceCall(&persistence.TimeoutError{Msg: "timeout"})
+	s.tuner.adjust()
+	s.Equal(10, s.tuner.pollRPS)
+	s.Equal(5, s.tuner.workers)
+
+	// errors which do not come from an overloaded persistence do not count
+	s.persistenceCall(&workflow.EntityNotExistsError{})
+	s.persistenceCall(&persistence.ConditionFailedError{})
+	s.tuner.adjust()
+	s.Equal(12, s.tuner.pollRPS)
+	s.Equal(6, s.tuner.workers)
+
+	// never below one
+	for i := 0; i < 5; i++ {
+		s.persistenceCall(&workflow.ServiceBusyError{})
+		s.tuner.adjust()
+	}
+	s.Equal(1, s.tuner.pollRPS)
+	s.Equal(1, s.tuner.workers)
+}
+
+func (s *queueProcessorTunerSuite) TestAdjust_MultiplicativeDecrease_Latency() {
+	s.loadClient.RecordTimer(metrics.PersistenceGetTransferTasksScope, metrics.PersistenceLatency, 3*time.Second)
+	s.loadClient.RecordTimer(metrics.PersistenceGetTransferTasksScope, metrics.PersistenceLatency, 0)
+	// other timers are not persistence calls
+	s.loadClient.RecordTimer(metrics.TransferQueueProcessorScope, metrics.CadenceLatency, time.Hour)
+	s.tuner.adjust()
+	s.Equal(10, s.tuner.pollRPS)
+	s.Equal(5, s.tuner.workers)
+}
+
+func (s *queueProcessorTunerSuite) TestAdjust_Idle() {
+	s.tuner.adjust()
+	s.Equal(20, s.tuner.pollRPS)
+	s.Equal(10, s.tuner.workers)
+}
+
+func (s *queueProcessorTunerSuite) TestAdjust_Disabled() {
+	s.persistenceCall(errors.New("some random error"))
+	s.tuner.adjust()
+	s.Equal(5, s.tuner.workers)
+
+	s.config.EnableQueueProcessorAutoTuning = dynamicconfig.GetBoolPropertyFn(false)
+	s.persistenceCall(errors.New("some random error"))
+	s.tuner.adjust()
+	s.Equal(20, s.tuner.pollRPS)
+	s.Equal(10, s.tuner.workers)
+
+	// the load observed while the tuning was disabled is not held against the shard
+	s.config.EnableQueueProcessorAutoTuning = dynamicconfig.GetBoolPropertyFn(true)
+	s.tuner.adjust()
+	s.Equal(20, s.tuner.pollRPS)
+	s.Equal(10, s.tuner.workers)
+}
+
+// persistenceCall reads the transfer tasks of the shard through the persistence metrics client, the read fails
+// with err if any
+func (s *queueProcessorTunerSuite) persistenceCall(err error) {
+	var response *persistence.GetTransferTasksResponse
+	if err == nil {
+		response = &persistence.GetTransferTasksResponse{}
+	}
+	s.mockExecutionMgr.On("GetTransferTasks", mock.Anything).Return(response, err).Once()
+	s.executionMgr.GetTransferTasks(&persistence.GetTransferTasksRequest{})
+}
+
+func (s *queueProcessorTunerSuite) TestAcquireWorker() {
+	s.config.QueueProcessorAutoTuningMaxWorkerCount = dynamicconfig.GetIntPropertyFn(1)
+	s.tuner.adjust()
+	s.True(s.tuner.acquireWorker())
+
+	acquired := make(chan bool)
+	go func() { acquired <- s.tuner.acquireWorker() }()
+	select {
+	case <-acquired:
+		s.Fail("worker acquired above the tuned worker count")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	s.tuner.releaseWorker()
+	s.True(<-acquired)
+
+	go func() { acquired <- s.tuner.acquireWorker() }()
+	s.tuner.stop()
+	s.False(<-acquired)
+}
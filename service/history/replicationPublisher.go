@@ -0,0 +1,258 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"golang.org/x/time/rate"
+)
+
+var errReplicationPublisherStopped = errors.New("replication publisher is stopped")
+
+type (
+	// replicationPublisher shapes the replication tasks published by all shards of a history host per remote
+	// cluster, so that a cluster catching up after a downtime is not sent more tasks than it can apply.
+	// Tasks are published once for all their target clusters, a task waits for the limits of each of them.
+	replicationPublisher struct {
+		producer      messaging.Producer
+		config        *Config
+		metricsClient metrics.Client
+		logger        bark.Logger
+
+		ctx    context.Context
+		cancel context.CancelFunc
+
+		sync.Mutex
+		stopped  bool
+		clusters map[string]*clusterPublishLimiter
+	}
+
+	clusterPublishLimiter struct {
+		name          string
+		rps           int
+		rateLimiter   *rate.Limiter
+		inFlight      int
+		inFlightCond  *sync.Cond
+		metricsClient metrics.Client
+	}
+)
+
+var _ messaging.Producer = (*replicationPublisher)(nil)
+
+func newReplicationPublisher(producer messaging.Producer, config *Config, metricsClient metrics.Client,
+	logger bark.Logger) *replicationPublisher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &replicationPublisher{
+		producer:      producer,
+		config:        config,
+		metricsClient: metricsClient,
+		logger:        logger,
+		ctx:           ctx,
+		cancel:        cancel,
+		clusters:      make(map[string]*clusterPublishLimiter),
+	}
+}
+
+// Publish publishes the task once the limits of all its target clusters admit it
+func (p *replicationPublisher) Publish(msg *replicator.ReplicationTask) error {
+	clusters := p.getClusterLimiters(msg)
+	tasks := make(map[*clusterPublishLimiter]int, len(clusters))
+	for _, cluster := range clusters {
+		tasks[cluster] = 1
+	}
+	if err := p.acquire(clusters, tasks); err != nil {
+		return err
+	}
+	defer p.release(clusters)
+	return p.producer.Publish(msg)
+}
+
+// PublishBatch publishes the tasks once the rate limits of their target clusters admit all of them, the batch
+// takes a single in-flight slot of each cluster
+func (p *replicationPublisher) PublishBatch(msgs []*replicator.ReplicationTask) error {
+	tasks := make(map[*clusterPublishLimiter]int)
+	for _, msg := range msgs {
+		for _, cluster := range p.getClusterLimiters(msg) {
+			tasks[cluster]++
+		}
+	}
+	clusters := make([]*clusterPublishLimiter, 0, len(tasks))
+	for cluster := range tasks {
+		clusters = append(clusters, cluster)
+	}
+	sortClusterLimiters(clusters)
+
+	if err := p.acquire(clusters, tasks); err != nil {
+		return err
+	}
+	defer p.release(clusters)
+	return p.producer.PublishBatch(msgs)
+}
+
+// Close closes the underlying producer
+func (p *replicationPublisher) Close() error {
+	return p.producer.Close()
+}
+
+// Stop fails the publishes waiting for the limits, so that the queue processors of the host are not held up
+// on shutdown
+func (p *replicationPublisher) Stop() {
+	p.Lock()
+	defer p.Unlock()
+	if p.stopped {
+		return
+	}
+	p.stopped = true
+	p.cancel()
+	for _, cluster := range p.clusters {
+		cluster.inFlightCond.Broadcast()
+	}
+}
+
+// getClusterLimiters returns the limiters of the target clusters of the task in name order, which is the order
+// they are acquired in so that concurrent publishes cannot deadlock on each other
+func (p *replicationPublisher) getClusterLimiters(msg *replicator.ReplicationTask) []*clusterPublishLimiter {
+	if msg.HistoryTaskAttributes == nil {
+		// shard status tasks are small and not applied by the remote clusters, they are not shaped
+		return nil
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	clusters := make([]*clusterPublishLimiter, 0, len(msg.HistoryTaskAttributes.TargetClusters))
+	for _, name := range msg.HistoryTaskAttributes.TargetClusters {
+		cluster, ok := p.clusters[name]
+		if !ok {
+			cluster = &clusterPublishLimiter{
+				name:         name,
+				inFlightCond: sync.NewCond(&p.Mutex),
+				metricsClient: p.metricsClient.Tagged(map[string]string{
+					metrics.TargetClusterTagName: name,
+				}),
+			}
+			p.clusters[name] = cluster
+		}
+		clusters = append(clusters, cluster)
+	}
+	sortClusterLimiters(clusters)
+	return clusters
+}
+
+// acquire takes an in-flight slot of each cluster, then waits for the rate limit of each cluster to admit its tasks
+func (p *replicationPublisher) acquire(clusters []*clusterPublishLimiter, tasks map[*clusterPublishLimiter]int) error {
+	if len(clusters) == 0 {
+		return nil
+	}
+	startTime := time.Now()
+	if err := p.acquireInFlight(clusters); err != nil {
+		return err
+	}
+	for _, cluster := range clusters {
+		if err := p.waitRate(cluster, tasks[cluster]); err != nil {
+			p.release(clusters)
+			return err
+		}
+	}
+	latency := time.Since(startTime)
+	for _, cluster := range clusters {
+		cluster.metricsClient.RecordTimer(metrics.ReplicatorQueueProcessorScope, metrics.ReplicationPublishShapingLatency,
+			latency)
+	}
+	return nil
+}
+
+func (p *replicationPublisher) acquireInFlight(clusters []*clusterPublishLimiter) error {
+	p.Lock()
+	defer p.Unlock()
+	for i, cluster := range clusters {
+		for {
+			if p.stopped {
+				p.releaseLocked(clusters[:i])
+				return errReplicationPublisherStopped
+			}
+			maxInFlight := p.config.ReplicatorPublishMaxInFlight(dynamicconfig.ClusterNameFilter(cluster.name))
+			if maxInFlight <= 0 || cluster.inFlight < maxInFlight {
+				break
+			}
+			cluster.inFlightCond.Wait()
+		}
+		cluster.inFlight++
+		cluster.metricsClient.UpdateGauge(metrics.ReplicatorQueueProcessorScope, metrics.ReplicationPublishInFlight,
+			float64(cluster.inFlight))
+	}
+	return nil
+}
+
+func (p *replicationPublisher) waitRate(cluster *clusterPublishLimiter, tasks int) error {
+	p.Lock()
+	rps := p.config.ReplicatorPublishRPS(dynamicconfig.ClusterNameFilter(cluster.name))
+	if rps <= 0 {
+		cluster.rps = 0
+		cluster.rateLimiter = nil
+	} else if rps != cluster.rps {
+		cluster.rps = rps
+		cluster.rateLimiter = rate.NewLimiter(rate.Limit(rps), rps)
+	}
+	rateLimiter := cluster.rateLimiter
+	p.Unlock()
+
+	if rateLimiter == nil {
+		return nil
+	}
+	for i := 0; i < tasks; i++ {
+		if err := rateLimiter.Wait(p.ctx); err != nil {
+			if p.ctx.Err() != nil {
+				return errReplicationPublisherStopped
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *replicationPublisher) release(clusters []*clusterPublishLimiter) {
+	p.Lock()
+	defer p.Unlock()
+	p.releaseLocked(clusters)
+}
+
+func (p *replicationPublisher) releaseLocked(clusters []*clusterPublishLimiter) {
+	for _, cluster := range clusters {
+		cluster.inFlight--
+		cluster.metricsClient.UpdateGauge(metrics.ReplicatorQueueProcessorScope, metrics.ReplicationPublishInFlight,
+			float64(cluster.inFlight))
+		cluster.inFlightCond.Signal()
+	}
+}
+
+func sortClusterLimiters(clusters []*clusterPublishLimiter) {
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].name < clusters[j].name })
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"golang.org/x/time/rate"
+)
+
+type (
+	replicationPublisherSuite struct {
+		suite.Suite
+		producer  *mocks.KafkaProducer
+		publisher *replicationPublisher
+	}
+)
+
+func TestReplicationPublisherSuite(t *testing.T) {
+	s := new(replicationPublisherSuite)
+	suite.Run(t, s)
+}
+
+func (s *replicationPublisherSuite) SetupTest() {
+	s.producer = &mocks.KafkaProducer{}
+	config := NewConfig(dynamicconfig.NewNopCollection(), 1)
+	// only the standby cluster is limited
+	config.ReplicatorPublishMaxInFlight = func(opts ...dynamicconfig.FilterOption) int {
+		return clusterConfigValue(opts, "standby", 1)
+	}
+	config.ReplicatorPublishRPS = func(opts ...dynamicconfig.FilterOption) int {
+		return clusterConfigValue(opts, "standby", 1000)
+	}
+	s.publisher = newReplicationPublisher(s.producer, config, metrics.NewClient(tally.NoopScope, metrics.History),
+		bark.NewNopLogger())
+}
+
+func (s *replicationPublisherSuite) TearDownTest() {
+	s.producer.AssertExpectations(s.T())
+}
+
+func (s *replicationPublisherSuite) waitForInFlight(clusterName string, inFlight int) {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		s.publisher.Lock()
+		cluster, ok := s.publisher.clusters[clusterName]
+		reached := ok && cluster.inFlight == inFlight
+		s.publisher.Unlock()
+		if reached {
+			return
+		}
+	}
+	s.Fail("in-flight publishes not reached", "cluster %v", clusterName)
+}
+
+func clusterConfigValue(opts []dynamicconfig.FilterOption, cluster string, value int) int {
+	filters := make(map[dynamicconfig.Filter]interface{})
+	for _, opt := range opts {
+		opt(filters)
+	}
+	if filters[dynamicconfig.ClusterName] == cluster {
+		return value
+	}
+	return 0
+}
+
+func newHistoryReplicationTask(targetClusters ...string) *replicator.ReplicationTask {
+	return &replicator.ReplicationTask{
+		TaskType: replicator.ReplicationTaskType.Ptr(replicator.ReplicationTaskTypeHistory),
+		HistoryTaskAttributes: &replicator.HistoryTaskAttributes{
+			TargetClusters: targetClusters,
+			DomainId:       common.StringPtr("domain-id"),
+		},
+	}
+}
+
+func (s *replicationPublisherSuite) TestPublish_LimitsInFlightPerCluster() {
+	blockedTask := newHistoryReplicationTask("other", "standby")
+	release := make(chan struct{})
+	s.producer.On("Publish", blockedTask).Return(nil).Run(func(args mock.Arguments) {
+		<-release
+	}).Once()
+
+	firstDone := make(chan error)
+	go func() { firstDone <- s.publisher.Publish(blockedTask) }()
+	s.waitForInFlight("standby", 1)
+
+	// the other cluster has no limit
+	otherTask := newHistoryReplicationTask("other")
+	s.producer.On("Publish", otherTask).Return(nil).Once()
+	s.Nil(s.publisher.Publish(otherTask))
+
+	// the standby cluster has its single in-flight publish taken
+	standbyTask := newHistoryReplicationTask("standby")
+	s.producer.On("Publish", standbyTask).Return(nil).Once()
+	secondDone := make(chan error)
+	go func() { secondDone <- s.publisher.Publish(standbyTask) }()
+	select {
+	case <-secondDone:
+		s.Fail("publish should wait for the in-flight publish of the cluster")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	s.Nil(<-firstDone)
+	s.Nil(<-secondDone)
+	s.Equal(0, s.publisher.clusters["standby"].inFlight)
+	s.Equal(rate.Limit(1000), s.publisher.clusters["standby"].rateLimiter.Limit())
+	s.Nil(s.publisher.clusters["other"].rateLimiter)
+}
+
+func (s *replicationPublisherSuite) TestPublish_ShardStatusTaskNotShaped() {
+	syncStatusTask := &replicator.ReplicationTask{
+		TaskType: replicator.ReplicationTaskType.Ptr(replicator.ReplicationTaskTypeSyncShardStatus),
+		SyncShardStatusTaskAttributes: &replicator.SyncShardStatusTaskAttributes{
+			SourceCluster: common.StringPtr("active"),
+		},
+	}
+	s.producer.On("Publish", syncStatusTask).Return(nil).Once()
+	s.Nil(s.publisher.Publish(syncStatusTask))
+	s.Empty(s.publisher.clusters)
+}
+
+func (s *replicationPublisherSuite) TestStop_FailsWaitingPublishes() {
+	blockedTask := newHistoryReplicationTask("standby")
+	release := make(chan struct{})
+	s.producer.On("Publish", blockedTask).Return(nil).Run(func(args mock.Arguments) {
+		<-release
+	}).Once()
+	firstDone := make(chan error)
+	go func() { firstDone <- s.publisher.Publish(blockedTask) }()
+	s.waitForInFlight("standby", 1)
+
+	secondDone := make(chan error)
+	go func() { secondDone <- s.publisher.Publish(newHistoryReplicationTask("standby")) }()
+	s.publisher.Stop()
+	s.Equal(errReplicationPublisherStopped, <-secondDone)
+
+	close(release)
+	s.Nil(<-firstDone)
+}
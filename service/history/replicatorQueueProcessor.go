@@ -52,6 +52,11 @@ type (
 
 		sync.Mutex
 		lastShardSyncTimestamp time.Time
+
+		purgeShutdownCh chan struct{}
+		purgeShutdownWG sync.WaitGroup
+		// tasks up to and including this task ID have been purged
+		purgedLevel int64
 	}
 )
 
@@ -93,9 +98,11 @@ func newReplicatorQueueProcessor(shard ShardContext, replicator messaging.Produc
 		metricsClient:       shard.GetMetricsClient(),
 		options:             options,
 		logger:              logger,
+		purgeShutdownCh:     make(chan struct{}),
+		purgedLevel:         shard.GetReplicatorAckLevel(),
 	}
 
-	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetReplicatorAckLevel(), logger)
+	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetReplicatorAckLevel(), common.ReplicatorQueueBacklogName, logger)
 	queueProcessorBase := newQueueProcessorBase(currentClusterNamer, shard, options, processor, queueAckMgr, logger)
 	processor.queueAckMgr = queueAckMgr
 	processor.queueProcessorBase = queueProcessorBase
@@ -103,6 +110,65 @@ func newReplicatorQueueProcessor(shard ShardContext, replicator messaging.Produc
 	return processor
 }
 
+func (p *replicatorQueueProcessorImpl) Start() {
+	p.queueProcessorBase.Start()
+	p.purgeShutdownWG.Add(1)
+	go p.purgePump()
+}
+
+func (p *replicatorQueueProcessorImpl) Stop() {
+	p.queueProcessorBase.Stop()
+	select {
+	case <-p.purgeShutdownCh:
+	default:
+		close(p.purgeShutdownCh)
+	}
+	if success := common.AwaitWaitGroup(&p.purgeShutdownWG, time.Minute); !success {
+		p.logger.Warn("Replication task purger timed out on shutdown.")
+	}
+}
+
+func (p *replicatorQueueProcessorImpl) purgePump() {
+	defer p.purgeShutdownWG.Done()
+
+	purgeTimer := time.NewTimer(p.shard.GetConfig().ReplicatorTaskPurgeInterval())
+	defer purgeTimer.Stop()
+
+	for {
+		select {
+		case <-p.purgeShutdownCh:
+			return
+		case <-purgeTimer.C:
+			if err := p.purgeTasks(); err != nil {
+				p.logger.WithField(logging.TagErr, err).Warn("Failed to purge replication tasks.")
+			}
+			purgeTimer.Reset(p.shard.GetConfig().ReplicatorTaskPurgeInterval())
+		}
+	}
+}
+
+// purgeTasks deletes all replication tasks at or below the replicator ack level with a single range delete.  Tasks are
+// published once to the replication topic which is consumed by every remote cluster, so the replicator ack level is
+// the minimum ack level across all of them.
+func (p *replicatorQueueProcessorImpl) purgeTasks() error {
+	ackLevel := p.shard.GetReplicatorAckLevel()
+	if ackLevel <= p.purgedLevel {
+		return nil
+	}
+
+	p.metricsClient.IncCounter(metrics.ReplicatorQueueProcessorScope, metrics.ReplicatorTaskPurgeCounter)
+	err := p.executionMgr.RangeCompleteReplicationTask(&persistence.RangeCompleteReplicationTaskRequest{
+		InclusiveEndTaskID: ackLevel,
+	})
+	if err != nil {
+		p.metricsClient.IncCounter(metrics.ReplicatorQueueProcessorScope, metrics.ReplicatorTaskPurgeFailures)
+		return err
+	}
+
+	p.purgedLevel = ackLevel
+	return nil
+}
+
 func (p *replicatorQueueProcessorImpl) process(qTask queueTaskInfo) error {
 	task, ok := qTask.(*persistence.ReplicationTaskInfo)
 	if !ok {
@@ -114,6 +180,9 @@ func (p *replicatorQueueProcessorImpl) process(qTask queueTaskInfo) error {
 	case persistence.ReplicationTaskTypeHistory:
 		scope = metrics.ReplicatorTaskHistoryScope
 		err = p.processHistoryReplicationTask(task)
+	case persistence.ReplicationTaskTypeSyncActivity:
+		scope = metrics.ReplicatorTaskSyncActivityScope
+		err = p.processSyncActivityTask(task)
 	default:
 		err = errUnknownReplicationTask
 	}
@@ -140,9 +209,11 @@ func (p *replicatorQueueProcessorImpl) processHistoryReplicationTask(task *persi
 	if err != nil {
 		return err
 	}
-	targetClusters := []string{}
-	for _, cluster := range domainEntry.GetReplicationConfig().Clusters {
-		targetClusters = append(targetClusters, cluster.ClusterName)
+	targetClusters := getReplicationTargetClusters(domainEntry.GetReplicationConfig(), p.currentClusterNamer)
+	if len(targetClusters) == 0 {
+		// the domain is placed on the current cluster only, there is no cluster to replicate to
+		p.metricsClient.IncCounter(metrics.ReplicatorTaskHistoryScope, metrics.ReplicationTaskNoTargetCounter)
+		return nil
 	}
 
 	history, err := p.getHistory(task.DomainID, task.WorkflowID, task.RunID, task.FirstEventID, task.NextEventID)
@@ -177,6 +248,7 @@ func (p *replicatorQueueProcessorImpl) processHistoryReplicationTask(task *persi
 			ReplicationInfo: convertLastReplicationInfo(task.LastReplicationInfo),
 			History:         history,
 			NewRunHistory:   newRunHistory,
+			Timestamp:       common.Int64Ptr(common.NewRealTimeSource().Now().UnixNano()),
 		},
 	}
 
@@ -189,11 +261,85 @@ func (p *replicatorQueueProcessorImpl) processHistoryReplicationTask(task *persi
 	return err
 }
 
+// processSyncActivityTask ships the retry state of an activity to the other clusters.  The attempt and the schedule
+// time of a retry are kept in mutable state only, they are read when the task is processed, so the latest state is
+// shipped even if the activity was retried again in the meantime.
+func (p *replicatorQueueProcessorImpl) processSyncActivityTask(task *persistence.ReplicationTaskInfo) error {
+	p.metricsClient.IncCounter(metrics.ReplicatorTaskSyncActivityScope, metrics.TaskRequests)
+	sw := p.metricsClient.StartTimer(metrics.ReplicatorTaskSyncActivityScope, metrics.TaskLatency)
+	defer sw.Stop()
+
+	domainEntry, err := p.shard.GetDomainCache().GetDomainByID(task.DomainID)
+	if err != nil {
+		return err
+	}
+	if len(getReplicationTargetClusters(domainEntry.GetReplicationConfig(), p.currentClusterNamer)) == 0 {
+		p.metricsClient.IncCounter(metrics.ReplicatorTaskSyncActivityScope, metrics.ReplicationTaskNoTargetCounter)
+		return nil
+	}
+
+	response, err := p.executionMgr.GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
+		DomainID: task.DomainID,
+		Execution: shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(task.WorkflowID),
+			RunId:      common.StringPtr(task.RunID),
+		},
+	})
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); ok {
+			return nil
+		}
+		return err
+	}
+	if response.State.ExecutionInfo.State == persistence.WorkflowStateCompleted {
+		return nil
+	}
+	ai, ok := response.State.ActivitInfos[task.ScheduledID]
+	if !ok {
+		// the activity is completed, its outcome is replicated by the history events
+		return nil
+	}
+
+	var startedTime *int64
+	if ai.StartedID != common.EmptyEventID {
+		startedTime = common.Int64Ptr(ai.StartedTime.UnixNano())
+	}
+	replicationTask := &replicator.ReplicationTask{
+		TaskType: replicator.ReplicationTaskType.Ptr(replicator.ReplicationTaskTypeSyncActivity),
+		SyncActivityTaskAttributes: &replicator.SyncActivityTaskAttributes{
+			DomainId:      common.StringPtr(task.DomainID),
+			WorkflowId:    common.StringPtr(task.WorkflowID),
+			RunId:         common.StringPtr(task.RunID),
+			Version:       common.Int64Ptr(ai.Version),
+			ScheduledId:   common.Int64Ptr(ai.ScheduleID),
+			ScheduledTime: common.Int64Ptr(ai.ScheduledTime.UnixNano()),
+			StartedId:     common.Int64Ptr(ai.StartedID),
+			StartedTime:   startedTime,
+			Attempt:       common.Int32Ptr(ai.Attempt),
+		},
+	}
+
+	return p.replicator.Publish(replicationTask)
+}
+
+// getReplicationTargetClusters returns the clusters the domain is placed on other than the current cluster,
+// the replication tasks of the domain are applied by these clusters only
+func getReplicationTargetClusters(replicationConfig *persistence.DomainReplicationConfig, currentCluster string) []string {
+	targetClusters := []string{}
+	for _, cluster := range replicationConfig.Clusters {
+		if cluster.ClusterName != currentCluster {
+			targetClusters = append(targetClusters, cluster.ClusterName)
+		}
+	}
+	return targetClusters
+}
+
 func (p *replicatorQueueProcessorImpl) readTasks(readLevel int64) ([]queueTaskInfo, bool, error) {
 	response, err := p.executionMgr.GetReplicationTasks(&persistence.GetReplicationTasksRequest{
-		ReadLevel:    readLevel,
-		MaxReadLevel: p.shard.GetTransferMaxReadLevel(),
-		BatchSize:    p.options.BatchSize(),
+		ReadLevel:         readLevel,
+		MaxReadLevel:      p.shard.GetTransferMaxReadLevel(),
+		BatchSize:         p.options.BatchSize(),
+		MaxBatchSizeBytes: int64(p.shard.GetConfig().ReplicatorTaskBatchSizeBytes()),
 	})
 
 	if err != nil {
@@ -209,9 +355,8 @@ func (p *replicatorQueueProcessorImpl) readTasks(readLevel int64) ([]queueTaskIn
 }
 
 func (p *replicatorQueueProcessorImpl) completeTask(taskID int64) error {
-	return p.executionMgr.CompleteReplicationTask(&persistence.CompleteReplicationTaskRequest{
-		TaskID: taskID,
-	})
+	// replication tasks are deleted in batches by the purger once the ack level moves past them
+	return nil
 }
 
 func (p *replicatorQueueProcessorImpl) updateAckLevel(ackLevel int64) error {
@@ -0,0 +1,55 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	replicatorQueueProcessorSuite struct {
+		suite.Suite
+	}
+)
+
+func TestReplicatorQueueProcessorSuite(t *testing.T) {
+	s := new(replicatorQueueProcessorSuite)
+	suite.Run(t, s)
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetReplicationTargetClusters() {
+	replicationConfig := &persistence.DomainReplicationConfig{
+		ActiveClusterName: "a",
+		Clusters: []*persistence.ClusterReplicationConfig{
+			{ClusterName: "a"},
+			{ClusterName: "c"},
+			{ClusterName: "d"},
+		},
+	}
+	s.Equal([]string{"c", "d"}, getReplicationTargetClusters(replicationConfig, "a"))
+	s.Equal([]string{"a", "d"}, getReplicationTargetClusters(replicationConfig, "c"))
+
+	replicationConfig.Clusters = replicationConfig.Clusters[:1]
+	s.Empty(getReplicationTargetClusters(replicationConfig, "a"))
+}
@@ -23,13 +23,16 @@ package history
 import (
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/uber-common/bark"
 	"github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	svcconfig "github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
@@ -37,6 +40,12 @@ import (
 type Config struct {
 	NumberOfShards int
 
+	// TaskInterceptors are run around the processing of transfer and timer tasks, see TaskInterceptor
+	TaskInterceptors []TaskInterceptor
+
+	// LogLevel is the level of the service logger, throttled loggers skip the messages it would discard
+	LogLevel logrus.Level
+
 	PersistenceMaxQPS dynamicconfig.FloatPropertyFn
 
 	// HistoryCache settings
@@ -44,6 +53,13 @@ type Config struct {
 	HistoryCacheInitialSize dynamicconfig.IntPropertyFn
 	HistoryCacheMaxSize     dynamicconfig.IntPropertyFn
 	HistoryCacheTTL         dynamicconfig.DurationPropertyFn
+	// HistoryCacheIdleTTL evicts contexts not used for that long, HistoryCacheEvictionInterval is how often
+	HistoryCacheIdleTTL          dynamicconfig.DurationPropertyFn
+	HistoryCacheEvictionInterval dynamicconfig.DurationPropertyFn
+	// HistoryCacheWarmupMaxExecutions caps the executions with pending decisions or imminent timers, which are
+	// loaded into the cache after the shard is acquired
+	HistoryCacheWarmupMaxExecutions dynamicconfig.IntPropertyFn
+	HistoryCacheWarmupTimerWindow   dynamicconfig.DurationPropertyFn
 
 	// ShardController settings
 	RangeSizeBits        uint
@@ -66,6 +82,8 @@ type Config struct {
 	TimerProcessorMaxPollRPS                       dynamicconfig.IntPropertyFn
 	TimerProcessorMaxPollInterval                  dynamicconfig.DurationPropertyFn
 	TimerProcessorMaxPollIntervalJitterCoefficient dynamicconfig.FloatPropertyFn
+	TimerProcessorMaxIdlePollInterval              dynamicconfig.DurationPropertyFn
+	TimerProcessorMaxClockSkew                     dynamicconfig.DurationPropertyFn
 
 	// TransferQueueProcessor settings
 	TransferTaskBatchSize                              dynamicconfig.IntPropertyFn
@@ -80,6 +98,9 @@ type Config struct {
 	TransferProcessorMaxPollIntervalJitterCoefficient  dynamicconfig.FloatPropertyFn
 	TransferProcessorUpdateAckInterval                 dynamicconfig.DurationPropertyFn
 	TransferProcessorCompleteTransferInterval          dynamicconfig.DurationPropertyFn
+	TransferProcessorEnableAsyncDecisionDispatch       dynamicconfig.BoolPropertyFn
+	TransferProcessorAsyncDecisionBufferSize           dynamicconfig.IntPropertyFn
+	TransferProcessorAsyncDecisionDispatcherCount      dynamicconfig.IntPropertyFn
 
 	// ReplicatorQueueProcessor settings
 	ReplicatorTaskBatchSize                             dynamicconfig.IntPropertyFn
@@ -90,32 +111,128 @@ type Config struct {
 	ReplicatorProcessorMaxPollInterval                  dynamicconfig.DurationPropertyFn
 	ReplicatorProcessorMaxPollIntervalJitterCoefficient dynamicconfig.FloatPropertyFn
 	ReplicatorProcessorUpdateAckInterval                dynamicconfig.DurationPropertyFn
+	ReplicatorTaskPurgeInterval                         dynamicconfig.DurationPropertyFn
+	ReplicatorTaskBatchSizeBytes                        dynamicconfig.IntPropertyFn
+	// rate and concurrency of the replication tasks published by the host, per remote cluster
+	ReplicatorPublishRPS         dynamicconfig.IntPropertyFn
+	ReplicatorPublishMaxInFlight dynamicconfig.IntPropertyFn
+
+	// Switches to pause processing of timer / transfer tasks, filtered by persistence task type
+	TimerTaskPaused         dynamicconfig.BoolPropertyFn
+	TransferTaskPaused      dynamicconfig.BoolPropertyFn
+	PausedTaskCheckInterval dynamicconfig.DurationPropertyFn
+
+	// Backoff of standby tasks which cannot be verified until the mutable state is replicated
+	StandbyTaskRedeliveryInterval    dynamicconfig.DurationPropertyFn
+	StandbyTaskRedeliveryMaxInterval dynamicconfig.DurationPropertyFn
+
+	// AIMD tuning of the queue processors poll rate and worker count from the observed persistence latency and errors
+	EnableQueueProcessorAutoTuning         dynamicconfig.BoolPropertyFn
+	QueueProcessorAutoTuningInterval       dynamicconfig.DurationPropertyFn
+	QueueProcessorAutoTuningTargetLatency  dynamicconfig.DurationPropertyFn
+	QueueProcessorAutoTuningMaxErrorRate   dynamicconfig.FloatPropertyFn
+	QueueProcessorAutoTuningMaxPollRPS     dynamicconfig.IntPropertyFn
+	QueueProcessorAutoTuningMaxWorkerCount dynamicconfig.IntPropertyFn
+
+	// Rate limit and sampling of debug and info logs of the timer queue processor and the history replicator
+	ThrottledLogRPS        dynamicconfig.IntPropertyFn
+	ThrottledLogSampleRate dynamicconfig.FloatPropertyFn
+
+	// CloseEventSink is the per domain sink the summaries of closed workflows are published to
+	CloseEventSink dynamicconfig.MapPropertyFnWithDomainFilter
+
+	// SignalAfterClosePolicy is the per domain handling of signals to a closed workflow
+	SignalAfterClosePolicy dynamicconfig.StringPropertyFnWithDomainFilter
+
+	// Sticky decisions failing with non-determinism errors, counted per binary checksum over the window
+	StickyPoisonFailureThreshold dynamicconfig.IntPropertyFnWithDomainFilter
+	StickyPoisonWindow           dynamicconfig.DurationPropertyFn
 
 	// Persistence settings
 	ExecutionMgrNumConns dynamicconfig.IntPropertyFn
 	HistoryMgrNumConns   dynamicconfig.IntPropertyFn
+	// PersistenceHedgedReadDelay is how long history and timer task reads wait before they are hedged
+	PersistenceHedgedReadDelay dynamicconfig.DurationPropertyFn
+	// PayloadDedupThreshold is the payload size from which the payloads of history events are stored once per run
+	PayloadDedupThreshold dynamicconfig.IntPropertyFn
+	// EnableAppendHistoryFencing verifies the shard lease after each history append, at the cost of a shard read
+	EnableAppendHistoryFencing dynamicconfig.BoolPropertyFn
 
 	// System Limits
-	MaximumBufferedEventsBatch dynamicconfig.IntPropertyFn
+	MaximumBufferedEventsBatch   dynamicconfig.IntPropertyFn
+	MaxGetMutableStatesBatchSize dynamicconfig.IntPropertyFn
+	MaximumUpdatesPerExecution   dynamicconfig.IntPropertyFnWithDomainFilter
+
+	// MutableStateSnapshotInterval is the number of updates after which the replication state and buffered events
+	// are written in full, the updates in between only write what changed
+	MutableStateSnapshotInterval dynamicconfig.IntPropertyFn
+
+	// Concurrency limits of in flight requests, per API name
+	MaxConcurrentRequests         dynamicconfig.MapPropertyFn
+	ConcurrentRequestsWaitTimeout dynamicconfig.DurationPropertyFn
 
 	// ShardUpdateMinInterval the minimal time interval which the shard info can be updated
 	ShardUpdateMinInterval dynamicconfig.DurationPropertyFn
 	// ShardSyncMinInterval the minimal time interval which the shard info should be sync to remote
 	ShardSyncMinInterval dynamicconfig.DurationPropertyFn
+	// ShardOperationLogSize is the number of recent operations kept in memory by each shard
+	ShardOperationLogSize dynamicconfig.IntPropertyFn
+
+	// ShardRebalancer settings
+	EnableShardRebalance        dynamicconfig.BoolPropertyFn
+	ShardRebalanceInterval      dynamicconfig.DurationPropertyFn
+	ShardRebalanceMaxMoves      dynamicconfig.IntPropertyFn
+	ShardRebalanceMaxHandoffs   dynamicconfig.IntPropertyFn
+	ShardRebalanceLoadThreshold dynamicconfig.FloatPropertyFn
+	ShardRebalanceCooldown      dynamicconfig.DurationPropertyFn
+	ShardRebalanceCacheWeight   dynamicconfig.FloatPropertyFn
+
+	// ShardLagEmitInterval is the interval at which the ack levels and lags of the task queues are emitted per shard
+	ShardLagEmitInterval dynamicconfig.DurationPropertyFn
+	// ShardLagEmitTopK is the number of shards with the highest lag emitted per task queue, 0 emits all shards
+	ShardLagEmitTopK dynamicconfig.IntPropertyFn
+
+	// GlobalRatelimiterHostTTL is how long a host keeps its share of a global rate limit after its last usage report
+	GlobalRatelimiterHostTTL dynamicconfig.DurationPropertyFn
 
 	// Time to hold a poll request before returning an empty response
 	// right now only used by GetMutableState
 	LongPollExpirationInterval dynamicconfig.DurationPropertyFnWithDomainFilter
+
+	// Decision start to close timeout scaling by history size
+	DecisionTimeoutScaleEventsPerSecond  dynamicconfig.IntPropertyFnWithDomainFilter
+	MaxScaledDecisionStartToCloseTimeout dynamicconfig.DurationPropertyFnWithDomainFilter
+
+	// Soft history limits from which workers are suggested to continue the workflow as new, 0 means no suggestion
+	ContinueAsNewSuggestedHistorySize  dynamicconfig.IntPropertyFnWithDomainFilter
+	ContinueAsNewSuggestedHistoryCount dynamicconfig.IntPropertyFnWithDomainFilter
+
+	// Cap on activity schedule to start timeouts, per domain and task list, 0 means no cap
+	MaxActivityScheduleToStartTimeout dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
+
+	// Activities requesting eager execution are started right away and returned to the worker completing the
+	// decision, when enabled for their domain and task list and up to the max per decision
+	EnableActivityEagerExecution          dynamicconfig.BoolPropertyFnWithTaskListInfoFilters
+	MaxActivityEagerExecutionsPerDecision dynamicconfig.IntPropertyFnWithDomainFilter
+	// Whether the first decision task can be started right away and returned to the caller starting the workflow
+	EnableDecisionEagerExecution dynamicconfig.BoolPropertyFnWithTaskListInfoFilters
+	// Min interval between writes of the heartbeats of an activity, capped at half its heartbeat timeout
+	ActivityHeartbeatPersistInterval dynamicconfig.DurationPropertyFnWithDomainFilter
 }
 
 // NewConfig returns new service config with default values
 func NewConfig(dc *dynamicconfig.Collection, numberOfShards int) *Config {
 	return &Config{
 		NumberOfShards:                                      numberOfShards,
+		LogLevel:                                            logrus.DebugLevel,
 		PersistenceMaxQPS:                                   dc.GetFloat64Property(dynamicconfig.HistoryPersistenceMaxQPS, 9000),
 		HistoryCacheInitialSize:                             dc.GetIntProperty(dynamicconfig.HistoryCacheInitialSize, 128),
 		HistoryCacheMaxSize:                                 dc.GetIntProperty(dynamicconfig.HistoryCacheMaxSize, 512),
 		HistoryCacheTTL:                                     dc.GetDurationProperty(dynamicconfig.HistoryCacheTTL, time.Hour),
+		HistoryCacheIdleTTL:                                 dc.GetDurationProperty(dynamicconfig.HistoryCacheIdleTTL, 10*time.Minute),
+		HistoryCacheEvictionInterval:                        dc.GetDurationProperty(dynamicconfig.HistoryCacheEvictionInterval, time.Minute),
+		HistoryCacheWarmupMaxExecutions:                     dc.GetIntProperty(dynamicconfig.HistoryCacheWarmupMaxExecutions, 0),
+		HistoryCacheWarmupTimerWindow:                       dc.GetDurationProperty(dynamicconfig.HistoryCacheWarmupTimerWindow, time.Minute),
 		RangeSizeBits:                                       20, // 20 bits for sequencer, 2^20 sequence number for any range
 		AcquireShardInterval:                                dc.GetDurationProperty(dynamicconfig.AcquireShardInterval, time.Minute),
 		StandbyClusterDelay:                                 dc.GetDurationProperty(dynamicconfig.AcquireShardInterval, 5*time.Minute),
@@ -132,6 +249,8 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int) *Config {
 		TimerProcessorMaxPollRPS:                            dc.GetIntProperty(dynamicconfig.TimerProcessorMaxPollRPS, 20),
 		TimerProcessorMaxPollInterval:                       dc.GetDurationProperty(dynamicconfig.TimerProcessorMaxPollInterval, 5*time.Minute),
 		TimerProcessorMaxPollIntervalJitterCoefficient:      dc.GetFloat64Property(dynamicconfig.TimerProcessorMaxPollIntervalJitterCoefficient, 0.15),
+		TimerProcessorMaxIdlePollInterval:                   dc.GetDurationProperty(dynamicconfig.TimerProcessorMaxIdlePollInterval, time.Hour),
+		TimerProcessorMaxClockSkew:                          dc.GetDurationProperty(dynamicconfig.TimerProcessorMaxClockSkew, time.Second),
 		TransferTaskBatchSize:                               dc.GetIntProperty(dynamicconfig.TransferTaskBatchSize, 100),
 		TransferProcessorFailoverMaxPollRPS:                 dc.GetIntProperty(dynamicconfig.TransferProcessorFailoverMaxPollRPS, 1),
 		TransferProcessorMaxPollRPS:                         dc.GetIntProperty(dynamicconfig.TransferProcessorMaxPollRPS, 20),
@@ -144,6 +263,9 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int) *Config {
 		TransferProcessorMaxPollIntervalJitterCoefficient:   dc.GetFloat64Property(dynamicconfig.TransferProcessorMaxPollIntervalJitterCoefficient, 0.15),
 		TransferProcessorUpdateAckInterval:                  dc.GetDurationProperty(dynamicconfig.TransferProcessorUpdateAckInterval, 5*time.Second),
 		TransferProcessorCompleteTransferInterval:           dc.GetDurationProperty(dynamicconfig.TransferProcessorCompleteTransferInterval, 3*time.Second),
+		TransferProcessorEnableAsyncDecisionDispatch:        dc.GetBoolProperty(dynamicconfig.TransferProcessorEnableAsyncDecisionDispatch, false),
+		TransferProcessorAsyncDecisionBufferSize:            dc.GetIntProperty(dynamicconfig.TransferProcessorAsyncDecisionBufferSize, 1000),
+		TransferProcessorAsyncDecisionDispatcherCount:       dc.GetIntProperty(dynamicconfig.TransferProcessorAsyncDecisionDispatcherCount, 10),
 		ReplicatorTaskBatchSize:                             dc.GetIntProperty(dynamicconfig.ReplicatorTaskBatchSize, 100),
 		ReplicatorTaskWorkerCount:                           dc.GetIntProperty(dynamicconfig.ReplicatorTaskWorkerCount, 10),
 		ReplicatorTaskMaxRetryCount:                         dc.GetIntProperty(dynamicconfig.ReplicatorTaskMaxRetryCount, 100),
@@ -152,15 +274,82 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int) *Config {
 		ReplicatorProcessorMaxPollInterval:                  dc.GetDurationProperty(dynamicconfig.ReplicatorProcessorMaxPollInterval, 1*time.Minute),
 		ReplicatorProcessorMaxPollIntervalJitterCoefficient: dc.GetFloat64Property(dynamicconfig.ReplicatorProcessorMaxPollIntervalJitterCoefficient, 0.15),
 		ReplicatorProcessorUpdateAckInterval:                dc.GetDurationProperty(dynamicconfig.ReplicatorProcessorUpdateAckInterval, 5*time.Second),
+		ReplicatorTaskPurgeInterval:                         dc.GetDurationProperty(dynamicconfig.ReplicatorTaskPurgeInterval, 1*time.Minute),
+		ReplicatorTaskBatchSizeBytes:                        dc.GetIntProperty(dynamicconfig.ReplicatorTaskBatchSizeBytes, 4*1024*1024),
+		ReplicatorPublishRPS:                                dc.GetIntProperty(dynamicconfig.ReplicatorPublishRPS, 0),
+		ReplicatorPublishMaxInFlight:                        dc.GetIntProperty(dynamicconfig.ReplicatorPublishMaxInFlight, 0),
+		TimerTaskPaused:                                     dc.GetBoolProperty(dynamicconfig.TimerTaskPaused, false),
+		TransferTaskPaused:                                  dc.GetBoolProperty(dynamicconfig.TransferTaskPaused, false),
+		PausedTaskCheckInterval:                             dc.GetDurationProperty(dynamicconfig.PausedTaskCheckInterval, 10*time.Second),
+		StandbyTaskRedeliveryInterval:                       dc.GetDurationProperty(dynamicconfig.StandbyTaskRedeliveryInterval, 1*time.Second),
+		StandbyTaskRedeliveryMaxInterval:                    dc.GetDurationProperty(dynamicconfig.StandbyTaskRedeliveryMaxInterval, 30*time.Second),
+		EnableQueueProcessorAutoTuning:                      dc.GetBoolProperty(dynamicconfig.EnableQueueProcessorAutoTuning, false),
+		QueueProcessorAutoTuningInterval:                    dc.GetDurationProperty(dynamicconfig.QueueProcessorAutoTuningInterval, 10*time.Second),
+		QueueProcessorAutoTuningTargetLatency:               dc.GetDurationProperty(dynamicconfig.QueueProcessorAutoTuningTargetLatency, 200*time.Millisecond),
+		QueueProcessorAutoTuningMaxErrorRate:                dc.GetFloat64Property(dynamicconfig.QueueProcessorAutoTuningMaxErrorRate, 0.05),
+		QueueProcessorAutoTuningMaxPollRPS:                  dc.GetIntProperty(dynamicconfig.QueueProcessorAutoTuningMaxPollRPS, 200),
+		QueueProcessorAutoTuningMaxWorkerCount:              dc.GetIntProperty(dynamicconfig.QueueProcessorAutoTuningMaxWorkerCount, 50),
+		ThrottledLogRPS:                                     dc.GetIntProperty(dynamicconfig.ThrottledLogRPS, 20),
+		ThrottledLogSampleRate:                              dc.GetFloat64Property(dynamicconfig.ThrottledLogSampleRate, 1),
+		CloseEventSink:                                      dc.GetMapPropertyFilteredByDomain(dynamicconfig.HistoryCloseEventSink, map[string]interface{}{}),
+		SignalAfterClosePolicy:                              dc.GetStringPropertyFilteredByDomain(dynamicconfig.SignalAfterClosePolicy, signalAfterClosePolicyReject),
+		StickyPoisonFailureThreshold:                        dc.GetIntPropertyFilteredByDomain(dynamicconfig.StickyPoisonFailureThreshold, 10),
+		StickyPoisonWindow:                                  dc.GetDurationProperty(dynamicconfig.StickyPoisonWindow, 10*time.Minute),
 		ExecutionMgrNumConns:                                dc.GetIntProperty(dynamicconfig.ExecutionMgrNumConns, 50),
 		HistoryMgrNumConns:                                  dc.GetIntProperty(dynamicconfig.HistoryMgrNumConns, 50),
+		PersistenceHedgedReadDelay:                          dc.GetDurationProperty(dynamicconfig.HistoryPersistenceHedgedReadDelay, 0),
+		PayloadDedupThreshold:                               dc.GetIntProperty(dynamicconfig.HistoryPayloadDedupThreshold, 0),
+		EnableAppendHistoryFencing:                          dc.GetBoolProperty(dynamicconfig.EnableAppendHistoryFencing, false),
 		MaximumBufferedEventsBatch:                          dc.GetIntProperty(dynamicconfig.MaximumBufferedEventsBatch, 100),
+		MaxGetMutableStatesBatchSize:                        dc.GetIntProperty(dynamicconfig.MaxGetMutableStatesBatchSize, 100),
+		MaximumUpdatesPerExecution:                          dc.GetIntPropertyFilteredByDomain(dynamicconfig.MaximumUpdatesPerExecution, 100),
+		MutableStateSnapshotInterval:                        dc.GetIntProperty(dynamicconfig.MutableStateSnapshotInterval, 50),
+		MaxConcurrentRequests:                               dc.GetMapProperty(dynamicconfig.HistoryMaxConcurrentRequests, map[string]interface{}{}),
+		ConcurrentRequestsWaitTimeout:                       dc.GetDurationProperty(dynamicconfig.HistoryConcurrentRequestsWaitTimeout, time.Second),
 		ShardUpdateMinInterval:                              dc.GetDurationProperty(dynamicconfig.ShardUpdateMinInterval, 5*time.Minute),
 		ShardSyncMinInterval:                                dc.GetDurationProperty(dynamicconfig.ShardSyncMinInterval, 5*time.Minute),
+		ShardOperationLogSize:                               dc.GetIntProperty(dynamicconfig.ShardOperationLogSize, 256),
+		EnableShardRebalance:                                dc.GetBoolProperty(dynamicconfig.EnableShardRebalance, false),
+		ShardRebalanceInterval:                              dc.GetDurationProperty(dynamicconfig.ShardRebalanceInterval, time.Minute),
+		ShardRebalanceMaxMoves:                              dc.GetIntProperty(dynamicconfig.ShardRebalanceMaxMoves, 1),
+		ShardRebalanceMaxHandoffs:                           dc.GetIntProperty(dynamicconfig.ShardRebalanceMaxHandoffs, 4),
+		ShardRebalanceLoadThreshold:                         dc.GetFloat64Property(dynamicconfig.ShardRebalanceLoadThreshold, 0.25),
+		ShardRebalanceCooldown:                              dc.GetDurationProperty(dynamicconfig.ShardRebalanceCooldown, 10*time.Minute),
+		ShardRebalanceCacheWeight:                           dc.GetFloat64Property(dynamicconfig.ShardRebalanceCacheWeight, 0.01),
+		ShardLagEmitInterval:                                dc.GetDurationProperty(dynamicconfig.ShardLagEmitInterval, time.Minute),
+		ShardLagEmitTopK:                                    dc.GetIntProperty(dynamicconfig.ShardLagEmitTopK, 10),
+		GlobalRatelimiterHostTTL:                            dc.GetDurationProperty(dynamicconfig.HistoryGlobalRatelimiterHostTTL, 30*time.Second),
 		// history client: client/history/client.go set the client timeout 30s
 		LongPollExpirationInterval: dc.GetDurationPropertyFilteredByDomain(
 			dynamicconfig.HistoryLongPollExpirationInterval, time.Second*20,
 		),
+		DecisionTimeoutScaleEventsPerSecond: dc.GetIntPropertyFilteredByDomain(
+			dynamicconfig.DecisionTimeoutScaleEventsPerSecond, 0,
+		),
+		MaxScaledDecisionStartToCloseTimeout: dc.GetDurationPropertyFilteredByDomain(
+			dynamicconfig.MaxScaledDecisionStartToCloseTimeout, 10*time.Minute,
+		),
+		ContinueAsNewSuggestedHistorySize: dc.GetIntPropertyFilteredByDomain(
+			dynamicconfig.ContinueAsNewSuggestedHistorySize, 0,
+		),
+		ContinueAsNewSuggestedHistoryCount: dc.GetIntPropertyFilteredByDomain(
+			dynamicconfig.ContinueAsNewSuggestedHistoryCount, 0,
+		),
+		MaxActivityScheduleToStartTimeout: dc.GetDurationPropertyFilteredByTaskListInfo(
+			dynamicconfig.MaxActivityScheduleToStartTimeout, 0,
+		),
+		EnableActivityEagerExecution: dc.GetBoolPropertyFilteredByTaskListInfo(
+			dynamicconfig.EnableActivityEagerExecution, false,
+		),
+		MaxActivityEagerExecutionsPerDecision: dc.GetIntPropertyFilteredByDomain(
+			dynamicconfig.MaxActivityEagerExecutionsPerDecision, 10,
+		),
+		EnableDecisionEagerExecution: dc.GetBoolPropertyFilteredByTaskListInfo(
+			dynamicconfig.EnableDecisionEagerExecution, false,
+		),
+		ActivityHeartbeatPersistInterval: dc.GetDurationPropertyFilteredByDomain(
+			dynamicconfig.HistoryActivityHeartbeatPersistInterval, 10*time.Second,
+		),
 	}
 }
 
@@ -169,6 +358,17 @@ func (config *Config) GetShardID(workflowID string) int {
 	return common.WorkflowIDToHistoryShard(workflowID, config.NumberOfShards)
 }
 
+// newThrottledLogger wraps the logger of a chatty component so its debug and info logs are sampled and
+// rate limited as configured
+func newThrottledLogger(logger bark.Logger, config *Config) bark.Logger {
+	return logging.NewThrottledLogger(
+		logger,
+		config.LogLevel,
+		func() int { return config.ThrottledLogRPS() },
+		func() float64 { return config.ThrottledLogSampleRate() },
+	)
+}
+
 // Service represents the cadence-history service
 type Service struct {
 	stopC         chan struct{}
@@ -177,15 +377,21 @@ type Service struct {
 	metricsClient metrics.Client
 }
 
-// NewService builds a new cadence-history service
-func NewService(params *service.BootstrapParams) common.Daemon {
+// NewService builds a new cadence-history service, the given interceptors are run
+// around the processing of every transfer and timer task of the service
+func NewService(params *service.BootstrapParams, interceptors ...TaskInterceptor) common.Daemon {
+	config := NewConfig(
+		dynamicconfig.NewCollection(params.DynamicConfig, params.Logger),
+		params.CassandraConfig.NumHistoryShards,
+	)
+	config.TaskInterceptors = interceptors
+	if len(params.LogLevel) > 0 {
+		config.LogLevel = svcconfig.ParseLogrusLevel(params.LogLevel)
+	}
 	return &Service{
 		params: params,
 		stopC:  make(chan struct{}),
-		config: NewConfig(
-			dynamicconfig.NewCollection(params.DynamicConfig, params.Logger),
-			params.CassandraConfig.NumHistoryShards,
-		),
+		config: config,
 	}
 }
 
@@ -206,8 +412,7 @@ func (s *Service) Start() {
 
 	shardMgr, err := persistence.NewCassandraShardPersistence(p.CassandraConfig.Hosts,
 		p.CassandraConfig.Port,
-		p.CassandraConfig.User,
-		p.CassandraConfig.Password,
+		p.CassandraCredentials,
 		p.CassandraConfig.Datacenter,
 		p.CassandraConfig.Keyspace,
 		p.ClusterMetadata.GetCurrentClusterName(),
@@ -225,8 +430,7 @@ func (s *Service) Start() {
 
 	metadata, err := persistence.NewMetadataManagerProxy(p.CassandraConfig.Hosts,
 		p.CassandraConfig.Port,
-		p.CassandraConfig.User,
-		p.CassandraConfig.Password,
+		p.CassandraCredentials,
 		p.CassandraConfig.Datacenter,
 		p.CassandraConfig.Keyspace,
 		p.ClusterMetadata.GetCurrentClusterName(),
@@ -240,8 +444,7 @@ func (s *Service) Start() {
 
 	visibility, err := persistence.NewCassandraVisibilityPersistence(p.CassandraConfig.Hosts,
 		p.CassandraConfig.Port,
-		p.CassandraConfig.User,
-		p.CassandraConfig.Password,
+		p.CassandraCredentials,
 		p.CassandraConfig.Datacenter,
 		p.CassandraConfig.VisibilityKeyspace,
 		p.Logger)
@@ -254,8 +457,7 @@ func (s *Service) Start() {
 
 	history, err := persistence.NewCassandraHistoryPersistence(p.CassandraConfig.Hosts,
 		p.CassandraConfig.Port,
-		p.CassandraConfig.User,
-		p.CassandraConfig.Password,
+		p.CassandraCredentials,
 		p.CassandraConfig.Datacenter,
 		p.CassandraConfig.Keyspace,
 		s.config.HistoryMgrNumConns(),
@@ -264,23 +466,47 @@ func (s *Service) Start() {
 	if err != nil {
 		log.Fatalf("Creating Cassandra history manager persistence failed: %v", err)
 	}
+	historyPayload, err := persistence.NewCassandraHistoryPayloadPersistence(p.CassandraConfig.Hosts,
+		p.CassandraConfig.Port,
+		p.CassandraCredentials,
+		p.CassandraConfig.Datacenter,
+		p.CassandraConfig.Keyspace,
+		p.Logger)
+	if err != nil {
+		log.Fatalf("Creating Cassandra history payload persistence failed: %v", err)
+	}
+	history = persistence.NewHistoryPersistencePayloadDedupClient(history, historyPayload, s.config.PayloadDedupThreshold)
 	history = persistence.NewHistoryPersistenceRateLimitedClient(history, persistenceRateLimiter, log)
 	history = persistence.NewHistoryPersistenceMetricsClient(history, base.GetMetricsClient(), log)
+	history = persistence.NewHistoryPersistenceHedgedClient(history, s.config.PersistenceHedgedReadDelay,
+		base.GetMetricsClient())
+
+	postCloseEvents, err := persistence.NewCassandraPostCloseEventsPersistence(p.CassandraConfig.Hosts,
+		p.CassandraConfig.Port,
+		p.CassandraCredentials,
+		p.CassandraConfig.Datacenter,
+		p.CassandraConfig.Keyspace,
+		p.Logger)
+	if err != nil {
+		log.Fatalf("Creating Cassandra post close events persistence failed: %v", err)
+	}
 
 	execMgrFactory, err := persistence.NewCassandraPersistenceClientFactory(p.CassandraConfig.Hosts,
 		p.CassandraConfig.Port,
-		p.CassandraConfig.User,
-		p.CassandraConfig.Password,
+		p.CassandraCredentials,
 		p.CassandraConfig.Datacenter,
 		p.CassandraConfig.Keyspace,
 		s.config.ExecutionMgrNumConns(),
 		p.Logger,
 		persistenceRateLimiter,
-		s.metricsClient,
+		// the execution managers are measured by their shard, which tunes its queue processors from them
+		nil,
 	)
 	if err != nil {
 		log.Fatalf("Creating Cassandra execution manager persistence factory failed: %v", err)
 	}
+	execMgrFactory = persistence.NewHedgedPersistenceClientFactory(execMgrFactory, s.config.PersistenceHedgedReadDelay,
+		s.metricsClient)
 
 	handler := NewHandler(base,
 		s.config,
@@ -288,6 +514,7 @@ func (s *Service) Start() {
 		metadata,
 		visibility,
 		history,
+		postCloseEvents,
 		execMgrFactory)
 
 	handler.Start()
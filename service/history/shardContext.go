@@ -22,6 +22,7 @@ package history
 
 import (
 	"fmt"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -47,6 +48,7 @@ type (
 		GetHistoryManager() persistence.HistoryManager
 		GetDomainCache() cache.DomainCache
 		GetNextTransferTaskID() (int64, error)
+		GetTaskCount() int64
 		GetTransferMaxReadLevel() int64
 		GetTransferAckLevel() int64
 		UpdateTransferAckLevel(ackLevel int64) error
@@ -71,13 +73,17 @@ type (
 		UpdateWorkflowExecution(request *persistence.UpdateWorkflowExecutionRequest) error
 		ResetMutableState(request *persistence.ResetMutableStateRequest) error
 		AppendHistoryEvents(request *persistence.AppendHistoryEventsRequest) error
-		NotifyNewHistoryEvent(event *historyEventNotification) error
+		NotifyNewHistoryEvent(event *HistoryEventNotification) error
 		GetConfig() *Config
 		GetLogger() bark.Logger
 		GetMetricsClient() metrics.Client
 		GetTimeSource() common.TimeSource
 		SetCurrentTime(cluster string, currentTime time.Time)
 		GetCurrentTime(cluster string) time.Time
+		UpdateQueueBacklogAge(scope int, queueName string, age time.Duration)
+		GetQueueBacklogAges() map[string]time.Duration
+		RecordOperation(operation string, details string)
+		GetRecentOperations(maximum int) []ShardOperation
 	}
 
 	shardContextImpl struct {
@@ -101,9 +107,20 @@ type (
 		transferSequenceNumber    int64
 		maxTransferSequenceNumber int64
 		transferMaxReadLevel      int64
+		taskCount                 int64
 
 		// exist only in memory
 		standbyClusterCurrentTime map[string]time.Time
+		queueBacklogs             map[string]queueBacklog
+		backlogMetricsClient      metrics.Client
+		operationLog              *shardOperationLog
+		persistenceLoad           *persistenceLoad
+	}
+
+	// queueBacklog is the age of the oldest unacked task of a task queue, along with the metric scope of the queue
+	queueBacklog struct {
+		scope int
+		age   time.Duration
 	}
 )
 
@@ -141,6 +158,11 @@ func (s *shardContextImpl) GetNextTransferTaskID() (int64, error) {
 	return s.getNextTransferTaskIDLocked()
 }
 
+// GetTaskCount returns the number of task IDs allocated by this shard since it was acquired
+func (s *shardContextImpl) GetTaskCount() int64 {
+	return atomic.LoadInt64(&s.taskCount)
+}
+
 func (s *shardContextImpl) GetTransferMaxReadLevel() int64 {
 	s.RLock()
 	defer s.RUnlock()
@@ -542,14 +564,63 @@ func (s *shardContextImpl) AppendHistoryEvents(request *persistence.AppendHistor
 		if _, ok := err0.(*persistence.ConditionFailedError); ok {
 			// Inserting a new event failed, lets try to overwrite the tail
 			request.Overwrite = true
-			return s.historyMgr.AppendHistoryEvents(request)
+			err0 = s.historyMgr.AppendHistoryEvents(request)
 		}
 	}
 
-	return err0
+	if err0 != nil || !s.config.EnableAppendHistoryFencing() {
+		return err0
+	}
+	return s.fenceAppendedHistoryEvents(request)
+}
+
+// fenceAppendedHistoryEvents verifies the shard is still owned once a batch was appended. Writes to the events table
+// cannot be conditioned on the shard row, so a host which lost the shard could otherwise keep appending batches
+// which no mutable state will reference. A batch appended after the shard was stolen is deleted again.
+func (s *shardContextImpl) fenceAppendedHistoryEvents(request *persistence.AppendHistoryEventsRequest) error {
+	response, err := s.shardManager.GetShard(&persistence.GetShardRequest{ShardID: s.shardID})
+	if err != nil {
+		return err
+	}
+
+	// the range is renewed under the shard lock after it is persisted, so once the lock is acquired a range
+	// renewed by this host is known here
+	s.Lock()
+	defer s.Unlock()
+	if response.ShardInfo.RangeID <= s.getRangeID() {
+		return nil
+	}
+
+	s.metricsClient.IncCounter(metrics.ShardInfoScope, metrics.AppendHistoryEventsFencedCounter)
+	err = s.historyMgr.DeleteHistoryBatch(&persistence.DeleteHistoryBatchRequest{
+		DomainID:      request.DomainID,
+		Execution:     request.Execution,
+		FirstEventID:  request.FirstEventID,
+		RangeID:       request.RangeID,
+		TransactionID: request.TransactionID,
+	})
+	if err != nil {
+		// a batch written again by the new owner is not deleted, any other leftover is overwritten by the
+		// new owner when it appends the same events
+		s.logger.WithFields(bark.Fields{
+			logging.TagDomainID:            request.DomainID,
+			logging.TagWorkflowExecutionID: request.Execution.GetWorkflowId(),
+			logging.TagWorkflowRunID:       request.Execution.GetRunId(),
+			logging.TagFirstEventID:        request.FirstEventID,
+			logging.TagErr:                 err,
+		}).Warn("Failed to delete history events appended after the shard was lost.")
+	}
+
+	// Shard is stolen, trigger shutdown of history engine
+	s.closeShard()
+	return &persistence.ShardOwnershipLostError{
+		ShardID: s.shardID,
+		Msg: fmt.Sprintf("Shard was lost while appending history events, request range %v, current range %v",
+			request.RangeID, response.ShardInfo.RangeID),
+	}
 }
 
-func (s *shardContextImpl) NotifyNewHistoryEvent(event *historyEventNotification) error {
+func (s *shardContextImpl) NotifyNewHistoryEvent(event *HistoryEventNotification) error {
 	// in theory, this function should call persistence layer, such as
 	// Kafka to actually sent out the notification, here, just make this
 	// function do nothing, to we can actually override this function
@@ -597,6 +668,7 @@ func (s *shardContextImpl) getNextTransferTaskIDLocked() (int64, error) {
 
 	taskID := s.transferSequenceNumber
 	s.transferSequenceNumber++
+	atomic.AddInt64(&s.taskCount, 1)
 
 	return taskID, nil
 }
@@ -640,6 +712,7 @@ func (s *shardContextImpl) renewRangeLocked(isStealing bool) error {
 
 	logging.LogShardRangeUpdatedEvent(s.logger, s.shardInfo.ShardID, s.shardInfo.RangeID, s.transferSequenceNumber,
 		s.maxTransferSequenceNumber)
+	s.RecordOperation(shardOperationRangeRenewed, fmt.Sprintf("range: %v, stealing: %v", s.shardInfo.RangeID, isStealing))
 
 	return nil
 }
@@ -763,6 +836,61 @@ func (s *shardContextImpl) GetCurrentTime(cluster string) time.Time {
 	return time.Now()
 }
 
+// UpdateQueueBacklogAge records the age of the oldest unacked task of a task queue of this shard.  The oldest age
+// across the queues reported under the same metric scope is exported as a gauge tagged with the shard.
+func (s *shardContextImpl) UpdateQueueBacklogAge(scope int, queueName string, age time.Duration) {
+	s.Lock()
+	if s.queueBacklogs == nil {
+		s.queueBacklogs = make(map[string]queueBacklog)
+	}
+	s.queueBacklogs[queueName] = queueBacklog{scope: scope, age: age}
+	maxAge := age
+	for _, backlog := range s.queueBacklogs {
+		if backlog.scope == scope && backlog.age > maxAge {
+			maxAge = backlog.age
+		}
+	}
+	if s.backlogMetricsClient == nil {
+		s.backlogMetricsClient = s.metricsClient.Tagged(map[string]string{metrics.ShardTagName: strconv.Itoa(s.shardID)})
+	}
+	metricsClient := s.backlogMetricsClient
+	s.Unlock()
+
+	metricsClient.UpdateGauge(scope, metrics.TaskBacklogAgeGauge, maxAge.Seconds())
+}
+
+// GetQueueBacklogAges returns the age of the oldest unacked task by task queue of this shard
+func (s *shardContextImpl) GetQueueBacklogAges() map[string]time.Duration {
+	s.RLock()
+	defer s.RUnlock()
+	ages := make(map[string]time.Duration, len(s.queueBacklogs))
+	for queueName, backlog := range s.queueBacklogs {
+		ages[queueName] = backlog.age
+	}
+	return ages
+}
+
+// RecordOperation keeps a significant operation of this shard in its in memory operation log
+func (s *shardContextImpl) RecordOperation(operation string, details string) {
+	// shards which were not acquired through acquireShard do not keep an operation log
+	if s.operationLog == nil {
+		return
+	}
+	s.operationLog.record(time.Now(), operation, details)
+}
+
+// GetRecentOperations returns up to maximum of the most recent operations of this shard, most recent first
+func (s *shardContextImpl) GetRecentOperations(maximum int) []ShardOperation {
+	if s.operationLog == nil {
+		return nil
+	}
+	return s.operationLog.recent(maximum)
+}
+
+func (s *shardContextImpl) getPersistenceLoad() *persistenceLoad {
+	return s.persistenceLoad
+}
+
 // TODO: This method has too many parameters.  Clean it up.  Maybe create a struct to pass in as parameter.
 func acquireShard(shardID int, svc service.Service, shardManager persistence.ShardManager,
 	historyMgr persistence.HistoryManager, executionMgr persistence.ExecutionManager, domainCache cache.DomainCache,
@@ -789,6 +917,11 @@ func acquireShard(shardID int, svc service.Service, shardManager persistence.Sha
 		}
 	}
 
+	// the execution manager is measured per shard, the queue processors of the shard are tuned from its load
+	load := &persistenceLoad{}
+	executionMgr = persistence.NewWorkflowExecutionPersistenceMetricsClient(executionMgr,
+		newPersistenceLoadMetricsClient(metricsClient, load), logger)
+
 	context := &shardContextImpl{
 		shardID:          shardID,
 		currentCluster:   svc.GetClusterMetadata().GetCurrentClusterName(),
@@ -802,6 +935,8 @@ func acquireShard(shardID int, svc service.Service, shardManager persistence.Sha
 		metricsClient:    metricsClient,
 		config:           config,
 		standbyClusterCurrentTime: standbyClusterCurrentTime,
+		operationLog:              newShardOperationLog(config.ShardOperationLogSize()),
+		persistenceLoad:           load,
 	}
 	context.logger = logger.WithFields(bark.Fields{
 		logging.TagHistoryShardID: shardID,
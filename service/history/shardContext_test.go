@@ -0,0 +1,133 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	shardContextSuite struct {
+		suite.Suite
+		mockShardManager *mocks.ShardManager
+		mockHistoryMgr   *mocks.HistoryManager
+		shardClosedCh    chan int
+		shard            *shardContextImpl
+	}
+)
+
+func TestShardContextSuite(t *testing.T) {
+	s := new(shardContextSuite)
+	suite.Run(t, s)
+}
+
+func (s *shardContextSuite) SetupTest() {
+	s.mockShardManager = &mocks.ShardManager{}
+	s.mockHistoryMgr = &mocks.HistoryManager{}
+	s.shardClosedCh = make(chan int, 1)
+
+	config := NewConfig(dynamicconfig.NewNopCollection(), 1)
+	config.EnableAppendHistoryFencing = dynamicconfig.GetBoolPropertyFn(true)
+	s.shard = &shardContextImpl{
+		shardID:       1,
+		rangeID:       5,
+		shardInfo:     &persistence.ShardInfo{ShardID: 1, RangeID: 5},
+		shardManager:  s.mockShardManager,
+		historyMgr:    s.mockHistoryMgr,
+		closeCh:       s.shardClosedCh,
+		config:        config,
+		logger:        bark.NewNopLogger(),
+		metricsClient: metrics.NewClient(tally.NoopScope, metrics.History),
+	}
+}
+
+func (s *shardContextSuite) TearDownTest() {
+	s.mockShardManager.AssertExpectations(s.T())
+	s.mockHistoryMgr.AssertExpectations(s.T())
+}
+
+func (s *shardContextSuite) appendRequest() *persistence.AppendHistoryEventsRequest {
+	return &persistence.AppendHistoryEventsRequest{
+		DomainID: "domain-id",
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr("wid"),
+			RunId:      common.StringPtr("rid"),
+		},
+		FirstEventID:  3,
+		TransactionID: 12,
+		Events:        persistence.NewSerializedHistoryEventBatch([]byte("events"), common.EncodingTypeJSON, 1),
+	}
+}
+
+func (s *shardContextSuite) TestAppendHistoryEventsShardOwned() {
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
+	// a range renewed by this host is persisted before it is known in memory
+	s.mockShardManager.On("GetShard", &persistence.GetShardRequest{ShardID: 1}).Return(
+		&persistence.GetShardResponse{ShardInfo: &persistence.ShardInfo{ShardID: 1, RangeID: 5}}, nil).Once()
+
+	s.NoError(s.shard.AppendHistoryEvents(s.appendRequest()))
+	s.False(s.shard.isClosed)
+}
+
+func (s *shardContextSuite) TestAppendHistoryEventsShardStolen() {
+	request := s.appendRequest()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
+	s.mockShardManager.On("GetShard", mock.Anything).Return(
+		&persistence.GetShardResponse{ShardInfo: &persistence.ShardInfo{ShardID: 1, RangeID: 6}}, nil).Once()
+	s.mockHistoryMgr.On("DeleteHistoryBatch", &persistence.DeleteHistoryBatchRequest{
+		DomainID:      request.DomainID,
+		Execution:     request.Execution,
+		FirstEventID:  3,
+		RangeID:       5,
+		TransactionID: 12,
+	}).Return(nil).Once()
+
+	err := s.shard.AppendHistoryEvents(request)
+	s.IsType(&persistence.ShardOwnershipLostError{}, err)
+	s.True(s.shard.isClosed)
+	s.Equal(1, <-s.shardClosedCh)
+}
+
+func (s *shardContextSuite) TestAppendHistoryEventsFencingDisabled() {
+	s.shard.config.EnableAppendHistoryFencing = dynamicconfig.GetBoolPropertyFn(false)
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil).Once()
+
+	s.NoError(s.shard.AppendHistoryEvents(s.appendRequest()))
+}
+
+func (s *shardContextSuite) TestAppendHistoryEventsFailedNotFenced() {
+	appendErr := errors.New("append failed")
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(appendErr).Once()
+
+	s.Equal(appendErr, s.shard.AppendHistoryEvents(s.appendRequest()))
+}
@@ -61,6 +61,8 @@ type (
 		logger              bark.Logger
 		config              *Config
 		metricsClient       metrics.Client
+		rebalancer          *shardRebalancer
+		lagEmitter          *shardLagEmitter
 
 		sync.RWMutex
 		historyShards map[int]*historyShardsItem
@@ -82,6 +84,18 @@ type (
 		logger        bark.Logger
 		metricsClient metrics.Client
 	}
+
+	// shardBacklogReporter is implemented by engines which are able to report the backlog of the task queues of the
+	// shard they own
+	shardBacklogReporter interface {
+		getQueueBacklogAges() map[string]time.Duration
+	}
+
+	// shardOperationReporter is implemented by engines which are able to report the recent operations of the shard
+	// they own
+	shardOperationReporter interface {
+		getRecentShardOperations(maximum int) []ShardOperation
+	}
 )
 
 func newShardController(svc service.Service, host *membership.HostInfo, resolver membership.ServiceResolver,
@@ -91,7 +105,7 @@ func newShardController(svc service.Service, host *membership.HostInfo, resolver
 	logger = logger.WithFields(bark.Fields{
 		logging.TagWorkflowComponent: logging.TagValueShardController,
 	})
-	return &shardController{
+	controller := &shardController{
 		service:             svc,
 		host:                host,
 		hServiceResolver:    resolver,
@@ -108,6 +122,9 @@ func newShardController(svc service.Service, host *membership.HostInfo, resolver
 		config:              config,
 		metricsClient:       metricsClient,
 	}
+	controller.rebalancer = newShardRebalancer(controller)
+	controller.lagEmitter = newShardLagEmitter(controller)
+	return controller
 }
 
 func newHistoryShardsItem(shardID int, svc service.Service, shardMgr persistence.ShardManager,
@@ -216,7 +233,7 @@ func (c *shardController) getOrCreateHistoryShardItem(shardID int) (*historyShar
 	if c.isStopping {
 		return nil, fmt.Errorf("shardController for host '%v' shutting down", c.host.Identity())
 	}
-	info, err := c.hServiceResolver.Lookup(string(shardID))
+	info, err := c.lookupShardOwner(shardID)
 	if err != nil {
 		return nil, err
 	}
@@ -260,6 +277,7 @@ func (c *shardController) removeHistoryShardItem(shardID int) (*historyShardsIte
 //   a. Ring membership change
 //   b. Periodic ticker
 //   c. ShardOwnershipLostError and subsequent ShardClosedEvents from engine
+//   d. Shard handoffs decided by the shard rebalancer
 func (c *shardController) shardManagementPump() {
 
 	defer c.shutdownWG.Done()
@@ -267,6 +285,13 @@ func (c *shardController) shardManagementPump() {
 	acquireTicker := time.NewTicker(c.config.AcquireShardInterval())
 	defer acquireTicker.Stop()
 
+	rebalanceTicker := time.NewTicker(c.config.ShardRebalanceInterval())
+	defer rebalanceTicker.Stop()
+
+	// the emit interval is read again on every tick so that it follows the dynamic config
+	lagTimer := time.NewTimer(c.config.ShardLagEmitInterval())
+	defer lagTimer.Stop()
+
 	for {
 
 		select {
@@ -275,6 +300,13 @@ func (c *shardController) shardManagementPump() {
 			return
 		case <-acquireTicker.C:
 			c.acquireShards()
+		case <-rebalanceTicker.C:
+			if c.rebalancer.rebalance() {
+				c.acquireShards()
+			}
+		case <-lagTimer.C:
+			c.lagEmitter.emit()
+			lagTimer.Reset(c.config.ShardLagEmitInterval())
 		case changedEvent := <-c.membershipUpdateCh:
 			c.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.MembershipChangedCounter)
 			logging.LogRingMembershipChangedEvent(c.logger, c.host.Identity(), len(changedEvent.HostsAdded),
@@ -283,6 +315,7 @@ func (c *shardController) shardManagementPump() {
 		case shardID := <-c.shardClosedCh:
 			c.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.ShardClosedCounter)
 			logging.LogShardClosedEvent(c.logger, c.host.Identity(), shardID)
+			c.rebalancer.onShardClosed(shardID)
 			c.removeEngineForShard(shardID)
 			// The async close notifications can cause a race
 			// between acquire/release when nodes are flapping
@@ -304,7 +337,7 @@ func (c *shardController) acquireShards() {
 
 AcquireLoop:
 	for shardID := 0; shardID < c.config.NumberOfShards; shardID++ {
-		info, err := c.hServiceResolver.Lookup(string(shardID))
+		info, err := c.lookupShardOwner(shardID)
 		if err != nil {
 			logging.LogOperationFailedEvent(c.logger, fmt.Sprintf("Error looking up host for shardID: %v", shardID), err)
 			continue AcquireLoop
@@ -324,6 +357,21 @@ AcquireLoop:
 	}
 
 	c.metricsClient.UpdateGauge(metrics.HistoryShardControllerScope, metrics.NumShardsGauge, float64(c.numShards()))
+	c.rebalancer.confirmHandoffs()
+}
+
+// lookupShardOwner returns the host owning the shard, which is the owner on the consistent hash ring unless the
+// ring owner handed the shard off to another host
+func (c *shardController) lookupShardOwner(shardID int) (*membership.HostInfo, error) {
+	info, err := c.hServiceResolver.Lookup(string(shardID))
+	if err != nil {
+		return nil, err
+	}
+
+	if target, ok := c.rebalancer.getHandoffTarget(shardID, info); ok {
+		return target, nil
+	}
+	return info, nil
 }
 
 func (c *shardController) doShutdown() {
@@ -342,6 +390,7 @@ func (c *shardController) processShardClosedEvents() {
 		case shardID := <-c.shardClosedCh:
 			c.metricsClient.IncCounter(metrics.HistoryShardControllerScope, metrics.ShardClosedCounter)
 			logging.LogShardClosedEvent(c.logger, c.host.Identity(), shardID)
+			c.rebalancer.onShardClosed(shardID)
 			c.removeEngineForShard(shardID)
 		default:
 			return
@@ -368,6 +417,21 @@ func (c *shardController) shardIDs() []int32 {
 	return ids
 }
 
+// shardBacklogs returns the backlog ages of the task queues by shard, for the shards owned by this host
+func (c *shardController) shardBacklogs() map[int]map[string]time.Duration {
+	c.RLock()
+	defer c.RUnlock()
+	backlogs := make(map[int]map[string]time.Duration)
+	for shardID, item := range c.historyShards {
+		reporter, ok := item.getEngine().(shardBacklogReporter)
+		if !ok {
+			continue
+		}
+		backlogs[shardID] = reporter.getQueueBacklogAges()
+	}
+	return backlogs
+}
+
 func (i *historyShardsItem) getEngine() Engine {
 	i.RLock()
 	defer i.RUnlock()
@@ -0,0 +1,202 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/uber/cadence/common/metrics"
+)
+
+type (
+	// shardAckLevels is the progress of the task queues of a single shard
+	shardAckLevels struct {
+		transferMaxReadLevel int64
+		transferAckLevel     int64
+		timerAckLevel        time.Time
+		// replicatorAckLevel is only set when replication is enabled on the cluster
+		replicationEnabled bool
+		replicatorAckLevel int64
+	}
+
+	// shardAckLevelReporter is implemented by engines which are able to report the ack levels of the task queues of
+	// the shard they own
+	shardAckLevelReporter interface {
+		getShardAckLevels() shardAckLevels
+	}
+
+	// shardQueueLag is the ack level and the estimated lag of one task queue of a shard
+	shardQueueLag struct {
+		shardID  int
+		ackLevel float64
+		lag      float64
+	}
+
+	// shardQueueGauges are the gauges a task queue emits its ack level and lag to, and the gauge of the maximum lag
+	// of the task queue for the host
+	shardQueueGauges struct {
+		ackLevel int
+		lag      int
+		maxLag   int
+	}
+
+	// shardLagEmitter periodically emits the ack levels and the estimated lags of the task queues of the shards owned
+	// by this host.  Tagging every gauge with the shard does not scale with the number of shards, so only the shards
+	// with the highest lag of each task queue are emitted with a shard tag, while the maximum lag of each task queue
+	// is always emitted for the host to its own gauge, which does not mix with the shard gauges when aggregated.  A shard which drops out of the top is emitted once more with a zero lag, so
+	// the panels do not keep showing its last lag.
+	shardLagEmitter struct {
+		controller    *shardController
+		config        *Config
+		metricsClient metrics.Client
+		timeSource    func() time.Time
+
+		// emitted is the shards emitted with a shard tag in the last round, by task queue
+		emitted      map[shardQueueGauges]map[int]struct{}
+		shardClients map[int]metrics.Client
+	}
+)
+
+var (
+	transferQueueGauges = shardQueueGauges{
+		ackLevel: metrics.ShardTransferAckLevelGauge,
+		lag:      metrics.ShardTransferLagGauge,
+		maxLag:   metrics.ShardTransferMaxLagGauge,
+	}
+	timerQueueGauges = shardQueueGauges{
+		ackLevel: metrics.ShardTimerAckLevelGauge,
+		lag:      metrics.ShardTimerLagGauge,
+		maxLag:   metrics.ShardTimerMaxLagGauge,
+	}
+	replicationQueueGauges = shardQueueGauges{
+		ackLevel: metrics.ShardReplicationAckLevelGauge,
+		lag:      metrics.ShardReplicationLagGauge,
+		maxLag:   metrics.ShardReplicationMaxLagGauge,
+	}
+)
+
+func newShardLagEmitter(controller *shardController) *shardLagEmitter {
+	return &shardLagEmitter{
+		controller:    controller,
+		config:        controller.config,
+		metricsClient: controller.metricsClient,
+		timeSource:    time.Now,
+		emitted:       make(map[shardQueueGauges]map[int]struct{}),
+		shardClients:  make(map[int]metrics.Client),
+	}
+}
+
+// emit emits the ack levels and lags of the task queues of the shards owned by this host
+func (e *shardLagEmitter) emit() {
+	now := e.timeSource()
+	var transferLags, timerLags, replicationLags []shardQueueLag
+	for shardID, levels := range e.getShardAckLevels() {
+		transferLags = append(transferLags, shardQueueLag{
+			shardID:  shardID,
+			ackLevel: float64(levels.transferAckLevel),
+			lag:      taskLag(levels.transferMaxReadLevel, levels.transferAckLevel),
+		})
+		timerLag := time.Duration(0)
+		if now.After(levels.timerAckLevel) {
+			timerLag = now.Sub(levels.timerAckLevel)
+		}
+		timerLags = append(timerLags, shardQueueLag{
+			shardID:  shardID,
+			ackLevel: float64(levels.timerAckLevel.Unix()),
+			lag:      timerLag.Seconds(),
+		})
+		if levels.replicationEnabled {
+			replicationLags = append(replicationLags, shardQueueLag{
+				shardID:  shardID,
+				ackLevel: float64(levels.replicatorAckLevel),
+				lag:      taskLag(levels.transferMaxReadLevel, levels.replicatorAckLevel),
+			})
+		}
+	}
+
+	topK := e.config.ShardLagEmitTopK()
+	e.emitQueue(transferQueueGauges, transferLags, topK)
+	e.emitQueue(timerQueueGauges, timerLags, topK)
+	e.emitQueue(replicationQueueGauges, replicationLags, topK)
+}
+
+func (e *shardLagEmitter) emitQueue(gauges shardQueueGauges, lags []shardQueueLag, topK int) {
+	sort.Slice(lags, func(i, j int) bool {
+		if lags[i].lag != lags[j].lag {
+			return lags[i].lag > lags[j].lag
+		}
+		return lags[i].shardID < lags[j].shardID
+	})
+
+	if len(lags) > 0 {
+		e.metricsClient.UpdateGauge(metrics.ShardInfoScope, gauges.maxLag, lags[0].lag)
+	}
+
+	if topK > 0 && len(lags) > topK {
+		lags = lags[:topK]
+	}
+	emitted := make(map[int]struct{}, len(lags))
+	for _, lag := range lags {
+		metricsClient := e.shardMetricsClient(lag.shardID)
+		metricsClient.UpdateGauge(metrics.ShardInfoScope, gauges.ackLevel, lag.ackLevel)
+		metricsClient.UpdateGauge(metrics.ShardInfoScope, gauges.lag, lag.lag)
+		emitted[lag.shardID] = struct{}{}
+	}
+	for shardID := range e.emitted[gauges] {
+		if _, ok := emitted[shardID]; !ok {
+			e.shardMetricsClient(shardID).UpdateGauge(metrics.ShardInfoScope, gauges.lag, 0)
+		}
+	}
+	e.emitted[gauges] = emitted
+}
+
+// taskLag estimates the number of tasks between the ack level of a task queue and the tasks allocated by the shard
+func taskLag(maxReadLevel int64, ackLevel int64) float64 {
+	if maxReadLevel < ackLevel {
+		return 0
+	}
+	return float64(maxReadLevel - ackLevel)
+}
+
+func (e *shardLagEmitter) shardMetricsClient(shardID int) metrics.Client {
+	if metricsClient, ok := e.shardClients[shardID]; ok {
+		return metricsClient
+	}
+	metricsClient := e.metricsClient.Tagged(map[string]string{metrics.ShardTagName: strconv.Itoa(shardID)})
+	e.shardClients[shardID] = metricsClient
+	return metricsClient
+}
+
+func (e *shardLagEmitter) getShardAckLevels() map[int]shardAckLevels {
+	levels := make(map[int]shardAckLevels)
+	e.controller.RLock()
+	defer e.controller.RUnlock()
+	for shardID, item := range e.controller.historyShards {
+		reporter, ok := item.getEngine().(shardAckLevelReporter)
+		if !ok {
+			continue
+		}
+		levels[shardID] = reporter.getShardAckLevels()
+	}
+	return levels
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	shardLagEmitterSuite struct {
+		suite.Suite
+		now        time.Time
+		scope      tally.TestScope
+		config     *Config
+		controller *shardController
+		emitter    *shardLagEmitter
+	}
+
+	// shardLagTestEngine reports fixed ack levels for the shard it owns
+	shardLagTestEngine struct {
+		*MockHistoryEngine
+		levels shardAckLevels
+	}
+)
+
+func TestShardLagEmitterSuite(t *testing.T) {
+	s := new(shardLagEmitterSuite)
+	suite.Run(t, s)
+}
+
+func (e *shardLagTestEngine) getShardAckLevels() shardAckLevels {
+	return e.levels
+}
+
+func (s *shardLagEmitterSuite) SetupTest() {
+	s.now = time.Now()
+	s.scope = tally.NewTestScope("", nil)
+	s.config = NewConfig(dynamicconfig.NewNopCollection(), 4)
+	s.config.ShardLagEmitTopK = dynamicconfig.GetIntPropertyFn(2)
+	s.controller = &shardController{
+		config:        s.config,
+		metricsClient: metrics.NewClient(s.scope, metrics.History),
+		historyShards: make(map[int]*historyShardsItem),
+	}
+	s.emitter = newShardLagEmitter(s.controller)
+	s.emitter.timeSource = func() time.Time { return s.now }
+}
+
+func (s *shardLagEmitterSuite) setShard(shardID int, transferLag int64, timerLag time.Duration) {
+	s.controller.historyShards[shardID] = &historyShardsItem{
+		shardID: shardID,
+		engine: &shardLagTestEngine{
+			MockHistoryEngine: &MockHistoryEngine{},
+			levels: shardAckLevels{
+				transferMaxReadLevel: 1000 + transferLag,
+				transferAckLevel:     1000,
+				timerAckLevel:        s.now.Add(-timerLag),
+			},
+		},
+	}
+}
+
+// gauge returns the value of a gauge emitted for a shard, an empty shard is the gauge emitted for the host
+func (s *shardLagEmitterSuite) gauge(name string, shard string) (float64, bool) {
+	for _, gauge := range s.scope.Snapshot().Gauges() {
+		if gauge.Name() == name && gauge.Tags()[metrics.ShardTagName] == shard {
+			return gauge.Value(), true
+		}
+	}
+	return 0, false
+}
+
+func (s *shardLagEmitterSuite) TestEmitTopK() {
+	s.setShard(0, 10, time.Second)
+	s.setShard(1, 50, time.Minute)
+	s.setShard(2, 30, time.Hour)
+
+	s.emitter.emit()
+
+	value, ok := s.gauge("shard-transfer-max-lag", "")
+	s.True(ok)
+	s.Equal(float64(50), value)
+	// the host maximum does not go to the gauge of the shards
+	_, ok = s.gauge("shard-transfer-lag", "")
+	s.False(ok)
+	value, ok = s.gauge("shard-transfer-lag", "1")
+	s.True(ok)
+	s.Equal(float64(50), value)
+	value, ok = s.gauge("shard-transfer-lag", "2")
+	s.True(ok)
+	s.Equal(float64(30), value)
+	_, ok = s.gauge("shard-transfer-lag", "0")
+	s.False(ok)
+	value, ok = s.gauge("shard-transfer-ack-level", "1")
+	s.True(ok)
+	s.Equal(float64(1000), value)
+
+	value, ok = s.gauge("shard-timer-max-lag", "")
+	s.True(ok)
+	s.Equal(time.Hour.Seconds(), value)
+	value, ok = s.gauge("shard-timer-ack-level", "2")
+	s.True(ok)
+	s.Equal(float64(s.now.Add(-time.Hour).Unix()), value)
+	_, ok = s.gauge("shard-timer-lag", "0")
+	s.False(ok)
+
+	// replication is not enabled on the shards
+	_, ok = s.gauge("shard-replication-max-lag", "")
+	s.False(ok)
+}
+
+func (s *shardLagEmitterSuite) TestEmitResetsShardDroppedOutOfTopK() {
+	s.setShard(0, 10, 0)
+	s.setShard(1, 50, 0)
+	s.setShard(2, 30, 0)
+	s.emitter.emit()
+
+	s.setShard(0, 100, 0)
+	s.emitter.emit()
+
+	value, ok := s.gauge("shard-transfer-lag", "0")
+	s.True(ok)
+	s.Equal(float64(100), value)
+	value, ok = s.gauge("shard-transfer-lag", "2")
+	s.True(ok)
+	s.Equal(float64(0), value)
+	value, ok = s.gauge("shard-transfer-lag", "1")
+	s.True(ok)
+	s.Equal(float64(50), value)
+}
+
+func (s *shardLagEmitterSuite) TestEmitAllShards() {
+	s.config.ShardLagEmitTopK = dynamicconfig.GetIntPropertyFn(0)
+	s.setShard(0, 10, 0)
+	s.setShard(1, 50, 0)
+	s.setShard(2, 30, 0)
+
+	s.emitter.emit()
+
+	for _, shard := range []string{"0", "1", "2"} {
+		_, ok := s.gauge("shard-transfer-lag", shard)
+		s.True(ok)
+	}
+}
+
+func (s *shardLagEmitterSuite) TestTaskLag() {
+	s.Equal(float64(5), taskLag(15, 10))
+	s.Equal(float64(0), taskLag(10, 15))
+}
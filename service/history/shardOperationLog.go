@@ -0,0 +1,115 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/common/persistence"
+)
+
+// significant operations recorded by a shard
+const (
+	shardOperationTaskProcessed     = "task-processed"
+	shardOperationAckLevelUpdated   = "ack-level-updated"
+	shardOperationConflictResolved  = "conflict-resolved"
+	shardOperationRangeRenewed      = "range-renewed"
+	shardOperationFailoverCompleted = "failover-completed"
+)
+
+type (
+	// ShardOperation is a significant operation of a shard, kept in memory for troubleshooting
+	ShardOperation struct {
+		timestamp time.Time
+		operation string
+		details   string
+	}
+
+	// shardOperationLog is a fixed size ring buffer of the most recent operations of a shard, the oldest operation
+	// is overwritten once the buffer is full
+	shardOperationLog struct {
+		sync.Mutex
+		operations []ShardOperation
+		next       int
+		count      int
+	}
+)
+
+func newShardOperationLog(size int) *shardOperationLog {
+	if size < 0 {
+		size = 0
+	}
+	return &shardOperationLog{
+		operations: make([]ShardOperation, size),
+	}
+}
+
+func (l *shardOperationLog) record(timestamp time.Time, operation string, details string) {
+	l.Lock()
+	defer l.Unlock()
+
+	if len(l.operations) == 0 {
+		return
+	}
+	l.operations[l.next] = ShardOperation{
+		timestamp: timestamp,
+		operation: operation,
+		details:   details,
+	}
+	l.next = (l.next + 1) % len(l.operations)
+	if l.count < len(l.operations) {
+		l.count++
+	}
+}
+
+// recent returns up to maximum operations, most recent first, all recorded operations are returned if maximum is
+// not positive
+func (l *shardOperationLog) recent(maximum int) []ShardOperation {
+	l.Lock()
+	defer l.Unlock()
+
+	count := l.count
+	if maximum > 0 && maximum < count {
+		count = maximum
+	}
+	operations := make([]ShardOperation, 0, count)
+	for i := 1; i <= count; i++ {
+		index := (l.next - i + len(l.operations)) % len(l.operations)
+		operations = append(operations, l.operations[index])
+	}
+	return operations
+}
+
+// describeProcessedTask returns the details recorded for a task which got processed by a task queue of a shard
+func describeProcessedTask(task queueTaskInfo, attempts int) string {
+	var queue string
+	switch task.(type) {
+	case *persistence.TransferTaskInfo:
+		queue = "transfer"
+	case *persistence.TimerTaskInfo:
+		queue = "timer"
+	case *persistence.ReplicationTaskInfo:
+		queue = "replication"
+	}
+	return fmt.Sprintf("queue: %v, task: %v, type: %v, attempts: %v", queue, task.GetTaskID(), task.GetTaskType(), attempts)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	shardOperationLogSuite struct {
+		suite.Suite
+		now time.Time
+	}
+)
+
+func TestShardOperationLogSuite(t *testing.T) {
+	s := new(shardOperationLogSuite)
+	suite.Run(t, s)
+}
+
+func (s *shardOperationLogSuite) SetupTest() {
+	s.now = time.Now()
+}
+
+func (s *shardOperationLogSuite) record(log *shardOperationLog, count int) {
+	for i := 0; i < count; i++ {
+		log.record(s.now.Add(time.Duration(i)*time.Second), shardOperationTaskProcessed, strconv.Itoa(i))
+	}
+}
+
+func (s *shardOperationLogSuite) details(operations []ShardOperation) []string {
+	var details []string
+	for _, operation := range operations {
+		details = append(details, operation.details)
+	}
+	return details
+}
+
+func (s *shardOperationLogSuite) TestRecentBeforeFull() {
+	log := newShardOperationLog(4)
+	s.Empty(log.recent(0))
+
+	s.record(log, 3)
+	operations := log.recent(0)
+	s.Equal([]string{"2", "1", "0"}, s.details(operations))
+	s.Equal(s.now.Add(2*time.Second), operations[0].timestamp)
+	s.Equal(shardOperationTaskProcessed, operations[0].operation)
+}
+
+func (s *shardOperationLogSuite) TestOldestOverwrittenOnceFull() {
+	log := newShardOperationLog(4)
+	s.record(log, 10)
+
+	s.Equal([]string{"9", "8", "7", "6"}, s.details(log.recent(0)))
+	s.Equal([]string{"9", "8"}, s.details(log.recent(2)))
+	s.Equal([]string{"9", "8", "7", "6"}, s.details(log.recent(100)))
+}
+
+func (s *shardOperationLogSuite) TestDisabled() {
+	log := newShardOperationLog(0)
+	s.record(log, 3)
+	s.Empty(log.recent(0))
+}
+
+func (s *shardOperationLogSuite) TestDescribeProcessedTask() {
+	s.Equal("queue: timer, task: 12, type: 3, attempts: 2",
+		describeProcessedTask(&persistence.TimerTaskInfo{TaskID: 12, TaskType: 3}, 2))
+	s.Equal("queue: transfer, task: 5, type: 1, attempts: 1",
+		describeProcessedTask(&persistence.TransferTaskInfo{TaskID: 5, TaskType: 1}, 1))
+}
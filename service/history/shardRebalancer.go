@@ -0,0 +1,548 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+)
+
+const (
+	// shardLoadLabel is the membership label carrying the load of a history host
+	shardLoadLabel = "shardLoad"
+	// shardHandoffLabel is the membership label carrying the shards handed off by a history host,
+	// encoded as a comma separated list of shardID=hostAddress
+	shardHandoffLabel = "shardHandoff"
+	// shardAcquiredLabel is the membership label carrying the shards a history host acquired after they were
+	// handed off to it, encoded as a comma separated list of shardIDs
+	shardAcquiredLabel = "shardAcquired"
+
+	// shardHandoffConfirmRounds is the number of rebalance rounds the target of a handoff has to acquire the shard,
+	// the handoff is cancelled after that
+	shardHandoffConfirmRounds = 3
+)
+
+type (
+	// shardLoad is the raw load counters of a single shard
+	shardLoad struct {
+		taskCount int64
+		cacheSize int
+	}
+
+	// shardLoadReporter is implemented by engines which are able to report the load of the shard they own
+	shardLoadReporter interface {
+		getShardLoad() shardLoad
+	}
+
+	shardHandoff struct {
+		target   *membership.HostInfo
+		handedAt time.Time
+		// confirmed is set once the target acquired the shard, until then this host keeps running it
+		confirmed bool
+	}
+
+	shardPlacement struct {
+		ringOwner string
+		target    *membership.HostInfo
+	}
+
+	// shardRebalancer moves shards away from history hosts which carry noticeably more load than the average
+	// host.  Placement still starts from the consistent hash ring: the ring owner of a shard may hand it off to
+	// a colder host by advertising the handoff through its membership labels.  The target acquires the shard
+	// and confirms it through its own labels, the ring owner keeps running the shard until then.  All history
+	// hosts honor the confirmed handoffs when resolving shard ownership, and requests still routed to the ring
+	// owner get redirected by the ShardOwnershipLostError it returns.  A handoff disappears together with the
+	// ring owner, which makes the shard fall back to plain consistent hash placement.
+	shardRebalancer struct {
+		controller    *shardController
+		host          *membership.HostInfo
+		resolver      membership.ServiceResolver
+		config        *Config
+		logger        bark.Logger
+		metricsClient metrics.Client
+
+		sync.RWMutex
+		handoffs      map[int]*shardHandoff
+		placement     map[int]*shardPlacement
+		lastTaskCount map[int]int64
+		lastRebalance time.Time
+		published     string
+		acquired      string
+	}
+)
+
+func newShardRebalancer(controller *shardController) *shardRebalancer {
+	return &shardRebalancer{
+		controller:    controller,
+		host:          controller.host,
+		resolver:      controller.hServiceResolver,
+		config:        controller.config,
+		logger:        controller.logger,
+		metricsClient: controller.metricsClient,
+		handoffs:      make(map[int]*shardHandoff),
+		placement:     make(map[int]*shardPlacement),
+		lastTaskCount: make(map[int]int64),
+	}
+}
+
+// getHandoffTarget returns the host a shard was handed off to by its ring owner, if any
+func (r *shardRebalancer) getHandoffTarget(shardID int, ringOwner *membership.HostInfo) (*membership.HostInfo, bool) {
+	if !r.config.EnableShardRebalance() {
+		return nil, false
+	}
+
+	r.RLock()
+	defer r.RUnlock()
+	if p, ok := r.placement[shardID]; ok && p.ringOwner == ringOwner.Identity() {
+		return p.target, true
+	}
+	return nil, false
+}
+
+// rebalance evaluates the load of this host against its peers and hands off or reclaims shards accordingly.
+// It returns true if the ownership of any shard changed as seen by this host.
+func (r *shardRebalancer) rebalance() bool {
+	if !r.config.EnableShardRebalance() {
+		r.reset()
+		return false
+	}
+
+	sw := r.metricsClient.StartTimer(metrics.HistoryShardRebalancerScope, metrics.ShardRebalanceLatency)
+	defer sw.Stop()
+
+	members, err := r.resolver.Members()
+	if err != nil {
+		logging.LogOperationFailedEvent(r.logger, "Error listing history hosts for shard rebalance", err)
+		return false
+	}
+
+	shardLoads := r.getShardLoads()
+	hostLoad := 0.0
+	for _, load := range shardLoads {
+		hostLoad += load
+	}
+	r.metricsClient.UpdateGauge(metrics.HistoryShardRebalancerScope, metrics.ShardRebalanceHostLoadGauge, hostLoad)
+	owned := r.getOwnedShards()
+
+	r.Lock()
+	defer r.Unlock()
+	changed := r.refreshPlacementLocked(members)
+	// a shard handed off in this round keeps running here until its target confirms the handoff
+	r.handoffShardsLocked(members, shardLoads, hostLoad)
+	if r.reclaimShardsLocked(members, hostLoad) {
+		changed = true
+	}
+	r.publishLocked(hostLoad)
+	r.publishAcquiredLocked(owned)
+	return changed
+}
+
+// confirmHandoffs lets the ring owners know which of the shards handed off to this host it acquired
+func (r *shardRebalancer) confirmHandoffs() {
+	if !r.config.EnableShardRebalance() {
+		return
+	}
+
+	owned := r.getOwnedShards()
+	r.Lock()
+	defer r.Unlock()
+	r.publishAcquiredLocked(owned)
+}
+
+// onShardClosed is called when the engine of a shard is closed after losing the ownership of the shard.  If the
+// shard is being handed off, the target acquired it and the handoff is confirmed, so the shard is not acquired
+// back by this host.
+func (r *shardRebalancer) onShardClosed(shardID int) {
+	r.Lock()
+	defer r.Unlock()
+	if handoff, ok := r.handoffs[shardID]; ok && !handoff.confirmed {
+		r.confirmHandoffLocked(shardID, handoff)
+		r.placement[shardID] = &shardPlacement{ringOwner: r.host.Identity(), target: handoff.target}
+	}
+}
+
+func (r *shardRebalancer) reset() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.placement = make(map[int]*shardPlacement)
+	r.lastTaskCount = make(map[int]int64)
+	r.lastRebalance = time.Time{}
+	if len(r.handoffs) == 0 && r.published == "" && r.acquired == "" {
+		return
+	}
+
+	r.handoffs = make(map[int]*shardHandoff)
+	r.setLabel(shardHandoffLabel, "")
+	r.setLabel(shardAcquiredLabel, "")
+	r.setLabel(shardLoadLabel, "")
+	r.published = ""
+	r.acquired = ""
+}
+
+// getShardLoads estimates the load of each shard owned by this host as its task rate since the previous round
+// plus the weighted number of workflows held in its history cache
+func (r *shardRebalancer) getShardLoads() map[int]float64 {
+	now := time.Now()
+	elapsed := now.Sub(r.lastRebalance).Seconds()
+	firstRound := r.lastRebalance.IsZero()
+	r.lastRebalance = now
+
+	loads := make(map[int]float64)
+	taskCounts := make(map[int]int64)
+	r.controller.RLock()
+	for shardID, item := range r.controller.historyShards {
+		reporter, ok := item.getEngine().(shardLoadReporter)
+		if !ok {
+			continue
+		}
+		load := reporter.getShardLoad()
+		taskCounts[shardID] = load.taskCount
+
+		taskRate := 0.0
+		if last, ok := r.lastTaskCount[shardID]; ok && !firstRound && elapsed > 0 && load.taskCount >= last {
+			taskRate = float64(load.taskCount-last) / elapsed
+		}
+		loads[shardID] = taskRate + r.config.ShardRebalanceCacheWeight()*float64(load.cacheSize)
+	}
+	r.controller.RUnlock()
+
+	r.lastTaskCount = taskCounts
+	return loads
+}
+
+// getOwnedShards returns the shards this host is running an engine for
+func (r *shardRebalancer) getOwnedShards() map[int]bool {
+	owned := make(map[int]bool)
+	r.controller.RLock()
+	defer r.controller.RUnlock()
+	for shardID, item := range r.controller.historyShards {
+		if item.getEngine() != nil {
+			owned[shardID] = true
+		}
+	}
+	return owned
+}
+
+// refreshPlacementLocked rebuilds the view of handed off shards from the labels of all history hosts
+func (r *shardRebalancer) refreshPlacementLocked(members []*membership.HostInfo) bool {
+	hosts := make(map[string]*membership.HostInfo)
+	for _, member := range members {
+		hosts[member.Identity()] = member
+	}
+
+	acquired := make(map[string]map[int]bool)
+	for _, member := range members {
+		if value, ok := member.Label(shardAcquiredLabel); ok {
+			acquired[member.Identity()] = decodeShardIDs(value)
+		}
+	}
+
+	changed := false
+	confirmTimeout := time.Duration(shardHandoffConfirmRounds) * r.config.ShardRebalanceInterval()
+	for shardID, handoff := range r.handoffs {
+		if _, ok := hosts[handoff.target.Identity()]; !ok {
+			// target host left the ring, take the shard back right away
+			delete(r.handoffs, shardID)
+			r.metricsClient.IncCounter(metrics.HistoryShardRebalancerScope, metrics.ShardRebalanceReclaimCounter)
+			changed = true
+			continue
+		}
+		if handoff.confirmed {
+			continue
+		}
+		if acquired[handoff.target.Identity()][shardID] {
+			r.confirmHandoffLocked(shardID, handoff)
+		} else if time.Since(handoff.handedAt) > confirmTimeout {
+			// this host never stopped running the shard, there is nothing to take back
+			delete(r.handoffs, shardID)
+			r.metricsClient.IncCounter(metrics.HistoryShardRebalancerScope, metrics.ShardRebalanceCancelCounter)
+			r.logger.WithFields(bark.Fields{
+				logging.TagHistoryShardID: shardID,
+				"target-host":             handoff.target.Identity(),
+			}).Warn("Cancelling shard handoff which was not confirmed by the target host.")
+		}
+	}
+
+	placement := make(map[int]*shardPlacement)
+	for _, member := range members {
+		if member.Identity() == r.host.Identity() {
+			continue
+		}
+		value, ok := member.Label(shardHandoffLabel)
+		if !ok {
+			continue
+		}
+		for shardID, target := range decodeShardHandoffs(value) {
+			targetHost, ok := hosts[target]
+			if !ok {
+				continue
+			}
+			// this host acquires the shards handed off to it, the other hosts wait for the confirmation
+			if target == r.host.Identity() || acquired[target][shardID] {
+				placement[shardID] = &shardPlacement{ringOwner: member.Identity(), target: targetHost}
+			}
+		}
+	}
+	for shardID, handoff := range r.handoffs {
+		if handoff.confirmed {
+			placement[shardID] = &shardPlacement{ringOwner: r.host.Identity(), target: handoff.target}
+		}
+	}
+
+	for shardID, p := range placement {
+		if old, ok := r.placement[shardID]; !ok || old.target.Identity() != p.target.Identity() {
+			changed = true
+		}
+	}
+	if len(placement) != len(r.placement) {
+		changed = true
+	}
+	r.placement = placement
+	return changed
+}
+
+// confirmHandoffLocked records that the target acquired a shard handed off by this host
+func (r *shardRebalancer) confirmHandoffLocked(shardID int, handoff *shardHandoff) {
+	handoff.confirmed = true
+	r.logger.WithFields(bark.Fields{
+		logging.TagHistoryShardID: shardID,
+		"target-host":             handoff.target.Identity(),
+	}).Info("Shard handoff confirmed by the target host.")
+}
+
+// handoffShardsLocked hands off the hottest shards of this host to the coldest peer, if this host is hot
+func (r *shardRebalancer) handoffShardsLocked(members []*membership.HostInfo, shardLoads map[int]float64,
+	hostLoad float64) []int {
+
+	average, coldHost, coldLoad := r.getClusterLoad(members, hostLoad)
+	if coldHost == nil || hostLoad <= average*(1+r.config.ShardRebalanceLoadThreshold()) {
+		return nil
+	}
+
+	budget := r.config.ShardRebalanceMaxMoves()
+	if remaining := r.config.ShardRebalanceMaxHandoffs() - len(r.handoffs); remaining < budget {
+		budget = remaining
+	}
+
+	candidates := make([]int, 0, len(shardLoads))
+	for shardID := range shardLoads {
+		candidates = append(candidates, shardID)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return shardLoads[candidates[i]] > shardLoads[candidates[j]]
+	})
+
+	var moved []int
+	now := time.Now()
+	for _, shardID := range candidates {
+		if len(moved) >= budget {
+			break
+		}
+		load := shardLoads[shardID]
+		// moving the shard must not turn the cold host into the hotter one, otherwise shards would ping-pong
+		if load <= 0 || coldLoad+load >= hostLoad-load {
+			continue
+		}
+		if _, ok := r.handoffs[shardID]; ok {
+			continue
+		}
+		if p, ok := r.placement[shardID]; ok && p.ringOwner != r.host.Identity() {
+			// shard was handed to this host by its ring owner, only the ring owner can move it
+			continue
+		}
+		ringOwner, err := r.resolver.Lookup(string(shardID))
+		if err != nil || ringOwner.Identity() != r.host.Identity() {
+			continue
+		}
+
+		r.handoffs[shardID] = &shardHandoff{target: coldHost, handedAt: now}
+		hostLoad -= load
+		coldLoad += load
+		moved = append(moved, shardID)
+
+		r.metricsClient.IncCounter(metrics.HistoryShardRebalancerScope, metrics.ShardRebalanceHandoffCounter)
+		r.logger.WithFields(bark.Fields{
+			logging.TagHistoryShardID: shardID,
+			"target-host":             coldHost.Identity(),
+			"shard-load":              load,
+		}).Info("Handing off shard to colder history host.")
+	}
+	return moved
+}
+
+// reclaimShardsLocked takes back shards handed off by this host once it has enough headroom to run them again
+func (r *shardRebalancer) reclaimShardsLocked(members []*membership.HostInfo, hostLoad float64) bool {
+	if len(r.handoffs) == 0 {
+		return false
+	}
+
+	average, _, _ := r.getClusterLoad(members, hostLoad)
+	limit := average * (1 - r.config.ShardRebalanceLoadThreshold())
+	cooldown := r.config.ShardRebalanceCooldown()
+	reclaimed := false
+	for shardID, handoff := range r.handoffs {
+		if hostLoad >= limit || time.Since(handoff.handedAt) < cooldown {
+			continue
+		}
+		delete(r.handoffs, shardID)
+		delete(r.placement, shardID)
+		reclaimed = true
+
+		r.metricsClient.IncCounter(metrics.HistoryShardRebalancerScope, metrics.ShardRebalanceReclaimCounter)
+		r.logger.WithFields(bark.Fields{
+			logging.TagHistoryShardID: shardID,
+			"target-host":             handoff.target.Identity(),
+		}).Info("Reclaiming previously handed off shard.")
+		// only take back one shard per round, its load is unknown until the next round
+		break
+	}
+	return reclaimed
+}
+
+// getClusterLoad returns the average load of all hosts which published their load, and the coldest peer
+func (r *shardRebalancer) getClusterLoad(members []*membership.HostInfo, hostLoad float64) (
+	float64, *membership.HostInfo, float64) {
+
+	total := hostLoad
+	count := 1
+	var coldHost *membership.HostInfo
+	coldLoad := 0.0
+	for _, member := range members {
+		if member.Identity() == r.host.Identity() {
+			continue
+		}
+		value, ok := member.Label(shardLoadLabel)
+		if !ok || value == "" {
+			// host does not take part in rebalancing
+			continue
+		}
+		load, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		total += load
+		count++
+		if coldHost == nil || load < coldLoad {
+			coldHost = member
+			coldLoad = load
+		}
+	}
+	return total / float64(count), coldHost, coldLoad
+}
+
+func (r *shardRebalancer) publishLocked(hostLoad float64) {
+	r.setLabel(shardLoadLabel, strconv.FormatFloat(hostLoad, 'f', 2, 64))
+	handoffs := encodeShardHandoffs(r.handoffs)
+	if handoffs != r.published {
+		if r.setLabel(shardHandoffLabel, handoffs) {
+			r.published = handoffs
+		}
+	}
+}
+
+// publishAcquiredLocked publishes the shards handed off to this host which it acquired
+func (r *shardRebalancer) publishAcquiredLocked(owned map[int]bool) {
+	var shardIDs []int
+	for shardID, p := range r.placement {
+		if p.target.Identity() == r.host.Identity() && p.ringOwner != r.host.Identity() && owned[shardID] {
+			shardIDs = append(shardIDs, shardID)
+		}
+	}
+	acquired := encodeShardIDs(shardIDs)
+	if acquired != r.acquired {
+		if r.setLabel(shardAcquiredLabel, acquired) {
+			r.acquired = acquired
+		}
+	}
+}
+
+func (r *shardRebalancer) setLabel(key string, value string) bool {
+	if err := r.controller.service.GetMembershipMonitor().SetLabel(key, value); err != nil {
+		logging.LogOperationFailedEvent(r.logger, fmt.Sprintf("Error publishing membership label %v", key), err)
+		return false
+	}
+	return true
+}
+
+func encodeShardHandoffs(handoffs map[int]*shardHandoff) string {
+	shardIDs := make([]int, 0, len(handoffs))
+	for shardID := range handoffs {
+		shardIDs = append(shardIDs, shardID)
+	}
+	sort.Ints(shardIDs)
+
+	parts := make([]string, 0, len(shardIDs))
+	for _, shardID := range shardIDs {
+		parts = append(parts, fmt.Sprintf("%v=%v", shardID, handoffs[shardID].target.Identity()))
+	}
+	return strings.Join(parts, ",")
+}
+
+func decodeShardHandoffs(value string) map[int]string {
+	handoffs := make(map[int]string)
+	if value == "" {
+		return handoffs
+	}
+	for _, part := range strings.Split(value, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		shardID, err := strconv.Atoi(kv[0])
+		if err != nil {
+			continue
+		}
+		handoffs[shardID] = kv[1]
+	}
+	return handoffs
+}
+
+func encodeShardIDs(shardIDs []int) string {
+	sort.Ints(shardIDs)
+	parts := make([]string, 0, len(shardIDs))
+	for _, shardID := range shardIDs {
+		parts = append(parts, strconv.Itoa(shardID))
+	}
+	return strings.Join(parts, ",")
+}
+
+func decodeShardIDs(value string) map[int]bool {
+	shardIDs := make(map[int]bool)
+	if value == "" {
+		return shardIDs
+	}
+	for _, part := range strings.Split(value, ",") {
+		if shardID, err := strconv.Atoi(part); err == nil {
+			shardIDs[shardID] = true
+		}
+	}
+	return shardIDs
+}
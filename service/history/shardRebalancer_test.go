@@ -0,0 +1,197 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	mmocks "github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	shardRebalancerSuite struct {
+		suite.Suite
+		hostInfo            *membership.HostInfo
+		coldHost            *membership.HostInfo
+		mockServiceResolver *mmocks.ServiceResolver
+		config              *Config
+		rebalancer          *shardRebalancer
+	}
+)
+
+func TestShardRebalancerSuite(t *testing.T) {
+	s := new(shardRebalancerSuite)
+	suite.Run(t, s)
+}
+
+func (s *shardRebalancerSuite) SetupTest() {
+	s.hostInfo = membership.NewHostInfo("hot-host:7934", nil)
+	s.coldHost = membership.NewHostInfo("cold-host:7934", map[string]string{shardLoadLabel: "10.00"})
+	s.mockServiceResolver = &mmocks.ServiceResolver{}
+	s.config = NewConfig(dynamicconfig.NewNopCollection(), 4)
+	s.config.EnableShardRebalance = dynamicconfig.GetBoolPropertyFn(true)
+	s.config.ShardRebalanceMaxMoves = dynamicconfig.GetIntPropertyFn(1)
+	controller := &shardController{
+		host:             s.hostInfo,
+		hServiceResolver: s.mockServiceResolver,
+		config:           s.config,
+		logger:           bark.NewLoggerFromLogrus(log.New()),
+		metricsClient:    metrics.NewClient(tally.NoopScope, metrics.History),
+		historyShards:    make(map[int]*historyShardsItem),
+	}
+	s.rebalancer = newShardRebalancer(controller)
+}
+
+func (s *shardRebalancerSuite) TearDownTest() {
+	s.mockServiceResolver.AssertExpectations(s.T())
+}
+
+func (s *shardRebalancerSuite) TestEncodeDecodeShardHandoffs() {
+	handoffs := map[int]*shardHandoff{
+		3: {target: s.coldHost},
+		1: {target: s.coldHost},
+	}
+	encoded := encodeShardHandoffs(handoffs)
+	s.Equal("1=cold-host:7934,3=cold-host:7934", encoded)
+	s.Equal(map[int]string{1: "cold-host:7934", 3: "cold-host:7934"}, decodeShardHandoffs(encoded))
+	s.Empty(decodeShardHandoffs(""))
+	s.Empty(decodeShardHandoffs("garbage,x=y"))
+}
+
+func (s *shardRebalancerSuite) TestHandoffHotShard() {
+	s.mockServiceResolver.On("Lookup", string(0)).Return(s.hostInfo, nil).Once()
+	members := []*membership.HostInfo{s.hostInfo, s.coldHost}
+	// shard 2 is skipped as moving it would make the cold host hotter than this one
+	shardLoads := map[int]float64{0: 20, 1: 5, 2: 40}
+
+	moved := s.rebalancer.handoffShardsLocked(members, shardLoads, 65)
+	s.Equal([]int{0}, moved)
+
+	// this host keeps the shard until the target confirms it acquired the shard
+	_, ok := s.rebalancer.getHandoffTarget(0, s.hostInfo)
+	s.False(ok)
+	s.False(s.rebalancer.refreshPlacementLocked(members))
+	_, ok = s.rebalancer.getHandoffTarget(0, s.hostInfo)
+	s.False(ok)
+
+	coldHost := membership.NewHostInfo(s.coldHost.Identity(), map[string]string{
+		shardLoadLabel:     "10.00",
+		shardAcquiredLabel: "0",
+	})
+	s.True(s.rebalancer.refreshPlacementLocked([]*membership.HostInfo{s.hostInfo, coldHost}))
+	target, ok := s.rebalancer.getHandoffTarget(0, s.hostInfo)
+	s.True(ok)
+	s.Equal(s.coldHost.Identity(), target.Identity())
+
+	// handoff is only honored while the advertising host is still the ring owner
+	_, ok = s.rebalancer.getHandoffTarget(0, s.coldHost)
+	s.False(ok)
+}
+
+func (s *shardRebalancerSuite) TestHandoffConfirmedByShardClosed() {
+	s.rebalancer.handoffs[0] = &shardHandoff{target: s.coldHost, handedAt: time.Now()}
+	_, ok := s.rebalancer.getHandoffTarget(0, s.hostInfo)
+	s.False(ok)
+
+	// the shard of this host is closed once the target acquired it
+	s.rebalancer.onShardClosed(0)
+	target, ok := s.rebalancer.getHandoffTarget(0, s.hostInfo)
+	s.True(ok)
+	s.Equal(s.coldHost.Identity(), target.Identity())
+
+	// a shard which is not handed off is acquired again
+	s.rebalancer.onShardClosed(1)
+	_, ok = s.rebalancer.getHandoffTarget(1, s.hostInfo)
+	s.False(ok)
+}
+
+func (s *shardRebalancerSuite) TestHandoffNotConfirmedIsCancelled() {
+	s.config.ShardRebalanceInterval = dynamicconfig.GetDurationPropertyFn(time.Millisecond)
+	members := []*membership.HostInfo{s.hostInfo, s.coldHost}
+	s.rebalancer.handoffs[0] = &shardHandoff{target: s.coldHost, handedAt: time.Now().Add(-time.Second)}
+
+	s.False(s.rebalancer.refreshPlacementLocked(members))
+	s.Empty(s.rebalancer.handoffs)
+	_, ok := s.rebalancer.getHandoffTarget(0, s.hostInfo)
+	s.False(ok)
+}
+
+func (s *shardRebalancerSuite) TestNoHandoffWhenBalanced() {
+	members := []*membership.HostInfo{s.hostInfo, s.coldHost}
+	shardLoads := map[int]float64{0: 6, 1: 5}
+
+	moved := s.rebalancer.handoffShardsLocked(members, shardLoads, 11)
+	s.Empty(moved)
+}
+
+func (s *shardRebalancerSuite) TestPlacementFromPeerLabels() {
+	peer := membership.NewHostInfo("peer-host:7934", map[string]string{
+		shardLoadLabel:    "50.00",
+		shardHandoffLabel: "1=" + s.hostInfo.Identity() + ",2=gone-host:7934,3=" + s.coldHost.Identity(),
+	})
+	members := []*membership.HostInfo{s.hostInfo, peer, s.coldHost}
+
+	s.True(s.rebalancer.refreshPlacementLocked(members))
+	target, ok := s.rebalancer.getHandoffTarget(1, peer)
+	s.True(ok)
+	s.Equal(s.hostInfo.Identity(), target.Identity())
+
+	// handoff to a host which is not part of the ring is ignored
+	_, ok = s.rebalancer.getHandoffTarget(2, peer)
+	s.False(ok)
+
+	// handoff to another host is only honored once that host acquired the shard
+	_, ok = s.rebalancer.getHandoffTarget(3, peer)
+	s.False(ok)
+	s.False(s.rebalancer.refreshPlacementLocked(members))
+
+	coldHost := membership.NewHostInfo(s.coldHost.Identity(), map[string]string{
+		shardLoadLabel:     "10.00",
+		shardAcquiredLabel: "3",
+	})
+	s.True(s.rebalancer.refreshPlacementLocked([]*membership.HostInfo{s.hostInfo, peer, coldHost}))
+	target, ok = s.rebalancer.getHandoffTarget(3, peer)
+	s.True(ok)
+	s.Equal(s.coldHost.Identity(), target.Identity())
+}
+
+func (s *shardRebalancerSuite) TestEncodeDecodeShardIDs() {
+	encoded := encodeShardIDs([]int{3, 1})
+	s.Equal("1,3", encoded)
+	s.Equal(map[int]bool{1: true, 3: true}, decodeShardIDs(encoded))
+	s.Empty(decodeShardIDs(""))
+	s.Empty(decodeShardIDs("garbage"))
+}
+
+func (s *shardRebalancerSuite) TestRebalanceDisabled() {
+	s.config.EnableShardRebalance = dynamicconfig.GetBoolPropertyFn(false)
+	s.rebalancer.placement[1] = &shardPlacement{ringOwner: s.hostInfo.Identity(), target: s.coldHost}
+	_, ok := s.rebalancer.getHandoffTarget(1, s.hostInfo)
+	s.False(ok)
+}
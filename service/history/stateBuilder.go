@@ -29,6 +29,7 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 )
 
@@ -36,7 +37,7 @@ type (
 	stateBuilder interface {
 		applyEvents(domainID, requestID string, execution shared.WorkflowExecution,
 			history *shared.History, newRunHistory *shared.History) (*shared.HistoryEvent,
-			*decisionInfo, mutableState, error)
+			*DecisionInfo, MutableState, error)
 		getTransferTasks() []persistence.Task
 		getTimerTasks() []persistence.Task
 		getNewRunTransferTasks() []persistence.Task
@@ -46,9 +47,11 @@ type (
 	stateBuilderImpl struct {
 		shard           ShardContext
 		clusterMetadata cluster.Metadata
-		msBuilder       mutableState
+		msBuilder       MutableState
 		domainCache     cache.DomainCache
 		logger          bark.Logger
+		// taggedMetrics is set when applying replicated events, to count them per domain and event type
+		taggedMetrics *metricsClientCache
 
 		transferTasks       []persistence.Task
 		timerTasks          []persistence.Task
@@ -57,7 +60,7 @@ type (
 	}
 )
 
-func newStateBuilder(shard ShardContext, msBuilder mutableState, logger bark.Logger) *stateBuilderImpl {
+func newStateBuilder(shard ShardContext, msBuilder MutableState, logger bark.Logger) *stateBuilderImpl {
 
 	return &stateBuilderImpl{
 		shard:           shard,
@@ -86,10 +89,10 @@ func (b *stateBuilderImpl) getNewRunTimerTasks() []persistence.Task {
 
 func (b *stateBuilderImpl) applyEvents(domainID, requestID string, execution shared.WorkflowExecution,
 	history *shared.History, newRunHistory *shared.History) (*shared.HistoryEvent,
-	*decisionInfo, mutableState, error) {
+	*DecisionInfo, MutableState, error) {
 	var lastEvent *shared.HistoryEvent
-	var lastDecision *decisionInfo
-	var newRunStateBuilder mutableState
+	var lastDecision *DecisionInfo
+	var newRunStateBuilder MutableState
 	for _, event := range history.Events {
 		lastEvent = event
 		// must set the current version, since this is standby here, not active
@@ -283,6 +286,15 @@ func (b *stateBuilderImpl) applyEvents(domainID, requestID string, execution sha
 		case shared.EventTypeWorkflowExecutionSignaled:
 			// No mutable state action is needed
 
+		case shared.EventTypeWorkflowExecutionUpdateRequested:
+			b.msBuilder.ReplicateWorkflowExecutionUpdateRequestedEvent(event)
+
+		case shared.EventTypeWorkflowExecutionUpdateCompleted:
+			b.msBuilder.ReplicateWorkflowExecutionUpdateCompletedEvent(event)
+
+		case shared.EventTypeWorkflowExecutionTaskListChanged:
+			b.msBuilder.ReplicateWorkflowExecutionTaskListChangedEvent(event)
+
 		case shared.EventTypeWorkflowExecutionCancelRequested:
 			b.msBuilder.ReplicateWorkflowExecutionCancelRequestedEvent(event)
 
@@ -389,9 +401,31 @@ func (b *stateBuilderImpl) applyEvents(domainID, requestID string, execution sha
 		}
 	}
 
+	b.recordAppliedEvents(domainID, history, newRunHistory)
 	return lastEvent, lastDecision, newRunStateBuilder, nil
 }
 
+func (b *stateBuilderImpl) recordAppliedEvents(domainID string, histories ...*shared.History) {
+	if b.taggedMetrics == nil {
+		return
+	}
+
+	counts := make(map[shared.EventType]int64)
+	for _, history := range histories {
+		if history == nil {
+			continue
+		}
+		for _, event := range history.Events {
+			counts[event.GetEventType()]++
+		}
+	}
+	domainName := getDomainNameForMetrics(b.domainCache, domainID)
+	for eventType, count := range counts {
+		client := b.taggedMetrics.get(metrics.DomainTagName, domainName, metrics.EventTypeTagName, eventType.String())
+		client.AddCounter(metrics.ReplicateHistoryEventsScope, metrics.ReplicationEventsAppliedCounter, count)
+	}
+}
+
 func (b *stateBuilderImpl) scheduleDecisionTransferTask(domainID string, tasklist string,
 	scheduleID int64) persistence.Task {
 	return &persistence.DecisionTask{
@@ -451,19 +485,19 @@ func (b *stateBuilderImpl) scheduleDecisionTimerTask(event *shared.HistoryEvent,
 }
 
 func (b *stateBuilderImpl) scheduleUserTimerTask(event *shared.HistoryEvent,
-	ti *persistence.TimerInfo, msBuilder mutableState) persistence.Task {
+	ti *persistence.TimerInfo, msBuilder MutableState) persistence.Task {
 	timerBuilder := b.getTimerBuilder(event)
 	timerBuilder.AddUserTimer(ti, msBuilder)
 	return timerBuilder.GetUserTimerTaskIfNeeded(msBuilder)
 }
 
 func (b *stateBuilderImpl) scheduleActivityTimerTask(event *shared.HistoryEvent,
-	msBuilder mutableState) persistence.Task {
+	msBuilder MutableState) persistence.Task {
 	return b.getTimerBuilder(event).GetActivityTimerTaskIfNeeded(msBuilder)
 }
 
 func (b *stateBuilderImpl) scheduleWorkflowTimerTask(event *shared.HistoryEvent,
-	msBuilder mutableState) persistence.Task {
+	msBuilder MutableState) persistence.Task {
 	now := time.Unix(0, event.GetTimestamp())
 	timeout := now.Add(time.Duration(msBuilder.GetExecutionInfo().WorkflowTimeout) * time.Second)
 	return &persistence.WorkflowTimeoutTask{VisibilityTimestamp: timeout}
@@ -482,7 +516,7 @@ func (b *stateBuilderImpl) scheduleDeleteHistoryTimerTask(event *shared.HistoryE
 	return b.getTimerBuilder(event).createDeleteHistoryEventTimerTask(time.Duration(retentionInDays) * time.Hour * 24), nil
 }
 
-func (b *stateBuilderImpl) getTaskList(msBuilder mutableState) string {
+func (b *stateBuilderImpl) getTaskList(msBuilder MutableState) string {
 	// on the standby side, sticky tasklist is meaningless, so always use the normal tasklist
 	return msBuilder.GetExecutionInfo().TaskList
 }
@@ -131,6 +131,28 @@ func (s *stateBuilderSuite) toHistory(events ...*shared.HistoryEvent) *shared.Hi
 	return &shared.History{Events: events}
 }
 
+func (s *stateBuilderSuite) TestRecordAppliedEvents() {
+	domainID := validDomainID
+	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: domainID}).Return(
+		nil, &shared.EntityNotExistsError{},
+	).Once()
+
+	// no metrics recorded when events are not replicated
+	s.stateBuilder.recordAppliedEvents(domainID, &shared.History{})
+	s.stateBuilder.taggedMetrics = newMetricsClientCache(s.mockShard.GetMetricsClient())
+
+	history := &shared.History{Events: []*shared.HistoryEvent{
+		{EventType: shared.EventTypeWorkflowExecutionSignaled.Ptr()},
+		{EventType: shared.EventTypeWorkflowExecutionSignaled.Ptr()},
+		{EventType: shared.EventTypeDecisionTaskScheduled.Ptr()},
+	}}
+	newRunHistory := &shared.History{Events: []*shared.HistoryEvent{
+		{EventType: shared.EventTypeWorkflowExecutionStarted.Ptr()},
+	}}
+	s.stateBuilder.recordAppliedEvents(domainID, history, newRunHistory)
+	s.Equal(3, s.stateBuilder.taggedMetrics.size())
+}
+
 func (s *stateBuilderSuite) TestApplyEvents_EventTypeWorkflowExecutionStarted() {
 	version := int64(1)
 	requestID := uuid.New()
@@ -314,6 +336,37 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeWorkflowExecutionSignaled()
 	s.Empty(s.stateBuilder.newRunTransferTasks)
 }
 
+func (s *stateBuilderSuite) TestApplyEvents_EventTypeWorkflowExecutionTaskListChanged() {
+	version := int64(1)
+	requestID := uuid.New()
+	domainID := validDomainID
+	execution := shared.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	now := time.Now()
+	evenType := shared.EventTypeWorkflowExecutionTaskListChanged
+	event := &shared.HistoryEvent{
+		Version:   common.Int64Ptr(version),
+		EventId:   common.Int64Ptr(130),
+		Timestamp: common.Int64Ptr(now.UnixNano()),
+		EventType: &evenType,
+		WorkflowExecutionTaskListChangedEventAttributes: &shared.WorkflowExecutionTaskListChangedEventAttributes{
+			TaskList: &shared.TaskList{Name: common.StringPtr("some random task list")},
+		},
+	}
+	s.mockMutableState.On("ReplicateWorkflowExecutionTaskListChangedEvent", event).Once()
+	s.mockUpdateVersion(event)
+
+	s.stateBuilder.applyEvents(domainID, requestID, execution, s.toHistory(event), nil)
+
+	s.Empty(s.stateBuilder.timerTasks)
+	s.Empty(s.stateBuilder.transferTasks)
+	s.Empty(s.stateBuilder.newRunTimerTasks)
+	s.Empty(s.stateBuilder.newRunTransferTasks)
+}
+
 func (s *stateBuilderSuite) TestApplyEvents_EventTypeWorkflowExecutionFailed() {
 	version := int64(1)
 	requestID := uuid.New()
@@ -464,7 +517,7 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeWorkflowExecutionContinuedA
 		domainID,
 		continueAsNewEvent,
 		newRunStartedEvent,
-		&decisionInfo{
+		&DecisionInfo{
 			Version:         newRunDecisionEvent.GetVersion(),
 			ScheduleID:      newRunDecisionEvent.GetEventId(),
 			StartedID:       common.EmptyEventID,
@@ -1228,7 +1281,7 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeDecisionTaskStarted() {
 			RequestId:        common.StringPtr(decisionRequestID),
 		},
 	}
-	di := &decisionInfo{
+	di := &DecisionInfo{
 		Version:         event.GetVersion(),
 		ScheduleID:      scheduleID,
 		StartedID:       event.GetEventId(),
@@ -1238,7 +1291,7 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeDecisionTaskStarted() {
 		Attempt:         0,
 	}
 	s.mockMutableState.On("ReplicateDecisionTaskStartedEvent",
-		(*decisionInfo)(nil), event.GetVersion(), scheduleID, event.GetEventId(), decisionRequestID, event.GetTimestamp()).Return(di).Once()
+		(*DecisionInfo)(nil), event.GetVersion(), scheduleID, event.GetEventId(), decisionRequestID, event.GetTimestamp()).Return(di).Once()
 	s.mockMutableState.On("UpdateDecision", di).Once()
 	s.mockUpdateVersion(event)
 
@@ -1278,7 +1331,7 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeDecisionTaskScheduled() {
 			StartToCloseTimeoutSeconds: common.Int32Ptr(timeoutSecond),
 		},
 	}
-	di := &decisionInfo{
+	di := &DecisionInfo{
 		Version:         event.GetVersion(),
 		ScheduleID:      event.GetEventId(),
 		StartedID:       common.EmptyEventID,
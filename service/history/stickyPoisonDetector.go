@@ -0,0 +1,246 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// stickyPoisonDetector counts, per domain and binary checksum, the sticky decisions failed with non-determinism
+	// errors. Once a binary reaches the threshold within the window it is marked suspect in the domain metadata,
+	// and workflows are no longer kept sticky on the workers of that binary. It is shared by all shards of the host.
+	// The workflows of a domain are spread over all the history hosts, so each host marks a binary once it counts
+	// its share of the threshold.
+	stickyPoisonDetector struct {
+		config          *Config
+		metadataMgr     persistence.MetadataManager
+		domainCache     cache.DomainCache
+		resolver        membership.ServiceResolver
+		clusterMetadata cluster.Metadata
+		clientFactory   client.Factory
+		metricsClient   metrics.Client
+		logger          bark.Logger
+		timeSource      common.TimeSource
+
+		sync.Mutex
+		failures map[stickyPoisonKey]*stickyPoisonFailures
+		// masterFrontend is the frontend of the master cluster, the suspect binaries of a global domain are set
+		// through its UpdateDomain, which replicates them to the other clusters
+		masterFrontend frontend.Client
+	}
+
+	stickyPoisonKey struct {
+		domainID       string
+		binaryChecksum string
+	}
+
+	// stickyPoisonFailures are the failures of a binary counted since the start of the window
+	stickyPoisonFailures struct {
+		windowStart time.Time
+		count       int
+		marked      bool
+	}
+)
+
+// stickyPoisonUpdateTimeout bounds the UpdateDomain call marking a binary of a global domain suspect
+const stickyPoisonUpdateTimeout = 10 * time.Second
+
+var errStickyPoisonNoMasterAddress = errors.New("the address of the frontend of the master cluster is not set")
+
+func newStickyPoisonDetector(config *Config, metadataMgr persistence.MetadataManager, domainCache cache.DomainCache,
+	resolver membership.ServiceResolver, clusterMetadata cluster.Metadata, clientFactory client.Factory,
+	metricsClient metrics.Client, logger bark.Logger) *stickyPoisonDetector {
+	return &stickyPoisonDetector{
+		config:          config,
+		metadataMgr:     metadataMgr,
+		domainCache:     domainCache,
+		resolver:        resolver,
+		clusterMetadata: clusterMetadata,
+		clientFactory:   clientFactory,
+		metricsClient:   metricsClient,
+		logger: logger.WithFields(bark.Fields{
+			logging.TagWorkflowComponent: logging.TagValueStickyPoisonDetectorComponent,
+		}),
+		timeSource: common.NewRealTimeSource(),
+		failures:   make(map[stickyPoisonKey]*stickyPoisonFailures),
+	}
+}
+
+// recordFailure counts a sticky decision of the binary failed with a non-determinism error, and marks the binary
+// suspect in the domain metadata once it reaches the threshold of the domain
+func (d *stickyPoisonDetector) recordFailure(domainEntry *cache.DomainCacheEntry, binaryChecksum string) {
+	d.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskFailedScope, metrics.StickyNonDeterministicFailureCounter)
+
+	threshold := d.config.StickyPoisonFailureThreshold(domainEntry.GetInfo().Name)
+	if threshold <= 0 || len(binaryChecksum) == 0 || domainEntry.IsBinaryChecksumSuspect(binaryChecksum) {
+		return
+	}
+
+	key := stickyPoisonKey{domainID: domainEntry.GetInfo().ID, binaryChecksum: binaryChecksum}
+	if !d.countFailure(key, d.hostThreshold(threshold)) {
+		return
+	}
+
+	logger := d.logger.WithFields(bark.Fields{
+		logging.TagDomainID:       domainEntry.GetInfo().ID,
+		logging.TagBinaryChecksum: binaryChecksum,
+	})
+	if err := d.markSuspect(domainEntry.GetInfo().ID, binaryChecksum); err != nil {
+		logger.WithField(logging.TagErr, err).Warn("Failed to mark binary checksum suspect.")
+		return
+	}
+	d.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskFailedScope, metrics.SuspectBinaryChecksumMarkedCounter)
+	logger.Warn("Binary checksum marked suspect after repeated non-deterministic sticky decisions.")
+}
+
+// hostThreshold returns the share of the domain threshold counted by this host, rounded up
+func (d *stickyPoisonDetector) hostThreshold(threshold int) int {
+	hosts, err := d.resolver.Members()
+	if err != nil || len(hosts) <= 1 {
+		return threshold
+	}
+	return (threshold + len(hosts) - 1) / len(hosts)
+}
+
+// countFailure returns true when the failure makes the binary reach the threshold, only once per window
+func (d *stickyPoisonDetector) countFailure(key stickyPoisonKey, threshold int) bool {
+	d.Lock()
+	defer d.Unlock()
+
+	now := d.timeSource.Now()
+	window := d.config.StickyPoisonWindow()
+	for k, failures := range d.failures {
+		if now.Sub(failures.windowStart) > window {
+			delete(d.failures, k)
+		}
+	}
+
+	failures, ok := d.failures[key]
+	if !ok {
+		failures = &stickyPoisonFailures{windowStart: now}
+		d.failures[key] = failures
+	}
+	failures.count++
+	if failures.marked || failures.count < threshold {
+		return false
+	}
+	failures.marked = true
+	return true
+}
+
+func (d *stickyPoisonDetector) markSuspect(domainID string, binaryChecksum string) error {
+	// the notification version is the lock on the v2 domain table, it has to be read before the domain
+	metadata, err := d.metadataMgr.GetMetadata()
+	if err != nil {
+		return err
+	}
+	getResponse, err := d.metadataMgr.GetDomain(&persistence.GetDomainRequest{ID: domainID})
+	if err != nil {
+		return err
+	}
+
+	config := getResponse.Config
+	for _, suspect := range config.SuspectBinaryChecksums {
+		if suspect == binaryChecksum {
+			return nil
+		}
+	}
+	config.SuspectBinaryChecksums = append(config.SuspectBinaryChecksums, binaryChecksum)
+	if getResponse.IsGlobalDomain {
+		return d.updateGlobalDomain(getResponse.Info.Name, config.SuspectBinaryChecksums)
+	}
+
+	updateReq := &persistence.UpdateDomainRequest{
+		Info:                        getResponse.Info,
+		Config:                      config,
+		ReplicationConfig:           getResponse.ReplicationConfig,
+		ConfigVersion:               getResponse.ConfigVersion + 1,
+		FailoverVersion:             getResponse.FailoverVersion,
+		FailoverNotificationVersion: getResponse.FailoverNotificationVersion,
+		TableVersion:                getResponse.TableVersion,
+	}
+	switch getResponse.TableVersion {
+	case persistence.DomainTableVersionV1:
+		updateReq.NotificationVersion = getResponse.NotificationVersion
+	case persistence.DomainTableVersionV2:
+		updateReq.NotificationVersion = metadata.NotificationVersion
+	default:
+		return errors.New("domain table version is not set")
+	}
+	if err := d.metadataMgr.UpdateDomain(updateReq); err != nil {
+		return err
+	}
+
+	d.domainCache.TriggerRefresh()
+	return nil
+}
+
+// updateGlobalDomain sets the suspect binaries of a global domain through the frontend of the master cluster, only
+// an update made there is replicated to the other clusters
+func (d *stickyPoisonDetector) updateGlobalDomain(domainName string, suspectBinaryChecksums []string) error {
+	frontendClient, err := d.getMasterFrontend()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), stickyPoisonUpdateTimeout)
+	defer cancel()
+	_, err = frontendClient.UpdateDomain(ctx, &shared.UpdateDomainRequest{
+		Name: common.StringPtr(domainName),
+		Configuration: &shared.DomainConfiguration{
+			SuspectBinaryChecksums: suspectBinaryChecksums,
+		},
+	})
+	return err
+}
+
+func (d *stickyPoisonDetector) getMasterFrontend() (frontend.Client, error) {
+	d.Lock()
+	defer d.Unlock()
+	if d.masterFrontend != nil {
+		return d.masterFrontend, nil
+	}
+	address, ok := d.clusterMetadata.GetAllClientAddress()[d.clusterMetadata.GetMasterClusterName()]
+	if !ok || address.RPCAddress == "" {
+		return nil, errStickyPoisonNoMasterAddress
+	}
+	frontendClient, err := d.clientFactory.NewFrontendClientForHost(address.RPCAddress)
+	if err != nil {
+		return nil, err
+	}
+	d.masterFrontend = frontendClient
+	return frontendClient, nil
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/cadence/workflowservicetest"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	stickyPoisonDetectorSuite struct {
+		suite.Suite
+		mockMetadataMgr *mocks.MetadataManager
+		mockCluster     *mocks.ClusterMetadata
+		clientFactory   *stickyPoisonTestClientFactory
+		historyHosts    []*membership.HostInfo
+		timeSource      *fakeStickyPoisonTimeSource
+		domainResponse  *persistence.GetDomainResponse
+		domainEntry     *cache.DomainCacheEntry
+		detector        *stickyPoisonDetector
+	}
+
+	fakeStickyPoisonTimeSource struct {
+		now time.Time
+	}
+
+	// stickyPoisonTestClientFactory hands out the same frontend client for every host
+	stickyPoisonTestClientFactory struct {
+		client.Factory
+		frontendClient frontend.Client
+		addresses      []string
+	}
+)
+
+const (
+	stickyPoisonTestDomainID = "sticky-poison-domain-id"
+	stickyPoisonTestChecksum = "bad-binary"
+)
+
+func TestStickyPoisonDetectorSuite(t *testing.T) {
+	s := new(stickyPoisonDetectorSuite)
+	suite.Run(t, s)
+}
+
+func (ts *fakeStickyPoisonTimeSource) Now() time.Time {
+	return ts.now
+}
+
+func (f *stickyPoisonTestClientFactory) NewFrontendClientForHost(rpcAddress string) (frontend.Client, error) {
+	f.addresses = append(f.addresses, rpcAddress)
+	return f.frontendClient, nil
+}
+
+func (s *stickyPoisonDetectorSuite) SetupTest() {
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+	mockClusterMetadata := &mocks.ClusterMetadata{}
+	mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	mockClusterMetadata.On("GetAllClusterFailoverVersions").Return(cluster.TestAllClusterFailoverVersions)
+	mockClusterMetadata.On("IsGlobalDomainEnabled").Return(false)
+	s.mockCluster = mockClusterMetadata
+	s.clientFactory = &stickyPoisonTestClientFactory{}
+	s.historyHosts = []*membership.HostInfo{membership.NewHostInfo("history-host-0", nil)}
+	mockResolver := &mocks.ServiceResolver{}
+	mockResolver.On("Members").Return(func() []*membership.HostInfo { return s.historyHosts }, nil)
+
+	s.domainResponse = &persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: stickyPoisonTestDomainID, Name: "sticky-poison-domain"},
+		Config: &persistence.DomainConfig{Retention: 1},
+		ReplicationConfig: &persistence.DomainReplicationConfig{
+			ActiveClusterName: cluster.TestCurrentClusterName,
+			Clusters:          []*persistence.ClusterReplicationConfig{{ClusterName: cluster.TestCurrentClusterName}},
+		},
+		ConfigVersion: 3,
+		TableVersion:  persistence.DomainTableVersionV1,
+	}
+	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: stickyPoisonTestDomainID}).Return(s.domainResponse, nil)
+	s.mockMetadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: 7}, nil)
+
+	metricsClient := metrics.NewClient(tally.NoopScope, metrics.History)
+	domainCache := cache.NewDomainCache(s.mockMetadataMgr, mockClusterMetadata, metricsClient, bark.NewNopLogger())
+	entry, err := domainCache.GetDomainByID(stickyPoisonTestDomainID)
+	s.NoError(err)
+	s.domainEntry = entry
+
+	config := NewConfig(dynamicconfig.NewNopCollection(), 1)
+	config.StickyPoisonFailureThreshold = dynamicconfig.GetIntPropertyFilteredByDomain(3)
+	config.StickyPoisonWindow = dynamicconfig.GetDurationPropertyFn(time.Minute)
+	s.timeSource = &fakeStickyPoisonTimeSource{now: time.Unix(1000, 0)}
+	s.detector = newStickyPoisonDetector(config, s.mockMetadataMgr, domainCache, mockResolver, mockClusterMetadata,
+		s.clientFactory, metricsClient, bark.NewNopLogger())
+	s.detector.timeSource = s.timeSource
+}
+
+func (s *stickyPoisonDetectorSuite) TestMarkSuspectAtThreshold() {
+	s.mockMetadataMgr.On("UpdateDomain", mock.MatchedBy(func(request *persistence.UpdateDomainRequest) bool {
+		return len(request.Config.SuspectBinaryChecksums) == 1 &&
+			request.Config.SuspectBinaryChecksums[0] == stickyPoisonTestChecksum &&
+			request.ConfigVersion == 4 &&
+			request.TableVersion == persistence.DomainTableVersionV1
+	})).Return(nil).Once()
+
+	s.detector.recordFailure(s.domainEntry, stickyPoisonTestChecksum)
+	s.detector.recordFailure(s.domainEntry, stickyPoisonTestChecksum)
+	s.mockMetadataMgr.AssertNotCalled(s.T(), "UpdateDomain", mock.Anything)
+
+	s.detector.recordFailure(s.domainEntry, stickyPoisonTestChecksum)
+	// marked once per window
+	s.detector.recordFailure(s.domainEntry, stickyPoisonTestChecksum)
+	s.mockMetadataMgr.AssertExpectations(s.T())
+}
+
+func (s *stickyPoisonDetectorSuite) TestFailuresExpireWithWindow() {
+	s.detector.recordFailure(s.domainEntry, stickyPoisonTestChecksum)
+	s.detector.recordFailure(s.domainEntry, stickyPoisonTestChecksum)
+	s.timeSource.now = s.timeSource.now.Add(2 * time.Minute)
+	s.detector.recordFailure(s.domainEntry, stickyPoisonTestChecksum)
+	s.detector.recordFailure(s.domainEntry, stickyPoisonTestChecksum)
+	s.mockMetadataMgr.AssertNotCalled(s.T(), "UpdateDomain", mock.Anything)
+
+	// the failures of other binaries are counted apart
+	s.detector.recordFailure(s.domainEntry, "other-binary")
+	s.mockMetadataMgr.AssertNotCalled(s.T(), "UpdateDomain", mock.Anything)
+}
+
+func (s *stickyPoisonDetectorSuite) TestThresholdSplitBetweenHosts() {
+	s.historyHosts = append(s.historyHosts, membership.NewHostInfo("history-host-1", nil))
+	s.mockMetadataMgr.On("UpdateDomain", mock.Anything).Return(nil).Once()
+
+	// a threshold of 3 is 2 failures on each of the 2 hosts, rounded up
+	s.detector.recordFailure(s.domainEntry, stickyPoisonTestChecksum)
+	s.mockMetadataMgr.AssertNotCalled(s.T(), "UpdateDomain", mock.Anything)
+	s.detector.recordFailure(s.domainEntry, stickyPoisonTestChecksum)
+	s.mockMetadataMgr.AssertExpectations(s.T())
+}
+
+func (s *stickyPoisonDetectorSuite) TestGlobalDomainMarkedThroughMasterCluster() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+	mockFrontend := workflowservicetest.NewMockClient(ctrl)
+	s.clientFactory.frontendClient = mockFrontend
+	s.mockCluster.On("GetMasterClusterName").Return(cluster.TestAlternativeClusterName)
+	s.mockCluster.On("GetAllClientAddress").Return(map[string]config.Address{
+		cluster.TestAlternativeClusterName: {RPCName: common.FrontendServiceName, RPCAddress: "master-frontend"},
+	})
+	s.domainResponse.IsGlobalDomain = true
+	s.domainResponse.Config.SuspectBinaryChecksums = []string{"other-binary"}
+
+	// the update is made by the master cluster, which replicates it
+	mockFrontend.EXPECT().UpdateDomain(gomock.Any(), &shared.UpdateDomainRequest{
+		Name: common.StringPtr("sticky-poison-domain"),
+		Configuration: &shared.DomainConfiguration{
+			SuspectBinaryChecksums: []string{"other-binary", stickyPoisonTestChecksum},
+		},
+	}).Return(&shared.UpdateDomainResponse{}, nil).Times(1)
+	for i := 0; i < 3; i++ {
+		s.detector.recordFailure(s.domainEntry, stickyPoisonTestChecksum)
+	}
+	s.mockMetadataMgr.AssertNotCalled(s.T(), "UpdateDomain", mock.Anything)
+	s.Equal([]string{"master-frontend"}, s.clientFactory.addresses)
+}
+
+func (s *stickyPoisonDetectorSuite) TestNoChecksumOrAlreadySuspect() {
+	for i := 0; i < 3; i++ {
+		s.detector.recordFailure(s.domainEntry, "")
+	}
+	s.domainEntry.GetConfig().SuspectBinaryChecksums = []string{stickyPoisonTestChecksum}
+	for i := 0; i < 3; i++ {
+		s.detector.recordFailure(s.domainEntry, stickyPoisonTestChecksum)
+	}
+	s.mockMetadataMgr.AssertNotCalled(s.T(), "UpdateDomain", mock.Anything)
+	s.True(s.domainEntry.IsBinaryChecksumSuspect(stickyPoisonTestChecksum))
+	s.False(s.domainEntry.IsBinaryChecksumSuspect("other-binary"))
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"time"
+
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	// TaskQueueTransfer is the queue of transfer tasks
+	TaskQueueTransfer TaskQueue = iota
+	// TaskQueueTimer is the queue of timer tasks
+	TaskQueueTimer
+)
+
+type (
+	// TaskQueue identifies the queue a task is processed from
+	TaskQueue int
+
+	// TaskInterceptorInfo describes a transfer or timer task handed to the task interceptors
+	TaskInterceptorInfo struct {
+		ShardID             int
+		Queue               TaskQueue
+		DomainID            string
+		WorkflowID          string
+		RunID               string
+		TaskID              int64
+		TaskType            int
+		Version             int64
+		VisibilityTimestamp time.Time
+		// Attempt is the number of failed processing attempts of the task so far
+		Attempt int
+	}
+
+	// TaskInterceptor is invoked around every processing attempt of a transfer or timer task.
+	// An interceptor runs its pre processing logic, calls next to hand the task down the chain
+	// and gets back the outcome of the processing, which it is expected to return, possibly replaced.
+	// Interceptors are registered when the history service is created and are run in registration order.
+	TaskInterceptor func(info *TaskInterceptorInfo, next func() error) error
+)
+
+func (q TaskQueue) String() string {
+	switch q {
+	case TaskQueueTransfer:
+		return "transfer"
+	case TaskQueueTimer:
+		return "timer"
+	default:
+		return "unknown"
+	}
+}
+
+// newTaskInterceptorInfo returns the interceptor view of the given task, or nil for
+// tasks which are not subject to interception, e.g. replication tasks
+func newTaskInterceptorInfo(shardID int, task queueTaskInfo, attempt int) *TaskInterceptorInfo {
+	switch t := task.(type) {
+	case *persistence.TransferTaskInfo:
+		return &TaskInterceptorInfo{
+			ShardID:             shardID,
+			Queue:               TaskQueueTransfer,
+			DomainID:            t.DomainID,
+			WorkflowID:          t.WorkflowID,
+			RunID:               t.RunID,
+			TaskID:              t.TaskID,
+			TaskType:            t.TaskType,
+			Version:             t.Version,
+			VisibilityTimestamp: t.VisibilityTimestamp,
+			Attempt:             attempt,
+		}
+	case *persistence.TimerTaskInfo:
+		return &TaskInterceptorInfo{
+			ShardID:             shardID,
+			Queue:               TaskQueueTimer,
+			DomainID:            t.DomainID,
+			WorkflowID:          t.WorkflowID,
+			RunID:               t.RunID,
+			TaskID:              t.TaskID,
+			TaskType:            t.TaskType,
+			Version:             t.Version,
+			VisibilityTimestamp: t.VisibilityTimestamp,
+			Attempt:             attempt,
+		}
+	default:
+		return nil
+	}
+}
+
+// interceptTask runs process through the given interceptor chain, the first interceptor being the outermost
+func interceptTask(interceptors []TaskInterceptor, info *TaskInterceptorInfo, process func() error) error {
+	if len(interceptors) == 0 || info == nil {
+		return process()
+	}
+
+	var next func(index int) error
+	next = func(index int) error {
+		if index == len(interceptors) {
+			return process()
+		}
+		return interceptors[index](info, func() error {
+			return next(index + 1)
+		})
+	}
+	return next(0)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	taskInterceptorSuite struct {
+		suite.Suite
+	}
+)
+
+func TestTaskInterceptorSuite(t *testing.T) {
+	s := new(taskInterceptorSuite)
+	suite.Run(t, s)
+}
+
+func (s *taskInterceptorSuite) TestChainOrderAndOutcome() {
+	var calls []string
+	record := func(name string) TaskInterceptor {
+		return func(info *TaskInterceptorInfo, next func() error) error {
+			calls = append(calls, "pre-"+name)
+			err := next()
+			calls = append(calls, "post-"+name+":"+err.Error())
+			return err
+		}
+	}
+	processErr := errors.New("process")
+	info := newTaskInterceptorInfo(1, &persistence.TransferTaskInfo{TaskID: 5}, 0)
+
+	err := interceptTask([]TaskInterceptor{record("a"), record("b")}, info, func() error {
+		calls = append(calls, "process")
+		return processErr
+	})
+	s.Equal(processErr, err)
+	s.Equal([]string{"pre-a", "pre-b", "process", "post-b:process", "post-a:process"}, calls)
+}
+
+func (s *taskInterceptorSuite) TestInterceptorShortCircuits() {
+	skipErr := errors.New("throttled")
+	processed := false
+	throttle := func(info *TaskInterceptorInfo, next func() error) error {
+		return skipErr
+	}
+	info := newTaskInterceptorInfo(1, &persistence.TimerTaskInfo{TaskID: 5}, 0)
+
+	err := interceptTask([]TaskInterceptor{throttle}, info, func() error {
+		processed = true
+		return nil
+	})
+	s.Equal(skipErr, err)
+	s.False(processed)
+}
+
+func (s *taskInterceptorSuite) TestReplicationTasksNotIntercepted() {
+	intercepted := false
+	interceptor := func(info *TaskInterceptorInfo, next func() error) error {
+		intercepted = true
+		return next()
+	}
+	info := newTaskInterceptorInfo(1, &persistence.ReplicationTaskInfo{TaskID: 5}, 0)
+	s.Nil(info)
+
+	err := interceptTask([]TaskInterceptor{interceptor}, info, func() error { return nil })
+	s.NoError(err)
+	s.False(intercepted)
+}
+
+func (s *taskInterceptorSuite) TestInfo() {
+	info := newTaskInterceptorInfo(3, &persistence.TimerTaskInfo{
+		DomainID:   "domain",
+		WorkflowID: "wid",
+		RunID:      "rid",
+		TaskID:     7,
+		TaskType:   persistence.TaskTypeUserTimer,
+		Version:    2,
+	}, 1)
+	s.Equal(3, info.ShardID)
+	s.Equal(TaskQueueTimer, info.Queue)
+	s.Equal("timer", info.Queue.String())
+	s.Equal("domain", info.DomainID)
+	s.Equal("wid", info.WorkflowID)
+	s.Equal("rid", info.RunID)
+	s.Equal(int64(7), info.TaskID)
+	s.Equal(persistence.TaskTypeUserTimer, info.TaskType)
+	s.Equal(int64(2), info.Version)
+	s.Equal(1, info.Attempt)
+}
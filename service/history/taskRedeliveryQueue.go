@@ -0,0 +1,131 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+)
+
+type (
+	// taskRedeliveryQueue holds the standby tasks which cannot be verified yet, since the mutable state they are
+	// checked against has not been replicated, and hands them back for processing once their backoff expires.
+	// Each time the same task is deferred again, it backs off further.
+	taskRedeliveryQueue struct {
+		sync.Mutex
+		retryPolicy backoff.RetryPolicy
+		maxInterval time.Duration
+		timeSource  common.TimeSource
+		attempts    map[int64]int
+		pending     map[int64]*redeliveryTask
+	}
+
+	redeliveryTask struct {
+		task      queueTaskInfo
+		deliverAt time.Time
+	}
+)
+
+func newTaskRedeliveryQueue(initialInterval time.Duration, maxInterval time.Duration,
+	timeSource common.TimeSource) *taskRedeliveryQueue {
+
+	policy := backoff.NewExponentialRetryPolicy(initialInterval)
+	policy.SetMaximumInterval(maxInterval)
+	policy.SetExpirationInterval(backoff.NoInterval)
+
+	return &taskRedeliveryQueue{
+		retryPolicy: policy,
+		maxInterval: maxInterval,
+		timeSource:  timeSource,
+		attempts:    make(map[int64]int),
+		pending:     make(map[int64]*redeliveryTask),
+	}
+}
+
+// add defers the task, returning how long it will wait before being redelivered
+func (q *taskRedeliveryQueue) add(task queueTaskInfo) time.Duration {
+	q.Lock()
+	defer q.Unlock()
+
+	taskID := task.GetTaskID()
+	attempt := q.attempts[taskID]
+	q.attempts[taskID] = attempt + 1
+
+	delay := q.retryPolicy.ComputeNextDelay(0, attempt)
+	if delay < 0 {
+		delay = q.maxInterval
+	}
+	q.pending[taskID] = &redeliveryTask{
+		task:      task,
+		deliverAt: q.timeSource.Now().Add(delay),
+	}
+	return delay
+}
+
+// remove forgets about the task, it should be called once the task does not need to be retried anymore
+func (q *taskRedeliveryQueue) remove(taskID int64) {
+	q.Lock()
+	defer q.Unlock()
+
+	delete(q.attempts, taskID)
+	delete(q.pending, taskID)
+}
+
+// getDueTasks removes and returns, in task ID order, the deferred tasks whose backoff has expired
+func (q *taskRedeliveryQueue) getDueTasks() []queueTaskInfo {
+	q.Lock()
+	defer q.Unlock()
+
+	now := q.timeSource.Now()
+	var tasks []queueTaskInfo
+	for taskID, pending := range q.pending {
+		if !pending.deliverAt.After(now) {
+			tasks = append(tasks, pending.task)
+			delete(q.pending, taskID)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].GetTaskID() < tasks[j].GetTaskID()
+	})
+	return tasks
+}
+
+// redeliverAll makes all deferred tasks due immediately, e.g. after a domain failover
+func (q *taskRedeliveryQueue) redeliverAll() {
+	q.Lock()
+	defer q.Unlock()
+
+	now := q.timeSource.Now()
+	for _, pending := range q.pending {
+		pending.deliverAt = now
+	}
+}
+
+func (q *taskRedeliveryQueue) size() int {
+	q.Lock()
+	defer q.Unlock()
+
+	return len(q.pending)
+}
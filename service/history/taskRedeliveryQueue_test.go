@@ -0,0 +1,96 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	taskRedeliveryQueueSuite struct {
+		suite.Suite
+		timeSource *common.EventTimeSource
+		queue      *taskRedeliveryQueue
+	}
+)
+
+func TestTaskRedeliveryQueueSuite(t *testing.T) {
+	s := new(taskRedeliveryQueueSuite)
+	suite.Run(t, s)
+}
+
+func (s *taskRedeliveryQueueSuite) SetupTest() {
+	s.timeSource = common.NewEventTimeSource().Update(time.Now())
+	s.queue = newTaskRedeliveryQueue(time.Second, 4*time.Second, s.timeSource)
+}
+
+func (s *taskRedeliveryQueueSuite) advance(d time.Duration) {
+	s.timeSource.Update(s.timeSource.Now().Add(d))
+}
+
+func (s *taskRedeliveryQueueSuite) TestBackoffIncreasesPerTask() {
+	task := &persistence.TransferTaskInfo{TaskID: 10}
+
+	delay := s.queue.add(task)
+	s.True(delay <= time.Second)
+	s.Empty(s.queue.getDueTasks())
+	s.advance(time.Second)
+	s.Equal([]queueTaskInfo{task}, s.queue.getDueTasks())
+	s.Equal(0, s.queue.size())
+
+	// deferred a second time, the task backs off further
+	delay = s.queue.add(task)
+	s.True(delay > time.Second)
+	s.advance(time.Second)
+	s.Empty(s.queue.getDueTasks())
+	s.advance(time.Second)
+	s.Equal([]queueTaskInfo{task}, s.queue.getDueTasks())
+
+	// the backoff is capped
+	for i := 0; i < 10; i++ {
+		delay = s.queue.add(task)
+	}
+	s.True(delay <= 4*time.Second)
+
+	// once removed, the task starts over with the initial backoff
+	s.queue.remove(task.TaskID)
+	s.Equal(0, s.queue.size())
+	s.True(s.queue.add(task) <= time.Second)
+}
+
+func (s *taskRedeliveryQueueSuite) TestDueTasksInTaskIDOrder() {
+	task1 := &persistence.TimerTaskInfo{TaskID: 3}
+	task2 := &persistence.TimerTaskInfo{TaskID: 1}
+	task3 := &persistence.TimerTaskInfo{TaskID: 2}
+	s.queue.add(task1)
+	s.queue.add(task2)
+	s.queue.add(task3)
+	s.Equal(3, s.queue.size())
+
+	s.queue.redeliverAll()
+	s.Equal([]queueTaskInfo{task2, task3, task1}, s.queue.getDueTasks())
+	s.Equal(0, s.queue.size())
+}
@@ -180,7 +180,7 @@ func (tb *timerBuilder) AddActivityTimeoutTask(scheduleID int64,
 }
 
 // AddUserTimer - Adds an user timeout request.
-func (tb *timerBuilder) AddUserTimer(ti *persistence.TimerInfo, msBuilder mutableState) {
+func (tb *timerBuilder) AddUserTimer(ti *persistence.TimerInfo, msBuilder MutableState) {
 	if !tb.isLoadedUserTimers {
 		tb.loadUserTimers(msBuilder)
 	}
@@ -194,7 +194,7 @@ func (tb *timerBuilder) AddUserTimer(ti *persistence.TimerInfo, msBuilder mutabl
 }
 
 // GetUserTimerTaskIfNeeded - if we need create a timer task for the user timers
-func (tb *timerBuilder) GetUserTimerTaskIfNeeded(msBuilder mutableState) persistence.Task {
+func (tb *timerBuilder) GetUserTimerTaskIfNeeded(msBuilder MutableState) persistence.Task {
 	if !tb.isLoadedUserTimers {
 		return nil
 	}
@@ -210,7 +210,7 @@ func (tb *timerBuilder) GetUserTimerTaskIfNeeded(msBuilder mutableState) persist
 }
 
 // GetUserTimers - Get all user timers.
-func (tb *timerBuilder) GetUserTimers(msBuilder mutableState) timers {
+func (tb *timerBuilder) GetUserTimers(msBuilder MutableState) timers {
 	tb.loadUserTimers(msBuilder)
 	return tb.userTimers
 }
@@ -228,13 +228,13 @@ func (tb *timerBuilder) IsTimerExpired(td *timerDetails, referenceTime time.Time
 	return expiry <= referenceTime.Unix()
 }
 
-func (tb *timerBuilder) GetActivityTimers(msBuilder mutableState) timers {
+func (tb *timerBuilder) GetActivityTimers(msBuilder MutableState) timers {
 	tb.loadActivityTimers(msBuilder)
 	return tb.activityTimers
 }
 
 // GetActivityTimerTaskIfNeeded - if we need create a activity timer task for the activities
-func (tb *timerBuilder) GetActivityTimerTaskIfNeeded(msBuilder mutableState) persistence.Task {
+func (tb *timerBuilder) GetActivityTimerTaskIfNeeded(msBuilder MutableState) persistence.Task {
 	if !tb.isLoadedActivityTimers {
 		tb.loadActivityTimers(msBuilder)
 	}
@@ -255,7 +255,7 @@ func (tb *timerBuilder) GetActivityTimerTaskIfNeeded(msBuilder mutableState) per
 }
 
 // loadUserTimers - Load all user timers from mutable state.
-func (tb *timerBuilder) loadUserTimers(msBuilder mutableState) {
+func (tb *timerBuilder) loadUserTimers(msBuilder MutableState) {
 	tb.pendingUserTimers = msBuilder.GetPendingTimerInfos()
 	tb.userTimers = make(timers, 0, len(tb.pendingUserTimers))
 	for _, v := range tb.pendingUserTimers {
@@ -270,7 +270,7 @@ func (tb *timerBuilder) loadUserTimers(msBuilder mutableState) {
 	tb.isLoadedUserTimers = true
 }
 
-func (tb *timerBuilder) loadActivityTimers(msBuilder mutableState) {
+func (tb *timerBuilder) loadActivityTimers(msBuilder MutableState) {
 	tb.pendingActivityTimers = msBuilder.GetPendingActivityInfos()
 	tb.activityTimers = make(timers, 0, len(tb.pendingActivityTimers))
 	for _, v := range tb.pendingActivityTimers {
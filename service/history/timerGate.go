@@ -51,11 +51,49 @@ type (
 		// the channel which will be used to proxy the fired timer
 		fireChan  chan struct{}
 		closeChan chan struct{}
+		closeOnce sync.Once
 
+		// wall and monotonic clocks of the gate, the wake up times are compared against the wall clock
+		clock gateClock
+		// wall clock jumps larger than this re-arm the timer, nil disables the detection
+		maxClockSkew func() time.Duration
+		onClockJump  func(jump time.Duration)
+
+		// lock for timer and next wake up time
+		sync.Mutex
 		// the actual timer which will fire
-		timer *time.Timer
+		timer gateTimer
 		// variable indicating when the above timer will fire
 		nextWakeupTime time.Time
+		// wall and monotonic clock readings of when the timer was last armed
+		armedWall      time.Time
+		armedMonotonic time.Time
+	}
+
+	// gateClock is the source of time of a local timer gate, it is mocked in tests
+	gateClock interface {
+		// Now returns the wall clock time
+		Now() time.Time
+		// Monotonic returns a reading of the monotonic clock, the durations between such readings
+		// are not affected by jumps of the wall clock
+		Monotonic() time.Time
+		// NewTimer returns a stopped timer
+		NewTimer() gateTimer
+		// NewTicker returns the channel of a ticker and the func stopping it
+		NewTicker(d time.Duration) (<-chan time.Time, func())
+	}
+
+	// gateTimer is the subset of time.Timer used by a local timer gate
+	gateTimer interface {
+		Chan() <-chan time.Time
+		Stop() bool
+		Reset(d time.Duration) bool
+	}
+
+	realGateClock struct{}
+
+	realGateTimer struct {
+		*time.Timer
 	}
 
 	// RemoteTimerGate interface
@@ -85,27 +123,94 @@ type (
 
 // NewLocalTimerGate create a new timer gate instance
 func NewLocalTimerGate() LocalTimerGate {
+	return newLocalTimerGate(realGateClock{}, 0, nil, nil)
+}
+
+// NewLocalTimerGateWithClockJumpDetection create a new timer gate instance which watches the wall clock for
+// jumps larger than maxClockSkew, e.g. NTP corrections. The underlying timer runs on the monotonic clock,
+// so on a jump the timer is re-armed against the wall clock: it neither fires early and spins after
+// a backward jump nor stalls after a forward one. onClockJump is called with the size of every such jump.
+func NewLocalTimerGateWithClockJumpDetection(maxClockSkew func() time.Duration, onClockJump func(jump time.Duration)) LocalTimerGate {
+	return newLocalTimerGate(realGateClock{}, clockJumpCheckInterval, maxClockSkew, onClockJump)
+}
+
+const clockJumpCheckInterval = time.Second
+
+// Now returns the current time stripped of its monotonic clock reading,
+// so durations computed from it follow the wall clock
+func (realGateClock) Now() time.Time {
+	return time.Now().Round(0)
+}
+
+func (realGateClock) Monotonic() time.Time {
+	return time.Now()
+}
+
+func (realGateClock) NewTimer() gateTimer {
+	timer := time.NewTimer(0)
+	// the timer should be stopped when initialized
+	if !timer.Stop() {
+		// drain the existing signal if exist
+		<-timer.C
+	}
+	return realGateTimer{Timer: timer}
+}
+
+func (realGateClock) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	ticker := time.NewTicker(d)
+	return ticker.C, ticker.Stop
+}
+
+func (t realGateTimer) Chan() <-chan time.Time {
+	return t.C
+}
+
+func newLocalTimerGate(clock gateClock, checkInterval time.Duration,
+	maxClockSkew func() time.Duration, onClockJump func(jump time.Duration)) *LocalTimerGateImpl {
 	timer := &LocalTimerGateImpl{
-		timer:          time.NewTimer(0),
+		timer:          clock.NewTimer(),
 		nextWakeupTime: time.Time{},
 		fireChan:       make(chan struct{}),
 		closeChan:      make(chan struct{}),
-	}
-	// the timer should be stopped when initialized
-	if !timer.timer.Stop() {
-		// drain the existing signal if exist
-		<-timer.timer.C
+		clock:          clock,
+		maxClockSkew:   maxClockSkew,
+		onClockJump:    onClockJump,
 	}
 
 	go func() {
 		defer close(timer.fireChan)
 		defer timer.timer.Stop()
+
+		var checkChan <-chan time.Time
+		if maxClockSkew != nil && checkInterval > 0 {
+			var stopTicker func()
+			checkChan, stopTicker = clock.NewTicker(checkInterval)
+			defer stopTicker()
+		}
+		lastCheckMonotonic := clock.Monotonic()
+		lastCheckWall := clock.Now()
 	loop:
 		for {
 			select {
-			case <-timer.timer.C:
+			case <-timer.timer.Chan():
+				if timer.rearmIfEarly() {
+					continue loop
+				}
 				// re-transmit on gateC
-				timer.fireChan <- struct{}{}
+				select {
+				case timer.fireChan <- struct{}{}:
+				case <-timer.closeChan:
+					break loop
+				}
+
+			case <-checkChan:
+				nowMonotonic, nowWall := clock.Monotonic(), clock.Now()
+				jump := nowWall.Sub(lastCheckWall) - nowMonotonic.Sub(lastCheckMonotonic)
+				lastCheckMonotonic, lastCheckWall = nowMonotonic, nowWall
+				if timer.exceedsClockSkew(jump) {
+					timer.rearm()
+					timer.onClockJump(jump)
+				}
 
 			case <-timer.closeChan:
 				// closed; cleanup and quit
@@ -117,6 +222,53 @@ func NewLocalTimerGate() LocalTimerGate {
 	return timer
 }
 
+// rearmIfEarly re-arms the fired timer if the wall clock jumped backward since the timer was armed
+// and the wake up time is not reached yet according to the wall clock
+func (timerGate *LocalTimerGateImpl) rearmIfEarly() bool {
+	if timerGate.maxClockSkew == nil {
+		return false
+	}
+
+	timerGate.Lock()
+	now := timerGate.clock.Now()
+	jump := now.Sub(timerGate.armedWall) - timerGate.clock.Monotonic().Sub(timerGate.armedMonotonic)
+	rearm := timerGate.exceedsClockSkew(jump) && timerGate.nextWakeupTime.After(now)
+	if rearm {
+		timerGate.reset(timerGate.nextWakeupTime.Sub(now))
+	}
+	timerGate.Unlock()
+
+	if rearm {
+		timerGate.onClockJump(jump)
+	}
+	return rearm
+}
+
+// rearm resets a pending timer to the wake up time according to the current wall clock
+func (timerGate *LocalTimerGateImpl) rearm() {
+	timerGate.Lock()
+	defer timerGate.Unlock()
+
+	// a timer which already fired is checked by rearmIfEarly when its signal is received
+	if timerGate.timer.Stop() {
+		timerGate.reset(timerGate.nextWakeupTime.Sub(timerGate.clock.Now()))
+	}
+}
+
+// reset arms the timer, the lock must be held
+func (timerGate *LocalTimerGateImpl) reset(d time.Duration) {
+	timerGate.timer.Reset(d)
+	timerGate.armedWall = timerGate.clock.Now()
+	timerGate.armedMonotonic = timerGate.clock.Monotonic()
+}
+
+func (timerGate *LocalTimerGateImpl) exceedsClockSkew(jump time.Duration) bool {
+	if jump < 0 {
+		jump = -jump
+	}
+	return jump > timerGate.maxClockSkew()
+}
+
 // FireChan return the channel which will be fired when time is up
 func (timerGate *LocalTimerGateImpl) FireChan() <-chan struct{} {
 	return timerGate.fireChan
@@ -124,32 +276,40 @@ func (timerGate *LocalTimerGateImpl) FireChan() <-chan struct{} {
 
 // FireAfter check will the timer get fired after a certain time
 func (timerGate *LocalTimerGateImpl) FireAfter(now time.Time) bool {
+	timerGate.Lock()
+	defer timerGate.Unlock()
+
 	return timerGate.nextWakeupTime.After(now)
 }
 
 // Update update the timer gate, return true if update is a success
 // success means timer is idle or timer is set with a sooner time to fire
 func (timerGate *LocalTimerGateImpl) Update(nextTime time.Time) bool {
+	timerGate.Lock()
+	defer timerGate.Unlock()
+
 	// NOTE: negative duration will make the timer fire immediately
-	now := time.Now()
+	now := timerGate.clock.Now()
 
 	if timerGate.timer.Stop() && timerGate.nextWakeupTime.Before(nextTime) {
 		// this means the timer, before stopped, is active && next wake up time do not have to be updated
-		timerGate.timer.Reset(timerGate.nextWakeupTime.Sub(now))
+		timerGate.reset(timerGate.nextWakeupTime.Sub(now))
 		return false
 	}
 
 	// this means the timer, before stopped, is active && next wake up time has to be updated
 	// or this means the timer, before stopped, is already fired / never active
 	timerGate.nextWakeupTime = nextTime
-	timerGate.timer.Reset(nextTime.Sub(now))
+	timerGate.reset(nextTime.Sub(now))
 	// Notifies caller that next notification is reset to fire at passed in 'next' visibility time
 	return true
 }
 
-// Close shutdown the timer
+// Close shutdown the timer, it is safe to call more than once
 func (timerGate *LocalTimerGateImpl) Close() {
-	close(timerGate.closeChan)
+	timerGate.closeOnce.Do(func() {
+		close(timerGate.closeChan)
+	})
 }
 
 // NewRemoteTimerGate create a new timer gate instance
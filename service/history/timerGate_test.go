@@ -22,6 +22,7 @@ package history
 
 import (
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -40,8 +41,104 @@ type (
 		currentTime     time.Time
 		remoteTimerGate RemoteTimerGate
 	}
+
+	// mockGateClock is a gate clock whose wall and monotonic clocks only move when told to
+	mockGateClock struct {
+		sync.Mutex
+		wall      time.Time
+		monotonic time.Time
+		timer     *mockGateTimer
+		ticks     chan time.Time
+	}
+
+	// mockGateTimer is a gate timer which only fires when told to
+	mockGateTimer struct {
+		sync.Mutex
+		c        chan time.Time
+		active   bool
+		duration time.Duration
+	}
 )
 
+func newMockGateClock() *mockGateClock {
+	now := time.Unix(1000, 0)
+	return &mockGateClock{
+		wall:      now,
+		monotonic: now,
+		timer:     &mockGateTimer{c: make(chan time.Time, 1)},
+		ticks:     make(chan time.Time),
+	}
+}
+
+func (c *mockGateClock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+	return c.wall
+}
+
+func (c *mockGateClock) Monotonic() time.Time {
+	c.Lock()
+	defer c.Unlock()
+	return c.monotonic
+}
+
+func (c *mockGateClock) NewTimer() gateTimer {
+	return c.timer
+}
+
+func (c *mockGateClock) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	return c.ticks, func() {}
+}
+
+// advance moves the wall and the monotonic clocks, the difference of the two is a jump of the wall clock
+func (c *mockGateClock) advance(wall time.Duration, monotonic time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	c.wall = c.wall.Add(wall)
+	c.monotonic = c.monotonic.Add(monotonic)
+}
+
+// tick triggers the periodic clock jump check, it returns once the gate received the tick
+func (c *mockGateClock) tick() {
+	c.ticks <- c.Now()
+}
+
+func (t *mockGateTimer) Chan() <-chan time.Time {
+	return t.c
+}
+
+func (t *mockGateTimer) Stop() bool {
+	t.Lock()
+	defer t.Unlock()
+	active := t.active
+	t.active = false
+	return active
+}
+
+func (t *mockGateTimer) Reset(d time.Duration) bool {
+	t.Lock()
+	defer t.Unlock()
+	active := t.active
+	t.active = true
+	t.duration = d
+	return active
+}
+
+// fire fires the armed timer
+func (t *mockGateTimer) fire() {
+	t.Lock()
+	defer t.Unlock()
+	t.active = false
+	t.c <- time.Time{}
+}
+
+// armedFor returns the duration the timer was last armed for
+func (t *mockGateTimer) armedFor() time.Duration {
+	t.Lock()
+	defer t.Unlock()
+	return t.duration
+}
+
 func BenchmarkLocalTimer(b *testing.B) {
 	timer := NewLocalTimerGate()
 
@@ -218,6 +315,80 @@ func (s *localTimerGateSuite) TestTimerWillFire() {
 	s.False(s.localTimerGate.FireAfter(timeAfterNewTimer))
 }
 
+// newJumpingTimerGate returns a timer gate running on a mock clock, and the channel of the clock jumps it reports
+func (s *localTimerGateSuite) newJumpingTimerGate() (*LocalTimerGateImpl, *mockGateClock, chan time.Duration) {
+	clock := newMockGateClock()
+	jumps := make(chan time.Duration, 10)
+	gate := newLocalTimerGate(clock, time.Second,
+		func() time.Duration { return 100 * time.Millisecond },
+		func(jump time.Duration) { jumps <- jump },
+	)
+	return gate, clock, jumps
+}
+
+func (s *localTimerGateSuite) TestClockJumpBackward() {
+	gate, clock, jumps := s.newJumpingTimerGate()
+	defer gate.Close()
+
+	wakeupTime := clock.Now().Add(200 * time.Millisecond)
+	gate.Update(wakeupTime)
+	s.Equal(200*time.Millisecond, clock.timer.armedFor())
+
+	// the monotonic timer fires on time but the wall clock jumped a second back meanwhile
+	clock.advance(-800*time.Millisecond, 200*time.Millisecond)
+	clock.timer.fire()
+	s.Equal(-time.Second, <-jumps)
+	s.Equal(time.Second, clock.timer.armedFor())
+	select {
+	case <-gate.FireChan():
+		s.Fail("timer should not fire before the wall clock reaches the wake up time")
+	default:
+	}
+
+	clock.advance(time.Second, time.Second)
+	clock.timer.fire()
+	<-gate.FireChan()
+	s.False(gate.FireAfter(wakeupTime))
+}
+
+func (s *localTimerGateSuite) TestClockJumpForward() {
+	gate, clock, jumps := s.newJumpingTimerGate()
+	defer gate.Close()
+
+	gate.Update(clock.Now().Add(5 * time.Second))
+	s.Equal(5*time.Second, clock.timer.armedFor())
+
+	// the wall clock passes the wake up time, the periodic check re-arms the timer to fire right away
+	clock.advance(5*time.Second, time.Second)
+	clock.tick()
+	s.Equal(4*time.Second, <-jumps)
+	s.Equal(time.Duration(0), clock.timer.armedFor())
+
+	clock.timer.fire()
+	<-gate.FireChan()
+	s.Empty(jumps)
+}
+
+func (s *localTimerGateSuite) TestCloseTwice() {
+	gate := NewLocalTimerGate()
+	gate.Close()
+	gate.Close()
+}
+
+func (s *localTimerGateSuite) TestNoClockJump() {
+	gate, clock, jumps := s.newJumpingTimerGate()
+	defer gate.Close()
+
+	gate.Update(clock.Now().Add(100 * time.Millisecond))
+	clock.advance(time.Second, time.Second)
+	clock.tick()
+	s.Equal(100*time.Millisecond, clock.timer.armedFor())
+
+	clock.timer.fire()
+	<-gate.FireChan()
+	s.Empty(jumps)
+}
+
 func (s *remoteTimerGateSuite) TestTimerFire() {
 	now := s.currentTime
 	newTimer := now.Add(1 * time.Second)
@@ -21,6 +21,7 @@
 package history
 
 import (
+	"fmt"
 	"math"
 	"sort"
 	"sync"
@@ -40,6 +41,7 @@ type (
 
 	timerQueueAckMgrImpl struct {
 		scope               int
+		queueName           string
 		isFailover          bool
 		shard               ShardContext
 		executionMgr        persistence.ExecutionManager
@@ -78,7 +80,7 @@ type (
 	// TODO this processing logic potentially has bug, refer to #605, #608
 )
 
-var _ timerQueueAckMgr = (*timerQueueAckMgrImpl)(nil)
+var _ TimerQueueAckMgr = (*timerQueueAckMgrImpl)(nil)
 
 // Len implements sort.Interace
 func (t timerSequenceIDs) Len() int {
@@ -95,13 +97,14 @@ func (t timerSequenceIDs) Less(i, j int) bool {
 	return compareTimerIDLess(&t[i], &t[j])
 }
 
-func newTimerQueueAckMgr(scope int, shard ShardContext, metricsClient metrics.Client,
+func newTimerQueueAckMgr(scope int, queueName string, shard ShardContext, metricsClient metrics.Client,
 	minLevel time.Time, timeNow timeNow, updateTimerAckLevel updateTimerAckLevel, logger bark.Logger) *timerQueueAckMgrImpl {
 	ackLevel := TimerSequenceID{VisibilityTimestamp: minLevel}
 	maxQueryLevel := timerQueueAckMgrMaxQueryLevel
 
 	timerQueueAckMgrImpl := &timerQueueAckMgrImpl{
 		scope:               scope,
+		queueName:           queueName,
 		isFailover:          false,
 		shard:               shard,
 		executionMgr:        shard.GetExecutionManager(),
@@ -154,11 +157,11 @@ func newTimerQueueFailoverAckMgr(shard ShardContext, metricsClient metrics.Clien
 	return timerQueueAckMgrImpl
 }
 
-func (t *timerQueueAckMgrImpl) getFinishedChan() <-chan struct{} {
+func (t *timerQueueAckMgrImpl) GetFinishedChan() <-chan struct{} {
 	return t.finishedChan
 }
 
-func (t *timerQueueAckMgrImpl) readTimerTasks() ([]*persistence.TimerTaskInfo, *persistence.TimerTaskInfo, bool, error) {
+func (t *timerQueueAckMgrImpl) ReadTimerTasks() ([]*persistence.TimerTaskInfo, *persistence.TimerTaskInfo, bool, error) {
 	t.Lock()
 	minQueryLevel := t.minQueryLevel
 	maxQueryLevel := t.maxQueryLevel
@@ -229,7 +232,7 @@ TaskFilterLoop:
 	return filteredTasks, lookAheadTask, moreTasks, nil
 }
 
-func (t *timerQueueAckMgrImpl) completeTimerTask(timerTask *persistence.TimerTaskInfo) {
+func (t *timerQueueAckMgrImpl) CompleteTimerTask(timerTask *persistence.TimerTaskInfo) {
 	timerSequenceID := TimerSequenceID{VisibilityTimestamp: timerTask.VisibilityTimestamp, TaskID: timerTask.TaskID}
 	t.Lock()
 	defer t.Unlock()
@@ -237,23 +240,24 @@ func (t *timerQueueAckMgrImpl) completeTimerTask(timerTask *persistence.TimerTas
 	t.outstandingTasks[timerSequenceID] = true
 }
 
-func (t *timerQueueAckMgrImpl) getAckLevel() TimerSequenceID {
+func (t *timerQueueAckMgrImpl) GetAckLevel() TimerSequenceID {
 	t.Lock()
 	defer t.Unlock()
 	return t.ackLevel
 }
 
-func (t *timerQueueAckMgrImpl) getReadLevel() TimerSequenceID {
+func (t *timerQueueAckMgrImpl) GetReadLevel() TimerSequenceID {
 	t.Lock()
 	defer t.Unlock()
 	return t.readLevel
 }
 
-func (t *timerQueueAckMgrImpl) updateAckLevel() {
+func (t *timerQueueAckMgrImpl) UpdateAckLevel() {
 	t.metricsClient.IncCounter(t.scope, metrics.AckLevelUpdateCounter)
 
 	t.Lock()
 	ackLevel := t.ackLevel
+	previousAckLevel := ackLevel
 	outstandingTasks := t.outstandingTasks
 
 	t.logger.Debugf("Moving timer ack level from %v, with %v.", ackLevel, outstandingTasks)
@@ -279,6 +283,17 @@ MoveAckLevelLoop:
 	}
 	t.ackLevel = ackLevel
 
+	// the oldest outstanding timer which is already due determines the backlog age of the queue
+	var backlogAge time.Duration
+	for _, current := range sequenceIDs {
+		if _, ok := outstandingTasks[current]; ok {
+			if age := t.timeNow().Sub(current.VisibilityTimestamp); age > 0 {
+				backlogAge = age
+			}
+			break
+		}
+	}
+
 	if t.isFailover && t.isReadFinished && len(outstandingTasks) == 0 {
 		t.Unlock()
 		// this means in failover mode, all possible failover timer tasks
@@ -290,6 +305,13 @@ MoveAckLevelLoop:
 	}
 
 	t.Unlock()
+	if t.queueName != "" {
+		t.shard.UpdateQueueBacklogAge(t.scope, t.queueName, backlogAge)
+	}
+	if ackLevel != previousAckLevel {
+		t.shard.RecordOperation(shardOperationAckLevelUpdated,
+			fmt.Sprintf("queue: %v, ack level: %v -> %v", t.queueName, previousAckLevel, ackLevel))
+	}
 	if err := t.updateTimerAckLevel(ackLevel); err != nil {
 		t.metricsClient.IncCounter(t.scope, metrics.AckLevelUpdateFailedCounter)
 		t.logger.Errorf("Error updating timer ack level for shard: %v", err)
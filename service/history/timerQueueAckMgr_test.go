@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
 	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/messaging"
@@ -141,6 +142,7 @@ func (s *timerQueueAckMgrSuite) SetupTest() {
 	s.clusterName = cluster.TestCurrentClusterName
 	s.timerQueueAckMgr = newTimerQueueAckMgr(
 		0,
+		common.TimerQueueBacklogPrefix+s.clusterName,
 		s.mockShard,
 		s.metricsClient,
 		s.mockShard.GetTimerClusterAckLevel(s.clusterName),
@@ -287,7 +289,7 @@ func (s *timerQueueAckMgrSuite) TestReadTimerTasks_NoLookAhead_NoNextPage() {
 	}
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
 	s.mockExecutionMgr.On("GetTimerIndexTasks", request).Return(response, nil).Once()
-	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
+	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.ReadTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{timer}, filteredTasks)
 	s.Nil(lookAheadTask)
@@ -342,7 +344,7 @@ func (s *timerQueueAckMgrSuite) TestReadTimerTasks_NoLookAhead_HasNextPage() {
 	}
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
 	s.mockExecutionMgr.On("GetTimerIndexTasks", request).Return(response, nil).Once()
-	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
+	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.ReadTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{timer}, filteredTasks)
 	s.Nil(lookAheadTask)
@@ -395,7 +397,7 @@ func (s *timerQueueAckMgrSuite) TestReadTimerTasks_HasLookAhead_NoNextPage() {
 	}
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
 	s.mockExecutionMgr.On("GetTimerIndexTasks", request).Return(response, nil).Once()
-	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
+	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.ReadTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{}, filteredTasks)
 	s.Equal(timer, lookAheadTask)
@@ -449,7 +451,7 @@ func (s *timerQueueAckMgrSuite) TestReadTimerTasks_HasLookAhead_HasNextPage() {
 	}
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
 	s.mockExecutionMgr.On("GetTimerIndexTasks", request).Return(response, nil).Once()
-	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
+	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.ReadTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{}, filteredTasks)
 	s.Equal(timer, lookAheadTask)
@@ -515,7 +517,7 @@ func (s *timerQueueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	}
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
 	s.mockExecutionMgr.On("GetTimerIndexTasks", request).Return(response, nil).Once()
-	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.readTimerTasks()
+	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueAckMgr.ReadTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{timer1, timer2, timer3}, filteredTasks)
 	s.Nil(lookAheadTask)
@@ -524,25 +526,25 @@ func (s *timerQueueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	// we are not testing shard context
 	s.mockShardMgr.On("UpdateShard", mock.Anything).Return(nil).Once()
 	timerSequenceID1 := TimerSequenceID{VisibilityTimestamp: timer1.VisibilityTimestamp, TaskID: timer1.TaskID}
-	s.timerQueueAckMgr.completeTimerTask(timer1)
+	s.timerQueueAckMgr.CompleteTimerTask(timer1)
 	s.True(s.timerQueueAckMgr.outstandingTasks[timerSequenceID1])
-	s.timerQueueAckMgr.updateAckLevel()
+	s.timerQueueAckMgr.UpdateAckLevel()
 	s.Equal(timer1.VisibilityTimestamp, s.mockShard.GetTimerClusterAckLevel(s.clusterName))
 
 	s.mockShardMgr.On("UpdateShard", mock.Anything).Return(nil).Once()
 	timerSequenceID3 := TimerSequenceID{VisibilityTimestamp: timer3.VisibilityTimestamp, TaskID: timer3.TaskID}
-	s.timerQueueAckMgr.completeTimerTask(timer3)
+	s.timerQueueAckMgr.CompleteTimerTask(timer3)
 	s.True(s.timerQueueAckMgr.outstandingTasks[timerSequenceID3])
-	s.timerQueueAckMgr.updateAckLevel()
+	s.timerQueueAckMgr.UpdateAckLevel()
 	// ack level remains unchanged
 	s.Equal(timer1.VisibilityTimestamp, s.mockShard.GetTimerClusterAckLevel(s.clusterName))
 
 	// we are not testing shard context
 	s.mockShardMgr.On("UpdateShard", mock.Anything).Return(nil).Once()
 	timerSequenceID2 := TimerSequenceID{VisibilityTimestamp: timer2.VisibilityTimestamp, TaskID: timer2.TaskID}
-	s.timerQueueAckMgr.completeTimerTask(timer2)
+	s.timerQueueAckMgr.CompleteTimerTask(timer2)
 	s.True(s.timerQueueAckMgr.outstandingTasks[timerSequenceID2])
-	s.timerQueueAckMgr.updateAckLevel()
+	s.timerQueueAckMgr.UpdateAckLevel()
 	s.Equal(timer3.VisibilityTimestamp, s.mockShard.GetTimerClusterAckLevel(s.clusterName))
 }
 
@@ -690,7 +692,7 @@ func (s *timerQueueFailoverAckMgrSuite) TestReadTimerTasks_HasNextPage() {
 	}
 
 	s.mockExecutionMgr.On("GetTimerIndexTasks", request).Return(response, nil).Once()
-	timers, lookAheadTimer, more, err := s.timerQueueFailoverAckMgr.readTimerTasks()
+	timers, lookAheadTimer, more, err := s.timerQueueFailoverAckMgr.ReadTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{timer1, timer2}, timers)
 	s.Nil(lookAheadTimer)
@@ -731,7 +733,7 @@ func (s *timerQueueFailoverAckMgrSuite) TestReadTimerTasks_NoNextPage() {
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
 	s.mockExecutionMgr.On("GetTimerIndexTasks", request).Return(response, nil).Once()
 
-	timers, lookAheadTimer, more, err := s.timerQueueFailoverAckMgr.readTimerTasks()
+	timers, lookAheadTimer, more, err := s.timerQueueFailoverAckMgr.ReadTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{}, timers)
 	s.Nil(lookAheadTimer)
@@ -754,7 +756,7 @@ func (s *timerQueueFailoverAckMgrSuite) TestReadTimerTasks_InTheFuture() {
 	s.timerQueueFailoverAckMgr.minQueryLevel = maxQueryLevel.Add(1 * time.Second)
 	s.timerQueueFailoverAckMgr.maxQueryLevel = maxQueryLevel
 
-	timers, lookAheadTimer, more, err := s.timerQueueFailoverAckMgr.readTimerTasks()
+	timers, lookAheadTimer, more, err := s.timerQueueFailoverAckMgr.ReadTimerTasks()
 	s.Nil(err)
 	s.Equal(0, len(timers))
 	s.Nil(lookAheadTimer)
@@ -825,41 +827,41 @@ func (s *timerQueueFailoverAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	}
 	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
 	s.mockExecutionMgr.On("GetTimerIndexTasks", request).Return(response, nil).Once()
-	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueFailoverAckMgr.readTimerTasks()
+	filteredTasks, lookAheadTask, moreTasks, err := s.timerQueueFailoverAckMgr.ReadTimerTasks()
 	s.Nil(err)
 	s.Equal([]*persistence.TimerTaskInfo{timer1, timer2, timer3}, filteredTasks)
 	s.Nil(lookAheadTask)
 	s.False(moreTasks)
 
 	timerSequenceID2 := TimerSequenceID{VisibilityTimestamp: timer2.VisibilityTimestamp, TaskID: timer2.TaskID}
-	s.timerQueueFailoverAckMgr.completeTimerTask(timer2)
+	s.timerQueueFailoverAckMgr.CompleteTimerTask(timer2)
 	s.True(s.timerQueueFailoverAckMgr.outstandingTasks[timerSequenceID2])
 	s.mockShardMgr.On("UpdateShard", mock.Anything).Return(nil).Once()
-	s.timerQueueFailoverAckMgr.updateAckLevel()
+	s.timerQueueFailoverAckMgr.UpdateAckLevel()
 	select {
-	case <-s.timerQueueFailoverAckMgr.getFinishedChan():
+	case <-s.timerQueueFailoverAckMgr.GetFinishedChan():
 		s.Fail("timer queue ack mgr finished chan should not be fired")
 	default:
 	}
 
 	timerSequenceID3 := TimerSequenceID{VisibilityTimestamp: timer3.VisibilityTimestamp, TaskID: timer3.TaskID}
-	s.timerQueueFailoverAckMgr.completeTimerTask(timer3)
+	s.timerQueueFailoverAckMgr.CompleteTimerTask(timer3)
 	s.True(s.timerQueueFailoverAckMgr.outstandingTasks[timerSequenceID3])
 	s.mockShardMgr.On("UpdateShard", mock.Anything).Return(nil).Once()
-	s.timerQueueFailoverAckMgr.updateAckLevel()
+	s.timerQueueFailoverAckMgr.UpdateAckLevel()
 	select {
-	case <-s.timerQueueFailoverAckMgr.getFinishedChan():
+	case <-s.timerQueueFailoverAckMgr.GetFinishedChan():
 		s.Fail("timer queue ack mgr finished chan should not be fired")
 	default:
 	}
 
 	timerSequenceID1 := TimerSequenceID{VisibilityTimestamp: timer1.VisibilityTimestamp, TaskID: timer1.TaskID}
-	s.timerQueueFailoverAckMgr.completeTimerTask(timer1)
+	s.timerQueueFailoverAckMgr.CompleteTimerTask(timer1)
 	s.True(s.timerQueueFailoverAckMgr.outstandingTasks[timerSequenceID1])
 	s.mockShardMgr.On("UpdateShard", mock.Anything).Return(nil).Once()
-	s.timerQueueFailoverAckMgr.updateAckLevel()
+	s.timerQueueFailoverAckMgr.UpdateAckLevel()
 	select {
-	case <-s.timerQueueFailoverAckMgr.getFinishedChan():
+	case <-s.timerQueueFailoverAckMgr.GetFinishedChan():
 	default:
 		s.Fail("timer queue ack mgr finished chan should be fired")
 	}
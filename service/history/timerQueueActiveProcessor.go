@@ -29,6 +29,7 @@ import (
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
@@ -38,7 +39,7 @@ type (
 	timerQueueActiveProcessorImpl struct {
 		shard                   ShardContext
 		historyService          *historyEngineImpl
-		cache                   *historyCache
+		cache                   HistoryCache
 		timerTaskFilter         timerTaskFilter
 		now                     timeNow
 		logger                  bark.Logger
@@ -47,7 +48,7 @@ type (
 		matchingClient          matching.Client
 		timerGate               LocalTimerGate
 		timerQueueProcessorBase *timerQueueProcessorBase
-		timerQueueAckMgr        timerQueueAckMgr
+		timerQueueAckMgr        TimerQueueAckMgr
 	}
 )
 
@@ -59,15 +60,19 @@ func newTimerQueueActiveProcessor(shard ShardContext, historyService *historyEng
 	updateShardAckLevel := func(ackLevel TimerSequenceID) error {
 		return shard.UpdateTimerClusterAckLevel(currentClusterName, ackLevel.VisibilityTimestamp)
 	}
-	logger = logger.WithFields(bark.Fields{
+	logger = newThrottledLogger(logger, shard.GetConfig()).WithFields(bark.Fields{
 		logging.TagWorkflowCluster: currentClusterName,
 	})
 	timerTaskFilter := func(timer *persistence.TimerTaskInfo) (bool, error) {
-		return verifyActiveTask(shard, logger, timer.DomainID, timer)
+		if err := verifyTimerTaskNotPaused(shard, timer.TaskType); err != nil {
+			return false, err
+		}
+		return verifyActiveTask(shard, logger, timer.DomainID, timer.TaskID, common.EmptyVersion, timer)
 	}
 
 	timerQueueAckMgr := newTimerQueueAckMgr(
 		metrics.TimerActiveQueueProcessorScope,
+		common.TimerQueueBacklogPrefix+currentClusterName,
 		shard,
 		historyService.metricsClient,
 		shard.GetTimerClusterAckLevel(currentClusterName),
@@ -87,7 +92,7 @@ func newTimerQueueActiveProcessor(shard ShardContext, historyService *historyEng
 		matchingClient:     retryableMatchingClient,
 		metricsClient:      historyService.metricsClient,
 		currentClusterName: currentClusterName,
-		timerGate:          NewLocalTimerGate(),
+		timerGate:          newActiveTimerGate(shard.GetConfig(), historyService.metricsClient, logger),
 		timerQueueProcessorBase: newTimerQueueProcessorBase(
 			metrics.TimerActiveQueueProcessorScope,
 			shard,
@@ -103,8 +108,21 @@ func newTimerQueueActiveProcessor(shard ShardContext, historyService *historyEng
 	return processor
 }
 
+// newActiveTimerGate creates the timer gate of an active processor, which runs on the local wall clock
+// and so has to survive jumps of that clock
+func newActiveTimerGate(config *Config, metricsClient metrics.Client, logger bark.Logger) LocalTimerGate {
+	return NewLocalTimerGateWithClockJumpDetection(
+		func() time.Duration { return config.TimerProcessorMaxClockSkew() },
+		func(jump time.Duration) {
+			metricsClient.IncCounter(metrics.TimerActiveQueueProcessorScope, metrics.TimerClockJumpCounter)
+			logger.Warnf("Wall clock jumped by %v, timer gate re-armed.", jump)
+		},
+	)
+}
+
 func newTimerQueueFailoverProcessor(shard ShardContext, historyService *historyEngineImpl, domainID string, standbyClusterName string,
-	minLevel time.Time, maxLevel time.Time, matchingClient matching.Client, logger bark.Logger) *timerQueueActiveProcessorImpl {
+	minLevel time.Time, maxLevel time.Time, matchingClient matching.Client, failoverCompleted func(),
+	logger bark.Logger) *timerQueueActiveProcessorImpl {
 	currentClusterName := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
 	timeNow := func() time.Time {
 		// should use current cluster's time when doing domain failover
@@ -122,17 +140,37 @@ func newTimerQueueFailoverProcessor(shard ShardContext, historyService *historyE
 		)
 	}
 
-	timerAckMgrShutdown := func() error {
-		return shard.DeleteTimerFailoverLevel(domainID)
-	}
-
 	logger = logger.WithFields(bark.Fields{
 		logging.TagWorkflowCluster: currentClusterName,
 		logging.TagDomainID:        domainID,
 		logging.TagFailover:        "from: " + standbyClusterName,
 	})
+
+	timerAckMgrShutdown := func() error {
+		// the failover level holds back the timer ack level of the shard, so its removal is retried
+		op := func() error {
+			return shard.DeleteTimerFailoverLevel(domainID)
+		}
+		if err := backoff.Retry(op, common.CreatePersistanceRetryPolicy(), common.IsPersistenceTransientError); err != nil {
+			// the level is already gone from the shard info in memory, so it no longer holds back the ack level
+			// and the next shard update persists its removal. The processor is done either way.
+			logger.Errorf("Failed to persist completion of timer failover: %v", err)
+			failoverCompleted()
+			return err
+		}
+		historyService.metricsClient.IncCounter(metrics.TimerActiveQueueProcessorScope, metrics.FailoverProcessingCompleteCounter)
+		shard.RecordOperation(shardOperationFailoverCompleted,
+			fmt.Sprintf("queue: timer, domain: %v, min level: %v, max level: %v", domainID, minLevel, maxLevel))
+		logger.Info("Timer failover processing complete.")
+		failoverCompleted()
+		return nil
+	}
+
 	timerTaskFilter := func(timer *persistence.TimerTaskInfo) (bool, error) {
-		return verifyFailoverActiveTask(logger, domainID, timer.DomainID, timer)
+		if err := verifyTimerTaskNotPaused(shard, timer.TaskType); err != nil {
+			return false, err
+		}
+		return verifyFailoverActiveTask(logger, domainID, timer.DomainID, common.EmptyVersion, common.EmptyVersion, timer)
 	}
 
 	timerQueueAckMgr := newTimerQueueFailoverAckMgr(
@@ -156,7 +194,7 @@ func newTimerQueueFailoverProcessor(shard ShardContext, historyService *historyE
 		logger:          logger,
 		metricsClient:   historyService.metricsClient,
 		matchingClient:  retryableMatchingClient,
-		timerGate:       NewLocalTimerGate(),
+		timerGate:       newActiveTimerGate(shard.GetConfig(), historyService.metricsClient, logger),
 		timerQueueProcessorBase: newTimerQueueProcessorBase(
 			metrics.TimerActiveQueueProcessorScope,
 			shard,
@@ -200,7 +238,7 @@ func (t *timerQueueActiveProcessorImpl) process(timerTask *persistence.TimerTask
 	if err != nil {
 		return err
 	} else if !ok {
-		t.timerQueueAckMgr.completeTimerTask(timerTask)
+		t.timerQueueAckMgr.CompleteTimerTask(timerTask)
 		t.logger.Debugf("Discarding timer: (%v, %v), for WorkflowID: %v, RunID: %v, Type: %v, EventID: %v, Error: %v",
 			timerTask.TaskID, timerTask.VisibilityTimestamp, timerTask.WorkflowID, timerTask.RunID, timerTask.TaskType, timerTask.EventID, err)
 		return nil
@@ -240,14 +278,14 @@ func (t *timerQueueActiveProcessorImpl) process(timerTask *persistence.TimerTask
 		if _, ok := err.(*workflow.EntityNotExistsError); ok {
 			// Timer could fire after the execution is deleted.
 			// In which case just ignore the error so we can complete the timer task.
-			t.timerQueueAckMgr.completeTimerTask(timerTask)
+			t.timerQueueAckMgr.CompleteTimerTask(timerTask)
 			err = nil
 		}
 		if err != nil {
 			t.metricsClient.IncCounter(scope, metrics.TaskFailures)
 		}
 	} else {
-		t.timerQueueAckMgr.completeTimerTask(timerTask)
+		t.timerQueueAckMgr.CompleteTimerTask(timerTask)
 	}
 
 	return err
@@ -258,7 +296,7 @@ func (t *timerQueueActiveProcessorImpl) processExpiredUserTimer(task *persistenc
 	sw := t.metricsClient.StartTimer(metrics.TimerActiveTaskUserTimerScope, metrics.TaskLatency)
 	defer sw.Stop()
 
-	context, release, err0 := t.cache.getOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task))
+	context, release, err0 := t.cache.GetOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task))
 	if err0 != nil {
 		return err0
 	}
@@ -327,7 +365,7 @@ func (t *timerQueueActiveProcessorImpl) processActivityTimeout(timerTask *persis
 	sw := t.metricsClient.StartTimer(metrics.TimerActiveTaskActivityTimeoutScope, metrics.TaskLatency)
 	defer sw.Stop()
 
-	context, release, err0 := t.cache.getOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(timerTask))
+	context, release, err0 := t.cache.GetOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(timerTask))
 	if err0 != nil {
 		return err0
 	}
@@ -487,7 +525,7 @@ func (t *timerQueueActiveProcessorImpl) processDecisionTimeout(task *persistence
 	sw := t.metricsClient.StartTimer(metrics.TimerActiveTaskDecisionTimeoutScope, metrics.TaskLatency)
 	defer sw.Stop()
 
-	context, release, err0 := t.cache.getOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task))
+	context, release, err0 := t.cache.GetOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task))
 	if err0 != nil {
 		return err0
 	}
@@ -564,7 +602,7 @@ func (t *timerQueueActiveProcessorImpl) processRetryTimer(task *persistence.Time
 	defer sw.Stop()
 
 	processFn := func() error {
-		context, release, err0 := t.cache.getOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task))
+		context, release, err0 := t.cache.GetOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task))
 		defer release(nil)
 		if err0 != nil {
 			return err0
@@ -588,6 +626,15 @@ func (t *timerQueueActiveProcessorImpl) processRetryTimer(task *persistence.Time
 		} else if !ok {
 			return nil
 		}
+		// the domain may have failed over since the timer fired, neither a failover processor nor a stale filter
+		// may dispatch the retry from a standby cluster, whose standby processor holds on to the timer instead
+		ok, err = verifyActiveTask(t.shard, t.logger, task.DomainID, task.TaskID, common.EmptyVersion, task)
+		if err != nil {
+			return err
+		} else if !ok {
+			t.metricsClient.IncCounter(metrics.TimerActiveTaskRetryTimerScope, metrics.RetryTimerSuppressedCounter)
+			return nil
+		}
 
 		domainID := task.DomainID
 		targetDomainID := domainID
@@ -607,6 +654,9 @@ func (t *timerQueueActiveProcessorImpl) processRetryTimer(task *persistence.Time
 			Name: &ai.TaskList,
 		}
 		scheduleToStartTimeout := ai.ScheduleToStartTimeout
+		scheduledTime := ai.ScheduledTime
+		isolationGroup := msBuilder.GetExecutionInfo().IsolationGroup
+		priority := msBuilder.GetExecutionInfo().Priority
 
 		release(nil) // release earlier as we don't need the lock anymore
 		err = t.matchingClient.AddActivityTask(nil, &m.AddActivityTaskRequest{
@@ -616,6 +666,9 @@ func (t *timerQueueActiveProcessorImpl) processRetryTimer(task *persistence.Time
 			TaskList:                      taskList,
 			ScheduleId:                    &scheduledID,
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(scheduleToStartTimeout),
+			IsolationGroup:                common.StringPtr(isolationGroup),
+			Priority:                      common.Int32Ptr(priority),
+			ScheduledTimestamp:            common.Int64Ptr(scheduledTime.UnixNano()),
 		})
 
 		t.logger.Debugf("Adding ActivityTask for retry, WorkflowID: %v, RunID: %v, ScheduledID: %v, TaskList: %v, Attempt: %v, Err: %v",
@@ -638,7 +691,7 @@ func (t *timerQueueActiveProcessorImpl) processWorkflowTimeout(task *persistence
 	sw := t.metricsClient.StartTimer(metrics.TimerActiveTaskWorkflowTimeoutScope, metrics.TaskLatency)
 	defer sw.Stop()
 
-	context, release, err0 := t.cache.getOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task))
+	context, release, err0 := t.cache.GetOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task))
 	if err0 != nil {
 		return err0
 	}
@@ -683,8 +736,8 @@ Update_History_Loop:
 }
 
 func (t *timerQueueActiveProcessorImpl) updateWorkflowExecution(
-	context *workflowExecutionContext,
-	msBuilder mutableState,
+	context *WorkflowExecutionContext,
+	msBuilder MutableState,
 	scheduleNewDecision bool,
 	createDeletionTask bool,
 	timerTasks []persistence.Task,
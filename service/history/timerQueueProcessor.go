@@ -22,6 +22,7 @@ package history
 
 import (
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -52,6 +53,10 @@ type (
 		shutdownChan           chan struct{}
 		activeTimerProcessor   *timerQueueActiveProcessorImpl
 		standbyTimerProcessors map[string]*timerQueueStandbyProcessorImpl
+
+		sync.Mutex
+		// failover processors still draining their window, by domain ID
+		failoverTimerProcessors map[string]*timerQueueActiveProcessorImpl
 	}
 )
 
@@ -80,6 +85,8 @@ func newTimerQueueProcessor(shard ShardContext, historyService *historyEngineImp
 		shutdownChan:           make(chan struct{}),
 		activeTimerProcessor:   newTimerQueueActiveProcessor(shard, historyService, matchingClient, logger),
 		standbyTimerProcessors: standbyTimerProcessors,
+
+		failoverTimerProcessors: make(map[string]*timerQueueActiveProcessorImpl),
 	}
 }
 
@@ -106,6 +113,9 @@ func (t *timerQueueProcessorImpl) Stop() {
 			standbyTimerProcessor.Stop()
 		}
 	}
+	for _, failoverTimerProcessor := range t.getFailoverTimerProcessors() {
+		failoverTimerProcessor.Stop()
+	}
 	close(t.shutdownChan)
 }
 
@@ -123,7 +133,6 @@ func (t *timerQueueProcessorImpl) NotifyNewTimers(clusterName string, currentTim
 	}
 	standbyTimerProcessor.setCurrentTime(currentTime)
 	standbyTimerProcessor.notifyNewTimers(timerTasks)
-	standbyTimerProcessor.retryTasks()
 }
 
 func (t *timerQueueProcessorImpl) FailoverDomain(domainID string) {
@@ -137,18 +146,21 @@ func (t *timerQueueProcessorImpl) FailoverDomain(domainID string) {
 		}
 	}
 	// the ack manager is exclusive, so just add a cassandra min presicition
-	maxLevel := t.activeTimerProcessor.timerQueueAckMgr.getReadLevel().VisibilityTimestamp.Add(1 * time.Millisecond)
+	maxLevel := t.activeTimerProcessor.timerQueueAckMgr.GetReadLevel().VisibilityTimestamp.Add(1 * time.Millisecond)
 	t.logger.Infof("Timer Failover Triggered: %v, min level: %v, max level: %v.\n", domainID, minLevel, maxLevel)
 	// we should consider make the failover idempotent
-	failoverTimerProcessor := newTimerQueueFailoverProcessor(t.shard, t.historyService, domainID,
-		standbyClusterName, minLevel, maxLevel, t.matchingClient, t.logger)
+	var failoverTimerProcessor *timerQueueActiveProcessorImpl
+	failoverTimerProcessor = newTimerQueueFailoverProcessor(t.shard, t.historyService, domainID,
+		standbyClusterName, minLevel, maxLevel, t.matchingClient, func() {
+			t.removeFailoverTimerProcessor(domainID, failoverTimerProcessor)
+		}, t.logger)
 
 	for _, standbyTimerProcessor := range t.standbyTimerProcessors {
 		standbyTimerProcessor.retryTasks()
 	}
 
-	failoverTimerProcessor.Start()
-
+	// the failover level is persisted before the processor starts, otherwise a processor finishing right away
+	// would have its completion overwritten
 	// err is ignored
 	t.shard.UpdateTimerFailoverLevel(
 		domainID,
@@ -159,6 +171,31 @@ func (t *timerQueueProcessorImpl) FailoverDomain(domainID string) {
 			DomainIDs:    []string{domainID},
 		},
 	)
+
+	t.Lock()
+	t.failoverTimerProcessors[domainID] = failoverTimerProcessor
+	t.Unlock()
+	failoverTimerProcessor.Start()
+}
+
+// removeFailoverTimerProcessor forgets a failover processor which drained its window, the processor stops itself
+func (t *timerQueueProcessorImpl) removeFailoverTimerProcessor(domainID string, processor *timerQueueActiveProcessorImpl) {
+	t.Lock()
+	defer t.Unlock()
+	// a later failover of the same domain replaces the processor
+	if t.failoverTimerProcessors[domainID] == processor {
+		delete(t.failoverTimerProcessors, domainID)
+	}
+}
+
+func (t *timerQueueProcessorImpl) getFailoverTimerProcessors() []*timerQueueActiveProcessorImpl {
+	t.Lock()
+	defer t.Unlock()
+	processors := make([]*timerQueueActiveProcessorImpl, 0, len(t.failoverTimerProcessors))
+	for _, processor := range t.failoverTimerProcessors {
+		processors = append(processors, processor)
+	}
+	return processors
 }
 
 func (t *timerQueueProcessorImpl) getTimerFiredCount(clusterName string) uint64 {
@@ -202,10 +239,10 @@ func (t *timerQueueProcessorImpl) completeTimersLoop() {
 func (t *timerQueueProcessorImpl) completeTimers() error {
 	lowerAckLevel := t.ackLevel
 
-	upperAckLevel := t.activeTimerProcessor.timerQueueAckMgr.getAckLevel()
+	upperAckLevel := t.activeTimerProcessor.timerQueueAckMgr.GetAckLevel()
 	if t.isGlobalDomainEnabled {
 		for _, standbyTimerProcessor := range t.standbyTimerProcessors {
-			ackLevel := standbyTimerProcessor.timerQueueAckMgr.getAckLevel()
+			ackLevel := standbyTimerProcessor.timerQueueAckMgr.GetAckLevel()
 			if !compareTimerIDLess(&upperAckLevel, &ackLevel) {
 				upperAckLevel = ackLevel
 			}
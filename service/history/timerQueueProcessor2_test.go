@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/pborman/uuid"
+	"github.com/uber/cadence/.gen/go/matching"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
@@ -301,3 +302,138 @@ func (s *timerQueueProcessor2Suite) TestWorkflowTimeout() {
 	<-waitCh
 	s.mockHistoryEngine.timerProcessor.(*timerQueueProcessorImpl).activeTimerProcessor.Stop()
 }
+
+func (s *timerQueueProcessor2Suite) TestFailoverProcessorCompletion() {
+	domainID := testDomainActiveID
+	s.mockShard.(*shardContextImpl).shardInfo.TimerFailoverLevels = map[string]persistence.TimerFailoverLevel{}
+	s.mockShardManager.On("UpdateShard", mock.Anything).Return(nil)
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(&persistence.GetTimerIndexTasksResponse{}, nil).Once()
+
+	maxLevel := time.Now()
+	minLevel := maxLevel.Add(-time.Minute)
+	err := s.mockShard.UpdateTimerFailoverLevel(domainID, persistence.TimerFailoverLevel{
+		MinLevel:     minLevel,
+		CurrentLevel: minLevel,
+		MaxLevel:     maxLevel,
+		DomainIDs:    []string{domainID},
+	})
+	s.Nil(err)
+
+	completed := false
+	processor := newTimerQueueFailoverProcessor(s.mockShard, s.mockHistoryEngine, domainID, cluster.TestAlternativeClusterName,
+		minLevel, maxLevel, s.mockMatchingClient, func() { completed = true }, s.logger)
+	defer processor.timerGate.Close()
+
+	_, _, _, err = processor.timerQueueAckMgr.ReadTimerTasks()
+	s.Nil(err)
+	processor.timerQueueAckMgr.UpdateAckLevel()
+
+	s.True(completed)
+	s.Empty(s.mockShard.GetAllTimerFailoverLevels())
+	select {
+	case <-processor.timerQueueAckMgr.GetFinishedChan():
+	default:
+		s.Fail("failover processor is expected to be finished")
+	}
+}
+
+func (s *timerQueueProcessor2Suite) TestFailoverProcessorCompletionDeleteLevelFailure() {
+	domainID := testDomainActiveID
+	maxLevel := time.Now()
+	minLevel := maxLevel.Add(-time.Minute)
+	s.mockShard.(*shardContextImpl).shardInfo.TimerFailoverLevels = map[string]persistence.TimerFailoverLevel{
+		domainID: {
+			MinLevel:     minLevel,
+			CurrentLevel: minLevel,
+			MaxLevel:     maxLevel,
+			DomainIDs:    []string{domainID},
+		},
+	}
+	s.mockShardManager.On("UpdateShard", mock.Anything).Return(errors.New("some random error"))
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(&persistence.GetTimerIndexTasksResponse{}, nil).Once()
+
+	completed := false
+	processor := newTimerQueueFailoverProcessor(s.mockShard, s.mockHistoryEngine, domainID, cluster.TestAlternativeClusterName,
+		minLevel, maxLevel, s.mockMatchingClient, func() { completed = true }, s.logger)
+
+	_, _, _, err := processor.timerQueueAckMgr.ReadTimerTasks()
+	s.Nil(err)
+	processor.timerQueueAckMgr.UpdateAckLevel()
+
+	// the processor is deregistered although the removal of its level was not persisted
+	s.True(completed)
+	s.Empty(s.mockShard.GetAllTimerFailoverLevels())
+
+	// the owner of the processor may stop it again after it stopped itself
+	processor.Stop()
+	processor.Stop()
+}
+
+func (s *timerQueueProcessor2Suite) TestFailoverProcessorRetryTimer_SyncedAttempt() {
+	domainID := testDomainActiveID
+	we := workflow.WorkflowExecution{WorkflowId: common.StringPtr("workflow-retry-failover-test"),
+		RunId: common.StringPtr(validRunID)}
+	taskList := "task-retry-failover"
+
+	builder := newMutableStateBuilder(s.config, s.logger)
+	builder.AddWorkflowExecutionStartedEvent(we, &history.StartWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		StartRequest: &workflow.StartWorkflowExecutionRequest{
+			WorkflowType: &workflow.WorkflowType{Name: common.StringPtr("wType")},
+			TaskList:     common.TaskListPtr(workflow.TaskList{Name: common.StringPtr(taskList)}),
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(10),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+		},
+	})
+	di := addDecisionTaskScheduledEvent(builder)
+	event := addDecisionTaskStartedEvent(builder, di.ScheduleID, taskList, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(builder, di.ScheduleID, di.StartedID, nil, "some random identity")
+	_, ai := addActivityTaskScheduledEvent(builder, event.GetEventId(), "activity", "activity type", taskList,
+		[]byte(nil), 10, 10, 10)
+	// the attempt and its retry timer were synced from the previously active cluster, which never wrote an event
+	// for them
+	ai.Attempt = 2
+	ai.ScheduledTime = time.Now()
+
+	ms := createMutableState(builder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: ms}, nil).Once()
+	s.mockMatchingClient.On("AddActivityTask", nil, mock.MatchedBy(func(request *matching.AddActivityTaskRequest) bool {
+		return request.GetDomainUUID() == domainID &&
+			request.Execution.GetWorkflowId() == we.GetWorkflowId() &&
+			request.GetScheduleId() == ai.ScheduleID &&
+			request.TaskList.GetName() == taskList
+	})).Return(nil).Once()
+
+	maxLevel := time.Now().Add(time.Minute)
+	minLevel := maxLevel.Add(-2 * time.Minute)
+	processor := newTimerQueueFailoverProcessor(s.mockShard, s.mockHistoryEngine, domainID, cluster.TestAlternativeClusterName,
+		minLevel, maxLevel, s.mockMatchingClient, func() {}, s.logger)
+	defer processor.timerGate.Close()
+
+	// the timer of the previous attempt is superseded by the synced one
+	staleTimer := &persistence.TimerTaskInfo{
+		DomainID:            domainID,
+		WorkflowID:          we.GetWorkflowId(),
+		RunID:               we.GetRunId(),
+		TaskID:              int64(100),
+		TaskType:            persistence.TaskTypeRetryTimer,
+		VisibilityTimestamp: ai.ScheduledTime.Add(-time.Second),
+		EventID:             ai.ScheduleID,
+		ScheduleAttempt:     1,
+	}
+	s.Nil(processor.process(staleTimer))
+
+	syncedTimer := &persistence.TimerTaskInfo{
+		DomainID:            domainID,
+		WorkflowID:          we.GetWorkflowId(),
+		RunID:               we.GetRunId(),
+		TaskID:              int64(101),
+		TaskType:            persistence.TaskTypeRetryTimer,
+		VisibilityTimestamp: ai.ScheduledTime,
+		EventID:             ai.ScheduleID,
+		ScheduleAttempt:     2,
+	}
+	s.Nil(processor.process(syncedTimer))
+}
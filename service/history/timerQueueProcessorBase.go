@@ -51,7 +51,7 @@ type (
 		scope            int
 		shard            ShardContext
 		historyService   *historyEngineImpl
-		cache            *historyCache
+		cache            HistoryCache
 		executionManager persistence.ExecutionManager
 		status           int32
 		shutdownWG       sync.WaitGroup
@@ -62,14 +62,16 @@ type (
 		metricsClient    metrics.Client
 		timerFiredCount  uint64
 		timerProcessor   timerProcessor
-		timerQueueAckMgr timerQueueAckMgr
-		rateLimiter      common.TokenBucket
+		timerQueueAckMgr TimerQueueAckMgr
+		tuner            *queueProcessorTuner
 		startDelay       dynamicconfig.DurationPropertyFn
 		retryPolicy      backoff.RetryPolicy
 
-		// worker coroutines notification
-		workerNotificationChans []chan struct{}
-		// duplicate numOfWorker from config.TimerTaskWorkerCount for dynamic config works correctly
+		// standby tasks waiting for the mutable state to be replicated
+		redeliveryQueue *taskRedeliveryQueue
+		// timers of a paused task type, checked again every paused task check interval
+		pausedQueue *taskRedeliveryQueue
+		// duplicate numOfWorker from the tuner, which follows config.TimerTaskWorkerCount, for dynamic config works correctly
 		numOfWorker int
 
 		lastPollTime time.Time
@@ -82,38 +84,44 @@ type (
 )
 
 func newTimerQueueProcessorBase(scope int, shard ShardContext, historyService *historyEngineImpl,
-	timerQueueAckMgr timerQueueAckMgr, maxPollRPS dynamicconfig.IntPropertyFn,
+	timerQueueAckMgr TimerQueueAckMgr, maxPollRPS dynamicconfig.IntPropertyFn,
 	startDelay dynamicconfig.DurationPropertyFn, logger bark.Logger) *timerQueueProcessorBase {
 	log := logger.WithFields(bark.Fields{
 		logging.TagWorkflowComponent: logging.TagValueTimerQueueComponent,
 	})
 
-	workerNotificationChans := []chan struct{}{}
-	numOfWorker := shard.GetConfig().TimerTaskWorkerCount()
-	for index := 0; index < numOfWorker; index++ {
-		workerNotificationChans = append(workerNotificationChans, make(chan struct{}, 1))
-	}
-
+	config := shard.GetConfig()
+	tuner := newQueueProcessorTuner(config, maxPollRPS, config.TimerTaskWorkerCount, getShardPersistenceLoad(shard),
+		historyService.metricsClient, scope)
 	base := &timerQueueProcessorBase{
-		scope:                   scope,
-		shard:                   shard,
-		historyService:          historyService,
-		cache:                   historyService.historyCache,
-		executionManager:        shard.GetExecutionManager(),
-		status:                  common.DaemonStatusInitialized,
-		shutdownCh:              make(chan struct{}),
-		tasksCh:                 make(chan *persistence.TimerTaskInfo, 10*shard.GetConfig().TimerTaskBatchSize()),
-		config:                  shard.GetConfig(),
-		logger:                  log,
-		metricsClient:           historyService.metricsClient,
-		timerQueueAckMgr:        timerQueueAckMgr,
-		numOfWorker:             numOfWorker,
-		workerNotificationChans: workerNotificationChans,
-		newTimerCh:              make(chan struct{}, 1),
-		lastPollTime:            time.Time{},
-		rateLimiter:             common.NewTokenBucket(maxPollRPS(), common.NewRealTimeSource()),
-		startDelay:              startDelay,
-		retryPolicy:             common.CreatePersistanceRetryPolicy(),
+		scope:            scope,
+		shard:            shard,
+		historyService:   historyService,
+		cache:            historyService.historyCache,
+		executionManager: shard.GetExecutionManager(),
+		status:           common.DaemonStatusInitialized,
+		shutdownCh:       make(chan struct{}),
+		tasksCh:          make(chan *persistence.TimerTaskInfo, 10*config.TimerTaskBatchSize()),
+		config:           config,
+		logger:           log,
+		metricsClient:    historyService.metricsClient,
+		timerQueueAckMgr: timerQueueAckMgr,
+		numOfWorker:      tuner.numOfWorkers(),
+		redeliveryQueue: newTaskRedeliveryQueue(
+			config.StandbyTaskRedeliveryInterval(),
+			config.StandbyTaskRedeliveryMaxInterval(),
+			common.NewRealTimeSource(),
+		),
+		pausedQueue: newTaskRedeliveryQueue(
+			config.PausedTaskCheckInterval(),
+			config.PausedTaskCheckInterval(),
+			common.NewRealTimeSource(),
+		),
+		newTimerCh:   make(chan struct{}, 1),
+		lastPollTime: time.Time{},
+		tuner:        tuner,
+		startDelay:   startDelay,
+		retryPolicy:  common.CreatePersistanceRetryPolicy(),
 	}
 
 	return base
@@ -154,8 +162,7 @@ func (t *timerQueueProcessorBase) processorPump() {
 	var workerWG sync.WaitGroup
 	for i := 0; i < t.numOfWorker; i++ {
 		workerWG.Add(1)
-		notificationChan := t.workerNotificationChans[i]
-		go t.taskWorker(&workerWG, notificationChan)
+		go t.taskWorker(&workerWG)
 	}
 
 RetryProcessor:
@@ -172,6 +179,7 @@ RetryProcessor:
 	}
 
 	t.logger.Info("Timer queue processor pump shutting down.")
+	t.tuner.stop()
 	// This is the only pump which writes to tasksCh, so it is safe to close channel here
 	close(t.tasksCh)
 	if success := common.AwaitWaitGroup(&workerWG, 10*time.Second); !success {
@@ -180,18 +188,25 @@ RetryProcessor:
 	t.logger.Info("Timer processor exiting.")
 }
 
-func (t *timerQueueProcessorBase) taskWorker(workerWG *sync.WaitGroup, notificationChan chan struct{}) {
+func (t *timerQueueProcessorBase) taskWorker(workerWG *sync.WaitGroup) {
 	defer workerWG.Done()
 
 	for {
+		// the worker only takes a task once the tuner lets it process one
+		if !t.tuner.acquireWorker() {
+			return
+		}
 		select {
 		case <-t.shutdownCh:
+			t.tuner.releaseWorker()
 			return
 		case task, ok := <-t.tasksCh:
 			if !ok {
+				t.tuner.releaseWorker()
 				return
 			}
-			t.processWithRetry(notificationChan, task)
+			t.processWithRetry(task)
+			t.tuner.releaseWorker()
 		}
 	}
 }
@@ -273,56 +288,101 @@ func (t *timerQueueProcessorBase) notifyNewTimer(newTime time.Time) {
 func (t *timerQueueProcessorBase) internalProcessor() error {
 	timerGate := t.timerProcessor.getTimerGate()
 	jitter := backoff.NewJitter()
+	// the poll interval backs off while the shard has no timers, new timers are notified so they do not wait for it
+	pollInterval := t.config.TimerProcessorMaxPollInterval()
 	pollTimer := time.NewTimer(jitter.JitDuration(
-		t.config.TimerProcessorMaxPollInterval(),
+		pollInterval,
 		t.config.TimerProcessorMaxPollIntervalJitterCoefficient(),
 	))
 	defer pollTimer.Stop()
+	resetPollInterval := func() {
+		if pollInterval == t.config.TimerProcessorMaxPollInterval() {
+			return
+		}
+		pollInterval = t.config.TimerProcessorMaxPollInterval()
+		if !pollTimer.Stop() {
+			select {
+			case <-pollTimer.C:
+			default:
+			}
+		}
+		pollTimer.Reset(jitter.JitDuration(
+			pollInterval,
+			t.config.TimerProcessorMaxPollIntervalJitterCoefficient(),
+		))
+	}
 
 	updateAckTicker := time.NewTicker(t.shard.GetConfig().TimerProcessorUpdateAckInterval())
 	defer updateAckTicker.Stop()
 
+	redeliveryTicker := time.NewTicker(t.config.StandbyTaskRedeliveryInterval())
+	defer redeliveryTicker.Stop()
+
+	pausedTaskTicker := time.NewTicker(t.config.PausedTaskCheckInterval())
+	defer pausedTaskTicker.Stop()
+
+	tuningTicker := time.NewTicker(t.config.QueueProcessorAutoTuningInterval())
+	defer tuningTicker.Stop()
+
 	for {
-		// Wait until one of four things occurs:
+		// Wait until one of seven things occurs:
 		// 1. we get notified of a new message
 		// 2. the timer gate fires (message scheduled to be delivered)
 		// 3. shutdown was triggered.
 		// 4. updating ack level
+		// 5. deferred standby tasks are due for redelivery
+		// 6. timers of a paused task type are due for another check
+		// 7. the poll rate and worker count are due for tuning
 		//
 		select {
 		case <-t.shutdownCh:
 			t.logger.Debug("Timer queue processor pump shutting down.")
 			return nil
-		case <-t.timerQueueAckMgr.getFinishedChan():
+		case <-t.timerQueueAckMgr.GetFinishedChan():
 			// timer queue ack manager indicate that all task scanned
 			// are finished and no more tasks
 			// use a separate gorouting since the caller hold the shutdownWG
-			go t.Stop()
+			go t.timerProcessor.Stop()
 			return nil
 		case <-timerGate.FireChan():
-			lookAheadTimer, err := t.readAndFanoutTimerTasks()
+			lookAheadTimer, idle, err := t.readAndFanoutTimerTasks()
 			if err != nil {
 				return err
 			}
 			if lookAheadTimer != nil {
 				timerGate.Update(lookAheadTimer.VisibilityTimestamp)
 			}
+			if !idle {
+				resetPollInterval()
+			}
 		case <-pollTimer.C:
-			pollTimer.Reset(jitter.JitDuration(
-				t.config.TimerProcessorMaxPollInterval(),
-				t.config.TimerProcessorMaxPollIntervalJitterCoefficient(),
-			))
 			if t.lastPollTime.Add(t.config.TimerProcessorMaxPollInterval()).Before(time.Now()) {
-				lookAheadTimer, err := t.readAndFanoutTimerTasks()
+				lookAheadTimer, idle, err := t.readAndFanoutTimerTasks()
 				if err != nil {
 					return err
 				}
 				if lookAheadTimer != nil {
 					timerGate.Update(lookAheadTimer.VisibilityTimestamp)
 				}
+				pollInterval = nextTimerPollInterval(pollInterval, t.config.TimerProcessorMaxPollInterval(),
+					t.config.TimerProcessorMaxIdlePollInterval(), idle)
 			}
+			pollTimer.Reset(jitter.JitDuration(
+				pollInterval,
+				t.config.TimerProcessorMaxPollIntervalJitterCoefficient(),
+			))
 		case <-updateAckTicker.C:
-			t.timerQueueAckMgr.updateAckLevel()
+			t.timerQueueAckMgr.UpdateAckLevel()
+		case <-redeliveryTicker.C:
+			for _, task := range t.redeliveryQueue.getDueTasks() {
+				t.tasksCh <- task.(*persistence.TimerTaskInfo)
+			}
+		case <-pausedTaskTicker.C:
+			for _, task := range t.pausedQueue.getDueTasks() {
+				t.tasksCh <- task.(*persistence.TimerTaskInfo)
+			}
+		case <-tuningTicker.C:
+			t.tuner.adjust()
 		case <-t.newTimerCh:
 			t.newTimeLock.Lock()
 			newTime := t.newTime
@@ -331,20 +391,23 @@ func (t *timerQueueProcessorBase) internalProcessor() error {
 			// New Timer has arrived.
 			t.metricsClient.IncCounter(t.scope, metrics.NewTimerNotifyCounter)
 			timerGate.Update(newTime)
+			resetPollInterval()
 		}
 	}
 }
 
-func (t *timerQueueProcessorBase) readAndFanoutTimerTasks() (*persistence.TimerTaskInfo, error) {
-	if !t.rateLimiter.Consume(1, t.shard.GetConfig().TimerProcessorMaxPollInterval()) {
+// readAndFanoutTimerTasks reads the due timers and hands them to the workers, it also returns the next timer to fire
+// if any, and whether the shard turned out to have no timers at all
+func (t *timerQueueProcessorBase) readAndFanoutTimerTasks() (*persistence.TimerTaskInfo, bool, error) {
+	if !t.tuner.consumePoll(t.shard.GetConfig().TimerProcessorMaxPollInterval()) {
 		t.notifyNewTimer(time.Time{}) // re-enqueue the event
-		return nil, nil
+		return nil, false, nil
 	}
 
 	t.lastPollTime = time.Now()
-	timerTasks, lookAheadTask, moreTasks, err := t.timerQueueAckMgr.readTimerTasks()
+	timerTasks, lookAheadTask, moreTasks, err := t.timerQueueAckMgr.ReadTimerTasks()
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	for _, task := range timerTasks {
@@ -353,32 +416,52 @@ func (t *timerQueueProcessorBase) readAndFanoutTimerTasks() (*persistence.TimerT
 	}
 
 	if !moreTasks {
-		return lookAheadTask, nil
+		return lookAheadTask, len(timerTasks) == 0 && lookAheadTask == nil, nil
 	}
 
 	t.notifyNewTimer(time.Time{}) // re-enqueue the event
-	return nil, nil
+	return nil, false, nil
 }
 
-func (t *timerQueueProcessorBase) retryTasks() {
-	for _, workerNotificationChan := range t.workerNotificationChans {
-		select {
-		case workerNotificationChan <- struct{}{}:
-		default:
-		}
+// nextTimerPollInterval doubles the poll interval, up to maxIdleInterval, after a poll which found the shard without
+// timers, and goes back to the regular interval as soon as timers show up
+func nextTimerPollInterval(current, interval, maxIdleInterval time.Duration, idle bool) time.Duration {
+	if !idle || maxIdleInterval <= interval {
+		return interval
+	}
+	next := 2 * current
+	if next > maxIdleInterval {
+		next = maxIdleInterval
 	}
+	if next < interval {
+		next = interval
+	}
+	return next
+}
+
+func (t *timerQueueProcessorBase) retryTasks() {
+	t.redeliveryQueue.redeliverAll()
 }
 
-func (t *timerQueueProcessorBase) processWithRetry(notificationChan <-chan struct{}, task *persistence.TimerTaskInfo) {
+func (t *timerQueueProcessorBase) processWithRetry(task *persistence.TimerTaskInfo) {
 
 	var logger bark.Logger
 	var err error
 	startTime := time.Now()
 
+	deferred := false
+	defer func() {
+		if !deferred {
+			t.redeliveryQueue.remove(task.GetTaskID())
+			t.pausedQueue.remove(task.GetTaskID())
+		}
+	}()
+
 	attempt := 0
 	op := func() error {
-		err = t.timerProcessor.process(task)
-		if err != nil && err != ErrTaskRetry {
+		err = interceptTask(t.config.TaskInterceptors, newTaskInterceptorInfo(t.shard.GetShardID(), task, attempt),
+			func() error { return t.timerProcessor.process(task) })
+		if err != nil && err != ErrTaskRetry && err != ErrTaskPaused {
 			attempt++
 			logger = t.initializeLoggerForTask(task, logger)
 			logging.LogTaskProcessingFailedEvent(logger, err)
@@ -392,20 +475,24 @@ ProcessRetryLoop:
 		case <-t.shutdownCh:
 			return
 		default:
-			// clear the existing notification
-			select {
-			case <-notificationChan:
-			default:
-			}
-
 			err = backoff.Retry(op, t.retryPolicy, func(err error) bool {
-				return err != ErrTaskRetry
+				return err != ErrTaskRetry && err != ErrTaskPaused
 			})
 
 			if err != nil {
 				if err == ErrTaskRetry {
+					// the timer stays outstanding in the ack manager until it is redelivered and verified
 					t.metricsClient.IncCounter(t.scope, metrics.HistoryTaskStandbyRetryCounter)
-					<-notificationChan
+					t.redeliveryQueue.add(task)
+					deferred = true
+					return
+				} else if err == ErrTaskPaused {
+					// park the timer instead of holding on to the worker, it stays unacked and is handed back
+					// every check interval until its task type gets resumed
+					t.metricsClient.IncCounter(t.scope, metrics.HistoryTaskPausedCounter)
+					t.pausedQueue.add(task)
+					deferred = true
+					return
 				} else if _, ok := err.(*workflow.DomainNotActiveError); ok && time.Now().Sub(startTime) > cache.DomainCacheRefreshInterval {
 					t.metricsClient.IncCounter(t.scope, metrics.HistoryTaskNotActiveCounter)
 					return
@@ -413,6 +500,7 @@ ProcessRetryLoop:
 				continue ProcessRetryLoop
 			}
 			atomic.AddUint64(&t.timerFiredCount, 1)
+			t.shard.RecordOperation(shardOperationTaskProcessed, describeProcessedTask(task, attempt+1))
 			return
 		}
 	}
@@ -464,7 +552,7 @@ func (t *timerQueueProcessorBase) processDeleteHistoryEvent(task *persistence.Ti
 	sw := t.metricsClient.StartTimer(t.scope, metrics.TaskLatency)
 	defer sw.Stop()
 
-	context, release, err := t.cache.getOrCreateWorkflowExecution(t.getDomainIDAndWorkflowExecution(task))
+	context, release, err := t.cache.GetOrCreateWorkflowExecution(t.getDomainIDAndWorkflowExecution(task))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,63 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	timerQueueProcessorBaseSuite struct {
+		suite.Suite
+	}
+)
+
+func TestTimerQueueProcessorBaseSuite(t *testing.T) {
+	s := new(timerQueueProcessorBaseSuite)
+	suite.Run(t, s)
+}
+
+func (s *timerQueueProcessorBaseSuite) TestNextTimerPollInterval_BacksOffWhileIdle() {
+	interval := 5 * time.Minute
+	maxIdleInterval := time.Hour
+
+	current := interval
+	var intervals []time.Duration
+	for i := 0; i < 5; i++ {
+		current = nextTimerPollInterval(current, interval, maxIdleInterval, true)
+		intervals = append(intervals, current)
+	}
+	s.Equal([]time.Duration{10 * time.Minute, 20 * time.Minute, 40 * time.Minute, time.Hour, time.Hour}, intervals)
+
+	// timers showed up, polling goes back to the regular interval
+	s.Equal(interval, nextTimerPollInterval(current, interval, maxIdleInterval, false))
+}
+
+func (s *timerQueueProcessorBaseSuite) TestNextTimerPollInterval_BackoffDisabled() {
+	interval := 5 * time.Minute
+	s.Equal(interval, nextTimerPollInterval(interval, interval, interval, true))
+	s.Equal(interval, nextTimerPollInterval(interval, interval, time.Minute, true))
+	// the regular interval got raised above the current one
+	s.Equal(interval, nextTimerPollInterval(time.Minute, interval, time.Hour, false))
+}
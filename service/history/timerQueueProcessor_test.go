@@ -381,7 +381,7 @@ func (s *timerQueueProcessorSuite) checkTimedOutEventForUserTimer(domainID strin
 	return isRunning
 }
 
-func (s *timerQueueProcessorSuite) updateHistoryAndTimers(ms mutableState, timerTasks []persistence.Task, condition int64) {
+func (s *timerQueueProcessorSuite) updateHistoryAndTimers(ms MutableState, timerTasks []persistence.Task, condition int64) {
 	updatedState := createMutableState(ms)
 
 	actInfos := []*persistence.ActivityInfo{}
@@ -949,7 +949,7 @@ func (s *timerQueueProcessorSuite) TestTimersOnClosedWorkflow() {
 	s.Equal(state0.ExecutionInfo.NextEventID, state1.ExecutionInfo.NextEventID)
 }
 
-func (s *timerQueueProcessorSuite) printHistory(builder mutableState) string {
+func (s *timerQueueProcessorSuite) printHistory(builder MutableState) string {
 	history, err := builder.GetHistoryBuilder().Serialize()
 	if err != nil {
 		s.logger.Errorf("Error serializing history: %v", err)
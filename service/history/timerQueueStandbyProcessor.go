@@ -36,14 +36,14 @@ type (
 	timerQueueStandbyProcessorImpl struct {
 		shard                   ShardContext
 		historyService          *historyEngineImpl
-		cache                   *historyCache
+		cache                   HistoryCache
 		timerTaskFilter         timerTaskFilter
 		logger                  bark.Logger
 		metricsClient           metrics.Client
 		clusterName             string
 		timerGate               RemoteTimerGate
 		timerQueueProcessorBase *timerQueueProcessorBase
-		timerQueueAckMgr        timerQueueAckMgr
+		timerQueueAckMgr        TimerQueueAckMgr
 	}
 )
 
@@ -58,6 +58,9 @@ func newTimerQueueStandbyProcessor(shard ShardContext, historyService *historyEn
 		logging.TagWorkflowCluster: clusterName,
 	})
 	timerTaskFilter := func(timer *persistence.TimerTaskInfo) (bool, error) {
+		if err := verifyTimerTaskNotPaused(shard, timer.TaskType); err != nil {
+			return false, err
+		}
 		return verifyStandbyTask(shard, logger, clusterName, timer.DomainID, timer)
 	}
 
@@ -65,6 +68,7 @@ func newTimerQueueStandbyProcessor(shard ShardContext, historyService *historyEn
 	timerGate.SetCurrentTime(shard.GetCurrentTime(clusterName))
 	timerQueueAckMgr := newTimerQueueAckMgr(
 		metrics.TimerStandbyQueueProcessorScope,
+		common.TimerQueueBacklogPrefix+clusterName,
 		shard,
 		historyService.metricsClient,
 		shard.GetTimerClusterAckLevel(clusterName),
@@ -131,7 +135,7 @@ func (t *timerQueueStandbyProcessorImpl) process(timerTask *persistence.TimerTas
 	if err != nil {
 		return err
 	} else if !ok {
-		t.timerQueueAckMgr.completeTimerTask(timerTask)
+		t.timerQueueAckMgr.CompleteTimerTask(timerTask)
 		return nil
 	}
 
@@ -160,7 +164,7 @@ func (t *timerQueueStandbyProcessorImpl) process(timerTask *persistence.TimerTas
 
 	case persistence.TaskTypeRetryTimer:
 		scope = metrics.TimerStandbyTaskRetryTimerScope
-		err = nil // retry backoff timer should not get created on passive cluster
+		err = t.processRetryTimer(timerTask)
 
 	case persistence.TaskTypeDeleteHistoryEvent:
 		scope = metrics.TimerStandbyTaskDeleteHistoryEvent
@@ -171,14 +175,14 @@ func (t *timerQueueStandbyProcessorImpl) process(timerTask *persistence.TimerTas
 		if _, ok := err.(*workflow.EntityNotExistsError); ok {
 			// Timer could fire after the execution is deleted.
 			// In which case just ignore the error so we can complete the timer task.
-			t.timerQueueAckMgr.completeTimerTask(timerTask)
+			t.timerQueueAckMgr.CompleteTimerTask(timerTask)
 			err = nil
 		}
 		if err != nil {
 			t.metricsClient.IncCounter(scope, metrics.TaskFailures)
 		}
 	} else {
-		t.timerQueueAckMgr.completeTimerTask(timerTask)
+		t.timerQueueAckMgr.CompleteTimerTask(timerTask)
 	}
 
 	return err
@@ -189,7 +193,7 @@ func (t *timerQueueStandbyProcessorImpl) processExpiredUserTimer(timerTask *pers
 	sw := t.metricsClient.StartTimer(metrics.TimerStandbyTaskUserTimerScope, metrics.TaskLatency)
 	defer sw.Stop()
 
-	return t.processTimer(timerTask, func(msBuilder mutableState) error {
+	return t.processTimer(timerTask, func(msBuilder MutableState) error {
 		executionInfo := msBuilder.GetExecutionInfo()
 		tBuilder := t.historyService.getTimerBuilder(&workflow.WorkflowExecution{
 			WorkflowId: common.StringPtr(executionInfo.WorkflowID),
@@ -230,7 +234,7 @@ func (t *timerQueueStandbyProcessorImpl) processActivityTimeout(timerTask *persi
 	sw := t.metricsClient.StartTimer(metrics.TimerStandbyTaskActivityTimeoutScope, metrics.TaskLatency)
 	defer sw.Stop()
 
-	return t.processTimer(timerTask, func(msBuilder mutableState) error {
+	return t.processTimer(timerTask, func(msBuilder MutableState) error {
 		executionInfo := msBuilder.GetExecutionInfo()
 		tBuilder := t.historyService.getTimerBuilder(&workflow.WorkflowExecution{
 			WorkflowId: common.StringPtr(executionInfo.WorkflowID),
@@ -271,7 +275,7 @@ func (t *timerQueueStandbyProcessorImpl) processDecisionTimeout(timerTask *persi
 	sw := t.metricsClient.StartTimer(metrics.TimerStandbyTaskDecisionTimeoutScope, metrics.TaskLatency)
 	defer sw.Stop()
 
-	return t.processTimer(timerTask, func(msBuilder mutableState) error {
+	return t.processTimer(timerTask, func(msBuilder MutableState) error {
 		di, isPending := msBuilder.GetPendingDecision(timerTask.EventID)
 
 		if !isPending {
@@ -300,7 +304,7 @@ func (t *timerQueueStandbyProcessorImpl) processWorkflowTimeout(timerTask *persi
 	sw := t.metricsClient.StartTimer(metrics.TimerStandbyTaskWorkflowTimeoutScope, metrics.TaskLatency)
 	defer sw.Stop()
 
-	return t.processTimer(timerTask, func(msBuilder mutableState) error {
+	return t.processTimer(timerTask, func(msBuilder MutableState) error {
 		// we do not need to notity new timer to base, since if there is no new event being replicated
 		// checking again if the timer can be completed is meaningless
 
@@ -315,8 +319,35 @@ func (t *timerQueueStandbyProcessorImpl) processWorkflowTimeout(timerTask *persi
 	})
 }
 
-func (t *timerQueueStandbyProcessorImpl) processTimer(timerTask *persistence.TimerTaskInfo, fn func(mutableState) error) (retError error) {
-	context, release, err := t.cache.getOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(timerTask))
+// processRetryTimer keeps the retry timer of an activity pending for as long as the activity waits for that retry.
+// The timer is created by this cluster while the domain was active in it, or by the activity state synced from the
+// active cluster.  The retry is never dispatched from here, but once the domain fails over to this cluster the
+// failover processor picks the timer up and resumes the retry.  The timer is released as soon as the active cluster
+// syncs the start of that attempt or a later one.
+func (t *timerQueueStandbyProcessorImpl) processRetryTimer(timerTask *persistence.TimerTaskInfo) error {
+	t.metricsClient.IncCounter(metrics.TimerStandbyTaskRetryTimerScope, metrics.TaskRequests)
+	sw := t.metricsClient.StartTimer(metrics.TimerStandbyTaskRetryTimerScope, metrics.TaskLatency)
+	defer sw.Stop()
+
+	return t.processTimer(timerTask, func(msBuilder MutableState) error {
+		ai, isPending := msBuilder.GetActivityInfo(timerTask.EventID)
+		if !isPending || timerTask.ScheduleAttempt < int64(ai.Attempt) || ai.StartedID != common.EmptyEventID {
+			return nil
+		}
+
+		ok, err := verifyTaskVersion(t.shard, t.logger, timerTask.DomainID, ai.Version, timerTask.Version, timerTask)
+		if err != nil {
+			return err
+		} else if !ok {
+			return nil
+		}
+
+		return ErrTaskRetry
+	})
+}
+
+func (t *timerQueueStandbyProcessorImpl) processTimer(timerTask *persistence.TimerTaskInfo, fn func(MutableState) error) (retError error) {
+	context, release, err := t.cache.GetOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(timerTask))
 	if err != nil {
 		return err
 	}
@@ -261,7 +261,7 @@ func (s *timerQueueStandbyProcessorSuite) TestProcessExpiredUserTimer_Success()
 
 	persistenceMutableState := createMutableState(msBuilder)
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil).Once()
-	s.mocktimerQueueAckMgr.On("completeTimerTask", timerTask).Return(nil).Once()
+	s.mocktimerQueueAckMgr.On("CompleteTimerTask", timerTask).Return(nil).Once()
 
 	s.Nil(s.timerQueueStandbyProcessor.process(timerTask))
 }
@@ -323,7 +323,7 @@ func (s *timerQueueStandbyProcessorSuite) TestProcessExpiredUserTimer_Multiple()
 
 	persistenceMutableState := createMutableState(msBuilder)
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil).Once()
-	s.mocktimerQueueAckMgr.On("completeTimerTask", timerTask).Return(nil).Once()
+	s.mocktimerQueueAckMgr.On("CompleteTimerTask", timerTask).Return(nil).Once()
 
 	s.Nil(s.timerQueueStandbyProcessor.process(timerTask))
 }
@@ -441,7 +441,7 @@ func (s *timerQueueStandbyProcessorSuite) TestProcessActivityTimeout_Success() {
 
 	persistenceMutableState := createMutableState(msBuilder)
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil).Once()
-	s.mocktimerQueueAckMgr.On("completeTimerTask", timerTask).Return(nil).Once()
+	s.mocktimerQueueAckMgr.On("CompleteTimerTask", timerTask).Return(nil).Once()
 
 	s.Nil(s.timerQueueStandbyProcessor.process(timerTask))
 }
@@ -510,7 +510,7 @@ func (s *timerQueueStandbyProcessorSuite) TestProcessActivityTimeout_Multiple()
 
 	persistenceMutableState := createMutableState(msBuilder)
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil).Once()
-	s.mocktimerQueueAckMgr.On("completeTimerTask", timerTask).Return(nil).Once()
+	s.mocktimerQueueAckMgr.On("CompleteTimerTask", timerTask).Return(nil).Once()
 
 	s.Nil(s.timerQueueStandbyProcessor.process(timerTask))
 }
@@ -603,7 +603,7 @@ func (s *timerQueueStandbyProcessorSuite) TestProcessDecisionTimeout_Success() {
 
 	persistenceMutableState := createMutableState(msBuilder)
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil).Once()
-	s.mocktimerQueueAckMgr.On("completeTimerTask", timerTask).Return(nil).Once()
+	s.mocktimerQueueAckMgr.On("CompleteTimerTask", timerTask).Return(nil).Once()
 
 	s.Nil(s.timerQueueStandbyProcessor.process(timerTask))
 }
@@ -697,7 +697,7 @@ func (s *timerQueueStandbyProcessorSuite) TestProcessWorkflowTimeout_Success() {
 
 	persistenceMutableState := createMutableState(msBuilder)
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil).Once()
-	s.mocktimerQueueAckMgr.On("completeTimerTask", timerTask).Return(nil).Once()
+	s.mocktimerQueueAckMgr.On("CompleteTimerTask", timerTask).Return(nil).Once()
 
 	s.Nil(s.timerQueueStandbyProcessor.process(timerTask))
 }
@@ -726,6 +726,7 @@ func (s *timerQueueStandbyProcessorSuite) TestProcessRetryTimeout() {
 		},
 	)
 
+	// the activity the retry was for is no longer pending
 	timerTask := &persistence.TimerTaskInfo{
 		Version:             version,
 		DomainID:            domainID,
@@ -735,9 +736,123 @@ func (s *timerQueueStandbyProcessorSuite) TestProcessRetryTimeout() {
 		TaskType:            persistence.TaskTypeRetryTimer,
 		TimeoutType:         int(workflow.TimeoutTypeStartToClose),
 		VisibilityTimestamp: time.Now(),
+		EventID:             int64(5),
 	}
 
-	s.mocktimerQueueAckMgr.On("completeTimerTask", timerTask).Return(nil).Once()
+	persistenceMutableState := createMutableState(msBuilder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil).Once()
+	s.mocktimerQueueAckMgr.On("CompleteTimerTask", timerTask).Return(nil).Once()
+
+	s.Nil(s.timerQueueStandbyProcessor.process(timerTask))
+}
+
+func (s *timerQueueStandbyProcessorSuite) TestProcessRetryTimeout_Pending() {
+	domainID := "some random domain ID"
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	version := int64(4096)
+	msBuilder := newMutableStateBuilderWithReplicationState(s.mockShard.GetConfig(), s.logger, version)
+	msBuilder.AddWorkflowExecutionStartedEvent(
+		execution,
+		&history.StartWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(domainID),
+			StartRequest: &workflow.StartWorkflowExecutionRequest{
+				WorkflowType: &workflow.WorkflowType{Name: common.StringPtr(workflowType)},
+				TaskList:     &workflow.TaskList{Name: common.StringPtr(taskListName)},
+				ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(2),
+				TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			},
+		},
+	)
+
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	event := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, di.StartedID, nil, "some random identity")
+
+	timerTimeout := 2 * time.Second
+	scheduledEvent, ai := addActivityTaskScheduledEvent(msBuilder, event.GetEventId(), "activity", "activity type",
+		"tasklist", []byte(nil), int32(timerTimeout.Seconds()), int32(timerTimeout.Seconds()),
+		int32(timerTimeout.Seconds()))
+	// the activity waits for its first retry
+	ai.Attempt = 1
+
+	timerTask := &persistence.TimerTaskInfo{
+		Version:             version,
+		DomainID:            domainID,
+		WorkflowID:          execution.GetWorkflowId(),
+		RunID:               execution.GetRunId(),
+		TaskID:              int64(100),
+		TaskType:            persistence.TaskTypeRetryTimer,
+		VisibilityTimestamp: time.Now(),
+		EventID:             scheduledEvent.GetEventId(),
+		ScheduleAttempt:     1,
+	}
+
+	persistenceMutableState := createMutableState(msBuilder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil).Once()
+
+	s.Equal(ErrTaskRetry, s.timerQueueStandbyProcessor.process(timerTask))
+}
+
+func (s *timerQueueStandbyProcessorSuite) TestProcessRetryTimeout_StartSynced() {
+	domainID := "some random domain ID"
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	version := int64(4096)
+	msBuilder := newMutableStateBuilderWithReplicationState(s.mockShard.GetConfig(), s.logger, version)
+	msBuilder.AddWorkflowExecutionStartedEvent(
+		execution,
+		&history.StartWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(domainID),
+			StartRequest: &workflow.StartWorkflowExecutionRequest{
+				WorkflowType: &workflow.WorkflowType{Name: common.StringPtr(workflowType)},
+				TaskList:     &workflow.TaskList{Name: common.StringPtr(taskListName)},
+				ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(2),
+				TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			},
+		},
+	)
+
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	event := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, di.StartedID, nil, "some random identity")
+
+	timerTimeout := 2 * time.Second
+	scheduledEvent, ai := addActivityTaskScheduledEvent(msBuilder, event.GetEventId(), "activity", "activity type",
+		"tasklist", []byte(nil), int32(timerTimeout.Seconds()), int32(timerTimeout.Seconds()),
+		int32(timerTimeout.Seconds()))
+	// the active cluster synced the start of the first retry
+	ai.Attempt = 1
+	ai.StartedID = common.TransientEventID
+	ai.StartedTime = time.Now()
+
+	timerTask := &persistence.TimerTaskInfo{
+		Version:             version,
+		DomainID:            domainID,
+		WorkflowID:          execution.GetWorkflowId(),
+		RunID:               execution.GetRunId(),
+		TaskID:              int64(100),
+		TaskType:            persistence.TaskTypeRetryTimer,
+		VisibilityTimestamp: time.Now(),
+		EventID:             scheduledEvent.GetEventId(),
+		ScheduleAttempt:     1,
+	}
+
+	persistenceMutableState := createMutableState(msBuilder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil).Once()
+	s.mocktimerQueueAckMgr.On("CompleteTimerTask", timerTask).Return(nil).Once()
 
 	s.Nil(s.timerQueueStandbyProcessor.process(timerTask))
 }
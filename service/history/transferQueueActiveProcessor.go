@@ -50,11 +50,13 @@ type (
 		visibilityManager  persistence.VisibilityManager
 		matchingClient     matching.Client
 		historyClient      history.Client
-		cache              *historyCache
+		cache              HistoryCache
 		transferTaskFilter transferTaskFilter
 		logger             bark.Logger
 		metricsClient      metrics.Client
 		maxReadAckLevel    maxReadAckLevel
+		// decisionDispatcher is nil for failover processors, which always dispatch synchronously
+		decisionDispatcher *decisionTaskDispatcher
 		*transferQueueProcessorBase
 		*queueProcessorBase
 		queueAckMgr
@@ -63,6 +65,8 @@ type (
 
 var (
 	errUnknownTransferTask = errors.New("Unknown transfer task")
+	// errDecisionTaskDispatchedAsync indicates the task is acked by the decision task dispatcher once it is done
+	errDecisionTaskDispatchedAsync = errors.New("decision task handed off for async dispatch")
 )
 
 func newTransferQueueActiveProcessor(shard ShardContext, historyService *historyEngineImpl, visibilityMgr persistence.VisibilityManager,
@@ -84,7 +88,10 @@ func newTransferQueueActiveProcessor(shard ShardContext, historyService *history
 		logging.TagWorkflowCluster: currentClusterName,
 	})
 	transferTaskFilter := func(task *persistence.TransferTaskInfo) (bool, error) {
-		return verifyActiveTask(shard, logger, task.DomainID, task)
+		if err := verifyTransferTaskNotPaused(shard, task.TaskType); err != nil {
+			return false, err
+		}
+		return verifyActiveTask(shard, logger, task.DomainID, task.TaskID, getTransferTaskFailoverVersion(task), task)
 	}
 	maxReadAckLevel := func() int64 {
 		return shard.GetTransferMaxReadLevel()
@@ -114,8 +121,11 @@ func newTransferQueueActiveProcessor(shard ShardContext, historyService *history
 		transferTaskFilter:         transferTaskFilter,
 		transferQueueProcessorBase: newTransferQueueProcessorBase(shard, options, maxReadAckLevel, updateTransferAckLevel, transferQueueShutdown),
 	}
+	processor.decisionDispatcher = newDecisionTaskDispatcher(shard, retryableMatchingClient, options.MaxRetryCount,
+		logger, historyService.metricsClient)
 
-	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetTransferClusterAckLevel(currentClusterName), logger)
+	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetTransferClusterAckLevel(currentClusterName),
+		common.TransferQueueBacklogPrefix+currentClusterName, logger)
 	queueProcessorBase := newQueueProcessorBase(currentClusterName, shard, options, processor, queueAckMgr, logger)
 	processor.queueAckMgr = queueAckMgr
 	processor.queueProcessorBase = queueProcessorBase
@@ -125,7 +135,7 @@ func newTransferQueueActiveProcessor(shard ShardContext, historyService *history
 
 func newTransferQueueFailoverProcessor(shard ShardContext, historyService *historyEngineImpl, visibilityMgr persistence.VisibilityManager,
 	matchingClient matching.Client, historyClient history.Client, domainID string, standbyClusterName string,
-	minLevel int64, maxLevel int64, logger bark.Logger) *transferQueueActiveProcessorImpl {
+	failoverVersion int64, minLevel int64, maxLevel int64, logger bark.Logger) *transferQueueActiveProcessorImpl {
 	config := shard.GetConfig()
 	options := &QueueProcessorOptions{
 		StartDelay:                       config.TransferProcessorFailoverStartDelay,
@@ -145,7 +155,10 @@ func newTransferQueueFailoverProcessor(shard ShardContext, historyService *histo
 		logging.TagFailover:        "from: " + standbyClusterName,
 	})
 	transferTaskFilter := func(task *persistence.TransferTaskInfo) (bool, error) {
-		return verifyFailoverActiveTask(logger, domainID, task.DomainID, task)
+		if err := verifyTransferTaskNotPaused(shard, task.TaskType); err != nil {
+			return false, err
+		}
+		return verifyFailoverActiveTask(logger, domainID, task.DomainID, failoverVersion, getTransferTaskFailoverVersion(task), task)
 	}
 	maxReadAckLevel := func() int64 {
 		return maxLevel // this is a const
@@ -154,10 +167,11 @@ func newTransferQueueFailoverProcessor(shard ShardContext, historyService *histo
 		return shard.UpdateTransferFailoverLevel(
 			domainID,
 			persistence.TransferFailoverLevel{
-				MinLevel:     minLevel,
-				CurrentLevel: ackLevel,
-				MaxLevel:     maxLevel,
-				DomainIDs:    []string{domainID},
+				MinLevel:        minLevel,
+				CurrentLevel:    ackLevel,
+				MaxLevel:        maxLevel,
+				FailoverVersion: failoverVersion,
+				DomainIDs:       []string{domainID},
 			},
 		)
 	}
@@ -189,6 +203,20 @@ func newTransferQueueFailoverProcessor(shard ShardContext, historyService *histo
 	return processor
 }
 
+func (t *transferQueueActiveProcessorImpl) Start() {
+	if t.decisionDispatcher != nil {
+		t.decisionDispatcher.Start()
+	}
+	t.queueProcessorBase.Start()
+}
+
+func (t *transferQueueActiveProcessorImpl) Stop() {
+	t.queueProcessorBase.Stop()
+	if t.decisionDispatcher != nil {
+		t.decisionDispatcher.Stop()
+	}
+}
+
 func (t *transferQueueActiveProcessorImpl) notifyNewTask() {
 	t.queueProcessorBase.notifyNewTask()
 }
@@ -235,6 +263,9 @@ func (t *transferQueueActiveProcessorImpl) process(qTask queueTaskInfo) error {
 	t.logger.Debugf("Processing task: (%s), for WorkflowID: %v, RunID: %v, Type: %v, EventID: %v, Error: %v",
 		task.TaskID, task.WorkflowID, task.RunID, task.TaskType, task.ScheduleID, err)
 
+	if err == errDecisionTaskDispatchedAsync {
+		return nil
+	}
 	if err != nil {
 		if _, ok := err.(*workflow.EntityNotExistsError); ok {
 			// Timer could fire after the execution is deleted.
@@ -267,13 +298,13 @@ func (t *transferQueueActiveProcessorImpl) processActivityTask(task *persistence
 		Name: &task.TaskList,
 	}
 
-	context, release, err := t.cache.getOrCreateWorkflowExecution(domainID, execution)
+	context, release, err := t.cache.GetOrCreateWorkflowExecution(domainID, execution)
 	if err != nil {
 		return err
 	}
 	defer func() { release(retError) }()
 
-	var msBuilder mutableState
+	var msBuilder MutableState
 	msBuilder, err = loadMutableStateForTransferTask(context, task, t.metricsClient, t.logger)
 	if err != nil {
 		return err
@@ -294,6 +325,9 @@ func (t *transferQueueActiveProcessorImpl) processActivityTask(task *persistence
 	}
 
 	timeout := common.MinInt32(ai.ScheduleToStartTimeout, common.MaxTaskTimeout)
+	scheduledTime := ai.ScheduledTime
+	isolationGroup := msBuilder.GetExecutionInfo().IsolationGroup
+	priority := msBuilder.GetExecutionInfo().Priority
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
@@ -304,6 +338,9 @@ func (t *transferQueueActiveProcessorImpl) processActivityTask(task *persistence
 		TaskList:                      taskList,
 		ScheduleId:                    &task.ScheduleID,
 		ScheduleToStartTimeoutSeconds: common.Int32Ptr(timeout),
+		IsolationGroup:                common.StringPtr(isolationGroup),
+		Priority:                      common.Int32Ptr(priority),
+		ScheduledTimestamp:            common.Int64Ptr(scheduledTime.UnixNano()),
 	})
 
 	return err
@@ -325,13 +362,13 @@ func (t *transferQueueActiveProcessorImpl) processDecisionTask(task *persistence
 	}
 
 	// get workflow timeout
-	context, release, err := t.cache.getOrCreateWorkflowExecution(domainID, execution)
+	context, release, err := t.cache.GetOrCreateWorkflowExecution(domainID, execution)
 	if err != nil {
 		return err
 	}
 	defer func() { release(retError) }()
 
-	var msBuilder mutableState
+	var msBuilder MutableState
 	msBuilder, err = loadMutableStateForTransferTask(context, task, t.metricsClient, t.logger)
 	if err != nil {
 		return err
@@ -340,15 +377,21 @@ func (t *transferQueueActiveProcessorImpl) processDecisionTask(task *persistence
 	}
 
 	di, found := msBuilder.GetPendingDecision(task.ScheduleID)
-	if !found {
+	// The first decision of a run can be started eagerly by StartWorkflowExecution, and even be completed before its
+	// transfer task is processed.  Such a decision is not dispatched through matching, the task only records the run
+	// in the open visibility.
+	recordStartedOnly := task.ScheduleID <= common.FirstEventID+2 && (!found || di.StartedID != common.EmptyEventID)
+	if !found && !recordStartedOnly {
 		logging.LogDuplicateTransferTaskEvent(t.logger, persistence.TaskTypeDecisionTimeout, task.TaskID, task.ScheduleID)
 		return nil
 	}
-	ok, err := verifyTaskVersion(t.shard, t.logger, domainID, di.Version, task.Version, task)
-	if err != nil {
-		return err
-	} else if !ok {
-		return nil
+	if found {
+		ok, err := verifyTaskVersion(t.shard, t.logger, domainID, di.Version, task.Version, task)
+		if err != nil {
+			return err
+		} else if !ok {
+			return nil
+		}
 	}
 
 	executionInfo := msBuilder.GetExecutionInfo()
@@ -356,6 +399,10 @@ func (t *transferQueueActiveProcessorImpl) processDecisionTask(task *persistence
 	decisionTimeout := common.MinInt32(workflowTimeout, common.MaxTaskTimeout)
 	wfTypeName := executionInfo.WorkflowTypeName
 	startTimestamp := executionInfo.StartTimestamp
+	tags := executionInfo.Tags
+	firstRunID := executionInfo.FirstRunID
+	isolationGroup := executionInfo.IsolationGroup
+	priority := executionInfo.Priority
 	if msBuilder.IsStickyTaskListEnabled() {
 		taskList.Name = common.StringPtr(executionInfo.StickyTaskList)
 		taskList.Kind = common.TaskListKindPtr(workflow.TaskListKindSticky)
@@ -365,22 +412,44 @@ func (t *transferQueueActiveProcessorImpl) processDecisionTask(task *persistence
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
-	err = t.matchingClient.AddDecisionTask(nil, &m.AddDecisionTaskRequest{
+	if recordStartedOnly {
+		return t.recordWorkflowExecutionStarted(execution, task, wfTypeName, startTimestamp, workflowTimeout, tags,
+			firstRunID)
+	}
+
+	// the visibility timestamp of the transfer task is the time the decision was scheduled
+	request := &m.AddDecisionTaskRequest{
 		DomainUUID:                    common.StringPtr(domainID),
 		Execution:                     &execution,
 		TaskList:                      taskList,
 		ScheduleId:                    &task.ScheduleID,
 		ScheduleToStartTimeoutSeconds: common.Int32Ptr(decisionTimeout),
-	})
+		IsolationGroup:                common.StringPtr(isolationGroup),
+		Priority:                      common.Int32Ptr(priority),
+		ScheduledTimestamp:            common.Int64Ptr(task.VisibilityTimestamp.UnixNano()),
+	}
+	postDispatch := func() error {
+		if task.ScheduleID <= common.FirstEventID+2 {
+			return t.recordWorkflowExecutionStarted(execution, task, wfTypeName, startTimestamp, workflowTimeout, tags,
+				firstRunID)
+		}
+		return nil
+	}
 
-	if err != nil {
-		return err
+	if t.decisionDispatcher != nil && t.decisionDispatcher.tryDispatch(&decisionDispatchRequest{
+		request:      request,
+		postDispatch: postDispatch,
+		onComplete:   func() { t.queueAckMgr.completeQueueTask(task.TaskID) },
+	}) {
+		return errDecisionTaskDispatchedAsync
 	}
 
-	if task.ScheduleID <= common.FirstEventID+2 {
-		err = t.recordWorkflowExecutionStarted(execution, task, wfTypeName, startTimestamp, workflowTimeout)
+	err = t.matchingClient.AddDecisionTask(nil, request)
+	if err != nil {
+		return err
 	}
 
+	err = postDispatch()
 	if err != nil {
 		if _, ok := err.(*workflow.EntityNotExistsError); ok {
 			// this could happen if this is a duplicate processing of the task, and the execution has already completed.
@@ -401,13 +470,13 @@ func (t *transferQueueActiveProcessorImpl) processCloseExecution(task *persisten
 	execution := workflow.WorkflowExecution{WorkflowId: common.StringPtr(task.WorkflowID),
 		RunId: common.StringPtr(task.RunID)}
 
-	context, release, err := t.cache.getOrCreateWorkflowExecution(domainID, execution)
+	context, release, err := t.cache.GetOrCreateWorkflowExecution(domainID, execution)
 	if err != nil {
 		return err
 	}
 	defer func() { release(retError) }()
 
-	var msBuilder mutableState
+	var msBuilder MutableState
 	msBuilder, err = loadMutableStateForTransferTask(context, task, t.metricsClient, t.logger)
 	if err != nil {
 		return err
@@ -439,6 +508,8 @@ func (t *transferQueueActiveProcessorImpl) processCloseExecution(task *persisten
 	workflowCloseTimestamp := msBuilder.GetLastUpdatedTimestamp()
 	workflowCloseStatus := getWorkflowExecutionCloseStatus(executionInfo.CloseStatus)
 	workflowHistoryLength := msBuilder.GetNextEventID()
+	workflowTags := executionInfo.Tags
+	workflowFirstRunID := executionInfo.FirstRunID
 
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
@@ -482,16 +553,37 @@ func (t *transferQueueActiveProcessorImpl) processCloseExecution(task *persisten
 		retentionSeconds = int64(domainEntry.GetConfig().Retention) * 24 * 60 * 60
 	}
 
-	return t.visibilityManager.RecordWorkflowExecutionClosed(&persistence.RecordWorkflowExecutionClosedRequest{
-		DomainUUID:       task.DomainID,
-		Execution:        execution,
-		WorkflowTypeName: workflowTypeName,
-		StartTimestamp:   workflowStartTimestamp,
-		CloseTimestamp:   workflowCloseTimestamp,
-		Status:           workflowCloseStatus,
-		HistoryLength:    workflowHistoryLength,
-		RetentionSeconds: retentionSeconds,
+	err = t.visibilityManager.RecordWorkflowExecutionClosed(&persistence.RecordWorkflowExecutionClosedRequest{
+		DomainUUID:         task.DomainID,
+		Execution:          execution,
+		WorkflowTypeName:   workflowTypeName,
+		StartTimestamp:     workflowStartTimestamp,
+		ExecutionTimestamp: workflowStartTimestamp,
+		CloseTimestamp:     workflowCloseTimestamp,
+		Status:             workflowCloseStatus,
+		HistoryLength:      workflowHistoryLength,
+		RetentionSeconds:   retentionSeconds,
+		Tags:               workflowTags,
+		FirstRunID:         workflowFirstRunID,
+	})
+	if err != nil || domainEntry == nil || t.historyService.outboundProcessor == nil {
+		return err
+	}
+
+	// the summary is published in the background, an unavailable sink must not fail the task
+	t.historyService.outboundProcessor.publishCloseEvent(domainEntry.GetInfo().Name, &workflowCloseSummary{
+		DomainID:      task.DomainID,
+		Domain:        domainEntry.GetInfo().Name,
+		WorkflowID:    task.WorkflowID,
+		RunID:         task.RunID,
+		WorkflowType:  workflowTypeName,
+		CloseStatus:   workflowCloseStatus.String(),
+		StartTime:     time.Unix(0, workflowStartTimestamp),
+		CloseTime:     time.Unix(0, workflowCloseTimestamp),
+		HistoryLength: workflowHistoryLength,
+		Memo:          workflowTags,
 	})
+	return nil
 }
 
 func (t *transferQueueActiveProcessorImpl) processCancelExecution(task *persistence.TransferTaskInfo) (retError error) {
@@ -507,16 +599,16 @@ func (t *transferQueueActiveProcessorImpl) processCancelExecution(task *persiste
 		RunId:      common.StringPtr(task.RunID),
 	}
 
-	var context *workflowExecutionContext
-	var release releaseWorkflowExecutionFunc
-	context, release, err = t.cache.getOrCreateWorkflowExecution(domainID, execution)
+	var context *WorkflowExecutionContext
+	var release ReleaseWorkflowExecutionFunc
+	context, release, err = t.cache.GetOrCreateWorkflowExecution(domainID, execution)
 	if err != nil {
 		return err
 	}
 	defer func() { release(retError) }()
 
 	// First load the execution to validate if there is pending request cancellation for this transfer task
-	var msBuilder mutableState
+	var msBuilder MutableState
 	msBuilder, err = loadMutableStateForTransferTask(context, task, t.metricsClient, t.logger)
 	if err != nil {
 		return err
@@ -628,15 +720,15 @@ func (t *transferQueueActiveProcessorImpl) processSignalExecution(task *persiste
 	execution := workflow.WorkflowExecution{WorkflowId: common.StringPtr(task.WorkflowID),
 		RunId: common.StringPtr(task.RunID)}
 
-	var context *workflowExecutionContext
-	var release releaseWorkflowExecutionFunc
-	context, release, err = t.cache.getOrCreateWorkflowExecution(domainID, execution)
+	var context *WorkflowExecutionContext
+	var release ReleaseWorkflowExecutionFunc
+	context, release, err = t.cache.GetOrCreateWorkflowExecution(domainID, execution)
 	if err != nil {
 		return err
 	}
 	defer func() { release(retError) }()
 
-	var msBuilder mutableState
+	var msBuilder MutableState
 	msBuilder, err = loadMutableStateForTransferTask(context, task, t.metricsClient, t.logger)
 	if err != nil {
 		return err
@@ -760,16 +852,16 @@ func (t *transferQueueActiveProcessorImpl) processStartChildExecution(task *pers
 	execution := workflow.WorkflowExecution{WorkflowId: common.StringPtr(task.WorkflowID),
 		RunId: common.StringPtr(task.RunID)}
 
-	var context *workflowExecutionContext
-	var release releaseWorkflowExecutionFunc
-	context, release, err = t.cache.getOrCreateWorkflowExecution(domainID, execution)
+	var context *WorkflowExecutionContext
+	var release ReleaseWorkflowExecutionFunc
+	context, release, err = t.cache.GetOrCreateWorkflowExecution(domainID, execution)
 	if err != nil {
 		return err
 	}
 	defer func() { release(retError) }()
 
 	// First step is to load workflow execution so we can retrieve the initiated event
-	var msBuilder mutableState
+	var msBuilder MutableState
 	msBuilder, err = loadMutableStateForTransferTask(context, task, t.metricsClient, t.logger)
 	if err != nil {
 		return err
@@ -833,6 +925,8 @@ func (t *transferQueueActiveProcessorImpl) processStartChildExecution(task *pers
 				RequestId:             common.StringPtr(ci.CreateRequestID),
 				WorkflowIdReusePolicy: attributes.WorkflowIdReusePolicy,
 				ChildPolicy:           attributes.ChildPolicy,
+				// Children inherit the priority of their parent
+				Priority: common.Int32Ptr(msBuilder.GetExecutionInfo().Priority),
 			},
 			ParentExecutionInfo: &h.ParentExecutionInfo{
 				DomainUUID: common.StringPtr(domainID),
@@ -885,7 +979,7 @@ func (t *transferQueueActiveProcessorImpl) processStartChildExecution(task *pers
 
 func (t *transferQueueActiveProcessorImpl) recordWorkflowExecutionStarted(
 	execution workflow.WorkflowExecution, task *persistence.TransferTaskInfo, wfTypeName string,
-	startTimestamp time.Time, timeout int32,
+	startTimestamp time.Time, timeout int32, tags map[string]string, firstRunID string,
 ) error {
 	err := t.visibilityManager.RecordWorkflowExecutionStarted(&persistence.RecordWorkflowExecutionStartedRequest{
 		DomainUUID:       task.DomainID,
@@ -893,17 +987,19 @@ func (t *transferQueueActiveProcessorImpl) recordWorkflowExecutionStarted(
 		WorkflowTypeName: wfTypeName,
 		StartTimestamp:   startTimestamp.UnixNano(),
 		WorkflowTimeout:  int64(timeout),
+		Tags:             tags,
+		FirstRunID:       firstRunID,
 	})
 
 	return err
 }
 
 func (t *transferQueueActiveProcessorImpl) recordChildExecutionStarted(task *persistence.TransferTaskInfo,
-	context *workflowExecutionContext, initiatedAttributes *workflow.StartChildWorkflowExecutionInitiatedEventAttributes,
+	context *WorkflowExecutionContext, initiatedAttributes *workflow.StartChildWorkflowExecutionInitiatedEventAttributes,
 	runID string) error {
 
 	return t.updateWorkflowExecution(task.DomainID, context, true,
-		func(msBuilder mutableState) error {
+		func(msBuilder MutableState) error {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
 			}
@@ -926,11 +1022,11 @@ func (t *transferQueueActiveProcessorImpl) recordChildExecutionStarted(task *per
 }
 
 func (t *transferQueueActiveProcessorImpl) recordStartChildExecutionFailed(task *persistence.TransferTaskInfo,
-	context *workflowExecutionContext,
+	context *WorkflowExecutionContext,
 	initiatedAttributes *workflow.StartChildWorkflowExecutionInitiatedEventAttributes) error {
 
 	return t.updateWorkflowExecution(task.DomainID, context, true,
-		func(msBuilder mutableState) error {
+		func(msBuilder MutableState) error {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
 			}
@@ -969,10 +1065,10 @@ func (t *transferQueueActiveProcessorImpl) createFirstDecisionTask(domainID stri
 }
 
 func (t *transferQueueActiveProcessorImpl) requestCancelCompleted(task *persistence.TransferTaskInfo,
-	context *workflowExecutionContext, request *h.RequestCancelWorkflowExecutionRequest) error {
+	context *WorkflowExecutionContext, request *h.RequestCancelWorkflowExecutionRequest) error {
 
 	return t.updateWorkflowExecution(task.DomainID, context, true,
-		func(msBuilder mutableState) error {
+		func(msBuilder MutableState) error {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
 			}
@@ -995,11 +1091,11 @@ func (t *transferQueueActiveProcessorImpl) requestCancelCompleted(task *persiste
 }
 
 func (t *transferQueueActiveProcessorImpl) requestSignalCompleted(task *persistence.TransferTaskInfo,
-	context *workflowExecutionContext,
+	context *WorkflowExecutionContext,
 	request *h.SignalWorkflowExecutionRequest) error {
 
 	return t.updateWorkflowExecution(task.DomainID, context, true,
-		func(msBuilder mutableState) error {
+		func(msBuilder MutableState) error {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
 			}
@@ -1022,10 +1118,10 @@ func (t *transferQueueActiveProcessorImpl) requestSignalCompleted(task *persiste
 }
 
 func (t *transferQueueActiveProcessorImpl) requestCancelFailed(task *persistence.TransferTaskInfo,
-	context *workflowExecutionContext, request *h.RequestCancelWorkflowExecutionRequest) error {
+	context *WorkflowExecutionContext, request *h.RequestCancelWorkflowExecutionRequest) error {
 
 	return t.updateWorkflowExecution(task.DomainID, context, true,
-		func(msBuilder mutableState) error {
+		func(msBuilder MutableState) error {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return &workflow.EntityNotExistsError{Message: "Workflow execution already completed."}
 			}
@@ -1049,11 +1145,11 @@ func (t *transferQueueActiveProcessorImpl) requestCancelFailed(task *persistence
 }
 
 func (t *transferQueueActiveProcessorImpl) requestSignalFailed(task *persistence.TransferTaskInfo,
-	context *workflowExecutionContext,
+	context *WorkflowExecutionContext,
 	request *h.SignalWorkflowExecutionRequest) error {
 
 	return t.updateWorkflowExecution(task.DomainID, context, true,
-		func(msBuilder mutableState) error {
+		func(msBuilder MutableState) error {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return &workflow.EntityNotExistsError{Message: "Workflow is not running."}
 			}
@@ -1077,8 +1173,8 @@ func (t *transferQueueActiveProcessorImpl) requestSignalFailed(task *persistence
 		})
 }
 
-func (t *transferQueueActiveProcessorImpl) updateWorkflowExecution(domainID string, context *workflowExecutionContext,
-	createDecisionTask bool, action func(builder mutableState) error) error {
+func (t *transferQueueActiveProcessorImpl) updateWorkflowExecution(domainID string, context *WorkflowExecutionContext,
+	createDecisionTask bool, action func(builder MutableState) error) error {
 Update_History_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
 		msBuilder, err1 := context.loadWorkflowExecution()
@@ -23,6 +23,7 @@ package history
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/pborman/uuid"
 	log "github.com/sirupsen/logrus"
@@ -283,6 +284,59 @@ func (s *transferQueueActiveProcessorSuite) TestProcessActivityTask_Duplication(
 	s.Nil(s.transferQueueActiveProcessor.process(transferTask))
 }
 
+func (s *transferQueueActiveProcessorSuite) TestProcessTask_Paused() {
+	s.mockShard.GetConfig().TransferTaskPaused = dynamicconfig.GetBoolPropertyFn(true)
+
+	transferTask := &persistence.TransferTaskInfo{
+		Version:    s.version,
+		DomainID:   validDomainID,
+		WorkflowID: "some random workflow ID",
+		RunID:      uuid.New(),
+		TaskID:     int64(59),
+		TaskList:   "some random task list",
+		TaskType:   persistence.TransferTaskTypeActivityTask,
+		ScheduleID: int64(5),
+	}
+
+	// the task is neither processed nor acked while its task type is paused
+	err := s.transferQueueActiveProcessor.process(transferTask)
+	s.Equal(ErrTaskPaused, err)
+}
+
+func (s *transferQueueActiveProcessorSuite) TestProcessWithRetry_Paused() {
+	s.mockShard.GetConfig().TransferTaskPaused = dynamicconfig.GetBoolPropertyFn(true)
+	timeSource := common.NewEventTimeSource().Update(time.Now())
+	pausedQueue := newTaskRedeliveryQueue(time.Minute, time.Minute, timeSource)
+	s.transferQueueActiveProcessor.queueProcessorBase.pausedQueue = pausedQueue
+
+	transferTask := &persistence.TransferTaskInfo{
+		Version:    s.version,
+		DomainID:   validDomainID,
+		WorkflowID: "some random workflow ID",
+		RunID:      uuid.New(),
+		TaskID:     int64(59),
+		TaskList:   "some random task list",
+		TaskType:   persistence.TransferTaskTypeActivityTask,
+		ScheduleID: int64(5),
+	}
+
+	// the paused task is parked, the worker is free to take the next task right away
+	s.transferQueueActiveProcessor.queueProcessorBase.processWithRetry(transferTask)
+	s.Equal(1, pausedQueue.size())
+	s.Empty(pausedQueue.getDueTasks())
+
+	timeSource.Update(timeSource.Now().Add(time.Minute))
+	dueTasks := pausedQueue.getDueTasks()
+	s.Equal([]queueTaskInfo{transferTask}, dueTasks)
+
+	// once resumed, the task handed back is processed and forgotten by the paused queue
+	s.mockShard.GetConfig().TransferTaskPaused = dynamicconfig.GetBoolPropertyFn(false)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, &workflow.EntityNotExistsError{}).Once()
+	s.mockQueueAckMgr.On("completeQueueTask", transferTask.TaskID).Return(nil).Once()
+	s.transferQueueActiveProcessor.queueProcessorBase.processWithRetry(dueTasks[0])
+	s.Equal(0, pausedQueue.size())
+}
+
 func (s *transferQueueActiveProcessorSuite) TestProcessDecisionTask_FirstDecision() {
 	domainID := "some random domain ID"
 	execution := workflow.WorkflowExecution{
@@ -329,6 +383,163 @@ func (s *transferQueueActiveProcessorSuite) TestProcessDecisionTask_FirstDecisio
 	s.Nil(s.transferQueueActiveProcessor.process(transferTask))
 }
 
+func (s *transferQueueActiveProcessorSuite) TestProcessDecisionTask_FirstDecisionStartedEagerly() {
+	domainID := "some random domain ID"
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	msBuilder := newMutableStateBuilderWithReplicationState(s.mockShard.GetConfig(), s.logger, s.version)
+	msBuilder.AddWorkflowExecutionStartedEvent(
+		execution,
+		&history.StartWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(domainID),
+			StartRequest: &workflow.StartWorkflowExecutionRequest{
+				WorkflowType: &workflow.WorkflowType{Name: common.StringPtr(workflowType)},
+				TaskList:     &workflow.TaskList{Name: common.StringPtr(taskListName)},
+				ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(2),
+				TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			},
+		},
+	)
+
+	taskID := int64(59)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	// the decision was started along with the run by StartWorkflowExecution
+	event := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, taskListName, "some random identity")
+	msBuilder.UpdateReplicationStateLastEventID("", s.version, event.GetEventId())
+
+	transferTask := &persistence.TransferTaskInfo{
+		Version:    s.version,
+		DomainID:   domainID,
+		WorkflowID: execution.GetWorkflowId(),
+		RunID:      execution.GetRunId(),
+		TaskID:     taskID,
+		TaskList:   taskListName,
+		TaskType:   persistence.TransferTaskTypeDecisionTask,
+		ScheduleID: di.ScheduleID,
+	}
+
+	persistenceMutableState := createMutableState(msBuilder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockVisibilityMgr.On("RecordWorkflowExecutionStarted", s.createRecordWorkflowExecutionStartedRequest(transferTask, msBuilder)).Once().Return(nil)
+	s.mockQueueAckMgr.On("completeQueueTask", taskID).Return(nil).Once()
+	s.Nil(s.transferQueueActiveProcessor.process(transferTask))
+	s.mockMatchingClient.AssertNotCalled(s.T(), "AddDecisionTask", mock.Anything, mock.Anything)
+}
+
+func (s *transferQueueActiveProcessorSuite) TestProcessDecisionTask_FirstDecisionCompletedEagerly() {
+	domainID := "some random domain ID"
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	msBuilder := newMutableStateBuilderWithReplicationState(s.mockShard.GetConfig(), s.logger, s.version)
+	msBuilder.AddWorkflowExecutionStartedEvent(
+		execution,
+		&history.StartWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(domainID),
+			StartRequest: &workflow.StartWorkflowExecutionRequest{
+				WorkflowType: &workflow.WorkflowType{Name: common.StringPtr(workflowType)},
+				TaskList:     &workflow.TaskList{Name: common.StringPtr(taskListName)},
+				ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(2),
+				TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			},
+		},
+	)
+
+	taskID := int64(59)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	// the decision was started along with the run, and completed before its transfer task is processed
+	event := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, taskListName, "some random identity")
+	event = addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, event.GetEventId(), nil, "some random identity")
+	msBuilder.UpdateReplicationStateLastEventID("", s.version, event.GetEventId())
+
+	transferTask := &persistence.TransferTaskInfo{
+		Version:    s.version,
+		DomainID:   domainID,
+		WorkflowID: execution.GetWorkflowId(),
+		RunID:      execution.GetRunId(),
+		TaskID:     taskID,
+		TaskList:   taskListName,
+		TaskType:   persistence.TransferTaskTypeDecisionTask,
+		ScheduleID: di.ScheduleID,
+	}
+
+	persistenceMutableState := createMutableState(msBuilder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockVisibilityMgr.On("RecordWorkflowExecutionStarted", s.createRecordWorkflowExecutionStartedRequest(transferTask, msBuilder)).Once().Return(nil)
+	s.mockQueueAckMgr.On("completeQueueTask", taskID).Return(nil).Once()
+	s.Nil(s.transferQueueActiveProcessor.process(transferTask))
+	s.mockMatchingClient.AssertNotCalled(s.T(), "AddDecisionTask", mock.Anything, mock.Anything)
+}
+
+func (s *transferQueueActiveProcessorSuite) TestProcessDecisionTask_AsyncDispatch() {
+	domainID := "some random domain ID"
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	msBuilder := newMutableStateBuilderWithReplicationState(s.mockShard.GetConfig(), s.logger, s.version)
+	msBuilder.AddWorkflowExecutionStartedEvent(
+		execution,
+		&history.StartWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(domainID),
+			StartRequest: &workflow.StartWorkflowExecutionRequest{
+				WorkflowType: &workflow.WorkflowType{Name: common.StringPtr(workflowType)},
+				TaskList:     &workflow.TaskList{Name: common.StringPtr(taskListName)},
+				ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(2),
+				TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			},
+		},
+	)
+
+	taskID := int64(59)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	msBuilder.UpdateReplicationStateLastEventID("", s.version, di.ScheduleID)
+
+	transferTask := &persistence.TransferTaskInfo{
+		Version:    s.version,
+		DomainID:   domainID,
+		WorkflowID: execution.GetWorkflowId(),
+		RunID:      execution.GetRunId(),
+		TaskID:     taskID,
+		TaskList:   taskListName,
+		TaskType:   persistence.TransferTaskTypeDecisionTask,
+		ScheduleID: di.ScheduleID,
+	}
+
+	dispatcher := s.transferQueueActiveProcessor.decisionDispatcher
+	dispatcher.enabled = dynamicconfig.GetBoolPropertyFn(true)
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	completed := make(chan struct{})
+	persistenceMutableState := createMutableState(msBuilder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockMatchingClient.On("AddDecisionTask", nil, s.createAddDecisionTaskRequest(transferTask, msBuilder)).Once().Return(nil)
+	s.mockVisibilityMgr.On("RecordWorkflowExecutionStarted", s.createRecordWorkflowExecutionStartedRequest(transferTask, msBuilder)).Once().Return(nil)
+	s.mockQueueAckMgr.On("completeQueueTask", taskID).Return(nil).Once().Run(func(args mock.Arguments) {
+		close(completed)
+	})
+	s.Nil(s.transferQueueActiveProcessor.process(transferTask))
+
+	select {
+	case <-completed:
+	case <-time.After(5 * time.Second):
+		s.Fail("decision task was not acked after async dispatch")
+	}
+}
+
 func (s *transferQueueActiveProcessorSuite) TestProcessDecisionTask_NonFirstDecision() {
 	domainID := "some random domain ID"
 	execution := workflow.WorkflowExecution{
@@ -1349,6 +1560,93 @@ func (s *transferQueueActiveProcessorSuite) TestProcessStartChildExecution_Dupli
 	s.Nil(s.transferQueueActiveProcessor.process(transferTask))
 }
 
+func (s *transferQueueActiveProcessorSuite) TestTransferTaskFilter_PreFailoverTask() {
+	preFailoverVersion := s.version - cluster.TestFailoverVersionIncrement + cluster.TestAlternativeClusterInitialFailoverVersion
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", preFailoverVersion).Return(cluster.TestAlternativeClusterName)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", s.version).Return(cluster.TestCurrentClusterName)
+	s.mockShard.(*shardContextImpl).shardInfo.TransferFailoverLevels = map[string]persistence.TransferFailoverLevel{
+		validDomainID: {
+			MinLevel:        10,
+			CurrentLevel:    10,
+			MaxLevel:        100,
+			FailoverVersion: s.version,
+			DomainIDs:       []string{validDomainID},
+		},
+	}
+
+	newTask := func(taskID int64, taskType int, version int64) *persistence.TransferTaskInfo {
+		return &persistence.TransferTaskInfo{
+			Version:    version,
+			DomainID:   validDomainID,
+			WorkflowID: "some random workflow ID",
+			RunID:      uuid.New(),
+			TaskID:     taskID,
+			TaskList:   "some random task list",
+			TaskType:   taskType,
+			ScheduleID: int64(2),
+		}
+	}
+
+	// dispatch tasks created before the failover and within the failover range are owned by the failover processor
+	ok, err := s.transferQueueActiveProcessor.transferTaskFilter(newTask(50, persistence.TransferTaskTypeActivityTask, preFailoverVersion))
+	s.Nil(err)
+	s.False(ok)
+	ok, err = s.transferQueueActiveProcessor.transferTaskFilter(newTask(50, persistence.TransferTaskTypeDecisionTask, preFailoverVersion))
+	s.Nil(err)
+	s.False(ok)
+
+	// outside the failover range
+	ok, err = s.transferQueueActiveProcessor.transferTaskFilter(newTask(150, persistence.TransferTaskTypeActivityTask, preFailoverVersion))
+	s.Nil(err)
+	s.True(ok)
+
+	// created by the current cluster
+	ok, err = s.transferQueueActiveProcessor.transferTaskFilter(newTask(50, persistence.TransferTaskTypeActivityTask, s.version))
+	s.Nil(err)
+	s.True(ok)
+
+	// only dispatch tasks are subject to version check
+	ok, err = s.transferQueueActiveProcessor.transferTaskFilter(newTask(50, persistence.TransferTaskTypeCloseExecution, preFailoverVersion))
+	s.Nil(err)
+	s.True(ok)
+}
+
+func (s *transferQueueActiveProcessorSuite) TestTransferFailoverTaskFilter() {
+	preFailoverVersion := s.version - cluster.TestFailoverVersionIncrement + cluster.TestAlternativeClusterInitialFailoverVersion
+	failoverProcessor := newTransferQueueFailoverProcessor(s.mockShard, s.mockHistoryEngine, s.mockVisibilityMgr,
+		s.mockMatchingClient, s.mockHistoryClient, validDomainID, cluster.TestAlternativeClusterName, s.version, 10, 100, s.logger)
+
+	newTask := func(domainID string, taskType int, version int64) *persistence.TransferTaskInfo {
+		return &persistence.TransferTaskInfo{
+			Version:    version,
+			DomainID:   domainID,
+			WorkflowID: "some random workflow ID",
+			RunID:      uuid.New(),
+			TaskID:     50,
+			TaskList:   "some random task list",
+			TaskType:   taskType,
+			ScheduleID: int64(2),
+		}
+	}
+
+	ok, err := failoverProcessor.transferTaskFilter(newTask(validDomainID, persistence.TransferTaskTypeActivityTask, preFailoverVersion))
+	s.Nil(err)
+	s.True(ok)
+
+	// created after the failover, owned by the active processor
+	ok, err = failoverProcessor.transferTaskFilter(newTask(validDomainID, persistence.TransferTaskTypeDecisionTask, s.version))
+	s.Nil(err)
+	s.False(ok)
+
+	ok, err = failoverProcessor.transferTaskFilter(newTask(validDomainID, persistence.TransferTaskTypeCloseExecution, s.version))
+	s.Nil(err)
+	s.True(ok)
+
+	ok, err = failoverProcessor.transferTaskFilter(newTask(uuid.New(), persistence.TransferTaskTypeActivityTask, preFailoverVersion))
+	s.Nil(err)
+	s.False(ok)
+}
+
 func (s *transferQueueActiveProcessorSuite) createAddActivityTaskRequest(task *persistence.TransferTaskInfo,
 	ai *persistence.ActivityInfo) *matching.AddActivityTaskRequest {
 	execution := workflow.WorkflowExecution{
@@ -1364,11 +1662,14 @@ func (s *transferQueueActiveProcessorSuite) createAddActivityTaskRequest(task *p
 		TaskList:                      taskList,
 		ScheduleId:                    &task.ScheduleID,
 		ScheduleToStartTimeoutSeconds: common.Int32Ptr(ai.ScheduleToStartTimeout),
+		IsolationGroup:                common.StringPtr(""),
+		Priority:                      common.Int32Ptr(0),
+		ScheduledTimestamp:            common.Int64Ptr(ai.ScheduledTime.UnixNano()),
 	}
 }
 
 func (s *transferQueueActiveProcessorSuite) createAddDecisionTaskRequest(task *persistence.TransferTaskInfo,
-	msBuilder mutableState) *matching.AddDecisionTaskRequest {
+	msBuilder MutableState) *matching.AddDecisionTaskRequest {
 	execution := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr(task.WorkflowID),
 		RunId:      common.StringPtr(task.RunID),
@@ -1387,11 +1688,14 @@ func (s *transferQueueActiveProcessorSuite) createAddDecisionTaskRequest(task *p
 		TaskList:                      taskList,
 		ScheduleId:                    common.Int64Ptr(task.ScheduleID),
 		ScheduleToStartTimeoutSeconds: common.Int32Ptr(timeout),
+		IsolationGroup:                common.StringPtr(executionInfo.IsolationGroup),
+		Priority:                      common.Int32Ptr(executionInfo.Priority),
+		ScheduledTimestamp:            common.Int64Ptr(task.VisibilityTimestamp.UnixNano()),
 	}
 }
 
 func (s *transferQueueActiveProcessorSuite) createRecordWorkflowExecutionStartedRequest(task *persistence.TransferTaskInfo,
-	msBuilder mutableState) *persistence.RecordWorkflowExecutionStartedRequest {
+	msBuilder MutableState) *persistence.RecordWorkflowExecutionStartedRequest {
 	execution := workflow.WorkflowExecution{
 		WorkflowId: common.StringPtr(task.WorkflowID),
 		RunId:      common.StringPtr(task.RunID),
@@ -1461,7 +1765,7 @@ func (s *transferQueueActiveProcessorSuite) createSignallWorkflowExecutionReques
 }
 
 func (s *transferQueueActiveProcessorSuite) createChildWorkflowExecutionRequest(task *persistence.TransferTaskInfo,
-	msBuilder mutableState, ci *persistence.ChildExecutionInfo, domainName string, targetDomainName string) *history.StartWorkflowExecutionRequest {
+	msBuilder MutableState, ci *persistence.ChildExecutionInfo, domainName string, targetDomainName string) *history.StartWorkflowExecutionRequest {
 
 	event, ok := msBuilder.GetChildExecutionInitiatedEvent(task.ScheduleID)
 	if !ok {
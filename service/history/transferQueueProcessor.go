@@ -133,10 +133,9 @@ func (t *transferQueueProcessorImpl) NotifyNewTask(clusterName string, transferT
 	if len(transferTasks) != 0 {
 		standbyTaskProcessor.notifyNewTask()
 	}
-	standbyTaskProcessor.retryTasks()
 }
 
-func (t *transferQueueProcessorImpl) FailoverDomain(domainID string) {
+func (t *transferQueueProcessorImpl) FailoverDomain(domainID string, failoverVersion int64) {
 	minLevel := t.shard.GetTransferClusterAckLevel(t.currentClusterName)
 	standbyClusterName := t.currentClusterName
 	for cluster := range t.shard.GetService().GetClusterMetadata().GetAllClusterFailoverVersions() {
@@ -149,10 +148,11 @@ func (t *transferQueueProcessorImpl) FailoverDomain(domainID string) {
 
 	// the ack manager is exclusive, so add 1
 	maxLevel := t.activeTaskProcessor.getQueueReadLevel() + 1
-	t.logger.Infof("Transfer Failover Triggered: %v, min level: %v, max level: %v.\n", domainID, minLevel, maxLevel)
+	t.logger.Infof("Transfer Failover Triggered: %v, failover version: %v, min level: %v, max level: %v.\n",
+		domainID, failoverVersion, minLevel, maxLevel)
 	failoverTaskProcessor := newTransferQueueFailoverProcessor(
 		t.shard, t.historyService, t.visibilityMgr, t.matchingClient, t.historyClient,
-		domainID, standbyClusterName, minLevel, maxLevel, t.logger,
+		domainID, standbyClusterName, failoverVersion, minLevel, maxLevel, t.logger,
 	)
 
 	for _, standbyTaskProcessor := range t.standbyTaskProcessors {
@@ -165,10 +165,11 @@ func (t *transferQueueProcessorImpl) FailoverDomain(domainID string) {
 	t.shard.UpdateTransferFailoverLevel(
 		domainID,
 		persistence.TransferFailoverLevel{
-			MinLevel:     minLevel,
-			CurrentLevel: minLevel,
-			MaxLevel:     maxLevel,
-			DomainIDs:    []string{domainID},
+			MinLevel:        minLevel,
+			CurrentLevel:    minLevel,
+			MaxLevel:        maxLevel,
+			FailoverVersion: failoverVersion,
+			DomainIDs:       []string{domainID},
 		},
 	)
 }
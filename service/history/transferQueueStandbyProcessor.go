@@ -37,7 +37,7 @@ type (
 		options            *QueueProcessorOptions
 		executionManager   persistence.ExecutionManager
 		visibilityMgr      persistence.VisibilityManager
-		cache              *historyCache
+		cache              HistoryCache
 		transferTaskFilter transferTaskFilter
 		logger             bark.Logger
 		metricsClient      metrics.Client
@@ -66,6 +66,9 @@ func newTransferQueueStandbyProcessor(clusterName string, shard ShardContext, hi
 	})
 
 	transferTaskFilter := func(task *persistence.TransferTaskInfo) (bool, error) {
+		if err := verifyTransferTaskNotPaused(shard, task.TaskType); err != nil {
+			return false, err
+		}
 		return verifyStandbyTask(shard, logger, clusterName, task.DomainID, task)
 	}
 	maxReadAckLevel := func() int64 {
@@ -91,7 +94,8 @@ func newTransferQueueStandbyProcessor(clusterName string, shard ShardContext, hi
 		metricsClient:              historyService.metricsClient,
 		transferQueueProcessorBase: newTransferQueueProcessorBase(shard, options, maxReadAckLevel, updateClusterAckLevel, transferQueueShutdown),
 	}
-	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetTransferClusterAckLevel(clusterName), logger)
+	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetTransferClusterAckLevel(clusterName),
+		common.TransferQueueBacklogPrefix+clusterName, logger)
 	queueProcessorBase := newQueueProcessorBase(clusterName, shard, options, processor, queueAckMgr, logger)
 	processor.queueAckMgr = queueAckMgr
 	processor.queueProcessorBase = queueProcessorBase
@@ -163,7 +167,7 @@ func (t *transferQueueStandbyProcessorImpl) processActivityTask(transferTask *pe
 	defer sw.Stop()
 
 	processTaskIfClosed := false
-	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder mutableState) error {
+	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder MutableState) error {
 		activityInfo, isPending := msBuilder.GetActivityInfo(transferTask.ScheduleID)
 
 		if !isPending {
@@ -176,7 +180,8 @@ func (t *transferQueueStandbyProcessorImpl) processActivityTask(transferTask *pe
 			return nil
 		}
 
-		if activityInfo.StartedID == common.EmptyEventID {
+		// once the activity is retried, the retry timer synced from the active cluster dispatches the activity
+		if activityInfo.StartedID == common.EmptyEventID && activityInfo.Attempt == 0 {
 			return ErrTaskRetry
 		}
 		return nil
@@ -189,7 +194,7 @@ func (t *transferQueueStandbyProcessorImpl) processDecisionTask(transferTask *pe
 	defer sw.Stop()
 
 	processTaskIfClosed := false
-	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder mutableState) error {
+	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder MutableState) error {
 		decisionInfo, isPending := msBuilder.GetPendingDecision(transferTask.ScheduleID)
 
 		if !isPending {
@@ -223,7 +228,7 @@ func (t *transferQueueStandbyProcessorImpl) processCloseExecution(transferTask *
 	defer sw.Stop()
 
 	processTaskIfClosed := true
-	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder mutableState) error {
+	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder MutableState) error {
 
 		if msBuilder.IsWorkflowExecutionRunning() {
 			// this can happen if workflow is reset.
@@ -263,7 +268,7 @@ func (t *transferQueueStandbyProcessorImpl) processCancelExecution(transferTask
 	defer sw.Stop()
 
 	processTaskIfClosed := false
-	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder mutableState) error {
+	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder MutableState) error {
 		requestCancelInfo, isPending := msBuilder.GetRequestCancelInfo(transferTask.ScheduleID)
 
 		if !isPending {
@@ -286,7 +291,7 @@ func (t *transferQueueStandbyProcessorImpl) processSignalExecution(transferTask
 	defer sw.Stop()
 
 	processTaskIfClosed := false
-	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder mutableState) error {
+	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder MutableState) error {
 		signalInfo, isPending := msBuilder.GetSignalInfo(transferTask.ScheduleID)
 
 		if !isPending {
@@ -309,7 +314,7 @@ func (t *transferQueueStandbyProcessorImpl) processStartChildExecution(transferT
 	defer sw.Stop()
 
 	processTaskIfClosed := false
-	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder mutableState) error {
+	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder MutableState) error {
 		childWorkflowInfo, isPending := msBuilder.GetChildExecutionInfo(transferTask.ScheduleID)
 
 		if !isPending {
@@ -329,8 +334,8 @@ func (t *transferQueueStandbyProcessorImpl) processStartChildExecution(transferT
 	})
 }
 
-func (t *transferQueueStandbyProcessorImpl) processTransfer(processTaskIfClosed bool, transferTask *persistence.TransferTaskInfo, fn func(mutableState) error) (retError error) {
-	context, release, err := t.cache.getOrCreateWorkflowExecution(t.getDomainIDAndWorkflowExecution(transferTask))
+func (t *transferQueueStandbyProcessorImpl) processTransfer(processTaskIfClosed bool, transferTask *persistence.TransferTaskInfo, fn func(MutableState) error) (retError error) {
+	context, release, err := t.cache.GetOrCreateWorkflowExecution(t.getDomainIDAndWorkflowExecution(transferTask))
 	if err != nil {
 		return err
 	}
@@ -364,7 +369,7 @@ func (t *transferQueueStandbyProcessorImpl) getDomainIDAndWorkflowExecution(tran
 	}
 }
 
-func (t *transferQueueStandbyProcessorImpl) recordWorkflowStarted(msBuilder mutableState) error {
+func (t *transferQueueStandbyProcessorImpl) recordWorkflowStarted(msBuilder MutableState) error {
 	executionInfo := msBuilder.GetExecutionInfo()
 	return t.visibilityMgr.RecordWorkflowExecutionStarted(&persistence.RecordWorkflowExecutionStartedRequest{
 		DomainUUID: executionInfo.DomainID,
@@ -375,10 +380,12 @@ func (t *transferQueueStandbyProcessorImpl) recordWorkflowStarted(msBuilder muta
 		WorkflowTypeName: executionInfo.WorkflowTypeName,
 		StartTimestamp:   executionInfo.StartTimestamp.UnixNano(),
 		WorkflowTimeout:  int64(executionInfo.WorkflowTimeout),
+		Tags:             executionInfo.Tags,
+		FirstRunID:       executionInfo.FirstRunID,
 	})
 }
 
-func (t *transferQueueStandbyProcessorImpl) recordWorkflowClosed(msBuilder mutableState, retentionSeconds int64) error {
+func (t *transferQueueStandbyProcessorImpl) recordWorkflowClosed(msBuilder MutableState, retentionSeconds int64) error {
 	executionInfo := msBuilder.GetExecutionInfo()
 	return t.visibilityMgr.RecordWorkflowExecutionClosed(&persistence.RecordWorkflowExecutionClosedRequest{
 		DomainUUID: executionInfo.DomainID,
@@ -386,11 +393,14 @@ func (t *transferQueueStandbyProcessorImpl) recordWorkflowClosed(msBuilder mutab
 			WorkflowId: common.StringPtr(executionInfo.WorkflowID),
 			RunId:      common.StringPtr(executionInfo.RunID),
 		},
-		WorkflowTypeName: executionInfo.WorkflowTypeName,
-		StartTimestamp:   executionInfo.StartTimestamp.UnixNano(),
-		CloseTimestamp:   msBuilder.GetLastUpdatedTimestamp(),
-		Status:           getWorkflowExecutionCloseStatus(executionInfo.CloseStatus),
-		HistoryLength:    msBuilder.GetNextEventID(),
-		RetentionSeconds: retentionSeconds,
+		WorkflowTypeName:   executionInfo.WorkflowTypeName,
+		StartTimestamp:     executionInfo.StartTimestamp.UnixNano(),
+		ExecutionTimestamp: executionInfo.StartTimestamp.UnixNano(),
+		CloseTimestamp:     msBuilder.GetLastUpdatedTimestamp(),
+		Status:             getWorkflowExecutionCloseStatus(executionInfo.CloseStatus),
+		HistoryLength:      msBuilder.GetNextEventID(),
+		RetentionSeconds:   retentionSeconds,
+		Tags:               executionInfo.Tags,
+		FirstRunID:         executionInfo.FirstRunID,
 	})
 }
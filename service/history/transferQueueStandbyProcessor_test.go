@@ -23,6 +23,7 @@ package history
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/pborman/uuid"
 	log "github.com/sirupsen/logrus"
@@ -207,6 +208,88 @@ func (s *transferQueueStandbyProcessorSuite) TestProcessActivityTask_Pending() {
 	s.Equal(ErrTaskRetry, s.transferQueueStandbyProcessor.process(transferTask))
 }
 
+func (s *transferQueueStandbyProcessorSuite) TestProcessWithRetry_Pending() {
+	timeSource := common.NewEventTimeSource().Update(time.Now())
+	redeliveryQueue := newTaskRedeliveryQueue(time.Minute, 10*time.Minute, timeSource)
+	s.transferQueueStandbyProcessor.queueProcessorBase.redeliveryQueue = redeliveryQueue
+
+	domainID := "some random domain ID"
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	version := int64(4096)
+	msBuilder := newMutableStateBuilderWithReplicationState(s.mockShard.GetConfig(), s.logger, version)
+	msBuilder.AddWorkflowExecutionStartedEvent(
+		execution,
+		&history.StartWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(domainID),
+			StartRequest: &workflow.StartWorkflowExecutionRequest{
+				WorkflowType: &workflow.WorkflowType{Name: common.StringPtr(workflowType)},
+				TaskList:     &workflow.TaskList{Name: common.StringPtr(taskListName)},
+				ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(2),
+				TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			},
+		},
+	)
+
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	event := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, di.StartedID, nil, "some random identity")
+
+	taskID := int64(59)
+	activityID := "activity-1"
+	activityType := "some random activity type"
+	event, _ = addActivityTaskScheduledEvent(msBuilder, event.GetEventId(), activityID, activityType, taskListName, []byte{}, 1, 1, 1)
+
+	transferTask := &persistence.TransferTaskInfo{
+		Version:    version,
+		DomainID:   domainID,
+		WorkflowID: execution.GetWorkflowId(),
+		RunID:      execution.GetRunId(),
+		TaskID:     taskID,
+		TaskList:   taskListName,
+		TaskType:   persistence.TransferTaskTypeActivityTask,
+		ScheduleID: event.GetEventId(),
+	}
+
+	persistenceMutableState := createMutableState(msBuilder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil).Once()
+
+	// the task which cannot be verified yet is handed over to the redelivery queue, freeing the worker
+	s.transferQueueStandbyProcessor.queueProcessorBase.processWithRetry(transferTask)
+	s.Equal(1, redeliveryQueue.size())
+	s.Empty(redeliveryQueue.getDueTasks())
+
+	timeSource.Update(timeSource.Now().Add(time.Minute))
+	dueTasks := redeliveryQueue.getDueTasks()
+	s.Equal([]queueTaskInfo{transferTask}, dueTasks)
+
+	// still not verifiable, the task is deferred again and backs off further
+	s.transferQueueStandbyProcessor.queueProcessorBase.processWithRetry(dueTasks[0])
+	s.Equal(1, redeliveryQueue.size())
+	timeSource.Update(timeSource.Now().Add(time.Minute))
+	s.Empty(redeliveryQueue.getDueTasks())
+
+	// a domain failover makes the deferred task due right away
+	s.transferQueueStandbyProcessor.retryTasks()
+	dueTasks = redeliveryQueue.getDueTasks()
+	s.Equal([]queueTaskInfo{transferTask}, dueTasks)
+
+	// once the activity started event is replicated, the task is acked and forgotten by the redelivery queue
+	context, release, err := s.mockHistoryEngine.historyCache.GetOrCreateWorkflowExecution(domainID, execution)
+	s.Nil(err)
+	addActivityTaskStartedEvent(context.msBuilder, event.GetEventId(), taskListName, "")
+	release(nil)
+	s.mockQueueAckMgr.On("completeQueueTask", taskID).Return(nil).Once()
+	s.transferQueueStandbyProcessor.queueProcessorBase.processWithRetry(dueTasks[0])
+	s.Equal(0, redeliveryQueue.size())
+}
+
 func (s *transferQueueStandbyProcessorSuite) TestProcessActivityTask_Success() {
 	domainID := "some random domain ID"
 	execution := workflow.WorkflowExecution{
@@ -261,6 +344,60 @@ func (s *transferQueueStandbyProcessorSuite) TestProcessActivityTask_Success() {
 	s.Nil(s.transferQueueStandbyProcessor.process(transferTask))
 }
 
+func (s *transferQueueStandbyProcessorSuite) TestProcessActivityTask_Retried() {
+	domainID := "some random domain ID"
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	version := int64(4096)
+	msBuilder := newMutableStateBuilderWithReplicationState(s.mockShard.GetConfig(), s.logger, version)
+	msBuilder.AddWorkflowExecutionStartedEvent(
+		execution,
+		&history.StartWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(domainID),
+			StartRequest: &workflow.StartWorkflowExecutionRequest{
+				WorkflowType: &workflow.WorkflowType{Name: common.StringPtr(workflowType)},
+				TaskList:     &workflow.TaskList{Name: common.StringPtr(taskListName)},
+				ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(2),
+				TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			},
+		},
+	)
+
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	event := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, di.StartedID, nil, "some random identity")
+
+	taskID := int64(59)
+	activityID := "activity-1"
+	activityType := "some random activity type"
+	event, ai := addActivityTaskScheduledEvent(msBuilder, event.GetEventId(), activityID, activityType, taskListName, []byte{}, 1, 1, 1)
+	// the retry of the activity synced from the active cluster is pending on its own retry timer
+	ai.Attempt = 1
+
+	transferTask := &persistence.TransferTaskInfo{
+		Version:    version,
+		DomainID:   domainID,
+		WorkflowID: execution.GetWorkflowId(),
+		RunID:      execution.GetRunId(),
+		TaskID:     taskID,
+		TaskList:   taskListName,
+		TaskType:   persistence.TransferTaskTypeActivityTask,
+		ScheduleID: event.GetEventId(),
+	}
+
+	persistenceMutableState := createMutableState(msBuilder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockQueueAckMgr.On("completeQueueTask", taskID).Return(nil).Once()
+
+	s.Nil(s.transferQueueStandbyProcessor.process(transferTask))
+}
+
 func (s *transferQueueStandbyProcessorSuite) TestProcessDecisionTask_Pending() {
 	domainID := "some random domain ID"
 	execution := workflow.WorkflowExecution{
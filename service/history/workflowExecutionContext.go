@@ -32,6 +32,7 @@ import (
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/errors"
 	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 )
 
@@ -40,19 +41,25 @@ const (
 )
 
 type (
-	workflowExecutionContext struct {
+	// WorkflowExecutionContext loads, holds and updates the mutable state of a workflow execution
+	WorkflowExecutionContext struct {
 		domainID          string
 		workflowExecution workflow.WorkflowExecution
 		shard             ShardContext
 		clusterMetadata   cluster.Metadata
 		executionManager  persistence.ExecutionManager
+		metricsClient     metrics.Client
 		logger            bark.Logger
 
 		locker                common.Mutex
-		msBuilder             mutableState
+		msBuilder             MutableState
 		updateCondition       int64
 		deleteTimerTask       persistence.Task
 		createReplicationTask bool
+		// replication state as last written to persistence, nil when not known
+		persistedReplicationState *persistence.ReplicationState
+		// decisionPinned is whether the history cache holds an extra pin on the context for an in-flight decision
+		decisionPinned bool
 	}
 )
 
@@ -61,24 +68,25 @@ var (
 )
 
 func newWorkflowExecutionContext(domainID string, execution workflow.WorkflowExecution, shard ShardContext,
-	executionManager persistence.ExecutionManager, logger bark.Logger) *workflowExecutionContext {
+	executionManager persistence.ExecutionManager, logger bark.Logger) *WorkflowExecutionContext {
 	lg := logger.WithFields(bark.Fields{
 		logging.TagWorkflowExecutionID: *execution.WorkflowId,
 		logging.TagWorkflowRunID:       *execution.RunId,
 	})
 
-	return &workflowExecutionContext{
+	return &WorkflowExecutionContext{
 		domainID:          domainID,
 		workflowExecution: execution,
 		shard:             shard,
 		clusterMetadata:   shard.GetService().GetClusterMetadata(),
 		executionManager:  executionManager,
+		metricsClient:     shard.GetMetricsClient(),
 		logger:            lg,
 		locker:            common.NewMutex(),
 	}
 }
 
-func (c *workflowExecutionContext) loadWorkflowExecution() (mutableState, error) {
+func (c *WorkflowExecutionContext) loadWorkflowExecution() (MutableState, error) {
 	if c.msBuilder != nil {
 		if err := c.updateVersion(); err != nil {
 			return nil, err
@@ -98,11 +106,13 @@ func (c *workflowExecutionContext) loadWorkflowExecution() (mutableState, error)
 	}
 
 	msBuilder := newMutableStateBuilder(c.shard.GetConfig(), c.logger)
+	msBuilder.SetHistoryEncodingFn(c.getHistoryEncoding)
 	if response != nil && response.State != nil {
 		state := response.State
 		msBuilder.Load(state)
 		info := state.ExecutionInfo
 		c.updateCondition = info.NextEventID
+		c.persistedReplicationState = cloneReplicationState(state.ReplicationState)
 	}
 
 	c.msBuilder = msBuilder
@@ -112,7 +122,7 @@ func (c *workflowExecutionContext) loadWorkflowExecution() (mutableState, error)
 	return msBuilder, nil
 }
 
-func (c *workflowExecutionContext) resetWorkflowExecution(resetBuilder mutableState) (mutableState,
+func (c *WorkflowExecutionContext) resetWorkflowExecution(resetBuilder MutableState) (MutableState,
 	error) {
 	snapshotRequest := resetBuilder.ResetSnapshot()
 	snapshotRequest.Condition = c.updateCondition
@@ -126,21 +136,21 @@ func (c *workflowExecutionContext) resetWorkflowExecution(resetBuilder mutableSt
 	return c.loadWorkflowExecution()
 }
 
-func (c *workflowExecutionContext) updateWorkflowExecutionWithContext(context []byte, transferTasks []persistence.Task,
+func (c *WorkflowExecutionContext) updateWorkflowExecutionWithContext(context []byte, transferTasks []persistence.Task,
 	timerTasks []persistence.Task, transactionID int64) error {
 	c.msBuilder.GetExecutionInfo().ExecutionContext = context
 
 	return c.updateWorkflowExecution(transferTasks, timerTasks, transactionID)
 }
 
-func (c *workflowExecutionContext) updateWorkflowExecutionWithDeleteTask(transferTasks []persistence.Task,
+func (c *WorkflowExecutionContext) updateWorkflowExecutionWithDeleteTask(transferTasks []persistence.Task,
 	timerTasks []persistence.Task, deleteTimerTask persistence.Task, transactionID int64) error {
 	c.deleteTimerTask = deleteTimerTask
 
 	return c.updateWorkflowExecution(transferTasks, timerTasks, transactionID)
 }
 
-func (c *workflowExecutionContext) replicateWorkflowExecution(request *h.ReplicateEventsRequest,
+func (c *WorkflowExecutionContext) replicateWorkflowExecution(request *h.ReplicateEventsRequest,
 	transferTasks []persistence.Task, timerTasks []persistence.Task, lastEventID, transactionID int64, now time.Time) error {
 	nextEventID := lastEventID + 1
 	c.msBuilder.GetExecutionInfo().NextEventID = nextEventID
@@ -149,7 +159,7 @@ func (c *workflowExecutionContext) replicateWorkflowExecution(request *h.Replica
 	return c.updateHelper(transferTasks, timerTasks, transactionID, now, false, standbyHistoryBuilder, request.GetSourceCluster())
 }
 
-func (c *workflowExecutionContext) updateVersion() error {
+func (c *WorkflowExecutionContext) updateVersion() error {
 	if c.shard.GetService().GetClusterMetadata().IsGlobalDomainEnabled() && c.msBuilder.GetReplicationState() != nil {
 		if !c.msBuilder.IsWorkflowExecutionRunning() {
 			// we should not update the version on mutable state when the workflow is finished
@@ -168,7 +178,17 @@ func (c *workflowExecutionContext) updateVersion() error {
 	return nil
 }
 
-func (c *workflowExecutionContext) updateWorkflowExecution(transferTasks []persistence.Task,
+// getHistoryEncoding returns the encoding the domain writes new history batches in. Batches of any
+// encoding can be read back, so the default one is used when the domain cannot be loaded.
+func (c *WorkflowExecutionContext) getHistoryEncoding() common.EncodingType {
+	domainEntry, err := c.shard.GetDomainCache().GetDomainByID(c.domainID)
+	if err != nil {
+		return persistence.DefaultEncodingType
+	}
+	return domainEntry.GetHistoryEncoding()
+}
+
+func (c *WorkflowExecutionContext) updateWorkflowExecution(transferTasks []persistence.Task,
 	timerTasks []persistence.Task, transactionID int64) error {
 
 	currentVersion := c.msBuilder.GetCurrentVersion()
@@ -190,9 +210,9 @@ func (c *workflowExecutionContext) updateWorkflowExecution(transferTasks []persi
 	return c.updateHelper(transferTasks, timerTasks, transactionID, now, c.createReplicationTask, nil, "")
 }
 
-func (c *workflowExecutionContext) updateHelper(transferTasks []persistence.Task, timerTasks []persistence.Task,
+func (c *WorkflowExecutionContext) updateHelper(transferTasks []persistence.Task, timerTasks []persistence.Task,
 	transactionID int64, now time.Time,
-	createReplicationTask bool, standbyHistoryBuilder *historyBuilder, sourceCluster string) (errRet error) {
+	createReplicationTask bool, standbyHistoryBuilder *HistoryBuilder, sourceCluster string) (errRet error) {
 
 	defer func() {
 		if errRet != nil {
@@ -251,6 +271,7 @@ func (c *workflowExecutionContext) updateHelper(transferTasks []persistence.Task
 	}
 
 	// Some operations only update the mutable state. For example RecordActivityTaskHeartbeat.
+	historySizeBeforeActiveEvents := executionInfo.HistorySize
 	if hasNewActiveHistoryEvents {
 		firstEvent := activeHistoryBuilder.GetFirstEvent()
 		// Transient decision events need to be written as a separate batch
@@ -289,14 +310,31 @@ func (c *workflowExecutionContext) updateHelper(transferTasks []persistence.Task
 	// Check if the update resulted in new history events before generating replication task
 	if hasNewActiveHistoryEvents && createReplicationTask {
 		// Let's create a replication task as part of this update
-		replicationTasks = append(replicationTasks, c.msBuilder.CreateReplicationTask())
+		replicationTask := c.msBuilder.CreateReplicationTask()
+		replicationTask.HistorySize = executionInfo.HistorySize - historySizeBeforeActiveEvents
+		replicationTasks = append(replicationTasks, replicationTask)
+	}
+	// The attempt of an activity being retried is not recorded by a history event until the activity completes
+	if createReplicationTask {
+		for _, scheduleID := range updates.syncActivityInfos {
+			replicationTasks = append(replicationTasks, &persistence.SyncActivityTask{
+				Version:     c.msBuilder.GetCurrentVersion(),
+				ScheduledID: scheduleID,
+			})
+		}
 	}
 
 	setTaskInfo(c.msBuilder.GetCurrentVersion(), now, transferTasks, timerTasks)
 
-	if err1 := c.updateWorkflowExecutionWithRetry(&persistence.UpdateWorkflowExecutionRequest{
+	replicationState := c.msBuilder.GetReplicationState()
+	if !updates.snapshot && replicationStateEquals(c.persistedReplicationState, replicationState) {
+		// persistence leaves the replication state untouched when none is given
+		replicationState = nil
+	}
+
+	request := &persistence.UpdateWorkflowExecutionRequest{
 		ExecutionInfo:                 executionInfo,
-		ReplicationState:              c.msBuilder.GetReplicationState(),
+		ReplicationState:              replicationState,
 		TransferTasks:                 transferTasks,
 		ReplicationTasks:              replicationTasks,
 		TimerTasks:                    timerTasks,
@@ -314,6 +352,7 @@ func (c *workflowExecutionContext) updateHelper(transferTasks []persistence.Task
 		DeleteSignalInfo:              updates.deleteSignalInfo,
 		UpsertSignalRequestedIDs:      updates.updateSignalRequestedIDs,
 		DeleteSignalRequestedID:       updates.deleteSignalRequestedID,
+		UpsertUpdateInfos:             updates.updateUpdateInfos,
 		NewBufferedEvents:             updates.newBufferedEvents,
 		ClearBufferedEvents:           updates.clearBufferedEvents,
 		NewBufferedReplicationTask:    updates.newBufferedReplicationEventsInfo,
@@ -321,7 +360,8 @@ func (c *workflowExecutionContext) updateHelper(transferTasks []persistence.Task
 		ContinueAsNew:                 continueAsNew,
 		FinishExecution:               finishExecution,
 		FinishedExecutionTTL:          finishExecutionTTL,
-	}); err1 != nil {
+	}
+	if err1 := c.updateWorkflowExecutionWithRetry(request); err1 != nil {
 		switch err1.(type) {
 		case *persistence.ConditionFailedError:
 			return ErrConflict
@@ -335,6 +375,14 @@ func (c *workflowExecutionContext) updateHelper(transferTasks []persistence.Task
 	// Update went through so update the condition for new updates
 	c.updateCondition = c.msBuilder.GetNextEventID()
 	c.msBuilder.GetExecutionInfo().LastUpdatedTimestamp = time.Now()
+	if replicationState != nil {
+		c.persistedReplicationState = cloneReplicationState(replicationState)
+	}
+	c.metricsClient.RecordTimer(metrics.WorkflowContextUpdateScope, metrics.MutableStateUpdateSizeTimer,
+		time.Duration(getUpdateWorkflowExecutionSize(request)))
+	if updates.snapshot {
+		c.metricsClient.IncCounter(metrics.WorkflowContextUpdateScope, metrics.MutableStateSnapshotCounter)
+	}
 
 	// for any change in the workflow, send a event
 	c.shard.NotifyNewHistoryEvent(newHistoryEventNotification(
@@ -348,7 +396,7 @@ func (c *workflowExecutionContext) updateHelper(transferTasks []persistence.Task
 	return nil
 }
 
-func (c *workflowExecutionContext) appendHistoryEvents(builder *historyBuilder, history []*workflow.HistoryEvent,
+func (c *WorkflowExecutionContext) appendHistoryEvents(builder *HistoryBuilder, history []*workflow.HistoryEvent,
 	transactionID int64) error {
 
 	firstEvent := history[0]
@@ -375,15 +423,17 @@ func (c *workflowExecutionContext) appendHistoryEvents(builder *historyBuilder,
 		return err0
 	}
 
+	// persisted along with the rest of the execution info by the following mutable state update
+	c.msBuilder.GetExecutionInfo().HistorySize += int64(len(serializedHistory.Data))
 	return nil
 }
 
-func (c *workflowExecutionContext) replicateContinueAsNewWorkflowExecution(newStateBuilder mutableState,
+func (c *WorkflowExecutionContext) replicateContinueAsNewWorkflowExecution(newStateBuilder MutableState,
 	transferTasks []persistence.Task, timerTasks []persistence.Task, transactionID int64) error {
 	return c.continueAsNewWorkflowExecutionHelper(nil, newStateBuilder, transferTasks, timerTasks, transactionID)
 }
 
-func (c *workflowExecutionContext) continueAsNewWorkflowExecution(context []byte, newStateBuilder mutableState,
+func (c *WorkflowExecutionContext) continueAsNewWorkflowExecution(context []byte, newStateBuilder MutableState,
 	transferTasks []persistence.Task, timerTasks []persistence.Task, transactionID int64) error {
 
 	err1 := c.continueAsNewWorkflowExecutionHelper(context, newStateBuilder, transferTasks, timerTasks, transactionID)
@@ -400,7 +450,7 @@ func (c *workflowExecutionContext) continueAsNewWorkflowExecution(context []byte
 	return err2
 }
 
-func (c *workflowExecutionContext) continueAsNewWorkflowExecutionHelper(context []byte, newStateBuilder mutableState,
+func (c *WorkflowExecutionContext) continueAsNewWorkflowExecutionHelper(context []byte, newStateBuilder MutableState,
 	transferTasks []persistence.Task, timerTasks []persistence.Task, transactionID int64) error {
 	executionInfo := newStateBuilder.GetExecutionInfo()
 	domainID := executionInfo.DomainID
@@ -419,16 +469,26 @@ func (c *workflowExecutionContext) continueAsNewWorkflowExecutionHelper(context
 		return serializedError
 	}
 
-	return c.shard.AppendHistoryEvents(&persistence.AppendHistoryEventsRequest{
+	err := c.shard.AppendHistoryEvents(&persistence.AppendHistoryEventsRequest{
 		DomainID:      domainID,
 		Execution:     newExecution,
 		TransactionID: transactionID,
 		FirstEventID:  *firstEvent.EventId,
 		Events:        serializedHistory,
 	})
+	if err != nil {
+		return err
+	}
+
+	// the new run is created from the continue as new request along with the update of the current run
+	executionInfo.HistorySize = int64(len(serializedHistory.Data))
+	if continueAsNew := c.msBuilder.GetContinueAsNew(); continueAsNew != nil {
+		continueAsNew.HistorySize = executionInfo.HistorySize
+	}
+	return nil
 }
 
-func (c *workflowExecutionContext) getWorkflowExecutionWithRetry(
+func (c *WorkflowExecutionContext) getWorkflowExecutionWithRetry(
 	request *persistence.GetWorkflowExecutionRequest) (*persistence.GetWorkflowExecutionResponse, error) {
 	var response *persistence.GetWorkflowExecutionResponse
 	op := func() error {
@@ -446,7 +506,7 @@ func (c *workflowExecutionContext) getWorkflowExecutionWithRetry(
 	return response, nil
 }
 
-func (c *workflowExecutionContext) updateWorkflowExecutionWithRetry(
+func (c *WorkflowExecutionContext) updateWorkflowExecutionWithRetry(
 	request *persistence.UpdateWorkflowExecutionRequest) error {
 	op := func() error {
 		return c.shard.UpdateWorkflowExecution(request)
@@ -455,8 +515,9 @@ func (c *workflowExecutionContext) updateWorkflowExecutionWithRetry(
 	return backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError)
 }
 
-func (c *workflowExecutionContext) clear() {
+func (c *WorkflowExecutionContext) clear() {
 	c.msBuilder = nil
+	c.persistedReplicationState = nil
 }
 
 // scheduleNewDecision is helper method which has the logic for scheduling new decision for a workflow execution.
@@ -464,7 +525,7 @@ func (c *workflowExecutionContext) clear() {
 // and may append more tasks to it.  It also returns back the slice with new tasks appended to it.  It is expected
 // caller to assign returned slice to original passed in slices.  For this reason we return the original slices
 // even if the method fails due to an error on loading workflow execution.
-func (c *workflowExecutionContext) scheduleNewDecision(transferTasks []persistence.Task,
+func (c *WorkflowExecutionContext) scheduleNewDecision(transferTasks []persistence.Task,
 	timerTasks []persistence.Task) ([]persistence.Task, []persistence.Task, error) {
 	msBuilder, err := c.loadWorkflowExecution()
 	if err != nil {
@@ -489,3 +550,77 @@ func (c *workflowExecutionContext) scheduleNewDecision(transferTasks []persisten
 
 	return transferTasks, timerTasks, nil
 }
+
+// getUpdateWorkflowExecutionSize approximates the bytes written by an update, only the serialized payloads and the
+// replication state are counted as the execution info columns are written by every update anyway
+func getUpdateWorkflowExecutionSize(request *persistence.UpdateWorkflowExecutionRequest) int {
+	size := len(request.ExecutionInfo.ExecutionContext) + len(request.ExecutionInfo.CompletionEvent)
+	if request.ReplicationState != nil {
+		// current, start and last write versions plus the last write event ID
+		size += 32
+		for cluster := range request.ReplicationState.LastReplicationInfo {
+			size += len(cluster) + 16
+		}
+	}
+	for _, ai := range request.UpsertActivityInfos {
+		size += len(ai.ScheduledEvent) + len(ai.StartedEvent) + len(ai.Details)
+	}
+	for _, ci := range request.UpsertChildExecutionInfos {
+		size += len(ci.InitiatedEvent) + len(ci.StartedEvent)
+	}
+	for _, si := range request.UpsertSignalInfos {
+		size += len(si.Input) + len(si.Control)
+	}
+	if request.NewBufferedEvents != nil {
+		size += len(request.NewBufferedEvents.Data)
+	}
+	if task := request.NewBufferedReplicationTask; task != nil {
+		size += len(task.History.Data)
+		if task.NewRunHistory != nil {
+			size += len(task.NewRunHistory.Data)
+		}
+	}
+	return size
+}
+
+// cloneReplicationState copies the replication state, including the per cluster replication info
+func cloneReplicationState(source *persistence.ReplicationState) *persistence.ReplicationState {
+	if source == nil {
+		return nil
+	}
+
+	result := &persistence.ReplicationState{
+		CurrentVersion:   source.CurrentVersion,
+		StartVersion:     source.StartVersion,
+		LastWriteVersion: source.LastWriteVersion,
+		LastWriteEventID: source.LastWriteEventID,
+	}
+	if source.LastReplicationInfo != nil {
+		result.LastReplicationInfo = make(map[string]*persistence.ReplicationInfo, len(source.LastReplicationInfo))
+		for cluster, info := range source.LastReplicationInfo {
+			result.LastReplicationInfo[cluster] = &persistence.ReplicationInfo{
+				Version:     info.Version,
+				LastEventID: info.LastEventID,
+			}
+		}
+	}
+	return result
+}
+
+func replicationStateEquals(s1, s2 *persistence.ReplicationState) bool {
+	if s1 == nil || s2 == nil {
+		return s1 == s2
+	}
+	if s1.CurrentVersion != s2.CurrentVersion || s1.StartVersion != s2.StartVersion ||
+		s1.LastWriteVersion != s2.LastWriteVersion || s1.LastWriteEventID != s2.LastWriteEventID ||
+		len(s1.LastReplicationInfo) != len(s2.LastReplicationInfo) {
+		return false
+	}
+	for cluster, info1 := range s1.LastReplicationInfo {
+		info2, ok := s2.LastReplicationInfo[cluster]
+		if !ok || info1.Version != info2.Version || info1.LastEventID != info2.LastEventID {
+			return false
+		}
+	}
+	return true
+}
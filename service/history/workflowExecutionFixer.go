@@ -0,0 +1,347 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/uber-common/bark"
+	h "github.com/uber/cadence/.gen/go/history"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/persistence"
+)
+
+// FixWorkflowExecution repairs a workflow execution run found inconsistent by a scanner: missing tasks are
+// regenerated from the mutable state, a corrupted mutable state is rebuilt from the history and an orphan run is
+// deleted. On a dry run the fix is only described.
+func (e *historyEngineImpl) FixWorkflowExecution(ctx context.Context,
+	request *h.FixWorkflowExecutionRequest) (*h.FixWorkflowExecutionResponse, error) {
+
+	domainID, err := validateDomainUUID(request.DomainUUID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Execution == nil || request.Execution.GetWorkflowId() == "" || request.Execution.GetRunId() == "" {
+		return nil, &workflow.BadRequestError{Message: "Execution with a WorkflowId and a RunId is not set on request."}
+	}
+	if request.IssueType == nil {
+		return nil, &workflow.BadRequestError{Message: "IssueType is not set on request."}
+	}
+
+	execution := workflow.WorkflowExecution{
+		WorkflowId: request.Execution.WorkflowId,
+		RunId:      request.Execution.RunId,
+	}
+	dryRun := request.GetDryRun()
+	var action string
+	switch request.GetIssueType() {
+	case workflow.WorkflowExecutionIssueTypeMissingTasks:
+		action, err = e.regenerateWorkflowTasks(ctx, domainID, execution, dryRun)
+	case workflow.WorkflowExecutionIssueTypeCorruptedMutableState:
+		action, err = e.rebuildWorkflowMutableState(ctx, domainID, execution, dryRun)
+	case workflow.WorkflowExecutionIssueTypeOrphanExecution:
+		action, err = e.deleteOrphanWorkflowExecution(ctx, domainID, execution, dryRun)
+	default:
+		return nil, &workflow.BadRequestError{Message: fmt.Sprintf("Unknown issue type: %v.", request.GetIssueType())}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !dryRun {
+		e.logger.WithFields(bark.Fields{
+			logging.TagDomainID:            domainID,
+			logging.TagWorkflowExecutionID: execution.GetWorkflowId(),
+			logging.TagWorkflowRunID:       execution.GetRunId(),
+		}).Infof("Workflow execution fixed: %v.", action)
+	}
+	return &h.FixWorkflowExecutionResponse{Action: common.StringPtr(action)}, nil
+}
+
+func (e *historyEngineImpl) regenerateWorkflowTasks(ctx context.Context, domainID string,
+	execution workflow.WorkflowExecution, dryRun bool) (action string, retError error) {
+
+	context, release, err := e.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	if err != nil {
+		return "", err
+	}
+	defer func() { release(retError) }()
+
+	msBuilder, err := context.loadWorkflowExecution()
+	if err != nil {
+		return "", err
+	}
+
+	if !msBuilder.IsWorkflowExecutionRunning() {
+		// writing a closed run also rewrites the current execution record of the workflow, which must keep pointing
+		// to a newer run
+		response, err := e.executionManager.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+			DomainID:   domainID,
+			WorkflowID: execution.GetWorkflowId(),
+		})
+		if err != nil {
+			return "", err
+		}
+		if response.RunID != execution.GetRunId() {
+			return "", &workflow.BadRequestError{
+				Message: "Tasks of a closed run can only be regenerated while it is the current run of the workflow.",
+			}
+		}
+	}
+
+	transferTasks, timerTasks, err := e.getRegeneratedTasks(domainID, msBuilder, e.getTimerBuilder(&execution))
+	if err != nil {
+		return "", err
+	}
+	action = fmt.Sprintf("regenerate %v transfer tasks and %v timer tasks", len(transferTasks), len(timerTasks))
+	if dryRun {
+		// the timer task flags were reset on the cached mutable state, it must be reloaded
+		context.clear()
+		return action, nil
+	}
+	if err := e.updateRegeneratedTasks(context, transferTasks, timerTasks); err != nil {
+		return "", err
+	}
+	return action, nil
+}
+
+func (e *historyEngineImpl) rebuildWorkflowMutableState(ctx context.Context, domainID string,
+	execution workflow.WorkflowExecution, dryRun bool) (action string, retError error) {
+
+	context, release, err := e.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	if err != nil {
+		return "", err
+	}
+	defer func() { release(retError) }()
+
+	msBuilder, err := context.loadWorkflowExecution()
+	if err != nil {
+		return "", err
+	}
+	// the history of a closed run ends with its close event, which cannot be replayed without the next run
+	if !msBuilder.IsWorkflowExecutionRunning() {
+		return "", &workflow.BadRequestError{Message: "Only the mutable state of a running execution can be rebuilt."}
+	}
+	// resetting a mutable state is only supported along with its replication state
+	if msBuilder.GetReplicationState() == nil {
+		return "", &workflow.BadRequestError{Message: "Only the mutable state of an execution of a global domain can be rebuilt."}
+	}
+
+	resolver := newConflictResolver(e.shard, context, e.historyMgr, e.logger)
+	lastEventID, err := e.getLastHistoryEventID(resolver, domainID, execution)
+	if err != nil {
+		return "", err
+	}
+	action = fmt.Sprintf("rebuild mutable state from history, next event ID %v -> %v", msBuilder.GetNextEventID(),
+		lastEventID+1)
+	if dryRun {
+		return action, nil
+	}
+
+	msBuilder, err = resolver.reset(uuid.New(), lastEventID, msBuilder.GetExecutionInfo().StartTimestamp)
+	if err != nil {
+		return "", err
+	}
+	// the tasks of the rebuilt mutable state have to be created again as well
+	transferTasks, timerTasks, err := e.getRegeneratedTasks(domainID, msBuilder, e.getTimerBuilder(&execution))
+	if err != nil {
+		return "", err
+	}
+	if err := e.updateRegeneratedTasks(context, transferTasks, timerTasks); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v, regenerate %v transfer tasks and %v timer tasks", action, len(transferTasks),
+		len(timerTasks)), nil
+}
+
+func (e *historyEngineImpl) deleteOrphanWorkflowExecution(ctx context.Context, domainID string,
+	execution workflow.WorkflowExecution, dryRun bool) (string, error) {
+
+	action := "delete the workflow execution"
+	if dryRun {
+		// only make sure there is a run to delete
+		context, release, err := e.historyCache.GetOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+		if err != nil {
+			return "", err
+		}
+		_, err = context.loadWorkflowExecution()
+		release(err)
+		if err != nil {
+			return "", err
+		}
+		return action, nil
+	}
+
+	err := e.DeleteWorkflowExecution(ctx, &h.DeleteWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  &execution,
+		Force:      common.BoolPtr(true),
+	})
+	if err != nil {
+		return "", err
+	}
+	return action, nil
+}
+
+// getRegeneratedTasks returns the transfer and timer tasks which the mutable state calls for. Outstanding external
+// cancellations and signals are left out, their targets are only recorded in the history.
+func (e *historyEngineImpl) getRegeneratedTasks(domainID string, msBuilder MutableState,
+	tBuilder *timerBuilder) ([]persistence.Task, []persistence.Task, error) {
+
+	executionInfo := msBuilder.GetExecutionInfo()
+	if !msBuilder.IsWorkflowExecutionRunning() {
+		closeTask, cleanupTask, err := e.getDeleteWorkflowTasks(domainID, tBuilder)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []persistence.Task{closeTask}, []persistence.Task{cleanupTask}, nil
+	}
+
+	var transferTasks []persistence.Task
+	timerTasks := []persistence.Task{&persistence.WorkflowTimeoutTask{
+		VisibilityTimestamp: executionInfo.StartTimestamp.Add(time.Duration(executionInfo.WorkflowTimeout) * time.Second),
+	}}
+
+	if di, ok := msBuilder.GetPendingDecision(executionInfo.DecisionScheduleID); ok {
+		if di.StartedID == common.EmptyEventID {
+			transferTasks = append(transferTasks, &persistence.DecisionTask{
+				DomainID:   domainID,
+				TaskList:   di.TaskList,
+				ScheduleID: di.ScheduleID,
+			})
+			if msBuilder.IsStickyTaskListEnabled() {
+				timerTasks = append(timerTasks, tBuilder.AddScheduleToStartDecisionTimoutTask(di.ScheduleID, di.Attempt,
+					executionInfo.StickyScheduleToStartTimeout))
+			}
+		} else {
+			timerTasks = append(timerTasks, tBuilder.AddStartToCloseDecisionTimoutTask(di.ScheduleID, di.Attempt,
+				di.DecisionTimeout))
+		}
+	}
+
+	now := e.shard.GetTimeSource().Now()
+	for _, ai := range msBuilder.GetPendingActivityInfos() {
+		if ai.StartedID == common.EmptyEventID {
+			if ai.Attempt > 0 && ai.ScheduledTime.After(now) {
+				timerTasks = append(timerTasks, &persistence.RetryTimerTask{
+					VisibilityTimestamp: ai.ScheduledTime,
+					EventID:             ai.ScheduleID,
+					Attempt:             ai.Attempt,
+				})
+			} else {
+				targetDomainID := ai.DomainID
+				if targetDomainID == "" {
+					targetDomainID = domainID
+				}
+				transferTasks = append(transferTasks, &persistence.ActivityTask{
+					DomainID:   targetDomainID,
+					TaskList:   ai.TaskList,
+					ScheduleID: ai.ScheduleID,
+				})
+			}
+		}
+		// the timer builder only creates the timers not flagged as created
+		ai.TimerTaskStatus = TimerTaskStatusNone
+		if err := msBuilder.UpdateActivity(ai); err != nil {
+			return nil, nil, err
+		}
+	}
+	if timerTask := tBuilder.GetActivityTimerTaskIfNeeded(msBuilder); timerTask != nil {
+		timerTasks = append(timerTasks, timerTask)
+	}
+
+	for _, ti := range msBuilder.GetPendingTimerInfos() {
+		ti.TaskID = TimerTaskStatusNone
+		msBuilder.UpdateUserTimer(ti.TimerID, ti)
+	}
+	tBuilder.GetUserTimers(msBuilder)
+	if timerTask := tBuilder.GetUserTimerTaskIfNeeded(msBuilder); timerTask != nil {
+		timerTasks = append(timerTasks, timerTask)
+	}
+
+	for _, ci := range msBuilder.GetPendingChildExecutionInfos() {
+		if ci.StartedID != common.EmptyEventID {
+			continue
+		}
+		initiatedEvent, ok := msBuilder.GetChildExecutionInitiatedEvent(ci.InitiatedID)
+		if !ok {
+			return nil, nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("Unable to find initiated event of child execution %v.", ci.InitiatedID),
+			}
+		}
+		attributes := initiatedEvent.StartChildWorkflowExecutionInitiatedEventAttributes
+		targetDomainID := domainID
+		if attributes.Domain != nil {
+			domainEntry, err := e.shard.GetDomainCache().GetDomain(attributes.GetDomain())
+			if err != nil {
+				return nil, nil, err
+			}
+			targetDomainID = domainEntry.GetInfo().ID
+		}
+		transferTasks = append(transferTasks, &persistence.StartChildExecutionTask{
+			TargetDomainID:   targetDomainID,
+			TargetWorkflowID: attributes.GetWorkflowId(),
+			InitiatedID:      ci.InitiatedID,
+		})
+	}
+
+	return transferTasks, timerTasks, nil
+}
+
+func (e *historyEngineImpl) updateRegeneratedTasks(context *WorkflowExecutionContext, transferTasks []persistence.Task,
+	timerTasks []persistence.Task) error {
+	transactionID, err := e.shard.GetNextTransferTaskID()
+	if err != nil {
+		return err
+	}
+	if err := context.updateWorkflowExecution(transferTasks, timerTasks, transactionID); err != nil {
+		return err
+	}
+	e.timerProcessor.NotifyNewTimers(e.currentClusterName, e.shard.GetCurrentTime(e.currentClusterName), timerTasks)
+	return nil
+}
+
+// getLastHistoryEventID returns the ID of the last event persisted in the history of a run
+func (e *historyEngineImpl) getLastHistoryEventID(resolver *conflictResolverImpl, domainID string,
+	execution workflow.WorkflowExecution) (int64, error) {
+	lastEventID := common.EmptyEventID
+	var nextPageToken []byte
+	for hasMore := true; hasMore; hasMore = len(nextPageToken) > 0 {
+		history, token, _, err := resolver.getHistory(domainID, execution, common.FirstEventID, common.EndEventID,
+			nextPageToken)
+		if err != nil {
+			return common.EmptyEventID, err
+		}
+		if len(history.Events) > 0 {
+			lastEventID = history.Events[len(history.Events)-1].GetEventId()
+		}
+		nextPageToken = token
+	}
+	if lastEventID == common.EmptyEventID {
+		return common.EmptyEventID, &workflow.EntityNotExistsError{Message: "Workflow execution history not found."}
+	}
+	return lastEventID, nil
+}
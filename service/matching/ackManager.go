@@ -22,6 +22,7 @@ package matching
 
 import (
 	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/persistence"
 	"go.uber.org/atomic"
 )
 
@@ -98,3 +99,69 @@ func (m *ackManager) completeTask(taskID int64) (ackLevel int64) {
 func (m *ackManager) getBacklogCountHint() int64 {
 	return m.backlogCounter.Load()
 }
+
+// getSettledLevel returns the level below which all the tasks read are acked, which is the read level once no
+// task is outstanding
+func (m *ackManager) getSettledLevel() int64 {
+	if len(m.outstandingTasks) == 0 && m.readLevel > m.ackLevel {
+		return m.readLevel
+	}
+	return m.ackLevel
+}
+
+// Tracks the ack level of a task list whose tasks are read per priority level. The tasks of each level are read
+// in increasing order of taskID, but the levels are read independently of each other. The ack level of the task
+// list is the lowest level below which all the tasks of every priority level are acked.
+type priorityAckManager struct {
+	levels [persistence.TaskPriorityLevels]ackManager
+}
+
+func newPriorityAckManager(logger bark.Logger) *priorityAckManager {
+	m := &priorityAckManager{}
+	for level := range m.levels {
+		m.levels[level] = newAckManager(logger)
+	}
+	return m
+}
+
+func (m *priorityAckManager) addTask(level int, taskID int64) {
+	m.levels[level].addTask(taskID)
+}
+
+func (m *priorityAckManager) getReadLevel(level int) int64 {
+	return m.levels[level].getReadLevel()
+}
+
+func (m *priorityAckManager) setReadLevel(level int, readLevel int64) {
+	m.levels[level].setReadLevel(readLevel)
+}
+
+// setAckLevel moves the ack level of all the priority levels, the task list ack level is persisted for all of them
+func (m *priorityAckManager) setAckLevel(ackLevel int64) {
+	for level := range m.levels {
+		m.levels[level].setAckLevel(ackLevel)
+	}
+}
+
+func (m *priorityAckManager) getAckLevel() int64 {
+	ackLevel := m.levels[0].getSettledLevel()
+	for level := 1; level < len(m.levels); level++ {
+		if settled := m.levels[level].getSettledLevel(); settled < ackLevel {
+			ackLevel = settled
+		}
+	}
+	return ackLevel
+}
+
+func (m *priorityAckManager) completeTask(level int, taskID int64) int64 {
+	m.levels[level].completeTask(taskID)
+	return m.getAckLevel()
+}
+
+func (m *priorityAckManager) getBacklogCountHint() int64 {
+	var backlog int64
+	for level := range m.levels {
+		backlog += m.levels[level].getBacklogCountHint()
+	}
+	return backlog
+}
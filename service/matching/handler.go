@@ -23,6 +23,8 @@ package matching
 import (
 	"context"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/uber-go/tally"
 	"github.com/uber/cadence/.gen/go/health"
@@ -33,7 +35,9 @@ import (
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/serviceerror"
 )
 
 var _ matchingserviceserver.Interface = (*Handler)(nil)
@@ -48,13 +52,13 @@ type Handler struct {
 	startWG         sync.WaitGroup
 	domainCache     cache.DomainCache
 	rateLimiter     common.TokenBucket
+	// dispatchLimiters enforce the global dispatch rate limits of the domains
+	dispatchLimiters *quotas.GlobalLimiters
+	// lastThrottleTime is the unix nanos this host last rejected a request on its RPS limit
+	lastThrottleTime int64
 	service.Service
 }
 
-var (
-	errMatchingHostThrottle = &gen.ServiceBusyError{Message: "Matching host rps exceeded"}
-)
-
 // NewHandler creates a thrift handler for the history service
 func NewHandler(sVice service.Service, config *Config, taskPersistence persistence.TaskManager, metadataMgr persistence.MetadataManager) *Handler {
 	handler := &Handler{
@@ -80,8 +84,16 @@ func (h *Handler) Start() error {
 	h.domainCache = cache.NewDomainCache(h.metadataMgr, h.GetClusterMetadata(), h.GetMetricsClient(), h.GetLogger())
 	h.domainCache.Start()
 	h.metricsClient = h.Service.GetMetricsClient()
+	h.dispatchLimiters = quotas.NewGlobalLimiters(
+		matchingDispatchLimiterPrefix,
+		func(domain string) float64 { return float64(h.config.GlobalDomainDispatchRPS(domain)) },
+		h.config.GlobalRatelimiterUpdateInterval,
+		h.GetLogger(),
+	)
+	h.dispatchLimiters.Start(quotas.NewHistoryUpdateFn(history, h.GetHostInfo().Identity()))
 	h.engine = NewEngine(
 		h.taskPersistence, history, h.config, h.Service.GetLogger(), h.Service.GetMetricsClient(), h.domainCache,
+		h.dispatchLimiters, h.GetMembershipMonitor(),
 	)
 	h.startWG.Done()
 	return nil
@@ -90,6 +102,7 @@ func (h *Handler) Start() error {
 // Stop stops the handler
 func (h *Handler) Stop() {
 	h.engine.Stop()
+	h.dispatchLimiters.Stop()
 	h.domainCache.Stop()
 	h.taskPersistence.Close()
 	h.metadataMgr.Close()
@@ -119,11 +132,11 @@ func (h *Handler) AddActivityTask(ctx context.Context, addRequest *m.AddActivity
 	sw := h.startRequestProfile("AddActivityTask", scope)
 	defer sw.Stop()
 
-	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
-		return h.handleErr(errMatchingHostThrottle, scope)
+	if ok, retryAfter := h.allowRequest(); !ok {
+		return h.handleErr(createMatchingHostThrottleError(retryAfter), scope)
 	}
 
-	return h.handleErr(h.engine.AddActivityTask(addRequest), scope)
+	return h.handleErr(h.engine.AddActivityTask(ctx, addRequest), scope)
 }
 
 // AddDecisionTask - adds a decision task.
@@ -132,11 +145,11 @@ func (h *Handler) AddDecisionTask(ctx context.Context, addRequest *m.AddDecision
 	sw := h.startRequestProfile("AddDecisionTask", scope)
 	defer sw.Stop()
 
-	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
-		return h.handleErr(errMatchingHostThrottle, scope)
+	if ok, retryAfter := h.allowRequest(); !ok {
+		return h.handleErr(createMatchingHostThrottleError(retryAfter), scope)
 	}
 
-	return h.handleErr(h.engine.AddDecisionTask(addRequest), scope)
+	return h.handleErr(h.engine.AddDecisionTask(ctx, addRequest), scope)
 }
 
 // PollForActivityTask - long poll for an activity task.
@@ -147,11 +160,19 @@ func (h *Handler) PollForActivityTask(ctx context.Context,
 	sw := h.startRequestProfile("PollForActivityTask", scope)
 	defer sw.Stop()
 
-	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
-		return nil, h.handleErr(errMatchingHostThrottle, scope)
+	if ok, retryAfter := h.allowRequest(); !ok {
+		return nil, h.handleErr(createMatchingHostThrottleError(retryAfter), scope)
 	}
 
 	response, err := h.engine.PollForActivityTask(ctx, pollRequest)
+	if response != nil {
+		if backoff := h.getPollBackoffMillis(); backoff != nil {
+			// the empty response is shared by all the polls
+			withBackoff := *response
+			withBackoff.PollBackoffMillis = backoff
+			response = &withBackoff
+		}
+	}
 	return response, h.handleErr(err, scope)
 }
 
@@ -163,11 +184,19 @@ func (h *Handler) PollForDecisionTask(ctx context.Context,
 	sw := h.startRequestProfile("PollForDecisionTask", scope)
 	defer sw.Stop()
 
-	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
-		return nil, h.handleErr(errMatchingHostThrottle, scope)
+	if ok, retryAfter := h.allowRequest(); !ok {
+		return nil, h.handleErr(createMatchingHostThrottleError(retryAfter), scope)
 	}
 
 	response, err := h.engine.PollForDecisionTask(ctx, pollRequest)
+	if response != nil {
+		if backoff := h.getPollBackoffMillis(); backoff != nil {
+			// the empty response is shared by all the polls
+			withBackoff := *response
+			withBackoff.PollBackoffMillis = backoff
+			response = &withBackoff
+		}
+	}
 	return response, h.handleErr(err, scope)
 }
 
@@ -178,8 +207,8 @@ func (h *Handler) QueryWorkflow(ctx context.Context,
 	sw := h.startRequestProfile("QueryWorkflow", scope)
 	defer sw.Stop()
 
-	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
-		return nil, h.handleErr(errMatchingHostThrottle, scope)
+	if ok, retryAfter := h.allowRequest(); !ok {
+		return nil, h.handleErr(createMatchingHostThrottleError(retryAfter), scope)
 	}
 
 	response, err := h.engine.QueryWorkflow(ctx, queryRequest)
@@ -220,47 +249,162 @@ func (h *Handler) DescribeTaskList(ctx context.Context, request *m.DescribeTaskL
 	sw := h.startRequestProfile("DescribeTaskList", scope)
 	defer sw.Stop()
 
-	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
-		return nil, h.handleErr(errMatchingHostThrottle, scope)
+	if ok, retryAfter := h.allowRequest(); !ok {
+		return nil, h.handleErr(createMatchingHostThrottleError(retryAfter), scope)
 	}
 
 	response, err := h.engine.DescribeTaskList(ctx, request)
 	return response, h.handleErr(err, scope)
 }
 
+// GetTaskListsByDomain returns the task lists of a domain together with their recent pollers, as persisted by
+// the matching hosts owning them.
+func (h *Handler) GetTaskListsByDomain(ctx context.Context,
+	request *m.GetTaskListsByDomainRequest) (*gen.GetTaskListsByDomainResponse, error) {
+	scope := metrics.MatchingGetTaskListsByDomainScope
+	sw := h.startRequestProfile("GetTaskListsByDomain", scope)
+	defer sw.Stop()
+
+	if ok, retryAfter := h.allowRequest(); !ok {
+		return nil, h.handleErr(createMatchingHostThrottleError(retryAfter), scope)
+	}
+
+	response, err := h.engine.GetTaskListsByDomain(ctx, request)
+	return response, h.handleErr(err, scope)
+}
+
+// RecordWorkerHeartbeat records the liveness and load of a worker on a tasklist
+func (h *Handler) RecordWorkerHeartbeat(ctx context.Context, request *m.RecordWorkerHeartbeatRequest) error {
+	scope := metrics.MatchingRecordWorkerHeartbeatScope
+	sw := h.startRequestProfile("RecordWorkerHeartbeat", scope)
+	defer sw.Stop()
+
+	if ok, retryAfter := h.allowRequest(); !ok {
+		return h.handleErr(createMatchingHostThrottleError(retryAfter), scope)
+	}
+
+	err := h.engine.RecordWorkerHeartbeat(ctx, request)
+	return h.handleErr(err, scope)
+}
+
+// ListTaskListDLQTasks returns the tasks parked in the DLQ of a tasklist
+func (h *Handler) ListTaskListDLQTasks(ctx context.Context,
+	request *m.ListTaskListDLQTasksRequest) (*gen.ListTaskListDLQTasksResponse, error) {
+	scope := metrics.MatchingListTaskListDLQTasksScope
+	sw := h.startRequestProfile("ListTaskListDLQTasks", scope)
+	defer sw.Stop()
+
+	response, err := h.engine.ListTaskListDLQTasks(ctx, request)
+	return response, h.handleErr(err, scope)
+}
+
+// RequeueTaskListDLQTasks writes tasks parked in the DLQ of a tasklist back to the tasklist
+func (h *Handler) RequeueTaskListDLQTasks(ctx context.Context,
+	request *m.RequeueTaskListDLQTasksRequest) (*gen.RequeueTaskListDLQTasksResponse, error) {
+	scope := metrics.MatchingRequeueTaskListDLQTasksScope
+	sw := h.startRequestProfile("RequeueTaskListDLQTasks", scope)
+	defer sw.Stop()
+
+	response, err := h.engine.RequeueTaskListDLQTasks(ctx, request)
+	return response, h.handleErr(err, scope)
+}
+
+// DescribeTaskListLatency returns the percentiles of the schedule-to-start latency of the tasks recently dispatched
+// from a tasklist
+func (h *Handler) DescribeTaskListLatency(ctx context.Context,
+	request *m.DescribeTaskListLatencyRequest) (*gen.DescribeTaskListLatencyResponse, error) {
+	scope := metrics.MatchingDescribeTaskListLatencyScope
+	sw := h.startRequestProfile("DescribeTaskListLatency", scope)
+	defer sw.Stop()
+
+	response, err := h.engine.DescribeTaskListLatency(ctx, request)
+	return response, h.handleErr(err, scope)
+}
+
+// ForceUnloadTaskList stops the manager of a tasklist loaded on this host
+func (h *Handler) ForceUnloadTaskList(ctx context.Context,
+	request *m.ForceUnloadTaskListRequest) (*gen.ForceUnloadTaskListResponse, error) {
+	scope := metrics.MatchingForceUnloadTaskListScope
+	sw := h.startRequestProfile("ForceUnloadTaskList", scope)
+	defer sw.Stop()
+
+	response, err := h.engine.ForceUnloadTaskList(ctx, request)
+	return response, h.handleErr(err, scope)
+}
+
+// RefreshDomainCache refreshes the domain cache of this host right away, it is called after a domain got updated
+func (h *Handler) RefreshDomainCache(ctx context.Context, request *gen.RefreshDomainCacheRequest) error {
+	scope := metrics.MatchingRefreshDomainCacheScope
+	sw := h.startRequestProfile("RefreshDomainCache", scope)
+	defer sw.Stop()
+
+	h.domainCache.TriggerRefresh()
+	return nil
+}
+
 func (h *Handler) handleErr(err error, scope int) error {
 
 	if err == nil {
 		return nil
 	}
 
-	switch err.(type) {
+	cause := serviceerror.Cause(err)
+	switch cause.(type) {
 	case *gen.InternalServiceError:
 		h.metricsClient.IncCounter(scope, metrics.CadenceFailures)
-		return err
+		return cause
 	case *gen.BadRequestError:
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrBadRequestCounter)
-		return err
+		return cause
 	case *gen.EntityNotExistsError:
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrEntityNotExistsCounter)
-		return err
+		return cause
 	case *gen.WorkflowExecutionAlreadyStartedError:
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrExecutionAlreadyStartedCounter)
-		return err
+		return cause
 	case *gen.DomainAlreadyExistsError:
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrDomainAlreadyExistsCounter)
-		return err
+		return cause
 	case *gen.QueryFailedError:
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrQueryFailedCounter)
-		return err
+		return cause
 	case *gen.LimitExceededError:
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrLimitExceededCounter)
-		return err
+		return cause
 	case *gen.ServiceBusyError:
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrServiceBusyCounter)
-		return err
+		return cause
 	default:
 		h.metricsClient.IncCounter(scope, metrics.CadenceFailures)
 		return &gen.InternalServiceError{Message: err.Error()}
 	}
 }
+
+// allowRequest takes a token of the host RPS limit, a rejected request marks the host as shedding load
+func (h *Handler) allowRequest() (bool, time.Duration) {
+	ok, retryAfter := h.rateLimiter.TryConsume(1)
+	if !ok {
+		atomic.StoreInt64(&h.lastThrottleTime, time.Now().UnixNano())
+	}
+	return ok, retryAfter
+}
+
+// getPollBackoffMillis returns the backoff suggested to the pollers while this host is shedding load, which is
+// for LoadSheddingPollBackoff after it last rejected a request on its RPS limit
+func (h *Handler) getPollBackoffMillis() *int64 {
+	backoff := h.config.LoadSheddingPollBackoff()
+	if backoff <= 0 {
+		return nil
+	}
+	if time.Since(time.Unix(0, atomic.LoadInt64(&h.lastThrottleTime))) >= backoff {
+		return nil
+	}
+	return common.Int64Ptr(int64(backoff / time.Millisecond))
+}
+
+func createMatchingHostThrottleError(retryAfter time.Duration) *gen.ServiceBusyError {
+	return &gen.ServiceBusyError{
+		Message:          "Matching host rps exceeded",
+		RetryAfterMillis: common.Int64Ptr(int64(retryAfter / time.Millisecond)),
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+func TestPollBackoffWhileSheddingLoad(t *testing.T) {
+	config := NewConfig(dynamicconfig.NewNopCollection())
+	config.LoadSheddingPollBackoff = dynamicconfig.GetDurationPropertyFn(time.Minute)
+	h := &Handler{config: config, rateLimiter: common.NewTokenBucket(1000, common.NewRealTimeSource())}
+
+	ok, _ := h.allowRequest()
+	require.True(t, ok)
+	require.Nil(t, h.getPollBackoffMillis(), "the host is not shedding load")
+
+	h.rateLimiter = common.NewTokenBucket(0, common.NewRealTimeSource())
+	ok, _ = h.allowRequest()
+	require.False(t, ok)
+	require.Equal(t, int64(time.Minute/time.Millisecond), *h.getPollBackoffMillis())
+
+	// the backoff is suggested for LoadSheddingPollBackoff after the last rejected request
+	h.lastThrottleTime = time.Now().Add(-time.Minute).UnixNano()
+	require.Nil(t, h.getPollBackoffMillis())
+
+	config.LoadSheddingPollBackoff = dynamicconfig.GetDurationPropertyFn(0)
+	h.lastThrottleTime = time.Now().UnixNano()
+	require.Nil(t, h.getPollBackoffMillis(), "the hint is disabled")
+}
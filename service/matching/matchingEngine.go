@@ -25,22 +25,30 @@ import (
 	"errors"
 	"math"
 	"sync"
+	"time"
 
 	h "github.com/uber/cadence/.gen/go/history"
 	m "github.com/uber/cadence/.gen/go/matching"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/client"
 	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/quotas"
 
 	"github.com/pborman/uuid"
 	"github.com/uber-common/bark"
 	"github.com/uber/cadence/common/cache"
+	"go.uber.org/yarpc"
 )
 
+// matchingDispatchLimiterPrefix namespaces the matching dispatch limits in the history aggregators
+const matchingDispatchLimiterPrefix = "matching.dispatch:"
+
 // Implements matching.Engine
 // TODO: Switch implementation from lock/channel based to a partitioned agent
 // to simplify code and reduce possiblity of synchronization errors.
@@ -59,6 +67,14 @@ type matchingEngineImpl struct {
 	// unblock QueryWorkflow() call.
 	queryTaskMap map[string]chan *workflow.RespondQueryTaskCompletedRequest
 	domainCache  cache.DomainCache
+	// domainDispatchLimiters enforce the global dispatch rate limits of the domains, nil disables them
+	domainDispatchLimiters *quotas.GlobalLimiters
+	latencyTagLimiter      *taskListTagLimiter
+	// movedTaskLists are the tasklists force unloaded from this host with the time their move expires at, they
+	// are published through the membership labels for the matching clients to route them to another host
+	movedLock         sync.Mutex
+	movedTaskLists    map[string]time.Time
+	membershipMonitor membership.Monitor
 }
 
 type taskListID struct {
@@ -69,6 +85,8 @@ type taskListID struct {
 
 type pollerIDCtxKey string
 type identityCtxKey string
+type pollerVersionCtxKey string
+type isolationGroupCtxKey string
 
 var (
 	// EmptyPollForDecisionTaskResponse is the response when there are no decision tasks to hand out
@@ -81,9 +99,16 @@ var (
 	// ErrNoTasks is exported temporarily for integration test
 	ErrNoTasks    = errors.New("No tasks")
 	errPumpClosed = errors.New("Task list pump closed its channel")
+	// errTaskListMoved is returned for the tasklists moved away from this host until the matching clients route
+	// them to another host
+	errTaskListMoved = &workflow.ServiceBusyError{Message: "Task list was moved away from this host."}
 
 	pollerIDKey pollerIDCtxKey = "pollerID"
 	identityKey identityCtxKey = "identity"
+	// pollerVersionKey carries the client library version of the poller
+	pollerVersionKey pollerVersionCtxKey = "pollerVersion"
+	// isolationGroupKey carries the isolation group declared by the poller
+	isolationGroupKey isolationGroupCtxKey = "isolationGroup"
 )
 
 func (t *taskListID) String() string {
@@ -108,6 +133,8 @@ func NewEngine(taskManager persistence.TaskManager,
 	logger bark.Logger,
 	metricsClient metrics.Client,
 	domainCache cache.DomainCache,
+	domainDispatchLimiters *quotas.GlobalLimiters,
+	membershipMonitor membership.Monitor,
 ) Engine {
 
 	return &matchingEngineImpl{
@@ -122,6 +149,11 @@ func NewEngine(taskManager persistence.TaskManager,
 		config:        config,
 		queryTaskMap:  make(map[string]chan *workflow.RespondQueryTaskCompletedRequest),
 		domainCache:   domainCache,
+
+		domainDispatchLimiters: domainDispatchLimiters,
+		latencyTagLimiter:      newTaskListTagLimiter(config.MaxTaskListLatencyMetricTags),
+		movedTaskLists:         make(map[string]time.Time),
+		membershipMonitor:      membershipMonitor,
 	}
 }
 
@@ -179,6 +211,10 @@ func (e *matchingEngineImpl) getTaskListManager(taskList *taskListID,
 		e.taskListsLock.Unlock()
 		return result, nil
 	}
+	if e.isTaskListMoved(taskList.taskListName) {
+		e.taskListsLock.Unlock()
+		return nil, errTaskListMoved
+	}
 	logging.LogTaskListLoadingEvent(e.logger, taskList.taskListName, taskList.taskType)
 	mgr, err := newTaskListManager(e, taskList, taskListKind, e.config)
 	if err != nil {
@@ -211,7 +247,7 @@ func (e *matchingEngineImpl) removeTaskListManager(id *taskListID) {
 }
 
 // AddDecisionTask either delivers task directly to waiting poller or save it into task list persistence.
-func (e *matchingEngineImpl) AddDecisionTask(addRequest *m.AddDecisionTaskRequest) error {
+func (e *matchingEngineImpl) AddDecisionTask(ctx context.Context, addRequest *m.AddDecisionTaskRequest) error {
 	domainID := addRequest.GetDomainUUID()
 	taskListName := addRequest.TaskList.GetName()
 	taskListKind := common.TaskListKindPtr(addRequest.TaskList.GetKind())
@@ -229,12 +265,15 @@ func (e *matchingEngineImpl) AddDecisionTask(addRequest *m.AddDecisionTaskReques
 		WorkflowID:             addRequest.Execution.GetWorkflowId(),
 		ScheduleID:             addRequest.GetScheduleId(),
 		ScheduleToStartTimeout: addRequest.GetScheduleToStartTimeoutSeconds(),
+		IsolationGroup:         addRequest.GetIsolationGroup(),
+		Priority:               addRequest.GetPriority(),
+		CreatedTime:            getScheduledTime(addRequest.ScheduledTimestamp),
 	}
-	return tlMgr.AddTask(addRequest.Execution, taskInfo)
+	return tlMgr.AddTask(ctx, addRequest.Execution, taskInfo)
 }
 
 // AddActivityTask either delivers task directly to waiting poller or save it into task list persistence.
-func (e *matchingEngineImpl) AddActivityTask(addRequest *m.AddActivityTaskRequest) error {
+func (e *matchingEngineImpl) AddActivityTask(ctx context.Context, addRequest *m.AddActivityTaskRequest) error {
 	domainID := addRequest.GetDomainUUID()
 	sourceDomainID := addRequest.GetSourceDomainUUID()
 	taskListName := addRequest.TaskList.GetName()
@@ -252,8 +291,11 @@ func (e *matchingEngineImpl) AddActivityTask(addRequest *m.AddActivityTaskReques
 		WorkflowID:             addRequest.Execution.GetWorkflowId(),
 		ScheduleID:             addRequest.GetScheduleId(),
 		ScheduleToStartTimeout: addRequest.GetScheduleToStartTimeoutSeconds(),
+		IsolationGroup:         addRequest.GetIsolationGroup(),
+		Priority:               addRequest.GetPriority(),
+		CreatedTime:            getScheduledTime(addRequest.ScheduledTimestamp),
 	}
-	return tlMgr.AddTask(addRequest.Execution, taskInfo)
+	return tlMgr.AddTask(ctx, addRequest.Execution, taskInfo)
 }
 
 // PollForDecisionTask tries to get the decision task using exponential backoff.
@@ -261,6 +303,7 @@ func (e *matchingEngineImpl) PollForDecisionTask(ctx context.Context, req *m.Pol
 	*m.PollForDecisionTaskResponse, error) {
 	domainID := req.GetDomainUUID()
 	pollerID := req.GetPollerID()
+	pollerVersion := yarpc.CallFromContext(ctx).Header(common.LibraryVersionHeaderName)
 	request := req.PollRequest
 	taskListName := request.TaskList.GetName()
 	e.logger.Debugf("Received PollForDecisionTask for taskList=%v", taskListName)
@@ -274,6 +317,8 @@ pollLoop:
 		// long-poll when frontend calls CancelOutstandingPoll API
 		pollerCtx := context.WithValue(ctx, pollerIDKey, pollerID)
 		pollerCtx = context.WithValue(pollerCtx, identityKey, request.GetIdentity())
+		pollerCtx = context.WithValue(pollerCtx, pollerVersionKey, pollerVersion)
+		pollerCtx = context.WithValue(pollerCtx, isolationGroupKey, request.GetIsolationGroup())
 		taskList := newTaskListID(domainID, taskListName, persistence.TaskListTypeDecision)
 		taskListKind := common.TaskListKindPtr(request.TaskList.GetKind())
 		tCtx, err := e.getTask(pollerCtx, taskList, nil, taskListKind)
@@ -348,6 +393,7 @@ pollLoop:
 			continue pollLoop
 		}
 		tCtx.completeTask(nil)
+		tCtx.recordScheduleToStartLatency()
 		return e.createPollForDecisionTaskResponse(tCtx, resp), nil
 	}
 }
@@ -359,6 +405,7 @@ func (e *matchingEngineImpl) PollForActivityTask(ctx context.Context, req *m.Pol
 	*workflow.PollForActivityTaskResponse, error) {
 	domainID := req.GetDomainUUID()
 	pollerID := req.GetPollerID()
+	pollerVersion := yarpc.CallFromContext(ctx).Header(common.LibraryVersionHeaderName)
 	request := req.PollRequest
 	taskListName := request.TaskList.GetName()
 	e.logger.Debugf("Received PollForActivityTask for taskList=%v", taskListName)
@@ -378,6 +425,8 @@ pollLoop:
 		// long-poll when frontend calls CancelOutstandingPoll API
 		pollerCtx := context.WithValue(ctx, pollerIDKey, pollerID)
 		pollerCtx = context.WithValue(pollerCtx, identityKey, request.GetIdentity())
+		pollerCtx = context.WithValue(pollerCtx, pollerVersionKey, pollerVersion)
+		pollerCtx = context.WithValue(pollerCtx, isolationGroupKey, request.GetIsolationGroup())
 		taskListKind := common.TaskListKindPtr(request.TaskList.GetKind())
 		tCtx, err := e.getTask(pollerCtx, taskList, maxDispatch, taskListKind)
 		if err != nil {
@@ -410,6 +459,7 @@ pollLoop:
 			continue pollLoop
 		}
 		tCtx.completeTask(nil)
+		tCtx.recordScheduleToStartLatency()
 		return e.createPollForActivityTaskResponse(tCtx, resp), nil
 	}
 }
@@ -502,13 +552,216 @@ func (e *matchingEngineImpl) DescribeTaskList(ctx context.Context, request *m.De
 	}
 
 	pollers := []*workflow.PollerInfo{}
+	lastPollTimes := make(map[string]int64)
 	for _, poller := range tlMgr.GetAllPollerInfo() {
 		pollers = append(pollers, &workflow.PollerInfo{
 			Identity:       common.StringPtr(poller.identity),
 			LastAccessTime: common.Int64Ptr(poller.lastAccessTime.UnixNano()),
+			Version:        common.StringPtr(poller.version),
+		})
+		lastPollTimes[poller.identity] = poller.lastAccessTime.UnixNano()
+	}
+
+	workers := []*workflow.WorkerInfo{}
+	for _, worker := range tlMgr.GetAllWorkerInfo() {
+		workerInfo := &workflow.WorkerInfo{
+			Identity:          common.StringPtr(worker.identity),
+			Capabilities:      worker.capabilities,
+			CurrentLoad:       common.Int32Ptr(worker.currentLoad),
+			LastHeartbeatTime: common.Int64Ptr(worker.lastHeartbeatTime.UnixNano()),
+		}
+		// a worker which heartbeats without polling is connected but not making progress
+		if lastPollTime, ok := lastPollTimes[worker.identity]; ok {
+			workerInfo.LastPollTime = common.Int64Ptr(lastPollTime)
+		}
+		workers = append(workers, workerInfo)
+	}
+	return &workflow.DescribeTaskListResponse{Pollers: pollers, Workers: workers}, nil
+}
+
+func (e *matchingEngineImpl) RecordWorkerHeartbeat(ctx context.Context, request *m.RecordWorkerHeartbeatRequest) error {
+	domainID := request.GetDomainUUID()
+	heartbeatRequest := request.HeartbeatRequest
+	taskListType := persistence.TaskListTypeDecision
+	if heartbeatRequest.GetTaskListType() == workflow.TaskListTypeActivity {
+		taskListType = persistence.TaskListTypeActivity
+	}
+	taskListName := heartbeatRequest.TaskList.GetName()
+
+	taskList := newTaskListID(domainID, taskListName, taskListType)
+	taskListKind := common.TaskListKindPtr(heartbeatRequest.TaskList.GetKind())
+	tlMgr, err := e.getTaskListManager(taskList, taskListKind)
+	if err != nil {
+		return err
+	}
+
+	tlMgr.RecordWorkerHeartbeat(heartbeatRequest.GetIdentity(), heartbeatRequest.Capabilities,
+		heartbeatRequest.GetCurrentLoad())
+	return nil
+}
+
+func (e *matchingEngineImpl) ListTaskListDLQTasks(ctx context.Context,
+	request *m.ListTaskListDLQTasksRequest) (*workflow.ListTaskListDLQTasksResponse, error) {
+	listRequest := request.ListRequest
+	taskListType := persistence.TaskListTypeDecision
+	if listRequest.GetTaskListType() == workflow.TaskListTypeActivity {
+		taskListType = persistence.TaskListTypeActivity
+	}
+	resp, err := e.taskManager.GetDLQTasks(&persistence.GetDLQTasksRequest{
+		DomainID:      request.GetDomainUUID(),
+		TaskList:      listRequest.TaskList.GetName(),
+		TaskType:      taskListType,
+		PageSize:      int(listRequest.GetMaximumPageSize()),
+		NextPageToken: listRequest.NextPageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []*workflow.TaskListDLQTaskInfo{}
+	for _, task := range resp.Tasks {
+		tasks = append(tasks, &workflow.TaskListDLQTaskInfo{
+			TaskId: common.Int64Ptr(task.TaskID),
+			WorkflowExecution: &workflow.WorkflowExecution{
+				WorkflowId: common.StringPtr(task.WorkflowID),
+				RunId:      common.StringPtr(task.RunID),
+			},
+			ScheduleId:       common.Int64Ptr(task.ScheduleID),
+			DispatchAttempts: common.Int32Ptr(task.DispatchAttempts),
 		})
 	}
-	return &workflow.DescribeTaskListResponse{Pollers: pollers}, nil
+
+	response := &workflow.ListTaskListDLQTasksResponse{Tasks: tasks}
+	if len(resp.NextPageToken) > 0 {
+		response.NextPageToken = resp.NextPageToken
+	}
+	return response, nil
+}
+
+func (e *matchingEngineImpl) RequeueTaskListDLQTasks(ctx context.Context,
+	request *m.RequeueTaskListDLQTasksRequest) (*workflow.RequeueTaskListDLQTasksResponse, error) {
+	requeueRequest := request.RequeueRequest
+	taskListType := persistence.TaskListTypeDecision
+	if requeueRequest.GetTaskListType() == workflow.TaskListTypeActivity {
+		taskListType = persistence.TaskListTypeActivity
+	}
+
+	taskList := newTaskListID(request.GetDomainUUID(), requeueRequest.TaskList.GetName(), taskListType)
+	taskListKind := common.TaskListKindPtr(requeueRequest.TaskList.GetKind())
+	tlMgr, err := e.getTaskListManager(taskList, taskListKind)
+	if err != nil {
+		return nil, err
+	}
+
+	requeued, err := tlMgr.RequeueDLQTasks(requeueRequest.TaskIds)
+	if err != nil {
+		return nil, err
+	}
+	return &workflow.RequeueTaskListDLQTasksResponse{RequeuedCount: common.Int32Ptr(requeued)}, nil
+}
+
+// getScheduledTime returns the time history scheduled a task, the schedule-to-start latency of the task starts
+// there. Tasks added by history hosts which do not send it are stamped with the time they are received.
+func getScheduledTime(scheduledTimestamp *int64) time.Time {
+	if scheduledTimestamp == nil || *scheduledTimestamp <= 0 {
+		return time.Now()
+	}
+	return time.Unix(0, *scheduledTimestamp)
+}
+
+func (e *matchingEngineImpl) DescribeTaskListLatency(ctx context.Context,
+	request *m.DescribeTaskListLatencyRequest) (*workflow.DescribeTaskListLatencyResponse, error) {
+	describeRequest := request.DescribeRequest
+	taskListType := persistence.TaskListTypeDecision
+	if describeRequest.GetTaskListType() == workflow.TaskListTypeActivity {
+		taskListType = persistence.TaskListTypeActivity
+	}
+
+	taskList := newTaskListID(request.GetDomainUUID(), describeRequest.TaskList.GetName(), taskListType)
+	taskListKind := common.TaskListKindPtr(describeRequest.TaskList.GetKind())
+	tlMgr, err := e.getTaskListManager(taskList, taskListKind)
+	if err != nil {
+		return nil, err
+	}
+
+	percentiles := tlMgr.GetScheduleToStartLatency()
+	return &workflow.DescribeTaskListLatencyResponse{
+		SampleCount:              common.Int32Ptr(int32(percentiles.count)),
+		ScheduleToStartP50Millis: common.Int64Ptr(int64(percentiles.p50 / time.Millisecond)),
+		ScheduleToStartP90Millis: common.Int64Ptr(int64(percentiles.p90 / time.Millisecond)),
+		ScheduleToStartP99Millis: common.Int64Ptr(int64(percentiles.p99 / time.Millisecond)),
+		ScheduleToStartMaxMillis: common.Int64Ptr(int64(percentiles.max / time.Millisecond)),
+	}, nil
+}
+
+// ForceUnloadTaskList stops the manager of a tasklist if it is loaded on this host. The tasklist is moved away from
+// this host for MovedTaskListTTL: the matching clients route it to another host, which takes the lease over.
+func (e *matchingEngineImpl) ForceUnloadTaskList(ctx context.Context,
+	request *m.ForceUnloadTaskListRequest) (*workflow.ForceUnloadTaskListResponse, error) {
+	unloadRequest := request.UnloadRequest
+	taskListType := persistence.TaskListTypeDecision
+	if unloadRequest.GetTaskListType() == workflow.TaskListTypeActivity {
+		taskListType = persistence.TaskListTypeActivity
+	}
+
+	taskList := newTaskListID(request.GetDomainUUID(), unloadRequest.TaskList.GetName(), taskListType)
+	unloaded := e.unloadTaskList(taskList)
+	e.moveTaskList(taskList.taskListName)
+	if unloaded {
+		e.logger.WithFields(bark.Fields{
+			logging.TagDomainID:     taskList.domainID,
+			logging.TagTaskListName: taskList.taskListName,
+			logging.TagTaskListType: taskList.taskType,
+		}).Info("Tasklist force unloaded.")
+	}
+	return &workflow.ForceUnloadTaskListResponse{Unloaded: common.BoolPtr(unloaded)}, nil
+}
+
+func (e *matchingEngineImpl) GetTaskListsByDomain(ctx context.Context,
+	request *m.GetTaskListsByDomainRequest) (*workflow.GetTaskListsByDomainResponse, error) {
+	listRequest := request.ListRequest
+	resp, err := e.taskManager.ListTaskList(&persistence.ListTaskListRequest{
+		DomainID:      request.GetDomainUUID(),
+		PageSize:      int(listRequest.GetPageSize()),
+		NextPageToken: listRequest.NextPageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	taskLists := []*workflow.TaskListByDomainInfo{}
+	for _, tli := range resp.Items {
+		taskListType := workflow.TaskListTypeDecision
+		if tli.TaskType == persistence.TaskListTypeActivity {
+			taskListType = workflow.TaskListTypeActivity
+		}
+		taskListKind := workflow.TaskListKindNormal
+		if tli.Kind == persistence.TaskListKindSticky {
+			taskListKind = workflow.TaskListKindSticky
+		}
+		pollers := []*workflow.PollerInfo{}
+		for _, poller := range tli.Pollers {
+			pollers = append(pollers, &workflow.PollerInfo{
+				Identity:       common.StringPtr(poller.Identity),
+				LastAccessTime: common.Int64Ptr(poller.LastAccessTime.UnixNano()),
+				Version:        common.StringPtr(poller.Version),
+			})
+		}
+		taskLists = append(taskLists, &workflow.TaskListByDomainInfo{
+			TaskList: &workflow.TaskList{
+				Name: common.StringPtr(tli.Name),
+				Kind: common.TaskListKindPtr(taskListKind),
+			},
+			TaskListType: common.TaskListTypePtr(taskListType),
+			Pollers:      pollers,
+		})
+	}
+
+	response := &workflow.GetTaskListsByDomainResponse{TaskLists: taskLists}
+	if len(resp.NextPageToken) > 0 {
+		response.NextPageToken = resp.NextPageToken
+	}
+	return response, nil
 }
 
 // Loads a task from persistence and wraps it in a task context
@@ -522,7 +775,7 @@ func (e *matchingEngineImpl) getTask(
 	return tlMgr.GetTaskContext(ctx, maxDispatchPerSecond)
 }
 
-func (e *matchingEngineImpl) unloadTaskList(id *taskListID) {
+func (e *matchingEngineImpl) unloadTaskList(id *taskListID) bool {
 	e.taskListsLock.Lock()
 	tlMgr, ok := e.taskLists[*id]
 	if ok {
@@ -532,6 +785,64 @@ func (e *matchingEngineImpl) unloadTaskList(id *taskListID) {
 	if ok {
 		tlMgr.Stop()
 	}
+	return ok
+}
+
+// moveTaskList stops serving every tasklist with the given name on this host, the clients route requests by
+// tasklist name so all of its types move together
+func (e *matchingEngineImpl) moveTaskList(taskListName string) {
+	ttl := e.config.MovedTaskListTTL()
+	if ttl <= 0 {
+		return
+	}
+
+	e.movedLock.Lock()
+	now := time.Now()
+	for name, expiry := range e.movedTaskLists {
+		if !expiry.After(now) {
+			delete(e.movedTaskLists, name)
+		}
+	}
+	e.movedTaskLists[taskListName] = now.Add(ttl)
+	e.publishMovedTaskListsLocked()
+	e.movedLock.Unlock()
+
+	var ids []taskListID
+	e.taskListsLock.RLock()
+	for id := range e.taskLists {
+		if id.taskListName == taskListName {
+			ids = append(ids, id)
+		}
+	}
+	e.taskListsLock.RUnlock()
+	for i := range ids {
+		e.unloadTaskList(&ids[i])
+	}
+}
+
+func (e *matchingEngineImpl) isTaskListMoved(taskListName string) bool {
+	e.movedLock.Lock()
+	defer e.movedLock.Unlock()
+	expiry, ok := e.movedTaskLists[taskListName]
+	if !ok {
+		return false
+	}
+	if expiry.After(time.Now()) {
+		return true
+	}
+	delete(e.movedTaskLists, taskListName)
+	e.publishMovedTaskListsLocked()
+	return false
+}
+
+func (e *matchingEngineImpl) publishMovedTaskListsLocked() {
+	if e.membershipMonitor == nil {
+		return
+	}
+	err := e.membershipMonitor.SetLabel(matching.MovedTaskListsLabel, matching.EncodeMovedTaskLists(e.movedTaskLists))
+	if err != nil {
+		logging.LogOperationFailedEvent(e.logger, "Error publishing the moved tasklists", err)
+	}
 }
 
 // Populate the decision task response based on context and scheduled/started events.
@@ -574,27 +885,6 @@ func (e *matchingEngineImpl) createPollForActivityTaskResponse(context *taskCont
 	historyResponse *h.RecordActivityTaskStartedResponse) *workflow.PollForActivityTaskResponse {
 	task := context.info
 
-	scheduledEvent := historyResponse.ScheduledEvent
-	if scheduledEvent.ActivityTaskScheduledEventAttributes == nil {
-		panic("GetActivityTaskScheduledEventAttributes is not set")
-	}
-	attributes := scheduledEvent.ActivityTaskScheduledEventAttributes
-	if attributes.ActivityId == nil {
-		panic("ActivityTaskScheduledEventAttributes.ActivityID is not set")
-	}
-
-	response := &workflow.PollForActivityTaskResponse{}
-	response.ActivityId = attributes.ActivityId
-	response.ActivityType = attributes.ActivityType
-	response.Input = attributes.Input
-	response.WorkflowExecution = workflowExecutionPtr(context.workflowExecution)
-	response.ScheduledTimestampOfThisAttempt = historyResponse.ScheduledTimestampOfThisAttempt
-	response.ScheduledTimestamp = common.Int64Ptr(*scheduledEvent.Timestamp)
-	response.ScheduleToCloseTimeoutSeconds = common.Int32Ptr(*attributes.ScheduleToCloseTimeoutSeconds)
-	response.StartedTimestamp = historyResponse.StartedTimestamp
-	response.StartToCloseTimeoutSeconds = common.Int32Ptr(*attributes.StartToCloseTimeoutSeconds)
-	response.HeartbeatTimeoutSeconds = common.Int32Ptr(*attributes.HeartbeatTimeoutSeconds)
-
 	token := &common.TaskToken{
 		DomainID:        task.DomainID,
 		WorkflowID:      task.WorkflowID,
@@ -602,10 +892,10 @@ func (e *matchingEngineImpl) createPollForActivityTaskResponse(context *taskCont
 		ScheduleID:      task.ScheduleID,
 		ScheduleAttempt: historyResponse.GetAttempt(),
 	}
+	serializedToken, _ := e.tokenSerializer.Serialize(token)
 
-	response.TaskToken, _ = e.tokenSerializer.Serialize(token)
-	response.Attempt = common.Int32Ptr(int32(token.ScheduleAttempt))
-	return response
+	return common.CreatePollForActivityTaskResponse(historyResponse, workflowExecutionPtr(context.workflowExecution),
+		serializedToken)
 }
 
 func newTaskListID(domainID, taskListName string, taskType int) *taskListID {
@@ -31,13 +31,19 @@ type (
 	// Engine exposes interfaces for clients to poll for activity and decision tasks.
 	Engine interface {
 		Stop()
-		AddDecisionTask(addRequest *m.AddDecisionTaskRequest) error
-		AddActivityTask(addRequest *m.AddActivityTaskRequest) error
+		AddDecisionTask(ctx context.Context, addRequest *m.AddDecisionTaskRequest) error
+		AddActivityTask(ctx context.Context, addRequest *m.AddActivityTaskRequest) error
 		PollForDecisionTask(ctx context.Context, request *m.PollForDecisionTaskRequest) (*m.PollForDecisionTaskResponse, error)
 		PollForActivityTask(ctx context.Context, request *m.PollForActivityTaskRequest) (*workflow.PollForActivityTaskResponse, error)
 		QueryWorkflow(ctx context.Context, request *m.QueryWorkflowRequest) (*workflow.QueryWorkflowResponse, error)
 		RespondQueryTaskCompleted(ctx context.Context, request *m.RespondQueryTaskCompletedRequest) error
 		CancelOutstandingPoll(ctx context.Context, request *m.CancelOutstandingPollRequest) error
 		DescribeTaskList(ctx context.Context, request *m.DescribeTaskListRequest) (*workflow.DescribeTaskListResponse, error)
+		GetTaskListsByDomain(ctx context.Context, request *m.GetTaskListsByDomainRequest) (*workflow.GetTaskListsByDomainResponse, error)
+		RecordWorkerHeartbeat(ctx context.Context, request *m.RecordWorkerHeartbeatRequest) error
+		ListTaskListDLQTasks(ctx context.Context, request *m.ListTaskListDLQTasksRequest) (*workflow.ListTaskListDLQTasksResponse, error)
+		RequeueTaskListDLQTasks(ctx context.Context, request *m.RequeueTaskListDLQTasksRequest) (*workflow.RequeueTaskListDLQTasksResponse, error)
+		DescribeTaskListLatency(ctx context.Context, request *m.DescribeTaskListLatencyRequest) (*workflow.DescribeTaskListLatencyResponse, error)
+		ForceUnloadTaskList(ctx context.Context, request *m.ForceUnloadTaskListRequest) (*workflow.ForceUnloadTaskListResponse, error)
 	}
 )
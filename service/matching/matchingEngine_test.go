@@ -132,6 +132,9 @@ func newMatchingEngine(
 		tokenSerializer: common.NewJSONTaskTokenSerializer(),
 		config:          config,
 		domainCache:     domainCache,
+
+		latencyTagLimiter: newTaskListTagLimiter(config.MaxTaskListLatencyMetricTags),
+		movedTaskLists:    make(map[string]time.Time),
 	}
 }
 
@@ -191,6 +194,38 @@ func (s *matchingEngineSuite) TestAckManager() {
 	s.EqualValues(t5, m.getReadLevel())
 }
 
+func (s *matchingEngineSuite) TestPriorityAckManager() {
+	m := newPriorityAckManager(s.logger)
+	m.setAckLevel(100)
+	s.EqualValues(100, m.getAckLevel())
+
+	m.addTask(0, 200)
+	m.addTask(2, 220)
+	m.addTask(0, 240)
+	s.EqualValues(100, m.getAckLevel())
+	s.EqualValues(240, m.getReadLevel(0))
+	s.EqualValues(220, m.getReadLevel(2))
+	s.EqualValues(3, m.getBacklogCountHint())
+
+	// the levels without tasks are read up to the max read level
+	m.setReadLevel(1, 240)
+	m.setReadLevel(3, 240)
+	s.EqualValues(100, m.getAckLevel())
+
+	m.completeTask(2, 220)
+	s.EqualValues(100, m.getAckLevel())
+
+	m.completeTask(0, 200)
+	s.EqualValues(200, m.getAckLevel())
+
+	// a level without outstanding tasks does not hold the ack level back below its read level
+	m.completeTask(0, 240)
+	s.EqualValues(220, m.getAckLevel())
+	m.setReadLevel(2, 240)
+	s.EqualValues(240, m.getAckLevel())
+	s.EqualValues(0, m.getBacklogCountHint())
+}
+
 func (s *matchingEngineSuite) TestPollForActivityTasksEmptyResult() {
 	s.PollForTasksEmptyResultTest(persistence.TaskListTypeActivity)
 }
@@ -254,6 +289,140 @@ func (s *matchingEngineSuite) PollForTasksEmptyResultTest(taskType int) {
 	s.EqualValues(1, s.taskManager.taskLists[*tlID].rangeID)
 }
 
+func (s *matchingEngineSuite) TestDescribeTaskListAfterReload() {
+	s.matchingEngine.config.LongPollExpirationInterval = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(10 * time.Millisecond)
+
+	domainID := "domainId"
+	tl := "makeToast"
+	identity := "selfDrivingToaster"
+	taskList := &workflow.TaskList{Name: &tl}
+	taskListType := workflow.TaskListTypeActivity
+	tlID := newTaskListID(domainID, tl, persistence.TaskListTypeActivity)
+
+	_, err := s.matchingEngine.PollForActivityTask(s.callContext, &matching.PollForActivityTaskRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PollRequest: &workflow.PollForActivityTaskRequest{
+			TaskList: taskList,
+			Identity: &identity,
+		},
+	})
+	s.NoError(err)
+
+	// persist the poller history and move the task list away from the in-memory state
+	tlMgr, err := s.matchingEngine.getTaskListManager(tlID, common.TaskListKindPtr(workflow.TaskListKindNormal))
+	s.NoError(err)
+	s.NoError(tlMgr.(*taskListManagerImpl).persistAckLevel())
+	s.matchingEngine.unloadTaskList(tlID)
+
+	descResp, err := s.matchingEngine.DescribeTaskList(s.callContext, &matching.DescribeTaskListRequest{
+		DomainUUID: common.StringPtr(domainID),
+		DescRequest: &workflow.DescribeTaskListRequest{
+			TaskList:     taskList,
+			TaskListType: &taskListType,
+		},
+	})
+	s.NoError(err)
+	s.Equal(1, len(descResp.Pollers))
+	s.Equal(identity, descResp.Pollers[0].GetIdentity())
+	s.NotEmpty(descResp.Pollers[0].GetLastAccessTime())
+}
+
+func (s *matchingEngineSuite) TestRecordWorkerHeartbeat() {
+	s.matchingEngine.config.LongPollExpirationInterval = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(10 * time.Millisecond)
+
+	domainID := "domainId"
+	tl := "makeToast"
+	pollingWorker := "selfDrivingToaster"
+	wedgedWorker := "wedgedToaster"
+	taskList := &workflow.TaskList{Name: &tl}
+	taskListType := workflow.TaskListTypeActivity
+
+	_, err := s.matchingEngine.PollForActivityTask(s.callContext, &matching.PollForActivityTaskRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PollRequest: &workflow.PollForActivityTaskRequest{
+			TaskList: taskList,
+			Identity: &pollingWorker,
+		},
+	})
+	s.NoError(err)
+
+	for _, identity := range []string{pollingWorker, wedgedWorker} {
+		err = s.matchingEngine.RecordWorkerHeartbeat(s.callContext, &matching.RecordWorkerHeartbeatRequest{
+			DomainUUID: common.StringPtr(domainID),
+			HeartbeatRequest: &workflow.RecordWorkerHeartbeatRequest{
+				TaskList:     taskList,
+				TaskListType: &taskListType,
+				Identity:     common.StringPtr(identity),
+				Capabilities: []string{"activity_type1"},
+				CurrentLoad:  common.Int32Ptr(3),
+			},
+		})
+		s.NoError(err)
+	}
+
+	descResp, err := s.matchingEngine.DescribeTaskList(s.callContext, &matching.DescribeTaskListRequest{
+		DomainUUID: common.StringPtr(domainID),
+		DescRequest: &workflow.DescribeTaskListRequest{
+			TaskList:     taskList,
+			TaskListType: &taskListType,
+		},
+	})
+	s.NoError(err)
+	s.Equal(2, len(descResp.Workers))
+	for _, worker := range descResp.Workers {
+		s.Equal([]string{"activity_type1"}, worker.Capabilities)
+		s.Equal(int32(3), worker.GetCurrentLoad())
+		s.NotEmpty(worker.GetLastHeartbeatTime())
+		if worker.GetIdentity() == pollingWorker {
+			s.NotNil(worker.LastPollTime)
+		} else {
+			s.Equal(wedgedWorker, worker.GetIdentity())
+			s.Nil(worker.LastPollTime)
+		}
+	}
+}
+
+func (s *matchingEngineSuite) TestGetTaskListsByDomain() {
+	s.matchingEngine.config.LongPollExpirationInterval = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(10 * time.Millisecond)
+
+	domainID := "domainId"
+	tl := "makeToast"
+	identity := "selfDrivingToaster"
+	taskList := &workflow.TaskList{Name: &tl}
+	tlID := newTaskListID(domainID, tl, persistence.TaskListTypeActivity)
+
+	_, err := s.matchingEngine.PollForActivityTask(s.callContext, &matching.PollForActivityTaskRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PollRequest: &workflow.PollForActivityTaskRequest{
+			TaskList: taskList,
+			Identity: &identity,
+		},
+	})
+	s.NoError(err)
+	tlMgr, err := s.matchingEngine.getTaskListManager(tlID, common.TaskListKindPtr(workflow.TaskListKindNormal))
+	s.NoError(err)
+	s.NoError(tlMgr.(*taskListManagerImpl).persistAckLevel())
+
+	listResp, err := s.matchingEngine.GetTaskListsByDomain(s.callContext, &matching.GetTaskListsByDomainRequest{
+		DomainUUID:  common.StringPtr(domainID),
+		ListRequest: &workflow.GetTaskListsByDomainRequest{PageSize: common.Int32Ptr(10)},
+	})
+	s.NoError(err)
+	s.Equal(1, len(listResp.TaskLists))
+	s.Equal(tl, listResp.TaskLists[0].TaskList.GetName())
+	s.Equal(workflow.TaskListTypeActivity, listResp.TaskLists[0].GetTaskListType())
+	s.Equal(1, len(listResp.TaskLists[0].Pollers))
+	s.Equal(identity, listResp.TaskLists[0].Pollers[0].GetIdentity())
+	s.Nil(listResp.NextPageToken)
+
+	listResp, err = s.matchingEngine.GetTaskListsByDomain(s.callContext, &matching.GetTaskListsByDomainRequest{
+		DomainUUID:  common.StringPtr("otherDomainId"),
+		ListRequest: &workflow.GetTaskListsByDomainRequest{PageSize: common.Int32Ptr(10)},
+	})
+	s.NoError(err)
+	s.Equal(0, len(listResp.TaskLists))
+}
+
 func (s *matchingEngineSuite) TestAddActivityTasks() {
 	s.AddTasksTest(persistence.TaskListTypeActivity)
 }
@@ -290,7 +459,7 @@ func (s *matchingEngineSuite) AddTasksTest(taskType int) {
 				ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
 			}
 
-			err = s.matchingEngine.AddActivityTask(&addRequest)
+			err = s.matchingEngine.AddActivityTask(context.Background(), &addRequest)
 		} else {
 			addRequest := matching.AddDecisionTaskRequest{
 				DomainUUID:                    common.StringPtr(domainID),
@@ -300,7 +469,7 @@ func (s *matchingEngineSuite) AddTasksTest(taskType int) {
 				ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
 			}
 
-			err = s.matchingEngine.AddDecisionTask(&addRequest)
+			err = s.matchingEngine.AddDecisionTask(context.Background(), &addRequest)
 		}
 		s.NoError(err)
 	}
@@ -349,12 +518,12 @@ func (s *matchingEngineSuite) TestTaskWriterShutdown() {
 	// now attempt to add a task
 	scheduleID := int64(5)
 	addRequest.ScheduleId = &scheduleID
-	err = s.matchingEngine.AddActivityTask(&addRequest)
+	err = s.matchingEngine.AddActivityTask(context.Background(), &addRequest)
 	s.Error(err)
 
 	// test race
 	tlmImpl.taskWriter.stopped = 0
-	err = s.matchingEngine.AddActivityTask(&addRequest)
+	err = s.matchingEngine.AddActivityTask(context.Background(), &addRequest)
 	s.Error(err)
 	tlmImpl.taskWriter.stopped = 1 // reset it back to old value
 }
@@ -391,7 +560,7 @@ func (s *matchingEngineSuite) TestAddThenConsumeActivities() {
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
 		}
 
-		err := s.matchingEngine.AddActivityTask(&addRequest)
+		err := s.matchingEngine.AddActivityTask(context.Background(), &addRequest)
 		s.NoError(err)
 	}
 	s.EqualValues(taskCount, s.taskManager.getTaskCount(tlID))
@@ -450,6 +619,8 @@ func (s *matchingEngineSuite) TestAddThenConsumeActivities() {
 		s.Equal(true, validateTimeRange(time.Unix(0, *result.StartedTimestamp), time.Minute))
 		s.Equal(int32(50), *result.StartToCloseTimeoutSeconds)
 		s.Equal(int32(10), *result.HeartbeatTimeoutSeconds)
+		s.Equal(*result.ScheduledTimestamp+int64(100*time.Second), *result.ScheduleToCloseDeadline)
+		s.Equal(*result.StartedTimestamp+int64(50*time.Second), *result.StartToCloseDeadline)
 		token := &common.TaskToken{
 			DomainID:   domainID,
 			WorkflowID: workflowID,
@@ -470,6 +641,49 @@ func (s *matchingEngineSuite) TestAddThenConsumeActivities() {
 	s.True(expectedRange <= s.taskManager.getTaskListManager(tlID).rangeID)
 }
 
+func (s *matchingEngineSuite) TestCreatePollForActivityTaskResponse_Deadlines() {
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("workflowID"),
+		RunId:      common.StringPtr("runID"),
+	}
+	scheduledEvent := newActivityTaskScheduledEvent(5, 0, &workflow.ScheduleActivityTaskDecisionAttributes{
+		ActivityId:                    common.StringPtr("activityID"),
+		ActivityType:                  &workflow.ActivityType{Name: common.StringPtr("activityType")},
+		ScheduleToCloseTimeoutSeconds: common.Int32Ptr(100),
+		ScheduleToStartTimeoutSeconds: common.Int32Ptr(50),
+		StartToCloseTimeoutSeconds:    common.Int32Ptr(50),
+		HeartbeatTimeoutSeconds:       common.Int32Ptr(10),
+	})
+	scheduledEvent.Timestamp = common.Int64Ptr(int64(1000 * time.Second))
+	tCtx := &taskContext{
+		info: &persistence.TaskInfo{
+			DomainID:   "domainID",
+			WorkflowID: "workflowID",
+			RunID:      "runID",
+			ScheduleID: 5,
+		},
+		workflowExecution: workflowExecution,
+	}
+
+	// the schedule to close timeout runs from the schedule of the current attempt
+	result := s.matchingEngine.createPollForActivityTaskResponse(tCtx, &gohistory.RecordActivityTaskStartedResponse{
+		ScheduledEvent:                  scheduledEvent,
+		StartedTimestamp:                common.Int64Ptr(int64(1300 * time.Second)),
+		Attempt:                         common.Int64Ptr(1),
+		ScheduledTimestampOfThisAttempt: common.Int64Ptr(int64(1200 * time.Second)),
+	})
+	s.Equal(int64(1300*time.Second), result.GetScheduleToCloseDeadline())
+	s.Equal(int64(1350*time.Second), result.GetStartToCloseDeadline())
+
+	// and from the scheduled event when history does not return the schedule of the attempt
+	result = s.matchingEngine.createPollForActivityTaskResponse(tCtx, &gohistory.RecordActivityTaskStartedResponse{
+		ScheduledEvent:   scheduledEvent,
+		StartedTimestamp: common.Int64Ptr(int64(1010 * time.Second)),
+	})
+	s.Equal(int64(1100*time.Second), result.GetScheduleToCloseDeadline())
+	s.Equal(int64(1060*time.Second), result.GetStartToCloseDeadline())
+}
+
 func (s *matchingEngineSuite) TestSyncMatchActivities() {
 	// Set a short long poll expiration so we don't have to wait too long for 0 throttling cases
 	s.matchingEngine.config.LongPollExpirationInterval = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(50 * time.Millisecond)
@@ -565,7 +779,7 @@ func (s *matchingEngineSuite) TestSyncMatchActivities() {
 			TaskList:                      taskList,
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
 		}
-		err := s.matchingEngine.AddActivityTask(&addRequest)
+		err := s.matchingEngine.AddActivityTask(context.Background(), &addRequest)
 		wg.Wait()
 		s.NoError(err)
 		s.NoError(pollErr)
@@ -679,7 +893,7 @@ func (s *matchingEngineSuite) concurrentPublishConsumeActivities(
 					ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
 				}
 
-				err := s.matchingEngine.AddActivityTask(&addRequest)
+				err := s.matchingEngine.AddActivityTask(context.Background(), &addRequest)
 				if err != nil {
 					s.logger.Infof("Failure in AddActivityTask: %v", err)
 					i--
@@ -806,7 +1020,7 @@ func (s *matchingEngineSuite) TestConcurrentPublishConsumeDecisions() {
 					ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
 				}
 
-				err := s.matchingEngine.AddDecisionTask(&addRequest)
+				err := s.matchingEngine.AddDecisionTask(context.Background(), &addRequest)
 				if err != nil {
 					panic(err)
 				}
@@ -960,7 +1174,7 @@ func (s *matchingEngineSuite) TestMultipleEnginesActivitiesRangeStealing() {
 					ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
 				}
 
-				err := engine.AddActivityTask(&addRequest)
+				err := engine.AddActivityTask(context.Background(), &addRequest)
 				if err != nil {
 					if _, ok := err.(*persistence.ConditionFailedError); ok {
 						i-- // retry adding
@@ -1112,7 +1326,7 @@ func (s *matchingEngineSuite) TestMultipleEnginesDecisionsRangeStealing() {
 					ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
 				}
 
-				err := engine.AddDecisionTask(&addRequest)
+				err := engine.AddDecisionTask(context.Background(), &addRequest)
 				if err != nil {
 					if _, ok := err.(*persistence.ConditionFailedError); ok {
 						i-- // retry adding
@@ -1237,7 +1451,7 @@ func (s *matchingEngineSuite) TestAddTaskAfterStartFailure() {
 		ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
 	}
 
-	err := s.matchingEngine.AddActivityTask(&addRequest)
+	err := s.matchingEngine.AddActivityTask(context.Background(), &addRequest)
 	s.NoError(err)
 	s.EqualValues(1, s.taskManager.getTaskCount(tlID))
 
@@ -1258,6 +1472,236 @@ func (s *matchingEngineSuite) TestAddTaskAfterStartFailure() {
 	s.EqualValues(0, s.taskManager.getTaskCount(tlID))
 }
 
+func (s *matchingEngineSuite) TestDescribeTaskListLatency() {
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	const taskCount = 3
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeActivity}
+
+	taskList := &workflow.TaskList{}
+	taskList.Name = &tl
+	describeRequest := &matching.DescribeTaskListLatencyRequest{
+		DomainUUID: common.StringPtr(domainID),
+		DescribeRequest: &workflow.DescribeTaskListLatencyRequest{
+			TaskList:     taskList,
+			TaskListType: common.TaskListTypePtr(workflow.TaskListTypeActivity),
+		},
+	}
+
+	resp, err := s.matchingEngine.DescribeTaskListLatency(context.Background(), describeRequest)
+	s.NoError(err)
+	s.Equal(int32(0), resp.GetSampleCount())
+
+	// the latency starts when history scheduled the tasks, not when matching received them
+	scheduledTime := time.Now().Add(-time.Minute)
+	for i := int64(0); i < taskCount; i++ {
+		scheduleID := i * 3
+		addRequest := matching.AddActivityTaskRequest{
+			SourceDomainUUID:              common.StringPtr(domainID),
+			DomainUUID:                    common.StringPtr(domainID),
+			Execution:                     &workflowExecution,
+			ScheduleId:                    &scheduleID,
+			TaskList:                      taskList,
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(100),
+			ScheduledTimestamp:            common.Int64Ptr(scheduledTime.UnixNano()),
+		}
+		err := s.matchingEngine.AddActivityTask(context.Background(), &addRequest)
+		s.NoError(err)
+	}
+	s.EqualValues(taskCount, s.taskManager.getTaskCount(tlID))
+
+	s.historyClient.On("RecordActivityTaskStarted", nil,
+		mock.AnythingOfType("*history.RecordActivityTaskStartedRequest")).Return(
+		func(ctx context.Context, taskRequest *gohistory.RecordActivityTaskStartedRequest) *gohistory.RecordActivityTaskStartedResponse {
+			return &gohistory.RecordActivityTaskStartedResponse{
+				ScheduledEvent: newActivityTaskScheduledEvent(*taskRequest.ScheduleId, 0,
+					&workflow.ScheduleActivityTaskDecisionAttributes{
+						ActivityId:   common.StringPtr("activityId1"),
+						TaskList:     &workflow.TaskList{Name: taskList.Name},
+						ActivityType: &workflow.ActivityType{Name: common.StringPtr("activity1")},
+					}),
+				StartedTimestamp: common.Int64Ptr(time.Now().UnixNano()),
+			}
+		}, nil)
+
+	for i := 0; i < taskCount; {
+		result, err := s.matchingEngine.PollForActivityTask(s.callContext, &matching.PollForActivityTaskRequest{
+			DomainUUID: common.StringPtr(domainID),
+			PollRequest: &workflow.PollForActivityTaskRequest{
+				TaskList: taskList,
+				Identity: common.StringPtr("nobody")},
+		})
+		s.NoError(err)
+		if len(result.TaskToken) == 0 {
+			continue
+		}
+		i++
+	}
+
+	resp, err = s.matchingEngine.DescribeTaskListLatency(context.Background(), describeRequest)
+	s.NoError(err)
+	s.Equal(int32(taskCount), resp.GetSampleCount())
+	s.True(resp.GetScheduleToStartP50Millis() >= int64(time.Minute/time.Millisecond))
+	s.True(resp.GetScheduleToStartP50Millis() <= resp.GetScheduleToStartP99Millis())
+	s.True(resp.GetScheduleToStartP99Millis() <= resp.GetScheduleToStartMaxMillis())
+}
+
+func (s *matchingEngineSuite) TestForceUnloadTaskList() {
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := newTaskListID(domainID, tl, persistence.TaskListTypeActivity)
+	unloadRequest := &matching.ForceUnloadTaskListRequest{
+		DomainUUID: common.StringPtr(domainID),
+		UnloadRequest: &workflow.ForceUnloadTaskListRequest{
+			TaskList:     &workflow.TaskList{Name: common.StringPtr(tl)},
+			TaskListType: common.TaskListTypePtr(workflow.TaskListTypeActivity),
+		},
+	}
+
+	tlMgr, err := s.matchingEngine.getTaskListManager(tlID, common.TaskListKindPtr(workflow.TaskListKindNormal))
+	s.NoError(err)
+	rangeID := tlMgr.(*taskListManagerImpl).getRangeID()
+
+	// the other type of the tasklist moves together with it
+	decisionTlID := newTaskListID(domainID, tl, persistence.TaskListTypeDecision)
+	_, err = s.matchingEngine.getTaskListManager(decisionTlID, common.TaskListKindPtr(workflow.TaskListKindNormal))
+	s.NoError(err)
+
+	resp, err := s.matchingEngine.ForceUnloadTaskList(context.Background(), unloadRequest)
+	s.NoError(err)
+	s.True(resp.GetUnloaded())
+	s.Equal(0, len(s.matchingEngine.getTaskLists(10)))
+
+	// nothing is left to unload on this host
+	resp, err = s.matchingEngine.ForceUnloadTaskList(context.Background(), unloadRequest)
+	s.NoError(err)
+	s.False(resp.GetUnloaded())
+
+	// this host does not load the tasklist again while it is moved
+	_, err = s.matchingEngine.getTaskListManager(tlID, common.TaskListKindPtr(workflow.TaskListKindNormal))
+	s.Equal(errTaskListMoved, err)
+	_, err = s.matchingEngine.getTaskListManager(decisionTlID, common.TaskListKindPtr(workflow.TaskListKindNormal))
+	s.Equal(errTaskListMoved, err)
+
+	// the host the clients route the tasklist to takes the lease over
+	otherEngine := s.newMatchingEngine(defaultTestConfig(), s.taskManager)
+	defer otherEngine.Stop()
+	tlMgr, err = otherEngine.getTaskListManager(tlID, common.TaskListKindPtr(workflow.TaskListKindNormal))
+	s.NoError(err)
+	s.True(tlMgr.(*taskListManagerImpl).getRangeID() > rangeID)
+
+	// the move expires after MovedTaskListTTL
+	s.matchingEngine.movedTaskLists[tl] = time.Now().Add(-time.Second)
+	_, err = s.matchingEngine.getTaskListManager(tlID, common.TaskListKindPtr(workflow.TaskListKindNormal))
+	s.NoError(err)
+	s.Equal(0, len(s.matchingEngine.movedTaskLists))
+}
+
+func (s *matchingEngineSuite) TestForceUnloadTaskList_MoveDisabled() {
+	s.matchingEngine.config.MovedTaskListTTL = dynamicconfig.GetDurationPropertyFn(0)
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := newTaskListID(domainID, tl, persistence.TaskListTypeActivity)
+
+	_, err := s.matchingEngine.getTaskListManager(tlID, common.TaskListKindPtr(workflow.TaskListKindNormal))
+	s.NoError(err)
+	resp, err := s.matchingEngine.ForceUnloadTaskList(context.Background(), &matching.ForceUnloadTaskListRequest{
+		DomainUUID: common.StringPtr(domainID),
+		UnloadRequest: &workflow.ForceUnloadTaskListRequest{
+			TaskList:     &workflow.TaskList{Name: common.StringPtr(tl)},
+			TaskListType: common.TaskListTypePtr(workflow.TaskListTypeActivity),
+		},
+	})
+	s.NoError(err)
+	s.True(resp.GetUnloaded())
+
+	// the tasklist is loaded again on this host by the next request
+	_, err = s.matchingEngine.getTaskListManager(tlID, common.TaskListKindPtr(workflow.TaskListKindNormal))
+	s.NoError(err)
+	s.Equal(0, len(s.matchingEngine.movedTaskLists))
+}
+
+func (s *matchingEngineSuite) TestTaskMovedToDLQAfterMaxDispatchAttempts() {
+	s.matchingEngine.config.MaxTaskDispatchAttempts = dynamicconfig.GetIntPropertyFilteredByTaskListInfo(2)
+
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeActivity}
+	tlKind := common.TaskListKindPtr(workflow.TaskListKindNormal)
+
+	taskList := &workflow.TaskList{}
+	taskList.Name = &tl
+
+	scheduleID := int64(5)
+	addRequest := matching.AddActivityTaskRequest{
+		SourceDomainUUID:              common.StringPtr(domainID),
+		DomainUUID:                    common.StringPtr(domainID),
+		Execution:                     &workflowExecution,
+		ScheduleId:                    &scheduleID,
+		TaskList:                      taskList,
+		ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
+	}
+
+	err := s.matchingEngine.AddActivityTask(context.Background(), &addRequest)
+	s.NoError(err)
+
+	ctx, err := s.matchingEngine.getTask(context.Background(), tlID, nil, tlKind)
+	s.NoError(err)
+	ctx.completeTask(errors.New("test error"))
+	s.EqualValues(1, s.taskManager.getTaskCount(tlID))
+	s.EqualValues(0, s.taskManager.getDLQTaskCount(tlID))
+
+	// second failed dispatch exhausts the budget and parks the task in the DLQ
+	ctx, err = s.matchingEngine.getTask(context.Background(), tlID, nil, tlKind)
+	s.NoError(err)
+	s.EqualValues(1, ctx.info.DispatchAttempts)
+	ctx.completeTask(errors.New("test error"))
+	s.EqualValues(0, s.taskManager.getTaskCount(tlID))
+	s.EqualValues(1, s.taskManager.getDLQTaskCount(tlID))
+
+	listResp, err := s.matchingEngine.ListTaskListDLQTasks(context.Background(), &matching.ListTaskListDLQTasksRequest{
+		DomainUUID: common.StringPtr(domainID),
+		ListRequest: &workflow.ListTaskListDLQTasksRequest{
+			Domain:       common.StringPtr("domainName"),
+			TaskList:     taskList,
+			TaskListType: common.TaskListTypePtr(workflow.TaskListTypeActivity),
+		},
+	})
+	s.NoError(err)
+	s.Equal(1, len(listResp.Tasks))
+	s.Equal(workflowID, listResp.Tasks[0].WorkflowExecution.GetWorkflowId())
+	s.Equal(scheduleID, listResp.Tasks[0].GetScheduleId())
+	s.EqualValues(2, listResp.Tasks[0].GetDispatchAttempts())
+
+	requeueResp, err := s.matchingEngine.RequeueTaskListDLQTasks(context.Background(), &matching.RequeueTaskListDLQTasksRequest{
+		DomainUUID: common.StringPtr(domainID),
+		RequeueRequest: &workflow.RequeueTaskListDLQTasksRequest{
+			Domain:       common.StringPtr("domainName"),
+			TaskList:     taskList,
+			TaskListType: common.TaskListTypePtr(workflow.TaskListTypeActivity),
+		},
+	})
+	s.NoError(err)
+	s.EqualValues(1, requeueResp.GetRequeuedCount())
+	s.EqualValues(1, s.taskManager.getTaskCount(tlID))
+	s.EqualValues(0, s.taskManager.getDLQTaskCount(tlID))
+
+	ctx, err = s.matchingEngine.getTask(context.Background(), tlID, nil, tlKind)
+	s.NoError(err)
+	s.EqualValues(0, ctx.info.DispatchAttempts)
+	s.Equal(scheduleID, ctx.info.ScheduleID)
+	ctx.completeTask(nil)
+	s.EqualValues(0, s.taskManager.getTaskCount(tlID))
+}
+
 func (s *matchingEngineSuite) TestTaskListManagerGetTaskBatch() {
 	runID := "run1"
 	workflowID := "workflow1"
@@ -1286,7 +1730,7 @@ func (s *matchingEngineSuite) TestTaskListManagerGetTaskBatch() {
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
 		}
 
-		err := s.matchingEngine.AddActivityTask(&addRequest)
+		err := s.matchingEngine.AddActivityTask(context.Background(), &addRequest)
 		s.NoError(err)
 	}
 	tlMgr, ok := s.matchingEngine.taskLists[*tlID].(*taskListManagerImpl)
@@ -1302,14 +1746,14 @@ func (s *matchingEngineSuite) TestTaskListManagerGetTaskBatch() {
 
 	// setReadLevel should NEVER be called without updating ackManager.outstandingTasks
 	// This is only for unit test purpose
-	tlMgr.taskAckManager.setReadLevel(tlMgr.taskWriter.GetMaxReadLevel())
-	tasks, readLevel, err := tlMgr.getTaskBatch()
+	tlMgr.taskAckManager.setReadLevel(0, tlMgr.taskWriter.GetMaxReadLevel())
+	tasks, readLevel, err := tlMgr.getTaskBatch(0, tlMgr.taskAckManager.getReadLevel(0))
 	s.Nil(err)
 	s.EqualValues(0, len(tasks))
 	s.EqualValues(tlMgr.taskWriter.GetMaxReadLevel(), readLevel)
 
-	tlMgr.taskAckManager.setReadLevel(0)
-	tasks, readLevel, err = tlMgr.getTaskBatch()
+	tlMgr.taskAckManager.setReadLevel(0, 0)
+	tasks, readLevel, err = tlMgr.getTaskBatch(0, tlMgr.taskAckManager.getReadLevel(0))
 	s.Nil(err)
 	s.EqualValues(rangeSize, len(tasks))
 	s.EqualValues(rangeSize, readLevel)
@@ -1359,7 +1803,7 @@ func (s *matchingEngineSuite) TestTaskListManagerGetTaskBatch() {
 	}
 	s.EqualValues(taskCount-rangeSize, s.taskManager.getTaskCount(tlID))
 
-	tasks, readLevel, err = tlMgr.getTaskBatch()
+	tasks, readLevel, err = tlMgr.getTaskBatch(0, tlMgr.taskAckManager.getReadLevel(0))
 	s.Nil(err)
 	s.True(0 < len(tasks) && len(tasks) <= rangeSize)
 	s.EqualValues(rangeSize*2, readLevel)
@@ -1439,7 +1883,10 @@ type testTaskListManager struct {
 	rangeID         int64
 	ackLevel        int64
 	createTaskCount int
+	getTasksCount   [persistence.TaskPriorityLevels]int
 	tasks           *treemap.Map
+	dlqTasks        *treemap.Map
+	pollers         []*persistence.TaskListPollerInfo
 }
 
 func Int64Comparator(a, b interface{}) int {
@@ -1456,7 +1903,7 @@ func Int64Comparator(a, b interface{}) int {
 }
 
 func newTestTaskListManager() *testTaskListManager {
-	return &testTaskListManager{tasks: treemap.NewWith(Int64Comparator)}
+	return &testTaskListManager{tasks: treemap.NewWith(Int64Comparator), dlqTasks: treemap.NewWith(Int64Comparator)}
 }
 
 // LeaseTaskList provides a mock function with given fields: request
@@ -1475,6 +1922,7 @@ func (m *testTaskManager) LeaseTaskList(request *persistence.LeaseTaskListReques
 			TaskType: request.TaskType,
 			RangeID:  tlm.rangeID,
 			Kind:     request.TaskListKind,
+			Pollers:  tlm.pollers,
 		},
 	}, nil
 }
@@ -1494,9 +1942,33 @@ func (m *testTaskManager) UpdateTaskList(request *persistence.UpdateTaskListRequ
 		}
 	}
 	tlm.ackLevel = tli.AckLevel
+	tlm.pollers = tli.Pollers
 	return &persistence.UpdateTaskListResponse{}, nil
 }
 
+// ListTaskList returns all the task lists of the domain in a single page
+func (m *testTaskManager) ListTaskList(request *persistence.ListTaskListRequest) (*persistence.ListTaskListResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	response := &persistence.ListTaskListResponse{}
+	for id, tlm := range m.taskLists {
+		if id.domainID != request.DomainID {
+			continue
+		}
+		tlm.Lock()
+		response.Items = append(response.Items, &persistence.TaskListInfo{
+			DomainID: id.domainID,
+			Name:     id.taskListName,
+			TaskType: id.taskType,
+			RangeID:  tlm.rangeID,
+			AckLevel: tlm.ackLevel,
+			Pollers:  tlm.pollers,
+		})
+		tlm.Unlock()
+	}
+	return response, nil
+}
+
 // CompleteTask provides a mock function with given fields: request
 func (m *testTaskManager) CompleteTask(request *persistence.CompleteTaskRequest) error {
 	m.logger.Debugf("CompleteTask taskID=%v, ackLevel=%v", request.TaskID, request.TaskList.AckLevel)
@@ -1551,11 +2023,13 @@ func (m *testTaskManager) CreateTasks(request *persistence.CreateTasksRequest) (
 	for _, task := range request.Tasks {
 		scheduleID := task.Data.ScheduleID
 		tlm.tasks.Put(task.TaskID, &persistence.TaskInfo{
-			DomainID:   domainID,
-			RunID:      *task.Execution.RunId,
-			ScheduleID: scheduleID,
-			TaskID:     task.TaskID,
-			WorkflowID: *task.Execution.WorkflowId,
+			DomainID:         domainID,
+			RunID:            *task.Execution.RunId,
+			ScheduleID:       scheduleID,
+			TaskID:           task.TaskID,
+			WorkflowID:       *task.Execution.WorkflowId,
+			DispatchAttempts: task.Data.DispatchAttempts,
+			CreatedTime:      task.Data.CreatedTime,
 		})
 		tlm.createTaskCount++
 	}
@@ -1576,6 +2050,7 @@ func (m *testTaskManager) GetTasks(request *persistence.GetTasksRequest) (*persi
 				request.TaskList, request.TaskType, request.RangeID, tlm.rangeID),
 		}
 	}
+	tlm.getTasksCount[request.PriorityLevel]++
 	var tasks []*persistence.TaskInfo
 
 	it := tlm.tasks.Iterator()
@@ -1587,13 +2062,58 @@ func (m *testTaskManager) GetTasks(request *persistence.GetTasksRequest) (*persi
 		if taskID > request.MaxReadLevel {
 			break
 		}
-		tasks = append(tasks, it.Value().(*persistence.TaskInfo))
+		task := it.Value().(*persistence.TaskInfo)
+		if persistence.TaskPriorityLevel(task.Priority) != request.PriorityLevel {
+			continue
+		}
+		tasks = append(tasks, task)
 	}
 	return &persistence.GetTasksResponse{
 		Tasks: tasks,
 	}, nil
 }
 
+// CreateDLQTask moves the given task into the dead letter queue of the task list
+func (m *testTaskManager) CreateDLQTask(request *persistence.CreateDLQTaskRequest) error {
+	tli := request.TaskList
+	tlm := m.getTaskListManager(newTaskListID(tli.DomainID, tli.Name, tli.TaskType))
+	tlm.Lock()
+	defer tlm.Unlock()
+	task := *request.Task
+	tlm.dlqTasks.Put(task.TaskID, &task)
+	return nil
+}
+
+// GetDLQTasks returns all the dead letter queue tasks of the task list in a single page
+func (m *testTaskManager) GetDLQTasks(request *persistence.GetDLQTasksRequest) (*persistence.GetDLQTasksResponse, error) {
+	tlm := m.getTaskListManager(newTaskListID(request.DomainID, request.TaskList, request.TaskType))
+	tlm.Lock()
+	defer tlm.Unlock()
+	response := &persistence.GetDLQTasksResponse{}
+	for _, t := range tlm.dlqTasks.Values() {
+		response.Tasks = append(response.Tasks, t.(*persistence.TaskInfo))
+	}
+	return response, nil
+}
+
+// DeleteDLQTask removes a task from the dead letter queue of the task list
+func (m *testTaskManager) DeleteDLQTask(request *persistence.DeleteDLQTaskRequest) error {
+	tli := request.TaskList
+	tlm := m.getTaskListManager(newTaskListID(tli.DomainID, tli.Name, tli.TaskType))
+	tlm.Lock()
+	defer tlm.Unlock()
+	tlm.dlqTasks.Remove(request.TaskID)
+	return nil
+}
+
+// getDLQTaskCount returns number of tasks in the dead letter queue of a task list
+func (m *testTaskManager) getDLQTaskCount(taskList *taskListID) int {
+	tlm := m.getTaskListManager(taskList)
+	tlm.Lock()
+	defer tlm.Unlock()
+	return tlm.dlqTasks.Size()
+}
+
 // getTaskCount returns number of tasks in a task list
 func (m *testTaskManager) getTaskCount(taskList *taskListID) int {
 	tlm := m.getTaskListManager(taskList)
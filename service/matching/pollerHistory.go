@@ -21,15 +21,19 @@
 package matching
 
 import (
+	"sort"
 	"time"
 
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
 )
 
 const (
 	pollerHistoryInitSize    = 0
 	pollerHistoryInitMaxSize = 1000
 	pollerHistoryTTL         = 5 * time.Minute
+	// number of most recent pollers kept in the task list record
+	pollerHistoryMaxPersisted = 10
 )
 
 type (
@@ -41,13 +45,16 @@ type (
 	pollerInfo struct {
 		identity string
 		// TODO add IP, T1396795
+		version        string
 		lastAccessTime time.Time
 	}
+
+	pollerInfosByLastAccessTime []*pollerInfo
 )
 
 type pollerHistory struct {
-	// poller ID -> last access time
-	// pollers map[pollerID]time.Time
+	// poller ID -> poller info
+	// pollers map[pollerID]*pollerInfo
 	history cache.Cache
 }
 
@@ -63,25 +70,79 @@ func newPollerHistory() *pollerHistory {
 	}
 }
 
-func (pollers *pollerHistory) updatePollerInfo(id pollerIdentity) {
-	pollers.history.Put(id, nil)
+func (pollers *pollerHistory) updatePollerInfo(id pollerIdentity, version string) {
+	pollers.history.Put(id, &pollerInfo{
+		identity:       id.identity,
+		version:        version,
+		lastAccessTime: time.Now(),
+	})
+}
+
+// restorePollerInfo seeds the history with pollers persisted by a previous owner of the task list.
+// Pollers already seen by this history win, and persisted entries older than the TTL are dropped.
+func (pollers *pollerHistory) restorePollerInfo(persisted []*persistence.TaskListPollerInfo) {
+	now := time.Now()
+	for _, p := range persisted {
+		if now.Sub(p.LastAccessTime) >= pollerHistoryTTL {
+			continue
+		}
+		pollers.history.PutIfNotExist(pollerIdentity{identity: p.Identity}, &pollerInfo{
+			identity:       p.Identity,
+			version:        p.Version,
+			lastAccessTime: p.LastAccessTime,
+		})
+	}
 }
 
 func (pollers *pollerHistory) getAllPollerInfo() []*pollerInfo {
 	result := []*pollerInfo{}
 
+	now := time.Now()
 	ite := pollers.history.Iterator()
 	defer ite.Close()
 	for ite.HasNext() {
 		entry := ite.Next()
-		key := entry.Key().(pollerIdentity)
-		lastAccessTime := entry.CreateTime()
-		result = append(result, &pollerInfo{
-			identity: key.identity,
-			// TODO add IP, T1396795
-			lastAccessTime: lastAccessTime,
-		})
+		info := entry.Value().(*pollerInfo)
+		// restored entries expire from the cache relative to the restore, not to their last access
+		if now.Sub(info.lastAccessTime) >= pollerHistoryTTL {
+			continue
+		}
+		result = append(result, info)
 	}
 
 	return result
 }
+
+// getPersistablePollerInfo returns the most recently seen pollers to be stored with the task list
+func (pollers *pollerHistory) getPersistablePollerInfo() []*persistence.TaskListPollerInfo {
+	infos := pollers.getAllPollerInfo()
+	sort.Sort(pollerInfosByLastAccessTime(infos))
+	if len(infos) > pollerHistoryMaxPersisted {
+		infos = infos[:pollerHistoryMaxPersisted]
+	}
+
+	result := make([]*persistence.TaskListPollerInfo, 0, len(infos))
+	for _, info := range infos {
+		result = append(result, &persistence.TaskListPollerInfo{
+			Identity:       info.identity,
+			Version:        info.version,
+			LastAccessTime: info.lastAccessTime,
+		})
+	}
+	return result
+}
+
+// Len implements sort.Interface, most recently seen pollers first
+func (p pollerInfosByLastAccessTime) Len() int {
+	return len(p)
+}
+
+// Swap implements sort.Interface
+func (p pollerInfosByLastAccessTime) Swap(i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// Less implements sort.Interface
+func (p pollerInfosByLastAccessTime) Less(i, j int) bool {
+	return p[i].lastAccessTime.After(p[j].lastAccessTime)
+}
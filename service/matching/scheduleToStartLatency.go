@@ -0,0 +1,163 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	s "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+const (
+	scheduleToStartSampleSize = 1000
+	scheduleToStartWindow     = 5 * time.Minute
+)
+
+type (
+	latencySample struct {
+		latency      time.Duration
+		dispatchTime time.Time
+	}
+
+	// scheduleToStartLatencies keeps the schedule-to-start latencies of the most recent dispatches of a tasklist,
+	// so that their percentiles can be reported without going through the metrics backend.
+	scheduleToStartLatencies struct {
+		sync.Mutex
+		samples []latencySample
+		next    int
+	}
+
+	latencyPercentiles struct {
+		count int
+		p50   time.Duration
+		p90   time.Duration
+		p99   time.Duration
+		max   time.Duration
+	}
+
+	// taskListTagLimiter bounds the number of tasklists which are tagged on the schedule-to-start latency metric
+	// of a host. The tasklists loaded past the limit share a single tag value, which keeps the cardinality of the
+	// metric under control for domains creating a tasklist per workflow.
+	taskListTagLimiter struct {
+		sync.Mutex
+		maxTaskLists dynamicconfig.IntPropertyFn
+		taskLists    map[string]struct{}
+	}
+)
+
+func newScheduleToStartLatencies() *scheduleToStartLatencies {
+	return &scheduleToStartLatencies{
+		samples: make([]latencySample, 0, scheduleToStartSampleSize),
+	}
+}
+
+func (l *scheduleToStartLatencies) record(latency time.Duration, now time.Time) {
+	l.Lock()
+	defer l.Unlock()
+
+	sample := latencySample{latency: latency, dispatchTime: now}
+	if len(l.samples) < scheduleToStartSampleSize {
+		l.samples = append(l.samples, sample)
+		return
+	}
+	l.samples[l.next] = sample
+	l.next = (l.next + 1) % scheduleToStartSampleSize
+}
+
+// percentiles computes the percentiles of the latencies of the tasks dispatched within the window
+func (l *scheduleToStartLatencies) percentiles(now time.Time) latencyPercentiles {
+	l.Lock()
+	latencies := make([]time.Duration, 0, len(l.samples))
+	for _, sample := range l.samples {
+		if now.Sub(sample.dispatchTime) <= scheduleToStartWindow {
+			latencies = append(latencies, sample.latency)
+		}
+	}
+	l.Unlock()
+
+	result := latencyPercentiles{count: len(latencies)}
+	if len(latencies) == 0 {
+		return result
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	result.p50 = percentile(latencies, 50)
+	result.p90 = percentile(latencies, 90)
+	result.p99 = percentile(latencies, 99)
+	result.max = latencies[len(latencies)-1]
+	return result
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func newTaskListTagLimiter(maxTaskLists dynamicconfig.IntPropertyFn) *taskListTagLimiter {
+	return &taskListTagLimiter{
+		maxTaskLists: maxTaskLists,
+		taskLists:    make(map[string]struct{}),
+	}
+}
+
+// tagValue returns the value a tasklist is tagged with. Tasklists keep their tag once given one, a tasklist
+// reloaded after being unloaded does not take a new slot.
+func (l *taskListTagLimiter) tagValue(domainName string, taskListName string) string {
+	key := domainName + "/" + taskListName
+	l.Lock()
+	defer l.Unlock()
+
+	if _, ok := l.taskLists[key]; ok {
+		return taskListName
+	}
+	if len(l.taskLists) >= l.maxTaskLists() {
+		return metrics.OtherTaskListsTagValue
+	}
+	l.taskLists[key] = struct{}{}
+	return taskListName
+}
+
+// newLatencyMetricsClient returns the client a tasklist emits its schedule-to-start latency with. Sticky tasklists
+// are never tagged, there is one per worker.
+func newLatencyMetricsClient(e *matchingEngineImpl, taskList *taskListID, taskListKind *s.TaskListKind,
+	domainName string) metrics.Client {
+	taskListTag := metrics.OtherTaskListsTagValue
+	if taskListKind == nil || *taskListKind != s.TaskListKindSticky {
+		taskListTag = e.latencyTagLimiter.tagValue(domainName, taskList.taskListName)
+	}
+	taskListTypeTag := "decision"
+	if taskList.taskType == persistence.TaskListTypeActivity {
+		taskListTypeTag = "activity"
+	}
+	return e.metricsClient.Tagged(map[string]string{
+		metrics.DomainTagName:       domainName,
+		metrics.TaskListTagName:     taskListTag,
+		metrics.TaskListTypeTagName: taskListTypeTag,
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+func TestScheduleToStartLatencies_Percentiles(t *testing.T) {
+	now := time.Now()
+	l := newScheduleToStartLatencies()
+	require.Equal(t, latencyPercentiles{}, l.percentiles(now))
+
+	for i := 1; i <= 100; i++ {
+		l.record(time.Duration(i)*time.Millisecond, now)
+	}
+	p := l.percentiles(now)
+	require.Equal(t, 100, p.count)
+	require.Equal(t, 50*time.Millisecond, p.p50)
+	require.Equal(t, 90*time.Millisecond, p.p90)
+	require.Equal(t, 99*time.Millisecond, p.p99)
+	require.Equal(t, 100*time.Millisecond, p.max)
+}
+
+func TestScheduleToStartLatencies_KeepsRecentDispatches(t *testing.T) {
+	now := time.Now()
+	l := newScheduleToStartLatencies()
+	l.record(time.Hour, now.Add(-2*scheduleToStartWindow))
+	for i := 0; i < scheduleToStartSampleSize; i++ {
+		l.record(time.Minute, now.Add(-time.Second))
+	}
+	l.record(time.Second, now)
+
+	// the samples past the size of the buffer overwrite the oldest ones
+	p := l.percentiles(now)
+	require.Equal(t, scheduleToStartSampleSize, p.count)
+	require.Equal(t, time.Minute, p.max)
+	require.Equal(t, time.Minute, p.p50)
+
+	p = l.percentiles(now.Add(scheduleToStartWindow))
+	require.Equal(t, 1, p.count)
+	require.Equal(t, time.Second, p.p99)
+}
+
+func TestTaskListTagLimiter(t *testing.T) {
+	l := newTaskListTagLimiter(dynamicconfig.GetIntPropertyFn(2))
+
+	require.Equal(t, "tl1", l.tagValue("domain", "tl1"))
+	require.Equal(t, "tl2", l.tagValue("other-domain", "tl2"))
+	require.Equal(t, metrics.OtherTaskListsTagValue, l.tagValue("domain", "tl3"))
+	// a tagged tasklist keeps its tag when it is reloaded
+	require.Equal(t, "tl1", l.tagValue("domain", "tl1"))
+	require.Equal(t, metrics.OtherTaskListsTagValue, l.tagValue("domain", "tl2"))
+}
+
+func TestGetScheduledTime(t *testing.T) {
+	scheduledTime := time.Unix(0, 1000)
+	require.Equal(t, scheduledTime, getScheduledTime(common.Int64Ptr(scheduledTime.UnixNano())))
+
+	// tasks added without a scheduled time start when they are received
+	before := time.Now()
+	require.False(t, getScheduledTime(nil).Before(before))
+}
@@ -48,6 +48,29 @@ type Config struct {
 	// taskWriter configuration
 	OutstandingTaskAppendsThreshold dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 	MaxTaskBatchSize                dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+	// Time the task writer waits for more tasks to fill a batch, trading add task latency for fewer writes
+	MaxTaskBatchWait dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
+	// Time reserved out of the AddTask deadline to persist a task when sync match does not complete
+	SyncMatchPersistReserve dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
+	// Failed dispatches of a task after which it is moved to the task list DLQ, 0 disables the DLQ
+	MaxTaskDispatchAttempts dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+
+	// isolation group configuration
+	EnableIsolationGroups  dynamicconfig.BoolPropertyFnWithTaskListInfoFilters
+	DrainedIsolationGroups dynamicconfig.MapPropertyFnWithDomainFilter
+	// Times the oldest buffered task can be passed over by tasks of higher priority
+	PriorityStarvationLimit dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+
+	// global rate limit of the tasks dispatched for a domain by all matching hosts
+	GlobalDomainDispatchRPS         dynamicconfig.IntPropertyFnWithDomainFilter
+	GlobalRatelimiterUpdateInterval dynamicconfig.DurationPropertyFn
+
+	// tasklists of a host tagged on the schedule-to-start latency metric, the others share a tag
+	MaxTaskListLatencyMetricTags dynamicconfig.IntPropertyFn
+	// time a force unloaded tasklist is served by another host, 0 lets this host load it again right away
+	MovedTaskListTTL dynamicconfig.DurationPropertyFn
+	// backoff suggested to the pollers for this long after the host rejected a request on its RPS limit, 0 disables it
+	LoadSheddingPollBackoff dynamicconfig.DurationPropertyFn
 }
 
 // NewConfig returns new service config with default values
@@ -65,6 +88,17 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 		MinTaskThrottlingBurstSize:      dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMinTaskThrottlingBurstSize, 1),
 		OutstandingTaskAppendsThreshold: dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingOutstandingTaskAppendsThreshold, 250),
 		MaxTaskBatchSize:                dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxTaskBatchSize, 100),
+		MaxTaskBatchWait:                dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxTaskBatchWait, 0),
+		SyncMatchPersistReserve:         dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingSyncMatchPersistReserve, 500*time.Millisecond),
+		MaxTaskDispatchAttempts:         dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxTaskDispatchAttempts, 10),
+		EnableIsolationGroups:           dc.GetBoolPropertyFilteredByTaskListInfo(dynamicconfig.MatchingEnableIsolationGroups, false),
+		DrainedIsolationGroups:          dc.GetMapPropertyFilteredByDomain(dynamicconfig.MatchingDrainedIsolationGroups, map[string]interface{}{}),
+		PriorityStarvationLimit:         dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingPriorityStarvationLimit, 10),
+		GlobalDomainDispatchRPS:         dc.GetIntPropertyFilteredByDomain(dynamicconfig.MatchingGlobalDomainDispatchRPS, 0),
+		GlobalRatelimiterUpdateInterval: dc.GetDurationProperty(dynamicconfig.GlobalRatelimiterUpdateInterval, 3*time.Second),
+		MaxTaskListLatencyMetricTags:    dc.GetIntProperty(dynamicconfig.MatchingMaxTaskListLatencyMetricTags, 1000),
+		MovedTaskListTTL:                dc.GetDurationProperty(dynamicconfig.MatchingMovedTaskListTTL, 10*time.Minute),
+		LoadSheddingPollBackoff:         dc.GetDurationProperty(dynamicconfig.MatchingLoadSheddingPollBackoff, time.Second),
 	}
 }
 
@@ -99,8 +133,7 @@ func (s *Service) Start() {
 
 	taskPersistence, err := persistence.NewCassandraTaskPersistence(p.CassandraConfig.Hosts,
 		p.CassandraConfig.Port,
-		p.CassandraConfig.User,
-		p.CassandraConfig.Password,
+		p.CassandraCredentials,
 		p.CassandraConfig.Datacenter,
 		p.CassandraConfig.Keyspace,
 		log)
@@ -112,8 +145,7 @@ func (s *Service) Start() {
 
 	metadata, err := persistence.NewMetadataManagerProxy(p.CassandraConfig.Hosts,
 		p.CassandraConfig.Port,
-		p.CassandraConfig.User,
-		p.CassandraConfig.Password,
+		p.CassandraCredentials,
 		p.CassandraConfig.Datacenter,
 		p.CassandraConfig.Keyspace,
 		p.ClusterMetadata.GetCurrentClusterName(),
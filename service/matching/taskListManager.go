@@ -58,11 +58,15 @@ var errAddTasklistThrottled = errors.New("cannot add to tasklist, limit exceeded
 type taskListManager interface {
 	Start() error
 	Stop()
-	AddTask(execution *s.WorkflowExecution, taskInfo *persistence.TaskInfo) error
+	AddTask(ctx context.Context, execution *s.WorkflowExecution, taskInfo *persistence.TaskInfo) error
 	GetTaskContext(ctx context.Context, maxDispatchPerSecond *float64) (*taskContext, error)
 	SyncMatchQueryTask(ctx context.Context, queryTask *queryTaskInfo) error
 	CancelPoller(pollerID string)
 	GetAllPollerInfo() []*pollerInfo
+	RecordWorkerHeartbeat(identity string, capabilities []string, currentLoad int32)
+	GetAllWorkerInfo() []*workerInfo
+	RequeueDLQTasks(taskIDs []int64) (int32, error)
+	GetScheduleToStartLatency() latencyPercentiles
 	String() string
 }
 
@@ -79,6 +83,18 @@ type taskListConfig struct {
 	// taskWriter configuration
 	OutstandingTaskAppendsThreshold func() int
 	MaxTaskBatchSize                func() int
+	MaxTaskBatchWait                func() time.Duration
+	// Time kept out of the AddTask deadline to persist the task if sync match does not complete
+	SyncMatchPersistReserve func() time.Duration
+	// Failed dispatches after which a task is moved to the task list DLQ
+	MaxTaskDispatchAttempts func() int
+	// Dispatch of tasks to pollers of the isolation group the tasks originate from
+	EnableIsolationGroups  func() bool
+	DrainedIsolationGroups func() map[string]interface{}
+	// Times the oldest buffered task can be passed over by tasks of higher priority
+	PriorityStarvationLimit func() int
+	// Name of the domain, the key of its global dispatch rate limit
+	DomainName string
 }
 
 func newTaskListConfig(id *taskListID, config *Config, domainCache cache.DomainCache) (*taskListConfig, error) {
@@ -91,7 +107,8 @@ func newTaskListConfig(id *taskListID, config *Config, domainCache cache.DomainC
 	taskListName := id.taskListName
 	taskType := id.taskType
 	return &taskListConfig{
-		RangeSize: config.RangeSize,
+		DomainName: domain,
+		RangeSize:  config.RangeSize,
 		GetTasksBatchSize: func() int {
 			return config.GetTasksBatchSize(domain, taskListName, taskType)
 		},
@@ -119,6 +136,24 @@ func newTaskListConfig(id *taskListID, config *Config, domainCache cache.DomainC
 		MaxTaskBatchSize: func() int {
 			return config.MaxTaskBatchSize(domain, taskListName, taskType)
 		},
+		MaxTaskBatchWait: func() time.Duration {
+			return config.MaxTaskBatchWait(domain, taskListName, taskType)
+		},
+		SyncMatchPersistReserve: func() time.Duration {
+			return config.SyncMatchPersistReserve(domain, taskListName, taskType)
+		},
+		MaxTaskDispatchAttempts: func() int {
+			return config.MaxTaskDispatchAttempts(domain, taskListName, taskType)
+		},
+		EnableIsolationGroups: func() bool {
+			return config.EnableIsolationGroups(domain, taskListName, taskType)
+		},
+		DrainedIsolationGroups: func() map[string]interface{} {
+			return config.DrainedIsolationGroups(domain)
+		},
+		PriorityStarvationLimit: func() int {
+			return config.PriorityStarvationLimit(domain, taskListName, taskType)
+		},
 	}, nil
 }
 
@@ -228,13 +263,18 @@ func newTaskListManagerWithRateLimiter(
 			logging.TagTaskListName: taskList.taskListName,
 		}),
 		metricsClient:       e.metricsClient,
-		taskAckManager:      newAckManager(e.logger),
+		taskAckManager:      newPriorityAckManager(e.logger),
 		tasksForPoll:        make(chan *getTaskResult),
 		config:              config,
 		pollerHistory:       newPollerHistory(),
+		workerRegistry:      newWorkerRegistry(),
 		outstandingPollsMap: make(map[string]context.CancelFunc),
+		isolatedPollsMap:    make(map[string]*isolatedPolls),
 		rateLimiter:         rl,
 		taskListKind:        taskListKind,
+
+		scheduleToStartLatencies: newScheduleToStartLatencies(),
+		latencyMetricsClient:     newLatencyMetricsClient(e, taskList, taskListKind, config.DomainName),
 	}
 	tlMgr.taskWriter = newTaskWriter(tlMgr)
 	tlMgr.startWG.Add(1)
@@ -266,6 +306,12 @@ type taskListManagerImpl struct {
 
 	// pollerHistory stores poller which poll from this tasklist in last few minutes
 	pollerHistory *pollerHistory
+	// workerRegistry stores workers which heartbeated on this tasklist in last few minutes
+	workerRegistry *workerRegistry
+	// scheduleToStartLatencies stores the schedule-to-start latencies of the tasks recently dispatched
+	scheduleToStartLatencies *scheduleToStartLatencies
+	// latencyMetricsClient is tagged with the tasklist to emit its schedule-to-start latency
+	latencyMetricsClient metrics.Client
 
 	// serializes all writes to persistence
 	// This is needed because of a known Cassandra issue where concurrent LWT to the same partition
@@ -285,6 +331,7 @@ type taskListManagerImpl struct {
 	deliverBufferShutdownCh chan struct{}  // Delivers stop to the pump that populates taskBuffer
 	startWG                 sync.WaitGroup // ensures that background processes do not start until setup is ready
 	stopped                 int32
+	lastNewTaskTime         int64 // unix nanos of the last new task signaled to the pump, used to unload idle task lists
 	// The cancel objects are to cancel the ratelimiter Wait in deliverBufferTasksLoop. The ideal
 	// approach is to use request-scoped contexts and use a unique one for each call to Wait. However
 	// in order to cancel it on shutdown, we need a new goroutine for each call that would wait on
@@ -294,10 +341,10 @@ type taskListManagerImpl struct {
 	cancelFunc context.CancelFunc
 
 	sync.Mutex
-	taskAckManager          ackManager // tracks ackLevel for delivered messages
-	rangeID                 int64      // Current range of the task list. Starts from 1.
-	taskSequenceNumber      int64      // Sequence number of the next task. Starts from 1.
-	nextRangeSequenceNumber int64      // Current range boundary
+	taskAckManager          *priorityAckManager // tracks ackLevel for delivered messages
+	rangeID                 int64               // Current range of the task list. Starts from 1.
+	taskSequenceNumber      int64               // Sequence number of the next task. Starts from 1.
+	nextRangeSequenceNumber int64               // Current range boundary
 
 	// outstandingPollsMap is needed to keep track of all outstanding pollers for a
 	// particular tasklist.  PollerID generated by frontend is used as the key and
@@ -306,12 +353,24 @@ type taskListManagerImpl struct {
 	// prevent tasks being dispatched to zombie pollers.
 	outstandingPollsLock sync.Mutex
 	outstandingPollsMap  map[string]context.CancelFunc
+	// isolatedPollsMap holds an unbuffered channel per isolation group, like tasksForPoll but only
+	// consumed by pollers of that group. Those pollers also consume from tasksForPoll, so offering a
+	// task on the channel of its origin group first makes same group dispatch preferred. A group is
+	// only kept while it has outstanding pollers, which bounds the map by the number of pollers.
+	isolatedPollsLock sync.Mutex
+	isolatedPollsMap  map[string]*isolatedPolls
 	// Rate limiter for task dispatch
 	rateLimiter *rateLimiter
 
 	taskListKind *s.TaskListKind // sticky taskList has different process in persistence
 }
 
+// isolatedPolls is the channel of the outstanding pollers of an isolation group
+type isolatedPolls struct {
+	tasksForPoll chan *getTaskResult
+	pollers      int
+}
+
 // getTaskResult contains task info and optional channel to notify createTask caller
 // that task is successfully started and returned to a poller
 type getTaskResult struct {
@@ -319,6 +378,9 @@ type getTaskResult struct {
 	C         chan *syncMatchResponse
 	queryTask *queryTaskInfo
 	syncMatch bool
+	// pollerDone receives the done channel of the poll request that picked up a sync match task,
+	// so the createTask caller stops waiting on the start if the poller goes away
+	pollerDone chan (<-chan struct{})
 }
 
 // syncMatchResponse result of sync match delivered to a createTask caller
@@ -359,10 +421,12 @@ func (c *taskListManagerImpl) Stop() {
 	logging.LogTaskListUnloadedEvent(c.logger)
 }
 
-func (c *taskListManagerImpl) AddTask(execution *s.WorkflowExecution, taskInfo *persistence.TaskInfo) error {
+func (c *taskListManagerImpl) AddTask(
+	ctx context.Context, execution *s.WorkflowExecution, taskInfo *persistence.TaskInfo,
+) error {
 	c.startWG.Wait()
 	_, err := c.executeWithRetry(func(rangeID int64) (interface{}, error) {
-		r, err := c.trySyncMatch(taskInfo)
+		r, err := c.trySyncMatch(ctx, taskInfo)
 		if (err != nil && err != errAddTasklistThrottled) || r != nil {
 			return r, err
 		}
@@ -445,6 +509,7 @@ func (c *taskListManagerImpl) persistAckLevel() error {
 			AckLevel: c.taskAckManager.getAckLevel(),
 			RangeID:  c.rangeID,
 			Kind:     c.getTaskListKind(),
+			Pollers:  c.pollerHistory.getPersistablePollerInfo(),
 		},
 	}
 	c.Unlock()
@@ -481,6 +546,24 @@ func (c *taskListManagerImpl) getAckLevel() (ackLevel int64) {
 	return c.taskAckManager.getAckLevel()
 }
 
+// getBacklogCountHint estimates the tasks persisted but not read yet from the task IDs written past the lowest read
+// level of the priority levels with tasks left to read. The task IDs are shared by the priority levels, so the
+// estimate also counts the tasks of the other levels read already.
+func (c *taskListManagerImpl) getBacklogCountHint() int64 {
+	// loaded before the last written IDs, see getLastWrittenID
+	maxReadLevel := c.taskWriter.GetMaxReadLevel()
+	readLevel := maxReadLevel
+	c.Lock()
+	for level := 0; level < persistence.TaskPriorityLevels; level++ {
+		levelReadLevel := c.taskAckManager.getReadLevel(level)
+		if levelReadLevel < c.taskWriter.getLastWrittenID(level) && levelReadLevel < readLevel {
+			readLevel = levelReadLevel
+		}
+	}
+	c.Unlock()
+	return maxReadLevel - readLevel
+}
+
 func (c *taskListManagerImpl) getTaskListKind() int {
 	// there is no need to lock here,
 	// since c.taskListKind is assigned when taskListManager been created and never changed.
@@ -492,10 +575,10 @@ func (c *taskListManagerImpl) getTaskListKind() int {
 
 // completeTaskPoll should be called after task poll is done even if append has failed.
 // There is no correspondent initiateTaskPoll as append is initiated in getTasksPump
-func (c *taskListManagerImpl) completeTaskPoll(taskID int64) (ackLevel int64) {
+func (c *taskListManagerImpl) completeTaskPoll(priorityLevel int, taskID int64) (ackLevel int64) {
 	c.Lock()
 	defer c.Unlock()
-	ackLevel = c.taskAckManager.completeTask(taskID)
+	ackLevel = c.taskAckManager.completeTask(priorityLevel, taskID)
 	return
 }
 
@@ -525,18 +608,28 @@ func (c *taskListManagerImpl) getTask(ctx context.Context) (*getTaskResult, erro
 
 	identity, ok := ctx.Value(identityKey).(string)
 	if ok && identity != "" {
+		version, _ := ctx.Value(pollerVersionKey).(string)
 		c.pollerHistory.updatePollerInfo(pollerIdentity{
 			identity: identity,
-		})
+		}, version)
 	}
 
+	tasksCh := c.tasksForPoll
+	isolationGroup := c.getPollerIsolationGroup(ctx)
+	if isolationGroup != "" && c.isIsolationGroupDrained(isolationGroup) {
+		// pollers of a drained group are held until the long poll expires without being offered any task
+		c.metricsClient.IncCounter(scope, metrics.DrainedIsolationGroupPollCounter)
+		tasksCh = nil
+		isolationGroup = ""
+	}
+
+	isolatedTasksCh, removePoller := c.addIsolatedPoller(isolationGroup)
+	defer removePoller()
+
+	var result *getTaskResult
 	select {
-	case result := <-c.tasksForPoll:
-		if result.syncMatch {
-			c.metricsClient.IncCounter(scope, metrics.PollSuccessWithSyncCounter)
-		}
-		c.metricsClient.IncCounter(scope, metrics.PollSuccessCounter)
-		return result, nil
+	case result = <-tasksCh:
+	case result = <-isolatedTasksCh:
 	case <-timer.C:
 		c.metricsClient.IncCounter(scope, metrics.PollTimeoutCounter)
 		return nil, ErrNoTasks
@@ -548,6 +641,14 @@ func (c *taskListManagerImpl) getTask(ctx context.Context) (*getTaskResult, erro
 		c.metricsClient.IncCounter(scope, metrics.PollTimeoutCounter)
 		return nil, err
 	}
+	if result.syncMatch {
+		if result.pollerDone != nil {
+			result.pollerDone <- ctx.Done()
+		}
+		c.metricsClient.IncCounter(scope, metrics.PollSuccessWithSyncCounter)
+	}
+	c.metricsClient.IncCounter(scope, metrics.PollSuccessCounter)
+	return result, nil
 }
 
 func (c *taskListManagerImpl) CancelPoller(pollerID string) {
@@ -560,18 +661,55 @@ func (c *taskListManagerImpl) CancelPoller(pollerID string) {
 	}
 }
 
-// Returns a batch of tasks from persistence starting form current read level.
+// readTasks loads a batch of tasks of every priority level, from the highest level to the lowest, and registers
+// them with the ack manager. Each level is read from its own read level, so the tasks of a higher level are
+// loaded as soon as they are written whatever the backlog of the lower levels. The levels without any task
+// written past their read level are not read, their read level moves up to the max read level.
+func (c *taskListManagerImpl) readTasks() ([]*persistence.TaskInfo, error) {
+	var tasks []*persistence.TaskInfo
+	// loaded before the last written IDs, see getLastWrittenID
+	maxReadLevel := c.taskWriter.GetMaxReadLevel()
+	for level := persistence.TaskPriorityLevels - 1; level >= 0; level-- {
+		c.Lock()
+		readLevel := c.taskAckManager.getReadLevel(level)
+		c.Unlock()
+		if readLevel >= c.taskWriter.getLastWrittenID(level) {
+			c.Lock()
+			if readLevel < maxReadLevel {
+				c.taskAckManager.setReadLevel(level, maxReadLevel)
+			}
+			c.Unlock()
+			continue
+		}
+		levelTasks, readLevel, err := c.getTaskBatch(level, readLevel)
+		if err != nil {
+			return tasks, err
+		}
+		c.Lock()
+		if len(levelTasks) == 0 {
+			c.taskAckManager.setReadLevel(level, readLevel)
+		} else {
+			for _, t := range levelTasks {
+				c.taskAckManager.addTask(level, t.TaskID)
+			}
+		}
+		c.Unlock()
+		tasks = append(tasks, levelTasks...)
+	}
+	return tasks, nil
+}
+
+// Returns a batch of tasks of a priority level from persistence starting form its read level.
 // Also return a number that can be used to update readLevel
-func (c *taskListManagerImpl) getTaskBatch() ([]*persistence.TaskInfo, int64, error) {
+func (c *taskListManagerImpl) getTaskBatch(priorityLevel int, readLevel int64) ([]*persistence.TaskInfo, int64, error) {
 	var tasks []*persistence.TaskInfo
-	readLevel := c.taskAckManager.getReadLevel()
 	maxReadLevel := c.taskWriter.GetMaxReadLevel()
 	for readLevel < maxReadLevel {
 		upper := readLevel + c.config.RangeSize
 		if upper > maxReadLevel {
 			upper = maxReadLevel
 		}
-		tasks, err := c.getTaskBatchWithRange(readLevel, upper)
+		tasks, err := c.getTaskBatchWithRange(priorityLevel, readLevel, upper)
 		if err != nil {
 			return nil, readLevel, err
 		}
@@ -584,17 +722,19 @@ func (c *taskListManagerImpl) getTaskBatch() ([]*persistence.TaskInfo, int64, er
 	return tasks, readLevel, nil // caller will update readLevel when no task grabbed
 }
 
-func (c *taskListManagerImpl) getTaskBatchWithRange(readLevel int64, maxReadLevel int64) ([]*persistence.TaskInfo, error) {
+func (c *taskListManagerImpl) getTaskBatchWithRange(priorityLevel int, readLevel int64,
+	maxReadLevel int64) ([]*persistence.TaskInfo, error) {
 	response, err := c.executeWithRetry(func(rangeID int64) (interface{}, error) {
 		c.Lock()
 		request := &persistence.GetTasksRequest{
-			DomainID:     c.taskListID.domainID,
-			TaskList:     c.taskListID.taskListName,
-			TaskType:     c.taskListID.taskType,
-			BatchSize:    c.config.GetTasksBatchSize(),
-			RangeID:      rangeID,
-			ReadLevel:    readLevel,    // exclusive
-			MaxReadLevel: maxReadLevel, // inclusive
+			DomainID:      c.taskListID.domainID,
+			TaskList:      c.taskListID.taskListName,
+			TaskType:      c.taskListID.taskType,
+			PriorityLevel: priorityLevel,
+			BatchSize:     c.config.GetTasksBatchSize(),
+			RangeID:       rangeID,
+			ReadLevel:     readLevel,    // exclusive
+			MaxReadLevel:  maxReadLevel, // inclusive
 		}
 		c.Unlock()
 		return c.engine.taskManager.GetTasks(request)
@@ -638,6 +778,10 @@ func (c *taskListManagerImpl) updateRangeIfNeededLocked(e *matchingEngineImpl) e
 	}
 
 	tli := resp.TaskListInfo
+	if c.rangeID == 0 {
+		// first lease since the task list was loaded on this host
+		c.pollerHistory.restorePollerInfo(tli.Pollers)
+	}
 	c.rangeID = tli.RangeID // Starts from 1
 	c.taskAckManager.setAckLevel(tli.AckLevel)
 	c.taskSequenceNumber = (tli.RangeID-1)*c.config.RangeSize + 1
@@ -661,15 +805,79 @@ func (c *taskListManagerImpl) String() string {
 	r += fmt.Sprintf("RangeID=%v\n", c.rangeID)
 	r += fmt.Sprintf("TaskSequenceNumber=%v\n", c.taskSequenceNumber)
 	r += fmt.Sprintf("NextRangeSequenceNumber=%v\n", c.nextRangeSequenceNumber)
-	r += fmt.Sprintf("AckLevel=%v\n", c.taskAckManager.ackLevel)
-	r += fmt.Sprintf("MaxReadLevel=%v\n", c.taskAckManager.getReadLevel())
+	r += fmt.Sprintf("AckLevel=%v\n", c.taskAckManager.getAckLevel())
+	for level := persistence.TaskPriorityLevels - 1; level >= 0; level-- {
+		r += fmt.Sprintf("MaxReadLevel[%v]=%v\n", level, c.taskAckManager.getReadLevel(level))
+	}
 
 	return r
 }
 
+// getIsolatedTasksForPoll returns the channel delivering tasks to pollers of the given isolation
+// group, or nil (which blocks forever in a select) for no group or a group without pollers.
+func (c *taskListManagerImpl) getIsolatedTasksForPoll(isolationGroup string) chan *getTaskResult {
+	if isolationGroup == "" {
+		return nil
+	}
+	c.isolatedPollsLock.Lock()
+	defer c.isolatedPollsLock.Unlock()
+	if polls, ok := c.isolatedPollsMap[isolationGroup]; ok {
+		return polls.tasksForPoll
+	}
+	return nil
+}
+
+// addIsolatedPoller registers a poller of the given isolation group and returns the channel it
+// consumes from, along with the func removing the poller. The channel of a group is dropped with
+// its last poller.
+func (c *taskListManagerImpl) addIsolatedPoller(isolationGroup string) (chan *getTaskResult, func()) {
+	if isolationGroup == "" {
+		return nil, func() {}
+	}
+	c.isolatedPollsLock.Lock()
+	defer c.isolatedPollsLock.Unlock()
+	polls, ok := c.isolatedPollsMap[isolationGroup]
+	if !ok {
+		polls = &isolatedPolls{tasksForPoll: make(chan *getTaskResult)}
+		c.isolatedPollsMap[isolationGroup] = polls
+	}
+	polls.pollers++
+	return polls.tasksForPoll, func() {
+		c.isolatedPollsLock.Lock()
+		defer c.isolatedPollsLock.Unlock()
+		polls.pollers--
+		if polls.pollers == 0 {
+			delete(c.isolatedPollsMap, isolationGroup)
+		}
+	}
+}
+
+// getPollerIsolationGroup returns the isolation group declared by the poller, if isolation groups are enabled
+func (c *taskListManagerImpl) getPollerIsolationGroup(ctx context.Context) string {
+	if !c.config.EnableIsolationGroups() {
+		return ""
+	}
+	isolationGroup, _ := ctx.Value(isolationGroupKey).(string)
+	return isolationGroup
+}
+
+// getTaskIsolationGroup returns the isolation group the task should be dispatched to first. Tasks
+// originating from a drained group are dispatched to any group.
+func (c *taskListManagerImpl) getTaskIsolationGroup(task *persistence.TaskInfo) string {
+	if !c.config.EnableIsolationGroups() || task.IsolationGroup == "" || c.isIsolationGroupDrained(task.IsolationGroup) {
+		return ""
+	}
+	return task.IsolationGroup
+}
+
+func (c *taskListManagerImpl) isIsolationGroupDrained(isolationGroup string) bool {
+	drained, _ := c.config.DrainedIsolationGroups()[isolationGroup].(bool)
+	return drained
+}
+
 // updatePollerInfo update the poller information for this tasklist
 func (c *taskListManagerImpl) updatePollerInfo(id pollerIdentity) {
-	c.pollerHistory.updatePollerInfo(id)
+	c.pollerHistory.updatePollerInfo(id, "")
 }
 
 // getAllPollerInfo return poller which poll from this tasklist in last few minutes
@@ -677,39 +885,136 @@ func (c *taskListManagerImpl) GetAllPollerInfo() []*pollerInfo {
 	return c.pollerHistory.getAllPollerInfo()
 }
 
+// RecordWorkerHeartbeat records the capabilities and current load reported by a worker of this tasklist
+func (c *taskListManagerImpl) RecordWorkerHeartbeat(identity string, capabilities []string, currentLoad int32) {
+	c.workerRegistry.recordHeartbeat(identity, capabilities, currentLoad)
+}
+
+// GetAllWorkerInfo return workers which heartbeated on this tasklist in last few minutes
+func (c *taskListManagerImpl) GetAllWorkerInfo() []*workerInfo {
+	return c.workerRegistry.getAllWorkerInfo()
+}
+
+// GetScheduleToStartLatency returns the percentiles of the schedule-to-start latency of the tasks recently dispatched
+func (c *taskListManagerImpl) GetScheduleToStartLatency() latencyPercentiles {
+	return c.scheduleToStartLatencies.percentiles(time.Now())
+}
+
 // Tries to match task to a poller that is already waiting on getTask.
 // When this method returns non nil response without error it is guaranteed that the task is started
 // and sent to a poller. So it not necessary to persist it.
 // Returns (nil, nil) if there is no waiting poller which indicates that task has to be persisted.
-func (c *taskListManagerImpl) trySyncMatch(task *persistence.TaskInfo) (*persistence.CreateTasksResponse, error) {
+// The wait for the poller is bounded by the deadline of ctx minus SyncMatchPersistReserve, so a slow
+// RecordTaskStarted on the poller side does not consume the time needed to persist the task instead.
+// The wait is also canceled early, and the task persisted, if the poller disappears before the start.
+// Persisting a task the poller ends up starting is safe: history rejects the second start with
+// EventAlreadyStartedError and the duplicate is dropped.
+func (c *taskListManagerImpl) trySyncMatch(
+	ctx context.Context, task *persistence.TaskInfo,
+) (*persistence.CreateTasksResponse, error) {
 	if !c.config.EnableSyncMatch() {
 		return nil, nil
 	}
+	budget, hasDeadline := c.syncMatchBudget(ctx)
+	if hasDeadline && budget <= 0 {
+		c.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.SyncMatchBudgetExceededCounter)
+		return nil, nil
+	}
 	// Request from the point of view of Add(Activity|Decision)Task operation.
 	// But it is getTask result from the point of view of a poll operation.
-	request := &getTaskResult{task: task, C: make(chan *syncMatchResponse, 1), syncMatch: true}
+	request := &getTaskResult{
+		task:       task,
+		C:          make(chan *syncMatchResponse, 1),
+		syncMatch:  true,
+		pollerDone: make(chan (<-chan struct{}), 1),
+	}
 
+	maxDelay := time.Second
+	if hasDeadline && budget < maxDelay {
+		maxDelay = budget
+	}
+	if !c.engine.domainDispatchLimiters.Allow(c.config.DomainName) {
+		c.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.SyncThrottleCounter)
+		return nil, errAddTasklistThrottled
+	}
 	rsv := c.rateLimiter.Reserve()
 	// If we have to wait too long for reservation, better to store in task buffer and handle later.
-	if !rsv.OK() || rsv.Delay() > time.Second {
+	if !rsv.OK() || rsv.Delay() > maxDelay {
 		c.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.SyncThrottleCounter)
 		return nil, errAddTasklistThrottled
 	}
 	time.Sleep(rsv.Delay())
+	if !c.offerTask(request) { // no poller waiting for tasks
+		rsv.Cancel()
+		return nil, nil
+	}
+	// poller goroutine picked up the task
+	pollerDone := <-request.pollerDone
+	var timerC <-chan time.Time
+	if hasDeadline {
+		timer := time.NewTimer(budget - rsv.Delay())
+		defer timer.Stop()
+		timerC = timer.C
+	}
 	select {
-	case c.tasksForPoll <- request: // poller goroutine picked up the task
-		r := <-request.C
+	case r := <-request.C:
 		return r.response, r.err
-	default: // no poller waiting for tasks
-		rsv.Cancel()
+	case <-pollerDone:
+		// The poll request was canceled or timed out before the poller confirmed the start. The poll
+		// completes the start before returning, so a start already done is still reported here.
+		select {
+		case r := <-request.C:
+			return r.response, r.err
+		default:
+		}
+		c.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.SyncMatchPollerGoneCounter)
+		return nil, nil
+	case <-timerC:
+		// The poller has not confirmed the start in time; request.C is buffered so its
+		// late response is simply discarded.
+		c.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.SyncMatchBudgetExceededCounter)
 		return nil, nil
 	}
 }
 
+// offerTask hands the task to a poller that is already waiting, preferring pollers of the isolation
+// group the task originates from. Returns false if no poller is waiting.
+func (c *taskListManagerImpl) offerTask(request *getTaskResult) bool {
+	if isolatedCh := c.getIsolatedTasksForPoll(c.getTaskIsolationGroup(request.task)); isolatedCh != nil {
+		select {
+		case isolatedCh <- request:
+			c.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.IsolationGroupMatchCounter)
+			return true
+		default:
+		}
+	}
+	select {
+	case c.tasksForPoll <- request:
+		return true
+	default:
+		return false
+	}
+}
+
+// syncMatchBudget returns how long sync match may wait on a poller before the task must be
+// persisted to still complete within the deadline of ctx. The second return value is false if
+// ctx carries no deadline.
+func (c *taskListManagerImpl) syncMatchBudget(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return deadline.Sub(time.Now()) - c.config.SyncMatchPersistReserve(), true
+}
+
 func (c *taskListManagerImpl) deliverBufferTasksForPoll() {
+	queue := newTaskPriorityQueue()
 deliverBufferTasksLoop:
 	for {
 		err := c.rateLimiter.Wait(c.cancelCtx)
+		if err == nil {
+			err = c.engine.domainDispatchLimiters.Wait(c.cancelCtx, c.config.DomainName)
+		}
 		if err != nil {
 			if err == context.Canceled {
 				c.logger.Info("Tasklist manager context is cancelled, shutting down")
@@ -724,22 +1029,50 @@ deliverBufferTasksLoop:
 			runtime.Gosched()
 			continue
 		}
-		select {
-		case task, ok := <-c.taskBuffer:
-			if !ok { // Task list getTasks pump is shutdown
-				break deliverBufferTasksLoop
-			}
+		if queue.len() == 0 {
 			select {
-			case c.tasksForPoll <- &getTaskResult{task: task}:
+			case task, ok := <-c.taskBuffer:
+				if !ok { // Task list getTasks pump is shutdown
+					break deliverBufferTasksLoop
+				}
+				queue.add(task)
 			case <-c.deliverBufferShutdownCh:
 				break deliverBufferTasksLoop
 			}
+		}
+		c.bufferTasks(queue)
+
+		task := queue.next(c.config.PriorityStarvationLimit())
+		request := &getTaskResult{task: task}
+		if c.offerTask(request) {
+			continue
+		}
+		select {
+		case c.getIsolatedTasksForPoll(c.getTaskIsolationGroup(task)) <- request:
+			c.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.IsolationGroupMatchCounter)
+		case c.tasksForPoll <- request:
 		case <-c.deliverBufferShutdownCh:
 			break deliverBufferTasksLoop
 		}
 	}
 }
 
+// bufferTasks moves the tasks loaded by the pump into the priority queue without blocking, so the highest
+// priority task among them is dispatched next. The queue holds at most as many tasks as the task buffer.
+func (c *taskListManagerImpl) bufferTasks(queue *taskPriorityQueue) {
+	for queue.len() <= cap(c.taskBuffer) {
+		select {
+		case task, ok := <-c.taskBuffer:
+			if !ok {
+				return
+			}
+			queue.add(task)
+		default:
+			return
+		}
+	}
+}
+
 func (c *taskListManagerImpl) getTasksPump() {
 	defer close(c.taskBuffer)
 	c.startWG.Wait()
@@ -747,7 +1080,6 @@ func (c *taskListManagerImpl) getTasksPump() {
 	go c.deliverBufferTasksForPoll()
 	updateAckTimer := time.NewTimer(c.config.UpdateAckInterval())
 	checkIdleTaskListTimer := time.NewTimer(c.config.IdleTasklistCheckInterval())
-	lastTimeWriteTask := time.Time{}
 getTasksPumpLoop:
 	for {
 		select {
@@ -755,23 +1087,12 @@ getTasksPumpLoop:
 			break getTasksPumpLoop
 		case <-c.notifyCh:
 			{
-				lastTimeWriteTask = time.Now()
-
-				tasks, readLevel, err := c.getTaskBatch()
+				tasks, err := c.readTasks()
 				if err != nil {
-					c.signalNewTask() // re-enqueue the event
+					c.notifyPump() // re-enqueue the event
 					// TODO: Should we ever stop retrying on db errors?
-					continue getTasksPumpLoop
-				}
-				c.Lock()
-				if len(tasks) == 0 {
-					c.taskAckManager.setReadLevel(readLevel)
-				} else {
-					for _, t := range tasks {
-						c.taskAckManager.addTask(t.TaskID)
-					}
 				}
-				c.Unlock()
+				// tasks of the levels read before the error are registered with the ack manager already
 				for _, t := range tasks {
 					select {
 					case c.taskBuffer <- t:
@@ -783,7 +1104,7 @@ getTasksPumpLoop:
 				if len(tasks) > 0 {
 					// There maybe more tasks.
 					// We yield now, but signal pump to check again later.
-					c.signalNewTask()
+					c.notifyPump()
 				}
 			}
 		case <-updateAckTimer.C:
@@ -800,13 +1121,13 @@ getTasksPumpLoop:
 					}
 					// keep going as saving ack is not critical
 				}
-				c.signalNewTask() // periodically signal pump to check persistence for tasks
+				c.notifyPump() // periodically signal pump to check persistence for tasks
 				updateAckTimer = time.NewTimer(c.config.UpdateAckInterval())
 			}
 		case <-checkIdleTaskListTimer.C:
 			{
-				if !c.isTaskAddedRecently(lastTimeWriteTask) && len(c.GetAllPollerInfo()) == 0 {
-					c.Stop()
+				if c.isIdle() {
+					c.unloadIdle()
 				}
 				checkIdleTaskListTimer = time.NewTimer(c.config.IdleTasklistCheckInterval())
 			}
@@ -856,7 +1177,14 @@ func (c *taskListManagerImpl) executeWithRetry(
 	return
 }
 
+// signalNewTask notifies the pump of a new task, which also marks the task list as active
 func (c *taskListManagerImpl) signalNewTask() {
+	atomic.StoreInt64(&c.lastNewTaskTime, time.Now().UnixNano())
+	c.notifyPump()
+}
+
+// notifyPump wakes up the pump to check persistence for tasks
+func (c *taskListManagerImpl) notifyPump() {
 	var event struct{}
 	select {
 	case c.notifyCh <- event:
@@ -864,6 +1192,115 @@ func (c *taskListManagerImpl) signalNewTask() {
 	}
 }
 
+// moveTaskToDLQ parks a persisted task in the dead letter queue of the task list
+func (c *taskListManagerImpl) moveTaskToDLQ(task *persistence.TaskInfo) error {
+	dlqTask := *task
+	dlqTask.DispatchAttempts++
+	err := c.engine.taskManager.CreateDLQTask(&persistence.CreateDLQTaskRequest{
+		TaskList: &persistence.TaskListInfo{
+			DomainID: c.taskListID.domainID,
+			Name:     c.taskListID.taskListName,
+			TaskType: c.taskListID.taskType,
+		},
+		Task: &dlqTask,
+	})
+	if err != nil {
+		return err
+	}
+	c.logger.Warnf("Moved task to DLQ after %v failed dispatches. {taskID: %v, workflowID: %v, runID: %v}",
+		dlqTask.DispatchAttempts, task.TaskID, task.WorkflowID, task.RunID)
+	c.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.TaskMovedToDLQCounter)
+	return nil
+}
+
+// RequeueDLQTasks writes the given DLQ tasks, or all of them when taskIDs is empty, back to the task list
+// with a fresh dispatch budget and returns the number of requeued tasks
+func (c *taskListManagerImpl) RequeueDLQTasks(taskIDs []int64) (int32, error) {
+	selected := make(map[int64]struct{}, len(taskIDs))
+	for _, taskID := range taskIDs {
+		selected[taskID] = struct{}{}
+	}
+	tlInfo := &persistence.TaskListInfo{
+		DomainID: c.taskListID.domainID,
+		Name:     c.taskListID.taskListName,
+		TaskType: c.taskListID.taskType,
+	}
+
+	var requeued int32
+	var nextPageToken []byte
+	for {
+		resp, err := c.engine.taskManager.GetDLQTasks(&persistence.GetDLQTasksRequest{
+			DomainID:      c.taskListID.domainID,
+			TaskList:      c.taskListID.taskListName,
+			TaskType:      c.taskListID.taskType,
+			PageSize:      c.config.GetTasksBatchSize(),
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return requeued, err
+		}
+
+		for _, task := range resp.Tasks {
+			if _, ok := selected[task.TaskID]; len(selected) > 0 && !ok {
+				continue
+			}
+			execution := &s.WorkflowExecution{
+				WorkflowId: common.StringPtr(task.WorkflowID),
+				RunId:      common.StringPtr(task.RunID),
+			}
+			taskInfo := *task
+			taskInfo.DispatchAttempts = 0
+			_, err = c.executeWithRetry(func(rangeID int64) (interface{}, error) {
+				return c.taskWriter.appendTask(execution, &taskInfo, rangeID)
+			})
+			if err != nil {
+				return requeued, err
+			}
+			err = c.engine.taskManager.DeleteDLQTask(&persistence.DeleteDLQTaskRequest{
+				TaskList: tlInfo,
+				TaskID:   task.TaskID,
+			})
+			if err != nil {
+				return requeued, err
+			}
+			requeued++
+			c.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.TaskRequeuedFromDLQCounter)
+		}
+
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		nextPageToken = resp.NextPageToken
+	}
+
+	if requeued > 0 {
+		c.signalNewTask()
+	}
+	return requeued, nil
+}
+
+// recordScheduleToStartLatency records the time the task waited in the tasklist before being started by a poller
+func (c *taskContext) recordScheduleToStartLatency() {
+	if c.info.CreatedTime.IsZero() {
+		// tasks persisted before the created time was recorded
+		return
+	}
+	now := time.Now()
+	// the task may have been received by another host, do not let a clock skew turn the latency negative
+	latency := now.Sub(c.info.CreatedTime)
+	if latency < 0 {
+		latency = 0
+	}
+	c.tlMgr.scheduleToStartLatencies.record(latency, now)
+	c.tlMgr.latencyMetricsClient.RecordTimer(metrics.MatchingTaskListMgrScope, metrics.ScheduleToStartLatency, latency)
+}
+
+// shouldMoveToDLQ is true when the current failed dispatch exhausts the dispatch budget of the task
+func (c *taskContext) shouldMoveToDLQ() bool {
+	maxAttempts := c.tlMgr.config.MaxTaskDispatchAttempts()
+	return maxAttempts > 0 && int(c.info.DispatchAttempts)+1 >= maxAttempts
+}
+
 func (c *taskContext) RecordDecisionTaskStartedWithRetry(
 	request *h.RecordDecisionTaskStartedRequest) (resp *h.RecordDecisionTaskStartedResponse, err error) {
 	op := func() error {
@@ -911,6 +1348,17 @@ func (c *taskContext) completeTask(err error) {
 		return
 	}
 
+	if err != nil && c.shouldMoveToDLQ() {
+		// the task keeps failing to start, most likely because its target execution is broken.
+		// Park it in the task list DLQ so it stops being re-written ahead of the read level.
+		err = tlMgr.moveTaskToDLQ(c.info)
+		if err != nil {
+			logging.LogPersistantStoreErrorEvent(tlMgr.logger, logging.TagValueStoreOperationCreateTask, err,
+				fmt.Sprintf("failed to move task to DLQ {taskID: %v, taskType: %v, taskList: %v}",
+					c.info.TaskID, tlMgr.taskListID.taskType, tlMgr.taskListID.taskListName))
+		}
+	}
+
 	if err != nil {
 		// failed to start the task.
 		// We cannot just remove it from persistence because then it will be lost.
@@ -919,6 +1367,7 @@ func (c *taskContext) completeTask(err error) {
 		// again the underlying reason for failing to start will be resolved.
 		// Note that RecordTaskStarted only fails after retrying for a long time, so a single task will not be
 		// re-written to persistence frequently.
+		c.info.DispatchAttempts++
 		_, err = tlMgr.executeWithRetry(func(rangeID int64) (interface{}, error) {
 			return tlMgr.taskWriter.appendTask(&c.workflowExecution, c.info, rangeID)
 		})
@@ -936,7 +1385,8 @@ func (c *taskContext) completeTask(err error) {
 		tlMgr.signalNewTask()
 	}
 
-	tlMgr.completeTaskPoll(c.info.TaskID)
+	priorityLevel := persistence.TaskPriorityLevel(c.info.Priority)
+	tlMgr.completeTaskPoll(priorityLevel, c.info.TaskID)
 
 	// TODO: use range deletes to complete all tasks below ack level instead of completing
 	// tasks one by one.
@@ -946,7 +1396,8 @@ func (c *taskContext) completeTask(err error) {
 			Name:     tlMgr.taskListID.taskListName,
 			TaskType: tlMgr.taskListID.taskType,
 		},
-		TaskID: c.info.TaskID,
+		PriorityLevel: priorityLevel,
+		TaskID:        c.info.TaskID,
 	})
 
 	if err2 != nil {
@@ -956,10 +1407,42 @@ func (c *taskContext) completeTask(err error) {
 	}
 }
 
-func createServiceBusyError(msg string) *s.ServiceBusyError {
-	return &s.ServiceBusyError{Message: msg}
+func createServiceBusyError(msg string, backlogCountHint int64) *s.ServiceBusyError {
+	return &s.ServiceBusyError{
+		Message:          msg,
+		BacklogCountHint: common.Int64Ptr(backlogCountHint),
+	}
 }
 
 func (c *taskListManagerImpl) isTaskAddedRecently(lastAddTime time.Time) bool {
 	return time.Now().Sub(lastAddTime) <= c.config.MaxTasklistIdleTime()
 }
+
+// isIdle returns true if no task was added to the task list and no poller polled it for longer than the max idle time
+func (c *taskListManagerImpl) isIdle() bool {
+	lastNewTaskTime := time.Time{}
+	if nanos := atomic.LoadInt64(&c.lastNewTaskTime); nanos != 0 {
+		lastNewTaskTime = time.Unix(0, nanos)
+	}
+	if c.isTaskAddedRecently(lastNewTaskTime) || len(c.GetAllPollerInfo()) > 0 {
+		return false
+	}
+	c.outstandingPollsLock.Lock()
+	defer c.outstandingPollsLock.Unlock()
+	return len(c.outstandingPollsMap) == 0
+}
+
+// unloadIdle persists the ack level and unloads the task list, it is loaded again on the next access and resumes
+// from the persisted ack level.  The task list stays loaded if the ack level cannot be persisted, unless it was
+// taken over by another host.
+func (c *taskListManagerImpl) unloadIdle() {
+	if err := c.persistAckLevel(); err != nil {
+		if _, ok := err.(*persistence.ConditionFailedError); !ok {
+			logging.LogPersistantStoreErrorEvent(c.logger, logging.TagValueStoreOperationUpdateTaskList, err,
+				"Persist AckLevel of idle task list failed")
+			return
+		}
+	}
+	c.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.IdleTaskListUnloadedCounter)
+	c.Stop()
+}
@@ -21,6 +21,9 @@
 package matching
 
 import (
+	"context"
+	"fmt"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -152,3 +155,351 @@ func TestCheckIdleTaskList(t *testing.T) {
 	tlm.Stop()
 	require.Equal(t, int32(1), tlm.stopped)
 }
+
+func TestCheckIdleTaskList_PersistsAckLevel(t *testing.T) {
+	cfg := NewConfig(dynamicconfig.NewNopCollection())
+	cfg.IdleTasklistCheckInterval = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(20 * time.Millisecond)
+	cfg.MaxTasklistIdleTime = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(10 * time.Millisecond)
+
+	tlm := createTestTaskListManagerWithConfig(cfg)
+	tlm.taskAckManager.setAckLevel(42)
+	tlMgrStartWithoutNotifyEvent(tlm)
+	tlm.notifyPump() // the pump checking persistence for tasks does not keep the task list loaded
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, int32(1), atomic.LoadInt32(&tlm.stopped))
+	tm := tlm.engine.taskManager.(*testTaskManager)
+	require.Equal(t, int64(42), tm.getTaskListManager(tlm.taskListID).ackLevel)
+}
+
+func TestTrySyncMatch_BudgetExceeded(t *testing.T) {
+	cfg := NewConfig(dynamicconfig.NewNopCollection())
+	cfg.SyncMatchPersistReserve = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(10 * time.Millisecond)
+	tlm := createTestTaskListManagerWithConfig(cfg)
+	// a buffered channel stands for a poller already waiting on tasksForPoll
+	tlm.tasksForPoll = make(chan *getTaskResult, 1)
+
+	// the poller picks up the task but never confirms the start
+	pickedUp := make(chan *getTaskResult, 1)
+	go func() {
+		result, _ := tlm.getTask(context.Background())
+		pickedUp <- result
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	r, err := tlm.trySyncMatch(ctx, &persistence.TaskInfo{})
+	require.NoError(t, err)
+	require.Nil(t, r)
+	require.NoError(t, ctx.Err(), "sync match must give up before the add task deadline")
+	require.True(t, (<-pickedUp).syncMatch)
+}
+
+type trySyncMatchResult struct {
+	response *persistence.CreateTasksResponse
+	err      error
+}
+
+func trySyncMatchAsync(tlm *taskListManagerImpl) <-chan trySyncMatchResult {
+	done := make(chan trySyncMatchResult, 1)
+	go func() {
+		r, err := tlm.trySyncMatch(context.Background(), &persistence.TaskInfo{})
+		done <- trySyncMatchResult{response: r, err: err}
+	}()
+	return done
+}
+
+func TestTrySyncMatch_PollerGone(t *testing.T) {
+	tlm := createTestTaskListManager()
+	tlm.tasksForPoll = make(chan *getTaskResult, 1)
+	done := trySyncMatchAsync(tlm)
+
+	// the poll request is canceled after the poller picked up the task but before it started it
+	pollCtx, cancel := context.WithCancel(context.Background())
+	result, err := tlm.getTask(pollCtx)
+	require.NoError(t, err)
+	require.True(t, result.syncMatch)
+	cancel()
+
+	matched := <-done
+	require.NoError(t, matched.err)
+	require.Nil(t, matched.response, "the task must be persisted once the poller is gone")
+}
+
+func TestTrySyncMatch_PollerGoneAfterStart(t *testing.T) {
+	tlm := createTestTaskListManager()
+	tlm.tasksForPoll = make(chan *getTaskResult, 1)
+	done := trySyncMatchAsync(tlm)
+
+	pollCtx, cancel := context.WithCancel(context.Background())
+	result, err := tlm.getTask(pollCtx)
+	require.NoError(t, err)
+	result.C <- &syncMatchResponse{response: &persistence.CreateTasksResponse{}}
+	cancel()
+
+	matched := <-done
+	require.NoError(t, matched.err)
+	require.NotNil(t, matched.response, "a task started before the poll request ended must not be persisted again")
+}
+
+func TestTrySyncMatch_NoBudgetLeft(t *testing.T) {
+	cfg := NewConfig(dynamicconfig.NewNopCollection())
+	cfg.SyncMatchPersistReserve = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(time.Second)
+	tlm := createTestTaskListManagerWithConfig(cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	r, err := tlm.trySyncMatch(ctx, &persistence.TaskInfo{})
+	require.NoError(t, err)
+	require.Nil(t, r)
+	select {
+	case <-tlm.tasksForPoll:
+		t.Fatal("task must not be offered to pollers without budget to wait for them")
+	default:
+	}
+}
+
+func TestTrySyncMatch_PrefersIsolationGroup(t *testing.T) {
+	cfg := NewConfig(dynamicconfig.NewNopCollection())
+	cfg.EnableIsolationGroups = dynamicconfig.GetBoolPropertyFnFilteredByTaskListInfo(true)
+	tlm := createTestTaskListManagerWithConfig(cfg)
+
+	// tasks of a group without pollers have no channel of their own
+	require.Nil(t, tlm.getIsolatedTasksForPoll("zone-a"))
+	require.False(t, tlm.offerTask(&getTaskResult{task: &persistence.TaskInfo{TaskID: 1, IsolationGroup: "zone-a"}}))
+
+	// only a poller of zone-a is waiting, the task is offered until it blocks on its group
+	isolatedTasksCh, removePoller := tlm.addIsolatedPoller("zone-a")
+	isolatedPoll := make(chan *getTaskResult, 1)
+	go func() { isolatedPoll <- <-isolatedTasksCh }()
+	for !tlm.offerTask(&getTaskResult{task: &persistence.TaskInfo{TaskID: 2, IsolationGroup: "zone-a"}}) {
+		runtime.Gosched()
+	}
+	require.Equal(t, int64(2), (<-isolatedPoll).task.TaskID)
+
+	// the zone-a poller is still registered but busy, the task goes to a poller of another group
+	sharedPoll := make(chan *getTaskResult, 1)
+	go func() { sharedPoll <- <-tlm.tasksForPoll }()
+	for !tlm.offerTask(&getTaskResult{task: &persistence.TaskInfo{TaskID: 3, IsolationGroup: "zone-a"}}) {
+		runtime.Gosched()
+	}
+	require.Equal(t, int64(3), (<-sharedPoll).task.TaskID)
+	require.False(t, tlm.offerTask(&getTaskResult{task: &persistence.TaskInfo{TaskID: 4, IsolationGroup: "zone-a"}}))
+
+	removePoller()
+	require.Nil(t, tlm.getIsolatedTasksForPoll("zone-a"))
+}
+
+func TestGetTask_RemovesIsolationGroupWithLastPoller(t *testing.T) {
+	cfg := NewConfig(dynamicconfig.NewNopCollection())
+	cfg.EnableIsolationGroups = dynamicconfig.GetBoolPropertyFnFilteredByTaskListInfo(true)
+	tlm := createTestTaskListManagerWithConfig(cfg)
+
+	_, removeFirst := tlm.addIsolatedPoller("zone-a")
+	_, removeSecond := tlm.addIsolatedPoller("zone-a")
+	removeFirst()
+	require.NotNil(t, tlm.getIsolatedTasksForPoll("zone-a"))
+	removeSecond()
+	require.Empty(t, tlm.isolatedPollsMap)
+
+	// pollers of arbitrary groups leave nothing behind once they return
+	for i := 0; i < 10; i++ {
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), isolationGroupKey, fmt.Sprintf("zone-%v", i)))
+		cancel()
+		_, err := tlm.getTask(ctx)
+		require.Equal(t, ErrNoTasks, err)
+	}
+	require.Empty(t, tlm.isolatedPollsMap)
+}
+
+func TestGetTask_DrainedIsolationGroup(t *testing.T) {
+	cfg := NewConfig(dynamicconfig.NewNopCollection())
+	cfg.EnableIsolationGroups = dynamicconfig.GetBoolPropertyFnFilteredByTaskListInfo(true)
+	cfg.DrainedIsolationGroups = dynamicconfig.GetMapPropertyFnFilteredByDomain(map[string]interface{}{"zone-a": true})
+	tlm := createTestTaskListManagerWithConfig(cfg)
+
+	// tasks originating from the drained group are dispatched to any group
+	require.Equal(t, "", tlm.getTaskIsolationGroup(&persistence.TaskInfo{IsolationGroup: "zone-a"}))
+	require.Equal(t, "zone-b", tlm.getTaskIsolationGroup(&persistence.TaskInfo{IsolationGroup: "zone-b"}))
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), isolationGroupKey, "zone-a"))
+	polled := make(chan error, 1)
+	go func() {
+		_, err := tlm.getTask(ctx)
+		polled <- err
+	}()
+	// pollers of a drained group are neither registered for their group nor offered any task
+	for i := 0; i < 100; i++ {
+		require.False(t, tlm.offerTask(&getTaskResult{task: &persistence.TaskInfo{IsolationGroup: "zone-b"}}))
+		require.Nil(t, tlm.getIsolatedTasksForPoll("zone-a"))
+		runtime.Gosched()
+	}
+	cancel()
+	require.Equal(t, ErrNoTasks, <-polled)
+}
+
+func TestPollerHistoryRestore(t *testing.T) {
+	pollers := newPollerHistory()
+	pollers.updatePollerInfo(pollerIdentity{identity: "live"}, "1.0.0")
+	pollers.restorePollerInfo([]*persistence.TaskListPollerInfo{
+		{Identity: "live", Version: "0.9.0", LastAccessTime: time.Now().Add(-time.Minute)},
+		{Identity: "restored", Version: "0.9.0", LastAccessTime: time.Now().Add(-time.Minute)},
+		{Identity: "stale", LastAccessTime: time.Now().Add(-2 * pollerHistoryTTL)},
+	})
+
+	persisted := pollers.getPersistablePollerInfo()
+	require.Equal(t, 2, len(persisted))
+	require.Equal(t, "live", persisted[0].Identity)
+	require.Equal(t, "1.0.0", persisted[0].Version)
+	require.Equal(t, "restored", persisted[1].Identity)
+	require.Equal(t, "0.9.0", persisted[1].Version)
+}
+
+func TestPollerHistoryPersistedLimit(t *testing.T) {
+	pollers := newPollerHistory()
+	for i := 0; i < pollerHistoryMaxPersisted+5; i++ {
+		pollers.updatePollerInfo(pollerIdentity{identity: fmt.Sprintf("poller-%v", i)}, "")
+	}
+	require.Equal(t, pollerHistoryMaxPersisted+5, len(pollers.getAllPollerInfo()))
+	require.Equal(t, pollerHistoryMaxPersisted, len(pollers.getPersistablePollerInfo()))
+}
+
+func TestTaskWriterGetWriteBatch_GroupCommit(t *testing.T) {
+	cfg := NewConfig(dynamicconfig.NewNopCollection())
+	cfg.MaxTaskBatchSize = dynamicconfig.GetIntPropertyFilteredByTaskListInfo(3)
+	cfg.MaxTaskBatchWait = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(time.Second)
+	tlm := createTestTaskListManagerWithConfig(cfg)
+	w := tlm.taskWriter
+
+	go func() {
+		for i := 0; i < 3; i++ {
+			time.Sleep(10 * time.Millisecond)
+			w.appendCh <- &writeTaskRequest{}
+		}
+	}()
+	start := time.Now()
+	reqs := w.getWriteBatch([]*writeTaskRequest{{}})
+	require.Equal(t, 4, len(reqs), "the batch waits for late requests until it is full")
+	require.True(t, time.Since(start) < time.Second, "a full batch is written without waiting for the timer")
+}
+
+func TestTaskWriterGetWriteBatch_WaitExpires(t *testing.T) {
+	cfg := NewConfig(dynamicconfig.NewNopCollection())
+	cfg.MaxTaskBatchWait = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(20 * time.Millisecond)
+	tlm := createTestTaskListManagerWithConfig(cfg)
+	w := tlm.taskWriter
+
+	w.appendCh <- &writeTaskRequest{}
+	reqs := w.getWriteBatch([]*writeTaskRequest{{}})
+	require.Equal(t, 2, len(reqs))
+}
+
+func TestTaskWriterGetWriteBatch_NoWait(t *testing.T) {
+	tlm := createTestTaskListManager()
+	w := tlm.taskWriter
+
+	w.appendCh <- &writeTaskRequest{}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		w.appendCh <- &writeTaskRequest{}
+	}()
+	reqs := w.getWriteBatch([]*writeTaskRequest{{}})
+	require.Equal(t, 2, len(reqs), "without a batch wait only the queued requests are written")
+}
+
+func TestReadTasks_HigherPriorityLevelsFirst(t *testing.T) {
+	tlm := createTestTaskListManager()
+	require.NoError(t, tlm.updateRangeIfNeeded())
+	tlm.taskWriter.Start()
+	defer tlm.taskWriter.Stop()
+
+	execution := &workflow.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr("rid")}
+	for i, priority := range []int32{0, 2, 0, 5} {
+		taskInfo := &persistence.TaskInfo{DomainID: "domain", WorkflowID: "wid", RunID: "rid", ScheduleID: int64(i), Priority: priority}
+		_, err := tlm.taskWriter.appendTask(execution, taskInfo, tlm.getRangeID())
+		require.NoError(t, err)
+	}
+
+	tasks, err := tlm.readTasks()
+	require.NoError(t, err)
+	var priorities []int32
+	for _, task := range tasks {
+		priorities = append(priorities, task.Priority)
+	}
+	require.Equal(t, []int32{5, 2, 0, 0}, priorities)
+	require.Equal(t, int64(4), tlm.taskAckManager.getBacklogCountHint())
+
+	// the ack level only moves past a task once it is acked on its own level
+	tlm.completeTaskPoll(persistence.TaskPriorityLevel(5), tasks[0].TaskID)
+	tlm.completeTaskPoll(persistence.TaskPriorityLevel(2), tasks[1].TaskID)
+	require.Equal(t, tasks[2].TaskID-1, tlm.getAckLevel())
+	tlm.completeTaskPoll(persistence.TaskPriorityLevel(0), tasks[2].TaskID)
+	tlm.completeTaskPoll(persistence.TaskPriorityLevel(0), tasks[3].TaskID)
+	require.Equal(t, int64(0), tlm.taskAckManager.getBacklogCountHint())
+
+	tasks, err = tlm.readTasks()
+	require.NoError(t, err)
+	require.Empty(t, tasks)
+	require.Equal(t, tlm.taskWriter.GetMaxReadLevel(), tlm.getAckLevel())
+}
+
+func TestGetBacklogCountHint(t *testing.T) {
+	tlm := createTestTaskListManager()
+	require.NoError(t, tlm.updateRangeIfNeeded())
+	tlm.taskWriter.Start()
+	defer tlm.taskWriter.Stop()
+	require.Equal(t, int64(0), tlm.getBacklogCountHint())
+
+	execution := &workflow.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr("rid")}
+	for i, priority := range []int32{0, 2, 0} {
+		taskInfo := &persistence.TaskInfo{DomainID: "domain", WorkflowID: "wid", RunID: "rid", ScheduleID: int64(i), Priority: priority}
+		_, err := tlm.taskWriter.appendTask(execution, taskInfo, tlm.getRangeID())
+		require.NoError(t, err)
+	}
+	require.Equal(t, int64(3), tlm.getBacklogCountHint())
+
+	// the backlog only counts the tasks not read yet, whether they are acked or not
+	tasks, err := tlm.readTasks()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(tasks))
+	require.Equal(t, int64(0), tlm.getBacklogCountHint())
+}
+
+func TestReadTasks_SkipsEmptyPriorityLevels(t *testing.T) {
+	tlm := createTestTaskListManager()
+	require.NoError(t, tlm.updateRangeIfNeeded())
+	tlm.taskWriter.Start()
+	defer tlm.taskWriter.Stop()
+	persisted := tlm.engine.taskManager.(*testTaskManager).getTaskListManager(tlm.taskListID)
+
+	execution := &workflow.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr("rid")}
+	appendTask := func(priority int32) {
+		taskInfo := &persistence.TaskInfo{DomainID: "domain", WorkflowID: "wid", RunID: "rid", Priority: priority}
+		_, err := tlm.taskWriter.appendTask(execution, taskInfo, tlm.getRangeID())
+		require.NoError(t, err)
+	}
+
+	// only the level written to is read
+	appendTask(2)
+	highTasks, err := tlm.readTasks()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(highTasks))
+	require.Equal(t, [persistence.TaskPriorityLevels]int{0, 0, 1, 0}, persisted.getTasksCount)
+
+	// no level has a task written past its read level
+	tasks, err := tlm.readTasks()
+	require.NoError(t, err)
+	require.Empty(t, tasks)
+	require.Equal(t, [persistence.TaskPriorityLevels]int{0, 0, 1, 0}, persisted.getTasksCount)
+
+	appendTask(0)
+	lowTasks, err := tlm.readTasks()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(lowTasks))
+	require.Equal(t, int32(0), lowTasks[0].Priority)
+	require.Equal(t, [persistence.TaskPriorityLevels]int{1, 0, 1, 0}, persisted.getTasksCount)
+
+	// the read levels of the skipped levels follow the max read level, they do not hold the ack level back
+	tlm.completeTaskPoll(persistence.TaskPriorityLevel(2), highTasks[0].TaskID)
+	tlm.completeTaskPoll(persistence.TaskPriorityLevel(0), lowTasks[0].TaskID)
+	require.Equal(t, tlm.taskWriter.GetMaxReadLevel(), tlm.getAckLevel())
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"container/heap"
+	"container/list"
+
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// Orders the tasks loaded from persistence for dispatch. Tasks of higher priority are dispatched first and
+	// tasks of the same priority in task ID order. To protect tasks of low priority from starvation, the oldest
+	// task is dispatched once it has been passed over starvationLimit times.
+	taskPriorityQueue struct {
+		byPriority priorityTaskHeap
+		byAge      *list.List // tasks in the order they were added, which is task ID order
+		skips      int        // times the oldest task was passed over
+	}
+
+	priorityTask struct {
+		task  *persistence.TaskInfo
+		index int           // index in byPriority
+		elem  *list.Element // element in byAge
+	}
+
+	priorityTaskHeap []*priorityTask
+)
+
+func newTaskPriorityQueue() *taskPriorityQueue {
+	return &taskPriorityQueue{byAge: list.New()}
+}
+
+func (q *taskPriorityQueue) len() int {
+	return len(q.byPriority)
+}
+
+func (q *taskPriorityQueue) add(task *persistence.TaskInfo) {
+	t := &priorityTask{task: task}
+	t.elem = q.byAge.PushBack(t)
+	heap.Push(&q.byPriority, t)
+}
+
+// Removes and returns the next task to dispatch, or nil if the queue is empty.
+func (q *taskPriorityQueue) next(starvationLimit int) *persistence.TaskInfo {
+	if q.len() == 0 {
+		return nil
+	}
+
+	oldest := q.byAge.Front().Value.(*priorityTask)
+	next := q.byPriority[0]
+	if next != oldest && q.skips >= starvationLimit {
+		next = oldest
+	}
+	if next == oldest {
+		q.skips = 0
+	} else {
+		q.skips++
+	}
+
+	heap.Remove(&q.byPriority, next.index)
+	q.byAge.Remove(next.elem)
+	return next.task
+}
+
+func (h priorityTaskHeap) Len() int {
+	return len(h)
+}
+
+func (h priorityTaskHeap) Less(i, j int) bool {
+	if h[i].task.Priority != h[j].task.Priority {
+		return h[i].task.Priority > h[j].task.Priority
+	}
+	return h[i].task.TaskID < h[j].task.TaskID
+}
+
+func (h priorityTaskHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *priorityTaskHeap) Push(x interface{}) {
+	t := x.(*priorityTask)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *priorityTaskHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return t
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber/cadence/common/persistence"
+)
+
+func addPriorityTasks(q *taskPriorityQueue, priorities ...int32) {
+	for i, priority := range priorities {
+		q.add(&persistence.TaskInfo{TaskID: int64(i + 1), Priority: priority})
+	}
+}
+
+func nextTaskIDs(q *taskPriorityQueue, starvationLimit int) []int64 {
+	var taskIDs []int64
+	for q.len() > 0 {
+		taskIDs = append(taskIDs, q.next(starvationLimit).TaskID)
+	}
+	return taskIDs
+}
+
+func TestTaskPriorityQueue_OrdersByPriority(t *testing.T) {
+	q := newTaskPriorityQueue()
+	require.Nil(t, q.next(10))
+
+	addPriorityTasks(q, 0, 5, 0, 9, 5)
+	require.Equal(t, []int64{4, 2, 5, 1, 3}, nextTaskIDs(q, 10))
+}
+
+func TestTaskPriorityQueue_StarvationLimit(t *testing.T) {
+	q := newTaskPriorityQueue()
+	addPriorityTasks(q, 0, 1, 1, 1, 1, 0)
+	// the oldest task is passed over twice before it is dispatched
+	require.Equal(t, []int64{2, 3, 1, 4, 5, 6}, nextTaskIDs(q, 2))
+}
+
+func TestTaskPriorityQueue_ZeroStarvationLimit(t *testing.T) {
+	q := newTaskPriorityQueue()
+	addPriorityTasks(q, 0, 1, 2, 3)
+	require.Equal(t, []int64{1, 2, 3, 4}, nextTaskIDs(q, 0))
+}
+
+func TestTaskPriorityQueue_Add(t *testing.T) {
+	q := newTaskPriorityQueue()
+	addPriorityTasks(q, 0, 0)
+	require.Equal(t, int64(1), q.next(10).TaskID)
+
+	q.add(&persistence.TaskInfo{TaskID: 3, Priority: 1})
+	require.Equal(t, 2, q.len())
+	require.Equal(t, int64(3), q.next(10).TaskID)
+	require.Equal(t, int64(2), q.next(10).TaskID)
+}
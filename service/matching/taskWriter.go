@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"sync/atomic"
+	"time"
 
 	"github.com/uber-common/bark"
 	s "github.com/uber/cadence/.gen/go/shared"
@@ -55,6 +56,9 @@ type (
 		stopped      int64 // set to 1 if the writer is stopped or is shutting down
 		logger       bark.Logger
 		stopCh       chan struct{} // shutdown signal for all routines in this class
+		// lastWrittenIDs holds the ID of the last task written to each priority level, a level read up to it has
+		// no task left to read
+		lastWrittenIDs [persistence.TaskPriorityLevels]int64
 	}
 )
 
@@ -75,6 +79,10 @@ func newTaskWriter(tlMgr *taskListManagerImpl) *taskWriter {
 
 func (w *taskWriter) Start() {
 	w.maxReadLevel = w.tlMgr.getTaskSequenceNumber() - 1
+	// the tasks written before the task list got loaded can be of any priority level
+	for level := range w.lastWrittenIDs {
+		w.lastWrittenIDs[level] = w.maxReadLevel
+	}
 	go w.taskWriterLoop()
 }
 
@@ -115,7 +123,7 @@ func (w *taskWriter) appendTask(execution *s.WorkflowExecution,
 			return nil, errShutdown
 		}
 	default: // channel is full, throttle
-		return nil, createServiceBusyError("Too many outstanding appends to the TaskList")
+		return nil, createServiceBusyError("Too many outstanding appends to the TaskList", w.tlMgr.getBacklogCountHint())
 	}
 }
 
@@ -123,6 +131,12 @@ func (w *taskWriter) GetMaxReadLevel() int64 {
 	return atomic.LoadInt64(&w.maxReadLevel)
 }
 
+// getLastWrittenID returns the ID of the last task written to a priority level. It is updated before the max read
+// level, so a level whose read level is at or above it has no task up to a max read level loaded before.
+func (w *taskWriter) getLastWrittenID(priorityLevel int) int64 {
+	return atomic.LoadInt64(&w.lastWrittenIDs[priorityLevel])
+}
+
 func (w *taskWriter) taskWriterLoop() {
 writerLoop:
 	for {
@@ -135,6 +149,7 @@ writerLoop:
 				batchSize := len(reqs)
 
 				maxReadLevel := int64(0)
+				var lastWrittenIDs [persistence.TaskPriorityLevels]int64
 
 				taskIDs, err := w.tlMgr.newTaskIDs(batchSize)
 				if err != nil {
@@ -154,6 +169,7 @@ writerLoop:
 						rangeID = req.rangeID // use the maximum rangeID provided for the write operation
 					}
 					maxReadLevel = taskIDs[i]
+					lastWrittenIDs[persistence.TaskPriorityLevel(req.taskInfo.Priority)] = taskIDs[i]
 				}
 
 				tlInfo := &persistence.TaskListInfo{
@@ -180,7 +196,13 @@ writerLoop:
 							taskIDs[0], taskIDs[batchSize-1], w.taskListID.taskType, w.taskListID.taskListName))
 				}
 
-				// Update the maxReadLevel after the writes are completed.
+				// Update the maxReadLevel after the writes are completed, and after the last written IDs of the
+				// levels so that the readers do not skip a level with tasks below it.
+				for level, taskID := range lastWrittenIDs {
+					if taskID > 0 {
+						atomic.StoreInt64(&w.lastWrittenIDs[level], taskID)
+					}
+				}
 				if maxReadLevel > 0 {
 					atomic.StoreInt64(&w.maxReadLevel, maxReadLevel)
 				}
@@ -196,9 +218,13 @@ writerLoop:
 	}
 }
 
+// getWriteBatch adds the requests already queued to the batch.  With a batch wait configured, it keeps collecting
+// requests until the batch is full or the wait expires, so a task list with a sustained add rate commits many tasks
+// per write at the price of a higher add task latency.
 func (w *taskWriter) getWriteBatch(reqs []*writeTaskRequest) []*writeTaskRequest {
+	maxBatchSize := w.config.MaxTaskBatchSize()
 readLoop:
-	for i := 0; i < w.config.MaxTaskBatchSize(); i++ {
+	for i := 0; i < maxBatchSize; i++ {
 		select {
 		case req := <-w.appendCh:
 			reqs = append(reqs, req)
@@ -206,6 +232,23 @@ readLoop:
 			break readLoop
 		}
 	}
+
+	batchWait := w.config.MaxTaskBatchWait()
+	if batchWait <= 0 || len(reqs) > maxBatchSize {
+		return reqs
+	}
+	timer := time.NewTimer(batchWait)
+	defer timer.Stop()
+	for len(reqs) <= maxBatchSize {
+		select {
+		case req := <-w.appendCh:
+			reqs = append(reqs, req)
+		case <-timer.C:
+			return reqs
+		case <-w.stopCh:
+			return reqs
+		}
+	}
 	return reqs
 }
 
@@ -0,0 +1,83 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"time"
+
+	"github.com/uber/cadence/common/cache"
+)
+
+const (
+	workerRegistryInitSize    = 0
+	workerRegistryInitMaxSize = 1000
+	workerRegistryTTL         = 5 * time.Minute
+)
+
+type (
+	workerInfo struct {
+		identity          string
+		capabilities      []string
+		currentLoad       int32
+		lastHeartbeatTime time.Time
+	}
+)
+
+// workerRegistry keeps the workers which heartbeated on a tasklist in last few minutes. Unlike the poller history
+// it is fed by the workers themselves, so a worker which stopped polling is still known to be connected.
+type workerRegistry struct {
+	// worker identity -> worker info
+	workers cache.Cache
+}
+
+func newWorkerRegistry() *workerRegistry {
+	opts := &cache.Options{
+		InitialCapacity: workerRegistryInitSize,
+		TTL:             workerRegistryTTL,
+		Pin:             false,
+	}
+
+	return &workerRegistry{
+		workers: cache.New(workerRegistryInitMaxSize, opts),
+	}
+}
+
+func (r *workerRegistry) recordHeartbeat(identity string, capabilities []string, currentLoad int32) {
+	r.workers.Put(identity, &workerInfo{
+		identity:          identity,
+		capabilities:      capabilities,
+		currentLoad:       currentLoad,
+		lastHeartbeatTime: time.Now(),
+	})
+}
+
+func (r *workerRegistry) getAllWorkerInfo() []*workerInfo {
+	result := []*workerInfo{}
+
+	ite := r.workers.Iterator()
+	defer ite.Close()
+	for ite.HasNext() {
+		entry := ite.Next()
+		result = append(result, entry.Value().(*workerInfo))
+	}
+
+	return result
+}
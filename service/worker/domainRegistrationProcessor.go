@@ -0,0 +1,319 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+const (
+	domainRegistrationListPageSize  = 100
+	domainRegistrationReviewTimeout = 10 * time.Second
+)
+
+// The decisions of a domain registration review
+const (
+	// DomainRegistrationPending keeps the domain pending, it is reviewed again on the next round
+	DomainRegistrationPending DomainRegistrationDecision = iota
+	// DomainRegistrationApproved makes the domain usable
+	DomainRegistrationApproved
+	// DomainRegistrationRejected deletes the domain with the reason of the rejection, its name can be registered again
+	DomainRegistrationRejected
+)
+
+type (
+	// DomainRegistrationDecision is the outcome of the review of a domain pending registration approval
+	DomainRegistrationDecision int
+
+	// DomainRegistrationPolicy reviews the domains pending registration approval and returns its decision
+	// together with the reason for it. A policy waiting on an external approval leaves the domain pending
+	// and is asked again on the next round, so a review must be idempotent.
+	DomainRegistrationPolicy interface {
+		Review(ctx context.Context, domain *persistence.GetDomainResponse) (DomainRegistrationDecision, string, error)
+	}
+
+	// DomainRegistrationProcessor reviews the domains pending registration approval owned by this worker host
+	// with the registration policies, and registers or rejects them according to the decision.
+	DomainRegistrationProcessor struct {
+		metadataMgr   persistence.MetadataManager
+		policy        DomainRegistrationPolicy
+		resolver      membership.ServiceResolver
+		hostIdentity  string
+		config        *Config
+		logger        bark.Logger
+		metricsClient metrics.Client
+
+		isStarted  int32
+		isStopped  int32
+		shutdownWG sync.WaitGroup
+		shutdownCh chan struct{}
+	}
+
+	// domainRegistrationPolicies runs the policies in order, the first one not approving the domain decides
+	domainRegistrationPolicies []DomainRegistrationPolicy
+
+	// emailDomainRegistrationPolicy approves the domains whose owner email is in one of the email domains
+	emailDomainRegistrationPolicy struct {
+		emailDomains dynamicconfig.StringPropertyFn
+	}
+)
+
+// NewDomainRegistrationProcessor creates a new processor for the pending domain registrations owned by the given
+// worker host. The registrations are reviewed by the default email domain policy followed by the given policies.
+func NewDomainRegistrationProcessor(metadataManagerV2 persistence.MetadataManager, resolver membership.ServiceResolver,
+	hostIdentity string, config *Config, logger bark.Logger, metricsClient metrics.Client,
+	policies ...DomainRegistrationPolicy) *DomainRegistrationProcessor {
+	policies = append([]DomainRegistrationPolicy{
+		NewEmailDomainRegistrationPolicy(config.DomainRegistrationEmailDomains),
+	}, policies...)
+	return &DomainRegistrationProcessor{
+		metadataMgr:  metadataManagerV2,
+		policy:       domainRegistrationPolicies(policies),
+		resolver:     resolver,
+		hostIdentity: hostIdentity,
+		config:       config,
+		logger: logger.WithFields(bark.Fields{
+			logging.TagWorkflowComponent: logging.TagValueDomainRegistrationComponent,
+		}),
+		metricsClient: metricsClient,
+		shutdownCh:    make(chan struct{}),
+	}
+}
+
+// NewEmailDomainRegistrationPolicy creates a policy approving the domains whose owner email is in one of the comma
+// separated email domains and rejecting the others, every domain is approved while no email domain is set
+func NewEmailDomainRegistrationPolicy(emailDomains dynamicconfig.StringPropertyFn) DomainRegistrationPolicy {
+	return &emailDomainRegistrationPolicy{emailDomains: emailDomains}
+}
+
+// Start starts the processor
+func (p *DomainRegistrationProcessor) Start() {
+	if !atomic.CompareAndSwapInt32(&p.isStarted, 0, 1) {
+		return
+	}
+
+	p.shutdownWG.Add(1)
+	go p.processorPump()
+	p.logger.Info("Domain registration processor started.")
+}
+
+// Stop stops the processor
+func (p *DomainRegistrationProcessor) Stop() {
+	if !atomic.CompareAndSwapInt32(&p.isStopped, 0, 1) {
+		return
+	}
+
+	if atomic.LoadInt32(&p.isStarted) == 1 {
+		close(p.shutdownCh)
+	}
+
+	if success := common.AwaitWaitGroup(&p.shutdownWG, time.Minute); !success {
+		p.logger.Warn("Domain registration processor timed out on shutdown.")
+	}
+	p.logger.Info("Domain registration processor stopped.")
+}
+
+func (p *DomainRegistrationProcessor) processorPump() {
+	defer p.shutdownWG.Done()
+
+	timer := time.NewTimer(p.config.DomainRegistrationInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case <-timer.C:
+			p.processDomains()
+			timer.Reset(p.config.DomainRegistrationInterval())
+		}
+	}
+}
+
+func (p *DomainRegistrationProcessor) processDomains() {
+	var token []byte
+	for {
+		resp, err := p.metadataMgr.ListDomains(&persistence.ListDomainsRequest{
+			PageSize:         domainRegistrationListPageSize,
+			NextPageToken:    token,
+			IncludeV1Domains: true,
+		})
+		if err != nil {
+			p.metricsClient.IncCounter(metrics.DomainRegistrationScope, metrics.DomainRegistrationFailures)
+			p.logger.WithField(logging.TagErr, err).Warn("Failed to list domains.")
+			return
+		}
+
+		for _, domain := range resp.Domains {
+			if domain.Info.Status == persistence.DomainStatusPendingApproval && p.isDomainOwned(domain.Info) {
+				if err := p.processDomain(domain); err != nil {
+					p.metricsClient.IncCounter(metrics.DomainRegistrationScope, metrics.DomainRegistrationFailures)
+					p.logger.WithFields(bark.Fields{
+						logging.TagDomainID: domain.Info.ID,
+						logging.TagErr:      err,
+					}).Warn("Failed to process domain registration.")
+				}
+			}
+		}
+
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			return
+		}
+	}
+}
+
+// isDomainOwned returns whether this host reviews the registration of the domain
+func (p *DomainRegistrationProcessor) isDomainOwned(domain *persistence.DomainInfo) bool {
+	host, err := p.resolver.Lookup(domain.ID)
+	if err != nil {
+		p.logger.WithField(logging.TagErr, err).Warn("Failed to lookup the worker host of a domain.")
+		return false
+	}
+	return host.Identity() == p.hostIdentity
+}
+
+func (p *DomainRegistrationProcessor) processDomain(domain *persistence.GetDomainResponse) error {
+	ctx, cancel := context.WithTimeout(context.Background(), domainRegistrationReviewTimeout)
+	defer cancel()
+	decision, reason, err := p.policy.Review(ctx, domain)
+	if err != nil {
+		return err
+	}
+
+	logger := p.logger.WithFields(bark.Fields{
+		logging.TagDomainID: domain.Info.ID,
+	})
+	switch decision {
+	case DomainRegistrationApproved:
+		if err := p.approveDomain(domain.Info.ID); err != nil {
+			return err
+		}
+		p.metricsClient.IncCounter(metrics.DomainRegistrationScope, metrics.DomainRegistrationApproved)
+		logger.Infof("Approved registration of domain %v: %v", domain.Info.Name, reason)
+	case DomainRegistrationRejected:
+		if err := p.rejectDomain(domain.Info.ID, reason); err != nil {
+			return err
+		}
+		p.metricsClient.IncCounter(metrics.DomainRegistrationScope, metrics.DomainRegistrationRejected)
+		logger.Infof("Rejected registration of domain %v: %v", domain.Info.Name, reason)
+	}
+	return nil
+}
+
+// approveDomain registers the pending domain
+func (p *DomainRegistrationProcessor) approveDomain(domainID string) error {
+	return p.updatePendingDomain(domainID, func(info *persistence.DomainInfo) {
+		info.Status = persistence.DomainStatusRegistered
+	})
+}
+
+// rejectDomain deletes the pending domain, it is kept with the reason of the rejection so the owner can describe
+// it, until the name is registered again
+func (p *DomainRegistrationProcessor) rejectDomain(domainID string, reason string) error {
+	return p.updatePendingDomain(domainID, func(info *persistence.DomainInfo) {
+		info.Status = persistence.DomainStatusDeleted
+		data := make(map[string]string, len(info.Data)+1)
+		for key, value := range info.Data {
+			data[key] = value
+		}
+		data[persistence.DomainDataKeyRegistrationRejectedReason] = reason
+		info.Data = data
+	})
+}
+
+// updatePendingDomain updates the info of the domain if it is still pending, it is read again after the notification
+// version, which acts as the lock of the domain table, so an update of the domain made during the review is not lost
+func (p *DomainRegistrationProcessor) updatePendingDomain(domainID string, update func(*persistence.DomainInfo)) error {
+	metadata, err := p.metadataMgr.GetMetadata()
+	if err != nil {
+		return err
+	}
+	domain, err := p.metadataMgr.GetDomain(&persistence.GetDomainRequest{ID: domainID})
+	if err != nil {
+		return err
+	}
+	if domain.Info.Status != persistence.DomainStatusPendingApproval {
+		return nil
+	}
+
+	update(domain.Info)
+	request := &persistence.UpdateDomainRequest{
+		Info:                        domain.Info,
+		Config:                      domain.Config,
+		ReplicationConfig:           domain.ReplicationConfig,
+		ConfigVersion:               domain.ConfigVersion + 1,
+		FailoverVersion:             domain.FailoverVersion,
+		FailoverNotificationVersion: domain.FailoverNotificationVersion,
+		NotificationVersion:         metadata.NotificationVersion,
+		TableVersion:                domain.TableVersion,
+	}
+	if domain.TableVersion == persistence.DomainTableVersionV1 {
+		// a domain of the v1 table is versioned on its own
+		request.NotificationVersion = domain.NotificationVersion
+	}
+	return p.metadataMgr.UpdateDomain(request)
+}
+
+// Review runs the policies in order until one of them does not approve the domain
+func (policies domainRegistrationPolicies) Review(ctx context.Context,
+	domain *persistence.GetDomainResponse) (DomainRegistrationDecision, string, error) {
+	var reasons []string
+	for _, policy := range policies {
+		decision, reason, err := policy.Review(ctx, domain)
+		if err != nil || decision != DomainRegistrationApproved {
+			return decision, reason, err
+		}
+		reasons = append(reasons, reason)
+	}
+	return DomainRegistrationApproved, strings.Join(reasons, "; "), nil
+}
+
+// Review approves the domain if its owner email is in one of the email domains
+func (policy *emailDomainRegistrationPolicy) Review(ctx context.Context,
+	domain *persistence.GetDomainResponse) (DomainRegistrationDecision, string, error) {
+	emailDomains := policy.emailDomains()
+	if emailDomains == "" {
+		return DomainRegistrationApproved, "no email domain is required", nil
+	}
+
+	ownerEmail := strings.ToLower(domain.Info.OwnerEmail)
+	for _, emailDomain := range strings.Split(emailDomains, ",") {
+		emailDomain = strings.ToLower(strings.TrimSpace(emailDomain))
+		if emailDomain != "" && strings.HasSuffix(ownerEmail, "@"+emailDomain) {
+			return DomainRegistrationApproved, fmt.Sprintf("owner email is in email domain %v", emailDomain), nil
+		}
+	}
+	return DomainRegistrationRejected, fmt.Sprintf("owner email %q is not in any of the email domains %v",
+		domain.Info.OwnerEmail, emailDomains), nil
+}
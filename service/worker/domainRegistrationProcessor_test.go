@@ -0,0 +1,226 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	domainRegistrationProcessorSuite struct {
+		suite.Suite
+		mockMetadataMgr *mocks.MetadataManager
+		mockResolver    *mocks.ServiceResolver
+		config          *Config
+	}
+
+	testDomainRegistrationPolicy struct {
+		decision DomainRegistrationDecision
+		err      error
+	}
+)
+
+func TestDomainRegistrationProcessorSuite(t *testing.T) {
+	s := new(domainRegistrationProcessorSuite)
+	suite.Run(t, s)
+}
+
+func (s *domainRegistrationProcessorSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+}
+
+func (s *domainRegistrationProcessorSuite) SetupTest() {
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+	s.mockResolver = &mocks.ServiceResolver{}
+	s.config = NewConfig(dynamicconfig.NewNopCollection())
+	s.config.DomainRegistrationEmailDomains = dynamicconfig.GetStringPropertyFn("example.com, corp.example.com")
+}
+
+func (s *domainRegistrationProcessorSuite) TearDownTest() {
+	s.mockMetadataMgr.AssertExpectations(s.T())
+	s.mockResolver.AssertExpectations(s.T())
+}
+
+func (s *domainRegistrationProcessorSuite) newProcessor(policies ...DomainRegistrationPolicy) *DomainRegistrationProcessor {
+	return NewDomainRegistrationProcessor(
+		s.mockMetadataMgr,
+		s.mockResolver,
+		"self",
+		s.config,
+		bark.NewLoggerFromLogrus(logrus.New()),
+		metrics.NewClient(tally.NoopScope, metrics.Worker),
+		policies...,
+	)
+}
+
+func (s *domainRegistrationProcessorSuite) pendingDomain(id string, ownerEmail string) *persistence.GetDomainResponse {
+	return &persistence.GetDomainResponse{
+		Info: &persistence.DomainInfo{
+			ID:         id,
+			Name:       id + "-name",
+			Status:     persistence.DomainStatusPendingApproval,
+			OwnerEmail: ownerEmail,
+		},
+		Config:            &persistence.DomainConfig{Retention: 7},
+		ReplicationConfig: &persistence.DomainReplicationConfig{ActiveClusterName: "active"},
+		ConfigVersion:     3,
+		FailoverVersion:   5,
+		TableVersion:      persistence.DomainTableVersionV2,
+	}
+}
+
+func (s *domainRegistrationProcessorSuite) TestProcessDomains() {
+	registered := s.pendingDomain("registered-domain", "owner@other.com")
+	registered.Info.Status = persistence.DomainStatusRegistered
+	s.mockMetadataMgr.On("ListDomains", mock.Anything).Return(&persistence.ListDomainsResponse{
+		Domains: []*persistence.GetDomainResponse{
+			s.pendingDomain("approved-domain", "Owner@Corp.Example.com"),
+			s.pendingDomain("rejected-domain", "owner@other.com"),
+			s.pendingDomain("other-domain", "owner@other.com"),
+			registered,
+		},
+	}, nil).Once()
+	s.mockResolver.On("Lookup", "approved-domain").Return(membership.NewHostInfo("self", nil), nil).Once()
+	s.mockResolver.On("Lookup", "rejected-domain").Return(membership.NewHostInfo("self", nil), nil).Once()
+	s.mockResolver.On("Lookup", "other-domain").Return(membership.NewHostInfo("other", nil), nil).Once()
+
+	approved := s.pendingDomain("approved-domain", "Owner@Corp.Example.com")
+	approved.Info.Description = "updated during the review"
+	s.mockMetadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: 11}, nil).Twice()
+	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: "approved-domain"}).Return(approved, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: "rejected-domain"}).
+		Return(s.pendingDomain("rejected-domain", "owner@other.com"), nil).Once()
+	s.mockMetadataMgr.On("UpdateDomain", mock.MatchedBy(func(request *persistence.UpdateDomainRequest) bool {
+		return request.Info.ID == "approved-domain"
+	})).Return(nil).Run(func(args mock.Arguments) {
+		request := args.Get(0).(*persistence.UpdateDomainRequest)
+		s.Equal(persistence.DomainStatusRegistered, request.Info.Status)
+		s.Equal("updated during the review", request.Info.Description)
+		s.Equal(int64(4), request.ConfigVersion)
+		s.Equal(int64(5), request.FailoverVersion)
+		s.Equal(int64(11), request.NotificationVersion)
+		s.Equal(persistence.DomainTableVersionV2, request.TableVersion)
+	}).Once()
+	s.mockMetadataMgr.On("UpdateDomain", mock.MatchedBy(func(request *persistence.UpdateDomainRequest) bool {
+		return request.Info.ID == "rejected-domain"
+	})).Return(nil).Run(func(args mock.Arguments) {
+		request := args.Get(0).(*persistence.UpdateDomainRequest)
+		s.Equal(persistence.DomainStatusDeleted, request.Info.Status)
+		s.Contains(request.Info.Data[persistence.DomainDataKeyRegistrationRejectedReason], "owner@other.com")
+		s.Equal(int64(11), request.NotificationVersion)
+	}).Once()
+
+	s.newProcessor().processDomains()
+}
+
+func (s *domainRegistrationProcessorSuite) TestProcessDomains_ListsV1Domains() {
+	s.mockMetadataMgr.On("ListDomains", &persistence.ListDomainsRequest{
+		PageSize:         domainRegistrationListPageSize,
+		IncludeV1Domains: true,
+	}).Return(&persistence.ListDomainsResponse{}, nil).Once()
+
+	s.newProcessor().processDomains()
+}
+
+func (s *domainRegistrationProcessorSuite) TestProcessDomain_Pending() {
+	processor := s.newProcessor(&testDomainRegistrationPolicy{decision: DomainRegistrationPending})
+	s.NoError(processor.processDomain(s.pendingDomain("pending-domain", "owner@example.com")))
+}
+
+func (s *domainRegistrationProcessorSuite) TestProcessDomain_PolicyError() {
+	processor := s.newProcessor(&testDomainRegistrationPolicy{err: errors.New("some random error")})
+	s.Error(processor.processDomain(s.pendingDomain("pending-domain", "owner@example.com")))
+}
+
+func (s *domainRegistrationProcessorSuite) TestProcessDomain_ApprovedByAllPolicies() {
+	s.config.DomainRegistrationEmailDomains = dynamicconfig.GetStringPropertyFn("")
+	processor := s.newProcessor(&testDomainRegistrationPolicy{decision: DomainRegistrationApproved})
+
+	s.mockMetadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: 1}, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: "pending-domain"}).
+		Return(s.pendingDomain("pending-domain", "owner@other.com"), nil).Once()
+	s.mockMetadataMgr.On("UpdateDomain", mock.Anything).Return(nil).Once()
+	s.NoError(processor.processDomain(s.pendingDomain("pending-domain", "owner@other.com")))
+}
+
+func (s *domainRegistrationProcessorSuite) TestApproveDomain_V1() {
+	pending := s.pendingDomain("pending-domain", "owner@example.com")
+	pending.TableVersion = persistence.DomainTableVersionV1
+	pending.NotificationVersion = 7
+	s.mockMetadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: 11}, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: "pending-domain"}).Return(pending, nil).Once()
+	s.mockMetadataMgr.On("UpdateDomain", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		request := args.Get(0).(*persistence.UpdateDomainRequest)
+		s.Equal(persistence.DomainStatusRegistered, request.Info.Status)
+		s.Equal(int64(7), request.NotificationVersion)
+		s.Equal(persistence.DomainTableVersionV1, request.TableVersion)
+	}).Once()
+
+	s.NoError(s.newProcessor().approveDomain("pending-domain"))
+}
+
+func (s *domainRegistrationProcessorSuite) TestApproveDomain_NoLongerPending() {
+	deprecated := s.pendingDomain("deprecated-domain", "owner@example.com")
+	deprecated.Info.Status = persistence.DomainStatusDeprecated
+	s.mockMetadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: 1}, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: "deprecated-domain"}).Return(deprecated, nil).Once()
+
+	s.NoError(s.newProcessor().approveDomain("deprecated-domain"))
+}
+
+func (policy *testDomainRegistrationPolicy) Review(ctx context.Context,
+	domain *persistence.GetDomainResponse) (DomainRegistrationDecision, string, error) {
+	return policy.decision, "test policy", policy.err
+}
+
+func (s *domainRegistrationProcessorSuite) TestRejectDomain_KeepsData() {
+	pending := s.pendingDomain("pending-domain", "owner@example.com")
+	pending.Info.Data = map[string]string{"k": "v"}
+	s.mockMetadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: 11}, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: "pending-domain"}).Return(pending, nil).Once()
+	s.mockMetadataMgr.On("UpdateDomain", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		request := args.Get(0).(*persistence.UpdateDomainRequest)
+		s.Equal(persistence.DomainStatusDeleted, request.Info.Status)
+		s.Equal(map[string]string{
+			"k": "v",
+			persistence.DomainDataKeyRegistrationRejectedReason: "some reason",
+		}, request.Info.Data)
+	}).Once()
+
+	s.NoError(s.newProcessor().rejectDomain("pending-domain", "some reason"))
+}
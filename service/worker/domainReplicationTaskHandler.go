@@ -26,6 +26,7 @@ import (
 	"github.com/uber-common/bark"
 	"github.com/uber/cadence/.gen/go/replicator"
 	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/persistence"
 )
 
@@ -101,12 +102,15 @@ func (domainReplicator *domainReplicatorImpl) handleDomainCreationReplicationTas
 			Data:        task.Info.Data,
 		},
 		Config: &persistence.DomainConfig{
-			Retention:  task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
-			EmitMetric: task.Config.GetEmitMetric(),
+			Retention:              task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
+			EmitMetric:             task.Config.GetEmitMetric(),
+			HistoryEncoding:        common.EncodingTypeFromThrift(task.Config.HistoryEncoding),
+			SuspectBinaryChecksums: task.Config.SuspectBinaryChecksums,
 		},
 		ReplicationConfig: &persistence.DomainReplicationConfig{
-			ActiveClusterName: task.ReplicationConfig.GetActiveClusterName(),
-			Clusters:          domainReplicator.convertClusterReplicationConfigFromThrift(task.ReplicationConfig.Clusters),
+			ActiveClusterName:        task.ReplicationConfig.GetActiveClusterName(),
+			Clusters:                 domainReplicator.convertClusterReplicationConfigFromThrift(task.ReplicationConfig.Clusters),
+			FailoverDrillClusterName: task.ReplicationConfig.GetFailoverDrillClusterName(),
 		},
 		IsGlobalDomain:  true, // local domain will not be replicated
 		ConfigVersion:   task.GetConfigVersion(),
@@ -165,17 +169,23 @@ func (domainReplicator *domainReplicatorImpl) handleDomainUpdateReplicationTask(
 			Status:      status,
 			Description: task.Info.GetDescription(),
 			OwnerEmail:  task.Info.GetOwnerEmail(),
+			Data:        task.Info.Data,
 		}
 		request.Config = &persistence.DomainConfig{
-			Retention:  task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
-			EmitMetric: task.Config.GetEmitMetric(),
+			Retention:              task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
+			EmitMetric:             task.Config.GetEmitMetric(),
+			HistoryEncoding:        common.EncodingTypeFromThrift(task.Config.HistoryEncoding),
+			SuspectBinaryChecksums: task.Config.SuspectBinaryChecksums,
 		}
 		request.ReplicationConfig.Clusters = domainReplicator.convertClusterReplicationConfigFromThrift(task.ReplicationConfig.Clusters)
+		request.ReplicationConfig.FailoverDrillClusterName = task.ReplicationConfig.GetFailoverDrillClusterName()
 		request.ConfigVersion = task.GetConfigVersion()
 	}
 	if resp.FailoverVersion < task.GetFailoverVersion() {
 		recordUpdated = true
 		request.ReplicationConfig.ActiveClusterName = task.ReplicationConfig.GetActiveClusterName()
+		// a failover ends the failover drill of the domain
+		request.ReplicationConfig.FailoverDrillClusterName = task.ReplicationConfig.GetFailoverDrillClusterName()
 		request.FailoverVersion = task.GetFailoverVersion()
 		request.FailoverNotificationVersion = notificationVersion
 	}
@@ -258,6 +258,7 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_UpdateC
 	updateStatus := shared.DomainStatusDeprecated
 	updateDescription := "other random domain test description"
 	updateOwnerEmail := "other random domain test owner"
+	updateData := map[string]string{"k": "v1"}
 	updateRetention := int32(122)
 	updateEmitMetric := true
 	updateClusterActive := "other random active cluster name"
@@ -280,6 +281,7 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_UpdateC
 			Status:      &updateStatus,
 			Description: common.StringPtr(updateDescription),
 			OwnerEmail:  common.StringPtr(updateOwnerEmail),
+			Data:        updateData,
 		},
 		Config: &shared.DomainConfiguration{
 			WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(updateRetention),
@@ -305,6 +307,7 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_UpdateC
 	s.Equal(persistence.DomainStatusDeprecated, resp.Info.Status)
 	s.Equal(updateDescription, resp.Info.Description)
 	s.Equal(updateOwnerEmail, resp.Info.OwnerEmail)
+	s.Equal(updateData, resp.Info.Data)
 	s.Equal(updateRetention, resp.Config.Retention)
 	s.Equal(updateEmitMetric, resp.Config.EmitMetric)
 	s.Equal(updateClusterActive, resp.ReplicationConfig.ActiveClusterName)
@@ -394,8 +397,9 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_UpdateC
 			EmitMetric:                             common.BoolPtr(updateEmitMetric),
 		},
 		ReplicationConfig: &shared.DomainReplicationConfiguration{
-			ActiveClusterName: common.StringPtr(updateClusterActive),
-			Clusters:          updateClusters,
+			ActiveClusterName:        common.StringPtr(updateClusterActive),
+			Clusters:                 updateClusters,
+			FailoverDrillClusterName: common.StringPtr(updateClusterStandby),
 		},
 		ConfigVersion:   common.Int64Ptr(updateConfigVersion),
 		FailoverVersion: common.Int64Ptr(updateFailoverVersion),
@@ -417,6 +421,7 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_UpdateC
 	s.Equal(updateEmitMetric, resp.Config.EmitMetric)
 	s.Equal(clusterActive, resp.ReplicationConfig.ActiveClusterName)
 	s.Equal(s.domainReplicator.convertClusterReplicationConfigFromThrift(updateClusters), resp.ReplicationConfig.Clusters)
+	s.Equal(updateClusterStandby, resp.ReplicationConfig.FailoverDrillClusterName)
 	s.Equal(updateConfigVersion, resp.ConfigVersion)
 	s.Equal(failoverVersion, resp.FailoverVersion)
 	s.Equal(int64(0), resp.FailoverNotificationVersion)
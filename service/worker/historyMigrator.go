@@ -0,0 +1,414 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package worker
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	historyMigratorListPageSize      = 100
+	historyMigratorExecutionPageSize = 20
+	historyMigratorBatchPageSize     = 100
+)
+
+var errReencodedBatchMismatch = errors.New("reencoded history batch does not match the original batch")
+
+type (
+	// HistoryMigrator reencodes the persisted history of the domains owned by this worker host to the encoding set
+	// with worker.historyMigrationTargetEncoding for the domain, so that the storage format of live data can be
+	// changed. Each interval it goes through the next few pages of workflow executions of every such domain, shard
+	// after shard, and reencodes their history batches. Batches are written back with the current history version,
+	// and only if history did not write them again since they were read. A domain is done once a pass over all the
+	// shards has no conflicts and no failures, another pass is made otherwise.
+	HistoryMigrator struct {
+		metadataMgr       persistence.MetadataManager
+		shardMgr          persistence.ShardManager
+		historyMgr        persistence.HistoryManager
+		numberOfShards    int
+		serializerFactory persistence.HistorySerializerFactory
+		resolver          membership.ServiceResolver
+		hostIdentity      string
+		config            *Config
+		logger            bark.Logger
+		metricsClient     metrics.Client
+
+		// the migrations in progress by domain ID, only accessed by the migrator pump
+		migrations map[string]*historyMigration
+
+		isStarted  int32
+		isStopped  int32
+		shutdownWG sync.WaitGroup
+		shutdownCh chan struct{}
+	}
+
+	// historyMigration is the progress of the current pass over the history of a domain
+	historyMigration struct {
+		targetEncoding common.EncodingType
+		// the shard and the page of its workflow executions the pass is at
+		shardID       int
+		nextPageToken []byte
+		reencoded     int64
+		conflicts     int64
+		failures      int64
+		// the last pass left nothing to reencode
+		done bool
+	}
+)
+
+// NewHistoryMigrator creates a new migrator for the history owned by the given worker host, the metadata manager
+// should cover the domains of both metadata tables
+func NewHistoryMigrator(metadataMgr persistence.MetadataManager, shardMgr persistence.ShardManager,
+	historyMgr persistence.HistoryManager, numberOfShards int, resolver membership.ServiceResolver,
+	hostIdentity string, config *Config, logger bark.Logger, metricsClient metrics.Client) *HistoryMigrator {
+	return &HistoryMigrator{
+		metadataMgr:       metadataMgr,
+		shardMgr:          shardMgr,
+		historyMgr:        historyMgr,
+		numberOfShards:    numberOfShards,
+		serializerFactory: persistence.NewHistorySerializerFactory(),
+		resolver:          resolver,
+		hostIdentity:      hostIdentity,
+		config:            config,
+		logger: logger.WithFields(bark.Fields{
+			logging.TagWorkflowComponent: logging.TagValueHistoryMigratorComponent,
+		}),
+		metricsClient: metricsClient,
+		migrations:    make(map[string]*historyMigration),
+		shutdownCh:    make(chan struct{}),
+	}
+}
+
+// Start starts the migrator
+func (m *HistoryMigrator) Start() {
+	if !atomic.CompareAndSwapInt32(&m.isStarted, 0, 1) {
+		return
+	}
+
+	m.shutdownWG.Add(1)
+	go m.migratorPump()
+	m.logger.Info("History migrator started.")
+}
+
+// Stop stops the migrator
+func (m *HistoryMigrator) Stop() {
+	if !atomic.CompareAndSwapInt32(&m.isStopped, 0, 1) {
+		return
+	}
+
+	if atomic.LoadInt32(&m.isStarted) == 1 {
+		close(m.shutdownCh)
+	}
+
+	if success := common.AwaitWaitGroup(&m.shutdownWG, time.Minute); !success {
+		m.logger.Warn("History migrator timed out on shutdown.")
+	}
+	m.logger.Info("History migrator stopped.")
+}
+
+func (m *HistoryMigrator) migratorPump() {
+	defer m.shutdownWG.Done()
+
+	timer := time.NewTimer(m.config.HistoryMigrationInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-m.shutdownCh:
+			return
+		case <-timer.C:
+			m.migrateDomains()
+			timer.Reset(m.config.HistoryMigrationInterval())
+		}
+	}
+}
+
+func (m *HistoryMigrator) migrateDomains() {
+	var token []byte
+	for {
+		resp, err := m.metadataMgr.ListDomains(&persistence.ListDomainsRequest{
+			PageSize:         historyMigratorListPageSize,
+			NextPageToken:    token,
+			IncludeV1Domains: true,
+		})
+		if err != nil {
+			m.metricsClient.IncCounter(metrics.HistoryMigratorScope, metrics.HistoryMigratorFailures)
+			m.logger.WithField(logging.TagErr, err).Warn("Failed to list domains.")
+			return
+		}
+
+		for _, domain := range resp.Domains {
+			target := common.EncodingType(m.config.HistoryMigrationTargetEncoding(domain.Info.Name))
+			if target == "" || !m.isDomainOwned(domain.Info) {
+				delete(m.migrations, domain.Info.ID)
+				continue
+			}
+
+			migration, ok := m.migrations[domain.Info.ID]
+			if !ok || migration.targetEncoding != target {
+				migration = &historyMigration{targetEncoding: target}
+				m.migrations[domain.Info.ID] = migration
+			}
+			if migration.done {
+				continue
+			}
+			if !m.migrateDomain(domain.Info, migration) {
+				return
+			}
+		}
+
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			return
+		}
+	}
+}
+
+// isDomainOwned returns whether this host migrates the history of the domain
+func (m *HistoryMigrator) isDomainOwned(domain *persistence.DomainInfo) bool {
+	host, err := m.resolver.Lookup(domain.ID)
+	if err != nil {
+		m.logger.WithField(logging.TagErr, err).Warn("Failed to lookup the worker host of a domain.")
+		return false
+	}
+	return host.Identity() == m.hostIdentity
+}
+
+// migrateDomain reencodes the history of the next pages of workflow executions of the domain, it returns false once
+// the migrator is shutting down
+func (m *HistoryMigrator) migrateDomain(domain *persistence.DomainInfo, migration *historyMigration) bool {
+	logger := m.logger.WithFields(bark.Fields{
+		logging.TagDomainID: domain.ID,
+	})
+	target, err := m.serializerFactory.Get(migration.targetEncoding)
+	if err != nil {
+		m.metricsClient.IncCounter(metrics.HistoryMigratorScope, metrics.HistoryMigratorFailures)
+		logger.WithField(logging.TagErr, err).Warn("Unknown target encoding of history migration.")
+		return true
+	}
+
+	for page := 0; page < m.config.HistoryMigrationPagesPerInterval(); page++ {
+		select {
+		case <-m.shutdownCh:
+			return false
+		default:
+		}
+
+		resp, err := m.shardMgr.ListDomainExecutions(&persistence.ListDomainExecutionsRequest{
+			ShardID:       migration.shardID,
+			DomainID:      domain.ID,
+			PageSize:      historyMigratorExecutionPageSize,
+			NextPageToken: migration.nextPageToken,
+		})
+		if err != nil {
+			m.metricsClient.IncCounter(metrics.HistoryMigratorScope, metrics.HistoryMigratorFailures)
+			logger.WithFields(bark.Fields{
+				logging.TagHistoryShardID: migration.shardID,
+				logging.TagErr:            err,
+			}).Warn("Failed to list workflow executions.")
+			return true
+		}
+
+		for _, execution := range resp.Executions {
+			if !m.migrateExecution(domain.ID, execution, target, migration) {
+				return false
+			}
+		}
+
+		migration.nextPageToken = resp.NextPageToken
+		if len(migration.nextPageToken) > 0 {
+			continue
+		}
+		migration.shardID++
+		if migration.shardID < m.numberOfShards {
+			continue
+		}
+
+		logger.Infof("Went through the history of the domain: reencoded %v batches to %v, %v conflicts, %v failures.",
+			migration.reencoded, migration.targetEncoding, migration.conflicts, migration.failures)
+		if migration.conflicts == 0 && migration.failures == 0 {
+			migration.done = true
+		} else {
+			m.migrations[domain.ID] = &historyMigration{targetEncoding: migration.targetEncoding}
+		}
+		return true
+	}
+	return true
+}
+
+// migrateExecution reencodes the history batches of a workflow execution, it returns false once the migrator is
+// shutting down
+func (m *HistoryMigrator) migrateExecution(domainID string, execution workflow.WorkflowExecution,
+	target persistence.HistorySerializer, migration *historyMigration) bool {
+	var token []byte
+	for {
+		select {
+		case <-m.shutdownCh:
+			return false
+		default:
+		}
+
+		resp, err := m.historyMgr.ListHistoryBatches(&persistence.ListHistoryBatchesRequest{
+			DomainID:      domainID,
+			Execution:     execution,
+			PageSize:      historyMigratorBatchPageSize,
+			NextPageToken: token,
+		})
+		if err != nil {
+			migration.failures++
+			m.metricsClient.IncCounter(metrics.HistoryMigratorScope, metrics.HistoryMigratorFailures)
+			m.logger.WithFields(bark.Fields{
+				logging.TagDomainID:            domainID,
+				logging.TagWorkflowExecutionID: execution.GetWorkflowId(),
+				logging.TagWorkflowRunID:       execution.GetRunId(),
+				logging.TagErr:                 err,
+			}).Warn("Failed to list history batches.")
+			return true
+		}
+
+		for _, batch := range resp.Batches {
+			m.migrateBatch(domainID, batch, target, migration)
+		}
+
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			return true
+		}
+	}
+}
+
+func (m *HistoryMigrator) migrateBatch(domainID string, batch *persistence.HistoryBatch,
+	target persistence.HistorySerializer, migration *historyMigration) {
+	version := persistence.GetDefaultHistoryVersion()
+	if batch.Events.EncodingType == migration.targetEncoding && batch.Events.Version == version {
+		return
+	}
+
+	reencoded, err := m.reencodeBatch(batch, target, version)
+	if err == nil {
+		err = m.historyMgr.ReencodeHistoryBatch(&persistence.ReencodeHistoryBatchRequest{
+			DomainID:             domainID,
+			Execution:            batch.Execution,
+			FirstEventID:         batch.FirstEventID,
+			TransactionID:        batch.TransactionID,
+			PreviousEncodingType: batch.Events.EncodingType,
+			PreviousVersion:      batch.Events.Version,
+			Events:               reencoded,
+		})
+	}
+	if err == nil {
+		err = m.verifyBatch(domainID, batch, reencoded)
+	}
+
+	switch err.(type) {
+	case nil:
+		migration.reencoded++
+		m.metricsClient.IncCounter(metrics.HistoryMigratorScope, metrics.HistoryMigratorReencodedBatches)
+	case *persistence.ConditionFailedError:
+		// history wrote the batch again, it is reencoded on the next pass if needed
+		migration.conflicts++
+		m.metricsClient.IncCounter(metrics.HistoryMigratorScope, metrics.HistoryMigratorConflicts)
+	default:
+		migration.failures++
+		m.metricsClient.IncCounter(metrics.HistoryMigratorScope, metrics.HistoryMigratorFailures)
+		m.logger.WithFields(bark.Fields{
+			logging.TagDomainID:            domainID,
+			logging.TagWorkflowExecutionID: batch.Execution.GetWorkflowId(),
+			logging.TagWorkflowRunID:       batch.Execution.GetRunId(),
+			logging.TagFirstEventID:        batch.FirstEventID,
+			logging.TagErr:                 err,
+		}).Warn("Failed to reencode history batch.")
+	}
+}
+
+// reencodeBatch serializes the events of the batch with the target serializer, and checks that the reencoded batch
+// reads back to the same events
+func (m *HistoryMigrator) reencodeBatch(batch *persistence.HistoryBatch, target persistence.HistorySerializer,
+	version int) (*persistence.SerializedHistoryEventBatch, error) {
+	source, err := m.serializerFactory.Get(batch.Events.EncodingType)
+	if err != nil {
+		return nil, err
+	}
+	history, err := source.Deserialize(batch.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	reencoded, err := target.Serialize(persistence.NewHistoryEventBatch(version, history.Events))
+	if err != nil {
+		return nil, err
+	}
+	readBack, err := target.Deserialize(reencoded)
+	if err != nil {
+		return nil, err
+	}
+	if !sameHistoryEvents(history.Events, readBack.Events) {
+		return nil, errReencodedBatchMismatch
+	}
+	return reencoded, nil
+}
+
+// verifyBatch checks that the reencoded batch is the one persisted
+func (m *HistoryMigrator) verifyBatch(domainID string, batch *persistence.HistoryBatch,
+	reencoded *persistence.SerializedHistoryEventBatch) error {
+	resp, err := m.historyMgr.GetWorkflowExecutionHistory(&persistence.GetWorkflowExecutionHistoryRequest{
+		DomainID:     domainID,
+		Execution:    batch.Execution,
+		FirstEventID: batch.FirstEventID,
+		NextEventID:  batch.FirstEventID + 1,
+		PageSize:     1,
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Events) != 1 {
+		return errReencodedBatchMismatch
+	}
+	persisted := resp.Events[0]
+	if persisted.EncodingType != reencoded.EncodingType || persisted.Version != reencoded.Version ||
+		!bytes.Equal(persisted.Data, reencoded.Data) {
+		return errReencodedBatchMismatch
+	}
+	return nil
+}
+
+func sameHistoryEvents(events []*workflow.HistoryEvent, other []*workflow.HistoryEvent) bool {
+	if len(events) != len(other) {
+		return false
+	}
+	for i := range events {
+		if !events[i].Equals(other[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package worker
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	historyMigratorSuite struct {
+		suite.Suite
+		*require.Assertions
+		mockMetadataMgr *mocks.MetadataManager
+		mockShardMgr    *mocks.ShardManager
+		mockHistoryMgr  *mocks.HistoryManager
+		mockResolver    *mocks.ServiceResolver
+		migrator        *HistoryMigrator
+	}
+)
+
+func TestHistoryMigratorSuite(t *testing.T) {
+	s := new(historyMigratorSuite)
+	suite.Run(t, s)
+}
+
+func (s *historyMigratorSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+}
+
+func (s *historyMigratorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+	s.mockShardMgr = &mocks.ShardManager{}
+	s.mockHistoryMgr = &mocks.HistoryManager{}
+	s.mockResolver = &mocks.ServiceResolver{}
+	config := NewConfig(dynamicconfig.NewNopCollection())
+	config.HistoryMigrationTargetEncoding = func(domain string) string {
+		if domain == "no-migration-domain" {
+			return ""
+		}
+		return string(common.EncodingTypeThriftRWGzip)
+	}
+	config.HistoryMigrationPagesPerInterval = dynamicconfig.GetIntPropertyFn(1)
+	s.migrator = NewHistoryMigrator(
+		s.mockMetadataMgr,
+		s.mockShardMgr,
+		s.mockHistoryMgr,
+		2,
+		s.mockResolver,
+		"self",
+		config,
+		bark.NewLoggerFromLogrus(logrus.New()),
+		metrics.NewClient(tally.NoopScope, metrics.Worker),
+	)
+}
+
+func (s *historyMigratorSuite) TearDownTest() {
+	s.mockMetadataMgr.AssertExpectations(s.T())
+	s.mockShardMgr.AssertExpectations(s.T())
+	s.mockHistoryMgr.AssertExpectations(s.T())
+	s.mockResolver.AssertExpectations(s.T())
+}
+
+func (s *historyMigratorSuite) TestMigrateDomains() {
+	s.mockMetadataMgr.On("ListDomains", &persistence.ListDomainsRequest{
+		PageSize:         historyMigratorListPageSize,
+		IncludeV1Domains: true,
+	}).Return(&persistence.ListDomainsResponse{
+		Domains: []*persistence.GetDomainResponse{
+			{Info: &persistence.DomainInfo{ID: "owned-domain", Name: "owned-domain"}},
+			{Info: &persistence.DomainInfo{ID: "other-domain", Name: "other-domain"}},
+			{Info: &persistence.DomainInfo{ID: "no-migration-domain", Name: "no-migration-domain"}},
+		},
+	}, nil).Times(3)
+	s.mockResolver.On("Lookup", "owned-domain").Return(membership.NewHostInfo("self", nil), nil).Times(3)
+	s.mockResolver.On("Lookup", "other-domain").Return(membership.NewHostInfo("other", nil), nil).Times(3)
+
+	event := &workflow.HistoryEvent{
+		EventId:   common.Int64Ptr(1),
+		EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionStarted),
+	}
+	jsonBatch, err := persistence.NewJSONHistorySerializer().Serialize(
+		persistence.NewHistoryEventBatch(1, []*workflow.HistoryEvent{event}))
+	s.NoError(err)
+	targetSerializer, err := s.migrator.serializerFactory.Get(common.EncodingTypeThriftRWGzip)
+	s.NoError(err)
+	targetBatch, err := targetSerializer.Serialize(persistence.NewHistoryEventBatch(1, []*workflow.HistoryEvent{event}))
+	s.NoError(err)
+
+	execution := func(runID string) workflow.WorkflowExecution {
+		return workflow.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr(runID)}
+	}
+	s.mockShardMgr.On("ListDomainExecutions", &persistence.ListDomainExecutionsRequest{
+		ShardID:  0,
+		DomainID: "owned-domain",
+		PageSize: historyMigratorExecutionPageSize,
+	}).Return(&persistence.ListDomainExecutionsResponse{
+		Executions:    []workflow.WorkflowExecution{execution("run1"), execution("run2"), execution("run3")},
+		NextPageToken: []byte("next"),
+	}, nil).Once()
+	s.mockShardMgr.On("ListDomainExecutions", &persistence.ListDomainExecutionsRequest{
+		ShardID:       0,
+		DomainID:      "owned-domain",
+		PageSize:      historyMigratorExecutionPageSize,
+		NextPageToken: []byte("next"),
+	}).Return(&persistence.ListDomainExecutionsResponse{}, nil).Once()
+	s.mockShardMgr.On("ListDomainExecutions", &persistence.ListDomainExecutionsRequest{
+		ShardID:  1,
+		DomainID: "owned-domain",
+		PageSize: historyMigratorExecutionPageSize,
+	}).Return(&persistence.ListDomainExecutionsResponse{}, nil).Once()
+
+	batches := map[string]*persistence.SerializedHistoryEventBatch{
+		"run1": jsonBatch,
+		"run2": targetBatch,
+		"run3": jsonBatch,
+	}
+	for runID, events := range batches {
+		s.mockHistoryMgr.On("ListHistoryBatches", &persistence.ListHistoryBatchesRequest{
+			DomainID:  "owned-domain",
+			Execution: execution(runID),
+			PageSize:  historyMigratorBatchPageSize,
+		}).Return(&persistence.ListHistoryBatchesResponse{
+			Batches: []*persistence.HistoryBatch{
+				{Execution: execution(runID), FirstEventID: 1, TransactionID: 10, Events: events},
+			},
+		}, nil).Once()
+	}
+
+	var persisted *persistence.SerializedHistoryEventBatch
+	s.mockHistoryMgr.On("ReencodeHistoryBatch", mock.MatchedBy(func(request *persistence.ReencodeHistoryBatchRequest) bool {
+		return request.Execution.GetRunId() == "run1"
+	})).Run(func(args mock.Arguments) {
+		request := args.Get(0).(*persistence.ReencodeHistoryBatchRequest)
+		s.Equal(int64(10), request.TransactionID)
+		s.Equal(common.EncodingTypeJSON, request.PreviousEncodingType)
+		s.Equal(common.EncodingTypeThriftRWGzip, request.Events.EncodingType)
+		persisted = request.Events
+	}).Return(nil).Once()
+	s.mockHistoryMgr.On("ReencodeHistoryBatch", mock.MatchedBy(func(request *persistence.ReencodeHistoryBatchRequest) bool {
+		return request.Execution.GetRunId() == "run3"
+	})).Return(&persistence.ConditionFailedError{}).Once()
+	s.mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.MatchedBy(func(request *persistence.GetWorkflowExecutionHistoryRequest) bool {
+		return request.Execution.GetRunId() == "run1" && request.FirstEventID == 1 && request.NextEventID == 2
+	})).Return(func(request *persistence.GetWorkflowExecutionHistoryRequest) *persistence.GetWorkflowExecutionHistoryResponse {
+		return &persistence.GetWorkflowExecutionHistoryResponse{Events: []persistence.SerializedHistoryEventBatch{*persisted}}
+	}, nil).Once()
+
+	s.migrator.migrateDomains()
+	migration := s.migrator.migrations["owned-domain"]
+	s.Equal(int64(1), migration.reencoded)
+	s.Equal(int64(1), migration.conflicts)
+	s.Equal(int64(0), migration.failures)
+	s.Equal(0, migration.shardID)
+	s.Equal([]byte("next"), migration.nextPageToken)
+	s.Equal(1, len(s.migrator.migrations))
+
+	// the first shard is done
+	s.migrator.migrateDomains()
+	migration = s.migrator.migrations["owned-domain"]
+	s.Equal(1, migration.shardID)
+	s.Empty(migration.nextPageToken)
+
+	// the pass is over, the conflict needs another one which starts from the first shard
+	s.migrator.migrateDomains()
+	migration = s.migrator.migrations["owned-domain"]
+	s.Equal(int64(0), migration.reencoded)
+	s.Equal(0, migration.shardID)
+	s.False(migration.done)
+}
+
+func (s *historyMigratorSuite) TestMigrateDomains_Done() {
+	s.migrator.numberOfShards = 1
+	s.mockMetadataMgr.On("ListDomains", mock.Anything).Return(&persistence.ListDomainsResponse{
+		Domains: []*persistence.GetDomainResponse{
+			{Info: &persistence.DomainInfo{ID: "owned-domain", Name: "owned-domain"}},
+		},
+	}, nil).Twice()
+	s.mockResolver.On("Lookup", "owned-domain").Return(membership.NewHostInfo("self", nil), nil).Twice()
+	s.mockShardMgr.On("ListDomainExecutions", &persistence.ListDomainExecutionsRequest{
+		ShardID:  0,
+		DomainID: "owned-domain",
+		PageSize: historyMigratorExecutionPageSize,
+	}).Return(&persistence.ListDomainExecutionsResponse{}, nil).Once()
+
+	s.migrator.migrateDomains()
+	s.True(s.migrator.migrations["owned-domain"].done)
+
+	// a domain which is done is not listed again
+	s.migrator.migrateDomains()
+	s.True(s.migrator.migrations["owned-domain"].done)
+}
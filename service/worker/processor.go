@@ -32,12 +32,16 @@ import (
 	h "github.com/uber/cadence/.gen/go/history"
 	"github.com/uber/cadence/.gen/go/replicator"
 	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
 	"go.uber.org/yarpc/yarpcerrors"
 )
 
@@ -62,7 +66,12 @@ type (
 		logger           bark.Logger
 		metricsClient    metrics.Client
 		domainReplicator DomainReplicator
+		domainCache      cache.DomainCache
+		shardMgr         persistence.ShardManager
+		numberOfShards   int
+		dlqTracker       *replicationDLQTracker
 		historyClient    history.Client
+		domainRefresher  client.DomainCacheRefresher
 	}
 )
 
@@ -88,27 +97,36 @@ var (
 )
 
 func newReplicationTaskProcessor(currentCluster, sourceCluster, consumer string, client messaging.Client, config *Config,
-	logger bark.Logger, metricsClient metrics.Client, domainReplicator DomainReplicator,
-	historyClient history.Client) *replicationTaskProcessor {
+	logger bark.Logger, metricsClient metrics.Client, domainReplicator DomainReplicator, domainCache cache.DomainCache,
+	shardMgr persistence.ShardManager, numberOfShards int, historyClient history.Client,
+	domainRefresher client.DomainCacheRefresher, resolver membership.ServiceResolver,
+	hostIdentity string) *replicationTaskProcessor {
 
 	retryableHistoryClient := history.NewRetryableClient(historyClient, common.CreateHistoryServiceRetryPolicy(),
 		common.IsWhitelistServiceTransientError)
 
+	logger = logger.WithFields(bark.Fields{
+		logging.TagWorkflowComponent: logging.TagValueReplicationTaskProcessorComponent,
+		logging.TagSourceCluster:     sourceCluster,
+		logging.TagConsumerName:      consumer,
+	})
 	return &replicationTaskProcessor{
-		currentCluster: currentCluster,
-		sourceCluster:  sourceCluster,
-		consumerName:   consumer,
-		client:         client,
-		shutdownCh:     make(chan struct{}),
-		config:         config,
-		logger: logger.WithFields(bark.Fields{
-			logging.TagWorkflowComponent: logging.TagValueReplicationTaskProcessorComponent,
-			logging.TagSourceCluster:     sourceCluster,
-			logging.TagConsumerName:      consumer,
-		}),
+		currentCluster:   currentCluster,
+		sourceCluster:    sourceCluster,
+		consumerName:     consumer,
+		client:           client,
+		shutdownCh:       make(chan struct{}),
+		config:           config,
+		logger:           logger,
 		metricsClient:    metricsClient,
 		domainReplicator: domainReplicator,
-		historyClient:    retryableHistoryClient,
+		domainCache:      domainCache,
+		shardMgr:         shardMgr,
+		numberOfShards:   numberOfShards,
+		dlqTracker: newReplicationDLQTracker(sourceCluster, numberOfShards, shardMgr, resolver, hostIdentity, config,
+			logger, metricsClient),
+		historyClient:   retryableHistoryClient,
+		domainRefresher: domainRefresher,
 	}
 }
 
@@ -132,6 +150,7 @@ func (p *replicationTaskProcessor) Start() error {
 	p.consumer = consumer
 	p.shutdownWG.Add(1)
 	go p.processorPump()
+	p.dlqTracker.start()
 
 	logging.LogReplicationTaskProcessorStartedEvent(p.logger)
 	return nil
@@ -148,6 +167,7 @@ func (p *replicationTaskProcessor) Stop() {
 	if atomic.LoadInt32(&p.isStarted) == 1 {
 		close(p.shutdownCh)
 	}
+	p.dlqTracker.stop()
 
 	if success := common.AwaitWaitGroup(&p.shutdownWG, time.Minute); !success {
 		logging.LogReplicationTaskProcessorShutdownTimedoutEvent(p.logger)
@@ -257,6 +277,7 @@ ProcessRetryLoop:
 	if err == nil {
 		// Successfully processed replication task.  Ack message to move the cursor forward.
 		msg.Ack()
+		p.deleteDLQMessage(msg)
 	} else {
 		// Task still failed after all retries.  This is most probably due to a bug in replication code.
 		// Nack the task to move it to DLQ to not block replication for other workflow executions.
@@ -268,10 +289,106 @@ ProcessRetryLoop:
 			logging.TagAttemptStart: startTime,
 			logging.TagAttemptEnd:   time.Now(),
 		}).Error("Error processing replication task.")
+		p.putDLQMessage(msg)
 		msg.Nack()
 	}
 }
 
+// putDLQMessage records a history replication task moved to the DLQ, so its depth can be reported per history shard
+func (p *replicationTaskProcessor) putDLQMessage(msg kafka.Message) {
+	task, err := deserialize(msg.Value())
+	if err != nil || task.TaskType == nil {
+		// the task is still counted
+		task = &replicator.ReplicationTask{}
+	}
+	domainID := ""
+	switch {
+	case task.DomainTaskAttributes != nil:
+		domainID = task.DomainTaskAttributes.GetID()
+	case task.HistoryTaskAttributes != nil:
+		domainID = task.HistoryTaskAttributes.GetDomainId()
+	case task.SyncActivityTaskAttributes != nil:
+		domainID = task.SyncActivityTaskAttributes.GetDomainId()
+	}
+	p.metricsClient.Tagged(map[string]string{
+		metrics.DomainTagName:        p.getDomainNameForMetrics(domainID),
+		metrics.SourceClusterTagName: p.sourceCluster,
+	}).IncCounter(metrics.ReplicatorScope, metrics.ReplicatorMessagesDLQ)
+
+	request := p.newDLQMessageRequest(task)
+	if request == nil {
+		return
+	}
+	if err := p.shardMgr.PutReplicationDLQMessage(request); err != nil {
+		p.logger.WithFields(bark.Fields{
+			logging.TagErr:          err,
+			logging.TagPartitionKey: msg.Partition(),
+			logging.TagOffset:       msg.Offset(),
+		}).Warn("Failed to record replication task moved to DLQ.")
+		return
+	}
+	p.dlqTracker.add(request.ShardID)
+}
+
+// deleteDLQMessage forgets a history replication task once it is applied, the task may have been merged back from the
+// DLQ or redelivered by the topic. The delete is always issued, the message may have been recorded by another worker
+// host or by a previous run of this one.
+func (p *replicationTaskProcessor) deleteDLQMessage(msg kafka.Message) {
+	task, err := deserialize(msg.Value())
+	if err != nil {
+		return
+	}
+	request := p.newDLQMessageRequest(task)
+	if request == nil {
+		return
+	}
+
+	err = p.shardMgr.DeleteReplicationDLQMessage(&persistence.DeleteReplicationDLQMessageRequest{
+		ReplicationDLQMessageKey: request.ReplicationDLQMessageKey,
+	})
+	if err != nil {
+		p.logger.WithFields(bark.Fields{
+			logging.TagErr:          err,
+			logging.TagPartitionKey: msg.Partition(),
+			logging.TagOffset:       msg.Offset(),
+		}).Warn("Failed to forget replication task merged back from DLQ.")
+	}
+}
+
+// newDLQMessageRequest keys the DLQ message of a history replication task by the task itself, in the history shard
+// of its workflow execution. Other tasks are only counted when moved to the DLQ, nil is returned for them.
+func (p *replicationTaskProcessor) newDLQMessageRequest(
+	task *replicator.ReplicationTask) *persistence.PutReplicationDLQMessageRequest {
+	if task.TaskType == nil || task.GetTaskType() != replicator.ReplicationTaskTypeHistory ||
+		task.HistoryTaskAttributes == nil {
+		return nil
+	}
+
+	attr := task.HistoryTaskAttributes
+	return &persistence.PutReplicationDLQMessageRequest{
+		ReplicationDLQMessageKey: persistence.ReplicationDLQMessageKey{
+			ShardID:       common.WorkflowIDToHistoryShard(attr.GetWorkflowId(), p.numberOfShards),
+			SourceCluster: p.sourceCluster,
+			DomainID:      attr.GetDomainId(),
+			WorkflowID:    attr.GetWorkflowId(),
+			RunID:         attr.GetRunId(),
+			FirstEventID:  attr.GetFirstEventId(),
+		},
+		NextEventID: attr.GetNextEventId(),
+		Version:     attr.GetVersion(),
+	}
+}
+
+func (p *replicationTaskProcessor) getDomainNameForMetrics(domainID string) string {
+	if domainID == "" {
+		return metrics.NoneDomainTagValue
+	}
+	if domainEntry, err := p.domainCache.GetDomainByID(domainID); err == nil {
+		return domainEntry.GetInfo().Name
+	}
+	return domainID
+}
+
 func (p *replicationTaskProcessor) process(msg kafka.Message, inRetry bool) error {
 	scope := metrics.ReplicatorScope
 	task, err := deserialize(msg.Value())
@@ -302,6 +419,9 @@ func (p *replicationTaskProcessor) process(msg kafka.Message, inRetry bool) erro
 	case replicator.ReplicationTaskTypeHistory:
 		scope = metrics.HistoryReplicationTaskScope
 		err = p.handleHistoryReplicationTask(task, inRetry)
+	case replicator.ReplicationTaskTypeSyncActivity:
+		scope = metrics.SyncActivityTaskScope
+		err = p.handleSyncActivityTask(task)
 	default:
 		err = ErrUnknownReplicationTask
 	}
@@ -319,7 +439,24 @@ func (p *replicationTaskProcessor) handleDomainReplicationTask(task *replicator.
 	defer sw.Stop()
 
 	p.logger.Debugf("Received domain replication task %v.", task.DomainTaskAttributes)
-	return p.domainReplicator.HandleReceivingTask(task.DomainTaskAttributes)
+	err := p.domainReplicator.HandleReceivingTask(task.DomainTaskAttributes)
+	if err != nil {
+		return err
+	}
+
+	// let the frontend, history and matching hosts pick up the replicated domain change, a failover in particular,
+	// right away
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	err = p.domainRefresher.RefreshDomainCache(ctx, task.DomainTaskAttributes.GetID())
+	if err != nil {
+		// the domain change is applied, the hosts still pick it up on their periodic refresh
+		p.logger.WithFields(bark.Fields{
+			logging.TagDomainID: task.DomainTaskAttributes.GetID(),
+			logging.TagErr:      err,
+		}).Warn("Failed to notify hosts of replicated domain change.")
+	}
+	return nil
 }
 
 func (p *replicationTaskProcessor) handleSyncShardTask(task *replicator.ReplicationTask) error {
@@ -340,6 +477,30 @@ func (p *replicationTaskProcessor) handleSyncShardTask(task *replicator.Replicat
 	return p.historyClient.SyncShardStatus(ctx, req)
 }
 
+func (p *replicationTaskProcessor) handleSyncActivityTask(task *replicator.ReplicationTask) error {
+	p.metricsClient.IncCounter(metrics.SyncActivityTaskScope, metrics.ReplicatorMessages)
+	sw := p.metricsClient.StartTimer(metrics.SyncActivityTaskScope, metrics.ReplicatorLatency)
+	defer sw.Stop()
+
+	attr := task.SyncActivityTaskAttributes
+	p.logger.Debugf("Received sync activity task %v.", attr)
+
+	req := &h.SyncActivityRequest{
+		DomainId:      attr.DomainId,
+		WorkflowId:    attr.WorkflowId,
+		RunId:         attr.RunId,
+		Version:       attr.Version,
+		ScheduledId:   attr.ScheduledId,
+		ScheduledTime: attr.ScheduledTime,
+		StartedId:     attr.StartedId,
+		StartedTime:   attr.StartedTime,
+		Attempt:       attr.Attempt,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return p.historyClient.SyncActivity(ctx, req)
+}
+
 func (p *replicationTaskProcessor) handleHistoryReplicationTask(task *replicator.ReplicationTask, inRetry bool) error {
 	p.metricsClient.IncCounter(metrics.HistoryReplicationTaskScope, metrics.ReplicatorMessages)
 	sw := p.metricsClient.StartTimer(metrics.HistoryReplicationTaskScope, metrics.ReplicatorLatency)
@@ -355,6 +516,8 @@ Loop:
 		}
 	}
 	if !processTask {
+		// the domain is not placed on the current cluster, only some of the clusters consuming the source apply the task
+		p.metricsClient.IncCounter(metrics.HistoryReplicationTaskScope, metrics.ReplicatorMessagesNotTargeted)
 		p.logger.WithFields(bark.Fields{
 			logging.TagDomainID:            attr.GetDomainId(),
 			logging.TagWorkflowExecutionID: attr.GetWorkflowId(),
@@ -362,7 +525,7 @@ Loop:
 			logging.TagFirstEventID:        attr.GetFirstEventId(),
 			logging.TagNextEventID:         attr.GetNextEventId(),
 			logging.TagVersion:             attr.GetVersion(),
-		}).Warn("Dropping non-targeted history task.")
+		}).Debug("Dropping non-targeted history task.")
 		return nil
 	}
 
@@ -381,6 +544,7 @@ Loop:
 		History:           attr.History,
 		NewRunHistory:     attr.NewRunHistory,
 		ForceBufferEvents: common.BoolPtr(inRetry),
+		SourceTimestamp:   attr.Timestamp,
 	}
 
 RetryLoop:
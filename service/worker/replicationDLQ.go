@@ -0,0 +1,222 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package worker
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	// pace of the scan for the shards with DLQ messages, which may have been moved there by another worker host or by
+	// a previous run of the worker
+	replicationDLQScanInterval = 20 * time.Millisecond
+)
+
+type (
+	// replicationDLQTracker reports the depth and the oldest message age of the DLQ of the replication tasks of a
+	// source cluster, per history shard. Every worker host moves tasks to the DLQ, but the gauges of a shard are only
+	// reported by the worker host owning it. The owned shards are scanned in the background for messages, and only
+	// the shards known to have messages are summarized on every interval, a shard is dropped once its DLQ is drained.
+	replicationDLQTracker struct {
+		sourceCluster  string
+		numberOfShards int
+		shardMgr       persistence.ShardManager
+		resolver       membership.ServiceResolver
+		hostIdentity   string
+		config         *Config
+		logger         bark.Logger
+		metricsClient  metrics.Client
+
+		sync.Mutex
+		// the last time a message of the shard was moved to the DLQ
+		shards map[int]time.Time
+
+		isStarted  int32
+		isStopped  int32
+		shutdownWG sync.WaitGroup
+		shutdownCh chan struct{}
+	}
+)
+
+func newReplicationDLQTracker(sourceCluster string, numberOfShards int, shardMgr persistence.ShardManager,
+	resolver membership.ServiceResolver, hostIdentity string, config *Config, logger bark.Logger,
+	metricsClient metrics.Client) *replicationDLQTracker {
+	return &replicationDLQTracker{
+		sourceCluster:  sourceCluster,
+		numberOfShards: numberOfShards,
+		shardMgr:       shardMgr,
+		resolver:       resolver,
+		hostIdentity:   hostIdentity,
+		config:         config,
+		logger:         logger,
+		metricsClient:  metricsClient,
+		shards:         make(map[int]time.Time),
+		shutdownCh:     make(chan struct{}),
+	}
+}
+
+func (t *replicationDLQTracker) start() {
+	if !atomic.CompareAndSwapInt32(&t.isStarted, 0, 1) {
+		return
+	}
+
+	t.shutdownWG.Add(1)
+	go t.trackerPump()
+}
+
+func (t *replicationDLQTracker) stop() {
+	if !atomic.CompareAndSwapInt32(&t.isStopped, 0, 1) {
+		return
+	}
+
+	if atomic.LoadInt32(&t.isStarted) == 1 {
+		close(t.shutdownCh)
+	}
+
+	if success := common.AwaitWaitGroup(&t.shutdownWG, time.Minute); !success {
+		t.logger.Warn("Replication DLQ tracker timed out on shutdown.")
+	}
+}
+
+// add starts tracking the DLQ of the given shard
+func (t *replicationDLQTracker) add(shardID int) {
+	t.Lock()
+	defer t.Unlock()
+	t.shards[shardID] = time.Now()
+}
+
+func (t *replicationDLQTracker) trackerPump() {
+	defer t.shutdownWG.Done()
+
+	ticker := time.NewTicker(replicationDLQScanInterval)
+	defer ticker.Stop()
+	timer := time.NewTimer(t.config.ReplicatorDLQMetricsInterval())
+	defer timer.Stop()
+	shardID := 0
+	for {
+		select {
+		case <-t.shutdownCh:
+			return
+		case <-ticker.C:
+			t.scanShard(shardID)
+			shardID = (shardID + 1) % t.numberOfShards
+		case <-timer.C:
+			t.refresh(time.Now())
+			timer.Reset(t.config.ReplicatorDLQMetricsInterval())
+		}
+	}
+}
+
+// scanShard starts tracking the DLQ of the given shard if it is owned by this host and has messages
+func (t *replicationDLQTracker) scanShard(shardID int) {
+	if !t.isShardOwned(shardID) {
+		return
+	}
+	resp, err := t.getSummary(shardID)
+	if err == nil && resp.MessageCount > 0 {
+		t.add(shardID)
+	}
+}
+
+// isShardOwned returns whether this host reports the DLQ gauges of the shard
+func (t *replicationDLQTracker) isShardOwned(shardID int) bool {
+	host, err := t.resolver.Lookup(strconv.Itoa(shardID))
+	if err != nil {
+		t.logger.WithFields(bark.Fields{
+			logging.TagHistoryShardID: shardID,
+			logging.TagErr:            err,
+		}).Warn("Failed to lookup the worker host of a shard.")
+		return false
+	}
+	return host.Identity() == t.hostIdentity
+}
+
+// refresh emits the depth and oldest message age of the DLQ of every tracked shard
+func (t *replicationDLQTracker) refresh(now time.Time) {
+	t.Lock()
+	shards := make([]int, 0, len(t.shards))
+	for shardID := range t.shards {
+		shards = append(shards, shardID)
+	}
+	t.Unlock()
+
+	for _, shardID := range shards {
+		if !t.isShardOwned(shardID) {
+			// the new owner finds the shard on its own scan
+			t.Lock()
+			delete(t.shards, shardID)
+			t.Unlock()
+			continue
+		}
+
+		summaryTime := time.Now()
+		resp, err := t.getSummary(shardID)
+		if err != nil {
+			continue
+		}
+
+		var oldestMessageAge time.Duration
+		if resp.MessageCount > 0 {
+			oldestMessageAge = now.Sub(resp.OldestMessageTimestamp)
+		}
+		metricsClient := t.metricsClient.Tagged(map[string]string{
+			metrics.ShardTagName:         strconv.Itoa(shardID),
+			metrics.SourceClusterTagName: t.sourceCluster,
+		})
+		metricsClient.UpdateGauge(metrics.ReplicatorScope, metrics.ReplicatorDLQDepth, float64(resp.MessageCount))
+		metricsClient.UpdateGauge(metrics.ReplicatorScope, metrics.ReplicatorDLQOldestMessageAge,
+			oldestMessageAge.Seconds())
+
+		if resp.MessageCount == 0 {
+			// the zero above is the last report for the shard until it gets a message again
+			t.Lock()
+			if t.shards[shardID].Before(summaryTime) {
+				delete(t.shards, shardID)
+			}
+			t.Unlock()
+		}
+	}
+}
+
+func (t *replicationDLQTracker) getSummary(shardID int) (*persistence.GetReplicationDLQSummaryResponse, error) {
+	resp, err := t.shardMgr.GetReplicationDLQSummary(&persistence.GetReplicationDLQSummaryRequest{
+		ShardID:       shardID,
+		SourceCluster: t.sourceCluster,
+	})
+	if err != nil {
+		t.metricsClient.IncCounter(metrics.ReplicatorScope, metrics.ReplicatorFailures)
+		t.logger.WithFields(bark.Fields{
+			logging.TagHistoryShardID: shardID,
+			logging.TagErr:            err,
+		}).Warn("Failed to summarize replication DLQ.")
+	}
+	return resp, err
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package worker
+
+import (
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	replicationDLQTrackerSuite struct {
+		suite.Suite
+		mockShardMgr *mocks.ShardManager
+		mockResolver *mocks.ServiceResolver
+		tracker      *replicationDLQTracker
+	}
+)
+
+func TestReplicationDLQTrackerSuite(t *testing.T) {
+	s := new(replicationDLQTrackerSuite)
+	suite.Run(t, s)
+}
+
+func (s *replicationDLQTrackerSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+}
+
+func (s *replicationDLQTrackerSuite) SetupTest() {
+	s.mockShardMgr = &mocks.ShardManager{}
+	s.mockResolver = &mocks.ServiceResolver{}
+	s.tracker = newReplicationDLQTracker(
+		"standby",
+		4,
+		s.mockShardMgr,
+		s.mockResolver,
+		"self",
+		NewConfig(dynamicconfig.NewNopCollection()),
+		bark.NewLoggerFromLogrus(logrus.New()),
+		metrics.NewClient(tally.NoopScope, metrics.Worker),
+	)
+}
+
+func (s *replicationDLQTrackerSuite) TearDownTest() {
+	s.mockShardMgr.AssertExpectations(s.T())
+	s.mockResolver.AssertExpectations(s.T())
+}
+
+func (s *replicationDLQTrackerSuite) TestRefresh() {
+	now := time.Now()
+	s.tracker.add(1)
+	s.tracker.add(2)
+	s.tracker.add(3)
+	s.tracker.add(4)
+
+	s.mockResolver.On("Lookup", "1").Return(membership.NewHostInfo("self", nil), nil).Once()
+	s.mockResolver.On("Lookup", "2").Return(membership.NewHostInfo("self", nil), nil).Once()
+	s.mockResolver.On("Lookup", "3").Return(membership.NewHostInfo("self", nil), nil).Once()
+	s.mockResolver.On("Lookup", "4").Return(membership.NewHostInfo("other", nil), nil).Once()
+	s.mockShardMgr.On("GetReplicationDLQSummary", &persistence.GetReplicationDLQSummaryRequest{
+		ShardID:       1,
+		SourceCluster: "standby",
+	}).Return(&persistence.GetReplicationDLQSummaryResponse{
+		MessageCount:           2,
+		OldestMessageTimestamp: now.Add(-time.Hour),
+	}, nil).Once()
+	s.mockShardMgr.On("GetReplicationDLQSummary", &persistence.GetReplicationDLQSummaryRequest{
+		ShardID:       2,
+		SourceCluster: "standby",
+	}).Return(&persistence.GetReplicationDLQSummaryResponse{}, nil).Once()
+	s.mockShardMgr.On("GetReplicationDLQSummary", &persistence.GetReplicationDLQSummaryRequest{
+		ShardID:       3,
+		SourceCluster: "standby",
+	}).Return(nil, errors.New("some random error")).Once()
+
+	s.tracker.refresh(now)
+
+	// the drained shard and the shard owned by another host are no longer tracked, the one which failed to be
+	// summarized still is
+	s.Equal(2, len(s.tracker.shards))
+	s.Contains(s.tracker.shards, 1)
+	s.Contains(s.tracker.shards, 3)
+}
+
+func (s *replicationDLQTrackerSuite) TestScanShard() {
+	s.mockResolver.On("Lookup", "0").Return(membership.NewHostInfo("self", nil), nil).Once()
+	s.mockResolver.On("Lookup", "1").Return(membership.NewHostInfo("self", nil), nil).Once()
+	s.mockResolver.On("Lookup", "2").Return(membership.NewHostInfo("other", nil), nil).Once()
+	s.mockShardMgr.On("GetReplicationDLQSummary", &persistence.GetReplicationDLQSummaryRequest{
+		ShardID:       0,
+		SourceCluster: "standby",
+	}).Return(&persistence.GetReplicationDLQSummaryResponse{
+		MessageCount:           1,
+		OldestMessageTimestamp: time.Now(),
+	}, nil).Once()
+	s.mockShardMgr.On("GetReplicationDLQSummary", &persistence.GetReplicationDLQSummaryRequest{
+		ShardID:       1,
+		SourceCluster: "standby",
+	}).Return(&persistence.GetReplicationDLQSummaryResponse{}, nil).Once()
+
+	s.tracker.scanShard(0)
+	s.tracker.scanShard(1)
+	// the messages of a shard owned by another host are not looked up
+	s.tracker.scanShard(2)
+
+	s.Equal(1, len(s.tracker.shards))
+	s.Contains(s.tracker.shards, 0)
+}
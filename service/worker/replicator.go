@@ -24,9 +24,12 @@ import (
 	"fmt"
 
 	"github.com/uber-common/bark"
+	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
@@ -37,7 +40,13 @@ type (
 	Replicator struct {
 		clusterMetadata  cluster.Metadata
 		domainReplicator DomainReplicator
+		domainCache      cache.DomainCache
+		shardMgr         persistence.ShardManager
+		numberOfShards   int
 		historyClient    history.Client
+		domainRefresher  client.DomainCacheRefresher
+		resolver         membership.ServiceResolver
+		hostIdentity     string
 		config           *Config
 		client           messaging.Client
 		processors       []*replicationTaskProcessor
@@ -48,15 +57,22 @@ type (
 
 // NewReplicator creates a new replicator for processing replication tasks
 func NewReplicator(clusterMetadata cluster.Metadata, metadataManagerV2 persistence.MetadataManager,
-	historyClient history.Client, config *Config, client messaging.Client, logger bark.Logger,
-	metricsClient metrics.Client) *Replicator {
+	shardMgr persistence.ShardManager, numberOfShards int, historyClient history.Client,
+	domainRefresher client.DomainCacheRefresher, resolver membership.ServiceResolver, hostIdentity string,
+	config *Config, client messaging.Client, logger bark.Logger, metricsClient metrics.Client) *Replicator {
 	logger = logger.WithFields(bark.Fields{
 		logging.TagWorkflowComponent: logging.TagValueReplicatorComponent,
 	})
 	return &Replicator{
 		clusterMetadata:  clusterMetadata,
 		domainReplicator: NewDomainReplicator(metadataManagerV2, logger),
+		domainCache:      cache.NewDomainCache(metadataManagerV2, clusterMetadata, metricsClient, logger),
+		shardMgr:         shardMgr,
+		numberOfShards:   numberOfShards,
 		historyClient:    historyClient,
+		domainRefresher:  domainRefresher,
+		resolver:         resolver,
+		hostIdentity:     hostIdentity,
 		config:           config,
 		client:           client,
 		logger:           logger,
@@ -66,12 +82,15 @@ func NewReplicator(clusterMetadata cluster.Metadata, metadataManagerV2 persisten
 
 // Start is called to start replicator
 func (r *Replicator) Start() error {
+	r.domainCache.Start()
+
 	currentClusterName := r.clusterMetadata.GetCurrentClusterName()
 	for cluster := range r.clusterMetadata.GetAllClusterFailoverVersions() {
 		if cluster != currentClusterName {
 			consumerName := getConsumerName(currentClusterName, cluster)
 			r.processors = append(r.processors, newReplicationTaskProcessor(currentClusterName, cluster, consumerName, r.client,
-				r.config, r.logger, r.metricsClient, r.domainReplicator, r.historyClient))
+				r.config, r.logger, r.metricsClient, r.domainReplicator, r.domainCache, r.shardMgr, r.numberOfShards,
+				r.historyClient, r.domainRefresher, r.resolver, r.hostIdentity))
 		}
 	}
 
@@ -89,6 +108,7 @@ func (r *Replicator) Stop() {
 	for _, processor := range r.processors {
 		processor.Stop()
 	}
+	r.domainCache.Stop()
 }
 
 func getConsumerName(currentCluster, remoteCluster string) string {
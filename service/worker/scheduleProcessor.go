@@ -0,0 +1,374 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/uber-common/bark"
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	scheduleProcessorIdentity = "cadence-schedule-processor"
+	scheduleListPageSize      = 100
+	scheduleRequestTimeout    = 10 * time.Second
+)
+
+// scheduleRunNamespace is the namespace of the request IDs of the runs started by schedules, derived
+// from the workflow ID of the run so a run started again after a failure is deduplicated by history
+var scheduleRunNamespace = uuid.Parse("5c8f6d43-0f3e-4d55-9a2b-6f0d1f6a7c21")
+
+type (
+	// ScheduleProcessor starts the runs of the schedules of the domains owned by this worker host. The domains
+	// are spread across the worker hosts by the worker membership ring, and the schedules of a global domain only
+	// run in the cluster where the domain is active.
+	ScheduleProcessor struct {
+		clusterMetadata cluster.Metadata
+		metadataMgr     persistence.MetadataManager
+		scheduleMgr     persistence.ScheduleManager
+		historyClient   history.Client
+		resolver        membership.ServiceResolver
+		hostIdentity    string
+		config          *Config
+		logger          bark.Logger
+		metricsClient   metrics.Client
+
+		isStarted  int32
+		isStopped  int32
+		shutdownWG sync.WaitGroup
+		shutdownCh chan struct{}
+	}
+)
+
+// NewScheduleProcessor creates a new processor for the schedules owned by the given worker host, the metadata manager
+// should cover the domains of both metadata tables
+func NewScheduleProcessor(clusterMetadata cluster.Metadata, metadataMgr persistence.MetadataManager,
+	scheduleMgr persistence.ScheduleManager, historyClient history.Client, resolver membership.ServiceResolver,
+	hostIdentity string, config *Config, logger bark.Logger, metricsClient metrics.Client) *ScheduleProcessor {
+	return &ScheduleProcessor{
+		clusterMetadata: clusterMetadata,
+		metadataMgr:     metadataMgr,
+		scheduleMgr:     scheduleMgr,
+		historyClient:   historyClient,
+		resolver:        resolver,
+		hostIdentity:    hostIdentity,
+		config:          config,
+		logger: logger.WithFields(bark.Fields{
+			logging.TagWorkflowComponent: logging.TagValueScheduleProcessorComponent,
+		}),
+		metricsClient: metricsClient,
+		shutdownCh:    make(chan struct{}),
+	}
+}
+
+// Start starts the processor
+func (p *ScheduleProcessor) Start() {
+	if !atomic.CompareAndSwapInt32(&p.isStarted, 0, 1) {
+		return
+	}
+
+	p.shutdownWG.Add(1)
+	go p.processorPump()
+	p.logger.Info("Schedule processor started.")
+}
+
+// Stop stops the processor
+func (p *ScheduleProcessor) Stop() {
+	if !atomic.CompareAndSwapInt32(&p.isStopped, 0, 1) {
+		return
+	}
+
+	if atomic.LoadInt32(&p.isStarted) == 1 {
+		close(p.shutdownCh)
+	}
+
+	if success := common.AwaitWaitGroup(&p.shutdownWG, time.Minute); !success {
+		p.logger.Warn("Schedule processor timed out on shutdown.")
+	}
+	p.logger.Info("Schedule processor stopped.")
+}
+
+func (p *ScheduleProcessor) processorPump() {
+	defer p.shutdownWG.Done()
+
+	timer := time.NewTimer(p.config.ScheduleProcessorRefreshInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case <-timer.C:
+			p.processDomains()
+			timer.Reset(p.config.ScheduleProcessorRefreshInterval())
+		}
+	}
+}
+
+func (p *ScheduleProcessor) processDomains() {
+	var token []byte
+	for {
+		resp, err := p.metadataMgr.ListDomains(&persistence.ListDomainsRequest{
+			PageSize:         scheduleListPageSize,
+			NextPageToken:    token,
+			IncludeV1Domains: true,
+		})
+		if err != nil {
+			p.metricsClient.IncCounter(metrics.ScheduleProcessorScope, metrics.ScheduleProcessorFailures)
+			p.logger.WithField(logging.TagErr, err).Warn("Failed to list domains.")
+			return
+		}
+
+		for _, domain := range resp.Domains {
+			if p.isDomainOwned(domain) {
+				p.processDomain(domain.Info)
+			}
+		}
+
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			return
+		}
+	}
+}
+
+// isDomainOwned returns whether this host runs the schedules of the domain
+func (p *ScheduleProcessor) isDomainOwned(domain *persistence.GetDomainResponse) bool {
+	if domain.Info.Status != persistence.DomainStatusRegistered {
+		return false
+	}
+	if domain.IsGlobalDomain &&
+		domain.ReplicationConfig.ActiveClusterName != p.clusterMetadata.GetCurrentClusterName() {
+		return false
+	}
+	host, err := p.resolver.Lookup(domain.Info.ID)
+	if err != nil {
+		p.logger.WithField(logging.TagErr, err).Warn("Failed to lookup the worker host of a domain.")
+		return false
+	}
+	return host.Identity() == p.hostIdentity
+}
+
+func (p *ScheduleProcessor) processDomain(domain *persistence.DomainInfo) {
+	var token []byte
+	for {
+		resp, err := p.scheduleMgr.ListSchedules(&persistence.ListSchedulesRequest{
+			DomainID:      domain.ID,
+			PageSize:      scheduleListPageSize,
+			NextPageToken: token,
+		})
+		if err != nil {
+			p.metricsClient.IncCounter(metrics.ScheduleProcessorScope, metrics.ScheduleProcessorFailures)
+			p.logger.WithFields(bark.Fields{
+				logging.TagDomainID: domain.ID,
+				logging.TagErr:      err,
+			}).Warn("Failed to list schedules.")
+			return
+		}
+
+		for _, schedule := range resp.Schedules {
+			if err := p.processSchedule(domain.Name, schedule, time.Now()); err != nil {
+				p.metricsClient.IncCounter(metrics.ScheduleProcessorScope, metrics.ScheduleProcessorFailures)
+				p.logger.WithFields(bark.Fields{
+					logging.TagDomainID:   domain.ID,
+					logging.TagScheduleID: schedule.ScheduleID,
+					logging.TagErr:        err,
+				}).Warn("Failed to process schedule.")
+			}
+		}
+
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			return
+		}
+	}
+}
+
+// processSchedule starts the runs of the schedule which are due according to its overlap policy. The runs missed
+// while the schedule was not processed are collapsed into the latest one, and the runs buffered behind an open run
+// are started one at a time once the previous run closes.
+func (p *ScheduleProcessor) processSchedule(domainName string, schedule *persistence.ScheduleInfo, now time.Time) error {
+	if schedule.Paused {
+		return nil
+	}
+	due := !schedule.NextRunTime.IsZero() && !schedule.NextRunTime.After(now)
+	if !due && len(schedule.BufferedRunTimes) == 0 {
+		return nil
+	}
+
+	open, err := p.isLastRunOpen(domainName, schedule)
+	if err != nil {
+		return err
+	}
+	if !due && open {
+		return nil
+	}
+
+	previousVersion := schedule.Version
+	if !open && len(schedule.BufferedRunTimes) > 0 {
+		runTime := schedule.BufferedRunTimes[0]
+		if err := p.startRun(domainName, schedule, runTime); err != nil {
+			return err
+		}
+		schedule.BufferedRunTimes = schedule.BufferedRunTimes[1:]
+		open = true
+	}
+
+	if due {
+		runTime := schedule.NextRunTime
+		switch {
+		case !open:
+			if err := p.startRun(domainName, schedule, runTime); err != nil {
+				return err
+			}
+		case schedule.OverlapPolicy == int(shared.ScheduleOverlapPolicyCancelOther):
+			if err := p.cancelLastRun(domainName, schedule); err != nil {
+				return err
+			}
+			if err := p.startRun(domainName, schedule, runTime); err != nil {
+				return err
+			}
+			p.metricsClient.IncCounter(metrics.ScheduleProcessorScope, metrics.ScheduleRunCancelled)
+		case schedule.OverlapPolicy == int(shared.ScheduleOverlapPolicyBuffer) &&
+			len(schedule.BufferedRunTimes) < p.config.ScheduleMaxBufferedRuns():
+			schedule.BufferedRunTimes = append(schedule.BufferedRunTimes, runTime)
+			p.metricsClient.IncCounter(metrics.ScheduleProcessorScope, metrics.ScheduleRunBuffered)
+		default:
+			p.metricsClient.IncCounter(metrics.ScheduleProcessorScope, metrics.ScheduleRunSkipped)
+		}
+		schedule.NextRunTime = schedule.GetNextRunTime(now)
+	}
+
+	schedule.Version++
+	return p.scheduleMgr.UpdateSchedule(&persistence.UpdateScheduleRequest{
+		Schedule:        schedule,
+		PreviousVersion: previousVersion,
+	})
+}
+
+func (p *ScheduleProcessor) isLastRunOpen(domainName string, schedule *persistence.ScheduleInfo) (bool, error) {
+	if schedule.LastWorkflowID == "" {
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), scheduleRequestTimeout)
+	defer cancel()
+	resp, err := p.historyClient.DescribeWorkflowExecution(ctx, &h.DescribeWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(schedule.DomainID),
+		Request: &shared.DescribeWorkflowExecutionRequest{
+			Domain: common.StringPtr(domainName),
+			Execution: &shared.WorkflowExecution{
+				WorkflowId: common.StringPtr(schedule.LastWorkflowID),
+				RunId:      common.StringPtr(schedule.LastRunID),
+			},
+		},
+	})
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); ok {
+			return false, nil
+		}
+		return false, err
+	}
+	return resp.WorkflowExecutionInfo.CloseStatus == nil, nil
+}
+
+// startRun starts the run of the schedule due at the given time and records it as the last run of the schedule
+func (p *ScheduleProcessor) startRun(domainName string, schedule *persistence.ScheduleInfo, runTime time.Time) error {
+	workflowID := getScheduleRunWorkflowID(schedule, runTime)
+	ctx, cancel := context.WithTimeout(context.Background(), scheduleRequestTimeout)
+	defer cancel()
+	resp, err := p.historyClient.StartWorkflowExecution(ctx, &h.StartWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(schedule.DomainID),
+		StartRequest: &shared.StartWorkflowExecutionRequest{
+			Domain:                              common.StringPtr(domainName),
+			WorkflowId:                          common.StringPtr(workflowID),
+			WorkflowType:                        &shared.WorkflowType{Name: common.StringPtr(schedule.WorkflowTypeName)},
+			TaskList:                            &shared.TaskList{Name: common.StringPtr(schedule.TaskList)},
+			Input:                               schedule.Input,
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(schedule.ExecutionStartToCloseTimeoutSeconds),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(schedule.TaskStartToCloseTimeoutSeconds),
+			Identity:                            common.StringPtr(scheduleProcessorIdentity),
+			RequestId:                           common.StringPtr(uuid.NewSHA1(scheduleRunNamespace, []byte(schedule.DomainID+workflowID)).String()),
+			WorkflowIdReusePolicy:               shared.WorkflowIdReusePolicyRejectDuplicate.Ptr(),
+		},
+	})
+
+	var runID string
+	switch err := err.(type) {
+	case nil:
+		runID = resp.GetRunId()
+	case *shared.WorkflowExecutionAlreadyStartedError:
+		// the run was started before the state of the schedule could be updated
+		runID = err.GetRunId()
+	default:
+		return err
+	}
+
+	schedule.LastRunTime = runTime
+	schedule.LastWorkflowID = workflowID
+	schedule.LastRunID = runID
+	p.metricsClient.IncCounter(metrics.ScheduleProcessorScope, metrics.ScheduleRunStarted)
+	return nil
+}
+
+func (p *ScheduleProcessor) cancelLastRun(domainName string, schedule *persistence.ScheduleInfo) error {
+	ctx, cancel := context.WithTimeout(context.Background(), scheduleRequestTimeout)
+	defer cancel()
+	err := p.historyClient.RequestCancelWorkflowExecution(ctx, &h.RequestCancelWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(schedule.DomainID),
+		CancelRequest: &shared.RequestCancelWorkflowExecutionRequest{
+			Domain: common.StringPtr(domainName),
+			WorkflowExecution: &shared.WorkflowExecution{
+				WorkflowId: common.StringPtr(schedule.LastWorkflowID),
+				RunId:      common.StringPtr(schedule.LastRunID),
+			},
+			Identity: common.StringPtr(scheduleProcessorIdentity),
+		},
+	})
+	switch err.(type) {
+	case nil, *shared.CancellationAlreadyRequestedError, *shared.EntityNotExistsError:
+		return nil
+	default:
+		return err
+	}
+}
+
+// getScheduleRunWorkflowID returns the workflow ID of the run of the schedule due at the given time
+func getScheduleRunWorkflowID(schedule *persistence.ScheduleInfo, runTime time.Time) string {
+	prefix := schedule.WorkflowIDPrefix
+	if prefix == "" {
+		prefix = schedule.ScheduleID
+	}
+	return fmt.Sprintf("%v-%v", prefix, runTime.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package worker
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	scheduleProcessorSuite struct {
+		suite.Suite
+		mockHistoryClient *mocks.HistoryClient
+		mockMetadataMgr   *mocks.MetadataManager
+		mockScheduleMgr   *mocks.ScheduleManager
+		mockResolver      *mocks.ServiceResolver
+		processor         *ScheduleProcessor
+	}
+)
+
+func TestScheduleProcessorSuite(t *testing.T) {
+	s := new(scheduleProcessorSuite)
+	suite.Run(t, s)
+}
+
+func (s *scheduleProcessorSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+}
+
+func (s *scheduleProcessorSuite) SetupTest() {
+	s.mockHistoryClient = &mocks.HistoryClient{}
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+	s.mockScheduleMgr = &mocks.ScheduleManager{}
+	s.mockResolver = &mocks.ServiceResolver{}
+	s.processor = NewScheduleProcessor(
+		&mocks.ClusterMetadata{},
+		s.mockMetadataMgr,
+		s.mockScheduleMgr,
+		s.mockHistoryClient,
+		s.mockResolver,
+		"self",
+		NewConfig(dynamicconfig.NewNopCollection()),
+		bark.NewLoggerFromLogrus(logrus.New()),
+		metrics.NewClient(tally.NoopScope, metrics.Worker),
+	)
+}
+
+func (s *scheduleProcessorSuite) TearDownTest() {
+	s.mockHistoryClient.AssertExpectations(s.T())
+	s.mockMetadataMgr.AssertExpectations(s.T())
+	s.mockScheduleMgr.AssertExpectations(s.T())
+	s.mockResolver.AssertExpectations(s.T())
+}
+
+func (s *scheduleProcessorSuite) newSchedule(policy shared.ScheduleOverlapPolicy) *persistence.ScheduleInfo {
+	return &persistence.ScheduleInfo{
+		DomainID:          "domain-id",
+		ScheduleID:        "schedule",
+		IntervalInSeconds: 60,
+		WorkflowTypeName:  "workflow-type",
+		TaskList:          "task-list",
+		OverlapPolicy:     int(policy),
+		NextRunTime:       time.Unix(60, 0),
+		LastWorkflowID:    "schedule-1970-01-01T00:00:00Z",
+		LastRunID:         "last-run-id",
+		Version:           3,
+	}
+}
+
+func (s *scheduleProcessorSuite) mockLastRun(open bool) {
+	info := &shared.WorkflowExecutionInfo{}
+	if !open {
+		info.CloseStatus = shared.WorkflowExecutionCloseStatusCompleted.Ptr()
+	}
+	s.mockHistoryClient.On("DescribeWorkflowExecution", mock.Anything, mock.Anything).
+		Return(&shared.DescribeWorkflowExecutionResponse{WorkflowExecutionInfo: info}, nil).Once()
+}
+
+func (s *scheduleProcessorSuite) mockStart(workflowID string) {
+	s.mockHistoryClient.On("StartWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *h.StartWorkflowExecutionRequest) bool {
+			return request.StartRequest.GetWorkflowId() == workflowID
+		})).Return(&shared.StartWorkflowExecutionResponse{RunId: common.StringPtr("new-run-id")}, nil).Once()
+}
+
+func (s *scheduleProcessorSuite) mockUpdate() {
+	s.mockScheduleMgr.On("UpdateSchedule", mock.MatchedBy(func(request *persistence.UpdateScheduleRequest) bool {
+		return request.PreviousVersion == 3 && request.Schedule.Version == 4
+	})).Return(nil).Once()
+}
+
+func (s *scheduleProcessorSuite) TestProcessDomains_ListsV1Domains() {
+	s.mockMetadataMgr.On("ListDomains", &persistence.ListDomainsRequest{
+		PageSize:         scheduleListPageSize,
+		IncludeV1Domains: true,
+	}).Return(&persistence.ListDomainsResponse{
+		Domains: []*persistence.GetDomainResponse{
+			{
+				Info: &persistence.DomainInfo{
+					ID:     "v1-domain-id",
+					Name:   "v1-domain",
+					Status: persistence.DomainStatusRegistered,
+				},
+				TableVersion: persistence.DomainTableVersionV1,
+			},
+		},
+	}, nil).Once()
+	s.mockResolver.On("Lookup", "v1-domain-id").Return(membership.NewHostInfo("self", nil), nil).Once()
+	s.mockScheduleMgr.On("ListSchedules", &persistence.ListSchedulesRequest{
+		DomainID: "v1-domain-id",
+		PageSize: scheduleListPageSize,
+	}).Return(&persistence.ListSchedulesResponse{}, nil).Once()
+
+	s.processor.processDomains()
+}
+
+func (s *scheduleProcessorSuite) TestProcessSchedule_NotDue() {
+	schedule := s.newSchedule(shared.ScheduleOverlapPolicySkip)
+	s.NoError(s.processor.processSchedule("domain", schedule, time.Unix(30, 0)))
+}
+
+func (s *scheduleProcessorSuite) TestProcessSchedule_Paused() {
+	schedule := s.newSchedule(shared.ScheduleOverlapPolicySkip)
+	schedule.Paused = true
+	s.NoError(s.processor.processSchedule("domain", schedule, time.Unix(90, 0)))
+}
+
+func (s *scheduleProcessorSuite) TestProcessSchedule_Start_CollapsesMissedRuns() {
+	schedule := s.newSchedule(shared.ScheduleOverlapPolicySkip)
+	s.mockLastRun(false)
+	s.mockStart("schedule-1970-01-01T00:01:00Z")
+	s.mockUpdate()
+
+	s.NoError(s.processor.processSchedule("domain", schedule, time.Unix(250, 0)))
+	s.Equal(time.Unix(300, 0), schedule.NextRunTime)
+	s.Equal(time.Unix(60, 0), schedule.LastRunTime)
+	s.Equal("new-run-id", schedule.LastRunID)
+}
+
+func (s *scheduleProcessorSuite) TestProcessSchedule_Start_AlreadyStarted() {
+	schedule := s.newSchedule(shared.ScheduleOverlapPolicySkip)
+	s.mockLastRun(false)
+	s.mockHistoryClient.On("StartWorkflowExecution", mock.Anything, mock.Anything).
+		Return(nil, &shared.WorkflowExecutionAlreadyStartedError{RunId: common.StringPtr("started-run-id")}).Once()
+	s.mockUpdate()
+
+	s.NoError(s.processor.processSchedule("domain", schedule, time.Unix(60, 0)))
+	s.Equal("schedule-1970-01-01T00:01:00Z", schedule.LastWorkflowID)
+	s.Equal("started-run-id", schedule.LastRunID)
+}
+
+func (s *scheduleProcessorSuite) TestProcessSchedule_Skip() {
+	schedule := s.newSchedule(shared.ScheduleOverlapPolicySkip)
+	s.mockLastRun(true)
+	s.mockUpdate()
+
+	s.NoError(s.processor.processSchedule("domain", schedule, time.Unix(60, 0)))
+	s.Equal(time.Unix(120, 0), schedule.NextRunTime)
+	s.Equal("last-run-id", schedule.LastRunID)
+	s.Empty(schedule.BufferedRunTimes)
+}
+
+func (s *scheduleProcessorSuite) TestProcessSchedule_Buffer() {
+	schedule := s.newSchedule(shared.ScheduleOverlapPolicyBuffer)
+	s.mockLastRun(true)
+	s.mockUpdate()
+
+	s.NoError(s.processor.processSchedule("domain", schedule, time.Unix(60, 0)))
+	s.Equal([]time.Time{time.Unix(60, 0)}, schedule.BufferedRunTimes)
+	s.Equal("last-run-id", schedule.LastRunID)
+}
+
+func (s *scheduleProcessorSuite) TestProcessSchedule_StartBuffered() {
+	schedule := s.newSchedule(shared.ScheduleOverlapPolicyBuffer)
+	schedule.BufferedRunTimes = []time.Time{time.Unix(60, 0)}
+	schedule.NextRunTime = time.Unix(120, 0)
+	s.mockLastRun(false)
+	s.mockStart("schedule-1970-01-01T00:01:00Z")
+	s.mockUpdate()
+
+	s.NoError(s.processor.processSchedule("domain", schedule, time.Unix(90, 0)))
+	s.Empty(schedule.BufferedRunTimes)
+	s.Equal(time.Unix(120, 0), schedule.NextRunTime)
+	s.Equal("new-run-id", schedule.LastRunID)
+}
+
+func (s *scheduleProcessorSuite) TestProcessSchedule_CancelOther() {
+	schedule := s.newSchedule(shared.ScheduleOverlapPolicyCancelOther)
+	s.mockLastRun(true)
+	s.mockHistoryClient.On("RequestCancelWorkflowExecution", mock.Anything, mock.MatchedBy(
+		func(request *h.RequestCancelWorkflowExecutionRequest) bool {
+			return request.CancelRequest.WorkflowExecution.GetRunId() == "last-run-id"
+		})).Return(&shared.CancellationAlreadyRequestedError{}).Once()
+	s.mockStart("schedule-1970-01-01T00:01:00Z")
+	s.mockUpdate()
+
+	s.NoError(s.processor.processSchedule("domain", schedule, time.Unix(60, 0)))
+	s.Equal("new-run-id", schedule.LastRunID)
+}
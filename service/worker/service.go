@@ -21,6 +21,9 @@
 package worker
 
 import (
+	"time"
+
+	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
@@ -31,7 +34,8 @@ import (
 type (
 	// Service represents the cadence-worker service.  This service host all background processing which needs to happen
 	// for a Cadence cluster.  This service runs the replicator which is responsible for applying replication tasks
-	// generated by remote clusters.
+	// generated by remote clusters, along with the schedule processor, the visibility pruner, the history migrator and
+	// the domain registration processor.
 	Service struct {
 		stopC         chan struct{}
 		params        *service.BootstrapParams
@@ -46,14 +50,38 @@ type (
 		ReplicatorConcurrency      int
 		ReplicatorBufferRetryCount int
 		ReplicationTaskMaxRetry    int
+		// how often the depth and oldest message age of the replication DLQs are reported
+		ReplicatorDLQMetricsInterval dynamicconfig.DurationPropertyFn
+
+		// Schedule processor settings
+		ScheduleProcessorRefreshInterval dynamicconfig.DurationPropertyFn
+		ScheduleMaxBufferedRuns          dynamicconfig.IntPropertyFn
+
+		// Visibility pruner settings
+		VisibilityPrunerInterval dynamicconfig.DurationPropertyFn
+
+		// History migrator settings
+		HistoryMgrNumConns               int
+		HistoryMigrationTargetEncoding   dynamicconfig.StringPropertyFnWithDomainFilter
+		HistoryMigrationInterval         dynamicconfig.DurationPropertyFn
+		HistoryMigrationPagesPerInterval dynamicconfig.IntPropertyFn
+
+		// Domain registration processor settings
+		DomainRegistrationInterval     dynamicconfig.DurationPropertyFn
+		DomainRegistrationEmailDomains dynamicconfig.StringPropertyFn
+		// DomainRegistrationPolicies review the pending domains after the email domain policy, see DomainRegistrationPolicy
+		DomainRegistrationPolicies []DomainRegistrationPolicy
 	}
 )
 
-// NewService builds a new cadence-worker service
-func NewService(params *service.BootstrapParams) common.Daemon {
+// NewService builds a new cadence-worker service, the given policies review the domains pending registration
+// approval after the built-in email domain policy
+func NewService(params *service.BootstrapParams, registrationPolicies ...DomainRegistrationPolicy) common.Daemon {
+	config := NewConfig(dynamicconfig.NewCollection(params.DynamicConfig, params.Logger))
+	config.DomainRegistrationPolicies = registrationPolicies
 	return &Service{
 		params: params,
-		config: NewConfig(dynamicconfig.NewCollection(params.DynamicConfig, params.Logger)),
+		config: config,
 		stopC:  make(chan struct{}),
 	}
 }
@@ -65,6 +93,21 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 		ReplicatorConcurrency:      1000,
 		ReplicatorBufferRetryCount: 8,
 		ReplicationTaskMaxRetry:    5,
+		ReplicatorDLQMetricsInterval: dc.GetDurationProperty(dynamicconfig.WorkerReplicatorDLQMetricsInterval,
+			time.Minute),
+
+		ScheduleProcessorRefreshInterval: dc.GetDurationProperty(dynamicconfig.WorkerScheduleProcessorRefreshInterval, 10*time.Second),
+		ScheduleMaxBufferedRuns:          dc.GetIntProperty(dynamicconfig.WorkerScheduleMaxBufferedRuns, 10),
+
+		VisibilityPrunerInterval: dc.GetDurationProperty(dynamicconfig.WorkerVisibilityPrunerInterval, time.Hour),
+
+		HistoryMgrNumConns:               10,
+		HistoryMigrationTargetEncoding:   dc.GetStringPropertyFilteredByDomain(dynamicconfig.WorkerHistoryMigrationTargetEncoding, ""),
+		HistoryMigrationInterval:         dc.GetDurationProperty(dynamicconfig.WorkerHistoryMigrationInterval, time.Minute),
+		HistoryMigrationPagesPerInterval: dc.GetIntProperty(dynamicconfig.WorkerHistoryMigrationPagesPerInterval, 10),
+
+		DomainRegistrationInterval:     dc.GetDurationProperty(dynamicconfig.WorkerDomainRegistrationInterval, 30*time.Second),
+		DomainRegistrationEmailDomains: dc.GetStringProperty(dynamicconfig.WorkerDomainRegistrationEmailDomains, ""),
 	}
 }
 
@@ -82,11 +125,10 @@ func (s *Service) Start() {
 
 	s.metricsClient = base.GetMetricsClient()
 
-	// worker only use the v2
+	// the replicator only use the v2, it only deals with global domains
 	metadataManager, err := persistence.NewCassandraMetadataPersistenceV2(p.CassandraConfig.Hosts,
 		p.CassandraConfig.Port,
-		p.CassandraConfig.User,
-		p.CassandraConfig.Password,
+		p.CassandraCredentials,
 		p.CassandraConfig.Datacenter,
 		p.CassandraConfig.Keyspace,
 		p.ClusterMetadata.GetCurrentClusterName(),
@@ -98,20 +140,120 @@ func (s *Service) Start() {
 	metadataManager = persistence.NewMetadataPersistenceRateLimitedClient(metadataManager, persistenceRateLimiter, log)
 	metadataManager = persistence.NewMetadataPersistenceMetricsClient(metadataManager, base.GetMetricsClient(), log)
 
+	// the other processors go through the proxy, which covers the domains of both tables
+	metadataProxy, err := persistence.NewMetadataManagerProxy(p.CassandraConfig.Hosts,
+		p.CassandraConfig.Port,
+		p.CassandraCredentials,
+		p.CassandraConfig.Datacenter,
+		p.CassandraConfig.Keyspace,
+		p.ClusterMetadata.GetCurrentClusterName(),
+		p.Logger)
+	if err != nil {
+		log.Fatalf("failed to create metadata manager proxy: %v", err)
+	}
+	metadataProxy = persistence.NewMetadataPersistenceRateLimitedClient(metadataProxy, persistenceRateLimiter, log)
+	metadataProxy = persistence.NewMetadataPersistenceMetricsClient(metadataProxy, base.GetMetricsClient(), log)
+
 	history, err := base.GetClientFactory().NewHistoryClient()
 	if err != nil {
 		log.Fatalf("failed to create history service client: %v", err)
 	}
 
-	replicator := NewReplicator(p.ClusterMetadata, metadataManager, history, s.config, p.MessagingClient, log,
+	shardManager, err := persistence.NewCassandraShardPersistence(p.CassandraConfig.Hosts,
+		p.CassandraConfig.Port,
+		p.CassandraCredentials,
+		p.CassandraConfig.Datacenter,
+		p.CassandraConfig.Keyspace,
+		p.ClusterMetadata.GetCurrentClusterName(),
+		p.Logger)
+	if err != nil {
+		log.Fatalf("failed to create shard manager: %v", err)
+	}
+	shardManager = persistence.NewShardPersistenceRateLimitedClient(shardManager, persistenceRateLimiter, log)
+	shardManager = persistence.NewShardPersistenceMetricsClient(shardManager, base.GetMetricsClient(), log)
+
+	resolver, err := base.GetMembershipMonitor().GetResolver(common.WorkerServiceName)
+	if err != nil {
+		log.Fatalf("failed to get worker service resolver: %v", err)
+	}
+
+	matching, err := base.GetClientFactory().NewMatchingClient()
+	if err != nil {
+		log.Fatalf("failed to create matching service client: %v", err)
+	}
+	frontendResolver, err := base.GetMembershipMonitor().GetResolver(common.FrontendServiceName)
+	if err != nil {
+		log.Fatalf("failed to get frontend service resolver: %v", err)
+	}
+	domainCacheRefresher := client.NewDomainCacheRefresher(base.GetClientFactory(), frontendResolver, history, matching)
+
+	replicator := NewReplicator(p.ClusterMetadata, metadataManager, shardManager, p.CassandraConfig.NumHistoryShards,
+		history, domainCacheRefresher, resolver, base.GetHostInfo().Identity(), s.config, p.MessagingClient, log,
 		s.metricsClient)
 	if err := replicator.Start(); err != nil {
 		replicator.Stop()
 		log.Fatalf("Fail to start replicator: %v", err)
 	}
 
+	scheduleManager, err := persistence.NewCassandraSchedulePersistence(p.CassandraConfig.Hosts,
+		p.CassandraConfig.Port,
+		p.CassandraCredentials,
+		p.CassandraConfig.Datacenter,
+		p.CassandraConfig.Keyspace,
+		p.Logger)
+	if err != nil {
+		log.Fatalf("failed to create schedule manager: %v", err)
+	}
+	scheduleManager = persistence.NewSchedulePersistenceRateLimitedClient(scheduleManager, persistenceRateLimiter, log)
+	scheduleManager = persistence.NewSchedulePersistenceMetricsClient(scheduleManager, base.GetMetricsClient(), log)
+
+	scheduleProcessor := NewScheduleProcessor(p.ClusterMetadata, metadataProxy, scheduleManager, history, resolver,
+		base.GetHostInfo().Identity(), s.config, log, s.metricsClient)
+	scheduleProcessor.Start()
+
+	visibilityManager, err := persistence.NewCassandraVisibilityPersistence(p.CassandraConfig.Hosts,
+		p.CassandraConfig.Port,
+		p.CassandraCredentials,
+		p.CassandraConfig.Datacenter,
+		p.CassandraConfig.VisibilityKeyspace,
+		p.Logger)
+	if err != nil {
+		log.Fatalf("failed to create visibility manager: %v", err)
+	}
+	visibilityManager = persistence.NewVisibilityPersistenceRateLimitedClient(visibilityManager, persistenceRateLimiter, log)
+	visibilityManager = persistence.NewVisibilityPersistenceMetricsClient(visibilityManager, base.GetMetricsClient(), log)
+
+	visibilityPruner := NewVisibilityPruner(metadataProxy, visibilityManager, resolver, base.GetHostInfo().Identity(),
+		s.config, log, s.metricsClient)
+	visibilityPruner.Start()
+
+	historyManager, err := persistence.NewCassandraHistoryPersistence(p.CassandraConfig.Hosts,
+		p.CassandraConfig.Port,
+		p.CassandraCredentials,
+		p.CassandraConfig.Datacenter,
+		p.CassandraConfig.Keyspace,
+		s.config.HistoryMgrNumConns,
+		p.Logger)
+	if err != nil {
+		log.Fatalf("failed to create history manager: %v", err)
+	}
+	historyManager = persistence.NewHistoryPersistenceRateLimitedClient(historyManager, persistenceRateLimiter, log)
+	historyManager = persistence.NewHistoryPersistenceMetricsClient(historyManager, base.GetMetricsClient(), log)
+
+	historyMigrator := NewHistoryMigrator(metadataProxy, shardManager, historyManager,
+		p.CassandraConfig.NumHistoryShards, resolver, base.GetHostInfo().Identity(), s.config, log, s.metricsClient)
+	historyMigrator.Start()
+
+	domainRegistrationProcessor := NewDomainRegistrationProcessor(metadataProxy, resolver,
+		base.GetHostInfo().Identity(), s.config, log, s.metricsClient, s.config.DomainRegistrationPolicies...)
+	domainRegistrationProcessor.Start()
+
 	log.Infof("%v started", common.WorkerServiceName)
 	<-s.stopC
+	domainRegistrationProcessor.Stop()
+	historyMigrator.Stop()
+	visibilityPruner.Stop()
+	scheduleProcessor.Stop()
 	base.Stop()
 }
 
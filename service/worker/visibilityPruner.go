@@ -0,0 +1,186 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package worker
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	visibilityPrunerListPageSize = 100
+	// the retention of a domain without one, same as the TTL of the records it closes
+	defaultVisibilityRetentionDays = 1
+)
+
+type (
+	// VisibilityPruner drops the closed execution records of the domains owned by this worker host once they are past
+	// the retention of their domain. Visibility records are local to a cluster, so every cluster prunes its own,
+	// whether the domain is active in it or not.
+	VisibilityPruner struct {
+		metadataMgr   persistence.MetadataManager
+		visibilityMgr persistence.VisibilityManager
+		resolver      membership.ServiceResolver
+		hostIdentity  string
+		config        *Config
+		logger        bark.Logger
+		metricsClient metrics.Client
+
+		isStarted  int32
+		isStopped  int32
+		shutdownWG sync.WaitGroup
+		shutdownCh chan struct{}
+	}
+)
+
+// NewVisibilityPruner creates a new pruner for the visibility records owned by the given worker host, the metadata
+// manager should cover the domains of both metadata tables
+func NewVisibilityPruner(metadataMgr persistence.MetadataManager, visibilityMgr persistence.VisibilityManager,
+	resolver membership.ServiceResolver, hostIdentity string, config *Config, logger bark.Logger,
+	metricsClient metrics.Client) *VisibilityPruner {
+	return &VisibilityPruner{
+		metadataMgr:   metadataMgr,
+		visibilityMgr: visibilityMgr,
+		resolver:      resolver,
+		hostIdentity:  hostIdentity,
+		config:        config,
+		logger: logger.WithFields(bark.Fields{
+			logging.TagWorkflowComponent: logging.TagValueVisibilityPrunerComponent,
+		}),
+		metricsClient: metricsClient,
+		shutdownCh:    make(chan struct{}),
+	}
+}
+
+// Start starts the pruner
+func (p *VisibilityPruner) Start() {
+	if !atomic.CompareAndSwapInt32(&p.isStarted, 0, 1) {
+		return
+	}
+
+	p.shutdownWG.Add(1)
+	go p.prunerPump()
+	p.logger.Info("Visibility pruner started.")
+}
+
+// Stop stops the pruner
+func (p *VisibilityPruner) Stop() {
+	if !atomic.CompareAndSwapInt32(&p.isStopped, 0, 1) {
+		return
+	}
+
+	if atomic.LoadInt32(&p.isStarted) == 1 {
+		close(p.shutdownCh)
+	}
+
+	if success := common.AwaitWaitGroup(&p.shutdownWG, time.Minute); !success {
+		p.logger.Warn("Visibility pruner timed out on shutdown.")
+	}
+	p.logger.Info("Visibility pruner stopped.")
+}
+
+func (p *VisibilityPruner) prunerPump() {
+	defer p.shutdownWG.Done()
+
+	timer := time.NewTimer(p.config.VisibilityPrunerInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case <-timer.C:
+			p.pruneDomains(time.Now())
+			timer.Reset(p.config.VisibilityPrunerInterval())
+		}
+	}
+}
+
+func (p *VisibilityPruner) pruneDomains(now time.Time) {
+	var token []byte
+	for {
+		resp, err := p.metadataMgr.ListDomains(&persistence.ListDomainsRequest{
+			PageSize:         visibilityPrunerListPageSize,
+			NextPageToken:    token,
+			IncludeV1Domains: true,
+		})
+		if err != nil {
+			p.metricsClient.IncCounter(metrics.VisibilityPrunerScope, metrics.VisibilityPrunerFailures)
+			p.logger.WithField(logging.TagErr, err).Warn("Failed to list domains.")
+			return
+		}
+
+		for _, domain := range resp.Domains {
+			if p.isDomainOwned(domain.Info) {
+				p.pruneDomain(domain, now)
+			}
+		}
+
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			return
+		}
+	}
+}
+
+// isDomainOwned returns whether this host prunes the visibility records of the domain
+func (p *VisibilityPruner) isDomainOwned(domain *persistence.DomainInfo) bool {
+	host, err := p.resolver.Lookup(domain.ID)
+	if err != nil {
+		p.logger.WithField(logging.TagErr, err).Warn("Failed to lookup the worker host of a domain.")
+		return false
+	}
+	return host.Identity() == p.hostIdentity
+}
+
+func (p *VisibilityPruner) pruneDomain(domain *persistence.GetDomainResponse, now time.Time) {
+	retentionDays := domain.Config.Retention
+	if retentionDays <= 0 {
+		retentionDays = defaultVisibilityRetentionDays
+	}
+	closeTimeBefore := now.Add(-time.Duration(retentionDays) * 24 * time.Hour)
+
+	resp, err := p.visibilityMgr.PruneClosedWorkflowExecutions(&persistence.PruneClosedWorkflowExecutionsRequest{
+		DomainUUID:      domain.Info.ID,
+		CloseTimeBefore: closeTimeBefore.UnixNano(),
+	})
+	if resp != nil && resp.PrunedBuckets > 0 {
+		p.metricsClient.AddCounter(metrics.VisibilityPrunerScope, metrics.VisibilityPrunedBuckets,
+			int64(resp.PrunedBuckets))
+		p.logger.WithFields(bark.Fields{
+			logging.TagDomainID: domain.Info.ID,
+		}).Infof("Pruned %v close buckets of visibility records.", resp.PrunedBuckets)
+	}
+	if err != nil {
+		p.metricsClient.IncCounter(metrics.VisibilityPrunerScope, metrics.VisibilityPrunerFailures)
+		p.logger.WithFields(bark.Fields{
+			logging.TagDomainID: domain.Info.ID,
+			logging.TagErr:      err,
+		}).Warn("Failed to prune visibility records.")
+	}
+}
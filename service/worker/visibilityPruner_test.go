@@ -0,0 +1,118 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package worker
+
+import (
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	visibilityPrunerSuite struct {
+		suite.Suite
+		mockMetadataMgr   *mocks.MetadataManager
+		mockVisibilityMgr *mocks.VisibilityManager
+		mockResolver      *mocks.ServiceResolver
+		pruner            *VisibilityPruner
+	}
+)
+
+func TestVisibilityPrunerSuite(t *testing.T) {
+	s := new(visibilityPrunerSuite)
+	suite.Run(t, s)
+}
+
+func (s *visibilityPrunerSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+}
+
+func (s *visibilityPrunerSuite) SetupTest() {
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+	s.mockVisibilityMgr = &mocks.VisibilityManager{}
+	s.mockResolver = &mocks.ServiceResolver{}
+	s.pruner = NewVisibilityPruner(
+		s.mockMetadataMgr,
+		s.mockVisibilityMgr,
+		s.mockResolver,
+		"self",
+		NewConfig(dynamicconfig.NewNopCollection()),
+		bark.NewLoggerFromLogrus(logrus.New()),
+		metrics.NewClient(tally.NoopScope, metrics.Worker),
+	)
+}
+
+func (s *visibilityPrunerSuite) TearDownTest() {
+	s.mockMetadataMgr.AssertExpectations(s.T())
+	s.mockVisibilityMgr.AssertExpectations(s.T())
+	s.mockResolver.AssertExpectations(s.T())
+}
+
+func (s *visibilityPrunerSuite) TestPruneDomains() {
+	now := time.Now()
+	s.mockMetadataMgr.On("ListDomains", &persistence.ListDomainsRequest{
+		PageSize:         visibilityPrunerListPageSize,
+		IncludeV1Domains: true,
+	}).Return(&persistence.ListDomainsResponse{
+		Domains: []*persistence.GetDomainResponse{
+			{
+				Info:   &persistence.DomainInfo{ID: "owned-domain"},
+				Config: &persistence.DomainConfig{Retention: 7},
+			},
+			{
+				Info:   &persistence.DomainInfo{ID: "no-retention-domain"},
+				Config: &persistence.DomainConfig{Retention: 0},
+			},
+			{
+				Info:   &persistence.DomainInfo{ID: "other-domain"},
+				Config: &persistence.DomainConfig{Retention: 7},
+			},
+		},
+	}, nil).Once()
+	s.mockResolver.On("Lookup", "owned-domain").Return(membership.NewHostInfo("self", nil), nil).Once()
+	s.mockResolver.On("Lookup", "no-retention-domain").Return(membership.NewHostInfo("self", nil), nil).Once()
+	s.mockResolver.On("Lookup", "other-domain").Return(membership.NewHostInfo("other", nil), nil).Once()
+
+	s.mockVisibilityMgr.On("PruneClosedWorkflowExecutions", &persistence.PruneClosedWorkflowExecutionsRequest{
+		DomainUUID:      "owned-domain",
+		CloseTimeBefore: now.Add(-7 * 24 * time.Hour).UnixNano(),
+	}).Return(&persistence.PruneClosedWorkflowExecutionsResponse{PrunedBuckets: 2}, nil).Once()
+	s.mockVisibilityMgr.On("PruneClosedWorkflowExecutions", &persistence.PruneClosedWorkflowExecutionsRequest{
+		DomainUUID:      "no-retention-domain",
+		CloseTimeBefore: now.Add(-24 * time.Hour).UnixNano(),
+	}).Return(nil, errors.New("some random error")).Once()
+
+	s.pruner.pruneDomains(now)
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package capacitysim
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+type (
+	// Spec describes the cluster being planned: its static settings and the dynamic config the history and matching
+	// services would run with
+	Spec struct {
+		// NumHistoryShards is the number of history shards of the cluster
+		NumHistoryShards int `yaml:"numHistoryShards"`
+		// Growth scales all the rates of the snapshot, e.g. 2 plans for twice the recorded traffic, 1 if unset
+		Growth float64 `yaml:"growth"`
+		// TargetUtilization is the fraction of the limits of a host the plan is allowed to use, 0.7 if unset
+		TargetUtilization float64 `yaml:"targetUtilization"`
+		// HistoryHostMemoryBytes is the memory of a history host available to the mutable state caches of its
+		// shards, 0 leaves the memory out of the plan
+		HistoryHostMemoryBytes int64 `yaml:"historyHostMemoryBytes"`
+		// DynamicConfig overrides the defaults of the service configs, keyed by dynamic config key name, e.g.
+		// history.transferTaskWorkerCount, durations are given as strings, e.g. 1m
+		DynamicConfig map[string]interface{} `yaml:"dynamicConfig"`
+	}
+
+	// Snapshot holds the traffic and the persistence latencies recorded from the metrics of a running cluster,
+	// rates are per second across the cluster
+	Snapshot struct {
+		// WorkflowStartsPerSecond is the rate of started workflow executions
+		WorkflowStartsPerSecond float64 `yaml:"workflowStartsPerSecond"`
+		// DecisionsPerWorkflow is the average number of decision tasks completed by a workflow execution
+		DecisionsPerWorkflow float64 `yaml:"decisionsPerWorkflow"`
+		// ActivitiesPerWorkflow is the average number of activity tasks scheduled by a workflow execution
+		ActivitiesPerWorkflow float64 `yaml:"activitiesPerWorkflow"`
+		// TimersPerWorkflow is the average number of user timers started by a workflow execution
+		TimersPerWorkflow float64 `yaml:"timersPerWorkflow"`
+		// SignalsPerSecond is the rate of signals to workflow executions
+		SignalsPerSecond float64 `yaml:"signalsPerSecond"`
+		// HotShardFactor is the ratio of the load of the busiest history shard to the average, 1 if unset
+		HotShardFactor float64 `yaml:"hotShardFactor"`
+		// MutableStateBytes is the average size of a mutable state in the history cache
+		MutableStateBytes int64 `yaml:"mutableStateBytes"`
+		// TransferTaskLatency and TimerTaskLatency are the average task-latency of the transfer and timer queue
+		// processors, estimated from the persistence latencies if unset
+		TransferTaskLatency time.Duration `yaml:"transferTaskLatency"`
+		TimerTaskLatency    time.Duration `yaml:"timerTaskLatency"`
+		// PersistenceLatencies are the average latencies of the persistence operations, keyed by operation as
+		// in the persistence metric scopes, e.g. UpdateWorkflowExecution
+		PersistenceLatencies map[string]time.Duration `yaml:"persistenceLatencies"`
+		// SyncMatchRate is the fraction of the tasks handed to a waiting poller without being persisted
+		SyncMatchRate float64 `yaml:"syncMatchRate"`
+		// TaskLists are the rates of tasks added per task list, the tasks of the workflows are spread over a
+		// single task list if empty
+		TaskLists []TaskListSnapshot `yaml:"taskLists"`
+	}
+
+	// TaskListSnapshot is the rate of tasks added to a task list
+	TaskListSnapshot struct {
+		Name           string  `yaml:"name"`
+		TasksPerSecond float64 `yaml:"tasksPerSecond"`
+	}
+
+	// ConfigError is an error type that
+	// represents a problem with the spec or the snapshot
+	ConfigError struct {
+		msg string
+	}
+)
+
+const (
+	defaultTargetUtilization = 0.7
+	// used for the persistence operations missing from the snapshot
+	defaultPersistenceLatency = 10 * time.Millisecond
+)
+
+// LoadSpec reads a spec from the given yaml file
+func LoadSpec(path string) (*Spec, error) {
+	spec := &Spec{}
+	if err := loadYaml(path, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// LoadSnapshot reads a snapshot from the given yaml file
+func LoadSnapshot(path string) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	if err := loadYaml(path, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func loadYaml(path string, out interface{}) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(content, out); err != nil {
+		return fmt.Errorf("failed to parse %v: %v", path, err)
+	}
+	return nil
+}
+
+func validate(spec *Spec, snapshot *Snapshot) error {
+	if spec.NumHistoryShards <= 0 {
+		return newConfigError("number of history shards must be positive")
+	}
+	if spec.Growth < 0 {
+		return newConfigError("growth cannot be negative")
+	}
+	if spec.TargetUtilization < 0 || spec.TargetUtilization > 1 {
+		return newConfigError("target utilization must be between 0 and 1")
+	}
+	if snapshot.WorkflowStartsPerSecond < 0 || snapshot.SignalsPerSecond < 0 {
+		return newConfigError("rates cannot be negative")
+	}
+	if snapshot.SyncMatchRate < 0 || snapshot.SyncMatchRate > 1 {
+		return newConfigError("sync match rate must be between 0 and 1")
+	}
+	for _, taskList := range snapshot.TaskLists {
+		if taskList.TasksPerSecond < 0 {
+			return newConfigError(fmt.Sprintf("rate of task list %v cannot be negative", taskList.Name))
+		}
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the spec and the snapshot, with the rates scaled by the growth and the unset
+// values defaulted
+func withDefaults(spec *Spec, snapshot *Snapshot) (*Spec, *Snapshot) {
+	s := *spec
+	if s.Growth == 0 {
+		s.Growth = 1
+	}
+	if s.TargetUtilization == 0 {
+		s.TargetUtilization = defaultTargetUtilization
+	}
+
+	w := *snapshot
+	w.WorkflowStartsPerSecond *= s.Growth
+	w.SignalsPerSecond *= s.Growth
+	w.TaskLists = nil
+	for _, taskList := range snapshot.TaskLists {
+		taskList.TasksPerSecond *= s.Growth
+		w.TaskLists = append(w.TaskLists, taskList)
+	}
+	if w.HotShardFactor < 1 {
+		w.HotShardFactor = 1
+	}
+	// a task loads and updates the mutable state, then is completed
+	if w.TransferTaskLatency <= 0 {
+		w.TransferTaskLatency = w.persistenceLatency(opUpdateWorkflowExecution) +
+			w.persistenceLatency(opCompleteTransferTask)
+	}
+	if w.TimerTaskLatency <= 0 {
+		w.TimerTaskLatency = w.persistenceLatency(opUpdateWorkflowExecution) + w.persistenceLatency(opCompleteTimerTask)
+	}
+	return &s, &w
+}
+
+func (w *Snapshot) persistenceLatency(operation string) time.Duration {
+	if latency, ok := w.PersistenceLatencies[operation]; ok && latency > 0 {
+		return latency
+	}
+	return defaultPersistenceLatency
+}
+
+func newConfigError(msg string) error {
+	return &ConfigError{msg: msg}
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("Config Error:%v", e.msg)
+}
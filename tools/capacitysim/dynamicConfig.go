@@ -0,0 +1,171 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package capacitysim
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	// overridesClient is a dynamic config client serving the overrides of a spec, filters are ignored so an
+	// override applies to all the domains and task lists. It keeps track of the overrides read, so the ones
+	// the simulation does not depend on, or misspelled, can be reported.
+	overridesClient struct {
+		values map[string]interface{}
+		read   map[string]bool
+		// invalid are the overrides of the wrong type, which the collection falls back to the defaults for
+		invalid []string
+	}
+)
+
+var errNoOverride = errors.New("no override for key")
+
+func newOverridesClient(values map[string]interface{}) *overridesClient {
+	return &overridesClient{values: values, read: map[string]bool{}}
+}
+
+// unread returns the sorted names of the overrides which were never read
+func (c *overridesClient) unread() []string {
+	var names []string
+	for name := range c.values {
+		if !c.read[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *overridesClient) GetValue(name dynamicconfig.Key, defaultValue interface{}) (interface{}, error) {
+	if value, ok := c.values[name.String()]; ok {
+		c.read[name.String()] = true
+		return value, nil
+	}
+	return defaultValue, errNoOverride
+}
+
+func (c *overridesClient) GetValueWithFilters(
+	name dynamicconfig.Key, filters map[dynamicconfig.Filter]interface{}, defaultValue interface{},
+) (interface{}, error) {
+	return c.GetValue(name, defaultValue)
+}
+
+func (c *overridesClient) GetIntValue(
+	name dynamicconfig.Key, filters map[dynamicconfig.Filter]interface{}, defaultValue int,
+) (int, error) {
+	value, err := c.GetValue(name, defaultValue)
+	if err != nil {
+		return defaultValue, err
+	}
+	if intValue, ok := value.(int); ok {
+		return intValue, nil
+	}
+	return defaultValue, c.typeError(name, value, "int")
+}
+
+func (c *overridesClient) GetFloatValue(
+	name dynamicconfig.Key, filters map[dynamicconfig.Filter]interface{}, defaultValue float64,
+) (float64, error) {
+	value, err := c.GetValue(name, defaultValue)
+	if err != nil {
+		return defaultValue, err
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	}
+	return defaultValue, c.typeError(name, value, "float")
+}
+
+func (c *overridesClient) GetBoolValue(
+	name dynamicconfig.Key, filters map[dynamicconfig.Filter]interface{}, defaultValue bool,
+) (bool, error) {
+	value, err := c.GetValue(name, defaultValue)
+	if err != nil {
+		return defaultValue, err
+	}
+	if boolValue, ok := value.(bool); ok {
+		return boolValue, nil
+	}
+	return defaultValue, c.typeError(name, value, "bool")
+}
+
+func (c *overridesClient) GetStringValue(
+	name dynamicconfig.Key, filters map[dynamicconfig.Filter]interface{}, defaultValue string,
+) (string, error) {
+	value, err := c.GetValue(name, defaultValue)
+	if err != nil {
+		return defaultValue, err
+	}
+	if stringValue, ok := value.(string); ok {
+		return stringValue, nil
+	}
+	return defaultValue, c.typeError(name, value, "string")
+}
+
+func (c *overridesClient) GetMapValue(
+	name dynamicconfig.Key, filters map[dynamicconfig.Filter]interface{}, defaultValue map[string]interface{},
+) (map[string]interface{}, error) {
+	value, err := c.GetValue(name, defaultValue)
+	if err != nil {
+		return defaultValue, err
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, nil
+	case map[interface{}]interface{}:
+		// the maps nested in a yaml document are decoded with interface keys
+		mapValue := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			mapValue[fmt.Sprintf("%v", key)] = item
+		}
+		return mapValue, nil
+	}
+	return defaultValue, c.typeError(name, value, "map")
+}
+
+func (c *overridesClient) GetDurationValue(
+	name dynamicconfig.Key, filters map[dynamicconfig.Filter]interface{}, defaultValue time.Duration,
+) (time.Duration, error) {
+	value, err := c.GetValue(name, defaultValue)
+	if err != nil {
+		return defaultValue, err
+	}
+	if stringValue, ok := value.(string); ok {
+		if duration, err := time.ParseDuration(stringValue); err == nil {
+			return duration, nil
+		}
+	}
+	return defaultValue, c.typeError(name, value, "duration")
+}
+
+func (c *overridesClient) typeError(name dynamicconfig.Key, value interface{}, expected string) error {
+	err := fmt.Errorf("override %v of %v is not a %v", value, name, expected)
+	c.invalid = append(c.invalid, err.Error())
+	return err
+}
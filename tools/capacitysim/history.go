@@ -0,0 +1,157 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package capacitysim
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/uber/cadence/common/collection"
+	"github.com/uber/cadence/service/history"
+)
+
+type (
+	// HistoryReport is the predicted load of the history service and the number of hosts needed to serve it
+	HistoryReport struct {
+		// PersistenceQPS is the rate of each persistence operation across the cluster
+		PersistenceQPS map[string]float64
+		// TotalPersistenceQPS is the rate of all the persistence operations across the cluster
+		TotalPersistenceQPS float64
+		// TransferTasksPerShard and TimerTasksPerShard are the task rates of the busiest shard
+		TransferTasksPerShard float64
+		TimerTasksPerShard    float64
+		// TransferUtilization and TimerUtilization are the fractions of the capacity of the queue processors of
+		// the busiest shard in use, above 1 the queues build a backlog
+		TransferUtilization float64
+		TimerUtilization    float64
+		// HostsForPersistence is the number of hosts needed to stay under the persistence QPS limit of a host
+		HostsForPersistence int
+		// HostsForMemory is the number of hosts needed to fit the mutable state caches of the shards, 0 if the
+		// memory is left out of the plan
+		HostsForMemory int
+		// Hosts is the number of history hosts needed
+		Hosts    int
+		Warnings []string
+	}
+)
+
+// persistence operations, named as in the persistence metric scopes
+const (
+	opCreateWorkflowExecution        = "CreateWorkflowExecution"
+	opUpdateWorkflowExecution        = "UpdateWorkflowExecution"
+	opDeleteWorkflowExecution        = "DeleteWorkflowExecution"
+	opAppendHistoryEvents            = "AppendHistoryEvents"
+	opDeleteWorkflowExecutionHistory = "DeleteWorkflowExecutionHistory"
+	opGetTransferTasks               = "GetTransferTasks"
+	opCompleteTransferTask           = "CompleteTransferTask"
+	opGetTimerIndexTasks             = "GetTimerIndexTasks"
+	opCompleteTimerTask              = "CompleteTimerTask"
+	opRecordWorkflowExecutionStarted = "RecordWorkflowExecutionStarted"
+	opRecordWorkflowExecutionClosed  = "RecordWorkflowExecutionClosed"
+)
+
+// simulateHistory predicts the load of the history service from the rates of the snapshot. A workflow execution
+// is modeled as its start, its decisions, activities and timers, its close and its deletion after the retention:
+//   - every decision and activity is scheduled, started and completed: two updates of the mutable state and one
+//     transfer task each
+//   - every decision and activity is guarded by timeout timers, one and two timer tasks respectively
+//   - the start and the close are recorded in the visibility store by two more transfer tasks
+//   - the workflow timeout and the deletion after the retention are two more timer tasks
+//   - every signal is one more update
+func simulateHistory(spec *Spec, snapshot *Snapshot, config *history.Config) *HistoryReport {
+	report := &HistoryReport{PersistenceQPS: map[string]float64{}}
+	workflows := snapshot.WorkflowStartsPerSecond
+	decisions := workflows * snapshot.DecisionsPerWorkflow
+	activities := workflows * snapshot.ActivitiesPerWorkflow
+	timers := workflows * snapshot.TimersPerWorkflow
+
+	updates := 2*decisions + 2*activities + timers + snapshot.SignalsPerSecond
+	transferTasks := decisions + activities + 2*workflows
+	timerTasks := decisions + 2*activities + timers + 2*workflows
+
+	report.PersistenceQPS[opCreateWorkflowExecution] = workflows
+	report.PersistenceQPS[opUpdateWorkflowExecution] = updates
+	report.PersistenceQPS[opAppendHistoryEvents] = workflows + updates
+	report.PersistenceQPS[opDeleteWorkflowExecution] = workflows
+	report.PersistenceQPS[opDeleteWorkflowExecutionHistory] = workflows
+	report.PersistenceQPS[opGetTransferTasks] = transferTasks / float64(config.TransferTaskBatchSize())
+	report.PersistenceQPS[opCompleteTransferTask] = transferTasks
+	report.PersistenceQPS[opGetTimerIndexTasks] = timerTasks / float64(config.TimerTaskBatchSize())
+	report.PersistenceQPS[opCompleteTimerTask] = timerTasks
+	report.PersistenceQPS[opRecordWorkflowExecutionStarted] = workflows
+	report.PersistenceQPS[opRecordWorkflowExecutionClosed] = workflows
+	for _, qps := range report.PersistenceQPS {
+		report.TotalPersistenceQPS += qps
+	}
+
+	// the queue processors of a shard process its tasks with a fixed number of workers, and read them in
+	// batches at a limited poll rate
+	numShards := float64(spec.NumHistoryShards)
+	report.TransferTasksPerShard = transferTasks / numShards * snapshot.HotShardFactor
+	report.TimerTasksPerShard = timerTasks / numShards * snapshot.HotShardFactor
+	transferCapacity := math.Min(
+		float64(config.TransferTaskWorkerCount())/snapshot.TransferTaskLatency.Seconds(),
+		float64(config.TransferProcessorMaxPollRPS()*config.TransferTaskBatchSize()),
+	)
+	timerCapacity := math.Min(
+		float64(config.TimerTaskWorkerCount())/snapshot.TimerTaskLatency.Seconds(),
+		float64(config.TimerProcessorMaxPollRPS()*config.TimerTaskBatchSize()),
+	)
+	report.TransferUtilization = report.TransferTasksPerShard / transferCapacity
+	report.TimerUtilization = report.TimerTasksPerShard / timerCapacity
+	if report.TransferUtilization > spec.TargetUtilization {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("transfer queue of the busiest shard is %.0f%% utilized, "+
+			"raise the number of shards or the transfer task worker count", 100*report.TransferUtilization))
+	}
+	if report.TimerUtilization > spec.TargetUtilization {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("timer queue of the busiest shard is %.0f%% utilized, "+
+			"raise the number of shards or the timer task worker count", 100*report.TimerUtilization))
+	}
+
+	report.HostsForPersistence = hostsNeeded(report.TotalPersistenceQPS,
+		config.PersistenceMaxQPS()*spec.TargetUtilization)
+	report.Hosts = report.HostsForPersistence
+	if spec.HistoryHostMemoryBytes > 0 && snapshot.MutableStateBytes > 0 {
+		shardBytes := float64(int64(config.HistoryCacheMaxSize()) * snapshot.MutableStateBytes)
+		shardsPerHost := math.Floor(float64(spec.HistoryHostMemoryBytes) * spec.TargetUtilization / shardBytes)
+		if shardsPerHost < 1 {
+			report.Warnings = append(report.Warnings, "the history cache of a single shard does not fit the memory "+
+				"of a host, lower the history cache max size")
+			shardsPerHost = 1
+		}
+		report.HostsForMemory = hostsNeeded(numShards, shardsPerHost)
+		report.Hosts = collection.MaxInt(report.Hosts, report.HostsForMemory)
+	}
+	if report.Hosts > spec.NumHistoryShards {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%v hosts are needed but only %v shards can be "+
+			"spread over them, raise the number of shards", report.Hosts, spec.NumHistoryShards))
+		report.Hosts = spec.NumHistoryShards
+	}
+	return report
+}
+
+// hostsNeeded returns how many hosts of the given capacity serve the load, at least one
+func hostsNeeded(load float64, capacityPerHost float64) int {
+	if capacityPerHost <= 0 {
+		return 1
+	}
+	return collection.MaxInt(1, int(math.Ceil(load/capacityPerHost)))
+}
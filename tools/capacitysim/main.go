@@ -0,0 +1,159 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package capacitysim
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+)
+
+const (
+	cliOptSpec     = "spec"
+	cliOptSnapshot = "snapshot"
+	cliOptGrowth   = "growth"
+
+	cliFlagSpec     = cliOptSpec + ", s"
+	cliFlagSnapshot = cliOptSnapshot + ", m"
+	cliFlagGrowth   = cliOptGrowth + ", g"
+)
+
+// RunTool runs the cadence-capacity-sim command line tool
+func RunTool(args []string) error {
+	app := buildCLIOptions()
+	return app.Run(args)
+}
+
+func buildCLIOptions() *cli.App {
+
+	app := cli.NewApp()
+	app.Name = "cadence-capacity-sim"
+	app.Usage = "Command line tool predicting the history and matching hosts a cadence cluster needs"
+	app.Version = "0.0.1"
+
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:  cliFlagSpec,
+			Usage: "yaml file describing the planned cluster: number of shards, host limits and dynamic config",
+		},
+		cli.StringFlag{
+			Name:  cliFlagSnapshot,
+			Usage: "yaml file with the traffic and the persistence latencies recorded from the metrics of a cluster",
+		},
+		cli.Float64Flag{
+			Name:  cliFlagGrowth,
+			Usage: "factor the recorded traffic is scaled by, overrides the growth of the spec",
+		},
+	}
+
+	app.Action = func(c *cli.Context) {
+		if err := runSimulation(c); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	return app
+}
+
+func runSimulation(c *cli.Context) error {
+	if c.GlobalString(cliOptSpec) == "" || c.GlobalString(cliOptSnapshot) == "" {
+		return newConfigError("both a spec and a snapshot are required")
+	}
+	spec, err := LoadSpec(c.GlobalString(cliOptSpec))
+	if err != nil {
+		return err
+	}
+	snapshot, err := LoadSnapshot(c.GlobalString(cliOptSnapshot))
+	if err != nil {
+		return err
+	}
+	if growth := c.GlobalFloat64(cliOptGrowth); growth > 0 {
+		spec.Growth = growth
+	}
+
+	report, err := Simulate(spec, snapshot)
+	if err != nil {
+		return err
+	}
+	printReport(report)
+	return nil
+}
+
+func printReport(report *Report) {
+	printPersistenceQPS("history", report.History.PersistenceQPS)
+	printPersistenceQPS("matching", report.Matching.PersistenceQPS)
+
+	hostsForMemory := "-"
+	if report.History.HostsForMemory > 0 {
+		hostsForMemory = strconv.Itoa(report.History.HostsForMemory)
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Service", "Hosts", "Bound By Persistence", "Bound By Requests", "Bound By Memory"})
+	table.Append([]string{
+		"history",
+		strconv.Itoa(report.History.Hosts),
+		strconv.Itoa(report.History.HostsForPersistence),
+		"-",
+		hostsForMemory,
+	})
+	table.Append([]string{
+		"matching",
+		strconv.Itoa(report.Matching.Hosts),
+		strconv.Itoa(report.Matching.HostsForPersistence),
+		strconv.Itoa(report.Matching.HostsForRequests),
+		"-",
+	})
+	table.Render()
+
+	fmt.Printf("busiest history shard: %.1f transfer tasks/s (%.0f%% of its capacity), "+
+		"%.1f timer tasks/s (%.0f%% of its capacity)\n",
+		report.History.TransferTasksPerShard, 100*report.History.TransferUtilization,
+		report.History.TimerTasksPerShard, 100*report.History.TimerUtilization)
+
+	var warnings []string
+	warnings = append(warnings, report.Warnings...)
+	warnings = append(warnings, report.History.Warnings...)
+	warnings = append(warnings, report.Matching.Warnings...)
+	for _, warning := range warnings {
+		fmt.Printf("WARNING: %v\n", warning)
+	}
+}
+
+func printPersistenceQPS(service string, qps map[string]float64) {
+	var operations []string
+	for operation := range qps {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Service", "Operation", "QPS"})
+	for _, operation := range operations {
+		table.Append([]string{service, operation, fmt.Sprintf("%.1f", qps[operation])})
+	}
+	table.Render()
+}
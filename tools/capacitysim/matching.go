@@ -0,0 +1,119 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package capacitysim
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/uber/cadence/common/collection"
+	"github.com/uber/cadence/service/matching"
+)
+
+type (
+	// MatchingReport is the predicted load of the matching service and the number of hosts needed to serve it
+	MatchingReport struct {
+		// PersistenceQPS is the rate of each persistence operation across the cluster
+		PersistenceQPS map[string]float64
+		// TotalPersistenceQPS is the rate of all the persistence operations across the cluster
+		TotalPersistenceQPS float64
+		// RequestsPerSecond is the rate of the AddTask and PollForTask requests across the cluster
+		RequestsPerSecond float64
+		// HostsForPersistence is the number of hosts needed to stay under the persistence QPS limit of a host
+		HostsForPersistence int
+		// HostsForRequests is the number of hosts needed to stay under the request rate limit of a host
+		HostsForRequests int
+		// Hosts is the number of matching hosts needed
+		Hosts    int
+		Warnings []string
+	}
+)
+
+// persistence operations, named as in the persistence metric scopes
+const (
+	opCreateTask     = "CreateTask"
+	opGetTasks       = "GetTasks"
+	opCompleteTask   = "CompleteTask"
+	opUpdateTaskList = "UpdateTaskList"
+)
+
+// simulateMatching predicts the load of the matching service from the rates of the snapshot. Every decision and
+// activity of a workflow is a task added to, and polled from, a task list. The tasks which are not sync matched
+// are written by the task writer in batches, read back by the task reader in batches and completed one by one.
+func simulateMatching(spec *Spec, snapshot *Snapshot, config *matching.Config) *MatchingReport {
+	report := &MatchingReport{PersistenceQPS: map[string]float64{}}
+	taskLists := snapshot.TaskLists
+	if len(taskLists) == 0 {
+		workflows := snapshot.WorkflowStartsPerSecond
+		taskLists = []TaskListSnapshot{{
+			TasksPerSecond: workflows * (snapshot.DecisionsPerWorkflow + snapshot.ActivitiesPerWorkflow),
+		}}
+	}
+	// the busiest task lists first
+	sort.SliceStable(taskLists, func(i, j int) bool { return taskLists[i].TasksPerSecond > taskLists[j].TasksPerSecond })
+
+	hostRPS := float64(config.RPS()) * spec.TargetUtilization
+	for _, taskList := range taskLists {
+		tasks := taskList.TasksPerSecond
+		persisted := tasks * (1 - snapshot.SyncMatchRate)
+
+		// under a steady rate, a batch holds the tasks arriving while the writer waits for more
+		maxBatchSize := float64(config.MaxTaskBatchSize("", taskList.Name, 0))
+		batchWait := config.MaxTaskBatchWait("", taskList.Name, 0).Seconds()
+		batchSize := math.Max(1, math.Min(maxBatchSize, persisted*batchWait))
+		readBatchSize := float64(config.GetTasksBatchSize("", taskList.Name, 0))
+
+		report.PersistenceQPS[opCreateTask] += persisted / batchSize
+		report.PersistenceQPS[opGetTasks] += persisted / readBatchSize
+		report.PersistenceQPS[opCompleteTask] += persisted
+		report.PersistenceQPS[opUpdateTaskList] += 1 / config.UpdateAckInterval("", taskList.Name, 0).Seconds()
+		report.RequestsPerSecond += 2 * tasks
+
+		// a task list is owned by a single host, and is written by a single writer one batch at a time
+		if 2*tasks > hostRPS {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("task list %v needs %.0f requests per second, "+
+				"more than a single host serves", taskListName(taskList), 2*tasks))
+		}
+		writeCapacity := maxBatchSize / snapshot.persistenceLatency(opCreateTask).Seconds()
+		if persisted > writeCapacity*spec.TargetUtilization {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("task list %v persists %.0f tasks per second, "+
+				"its writer keeps up with %.0f, raise the max task batch size", taskListName(taskList), persisted,
+				writeCapacity))
+		}
+	}
+	for _, qps := range report.PersistenceQPS {
+		report.TotalPersistenceQPS += qps
+	}
+
+	report.HostsForPersistence = hostsNeeded(report.TotalPersistenceQPS,
+		config.PersistenceMaxQPS()*spec.TargetUtilization)
+	report.HostsForRequests = hostsNeeded(report.RequestsPerSecond, hostRPS)
+	report.Hosts = collection.MaxInt(report.HostsForPersistence, report.HostsForRequests)
+	return report
+}
+
+func taskListName(taskList TaskListSnapshot) string {
+	if taskList.Name == "" {
+		return "<all>"
+	}
+	return taskList.Name
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package capacitysim
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/uber-common/bark"
+
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/service/history"
+	"github.com/uber/cadence/service/matching"
+)
+
+type (
+	// Report is the outcome of a simulation
+	Report struct {
+		History  *HistoryReport
+		Matching *MatchingReport
+		// Warnings are the issues found with the spec itself
+		Warnings []string
+	}
+)
+
+// Simulate predicts the load of the history and matching services of the cluster described by the spec, serving
+// the traffic of the snapshot, and the number of hosts each service needs. The services are modeled with their
+// real configs, built from the dynamic config overrides of the spec.
+func Simulate(spec *Spec, snapshot *Snapshot) (*Report, error) {
+	if err := validate(spec, snapshot); err != nil {
+		return nil, err
+	}
+	spec, snapshot = withDefaults(spec, snapshot)
+
+	client := newOverridesClient(spec.DynamicConfig)
+	dc := dynamicconfig.NewCollection(client, bark.NewLoggerFromLogrus(logrus.New()))
+	report := &Report{
+		History:  simulateHistory(spec, snapshot, history.NewConfig(dc, spec.NumHistoryShards)),
+		Matching: simulateMatching(spec, snapshot, matching.NewConfig(dc)),
+	}
+	if len(client.invalid) > 0 {
+		return nil, newConfigError(strings.Join(client.invalid, ", "))
+	}
+	for _, name := range client.unread() {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("dynamic config %v is not used by the simulation", name))
+	}
+	return report, nil
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package capacitysim
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	simulatorSuite struct {
+		suite.Suite
+		snapshot *Snapshot
+	}
+)
+
+func TestSimulatorSuite(t *testing.T) {
+	suite.Run(t, new(simulatorSuite))
+}
+
+func (s *simulatorSuite) SetupTest() {
+	s.snapshot = &Snapshot{
+		WorkflowStartsPerSecond: 10,
+		DecisionsPerWorkflow:    4,
+		ActivitiesPerWorkflow:   2,
+		TimersPerWorkflow:       1,
+		SignalsPerSecond:        5,
+		HotShardFactor:          2,
+	}
+}
+
+func (s *simulatorSuite) TestSimulateHistory() {
+	spec := &Spec{
+		NumHistoryShards: 16,
+		DynamicConfig:    map[string]interface{}{"history.persistenceMaxQPS": 100},
+	}
+	report, err := Simulate(spec, s.snapshot)
+	s.NoError(err)
+	s.Empty(report.Warnings)
+
+	history := report.History
+	s.Equal(10.0, history.PersistenceQPS[opCreateWorkflowExecution])
+	s.Equal(135.0, history.PersistenceQPS[opUpdateWorkflowExecution])
+	s.Equal(145.0, history.PersistenceQPS[opAppendHistoryEvents])
+	s.Equal(80.0, history.PersistenceQPS[opCompleteTransferTask])
+	s.InDelta(0.8, history.PersistenceQPS[opGetTransferTasks], 0.001)
+	s.Equal(110.0, history.PersistenceQPS[opCompleteTimerTask])
+	s.InDelta(521.9, history.TotalPersistenceQPS, 0.001)
+
+	// 80 transfer tasks/s over 16 shards, doubled on the hot shard, against 10 workers taking 20ms per task
+	s.Equal(10.0, history.TransferTasksPerShard)
+	s.InDelta(0.02, history.TransferUtilization, 0.001)
+	s.Empty(history.Warnings)
+
+	s.Equal(8, history.HostsForPersistence)
+	s.Equal(0, history.HostsForMemory)
+	s.Equal(8, history.Hosts)
+}
+
+func (s *simulatorSuite) TestSimulateHistory_Limits() {
+	spec := &Spec{
+		NumHistoryShards:       4,
+		HistoryHostMemoryBytes: 4 << 30,
+		Growth:                 2,
+		DynamicConfig: map[string]interface{}{
+			"history.persistenceMaxQPS":       100,
+			"history.transferTaskWorkerCount": 1,
+		},
+	}
+	s.snapshot.MutableStateBytes = 1 << 20
+	s.snapshot.TransferTaskLatency = 100 * time.Millisecond
+	report, err := Simulate(spec, s.snapshot)
+	s.NoError(err)
+
+	history := report.History
+	// 160 transfer tasks/s over 4 shards, doubled on the hot shard, against 1 worker taking 100ms per task
+	s.Equal(80.0, history.TransferTasksPerShard)
+	s.Equal(8.0, history.TransferUtilization)
+	// 5 caches of 512 mutable states of 1MB fit 70% of 4GB
+	s.Equal(1, history.HostsForMemory)
+	// more hosts than shards are needed to serve the doubled traffic
+	s.Equal(15, history.HostsForPersistence)
+	s.Equal(4, history.Hosts)
+	s.Len(history.Warnings, 2)
+}
+
+func (s *simulatorSuite) TestSimulateMatching() {
+	spec := &Spec{
+		NumHistoryShards: 16,
+		DynamicConfig: map[string]interface{}{
+			"matching.maxTaskBatchWait":  "100ms",
+			"matching.rps":               400,
+			"matching.persistenceMaxQPS": 200,
+		},
+	}
+	s.snapshot.SyncMatchRate = 0.5
+	s.snapshot.TaskLists = []TaskListSnapshot{
+		{Name: "b", TasksPerSecond: 100},
+		{Name: "a", TasksPerSecond: 300},
+	}
+	report, err := Simulate(spec, s.snapshot)
+	s.NoError(err)
+
+	matching := report.Matching
+	// batches of 15 and 5 tasks, filled while the writers wait 100ms
+	s.InDelta(20, matching.PersistenceQPS[opCreateTask], 0.001)
+	s.InDelta(0.2, matching.PersistenceQPS[opGetTasks], 0.001)
+	s.InDelta(200, matching.PersistenceQPS[opCompleteTask], 0.001)
+	s.InDelta(220.233, matching.TotalPersistenceQPS, 0.001)
+	s.Equal(800.0, matching.RequestsPerSecond)
+
+	s.Equal(2, matching.HostsForPersistence)
+	s.Equal(3, matching.HostsForRequests)
+	s.Equal(3, matching.Hosts)
+	s.Len(matching.Warnings, 1)
+	s.Contains(matching.Warnings[0], "task list a")
+}
+
+func (s *simulatorSuite) TestSimulate_DynamicConfig() {
+	spec := &Spec{
+		NumHistoryShards: 16,
+		DynamicConfig:    map[string]interface{}{"history.unknown": 1},
+	}
+	report, err := Simulate(spec, s.snapshot)
+	s.NoError(err)
+	s.Equal([]string{"dynamic config history.unknown is not used by the simulation"}, report.Warnings)
+
+	spec.DynamicConfig = map[string]interface{}{"history.transferTaskWorkerCount": "ten"}
+	_, err = Simulate(spec, s.snapshot)
+	s.IsType(&ConfigError{}, err)
+
+	_, err = Simulate(&Spec{}, s.snapshot)
+	s.IsType(&ConfigError{}, err)
+}
+
+func (s *simulatorSuite) TestLoad() {
+	dir, err := ioutil.TempDir("", "capacitysim")
+	s.NoError(err)
+	defer os.RemoveAll(dir)
+
+	specPath := filepath.Join(dir, "spec.yaml")
+	s.NoError(ioutil.WriteFile(specPath, []byte(`
+numHistoryShards: 1024
+dynamicConfig:
+  history.transferTaskWorkerCount: 20
+  matching.maxTaskBatchWait: 50ms
+`), 0600))
+	snapshotPath := filepath.Join(dir, "snapshot.yaml")
+	s.NoError(ioutil.WriteFile(snapshotPath, []byte(`
+workflowStartsPerSecond: 100
+transferTaskLatency: 15ms
+persistenceLatencies:
+  UpdateWorkflowExecution: 8ms
+taskLists:
+  - name: orders
+    tasksPerSecond: 50
+`), 0600))
+
+	spec, err := LoadSpec(specPath)
+	s.NoError(err)
+	s.Equal(1024, spec.NumHistoryShards)
+	s.Equal(20, spec.DynamicConfig["history.transferTaskWorkerCount"])
+	snapshot, err := LoadSnapshot(snapshotPath)
+	s.NoError(err)
+	s.Equal(15*time.Millisecond, snapshot.TransferTaskLatency)
+	s.Equal(8*time.Millisecond, snapshot.persistenceLatency(opUpdateWorkflowExecution))
+	s.Equal(defaultPersistenceLatency, snapshot.persistenceLatency(opCompleteTransferTask))
+	s.Equal([]TaskListSnapshot{{Name: "orders", TasksPerSecond: 50}}, snapshot.TaskLists)
+
+	report, err := Simulate(spec, snapshot)
+	s.NoError(err)
+	s.Empty(report.Warnings)
+}
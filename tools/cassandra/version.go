@@ -141,7 +141,16 @@ func VerifyCompatibleVersion(cfg config.Cassandra, rootPath string) error {
 
 // checkCompatibleVersion check the version compatibility
 func checkCompatibleVersion(cfg config.Cassandra, keyspace string, dirPath string) error {
-	cqlClient, err := newCQLClient(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, keyspace, defaultTimeout)
+	credentialsProvider, err := cfg.Credentials.NewProvider(cfg.User, cfg.Password)
+	if err != nil {
+		return fmt.Errorf("unable to create credentials provider: %v", err.Error())
+	}
+	credentials, err := credentialsProvider.GetCredentials()
+	if err != nil {
+		return fmt.Errorf("unable to get cassandra credentials: %v", err.Error())
+	}
+	cqlClient, err := newCQLClient(cfg.Hosts, cfg.Port, credentials.User, credentials.Password, keyspace,
+		defaultTimeout)
 	if err != nil {
 		return fmt.Errorf("unable to create CQL Client: %v", err.Error())
 	}
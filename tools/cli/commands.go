@@ -195,7 +195,7 @@ func RegisterDomain(c *cli.Context) {
 	}
 
 	domainData := map[string]string{}
-	if len(requiredDomainDataKeys) > 0 {
+	if c.IsSet(FlagDomainData) || len(requiredDomainDataKeys) > 0 {
 		domainDataStr := getRequiredOption(c, FlagDomainData)
 		domainData, err = parseDomainDataKVs(domainDataStr)
 		if err != nil {
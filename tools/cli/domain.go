@@ -133,7 +133,7 @@ func newDomainCommands() []cli.Command {
 				},
 				cli.StringFlag{
 					Name:  FlagDomainDataWithAlias,
-					Usage: "Domain data of key value pairs, in format of k1:v1,k2:v2,k3:v3 ",
+					Usage: "Domain data of key value pairs to set, in format of k1:v1,k2:v2,k3:v3, an empty value like k1: removes the key",
 				},
 			},
 			Action: func(c *cli.Context) {
@@ -153,6 +153,9 @@ func HistoryEventToString(e *s.HistoryEvent) string {
 	case s.EventTypeWorkflowExecutionSignaled:
 		data = e.WorkflowExecutionSignaledEventAttributes
 
+	case s.EventTypeWorkflowExecutionTaskListChanged:
+		data = e.WorkflowExecutionTaskListChangedEventAttributes
+
 	case s.EventTypeWorkflowExecutionTerminated:
 		data = e.WorkflowExecutionTerminatedEventAttributes
 
@@ -0,0 +1,306 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistencebench
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pborman/uuid"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// Stores are the persistence managers exercised by a benchmark, any implementation of the persistence
+	// interfaces can be benchmarked
+	Stores struct {
+		ShardMgr            persistence.ShardManager
+		ExecutionMgrFactory persistence.ExecutionManagerFactory
+		HistoryMgr          persistence.HistoryManager
+		TaskMgr             persistence.TaskManager
+	}
+
+	// benchmark runs the configured operations against the stores, all the workflow executions and task lists it
+	// creates belong to a random domain ID so that runs do not interfere with each other
+	benchmark struct {
+		stores     *Stores
+		config     *Config
+		domainID   string
+		serializer persistence.HistorySerializer
+
+		// only set up when UpdateWorkflowExecution is benchmarked
+		executionMgr persistence.ExecutionManager
+		rangeID      int64
+	}
+
+	// operationFactory returns the call made by a worker to the benchmarked operation, each worker gets its own
+	// call so that it can keep its state across calls, like the next event ID of its workflow execution
+	operationFactory func(b *benchmark) (func() error, error)
+)
+
+const (
+	benchOwner        = "cadence-persistence-bench"
+	benchTaskList     = "cadence-persistence-bench-tasklist"
+	benchWorkflowType = "cadence-persistence-bench-workflow"
+)
+
+var operationFactories = map[string]operationFactory{
+	OperationAppendHistoryEvents:     (*benchmark).newAppendHistoryEvents,
+	OperationUpdateWorkflowExecution: (*benchmark).newUpdateWorkflowExecution,
+	OperationCreateTasks:             (*benchmark).newCreateTasks,
+}
+
+// Run benchmarks the configured operations one after the other and returns the latencies observed for each of
+// them.  Workflow executions and task lists are created as needed, so the stores should not be the ones of a live
+// cluster.
+func Run(stores *Stores, config *Config) ([]*Result, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+	serializer, err := persistence.NewHistorySerializerFactory().Get(common.EncodingTypeJSON)
+	if err != nil {
+		return nil, err
+	}
+	b := &benchmark{
+		stores:     stores,
+		config:     config,
+		domainID:   uuid.New(),
+		serializer: serializer,
+	}
+
+	var results []*Result
+	for _, operation := range config.Operations {
+		result, err := b.run(operation)
+		if err != nil {
+			return results, fmt.Errorf("error benchmarking %v: %v", operation, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (b *benchmark) run(operation string) (*Result, error) {
+	newCall := operationFactories[operation]
+	calls := make([]func() error, b.config.Concurrency)
+	for i := range calls {
+		call, err := newCall(b)
+		if err != nil {
+			return nil, err
+		}
+		calls[i] = call
+	}
+
+	var requests int64
+	var errors int64
+	latencies := make([][]time.Duration, len(calls))
+	var wg sync.WaitGroup
+	startTime := time.Now()
+	for i := range calls {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for atomic.AddInt64(&requests, 1) <= int64(b.config.Requests) {
+				callStartTime := time.Now()
+				if err := calls[worker](); err != nil {
+					atomic.AddInt64(&errors, 1)
+				}
+				latencies[worker] = append(latencies[worker], time.Since(callStartTime))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var all []time.Duration
+	for _, workerLatencies := range latencies {
+		all = append(all, workerLatencies...)
+	}
+	return newResult(operation, all, int(errors), time.Since(startTime)), nil
+}
+
+func (b *benchmark) newExecution() workflow.WorkflowExecution {
+	return workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(fmt.Sprintf("%v-%v", benchOwner, uuid.New())),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+}
+
+// newAppendHistoryEvents appends batches of events to the history of a new workflow execution
+func (b *benchmark) newAppendHistoryEvents() (func() error, error) {
+	execution := b.newExecution()
+	nextEventID := common.FirstEventID
+	transactionID := int64(0)
+
+	return func() error {
+		events := make([]*workflow.HistoryEvent, b.config.EventsPerBatch)
+		for i := range events {
+			events[i] = &workflow.HistoryEvent{
+				EventId:   common.Int64Ptr(nextEventID + int64(i)),
+				Timestamp: common.Int64Ptr(time.Now().UnixNano()),
+				EventType: workflow.EventTypeMarkerRecorded.Ptr(),
+			}
+		}
+		batch, err := b.serializer.Serialize(persistence.NewHistoryEventBatch(persistence.GetDefaultHistoryVersion(), events))
+		if err != nil {
+			return err
+		}
+
+		transactionID++
+		err = b.stores.HistoryMgr.AppendHistoryEvents(&persistence.AppendHistoryEventsRequest{
+			DomainID:      b.domainID,
+			Execution:     execution,
+			FirstEventID:  nextEventID,
+			RangeID:       b.rangeID,
+			TransactionID: transactionID,
+			Events:        batch,
+		})
+		if err != nil {
+			return err
+		}
+		nextEventID += int64(len(events))
+		return nil
+	}, nil
+}
+
+// newUpdateWorkflowExecution moves the next event ID of a new workflow execution forward
+func (b *benchmark) newUpdateWorkflowExecution() (func() error, error) {
+	if err := b.setupShard(); err != nil {
+		return nil, err
+	}
+
+	execution := b.newExecution()
+	_, err := b.executionMgr.CreateWorkflowExecution(&persistence.CreateWorkflowExecutionRequest{
+		RequestID:                   uuid.New(),
+		DomainID:                    b.domainID,
+		Execution:                   execution,
+		TaskList:                    benchTaskList,
+		WorkflowTypeName:            benchWorkflowType,
+		WorkflowTimeout:             int32(time.Hour.Seconds()),
+		DecisionTimeoutValue:        int32(time.Minute.Seconds()),
+		NextEventID:                 common.FirstEventID + 1,
+		LastProcessedEvent:          common.EmptyEventID,
+		RangeID:                     b.rangeID,
+		DecisionScheduleID:          common.EmptyEventID,
+		DecisionStartedID:           common.EmptyEventID,
+		DecisionStartToCloseTimeout: int32(time.Minute.Seconds()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	response, err := b.executionMgr.GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
+		DomainID:  b.domainID,
+		Execution: execution,
+	})
+	if err != nil {
+		return nil, err
+	}
+	info := response.State.ExecutionInfo
+
+	return func() error {
+		condition := info.NextEventID
+		info.NextEventID += int64(b.config.EventsPerBatch)
+		info.LastUpdatedTimestamp = time.Now()
+		err := b.executionMgr.UpdateWorkflowExecution(&persistence.UpdateWorkflowExecutionRequest{
+			ExecutionInfo: info,
+			Condition:     condition,
+			RangeID:       b.rangeID,
+		})
+		if err != nil {
+			info.NextEventID = condition
+		}
+		return err
+	}, nil
+}
+
+// newCreateTasks creates activity tasks, one per call, in a newly leased task list
+func (b *benchmark) newCreateTasks() (func() error, error) {
+	response, err := b.stores.TaskMgr.LeaseTaskList(&persistence.LeaseTaskListRequest{
+		DomainID: b.domainID,
+		TaskList: fmt.Sprintf("%v-%v", benchTaskList, uuid.New()),
+		TaskType: persistence.TaskListTypeActivity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	taskListInfo := response.TaskListInfo
+	execution := b.newExecution()
+	taskID := int64(0)
+
+	return func() error {
+		taskID++
+		_, err := b.stores.TaskMgr.CreateTasks(&persistence.CreateTasksRequest{
+			TaskListInfo: taskListInfo,
+			Tasks: []*persistence.CreateTaskInfo{
+				{
+					Execution: execution,
+					TaskID:    taskID,
+					Data: &persistence.TaskInfo{
+						DomainID:               b.domainID,
+						WorkflowID:             execution.GetWorkflowId(),
+						RunID:                  execution.GetRunId(),
+						TaskID:                 taskID,
+						ScheduleID:             taskID,
+						ScheduleToStartTimeout: int32(time.Minute.Seconds()),
+					},
+				},
+			},
+		})
+		return err
+	}, nil
+}
+
+// setupShard creates the shard used by the benchmark, or reuses its range when it already exists, along with the
+// execution manager of the shard
+func (b *benchmark) setupShard() error {
+	if b.executionMgr != nil {
+		return nil
+	}
+
+	err := b.stores.ShardMgr.CreateShard(&persistence.CreateShardRequest{
+		ShardInfo: &persistence.ShardInfo{
+			ShardID:                 b.config.ShardID,
+			Owner:                   benchOwner,
+			ClusterTransferAckLevel: map[string]int64{},
+			ClusterTimerAckLevel:    map[string]time.Time{},
+		},
+	})
+	if err != nil {
+		if _, ok := err.(*persistence.ShardAlreadyExistError); !ok {
+			return err
+		}
+		response, err := b.stores.ShardMgr.GetShard(&persistence.GetShardRequest{ShardID: b.config.ShardID})
+		if err != nil {
+			return err
+		}
+		b.rangeID = response.ShardInfo.RangeID
+	}
+
+	executionMgr, err := b.stores.ExecutionMgrFactory.CreateExecutionManager(b.config.ShardID)
+	if err != nil {
+		return err
+	}
+	b.executionMgr = executionMgr
+	return nil
+}
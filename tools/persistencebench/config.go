@@ -0,0 +1,116 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistencebench
+
+import (
+	"fmt"
+)
+
+type (
+	// Config holds the params of a benchmark run
+	Config struct {
+		// Operations are the persistence operations to benchmark, run one after the other
+		Operations []string
+		// Concurrency is the number of workers calling an operation in parallel
+		Concurrency int
+		// Requests is the number of calls made to each operation, spread over the workers
+		Requests int
+		// ShardID is the shard owning the workflow executions created by the benchmark
+		ShardID int
+		// EventsPerBatch is the number of history events appended, or added to the next event ID, per call
+		EventsPerBatch int
+	}
+
+	// ConfigError is an error type that
+	// represents a problem with the config
+	ConfigError struct {
+		msg string
+	}
+)
+
+// operations which can be benchmarked
+const (
+	OperationAppendHistoryEvents     = "AppendHistoryEvents"
+	OperationUpdateWorkflowExecution = "UpdateWorkflowExecution"
+	OperationCreateTasks             = "CreateTasks"
+)
+
+const (
+	cliOptEndpoint       = "endpoint"
+	cliOptPort           = "port"
+	cliOptUser           = "user"
+	cliOptPassword       = "password"
+	cliOptDatacenter     = "datacenter"
+	cliOptKeyspace       = "keyspace"
+	cliOptOperations     = "operations"
+	cliOptConcurrency    = "concurrency"
+	cliOptRequests       = "requests"
+	cliOptShardID        = "shard-id"
+	cliOptEventsPerBatch = "events-per-batch"
+
+	cliFlagEndpoint       = cliOptEndpoint + ", ep"
+	cliFlagPort           = cliOptPort + ", p"
+	cliFlagUser           = cliOptUser + ", u"
+	cliFlagPassword       = cliOptPassword + ", pw"
+	cliFlagDatacenter     = cliOptDatacenter + ", dc"
+	cliFlagKeyspace       = cliOptKeyspace + ", k"
+	cliFlagOperations     = cliOptOperations + ", op"
+	cliFlagConcurrency    = cliOptConcurrency + ", c"
+	cliFlagRequests       = cliOptRequests + ", n"
+	cliFlagShardID        = cliOptShardID + ", s"
+	cliFlagEventsPerBatch = cliOptEventsPerBatch + ", e"
+
+	defaultCassandraPort = 9042
+)
+
+// AllOperations returns the names of all the operations which can be benchmarked
+func AllOperations() []string {
+	return []string{OperationAppendHistoryEvents, OperationUpdateWorkflowExecution, OperationCreateTasks}
+}
+
+func validateConfig(config *Config) error {
+	if len(config.Operations) == 0 {
+		return newConfigError("no operation to benchmark")
+	}
+	for _, operation := range config.Operations {
+		if _, ok := operationFactories[operation]; !ok {
+			return newConfigError(fmt.Sprintf("unknown operation %v, expected one of %v", operation, AllOperations()))
+		}
+	}
+	if config.Concurrency <= 0 {
+		return newConfigError("concurrency must be positive")
+	}
+	if config.Requests <= 0 {
+		return newConfigError("requests must be positive")
+	}
+	if config.EventsPerBatch <= 0 {
+		return newConfigError("events per batch must be positive")
+	}
+	return nil
+}
+
+func newConfigError(msg string) error {
+	return &ConfigError{msg: msg}
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("Config Error:%v", e.msg)
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistencebench
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+	"github.com/uber-common/bark"
+	"github.com/urfave/cli"
+
+	"github.com/uber/cadence/common/credentials"
+	"github.com/uber/cadence/common/persistence"
+)
+
+// RunTool runs the cadence-persistence-bench command line tool
+func RunTool(args []string) error {
+	app := buildCLIOptions()
+	return app.Run(args)
+}
+
+func buildCLIOptions() *cli.App {
+
+	app := cli.NewApp()
+	app.Name = "cadence-persistence-bench"
+	app.Usage = "Command line tool benchmarking the cadence persistence layer against a cassandra keyspace"
+	app.Version = "0.0.1"
+
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:   cliFlagEndpoint,
+			Value:  "127.0.0.1",
+			Usage:  "hostname or ip address of cassandra host to connect to",
+			EnvVar: "CASSANDRA_HOST",
+		},
+		cli.IntFlag{
+			Name:   cliFlagPort,
+			Value:  defaultCassandraPort,
+			Usage:  "port of cassandra host to connect to",
+			EnvVar: "CASSANDRA_PORT",
+		},
+		cli.StringFlag{
+			Name:   cliFlagUser,
+			Value:  "",
+			Usage:  "user name used for authentication for connecting to cassandra host",
+			EnvVar: "CASSANDRA_USER",
+		},
+		cli.StringFlag{
+			Name:   cliFlagPassword,
+			Value:  "",
+			Usage:  "password used for authentication for connecting to cassandra host",
+			EnvVar: "CASSANDRA_PASSWORD",
+		},
+		cli.StringFlag{
+			Name:  cliFlagDatacenter,
+			Value: "",
+			Usage: "cassandra datacenter the connections are restricted to",
+		},
+		cli.StringFlag{
+			Name:   cliFlagKeyspace,
+			Value:  "cadence_bench",
+			Usage:  "name of the cassandra keyspace, with the cadence schema set up, which should not be used by a live cluster",
+			EnvVar: "CASSANDRA_KEYSPACE",
+		},
+		cli.StringFlag{
+			Name:  cliFlagOperations,
+			Value: strings.Join(AllOperations(), ","),
+			Usage: "comma separated persistence operations to benchmark",
+		},
+		cli.IntFlag{
+			Name:  cliFlagConcurrency,
+			Value: 10,
+			Usage: "number of workers calling an operation in parallel",
+		},
+		cli.IntFlag{
+			Name:  cliFlagRequests,
+			Value: 1000,
+			Usage: "number of calls made to each operation",
+		},
+		cli.IntFlag{
+			Name:  cliFlagShardID,
+			Value: 0,
+			Usage: "shard owning the workflow executions created by the benchmark",
+		},
+		cli.IntFlag{
+			Name:  cliFlagEventsPerBatch,
+			Value: 5,
+			Usage: "number of history events written per call",
+		},
+	}
+
+	app.Action = func(c *cli.Context) {
+		if err := runBenchmark(c); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	return app
+}
+
+func runBenchmark(c *cli.Context) error {
+	config := &Config{
+		Concurrency:    c.GlobalInt(cliOptConcurrency),
+		Requests:       c.GlobalInt(cliOptRequests),
+		ShardID:        c.GlobalInt(cliOptShardID),
+		EventsPerBatch: c.GlobalInt(cliOptEventsPerBatch),
+	}
+	for _, operation := range strings.Split(c.GlobalString(cliOptOperations), ",") {
+		if operation = strings.TrimSpace(operation); operation != "" {
+			config.Operations = append(config.Operations, operation)
+		}
+	}
+	if err := validateConfig(config); err != nil {
+		return err
+	}
+
+	stores, err := newCassandraStores(c, config.Concurrency)
+	if err != nil {
+		return fmt.Errorf("error connecting to cassandra: %v", err)
+	}
+	defer stores.close()
+
+	results, err := Run(stores, config)
+	printResults(results)
+	return err
+}
+
+func newCassandraStores(c *cli.Context, numConns int) (*Stores, error) {
+	hosts := c.GlobalString(cliOptEndpoint)
+	port := c.GlobalInt(cliOptPort)
+	credentialsProvider := credentials.NewStaticProvider(c.GlobalString(cliOptUser), c.GlobalString(cliOptPassword))
+	datacenter := c.GlobalString(cliOptDatacenter)
+	keyspace := c.GlobalString(cliOptKeyspace)
+	logger := bark.NewLoggerFromLogrus(logrus.New())
+
+	stores := &Stores{}
+	var err error
+	stores.ShardMgr, err = persistence.NewCassandraShardPersistence(hosts, port, credentialsProvider, datacenter,
+		keyspace, "", logger)
+	if err != nil {
+		return nil, err
+	}
+	stores.ExecutionMgrFactory, err = persistence.NewCassandraPersistenceClientFactory(hosts, port, credentialsProvider,
+		datacenter, keyspace, numConns, logger, nil, nil)
+	if err != nil {
+		stores.close()
+		return nil, err
+	}
+	stores.HistoryMgr, err = persistence.NewCassandraHistoryPersistence(hosts, port, credentialsProvider, datacenter,
+		keyspace, numConns, logger)
+	if err != nil {
+		stores.close()
+		return nil, err
+	}
+	stores.TaskMgr, err = persistence.NewCassandraTaskPersistence(hosts, port, credentialsProvider, datacenter, keyspace,
+		logger)
+	if err != nil {
+		stores.close()
+		return nil, err
+	}
+	return stores, nil
+}
+
+func (s *Stores) close() {
+	if s.ShardMgr != nil {
+		s.ShardMgr.Close()
+	}
+	if s.ExecutionMgrFactory != nil {
+		s.ExecutionMgrFactory.Close()
+	}
+	if s.HistoryMgr != nil {
+		s.HistoryMgr.Close()
+	}
+	if s.TaskMgr != nil {
+		s.TaskMgr.Close()
+	}
+}
+
+func printResults(results []*Result) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Operation", "Requests", "Errors", "Throughput", "P50", "P90", "P99", "Max"})
+	for _, result := range results {
+		table.Append([]string{
+			result.Operation,
+			strconv.Itoa(result.Requests),
+			strconv.Itoa(result.Errors),
+			fmt.Sprintf("%.1f/s", result.Throughput),
+			result.P50.String(),
+			result.P90.String(),
+			result.P99.String(),
+			result.Max.String(),
+		})
+	}
+	table.Render()
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistencebench
+
+import (
+	"sort"
+	"time"
+)
+
+type (
+	// Result holds the latencies observed while benchmarking an operation, failed calls included
+	Result struct {
+		Operation  string
+		Requests   int
+		Errors     int
+		Elapsed    time.Duration
+		Throughput float64 // requests per second
+		P50        time.Duration
+		P90        time.Duration
+		P99        time.Duration
+		Max        time.Duration
+	}
+)
+
+func newResult(operation string, latencies []time.Duration, errors int, elapsed time.Duration) *Result {
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	result := &Result{
+		Operation: operation,
+		Requests:  len(latencies),
+		Errors:    errors,
+		Elapsed:   elapsed,
+		P50:       percentile(latencies, 50),
+		P90:       percentile(latencies, 90),
+		P99:       percentile(latencies, 99),
+	}
+	if len(latencies) > 0 {
+		result.Max = latencies[len(latencies)-1]
+	}
+	if elapsed > 0 {
+		result.Throughput = float64(len(latencies)) / elapsed.Seconds()
+	}
+	return result
+}
+
+// percentile returns the latency below or at which the given percent of the sorted latencies are, using the
+// nearest rank method
+func percentile(sorted []time.Duration, percent int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (percent*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
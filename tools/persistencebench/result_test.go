@@ -0,0 +1,85 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistencebench
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	resultSuite struct {
+		suite.Suite
+	}
+)
+
+func TestResultSuite(t *testing.T) {
+	suite.Run(t, new(resultSuite))
+}
+
+func (s *resultSuite) TestPercentiles() {
+	var latencies []time.Duration
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	result := newResult(OperationCreateTasks, latencies, 3, 2*time.Second)
+	s.Equal(OperationCreateTasks, result.Operation)
+	s.Equal(100, result.Requests)
+	s.Equal(3, result.Errors)
+	s.Equal(50*time.Millisecond, result.P50)
+	s.Equal(90*time.Millisecond, result.P90)
+	s.Equal(99*time.Millisecond, result.P99)
+	s.Equal(100*time.Millisecond, result.Max)
+	s.Equal(50.0, result.Throughput)
+}
+
+func (s *resultSuite) TestPercentilesOfFewRequests() {
+	result := newResult(OperationAppendHistoryEvents, []time.Duration{3 * time.Millisecond, time.Millisecond}, 0, 0)
+	s.Equal(time.Millisecond, result.P50)
+	s.Equal(3*time.Millisecond, result.P90)
+	s.Equal(3*time.Millisecond, result.P99)
+	s.Equal(0.0, result.Throughput)
+
+	result = newResult(OperationAppendHistoryEvents, nil, 0, time.Second)
+	s.Equal(0, result.Requests)
+	s.Equal(time.Duration(0), result.P99)
+	s.Equal(time.Duration(0), result.Max)
+}
+
+func (s *resultSuite) TestValidateConfig() {
+	config := &Config{
+		Operations:     AllOperations(),
+		Concurrency:    1,
+		Requests:       1,
+		EventsPerBatch: 1,
+	}
+	s.NoError(validateConfig(config))
+
+	config.Operations = []string{"GetWorkflowExecution"}
+	s.IsType(&ConfigError{}, validateConfig(config))
+
+	config.Operations = AllOperations()
+	config.Concurrency = 0
+	s.IsType(&ConfigError{}, validateConfig(config))
+}